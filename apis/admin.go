@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tokens"
@@ -20,12 +21,17 @@ func bindAdminApi(app core.App, rg *echo.Group) {
 	subGroup.POST("/auth-with-password", api.authWithPassword)
 	subGroup.POST("/request-password-reset", api.requestPasswordReset)
 	subGroup.POST("/confirm-password-reset", api.confirmPasswordReset)
-	subGroup.POST("/auth-refresh", api.authRefresh, RequireAdminAuth())
+	subGroup.POST("/auth-refresh", api.authRefresh, LoadRefreshTokenContext(app), RequireAdminAuth())
+	subGroup.POST("/preview-request", api.previewRequest, RequireAdminAuth())
+	subGroup.GET("/ui-config", api.uiConfig, RequireAdminAuth())
 	subGroup.GET("", api.list, RequireAdminAuth())
 	subGroup.POST("", api.create, RequireAdminAuthOnlyIfAny(app))
 	subGroup.GET("/:id", api.view, RequireAdminAuth())
 	subGroup.PATCH("/:id", api.update, RequireAdminAuth())
 	subGroup.DELETE("/:id", api.delete, RequireAdminAuth())
+	subGroup.GET("/:id/sessions", api.listSessions, RequireAdminAuth())
+	subGroup.DELETE("/:id/sessions/:sessionId", api.deleteSession, RequireAdminAuth())
+	subGroup.POST("/:id/revoke-sessions", api.revokeSessions, RequireAdminAuth())
 }
 
 type adminApi struct {
@@ -33,7 +39,18 @@ type adminApi struct {
 }
 
 func (api *adminApi) authResponse(c echo.Context, admin *models.Admin, finalizers ...func(token string) error) error {
-	token, tokenErr := tokens.NewAdminAuthToken(api.app, admin)
+	session, refreshToken, refreshTokenErr := authSession(api.app, c, "", admin.Id)
+	if refreshTokenErr != nil {
+		return NewBadRequestError("Failed to create refresh token.", refreshTokenErr)
+	}
+
+	var token string
+	var tokenErr error
+	if session != nil {
+		token, tokenErr = tokens.NewAdminSessionAuthToken(api.app, admin, session)
+	} else {
+		token, tokenErr = tokens.NewAdminAuthToken(api.app, admin)
+	}
 	if tokenErr != nil {
 		return NewBadRequestError("Failed to create auth token.", tokenErr)
 	}
@@ -48,6 +65,7 @@ func (api *adminApi) authResponse(c echo.Context, admin *models.Admin, finalizer
 	event.HttpContext = c
 	event.Admin = admin
 	event.Token = token
+	event.RefreshToken = refreshToken
 
 	return api.app.OnAdminAuthRequest().Trigger(event, func(e *core.AdminAuthEvent) error {
 		if e.HttpContext.Response().Committed {
@@ -55,8 +73,9 @@ func (api *adminApi) authResponse(c echo.Context, admin *models.Admin, finalizer
 		}
 
 		return e.HttpContext.JSON(200, map[string]any{
-			"token": e.Token,
-			"admin": e.Admin,
+			"token":        e.Token,
+			"refreshToken": e.RefreshToken,
+			"admin":        e.Admin,
 		})
 	})
 }
@@ -351,3 +370,75 @@ func (api *adminApi) delete(c echo.Context) error {
 		})
 	})
 }
+
+func (api *adminApi) revokeSessions(c echo.Context) error {
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	admin, err := api.app.Dao().FindAdminById(id)
+	if err != nil || admin == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := revokeAllSessions(api.app, c, "", admin.Id); err != nil {
+		return NewBadRequestError("Failed to revoke the admin sessions.", err)
+	}
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		if err := txDao.RevokeAllRefreshTokens("", admin.Id); err != nil {
+			return err
+		}
+
+		// invalidate also all previously issued admin auth tokens
+		if err := admin.RefreshTokenKey(); err != nil {
+			return err
+		}
+
+		return txDao.SaveAdmin(admin)
+	})
+	if txErr != nil {
+		return NewBadRequestError("Failed to revoke the admin sessions.", txErr)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *adminApi) listSessions(c echo.Context) error {
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	admin, err := api.app.Dao().FindAdminById(id)
+	if err != nil || admin == nil {
+		return NewNotFoundError("", err)
+	}
+
+	sessions, err := api.app.Dao().FindAllSessionsByOwner("", admin.Id)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+func (api *adminApi) deleteSession(c echo.Context) error {
+	id := c.PathParam("id")
+	sessionId := c.PathParam("sessionId")
+	if id == "" || sessionId == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	session, err := api.app.Dao().FindSessionById(sessionId)
+	if err != nil || !session.IsAdmin() || session.RecordId != id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := revokeSession(api.app, c, session); err != nil {
+		return NewBadRequestError("Failed to revoke the admin session.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
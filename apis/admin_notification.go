@@ -0,0 +1,156 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/notifications"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindAdminNotificationApi registers the admin notification center api
+// endpoints and the daily email digest serve hooks.
+func bindAdminNotificationApi(app core.App, rg *echo.Group) {
+	api := adminNotificationApi{app: app}
+
+	subGroup := rg.Group("/admin-notifications", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.GET("/unread", api.unread)
+	subGroup.POST("/read-all", api.markAllAsRead)
+	subGroup.POST("/:id/read", api.markAsRead)
+	subGroup.DELETE("/:id", api.delete)
+
+	bindAdminNotificationsDigest(app)
+}
+
+type adminNotificationApi struct {
+	app core.App
+}
+
+var adminNotificationFilterFields = []string{
+	"id", "created", "updated",
+	"severity", "source", "title", "body", "read", "count", "data",
+	`^data\.[\w\.\:]*\w+$`,
+}
+
+func (api *adminNotificationApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(adminNotificationFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().AdminNotificationQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.AdminNotification{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *adminNotificationApi) unread(c echo.Context) error {
+	total, err := api.app.Dao().TotalUnreadAdminNotifications()
+	if err != nil {
+		return NewBadRequestError("Failed to count the unread notifications.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"total": total})
+}
+
+func (api *adminNotificationApi) markAsRead(c echo.Context) error {
+	notification, err := api.app.Dao().FindAdminNotificationById(c.PathParam("id"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := notifications.MarkAdminNotificationAsRead(api.app, notification); err != nil {
+		return NewBadRequestError("Failed to mark the notification as read.", err)
+	}
+
+	return c.JSON(http.StatusOK, notification)
+}
+
+func (api *adminNotificationApi) markAllAsRead(c echo.Context) error {
+	if err := notifications.MarkAllAdminNotificationsAsRead(api.app); err != nil {
+		return NewBadRequestError("Failed to mark the notifications as read.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *adminNotificationApi) delete(c echo.Context) error {
+	notification, err := api.app.Dao().FindAdminNotificationById(c.PathParam("id"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteAdminNotification(notification); err != nil {
+		return NewBadRequestError("Failed to delete the notification.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// bindAdminNotificationsDigest registers the app serve hooks
+// that schedule the daily admin notifications email digest.
+func bindAdminNotificationsDigest(app core.App) {
+	c := cron.New()
+	c.SetLocker(app)
+	app.Store().Set(core.StoreKeyCronPrefix+"adminNotifications", c)
+	isServe := false
+
+	loadJob := func() {
+		c.Stop()
+		c.RemoveAll()
+
+		config := app.Settings().AdminNotifications
+		if !config.DigestEnabled || !isServe {
+			return
+		}
+
+		err := c.Add("@adminNotificationsDigest", config.DigestCron(), func() {
+			if err := notifications.SendAdminDigest(app); err != nil {
+				app.Logger().Debug(
+					"[Admin notifications] Failed to send the daily digest",
+					slog.String("error", err.Error()),
+				)
+			}
+		})
+		if err != nil {
+			app.Logger().Debug(
+				"[Admin notifications] Failed to schedule the daily digest",
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+
+		c.Start()
+	}
+
+	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		isServe = true
+		loadJob()
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		c.Stop()
+		c.Wait()
+		return nil
+	})
+
+	// reload on app settings change
+	app.OnModelAfterUpdate((&models.Param{}).TableName()).Add(func(e *core.ModelEvent) error {
+		p, _ := e.Model.(*models.Param)
+		if p == nil || p.Key != models.ParamAppSettings {
+			return nil
+		}
+
+		loadJob()
+
+		return nil
+	})
+}
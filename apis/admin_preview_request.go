@@ -0,0 +1,357 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// errPreviewRollback is used to rollback the previewed delete transaction.
+var errPreviewRollback = errors.New("preview rollback")
+
+// previewRule describes the evaluation result of a single collection
+// API rule for the previewed request.
+type previewRule struct {
+	Name string `json:"name"`
+
+	// Rule is the collection API rule (nil for admins only).
+	Rule *string `json:"rule"`
+
+	// Matched reports whether the rule allowed the previewed request
+	// (for the list rule - whether the request is allowed at all since
+	// the list rule only filters the returned records).
+	Matched bool `json:"matched"`
+}
+
+// previewRequest executes a records API request as another identity
+// (guest or auth record) in dry-run mode and returns its response
+// together with the evaluated collection API rules.
+//
+// The GET requests are executed with the regular records API handlers,
+// while the write requests are submitted in a rolled back transaction
+// without triggering the request and model hooks.
+func (api *adminApi) previewRequest(c echo.Context) error {
+	form := forms.NewAdminPreviewRequest(api.app)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	authRecord, err := form.FindAuthRecord()
+	if err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	target, err := url.Parse(form.Path)
+	if err != nil {
+		return NewBadRequestError("Invalid request path.", err)
+	}
+
+	// /api/collections/{collection}/records[/{id}]
+	parts := strings.Split(strings.Trim(target.Path, "/"), "/")
+	collectionName := parts[2]
+	var recordId string
+	if len(parts) > 4 {
+		recordId = parts[4]
+	}
+
+	collection, err := api.app.Dao().FindCollectionByNameOrId(collectionName)
+	if err != nil || collection == nil {
+		return NewNotFoundError("Missing collection with id or name "+collectionName+".", err)
+	}
+
+	body, err := json.Marshal(form.Body)
+	if err != nil {
+		return NewBadRequestError("Invalid request body.", err)
+	}
+
+	req, err := http.NewRequestWithContext(c.Request().Context(), form.Method, form.Path, bytes.NewReader(body))
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+
+	previewCtx := c.Echo().NewContext(req, rec)
+	previewCtx.SetPathParams(echo.PathParams{
+		{Name: "collection", Value: collectionName},
+		{Name: "id", Value: recordId},
+	})
+	previewCtx.Set(ContextCollectionKey, collection)
+	if authRecord != nil {
+		previewCtx.Set(ContextAuthRecordKey, authRecord)
+	}
+
+	preview := &recordPreview{
+		app:        api.app,
+		c:          previewCtx,
+		collection: collection,
+		recordId:   recordId,
+		rules:      []previewRule{},
+	}
+
+	var previewErr error
+
+	switch {
+	case form.Method == http.MethodGet && recordId == "":
+		previewErr = preview.list()
+	case form.Method == http.MethodGet:
+		previewErr = preview.view()
+	case form.Method == http.MethodPost && recordId == "":
+		previewErr = preview.create()
+	case form.Method == http.MethodPatch && recordId != "":
+		previewErr = preview.update()
+	case form.Method == http.MethodDelete && recordId != "":
+		previewErr = preview.delete()
+	default:
+		previewErr = NewApiError(http.StatusMethodNotAllowed, "The request method is not supported for the specified path.", nil)
+	}
+
+	result := map[string]any{
+		"rules": preview.rules,
+	}
+
+	var apiErr *ApiError
+	var httpErr *echo.HTTPError
+
+	switch {
+	case previewErr == nil:
+		result["status"] = rec.Code
+		if rec.Body.Len() > 0 {
+			result["response"] = json.RawMessage(rec.Body.Bytes())
+		} else {
+			result["response"] = nil
+		}
+	case errors.As(previewErr, &apiErr):
+		result["status"] = apiErr.Code
+		result["response"] = apiErr
+	case errors.As(previewErr, &httpErr):
+		result["status"] = httpErr.Code
+		result["response"] = NewApiError(httpErr.Code, http.StatusText(httpErr.Code), nil)
+	default:
+		result["status"] = http.StatusBadRequest
+		result["response"] = NewBadRequestError("", previewErr)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// recordPreview evaluates a single previewed records API request.
+type recordPreview struct {
+	app        core.App
+	c          echo.Context
+	collection *models.Collection
+	recordId   string
+	rules      []previewRule
+}
+
+// addRule registers a previewed rule evaluation result.
+func (p *recordPreview) addRule(name string, rule *string, matched bool) {
+	p.rules = append(p.rules, previewRule{Name: name, Rule: rule, Matched: matched})
+}
+
+// findRecord returns the previewed record if it satisfies the provided rule
+// (registering the rule evaluation result).
+func (p *recordPreview) findRecord(name string, rule *string) (*models.Record, error) {
+	if rule == nil {
+		p.addRule(name, rule, false)
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	requestInfo := RequestInfo(p.c)
+
+	ruleFunc, err := recordRuleFunc(p.app.Dao(), p.collection, requestInfo, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	record, fetchErr := p.app.Dao().FindRecordById(p.collection.Id, p.recordId, ruleFunc)
+
+	p.addRule(name, rule, fetchErr == nil && record != nil)
+
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	return record, nil
+}
+
+func (p *recordPreview) list() error {
+	p.addRule("listRule", p.collection.ListRule, p.collection.ListRule != nil)
+
+	return (&recordApi{app: p.app}).list(p.c)
+}
+
+func (p *recordPreview) view() error {
+	if _, err := p.findRecord("viewRule", p.collection.ViewRule); err != nil {
+		return err
+	}
+
+	return (&recordApi{app: p.app}).view(p.c)
+}
+
+func (p *recordPreview) create() error {
+	if p.collection.IsView() {
+		return NewBadRequestError("The view collection records cannot be created.", nil)
+	}
+
+	requestInfo := RequestInfo(p.c)
+
+	hasFullManageAccess, err := checkRecordCreateRule(p.app, p.c, p.collection, requestInfo)
+
+	p.addRule("createRule", p.collection.CreateRule, err == nil)
+
+	if err != nil {
+		return err
+	}
+
+	if p.collection.IsAuth() {
+		if manageRule := p.collection.AuthOptions().ManageRule; manageRule != nil {
+			p.addRule("manageRule", manageRule, hasFullManageAccess)
+		}
+	}
+
+	record := models.NewRecord(p.collection)
+
+	form, err := newRecordUpsertForm(p.app, p.c, record, requestInfo, hasFullManageAccess)
+	if err != nil {
+		return err
+	}
+
+	submitErr := form.DrySubmit(func(txDao *daos.Dao) error {
+		return p.respond(txDao, record)
+	})
+	if submitErr != nil {
+		return NewBadRequestError("Failed to create record.", submitErr)
+	}
+
+	return nil
+}
+
+func (p *recordPreview) update() error {
+	if p.collection.IsView() {
+		return NewBadRequestError("The view collection records cannot be updated.", nil)
+	}
+
+	if p.collection.Immutable() != nil {
+		return NewForbiddenError("The immutable collection records cannot be updated.", nil)
+	}
+
+	requestInfo := RequestInfo(p.c)
+
+	// eager fetch the record so that the modifier field values are replaced
+	// and available when accessing requestInfo.Data using just the field name
+	if requestInfo.HasModifierDataKeys() && p.collection.UpdateRule != nil {
+		record, err := p.app.Dao().FindRecordById(p.collection.Id, p.recordId)
+		if err != nil || record == nil {
+			return NewNotFoundError("", err)
+		}
+		requestInfo.Data = record.ReplaceModifers(requestInfo.Data)
+	}
+
+	record, err := p.findRecord("updateRule", p.collection.UpdateRule)
+	if err != nil {
+		return err
+	}
+
+	form, err := newRecordUpsertForm(p.app, p.c, record, requestInfo, p.manageAccess(record, requestInfo))
+	if err != nil {
+		return err
+	}
+
+	submitErr := form.DrySubmit(func(txDao *daos.Dao) error {
+		return p.respond(txDao, record)
+	})
+	if submitErr != nil {
+		if errors.Is(submitErr, daos.ErrRecordRevisionConflict) {
+			return NewApiError(http.StatusConflict, "The record was modified by another request.", nil)
+		}
+		return NewBadRequestError("Failed to update record.", submitErr)
+	}
+
+	return nil
+}
+
+func (p *recordPreview) delete() error {
+	if p.collection.IsView() {
+		return NewBadRequestError("The view collection records cannot be deleted.", nil)
+	}
+
+	if p.collection.Immutable() != nil {
+		return NewForbiddenError("The immutable collection records cannot be deleted.", nil)
+	}
+
+	record, err := p.findRecord("deleteRule", p.collection.DeleteRule)
+	if err != nil {
+		return err
+	}
+
+	// a new dao without hooks so that the delete only checks the db constraints
+	dryDao := daos.New(p.app.Dao().NonconcurrentDB())
+
+	deleteErr := dryDao.RunInTransaction(func(txDao *daos.Dao) error {
+		if err := txDao.DeleteRecord(record); err != nil {
+			return err
+		}
+
+		return errPreviewRollback
+	})
+
+	switch {
+	case errors.Is(deleteErr, errPreviewRollback):
+		return p.c.NoContent(http.StatusNoContent)
+	case errors.Is(deleteErr, daos.ErrRecordUnderLegalHold):
+		return NewForbiddenError("The record (or one of its cascade deleted references) is under legal hold.", nil)
+	default:
+		return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", deleteErr)
+	}
+}
+
+// manageAccess reports whether the previewed identity has full manage
+// access to the auth record (registering the manage rule evaluation result).
+func (p *recordPreview) manageAccess(record *models.Record, requestInfo *models.RequestInfo) bool {
+	if !p.collection.IsAuth() {
+		return false
+	}
+
+	manageRule := p.collection.AuthOptions().ManageRule
+	if manageRule == nil {
+		return false
+	}
+
+	result := hasAuthManageAccess(p.app.Dao(), record, requestInfo)
+
+	p.addRule("manageRule", manageRule, result)
+
+	return result
+}
+
+// respond writes the enriched dry submitted record as preview response.
+func (p *recordPreview) respond(txDao *daos.Dao, record *models.Record) error {
+	if err := EnrichRecord(p.c, txDao, record); err != nil {
+		p.app.Logger().Debug(
+			"Failed to enrich the previewed record",
+			slog.String("id", record.Id),
+			slog.String("collectionName", record.Collection().Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	setRecordETag(p.c, record)
+
+	return p.c.JSON(http.StatusOK, record)
+}
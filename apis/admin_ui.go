@@ -0,0 +1,276 @@
+package apis
+
+import (
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/labstack/echo/v5/middleware"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/ui"
+)
+
+// adminUIConfigResponse is the admin UI customization config
+// returned for the current authenticated admin.
+type adminUIConfigResponse struct {
+	LogoUrl        string               `json:"logoUrl"`
+	PrimaryColor   string               `json:"primaryColor"`
+	HiddenSections []string             `json:"hiddenSections"`
+	Panels         []*core.AdminUIPanel `json:"panels"`
+}
+
+// uiConfig returns the admin UI customization config
+// (branding, hidden sections and custom panels) for the current admin.
+func (api *adminApi) uiConfig(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+
+	config := api.app.Settings().AdminUI
+
+	event := new(core.AdminUIConfigEvent)
+	event.HttpContext = c
+	event.Admin = admin
+	event.HiddenSections = append([]string{}, config.HiddenSections...)
+	event.Panels = []*core.AdminUIPanel{}
+
+	return api.app.OnAdminUIConfigRequest().Trigger(event, func(e *core.AdminUIConfigEvent) error {
+		if e.HttpContext.Response().Committed {
+			return nil
+		}
+
+		return e.HttpContext.JSON(http.StatusOK, &adminUIConfigResponse{
+			LogoUrl:        config.LogoUrl,
+			PrimaryColor:   config.PrimaryColor,
+			HiddenSections: e.HiddenSections,
+			Panels:         e.Panels,
+		})
+	})
+}
+
+// -------------------------------------------------------------------
+
+const (
+	adminUIBrandingFile   = "ui-branding.css"
+	adminUIExtensionsFile = "ui-extensions.js"
+)
+
+// bindAdminUICustomizations registers the admin UI index.html, branding
+// styles and extensions script routes for the specified admin UI path.
+//
+// The prebuilt index.html is served with injected references to the
+// branding styles and to the extensions script that loads the current
+// admin UI config (hidden sections and custom panels).
+func bindAdminUICustomizations(app core.App, e *echo.Echo, adminPath string) error {
+	rawIndex, err := fs.ReadFile(ui.DistDirFS, "index.html")
+	if err != nil {
+		return err
+	}
+
+	indexHandler := func(c echo.Context) error {
+		html := injectAdminUIAssets(string(rawIndex), app.Settings().AdminUI.LogoUrl)
+
+		return c.HTML(http.StatusOK, html)
+	}
+
+	e.GET(adminPath, indexHandler, installerRedirect(app, adminPath), middleware.Gzip())
+	e.GET(adminPath+"index.html", indexHandler, installerRedirect(app, adminPath), middleware.Gzip())
+
+	e.GET(adminPath+adminUIBrandingFile, func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "no-cache")
+
+		return c.Blob(http.StatusOK, "text/css; charset=utf-8", []byte(adminUIBrandingCSS(app)))
+	})
+
+	e.GET(adminPath+adminUIExtensionsFile, func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "no-cache")
+
+		return c.Blob(http.StatusOK, "text/javascript; charset=utf-8", []byte(adminUIExtensionsScript))
+	}, middleware.Gzip())
+
+	return nil
+}
+
+// injectAdminUIAssets injects the branding styles and extensions script
+// references in the provided admin UI index.html content.
+//
+// If logoUrl is from an external origin, it is also allowed in the
+// index.html Content-Security-Policy img-src directive.
+func injectAdminUIAssets(html string, logoUrl string) string {
+	if u, err := url.Parse(logoUrl); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		html = strings.Replace(html, "img-src 'self'", "img-src 'self' "+u.Scheme+"://"+u.Host, 1)
+	}
+
+	tags := `<link rel="stylesheet" href="./` + adminUIBrandingFile + `" />` +
+		`<script src="./` + adminUIExtensionsFile + `" defer></script>`
+
+	return strings.Replace(html, "</head>", tags+"</head>", 1)
+}
+
+// adminUIBrandingCSS generates the admin UI branding styles
+// from the current app settings.
+func adminUIBrandingCSS(app core.App) string {
+	config := app.Settings().AdminUI
+
+	var css strings.Builder
+
+	if config.PrimaryColor != "" {
+		css.WriteString(":root{--primaryColor:" + config.PrimaryColor + ";}")
+	}
+
+	if config.LogoUrl != "" {
+		css.WriteString(`img[alt="PocketBase logo"]{content:url(` + strconv.Quote(config.LogoUrl) + `);object-fit:contain;}`)
+	}
+
+	return css.String()
+}
+
+// adminUIExtensionsScript loads the current admin UI config and applies
+// the hidden sections and the custom panels to the admin UI.
+//
+// Custom panel scripts are expected to register their panel renderer with:
+//
+//	window.pbAdminUI.registerPanel("panelId", (container, ctx) => { ... })
+const adminUIExtensionsScript = `(function () {
+    "use strict";
+
+    var renderers = {};
+    var config = { hiddenSections: [], panels: [] };
+    var overlay = null;
+    var loaded = false;
+
+    function token() {
+        try {
+            return JSON.parse(localStorage.getItem("pb_admin_auth") || "{}").token || "";
+        } catch (_) {
+            return "";
+        }
+    }
+
+    function isHidden(section) {
+        return config.hiddenSections.some(function (hidden) {
+            return section === hidden || section.indexOf(hidden + "/") === 0;
+        });
+    }
+
+    function currentSection() {
+        return window.location.hash.replace(/^#\/?/, "").split("?")[0];
+    }
+
+    function closePanel() {
+        if (overlay) {
+            overlay.remove();
+            overlay = null;
+        }
+    }
+
+    function openPanel(panel) {
+        closePanel();
+
+        overlay = document.createElement("div");
+        overlay.className = "pb-ui-panel";
+        overlay.style.cssText = "position:fixed;top:0;right:0;bottom:0;left:var(--appSidebarWidth,75px);z-index:100;overflow:auto;padding:30px;background:var(--bodyColor,#fff)";
+
+        var container = document.createElement("div");
+        overlay.appendChild(container);
+        document.body.appendChild(overlay);
+
+        var render = renderers[panel.id];
+        if (render) {
+            render(container, { panel: panel, token: token(), close: closePanel });
+        }
+    }
+
+    function apply() {
+        config.hiddenSections.forEach(function (section) {
+            var links = document.querySelectorAll('a[href="#/' + section + '"], a[href^="#/' + section + '/"]');
+            links.forEach(function (link) {
+                link.style.display = "none";
+            });
+        });
+
+        if (isHidden(currentSection())) {
+            window.location.hash = "#/";
+        }
+
+        var menu = document.querySelector(".app-sidebar .main-menu");
+        if (!menu) {
+            return;
+        }
+
+        config.panels.forEach(function (panel) {
+            if (menu.querySelector('[data-pb-panel="' + panel.id + '"]')) {
+                return;
+            }
+
+            var item = document.createElement("a");
+            item.href = "#";
+            item.className = "menu-item";
+            item.title = panel.title;
+            item.setAttribute("aria-label", panel.title);
+            item.setAttribute("data-pb-panel", panel.id);
+            item.innerHTML = '<i class="' + (panel.icon || "ri-apps-line") + '"></i>';
+            item.addEventListener("click", function (e) {
+                e.preventDefault();
+                openPanel(panel);
+            });
+            menu.appendChild(item);
+        });
+    }
+
+    window.pbAdminUI = {
+        registerPanel: function (id, render) {
+            renderers[id] = render;
+        },
+        closePanel: closePanel,
+    };
+
+    function load() {
+        var authToken = token();
+        if (loaded || !authToken) {
+            return;
+        }
+        loaded = true;
+
+        fetch("../api/admins/ui-config", { headers: { Authorization: authToken } })
+            .then(function (res) {
+                return res.ok ? res.json() : null;
+            })
+            .then(function (data) {
+                if (!data) {
+                    loaded = false;
+                    return;
+                }
+
+                config.hiddenSections = data.hiddenSections || [];
+                config.panels = data.panels || [];
+
+                config.panels.forEach(function (panel) {
+                    if (panel.scriptUrl) {
+                        var script = document.createElement("script");
+                        script.src = panel.scriptUrl;
+                        document.head.appendChild(script);
+                    }
+                });
+
+                apply();
+                new MutationObserver(apply).observe(document.body, { childList: true, subtree: true });
+            })
+            .catch(function () {
+                loaded = false;
+            });
+    }
+
+    window.addEventListener("hashchange", function () {
+        closePanel();
+        load(); // in case of a new admin login
+        if (isHidden(currentSection())) {
+            window.location.hash = "#/";
+        }
+    });
+
+    load();
+})();
+`
@@ -151,6 +151,7 @@ func TestBackupsCreate(t *testing.T) {
 				}
 			},
 			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"OnBackupCreate": 1},
 		},
 		{
 			Name:   "authorized as admin (invalid name)",
@@ -193,6 +194,7 @@ func TestBackupsCreate(t *testing.T) {
 				}
 			},
 			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{"OnBackupCreate": 1},
 		},
 	}
 
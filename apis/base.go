@@ -8,8 +8,6 @@ import (
 	"io/fs"
 	"log/slog"
 	"net/http"
-	"net/url"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -21,8 +19,6 @@ import (
 	"github.com/spf13/cast"
 )
 
-const trailedAdminPath = "/_/"
-
 // InitApi creates a configured echo instance with registered
 // system and app specific routes and middlewares.
 func InitApi(app core.App) (*echo.Echo, error) {
@@ -35,13 +31,14 @@ func InitApi(app core.App) (*echo.Echo, error) {
 
 	// configure a custom router
 	e.ResetRouterCreator(func(ec *echo.Echo) echo.Router {
-		return echo.NewRouter(echo.RouterConfig{
+		return newRoutesRegistry(echo.NewRouter(echo.RouterConfig{
 			UnescapePathParamValues: true,
 			AllowOverwritingRoute:   true,
-		})
+		}))
 	})
 
 	// default middlewares
+	e.Pre(tracingMiddleware(app))
 	e.Pre(middleware.RemoveTrailingSlashWithConfig(middleware.RemoveTrailingSlashConfig{
 		Skipper: func(c echo.Context) bool {
 			// enable by default only for the API routes
@@ -49,6 +46,10 @@ func InitApi(app core.App) (*echo.Echo, error) {
 		},
 	}))
 	e.Pre(LoadAuthContext(app))
+	e.Pre(RouteRules(app))
+	e.Pre(RouteLimits(app))
+	e.Pre(RouteProxies(app))
+	e.Pre(DataSources(app))
 	e.Use(middleware.Recover())
 	e.Use(middleware.Secure())
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -59,6 +60,9 @@ func InitApi(app core.App) (*echo.Echo, error) {
 		}
 	})
 
+	uptime := newUptimeTracker(app)
+	e.Use(uptime.middleware)
+
 	// custom error handler
 	e.HTTPErrorHandler = func(c echo.Context, err error) {
 		if err == nil {
@@ -117,8 +121,19 @@ func InitApi(app core.App) (*echo.Echo, error) {
 	bindStaticAdminUI(app, e)
 
 	// default routes
-	api := e.Group("/api", eagerRequestInfoCache(app))
+	api := e.Group("/api", UploadProgress(app), eagerRequestInfoCache(app))
 	bindSettingsApi(app, api)
+	bindEmailTemplateApi(app, api)
+	bindEmailEventApi(app, api)
+	bindNotificationApi(app, api)
+	bindAdminNotificationApi(app, api)
+	bindQuarantineApi(app, api)
+	bindShareApi(app, api)
+	bindSignatureApi(app, api)
+	bindDataSourceApi(app, api)
+	bindPingerApi(app, api)
+	bindLegalHoldApi(app, api)
+	bindPublicFormApi(app, api)
 	bindAdminApi(app, api)
 	bindCollectionApi(app, api)
 	bindRecordCrudApi(app, api)
@@ -126,14 +141,23 @@ func InitApi(app core.App) (*echo.Echo, error) {
 	bindFileApi(app, api)
 	bindRealtimeApi(app, api)
 	bindLogsApi(app, api)
+	bindSlowQueryApi(app, api)
+	bindRecordsCacheApi(app, api)
 	bindHealthApi(app, api)
+	bindStatusApi(app, api, uptime)
 	bindBackupApi(app, api)
+	bindRoutesApi(app, api)
 
 	// catch all any route
 	api.Any("/*", func(c echo.Context) error {
 		return echo.ErrNotFound
 	}, ActivityLogger(app))
 
+	// the routes registered after this point are considered plugin routes
+	if registry, ok := e.Router().(*routesRegistry); ok {
+		registry.markCoreLoaded()
+	}
+
 	return e, nil
 }
 
@@ -145,58 +169,48 @@ func InitApi(app core.App) (*echo.Echo, error) {
 //
 // @see https://github.com/labstack/echo/issues/2211
 func StaticDirectoryHandler(fileSystem fs.FS, indexFallback bool) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		p := c.PathParam("*")
-
-		// escape url path
-		tmpPath, err := url.PathUnescape(p)
-		if err != nil {
-			return fmt.Errorf("failed to unescape path variable: %w", err)
-		}
-		p = tmpPath
-
-		// fs.FS.Open() already assumes that file names are relative to FS root path and considers name with prefix `/` as invalid
-		name := filepath.ToSlash(filepath.Clean(strings.TrimPrefix(p, "/")))
-
-		fileErr := c.FileFS(name, fileSystem)
-
-		if fileErr != nil && indexFallback && errors.Is(fileErr, echo.ErrNotFound) {
-			return c.FileFS("index.html", fileSystem)
-		}
-
-		return fileErr
-	}
+	return StaticDirectoryHandlerWithConfig(StaticConfig{
+		FS:            fileSystem,
+		IndexFallback: indexFallback,
+	})
 }
 
 // bindStaticAdminUI registers the endpoints that serves the static admin UI.
 func bindStaticAdminUI(app core.App, e *echo.Echo) error {
+	adminPath := app.Settings().AdminUI.TrailedPath()
+
 	// redirect to trailing slash to ensure that relative urls will still work properly
 	e.GET(
-		strings.TrimRight(trailedAdminPath, "/"),
+		strings.TrimRight(adminPath, "/"),
 		func(c echo.Context) error {
-			return c.Redirect(http.StatusTemporaryRedirect, strings.TrimLeft(trailedAdminPath, "/"))
+			return c.Redirect(http.StatusTemporaryRedirect, strings.TrimLeft(adminPath, "/"))
 		},
 	)
 
+	// serves the index.html with the admin UI customizations
+	if err := bindAdminUICustomizations(app, e, adminPath); err != nil {
+		return err
+	}
+
 	// serves static files from the /ui/dist directory
 	// (similar to echo.StaticFS but with gzip middleware enabled)
 	e.GET(
-		trailedAdminPath+"*",
+		adminPath+"*",
 		echo.StaticDirectoryHandler(ui.DistDirFS, false),
-		installerRedirect(app),
-		uiCacheControl(),
+		installerRedirect(app, adminPath),
+		uiCacheControl(adminPath),
 		middleware.Gzip(),
 	)
 
 	return nil
 }
 
-func uiCacheControl() echo.MiddlewareFunc {
+func uiCacheControl(adminPath string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// add default Cache-Control header for all Admin UI resources
 			// (ignoring the root admin path)
-			if c.Request().URL.Path != trailedAdminPath {
+			if c.Request().URL.Path != adminPath {
 				c.Response().Header().Set("Cache-Control", "max-age=1209600, stale-while-revalidate=86400")
 			}
 
@@ -220,7 +234,7 @@ func updateHasAdminsCache(app core.App) error {
 
 // installerRedirect redirects the user to the installer admin UI page
 // when the application needs some preliminary configurations to be done.
-func installerRedirect(app core.App) echo.MiddlewareFunc {
+func installerRedirect(app core.App, adminPath string) echo.MiddlewareFunc {
 	// keep hasAdminsCacheKey value up-to-date
 	app.OnAdminAfterCreateRequest().Add(func(data *core.AdminCreateEvent) error {
 		return updateHasAdminsCache(app)
@@ -234,7 +248,7 @@ func installerRedirect(app core.App) echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			// skip redirect checks for non-root level index.html requests
 			path := c.Request().URL.Path
-			if path != trailedAdminPath && path != trailedAdminPath+"index.html" {
+			if path != adminPath && path != adminPath+"index.html" {
 				return next(c)
 			}
 
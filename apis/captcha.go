@@ -0,0 +1,78 @@
+package apis
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/captcha"
+	"github.com/spf13/cast"
+)
+
+const (
+	// CaptchaTokenHeader is the request header that could be used
+	// for submitting the captcha response token.
+	CaptchaTokenHeader = "X-Captcha-Token"
+
+	// CaptchaTokenKey is the generic body or query parameter key
+	// of the captcha response token.
+	CaptchaTokenKey = "captchaToken"
+)
+
+// VerifyCaptcha verifies the captcha response token of the current
+// request with the app captcha provider and returns a [BadRequestError]
+// if the token is missing or invalid.
+//
+// The token is resolved from (in that order):
+//   - the [CaptchaTokenHeader] request header
+//   - the [CaptchaTokenKey] body or query parameter
+//   - the provider widget default input name (e.g. "h-captcha-response")
+//
+// Note that the verification fails if the app captcha settings are not enabled.
+func VerifyCaptcha(app core.App, c echo.Context) error {
+	verifier, err := app.NewCaptchaVerifier()
+	if err != nil {
+		return NewBadRequestError("The captcha verification is not configured.", err)
+	}
+
+	token := captchaRequestToken(c, captcha.ResponseField(app.Settings().Captcha.Provider))
+
+	if err := verifier.Verify(token, c.RealIP()); err != nil {
+		app.Logger().Debug(
+			"Captcha verification failure",
+			slog.String("ip", c.RealIP()),
+			slog.String("url", c.Request().URL.RequestURI()),
+			slog.String("error", err.Error()),
+		)
+
+		return NewBadRequestError("Missing or invalid captcha response.", nil)
+	}
+
+	return nil
+}
+
+// captchaRequestToken extracts the captcha response token from the request.
+func captchaRequestToken(c echo.Context, responseField string) string {
+	if token := c.Request().Header.Get(CaptchaTokenHeader); token != "" {
+		return token
+	}
+
+	info := RequestInfo(c)
+
+	keys := []string{CaptchaTokenKey}
+	if responseField != "" {
+		keys = append(keys, responseField)
+	}
+
+	for _, key := range keys {
+		if token := cast.ToString(info.Data[key]); token != "" {
+			return token
+		}
+
+		if token := cast.ToString(info.Query[key]); token != "" {
+			return token
+		}
+	}
+
+	return ""
+}
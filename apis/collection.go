@@ -21,6 +21,8 @@ func bindCollectionApi(app core.App, rg *echo.Group) {
 	subGroup.PATCH("/:collection", api.update)
 	subGroup.DELETE("/:collection", api.delete)
 	subGroup.PUT("/import", api.bulkImport)
+	subGroup.POST("/lint-rules", api.lintDraftRules)
+	subGroup.GET("/:collection/lint-rules", api.lintRules)
 }
 
 type collectionApi struct {
@@ -208,3 +210,29 @@ func (api *collectionApi) bulkImport(c echo.Context) error {
 		}
 	})
 }
+
+// lintRules lints and explains the API rules of an existing collection.
+func (api *collectionApi) lintRules(c echo.Context) error {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(c.PathParam("collection"))
+	if err != nil || collection == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, api.app.Dao().LintCollectionRules(collection))
+}
+
+// lintDraftRules lints and explains the API rules of the submitted
+// collection data (eg. while the collection is still being edited).
+func (api *collectionApi) lintDraftRules(c echo.Context) error {
+	collection := &models.Collection{}
+
+	if err := c.Bind(collection); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if collection.Type == "" {
+		collection.Type = models.CollectionTypeBase
+	}
+
+	return c.JSON(http.StatusOK, api.app.Dao().LintCollectionRules(collection))
+}
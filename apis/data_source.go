@@ -0,0 +1,515 @@
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/store"
+	"github.com/pocketbase/pocketbase/tools/transport"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+const (
+	// dataSourceDefaultTimeout is the default upstream request timeout in seconds.
+	dataSourceDefaultTimeout = 30
+
+	// dataSourceMaxResponseSize is the max allowed upstream response body size.
+	dataSourceMaxResponseSize = 32 << 20
+
+	// dataSourceMaxCacheEntries is the max number of the cached
+	// upstream responses after which the cache is reset.
+	dataSourceMaxCacheEntries = 1000
+)
+
+var errDataSourceNotFound = errors.New("the upstream item was not found")
+
+var dataSourceRecordsPathRegex = regexp.MustCompile(`^/api/collections/([^/]+)/records(?:/([^/]+))?$`)
+
+var dataSourceRecordIdRegex = regexp.MustCompile(`^[a-z0-9]{15}$`)
+
+// bindDataSourceApi registers the data sources api endpoints
+// and the data sources sync serve hooks.
+func bindDataSourceApi(app core.App, rg *echo.Group) {
+	api := dataSourceApi{app: app}
+
+	subGroup := rg.Group("/data-sources", ActivityLogger(app), RequireAdminAuth())
+	subGroup.POST("/:name/sync", api.sync)
+
+	bindDataSourcesSync(app)
+}
+
+type dataSourceApi struct {
+	app core.App
+}
+
+func (api *dataSourceApi) sync(c echo.Context) error {
+	config := api.app.Settings().FindDataSource(c.PathParam("name"))
+	if config == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	if config.SyncCollection == "" {
+		return NewBadRequestError("The data source doesn't have a sync collection.", nil)
+	}
+
+	synced, err := SyncDataSource(api.app, *config)
+	if err != nil {
+		return NewBadRequestError("Failed to sync the data source.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"synced": synced})
+}
+
+// DataSources middleware serves the records list and view requests of
+// the external REST data sources configured in the app settings
+// (see [settings.Settings.FindDataSource]) as read-only virtual collections.
+//
+// It is registered by default as pre middleware (see [InitApi]),
+// so that the data sources take precedence over the regular collections
+// with the same name.
+func DataSources(app core.App) echo.MiddlewareFunc {
+	client := newDataSourceClient()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			matches := dataSourceRecordsPathRegex.FindStringSubmatch(c.Request().URL.Path)
+			if matches == nil {
+				return next(c)
+			}
+
+			config := app.Settings().FindDataSource(matches[1])
+			if config == nil {
+				return next(c) // not a data source collection
+			}
+
+			vc := client.virtualCollection(*config)
+			api := &virtualCollectionApi{app: app, vc: vc, collection: vc.Collection()}
+
+			method := c.Request().Method
+			isRead := method == http.MethodGet || method == http.MethodHead
+
+			var handler echo.HandlerFunc
+			switch {
+			case isRead && matches[2] == "":
+				handler = api.list
+			case isRead:
+				c.SetPathParams(echo.PathParams{{Name: "id", Value: matches[2]}})
+				handler = api.view
+			default:
+				handler = api.readOnly
+			}
+
+			return ActivityLogger(app)(handler)(c)
+		}
+	}
+}
+
+// SyncDataSource fetches all items of the provided data source and
+// upserts them as records of its base SyncCollection.
+//
+// The upstream item ids that are valid record ids are used as they are,
+// otherwise the record id is derived from the data source name and
+// the upstream item id. Only the data source fields that exist in the
+// sync collection are copied and the records of the removed upstream
+// items are left untouched.
+//
+// It returns the number of the created or updated records.
+func SyncDataSource(app core.App, config settings.DataSourceConfig) (int, error) {
+	collection, err := app.Dao().FindCollectionByNameOrId(config.SyncCollection)
+	if err != nil {
+		return 0, err
+	}
+
+	if !collection.IsBase() {
+		return 0, errors.New("the data source sync collection must be a base collection")
+	}
+
+	items, err := newDataSourceClient().list(context.Background(), config, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var synced int
+
+	err = app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for _, item := range items {
+			id := dataSourceRecordId(config.Name, cast.ToString(item[schema.FieldNameId]))
+
+			record, _ := txDao.FindRecordById(collection.Id, id)
+			if record == nil {
+				record = models.NewRecord(collection)
+				record.SetId(id)
+			}
+
+			changed := record.IsNew()
+			for _, field := range config.Fields {
+				if collection.Schema.GetFieldByName(field.Name) == nil {
+					continue
+				}
+
+				old, _ := json.Marshal(record.Get(field.Name))
+				record.Set(field.Name, item[field.Name])
+				current, _ := json.Marshal(record.Get(field.Name))
+
+				if string(old) != string(current) {
+					changed = true
+				}
+			}
+
+			if !changed {
+				continue
+			}
+
+			if err := txDao.SaveRecord(record); err != nil {
+				return fmt.Errorf("failed to save record %q: %w", id, err)
+			}
+
+			synced++
+		}
+
+		return nil
+	})
+
+	return synced, err
+}
+
+// bindDataSourcesSync registers the app serve hooks that schedule
+// the data sources with SyncCron to be periodically synced.
+func bindDataSourcesSync(app core.App) {
+	c := cron.New()
+	c.SetLocker(app)
+	app.Store().Set(core.StoreKeyCronPrefix+"dataSources", c)
+	isServe := false
+
+	loadJobs := func() {
+		c.Stop()
+		c.RemoveAll()
+
+		if !isServe {
+			return
+		}
+
+		for _, config := range app.Settings().DataSources {
+			if config.SyncCron == "" || config.SyncCollection == "" {
+				continue
+			}
+
+			config := config
+
+			err := c.Add("@dataSource."+config.Name, config.SyncCron, func() {
+				if _, err := SyncDataSource(app, config); err != nil {
+					app.Logger().Debug(
+						"[Data sources] Failed to sync the data source",
+						slog.String("name", config.Name),
+						slog.String("error", err.Error()),
+					)
+				}
+			})
+			if err != nil {
+				app.Logger().Debug(
+					"[Data sources] Failed to schedule the data source sync",
+					slog.String("name", config.Name),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		if c.Total() > 0 {
+			c.Start()
+		}
+	}
+
+	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		isServe = true
+		loadJobs()
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		c.Stop()
+		c.Wait()
+		return nil
+	})
+
+	// reload on app settings change
+	app.OnModelAfterUpdate((&models.Param{}).TableName()).Add(func(e *core.ModelEvent) error {
+		p, _ := e.Model.(*models.Param)
+		if p == nil || p.Key != models.ParamAppSettings {
+			return nil
+		}
+
+		loadJobs()
+
+		return nil
+	})
+}
+
+// dataSourceRecordId returns the sync collection record id of the
+// specified data source upstream item id.
+func dataSourceRecordId(sourceName string, itemId string) string {
+	if dataSourceRecordIdRegex.MatchString(itemId) {
+		return itemId
+	}
+
+	return security.SHA256(sourceName + ":" + itemId)[:models.DefaultIdLength]
+}
+
+// -------------------------------------------------------------------
+
+type dataSourceCacheEntry struct {
+	data    any
+	expires time.Time
+}
+
+// dataSourceClient fetches and normalizes the data sources upstream items.
+type dataSourceClient struct {
+	cache *store.Store[*dataSourceCacheEntry]
+}
+
+func newDataSourceClient() *dataSourceClient {
+	return &dataSourceClient{
+		cache: store.New[*dataSourceCacheEntry](nil),
+	}
+}
+
+// virtualCollection returns the virtual collection of the provided data source.
+func (client *dataSourceClient) virtualCollection(config settings.DataSourceConfig) *VirtualCollection {
+	fields := make([]*schema.SchemaField, len(config.Fields))
+	for i, f := range config.Fields {
+		fields[i] = &schema.SchemaField{Name: f.Name, Type: f.Type}
+		if f.Type == schema.FieldTypeJson {
+			fields[i].Options = &schema.JsonOptions{MaxSize: dataSourceMaxResponseSize}
+		} else {
+			fields[i].InitOptions()
+		}
+	}
+
+	vc := &VirtualCollection{
+		Name:     config.Name,
+		Schema:   schema.NewSchema(fields...),
+		ListRule: config.ListRule,
+		ViewRule: config.ViewRule,
+		List: func(c echo.Context) ([]map[string]any, error) {
+			return client.list(c.Request().Context(), config, true)
+		},
+	}
+
+	if config.ViewUrl != "" {
+		vc.View = func(c echo.Context, id string) (map[string]any, error) {
+			return client.view(c.Request().Context(), config, id)
+		}
+	}
+
+	return vc
+}
+
+// list returns the normalized items of the data source list endpoint.
+//
+// The items without id are skipped.
+func (client *dataSourceClient) list(ctx context.Context, config settings.DataSourceConfig, useCache bool) ([]map[string]any, error) {
+	data, err := client.fetch(ctx, config, config.Url, useCache)
+	if err != nil {
+		return nil, err
+	}
+
+	rawItems, ok := dataSourcePathValue(data, config.ItemsPath).([]any)
+	if !ok {
+		return nil, fmt.Errorf("the upstream response %q path is not an array", config.ItemsPath)
+	}
+
+	items := make([]map[string]any, 0, len(rawItems))
+	for _, raw := range rawItems {
+		item := normalizeDataSourceItem(config, raw)
+		if item[schema.FieldNameId] == "" {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// view returns the normalized item of the data source view endpoint
+// (nil if missing).
+func (client *dataSourceClient) view(ctx context.Context, config settings.DataSourceConfig, id string) (map[string]any, error) {
+	viewUrl := strings.ReplaceAll(config.ViewUrl, "{id}", url.PathEscape(id))
+
+	data, err := client.fetch(ctx, config, viewUrl, true)
+	if err != nil {
+		if errors.Is(err, errDataSourceNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// the upstream id could be in a different format (eg. number)
+	item := normalizeDataSourceItem(config, data)
+	item[schema.FieldNameId] = id
+
+	return item, nil
+}
+
+// fetch sends a GET request to the specified data source url
+// and returns its decoded JSON response.
+func (client *dataSourceClient) fetch(ctx context.Context, config settings.DataSourceConfig, rawUrl string, useCache bool) (any, error) {
+	var cacheKey string
+
+	useCache = useCache && config.CacheTTL > 0
+	if useCache {
+		// the config is part of the key so that the settings changes invalidate the cache
+		rawConfig, _ := json.Marshal(config)
+		cacheKey = security.SHA256(string(rawConfig) + rawUrl)
+
+		if entry := client.cache.Get(cacheKey); entry != nil {
+			if time.Now().Before(entry.expires) {
+				return entry.data, nil
+			}
+			client.cache.Remove(cacheKey)
+		}
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = dataSourceDefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	for name, value := range config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	switch config.AuthType {
+	case settings.DataSourceAuthBearer:
+		req.Header.Set("Authorization", "Bearer "+config.AuthSecret)
+	case settings.DataSourceAuthBasic:
+		req.SetBasicAuth(config.AuthUsername, config.AuthSecret)
+	}
+
+	res, err := (&http.Client{Transport: transport.Default}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, errDataSourceNotFound
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected upstream response status %d", res.StatusCode)
+	}
+
+	var data any
+
+	decoder := json.NewDecoder(io.LimitReader(res.Body, dataSourceMaxResponseSize))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode the upstream response: %w", err)
+	}
+
+	if useCache {
+		if client.cache.Length() >= dataSourceMaxCacheEntries {
+			client.cache.RemoveAll()
+		}
+
+		client.cache.Set(cacheKey, &dataSourceCacheEntry{
+			data:    data,
+			expires: time.Now().Add(time.Duration(config.CacheTTL) * time.Second),
+		})
+	}
+
+	return data, nil
+}
+
+// normalizeDataSourceItem maps the upstream item values
+// to the data source fields.
+func normalizeDataSourceItem(config settings.DataSourceConfig, raw any) map[string]any {
+	idPath := config.IdPath
+	if idPath == "" {
+		idPath = schema.FieldNameId
+	}
+
+	item := make(map[string]any, len(config.Fields)+1)
+	item[schema.FieldNameId] = cast.ToString(dataSourcePathValue(raw, idPath))
+
+	for _, field := range config.Fields {
+		path := field.Path
+		if path == "" {
+			path = field.Name
+		}
+
+		value := dataSourcePathValue(raw, path)
+
+		switch field.Type {
+		case schema.FieldTypeNumber:
+			item[field.Name] = cast.ToFloat64(value)
+		case schema.FieldTypeBool:
+			item[field.Name] = cast.ToBool(value)
+		case schema.FieldTypeDate:
+			dt, _ := types.ParseDateTime(value)
+			item[field.Name] = dt.String()
+		case schema.FieldTypeJson:
+			item[field.Name] = value
+		default:
+			item[field.Name] = cast.ToString(value)
+		}
+	}
+
+	return item
+}
+
+// dataSourcePathValue returns the value of the dot-notation path
+// in the provided decoded JSON data (eg. "data.items.0.title").
+//
+// An empty path returns the data itself.
+func dataSourcePathValue(data any, path string) any {
+	if path == "" {
+		return data
+	}
+
+	result := data
+
+	for _, part := range strings.Split(path, ".") {
+		switch v := result.(type) {
+		case map[string]any:
+			result = v[part]
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			result = v[i]
+		default:
+			return nil
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,137 @@
+package apis
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/transport"
+)
+
+// maxEmailWebhookBodySize is the max allowed mail provider webhook request body size.
+const maxEmailWebhookBodySize = 5 << 20
+
+// bindEmailEventApi registers the mail provider events api endpoints.
+func bindEmailEventApi(app core.App, rg *echo.Group) {
+	api := emailEventApi{app: app}
+
+	subGroup := rg.Group("/email-events")
+	subGroup.GET("", api.list, RequireAdminAuth())
+	subGroup.POST("/webhooks/:provider", api.webhook)
+}
+
+type emailEventApi struct {
+	app core.App
+}
+
+var emailEventFilterFields = []string{
+	"id", "created", "updated",
+	"provider", "type", "email", "messageId", "reason",
+	`^data\.[\w\.\:]*\w+$`,
+}
+
+func (api *emailEventApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(emailEventFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().EmailEventQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.EmailEvent{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *emailEventApi) webhook(c echo.Context) error {
+	config := api.app.Settings().EmailApi
+
+	provider := c.PathParam("provider")
+
+	token := c.QueryParam("token")
+	if config.WebhookToken == "" ||
+		subtle.ConstantTimeCompare([]byte(token), []byte(config.WebhookToken)) != 1 {
+		return NewUnauthorizedError("Missing or invalid webhook token.", nil)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxEmailWebhookBodySize))
+	if err != nil {
+		return NewBadRequestError("Failed to read the webhook body.", err)
+	}
+
+	var events []*mailer.WebhookEvent
+
+	switch provider {
+	case mailer.ProviderSendGrid:
+		events, err = mailer.ParseSendGridWebhook(body)
+	case mailer.ProviderMailgun:
+		events, err = mailer.ParseMailgunWebhook(body)
+	case mailer.ProviderSes:
+		var subscribeUrl string
+		events, subscribeUrl, err = mailer.ParseSesWebhook(body)
+		if err == nil && subscribeUrl != "" {
+			if err := confirmSnsSubscription(subscribeUrl); err != nil {
+				return NewBadRequestError("Failed to confirm the SNS subscription.", err)
+			}
+			return c.NoContent(http.StatusNoContent)
+		}
+	default:
+		return NewNotFoundError("Unsupported mail provider.", nil)
+	}
+
+	if err != nil {
+		return NewBadRequestError("Failed to parse the webhook payload.", err)
+	}
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for _, e := range events {
+			model := &models.EmailEvent{
+				Provider:  provider,
+				Type:      e.Type,
+				Email:     e.Email,
+				MessageId: e.MessageId,
+				Reason:    e.Reason,
+				Data:      e.Raw,
+			}
+
+			if err := txDao.SaveEmailEvent(model); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		return NewBadRequestError("Failed to store the webhook events.", txErr)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func confirmSnsSubscription(subscribeUrl string) error {
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport.Default,
+	}
+
+	res, err := client.Get(subscribeUrl)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected SNS response status %d", res.StatusCode)
+	}
+
+	return nil
+}
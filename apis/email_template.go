@@ -0,0 +1,138 @@
+package apis
+
+import (
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// bindEmailTemplateApi registers the stored email templates api endpoints.
+func bindEmailTemplateApi(app core.App, rg *echo.Group) {
+	api := emailTemplateApi{app: app}
+
+	subGroup := rg.Group("/email-templates", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.POST("/preview", api.preview)
+	subGroup.POST("/test", api.test)
+	subGroup.GET("/:id", api.view)
+	subGroup.PATCH("/:id", api.update)
+	subGroup.DELETE("/:id", api.delete)
+}
+
+type emailTemplateApi struct {
+	app core.App
+}
+
+func (api *emailTemplateApi) list(c echo.Context) error {
+	templates, err := api.app.Dao().FindAllEmailTemplates()
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, templates)
+}
+
+func (api *emailTemplateApi) view(c echo.Context) error {
+	template, err := api.app.Dao().FindEmailTemplateById(c.PathParam("id"))
+	if err != nil || template == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, template)
+}
+
+func (api *emailTemplateApi) create(c echo.Context) error {
+	template := &models.EmailTemplate{}
+
+	form := forms.NewEmailTemplateUpsert(api.app, template)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to create email template.", err)
+	}
+
+	return c.JSON(http.StatusOK, template)
+}
+
+func (api *emailTemplateApi) update(c echo.Context) error {
+	template, err := api.app.Dao().FindEmailTemplateById(c.PathParam("id"))
+	if err != nil || template == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewEmailTemplateUpsert(api.app, template)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to update email template.", err)
+	}
+
+	return c.JSON(http.StatusOK, template)
+}
+
+func (api *emailTemplateApi) delete(c echo.Context) error {
+	template, err := api.app.Dao().FindEmailTemplateById(c.PathParam("id"))
+	if err != nil || template == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteEmailTemplate(template); err != nil {
+		return NewBadRequestError("Failed to delete email template.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *emailTemplateApi) preview(c echo.Context) error {
+	form := forms.NewEmailTemplatePreview(api.app)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	message, err := form.Render()
+	if err != nil {
+		return NewBadRequestError("Failed to render the email template.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"subject": message.Subject,
+		"html":    message.HTML,
+		"text":    message.Text,
+	})
+}
+
+func (api *emailTemplateApi) test(c echo.Context) error {
+	form := forms.NewEmailTemplatePreview(api.app)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		// form error
+		if fErr, ok := err.(validation.Errors); ok {
+			return NewBadRequestError("Failed to send the test email.", fErr)
+		}
+
+		// render or mailer error
+		return NewBadRequestError("Failed to send the test email. Raw error: \n"+err.Error(), nil)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
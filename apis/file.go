@@ -28,12 +28,7 @@ var defaultThumbSizes = []string{"100x100"}
 
 // bindFileApi registers the file api endpoints and the corresponding handlers.
 func bindFileApi(app core.App, rg *echo.Group) {
-	api := fileApi{
-		app:             app,
-		thumbGenSem:     semaphore.NewWeighted(int64(runtime.NumCPU() + 2)), // the value is arbitrary chosen and may change in the future
-		thumbGenPending: new(singleflight.Group),
-		thumbGenMaxWait: 60 * time.Second,
-	}
+	api := newFileApi(app)
 
 	subGroup := rg.Group("/files", ActivityLogger(app))
 	subGroup.POST("/token", api.fileToken)
@@ -41,6 +36,15 @@ func bindFileApi(app core.App, rg *echo.Group) {
 	subGroup.GET("/:collection/:recordId/:filename", api.download, LoadCollectionContext(api.app))
 }
 
+func newFileApi(app core.App) *fileApi {
+	return &fileApi{
+		app:             app,
+		thumbGenSem:     semaphore.NewWeighted(int64(runtime.NumCPU() + 2)), // the value is arbitrary chosen and may change in the future
+		thumbGenPending: new(singleflight.Group),
+		thumbGenMaxWait: 60 * time.Second,
+	}
+}
+
 type fileApi struct {
 	app core.App
 
@@ -133,11 +137,32 @@ func (api *fileApi) download(c echo.Context) error {
 			}
 		}
 
+		if !canAccessTenantRecord(&requestInfo, record) {
+			return NewForbiddenError("Insufficient permissions to access the file resource.", nil)
+		}
+
 		if ok, _ := api.app.Dao().CanAccessRecord(record, &requestInfo, record.Collection().ViewRule); !ok {
 			return NewForbiddenError("Insufficient permissions to access the file resource.", nil)
 		}
 	}
 
+	return api.serveRecordFile(c, collection, record, fileField, filename)
+}
+
+// serveRecordFile serves the specified record file (or its thumb)
+// without any access checks.
+func (api *fileApi) serveRecordFile(
+	c echo.Context,
+	collection *models.Collection,
+	record *models.Record,
+	fileField *schema.SchemaField,
+	filename string,
+) error {
+	options, ok := fileField.Options.(*schema.FileOptions)
+	if !ok {
+		return NewBadRequestError("", errors.New("Failed to load file options."))
+	}
+
 	baseFilesPath := record.BaseFilesPath()
 
 	// fetch the original view file field related record
@@ -176,7 +201,15 @@ func (api *fileApi) download(c echo.Context) error {
 
 			// create a new thumb if it doesn't exist
 			if exists, _ := fsys.Exists(servedPath); !exists {
-				if err := api.createThumb(c, fsys, originalPath, servedPath, thumbSize); err != nil {
+				release, err := EnterLoadSheddingGate(c, api.app, LoadSheddingFileTransforms)
+				if err != nil {
+					return err
+				}
+
+				err = api.createThumb(c, fsys, originalPath, servedPath, thumbSize)
+				release()
+
+				if err != nil {
 					api.app.Logger().Warn(
 						"Fallback to original - failed to create thumb "+servedName,
 						slog.Any("error", err),
@@ -205,6 +238,17 @@ func (api *fileApi) download(c echo.Context) error {
 	// (note: it is out of the hook to allow users to customize the behavior)
 	c.Response().Header().Del("X-Frame-Options")
 
+	// custom collection files Cache-Control
+	// (note: it is out of the hook to allow users to customize the behavior)
+	cacheControl := collection.FileCacheControl()
+	if options.Protected {
+		// protected files must not be stored in shared caches
+		cacheControl = privateCacheControl(cacheControl)
+	}
+	if cacheControl != "" {
+		c.Response().Header().Set("Cache-Control", cacheControl)
+	}
+
 	return api.app.OnFileDownloadRequest().Trigger(event, func(e *core.FileDownloadEvent) error {
 		if e.HttpContext.Response().Committed {
 			return nil
@@ -274,3 +318,31 @@ func (api *fileApi) createThumb(
 
 	return res.Err
 }
+
+// defaultProtectedFileCacheControl is the Cache-Control header value of
+// the protected files served without a custom collection Cache-Control.
+const defaultProtectedFileCacheControl = "private, max-age=2592000, stale-while-revalidate=86400"
+
+// privateCacheControl returns the provided Cache-Control header value
+// with its shared caches directives (public, s-maxage, proxy-revalidate)
+// replaced by "private".
+func privateCacheControl(cacheControl string) string {
+	if cacheControl == "" {
+		return defaultProtectedFileCacheControl
+	}
+
+	directives := []string{"private"}
+
+	for _, d := range strings.Split(cacheControl, ",") {
+		d = strings.TrimSpace(d)
+
+		name, _, _ := strings.Cut(strings.ToLower(d), "=")
+		if name == "" || name == "public" || name == "private" || name == "s-maxage" || name == "proxy-revalidate" {
+			continue
+		}
+
+		directives = append(directives, d)
+	}
+
+	return strings.Join(directives, ", ")
+}
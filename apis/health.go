@@ -1,12 +1,32 @@
 package apis
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/health"
+	"github.com/pocketbase/pocketbase/tools/osutils"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
 )
 
+// healthDeepCacheTTL is the duration for which the deep health
+// checks result is reused to prevent probing the components on every request.
+const healthDeepCacheTTL = 2 * time.Second
+
 // bindHealthApi registers the health api endpoint.
 func bindHealthApi(app core.App, rg *echo.Group) {
 	api := healthApi{app: app}
@@ -14,30 +34,317 @@ func bindHealthApi(app core.App, rg *echo.Group) {
 	subGroup := rg.Group("/health")
 	subGroup.HEAD("", api.healthCheck)
 	subGroup.GET("", api.healthCheck)
+	subGroup.HEAD("/live", api.liveCheck)
+	subGroup.GET("/live", api.liveCheck)
+	subGroup.HEAD("/ready", api.readyCheck)
+	subGroup.GET("/ready", api.readyCheck)
 }
 
 type healthApi struct {
 	app core.App
+
+	deepMux        sync.Mutex
+	deepResult     []*health.Component
+	deepResultTime time.Time
 }
 
 type healthCheckResponse struct {
 	Message string `json:"message"`
 	Code    int    `json:"code"`
 	Data    struct {
-		CanBackup bool `json:"canBackup"`
+		CanBackup  bool                `json:"canBackup"`
+		Status     string              `json:"status,omitempty"`
+		Components []*health.Component `json:"components,omitempty"`
 	} `json:"data"`
 }
 
+type healthProbeResponse struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// liveCheck returns a 200 OK response if the server process is running
+// (aka. a liveness probe).
+func (api *healthApi) liveCheck(c echo.Context) error {
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(http.StatusOK)
+	}
+
+	return c.JSON(http.StatusOK, healthProbeResponse{
+		Message: "API is live.",
+		Code:    http.StatusOK,
+	})
+}
+
+// readyCheck returns a 200 OK response if the app is ready to receive
+// traffic (aka. a readiness probe), otherwise - 503.
+//
+// The app is ready after it was bootstrapped, the migrations were applied
+// and the app hooks were loaded (see [core.StoreKeyReady]).
+// It is not ready during a backup restore or a shutdown.
+func (api *healthApi) readyCheck(c echo.Context) error {
+	resp := healthProbeResponse{
+		Message: "API is ready.",
+		Code:    http.StatusOK,
+	}
+
+	if !api.app.IsBootstrapped() || !api.app.Store().Has(core.StoreKeyReady) {
+		resp.Message = "API is not ready."
+		resp.Code = http.StatusServiceUnavailable
+	}
+
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(resp.Code)
+	}
+
+	return c.JSON(resp.Code, resp)
+}
+
 // healthCheck returns a 200 OK response if the server is healthy.
+//
+// With the "deep" query parameter it also probes the app components
+// (db, storage, smtp, etc.) and responds with 503 if the db is down or
+// the number of the failed components reaches the configured threshold.
 func (api *healthApi) healthCheck(c echo.Context) error {
+	deep := cast.ToBool(c.QueryParam("deep"))
+
+	if !deep {
+		if c.Request().Method == http.MethodHead {
+			return c.NoContent(http.StatusOK)
+		}
+
+		resp := new(healthCheckResponse)
+		resp.Code = http.StatusOK
+		resp.Message = "API is healthy."
+		resp.Data.CanBackup = !api.app.Store().Has(core.StoreKeyActiveBackup)
+
+		return c.JSON(http.StatusOK, resp)
+	}
+
+	components := api.deepCheck()
+
+	status := health.Overall(components, api.app.Settings().Health.FailureThreshold, "db")
+
+	code := http.StatusOK
+	if status == health.StatusDown {
+		code = http.StatusServiceUnavailable
+	}
+
 	if c.Request().Method == http.MethodHead {
-		return c.NoContent(http.StatusOK)
+		return c.NoContent(code)
+	}
+
+	// the component errors could contain sensitive
+	// information and are visible only to the admins
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin == nil {
+		public := make([]*health.Component, len(components))
+		for i, component := range components {
+			clone := *component
+			clone.Error = ""
+			public[i] = &clone
+		}
+		components = public
 	}
 
 	resp := new(healthCheckResponse)
-	resp.Code = http.StatusOK
-	resp.Message = "API is healthy."
+	resp.Code = code
 	resp.Data.CanBackup = !api.app.Store().Has(core.StoreKeyActiveBackup)
+	resp.Data.Status = status
+	resp.Data.Components = components
+
+	switch status {
+	case health.StatusDown:
+		resp.Message = "API is unhealthy."
+	case health.StatusDegraded:
+		resp.Message = "API is degraded."
+	default:
+		resp.Message = "API is healthy."
+	}
+
+	return c.JSON(code, resp)
+}
+
+// deepCheck runs the app components health checks
+// (or returns the cached result of the last run).
+func (api *healthApi) deepCheck() []*health.Component {
+	api.deepMux.Lock()
+	defer api.deepMux.Unlock()
+
+	if api.deepResult != nil && time.Since(api.deepResultTime) < healthDeepCacheTTL {
+		return api.deepResult
+	}
+
+	timeout := time.Duration(api.app.Settings().Health.Timeout) * time.Second
+
+	api.deepResult = health.Run(context.Background(), timeout, map[string]health.Check{
+		"db":      api.checkDB,
+		"storage": api.checkStorage,
+		"smtp":    api.checkSmtp,
+		"cron":    api.checkCron,
+		"queue":   api.checkQueue,
+		"disk":    api.checkDisk,
+	})
+	api.deepResultTime = time.Now()
+
+	return api.deepResult
+}
+
+// checkDB probes the db read and write connections.
+//
+// The write probe creates a temp table in a transaction that is always rolled back.
+func (api *healthApi) checkDB(ctx context.Context) (map[string]any, error) {
+	if _, err := api.app.Dao().ConcurrentDB().NewQuery("SELECT 1").WithContext(ctx).Execute(); err != nil {
+		return nil, fmt.Errorf("read probe failed: %w", err)
+	}
+
+	if _, err := api.app.LogsDao().ConcurrentDB().NewQuery("SELECT 1").WithContext(ctx).Execute(); err != nil {
+		return nil, fmt.Errorf("logs read probe failed: %w", err)
+	}
+
+	db, ok := api.app.Dao().NonconcurrentDB().(*dbx.DB)
+	if !ok {
+		return nil, nil // write probe is not supported
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("write probe failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	table := "_healthProbe" + security.PseudorandomString(5)
+
+	if _, err := tx.NewQuery("CREATE TABLE {{" + table + "}} ([[id]] INTEGER)").WithContext(ctx).Execute(); err != nil {
+		return nil, fmt.Errorf("write probe failed: %w", err)
+	}
+
+	return nil, nil
+}
+
+// checkStorage checks whether the app storage (local or S3) is reachable.
+func (api *healthApi) checkStorage(ctx context.Context) (map[string]any, error) {
+	details := map[string]any{"s3": api.app.Settings().S3.Enabled}
+
+	fs, err := api.app.NewFilesystem()
+	if err != nil {
+		return details, err
+	}
+	defer fs.Close()
+
+	if _, err := fs.Exists("@healthProbe"); err != nil {
+		return details, err
+	}
+
+	return details, nil
+}
+
+// checkSmtp checks whether the configured SMTP server is reachable.
+func (api *healthApi) checkSmtp(ctx context.Context) (map[string]any, error) {
+	config := api.app.Settings().Smtp
+	if !config.Enabled {
+		return nil, health.ErrDisabled
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, conn.Close()
+}
+
+// checkCron reports the registered cron schedulers (see [core.StoreKeyCronPrefix]).
+//
+// A scheduler with jobs that is not running is reported as degraded.
+func (api *healthApi) checkCron(ctx context.Context) (map[string]any, error) {
+	details := map[string]any{}
+
+	var stopped []string
+
+	for key, v := range api.app.Store().GetAll() {
+		scheduler, ok := v.(*cron.Cron)
+		if !ok || !strings.HasPrefix(key, core.StoreKeyCronPrefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(key, core.StoreKeyCronPrefix)
+		total := scheduler.Total()
+		started := scheduler.HasStarted()
+
+		details[name] = map[string]any{
+			"jobs":    total,
+			"started": started,
+		}
+
+		if total > 0 && !started {
+			stopped = append(stopped, name)
+		}
+	}
+
+	if len(stopped) > 0 {
+		sort.Strings(stopped)
+		return details, health.Degraded(fmt.Errorf("stopped schedulers with jobs: %s", strings.Join(stopped, ", ")))
+	}
+
+	return details, nil
+}
+
+// checkQueue reports the in-flight load shedding operations
+// and the pending tracing spans.
+//
+// A load shedding subsystem at its limit is reported as degraded.
+func (api *healthApi) checkQueue(ctx context.Context) (map[string]any, error) {
+	config := api.app.Settings().LoadShedding
+
+	limits := map[string]int{
+		LoadSheddingRecordWrites:   config.MaxRecordWrites,
+		LoadSheddingScripts:        config.MaxScripts,
+		LoadSheddingFileTransforms: config.MaxFileTransforms,
+	}
+
+	details := map[string]any{
+		"tracingSpans": api.app.Tracer().QueueSize(),
+	}
+
+	var saturated []string
+
+	for subsystem, limit := range limits {
+		inFlight := LoadSheddingGate(api.app, subsystem).InFlight()
+
+		details[subsystem] = inFlight
+
+		if limit > 0 && inFlight >= limit {
+			saturated = append(saturated, subsystem)
+		}
+	}
+
+	if len(saturated) > 0 {
+		sort.Strings(saturated)
+		return details, health.Degraded(fmt.Errorf("saturated subsystems: %s", strings.Join(saturated, ", ")))
+	}
+
+	return details, nil
+}
+
+// checkDisk reports the free space of the data dir filesystem.
+func (api *healthApi) checkDisk(ctx context.Context) (map[string]any, error) {
+	usage, err := osutils.GetDiskUsage(api.app.DataDir())
+	if err != nil {
+		if errors.Is(err, errors.ErrUnsupported) {
+			return nil, health.ErrDisabled
+		}
+		return nil, err
+	}
+
+	details := map[string]any{
+		"total": usage.Total,
+		"free":  usage.Free,
+	}
+
+	minFree := uint64(api.app.Settings().Health.MinFreeDiskSpace) << 20
+	if usage.Free < minFree {
+		return details, health.Degraded(fmt.Errorf("free disk space is below %dMB", api.app.Settings().Health.MinFreeDiskSpace))
+	}
 
-	return c.JSON(http.StatusOK, resp)
+	return details, nil
 }
@@ -0,0 +1,270 @@
+package apis
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// bindLegalHoldApi registers the records legal hold api endpoints.
+func bindLegalHoldApi(app core.App, rg *echo.Group) {
+	api := legalHoldApi{app: app}
+
+	subGroup := rg.Group("/legal-holds", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.GET("/export", api.export)
+	subGroup.GET("/:id", api.view)
+	subGroup.DELETE("/:id", api.release)
+}
+
+type legalHoldApi struct {
+	app core.App
+}
+
+var legalHoldFilterFields = []string{
+	"id", "created", "updated",
+	"collectionId", "recordId", "reason", "adminId",
+}
+
+func (api *legalHoldApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(legalHoldFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().LegalHoldQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.LegalHold{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *legalHoldApi) view(c echo.Context) error {
+	hold, err := api.app.Dao().FindLegalHoldById(c.PathParam("id"))
+	if err != nil || hold == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+func (api *legalHoldApi) create(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+
+	form := forms.NewLegalHoldCreate(api.app, admin)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	hold, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to place the legal hold.", err)
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+func (api *legalHoldApi) release(c echo.Context) error {
+	hold, err := api.app.Dao().FindLegalHoldById(c.PathParam("id"))
+	if err != nil || hold == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteLegalHold(hold); err != nil {
+		return NewBadRequestError("Failed to release the legal hold.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// export streams a zip archive with the held records and their files.
+//
+// The exported holds could be limited with the comma separated "ids" query parameter.
+func (api *legalHoldApi) export(c echo.Context) error {
+	var ids []string
+	if raw := c.QueryParam("ids"); raw != "" {
+		ids = strings.Split(raw, ",")
+	}
+
+	holds, err := api.app.Dao().FindLegalHoldsByIds(ids)
+	if err != nil {
+		return NewBadRequestError("Failed to load the legal holds.", err)
+	}
+	if len(holds) == 0 {
+		return NewNotFoundError("Missing legal holds to export.", nil)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/zip")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(
+		`attachment; filename="legal_holds_%s.zip"`,
+		time.Now().UTC().Format("20060102150405"),
+	))
+
+	return ExportLegalHolds(api.app, res, holds)
+}
+
+// -------------------------------------------------------------------
+
+// LegalHoldsManifest describes the content of a legal holds export archive.
+type LegalHoldsManifest struct {
+	Created types.DateTime            `json:"created"`
+	Holds   []*models.LegalHold       `json:"holds"`
+	Entries []*LegalHoldsArchiveEntry `json:"entries"`
+}
+
+// LegalHoldsArchiveEntry describes a single legal holds export archive file.
+type LegalHoldsArchiveEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ExportLegalHolds writes a zip archive with the provided
+// legal holds records and their files into w.
+//
+// The archive contains:
+//   - records/{collectionName}/{recordId}.json - the held record data
+//   - files/{collectionName}/{recordId}/{filename} - the held record files (decrypted, if encrypted)
+//   - manifest.json - the list of holds and the size and SHA-256 checksum of each of the above entries
+//   - manifest.json.sha256 - the SHA-256 checksum of the manifest itself
+//
+// The checksums are computed from the content as it is written, so that
+// the archive integrity could be later verified independently of the app.
+//
+// Note that the archive is streamed, aka. on error it is left incomplete
+// (without a valid zip central directory).
+func ExportLegalHolds(app core.App, w io.Writer, holds []*models.LegalHold) error {
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	zw := zip.NewWriter(w)
+
+	manifest := &LegalHoldsManifest{
+		Created: types.NowDateTime(),
+		Holds:   holds,
+		Entries: []*LegalHoldsArchiveEntry{},
+	}
+
+	writeEntry := func(path string, r io.Reader) error {
+		entryWriter, err := zw.Create(path)
+		if err != nil {
+			return err
+		}
+
+		h := sha256.New()
+
+		size, err := io.Copy(io.MultiWriter(entryWriter, h), r)
+		if err != nil {
+			return err
+		}
+
+		manifest.Entries = append(manifest.Entries, &LegalHoldsArchiveEntry{
+			Path:   path,
+			Size:   size,
+			SHA256: hex.EncodeToString(h.Sum(nil)),
+		})
+
+		return nil
+	}
+
+	// a record could have multiple holds but it is exported only once
+	exported := map[string]struct{}{}
+
+	for _, hold := range holds {
+		uniqueKey := hold.CollectionId + "/" + hold.RecordId
+		if _, ok := exported[uniqueKey]; ok {
+			continue
+		}
+		exported[uniqueKey] = struct{}{}
+
+		record, err := app.Dao().FindRecordById(hold.CollectionId, hold.RecordId)
+		if err != nil {
+			return fmt.Errorf("failed to load the held record %q: %w", uniqueKey, err)
+		}
+		record.IgnoreEmailVisibility(true)
+
+		raw, err := json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		recordPath := record.Collection().Name + "/" + record.Id
+
+		if err := writeEntry("records/"+recordPath+".json", bytes.NewReader(raw)); err != nil {
+			return err
+		}
+
+		for _, field := range record.Collection().Schema.Fields() {
+			if field.Type != schema.FieldTypeFile {
+				continue
+			}
+
+			for _, name := range record.GetStringSlice(field.Name) {
+				if err := exportLegalHoldFile(fsys, writeEntry, record.BaseFilesPath()+"/"+name, "files/"+recordPath+"/"+name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	rawManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	manifestWriter, err := zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	if _, err := manifestWriter.Write(rawManifest); err != nil {
+		return err
+	}
+
+	checksumWriter, err := zw.Create("manifest.json.sha256")
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(rawManifest)
+	if _, err := fmt.Fprintf(checksumWriter, "%s  manifest.json\n", hex.EncodeToString(sum[:])); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func exportLegalHoldFile(
+	fsys *filesystem.System,
+	writeEntry func(path string, r io.Reader) error,
+	fileKey string,
+	path string,
+) error {
+	r, err := fsys.OpenFile(fileKey)
+	if err != nil {
+		return fmt.Errorf("failed to open the held record file %q: %w", fileKey, err)
+	}
+	defer r.Close()
+
+	return writeEntry(path, r)
+}
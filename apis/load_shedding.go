@@ -0,0 +1,111 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/gate"
+)
+
+// Load shedding subsystems (see [settings.LoadSheddingConfig]).
+const (
+	LoadSheddingRecordWrites   string = "recordWrites"
+	LoadSheddingScripts        string = "scripts"
+	LoadSheddingFileTransforms string = "fileTransforms"
+)
+
+const loadSheddingStorePrefix = "@loadShedding."
+
+var loadSheddingMux sync.Mutex
+
+// LoadSheddingGate returns the concurrency gate of the specified
+// app subsystem (creating a new one if missing).
+func LoadSheddingGate(app core.App, subsystem string) *gate.Gate {
+	key := loadSheddingStorePrefix + subsystem
+
+	loadSheddingMux.Lock()
+	defer loadSheddingMux.Unlock()
+
+	g, _ := app.Store().Get(key).(*gate.Gate)
+	if g == nil {
+		g = &gate.Gate{}
+		app.Store().Set(key, g)
+	}
+
+	return g
+}
+
+// EnterLoadSheddingGate tries to acquire a slot from the specified
+// subsystem gate for the current request.
+//
+// On success it returns a release func that must be called once the
+// operation completes. If the configured limit is reached, it sets
+// the Retry-After response header and returns a 503 error.
+//
+// Nested calls for the same subsystem and request
+// (eg. a JS middleware followed by a JS handler) reuse the already
+// acquired slot and return a noop release func.
+func EnterLoadSheddingGate(c echo.Context, app core.App, subsystem string) (func(), error) {
+	key := loadSheddingStorePrefix + subsystem
+
+	if c.Get(key) != nil {
+		return func() {}, nil // already acquired
+	}
+
+	config := app.Settings().LoadShedding
+
+	var limit int
+	switch subsystem {
+	case LoadSheddingRecordWrites:
+		limit = config.MaxRecordWrites
+	case LoadSheddingScripts:
+		limit = config.MaxScripts
+	case LoadSheddingFileTransforms:
+		limit = config.MaxFileTransforms
+	}
+
+	g := LoadSheddingGate(app, subsystem)
+
+	if !g.TryEnter(limit) {
+		if config.RetryAfter > 0 {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(config.RetryAfter))
+		}
+
+		return nil, NewApiError(http.StatusServiceUnavailable, "The server is overloaded. Please try again later.", nil)
+	}
+
+	c.Set(key, true)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			c.Set(key, nil)
+			g.Leave()
+		})
+	}, nil
+}
+
+// LoadShedding middleware limits the number of the in-flight requests
+// of the specified subsystem, rejecting the ones over the
+// configured limit with 503 (instead of queueing them).
+//
+// Example:
+//
+//	e.Router.POST("/reports", handler, apis.LoadShedding(app, apis.LoadSheddingRecordWrites))
+func LoadShedding(app core.App, subsystem string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			release, err := EnterLoadSheddingGate(c, app, subsystem)
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			return next(c)
+		}
+	}
+}
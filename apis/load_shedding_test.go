@@ -0,0 +1,210 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestLoadSheddingGate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	g1 := apis.LoadSheddingGate(app, apis.LoadSheddingScripts)
+	g2 := apis.LoadSheddingGate(app, apis.LoadSheddingScripts)
+	g3 := apis.LoadSheddingGate(app, apis.LoadSheddingRecordWrites)
+
+	if g1 != g2 {
+		t.Fatal("Expected the same gate instance for the same subsystem")
+	}
+
+	if g1 == g3 {
+		t.Fatal("Expected different gate instances for different subsystems")
+	}
+}
+
+func TestEnterLoadSheddingGate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().LoadShedding.MaxScripts = 1
+	app.Settings().LoadShedding.RetryAfter = 5
+
+	e := echo.New()
+
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		return e.NewContext(req, rec), rec
+	}
+
+	c1, _ := newContext()
+
+	release1, err := apis.EnterLoadSheddingGate(c1, app, apis.LoadSheddingScripts)
+	if err != nil {
+		t.Fatalf("Expected the first request to enter, got %v", err)
+	}
+
+	// nested enter for the same request
+	releaseNested, err := apis.EnterLoadSheddingGate(c1, app, apis.LoadSheddingScripts)
+	if err != nil {
+		t.Fatalf("Expected the nested enter to succeed, got %v", err)
+	}
+	releaseNested()
+
+	if total := apis.LoadSheddingGate(app, apis.LoadSheddingScripts).InFlight(); total != 1 {
+		t.Fatalf("Expected 1 in-flight script, got %d", total)
+	}
+
+	// other request
+	c2, rec2 := newContext()
+
+	if _, err := apis.EnterLoadSheddingGate(c2, app, apis.LoadSheddingScripts); err == nil {
+		t.Fatal("Expected the second request to be rejected")
+	} else if apiErr, ok := err.(*apis.ApiError); !ok || apiErr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 ApiError, got %v", err)
+	}
+
+	if v := rec2.Header().Get("Retry-After"); v != "5" {
+		t.Fatalf("Expected Retry-After 5, got %q", v)
+	}
+
+	// other subsystem
+	releaseOther, err := apis.EnterLoadSheddingGate(c2, app, apis.LoadSheddingRecordWrites)
+	if err != nil {
+		t.Fatalf("Expected the other subsystem to be unlimited, got %v", err)
+	}
+	releaseOther()
+
+	// multiple release calls should decrement only once
+	release1()
+	release1()
+
+	if total := apis.LoadSheddingGate(app, apis.LoadSheddingScripts).InFlight(); total != 0 {
+		t.Fatalf("Expected 0 in-flight scripts, got %d", total)
+	}
+
+	c3, _ := newContext()
+
+	release3, err := apis.EnterLoadSheddingGate(c3, app, apis.LoadSheddingScripts)
+	if err != nil {
+		t.Fatalf("Expected the request to enter after release, got %v", err)
+	}
+	release3()
+}
+
+func TestLoadSheddingApi(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "record create with free record writes slot",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records",
+			Body:   strings.NewReader(`{"title":"new"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().LoadShedding.MaxRecordWrites = 2
+				apis.LoadSheddingGate(app, apis.LoadSheddingRecordWrites).TryEnter(2)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"title":"new"`},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+			},
+		},
+		{
+			Name:   "record create with max record writes reached",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo2/records",
+			Body:   strings.NewReader(`{"title":"new"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().LoadShedding.MaxRecordWrites = 1
+				apis.LoadSheddingGate(app, apis.LoadSheddingRecordWrites).TryEnter(1)
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if v := res.Header.Get("Retry-After"); v != "1" {
+					t.Fatalf("Expected Retry-After 1, got %q", v)
+				}
+			},
+			ExpectedStatus:  503,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "record delete with max record writes reached",
+			Method: http.MethodDelete,
+			Url:    "/api/collections/demo2/records/achvryl401bhse3",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().LoadShedding.MaxRecordWrites = 1
+				app.Settings().LoadShedding.RetryAfter = 0
+				apis.LoadSheddingGate(app, apis.LoadSheddingRecordWrites).TryEnter(1)
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if v := res.Header.Get("Retry-After"); v != "" {
+					t.Fatalf("Expected no Retry-After header, got %q", v)
+				}
+			},
+			ExpectedStatus:  503,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "records list with max record writes reached",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo2/records",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().LoadShedding.MaxRecordWrites = 1
+				apis.LoadSheddingGate(app, apis.LoadSheddingRecordWrites).TryEnter(1)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"totalItems":3`},
+			ExpectedEvents:  map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "existing thumb with max file transforms reached",
+			Method: http.MethodGet,
+			Url:    "/api/files/_pb_users_auth_/4q1xlclmfloku33/300_1SEi6Q6U72.png?thumb=70x50",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().LoadShedding.MaxFileTransforms = 1
+				apis.LoadSheddingGate(app, apis.LoadSheddingFileTransforms).TryEnter(1)
+			},
+			ExpectedStatus: 200,
+			ExpectedEvents: map[string]int{"OnFileDownloadRequest": 1},
+		},
+		{
+			Name:   "missing thumb with max file transforms reached",
+			Method: http.MethodGet,
+			Url:    "/api/files/_pb_users_auth_/4q1xlclmfloku33/300_1SEi6Q6U72.png?thumb=70x50",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				fsys, err := app.NewFilesystem()
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer fsys.Close()
+
+				if err := fsys.Delete("_pb_users_auth_/4q1xlclmfloku33/thumbs_300_1SEi6Q6U72.png/70x50_300_1SEi6Q6U72.png"); err != nil {
+					t.Fatal(err)
+				}
+
+				app.Settings().LoadShedding.MaxFileTransforms = 1
+				apis.LoadSheddingGate(app, apis.LoadSheddingFileTransforms).TryEnter(1)
+			},
+			ExpectedStatus:  503,
+			ExpectedContent: []string{`"data":{}`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
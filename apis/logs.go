@@ -1,13 +1,23 @@
 package apis
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/bus"
+	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 // bindLogsApi registers the request logs api endpoints.
@@ -17,6 +27,8 @@ func bindLogsApi(app core.App, rg *echo.Group) {
 	subGroup := rg.Group("/logs", RequireAdminAuth())
 	subGroup.GET("", api.list)
 	subGroup.GET("/stats", api.stats)
+	subGroup.GET("/export", api.export)
+	subGroup.GET("/stream", api.stream)
 	subGroup.GET("/:id", api.view)
 }
 
@@ -30,11 +42,23 @@ var logFilterFields = []string{
 	`^data\.[\w\.\:]*\w+$`,
 }
 
+var logDataParamRegex = regexp.MustCompile(`^data\.([\w\.]*\w)$`)
+
 func (api *logsApi) list(c echo.Context) error {
 	fieldResolver := search.NewSimpleFieldResolver(logFilterFields...)
 
+	query := api.app.LogsDao().LogQuery()
+
+	filterExpr, err := logsFilterExpr(c)
+	if err != nil {
+		return NewBadRequestError("Invalid logs filter.", err)
+	}
+	if filterExpr != nil {
+		query.AndWhere(filterExpr)
+	}
+
 	result, err := search.NewProvider(fieldResolver).
-		Query(api.app.LogsDao().LogQuery()).
+		Query(query).
 		ParseAndExec(c.QueryParams().Encode(), &[]*models.Log{})
 
 	if err != nil {
@@ -58,6 +82,18 @@ func (api *logsApi) stats(c echo.Context) error {
 		}
 	}
 
+	filterExpr, err := logsFilterExpr(c)
+	if err != nil {
+		return NewBadRequestError("Invalid logs filter.", err)
+	}
+	if filterExpr != nil {
+		if expr != nil {
+			expr = dbx.And(expr, filterExpr)
+		} else {
+			expr = filterExpr
+		}
+	}
+
 	stats, err := api.app.LogsDao().LogsStats(expr)
 	if err != nil {
 		return NewBadRequestError("Failed to generate logs stats.", err)
@@ -79,3 +115,224 @@ func (api *logsApi) view(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, log)
 }
+
+func (api *logsApi) export(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(logFilterFields...)
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "ndjson"
+	}
+	if format != "ndjson" && format != "csv" {
+		return NewBadRequestError("Invalid export format - must be ndjson or csv.", nil)
+	}
+
+	query := api.app.LogsDao().LogQuery().OrderBy("rowid DESC")
+
+	if filter := c.QueryParam(search.FilterQueryParam); filter != "" {
+		expr, err := search.FilterData(filter).BuildExpr(fieldResolver)
+		if err != nil {
+			return NewBadRequestError("Invalid filter format.", err)
+		}
+		query.AndWhere(expr)
+	}
+
+	filterExpr, err := logsFilterExpr(c)
+	if err != nil {
+		return NewBadRequestError("Invalid logs filter.", err)
+	}
+	if filterExpr != nil {
+		query.AndWhere(filterExpr)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return NewBadRequestError("Failed to export logs.", err)
+	}
+	defer rows.Close()
+
+	res := c.Response()
+
+	var write func(log *models.Log) error
+	var flush func() error
+
+	if format == "csv" {
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="logs.csv"`)
+		res.WriteHeader(http.StatusOK)
+
+		w := csv.NewWriter(res)
+		if err := w.Write([]string{"id", "created", "level", "message", "data"}); err != nil {
+			return err
+		}
+
+		write = func(log *models.Log) error {
+			data, _ := log.Data.MarshalJSON()
+			return w.Write([]string{
+				log.Id,
+				log.Created.String(),
+				strconv.Itoa(log.Level),
+				log.Message,
+				string(data),
+			})
+		}
+		flush = func() error {
+			w.Flush()
+			return w.Error()
+		}
+	} else {
+		res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="logs.ndjson"`)
+		res.WriteHeader(http.StatusOK)
+
+		enc := json.NewEncoder(res)
+
+		write = func(log *models.Log) error {
+			return enc.Encode(log)
+		}
+		flush = func() error {
+			return nil
+		}
+	}
+
+	for rows.Next() {
+		log := &models.Log{}
+		if err := rows.ScanStruct(log); err != nil {
+			return err
+		}
+
+		if err := write(log); err != nil {
+			return err
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return rows.Err()
+}
+
+func (api *logsApi) stream(c echo.Context) error {
+	filter, err := logsFilterFromQuery(c)
+	if err != nil {
+		return NewBadRequestError("Invalid logs filter.", err)
+	}
+
+	logs := make(chan *models.Log, 100)
+
+	subscriptionId := api.app.Bus().Subscribe(core.LogsBusTopic, func(m *bus.Message) error {
+		l, ok := m.Data.(*logger.Log)
+		if !ok {
+			return nil
+		}
+
+		model := &models.Log{
+			Level:   int(l.Level),
+			Message: l.Message,
+			Data:    l.Data,
+		}
+		model.Created, _ = types.ParseDateTime(l.Time)
+		model.Updated = model.Created
+
+		if !filter.Match(model) {
+			return nil
+		}
+
+		// drop the log if the client can't keep up
+		select {
+		case logs <- model:
+		default:
+		}
+
+		return nil
+	})
+	defer api.app.Bus().Unsubscribe(core.LogsBusTopic, subscriptionId)
+
+	res := c.Response()
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-store")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	ping := time.NewTicker(30 * time.Second)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-ping.C:
+			res.Write([]byte(":ping\n\n"))
+			res.Flush()
+		case log := <-logs:
+			data, err := json.Marshal(log)
+			if err != nil {
+				continue
+			}
+
+			res.Write([]byte("event:log\n"))
+			res.Write([]byte("data:"))
+			res.Write(data)
+			res.Write([]byte("\n\n"))
+			res.Flush()
+		}
+	}
+}
+
+// logsFilterExpr builds a logs filter expression from the
+// "level", "from", "to", "message" and "data.*" query parameters.
+//
+// Returns nil if none of the parameters are set.
+func logsFilterExpr(c echo.Context) (dbx.Expression, error) {
+	filter, err := logsFilterFromQuery(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.BuildExpr(), nil
+}
+
+// logsFilterFromQuery loads a logs filter from the
+// "level", "from", "to", "message" and "data.*" query parameters.
+func logsFilterFromQuery(c echo.Context) (daos.LogsFilter, error) {
+	filter := daos.LogsFilter{
+		Message: c.QueryParam("message"),
+		Data:    map[string]string{},
+	}
+
+	if raw := c.QueryParam("level"); raw != "" {
+		level, err := daos.ParseLogLevel(raw)
+		if err != nil {
+			return filter, err
+		}
+		filter.MinLevel = &level
+	}
+
+	if raw := c.QueryParam("from"); raw != "" {
+		from, err := types.ParseDateTime(raw)
+		if err != nil || from.IsZero() {
+			return filter, errors.New("invalid from date")
+		}
+		filter.From = from
+	}
+
+	if raw := c.QueryParam("to"); raw != "" {
+		to, err := types.ParseDateTime(raw)
+		if err != nil || to.IsZero() {
+			return filter, errors.New("invalid to date")
+		}
+		filter.To = to
+	}
+
+	for key, values := range c.QueryParams() {
+		matches := logDataParamRegex.FindStringSubmatch(key)
+		if len(matches) != 2 || len(values) == 0 {
+			continue
+		}
+		filter.Data[matches[1]] = values[0]
+	}
+
+	return filter, nil
+}
@@ -1,8 +1,10 @@
 package apis
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
@@ -16,15 +18,22 @@ import (
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/tracing"
 	"github.com/spf13/cast"
 )
 
 // Common request context keys used by the middlewares and api handlers.
 const (
-	ContextAdminKey      string = "admin"
-	ContextAuthRecordKey string = "authRecord"
-	ContextCollectionKey string = "collection"
-	ContextExecStartKey  string = "execStart"
+	ContextAdminKey        string = "admin"
+	ContextAuthRecordKey   string = "authRecord"
+	ContextCollectionKey   string = "collection"
+	ContextExecStartKey    string = "execStart"
+	ContextRefreshTokenKey string = "refreshToken"
+	ContextAuthSessionKey  string = "authSession"
+
+	// ContextAuthImpersonatedKey is set to true when the auth record
+	// is loaded from an admin issued impersonation token.
+	ContextAuthImpersonatedKey string = "authImpersonated"
 )
 
 // RequireGuestOnly middleware requires a request to NOT have a valid
@@ -235,7 +244,7 @@ func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 					token,
 					app.Settings().AdminAuthToken.Secret,
 				)
-				if err == nil && admin != nil {
+				if err == nil && admin != nil && checkAuthSession(app, c, claims, "", admin.Id) {
 					c.Set(ContextAdminKey, admin)
 				}
 			case tokens.TypeAuthRecord:
@@ -243,9 +252,69 @@ func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 					token,
 					app.Settings().RecordAuthToken.Secret,
 				)
-				if err == nil && record != nil {
+				if err == nil && record != nil && checkAuthSession(app, c, claims, record.Collection().Id, record.Id) {
 					c.Set(ContextAuthRecordKey, record)
+
+					if cast.ToBool(claims[tokens.ClaimImpersonated]) {
+						c.Set(ContextAuthImpersonatedKey, true)
+					}
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// LoadRefreshTokenContext middleware loads the auth state from
+// the "refreshToken" request body field (if any).
+//
+// If a collection is loaded in the context, the refresh token is expected
+// to be issued for an auth record from the same collection, otherwise
+// it is expected to be an admin refresh token.
+//
+// On success the token owner and the stored refresh token model
+// are loaded in the request context (the token is rotated later
+// by the auth response handlers).
+func LoadRefreshTokenContext(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token := cast.ToString(RequestInfo(c).Data["refreshToken"])
+			if token == "" {
+				return next(c)
+			}
+
+			invalidErr := NewUnauthorizedError("Invalid or expired refresh token.", nil)
+
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil {
+				model, err := tokens.FindAdminRefreshToken(app, token)
+				if err != nil {
+					return invalidErr
 				}
+
+				admin, err := app.Dao().FindAdminById(model.RecordId)
+				if err != nil {
+					return invalidErr
+				}
+
+				c.Set(ContextAuthRecordKey, nil)
+				c.Set(ContextAdminKey, admin)
+				c.Set(ContextRefreshTokenKey, model)
+			} else {
+				model, err := tokens.FindRecordRefreshToken(app, collection, token)
+				if err != nil {
+					return invalidErr
+				}
+
+				record, err := app.Dao().FindRecordById(collection.Id, model.RecordId)
+				if err != nil {
+					return invalidErr
+				}
+
+				c.Set(ContextAdminKey, nil)
+				c.Set(ContextAuthRecordKey, record)
+				c.Set(ContextRefreshTokenKey, model)
 			}
 
 			return next(c)
@@ -278,6 +347,28 @@ func LoadCollectionContext(app core.App, optCollectionTypes ...string) echo.Midd
 	}
 }
 
+// RequireCaptcha middleware requires a request to have a valid
+// captcha response token verified with the app captcha provider settings.
+//
+// The token is read from the "X-Captcha-Token" header, the "captchaToken"
+// body/query field or the provider widget default input name
+// (e.g. "cf-turnstile-response").
+//
+// Example:
+//
+//	e.Router.POST("/contact", handler, apis.RequireCaptcha(app))
+func RequireCaptcha(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := VerifyCaptcha(app, c); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // ActivityLogger middleware takes care to save the request information
 // into the logs database.
 //
@@ -298,8 +389,15 @@ func ActivityLogger(app core.App) echo.MiddlewareFunc {
 }
 
 func logRequest(app core.App, c echo.Context, err *ApiError) {
+	logsSettings := app.Settings().Logs
+
 	// no logs retention
-	if app.Settings().Logs.MaxDays == 0 {
+	if logsSettings.MaxDays == 0 {
+		return
+	}
+
+	// sample the successful requests
+	if err == nil && logsSettings.RequestsSampleRatio > 0 && rand.Float64() >= logsSettings.RequestsSampleRatio {
 		return
 	}
 
@@ -345,7 +443,11 @@ func logRequest(app core.App, c echo.Context, err *ApiError) {
 		slog.String("userAgent", httpRequest.UserAgent()),
 	)
 
-	if app.Settings().Logs.LogIp {
+	if span := tracing.SpanFromContext(httpRequest.Context()); span != nil {
+		attrs = append(attrs, slog.String("traceId", span.TraceId()))
+	}
+
+	if logsSettings.LogIp {
 		ip, _, _ := net.SplitHostPort(httpRequest.RemoteAddr)
 		attrs = append(
 			attrs,
@@ -354,6 +456,13 @@ func logRequest(app core.App, c echo.Context, err *ApiError) {
 		)
 	}
 
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+	} else if routeLevel, ok := logsSettings.RouteLevel(method, httpRequest.URL.Path); ok {
+		level = slog.Level(routeLevel)
+	}
+
 	// don't block on logs write
 	routine.FireAndForget(func() {
 		message := method + " "
@@ -364,11 +473,7 @@ func logRequest(app core.App, c echo.Context, err *ApiError) {
 			message += requestUri
 		}
 
-		if err != nil {
-			app.Logger().Error(message, attrs...)
-		} else {
-			app.Logger().Info(message, attrs...)
-		}
+		app.Logger().Log(context.Background(), level, message, attrs...)
 	})
 }
 
@@ -2,11 +2,13 @@ package apis_test
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/apis"
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 )
 
 func TestRequireGuestOnly(t *testing.T) {
@@ -882,6 +884,134 @@ func TestRequireAdminOrOwnerAuth(t *testing.T) {
 	}
 }
 
+func TestRequireCaptcha(t *testing.T) {
+	t.Parallel()
+
+	addRoute := func(t *testing.T, app *tests.TestApp, e *echo.Echo, enableCaptcha bool) {
+		if enableCaptcha {
+			app.Settings().Captcha.Enabled = true
+			app.Settings().Captcha.Provider = captcha.ProviderHCaptcha
+		}
+
+		e.AddRoute(echo.Route{
+			Method: http.MethodPost,
+			Path:   "/my/test",
+			Handler: func(c echo.Context) error {
+				return c.String(200, "test123")
+			},
+			Middlewares: []echo.MiddlewareFunc{
+				apis.RequireCaptcha(app),
+			},
+		})
+	}
+
+	expectVerified := func(total int) func(t *testing.T, app *tests.TestApp, res *http.Response) {
+		return func(t *testing.T, app *tests.TestApp, res *http.Response) {
+			if v := app.TestCaptchaVerifier.TotalVerified(); v != total {
+				t.Fatalf("Expected %d captcha verifications, got %d", total, v)
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "disabled captcha settings",
+			Method: http.MethodPost,
+			Url:    "/my/test",
+			RequestHeaders: map[string]string{
+				"X-Captcha-Token": tests.TestCaptchaValidToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addRoute(t, app, e, false)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			AfterTestFunc:   expectVerified(0),
+		},
+		{
+			Name:   "missing token",
+			Method: http.MethodPost,
+			Url:    "/my/test",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addRoute(t, app, e, true)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			AfterTestFunc:   expectVerified(1),
+		},
+		{
+			Name:   "invalid header token",
+			Method: http.MethodPost,
+			Url:    "/my/test",
+			RequestHeaders: map[string]string{
+				"X-Captcha-Token": "invalid",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addRoute(t, app, e, true)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			AfterTestFunc:   expectVerified(1),
+		},
+		{
+			Name:   "valid header token",
+			Method: http.MethodPost,
+			Url:    "/my/test",
+			RequestHeaders: map[string]string{
+				"X-Captcha-Token": tests.TestCaptchaValidToken,
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addRoute(t, app, e, true)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test123"},
+			AfterTestFunc:   expectVerified(1),
+		},
+		{
+			Name:   "valid body token",
+			Method: http.MethodPost,
+			Url:    "/my/test",
+			Body:   strings.NewReader(`{"captchaToken":"` + tests.TestCaptchaValidToken + `"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addRoute(t, app, e, true)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test123"},
+			AfterTestFunc:   expectVerified(1),
+		},
+		{
+			Name:   "valid query token",
+			Method: http.MethodPost,
+			Url:    "/my/test?captchaToken=" + tests.TestCaptchaValidToken,
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addRoute(t, app, e, true)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test123"},
+			AfterTestFunc:   expectVerified(1),
+		},
+		{
+			Name:   "valid provider widget token",
+			Method: http.MethodPost,
+			Url:    "/my/test",
+			Body:   strings.NewReader(`h-captcha-response=` + tests.TestCaptchaValidToken),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addRoute(t, app, e, true)
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test123"},
+			AfterTestFunc:   expectVerified(1),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
 func TestLoadCollectionContext(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,94 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/notifications"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindNotificationApi registers the auth record in-app notifications api endpoints.
+func bindNotificationApi(app core.App, rg *echo.Group) {
+	api := notificationApi{app: app}
+
+	subGroup := rg.Group("/notifications", ActivityLogger(app), RequireRecordAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("/read-all", api.markAllAsRead)
+	subGroup.POST("/:id/read", api.markAsRead)
+}
+
+type notificationApi struct {
+	app core.App
+}
+
+var notificationFilterFields = []string{
+	"id", "created", "updated",
+	"title", "body", "read", "data",
+	`^data\.[\w\.\:]*\w+$`,
+}
+
+func (api *notificationApi) list(c echo.Context) error {
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if authRecord == nil {
+		return NewUnauthorizedError("", nil)
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(notificationFilterFields...)
+
+	query := api.app.Dao().NotificationQuery().AndWhere(dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	})
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(query).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.Notification{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *notificationApi) markAsRead(c echo.Context) error {
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if authRecord == nil {
+		return NewUnauthorizedError("", nil)
+	}
+
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	notification, err := api.app.Dao().FindNotificationById(id)
+	if err != nil ||
+		notification.CollectionId != authRecord.Collection().Id ||
+		notification.RecordId != authRecord.Id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := notifications.MarkAsRead(api.app, notification); err != nil {
+		return NewBadRequestError("Failed to mark the notification as read.", err)
+	}
+
+	return c.JSON(http.StatusOK, notification)
+}
+
+func (api *notificationApi) markAllAsRead(c echo.Context) error {
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if authRecord == nil {
+		return NewUnauthorizedError("", nil)
+	}
+
+	if err := notifications.MarkAllAsRead(api.app, authRecord); err != nil {
+		return NewBadRequestError("Failed to mark the notifications as read.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,208 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/notifications"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/transport"
+)
+
+const (
+	// pingerDefaultTimeout is the default pinger request timeout in seconds.
+	pingerDefaultTimeout = 30
+
+	// pingerMaxResponseSize is the max pinger response body size
+	// that is read (and discarded) before closing the connection.
+	pingerMaxResponseSize = 1 << 20
+)
+
+// bindPingerApi registers the pingers api endpoints
+// and the pingers schedule serve hooks.
+func bindPingerApi(app core.App, rg *echo.Group) {
+	api := pingerApi{app: app}
+
+	subGroup := rg.Group("/pingers", ActivityLogger(app), RequireAdminAuth())
+	subGroup.POST("/:name/run", api.run)
+
+	bindPingers(app)
+}
+
+type pingerApi struct {
+	app core.App
+}
+
+func (api *pingerApi) run(c echo.Context) error {
+	config := api.app.Settings().FindPinger(c.PathParam("name"))
+	if config == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	status, err := RunPinger(c.Request().Context(), *config)
+	if err != nil {
+		return NewBadRequestError("Failed to run the pinger.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"status": status})
+}
+
+// RunPinger sends the HTTP request of the provided pinger config
+// and returns the response status code.
+//
+// An error is returned if the request fails or the response status
+// doesn't match the pinger expected status (or 2xx if not set).
+func RunPinger(ctx context.Context, config settings.PingerConfig) (int, error) {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = pingerDefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	method := config.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if config.Body != "" {
+		body = strings.NewReader(config.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, config.Url, body)
+	if err != nil {
+		return 0, err
+	}
+
+	for k, v := range config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := (&http.Client{Transport: transport.Default}).Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// drain the body to allow the connection reuse
+	io.Copy(io.Discard, io.LimitReader(res.Body, pingerMaxResponseSize))
+
+	if config.ExpectedStatus > 0 {
+		if res.StatusCode != config.ExpectedStatus {
+			return res.StatusCode, fmt.Errorf("unexpected response status %d (expected %d)", res.StatusCode, config.ExpectedStatus)
+		}
+	} else if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// bindPingers registers the app serve hooks that schedule
+// the enabled settings pingers.
+func bindPingers(app core.App) {
+	c := cron.New()
+	c.SetLocker(app)
+	app.Store().Set(core.StoreKeyCronPrefix+"pingers", c)
+	isServe := false
+
+	loadJobs := func() {
+		c.Stop()
+		c.RemoveAll()
+
+		if !isServe {
+			return
+		}
+
+		for _, config := range app.Settings().Pingers {
+			if config.Disabled {
+				continue
+			}
+
+			config := config
+
+			err := c.Add("@pinger."+config.Name, config.Cron, func() {
+				runScheduledPinger(app, config)
+			})
+			if err != nil {
+				app.Logger().Debug(
+					"[Pingers] Failed to schedule the pinger",
+					slog.String("name", config.Name),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		if c.Total() > 0 {
+			c.Start()
+		}
+	}
+
+	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		isServe = true
+		loadJobs()
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		c.Stop()
+		c.Wait()
+		return nil
+	})
+
+	// reload on app settings change
+	app.OnModelAfterUpdate((&models.Param{}).TableName()).Add(func(e *core.ModelEvent) error {
+		p, _ := e.Model.(*models.Param)
+		if p == nil || p.Key != models.ParamAppSettings {
+			return nil
+		}
+
+		loadJobs()
+
+		return nil
+	})
+}
+
+// runScheduledPinger runs the provided pinger and raises
+// an admin notification on failure.
+func runScheduledPinger(app core.App, config settings.PingerConfig) {
+	status, err := RunPinger(context.Background(), config)
+	if err == nil {
+		return
+	}
+
+	app.Logger().Debug(
+		"[Pingers] Failed to run the pinger",
+		slog.String("name", config.Name),
+		slog.Int("status", status),
+		slog.String("error", err.Error()),
+	)
+
+	// repeated failures are grouped in a single notification
+	_, notifyErr := notifications.NotifyAdmins(
+		app,
+		models.AdminNotificationSeverityWarning,
+		"pingers",
+		fmt.Sprintf("Pinger %q failed", config.Name),
+		err.Error(),
+		map[string]any{"name": config.Name, "status": status},
+	)
+	if notifyErr != nil {
+		app.Logger().Debug(
+			"[Pingers] Failed to raise admin notification",
+			slog.String("name", config.Name),
+			slog.String("error", notifyErr.Error()),
+		)
+	}
+}
@@ -0,0 +1,34 @@
+package apis
+
+import (
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/preflight"
+)
+
+// Supported [ServeConfig.Preflight] modes.
+const (
+	// PreflightStrict prints the preflight issues and refuses to start the server.
+	PreflightStrict string = "strict"
+
+	// PreflightWarn only prints the preflight issues.
+	PreflightWarn string = "warn"
+
+	// PreflightOff skips the preflight checks.
+	PreflightOff string = "off"
+)
+
+// RunPreflight triggers the app OnPreflight hook and returns
+// the consolidated report with the issues found by the
+// registered checks (eg. JS hooks compile errors).
+func RunPreflight(app core.App) (*preflight.Report, error) {
+	event := &core.PreflightEvent{
+		App:    app,
+		Report: &preflight.Report{},
+	}
+
+	if err := app.OnPreflight().Trigger(event); err != nil {
+		return event.Report, err
+	}
+
+	return event.Report, nil
+}
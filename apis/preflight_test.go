@@ -0,0 +1,96 @@
+package apis_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRunPreflight(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	report, err := apis.RunPreflight(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.HasIssues() {
+		t.Fatalf("Expected no issues, got %v", report.Issues())
+	}
+
+	app.OnPreflight().Add(func(e *core.PreflightEvent) error {
+		e.Report.Add("test1", "a.pb.js", errors.New("error1"))
+		e.Report.Add("test2", "", errors.New("error2"))
+		return nil
+	})
+
+	report, err = apis.RunPreflight(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues := report.Issues()
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %v", issues)
+	}
+
+	if v := issues[0].String(); v != "[test1] a.pb.js: error1" {
+		t.Fatalf("Unexpected first issue %q", v)
+	}
+
+	if v := issues[1].String(); v != "[test2] error2" {
+		t.Fatalf("Unexpected second issue %q", v)
+	}
+
+	if total := app.EventCalls["OnPreflight"]; total != 2 {
+		t.Fatalf("Expected OnPreflight to be called 2 times, got %d", total)
+	}
+}
+
+func TestRunPreflightHookError(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.OnPreflight().Add(func(e *core.PreflightEvent) error {
+		return errors.New("test")
+	})
+
+	if _, err := apis.RunPreflight(app); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestServeStrictPreflight(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.OnPreflight().Add(func(e *core.PreflightEvent) error {
+		e.Report.Add("test", "broken.pb.js", errors.New("SyntaxError"))
+		return nil
+	})
+
+	// the server should refuse to start before listening
+	server, err := apis.Serve(app, apis.ServeConfig{
+		HttpAddr:  "127.0.0.1:0",
+		Preflight: apis.PreflightStrict,
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "preflight checks failed") {
+		t.Fatalf("Expected preflight error, got %v", err)
+	}
+
+	if server != nil {
+		t.Fatal("Expected nil server")
+	}
+}
@@ -0,0 +1,167 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tokens"
+)
+
+// Headers with the current request auth state that are set by the
+// proxy to the upstream requests (any client provided value is removed).
+const (
+	ProxyAuthTypeHeader       = "X-Pb-Auth-Type"
+	ProxyAuthIdHeader         = "X-Pb-Auth-Id"
+	ProxyAuthCollectionHeader = "X-Pb-Auth-Collection"
+)
+
+// RouteProxies middleware forwards the requests matching one of the
+// reverse proxy routes configured in the app routes settings
+// (see [settings.RoutesConfig.FindProxy]) to their upstream service.
+//
+// It is registered by default as pre middleware (see [InitApi]),
+// so that the proxied paths take precedence over the script and core routes.
+func RouteProxies(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Routes.FindProxy(c.Request().URL.Path)
+			if config == nil {
+				return next(c) // not a proxied path
+			}
+
+			handler, err := ProxyHandler(*config)
+			if err != nil {
+				return NewApiError(http.StatusBadGateway, "Invalid proxy configuration.", err)
+			}
+
+			if config.Auth != "" {
+				handler = routeAuthMiddleware(config.Auth, config.AuthCollections)(handler)
+			}
+
+			return handler(c)
+		}
+	}
+}
+
+// Proxy returns a route handler that forwards the request to the target
+// upstream url by replacing the path prefix with the target url path.
+//
+// Example:
+//
+//	// "/svc/hello?a=1" -> "http://127.0.0.1:3000/v1/hello?a=1"
+//	handler, err := apis.Proxy("/svc", "http://127.0.0.1:3000/v1")
+//	if err != nil {
+//		return err
+//	}
+//	e.Router.Any("/svc/*", handler)
+func Proxy(prefix string, target string) (echo.HandlerFunc, error) {
+	return ProxyHandler(settings.RouteProxyConfig{Prefix: prefix, Target: target})
+}
+
+// ProxyHandler returns a route handler that forwards the request to the
+// upstream service of the specified reverse proxy route config.
+//
+// The websocket upgrade requests are forwarded as well.
+//
+// Note that the config auth requirement is not enforced by the handler
+// (see [RouteProxies]).
+func ProxyHandler(config settings.RouteProxyConfig) (echo.HandlerFunc, error) {
+	target, err := url.Parse(config.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	if (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, errors.New("the proxy target must be a valid http(s) url")
+	}
+
+	prefix := strings.TrimSuffix(config.Prefix, "/")
+	escapedPrefix := (&url.URL{Path: prefix}).EscapedPath()
+
+	return func(c echo.Context) error {
+		var proxyErr error
+
+		proxy := &httputil.ReverseProxy{
+			Rewrite: func(pr *httputil.ProxyRequest) {
+				// replace the prefix with the target path
+				pr.Out.URL.Path = strings.TrimPrefix(pr.In.URL.Path, prefix)
+				pr.Out.URL.RawPath = ""
+				if pr.In.URL.RawPath != "" {
+					pr.Out.URL.RawPath = strings.TrimPrefix(pr.In.URL.RawPath, escapedPrefix)
+				}
+				pr.SetURL(target)
+				pr.SetXForwarded()
+
+				if config.PreserveHost {
+					pr.Out.Host = pr.In.Host
+				}
+
+				setProxyAuthHeaders(c, pr.Out.Header, config.ForwardAuthorization)
+
+				for name, value := range config.RequestHeaders {
+					if value == "" {
+						pr.Out.Header.Del(name)
+					} else {
+						pr.Out.Header.Set(name, value)
+					}
+				}
+			},
+			ModifyResponse: func(res *http.Response) error {
+				for name, value := range config.ResponseHeaders {
+					if value == "" {
+						res.Header.Del(name)
+					} else {
+						res.Header.Set(name, value)
+					}
+				}
+
+				return nil
+			},
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				proxyErr = err
+			},
+		}
+
+		proxy.ServeHTTP(c.Response(), c.Request())
+
+		if proxyErr != nil && !c.Response().Committed {
+			return NewApiError(http.StatusBadGateway, "Failed to reach the upstream service.", proxyErr)
+		}
+
+		return nil
+	}, nil
+}
+
+// setProxyAuthHeaders replaces the proxy auth headers
+// with the ones of the current request auth state.
+//
+// The client Authorization header is also removed,
+// unless forwardAuthorization is set.
+func setProxyAuthHeaders(c echo.Context, header http.Header, forwardAuthorization bool) {
+	if !forwardAuthorization {
+		header.Del("Authorization")
+	}
+
+	header.Del(ProxyAuthTypeHeader)
+	header.Del(ProxyAuthIdHeader)
+	header.Del(ProxyAuthCollectionHeader)
+
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		header.Set(ProxyAuthTypeHeader, tokens.TypeAdmin)
+		header.Set(ProxyAuthIdHeader, admin.Id)
+		return
+	}
+
+	if record, _ := c.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+		header.Set(ProxyAuthTypeHeader, tokens.TypeAuthRecord)
+		header.Set(ProxyAuthIdHeader, record.Id)
+		header.Set(ProxyAuthCollectionHeader, record.Collection().Name)
+	}
+}
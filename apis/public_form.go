@@ -0,0 +1,153 @@
+package apis
+
+import (
+	"net/http"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+)
+
+// bindPublicFormApi registers the collections public form submissions api endpoint.
+func bindPublicFormApi(app core.App, rg *echo.Group) {
+	api := publicFormApi{app: app, limiter: ratelimit.New()}
+
+	rg.POST("/forms/:collection", api.submit, ActivityLogger(app))
+}
+
+type publicFormApi struct {
+	app     core.App
+	limiter *ratelimit.Limiter
+}
+
+func (api *publicFormApi) submit(c echo.Context) error {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(c.PathParam("collection"))
+	if err != nil || collection == nil {
+		return NewNotFoundError("", err)
+	}
+
+	options := collection.PublicForm()
+	if options == nil {
+		return NewNotFoundError("", "The collection doesn't accept public form submissions.")
+	}
+
+	if options.MaxRequests > 0 {
+		key := collection.Id + "@" + c.RealIP()
+		if !api.limiter.Allow(key, options.MaxRequests, time.Duration(options.Duration)*time.Second) {
+			return NewApiError(http.StatusTooManyRequests, "Too many requests.", nil)
+		}
+	}
+
+	data := RequestInfo(c).Data
+
+	// silently discard the submission to avoid revealing the honeypot to the bots
+	if options.Honeypot != "" && !validation.IsEmpty(data[options.Honeypot]) {
+		return api.respond(c, options)
+	}
+
+	if options.RequireCaptcha {
+		if err := VerifyCaptcha(api.app, c); err != nil {
+			return err
+		}
+	}
+
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(api.app, record)
+
+	// load only the allowlisted fields
+	allowedData := make(map[string]any, len(options.Fields))
+	for _, name := range options.Fields {
+		if v, ok := data[name]; ok {
+			allowedData[name] = v
+		}
+	}
+	if err := form.LoadData(allowedData); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := api.loadFiles(c, form, collection, options); err != nil {
+		return NewBadRequestError("Failed to load the submitted files.", err)
+	}
+
+	event := new(core.RecordCreateEvent)
+	event.HttpContext = c
+	event.Collection = collection
+	event.Record = record
+	event.UploadedFiles = form.FilesToUpload()
+
+	return form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(m *models.Record) error {
+			event.Record = m
+
+			return api.app.OnRecordBeforeCreateRequest().Trigger(event, func(e *core.RecordCreateEvent) error {
+				if err := next(e.Record); err != nil {
+					return NewBadRequestError("Failed to submit the form.", err)
+				}
+
+				return api.app.OnRecordAfterCreateRequest().Trigger(event, func(e *core.RecordCreateEvent) error {
+					if e.HttpContext.Response().Committed {
+						return nil
+					}
+
+					return api.respond(e.HttpContext, options)
+				})
+			})
+		}
+	})
+}
+
+// respond writes the public form submission response
+// (the created record is never returned to the client).
+func (api *publicFormApi) respond(c echo.Context, options *models.CollectionPublicFormOptions) error {
+	if options.RedirectUrl != "" {
+		return c.Redirect(http.StatusSeeOther, options.RedirectUrl)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// loadFiles loads the allowlisted file fields uploads (if any).
+func (api *publicFormApi) loadFiles(
+	c echo.Context,
+	form *forms.RecordUpsert,
+	collection *models.Collection,
+	options *models.CollectionPublicFormOptions,
+) error {
+	multipartForm := c.Request().MultipartForm
+	if multipartForm == nil {
+		return nil
+	}
+
+	for _, name := range options.Fields {
+		field := collection.Schema.GetFieldByName(name)
+		if field == nil || field.Type != schema.FieldTypeFile {
+			continue
+		}
+
+		headers := multipartForm.File[name]
+		if len(headers) == 0 {
+			continue
+		}
+
+		files := make([]*filesystem.File, 0, len(headers))
+		for _, header := range headers {
+			file, err := filesystem.NewFileFromMultipart(header)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+
+		if err := form.AddFiles(name, files...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
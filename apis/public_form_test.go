@@ -0,0 +1,315 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/captcha"
+)
+
+func enablePublicForm(t *testing.T, app *tests.TestApp, collectionName string, options *models.CollectionPublicFormOptions) {
+	collection, err := app.Dao().FindCollectionByNameOrId(collectionName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Options["publicForm"] = options
+
+	if err := app.Dao().WithoutHooks().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	core.ReloadCachedCollections(app)
+}
+
+func TestPublicFormSubmit(t *testing.T) {
+	t.Parallel()
+
+	expectRecordsCount := func(expected int) func(t *testing.T, app *tests.TestApp, res *http.Response) {
+		return func(t *testing.T, app *tests.TestApp, res *http.Response) {
+			records, err := app.Dao().FindRecordsByFilter("demo2", "title = 'new'", "", 0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(records) != expected {
+				t.Fatalf("Expected %d submitted records, got %d", expected, len(records))
+			}
+
+			// the non-allowlisted fields must be ignored
+			for _, r := range records {
+				if r.GetBool("active") {
+					t.Fatalf("Expected the non-allowlisted active field to be ignored")
+				}
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "missing collection",
+			Method:          http.MethodPost,
+			Url:             "/api/forms/missing",
+			Body:            strings.NewReader(`{"title":"new"}`),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "collection without public form",
+			Method:          http.MethodPost,
+			Url:             "/api/forms/demo2",
+			Body:            strings.NewReader(`{"title":"new"}`),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "disabled public form",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":"new"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Fields: []string{"title"},
+				})
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "auth collection with public form options",
+			Method: http.MethodPost,
+			Url:    "/api/forms/users",
+			Body:   strings.NewReader(`{"name":"new"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "users", &models.CollectionPublicFormOptions{
+					Enabled: true,
+					Fields:  []string{"name"},
+				})
+			},
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "json submission with non-allowlisted field",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":"new","active":true}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled: true,
+					Fields:  []string{"title"},
+				})
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+			AfterTestFunc: expectRecordsCount(1),
+		},
+		{
+			Name:   "urlencoded submission with redirect url",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`title=new&active=true`),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled:     true,
+					Fields:      []string{"title"},
+					RedirectUrl: "https://example.com/thanks",
+				})
+			},
+			ExpectedStatus: 303,
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if location := res.Header.Get("Location"); location != "https://example.com/thanks" {
+					t.Fatalf("Expected redirect location, got %q", location)
+				}
+
+				expectRecordsCount(1)(t, app, res)
+			},
+		},
+		{
+			Name:   "invalid submitted data",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":""}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+				if err != nil {
+					t.Fatal(err)
+				}
+				collection.Schema.GetFieldByName("title").Required = true
+				if err := app.Dao().WithoutHooks().SaveCollection(collection); err != nil {
+					t.Fatal(err)
+				}
+
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled: true,
+					Fields:  []string{"title"},
+				})
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"data":{`,
+				`"title":{"code":"validation_required"`,
+			},
+		},
+		{
+			Name:   "filled honeypot",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":"new","website":"https://example.com"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled:  true,
+					Fields:   []string{"title"},
+					Honeypot: "website",
+				})
+			},
+			ExpectedStatus: 204,
+			AfterTestFunc:  expectRecordsCount(0),
+		},
+		{
+			Name:   "empty honeypot",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":"new","website":""}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled:  true,
+					Fields:   []string{"title"},
+					Honeypot: "website",
+				})
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+			AfterTestFunc: expectRecordsCount(1),
+		},
+		{
+			Name:   "required captcha without configured provider",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":"new","captchaToken":"` + tests.TestCaptchaValidToken + `"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled:        true,
+					Fields:         []string{"title"},
+					RequireCaptcha: true,
+				})
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			AfterTestFunc:   expectRecordsCount(0),
+		},
+		{
+			Name:   "required captcha with invalid token",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":"new","captchaToken":"invalid"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Captcha.Enabled = true
+				app.Settings().Captcha.Provider = captcha.ProviderTurnstile
+
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled:        true,
+					Fields:         []string{"title"},
+					RequireCaptcha: true,
+				})
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			AfterTestFunc:   expectRecordsCount(0),
+		},
+		{
+			Name:   "required captcha with valid provider widget token",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`title=new&cf-turnstile-response=` + tests.TestCaptchaValidToken),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Captcha.Enabled = true
+				app.Settings().Captcha.Provider = captcha.ProviderTurnstile
+
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled:        true,
+					Fields:         []string{"title"},
+					RequireCaptcha: true,
+				})
+			},
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if total := app.TestCaptchaVerifier.TotalVerified(); total != 1 {
+					t.Fatalf("Expected 1 captcha verification, got %d", total)
+				}
+
+				expectRecordsCount(1)(t, app, res)
+			},
+		},
+		{
+			Name:   "rate limited submission",
+			Method: http.MethodPost,
+			Url:    "/api/forms/demo2",
+			Body:   strings.NewReader(`{"title":"new"}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enablePublicForm(t, app, "demo2", &models.CollectionPublicFormOptions{
+					Enabled:     true,
+					Fields:      []string{"title"},
+					MaxRequests: 1,
+					Duration:    60,
+				})
+
+				// first submission
+				req := httptest.NewRequest(http.MethodPost, "/api/forms/demo2", strings.NewReader(`{"title":"new"}`))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+				if rec.Code != 204 {
+					t.Fatalf("Expected the first submission to succeed, got %d", rec.Code)
+				}
+			},
+			ExpectedStatus:  429,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeCreateRequest": 1,
+				"OnRecordAfterCreateRequest":  1,
+				"OnModelBeforeCreate":         1,
+				"OnModelAfterCreate":          1,
+			},
+			AfterTestFunc: expectRecordsCount(1),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,81 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/quarantine"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindQuarantineApi registers the quarantined record files review api endpoints.
+func bindQuarantineApi(app core.App, rg *echo.Group) {
+	api := quarantineApi{app: app}
+
+	subGroup := rg.Group("/quarantine", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.GET("/:id", api.view)
+	subGroup.POST("/:id/release", api.release)
+	subGroup.DELETE("/:id", api.purge)
+}
+
+type quarantineApi struct {
+	app core.App
+}
+
+var quarantineFilterFields = []string{
+	"id", "created", "updated",
+	"collectionId", "recordId", "field",
+	"name", "originalName", "size", "reason",
+}
+
+func (api *quarantineApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(quarantineFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().QuarantinedFileQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.QuarantinedFile{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *quarantineApi) view(c echo.Context) error {
+	file, err := api.app.Dao().FindQuarantinedFileById(c.PathParam("id"))
+	if err != nil || file == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, file)
+}
+
+func (api *quarantineApi) release(c echo.Context) error {
+	file, err := api.app.Dao().FindQuarantinedFileById(c.PathParam("id"))
+	if err != nil || file == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := quarantine.Release(api.app, file); err != nil {
+		return NewBadRequestError("Failed to release the quarantined file.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *quarantineApi) purge(c echo.Context) error {
+	file, err := api.app.Dao().FindQuarantinedFileById(c.PathParam("id"))
+	if err != nil || file == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := quarantine.Purge(api.app, file); err != nil {
+		return NewBadRequestError("Failed to purge the quarantined file.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -9,41 +9,77 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ganigeorgiev/fexpr"
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/resolvers"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
 // bindRealtimeApi registers the realtime api endpoints.
 func bindRealtimeApi(app core.App, rg *echo.Group) {
-	api := realtimeApi{app: app}
+	api := realtimeApi{
+		app:           app,
+		nodeId:        security.RandomString(15),
+		schemaVersion: &realtimeSchemaVersion{},
+	}
 
 	subGroup := rg.Group("/realtime")
 	subGroup.GET("", api.connect)
 	subGroup.POST("", api.setSubscriptions, ActivityLogger(app))
+	subGroup.GET("/clients", api.listClients, RequireAdminAuth())
+	subGroup.GET("/clients/:id", api.viewClient, RequireAdminAuth())
+	subGroup.DELETE("/clients/:id", api.disconnectClient, RequireAdminAuth())
 
 	api.bindEvents()
+	api.bindSchemaEvents()
+
+	app.SubscriptionsBroker().OnClusterMessage(api.handleClusterEvent)
 }
 
 type realtimeApi struct {
 	app core.App
+
+	// nodeId identifies the current app instance in the realtime cluster.
+	nodeId string
+
+	schemaVersion *realtimeSchemaVersion
 }
 
+// realtimeNotificationsTopic is the realtime subscription topic for
+// receiving the in-app notifications of the current auth record.
+const realtimeNotificationsTopic = "@notifications"
+
+// realtimeAllRecordsTopic is the wildcard realtime subscription topic
+// for receiving the records changes of all collections
+// (each collection records are checked against its list rule).
+const realtimeAllRecordsTopic = "*"
+
 func (api *realtimeApi) connect(c echo.Context) error {
 	cancelCtx, cancelRequest := context.WithCancel(c.Request().Context())
 	defer cancelRequest()
 	c.SetRequest(c.Request().Clone(cancelCtx))
 
+	if err := checkRealtimeIpLimit(api.app, c.RealIP()); err != nil {
+		return err
+	}
+
 	// register new subscription client
 	client := subscriptions.NewDefaultClient()
+	client.Set(realtimeClientConnectedKey, types.NowDateTime())
+	client.Set(realtimeClientRemoteIpKey, c.RealIP())
+	client.Set(realtimeClientUserAgentKey, c.Request().UserAgent())
+	client.Set(realtimeClientCancelKey, cancelRequest)
 	api.app.SubscriptionsBroker().Register(client)
 	defer func() {
 		disconnectEvent := &core.RealtimeDisconnectEvent{
@@ -86,7 +122,7 @@ func (api *realtimeApi) connect(c echo.Context) error {
 		Client:      client,
 		Message: &subscriptions.Message{
 			Name: "PB_CONNECT",
-			Data: []byte(`{"clientId":"` + client.Id() + `"}`),
+			Data: []byte(`{"clientId":"` + client.Id() + `","schemaVersion":"` + api.schemaVersion.get(api.app.Dao()) + `"}`),
 		},
 	}
 	connectMsgErr := api.app.OnRealtimeBeforeMessageSend().Trigger(connectMsgEvent, func(e *core.RealtimeMessageEvent) error {
@@ -191,6 +227,18 @@ func (api *realtimeApi) setSubscriptions(c echo.Context) error {
 		return NewForbiddenError("The current and the previous request authorization don't match.", nil)
 	}
 
+	if authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record); authRecord != nil && oldAuthId == "" {
+		if err := checkRealtimeAuthRecordLimit(api.app, authRecord, client.Id()); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range form.Subscriptions {
+		if err := api.checkSubscriptionFilter(c, sub); err != nil {
+			return err
+		}
+	}
+
 	event := &core.RealtimeSubscribeEvent{
 		HttpContext:   c,
 		Client:        client,
@@ -224,6 +272,65 @@ func (api *realtimeApi) setSubscriptions(c echo.Context) error {
 	})
 }
 
+// checkSubscriptionFilter validates the server-side filter
+// of a single records subscription (eg. "posts/*?filter=org='X'")
+// against the subscribed collection fields and access rule.
+//
+// Subscriptions without filter and custom (non-collection) topics are not checked.
+func (api *realtimeApi) checkSubscriptionFilter(c echo.Context, sub string) error {
+	topic, options := subscriptions.ParseSubscription(sub)
+
+	filter := cast.ToString(options.Query[search.FilterQueryParam])
+	if filter == "" {
+		return nil
+	}
+
+	requestInfo := &models.RequestInfo{
+		Context: models.RequestInfoContextRealtime,
+		Method:  "GET",
+		Query:   options.Query,
+		Headers: options.Headers,
+	}
+	requestInfo.Admin, _ = c.Get(ContextAdminKey).(*models.Admin)
+	requestInfo.AuthRecord, _ = c.Get(ContextAuthRecordKey).(*models.Record)
+
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return err
+	}
+
+	// the wildcard filter fields are resolved separately for each collection
+	if topic == realtimeAllRecordsTopic {
+		if _, err := fexpr.Parse(filter); err != nil {
+			return NewBadRequestError("Invalid "+sub+" subscription filter.", err)
+		}
+		return nil
+	}
+
+	collectionNameOrId, recordId, _ := strings.Cut(topic, "/")
+
+	collection, err := api.app.Dao().FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil {
+		return nil // not a collection topic
+	}
+
+	rule := collection.ListRule
+	if recordId != "" && recordId != "*" {
+		rule = collection.ViewRule
+	}
+
+	if requestInfo.Admin == nil && rule == nil {
+		return NewForbiddenError("Only admins can subscribe to "+sub+".", nil)
+	}
+
+	resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, false)
+
+	if _, err := search.FilterData(filter).BuildExpr(resolver); err != nil {
+		return NewBadRequestError("Invalid "+sub+" subscription filter.", err)
+	}
+
+	return nil
+}
+
 // updateClientsAuthModel updates the existing clients auth model with the new one (matched by ID).
 func (api *realtimeApi) updateClientsAuthModel(contextKey string, newModel models.Model) error {
 	for _, client := range api.app.SubscriptionsBroker().Clients() {
@@ -256,10 +363,12 @@ func (api *realtimeApi) bindEvents() {
 	// update the clients that has admin or auth record association
 	api.app.OnModelAfterUpdate().PreAdd(func(e *core.ModelEvent) error {
 		if record := api.resolveRecord(e.Model); record != nil && record.Collection().IsAuth() {
+			api.publishRecordClusterEvent(realtimeClusterAuthUpdate, "", false, record)
 			return api.updateClientsAuthModel(ContextAuthRecordKey, record)
 		}
 
 		if admin, ok := e.Model.(*models.Admin); ok && admin != nil {
+			api.publishClusterEvent(&realtimeClusterEvent{Type: realtimeClusterAuthUpdate, ContextKey: ContextAdminKey}, admin)
 			return api.updateClientsAuthModel(ContextAdminKey, admin)
 		}
 
@@ -269,16 +378,50 @@ func (api *realtimeApi) bindEvents() {
 	// remove the client(s) associated to the deleted admin or auth record
 	api.app.OnModelAfterDelete().PreAdd(func(e *core.ModelEvent) error {
 		if collection := api.resolveRecordCollection(e.Model); collection != nil && collection.IsAuth() {
+			api.publishClusterEvent(
+				&realtimeClusterEvent{
+					Type:         realtimeClusterAuthDelete,
+					ContextKey:   ContextAuthRecordKey,
+					CollectionId: collection.Id,
+				},
+				map[string]any{schema.FieldNameId: e.Model.GetId()},
+			)
 			return api.unregisterClientsByAuthModel(ContextAuthRecordKey, e.Model)
 		}
 
 		if admin, ok := e.Model.(*models.Admin); ok && admin != nil {
+			api.publishClusterEvent(&realtimeClusterEvent{Type: realtimeClusterAuthDelete, ContextKey: ContextAdminKey}, admin)
 			return api.unregisterClientsByAuthModel(ContextAdminKey, admin)
 		}
 
 		return nil
 	})
 
+	// fan-out the in-app notification changes to the recipient clients
+	api.app.OnModelAfterCreate().PreAdd(func(e *core.ModelEvent) error {
+		if notification, ok := e.Model.(*models.Notification); ok && notification != nil {
+			api.broadcastNotification("create", notification)
+			api.publishClusterEvent(&realtimeClusterEvent{Type: realtimeClusterNotification, Action: "create"}, notification)
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterUpdate().PreAdd(func(e *core.ModelEvent) error {
+		if notification, ok := e.Model.(*models.Notification); ok && notification != nil {
+			api.broadcastNotification("update", notification)
+			api.publishClusterEvent(&realtimeClusterEvent{Type: realtimeClusterNotification, Action: "update"}, notification)
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterDelete().PreAdd(func(e *core.ModelEvent) error {
+		if notification, ok := e.Model.(*models.Notification); ok && notification != nil {
+			api.broadcastNotification("delete", notification)
+			api.publishClusterEvent(&realtimeClusterEvent{Type: realtimeClusterNotification, Action: "delete"}, notification)
+		}
+		return nil
+	})
+
 	api.app.OnModelAfterCreate().PreAdd(func(e *core.ModelEvent) error {
 		if record := api.resolveRecord(e.Model); record != nil {
 			if err := api.broadcastRecord("create", record, false); err != nil {
@@ -289,6 +432,8 @@ func (api *realtimeApi) bindEvents() {
 					slog.String("error", err.Error()),
 				)
 			}
+
+			api.publishRecordClusterEvent(realtimeClusterRecord, "create", false, record)
 		}
 		return nil
 	})
@@ -303,6 +448,8 @@ func (api *realtimeApi) bindEvents() {
 					slog.String("error", err.Error()),
 				)
 			}
+
+			api.publishRecordClusterEvent(realtimeClusterRecord, "update", false, record)
 		}
 		return nil
 	})
@@ -317,6 +464,8 @@ func (api *realtimeApi) bindEvents() {
 					slog.String("error", err.Error()),
 				)
 			}
+
+			api.publishRecordClusterEvent(realtimeClusterRecord, "delete", true, record)
 		}
 		return nil
 	})
@@ -331,6 +480,8 @@ func (api *realtimeApi) bindEvents() {
 					slog.String("error", err.Error()),
 				)
 			}
+
+			api.publishRecordClusterEvent(realtimeClusterRecord, "delete", false, record)
 		}
 		return nil
 	})
@@ -387,6 +538,8 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record, dr
 		// @deprecated: the same      as the wildcard topic but kept for backward compatibility
 		(collection.Name + "?"): collection.ListRule,
 		(collection.Id + "?"):   collection.ListRule,
+		// all collections records
+		(realtimeAllRecordsTopic + "?"): collection.ListRule,
 	}
 
 	dryCacheKey := action + "/" + record.Id
@@ -417,7 +570,7 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record, dr
 				requestInfo.Admin, _ = client.Get(ContextAdminKey).(*models.Admin)
 				requestInfo.AuthRecord, _ = client.Get(ContextAuthRecordKey).(*models.Record)
 
-				if !api.canAccessRecord(cleanRecord, requestInfo, rule) {
+				if !canAccessTenantRecord(requestInfo, cleanRecord) || !api.canAccessRecord(cleanRecord, requestInfo, rule) {
 					continue
 				}
 
@@ -506,6 +659,56 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record, dr
 	return nil
 }
 
+// notificationData represents the broadcasted notification subscription message data.
+type notificationData struct {
+	Notification *models.Notification `json:"notification"`
+	Action       string               `json:"action"`
+}
+
+// broadcastNotification sends the notification change to all
+// recipient auth record clients subscribed to the notifications topic.
+func (api *realtimeApi) broadcastNotification(action string, notification *models.Notification) {
+	clients := api.app.SubscriptionsBroker().Clients()
+	if len(clients) == 0 {
+		return // no subscribers
+	}
+
+	dataBytes, err := json.Marshal(&notificationData{
+		Action:       action,
+		Notification: notification,
+	})
+	if err != nil {
+		api.app.Logger().Debug(
+			"[broadcastNotification] data marshal error",
+			slog.String("id", notification.Id),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	for _, client := range clients {
+		client := client
+
+		authRecord, _ := client.Get(ContextAuthRecordKey).(*models.Record)
+		if authRecord == nil ||
+			authRecord.Id != notification.RecordId ||
+			authRecord.Collection().Id != notification.CollectionId {
+			continue
+		}
+
+		for sub := range client.Subscriptions(realtimeNotificationsTopic + "?") {
+			msg := subscriptions.Message{
+				Name: sub,
+				Data: dataBytes,
+			}
+
+			routine.FireAndForget(func() {
+				client.Send(msg)
+			})
+		}
+	}
+}
+
 // broadcastDryCachedRecord broadcasts all cached record related messages.
 func (api *realtimeApi) broadcastDryCachedRecord(action string, record *models.Record) error {
 	key := action + "/" + record.Id
@@ -0,0 +1,160 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// realtime client store keys with the connection metadata
+const (
+	realtimeClientConnectedKey = "@realtimeConnected"
+	realtimeClientRemoteIpKey  = "@realtimeRemoteIp"
+	realtimeClientUserAgentKey = "@realtimeUserAgent"
+	realtimeClientCancelKey    = "@realtimeCancel"
+)
+
+// RealtimeClientInfo describes a single connected realtime client
+// (eg. for debugging why a client doesn't receive the expected events).
+type RealtimeClientInfo struct {
+	Id string `json:"id"`
+
+	// Subscriptions is the sorted list of the client subscriptions.
+	Subscriptions []string `json:"subscriptions"`
+
+	// AuthType is "admin", "authRecord" or empty string for guests.
+	AuthType string `json:"authType"`
+
+	AuthId             string `json:"authId"`
+	AuthCollectionName string `json:"authCollectionName"`
+
+	Connected types.DateTime `json:"connected"`
+	RemoteIp  string         `json:"remoteIp"`
+	UserAgent string         `json:"userAgent"`
+}
+
+// NewRealtimeClientInfo extracts the connection metadata of the provided realtime client.
+func NewRealtimeClientInfo(client subscriptions.Client) *RealtimeClientInfo {
+	info := &RealtimeClientInfo{
+		Id:            client.Id(),
+		Subscriptions: []string{},
+	}
+
+	for sub := range client.Subscriptions() {
+		info.Subscriptions = append(info.Subscriptions, sub)
+	}
+	sort.Strings(info.Subscriptions)
+
+	if admin, _ := client.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		info.AuthType = "admin"
+		info.AuthId = admin.Id
+	} else if record, _ := client.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+		info.AuthType = "authRecord"
+		info.AuthId = record.Id
+		info.AuthCollectionName = record.Collection().Name
+	}
+
+	info.Connected, _ = client.Get(realtimeClientConnectedKey).(types.DateTime)
+	info.RemoteIp, _ = client.Get(realtimeClientRemoteIpKey).(string)
+	info.UserAgent, _ = client.Get(realtimeClientUserAgentKey).(string)
+
+	return info
+}
+
+// RealtimeClients returns the metadata of all realtime clients
+// connected to the current app instance (sorted by their connect date).
+func RealtimeClients(app core.App) []*RealtimeClientInfo {
+	clients := app.SubscriptionsBroker().Clients()
+
+	result := make([]*RealtimeClientInfo, 0, len(clients))
+	for _, client := range clients {
+		result = append(result, NewRealtimeClientInfo(client))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Connected.Time().Equal(result[j].Connected.Time()) {
+			return result[i].Id < result[j].Id
+		}
+		return result[i].Connected.Time().Before(result[j].Connected.Time())
+	})
+
+	return result
+}
+
+// DisconnectRealtimeClient closes the connection of the specified
+// realtime client and removes it from the subscriptions broker.
+//
+// The client could reconnect as a new client.
+func DisconnectRealtimeClient(app core.App, clientId string) error {
+	client, err := app.SubscriptionsBroker().ClientById(clientId)
+	if err != nil {
+		return err
+	}
+
+	if cancel, ok := client.Get(realtimeClientCancelKey).(context.CancelFunc); ok {
+		cancel()
+	}
+
+	app.SubscriptionsBroker().Unregister(client.Id())
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+func (api *realtimeApi) listClients(c echo.Context) error {
+	authId := c.QueryParam("authId")
+	topic := c.QueryParam("topic")
+
+	result := []*RealtimeClientInfo{}
+
+	for _, info := range RealtimeClients(api.app) {
+		if authId != "" && info.AuthId != authId {
+			continue
+		}
+
+		if topic != "" && !hasRealtimeTopic(info.Subscriptions, topic) {
+			continue
+		}
+
+		result = append(result, info)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *realtimeApi) viewClient(c echo.Context) error {
+	client, err := api.app.SubscriptionsBroker().ClientById(c.PathParam("id"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, NewRealtimeClientInfo(client))
+}
+
+func (api *realtimeApi) disconnectClient(c echo.Context) error {
+	if err := DisconnectRealtimeClient(api.app, c.PathParam("id")); err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// hasRealtimeTopic checks whether any of the subscriptions
+// has the specified topic (ignoring the subscriptions options).
+func hasRealtimeTopic(subs []string, topic string) bool {
+	for _, sub := range subs {
+		if strings.HasPrefix(sub+"?", topic+"?") {
+			return true
+		}
+	}
+
+	return false
+}
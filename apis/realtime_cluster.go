@@ -0,0 +1,188 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// Realtime cluster event types.
+const (
+	realtimeClusterRecord       = "record"
+	realtimeClusterNotification = "notification"
+	realtimeClusterAuthUpdate   = "authUpdate"
+	realtimeClusterAuthDelete   = "authDelete"
+	realtimeClusterSchema       = "schema"
+)
+
+// realtimeClusterEvent is a realtime event exchanged between the cluster
+// nodes so that each node could broadcast it to its own subscription clients
+// (the access rules are checked by the receiving node against the shared db).
+type realtimeClusterEvent struct {
+	// Node is the id of the node that published the event.
+	Node string `json:"node"`
+
+	Type   string `json:"type"`
+	Action string `json:"action,omitempty"`
+
+	// DryCache marks a record delete event whose messages should be only
+	// cached until the following (non dry cache) delete event of the same record.
+	DryCache bool `json:"dryCache,omitempty"`
+
+	// ContextKey is the subscription client context key of the auth model events.
+	ContextKey string `json:"contextKey,omitempty"`
+
+	// CollectionId is the collection of the record events data.
+	CollectionId string `json:"collectionId,omitempty"`
+
+	// Data is the serialized record, admin, notification or collection model.
+	Data json.RawMessage `json:"data"`
+}
+
+// publishRecordClusterEvent sends the record event to the other cluster nodes (if any).
+func (api *realtimeApi) publishRecordClusterEvent(eventType string, action string, dryCache bool, record *models.Record) {
+	if api.app.SubscriptionsBroker().ClusterTransport() == nil {
+		return // not in a cluster
+	}
+
+	event := &realtimeClusterEvent{
+		Type:         eventType,
+		Action:       action,
+		DryCache:     dryCache,
+		CollectionId: record.Collection().Id,
+	}
+
+	if eventType == realtimeClusterAuthUpdate || eventType == realtimeClusterAuthDelete {
+		event.ContextKey = ContextAuthRecordKey
+	}
+
+	api.publishClusterEvent(event, record.ColumnValueMap())
+}
+
+// publishClusterEvent serializes the event data and sends the event
+// to the other cluster nodes (if any).
+func (api *realtimeApi) publishClusterEvent(event *realtimeClusterEvent, data any) {
+	broker := api.app.SubscriptionsBroker()
+	if broker.ClusterTransport() == nil {
+		return // not in a cluster
+	}
+
+	rawData, err := json.Marshal(data)
+	if err == nil {
+		event.Node = api.nodeId
+		event.Data = rawData
+
+		var payload []byte
+		payload, err = json.Marshal(event)
+		if err == nil {
+			err = broker.PublishToCluster(payload)
+		}
+	}
+
+	if err != nil {
+		api.app.Logger().Debug(
+			"Failed to publish realtime cluster event",
+			slog.String("type", event.Type),
+			slog.String("action", event.Action),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// handleClusterEvent broadcasts a realtime event published by another
+// cluster node to the subscription clients of the current node.
+func (api *realtimeApi) handleClusterEvent(payload []byte) {
+	event := &realtimeClusterEvent{}
+
+	err := json.Unmarshal(payload, event)
+	if err == nil && event.Node == api.nodeId {
+		return // our own event
+	}
+
+	if err == nil {
+		switch event.Type {
+		case realtimeClusterRecord:
+			var record *models.Record
+			record, err = api.clusterEventRecord(event)
+			if err != nil {
+				break
+			}
+
+			if event.Action == "delete" && !event.DryCache {
+				err = api.broadcastDryCachedRecord(event.Action, record)
+			} else {
+				err = api.broadcastRecord(event.Action, record, event.DryCache)
+			}
+		case realtimeClusterNotification:
+			notification := &models.Notification{}
+			if err = json.Unmarshal(event.Data, notification); err == nil {
+				api.broadcastNotification(event.Action, notification)
+			}
+		case realtimeClusterSchema:
+			collection := &models.Collection{}
+			if err = json.Unmarshal(event.Data, collection); err == nil {
+				api.broadcastSchemaChange(event.Action, collection, nil)
+			}
+		case realtimeClusterAuthUpdate, realtimeClusterAuthDelete:
+			var model models.Model
+			model, err = api.clusterEventAuthModel(event)
+			if err != nil {
+				break
+			}
+
+			if event.Type == realtimeClusterAuthUpdate {
+				err = api.updateClientsAuthModel(event.ContextKey, model)
+			} else {
+				err = api.unregisterClientsByAuthModel(event.ContextKey, model)
+			}
+		default:
+			err = errors.New("unknown event type")
+		}
+	}
+
+	if err != nil {
+		api.app.Logger().Debug(
+			"Failed to handle realtime cluster event",
+			slog.String("type", event.Type),
+			slog.String("action", event.Action),
+			slog.String("node", event.Node),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// clusterEventRecord loads the record model from the cluster event data.
+func (api *realtimeApi) clusterEventRecord(event *realtimeClusterEvent) (*models.Record, error) {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(event.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return nil, err
+	}
+
+	record := models.NewRecord(collection)
+	record.Load(data)
+
+	return record, nil
+}
+
+// clusterEventAuthModel loads the admin or auth record model from the cluster event data.
+func (api *realtimeApi) clusterEventAuthModel(event *realtimeClusterEvent) (models.Model, error) {
+	switch event.ContextKey {
+	case ContextAdminKey:
+		admin := &models.Admin{}
+		if err := json.Unmarshal(event.Data, admin); err != nil {
+			return nil, err
+		}
+		return admin, nil
+	case ContextAuthRecordKey:
+		return api.clusterEventRecord(event)
+	default:
+		return nil, errors.New("unknown auth model context key")
+	}
+}
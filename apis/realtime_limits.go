@@ -0,0 +1,76 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// checkRealtimeIpLimit applies the realtime connections limit
+// for the specified client IP (if configured).
+func checkRealtimeIpLimit(app core.App, ip string) error {
+	return applyRealtimeLimit(app, app.Settings().RealtimeLimits.MaxPerIp, "", func(info *RealtimeClientInfo) bool {
+		return info.RemoteIp == ip
+	})
+}
+
+// checkRealtimeAuthRecordLimit applies the realtime connections limit
+// for the specified auth record (if configured).
+//
+// The current client (if any) is excluded from the existing connections count.
+func checkRealtimeAuthRecordLimit(app core.App, record *models.Record, currentClientId string) error {
+	return applyRealtimeLimit(app, app.Settings().RealtimeLimits.MaxPerAuthRecord, currentClientId, func(info *RealtimeClientInfo) bool {
+		return info.AuthType == "authRecord" &&
+			info.AuthId == record.Id &&
+			info.AuthCollectionName == record.Collection().Name
+	})
+}
+
+// applyRealtimeLimit checks whether there is room for one more
+// connection among the existing clients matching the provided filter.
+//
+// If the limit is reached, depending on the configured policy,
+// either returns a 429 error or disconnects the oldest matching clients.
+//
+// Note that the limit is not strict, and concurrent connect
+// requests could slightly exceed it.
+func applyRealtimeLimit(app core.App, limit int, excludeClientId string, filter func(info *RealtimeClientInfo) bool) error {
+	if limit <= 0 {
+		return nil // no limit
+	}
+
+	// the clients are sorted by their connect date (oldest first)
+	matching := []*RealtimeClientInfo{}
+	for _, info := range RealtimeClients(app) {
+		if info.Id != excludeClientId && filter(info) {
+			matching = append(matching, info)
+		}
+	}
+
+	excess := len(matching) - limit + 1
+	if excess <= 0 {
+		return nil
+	}
+
+	if app.Settings().RealtimeLimits.Policy != settings.RealtimeLimitPolicyEvictOldest {
+		return NewApiError(http.StatusTooManyRequests, "Too many realtime connections.", nil)
+	}
+
+	for _, info := range matching[:excess] {
+		if err := DisconnectRealtimeClient(app, info.Id); err != nil {
+			app.Logger().Debug(
+				"Failed to evict realtime client",
+				slog.String("clientId", info.Id),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		app.Logger().Debug("Realtime client evicted (connections limit reached).", slog.String("clientId", info.Id))
+	}
+
+	return nil
+}
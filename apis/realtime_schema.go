@@ -0,0 +1,152 @@
+package apis
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+)
+
+// realtimeSchemaTopic is the realtime subscription topic for
+// receiving the collections schema and API rules changes.
+const realtimeSchemaTopic = "@schema"
+
+// schemaChangeData represents the broadcasted schema change message data.
+type schemaChangeData struct {
+	Action string `json:"action"`
+
+	// Collection is the changed collection (it is omitted for the non-admin
+	// clients if the collection is not accessible via the API, aka. all of its rules are nil).
+	Collection *schemaChangeCollection `json:"collection,omitempty"`
+
+	// Version is the new collections schema version (see [daos.Dao.CollectionsSchemaVersion]).
+	Version string `json:"version"`
+}
+
+type schemaChangeCollection struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// realtimeSchemaVersion caches the current collections schema version
+// that is sent with the PB_CONNECT message.
+type realtimeSchemaVersion struct {
+	mux     sync.RWMutex
+	version string
+}
+
+// get returns the cached schema version (loading it if missing).
+func (v *realtimeSchemaVersion) get(dao *daos.Dao) string {
+	v.mux.RLock()
+	version := v.version
+	v.mux.RUnlock()
+
+	if version != "" {
+		return version
+	}
+
+	return v.refresh(dao)
+}
+
+// refresh reloads and caches the schema version.
+func (v *realtimeSchemaVersion) refresh(dao *daos.Dao) string {
+	version, err := dao.CollectionsSchemaVersion()
+	if err != nil {
+		version = "" // retry on the next call
+	}
+
+	v.mux.Lock()
+	v.version = version
+	v.mux.Unlock()
+
+	return version
+}
+
+// bindSchemaEvents registers the collection model hooks that
+// broadcast the schema changes to the @schema topic subscribers.
+func (api *realtimeApi) bindSchemaEvents() {
+	handler := func(action string) func(e *core.ModelEvent) error {
+		return func(e *core.ModelEvent) error {
+			collection, ok := e.Model.(*models.Collection)
+			if !ok || collection == nil {
+				return nil
+			}
+
+			// note: use the event dao to load the version
+			// because the change could be part of a transaction
+			api.broadcastSchemaChange(action, collection, e.Dao)
+
+			api.publishClusterEvent(&realtimeClusterEvent{Type: realtimeClusterSchema, Action: action}, collection)
+
+			return nil
+		}
+	}
+
+	tableName := (&models.Collection{}).TableName()
+
+	api.app.OnModelAfterCreate(tableName).Add(handler("create"))
+	api.app.OnModelAfterUpdate(tableName).Add(handler("update"))
+	api.app.OnModelAfterDelete(tableName).Add(handler("delete"))
+}
+
+// broadcastSchemaChange sends the collection change and the new
+// schema version to all clients subscribed to the @schema topic.
+func (api *realtimeApi) broadcastSchemaChange(action string, collection *models.Collection, dao *daos.Dao) {
+	if dao == nil {
+		dao = api.app.Dao()
+	}
+
+	version := api.schemaVersion.refresh(dao)
+
+	clients := api.app.SubscriptionsBroker().Clients()
+	if len(clients) == 0 {
+		return // no subscribers
+	}
+
+	data := &schemaChangeData{Action: action, Version: version}
+
+	publicData, err := json.Marshal(data)
+	if err != nil {
+		api.app.Logger().Debug("[broadcastSchemaChange] data marshal error", slog.String("error", err.Error()))
+		return
+	}
+
+	data.Collection = &schemaChangeCollection{Id: collection.Id, Name: collection.Name}
+
+	fullData, err := json.Marshal(data)
+	if err != nil {
+		api.app.Logger().Debug("[broadcastSchemaChange] data marshal error", slog.String("error", err.Error()))
+		return
+	}
+
+	isApiCollection := collection.ListRule != nil ||
+		collection.ViewRule != nil ||
+		collection.CreateRule != nil ||
+		collection.UpdateRule != nil ||
+		collection.DeleteRule != nil
+
+	for _, client := range clients {
+		client := client
+
+		dataBytes := publicData
+		if admin, _ := client.Get(ContextAdminKey).(*models.Admin); admin != nil || isApiCollection {
+			dataBytes = fullData
+		}
+
+		for sub := range client.Subscriptions(realtimeSchemaTopic + "?") {
+			msg := subscriptions.Message{
+				Name: sub,
+				Data: dataBytes,
+			}
+
+			routine.FireAndForget(func() {
+				client.Send(msg)
+			})
+		}
+	}
+}
@@ -48,9 +48,12 @@ func bindRecordAuthApi(app core.App, rg *echo.Group) {
 	subGroup.GET("/auth-methods", api.authMethods)
 	subGroup.GET("/otp/get", api.optQrCode, RequireSameContextRecordAuth())
 	subGroup.GET("/otp/:otp", api.optVerification, RequireSameContextRecordAuth())
-	subGroup.POST("/auth-refresh", api.authRefresh, RequireSameContextRecordAuth())
+	subGroup.POST("/auth-refresh", api.authRefresh, LoadRefreshTokenContext(app), RequireSameContextRecordAuth())
 	subGroup.POST("/auth-with-oauth2", api.authWithOAuth2)
 	subGroup.POST("/auth-with-password", api.authWithPassword)
+	subGroup.POST("/auth-with-custom", api.authWithCustom)
+	subGroup.POST("/request-otp", api.requestOTP)
+	subGroup.POST("/confirm-otp", api.confirmOTP)
 	subGroup.POST("/request-password-reset", api.requestPasswordReset)
 	subGroup.POST("/confirm-password-reset", api.confirmPasswordReset)
 	subGroup.POST("/request-verification", api.requestVerification)
@@ -59,6 +62,13 @@ func bindRecordAuthApi(app core.App, rg *echo.Group) {
 	subGroup.POST("/confirm-email-change", api.confirmEmailChange)
 	subGroup.GET("/records/:id/external-auths", api.listExternalAuths, RequireAdminOrOwnerAuth("id"))
 	subGroup.DELETE("/records/:id/external-auths/:provider", api.unlinkExternalAuth, RequireAdminOrOwnerAuth("id"))
+	subGroup.GET("/records/:id/sessions", api.listSessions, RequireAdminOrOwnerAuth("id"))
+	subGroup.DELETE("/records/:id/sessions/:sessionId", api.deleteSession, RequireAdminOrOwnerAuth("id"))
+	subGroup.POST("/records/:id/revoke-sessions", api.revokeSessions, RequireAdminAuth())
+	subGroup.POST("/records/:id/impersonate", api.impersonate, RequireAdminAuth())
+	subGroup.GET("/records/:id/push-devices", api.listPushDevices, RequireAdminOrOwnerAuth("id"))
+	subGroup.POST("/records/:id/push-devices", api.registerPushDevice, RequireAdminOrOwnerAuth("id"))
+	subGroup.DELETE("/records/:id/push-devices/:deviceId", api.deletePushDevice, RequireAdminOrOwnerAuth("id"))
 }
 
 type recordAuthApi struct {
@@ -155,6 +165,10 @@ func (api *recordAuthApi) authRefresh(c echo.Context) error {
 		return NewNotFoundError("Missing auth record context.", nil)
 	}
 
+	if impersonated, _ := c.Get(ContextAuthImpersonatedKey).(bool); impersonated {
+		return NewForbiddenError("Impersonation tokens cannot be refreshed.", nil)
+	}
+
 	event := new(core.RecordAuthRefreshEvent)
 	event.HttpContext = c
 	event.Collection = record.Collection()
@@ -191,10 +205,12 @@ func (api *recordAuthApi) authMethods(c echo.Context) error {
 		AuthProviders    []providerInfo `json:"authProviders"`
 		UsernamePassword bool           `json:"usernamePassword"`
 		EmailPassword    bool           `json:"emailPassword"`
+		OTP              bool           `json:"otp"`
 		OnlyVerified     bool           `json:"onlyVerified"`
 	}{
 		UsernamePassword: authOptions.AllowUsernameAuth,
 		EmailPassword:    authOptions.AllowEmailAuth,
+		OTP:              authOptions.AllowOTPAuth && api.app.Settings().Sms.Enabled,
 		OnlyVerified:     authOptions.OnlyVerified,
 		AuthProviders:    []providerInfo{},
 	}
@@ -408,6 +424,143 @@ func (api *recordAuthApi) authWithPassword(c echo.Context) error {
 	return submitErr
 }
 
+func (api *recordAuthApi) authWithCustom(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	form := forms.NewRecordCustomLogin(api.app, collection)
+	if readErr := c.Bind(form); readErr != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", readErr)
+	}
+
+	event := new(core.RecordAuthWithCustomMethodEvent)
+	event.HttpContext = c
+	event.Collection = collection
+	event.Method = form.Method
+	event.Payload = form.Payload
+	if event.Payload == nil {
+		event.Payload = map[string]any{}
+	}
+
+	_, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(record *models.Record) error {
+			event.Record = record
+
+			return api.app.OnRecordAuthWithCustomMethod().Trigger(event, func(e *core.RecordAuthWithCustomMethodEvent) error {
+				if err := next(e.Record); err != nil {
+					return NewBadRequestError("Failed to authenticate.", err)
+				}
+
+				return RecordAuthResponse(api.app, e.HttpContext, e.Record, e.Meta)
+			})
+		}
+	})
+
+	return submitErr
+}
+
+func (api *recordAuthApi) requestOTP(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	if !collection.AuthOptions().AllowOTPAuth || !api.app.Settings().Sms.Enabled {
+		return NewBadRequestError("The collection is not configured to allow OTP authentication.", nil)
+	}
+
+	form := forms.NewRecordOTPRequest(api.app, collection)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the form.", err)
+	}
+
+	event := new(core.RecordRequestOTPEvent)
+	event.HttpContext = c
+	event.Collection = collection
+
+	_, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*forms.RecordOTPRequestData]) forms.InterceptorNextFunc[*forms.RecordOTPRequestData] {
+		return func(data *forms.RecordOTPRequestData) error {
+			event.Record = data.Record
+			event.OTP = data.OTP
+			event.Password = data.Password
+
+			return api.app.OnRecordBeforeRequestOTPRequest().Trigger(event, func(e *core.RecordRequestOTPEvent) error {
+				data.Password = e.Password
+
+				if err := next(data); err != nil {
+					return err
+				}
+
+				return api.app.OnRecordAfterRequestOTPRequest().Trigger(event, func(e *core.RecordRequestOTPEvent) error {
+					if e.HttpContext.Response().Committed {
+						return nil
+					}
+
+					return e.HttpContext.JSON(http.StatusOK, map[string]string{"otpId": e.OTP.Id})
+				})
+			})
+		}
+	})
+
+	if submitErr != nil {
+		api.app.Logger().Debug(
+			"Failed to send OTP",
+			slog.String("error", submitErr.Error()),
+		)
+	}
+
+	// skip submit errors and write a random otpId
+	// as a measure against phone numbers enumeration
+	if !c.Response().Committed {
+		return c.JSON(http.StatusOK, map[string]string{"otpId": security.RandomString(15)})
+	}
+
+	return nil
+}
+
+func (api *recordAuthApi) confirmOTP(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	form := forms.NewRecordOTPConfirm(api.app, collection)
+	if readErr := c.Bind(form); readErr != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", readErr)
+	}
+
+	event := new(core.RecordConfirmOTPEvent)
+	event.HttpContext = c
+	event.Collection = collection
+
+	_, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*forms.RecordOTPConfirmData]) forms.InterceptorNextFunc[*forms.RecordOTPConfirmData] {
+		return func(data *forms.RecordOTPConfirmData) error {
+			event.Record = data.Record
+			event.OTP = data.OTP
+
+			return api.app.OnRecordBeforeConfirmOTPRequest().Trigger(event, func(e *core.RecordConfirmOTPEvent) error {
+				data.Record = e.Record
+
+				if err := next(data); err != nil {
+					return NewBadRequestError("Failed to authenticate.", err)
+				}
+
+				return api.app.OnRecordAfterConfirmOTPRequest().Trigger(event, func(e *core.RecordConfirmOTPEvent) error {
+					return RecordAuthResponse(api.app, e.HttpContext, e.Record, nil)
+				})
+			})
+		}
+	})
+
+	return submitErr
+}
+
 func (api *recordAuthApi) requestPasswordReset(c echo.Context) error {
 	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
 	if collection == nil {
@@ -750,12 +903,156 @@ func (api *recordAuthApi) unlinkExternalAuth(c echo.Context) error {
 	})
 }
 
+func (api *recordAuthApi) listSessions(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, id)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	sessions, err := api.app.Dao().FindAllSessionsByOwner(collection.Id, record.Id)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+func (api *recordAuthApi) deleteSession(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	sessionId := c.PathParam("sessionId")
+	if id == "" || sessionId == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	session, err := api.app.Dao().FindSessionById(sessionId)
+	if err != nil || session.CollectionId != collection.Id || session.RecordId != id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := revokeSession(api.app, c, session); err != nil {
+		return NewBadRequestError("Failed to revoke the record session.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *recordAuthApi) revokeSessions(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, id)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := revokeAllSessions(api.app, c, collection.Id, record.Id); err != nil {
+		return NewBadRequestError("Failed to revoke the record sessions.", err)
+	}
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		if err := txDao.RevokeAllRefreshTokens(collection.Id, record.Id); err != nil {
+			return err
+		}
+
+		// invalidate also all previously issued record auth tokens
+		if err := record.RefreshTokenKey(); err != nil {
+			return err
+		}
+
+		return txDao.SaveRecord(record)
+	})
+	if txErr != nil {
+		return NewBadRequestError("Failed to revoke the record sessions.", txErr)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *recordAuthApi) impersonate(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		return NewUnauthorizedError("The request requires admin authorization token to be set.", nil)
+	}
+
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, id)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordImpersonate(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	token, submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to generate impersonation token.", submitErr)
+	}
+
+	duration := form.Duration
+	if duration == 0 {
+		duration = forms.DefaultImpersonationDuration
+	}
+
+	// audit trail entry
+	api.app.Logger().Info(
+		"Admin auth record impersonation",
+		slog.String("type", "impersonation"),
+		slog.String("adminId", admin.Id),
+		slog.String("collectionId", collection.Id),
+		slog.String("recordId", record.Id),
+		slog.Int64("duration", duration),
+		slog.String("ip", requestIp(c)),
+	)
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil {
+		api.app.Logger().Debug("Failed to enrich the impersonated record", slog.String("error", err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"token":  token,
+		"record": record,
+	})
+}
+
 // -------------------------------------------------------------------
 
 const (
 	oauth2SubscriptionTopic   string = "@oauth2"
-	oauth2RedirectFailurePath string = "../_/#/auth/oauth2-redirect-failure"
-	oauth2RedirectSuccessPath string = "../_/#/auth/oauth2-redirect-success"
+	oauth2RedirectFailurePath string = "#/auth/oauth2-redirect-failure"
+	oauth2RedirectSuccessPath string = "#/auth/oauth2-redirect-success"
 )
 
 type oauth2EventMessage struct {
@@ -765,16 +1062,18 @@ type oauth2EventMessage struct {
 }
 
 func (api *recordAuthApi) oauth2SubscriptionRedirect(c echo.Context) error {
+	adminPath := ".." + api.app.Settings().AdminUI.TrailedPath()
+
 	state := c.QueryParam("state")
 	if state == "" {
 		api.app.Logger().Debug("Missing OAuth2 state parameter")
-		return c.Redirect(http.StatusTemporaryRedirect, oauth2RedirectFailurePath)
+		return c.Redirect(http.StatusTemporaryRedirect, adminPath+oauth2RedirectFailurePath)
 	}
 
 	client, err := api.app.SubscriptionsBroker().ClientById(state)
 	if err != nil || client.IsDiscarded() || !client.HasSubscription(oauth2SubscriptionTopic) {
 		api.app.Logger().Debug("Missing or invalid OAuth2 subscription client", "error", err, "clientId", state)
-		return c.Redirect(http.StatusTemporaryRedirect, oauth2RedirectFailurePath)
+		return c.Redirect(http.StatusTemporaryRedirect, adminPath+oauth2RedirectFailurePath)
 	}
 	defer client.Unsubscribe(oauth2SubscriptionTopic)
 
@@ -787,7 +1086,7 @@ func (api *recordAuthApi) oauth2SubscriptionRedirect(c echo.Context) error {
 	encodedData, err := json.Marshal(data)
 	if err != nil {
 		api.app.Logger().Debug("Failed to marshalize OAuth2 redirect data", "error", err)
-		return c.Redirect(http.StatusTemporaryRedirect, oauth2RedirectFailurePath)
+		return c.Redirect(http.StatusTemporaryRedirect, adminPath+oauth2RedirectFailurePath)
 	}
 
 	msg := subscriptions.Message{
@@ -799,8 +1098,85 @@ func (api *recordAuthApi) oauth2SubscriptionRedirect(c echo.Context) error {
 
 	if data.Error != "" || data.Code == "" {
 		api.app.Logger().Debug("Failed OAuth2 redirect due to an error or missing code parameter", "error", data.Error, "clientId", data.State)
-		return c.Redirect(http.StatusTemporaryRedirect, oauth2RedirectFailurePath)
+		return c.Redirect(http.StatusTemporaryRedirect, adminPath+oauth2RedirectFailurePath)
+	}
+
+	return c.Redirect(http.StatusTemporaryRedirect, adminPath+oauth2RedirectSuccessPath)
+}
+
+func (api *recordAuthApi) listPushDevices(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, id)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	devices, err := api.app.Dao().FindAllPushDevicesByRecord(collection.Id, record.Id)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, devices)
+}
+
+func (api *recordAuthApi) registerPushDevice(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, id)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordPushDeviceRegister(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	device, submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to register the push device.", submitErr)
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
+
+func (api *recordAuthApi) deletePushDevice(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	deviceId := c.PathParam("deviceId")
+	if id == "" || deviceId == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	device, err := api.app.Dao().FindPushDeviceById(deviceId)
+	if err != nil || device.CollectionId != collection.Id || device.RecordId != id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeletePushDevice(device); err != nil {
+		return NewBadRequestError("Failed to delete the push device.", err)
 	}
 
-	return c.Redirect(http.StatusTemporaryRedirect, oauth2RedirectSuccessPath)
+	return c.NoContent(http.StatusNoContent)
 }
@@ -0,0 +1,333 @@
+package apis
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRecordsCacheApi registers the records cache api endpoints.
+func bindRecordsCacheApi(app core.App, rg *echo.Group) {
+	api := recordsCacheApi{app: app}
+
+	subGroup := rg.Group("/records-cache", RequireAdminAuth())
+	subGroup.GET("", api.stats)
+	subGroup.POST("/flush", api.flush)
+}
+
+type recordsCacheApi struct {
+	app core.App
+}
+
+// recordsCacheStatsItem is the api representation of a single collection cache stats.
+type recordsCacheStatsItem struct {
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	HitRatio float64 `json:"hitRatio"`
+}
+
+func (api *recordsCacheApi) stats(c echo.Context) error {
+	stats := map[string]recordsCacheStatsItem{}
+
+	for name, item := range api.app.RecordsCache().Stats().Snapshot() {
+		stats[name] = recordsCacheStatsItem{
+			Hits:     item.Hits,
+			Misses:   item.Misses,
+			HitRatio: item.HitRatio(),
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"enabled": api.app.RecordsCache().Enabled(),
+		"stats":   stats,
+	})
+}
+
+func (api *recordsCacheApi) flush(c echo.Context) error {
+	if err := api.app.RecordsCache().Invalidate(c.Request().Context()); err != nil {
+		return NewBadRequestError("Failed to flush the records cache.", err)
+	}
+
+	api.app.RecordsCache().Stats().Reset()
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// -------------------------------------------------------------------
+
+// recordsCacheMaxDepth is the max relations depth of the collections
+// whose changes invalidate a cached response (same as the max expand depth).
+const recordsCacheMaxDepth = 6
+
+// recordsCacheMaxBodySize is the max size of a cacheable response body.
+const recordsCacheMaxBodySize = 1 << 20
+
+var (
+	recordsCacheCollectionRefRegex  = regexp.MustCompile(`@collection\.(\w+)`)
+	recordsCacheBackRelationRegex   = regexp.MustCompile(`(\w+)_via_\w+`)
+	recordsCacheCacheableTypesRegex = regexp.MustCompile(`^application/json`)
+)
+
+// cachedRecordsResponse is the stored records cache entry.
+type cachedRecordsResponse struct {
+	ETag string `json:"etag,omitempty"`
+	Body []byte `json:"body"`
+}
+
+// recordsCacheMiddleware serves the records list and view responses
+// from the app records cache (if enabled for the request collection).
+//
+// The cache key includes the request collection, path, query, auth
+// identity and tenant header together with the current cache versions
+// of the collection and its dependencies (the collections referenced
+// in its rules and relation fields), so any record change in them
+// invalidates the cached responses.
+//
+// Responses depending on the request headers (eg. "@request.headers.*"
+// rules or identifier macros) and the view collections are never cached.
+//
+// Note that a cache hit skips the OnRecordsListRequest and
+// OnRecordViewRequest hooks.
+func recordsCacheMiddleware(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || !app.RecordsCache().Enabled() {
+				return next(c)
+			}
+
+			ttl := app.Settings().RecordsCache.CollectionTTL(collection.Name)
+			if ttl <= 0 || !isRecordsRequestCacheable(c, collection) {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+			defer cancel()
+
+			key, err := recordsCacheKey(ctx, app, c, collection)
+			if err != nil {
+				app.Logger().Debug("Failed to resolve the records cache key", slog.String("error", err.Error()))
+				return next(c)
+			}
+
+			stats := app.RecordsCache().Stats()
+
+			if raw, err := app.RecordsCache().Get(ctx, key); err == nil {
+				cached := &cachedRecordsResponse{}
+				if err := json.Unmarshal(raw, cached); err == nil {
+					stats.Hit(collection.Name)
+
+					res := c.Response()
+					res.Header().Set("X-Cache", "HIT")
+					if cached.ETag != "" {
+						res.Header().Set("ETag", cached.ETag)
+					}
+
+					return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, cached.Body)
+				}
+			}
+
+			stats.Miss(collection.Name)
+
+			res := c.Response()
+			res.Header().Set("X-Cache", "MISS")
+
+			writer := &recordsCacheWriter{ResponseWriter: res.Writer}
+			res.Writer = writer
+			defer func() {
+				res.Writer = writer.ResponseWriter
+			}()
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			if res.Status != http.StatusOK ||
+				writer.overflow ||
+				!recordsCacheCacheableTypesRegex.MatchString(res.Header().Get(echo.HeaderContentType)) {
+				return nil
+			}
+
+			raw, err := json.Marshal(&cachedRecordsResponse{
+				ETag: res.Header().Get("ETag"),
+				Body: writer.body.Bytes(),
+			})
+			if err == nil {
+				err = app.RecordsCache().Set(ctx, key, raw, ttl)
+			}
+			if err != nil {
+				app.Logger().Debug("Failed to store the records cache entry", slog.String("error", err.Error()))
+			}
+
+			return nil
+		}
+	}
+}
+
+// isRecordsRequestCacheable checks whether the request response could be cached.
+func isRecordsRequestCacheable(c echo.Context, collection *models.Collection) bool {
+	// the view collections depend on arbitrary source tables
+	if collection.IsView() {
+		return false
+	}
+
+	req := c.Request()
+
+	if req.Header.Get("If-None-Match") != "" ||
+		strings.Contains(req.Header.Get("Cache-Control"), "no-cache") ||
+		isNDJSONRequest(c) {
+		return false
+	}
+
+	for _, rule := range []*string{collection.ListRule, collection.ViewRule} {
+		if rule != nil && (strings.Contains(*rule, "@request.headers.") || search.HasIdentifierMacro(*rule)) {
+			return false
+		}
+	}
+
+	for _, param := range []string{search.FilterQueryParam, search.SortQueryParam} {
+		if search.HasIdentifierMacro(c.QueryParam(param)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordsCacheKey returns the request records cache key.
+func recordsCacheKey(ctx context.Context, app core.App, c echo.Context, collection *models.Collection) (string, error) {
+	requestInfo := RequestInfo(c)
+
+	var auth string
+	if requestInfo.Admin != nil {
+		auth = "admin:" + requestInfo.Admin.Id
+	} else if requestInfo.AuthRecord != nil {
+		auth = requestInfo.AuthRecord.Collection().Id + ":" + requestInfo.AuthRecord.Id
+	}
+
+	version, err := app.RecordsCache().Version(ctx, recordsCacheDependencies(app, collection, c.QueryParam(expandQueryParam), requestInfo)...)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, part := range []string{
+		collection.Id,
+		c.Request().URL.Path,
+		c.QueryParams().Encode(),
+		auth,
+		c.Request().Header.Get(TenantHeader),
+		version,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// recordsCacheDependencies returns the sorted ids of the collections
+// whose records changes could affect the collection responses.
+func recordsCacheDependencies(app core.App, collection *models.Collection, expand string, requestInfo *models.RequestInfo) []string {
+	deps := map[string]struct{}{}
+
+	var visit func(c *models.Collection, depth int)
+	visit = func(c *models.Collection, depth int) {
+		if _, ok := deps[c.Id]; ok || depth > recordsCacheMaxDepth {
+			return
+		}
+		deps[c.Id] = struct{}{}
+
+		refs := []string{}
+
+		// referenced collections in the rules
+		for _, rule := range []*string{c.ListRule, c.ViewRule} {
+			if rule == nil {
+				continue
+			}
+
+			for _, m := range recordsCacheCollectionRefRegex.FindAllStringSubmatch(*rule, -1) {
+				refs = append(refs, m[1])
+			}
+
+			for _, m := range recordsCacheBackRelationRegex.FindAllStringSubmatch(*rule, -1) {
+				refs = append(refs, m[1])
+			}
+
+			// the auth record fields could be changed after the response was cached
+			if requestInfo.AuthRecord != nil && strings.Contains(*rule, "@request.auth.") {
+				refs = append(refs, requestInfo.AuthRecord.Collection().Id)
+			}
+		}
+
+		// related collections (eg. expand or rules with nested fields)
+		for _, field := range c.Schema.Fields() {
+			if options, ok := field.Options.(*schema.RelationOptions); ok {
+				refs = append(refs, options.CollectionId)
+			}
+		}
+
+		for _, ref := range refs {
+			if related, err := core.FindCachedCollectionByNameOrId(app, ref); err == nil {
+				visit(related, depth+1)
+			}
+		}
+	}
+
+	visit(collection, 0)
+
+	// back-relation expands
+	for _, m := range recordsCacheBackRelationRegex.FindAllStringSubmatch(expand, -1) {
+		if related, err := core.FindCachedCollectionByNameOrId(app, m[1]); err == nil {
+			visit(related, 1)
+		}
+	}
+
+	result := make([]string, 0, len(deps))
+	for id := range deps {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// recordsCacheWriter copies the response body (up to recordsCacheMaxBodySize).
+type recordsCacheWriter struct {
+	http.ResponseWriter
+
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *recordsCacheWriter) Write(b []byte) (int, error) {
+	if !w.overflow {
+		if w.body.Len()+len(b) > recordsCacheMaxBodySize {
+			w.overflow = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the original http.ResponseWriter (used by [http.ResponseController]).
+func (w *recordsCacheWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
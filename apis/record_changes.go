@@ -0,0 +1,199 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+const (
+	// defaultRecordChangesLimit is the max number of changes to read if ?limit is not set.
+	defaultRecordChangesLimit = 500
+
+	// maxRecordChangesLimit is the max allowed ?limit query param value.
+	maxRecordChangesLimit = 5000
+)
+
+// recordChangesResult defines the delta sync endpoint response.
+type recordChangesResult struct {
+	// Cursor is the seq of the last returned change
+	// (the value of the ?since param for the next request).
+	Cursor int64 `json:"cursor"`
+
+	// HasMore indicates that there are more changes after the cursor.
+	HasMore bool `json:"hasMore"`
+
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+}
+
+// changes returns the ids of the collection records created, updated
+// or deleted after the ?since cursor (for offline-first clients delta sync).
+//
+// If ?since is not set, only the current cursor is returned
+// (eg. to start tracking after an initial full records download).
+//
+// The created and updated ids are filtered with the collection
+// list rule, but the deleted ids are always returned (they could no
+// longer be checked and the clients ignore the unknown ones).
+func (api *recordApi) changes(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	if !collection.TrackChanges() {
+		return NewBadRequestError("The collection doesn't track its records changes.", nil)
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	result := &recordChangesResult{
+		Created: []string{},
+		Updated: []string{},
+		Deleted: []string{},
+	}
+
+	rawSince := c.QueryParam("since")
+	if rawSince == "" {
+		cursor, err := api.app.Dao().LastRecordChangeSeq(collection.Id)
+		if err != nil {
+			return NewBadRequestError("Failed to load the records changes cursor.", err)
+		}
+
+		result.Cursor = cursor
+
+		return c.JSON(http.StatusOK, result)
+	}
+
+	since, err := strconv.ParseInt(rawSince, 10, 64)
+	if err != nil || since < 0 {
+		return NewBadRequestError("Invalid since cursor.", err)
+	}
+
+	limit := cast.ToInt(c.QueryParam("limit"))
+	if limit <= 0 {
+		limit = defaultRecordChangesLimit
+	} else if limit > maxRecordChangesLimit {
+		limit = maxRecordChangesLimit
+	}
+
+	changes, err := api.app.Dao().FindRecordChanges(collection.Id, since, limit+1)
+	if err != nil {
+		return NewBadRequestError("Failed to load the records changes.", err)
+	}
+
+	if len(changes) > limit {
+		result.HasMore = true
+		changes = changes[:limit]
+	}
+
+	result.Cursor = since
+	if len(changes) > 0 {
+		result.Cursor = changes[len(changes)-1].Seq
+	}
+
+	// collapse the changes of each record into a single state
+	type recordState struct {
+		first string
+		last  string
+	}
+	states := map[string]*recordState{}
+	order := []string{}
+	for _, change := range changes {
+		state, ok := states[change.RecordId]
+		if !ok {
+			state = &recordState{first: change.Action}
+			states[change.RecordId] = state
+			order = append(order, change.RecordId)
+		}
+		state.last = change.Action
+	}
+
+	upserted := []string{}
+	for _, id := range order {
+		state := states[id]
+		if state.last == models.RecordChangeActionDelete {
+			result.Deleted = append(result.Deleted, id)
+		} else {
+			upserted = append(upserted, id)
+		}
+	}
+
+	accessible, err := api.accessibleRecordIds(requestInfo, collection, upserted)
+	if err != nil {
+		return NewBadRequestError("Failed to check the changed records access.", err)
+	}
+
+	for _, id := range upserted {
+		if !accessible[id] {
+			continue
+		}
+
+		if states[id].first == models.RecordChangeActionCreate {
+			result.Created = append(result.Created, id)
+		} else {
+			result.Updated = append(result.Updated, id)
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// accessibleRecordIds returns the subset of the provided
+// record ids that satisfy the collection list rule.
+func (api *recordApi) accessibleRecordIds(
+	requestInfo *models.RequestInfo,
+	collection *models.Collection,
+	ids []string,
+) (map[string]bool, error) {
+	result := make(map[string]bool, len(ids))
+
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	tenantId, tenantScoped, err := requestTenant(requestInfo, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if tenantScoped {
+			q.AndWhere(tenantExpr(collection, tenantId))
+		}
+		if requestInfo.Admin == nil && collection.ListRule != nil && *collection.ListRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+			expr, err := search.FilterData(*collection.ListRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	records, err := api.app.Dao().FindRecordsByIds(collection.Id, ids, ruleFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		result[record.Id] = true
+	}
+
+	return result, nil
+}
@@ -1,6 +1,7 @@
 package apis
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -25,11 +26,19 @@ func bindRecordCrudApi(app core.App, rg *echo.Group) {
 		ActivityLogger(app),
 	)
 
-	subGroup.GET("/records", api.list, LoadCollectionContext(app))
-	subGroup.GET("/records/:id", api.view, LoadCollectionContext(app))
-	subGroup.POST("/records", api.create, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
-	subGroup.PATCH("/records/:id", api.update, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
-	subGroup.DELETE("/records/:id", api.delete, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records", api.list, LoadCollectionContext(app), recordsCacheMiddleware(app))
+	subGroup.GET("/records/changes", api.changes, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records/:id", api.view, LoadCollectionContext(app), recordsCacheMiddleware(app))
+	subGroup.GET("/records/:id/graph", api.graph, LoadCollectionContext(app))
+	subGroup.POST("/records", api.create, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadShedding(app, LoadSheddingRecordWrites))
+	subGroup.PATCH("/records/:id", api.update, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadShedding(app, LoadSheddingRecordWrites))
+	subGroup.DELETE("/records/:id", api.delete, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadShedding(app, LoadSheddingRecordWrites))
+	subGroup.POST("/import", api.importRecords, RequireAdminAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadShedding(app, LoadSheddingRecordWrites))
+	subGroup.GET("/export", api.exportRecords, LoadCollectionContext(app))
+	subGroup.GET("/duplicates", api.duplicates, RequireAdminAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records/:id/duplicates", api.recordDuplicates, RequireAdminAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.POST("/records/:id/merge", api.merge, RequireAdminAuth(), LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadShedding(app, LoadSheddingRecordWrites))
+	subGroup.GET("/verify-chain", api.verifyChain, RequireAdminAuth(), LoadCollectionContext(app, models.CollectionTypeBase))
 }
 
 type recordApi struct {
@@ -42,6 +51,10 @@ func (api *recordApi) list(c echo.Context) error {
 		return NewNotFoundError("", "Missing collection context.")
 	}
 
+	if isNDJSONRequest(c) {
+		return api.streamList(c, collection)
+	}
+
 	requestInfo := RequestInfo(c)
 
 	// forbid users and guests to query special filter/sort fields
@@ -62,8 +75,16 @@ func (api *recordApi) list(c echo.Context) error {
 		requestInfo.Admin != nil,
 	)
 
+	query := api.app.Dao().RecordQuery(collection)
+	if err := applyRecordTreeFilters(c, api.app.Dao(), collection, query); err != nil {
+		return err
+	}
+	if err := applyRequestTenantFilter(requestInfo, collection, query); err != nil {
+		return err
+	}
+
 	searchProvider := search.NewProvider(fieldsResolver).
-		Query(api.app.Dao().RecordQuery(collection))
+		Query(query)
 
 	if requestInfo.Admin == nil && collection.ListRule != nil {
 		searchProvider.AddFilter(search.FilterData(*collection.ListRule))
@@ -113,17 +134,9 @@ func (api *recordApi) view(c echo.Context) error {
 		return NewForbiddenError("Only admins can perform this action.", nil)
 	}
 
-	ruleFunc := func(q *dbx.SelectQuery) error {
-		if requestInfo.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
-			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
-			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
-			if err != nil {
-				return err
-			}
-			resolver.UpdateQuery(q)
-			q.AndWhere(expr)
-		}
-		return nil
+	ruleFunc, err := recordRuleFunc(api.app.Dao(), collection, requestInfo, collection.ViewRule)
+	if err != nil {
+		return err
 	}
 
 	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
@@ -150,6 +163,8 @@ func (api *recordApi) view(c echo.Context) error {
 			)
 		}
 
+		setRecordETag(e.HttpContext, e.Record)
+
 		return e.HttpContext.JSON(http.StatusOK, e.Record)
 	})
 }
@@ -171,56 +186,18 @@ func (api *recordApi) create(c echo.Context) error {
 
 	// temporary save the record and check it against the create rule
 	if requestInfo.Admin == nil && collection.CreateRule != nil {
-		testRecord := models.NewRecord(collection)
-
-		// replace modifiers fields so that the resolved value is always
-		// available when accessing requestInfo.Data using just the field name
-		if requestInfo.HasModifierDataKeys() {
-			requestInfo.Data = testRecord.ReplaceModifers(requestInfo.Data)
-		}
-
-		testForm := forms.NewRecordUpsert(api.app, testRecord)
-		testForm.SetFullManageAccess(true)
-		if err := testForm.LoadRequest(c.Request(), ""); err != nil {
-			return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
-		}
-
-		createRuleFunc := func(q *dbx.SelectQuery) error {
-			if *collection.CreateRule == "" {
-				return nil // no create rule to resolve
-			}
-
-			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
-			expr, err := search.FilterData(*collection.CreateRule).BuildExpr(resolver)
-			if err != nil {
-				return err
-			}
-			resolver.UpdateQuery(q)
-			q.AndWhere(expr)
-			return nil
-		}
-
-		testErr := testForm.DrySubmit(func(txDao *daos.Dao) error {
-			foundRecord, err := txDao.FindRecordById(collection.Id, testRecord.Id, createRuleFunc)
-			if err != nil {
-				return fmt.Errorf("DrySubmit create rule failure: %w", err)
-			}
-			hasFullManageAccess = hasAuthManageAccess(txDao, foundRecord, requestInfo)
-			return nil
-		})
-
-		if testErr != nil {
-			return NewBadRequestError("Failed to create record.", testErr)
+		var err error
+		hasFullManageAccess, err = checkRecordCreateRule(api.app, c, collection, requestInfo)
+		if err != nil {
+			return err
 		}
 	}
 
 	record := models.NewRecord(collection)
-	form := forms.NewRecordUpsert(api.app, record)
-	form.SetFullManageAccess(hasFullManageAccess)
 
-	// load request
-	if err := form.LoadRequest(c.Request(), ""); err != nil {
-		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	form, err := newRecordUpsertForm(api.app, c, record, requestInfo, hasFullManageAccess)
+	if err != nil {
+		return err
 	}
 
 	event := new(core.RecordCreateEvent)
@@ -253,6 +230,8 @@ func (api *recordApi) create(c echo.Context) error {
 						return nil
 					}
 
+					setRecordETag(e.HttpContext, e.Record)
+
 					return e.HttpContext.JSON(http.StatusOK, e.Record)
 				})
 			})
@@ -266,6 +245,10 @@ func (api *recordApi) update(c echo.Context) error {
 		return NewNotFoundError("", "Missing collection context.")
 	}
 
+	if collection.Immutable() != nil {
+		return NewForbiddenError("The immutable collection records cannot be updated.", nil)
+	}
+
 	recordId := c.PathParam("id")
 	if recordId == "" {
 		return NewNotFoundError("", nil)
@@ -288,17 +271,9 @@ func (api *recordApi) update(c echo.Context) error {
 		requestInfo.Data = record.ReplaceModifers(requestInfo.Data)
 	}
 
-	ruleFunc := func(q *dbx.SelectQuery) error {
-		if requestInfo.Admin == nil && collection.UpdateRule != nil && *collection.UpdateRule != "" {
-			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
-			expr, err := search.FilterData(*collection.UpdateRule).BuildExpr(resolver)
-			if err != nil {
-				return err
-			}
-			resolver.UpdateQuery(q)
-			q.AndWhere(expr)
-		}
-		return nil
+	ruleFunc, err := recordRuleFunc(api.app.Dao(), collection, requestInfo, collection.UpdateRule)
+	if err != nil {
+		return err
 	}
 
 	// fetch record
@@ -307,12 +282,15 @@ func (api *recordApi) update(c echo.Context) error {
 		return NewNotFoundError("", fetchErr)
 	}
 
-	form := forms.NewRecordUpsert(api.app, record)
-	form.SetFullManageAccess(requestInfo.Admin != nil || hasAuthManageAccess(api.app.Dao(), record, requestInfo))
-
-	// load request
-	if err := form.LoadRequest(c.Request(), ""); err != nil {
-		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	form, err := newRecordUpsertForm(
+		api.app,
+		c,
+		record,
+		requestInfo,
+		requestInfo.Admin != nil || hasAuthManageAccess(api.app.Dao(), record, requestInfo),
+	)
+	if err != nil {
+		return err
 	}
 
 	event := new(core.RecordUpdateEvent)
@@ -328,7 +306,13 @@ func (api *recordApi) update(c echo.Context) error {
 
 			return api.app.OnRecordBeforeUpdateRequest().Trigger(event, func(e *core.RecordUpdateEvent) error {
 				if err := next(e.Record); err != nil {
-					return NewBadRequestError("Failed to update record.", err)
+					if !errors.Is(err, daos.ErrRecordRevisionConflict) {
+						return NewBadRequestError("Failed to update record.", err)
+					}
+
+					if err := resolveRecordSyncConflict(api.app, e, form, next); err != nil {
+						return err
+					}
 				}
 
 				if err := EnrichRecord(e.HttpContext, api.app.Dao(), e.Record); err != nil {
@@ -345,6 +329,8 @@ func (api *recordApi) update(c echo.Context) error {
 						return nil
 					}
 
+					setRecordETag(e.HttpContext, e.Record)
+
 					return e.HttpContext.JSON(http.StatusOK, e.Record)
 				})
 			})
@@ -358,6 +344,10 @@ func (api *recordApi) delete(c echo.Context) error {
 		return NewNotFoundError("", "Missing collection context.")
 	}
 
+	if collection.Immutable() != nil {
+		return NewForbiddenError("The immutable collection records cannot be deleted.", nil)
+	}
+
 	recordId := c.PathParam("id")
 	if recordId == "" {
 		return NewNotFoundError("", nil)
@@ -370,17 +360,9 @@ func (api *recordApi) delete(c echo.Context) error {
 		return NewForbiddenError("Only admins can perform this action.", nil)
 	}
 
-	ruleFunc := func(q *dbx.SelectQuery) error {
-		if requestInfo.Admin == nil && collection.DeleteRule != nil && *collection.DeleteRule != "" {
-			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
-			expr, err := search.FilterData(*collection.DeleteRule).BuildExpr(resolver)
-			if err != nil {
-				return err
-			}
-			resolver.UpdateQuery(q)
-			q.AndWhere(expr)
-		}
-		return nil
+	ruleFunc, err := recordRuleFunc(api.app.Dao(), collection, requestInfo, collection.DeleteRule)
+	if err != nil {
+		return err
 	}
 
 	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
@@ -396,6 +378,9 @@ func (api *recordApi) delete(c echo.Context) error {
 	return api.app.OnRecordBeforeDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
 		// delete the record
 		if err := api.app.Dao().DeleteRecord(e.Record); err != nil {
+			if errors.Is(err, daos.ErrRecordUnderLegalHold) {
+				return NewForbiddenError("The record (or one of its cascade deleted references) is under legal hold.", nil)
+			}
 			return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
 		}
 
@@ -408,3 +393,131 @@ func (api *recordApi) delete(c echo.Context) error {
 		})
 	})
 }
+
+// recordRuleFunc returns a record query filter function that restricts
+// the records to the request tenant (if the collection is tenant scoped)
+// and to the ones satisfying the provided collection API rule
+// (the rule is not applied for admins).
+func recordRuleFunc(
+	dao *daos.Dao,
+	collection *models.Collection,
+	requestInfo *models.RequestInfo,
+	rule *string,
+) (func(q *dbx.SelectQuery) error, error) {
+	tenantId, tenantScoped, err := requestTenant(requestInfo, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(q *dbx.SelectQuery) error {
+		if tenantScoped {
+			q.AndWhere(tenantExpr(collection, tenantId))
+		}
+		if requestInfo.Admin == nil && rule != nil && *rule != "" {
+			resolver := resolvers.NewRecordFieldResolver(dao, collection, requestInfo, true)
+			expr, err := search.FilterData(*rule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}, nil
+}
+
+// newRecordUpsertForm creates a new upsert form for the provided record
+// and loads into it the request data, the request tenant (if the collection
+// is tenant scoped) and the optimistic locking revision (for existing records).
+func newRecordUpsertForm(
+	app core.App,
+	c echo.Context,
+	record *models.Record,
+	requestInfo *models.RequestInfo,
+	fullManageAccess bool,
+) (*forms.RecordUpsert, error) {
+	collection := record.Collection()
+
+	tenantId, tenantScoped, err := requestTenant(requestInfo, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	form := forms.NewRecordUpsert(app, record)
+	form.SetFullManageAccess(fullManageAccess)
+	form.SetAuthRecord(requestInfo.AuthRecord)
+
+	// load request
+	if err := form.LoadRequest(c.Request(), ""); err != nil {
+		return nil, NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	// always store the request tenant
+	if tenantScoped {
+		if err := form.LoadData(map[string]any{collection.TenantField(): tenantId}); err != nil {
+			return nil, NewBadRequestError("Failed to load the record tenant.", err)
+		}
+	}
+
+	// optimistic locking
+	if !record.IsNew() {
+		if revision, ok, err := requestRecordRevision(c, collection); err != nil {
+			return nil, NewBadRequestError("Invalid If-Match or revision value.", err)
+		} else if ok {
+			form.SetExpectedRevision(revision)
+		}
+	}
+
+	return form, nil
+}
+
+// checkRecordCreateRule temporary saves the create request data as
+// a new record and checks it against the collection create rule.
+//
+// It returns whether the request has full manage access
+// to the created record (applicable only for auth records).
+func checkRecordCreateRule(
+	app core.App,
+	c echo.Context,
+	collection *models.Collection,
+	requestInfo *models.RequestInfo,
+) (bool, error) {
+	if collection.CreateRule == nil {
+		// only admins can access if the rule is nil
+		return false, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	testRecord := models.NewRecord(collection)
+
+	// replace modifiers fields so that the resolved value is always
+	// available when accessing requestInfo.Data using just the field name
+	if requestInfo.HasModifierDataKeys() {
+		requestInfo.Data = testRecord.ReplaceModifers(requestInfo.Data)
+	}
+
+	testForm, err := newRecordUpsertForm(app, c, testRecord, requestInfo, true)
+	if err != nil {
+		return false, err
+	}
+
+	createRuleFunc, err := recordRuleFunc(app.Dao(), collection, requestInfo, collection.CreateRule)
+	if err != nil {
+		return false, err
+	}
+
+	var hasFullManageAccess bool
+
+	testErr := testForm.DrySubmit(func(txDao *daos.Dao) error {
+		foundRecord, err := txDao.FindRecordById(collection.Id, testRecord.Id, createRuleFunc)
+		if err != nil {
+			return fmt.Errorf("DrySubmit create rule failure: %w", err)
+		}
+		hasFullManageAccess = hasAuthManageAccess(txDao, foundRecord, requestInfo)
+		return nil
+	})
+	if testErr != nil {
+		return false, NewBadRequestError("Failed to create record.", testErr)
+	}
+
+	return hasFullManageAccess, nil
+}
@@ -0,0 +1,146 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// defaultDuplicatesLimit is the max number of returned duplicates if ?limit is not set.
+const defaultDuplicatesLimit = 100
+
+type recordDuplicateResponse struct {
+	Score  float64        `json:"score"`
+	Record *models.Record `json:"record"`
+}
+
+type recordDuplicatePairResponse struct {
+	Score float64        `json:"score"`
+	A     *models.Record `json:"a"`
+	B     *models.Record `json:"b"`
+}
+
+// duplicates returns the probable duplicate record pairs of the
+// collection based on its duplicates options (admins only).
+func (api *recordApi) duplicates(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	threshold, limit, err := parseDuplicatesParams(c)
+	if err != nil {
+		return err
+	}
+
+	pairs, err := api.app.Dao().FindRecordDuplicatePairs(collection, threshold, limit)
+	if err != nil {
+		return NewBadRequestError("Failed to find duplicate records.", err)
+	}
+
+	result := make([]*recordDuplicatePairResponse, len(pairs))
+	records := make([]*models.Record, 0, len(pairs)*2)
+	for i, p := range pairs {
+		result[i] = &recordDuplicatePairResponse{Score: p.Score, A: p.A, B: p.B}
+		records = append(records, p.A, p.B)
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil {
+		api.app.Logger().Debug("Failed to enrich duplicate records", slog.String("error", err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// recordDuplicates returns the probable duplicates of a single
+// record based on its collection duplicates options (admins only).
+func (api *recordApi) recordDuplicates(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	threshold, limit, err := parseDuplicatesParams(c)
+	if err != nil {
+		return err
+	}
+
+	duplicates, err := api.app.Dao().FindRecordDuplicates(record, threshold, limit)
+	if err != nil {
+		return NewBadRequestError("Failed to find duplicate records.", err)
+	}
+
+	result := make([]*recordDuplicateResponse, len(duplicates))
+	records := make([]*models.Record, len(duplicates))
+	for i, d := range duplicates {
+		result[i] = &recordDuplicateResponse{Score: d.Score, Record: d.Record}
+		records[i] = d.Record
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil {
+		api.app.Logger().Debug("Failed to enrich duplicate records", slog.String("error", err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// merge merges the submitted duplicate records into
+// the requested record (admins only).
+func (api *recordApi) merge(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordMerge(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to merge the records.", submitErr)
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil {
+		api.app.Logger().Debug("Failed to enrich the merged record", slog.String("error", err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+func parseDuplicatesParams(c echo.Context) (float64, int, error) {
+	var threshold float64
+	if raw := c.QueryParam("threshold"); raw != "" {
+		var err error
+		threshold, err = strconv.ParseFloat(raw, 64)
+		if err != nil || threshold < 0 || threshold > 1 {
+			return 0, 0, NewBadRequestError("The threshold must be a number between 0 and 1.", err)
+		}
+	}
+
+	limit := defaultDuplicatesLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		var err error
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return 0, 0, NewBadRequestError("The limit must be a positive integer.", err)
+		}
+	}
+
+	return threshold, limit, nil
+}
@@ -0,0 +1,206 @@
+package apis
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+const (
+	// defaultRecordGraphDepth is the relation levels to traverse if ?depth is not set.
+	defaultRecordGraphDepth = 1
+
+	// maxRecordGraphDepth is the max allowed ?depth query param value.
+	maxRecordGraphDepth = 5
+)
+
+type recordGraphEdge struct {
+	From             string `json:"from"`
+	FromCollectionId string `json:"fromCollectionId"`
+	To               string `json:"to"`
+	ToCollectionId   string `json:"toCollectionId"`
+	Field            string `json:"field"`
+}
+
+type recordGraphResponse struct {
+	Record *models.Record     `json:"record"`
+	Nodes  []*models.Record   `json:"nodes"`
+	Edges  []*recordGraphEdge `json:"edges"`
+}
+
+// graph returns the requested record and its relation graph
+// (up to ?depth levels, optionally limited to the ?relations fields)
+// as a list of record nodes and relation edges.
+//
+// Only the related records that satisfy their collection view rule
+// are included (together with their edges).
+func (api *recordApi) graph(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	recordId := c.PathParam("id")
+	if recordId == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	depth := defaultRecordGraphDepth
+	if raw := c.QueryParam("depth"); raw != "" {
+		var err error
+		depth, err = strconv.Atoi(raw)
+		if err != nil || depth < 0 || depth > maxRecordGraphDepth {
+			return NewBadRequestError(fmt.Sprintf("The depth must be an integer between 0 and %d.", maxRecordGraphDepth), err)
+		}
+	}
+
+	var relations []string
+	if raw := c.QueryParam("relations"); raw != "" {
+		relations = list.ToUniqueStringSlice(strings.Split(raw, ","))
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.ViewRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	dao := api.app.Dao()
+	fetch := expandFetch(dao, requestInfo)
+
+	roots, err := fetch(collection, []string{recordId})
+	if err != nil || len(roots) == 0 {
+		return NewNotFoundError("", err)
+	}
+
+	result := &recordGraphResponse{
+		Record: roots[0],
+		Nodes:  []*models.Record{roots[0]},
+		Edges:  []*recordGraphEdge{},
+	}
+
+	nodeKey := func(collectionId string, id string) string {
+		return collectionId + "/" + id
+	}
+
+	nodes := map[string]struct{}{nodeKey(collection.Id, recordId): {}}
+
+	type pendingEdge struct {
+		from             string
+		fromCollectionId string
+		field            string
+		collectionId     string
+		to               string
+	}
+
+	frontier := result.Nodes
+
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		edges := []pendingEdge{}
+		idsToFetch := map[string][]string{}
+
+		for _, record := range frontier {
+			for _, field := range record.Collection().Schema.Fields() {
+				if field.Type != schema.FieldTypeRelation {
+					continue
+				}
+
+				if len(relations) > 0 && !list.ExistInSlice(field.Name, relations) {
+					continue
+				}
+
+				field.InitOptions()
+				options, _ := field.Options.(*schema.RelationOptions)
+				if options == nil {
+					continue
+				}
+
+				for _, relId := range record.GetStringSlice(field.Name) {
+					edges = append(edges, pendingEdge{record.Id, record.Collection().Id, field.Name, options.CollectionId, relId})
+
+					if _, ok := nodes[nodeKey(options.CollectionId, relId)]; !ok &&
+						!list.ExistInSlice(relId, idsToFetch[options.CollectionId]) {
+						idsToFetch[options.CollectionId] = append(idsToFetch[options.CollectionId], relId)
+					}
+				}
+			}
+		}
+
+		// sort for consistent nodes order
+		collectionIds := make([]string, 0, len(idsToFetch))
+		for id := range idsToFetch {
+			collectionIds = append(collectionIds, id)
+		}
+		sort.Strings(collectionIds)
+
+		next := []*models.Record{}
+
+		for _, collectionId := range collectionIds {
+			relCollection, err := dao.FindCollectionByNameOrId(collectionId)
+			if err != nil {
+				continue // missing collection
+			}
+
+			relRecords, err := fetch(relCollection, idsToFetch[collectionId])
+			if err != nil {
+				api.app.Logger().Debug(
+					"Failed to fetch graph relation records",
+					slog.String("collectionName", relCollection.Name),
+					slog.String("error", err.Error()),
+				)
+				continue // eg. not accessible by the current client
+			}
+
+			mappedRecords := make(map[string]*models.Record, len(relRecords))
+			for _, r := range relRecords {
+				mappedRecords[r.Id] = r
+			}
+
+			// preserve the relation values order
+			for _, id := range idsToFetch[collectionId] {
+				if r, ok := mappedRecords[id]; ok {
+					nodes[nodeKey(collectionId, id)] = struct{}{}
+					result.Nodes = append(result.Nodes, r)
+					next = append(next, r)
+				}
+			}
+		}
+
+		for _, e := range edges {
+			if _, ok := nodes[nodeKey(e.collectionId, e.to)]; ok {
+				result.Edges = append(result.Edges, &recordGraphEdge{
+					From:             e.from,
+					FromCollectionId: e.fromCollectionId,
+					To:               e.to,
+					ToCollectionId:   e.collectionId,
+					Field:            e.field,
+				})
+			}
+		}
+
+		frontier = next
+	}
+
+	// enrich the nodes the same way as the view endpoint
+	// (grouped by collection to resolve the email visibility)
+	groupedNodes := map[string][]*models.Record{}
+	for _, node := range result.Nodes {
+		groupedNodes[node.Collection().Id] = append(groupedNodes[node.Collection().Id], node)
+	}
+	for _, group := range groupedNodes {
+		if err := EnrichRecords(c, dao, group); err != nil {
+			api.app.Logger().Debug("Failed to enrich graph nodes", slog.String("error", err.Error()))
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v5"
@@ -17,6 +18,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
 )
 
 const ContextRequestInfoKey = "requestInfo"
@@ -24,6 +26,10 @@ const ContextRequestInfoKey = "requestInfo"
 const expandQueryParam = "expand"
 const fieldsQueryParam = "fields"
 
+const subtreeOfQueryParam = "subtreeOf"
+const ancestorsOfQueryParam = "ancestorsOf"
+const treeFieldQueryParam = "treeField"
+
 // Deprecated: Use RequestInfo instead.
 func RequestData(c echo.Context) *models.RequestInfo {
 	log.Println("RequestData(c) is deprecated and will be removed in the future! You can replace it with RequestInfo(c).")
@@ -82,7 +88,18 @@ func RecordAuthResponse(
 		return NewForbiddenError("Please verify your email first.", nil)
 	}
 
-	token, tokenErr := tokens.NewRecordAuthToken(app, authRecord)
+	session, refreshToken, refreshTokenErr := authSession(app, c, authRecord.Collection().Id, authRecord.Id)
+	if refreshTokenErr != nil {
+		return NewBadRequestError("Failed to create refresh token.", refreshTokenErr)
+	}
+
+	var token string
+	var tokenErr error
+	if session != nil {
+		token, tokenErr = tokens.NewRecordSessionAuthToken(app, authRecord, session)
+	} else {
+		token, tokenErr = tokens.NewRecordAuthToken(app, authRecord)
+	}
 	if tokenErr != nil {
 		return NewBadRequestError("Failed to create auth token.", tokenErr)
 	}
@@ -92,6 +109,7 @@ func RecordAuthResponse(
 	event.Collection = authRecord.Collection()
 	event.Record = authRecord
 	event.Token = token
+	event.RefreshToken = refreshToken
 	event.Meta = meta
 
 	return app.OnRecordAuthRequest().Trigger(event, func(e *core.RecordAuthEvent) error {
@@ -120,8 +138,9 @@ func RecordAuthResponse(
 		}
 
 		result := map[string]any{
-			"token":  e.Token,
-			"record": e.Record,
+			"token":        e.Token,
+			"refreshToken": e.RefreshToken,
+			"record":       e.Record,
 		}
 
 		if e.Meta != nil {
@@ -173,6 +192,33 @@ func EnrichRecords(c echo.Context, dao *daos.Dao, records []*models.Record, defa
 	return nil
 }
 
+// applyRecordTreeFilters limits the records query to the descendants
+// and/or the ancestors of the ?subtreeOf and ?ancestorsOf query params records.
+//
+// The tree is resolved from the ?treeField self-referencing relation field
+// or, if not set, from the only self-referencing relation field of the collection.
+func applyRecordTreeFilters(c echo.Context, dao *daos.Dao, collection *models.Collection, query *dbx.SelectQuery) error {
+	treeField := c.QueryParam(treeFieldQueryParam)
+
+	if id := c.QueryParam(subtreeOfQueryParam); id != "" {
+		expr, err := dao.RecordSubtreeExpr(collection, treeField, id)
+		if err != nil {
+			return NewBadRequestError("Invalid subtreeOf filter.", err)
+		}
+		query.AndWhere(expr)
+	}
+
+	if id := c.QueryParam(ancestorsOfQueryParam); id != "" {
+		expr, err := dao.RecordAncestorsExpr(collection, treeField, id)
+		if err != nil {
+			return NewBadRequestError("Invalid ancestorsOf filter.", err)
+		}
+		query.AndWhere(expr)
+	}
+
+	return nil
+}
+
 // expandFetch is the records fetch function that is used to expand related records.
 func expandFetch(
 	dao *daos.Dao,
@@ -343,3 +389,51 @@ func checkForAdminOnlyRuleFields(requestInfo *models.RequestInfo) error {
 
 	return nil
 }
+
+// requestRecordRevision extracts the expected record revision for
+// the optimistic locking check from the request If-Match header
+// or from the collection revision field submitted value.
+//
+// It reports false if the collection doesn't have a revision field,
+// no expected revision was submitted or the If-Match header is "*".
+func requestRecordRevision(c echo.Context, collection *models.Collection) (int, bool, error) {
+	field := collection.RevisionField()
+	if field == "" {
+		return 0, false, nil
+	}
+
+	if ifMatch := strings.TrimSpace(c.Request().Header.Get("If-Match")); ifMatch != "" {
+		// matches any existing record (RFC 9110 13.1.1)
+		if ifMatch == "*" {
+			return 0, false, nil
+		}
+
+		raw := strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+
+		revision, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, false, err
+		}
+
+		return revision, true, nil
+	}
+
+	if raw, ok := RequestInfo(c).Data[field]; ok {
+		revision, err := cast.ToIntE(raw)
+		if err != nil {
+			return 0, false, err
+		}
+
+		return revision, true, nil
+	}
+
+	return 0, false, nil
+}
+
+// setRecordETag sets the response ETag header to the record
+// revision counter (if the collection has a revision field).
+func setRecordETag(c echo.Context, record *models.Record) {
+	if field := record.Collection().RevisionField(); field != "" {
+		c.Response().Header().Set("ETag", `"`+strconv.Itoa(record.GetInt(field))+`"`)
+	}
+}
@@ -117,6 +117,7 @@ func TestRecordAuthResponse(t *testing.T) {
 			expectError: false,
 			expectedContent: []string{
 				`"token":"`,
+				`"refreshToken":"`,
 				`"record":{`,
 				`"id":"`,
 				`"expand":{"rel":{`,
@@ -126,6 +127,7 @@ func TestRecordAuthResponse(t *testing.T) {
 			},
 			expectedEvents: map[string]int{
 				"OnRecordAuthRequest": 1,
+				"OnSessionCreate":     1,
 			},
 		},
 		{
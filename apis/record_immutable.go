@@ -0,0 +1,39 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+type recordHashChainResponse struct {
+	Valid bool `json:"valid"`
+
+	// BrokenRecordId is the id of the first record
+	// with mismatched hash (if the chain is not valid).
+	BrokenRecordId string `json:"brokenRecordId"`
+}
+
+// verifyChain verifies the immutable collection
+// records hash chain (admins only).
+func (api *recordApi) verifyChain(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	if options := collection.Immutable(); options == nil || options.HashField == "" {
+		return NewBadRequestError("The collection doesn't have a records hash chain.", nil)
+	}
+
+	brokenId, err := api.app.Dao().VerifyRecordsHashChain(collection)
+	if err != nil {
+		return NewBadRequestError("Failed to verify the records hash chain.", err)
+	}
+
+	return c.JSON(http.StatusOK, &recordHashChainResponse{
+		Valid:          brokenId == "",
+		BrokenRecordId: brokenId,
+	})
+}
@@ -0,0 +1,424 @@
+package apis
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/rest"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// recordsExportBatchSize is the number of records that are loaded,
+// enriched and written at once during export and streamed list requests.
+const recordsExportBatchSize = 200
+
+const ndjsonContentType = "application/x-ndjson"
+
+func (api *recordApi) importRecords(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	form := forms.NewRecordsImport(api.app, collection)
+	form.Format = c.FormValue("format")
+	form.DryRun = cast.ToBool(c.FormValue("dryRun"))
+
+	if rawMapping := c.FormValue("mapping"); rawMapping != "" {
+		if err := json.Unmarshal([]byte(rawMapping), &form.Mapping); err != nil {
+			return NewBadRequestError("Invalid mapping - must be a JSON object with column-field pairs.", err)
+		}
+	}
+
+	files, err := rest.FindUploadedFiles(c.Request(), "file")
+	if err != nil {
+		return NewBadRequestError("Missing or invalid uploaded file.", err)
+	}
+	form.File = files[0]
+
+	result, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to import records.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *recordApi) exportRecords(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = forms.RecordsFormatNDJSON
+	}
+	if format != forms.RecordsFormatNDJSON && format != forms.RecordsFormatCSV {
+		return NewBadRequestError("Invalid export format - must be ndjson or csv.", nil)
+	}
+
+	query, err := api.recordsStreamQuery(c, collection)
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	if format == forms.RecordsFormatCSV {
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	} else {
+		res.Header().Set(echo.HeaderContentType, ndjsonContentType)
+	}
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, collection.Name, format))
+
+	return api.streamRecords(c, format, collection, query)
+}
+
+// streamList streams the records matching the list request filter
+// and sort params as NDJSON (see [isNDJSONRequest]).
+//
+// Admins could stream all matching records at once, while for everyone
+// else the page and perPage params are applied the same way as in the
+// regular list response (aka. at most [search.MaxPerPage] records).
+func (api *recordApi) streamList(c echo.Context, collection *models.Collection) error {
+	query, err := api.recordsStreamQuery(c, collection)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, ndjsonContentType)
+
+	return api.streamRecords(c, forms.RecordsFormatNDJSON, collection, query)
+}
+
+// recordsStreamQuery builds the records query of the export and streamed
+// list requests from the current request filter, sort and pagination params,
+// applying the collection list rule for non-admins.
+func (api *recordApi) recordsStreamQuery(c echo.Context, collection *models.Collection) (*dbx.SelectQuery, error) {
+	requestInfo := RequestInfo(c)
+
+	// forbid users and guests to query special filter/sort fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return nil, err
+	}
+
+	if requestInfo.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	resolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		requestInfo,
+		// hidden fields are searchable only by admins
+		requestInfo.Admin != nil,
+	)
+
+	filters := []search.FilterData{}
+	if requestInfo.Admin == nil && collection.ListRule != nil {
+		filters = append(filters, search.FilterData(*collection.ListRule))
+	}
+	if filter := c.QueryParam(search.FilterQueryParam); filter != "" {
+		filters = append(filters, search.FilterData(filter))
+	}
+
+	query := api.app.Dao().RecordQuery(collection)
+	if err := applyRecordTreeFilters(c, api.app.Dao(), collection, query); err != nil {
+		return nil, err
+	}
+	if err := applyRequestTenantFilter(requestInfo, collection, query); err != nil {
+		return nil, err
+	}
+
+	query, err := recordsSearchQuery(query, resolver, filters, c.QueryParam(search.SortQueryParam))
+	if err != nil {
+		return nil, NewBadRequestError("Invalid filter or sort format.", err)
+	}
+
+	// ensure stable order between the fetched batches
+	query.AndOrderBy(fmt.Sprintf("[[%s.id]] ASC", collection.Name))
+
+	page := cast.ToInt(c.QueryParam(search.PageQueryParam))
+	perPage := cast.ToInt(c.QueryParam(search.PerPageQueryParam))
+
+	if requestInfo.Admin == nil {
+		if perPage <= 0 {
+			perPage = search.DefaultPerPage
+		}
+		if perPage > search.MaxPerPage {
+			perPage = search.MaxPerPage
+		}
+	}
+
+	if perPage > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		query.Limit(int64(perPage)).Offset(int64((page - 1) * perPage))
+	}
+
+	return query, nil
+}
+
+// streamRecords writes the records of the provided query into the
+// current response, triggering the OnRecordsListRequest hook and
+// expanding the requested relations for each batch.
+func (api *recordApi) streamRecords(c echo.Context, format string, collection *models.Collection, query *dbx.SelectQuery) error {
+	page := 1
+	if info := query.Info(); info.Limit > 0 {
+		page = int(info.Offset/info.Limit) + 1
+	}
+
+	err := ExportRecords(c.Response(), format, collection, query, func(records []*models.Record) ([]*models.Record, error) {
+		event := new(core.RecordsListEvent)
+		event.HttpContext = c
+		event.Collection = collection
+		event.Records = records
+		event.Result = &search.Result{
+			Page:       page,
+			PerPage:    len(records),
+			TotalItems: -1,
+			TotalPages: -1,
+			Items:      records,
+		}
+
+		var result []*models.Record
+
+		err := api.app.OnRecordsListRequest().Trigger(event, func(e *core.RecordsListEvent) error {
+			if err := EnrichRecords(e.HttpContext, api.app.Dao(), e.Records); err != nil {
+				api.app.Logger().Debug("Failed to enrich streamed records", slog.String("error", err.Error()))
+			}
+
+			result = e.Records
+
+			return nil
+		})
+
+		return result, err
+	})
+	if err != nil {
+		if !c.Response().Committed {
+			return NewBadRequestError("Failed to load the records.", err)
+		}
+
+		// the response is already committed
+		api.app.Logger().Error("Failed to stream records", slog.String("collection", collection.Name), slog.String("error", err.Error()))
+	}
+
+	return nil
+}
+
+// isNDJSONRequest checks whether the request accepts a streamed NDJSON response.
+func isNDJSONRequest(c echo.Context) bool {
+	return strings.Contains(c.Request().Header.Get(echo.HeaderAccept), ndjsonContentType)
+}
+
+// recordsSearchQuery applies the provided filters and sort
+// expression to the records query.
+func recordsSearchQuery(query *dbx.SelectQuery, resolver *resolvers.RecordFieldResolver, filters []search.FilterData, sort string) (*dbx.SelectQuery, error) {
+	for _, f := range filters {
+		expr, err := f.BuildExpr(resolver)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			query.AndWhere(expr)
+		}
+	}
+
+	if sort != "" {
+		for _, sortField := range search.ParseSortFromString(sort) {
+			expr, err := sortField.BuildExpr(resolver)
+			if err != nil {
+				return nil, err
+			}
+			if expr != "" {
+				query.AndOrderBy(expr)
+			}
+		}
+	}
+
+	if err := resolver.UpdateQuery(query); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+// ExportRecords writes the records returned by the provided query
+// into w as CSV or NDJSON (see forms.RecordsFormatCSV and forms.RecordsFormatNDJSON).
+//
+// The records are fetched and written in batches of [recordsExportBatchSize]
+// (honoring the query limit and offset, if any), flushing w after each batch
+// if it implements [http.Flusher], so that no db read is kept open while
+// writing to slow clients.
+//
+// The optional beforeWrite callback is invoked for each batch and returns
+// the records to write (eg. to expand relations or to exclude records).
+//
+// CSV columns are the collection system and schema fields, with the
+// non-scalar values (eg. multiple relations) serialized as JSON.
+func ExportRecords(
+	w io.Writer,
+	format string,
+	collection *models.Collection,
+	query *dbx.SelectQuery,
+	beforeWrite func(records []*models.Record) ([]*models.Record, error),
+) error {
+	var write func(record *models.Record) error
+	var flush func() error
+
+	switch format {
+	case forms.RecordsFormatCSV:
+		columns := recordsExportColumns(collection)
+
+		cw := csv.NewWriter(w)
+		headerWritten := false
+
+		write = func(record *models.Record) error {
+			if !headerWritten {
+				if err := cw.Write(columns); err != nil {
+					return err
+				}
+				headerWritten = true
+			}
+
+			data := record.PublicExport()
+
+			values := make([]string, len(columns))
+			for i, column := range columns {
+				values[i] = recordsExportCSVValue(data[column])
+			}
+
+			return cw.Write(values)
+		}
+		flush = func() error {
+			if !headerWritten {
+				// always write the header even if there are no records
+				if err := cw.Write(columns); err != nil {
+					return err
+				}
+				headerWritten = true
+			}
+
+			cw.Flush()
+			return cw.Error()
+		}
+	case forms.RecordsFormatNDJSON:
+		enc := json.NewEncoder(w)
+
+		write = func(record *models.Record) error {
+			return enc.Encode(record)
+		}
+		flush = func() error {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+
+	info := query.Info()
+	offset := info.Offset
+	remaining := info.Limit // <= 0 means no limit
+
+	for {
+		limit := int64(recordsExportBatchSize)
+		if remaining > 0 && remaining < limit {
+			limit = remaining
+		}
+
+		batch := []*models.Record{}
+		if err := query.Limit(limit).Offset(offset).All(&batch); err != nil {
+			return err
+		}
+
+		fetched := int64(len(batch))
+
+		if beforeWrite != nil && fetched > 0 {
+			var err error
+			batch, err = beforeWrite(batch)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, record := range batch {
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+
+		if err := flush(); err != nil {
+			return err
+		}
+
+		// send the batch to the client before loading the next one
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		offset += fetched
+		if remaining > 0 {
+			remaining -= fetched
+		}
+
+		if fetched < limit || (info.Limit > 0 && remaining <= 0) {
+			return nil
+		}
+	}
+}
+
+// recordsExportColumns returns the CSV export columns of the collection.
+func recordsExportColumns(collection *models.Collection) []string {
+	columns := []string{schema.FieldNameId}
+
+	if collection.IsAuth() {
+		columns = append(
+			columns,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+		)
+	}
+
+	for _, field := range collection.Schema.Fields() {
+		columns = append(columns, field.Name)
+	}
+
+	return append(columns, schema.FieldNameCreated, schema.FieldNameUpdated)
+}
+
+// recordsExportCSVValue normalizes a single record field value for CSV export.
+func recordsExportCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case types.DateTime:
+		return v.String()
+	case types.JsonRaw:
+		return string(v)
+	case bool, int, int64, float64:
+		return cast.ToString(v)
+	default:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	}
+}
@@ -0,0 +1,62 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// resolveRecordSyncConflict triggers the OnSyncConflict hook for a stale
+// record update and retries the save against the current stored revision
+// if a hook handler has resolved the conflict.
+//
+// Note that the resolved record is persisted without revalidating the form,
+// similar to the changes made in the OnRecordBeforeUpdateRequest hook.
+func resolveRecordSyncConflict(
+	app core.App,
+	e *core.RecordUpdateEvent,
+	form *forms.RecordUpsert,
+	save forms.InterceptorNextFunc[*models.Record],
+) error {
+	conflictErr := NewApiError(http.StatusConflict, "The record was modified by another request.", nil)
+
+	serverRecord, err := app.Dao().FindRecordById(e.Collection.Id, e.Record.Id)
+	if err != nil || serverRecord == nil {
+		return conflictErr
+	}
+
+	clientRevision, _, _ := requestRecordRevision(e.HttpContext, e.Collection)
+
+	event := new(core.SyncConflictEvent)
+	event.HttpContext = e.HttpContext
+	event.Collection = e.Collection
+	event.Record = e.Record
+	event.ServerRecord = serverRecord
+	event.Data = RequestInfo(e.HttpContext).Data
+	event.ClientRevision = clientRevision
+
+	if err := app.OnSyncConflict().Trigger(event); err != nil {
+		return err
+	}
+
+	if !event.Resolved || event.Record == nil {
+		return conflictErr
+	}
+
+	form.SetExpectedRevision(serverRecord.GetInt(e.Collection.RevisionField()))
+
+	if err := save(event.Record); err != nil {
+		if errors.Is(err, daos.ErrRecordRevisionConflict) {
+			return conflictErr
+		}
+		return NewBadRequestError("Failed to update record.", err)
+	}
+
+	e.Record = event.Record
+
+	return nil
+}
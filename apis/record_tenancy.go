@@ -0,0 +1,91 @@
+package apis
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/spf13/cast"
+)
+
+// TenantHeader is the request header that the admins could use to
+// impersonate a tenant when accessing tenant scoped collections.
+//
+// Admin requests without the header are not tenant scoped.
+const TenantHeader = "X-Tenant"
+
+// requestTenant returns the tenant id that the request must be scoped to
+// when accessing the records of the provided collection.
+//
+// The returned scoped is false if the collection is not tenant scoped
+// or if the request is from an admin that doesn't impersonate a tenant.
+//
+// The tenant of an auth record request is:
+//   - the auth record id if it is from the tenant collection itself
+//   - otherwise, the auth record field value with the same name as the collection tenant field
+//
+// Returns a forbidden error if the auth record (or guest) doesn't belong to a tenant.
+func requestTenant(requestInfo *models.RequestInfo, collection *models.Collection) (tenantId string, scoped bool, err error) {
+	fieldName := collection.TenantField()
+	if fieldName == "" {
+		return "", false, nil
+	}
+
+	if requestInfo.Admin != nil {
+		tenantId = cast.ToString(requestInfo.Headers[inflector.Snakecase(TenantHeader)])
+		return tenantId, tenantId != "", nil
+	}
+
+	if requestInfo.AuthRecord != nil {
+		var tenantCollectionId string
+		if field := collection.Schema.GetFieldByName(fieldName); field != nil {
+			if options, _ := field.Options.(*schema.RelationOptions); options != nil {
+				tenantCollectionId = options.CollectionId
+			}
+		}
+
+		if requestInfo.AuthRecord.Collection().Id == tenantCollectionId {
+			tenantId = requestInfo.AuthRecord.Id
+		} else {
+			tenantId = requestInfo.AuthRecord.GetString(fieldName)
+		}
+	}
+
+	if tenantId == "" {
+		return "", true, NewForbiddenError("The request is not associated with a tenant.", nil)
+	}
+
+	return tenantId, true, nil
+}
+
+// applyRequestTenantFilter scopes the records query to the request tenant (if any).
+func applyRequestTenantFilter(requestInfo *models.RequestInfo, collection *models.Collection, query *dbx.SelectQuery) error {
+	tenantId, scoped, err := requestTenant(requestInfo, collection)
+	if err != nil {
+		return err
+	}
+
+	if scoped {
+		query.AndWhere(tenantExpr(collection, tenantId))
+	}
+
+	return nil
+}
+
+// tenantExpr returns the records query expression matching the tenant records.
+func tenantExpr(collection *models.Collection, tenantId string) dbx.Expression {
+	return dbx.HashExp{
+		inflector.Columnify(collection.Name) + "." + inflector.Columnify(collection.TenantField()): tenantId,
+	}
+}
+
+// canAccessTenantRecord checks whether the record belongs to the request tenant
+// (always true for not tenant scoped collections and admins without impersonation).
+func canAccessTenantRecord(requestInfo *models.RequestInfo, record *models.Record) bool {
+	tenantId, scoped, err := requestTenant(requestInfo, record.Collection())
+	if err != nil {
+		return false
+	}
+
+	return !scoped || record.GetString(record.Collection().TenantField()) == tenantId
+}
@@ -0,0 +1,88 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// RouteLimits middleware applies the request body size and timeouts limits
+// configured in the app routes settings (see [settings.RoutesConfig.FindLimits]).
+//
+// It is registered by default as pre middleware (see [InitApi]).
+func RouteLimits(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			limits := app.Settings().Routes.FindLimits(c.Request().URL.Path)
+
+			return RequestLimits(limits)(next)(c)
+		}
+	}
+}
+
+// RequestLimits returns a middleware that applies the specified request
+// body size and read/write timeouts limits (zero values are ignored).
+//
+// When used multiple times for the same request (eg. settings route group
+// and route specific limits), the smallest body size limit is applied
+// and the last set timeouts take precedence.
+//
+// Note that the body size also can't exceed the server [ServeConfig.MaxBodySize].
+func RequestLimits(limits settings.RequestLimitsConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			if limits.MaxBodySize > 0 && req.Body != nil && req.Body != http.NoBody {
+				if req.ContentLength > limits.MaxBodySize {
+					return NewApiError(http.StatusRequestEntityTooLarge, "Request entity too large.", nil)
+				}
+
+				req.Body = http.MaxBytesReader(c.Response().Writer, req.Body, limits.MaxBodySize)
+			}
+
+			if limits.ReadTimeout > 0 || limits.WriteTimeout > 0 {
+				rc := http.NewResponseController(c.Response())
+
+				// note: the errors are ignored because not all writers
+				// support deadlines (eg. the tests response recorder)
+				if limits.ReadTimeout > 0 {
+					rc.SetReadDeadline(time.Now().Add(time.Duration(limits.ReadTimeout) * time.Second))
+				}
+
+				if limits.WriteTimeout > 0 {
+					rc.SetWriteDeadline(time.Now().Add(time.Duration(limits.WriteTimeout) * time.Second))
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// maxBodySizeHandler wraps h to limit the body size of all requests
+// (zero or negative maxBodySize means no limit).
+func maxBodySizeHandler(h http.Handler, maxBodySize int64) http.Handler {
+	if maxBodySize <= 0 {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBodySize {
+			w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			json.NewEncoder(w).Encode(NewApiError(http.StatusRequestEntityTooLarge, "Request entity too large.", nil))
+			return
+		}
+
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,139 @@
+package apis_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRouteLimits(t *testing.T) {
+	t.Parallel()
+
+	addEchoRoute := func(e *echo.Echo, path string, middlewares ...echo.MiddlewareFunc) {
+		e.POST(path, func(c echo.Context) error {
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return apis.NewBadRequestError("", err)
+			}
+			return c.String(http.StatusOK, "body:"+string(body))
+		}, middlewares...)
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "no limits",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				addEchoRoute(e, "/custom/limits")
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"body:0123456789"},
+		},
+		{
+			Name:   "body larger than the global limit",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Routes.Limits.MaxBodySize = 5
+				addEchoRoute(e, "/custom/limits")
+			},
+			ExpectedStatus:  413,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "body equal to the global limit",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Routes.Limits.MaxBodySize = 10
+				addEchoRoute(e, "/custom/limits")
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"body:0123456789"},
+		},
+		{
+			Name:   "matching group limit overwriting the global one",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Routes.Limits.MaxBodySize = 5
+				app.Settings().Routes.GroupLimits = []settings.RouteGroupLimitsConfig{
+					{Group: "/custom", MaxBodySize: 100},
+				}
+				addEchoRoute(e, "/custom/limits")
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"body:0123456789"},
+		},
+		{
+			Name:   "non-matching group limit",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Routes.Limits.MaxBodySize = 5
+				app.Settings().Routes.GroupLimits = []settings.RouteGroupLimitsConfig{
+					{Group: "/api", MaxBodySize: 100},
+				}
+				addEchoRoute(e, "/custom/limits")
+			},
+			ExpectedStatus:  413,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "route limit lower than the settings one",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Routes.Limits.MaxBodySize = 100
+				addEchoRoute(e, "/custom/limits", apis.RequestLimits(settings.RequestLimitsConfig{MaxBodySize: 5}))
+			},
+			ExpectedStatus:  413,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "route limit higher than the settings one",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Routes.Limits.MaxBodySize = 5
+				app.Settings().Routes.GroupLimits = []settings.RouteGroupLimitsConfig{
+					{Group: "/custom", MaxBodySize: 8},
+				}
+				addEchoRoute(e, "/custom/limits", apis.RequestLimits(settings.RequestLimitsConfig{MaxBodySize: 100}))
+			},
+			ExpectedStatus:  413,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "timeouts with a writer that doesn't support deadlines",
+			Method: http.MethodPost,
+			Url:    "/custom/limits",
+			Body:   strings.NewReader("0123456789"),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				app.Settings().Routes.Limits.ReadTimeout = 1
+				app.Settings().Routes.Limits.WriteTimeout = 1
+				addEchoRoute(e, "/custom/limits")
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"body:0123456789"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,244 @@
+package apis
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/labstack/echo/v5/middleware"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+)
+
+// routeRulesCORSKey is the context key that is set when a route rule
+// overrides the CORS policy of the current request.
+const routeRulesCORSKey = "routeRulesCORS"
+
+// routeRulesAuthExceptions lists the paths on which the route rules
+// auth requirements are never enforced to prevent locking out the admins.
+var routeRulesAuthExceptions = []string{
+	"/api/admins/auth-with-password",
+	"/api/admins/auth-refresh",
+	"/api/admins/request-password-reset",
+	"/api/admins/confirm-password-reset",
+}
+
+// routeRulePatterns caches the compiled route rule path patterns.
+var routeRulePatterns sync.Map
+
+// RouteRules middleware applies the built-in middlewares configured in the
+// app routes settings (auth requirements, rate limits and the route rules,
+// route groups and collections CORS overrides) to the matching requests.
+//
+// It is registered by default as pre middleware (see [InitApi]),
+// so that the rules are evaluated before the script and core route handlers.
+func RouteRules(app core.App) echo.MiddlewareFunc {
+	limiter := ratelimit.New()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Routes
+			if len(config.Rules) == 0 && len(config.CORSOverrides) == 0 {
+				return next(c) // no rules
+			}
+
+			req := c.Request()
+			path := req.URL.Path
+
+			method := req.Method
+			preflight := method == http.MethodOptions && req.Header.Get(echo.HeaderAccessControlRequestMethod) != ""
+			if preflight {
+				method = req.Header.Get(echo.HeaderAccessControlRequestMethod)
+			}
+
+			cors := routeCORSOverride(app, config.CORSOverrides, path)
+
+			var middlewares []echo.MiddlewareFunc
+
+			for i, rule := range config.Rules {
+				if !routeRuleMatch(rule, method, path) {
+					continue
+				}
+
+				if cors == nil && rule.CORS.Enabled {
+					cors = &config.Rules[i].CORS
+				}
+
+				// the preflight requests are not authorized
+				if preflight {
+					continue
+				}
+
+				if rule.RateLimit != "" {
+					if profile := config.FindRateLimit(rule.RateLimit); profile != nil {
+						middlewares = append(middlewares, routeRuleRateLimit(limiter, rule, profile))
+					}
+				}
+
+				if rule.Auth != "" && !list.ExistInSlice(path, routeRulesAuthExceptions) && !strings.HasPrefix(path, app.Settings().AdminUI.TrailedPath()) {
+					middlewares = append(middlewares, routeAuthMiddleware(rule.Auth, rule.AuthCollections))
+				}
+			}
+
+			if cors != nil {
+				c.Set(routeRulesCORSKey, true)
+
+				corsMiddleware, err := middleware.CORSConfig{
+					AllowOrigins:     cors.AllowOrigins,
+					AllowHeaders:     cors.AllowHeaders,
+					AllowCredentials: cors.AllowCredentials,
+					MaxAge:           cors.MaxAge,
+					ExposeHeaders:    []string{UploadIdHeader},
+				}.ToMiddleware()
+				if err != nil {
+					return NewBadRequestError("Invalid route rule CORS config.", err)
+				}
+
+				middlewares = append([]echo.MiddlewareFunc{corsMiddleware}, middlewares...)
+			}
+
+			h := next
+			for i := len(middlewares) - 1; i >= 0; i-- {
+				h = middlewares[i](h)
+			}
+
+			return h(c)
+		}
+	}
+}
+
+// routeCORSOverride returns the most specific enabled CORS override
+// matching the request path (if any).
+//
+// Collection overrides take precedence over the route group ones and
+// from the route groups the one with the longest prefix is selected.
+func routeCORSOverride(app core.App, overrides []settings.CORSOverrideConfig, path string) *settings.CORSConfig {
+	var group *settings.CORSOverrideConfig
+	var collection *models.Collection
+	var collectionResolved bool
+
+	for i, override := range overrides {
+		if !override.CORS.Enabled {
+			continue
+		}
+
+		if len(override.Collections) > 0 {
+			if !collectionResolved {
+				collectionResolved = true
+				if identifier := routePathCollection(path); identifier != "" {
+					collection, _ = core.FindCachedCollectionByNameOrId(app, identifier)
+				}
+			}
+
+			if collection == nil {
+				continue
+			}
+
+			for _, nameOrId := range override.Collections {
+				if strings.EqualFold(nameOrId, collection.Name) || nameOrId == collection.Id {
+					return &overrides[i].CORS
+				}
+			}
+
+			continue
+		}
+
+		prefix := strings.TrimSuffix(override.Group, "/")
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+
+		if group == nil || len(prefix) > len(strings.TrimSuffix(group.Group, "/")) {
+			group = &overrides[i]
+		}
+	}
+
+	if group != nil {
+		return &group.CORS
+	}
+
+	return nil
+}
+
+// routePathCollection extracts the collection name or id
+// from a records or files api request path.
+func routePathCollection(path string) string {
+	for _, prefix := range []string{"/api/collections/", "/api/files/"} {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+
+		identifier, _, hasSubpath := strings.Cut(path[len(prefix):], "/")
+		if !hasSubpath {
+			return "" // not a records or files request
+		}
+
+		return identifier
+	}
+
+	return ""
+}
+
+// routeRuleMatch checks whether the request method and path match the route rule.
+func routeRuleMatch(rule settings.RouteRuleConfig, method string, path string) bool {
+	if len(rule.Methods) > 0 && !list.ExistInSlice(method, rule.Methods) {
+		return false
+	}
+
+	if !strings.Contains(rule.Path, "*") {
+		return rule.Path == path
+	}
+
+	var pattern *regexp.Regexp
+	if cached, ok := routeRulePatterns.Load(rule.Path); ok {
+		pattern = cached.(*regexp.Regexp)
+	} else {
+		pattern = regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(rule.Path), `\*`, ".*") + "$")
+		routeRulePatterns.Store(rule.Path, pattern)
+	}
+
+	return pattern.MatchString(path)
+}
+
+// routeAuthMiddleware returns the built-in auth middleware
+// of a route rule or proxy auth requirement.
+func routeAuthMiddleware(auth string, authCollections []string) echo.MiddlewareFunc {
+	switch auth {
+	case settings.RouteAuthAdmin:
+		return RequireAdminAuth()
+	case settings.RouteAuthRecord:
+		return RequireRecordAuth(authCollections...)
+	case settings.RouteAuthAdminOrRecord:
+		return RequireAdminOrRecordAuth(authCollections...)
+	case settings.RouteAuthGuest:
+		return RequireGuestOnly()
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return NewForbiddenError("Unsupported route auth requirement.", nil)
+		}
+	}
+}
+
+// routeRuleRateLimit returns a middleware that limits the number of
+// requests per client IP for the specified route rule and rate limit profile.
+func routeRuleRateLimit(limiter *ratelimit.Limiter, rule settings.RouteRuleConfig, profile *settings.RateLimitConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := profile.Name + "@" + rule.Path + "@" + c.RealIP()
+
+			if !limiter.Allow(key, profile.MaxRequests, time.Duration(profile.Duration)*time.Second) {
+				return NewApiError(http.StatusTooManyRequests, "Too many requests.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
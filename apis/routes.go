@@ -0,0 +1,216 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// Registered routes sources.
+const (
+	RouteSourceCore   = "core"
+	RouteSourcePlugin = "plugin"
+	RouteSourceScript = "script"
+)
+
+// SourcedRoute is an [echo.Route] annotated with its registration source,
+// allowing the routes listing and the conflicts report to point to
+// the script or plugin that registered it.
+//
+// Example:
+//
+//	e.Router.AddRoute(apis.SourcedRoute{
+//		Route:  echo.Route{Method: http.MethodGet, Path: "/hello", Handler: handler},
+//		Source: apis.RouteSourcePlugin,
+//		Origin: "myplugin",
+//	})
+type SourcedRoute struct {
+	echo.Route
+
+	// Source is the route registration source (plugin or script).
+	Source string
+
+	// Origin is an optional source identifier (eg. the script file name).
+	Origin string
+}
+
+// RouteDefinition describes a single registered route.
+type RouteDefinition struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	Origin string `json:"origin"`
+
+	// Conflicts lists the other registrations of the same
+	// (or an equivalent) route in "source[:origin]" format.
+	Conflicts []string `json:"conflicts"`
+}
+
+// SourceLabel returns the human readable route registration source
+// in "source[:origin]" format.
+func (d *RouteDefinition) SourceLabel() string {
+	if d.Origin == "" {
+		return d.Source
+	}
+
+	return d.Source + ":" + d.Origin
+}
+
+// routesRegistry is an [echo.Router] wrapper that keeps track
+// of the registration source of each added route.
+type routesRegistry struct {
+	echo.Router
+
+	mu          sync.RWMutex
+	definitions []*RouteDefinition
+	coreLoaded  bool
+}
+
+func newRoutesRegistry(router echo.Router) *routesRegistry {
+	return &routesRegistry{Router: router}
+}
+
+// markCoreLoaded marks all routes registered from now on as plugin routes.
+func (r *routesRegistry) markCoreLoaded() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.coreLoaded = true
+}
+
+// Add implements [echo.Router.Add] interface method.
+//
+// Adding the same script route (same method and path) more than once
+// returns an error since it is most likely a copy-paste mistake.
+// All other duplicated or equivalent routes (eg. a plugin overwriting
+// a core route) are allowed but they are reported as conflicts.
+func (r *routesRegistry) Add(routable echo.Routable) (echo.RouteInfo, error) {
+	route := routable.ToRoute()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	def := &RouteDefinition{
+		Method:    route.Method,
+		Path:      route.Path,
+		Source:    RouteSourcePlugin,
+		Conflicts: []string{},
+	}
+	if sourced, ok := routable.(SourcedRoute); ok {
+		def.Source = sourced.Source
+		def.Origin = sourced.Origin
+	} else if !r.coreLoaded {
+		def.Source = RouteSourceCore
+	}
+
+	normalizedPath := normalizeRoutePath(def.Path)
+
+	for _, existing := range r.definitions {
+		if existing.Method != def.Method || normalizeRoutePath(existing.Path) != normalizedPath {
+			continue
+		}
+
+		if def.Source == RouteSourceScript && existing.Source == RouteSourceScript && existing.Path == def.Path {
+			return nil, fmt.Errorf(
+				"route %s %s from %q is already registered by %q",
+				def.Method, def.Path, def.Origin, existing.Origin,
+			)
+		}
+
+		existing.Conflicts = append(existing.Conflicts, def.SourceLabel())
+		def.Conflicts = append(def.Conflicts, existing.SourceLabel())
+	}
+
+	ri, err := r.Router.Add(routable)
+	if err != nil {
+		return nil, err
+	}
+
+	r.definitions = append(r.definitions, def)
+
+	return ri, nil
+}
+
+// Definitions returns a copy of all registered route definitions
+// (including the overwritten ones) in their registration order.
+func (r *routesRegistry) Definitions() []*RouteDefinition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*RouteDefinition, len(r.definitions))
+	for i, def := range r.definitions {
+		clone := *def
+		clone.Conflicts = append([]string{}, def.Conflicts...)
+		result[i] = &clone
+	}
+
+	return result
+}
+
+// normalizeRoutePath replaces the path param names with a placeholder
+// so that equivalent routes like "/a/:id" and "/a/:slug" could be compared.
+func normalizeRoutePath(path string) string {
+	if path == "" {
+		path = "/"
+	}
+
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			parts[i] = ":"
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// RouteDefinitions returns the definitions of all routes registered
+// in the default router of the provided echo instance.
+//
+// It returns nil if the echo instance was not created with [InitApi].
+func RouteDefinitions(e *echo.Echo) []*RouteDefinition {
+	registry, ok := e.Router().(*routesRegistry)
+	if !ok {
+		return nil
+	}
+
+	return registry.Definitions()
+}
+
+// RouteConflicts returns only the route definitions that have conflicts.
+func RouteConflicts(e *echo.Echo) []*RouteDefinition {
+	result := []*RouteDefinition{}
+
+	for _, def := range RouteDefinitions(e) {
+		if len(def.Conflicts) > 0 {
+			result = append(result, def)
+		}
+	}
+
+	return result
+}
+
+// bindRoutesApi registers the routes listing api endpoint.
+func bindRoutesApi(app core.App, rg *echo.Group) {
+	api := routesApi{app: app}
+
+	subGroup := rg.Group("/routes", RequireAdminAuth())
+	subGroup.GET("", api.list)
+}
+
+type routesApi struct {
+	app core.App
+}
+
+func (api *routesApi) list(c echo.Context) error {
+	definitions := RouteDefinitions(c.Echo())
+	if definitions == nil {
+		definitions = []*RouteDefinition{}
+	}
+
+	return c.JSON(http.StatusOK, definitions)
+}
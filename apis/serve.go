@@ -3,7 +3,10 @@ package apis
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"path/filepath"
@@ -22,6 +25,8 @@ import (
 	"github.com/pocketbase/pocketbase/tools/migrate"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // ServeConfig defines a configuration struct for apis.Serve().
@@ -45,8 +50,69 @@ type ServeConfig struct {
 
 	// AllowedOrigins is an optional list of CORS origins (default to "*").
 	AllowedOrigins []string
+
+	// ShutdownTimeout is the max duration to wait for the in-flight
+	// requests to complete on app termination (default to 30s).
+	//
+	// The requests that are still running after the timeout are cancelled.
+	ShutdownTimeout time.Duration
+
+	// Preflight specifies how to handle the issues found by the
+	// startup preflight checks (eg. broken hook scripts).
+	//
+	// Supported values are "strict" (default), "warn" and "off".
+	Preflight string
+
+	// MaxBodySize is the optional max request body size in bytes of all routes.
+	//
+	// It is a hard limit and the routes settings limits (see [RouteLimits])
+	// and the [RequestLimits] middlewares could only lower it.
+	MaxBodySize int64
+
+	// ReadTimeout is the max duration for reading the entire request,
+	// including the body (default to 10m, negative means no timeout).
+	ReadTimeout time.Duration
+
+	// ReadHeaderTimeout is the max duration for reading the request headers
+	// (default to 30s, negative means no timeout).
+	//
+	// Unlike the other timeouts it can't be changed per route because
+	// the headers are read before the request routing.
+	ReadHeaderTimeout time.Duration
+
+	// WriteTimeout is the max duration for writing the response
+	// (default to no timeout because it also limits the realtime connections).
+	WriteTimeout time.Duration
+
+	// H2C enables the unencrypted HTTP/2 support for the plain HTTP server
+	// (eg. when running behind a trusted reverse proxy that talks
+	// HTTP/2 with the upstream).
+	//
+	// It is ignored when HttpsAddr is set because the HTTPS server
+	// already negotiates HTTP/2 via ALPN.
+	H2C bool
+
+	// Http3 is an optional [Http3Server] factory that enables the
+	// HTTP/3 (QUIC) serving alongside the HTTPS server.
+	//
+	// The HTTP/3 server is advertised to the clients with the Alt-Svc
+	// response header of the HTTPS server.
+	//
+	// It requires HttpsAddr to be set.
+	Http3 Http3ServerFunc
+
+	// Http3Addr is the optional UDP address to listen for the HTTP/3
+	// server (default to HttpsAddr).
+	Http3Addr string
 }
 
+// Default [ServeConfig] timeouts.
+const (
+	DefaultShutdownTimeout   = 30 * time.Second
+	DefaultReadTimeout       = 10 * time.Minute
+	DefaultReadHeaderTimeout = 30 * time.Second
+)
+
 // Serve starts a new app web server.
 //
 // NB! The app should be bootstrapped before starting the web server.
@@ -63,6 +129,41 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 		config.AllowedOrigins = []string{"*"}
 	}
 
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+
+	if config.ReadTimeout == 0 {
+		config.ReadTimeout = DefaultReadTimeout
+	}
+
+	if config.ReadHeaderTimeout == 0 {
+		config.ReadHeaderTimeout = DefaultReadHeaderTimeout
+	}
+
+	if config.Preflight == "" {
+		config.Preflight = PreflightStrict
+	}
+
+	if config.Http3 != nil && config.HttpsAddr == "" {
+		return nil, errors.New("the HTTP/3 server requires HttpsAddr to be set")
+	}
+
+	if config.Preflight != PreflightOff {
+		report, err := RunPreflight(app)
+		if err != nil {
+			return nil, err
+		}
+
+		if report.HasIssues() {
+			color.Red("%s", strings.TrimSpace(report.String()))
+
+			if config.Preflight != PreflightWarn {
+				return nil, errors.New("preflight checks failed (use --preflight=warn to start anyway)")
+			}
+		}
+	}
+
 	// ensure that the latest migrations are applied before starting the server
 	if err := runMigrations(app); err != nil {
 		return nil, err
@@ -84,9 +185,13 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 
 	// configure cors
 	router.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		Skipper:      middleware.DefaultSkipper,
-		AllowOrigins: config.AllowedOrigins,
-		AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		Skipper: func(c echo.Context) bool {
+			// the CORS policy was already overwritten by a route rule
+			return c.Get(routeRulesCORSKey) != nil
+		},
+		AllowOrigins:  config.AllowedOrigins,
+		AllowMethods:  []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
+		ExposeHeaders: []string{UploadIdHeader},
 	}))
 
 	// start http server
@@ -151,11 +256,11 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 			GetCertificate: certManager.GetCertificate,
 			NextProtos:     []string{acme.ALPNProto},
 		},
-		ReadTimeout:       10 * time.Minute,
-		ReadHeaderTimeout: 30 * time.Second,
-		// WriteTimeout: 60 * time.Second, // breaks sse!
-		Handler: router,
-		Addr:    mainAddr,
+		ReadTimeout:       config.ReadTimeout,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		Handler:           maxBodySizeHandler(router, config.MaxBodySize),
+		Addr:              mainAddr,
 		BaseContext: func(l net.Listener) context.Context {
 			return baseCtx
 		},
@@ -171,6 +276,16 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 		return nil, err
 	}
 
+	for _, def := range RouteConflicts(router) {
+		app.Logger().Warn(
+			"Route conflict",
+			"method", def.Method,
+			"path", def.Path,
+			"source", def.SourceLabel(),
+			"conflicts", def.Conflicts,
+		)
+	}
+
 	if config.ShowStartBanner {
 		schema := "http"
 		addr := server.Addr
@@ -195,22 +310,48 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 
 		regular := color.New()
 		regular.Printf("├─ REST API: %s\n", color.CyanString("%s://%s/api/", schema, addr))
-		regular.Printf("└─ Admin UI: %s\n", color.CyanString("%s://%s/_/", schema, addr))
+		regular.Printf("└─ Admin UI: %s\n", color.CyanString("%s://%s%s", schema, addr, app.Settings().AdminUI.TrailedPath()))
 	}
 
+	handover := newServeHandover(app)
+
+	// close the long-lived realtime connections once the server listeners
+	// are closed so that the clients could reconnect to another instance
+	server.RegisterOnShutdown(func() {
+		for clientId := range app.SubscriptionsBroker().Clients() {
+			DisconnectRealtimeClient(app, clientId)
+		}
+	})
+
 	// WaitGroup to block until server.ShutDown() returns because Serve and similar methods exit immediately.
 	// Note that the WaitGroup would not do anything if the app.OnTerminate() hook isn't triggered.
 	var wg sync.WaitGroup
 
 	// try to gracefully shutdown the server on app termination
-	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
-		cancelBaseCtx()
+	// (registered as first handler so that the in-flight requests are
+	// drained before the other cleanups, eg. the queued logs flush)
+	app.OnTerminate().PreAdd(func(e *core.TerminateEvent) error {
+		app.Store().Remove(core.StoreKeyReady)
+
+		handover.stop()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
 		defer cancel()
 
 		wg.Add(1)
-		server.Shutdown(ctx)
+
+		// stop accepting new connections and wait for the in-flight requests
+		if err := server.Shutdown(ctx); err != nil {
+			app.Logger().Warn(
+				"Failed to gracefully drain the in-flight requests",
+				slog.String("error", err.Error()),
+			)
+		}
+
+		// cancel the remaining requests (if any)
+		cancelBaseCtx()
+		server.Close()
+
 		if e.IsRestart {
 			// wait for execve and other handlers up to 5 seconds before exit
 			time.AfterFunc(5*time.Second, func() {
@@ -231,18 +372,111 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 	// not really useful when combined with the blocking serve calls
 	// ---
 
+	listenAddr := server.Addr
+	if listenAddr == "" {
+		if config.HttpsAddr != "" {
+			listenAddr = ":https"
+		} else {
+			listenAddr = ":http"
+		}
+	}
+
+	listener, err := serveListenConfig().Listen(context.Background(), "tcp", listenAddr)
+	if err != nil {
+		return server, err
+	}
+
 	// start HTTPS server
 	if config.HttpsAddr != "" {
+		if config.Http3 != nil {
+			http3Server, err := startHttp3Server(app, config, server, listenAddr)
+			if err != nil {
+				listener.Close()
+				return server, err
+			}
+
+			app.OnTerminate().PreAdd(func(e *core.TerminateEvent) error {
+				stopHttp3Server(app, http3Server, config.ShutdownTimeout)
+				return nil
+			})
+		}
+
 		// if httpAddr is set, start an HTTP server to redirect the traffic to the HTTPS version
 		if config.HttpAddr != "" {
-			go http.ListenAndServe(config.HttpAddr, certManager.HTTPHandler(nil))
+			redirectListener, err := serveListenConfig().Listen(context.Background(), "tcp", config.HttpAddr)
+			if err != nil {
+				listener.Close()
+				return server, err
+			}
+
+			go http.Serve(redirectListener, certManager.HTTPHandler(nil))
 		}
 
-		return server, server.ListenAndServeTLS("", "")
+		handover.start()
+
+		app.Store().Set(core.StoreKeyReady, true)
+
+		return server, server.ServeTLS(listener, "", "")
 	}
 
+	if config.H2C {
+		server.Handler = h2c.NewHandler(server.Handler, &http2.Server{})
+	}
+
+	handover.start()
+
+	app.Store().Set(core.StoreKeyReady, true)
+
 	// OR start HTTP server
-	return server, server.ListenAndServe()
+	return server, server.Serve(listener)
+}
+
+// startHttp3Server creates and starts in a goroutine the
+// config.Http3 server and advertises it via the Alt-Svc header
+// of the HTTPS server responses.
+func startHttp3Server(app core.App, config ServeConfig, server *http.Server, httpsAddr string) (Http3Server, error) {
+	addr := config.Http3Addr
+	if addr == "" {
+		addr = httpsAddr
+	}
+
+	altSvc, err := altSvcValue(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP/3 address %q: %w", addr, err)
+	}
+
+	tlsConfig := server.TLSConfig.Clone()
+	tlsConfig.NextProtos = []string{"h3"}
+
+	http3Server := config.Http3(addr, tlsConfig, server.Handler)
+
+	server.Handler = altSvcHandler(server.Handler, altSvc)
+
+	go func() {
+		if err := http3Server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			app.Logger().Error("HTTP/3 server error", slog.String("error", err.Error()))
+		}
+	}()
+
+	return http3Server, nil
+}
+
+// stopHttp3Server gracefully stops the HTTP/3 server (if supported)
+// and closes it after the timeout.
+func stopHttp3Server(app core.App, http3Server Http3Server, timeout time.Duration) {
+	if s, ok := http3Server.(interface{ Shutdown(context.Context) error }); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			app.Logger().Warn(
+				"Failed to gracefully shutdown the HTTP/3 server",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	http3Server.Close()
 }
 
 type migrationsConnection struct {
@@ -0,0 +1,178 @@
+package apis
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// handoverPidEnv is the env variable with the pid of the old app process
+// that should be terminated once the new process is ready to accept connections.
+const handoverPidEnv = "PB_HANDOVER_PID"
+
+// serveHandover implements the zero-downtime restart of a serving app.
+//
+// On SIGHUP a new app process is started with the same arguments
+// (eg. after replacing the executable with ghupdate) and once its
+// listener is bound (with SO_REUSEPORT), it sends SIGTERM to the old
+// process which stops accepting new connections and drains the
+// in-flight requests before exit.
+//
+// Note that the new process has a different pid, so the handover restart
+// is not suitable when the app is managed by a process supervisor that
+// tracks the main pid (eg. systemd with Type=simple).
+type serveHandover struct {
+	app      core.App
+	execPath string
+
+	mux     sync.Mutex
+	sigCh   chan os.Signal
+	running bool
+}
+
+func newServeHandover(app core.App) *serveHandover {
+	// resolve the executable path in advance because it could be
+	// renamed later (eg. ghupdate renames the old executable)
+	execPath, _ := os.Executable()
+
+	return &serveHandover{
+		app:      app,
+		execPath: execPath,
+	}
+}
+
+// start writes the serve pid file, terminates the previous app process
+// (if the current one was started by a handover restart) and starts
+// listening for the handover signal.
+func (h *serveHandover) start() {
+	err := os.WriteFile(h.pidFile(), []byte(strconv.Itoa(os.Getpid())), 0644)
+	if err != nil {
+		h.app.Logger().Warn("Failed to write the serve pid file", slog.String("error", err.Error()))
+	}
+
+	h.terminatePrev()
+
+	if !handoverSupported {
+		return
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.sigCh = make(chan os.Signal, 1)
+	signal.Notify(h.sigCh, syscall.SIGHUP)
+
+	go func(sigCh chan os.Signal) {
+		for range sigCh {
+			if err := h.restart(); err != nil {
+				h.app.Logger().Error("Failed to start the handover restart", slog.String("error", err.Error()))
+			}
+		}
+	}(h.sigCh)
+}
+
+// stop stops listening for the handover signal and removes
+// the serve pid file (if it wasn't replaced by a new process).
+func (h *serveHandover) stop() {
+	h.mux.Lock()
+	if h.sigCh != nil {
+		signal.Stop(h.sigCh)
+		close(h.sigCh)
+		h.sigCh = nil
+	}
+	h.mux.Unlock()
+
+	raw, err := os.ReadFile(h.pidFile())
+	if err == nil && strings.TrimSpace(string(raw)) == strconv.Itoa(os.Getpid()) {
+		os.Remove(h.pidFile())
+	}
+}
+
+// restart starts a new app process that will take over the serving.
+func (h *serveHandover) restart() error {
+	if h.execPath == "" {
+		return errors.New("unknown executable path")
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if h.running {
+		return errors.New("a handover restart is already in progress")
+	}
+
+	cmd := exec.Command(h.execPath, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), handoverPidEnv+"="+strconv.Itoa(os.Getpid()))
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	h.running = true
+
+	h.app.Logger().Info("Started a new app process for handover", slog.Int("pid", cmd.Process.Pid))
+
+	go func() {
+		// the new process is expected to terminate the current one
+		// so normally it shouldn't exit unless it has failed to start
+		err := cmd.Wait()
+
+		h.mux.Lock()
+		h.running = false
+		h.mux.Unlock()
+
+		attrs := []any{slog.Int("pid", cmd.Process.Pid)}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		h.app.Logger().Error("The handover app process has exited", attrs...)
+	}()
+
+	return nil
+}
+
+// terminatePrev sends SIGTERM to the old app process
+// that has started the current one with a handover restart.
+func (h *serveHandover) terminatePrev() {
+	raw := os.Getenv(handoverPidEnv)
+	if raw == "" {
+		return
+	}
+
+	// don't propagate to the processes started by the current one
+	os.Unsetenv(handoverPidEnv)
+
+	pid, err := strconv.Atoi(raw)
+	if err != nil {
+		h.app.Logger().Warn("Invalid handover pid", slog.String("pid", raw))
+		return
+	}
+
+	process, err := os.FindProcess(pid)
+	if err == nil {
+		err = process.Signal(syscall.SIGTERM)
+	}
+	if err != nil {
+		h.app.Logger().Warn(
+			"Failed to terminate the old app process",
+			slog.Int("pid", pid),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (h *serveHandover) pidFile() string {
+	return filepath.Join(h.app.DataDir(), core.ServePidFileName)
+}
@@ -0,0 +1,63 @@
+package apis
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Http3Server defines the minimal interface of an HTTP/3 (QUIC) server
+// that could be started alongside the HTTPS server (see [ServeConfig.Http3]).
+//
+// It is implemented for example by the quic-go http3.Server.
+//
+// If the server also has a Shutdown(context.Context) error method,
+// it is used for the graceful shutdown on app termination.
+type Http3Server interface {
+	ListenAndServe() error
+	Close() error
+}
+
+// Http3ServerFunc defines a factory func that creates a new [Http3Server]
+// listening on the UDP addr with the provided TLS config and handler.
+//
+// Example with quic-go:
+//
+//	func(addr string, tlsConfig *tls.Config, handler http.Handler) apis.Http3Server {
+//		return &http3.Server{
+//			Addr:      addr,
+//			TLSConfig: http3.ConfigureTLSConfig(tlsConfig),
+//			Handler:   handler,
+//		}
+//	}
+type Http3ServerFunc func(addr string, tlsConfig *tls.Config, handler http.Handler) Http3Server
+
+// DefaultAltSvcMaxAge is the default Alt-Svc max age (in seconds)
+// used to advertise the HTTP/3 server.
+const DefaultAltSvcMaxAge = 86400
+
+// altSvcValue returns the Alt-Svc header value advertising
+// an HTTP/3 server listening on the UDP addr.
+func altSvcValue(addr string) (string, error) {
+	_, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+
+	port, err := net.LookupPort("udp", portStr)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`h3=":%d"; ma=%d`, port, DefaultAltSvcMaxAge), nil
+}
+
+// altSvcHandler wraps h to add the specified Alt-Svc header to all responses.
+func altSvcHandler(h http.Handler, altSvc string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Alt-Svc", altSvc)
+
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package apis
+
+import "net"
+
+// handoverSupported indicates whether the zero-downtime handover
+// restart is supported on the current platform.
+const handoverSupported = false
+
+// serveListenConfig returns the server listeners config.
+func serveListenConfig() *net.ListenConfig {
+	return &net.ListenConfig{}
+}
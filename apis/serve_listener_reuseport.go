@@ -0,0 +1,35 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package apis
+
+import (
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// handoverSupported indicates whether the zero-downtime handover
+// restart is supported on the current platform.
+const handoverSupported = true
+
+// serveListenConfig returns the server listeners config.
+//
+// The listeners are created with SO_REUSEPORT so that a new app process
+// could bind to the same address while the old one is still draining.
+func serveListenConfig() *net.ListenConfig {
+	return &net.ListenConfig{
+		Control: func(network, address string, conn syscall.RawConn) error {
+			var sockErr error
+
+			err := conn.Control(func(fd uintptr) {
+				sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+			})
+			if err != nil {
+				return err
+			}
+
+			return sockErr
+		},
+	}
+}
@@ -0,0 +1,165 @@
+package apis
+
+import (
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/spf13/cast"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// sessionTouchInterval is the min interval between two session
+// last activity updates from the authenticated requests.
+const sessionTouchInterval = time.Minute
+
+// authSession returns the auth session and a new refresh token
+// for the current auth request.
+//
+// If the request context has a loaded refresh token of the same owner,
+// it is rotated and its session is returned.
+// If the request auth token is bound to a session of the same owner,
+// a new refresh token from that session is issued.
+// Otherwise a new session (aka. device login) is created.
+//
+// The returned session could be nil for refresh tokens issued
+// before the sessions tracking.
+//
+// collectionId is expected to be empty for admins.
+func authSession(app core.App, c echo.Context, collectionId string, recordId string) (*models.Session, string, error) {
+	model, _ := c.Get(ContextRefreshTokenKey).(*models.RefreshToken)
+	if model != nil && model.CollectionId == collectionId && model.RecordId == recordId {
+		token, err := tokens.RotateRefreshToken(app, model)
+		if err != nil {
+			return nil, "", err
+		}
+
+		session, _ := app.Dao().FindSessionById(model.Family)
+		if session != nil {
+			if err := touchSession(app, c, session); err != nil {
+				return nil, "", err
+			}
+		}
+
+		return session, token, nil
+	}
+
+	session, _ := c.Get(ContextAuthSessionKey).(*models.Session)
+	if session == nil || session.CollectionId != collectionId || session.RecordId != recordId {
+		var err error
+		session, err = createSession(app, c, collectionId, recordId)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	token, err := tokens.NewSessionRefreshToken(app, session)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return session, token, nil
+}
+
+// checkAuthSession checks whether the session of the provided auth token
+// claims (if any) is still active and belongs to the token owner.
+//
+// On success the session is loaded in the request context and its
+// last activity is updated (at most once per [sessionTouchInterval]).
+//
+// collectionId is expected to be empty for admins.
+func checkAuthSession(app core.App, c echo.Context, claims jwt.MapClaims, collectionId string, recordId string) bool {
+	sessionId := cast.ToString(claims[tokens.ClaimSessionId])
+	if sessionId == "" {
+		return true // not bound to a session
+	}
+
+	session, err := app.Dao().FindSessionById(sessionId)
+	if err != nil || session.CollectionId != collectionId || session.RecordId != recordId {
+		return false
+	}
+
+	c.Set(ContextAuthSessionKey, session)
+
+	if session.LastSeen.Time().Add(sessionTouchInterval).Before(time.Now()) {
+		if err := touchSession(app, c, session); err != nil {
+			app.Logger().Debug("Failed to update the session last activity", slog.String("sessionId", session.Id), slog.String("error", err.Error()))
+		}
+	}
+
+	return true
+}
+
+func createSession(app core.App, c echo.Context, collectionId string, recordId string) (*models.Session, error) {
+	session := &models.Session{
+		CollectionId: collectionId,
+		RecordId:     recordId,
+		UserAgent:    c.Request().UserAgent(),
+		Ip:           requestIp(c),
+		LastSeen:     types.NowDateTime(),
+	}
+
+	event := new(core.SessionEvent)
+	event.HttpContext = c
+	event.Session = session
+
+	err := app.OnSessionCreate().Trigger(event, func(e *core.SessionEvent) error {
+		// the sessions are internal bookkeeping records
+		// and are not expected to trigger the OnModel* hooks
+		return app.Dao().WithoutHooks().SaveSession(e.Session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event.Session, nil
+}
+
+func touchSession(app core.App, c echo.Context, session *models.Session) error {
+	session.UserAgent = c.Request().UserAgent()
+	session.Ip = requestIp(c)
+	session.LastSeen = types.NowDateTime()
+
+	return app.Dao().WithoutHooks().SaveSession(session)
+}
+
+// revokeSession deletes the provided session and revokes its refresh tokens.
+func revokeSession(app core.App, c echo.Context, session *models.Session) error {
+	event := new(core.SessionEvent)
+	event.HttpContext = c
+	event.Session = session
+
+	return app.OnSessionRevoke().Trigger(event, func(e *core.SessionEvent) error {
+		return app.Dao().WithoutHooks().DeleteSession(e.Session)
+	})
+}
+
+// revokeAllSessions deletes all sessions of the specified owner.
+//
+// collectionId is expected to be empty for admins.
+func revokeAllSessions(app core.App, c echo.Context, collectionId string, recordId string) error {
+	sessions, err := app.Dao().FindAllSessionsByOwner(collectionId, recordId)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if err := revokeSession(app, c, session); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func requestIp(c echo.Context) string {
+	ip, _, _ := net.SplitHostPort(c.Request().RemoteAddr)
+
+	return realUserIp(c.Request(), ip)
+}
@@ -17,6 +17,7 @@ func bindSettingsApi(app core.App, rg *echo.Group) {
 	subGroup := rg.Group("/settings", ActivityLogger(app), RequireAdminAuth())
 	subGroup.GET("", api.list)
 	subGroup.PATCH("", api.set)
+	subGroup.GET("/effective", api.effective)
 	subGroup.POST("/test/s3", api.testS3)
 	subGroup.POST("/test/email", api.testEmail)
 	subGroup.POST("/apple/generate-client-secret", api.generateAppleClientSecret)
@@ -45,6 +46,21 @@ func (api *settingsApi) list(c echo.Context) error {
 	})
 }
 
+// effective returns the effective (aka. merged with the config file
+// and env variables overrides) redacted settings and the sources
+// of the overridden fields (the other fields come from the stored settings).
+func (api *settingsApi) effective(c echo.Context) error {
+	settings, err := api.app.Settings().RedactClone()
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"settings": settings,
+		"sources":  api.app.Settings().Overrides(),
+	})
+}
+
 func (api *settingsApi) set(c echo.Context) error {
 	form := forms.NewSettingsUpsert(api.app)
 
@@ -0,0 +1,248 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// bindShareApi registers the record share links api endpoints.
+func bindShareApi(app core.App, rg *echo.Group) {
+	api := shareApi{app: app, files: newFileApi(app)}
+
+	manageGroup := rg.Group(
+		"/collections/:collection/records/:id/shares",
+		ActivityLogger(app),
+		RequireAdminOrRecordAuth(),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	manageGroup.GET("", api.list)
+	manageGroup.POST("", api.create)
+	manageGroup.GET("/comments", api.listRecordComments)
+	manageGroup.DELETE("/:shareId", api.revoke)
+
+	publicGroup := rg.Group("/share/:token", ActivityLogger(app))
+	publicGroup.GET("", api.view)
+	publicGroup.HEAD("/files/:filename", api.download)
+	publicGroup.GET("/files/:filename", api.download)
+	publicGroup.GET("/comments", api.listComments)
+	publicGroup.POST("/comments", api.createComment)
+}
+
+type shareApi struct {
+	app   core.App
+	files *fileApi
+}
+
+// findManageableRecord returns the path record if the current request
+// admin or auth record is allowed to manage its share links
+// (aka. it satisfies the collection update API rule).
+func (api *shareApi) findManageableRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	if ok, _ := api.app.Dao().CanAccessRecord(record, RequestInfo(c), collection.UpdateRule); !ok {
+		return nil, NewForbiddenError("You are not allowed to manage the record share links.", nil)
+	}
+
+	return record, nil
+}
+
+func (api *shareApi) list(c echo.Context) error {
+	record, err := api.findManageableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	links, err := api.app.Dao().FindShareLinksByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record share links.", err)
+	}
+
+	return c.JSON(http.StatusOK, links)
+}
+
+func (api *shareApi) create(c echo.Context) error {
+	record, err := api.findManageableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewShareLinkCreate(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	link, token, submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to create the share link.", submitErr)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"token":     token,
+		"shareLink": link,
+	})
+}
+
+func (api *shareApi) revoke(c echo.Context) error {
+	record, err := api.findManageableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	link, err := api.app.Dao().FindShareLinkById(c.PathParam("shareId"))
+	if err != nil || link.CollectionId != record.Collection().Id || link.RecordId != record.Id {
+		return NewNotFoundError("", err)
+	}
+
+	if !link.Revoked {
+		link.Revoked = true
+		if err := api.app.Dao().SaveShareLink(link); err != nil {
+			return NewBadRequestError("Failed to revoke the share link.", err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *shareApi) listRecordComments(c echo.Context) error {
+	record, err := api.findManageableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	comments, err := api.app.Dao().FindShareCommentsByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record share comments.", err)
+	}
+
+	return c.JSON(http.StatusOK, comments)
+}
+
+// -------------------------------------------------------------------
+
+// findSharedRecord returns the share link of the path token and its record.
+//
+// It returns a forbidden error if the share link max uses limit
+// was already reached (see also [shareApi.use]).
+func (api *shareApi) findSharedRecord(c echo.Context) (*models.ShareLink, *models.Record, error) {
+	token := c.PathParam("token")
+	if token == "" {
+		return nil, nil, NewNotFoundError("", nil)
+	}
+
+	link, err := api.app.Dao().FindShareLinkByHash(security.SHA256(token))
+	if err != nil || link.Revoked || link.IsExpired() {
+		return nil, nil, NewNotFoundError("Missing, expired or revoked share link.", err)
+	}
+
+	if link.IsExhausted() {
+		return nil, nil, NewForbiddenError("The share link usage limit was reached.", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(link.CollectionId, link.RecordId)
+	if err != nil {
+		return nil, nil, NewNotFoundError("", err)
+	}
+
+	return link, record, nil
+}
+
+// use increments the usage counter of the provided share link
+// (the record views and file downloads count as uses).
+func (api *shareApi) use(link *models.ShareLink) error {
+	if ok, err := api.app.Dao().IncrementShareLinkUses(link); err != nil {
+		return NewBadRequestError("Failed to update the share link usage.", err)
+	} else if !ok {
+		return NewForbiddenError("The share link usage limit was reached.", nil)
+	}
+
+	return nil
+}
+
+func (api *shareApi) view(c echo.Context) error {
+	link, record, err := api.findSharedRecord(c)
+	if err != nil {
+		return err
+	}
+
+	if err := api.use(link); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+func (api *shareApi) download(c echo.Context) error {
+	link, record, err := api.findSharedRecord(c)
+	if err != nil {
+		return err
+	}
+
+	filename := c.PathParam("filename")
+
+	fileField := record.FindFileFieldByFile(filename)
+	if fileField == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	if err := api.use(link); err != nil {
+		return err
+	}
+
+	return api.files.serveRecordFile(c, record.Collection(), record, fileField, filename)
+}
+
+func (api *shareApi) listComments(c echo.Context) error {
+	link, _, err := api.findSharedRecord(c)
+	if err != nil {
+		return err
+	}
+
+	if !link.AllowComments {
+		return NewForbiddenError("The share link doesn't allow comments.", nil)
+	}
+
+	// only the comments of the current share link are listed
+	// (eg. in case the record is shared with several external users)
+	comments, err := api.app.Dao().FindShareCommentsByShareLink(link.Id)
+	if err != nil {
+		return NewBadRequestError("Failed to load the share comments.", err)
+	}
+
+	return c.JSON(http.StatusOK, comments)
+}
+
+func (api *shareApi) createComment(c echo.Context) error {
+	link, _, err := api.findSharedRecord(c)
+	if err != nil {
+		return err
+	}
+
+	if !link.AllowComments {
+		return NewForbiddenError("The share link doesn't allow comments.", nil)
+	}
+
+	form := forms.NewShareCommentCreate(api.app, link)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	comment, submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to create the share comment.", submitErr)
+	}
+
+	return c.JSON(http.StatusOK, comment)
+}
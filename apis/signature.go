@@ -0,0 +1,205 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// bindSignatureApi registers the record documents signature requests api endpoints.
+func bindSignatureApi(app core.App, rg *echo.Group) {
+	api := signatureApi{app: app, files: newFileApi(app)}
+
+	manageGroup := rg.Group(
+		"/collections/:collection/records/:id/signature-requests",
+		ActivityLogger(app),
+		RequireAdminOrRecordAuth(),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	manageGroup.GET("", api.list)
+	manageGroup.POST("", api.create)
+	manageGroup.DELETE("/:requestId", api.revoke)
+
+	publicGroup := rg.Group("/sign/:token", ActivityLogger(app))
+	publicGroup.GET("", api.view)
+	publicGroup.HEAD("/document", api.document)
+	publicGroup.GET("/document", api.document)
+	publicGroup.POST("", api.sign)
+}
+
+type signatureApi struct {
+	app   core.App
+	files *fileApi
+}
+
+// findManageableRecord returns the path record if the current request
+// admin or auth record is allowed to manage its signature requests
+// (aka. it satisfies the collection update API rule).
+func (api *signatureApi) findManageableRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	if ok, _ := api.app.Dao().CanAccessRecord(record, RequestInfo(c), collection.UpdateRule); !ok {
+		return nil, NewForbiddenError("You are not allowed to manage the record signature requests.", nil)
+	}
+
+	return record, nil
+}
+
+func (api *signatureApi) list(c echo.Context) error {
+	record, err := api.findManageableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	requests, err := api.app.Dao().FindSignatureRequestsByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record signature requests.", err)
+	}
+
+	return c.JSON(http.StatusOK, requests)
+}
+
+func (api *signatureApi) create(c echo.Context) error {
+	record, err := api.findManageableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewSignatureRequestCreate(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	request, token, submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to create the signature request.", submitErr)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"token":            token,
+		"signatureRequest": request,
+	})
+}
+
+func (api *signatureApi) revoke(c echo.Context) error {
+	record, err := api.findManageableRecord(c)
+	if err != nil {
+		return err
+	}
+
+	request, err := api.app.Dao().FindSignatureRequestById(c.PathParam("requestId"))
+	if err != nil || request.CollectionId != record.Collection().Id || request.RecordId != record.Id {
+		return NewNotFoundError("", err)
+	}
+
+	if request.Status == models.SignatureRequestStatusSigned {
+		return NewBadRequestError("The document is already signed.", nil)
+	}
+
+	if request.Status != models.SignatureRequestStatusRevoked {
+		request.Status = models.SignatureRequestStatusRevoked
+		if err := api.app.Dao().SaveSignatureRequest(request); err != nil {
+			return NewBadRequestError("Failed to revoke the signature request.", err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// -------------------------------------------------------------------
+
+// findSignatureRequest returns the signature request of the path token.
+//
+// The signed requests remain accessible (eg. to download the signed
+// document) until they expire.
+func (api *signatureApi) findSignatureRequest(c echo.Context) (*models.SignatureRequest, error) {
+	token := c.PathParam("token")
+	if token == "" {
+		return nil, NewNotFoundError("", nil)
+	}
+
+	request, err := api.app.Dao().FindSignatureRequestByHash(security.SHA256(token))
+	if err != nil || request.Status == models.SignatureRequestStatusRevoked || request.IsExpired() {
+		return nil, NewNotFoundError("Missing, expired or revoked signature request.", err)
+	}
+
+	return request, nil
+}
+
+func (api *signatureApi) view(c echo.Context) error {
+	request, err := api.findSignatureRequest(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+func (api *signatureApi) document(c echo.Context) error {
+	request, err := api.findSignatureRequest(c)
+	if err != nil {
+		return err
+	}
+
+	record, err := api.app.Dao().FindRecordById(request.CollectionId, request.RecordId)
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	filename := request.File
+	if request.Status == models.SignatureRequestStatusSigned {
+		filename = request.SignedFile
+	}
+
+	fileField := record.FindFileFieldByFile(filename)
+	if fileField == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	return api.files.serveRecordFile(c, record.Collection(), record, fileField, filename)
+}
+
+func (api *signatureApi) sign(c echo.Context) error {
+	request, err := api.findSignatureRequest(c)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewSignatureRequestSign(api.app, request)
+	form.SetClient(requestIp(c), c.Request().UserAgent())
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	record, submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to sign the document.", submitErr)
+	}
+
+	event := &core.SignatureCompleteEvent{
+		HttpContext:      c,
+		Record:           record,
+		SignatureRequest: request,
+	}
+	event.Collection = record.Collection()
+
+	return api.app.OnSignatureComplete().Trigger(event, func(e *core.SignatureCompleteEvent) error {
+		if e.HttpContext.Response().Committed {
+			return nil
+		}
+
+		return e.HttpContext.JSON(http.StatusOK, e.SignatureRequest)
+	})
+}
@@ -0,0 +1,82 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindSlowQueryApi registers the slow queries log api endpoints.
+func bindSlowQueryApi(app core.App, rg *echo.Group) {
+	api := slowQueryApi{app: app}
+
+	subGroup := rg.Group("/slow-queries", RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.GET("/:id", api.view)
+	subGroup.POST("/:id/resolve", api.resolve)
+	subGroup.DELETE("/:id", api.delete)
+}
+
+type slowQueryApi struct {
+	app core.App
+}
+
+var slowQueryFilterFields = []string{
+	"id", "created", "updated",
+	"fingerprint", "sql", "plan", "count",
+	"maxDuration", "lastDuration", "resolved",
+}
+
+func (api *slowQueryApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(slowQueryFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.LogsDao().SlowQueryQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.SlowQuery{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *slowQueryApi) view(c echo.Context) error {
+	model, err := api.app.LogsDao().FindSlowQueryById(c.PathParam("id"))
+	if err != nil || model == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, model)
+}
+
+func (api *slowQueryApi) resolve(c echo.Context) error {
+	model, err := api.app.LogsDao().FindSlowQueryById(c.PathParam("id"))
+	if err != nil || model == nil {
+		return NewNotFoundError("", err)
+	}
+
+	model.Resolved = true
+
+	if err := api.app.LogsDao().SaveSlowQuery(model); err != nil {
+		return NewBadRequestError("Failed to resolve the slow query.", err)
+	}
+
+	return c.JSON(http.StatusOK, model)
+}
+
+func (api *slowQueryApi) delete(c echo.Context) error {
+	model, err := api.app.LogsDao().FindSlowQueryById(c.PathParam("id"))
+	if err != nil || model == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.LogsDao().DeleteSlowQuery(model); err != nil {
+		return NewBadRequestError("Failed to delete the slow query.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
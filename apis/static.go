@@ -0,0 +1,327 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+)
+
+// StaticImmutableCacheControl is the Cache-Control header value of the
+// static files matching the [StaticConfig.Immutable] pattern.
+const StaticImmutableCacheControl = "public, max-age=31536000, immutable"
+
+// staticPrecompressedVariants lists the supported pre-compressed
+// static file variants in order of preference.
+var staticPrecompressedVariants = []struct {
+	ext      string
+	encoding string
+}{
+	{".br", "br"},
+	{".gz", "gzip"},
+}
+
+// StaticConfig defines the config of the [StaticDirectoryHandlerWithConfig] handler.
+type StaticConfig struct {
+	// FS is the file system with the static files to serve.
+	FS fs.FS
+
+	// IndexFallback forwards the requests of the missing files
+	// to the root index.html (useful for SPA with pretty urls).
+	IndexFallback bool
+
+	// Browse enables the directory listing for the directories
+	// without index.html (the dot files are not listed).
+	Browse bool
+
+	// Precompressed enables serving the pre-compressed ".br" and ".gz"
+	// file variants (if exist) based on the request Accept-Encoding header.
+	Precompressed bool
+
+	// CacheControl is an optional Cache-Control header value
+	// of the served files (eg. "max-age=3600").
+	CacheControl string
+
+	// Immutable is an optional pattern of the file paths (eg. `^assets/`)
+	// that are served with [StaticImmutableCacheControl] instead of CacheControl.
+	Immutable *regexp.Regexp
+}
+
+// StaticDirectoryHandlerWithConfig is similar to [StaticDirectoryHandler]
+// but allows further customizing the served static files headers,
+// the pre-compressed files negotiation and the directory listing.
+//
+// Example:
+//
+//	e.Router.GET("/*", apis.StaticDirectoryHandlerWithConfig(apis.StaticConfig{
+//		FS:            os.DirFS("./pb_public"),
+//		IndexFallback: true,
+//		Precompressed: true,
+//		CacheControl:  "no-cache",
+//		Immutable:     regexp.MustCompile(`^assets/`),
+//	}))
+func StaticDirectoryHandlerWithConfig(config StaticConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		p := c.PathParam("*")
+
+		// escape url path
+		tmpPath, err := url.PathUnescape(p)
+		if err != nil {
+			return fmt.Errorf("failed to unescape path variable: %w", err)
+		}
+		p = tmpPath
+
+		// fs.FS.Open() already assumes that file names are relative to FS root path and considers name with prefix `/` as invalid
+		name := filepath.ToSlash(filepath.Clean(strings.TrimPrefix(p, "/")))
+
+		// set here and not in serveStaticFile to avoid
+		// duplicating the header with the index fallback
+		if config.Precompressed {
+			c.Response().Header().Add("Vary", "Accept-Encoding")
+		}
+
+		fileErr := serveStaticFile(c, config, name)
+
+		if fileErr != nil && config.IndexFallback && errors.Is(fileErr, echo.ErrNotFound) {
+			return serveStaticFile(c, config, "index.html")
+		}
+
+		return fileErr
+	}
+}
+
+// serveStaticFile serves the named file (or the directory index.html
+// or listing) from the config file system.
+func serveStaticFile(c echo.Context, config StaticConfig, name string) error {
+	info, err := fs.Stat(config.FS, name)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	if info.IsDir() {
+		indexName := path.Join(name, "index.html")
+
+		indexInfo, err := fs.Stat(config.FS, indexName)
+		if err != nil || indexInfo.IsDir() {
+			if config.Browse {
+				return serveStaticDirListing(c, config.FS, name)
+			}
+			return echo.ErrNotFound
+		}
+
+		name = indexName
+		info = indexInfo
+	}
+
+	header := c.Response().Header()
+
+	if config.Immutable != nil && config.Immutable.MatchString(name) {
+		header.Set("Cache-Control", StaticImmutableCacheControl)
+	} else if config.CacheControl != "" {
+		header.Set("Cache-Control", config.CacheControl)
+	}
+
+	if config.Precompressed {
+		for _, variant := range staticPrecompressedVariants {
+			if !acceptsEncoding(c.Request(), variant.encoding) {
+				continue
+			}
+
+			variantInfo, err := fs.Stat(config.FS, name+variant.ext)
+			if err != nil || variantInfo.IsDir() {
+				continue
+			}
+
+			// the content type must be resolved from the original
+			// file name since the compressed content can't be sniffed
+			contentType := mime.TypeByExtension(path.Ext(name))
+			if contentType == "" {
+				contentType = echo.MIMEOctetStream
+			}
+			header.Set(echo.HeaderContentType, contentType)
+			header.Set(echo.HeaderContentEncoding, variant.encoding)
+
+			return serveStaticContent(c, config.FS, name+variant.ext, variantInfo)
+		}
+	}
+
+	return serveStaticContent(c, config.FS, name, info)
+}
+
+func serveStaticContent(c echo.Context, fileSystem fs.FS, name string, info fs.FileInfo) error {
+	f, err := fileSystem.Open(name)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+	defer f.Close()
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		return errors.New("file does not implement io.ReadSeeker")
+	}
+
+	http.ServeContent(c.Response(), c.Request(), info.Name(), info.ModTime(), content)
+
+	return nil
+}
+
+// serveStaticDirListing writes a simple html page with the entries of the named directory.
+func serveStaticDirListing(c echo.Context, fileSystem fs.FS, name string) error {
+	entries, err := fs.ReadDir(fileSystem, name)
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	basePath := c.Request().URL.Path
+
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>")
+	b.WriteString(html.EscapeString(basePath))
+	b.WriteString("</title></head><body><ul>\n")
+
+	if name != "." {
+		b.WriteString(staticDirListingItem(path.Dir(basePath), ".."))
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		label := entry.Name()
+		if entry.IsDir() {
+			label += "/"
+		}
+
+		b.WriteString(staticDirListingItem(path.Join(basePath, entry.Name()), label))
+	}
+
+	b.WriteString("</ul></body></html>\n")
+
+	c.Response().Header().Set("Cache-Control", "no-cache")
+
+	return c.HTML(http.StatusOK, b.String())
+}
+
+func staticDirListingItem(href string, label string) string {
+	escapedHref := (&url.URL{Path: href}).EscapedPath()
+
+	return fmt.Sprintf("<li><a href=\"%s\">%s</a></li>\n", html.EscapeString(escapedHref), html.EscapeString(label))
+}
+
+// acceptsEncoding reports whether the request Accept-Encoding header
+// allows the specified content encoding.
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		token, params, _ := strings.Cut(part, ";")
+
+		token = strings.TrimSpace(token)
+		if !strings.EqualFold(token, encoding) && token != "*" {
+			continue
+		}
+
+		// explicitly disallowed
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// -------------------------------------------------------------------
+
+// StaticMount defines a static directory mount point.
+type StaticMount struct {
+	// Prefix is the mount point path prefix (eg. "/docs").
+	Prefix string
+
+	// Dir is the directory with the static files to serve.
+	Dir string
+
+	// IndexFallback forwards the requests of the missing files
+	// to the mount point root index.html.
+	IndexFallback bool
+
+	// Browse enables the mount point directories listing.
+	Browse bool
+}
+
+// ParseStaticMount parses a static mount point definition in the
+// format "prefix=dir[,option...]" (eg. "/app=./pb_app,spa").
+//
+// The supported options are "spa" (enables the index fallback)
+// and "browse" (enables the directories listing).
+func ParseStaticMount(value string) (StaticMount, error) {
+	result := StaticMount{}
+
+	prefix, rest, ok := strings.Cut(value, "=")
+	if !ok {
+		return result, fmt.Errorf("invalid static mount %q, expected prefix=dir[,option...]", value)
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	if !strings.HasPrefix(prefix, "/") {
+		return result, fmt.Errorf("invalid static mount %q, the prefix must start with /", value)
+	}
+
+	parts := strings.Split(rest, ",")
+
+	result.Prefix = "/" + strings.Trim(prefix, "/")
+	result.Dir = strings.TrimSpace(parts[0])
+	if result.Dir == "" {
+		return result, fmt.Errorf("invalid static mount %q, missing dir", value)
+	}
+
+	for _, option := range parts[1:] {
+		switch strings.TrimSpace(option) {
+		case "spa":
+			result.IndexFallback = true
+		case "browse":
+			result.Browse = true
+		default:
+			return result, fmt.Errorf("invalid static mount %q, unknown option %q", value, option)
+		}
+	}
+
+	return result, nil
+}
+
+// BindStaticMounts registers the GET routes that serve the static files
+// of the provided mount points.
+//
+// The config fields are used as defaults for all mount points
+// (the FS is replaced with the mount point dir).
+func BindStaticMounts(e *echo.Echo, config StaticConfig, mounts ...StaticMount) {
+	for _, mount := range mounts {
+		mountConfig := config
+		mountConfig.FS = os.DirFS(mount.Dir)
+		mountConfig.IndexFallback = config.IndexFallback || mount.IndexFallback
+		mountConfig.Browse = config.Browse || mount.Browse
+
+		handler := StaticDirectoryHandlerWithConfig(mountConfig)
+
+		prefix := strings.TrimSuffix(mount.Prefix, "/")
+		if prefix != "" {
+			e.GET(prefix, handler)
+		}
+		e.GET(prefix+"/*", handler)
+	}
+}
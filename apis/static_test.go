@@ -0,0 +1,253 @@
+package apis_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func newTestStaticFS() fstest.MapFS {
+	return fstest.MapFS{
+		"index.html":          {Data: []byte("test_index")},
+		"app.js":              {Data: []byte("test_js")},
+		"app.js.br":           {Data: []byte("test_js_br")},
+		"app.js.gz":           {Data: []byte("test_js_gz")},
+		"assets/app.css":      {Data: []byte("test_css")},
+		"sub/a b.txt":         {Data: []byte("test_txt")},
+		"sub/.hidden":         {Data: []byte("test_hidden")},
+		"sub/nested/test.txt": {Data: []byte("test_nested")},
+	}
+}
+
+func TestStaticDirectoryHandlerWithConfig(t *testing.T) {
+	t.Parallel()
+
+	bind := func(config apis.StaticConfig) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+			config.FS = newTestStaticFS()
+			e.GET("/static/*", apis.StaticDirectoryHandlerWithConfig(config))
+		}
+	}
+
+	expectHeaders := func(headers map[string]string) func(t *testing.T, app *tests.TestApp, res *http.Response) {
+		return func(t *testing.T, app *tests.TestApp, res *http.Response) {
+			for name, expected := range headers {
+				if v := res.Header.Get(name); v != expected {
+					t.Fatalf("Expected %s header %q, got %q", name, expected, v)
+				}
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "existing file",
+			Method:          http.MethodGet,
+			Url:             "/static/assets/app.css",
+			BeforeTestFunc:  bind(apis.StaticConfig{}),
+			AfterTestFunc:   expectHeaders(map[string]string{"Cache-Control": "", "Content-Type": "text/css; charset=utf-8"}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_css"},
+		},
+		{
+			Name:            "missing file without index fallback",
+			Method:          http.MethodGet,
+			Url:             "/static/missing.txt",
+			BeforeTestFunc:  bind(apis.StaticConfig{}),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "missing file with index fallback",
+			Method:          http.MethodGet,
+			Url:             "/static/missing/page",
+			BeforeTestFunc:  bind(apis.StaticConfig{IndexFallback: true}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_index"},
+		},
+		{
+			Name:            "directory without index and browse",
+			Method:          http.MethodGet,
+			Url:             "/static/sub",
+			BeforeTestFunc:  bind(apis.StaticConfig{}),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "directory listing",
+			Method:         http.MethodGet,
+			Url:            "/static/sub",
+			BeforeTestFunc: bind(apis.StaticConfig{Browse: true}),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`<a href="/static">..</a>`,
+				`<a href="/static/sub/a%20b.txt">a b.txt</a>`,
+				`<a href="/static/sub/nested">nested/</a>`,
+			},
+			NotExpectedContent: []string{".hidden"},
+		},
+		{
+			Name:            "cache control and immutable pattern",
+			Method:          http.MethodGet,
+			Url:             "/static/app.js",
+			BeforeTestFunc:  bind(apis.StaticConfig{CacheControl: "no-cache", Immutable: regexp.MustCompile(`^assets/`)}),
+			AfterTestFunc:   expectHeaders(map[string]string{"Cache-Control": "no-cache", "Content-Encoding": ""}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_js"},
+		},
+		{
+			Name:            "immutable file",
+			Method:          http.MethodGet,
+			Url:             "/static/assets/app.css",
+			BeforeTestFunc:  bind(apis.StaticConfig{CacheControl: "no-cache", Immutable: regexp.MustCompile(`^assets/`)}),
+			AfterTestFunc:   expectHeaders(map[string]string{"Cache-Control": apis.StaticImmutableCacheControl}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_css"},
+		},
+		{
+			Name:           "precompressed brotli variant",
+			Method:         http.MethodGet,
+			Url:            "/static/app.js",
+			RequestHeaders: map[string]string{"Accept-Encoding": "gzip, deflate, br"},
+			BeforeTestFunc: bind(apis.StaticConfig{Precompressed: true}),
+			AfterTestFunc: expectHeaders(map[string]string{
+				"Content-Encoding": "br",
+				"Content-Type":     "text/javascript; charset=utf-8",
+				"Vary":             "Accept-Encoding",
+			}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_js_br"},
+		},
+		{
+			Name:           "precompressed gzip variant",
+			Method:         http.MethodGet,
+			Url:            "/static/app.js",
+			RequestHeaders: map[string]string{"Accept-Encoding": "gzip, br;q=0"},
+			BeforeTestFunc: bind(apis.StaticConfig{Precompressed: true}),
+			AfterTestFunc: expectHeaders(map[string]string{
+				"Content-Encoding": "gzip",
+				"Content-Type":     "text/javascript; charset=utf-8",
+			}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_js_gz"},
+		},
+		{
+			Name:            "precompressed without accepted encoding",
+			Method:          http.MethodGet,
+			Url:             "/static/app.js",
+			RequestHeaders:  map[string]string{"Accept-Encoding": "deflate"},
+			BeforeTestFunc:  bind(apis.StaticConfig{Precompressed: true}),
+			AfterTestFunc:   expectHeaders(map[string]string{"Content-Encoding": ""}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_js"},
+		},
+		{
+			Name:           "precompressed with index fallback",
+			Method:         http.MethodGet,
+			Url:            "/static/missing/page",
+			RequestHeaders: map[string]string{"Accept-Encoding": "br"},
+			BeforeTestFunc: bind(apis.StaticConfig{Precompressed: true, IndexFallback: true}),
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if vary := res.Header.Values("Vary"); len(vary) != 1 || vary[0] != "Accept-Encoding" {
+					t.Fatalf("Expected a single Vary Accept-Encoding header, got %v", vary)
+				}
+			},
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_index"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestParseStaticMount(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		value       string
+		expected    apis.StaticMount
+		expectError bool
+	}{
+		{"", apis.StaticMount{}, true},
+		{"/app", apis.StaticMount{}, true},
+		{"app=./pb_app", apis.StaticMount{}, true},
+		{"/app=", apis.StaticMount{}, true},
+		{"/app=./pb_app,invalid", apis.StaticMount{}, true},
+		{"/=./pb_public", apis.StaticMount{Prefix: "/", Dir: "./pb_public"}, false},
+		{"/app/=./pb_app,spa", apis.StaticMount{Prefix: "/app", Dir: "./pb_app", IndexFallback: true}, false},
+		{"/files=/tmp/files,browse,spa", apis.StaticMount{Prefix: "/files", Dir: "/tmp/files", IndexFallback: true, Browse: true}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			result, err := apis.ParseStaticMount(s.value)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && result != s.expected {
+				t.Fatalf("Expected %+v, got %+v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestBindStaticMounts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("test_mount_index"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bind := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		apis.BindStaticMounts(
+			e,
+			apis.StaticConfig{CacheControl: "no-cache"},
+			apis.StaticMount{Prefix: "/app", Dir: dir, IndexFallback: true},
+			apis.StaticMount{Prefix: "/files", Dir: dir},
+		)
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "mount root",
+			Method:          http.MethodGet,
+			Url:             "/app",
+			BeforeTestFunc:  bind,
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_mount_index"},
+		},
+		{
+			Name:            "mount with index fallback",
+			Method:          http.MethodGet,
+			Url:             "/app/some/page",
+			BeforeTestFunc:  bind,
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"test_mount_index"},
+		},
+		{
+			Name:            "mount without index fallback",
+			Method:          http.MethodGet,
+			Url:             "/files/some/page",
+			BeforeTestFunc:  bind,
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,354 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// Status page subsystem states.
+const (
+	StatusOperational = "operational"
+	StatusDegraded    = "degraded"
+	StatusOutage      = "outage"
+)
+
+// uptimeRetention is the max period of the persisted uptime stats.
+const uptimeRetention = 31 * 24 * time.Hour
+
+var uptimeSubsystems = []string{
+	models.UptimeSubsystemApi,
+	models.UptimeSubsystemRealtime,
+	models.UptimeSubsystemStorage,
+}
+
+// bindStatusApi registers the public status page api endpoint
+// and the uptime tracker flush serve hooks.
+func bindStatusApi(app core.App, rg *echo.Group, tracker *uptimeTracker) {
+	api := statusApi{app: app, tracker: tracker}
+
+	subGroup := rg.Group("/status")
+	subGroup.GET("", api.status)
+
+	bindUptimeTrackerFlush(app, tracker)
+}
+
+type statusApi struct {
+	app     core.App
+	tracker *uptimeTracker
+}
+
+type statusPeriod struct {
+	Total        int     `json:"total"`
+	Errors       int     `json:"errors"`
+	Availability float64 `json:"availability"`
+}
+
+type statusSubsystem struct {
+	Name    string        `json:"name"`
+	Status  string        `json:"status"`
+	Last24h *statusPeriod `json:"last24h"`
+	Last30d *statusPeriod `json:"last30d"`
+}
+
+type statusResponse struct {
+	Status     string             `json:"status"`
+	Updated    types.DateTime     `json:"updated"`
+	Subsystems []*statusSubsystem `json:"subsystems"`
+}
+
+// status returns a summary with the availability of the app
+// subsystems for the last 24 hours and 30 days.
+//
+// The response is rendered as html if requested with ?format=html
+// or with an "Accept: text/html" header (eg. opened in a browser).
+func (api *statusApi) status(c echo.Context) error {
+	config := api.app.Settings().StatusPage
+	if !config.Enabled {
+		return NewNotFoundError("", nil)
+	}
+
+	// persist the pending counters so that the summary is up-to-date
+	api.tracker.flush()
+
+	now := time.Now().UTC()
+
+	resp := &statusResponse{
+		Status:     StatusOperational,
+		Updated:    types.NowDateTime(),
+		Subsystems: make([]*statusSubsystem, 0, len(uptimeSubsystems)),
+	}
+
+	for _, name := range uptimeSubsystems {
+		last24h, err := api.app.LogsDao().FindUptimeSummary(name, now.Add(-24*time.Hour))
+		if err != nil {
+			return NewBadRequestError("Failed to load the uptime stats.", err)
+		}
+
+		last30d, err := api.app.LogsDao().FindUptimeSummary(name, now.Add(-30*24*time.Hour))
+		if err != nil {
+			return NewBadRequestError("Failed to load the uptime stats.", err)
+		}
+
+		subsystem := &statusSubsystem{
+			Name:    name,
+			Status:  availabilityStatus(last24h.Availability()),
+			Last24h: newStatusPeriod(last24h.Total, last24h.Errors, last24h.Availability()),
+			Last30d: newStatusPeriod(last30d.Total, last30d.Errors, last30d.Availability()),
+		}
+
+		if statusSeverity(subsystem.Status) > statusSeverity(resp.Status) {
+			resp.Status = subsystem.Status
+		}
+
+		resp.Subsystems = append(resp.Subsystems, subsystem)
+	}
+
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", config.CacheMaxAge))
+	c.Response().Header().Add("Vary", "Accept")
+
+	if c.QueryParam("format") == "html" ||
+		(c.QueryParam("format") == "" && strings.Contains(c.Request().Header.Get("Accept"), "text/html")) {
+		var html strings.Builder
+
+		err := statusPageTemplate.Execute(&html, map[string]any{
+			"AppName": api.app.Settings().Meta.AppName,
+			"Status":  resp,
+		})
+		if err != nil {
+			return NewBadRequestError("Failed to render the status page.", err)
+		}
+
+		return c.HTML(http.StatusOK, html.String())
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func newStatusPeriod(total int, errors int, availability float64) *statusPeriod {
+	return &statusPeriod{
+		Total:        total,
+		Errors:       errors,
+		Availability: math.Round(availability*1000) / 1000,
+	}
+}
+
+// availabilityStatus returns the subsystem state for the provided availability percentage.
+func availabilityStatus(availability float64) string {
+	switch {
+	case availability >= 99:
+		return StatusOperational
+	case availability >= 90:
+		return StatusDegraded
+	default:
+		return StatusOutage
+	}
+}
+
+func statusSeverity(status string) int {
+	switch status {
+	case StatusDegraded:
+		return 1
+	case StatusOutage:
+		return 2
+	default:
+		return 0
+	}
+}
+
+var statusPageTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>{{.AppName}} status</title>
+	<style>
+		body { font-family: sans-serif; max-width: 720px; margin: 40px auto; padding: 0 20px; color: #1a1a24; }
+		table { width: 100%; border-collapse: collapse; }
+		th, td { padding: 10px; text-align: left; border-bottom: 1px solid #e4e9ec; }
+		.operational { color: #32ad84; }
+		.degraded { color: #ff944d; }
+		.outage { color: #e34562; }
+	</style>
+</head>
+<body>
+	<h1>{{.AppName}} status: <span class="{{.Status.Status}}">{{.Status.Status}}</span></h1>
+	<table>
+		<thead>
+			<tr>
+				<th>Subsystem</th>
+				<th>Status</th>
+				<th>Last 24h</th>
+				<th>Last 30d</th>
+			</tr>
+		</thead>
+		<tbody>
+			{{range .Status.Subsystems}}
+			<tr>
+				<td>{{.Name}}</td>
+				<td class="{{.Status}}">{{.Status}}</td>
+				<td>{{printf "%.3f" .Last24h.Availability}}%</td>
+				<td>{{printf "%.3f" .Last30d.Availability}}%</td>
+			</tr>
+			{{end}}
+		</tbody>
+	</table>
+	<p><small>Updated {{.Status.Updated}}</small></p>
+</body>
+</html>`))
+
+// -------------------------------------------------------------------
+
+type uptimeKey struct {
+	subsystem string
+	hour      time.Time
+}
+
+type uptimeCounter struct {
+	total  int
+	errors int
+}
+
+// uptimeTracker collects in memory the hourly requests and
+// server errors counters of the app subsystems until flushed
+// to the logs db.
+type uptimeTracker struct {
+	app     core.App
+	mux     sync.Mutex
+	pending map[uptimeKey]*uptimeCounter
+}
+
+func newUptimeTracker(app core.App) *uptimeTracker {
+	return &uptimeTracker{
+		app:     app,
+		pending: map[uptimeKey]*uptimeCounter{},
+	}
+}
+
+// middleware tracks the response status of the subsystem requests
+// (only when the status page is enabled).
+func (t *uptimeTracker) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !t.app.Settings().StatusPage.Enabled {
+			return next(c)
+		}
+
+		subsystem := uptimeSubsystem(c.Request().URL.Path)
+		if subsystem == "" {
+			return next(c)
+		}
+
+		err := next(c)
+
+		status := c.Response().Status
+		if err != nil {
+			var apiErr *ApiError
+			var httpErr *echo.HTTPError
+
+			switch {
+			case errors.As(err, &apiErr):
+				status = apiErr.Code
+			case errors.As(err, &httpErr):
+				status = httpErr.Code
+			default:
+				status = http.StatusBadRequest // see the InitApi error handler
+			}
+		}
+
+		t.track(subsystem, status >= 500)
+
+		return err
+	}
+}
+
+// track registers a single subsystem request in the current hourly bucket.
+func (t *uptimeTracker) track(subsystem string, failed bool) {
+	key := uptimeKey{subsystem: subsystem, hour: time.Now().UTC().Truncate(time.Hour)}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	counter, ok := t.pending[key]
+	if !ok {
+		counter = &uptimeCounter{}
+		t.pending[key] = counter
+	}
+
+	counter.total++
+	if failed {
+		counter.errors++
+	}
+}
+
+// flush persists and resets the pending counters.
+func (t *uptimeTracker) flush() {
+	t.mux.Lock()
+	pending := t.pending
+	t.pending = map[uptimeKey]*uptimeCounter{}
+	t.mux.Unlock()
+
+	for key, counter := range pending {
+		err := t.app.LogsDao().IncrementUptimeStat(key.subsystem, key.hour, counter.total, counter.errors)
+		if err != nil {
+			t.app.Logger().Debug(
+				"[Status] Failed to persist the uptime stats",
+				slog.String("subsystem", key.subsystem),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// uptimeSubsystem returns the tracked subsystem of the request path
+// (or empty string if the path shouldn't be tracked).
+func uptimeSubsystem(path string) string {
+	switch {
+	case path == "/api/status" || !strings.HasPrefix(path, "/api/"):
+		return ""
+	case strings.HasPrefix(path, "/api/realtime"):
+		return models.UptimeSubsystemRealtime
+	case strings.HasPrefix(path, "/api/files/"):
+		return models.UptimeSubsystemStorage
+	default:
+		return models.UptimeSubsystemApi
+	}
+}
+
+// bindUptimeTrackerFlush registers the app serve hooks that periodically
+// persist the tracked uptime counters and delete the expired stats.
+func bindUptimeTrackerFlush(app core.App, tracker *uptimeTracker) {
+	c := cron.New()
+	app.Store().Set(core.StoreKeyCronPrefix+"uptime", c)
+
+	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		c.MustAdd("@uptimeFlush", "* * * * *", tracker.flush)
+
+		c.MustAdd("@uptimeCleanup", "0 * * * *", func() {
+			if err := app.LogsDao().DeleteOldUptimeStats(time.Now().Add(-uptimeRetention)); err != nil {
+				app.Logger().Debug("[Status] Failed to delete the old uptime stats", slog.String("error", err.Error()))
+			}
+		})
+
+		c.Start()
+
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		c.Stop()
+		tracker.flush()
+		return nil
+	})
+}
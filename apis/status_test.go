@@ -0,0 +1,131 @@
+package apis_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestStatusAPI(t *testing.T) {
+	t.Parallel()
+
+	enableStatusPage := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		app.Settings().StatusPage.Enabled = true
+		app.Settings().StatusPage.CacheMaxAge = 30
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "disabled status page",
+			Method:          http.MethodGet,
+			Url:             "/api/status",
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "enabled status page without tracked requests",
+			Method:         http.MethodGet,
+			Url:            "/api/status",
+			BeforeTestFunc: enableStatusPage,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"status":"operational"`,
+				`"updated":"`,
+				`"name":"api"`,
+				`"name":"realtime"`,
+				`"name":"storage"`,
+				`"last24h":{"total":0,"errors":0,"availability":100}`,
+				`"last30d":{"total":0,"errors":0,"availability":100}`,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if v := res.Header.Get("Cache-Control"); v != "public, max-age=30" {
+					t.Fatalf("Expected Cache-Control %q, got %q", "public, max-age=30", v)
+				}
+			},
+		},
+		{
+			Name:   "enabled status page with persisted stats",
+			Method: http.MethodGet,
+			Url:    "/api/status",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableStatusPage(t, app, e)
+
+				now := time.Now()
+				if err := app.LogsDao().IncrementUptimeStat(models.UptimeSubsystemStorage, now, 100, 5); err != nil {
+					t.Fatal(err)
+				}
+				if err := app.LogsDao().IncrementUptimeStat(models.UptimeSubsystemApi, now.Add(-10*24*time.Hour), 10, 10); err != nil {
+					t.Fatal(err)
+				}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"status":"degraded"`,
+				`"name":"api","status":"operational","last24h":{"total":0,"errors":0,"availability":100},"last30d":{"total":10,"errors":10,"availability":0}`,
+				`"name":"storage","status":"degraded","last24h":{"total":100,"errors":5,"availability":95}`,
+			},
+		},
+		{
+			Name:   "tracked requests",
+			Method: http.MethodGet,
+			Url:    "/api/status",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableStatusPage(t, app, e)
+
+				e.GET("/api/test-failure", func(c echo.Context) error {
+					return apis.NewApiError(http.StatusInternalServerError, "test", nil)
+				})
+
+				for _, url := range []string{"/api/health", "/api/missing", "/api/test-failure", "/api/files/missing/missing/missing.png", "/_/"} {
+					e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, url, nil))
+				}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"status":"outage"`,
+				`"name":"api","status":"outage","last24h":{"total":3,"errors":1,"availability":66.667}`,
+				`"name":"realtime","status":"operational","last24h":{"total":0,"errors":0,"availability":100}`,
+				`"name":"storage","status":"operational","last24h":{"total":1,"errors":0,"availability":100}`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnBeforeApiError": 3,
+				"OnAfterApiError":  3,
+			},
+		},
+		{
+			Name:           "html status page (format query param)",
+			Method:         http.MethodGet,
+			Url:            "/api/status?format=html",
+			BeforeTestFunc: enableStatusPage,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`<title>acme_test status</title>`,
+				`<span class="operational">operational</span>`,
+				`<td>storage</td>`,
+				`100.000%`,
+			},
+		},
+		{
+			Name:           "html status page (Accept header)",
+			Method:         http.MethodGet,
+			Url:            "/api/status",
+			RequestHeaders: map[string]string{"Accept": "text/html,application/xhtml+xml"},
+			BeforeTestFunc: enableStatusPage,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`<title>acme_test status</title>`,
+				`<td>realtime</td>`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,60 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/tracing"
+)
+
+// tracingMiddleware starts a server span for each request (continuing
+// the trace of the incoming W3C "traceparent" header, if any) and
+// stores it in the request context so that the spans of the nested
+// operations (db queries, outgoing requests, etc.) are linked to it.
+func tracingMiddleware(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			tracer := app.Tracer()
+			if !tracer.Enabled() {
+				return next(c)
+			}
+
+			req := c.Request()
+
+			ctx := tracing.ContextWithTraceparent(req.Context(), req.Header.Get("traceparent"))
+			ctx, span := tracer.Start(ctx, req.Method, tracing.KindServer)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				// handle the error within the span so that
+				// the error response status is known
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+
+			route := c.Path()
+			if route == "" {
+				route = req.URL.Path
+			}
+
+			span.SetName(req.Method + " " + route)
+			span.SetAttribute("http.request.method", req.Method)
+			span.SetAttribute("http.route", route)
+			span.SetAttribute("url.path", req.URL.Path)
+			span.SetAttribute("http.response.status_code", status)
+
+			if err != nil {
+				span.SetError(err)
+			} else if status >= http.StatusInternalServerError {
+				span.SetError(echo.NewHTTPError(status))
+			}
+
+			return nil
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package apis
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+)
+
+// UploadIdHeader is the request (and response) header with the client
+// generated id of a multipart upload (see [UploadProgress]).
+const UploadIdHeader = "X-Upload-Id"
+
+// realtimeUploadsTopicPrefix is the realtime subscription topic prefix
+// for receiving the progress events of a single upload (eg. "@uploads/abc").
+const realtimeUploadsTopicPrefix = "@uploads/"
+
+var uploadIdRegex = regexp.MustCompile(`^[\w\-]{1,100}$`)
+
+// uploadProgressInterval is the min interval between 2 progress events of the same upload.
+var uploadProgressInterval = 250 * time.Millisecond
+
+// uploadProgressData represents the broadcasted upload progress subscription message data.
+type uploadProgressData struct {
+	UploadId string `json:"uploadId"`
+	Received int64  `json:"received"`
+
+	// Total is the request Content-Length or -1 if unknown.
+	Total int64 `json:"total"`
+
+	// Done indicates that the upload request processing has completed
+	// (successfully or not).
+	Done bool `json:"done"`
+}
+
+// UploadProgress middleware broadcasts periodic realtime progress events
+// for the multipart requests with [UploadIdHeader] header.
+//
+// To receive the progress events, the client should generate a random
+// upload id, subscribe to the "@uploads/{uploadId}" realtime topic and
+// then send the upload request with the "X-Upload-Id: {uploadId}" header.
+//
+// The progress events are delivered only to the realtime clients with
+// the same auth state as the upload request.
+//
+// It is registered by default for all "/api/*" routes (see [InitApi]),
+// before the request body is read by the eager request info cache.
+func UploadProgress(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			uploadId := req.Header.Get(UploadIdHeader)
+			if uploadId == "" || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+				return next(c)
+			}
+
+			if !uploadIdRegex.MatchString(uploadId) {
+				return NewBadRequestError("Invalid upload id.", nil)
+			}
+
+			c.Response().Header().Set(UploadIdHeader, uploadId)
+
+			authId := extractAuthIdFromGetter(c)
+
+			reader := &uploadProgressReader{
+				ReadCloser: req.Body,
+				total:      req.ContentLength,
+				notify: func(received int64, total int64, done bool) {
+					broadcastUploadProgress(app, authId, &uploadProgressData{
+						UploadId: uploadId,
+						Received: received,
+						Total:    total,
+						Done:     done,
+					})
+				},
+			}
+			req.Body = reader
+
+			defer reader.finish()
+
+			return next(c)
+		}
+	}
+}
+
+// uploadProgressReader is an [io.ReadCloser] wrapper that
+// reports the number of the read bytes.
+type uploadProgressReader struct {
+	io.ReadCloser
+
+	mu         sync.Mutex
+	total      int64
+	received   int64
+	lastNotify time.Time
+	notify     func(received int64, total int64, done bool)
+}
+
+// Read implements [io.Reader] interface method.
+func (r *uploadProgressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+
+	if n > 0 {
+		r.mu.Lock()
+		r.received += int64(n)
+		// always report the fully received body
+		if r.received == r.total || time.Since(r.lastNotify) >= uploadProgressInterval {
+			r.lastNotify = time.Now()
+			r.notify(r.received, r.total, false)
+		}
+		r.mu.Unlock()
+	}
+
+	return n, err
+}
+
+// finish reports the final upload progress after the request was processed.
+func (r *uploadProgressReader) finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.notify(r.received, r.total, true)
+}
+
+// broadcastUploadProgress sends the upload progress data to all realtime
+// clients with the same auth state subscribed to the upload topic.
+func broadcastUploadProgress(app core.App, authId string, data *uploadProgressData) {
+	clients := app.SubscriptionsBroker().Clients()
+	if len(clients) == 0 {
+		return // no subscribers
+	}
+
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		app.Logger().Debug(
+			"[broadcastUploadProgress] data marshal error",
+			slog.String("uploadId", data.UploadId),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	for _, client := range clients {
+		client := client
+
+		if extractAuthIdFromGetter(client) != authId {
+			continue
+		}
+
+		for sub := range client.Subscriptions(realtimeUploadsTopicPrefix + data.UploadId + "?") {
+			msg := subscriptions.Message{
+				Name: sub,
+				Data: dataBytes,
+			}
+
+			routine.FireAndForget(func() {
+				client.Send(msg)
+			})
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package apis
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+var virtualCollectionNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_]*$`)
+
+// VirtualCollection defines a read-only collection whose records
+// are provided by custom resolvers (eg. fetched from an external API
+// or computed on the fly) instead of a database table.
+//
+// The virtual collection records are exposed through the standard
+// records list and view api endpoints and the filter, sort and pagination
+// query parameters, the list and view API rules and the relations expand
+// are applied as for any other collection.
+type VirtualCollection struct {
+	// Name is the unique collection name used in the records api paths
+	// (it must not match an existing collection name).
+	Name string
+
+	// Schema defines the records fields (besides the system "id",
+	// "created" and "updated" fields).
+	Schema schema.Schema
+
+	// ListRule and ViewRule are the collection API rules
+	// (nil means admins only, empty string means public).
+	ListRule *string
+	ViewRule *string
+
+	// List returns the data of all collection records for the current request.
+	List func(c echo.Context) ([]map[string]any, error)
+
+	// View optionally returns the data of a single collection record
+	// for the current request (nil if missing).
+	//
+	// If not set, the record is searched in the List records.
+	View func(c echo.Context, id string) (map[string]any, error)
+}
+
+// Validate makes VirtualCollection validatable by implementing [validation.Validatable] interface.
+func (vc *VirtualCollection) Validate() error {
+	return validation.ValidateStruct(vc,
+		validation.Field(&vc.Name, validation.Required, validation.Length(1, 255), validation.Match(virtualCollectionNameRegex)),
+		validation.Field(&vc.Schema),
+		validation.Field(&vc.List, validation.By(func(value any) error {
+			if vc.List == nil {
+				return validation.ErrRequired
+			}
+			return nil
+		})),
+	)
+}
+
+// Collection returns the in-memory (unsaved) collection model of the virtual
+// collection that is used for resolving the API rules and serializing its records.
+//
+// The collection model id is the same as its name.
+func (vc *VirtualCollection) Collection() *models.Collection {
+	collection := &models.Collection{}
+	collection.Id = vc.Name
+	collection.Name = vc.Name
+	collection.Type = models.CollectionTypeView
+	collection.Schema = vc.Schema
+	collection.ListRule = vc.ListRule
+	collection.ViewRule = vc.ViewRule
+
+	return collection
+}
+
+// BindVirtualCollection registers the records list and view routes
+// of the provided virtual collection so that it is available through
+// the standard records api endpoints.
+//
+// The virtual collection routes take precedence over the core records routes
+// and the records create, update and delete requests are rejected.
+//
+// Example:
+//
+//	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+//		return apis.BindVirtualCollection(e.App, e.Router, &apis.VirtualCollection{
+//			Name:     "countries",
+//			Schema:   schema.NewSchema(&schema.SchemaField{Name: "name", Type: schema.FieldTypeText}),
+//			ListRule: types.Pointer(""),
+//			ViewRule: types.Pointer(""),
+//			List: func(c echo.Context) ([]map[string]any, error) {
+//				return []map[string]any{{"id": "bg", "name": "Bulgaria"}}, nil
+//			},
+//		})
+//	})
+func BindVirtualCollection(app core.App, e *echo.Echo, vc *VirtualCollection) error {
+	if err := vc.Validate(); err != nil {
+		return err
+	}
+
+	if existing, _ := app.Dao().FindCollectionByNameOrId(vc.Name); existing != nil {
+		return fmt.Errorf("virtual collection %q conflicts with an existing collection", vc.Name)
+	}
+
+	api := virtualCollectionApi{app: app, vc: vc, collection: vc.Collection()}
+
+	subGroup := e.Group("/api/collections/"+vc.Name, ActivityLogger(app))
+	subGroup.GET("/records", api.list)
+	subGroup.POST("/records", api.readOnly)
+	subGroup.GET("/records/:id", api.view)
+	subGroup.PATCH("/records/:id", api.readOnly)
+	subGroup.DELETE("/records/:id", api.readOnly)
+
+	return nil
+}
+
+type virtualCollectionApi struct {
+	app        core.App
+	vc         *VirtualCollection
+	collection *models.Collection
+}
+
+// query returns a records select query from the provided
+// virtual collection records data.
+//
+// The data is loaded in a derived table named after the collection
+// so that the API rules and filters could be resolved as for a
+// regular collection table.
+func (api *virtualCollectionApi) query(data []map[string]any) (*dbx.SelectQuery, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{schema.FieldNameId, schema.FieldNameCreated, schema.FieldNameUpdated}
+	for _, field := range api.collection.Schema.Fields() {
+		fields = append(fields, field.Name)
+	}
+
+	columns := make([]string, len(fields))
+	for i, name := range fields {
+		columns[i] = fmt.Sprintf("json_extract([[value]], '$.%s') AS [[%s]]", name, name)
+	}
+
+	from := fmt.Sprintf(
+		"(SELECT %s FROM json_each({:virtualCollectionData})) AS %s",
+		strings.Join(columns, ", "),
+		api.collection.Name,
+	)
+
+	query := api.app.Dao().DB().
+		Select(api.app.Dao().DB().QuoteSimpleColumnName(api.collection.Name) + ".*").
+		From(from).
+		Bind(dbx.Params{"virtualCollectionData": string(raw)})
+
+	return query, nil
+}
+
+func (api *virtualCollectionApi) readOnly(c echo.Context) error {
+	return NewBadRequestError("The virtual collection records are read-only.", nil)
+}
+
+func (api *virtualCollectionApi) list(c echo.Context) error {
+	requestInfo := RequestInfo(c)
+
+	// forbid users and guests to query special filter/sort fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return err
+	}
+
+	if requestInfo.Admin == nil && api.collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	data, err := api.vc.List(c)
+	if err != nil {
+		return NewBadRequestError("Failed to load the virtual collection records.", err)
+	}
+
+	query, err := api.query(data)
+	if err != nil {
+		return NewBadRequestError("Failed to load the virtual collection records.", err)
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		api.collection,
+		requestInfo,
+		// hidden fields are searchable only by admins
+		requestInfo.Admin != nil,
+	)
+
+	searchProvider := search.NewProvider(fieldsResolver).
+		Query(query)
+
+	if requestInfo.Admin == nil && api.collection.ListRule != nil {
+		searchProvider.AddFilter(search.FilterData(*api.collection.ListRule))
+	}
+
+	rows := []dbx.NullStringMap{}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &rows)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	records := models.NewRecordsFromNullStringMaps(api.collection, rows)
+	result.Items = records
+
+	event := new(core.RecordsListEvent)
+	event.HttpContext = c
+	event.Collection = api.collection
+	event.Records = records
+	event.Result = result
+
+	return api.app.OnRecordsListRequest().Trigger(event, func(e *core.RecordsListEvent) error {
+		if e.HttpContext.Response().Committed {
+			return nil
+		}
+
+		if err := EnrichRecords(e.HttpContext, api.app.Dao(), e.Records); err != nil {
+			api.app.Logger().Debug("Failed to enrich list records", slog.String("error", err.Error()))
+		}
+
+		return e.HttpContext.JSON(http.StatusOK, e.Result)
+	})
+}
+
+func (api *virtualCollectionApi) view(c echo.Context) error {
+	recordId := c.PathParam("id")
+	if recordId == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && api.collection.ViewRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil)
+	}
+
+	var data []map[string]any
+	if api.vc.View != nil {
+		item, err := api.vc.View(c, recordId)
+		if err != nil {
+			return NewNotFoundError("", err)
+		}
+		if item != nil {
+			data = append(data, item)
+		}
+	} else {
+		var err error
+		if data, err = api.vc.List(c); err != nil {
+			return NewNotFoundError("", err)
+		}
+	}
+
+	if len(data) == 0 {
+		return NewNotFoundError("", nil)
+	}
+
+	query, err := api.query(data)
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	query.AndWhere(dbx.HashExp{api.collection.Name + "." + schema.FieldNameId: recordId}).Limit(1)
+
+	if requestInfo.Admin == nil && *api.collection.ViewRule != "" {
+		resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), api.collection, requestInfo, true)
+		expr, err := search.FilterData(*api.collection.ViewRule).BuildExpr(resolver)
+		if err != nil {
+			return NewNotFoundError("", err)
+		}
+		resolver.UpdateQuery(query)
+		query.AndWhere(expr)
+	}
+
+	row := dbx.NullStringMap{}
+	if err := query.One(&row); err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	record := models.NewRecordFromNullStringMap(api.collection, row)
+
+	event := new(core.RecordViewEvent)
+	event.HttpContext = c
+	event.Collection = api.collection
+	event.Record = record
+
+	return api.app.OnRecordViewRequest().Trigger(event, func(e *core.RecordViewEvent) error {
+		if e.HttpContext.Response().Committed {
+			return nil
+		}
+
+		if err := EnrichRecord(e.HttpContext, api.app.Dao(), e.Record); err != nil {
+			api.app.Logger().Debug(
+				"Failed to enrich view record",
+				slog.String("id", e.Record.Id),
+				slog.String("collectionName", e.Record.Collection().Name),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return e.HttpContext.JSON(http.StatusOK, e.Record)
+	})
+}
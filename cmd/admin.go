@@ -3,16 +3,20 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/spf13/cobra"
 )
 
 // NewAdminCommand creates and returns new command for managing
-// admin accounts (create, update, delete).
+// admin accounts (create, update, delete, import).
 func NewAdminCommand(app core.App) *cobra.Command {
 	command := &cobra.Command{
 		Use:   "admin",
@@ -22,49 +26,159 @@ func NewAdminCommand(app core.App) *cobra.Command {
 	command.AddCommand(adminCreateCommand(app))
 	command.AddCommand(adminUpdateCommand(app))
 	command.AddCommand(adminDeleteCommand(app))
+	command.AddCommand(adminImportCommand(app))
 
 	return command
 }
 
 func adminCreateCommand(app core.App) *cobra.Command {
+	var randomPassword bool
+
 	command := &cobra.Command{
-		Use:          "create",
-		Example:      "admin create test@example.com 1234567890",
+		Use:          "create [email] [password]",
+		Example:      "admin create\nadmin create test@example.com --random-password\nadmin create test@example.com 1234567890",
 		Short:        "Creates a new admin account",
+		Long:         "Creates a new admin account.\n\nThe missing email and password arguments are prompted interactively (the password input is hidden).",
 		SilenceUsage: true,
 		RunE: func(command *cobra.Command, args []string) error {
-			if len(args) != 2 {
-				return errors.New("Missing email and password arguments.")
+			if len(args) > 2 {
+				return errors.New("Too many arguments - expected email and password.")
 			}
 
-			if args[0] == "" || is.EmailFormat.Validate(args[0]) != nil {
-				return errors.New("Missing or invalid email address.")
+			if len(args) == 2 && randomPassword {
+				return errors.New("The --random-password flag cannot be used together with the password argument.")
 			}
 
-			if len(args[1]) < 8 {
-				return errors.New("The password must be at least 8 chars long.")
+			if !app.Dao().HasTable((&models.Admin{}).TableName()) {
+				return errors.New("Migration are not initialized yet. Please run 'migrate up' and try again.")
 			}
 
-			admin := &models.Admin{}
-			admin.Email = args[0]
-			admin.SetPassword(args[1])
+			var email string
+			if len(args) > 0 {
+				email = args[0]
+			} else if err := survey.AskOne(&survey.Input{Message: "Email:"}, &email, survey.WithValidator(survey.Required)); err != nil {
+				return fmt.Errorf("Failed to read the admin email: %v", err)
+			}
 
-			if !app.Dao().HasTable(admin.TableName()) {
-				return errors.New("Migration are not initialized yet. Please run 'migrate up' and try again.")
+			if email == "" || is.EmailFormat.Validate(email) != nil {
+				return errors.New("Missing or invalid email address.")
+			}
+
+			if !app.Dao().IsAdminEmailUnique(email) {
+				return fmt.Errorf("Admin with email %s already exists.", email)
+			}
+
+			var password string
+			switch {
+			case len(args) == 2:
+				password = args[1]
+				if len(password) < 8 {
+					return errors.New("The password must be at least 8 chars long.")
+				}
+			case randomPassword:
+				password = security.RandomStringWithAlphabet(randomAdminPasswordLength, randomAdminPasswordAlphabet)
+			default:
+				var err error
+				password, err = promptAdminPassword(email)
+				if err != nil {
+					return err
+				}
 			}
 
+			admin := &models.Admin{}
+			admin.Email = email
+			admin.SetPassword(password)
+
 			if err := app.Dao().SaveAdmin(admin); err != nil {
 				return fmt.Errorf("Failed to create new admin account: %v", err)
 			}
 
 			color.Green("Successfully created new admin %s!", admin.Email)
+
+			if randomPassword {
+				// print it only once, without storing it anywhere
+				fmt.Fprintf(command.OutOrStdout(), "Generated password (it will not be shown again): %s\n", password)
+			}
+
 			return nil
 		},
 	}
 
+	command.PersistentFlags().BoolVar(&randomPassword, "random-password", false, "generate a random password and print it once")
+
 	return command
 }
 
+const (
+	randomAdminPasswordLength   = 20
+	randomAdminPasswordAlphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789!@#%^*-_=+"
+)
+
+// promptAdminPassword interactively asks for a new admin password
+// (with hidden input) and its confirmation.
+func promptAdminPassword(email string) (string, error) {
+	var password string
+	err := survey.AskOne(
+		&survey.Password{Message: "Password:"},
+		&password,
+		survey.WithValidator(func(ans any) error {
+			v, _ := ans.(string)
+			return validateAdminPasswordStrength(v, email)
+		}),
+	)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read the admin password: %v", err)
+	}
+
+	var confirm string
+	if err := survey.AskOne(&survey.Password{Message: "Confirm password:"}, &confirm); err != nil {
+		return "", fmt.Errorf("Failed to read the admin password confirmation: %v", err)
+	}
+
+	if confirm != password {
+		return "", errors.New("The passwords don't match.")
+	}
+
+	return password, nil
+}
+
+// validateAdminPasswordStrength checks whether the interactively
+// entered admin password is strong enough.
+//
+// The password must be at least 10 chars long, must contain at least
+// 3 of the lowercase, uppercase, digit and symbol characters groups
+// and must not contain the email name part.
+func validateAdminPasswordStrength(password string, email string) error {
+	if len(password) < 10 {
+		return errors.New("The password must be at least 10 chars long.")
+	}
+
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+
+	if lower+upper+digit+symbol < 3 {
+		return errors.New("The password must contain at least 3 of the following - lowercase letters, uppercase letters, digits and symbols.")
+	}
+
+	name, _, _ := strings.Cut(strings.ToLower(email), "@")
+	if len(name) >= 3 && strings.Contains(strings.ToLower(password), name) {
+		return errors.New("The password must not contain the email address name.")
+	}
+
+	return nil
+}
+
 func adminUpdateCommand(app core.App) *cobra.Command {
 	command := &cobra.Command{
 		Use:          "update",
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/spf13/cobra"
+)
+
+// errImportDryRun is used to rollback the dry-run import transaction.
+var errImportDryRun = errors.New("import dry-run")
+
+func adminImportCommand(app core.App) *cobra.Command {
+	var dryRun bool
+
+	command := &cobra.Command{
+		Use:     "import [file.csv]",
+		Example: "admin import ./admins.csv --dry-run",
+		Short:   "Creates or updates admin accounts in bulk from a CSV file",
+		Long: "Creates or updates admin accounts in bulk from a CSV file.\n\n" +
+			"The first CSV row must be a header with an \"email\" and optional \"password\" columns.\n" +
+			"Existing admins with an empty password cell are left unchanged.",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing CSV file argument.")
+			}
+
+			if !app.Dao().HasTable((&models.Admin{}).TableName()) {
+				return errors.New("Migration are not initialized yet. Please run 'migrate up' and try again.")
+			}
+
+			rows, err := readAdminImportRows(args[0])
+			if err != nil {
+				return fmt.Errorf("Failed to read the import file: %v", err)
+			}
+
+			out := command.OutOrStdout()
+
+			var created, updated, skipped, failed int
+
+			txErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+				seen := make(map[string]struct{}, len(rows))
+
+				for i, row := range rows {
+					status, err := importAdminRow(txDao, row, seen)
+					if err != nil {
+						failed++
+						fmt.Fprintf(out, "Row %d (%s): %v\n", i+1, row.email, err)
+						continue
+					}
+
+					switch status {
+					case "created":
+						created++
+					case "updated":
+						updated++
+					default:
+						skipped++
+					}
+
+					fmt.Fprintf(out, "Row %d (%s): %s\n", i+1, row.email, status)
+				}
+
+				if dryRun {
+					return errImportDryRun
+				}
+
+				return nil
+			})
+			if txErr != nil && !errors.Is(txErr, errImportDryRun) {
+				return fmt.Errorf("Failed to import admins: %v", txErr)
+			}
+
+			summary := fmt.Sprintf(
+				"Processed %d admins (%d created, %d updated, %d unchanged, %d failed).",
+				len(rows), created, updated, skipped, failed,
+			)
+			if dryRun {
+				summary = "[dry-run] " + summary
+			}
+
+			if failed > 0 {
+				fmt.Fprintln(out, color.YellowString(summary))
+			} else {
+				fmt.Fprintln(out, color.GreenString(summary))
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "validate and report the import rows without persisting them")
+
+	return command
+}
+
+type adminImportRow struct {
+	email    string
+	password string
+}
+
+// readAdminImportRows reads and parses the admin import CSV file rows.
+func readAdminImportRows(path string) ([]adminImportRow, error) {
+	records, err := readImportCSV(path, []string{"email"}, "password")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]adminImportRow, len(records))
+	for i, record := range records {
+		rows[i] = adminImportRow{
+			email:    strings.TrimSpace(record["email"]),
+			password: record["password"],
+		}
+	}
+
+	return rows, nil
+}
+
+// readImportCSV reads the rows of a CSV import file
+// whose first row is a header with the column names.
+//
+// The header must have all requiredColumns. Each returned row is keyed by the
+// lowercased column names (missing cells are returned as empty strings).
+func readImportCSV(path string, requiredColumns []string, optionalColumns ...string) ([]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing CSV header row")
+		}
+		return nil, err
+	}
+
+	columns := append(append([]string{}, requiredColumns...), optionalColumns...)
+
+	indexes := make(map[string]int, len(columns))
+	for i, column := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if list.ExistInSlice(name, columns) {
+			indexes[name] = i
+		}
+	}
+	for _, column := range requiredColumns {
+		if _, ok := indexes[column]; !ok {
+			return nil, fmt.Errorf("missing %q header column", column)
+		}
+	}
+
+	rows := []map[string]string{}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(columns))
+		for _, column := range columns {
+			if i, ok := indexes[column]; ok && i < len(record) {
+				row[column] = record[i]
+			} else {
+				row[column] = ""
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// importAdminRow creates or updates the admin account of a single
+// import row and returns its status ("created", "updated" or "unchanged").
+func importAdminRow(dao *daos.Dao, row adminImportRow, seen map[string]struct{}) (string, error) {
+	if row.email == "" || is.EmailFormat.Validate(row.email) != nil {
+		return "", errors.New("missing or invalid email address")
+	}
+
+	key := strings.ToLower(row.email)
+	if _, ok := seen[key]; ok {
+		return "", errors.New("duplicated email address")
+	}
+	seen[key] = struct{}{}
+
+	if row.password != "" && len(row.password) < 8 {
+		return "", errors.New("the password must be at least 8 chars long")
+	}
+
+	admin, err := dao.FindAdminByEmail(row.email)
+	if err != nil {
+		if row.password == "" {
+			return "", errors.New("missing password for the new admin")
+		}
+
+		admin = &models.Admin{}
+		admin.Email = row.email
+		admin.SetPassword(row.password)
+
+		if err := dao.SaveAdmin(admin); err != nil {
+			return "", fmt.Errorf("failed to create the admin: %v", err)
+		}
+
+		return "created", nil
+	}
+
+	if row.password == "" {
+		return "unchanged", nil
+	}
+
+	admin.SetPassword(row.password)
+
+	if err := dao.SaveAdmin(admin); err != nil {
+		return "", fmt.Errorf("failed to update the admin: %v", err)
+	}
+
+	return "updated", nil
+}
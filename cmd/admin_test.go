@@ -1,6 +1,10 @@
 package cmd_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/cmd"
@@ -88,6 +92,57 @@ func TestAdminCreateCommand(t *testing.T) {
 	}
 }
 
+func TestAdminCreateCommandRandomPassword(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// with password argument
+	{
+		command := cmd.NewAdminCommand(app)
+		command.SetArgs([]string{"create", "test_random1@example.com", "1234567890", "--random-password"})
+		command.SetOut(new(bytes.Buffer))
+		command.SetErr(new(bytes.Buffer))
+
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error due to the password argument")
+		}
+
+		if _, err := app.Dao().FindAdminByEmail("test_random1@example.com"); err == nil {
+			t.Fatal("Expected the admin to not be created")
+		}
+	}
+
+	// with generated password
+	{
+		out := new(bytes.Buffer)
+
+		command := cmd.NewAdminCommand(app)
+		command.SetArgs([]string{"create", "test_random2@example.com", "--random-password"})
+		command.SetOut(out)
+		command.SetErr(new(bytes.Buffer))
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, password, ok := strings.Cut(strings.TrimSpace(out.String()), "(it will not be shown again): ")
+		if !ok || len(password) != 20 {
+			t.Fatalf("Expected the generated password to be printed, got %q", out.String())
+		}
+
+		admin, err := app.Dao().FindAdminByEmail("test_random2@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !admin.ValidatePassword(password) {
+			t.Fatal("Expected the admin password to match the printed one")
+		}
+	}
+}
+
 func TestAdminUpdateCommand(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -219,3 +274,87 @@ func TestAdminDeleteCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestAdminImportCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	file := filepath.Join(t.TempDir(), "admins.csv")
+	content := strings.Join([]string{
+		"Email,Password",
+		"test_import1@example.com,1234567890",
+		"test@example.com,new_password",
+		"test2@example.com,",
+		"invalid,1234567890",
+		"test_import2@example.com,1234",
+		"test_import3@example.com,",
+		"test_import1@example.com,1234567890",
+	}, "\n")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOutput := []string{
+		"Row 1 (test_import1@example.com): created",
+		"Row 2 (test@example.com): updated",
+		"Row 3 (test2@example.com): unchanged",
+		"Row 4 (invalid): missing or invalid email address",
+		"Row 5 (test_import2@example.com): the password must be at least 8 chars long",
+		"Row 6 (test_import3@example.com): missing password for the new admin",
+		"Row 7 (test_import1@example.com): duplicated email address",
+		"Processed 7 admins (1 created, 1 updated, 1 unchanged, 4 failed).",
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		out := new(bytes.Buffer)
+
+		args := []string{"import", file}
+		if dryRun {
+			args = append(args, "--dry-run")
+		}
+
+		command := cmd.NewAdminCommand(app)
+		command.SetArgs(args)
+		command.SetOut(out)
+		command.SetErr(new(bytes.Buffer))
+
+		if err := command.Execute(); err != nil {
+			t.Fatalf("[dryRun %v] %v", dryRun, err)
+		}
+
+		for _, line := range expectedOutput {
+			if !strings.Contains(out.String(), line) {
+				t.Fatalf("[dryRun %v] Expected %q in the output:\n%s", dryRun, line, out.String())
+			}
+		}
+
+		_, err := app.Dao().FindAdminByEmail("test_import1@example.com")
+		if dryRun != (err != nil) {
+			t.Fatalf("[dryRun %v] Expected the new admin to be created only with the regular import (%v)", dryRun, err)
+		}
+
+		admin, err := app.Dao().FindAdminByEmail("test@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if admin.ValidatePassword("new_password") == dryRun {
+			t.Fatalf("[dryRun %v] Expected the existing admin password to be updated only with the regular import", dryRun)
+		}
+	}
+
+	// missing email column
+	missingColumnFile := filepath.Join(t.TempDir(), "missing.csv")
+	if err := os.WriteFile(missingColumnFile, []byte("name,password\ntest,1234567890"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	command := cmd.NewAdminCommand(app)
+	command.SetArgs([]string{"import", missingColumnFile})
+	command.SetOut(new(bytes.Buffer))
+	command.SetErr(new(bytes.Buffer))
+	if err := command.Execute(); err == nil {
+		t.Fatal("Expected error due to the missing email column")
+	}
+}
@@ -0,0 +1,645 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cobra"
+)
+
+// available benchmark operations
+const (
+	benchOpRead   = "read"
+	benchOpList   = "list"
+	benchOpWrite  = "write"
+	benchOpUpdate = "update"
+	benchOpDelete = "delete"
+)
+
+// benchAuthAdmin and benchAuthGuest are the supported benchmark request identities.
+const (
+	benchAuthAdmin = "admin"
+	benchAuthGuest = "guest"
+)
+
+// benchMaxPoolIds is the max number of existing record ids loaded
+// for the read operations.
+const benchMaxPoolIds = 1000
+
+// NewBenchCommand creates and returns new command for benchmarking
+// the records API of a collection with a configurable operations mix.
+//
+// The requests are executed against the in-process API router
+// (aka. without the network overhead) and the records created by the
+// benchmark are deleted at the end unless the --keep flag is set.
+func NewBenchCommand(app core.App) *cobra.Command {
+	var collectionName string
+	var ops string
+	var concurrency int
+	var duration time.Duration
+	var seed int
+	var auth string
+	var keep bool
+
+	command := &cobra.Command{
+		Use:          "bench",
+		Example:      "bench --collection posts --ops read=80,write=20 --concurrency 50 --duration 60s",
+		Short:        "Benchmarks the records API of a collection",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if collectionName == "" {
+				return errors.New("Missing required --collection flag.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(collectionName)
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", collectionName)
+			}
+
+			weights, err := parseBenchOps(ops)
+			if err != nil {
+				return err
+			}
+
+			if collection.IsView() {
+				for _, w := range weights {
+					if w.Op != benchOpRead && w.Op != benchOpList {
+						return fmt.Errorf("View collection %q supports only read and list operations.", collection.Name)
+					}
+				}
+			}
+
+			if concurrency <= 0 {
+				return errors.New("The --concurrency must be greater than 0.")
+			}
+
+			if duration <= 0 {
+				return errors.New("The --duration must be greater than 0.")
+			}
+
+			if auth != benchAuthAdmin && auth != benchAuthGuest {
+				return errors.New("Invalid --auth value - must be admin or guest.")
+			}
+
+			b, err := newBenchmark(app, collection, auth)
+			if err != nil {
+				return err
+			}
+
+			out := command.OutOrStdout()
+
+			printBenchPragmas(out, app)
+
+			if seed > 0 && !collection.IsView() {
+				fmt.Fprintf(out, "Creating %d seed record(s)...\n", seed)
+				for i := 0; i < seed; i++ {
+					if _, err := b.exec(benchOpWrite); err != nil {
+						return fmt.Errorf("Failed to create a seed record (use --seed=0 to skip the seeding): %w", err)
+					}
+				}
+			}
+
+			fmt.Fprintf(out, "Running %s for %s with %d concurrent worker(s)...\n", ops, duration, concurrency)
+
+			stats := b.run(command.Context(), weights, concurrency, duration)
+
+			printBenchStats(out, stats, duration)
+
+			if !keep {
+				if err := b.cleanup(); err != nil {
+					return fmt.Errorf("Failed to delete the benchmark records: %w", err)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().StringVar(&collectionName, "collection", "", "the name or id of the benchmarked collection")
+	command.PersistentFlags().StringVar(&ops, "ops", "read=80,write=20", "comma separated weighted operations mix (read, list, write, update, delete)")
+	command.PersistentFlags().IntVar(&concurrency, "concurrency", 10, "the number of concurrent workers")
+	command.PersistentFlags().DurationVar(&duration, "duration", 30*time.Second, "the benchmark duration")
+	command.PersistentFlags().IntVar(&seed, "seed", 100, "the number of records to create before the benchmark (used by the read, update and delete operations)")
+	command.PersistentFlags().StringVar(&auth, "auth", benchAuthAdmin, "the requests identity - admin or guest (applies the collection API rules)")
+	command.PersistentFlags().BoolVar(&keep, "keep", false, "keep the records created by the benchmark")
+
+	return command
+}
+
+// -------------------------------------------------------------------
+
+// benchOpWeight is a single weighted benchmark operation.
+type benchOpWeight struct {
+	Op     string
+	Weight int
+}
+
+// parseBenchOps parses an operations mix string (eg. "read=80,write=20").
+func parseBenchOps(raw string) ([]benchOpWeight, error) {
+	result := []benchOpWeight{}
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		op, rawWeight, hasWeight := strings.Cut(part, "=")
+
+		weight := 1
+		if hasWeight {
+			var err error
+			weight, err = strconv.Atoi(strings.TrimSpace(rawWeight))
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("Invalid %q operation weight - must be a positive integer.", op)
+			}
+		}
+
+		op = strings.TrimSpace(op)
+
+		switch op {
+		case benchOpRead, benchOpList, benchOpWrite, benchOpUpdate, benchOpDelete:
+		default:
+			return nil, fmt.Errorf("Unsupported operation %q - must be read, list, write, update or delete.", op)
+		}
+
+		result = append(result, benchOpWeight{Op: op, Weight: weight})
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("Missing benchmark operations.")
+	}
+
+	return result, nil
+}
+
+// pickBenchOp returns a random operation based on the operations weights.
+func pickBenchOp(r *rand.Rand, weights []benchOpWeight) string {
+	total := 0
+	for _, w := range weights {
+		total += w.Weight
+	}
+
+	n := r.Intn(total)
+	for _, w := range weights {
+		if n < w.Weight {
+			return w.Op
+		}
+		n -= w.Weight
+	}
+
+	return weights[len(weights)-1].Op
+}
+
+// -------------------------------------------------------------------
+
+// benchOpStats holds the collected measurements of a single operation.
+type benchOpStats struct {
+	Op        string
+	Errors    int
+	Skipped   int
+	Latencies []time.Duration
+}
+
+// percentile returns the q-th (0-1) latency percentile.
+//
+// Note that the latencies must be already sorted.
+func (s *benchOpStats) percentile(q float64) time.Duration {
+	if len(s.Latencies) == 0 {
+		return 0
+	}
+
+	return s.Latencies[int(q*float64(len(s.Latencies)-1))]
+}
+
+type benchmark struct {
+	app        core.App
+	collection *models.Collection
+	router     *echo.Echo
+	authToken  string
+
+	// relIds caches the related collections record ids used
+	// for generating the relation fields data
+	relIds map[string][]string
+
+	mu       sync.Mutex
+	existing []string
+	created  []string
+}
+
+func newBenchmark(app core.App, collection *models.Collection, auth string) (*benchmark, error) {
+	e, err := apis.InitApi(app)
+	if err != nil {
+		return nil, err
+	}
+
+	// trigger the serve event to ensure that the plugin and script routes and middlewares are registered
+	err = app.OnBeforeServe().Trigger(&core.ServeEvent{
+		App:    app,
+		Router: e,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to register the app routes: %v", err)
+	}
+
+	b := &benchmark{
+		app:        app,
+		collection: collection,
+		router:     e,
+		relIds:     map[string][]string{},
+	}
+
+	if auth == benchAuthAdmin {
+		admin := &models.Admin{}
+		if err := app.Dao().AdminQuery().OrderBy("created ASC").Limit(1).One(admin); err != nil {
+			return nil, errors.New("Missing admin account - create one or use --auth=guest.")
+		}
+
+		b.authToken, err = tokens.NewAdminAuthToken(app, admin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = app.Dao().RecordQuery(collection).
+		Select(collection.Name + ".id").
+		Limit(benchMaxPoolIds).
+		Column(&b.existing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range collection.Schema.Fields() {
+		// init the options in advance to avoid races when generating the workers data
+		field.InitOptions()
+
+		if field.Type != schema.FieldTypeRelation {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.RelationOptions)
+		if options == nil || b.relIds[options.CollectionId] != nil {
+			continue
+		}
+
+		ids := []string{}
+		if relCollection, err := app.Dao().FindCollectionByNameOrId(options.CollectionId); err == nil {
+			app.Dao().RecordQuery(relCollection).
+				Select(relCollection.Name + ".id").
+				Limit(benchMaxPoolIds).
+				Column(&ids)
+		}
+		b.relIds[options.CollectionId] = ids
+	}
+
+	return b, nil
+}
+
+// run executes the weighted operations with the specified number
+// of concurrent workers until the duration elapses or ctx is done.
+func (b *benchmark) run(ctx context.Context, weights []benchOpWeight, concurrency int, duration time.Duration) []*benchOpStats {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var mu sync.Mutex
+	stats := map[string]*benchOpStats{}
+	for _, w := range weights {
+		stats[w.Op] = &benchOpStats{Op: w.Op}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		go func(workerSeed int64) {
+			defer wg.Done()
+
+			r := rand.New(rand.NewSource(workerSeed))
+
+			for ctx.Err() == nil {
+				op := pickBenchOp(r, weights)
+
+				start := time.Now()
+				skipped, err := b.exec(op)
+				elapsed := time.Since(start)
+
+				mu.Lock()
+				s := stats[op]
+				switch {
+				case skipped:
+					s.Skipped++
+				case err != nil:
+					s.Errors++
+				default:
+					s.Latencies = append(s.Latencies, elapsed)
+				}
+				mu.Unlock()
+			}
+		}(time.Now().UnixNano() + int64(i))
+	}
+
+	wg.Wait()
+
+	result := make([]*benchOpStats, 0, len(weights))
+	for _, w := range weights {
+		s := stats[w.Op]
+		sort.Slice(s.Latencies, func(i, j int) bool {
+			return s.Latencies[i] < s.Latencies[j]
+		})
+		result = append(result, s)
+	}
+
+	return result
+}
+
+// exec executes a single benchmark operation.
+//
+// It returns skipped=true if the operation couldn't be executed
+// (eg. there are no records to update or delete).
+func (b *benchmark) exec(op string) (skipped bool, err error) {
+	basePath := "/api/collections/" + b.collection.Name + "/records"
+
+	var method, path string
+	var body []byte
+
+	switch op {
+	case benchOpRead:
+		id := b.randomId(false)
+		if id == "" {
+			return true, nil
+		}
+		method, path = http.MethodGet, basePath+"/"+id
+	case benchOpList:
+		method, path = http.MethodGet, basePath+"?page="+strconv.Itoa(1+rand.Intn(3))+"&perPage=30"
+	case benchOpWrite:
+		method, path = http.MethodPost, basePath
+		body, _ = json.Marshal(b.randomData())
+	case benchOpUpdate:
+		id := b.randomId(true)
+		if id == "" {
+			return true, nil
+		}
+		method, path = http.MethodPatch, basePath+"/"+id
+		body, _ = json.Marshal(b.randomData())
+	case benchOpDelete:
+		id := b.popCreatedId()
+		if id == "" {
+			return true, nil
+		}
+		method, path = http.MethodDelete, basePath+"/"+id
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if b.authToken != "" {
+		req.Header.Set("Authorization", b.authToken)
+	}
+	rec := httptest.NewRecorder()
+
+	b.router.ServeHTTP(rec, req)
+
+	if rec.Code >= 400 {
+		return false, fmt.Errorf("%s %s failed with %d status: %s", method, path, rec.Code, rec.Body.String())
+	}
+
+	if op == benchOpWrite {
+		created := struct {
+			Id string `json:"id"`
+		}{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &created); err == nil && created.Id != "" {
+			b.mu.Lock()
+			b.created = append(b.created, created.Id)
+			b.mu.Unlock()
+		}
+	}
+
+	return false, nil
+}
+
+// randomId returns a random record id from the ids pool.
+//
+// If onlyCreated is set, only the ids of the records created
+// by the benchmark are considered (aka. existing data is never modified).
+func (b *benchmark) randomId(onlyCreated bool) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	total := len(b.created)
+	if !onlyCreated {
+		total += len(b.existing)
+	}
+
+	if total == 0 {
+		return ""
+	}
+
+	n := rand.Intn(total)
+	if n < len(b.created) {
+		return b.created[n]
+	}
+
+	return b.existing[n-len(b.created)]
+}
+
+// popCreatedId removes and returns a random id of a record created by the benchmark.
+func (b *benchmark) popCreatedId() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.created) == 0 {
+		return ""
+	}
+
+	n := rand.Intn(len(b.created))
+	id := b.created[n]
+	b.created[n] = b.created[len(b.created)-1]
+	b.created = b.created[:len(b.created)-1]
+
+	return id
+}
+
+// cleanup deletes the remaining records created by the benchmark.
+func (b *benchmark) cleanup() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, id := range b.created {
+		record, err := b.app.Dao().FindRecordById(b.collection.Id, id)
+		if err != nil {
+			continue // already deleted
+		}
+
+		if err := b.app.Dao().DeleteRecord(record); err != nil {
+			return err
+		}
+	}
+
+	b.created = nil
+
+	return nil
+}
+
+// randomData generates random record data based on the collection schema.
+func (b *benchmark) randomData() map[string]any {
+	data := map[string]any{}
+
+	for _, field := range b.collection.Schema.Fields() {
+		switch field.Type {
+		case schema.FieldTypeText:
+			length := 10 + rand.Intn(40)
+			if options, ok := field.Options.(*schema.TextOptions); ok {
+				if options.Min != nil && length < *options.Min {
+					length = *options.Min
+				}
+				if options.Max != nil && *options.Max > 0 && length > *options.Max {
+					length = *options.Max
+				}
+			}
+			data[field.Name] = security.RandomString(length)
+		case schema.FieldTypeEditor:
+			data[field.Name] = "<p>" + security.RandomString(100+rand.Intn(400)) + "</p>"
+		case schema.FieldTypeNumber:
+			min, max := 0.0, 1000.0
+			if options, ok := field.Options.(*schema.NumberOptions); ok {
+				if options.Min != nil {
+					min = *options.Min
+				}
+				if options.Max != nil {
+					max = *options.Max
+				}
+			}
+			data[field.Name] = float64(int(min + rand.Float64()*(max-min)))
+		case schema.FieldTypeBool:
+			data[field.Name] = rand.Intn(2) == 1
+		case schema.FieldTypeEmail:
+			data[field.Name] = strings.ToLower(security.RandomString(10)) + "@example.com"
+		case schema.FieldTypeUrl:
+			data[field.Name] = "https://example.com/" + security.RandomString(10)
+		case schema.FieldTypeDate:
+			data[field.Name] = types.NowDateTime().String()
+		case schema.FieldTypeJson:
+			data[field.Name] = map[string]any{"value": security.RandomString(20), "n": rand.Intn(100)}
+		case schema.FieldTypeGeoPoint:
+			data[field.Name] = types.GeoPoint{Lat: rand.Float64()*180 - 90, Lng: rand.Float64()*360 - 180}
+		case schema.FieldTypeMoney:
+			if options, ok := field.Options.(*schema.MoneyOptions); ok {
+				var min, max int64 = 0, 100000
+				if options.Min != nil {
+					min = *options.Min
+				}
+				if options.Max != nil {
+					max = *options.Max
+				}
+				if max < min {
+					max = min
+				}
+				data[field.Name] = types.NewMoney(min+rand.Int63n(max-min+1), options.Currency)
+			}
+		case schema.FieldTypePhone:
+			if options, ok := field.Options.(*schema.PhoneOptions); ok &&
+				(len(options.OnlyCountries) == 0 || list.ExistInSlice("US", options.OnlyCountries)) {
+				data[field.Name] = fmt.Sprintf("+1202555%04d", rand.Intn(10000))
+			}
+		case schema.FieldTypeSelect:
+			if options, ok := field.Options.(*schema.SelectOptions); ok && len(options.Values) > 0 {
+				data[field.Name] = options.Values[rand.Intn(len(options.Values))]
+			}
+		case schema.FieldTypeRelation:
+			if options, ok := field.Options.(*schema.RelationOptions); ok {
+				if ids := b.relIds[options.CollectionId]; len(ids) > 0 {
+					data[field.Name] = ids[rand.Intn(len(ids))]
+				}
+			}
+		}
+	}
+
+	if b.collection.IsAuth() {
+		data[schema.FieldNameEmail] = strings.ToLower(security.RandomString(15)) + "@example.com"
+		data["password"] = "1234567890"
+		data["passwordConfirm"] = "1234567890"
+	}
+
+	return data
+}
+
+// -------------------------------------------------------------------
+
+func printBenchPragmas(out io.Writer, app core.App) {
+	pragmas := []string{"journal_mode", "synchronous", "cache_size", "busy_timeout"}
+
+	values := make([]string, 0, len(pragmas))
+
+	for _, pragma := range pragmas {
+		var value string
+		if err := app.Dao().DB().NewQuery("PRAGMA " + pragma).Row(&value); err != nil {
+			value = "n/a"
+		}
+
+		values = append(values, pragma+"="+value)
+	}
+
+	fmt.Fprintf(out, "SQLite pragmas: %s\n", strings.Join(values, ", "))
+}
+
+func printBenchStats(out io.Writer, stats []*benchOpStats, duration time.Duration) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "OP\tOK\tERRORS\tSKIPPED\tOPS/S\tP50\tP90\tP99\tMAX")
+
+	var totalOk, totalErrors int
+
+	for _, s := range stats {
+		totalOk += len(s.Latencies)
+		totalErrors += s.Errors
+
+		fmt.Fprintf(
+			w,
+			"%s\t%d\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\n",
+			s.Op,
+			len(s.Latencies),
+			s.Errors,
+			s.Skipped,
+			float64(len(s.Latencies))/duration.Seconds(),
+			s.percentile(0.5).Round(time.Microsecond),
+			s.percentile(0.9).Round(time.Microsecond),
+			s.percentile(0.99).Round(time.Microsecond),
+			s.percentile(1).Round(time.Microsecond),
+		)
+	}
+
+	w.Flush()
+
+	fmt.Fprintf(
+		out,
+		"Total: %d successful and %d failed operation(s), %.1f ops/s.\n",
+		totalOk,
+		totalErrors,
+		float64(totalOk)/duration.Seconds(),
+	)
+}
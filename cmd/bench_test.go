@@ -0,0 +1,134 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestBenchCommand(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		args           []string
+		expectError    bool
+		expectedOutput []string
+	}{
+		{
+			name:        "missing collection flag",
+			args:        []string{},
+			expectError: true,
+		},
+		{
+			name:        "missing collection",
+			args:        []string{"--collection", "missing"},
+			expectError: true,
+		},
+		{
+			name:        "invalid ops",
+			args:        []string{"--collection", "demo2", "--ops", "read=80,invalid=20"},
+			expectError: true,
+		},
+		{
+			name:        "invalid ops weight",
+			args:        []string{"--collection", "demo2", "--ops", "read=-1"},
+			expectError: true,
+		},
+		{
+			name:        "write ops for a view collection",
+			args:        []string{"--collection", "view1", "--ops", "read=80,write=20"},
+			expectError: true,
+		},
+		{
+			name:        "invalid auth",
+			args:        []string{"--collection", "demo2", "--auth", "invalid"},
+			expectError: true,
+		},
+		{
+			name: "mixed ops",
+			args: []string{
+				"--collection", "demo2",
+				"--ops", "read=40,list=20,write=20,update=10,delete=10",
+				"--concurrency", "4",
+				"--duration", "300ms",
+				"--seed", "5",
+			},
+			expectedOutput: []string{
+				"SQLite pragmas: journal_mode=",
+				"Creating 5 seed record(s)...",
+				"OP",
+				"read",
+				"list",
+				"write",
+				"update",
+				"delete",
+				"Total:",
+			},
+		},
+		{
+			name: "guest without seed",
+			args: []string{
+				"--collection", "demo2",
+				"--ops", "read,list",
+				"--concurrency", "2",
+				"--duration", "200ms",
+				"--seed", "0",
+				"--auth", "guest",
+			},
+			expectedOutput: []string{
+				"read",
+				"list",
+				"Total:",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			totalBefore := countRecords(t, app, "demo2")
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewBenchCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			// the benchmark records should be deleted
+			if totalAfter := countRecords(t, app, "demo2"); totalAfter != totalBefore {
+				t.Fatalf("Expected %d demo2 records after the benchmark, got %d", totalBefore, totalAfter)
+			}
+		})
+	}
+}
+
+func countRecords(t *testing.T, app *tests.TestApp, collection string) int {
+	var total int
+
+	err := app.Dao().RecordQuery(collection).Select("count(*)").Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
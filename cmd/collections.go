@@ -0,0 +1,371 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// collectionsTruncateBatchSize is the number of records
+// loaded at once when truncating a collection.
+const collectionsTruncateBatchSize = 500
+
+// NewCollectionsCommand creates and returns new command for managing
+// the app collections (list, export, import and truncate).
+//
+// By default the system collections are excluded (use --include-system).
+func NewCollectionsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "collections",
+		Short: "Manages the app collections",
+		// prevent cobra to print its default help message for the group command
+		RunE: func(command *cobra.Command, args []string) error {
+			return command.Help()
+		},
+	}
+
+	command.AddCommand(collectionsListCommand(app))
+	command.AddCommand(collectionsExportCommand(app))
+	command.AddCommand(collectionsImportCommand(app))
+	command.AddCommand(collectionsTruncateCommand(app))
+
+	return command
+}
+
+func collectionsListCommand(app core.App) *cobra.Command {
+	var includeSystem bool
+	var asJSON bool
+
+	command := &cobra.Command{
+		Use:          "list",
+		Example:      "collections list --include-system",
+		Short:        "Lists the app collections",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			collections, err := findCommandCollections(app, includeSystem)
+			if err != nil {
+				return err
+			}
+
+			out := command.OutOrStdout()
+
+			if asJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(collections)
+			}
+
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tTYPE\tFIELDS\tSYSTEM")
+			for _, c := range collections {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%v\n", c.Id, c.Name, collectionType(c), len(c.Schema.Fields()), c.System)
+			}
+
+			return w.Flush()
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&includeSystem, "include-system", false, "list also the system collections")
+	command.PersistentFlags().BoolVar(&asJSON, "json", false, "print the collections as JSON")
+
+	return command
+}
+
+func collectionsExportCommand(app core.App) *cobra.Command {
+	var includeSystem bool
+	var output string
+
+	command := &cobra.Command{
+		Use:          "export",
+		Example:      "collections export --output=./pb_schema.json",
+		Short:        "Exports the app collections as JSON (in the Admin UI import format)",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			collections, err := findCommandCollections(app, includeSystem)
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = command.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("Failed to create the output file: %v", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+
+			return enc.Encode(collections)
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&includeSystem, "include-system", false, "export also the system collections")
+	command.PersistentFlags().StringVarP(&output, "output", "o", "", "the output file path (default to stdout)")
+
+	return command
+}
+
+func collectionsImportCommand(app core.App) *cobra.Command {
+	var includeSystem bool
+	var deleteMissing bool
+	var dryRun bool
+	var yes bool
+
+	command := &cobra.Command{
+		Use:          "import <file>",
+		Example:      "collections import ./pb_schema.json --delete-missing\ncat ./pb_schema.json | collections import - --yes",
+		Short:        "Imports the collections from a JSON file (use - for stdin)",
+		Long:         "Imports the collections from a JSON file (use - for stdin).\n\nThe changes are previewed and must be confirmed before being applied (use --yes to skip the confirmation).",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing import file argument.")
+			}
+
+			out := command.OutOrStdout()
+
+			var raw []byte
+			var err error
+			if args[0] == "-" {
+				raw, err = io.ReadAll(command.InOrStdin())
+			} else {
+				raw, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("Failed to read the import file: %v", err)
+			}
+
+			collections := []*models.Collection{}
+			if err := json.Unmarshal(raw, &collections); err != nil {
+				return fmt.Errorf("Failed to parse the import file: %v", err)
+			}
+
+			if !includeSystem {
+				var skipped int
+				collections, skipped, err = excludeSystemCollections(app, collections, deleteMissing)
+				if err != nil {
+					return err
+				}
+				if skipped > 0 {
+					fmt.Fprintf(out, "Skipped %d system collection(s) (use --include-system to import them).\n", skipped)
+				}
+			}
+
+			changes, err := diffSchema(app, collections, deleteMissing)
+			if err != nil {
+				return err
+			}
+
+			printSchemaChanges(out, changes)
+
+			if len(changes) == 0 || dryRun {
+				return nil
+			}
+
+			if !yes {
+				confirmed, err := confirmCommandAction(fmt.Sprintf("Do you want to apply the above %d collection change(s)?", len(changes)))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Fprintln(out, "The import was cancelled.")
+					return nil
+				}
+			}
+
+			form := forms.NewCollectionsImport(app)
+			form.Collections = collections
+			form.DeleteMissing = deleteMissing
+
+			if err := form.Submit(); err != nil {
+				return fmt.Errorf("Failed to import the collections: %v", err)
+			}
+
+			fmt.Fprintf(out, "Successfully applied %d collection change(s).\n", len(changes))
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&includeSystem, "include-system", false, "import also the system collections (by default they are left unchanged)")
+	command.PersistentFlags().BoolVar(&deleteMissing, "delete-missing", false, "delete the collections and fields that are missing in the import file")
+	command.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the changes without applying them")
+	command.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "apply the changes without confirmation")
+
+	return command
+}
+
+func collectionsTruncateCommand(app core.App) *cobra.Command {
+	var includeSystem bool
+	var yes bool
+
+	command := &cobra.Command{
+		Use:          "truncate <collection>",
+		Example:      "collections truncate posts --yes",
+		Short:        "Deletes all records of a single collection",
+		Long:         "Deletes all records of a single collection.\n\nThe records are deleted one by one to ensure that their files and relation references are also removed.",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing collection argument.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", args[0])
+			}
+
+			if collection.IsView() {
+				return fmt.Errorf("View collection %q cannot be truncated.", collection.Name)
+			}
+
+			if collection.System && !includeSystem {
+				return fmt.Errorf("Collection %q is a system collection (use --include-system to truncate it).", collection.Name)
+			}
+
+			out := command.OutOrStdout()
+
+			if !yes {
+				confirmed, err := confirmCommandAction(fmt.Sprintf("Do you really want to delete all %q records?", collection.Name))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Fprintln(out, "The truncate was cancelled.")
+					return nil
+				}
+			}
+
+			total, err := truncateCollection(app.Dao(), collection)
+			if err != nil {
+				return fmt.Errorf("Failed to truncate collection %q: %v", collection.Name, err)
+			}
+
+			fmt.Fprintf(out, "Successfully deleted %d record(s) from %s.\n", total, collection.Name)
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&includeSystem, "include-system", false, "allow truncating a system collection")
+	command.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "delete the records without confirmation")
+
+	return command
+}
+
+// findCommandCollections returns the app collections ordered by their
+// created date (optionally excluding the system ones).
+func findCommandCollections(app core.App, includeSystem bool) ([]*models.Collection, error) {
+	collections := []*models.Collection{}
+
+	query := app.Dao().CollectionQuery().OrderBy("created ASC")
+	if !includeSystem {
+		query.AndWhere(dbx.HashExp{"system": false})
+	}
+
+	if err := query.All(&collections); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// excludeSystemCollections removes the system collections from the
+// import list and, if deleteMissing is set, appends the existing system
+// collections unchanged so that they are not deleted.
+//
+// It returns the filtered collections and the number of the skipped ones.
+func excludeSystemCollections(app core.App, collections []*models.Collection, deleteMissing bool) ([]*models.Collection, int, error) {
+	systemCollections := []*models.Collection{}
+	err := app.Dao().CollectionQuery().
+		AndWhere(dbx.HashExp{"system": true}).
+		OrderBy("created ASC").
+		All(&systemCollections)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	systemIds := make(map[string]struct{}, len(systemCollections))
+	for _, c := range systemCollections {
+		systemIds[c.Id] = struct{}{}
+	}
+
+	result := make([]*models.Collection, 0, len(collections)+len(systemCollections))
+
+	var skipped int
+	for _, c := range collections {
+		if _, ok := systemIds[c.Id]; ok || c.System {
+			skipped++
+			continue
+		}
+		result = append(result, c)
+	}
+
+	if deleteMissing {
+		result = append(result, systemCollections...)
+	}
+
+	return result, skipped, nil
+}
+
+// truncateCollection deletes all records of the provided collection
+// in a single transaction and returns the number of the deleted records.
+func truncateCollection(dao *daos.Dao, collection *models.Collection) (int, error) {
+	var total int
+
+	err := dao.RunInTransaction(func(txDao *daos.Dao) error {
+		for {
+			records := []*models.Record{}
+
+			err := txDao.RecordQuery(collection).
+				Limit(collectionsTruncateBatchSize).
+				All(&records)
+			if err != nil {
+				return err
+			}
+
+			if len(records) == 0 {
+				return nil
+			}
+
+			for _, record := range records {
+				if err := txDao.DeleteRecord(record); err != nil {
+					return fmt.Errorf("failed to delete record %q: %w", record.Id, err)
+				}
+				total++
+			}
+		}
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// confirmCommandAction interactively asks the user to confirm an action.
+func confirmCommandAction(message string) (bool, error) {
+	var confirmed bool
+
+	if err := survey.AskOne(&survey.Confirm{Message: message}, &confirmed); err != nil {
+		return false, fmt.Errorf("Failed to read the confirmation (use --yes in non-interactive mode): %v", err)
+	}
+
+	return confirmed, nil
+}
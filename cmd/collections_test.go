@@ -0,0 +1,251 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCollectionsCommand(t *testing.T) {
+	t.Parallel()
+
+	newCollectionJSON := `[{
+		"id": "cli_new_collect",
+		"name": "cli_new",
+		"type": "base",
+		"schema": [{"id": "cli_title", "name": "title", "type": "text"}]
+	}]`
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		stdin            string
+		expectError      bool
+		expectedOutput   []string
+		unexpectedOutput []string
+		afterFunc        func(t *testing.T, app *tests.TestApp)
+	}{
+		{
+			name: "list",
+			args: []string{"list"},
+			expectedOutput: []string{
+				"ID",
+				"demo1",
+				"view1",
+			},
+			unexpectedOutput: []string{
+				"nologin",
+			},
+		},
+		{
+			name: "list (include system)",
+			args: []string{"list", "--include-system"},
+			expectedOutput: []string{
+				"demo1",
+				"nologin",
+			},
+		},
+		{
+			name: "list (json)",
+			args: []string{"list", "--json"},
+			expectedOutput: []string{
+				`"name": "demo1"`,
+				`"type": "view"`,
+			},
+		},
+		{
+			name: "export",
+			args: []string{"export"},
+			expectedOutput: []string{
+				`"name": "users"`,
+				`"schema": [`,
+			},
+			unexpectedOutput: []string{
+				`"name": "nologin"`,
+			},
+		},
+		{
+			name:        "import - missing file arg",
+			args:        []string{"import"},
+			expectError: true,
+		},
+		{
+			name:        "import - invalid json",
+			args:        []string{"import", "-"},
+			stdin:       `[{`,
+			expectError: true,
+		},
+		{
+			name:  "import - new collection",
+			args:  []string{"import", "-", "--yes"},
+			stdin: newCollectionJSON,
+			expectedOutput: []string{
+				`+ collection "cli_new"`,
+				"Successfully applied 1 collection change(s).",
+			},
+			afterFunc: func(t *testing.T, app *tests.TestApp) {
+				collection, err := app.Dao().FindCollectionByNameOrId("cli_new")
+				if err != nil {
+					t.Fatalf("Expected the collection to be created: %v", err)
+				}
+
+				if collection.Schema.GetFieldByName("title") == nil {
+					t.Fatal("Expected the title field to be created")
+				}
+			},
+		},
+		{
+			name:  "import - system collection without --include-system",
+			args:  []string{"import", "-", "--yes"},
+			stdin: `[{"id": "kpv709sk2lqbqk8", "name": "nologin_renamed", "type": "auth"}]`,
+			// no collections to import after the system ones are skipped
+			expectError: true,
+			expectedOutput: []string{
+				"Skipped 1 system collection(s)",
+			},
+			afterFunc: func(t *testing.T, app *tests.TestApp) {
+				if _, err := app.Dao().FindCollectionByNameOrId("nologin"); err != nil {
+					t.Fatalf("Expected the system collection to be unchanged: %v", err)
+				}
+			},
+		},
+		{
+			name:  "import - dry-run with delete missing (preserves the system collections)",
+			args:  []string{"import", "-", "--dry-run", "--delete-missing"},
+			stdin: newCollectionJSON,
+			expectedOutput: []string{
+				`+ collection "cli_new"`,
+				`- collection "demo1"`,
+			},
+			unexpectedOutput: []string{
+				`- collection "nologin"`,
+			},
+			afterFunc: func(t *testing.T, app *tests.TestApp) {
+				if _, err := app.Dao().FindCollectionByNameOrId("cli_new"); err == nil {
+					t.Fatal("Expected the dry-run changes to not be applied")
+				}
+			},
+		},
+		{
+			name:        "truncate - missing collection",
+			args:        []string{"truncate", "missing", "--yes"},
+			expectError: true,
+		},
+		{
+			name:        "truncate - view collection",
+			args:        []string{"truncate", "view1", "--yes"},
+			expectError: true,
+		},
+		{
+			name:        "truncate - system collection without --include-system",
+			args:        []string{"truncate", "nologin", "--yes"},
+			expectError: true,
+		},
+		{
+			name: "truncate - existing collection",
+			args: []string{"truncate", "demo2", "--yes"},
+			expectedOutput: []string{
+				"Successfully deleted 3 record(s) from demo2.",
+			},
+			afterFunc: func(t *testing.T, app *tests.TestApp) {
+				if total := countRecords(t, app, "demo2"); total != 0 {
+					t.Fatalf("Expected 0 demo2 records, got %d", total)
+				}
+
+				// the relation references should be also removed
+				user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rel := user.GetString("rel"); rel != "" {
+					t.Fatalf("Expected the user rel field to be cleared, got %q", rel)
+				}
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewCollectionsCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetIn(strings.NewReader(s.stdin))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, str := range s.unexpectedOutput {
+				if strings.Contains(out.String(), str) {
+					t.Fatalf("Didn't expect %q in output:\n%s", str, out.String())
+				}
+			}
+
+			if s.afterFunc != nil {
+				s.afterFunc(t, app)
+			}
+		})
+	}
+}
+
+func TestCollectionsCommandExportImport(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	file := filepath.Join(t.TempDir(), "pb_schema.json")
+
+	export := cmd.NewCollectionsCommand(app)
+	export.SetOut(new(bytes.Buffer))
+	export.SetErr(new(bytes.Buffer))
+	export.SetArgs([]string{"export", "--include-system", "--output", file})
+	if err := export.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(raw), `"name": "nologin"`) {
+		t.Fatalf("Expected the system collection to be exported, got\n%s", raw)
+	}
+
+	out := new(bytes.Buffer)
+
+	// reimporting the same collections shouldn't have any changes
+	// (and shouldn't require a confirmation)
+	reimport := cmd.NewCollectionsCommand(app)
+	reimport.SetOut(out)
+	reimport.SetErr(new(bytes.Buffer))
+	reimport.SetArgs([]string{"import", file, "--include-system", "--delete-missing"})
+	if err := reimport.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), "No schema changes.") {
+		t.Fatalf("Expected no schema changes, got\n%s", out.String())
+	}
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// NewDroitsCommand creates and returns new command for managing
+// the admin permissions (droits).
+func NewDroitsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "droits",
+		Short: "Manages the admin permissions (droits)",
+	}
+
+	command.AddCommand(droitsImportCommand(app))
+
+	return command
+}
+
+func droitsImportCommand(app core.App) *cobra.Command {
+	var dryRun bool
+
+	command := &cobra.Command{
+		Use:     "import [file.csv]",
+		Example: "droits import ./droits.csv --dry-run",
+		Short:   "Grants or revokes admin permissions in bulk from a CSV file",
+		Long: "Grants or revokes admin permissions in bulk from a CSV file.\n\n" +
+			"The first CSV row must be a header with an \"email\", \"permission\" and optional \"action\" columns.\n" +
+			"The action could be \"grant\" (default) or \"revoke\". The admin accounts must already exist (see 'admin import').",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing CSV file argument.")
+			}
+
+			if !app.Dao().HasTable((&models.AdminPermission{}).TableName()) {
+				return errors.New("Migration are not initialized yet. Please run 'migrate up' and try again.")
+			}
+
+			rows, err := readDroitsImportRows(args[0])
+			if err != nil {
+				return fmt.Errorf("Failed to read the import file: %v", err)
+			}
+
+			out := command.OutOrStdout()
+
+			var granted, revoked, skipped, failed int
+
+			txErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+				seen := make(map[string]struct{}, len(rows))
+
+				for i, row := range rows {
+					status, err := importDroitRow(txDao, row, seen)
+					if err != nil {
+						failed++
+						fmt.Fprintf(out, "Row %d (%s %s): %v\n", i+1, row.email, row.permission, err)
+						continue
+					}
+
+					switch status {
+					case "granted":
+						granted++
+					case "revoked":
+						revoked++
+					default:
+						skipped++
+					}
+
+					fmt.Fprintf(out, "Row %d (%s %s): %s\n", i+1, row.email, row.permission, status)
+				}
+
+				if dryRun {
+					return errImportDryRun
+				}
+
+				return nil
+			})
+			if txErr != nil && !errors.Is(txErr, errImportDryRun) {
+				return fmt.Errorf("Failed to import the permissions: %v", txErr)
+			}
+
+			summary := fmt.Sprintf(
+				"Processed %d permissions (%d granted, %d revoked, %d unchanged, %d failed).",
+				len(rows), granted, revoked, skipped, failed,
+			)
+			if dryRun {
+				summary = "[dry-run] " + summary
+			}
+
+			if failed > 0 {
+				fmt.Fprintln(out, color.YellowString(summary))
+			} else {
+				fmt.Fprintln(out, color.GreenString(summary))
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "validate and report the import rows without persisting them")
+
+	return command
+}
+
+type droitImportRow struct {
+	email      string
+	permission string
+	action     string
+}
+
+// readDroitsImportRows reads and parses the permissions import CSV file rows.
+func readDroitsImportRows(path string) ([]droitImportRow, error) {
+	records, err := readImportCSV(path, []string{"email", "permission"}, "action")
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]droitImportRow, len(records))
+	for i, record := range records {
+		rows[i] = droitImportRow{
+			email:      strings.TrimSpace(record["email"]),
+			permission: strings.TrimSpace(record["permission"]),
+			action:     strings.ToLower(strings.TrimSpace(record["action"])),
+		}
+	}
+
+	return rows, nil
+}
+
+// importDroitRow grants or revokes the permission of a single import row
+// and returns its status ("granted", "revoked" or "unchanged").
+func importDroitRow(dao *daos.Dao, row droitImportRow, seen map[string]struct{}) (string, error) {
+	if row.email == "" || is.EmailFormat.Validate(row.email) != nil {
+		return "", errors.New("missing or invalid email address")
+	}
+
+	if row.permission == "" {
+		return "", errors.New("missing permission")
+	}
+
+	if row.action != "" && row.action != "grant" && row.action != "revoke" {
+		return "", fmt.Errorf("invalid action %q (expected grant or revoke)", row.action)
+	}
+
+	key := strings.ToLower(row.email) + " " + row.permission
+	if _, ok := seen[key]; ok {
+		return "", errors.New("duplicated permission row")
+	}
+	seen[key] = struct{}{}
+
+	admin, err := dao.FindAdminByEmail(row.email)
+	if err != nil {
+		return "", errors.New("missing admin account")
+	}
+
+	existing, _ := dao.FindAdminPermission(admin.Id, row.permission)
+
+	if row.action == "revoke" {
+		if existing == nil {
+			return "unchanged", nil
+		}
+
+		if err := dao.DeleteAdminPermission(existing); err != nil {
+			return "", fmt.Errorf("failed to revoke the permission: %v", err)
+		}
+
+		return "revoked", nil
+	}
+
+	if existing != nil {
+		return "unchanged", nil
+	}
+
+	permission := &models.AdminPermission{
+		AdminId:    admin.Id,
+		Permission: row.permission,
+	}
+
+	if err := dao.SaveAdminPermission(permission); err != nil {
+		return "", fmt.Errorf("failed to grant the permission: %v", err)
+	}
+
+	return "granted", nil
+}
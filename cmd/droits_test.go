@@ -0,0 +1,109 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestDroitsImportCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"logs:read", "backups:*"} {
+		if err := app.Dao().SaveAdminPermission(&models.AdminPermission{AdminId: admin.Id, Permission: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), "droits.csv")
+	content := strings.Join([]string{
+		"Email,Permission,Action",
+		"test@example.com,collections:posts:write,",
+		"test@example.com,logs:read,grant",
+		"test@example.com,backups:*,revoke",
+		"test2@example.com,logs:read,revoke",
+		"invalid,logs:read,",
+		"test2@example.com,,",
+		"test2@example.com,logs:read,remove",
+		"missing@example.com,logs:read,",
+		"test2@example.com,invalid permission,",
+		"test@example.com,collections:posts:write,grant",
+	}, "\n")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOutput := []string{
+		"Row 1 (test@example.com collections:posts:write): granted",
+		"Row 2 (test@example.com logs:read): unchanged",
+		"Row 3 (test@example.com backups:*): revoked",
+		"Row 4 (test2@example.com logs:read): unchanged",
+		"Row 5 (invalid logs:read): missing or invalid email address",
+		"Row 6 (test2@example.com ): missing permission",
+		`Row 7 (test2@example.com logs:read): invalid action "remove"`,
+		"Row 8 (missing@example.com logs:read): missing admin account",
+		"Row 9 (test2@example.com invalid permission): failed to grant the permission",
+		"Row 10 (test@example.com collections:posts:write): duplicated permission row",
+		"Processed 10 permissions (1 granted, 1 revoked, 2 unchanged, 6 failed).",
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		out := new(bytes.Buffer)
+
+		args := []string{"import", file}
+		if dryRun {
+			args = append(args, "--dry-run")
+		}
+
+		command := cmd.NewDroitsCommand(app)
+		command.SetArgs(args)
+		command.SetOut(out)
+		command.SetErr(new(bytes.Buffer))
+
+		if err := command.Execute(); err != nil {
+			t.Fatalf("[dryRun %v] %v", dryRun, err)
+		}
+
+		for _, line := range expectedOutput {
+			if !strings.Contains(out.String(), line) {
+				t.Fatalf("[dryRun %v] Expected %q in the output:\n%s", dryRun, line, out.String())
+			}
+		}
+
+		if app.Dao().HasAdminPermission(admin.Id, "collections:posts:write") == dryRun {
+			t.Fatalf("[dryRun %v] Expected the new permission to be granted only with the regular import", dryRun)
+		}
+
+		if app.Dao().HasAdminPermission(admin.Id, "backups:*") != dryRun {
+			t.Fatalf("[dryRun %v] Expected the existing permission to be revoked only with the regular import", dryRun)
+		}
+	}
+
+	// missing permission column
+	missingColumnFile := filepath.Join(t.TempDir(), "missing.csv")
+	if err := os.WriteFile(missingColumnFile, []byte("email,name\ntest@example.com,logs:read"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	command := cmd.NewDroitsCommand(app)
+	command.SetArgs([]string{"import", missingColumnFile})
+	command.SetOut(new(bytes.Buffer))
+	command.SetErr(new(bytes.Buffer))
+	if err := command.Execute(); err == nil {
+		t.Fatal("Expected error due to the missing permission column")
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCommand creates and returns new command for exporting
+// collection records as CSV or NDJSON.
+//
+// The export has full (admin) access to the collection records.
+func NewExportCommand(app core.App) *cobra.Command {
+	var format string
+	var filter string
+	var sort string
+	var output string
+
+	command := &cobra.Command{
+		Use:          "export",
+		Example:      "export posts --format=csv --filter='published=true' --output=./posts.csv",
+		Short:        "Exports collection records as CSV or NDJSON",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing collection argument.")
+			}
+
+			if format != forms.RecordsFormatNDJSON && format != forms.RecordsFormatCSV {
+				return errors.New("Invalid --format value - must be ndjson or csv.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", args[0])
+			}
+
+			query := app.Dao().RecordQuery(collection)
+
+			resolver := resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, true)
+
+			if filter != "" {
+				expr, err := search.FilterData(filter).BuildExpr(resolver)
+				if err != nil {
+					return fmt.Errorf("Invalid --filter value: %v", err)
+				}
+				query.AndWhere(expr)
+			}
+
+			if sort != "" {
+				for _, sortField := range search.ParseSortFromString(sort) {
+					expr, err := sortField.BuildExpr(resolver)
+					if err != nil {
+						return fmt.Errorf("Invalid --sort value: %v", err)
+					}
+					query.AndOrderBy(expr)
+				}
+			}
+
+			if err := resolver.UpdateQuery(query); err != nil {
+				return err
+			}
+
+			var w io.Writer = command.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("Failed to create the output file: %v", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			return exportRecords(w, format, collection, query)
+		},
+	}
+
+	command.PersistentFlags().StringVar(&format, "format", forms.RecordsFormatNDJSON, "the export format - csv or ndjson")
+	command.PersistentFlags().StringVar(&filter, "filter", "", "records filter expression")
+	command.PersistentFlags().StringVar(&sort, "sort", "", "records sort expression (eg. -created,title)")
+	command.PersistentFlags().StringVarP(&output, "output", "o", "", "the output file path (default to stdout)")
+
+	return command
+}
+
+func exportRecords(w io.Writer, format string, collection *models.Collection, query *dbx.SelectQuery) error {
+	return apis.ExportRecords(w, format, collection, query, func(records []*models.Record) ([]*models.Record, error) {
+		for _, record := range records {
+			record.IgnoreEmailVisibility(true)
+		}
+		return records, nil
+	})
+}
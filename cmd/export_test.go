@@ -0,0 +1,116 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestExportCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	outputFile := filepath.Join(t.TempDir(), "users.csv")
+
+	scenarios := []struct {
+		name            string
+		args            []string
+		outputFile      string
+		expectError     bool
+		expectedContent []string
+		notExpected     []string
+	}{
+		{
+			name:        "missing collection argument",
+			args:        []string{},
+			expectError: true,
+		},
+		{
+			name:        "missing collection",
+			args:        []string{"missing"},
+			expectError: true,
+		},
+		{
+			name:        "invalid format",
+			args:        []string{"demo2", "--format=xml"},
+			expectError: true,
+		},
+		{
+			name:        "invalid filter",
+			args:        []string{"demo2", "--filter=missing=1"},
+			expectError: true,
+		},
+		{
+			name:        "invalid sort",
+			args:        []string{"demo2", "--sort=missing"},
+			expectError: true,
+		},
+		{
+			name: "ndjson with filter and sort",
+			args: []string{"demo2", "--filter=title!='test2'", "--sort=-title"},
+			expectedContent: []string{
+				`"title":"test3","updated":"2022-10-14 10:52:49.596Z"}` + "\n" + `{"active":false,`,
+				`"id":"llvuca81nly1qls"`,
+			},
+			notExpected: []string{
+				`"id":"achvryl401bhse3"`,
+			},
+		},
+		{
+			name:       "csv into output file (with hidden emails)",
+			args:       []string{"users", "--format=csv", "--filter=id='oap640cot4yru2s'", "--output=" + outputFile},
+			outputFile: outputFile,
+			expectedContent: []string{
+				"id,username,email,emailVisibility,verified,",
+				"oap640cot4yru2s,",
+				"test2@example.com",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+
+			command := cmd.NewExportCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			content := out.String()
+			if s.outputFile != "" {
+				raw, err := os.ReadFile(s.outputFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				content = string(raw)
+			}
+
+			for _, str := range s.expectedContent {
+				if !strings.Contains(content, str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, content)
+				}
+			}
+
+			for _, str := range s.notExpected {
+				if strings.Contains(content, str) {
+					t.Fatalf("Didn't expect %q in output:\n%s", str, content)
+				}
+			}
+		})
+	}
+}
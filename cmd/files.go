@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// NewFilesCommand creates and returns new command for managing
+// the stored collection files (eg. encryption key rotation).
+func NewFilesCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "files",
+		Short: "Manages the stored collection files",
+		// prevent cobra to print its default help message for the group command
+		RunE: func(command *cobra.Command, args []string) error {
+			return command.Help()
+		},
+	}
+
+	command.AddCommand(filesEncryptCommand(app))
+
+	return command
+}
+
+func filesEncryptCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "encrypt [collection]",
+		Example: "files encrypt\nfiles encrypt documents",
+		Short: "Encrypts the existing files of the collections with enabled files encryption " +
+			"and rewraps the data keys of the files encrypted with a previous key",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			var collections []*models.Collection
+
+			if len(args) > 0 {
+				collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+				if err != nil {
+					return fmt.Errorf("Missing collection %q.", args[0])
+				}
+				if !collection.EncryptFiles() {
+					return fmt.Errorf("Collection %q doesn't have enabled files encryption.", collection.Name)
+				}
+				collections = append(collections, collection)
+			} else {
+				all := []*models.Collection{}
+				err := app.Dao().CollectionQuery().
+					AndWhere(dbx.NewExp("[[type]] != {:type}", dbx.Params{"type": models.CollectionTypeView})).
+					OrderBy("created ASC").
+					All(&all)
+				if err != nil {
+					return err
+				}
+				for _, collection := range all {
+					if collection.EncryptFiles() {
+						collections = append(collections, collection)
+					}
+				}
+			}
+
+			fsys, err := app.NewFilesystem()
+			if err != nil {
+				return err
+			}
+			defer fsys.Close()
+
+			var total, changed, failed int
+
+			for _, collection := range collections {
+				files, err := fsys.List(collection.BaseFilesPath() + "/")
+				if err != nil {
+					return err
+				}
+
+				for _, file := range files {
+					total++
+
+					ok, err := fsys.EncryptFile(file.Key)
+					if err != nil {
+						failed++
+						fmt.Fprintln(command.OutOrStdout(), color.RedString("! %s: %v", file.Key, err))
+						continue
+					}
+
+					if ok {
+						changed++
+						fmt.Fprintln(command.OutOrStdout(), color.GreenString("~ %s", file.Key))
+					}
+				}
+			}
+
+			fmt.Fprintf(
+				command.OutOrStdout(),
+				"Checked %d file(s) in %d collection(s), encrypted or rewrapped %d.\n",
+				total,
+				len(collections),
+				changed,
+			)
+
+			if failed > 0 {
+				return fmt.Errorf("Failed to encrypt %d file(s).", failed)
+			}
+
+			return nil
+		},
+	}
+
+	return command
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/spf13/cobra"
+)
+
+// NewImportCommand creates and returns new command for importing
+// collection records from a local CSV or NDJSON file.
+//
+// The "db" subcommand imports records from an external database or CSV source.
+func NewImportCommand(app core.App) *cobra.Command {
+	var format string
+	var mapping []string
+	var dryRun bool
+
+	command := &cobra.Command{
+		Use:          "import",
+		Example:      "import posts ./posts.csv --mapping=Name=title --dry-run",
+		Short:        "Imports collection records from a CSV or NDJSON file",
+		SilenceUsage: true,
+		// allow collection arguments with the "db" subcommand
+		Args: cobra.ArbitraryArgs,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return errors.New("Missing collection and file arguments.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", args[0])
+			}
+
+			file, err := filesystem.NewFileFromPath(args[1])
+			if err != nil {
+				return fmt.Errorf("Failed to load the import file: %v", err)
+			}
+
+			form := forms.NewRecordsImport(app, collection)
+			form.File = file
+			form.Format = format
+			form.DryRun = dryRun
+
+			if len(mapping) > 0 {
+				form.Mapping = make(map[string]string, len(mapping))
+				for _, item := range mapping {
+					column, field, ok := strings.Cut(item, "=")
+					if !ok || column == "" || field == "" {
+						return fmt.Errorf("Invalid --mapping value %q (expected column=field).", item)
+					}
+					form.Mapping[column] = field
+				}
+			}
+
+			result, err := form.Submit()
+			if err != nil {
+				return fmt.Errorf("Failed to import records: %v", err)
+			}
+
+			out := command.OutOrStdout()
+
+			for _, rowErr := range result.Errors {
+				fmt.Fprintf(out, "Row %d: %s\n", rowErr.Row, rowErr.Error)
+			}
+
+			summary := fmt.Sprintf("Imported %d of %d records (%d failed).", result.Imported, result.Total, result.Failed)
+			if result.DryRun {
+				summary = "[dry-run] " + summary
+			}
+
+			if result.Failed > 0 {
+				fmt.Fprintln(out, color.YellowString(summary))
+			} else {
+				fmt.Fprintln(out, color.GreenString(summary))
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().StringVar(&format, "format", "", "the import file format - csv or ndjson (default to the file extension)")
+	command.PersistentFlags().StringArrayVar(&mapping, "mapping", nil, "column=field mapping (could be specified multiple times)")
+	command.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "validate and report the import rows without persisting them")
+
+	command.AddCommand(importDbCommand(app))
+
+	return command
+}
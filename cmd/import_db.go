@@ -0,0 +1,625 @@
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/spf13/cobra"
+)
+
+// importDbDriverCSV is the pseudo driver name of the CSV file sources.
+const importDbDriverCSV = "csv"
+
+// importDbDriverAliases lists the registered [database/sql] driver
+// names that could be used for each of the supported source types.
+//
+// Except sqlite, the external database drivers are not bundled with
+// the prebuilt PocketBase executable and need to be registered by
+// the Go app main package (eg. import _ "github.com/go-sql-driver/mysql").
+var importDbDriverAliases = map[string][]string{
+	"mysql":    {"mysql"},
+	"postgres": {"postgres", "pgx"},
+	"sqlite":   {"sqlite", "sqlite3"},
+}
+
+// importDbState is the resume position of an import.
+type importDbState struct {
+	// Offset is the number of already processed source rows.
+	Offset int `json:"offset"`
+
+	// LastKey is the --key column value of the last processed
+	// source row (used only with the SQL sources).
+	//
+	// It is stored with the key column own type (eg. a number for the
+	// integer keys) so that the resume condition is not compared as text.
+	LastKey any `json:"lastKey,omitempty"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+//
+// The numeric LastKey values are restored as int64 (or float64).
+func (s *importDbState) UnmarshalJSON(raw []byte) error {
+	type alias importDbState
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	if err := decoder.Decode((*alias)(s)); err != nil {
+		return err
+	}
+
+	if n, ok := s.LastKey.(json.Number); ok {
+		if v, err := n.Int64(); err == nil {
+			s.LastKey = v
+		} else if v, err := n.Float64(); err == nil {
+			s.LastKey = v
+		} else {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importDbFieldMap defines how a single collection field is populated.
+//
+// In the mapping file it could be specified as a plain column name
+// string or as object, eg.:
+//
+//	{
+//		"title":  "post_title",
+//		"author": {"column": "user_id", "lookup": "legacy_id"}
+//	}
+type importDbFieldMap struct {
+	// Column is the source column name.
+	Column string `json:"column"`
+
+	// Lookup is the related collection field name used to resolve the
+	// relation record ids from the source column value (relation fields only).
+	Lookup string `json:"lookup"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (m *importDbFieldMap) UnmarshalJSON(raw []byte) error {
+	var column string
+	if err := json.Unmarshal(raw, &column); err == nil {
+		m.Column = column
+		return nil
+	}
+
+	type alias importDbFieldMap
+
+	return json.Unmarshal(raw, (*alias)(m))
+}
+
+// importDbSource is a batched rows reader.
+type importDbSource interface {
+	// next returns the next batch of rows starting from the current state
+	// (an empty result means that there are no more rows).
+	next(state *importDbState, limit int) ([]map[string]any, error)
+
+	close() error
+}
+
+func importDbCommand(app core.App) *cobra.Command {
+	var driver string
+	var dsn string
+	var table string
+	var collectionName string
+	var mapFile string
+	var key string
+	var batchSize int
+	var stateFile string
+
+	command := &cobra.Command{
+		Use:     "db",
+		Example: "import db --driver=mysql --dsn='user:pass@tcp(127.0.0.1:3306)/legacy' --table=posts --collection=posts --map=fields.json --key=id --state=posts_state.json\nimport db --dsn=./posts.csv --collection=posts",
+		Short:   "Imports collection records from a MySQL, Postgres, SQLite or CSV source",
+		Long: "Imports collection records from a MySQL, Postgres, SQLite or CSV source.\n\n" +
+			"The prebuilt executable supports only the SQLite and CSV sources.\n" +
+			"The MySQL and Postgres drivers must be registered by a Go extended app main package\n" +
+			"(eg. import _ \"github.com/go-sql-driver/mysql\" or import _ \"github.com/lib/pq\").",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			out := command.OutOrStdout()
+
+			if dsn == "" {
+				return errors.New("Missing --dsn value.")
+			}
+
+			if batchSize <= 0 {
+				return errors.New("The --batch value must be a positive integer.")
+			}
+
+			if driver == "" && strings.HasSuffix(strings.ToLower(dsn), ".csv") {
+				driver = importDbDriverCSV
+			}
+
+			var source importDbSource
+			var err error
+			if driver == importDbDriverCSV {
+				source, err = openImportCSVSource(dsn)
+			} else {
+				source, err = openImportSQLSource(driver, dsn, table, key)
+			}
+			if err != nil {
+				return err
+			}
+			defer source.close()
+
+			collection, err := app.Dao().FindCollectionByNameOrId(collectionName)
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", collectionName)
+			}
+			if collection.IsView() {
+				return errors.New("View collection records cannot be imported.")
+			}
+
+			mapping, err := loadImportDbMapping(collection, mapFile)
+			if err != nil {
+				return err
+			}
+
+			state := &importDbState{}
+			if stateFile != "" {
+				if raw, err := os.ReadFile(stateFile); err == nil {
+					if err := json.Unmarshal(raw, state); err != nil {
+						return fmt.Errorf("Failed to parse the state file: %v", err)
+					}
+					fmt.Fprintf(out, "Resuming the import after row %d.\n", state.Offset)
+				} else if !os.IsNotExist(err) {
+					return fmt.Errorf("Failed to read the state file: %v", err)
+				}
+			}
+
+			importer := &recordsDbImporter{
+				app:        app,
+				collection: collection,
+				mapping:    mapping,
+				lookups:    map[string]string{},
+			}
+
+			var imported, failed int
+
+			for {
+				rows, err := source.next(state, batchSize)
+				if err != nil {
+					return fmt.Errorf("Failed to read the source rows: %v", err)
+				}
+				if len(rows) == 0 {
+					break
+				}
+
+				firstRow := state.Offset - len(rows) + 1
+
+				rowErrs, err := importer.importBatch(rows)
+				if err != nil {
+					return err
+				}
+
+				for i, rowErr := range rowErrs {
+					if rowErr != nil {
+						failed++
+						fmt.Fprintf(out, "Row %d: %s\n", firstRow+i, rowErr.Error())
+					} else {
+						imported++
+					}
+				}
+
+				if stateFile != "" {
+					raw, _ := json.Marshal(state)
+					if err := os.WriteFile(stateFile, raw, 0644); err != nil {
+						return fmt.Errorf("Failed to write the state file: %v", err)
+					}
+				}
+			}
+
+			summary := fmt.Sprintf("Imported %d of %d rows (%d failed).", imported, imported+failed, failed)
+			if failed > 0 {
+				fmt.Fprintln(out, color.YellowString(summary))
+			} else {
+				fmt.Fprintln(out, color.GreenString(summary))
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().StringVar(&driver, "driver", "", "the source type - mysql, postgres, sqlite or csv (default to csv for .csv dsn files)")
+	command.PersistentFlags().StringVar(&dsn, "dsn", "", "the source database connection string or CSV file path")
+	command.PersistentFlags().StringVar(&table, "table", "", "the source table name (SQL sources only)")
+	command.PersistentFlags().StringVar(&collectionName, "collection", "", "the name or id of the collection to import into")
+	command.PersistentFlags().StringVar(&mapFile, "map", "", "JSON file with field => column (or {column, lookup}) mapping (default to matching the columns by the field names)")
+	command.PersistentFlags().StringVar(&key, "key", "", "unique ordered source column used for stable batching and resume (SQL sources only)")
+	command.PersistentFlags().IntVar(&batchSize, "batch", 500, "the number of rows imported in a single transaction")
+	command.PersistentFlags().StringVar(&stateFile, "state", "", "file to store the import progress after each batch and to resume from")
+
+	return command
+}
+
+// loadImportDbMapping loads and validates the field mapping file.
+//
+// If file is empty, the importable collection fields are
+// mapped to the source columns with the same name.
+func loadImportDbMapping(collection *models.Collection, file string) (map[string]importDbFieldMap, error) {
+	allowed := importableFieldNames(collection)
+
+	if file == "" {
+		mapping := make(map[string]importDbFieldMap, len(allowed))
+		for _, name := range allowed {
+			mapping[name] = importDbFieldMap{Column: name}
+		}
+		return mapping, nil
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the map file: %v", err)
+	}
+
+	mapping := map[string]importDbFieldMap{}
+	if err := json.Unmarshal(raw, &mapping); err != nil {
+		return nil, fmt.Errorf("Failed to parse the map file: %v", err)
+	}
+
+	for name, m := range mapping {
+		if !list.ExistInSlice(name, allowed) {
+			return nil, fmt.Errorf("Invalid or non-importable field %q.", name)
+		}
+
+		if m.Column == "" {
+			return nil, fmt.Errorf("Missing source column for field %q.", name)
+		}
+
+		if m.Lookup != "" {
+			field := collection.Schema.GetFieldByName(name)
+			if field == nil || field.Type != schema.FieldTypeRelation {
+				return nil, fmt.Errorf("Lookup is supported only for relation fields (%q).", name)
+			}
+		}
+	}
+
+	return mapping, nil
+}
+
+// importableFieldNames returns the names of the collection fields
+// that could be populated with the import.
+func importableFieldNames(collection *models.Collection) []string {
+	result := []string{schema.FieldNameId}
+
+	if collection.IsAuth() {
+		result = append(
+			result,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+			"password",
+			"passwordConfirm",
+		)
+	}
+
+	for _, field := range collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeFile {
+			continue // files are not supported
+		}
+		result = append(result, field.Name)
+	}
+
+	return result
+}
+
+// -------------------------------------------------------------------
+
+// recordsDbImporter creates collection records from the mapped source rows.
+type recordsDbImporter struct {
+	app        core.App
+	collection *models.Collection
+	mapping    map[string]importDbFieldMap
+
+	// lookups caches the resolved relation ids
+	// (the keys are in the format "collectionId|field|value").
+	lookups map[string]string
+}
+
+// importBatch creates a new record for each of the provided rows
+// in a single transaction and returns the errors of the failed rows
+// (nil for the successfully imported ones).
+func (imp *recordsDbImporter) importBatch(rows []map[string]any) ([]error, error) {
+	rowErrs := make([]error, len(rows))
+
+	txErr := imp.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for i, row := range rows {
+			rowErrs[i] = imp.importRow(txDao, row)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return rowErrs, nil
+}
+
+func (imp *recordsDbImporter) importRow(txDao *daos.Dao, row map[string]any) error {
+	data := make(map[string]any, len(imp.mapping))
+
+	for name, m := range imp.mapping {
+		value, ok := row[m.Column]
+		if !ok {
+			continue
+		}
+
+		if m.Lookup != "" && value != nil {
+			ids, err := imp.resolveRelation(txDao, name, m.Lookup, value)
+			if err != nil {
+				return err
+			}
+			value = ids
+		}
+
+		data[name] = value
+	}
+
+	// for convenience allow specifying only the auth record password
+	if _, ok := data["passwordConfirm"]; !ok && imp.collection.IsAuth() {
+		if password, ok := data["password"]; ok {
+			data["passwordConfirm"] = password
+		}
+	}
+
+	upsert := forms.NewRecordUpsert(imp.app, models.NewRecord(imp.collection))
+	upsert.SetFullManageAccess(true)
+	upsert.SetDao(txDao)
+
+	if err := upsert.LoadData(data); err != nil {
+		return err
+	}
+
+	return upsert.Submit()
+}
+
+// resolveRelation returns the ids of the related records
+// whose lookup field matches the source value.
+//
+// The multiple relation values are expected to be comma separated.
+func (imp *recordsDbImporter) resolveRelation(txDao *daos.Dao, fieldName string, lookup string, value any) ([]string, error) {
+	field := imp.collection.Schema.GetFieldByName(fieldName)
+	options, _ := field.Options.(*schema.RelationOptions)
+	if options == nil {
+		return nil, fmt.Errorf("invalid relation field %q", fieldName)
+	}
+
+	var values []string
+	for _, v := range strings.Split(fmt.Sprint(value), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	ids := make([]string, 0, len(values))
+
+	for _, v := range values {
+		cacheKey := options.CollectionId + "|" + lookup + "|" + v
+
+		if id, ok := imp.lookups[cacheKey]; ok {
+			ids = append(ids, id)
+			continue
+		}
+
+		record, err := txDao.FindFirstRecordByData(options.CollectionId, lookup, v)
+		if err != nil {
+			return nil, fmt.Errorf("missing %q relation record with %s=%q", fieldName, lookup, v)
+		}
+
+		imp.lookups[cacheKey] = record.Id
+		ids = append(ids, record.Id)
+	}
+
+	return ids, nil
+}
+
+// -------------------------------------------------------------------
+
+type importSQLSource struct {
+	db    *dbx.DB
+	table string
+	key   string
+}
+
+func openImportSQLSource(driver string, dsn string, table string, key string) (*importSQLSource, error) {
+	if driver == "" {
+		return nil, errors.New("Missing --driver value.")
+	}
+
+	if table == "" {
+		return nil, errors.New("Missing --table value.")
+	}
+
+	aliases, ok := importDbDriverAliases[driver]
+	if !ok {
+		return nil, errors.New("Invalid --driver value - must be mysql, postgres, sqlite or csv.")
+	}
+
+	var driverName string
+	for _, alias := range aliases {
+		if list.ExistInSlice(alias, sql.Drivers()) {
+			driverName = alias
+			break
+		}
+	}
+	if driverName == "" {
+		return nil, fmt.Errorf(
+			"The %s database driver is not registered - the prebuilt executable supports only the sqlite and csv sources "+
+				"and the %s driver must be imported in your Go app main package.",
+			driver,
+			driver,
+		)
+	}
+
+	db, err := dbx.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to the source database: %v", err)
+	}
+
+	return &importSQLSource{db: db, table: table, key: key}, nil
+}
+
+func (s *importSQLSource) next(state *importDbState, limit int) ([]map[string]any, error) {
+	query := s.db.Select("*").From(s.table).Limit(int64(limit))
+
+	if s.key != "" {
+		query.OrderBy(s.key + " ASC")
+		if state.Offset > 0 {
+			query.AndWhere(dbx.NewExp(s.db.QuoteColumnName(s.key)+" > {:lastKey}", dbx.Params{"lastKey": state.LastKey}))
+		}
+	} else {
+		query.Offset(int64(state.Offset))
+	}
+
+	rawRows, err := query.Build().Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rawRows.Close()
+
+	columns, err := rawRows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []map[string]any{}
+
+	for rawRows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rawRows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		// convert all values to strings and let the record fields to normalize them
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			var str sql.NullString
+			if err := str.Scan(values[i]); err != nil {
+				return nil, err
+			}
+
+			if str.Valid {
+				row[column] = str.String
+			} else {
+				row[column] = nil
+			}
+
+			// keep the key column own type for the resume comparison
+			if column == s.key {
+				if b, ok := values[i].([]byte); ok {
+					state.LastKey = string(b)
+				} else {
+					state.LastKey = values[i]
+				}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := rawRows.Err(); err != nil {
+		return nil, err
+	}
+
+	state.Offset += len(rows)
+
+	return rows, nil
+}
+
+func (s *importSQLSource) close() error {
+	return s.db.Close()
+}
+
+type importCSVSource struct {
+	file   *os.File
+	reader *csv.Reader
+	header []string
+
+	// read is the number of the already read rows
+	read int
+}
+
+func openImportCSVSource(path string) (*importCSVSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open the CSV file: %v", err)
+	}
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("Failed to read the CSV header: %v", err)
+	}
+
+	// strip the UTF-8 BOM (if any)
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\uFEFF")
+	}
+
+	return &importCSVSource{file: f, reader: reader, header: header}, nil
+}
+
+func (s *importCSVSource) next(state *importDbState, limit int) ([]map[string]any, error) {
+	rows := []map[string]any{}
+
+	for len(rows) < limit {
+		values, err := s.reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		s.read++
+		if s.read <= state.Offset {
+			continue // already imported
+		}
+
+		row := make(map[string]any, len(s.header))
+		for i, column := range s.header {
+			if i < len(values) {
+				row[column] = values[i]
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	state.Offset += len(rows)
+
+	return rows, nil
+}
+
+func (s *importCSVSource) close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,298 @@
+package cmd_test
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// createImportDbSource creates a sqlite source database
+// with a "legacy" table and returns its file path.
+func createImportDbSource(t *testing.T, dir string) string {
+	var driver string
+	for _, name := range []string{"sqlite", "sqlite3"} {
+		if list.ExistInSlice(name, sql.Drivers()) {
+			driver = name
+			break
+		}
+	}
+	if driver == "" {
+		t.Fatal("Missing registered sqlite driver")
+	}
+
+	file := filepath.Join(dir, "source.db")
+
+	db, err := dbx.Open(driver, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	queries := []string{
+		"CREATE TABLE legacy (pk INTEGER PRIMARY KEY, name TEXT, is_active INTEGER, demo2_title TEXT)",
+		"INSERT INTO legacy VALUES (1, 'legacy_a', 1, 'test1')",
+		"INSERT INTO legacy VALUES (2, 'b', 0, 'test2')",
+		"INSERT INTO legacy VALUES (3, 'legacy_c', 0, 'test1,test3')",
+		"INSERT INTO legacy VALUES (4, 'legacy_d', 1, 'missing')",
+		// no column type affinity (the key values are compared as they are)
+		"CREATE TABLE legacy_untyped (seq, name TEXT)",
+		"INSERT INTO legacy_untyped VALUES (1, 'untyped_a')",
+		"INSERT INTO legacy_untyped VALUES (2, 'untyped_b')",
+		"INSERT INTO legacy_untyped VALUES (10, 'untyped_c')",
+		"INSERT INTO legacy_untyped VALUES (11, 'untyped_d')",
+	}
+	for _, q := range queries {
+		if _, err := db.NewQuery(q).Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return file
+}
+
+func createImportDbTargetCollection(t *testing.T, app *tests.TestApp) {
+	collection := &models.Collection{
+		Name: "imported",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:     "title",
+				Type:     schema.FieldTypeText,
+				Required: true,
+				Options:  &schema.TextOptions{Min: types.Pointer(2)},
+			},
+			&schema.SchemaField{
+				Name: "active",
+				Type: schema.FieldTypeBool,
+			},
+			&schema.SchemaField{
+				Name: "demos",
+				Type: schema.FieldTypeRelation,
+				Options: &schema.RelationOptions{
+					CollectionId: "sz5l5z67tg7gku0", // demo2
+				},
+			},
+		),
+	}
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportDbCommand(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	sourceFile := createImportDbSource(t, dir)
+
+	mapFile := filepath.Join(dir, "map.json")
+	if err := os.WriteFile(mapFile, []byte(`{
+		"title": "name",
+		"active": {"column": "is_active"},
+		"demos": {"column": "demo2_title", "lookup": "title"}
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	invalidMapFile := filepath.Join(dir, "invalid_map.json")
+	if err := os.WriteFile(invalidMapFile, []byte(`{"title": {"column": "name", "lookup": "title"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	csvFile := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(csvFile, []byte("title,active\ncsv_a,true\ncsv_b,false\ncsv_c,true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// resume after the first CSV row
+	stateFile := filepath.Join(dir, "state.json")
+	if err := os.WriteFile(stateFile, []byte(`{"offset":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		expectError      bool
+		expectedOutput   []string
+		expectedImported []string
+		expectedMissing  []string
+	}{
+		{
+			name:        "missing dsn",
+			args:        []string{"db", "--collection=imported"},
+			expectError: true,
+		},
+		{
+			name:        "missing collection",
+			args:        []string{"db", "--dsn=" + csvFile, "--collection=missing"},
+			expectError: true,
+		},
+		{
+			name:        "missing driver",
+			args:        []string{"db", "--dsn=" + sourceFile, "--table=legacy", "--collection=imported"},
+			expectError: true,
+		},
+		{
+			name:        "unknown driver",
+			args:        []string{"db", "--driver=oracle", "--dsn=" + sourceFile, "--table=legacy", "--collection=imported"},
+			expectError: true,
+		},
+		{
+			name:        "missing table",
+			args:        []string{"db", "--driver=sqlite", "--dsn=" + sourceFile, "--collection=imported"},
+			expectError: true,
+		},
+		{
+			name:        "lookup on non-relation field",
+			args:        []string{"db", "--driver=sqlite", "--dsn=" + sourceFile, "--table=legacy", "--collection=imported", "--map=" + invalidMapFile},
+			expectError: true,
+		},
+		{
+			name: "sql source with mapping, lookups and small batches",
+			args: []string{"db", "--driver=sqlite", "--dsn=" + sourceFile, "--table=legacy", "--collection=imported", "--map=" + mapFile, "--key=pk", "--batch=2"},
+			expectedOutput: []string{
+				"Row 2: title:",
+				`Row 4: missing "demos" relation record with title="missing"`,
+				"Imported 2 of 4 rows (2 failed).",
+			},
+			expectedImported: []string{"legacy_a", "legacy_c"},
+			expectedMissing:  []string{"b", "legacy_d"},
+		},
+		{
+			name: "csv source with resume",
+			args: []string{"db", "--dsn=" + csvFile, "--collection=imported", "--state=" + stateFile},
+			expectedOutput: []string{
+				"Resuming the import after row 1.",
+				"Imported 2 of 2 rows (0 failed).",
+			},
+			expectedImported: []string{"csv_b", "csv_c"},
+			expectedMissing:  []string{"csv_a"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			createImportDbTargetCollection(t, app)
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewImportCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, title := range s.expectedImported {
+				if _, err := app.Dao().FindFirstRecordByData("imported", "title", title); err != nil {
+					t.Fatalf("Expected record %q to be imported", title)
+				}
+			}
+
+			for _, title := range s.expectedMissing {
+				if _, err := app.Dao().FindFirstRecordByData("imported", "title", title); err == nil {
+					t.Fatalf("Expected record %q to not be imported", title)
+				}
+			}
+		})
+	}
+
+	t.Run("resolved relations", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		createImportDbTargetCollection(t, app)
+
+		command := cmd.NewImportCommand(app)
+		command.SetOut(new(bytes.Buffer))
+		command.SetErr(new(bytes.Buffer))
+		command.SetArgs([]string{"db", "--driver=sqlite", "--dsn=" + sourceFile, "--table=legacy", "--collection=imported", "--map=" + mapFile, "--key=pk"})
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		record, err := app.Dao().FindFirstRecordByData("imported", "title", "legacy_c")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := "llvuca81nly1qls,0yxhwia2amd8gec"
+		if demos := strings.Join(record.GetStringSlice("demos"), ","); demos != expected {
+			t.Fatalf("Expected demos %q, got %q", expected, demos)
+		}
+
+		if record.GetBool("active") {
+			t.Fatal("Expected active to be false")
+		}
+	})
+
+	t.Run("resume with integer key", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		createImportDbTargetCollection(t, app)
+
+		intStateFile := filepath.Join(t.TempDir(), "state.json")
+		if err := os.WriteFile(intStateFile, []byte(`{"offset":2,"lastKey":2}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		out := new(bytes.Buffer)
+
+		command := cmd.NewImportCommand(app)
+		command.SetOut(out)
+		command.SetErr(new(bytes.Buffer))
+		command.SetArgs([]string{"db", "--driver=sqlite", "--dsn=" + sourceFile, "--table=legacy_untyped", "--collection=imported", "--map=" + mapFile, "--key=seq", "--batch=1", "--state=" + intStateFile})
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(out.String(), "Imported 2 of 2 rows (0 failed).") {
+			t.Fatalf("Expected 2 imported rows, got:\n%s", out.String())
+		}
+
+		for _, title := range []string{"untyped_c", "untyped_d"} {
+			if _, err := app.Dao().FindFirstRecordByData("imported", "title", title); err != nil {
+				t.Fatalf("Expected record %q to be imported", title)
+			}
+		}
+
+		state, err := os.ReadFile(intStateFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedState := `{"offset":4,"lastKey":11}`
+		if string(state) != expectedState {
+			t.Fatalf("Expected state %s, got %s", expectedState, state)
+		}
+	})
+}
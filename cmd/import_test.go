@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestImportCommand(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	csvFile := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(csvFile, []byte("name,active\ntest_a,true\na,false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ndjsonFile := filepath.Join(dir, "data.txt")
+	if err := os.WriteFile(ndjsonFile, []byte(`{"title":"test_a"}`+"\n"+`{"title":"test_b"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		expectError      bool
+		expectedOutput   []string
+		expectedImported []string
+		expectedMissing  []string
+	}{
+		{
+			name:        "missing arguments",
+			args:        []string{"demo2"},
+			expectError: true,
+		},
+		{
+			name:        "missing collection",
+			args:        []string{"missing", csvFile},
+			expectError: true,
+		},
+		{
+			name:        "missing file",
+			args:        []string{"demo2", filepath.Join(dir, "missing.csv")},
+			expectError: true,
+		},
+		{
+			name:        "invalid mapping flag",
+			args:        []string{"demo2", csvFile, "--mapping=name"},
+			expectError: true,
+		},
+		{
+			name:        "unresolvable format",
+			args:        []string{"demo2", ndjsonFile},
+			expectError: true,
+		},
+		{
+			name:             "csv with mapping and row errors",
+			args:             []string{"demo2", csvFile, "--mapping=name=title", "--mapping=active=active"},
+			expectedOutput:   []string{"Row 2: title:", "Imported 1 of 2 records (1 failed)."},
+			expectedImported: []string{"test_a"},
+		},
+		{
+			name:            "explicit format with dry-run",
+			args:            []string{"demo2", ndjsonFile, "--format=ndjson", "--dry-run"},
+			expectedOutput:  []string{"[dry-run] Imported 2 of 2 records (0 failed)."},
+			expectedMissing: []string{"test_a", "test_b"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewImportCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, title := range s.expectedImported {
+				if _, err := app.Dao().FindFirstRecordByData("demo2", "title", title); err != nil {
+					t.Fatalf("Expected record %q to be imported", title)
+				}
+			}
+
+			for _, title := range s.expectedMissing {
+				if _, err := app.Dao().FindFirstRecordByData("demo2", "title", title); err == nil {
+					t.Fatalf("Expected record %q to not be imported", title)
+				}
+			}
+		})
+	}
+}
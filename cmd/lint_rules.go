@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// NewLintRulesCommand creates and returns new command for checking
+// the collections API rules for unknown fields and relations
+// and optionally printing their generated SQL.
+func NewLintRulesCommand(app core.App) *cobra.Command {
+	var explain bool
+	var strict bool
+
+	command := &cobra.Command{
+		Use:          "lint-rules [collection]",
+		Example:      "lint-rules\nlint-rules posts --explain",
+		Short:        "Checks the collections API rules for mistakes",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			var collections []*models.Collection
+
+			if len(args) > 0 {
+				collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+				if err != nil {
+					return fmt.Errorf("Missing collection %q.", args[0])
+				}
+				collections = append(collections, collection)
+			} else {
+				if err := app.Dao().CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+					return err
+				}
+			}
+
+			var totalErrors, totalWarnings int
+
+			out := command.OutOrStdout()
+
+			for _, collection := range collections {
+				for _, lint := range app.Dao().LintCollectionRules(collection) {
+					if lint.Error != "" {
+						totalErrors++
+					}
+					totalWarnings += len(lint.Warnings)
+
+					printRuleLint(out, collection, lint, explain)
+				}
+			}
+
+			fmt.Fprintf(
+				out,
+				"Checked %d collection(s), found %d error(s) and %d warning(s).\n",
+				len(collections),
+				totalErrors,
+				totalWarnings,
+			)
+
+			if totalErrors > 0 || (strict && totalWarnings > 0) {
+				return errors.New("Found invalid collection rules.")
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&explain, "explain", false, "print the generated SQL (with placeholders) and params of each rule")
+	command.PersistentFlags().BoolVar(&strict, "strict", false, "fail also on warnings")
+
+	return command
+}
+
+func printRuleLint(out io.Writer, collection *models.Collection, lint *daos.CollectionRuleLint, explain bool) {
+	if !lint.HasIssues() && !explain {
+		return
+	}
+
+	rule := "null (admins only)"
+	if lint.Rule != nil {
+		rule = fmt.Sprintf("%q", *lint.Rule)
+	}
+
+	switch {
+	case lint.Error != "":
+		fmt.Fprintln(out, color.RedString("! %s.%s: %s", collection.Name, lint.Name, rule))
+		fmt.Fprintf(out, "    error: %s\n", lint.Error)
+	case len(lint.Warnings) > 0:
+		fmt.Fprintln(out, color.YellowString("~ %s.%s: %s", collection.Name, lint.Name, rule))
+	default:
+		fmt.Fprintf(out, "  %s.%s: %s\n", collection.Name, lint.Name, rule)
+	}
+
+	for _, warning := range lint.Warnings {
+		fmt.Fprintf(out, "    warning: %s\n", warning)
+	}
+
+	if explain && lint.SQL != "" {
+		fmt.Fprintf(out, "    sql: %s\n", lint.SQL)
+
+		params, _ := json.Marshal(lint.Params)
+		fmt.Fprintf(out, "    params: %s\n", params)
+	}
+}
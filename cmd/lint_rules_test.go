@@ -0,0 +1,102 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestLintRulesCommand(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		expectError      bool
+		expectedOutput   []string
+		unexpectedOutput []string
+	}{
+		{
+			name:        "missing collection",
+			args:        []string{"missing"},
+			expectError: true,
+		},
+		{
+			name: "single collection",
+			args: []string{"demo2"},
+			expectedOutput: []string{
+				`~ demo2.updateRule: ""`,
+				`~ demo2.deleteRule: ""`,
+				"warning: The rule is empty and allows the action to everyone (including guests).",
+				"Checked 1 collection(s), found 0 error(s) and 2 warning(s).",
+			},
+			unexpectedOutput: []string{
+				"demo2.listRule",
+				"sql:",
+			},
+		},
+		{
+			name:        "single collection (strict)",
+			args:        []string{"demo2", "--strict"},
+			expectError: true,
+			expectedOutput: []string{
+				"Checked 1 collection(s), found 0 error(s) and 2 warning(s).",
+			},
+		},
+		{
+			name: "single collection (explain)",
+			args: []string{"users", "--explain"},
+			expectedOutput: []string{
+				`users.listRule: null (admins only)`,
+				`users.viewRule: "id = @request.auth.id"`,
+				"sql: SELECT `users`.* FROM `users` WHERE `users`.`id` = {:",
+				"params: {",
+			},
+		},
+		{
+			name: "all collections",
+			args: []string{},
+			expectedOutput: []string{
+				"demo2.updateRule",
+				"nologin.updateRule",
+				"found 0 error(s)",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewLintRulesCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, str := range s.unexpectedOutput {
+				if strings.Contains(out.String(), str) {
+					t.Fatalf("Didn't expect %q in output:\n%s", str, out.String())
+				}
+			}
+		})
+	}
+}
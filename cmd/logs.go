@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/transport"
+	"github.com/spf13/cobra"
+)
+
+// logsPollInterval specifies how often the local logs db is checked
+// for new entries in --follow mode.
+var logsPollInterval = 1 * time.Second
+
+// NewLogsCommand creates and returns new command for printing
+// and following (aka. "tail -f") the app logs.
+//
+// By default the logs are loaded from the local logs db, but they
+// could be also fetched from a running remote instance with the
+// --url and --token (admin auth token) flags.
+func NewLogsCommand(app core.App) *cobra.Command {
+	var follow bool
+	var limit int
+	var level string
+	var message string
+	var data []string
+	var remoteUrl string
+	var token string
+
+	command := &cobra.Command{
+		Use:          "logs",
+		Example:      "logs --follow --level=warn --data=auth=admin",
+		Short:        "Prints the latest app logs (use --follow to stream the new ones)",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			params := url.Values{}
+			if level != "" {
+				params.Set("level", level)
+			}
+			if message != "" {
+				params.Set("message", message)
+			}
+			for _, item := range data {
+				key, value, ok := strings.Cut(item, "=")
+				if !ok || key == "" {
+					return fmt.Errorf("Invalid --data value %q (expected key=value).", item)
+				}
+				params.Set("data."+key, value)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			out := command.OutOrStdout()
+
+			if remoteUrl != "" {
+				return remoteLogs(ctx, out, remoteUrl, token, params, limit, follow)
+			}
+
+			return localLogs(ctx, app, out, params, limit, follow)
+		},
+	}
+
+	command.PersistentFlags().BoolVarP(&follow, "follow", "f", false, "keep printing the new log entries as they are created")
+	command.PersistentFlags().IntVar(&limit, "limit", 50, "the number of the latest log entries to print initially")
+	command.PersistentFlags().StringVar(&level, "level", "", "the minimum log level (debug, info, warn, error or a number)")
+	command.PersistentFlags().StringVar(&message, "message", "", "print only the logs which message contains the specified text")
+	command.PersistentFlags().StringArrayVar(&data, "data", nil, "print only the logs with the specified data key=value pair (could be repeated)")
+	command.PersistentFlags().StringVar(&remoteUrl, "url", "", "the base url of a running remote instance (eg. https://example.com)")
+	command.PersistentFlags().StringVar(&token, "token", "", "admin auth token to use with --url")
+
+	return command
+}
+
+type logRow struct {
+	models.Log
+
+	RowId int64 `db:"rowid"`
+}
+
+func localLogs(ctx context.Context, app core.App, out io.Writer, params url.Values, limit int, follow bool) error {
+	filter, err := logsFilterFromParams(params)
+	if err != nil {
+		return err
+	}
+
+	var lastRowId int64
+
+	fetch := func() error {
+		rows := []*logRow{}
+
+		query := app.LogsDao().LogQuery().AndSelect("rowid")
+
+		if expr := filter.BuildExpr(); expr != nil {
+			query.AndWhere(expr)
+		}
+
+		if lastRowId > 0 {
+			query.AndWhere(dbx.NewExp("rowid > {:rowid}", dbx.Params{"rowid": lastRowId}))
+		} else if limit > 0 {
+			query.Limit(int64(limit))
+		}
+
+		if err := query.OrderBy("rowid DESC").All(&rows); err != nil {
+			return err
+		}
+
+		// print in chronological order
+		for i := len(rows) - 1; i >= 0; i-- {
+			printLog(out, &rows[i].Log)
+			if rows[i].RowId > lastRowId {
+				lastRowId = rows[i].RowId
+			}
+		}
+
+		return nil
+	}
+
+	if err := fetch(); err != nil {
+		return err
+	}
+
+	if !follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(logsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := fetch(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func remoteLogs(ctx context.Context, out io.Writer, baseUrl string, token string, params url.Values, limit int, follow bool) error {
+	baseUrl = strings.TrimRight(baseUrl, "/")
+
+	if limit > 0 {
+		listParams := url.Values{}
+		for k, v := range params {
+			listParams[k] = v
+		}
+		listParams.Set("perPage", strconv.Itoa(limit))
+		listParams.Set("sort", "-rowid")
+		listParams.Set("skipTotal", "1")
+
+		res, err := remoteLogsRequest(ctx, baseUrl+"/api/logs?"+listParams.Encode(), token)
+		if err != nil {
+			return err
+		}
+
+		result := struct {
+			Items []*models.Log `json:"items"`
+		}{}
+		err = json.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for i := len(result.Items) - 1; i >= 0; i-- {
+			printLog(out, result.Items[i])
+		}
+	}
+
+	if !follow {
+		return nil
+	}
+
+	res, err := remoteLogsRequest(ctx, baseUrl+"/api/logs/stream?"+params.Encode(), token)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		raw, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue // event name, comment or empty line
+		}
+
+		log := &models.Log{}
+		if err := json.Unmarshal([]byte(raw), log); err != nil {
+			continue
+		}
+
+		printLog(out, log)
+	}
+
+	if ctx.Err() != nil {
+		return nil // interrupted
+	}
+
+	return scanner.Err()
+}
+
+func remoteLogsRequest(ctx context.Context, url string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", token)
+
+	res, err := (&http.Client{Transport: transport.Default}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		res.Body.Close()
+		return nil, fmt.Errorf("Failed to load the remote logs (%d): %s", res.StatusCode, body)
+	}
+
+	return res, nil
+}
+
+// logsFilterFromParams loads a logs filter from the
+// "level", "message" and "data.*" params.
+func logsFilterFromParams(params url.Values) (daos.LogsFilter, error) {
+	filter := daos.LogsFilter{
+		Message: params.Get("message"),
+		Data:    map[string]string{},
+	}
+
+	if raw := params.Get("level"); raw != "" {
+		level, err := daos.ParseLogLevel(raw)
+		if err != nil {
+			return filter, errors.New("Invalid --level value.")
+		}
+		filter.MinLevel = &level
+	}
+
+	for key := range params {
+		if dataKey, ok := strings.CutPrefix(key, "data."); ok {
+			filter.Data[dataKey] = params.Get(key)
+		}
+	}
+
+	return filter, nil
+}
+
+func printLog(out io.Writer, log *models.Log) {
+	data, _ := json.Marshal(log.Data)
+
+	fmt.Fprintf(
+		out,
+		"%s %-5s %s %s\n",
+		log.Created.String(),
+		slog.Level(log.Level).String(),
+		log.Message,
+		data,
+	)
+}
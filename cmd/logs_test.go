@@ -0,0 +1,182 @@
+package cmd_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestLogsCommand(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := tests.MockLogsData(app); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+		expected    []string
+	}{
+		{
+			"invalid data flag",
+			[]string{"--data=invalid"},
+			true,
+			nil,
+		},
+		{
+			"invalid level flag",
+			[]string{"--level=invalid"},
+			true,
+			nil,
+		},
+		{
+			"no filter",
+			nil,
+			false,
+			[]string{
+				"2022-05-01 10:00:00.123Z INFO  test_message1 {\"status\":200}",
+				"2022-05-02 10:00:00.123Z ERROR test_message2 {\"status\":400}",
+			},
+		},
+		{
+			"limit",
+			[]string{"--limit=1"},
+			false,
+			[]string{
+				"2022-05-02 10:00:00.123Z ERROR test_message2 {\"status\":400}",
+			},
+		},
+		{
+			"filters",
+			[]string{"--level=info", "--message=MESSAGE", "--data=status=200"},
+			false,
+			[]string{
+				"2022-05-01 10:00:00.123Z INFO  test_message1 {\"status\":200}",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+
+			command := cmd.NewLogsCommand(app)
+			command.SetArgs(s.args)
+			command.SetOut(out)
+			command.SetErr(&bytes.Buffer{})
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if strings.Join(lines, "\n") != strings.Join(s.expected, "\n") {
+				t.Fatalf("Expected lines\n%s\ngot\n%s", strings.Join(s.expected, "\n"), out.String())
+			}
+		})
+	}
+}
+
+func TestLogsCommandRemote(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "test_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/logs":
+			if r.URL.Query().Get("data.status") != "200" || r.URL.Query().Get("perPage") != "10" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"items":[` +
+				`{"id":"2","created":"2022-05-02 10:00:00.123Z","level":0,"message":"test2","data":{"status":200}},` +
+				`{"id":"1","created":"2022-05-01 10:00:00.123Z","level":0,"message":"test1","data":{"status":200}}` +
+				`]}`))
+		case "/api/logs/stream":
+			w.Write([]byte("event:log\ndata:" +
+				`{"id":"3","created":"2022-05-03 10:00:00.123Z","level":8,"message":"test3","data":{"status":200}}` +
+				"\n\n:ping\n\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+		expected    []string
+	}{
+		{
+			"invalid token",
+			[]string{"--url=" + server.URL, "--token=invalid"},
+			true,
+			nil,
+		},
+		{
+			"list",
+			[]string{"--url=" + server.URL, "--token=test_token", "--limit=10", "--data=status=200"},
+			false,
+			[]string{
+				"2022-05-01 10:00:00.123Z INFO  test1 {\"status\":200}",
+				"2022-05-02 10:00:00.123Z INFO  test2 {\"status\":200}",
+			},
+		},
+		{
+			"follow",
+			[]string{"--url=" + server.URL + "/", "--token=test_token", "--limit=0", "--follow"},
+			false,
+			[]string{
+				"2022-05-03 10:00:00.123Z ERROR test3 {\"status\":200}",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+
+			command := cmd.NewLogsCommand(nil)
+			command.SetArgs(s.args)
+			command.SetOut(out)
+			command.SetErr(&bytes.Buffer{})
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if s.expectError != hasErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if strings.TrimSpace(out.String()) != strings.Join(s.expected, "\n") {
+				t.Fatalf("Expected lines\n%s\ngot\n%s", strings.Join(s.expected, "\n"), out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+// recordsTableMaxCellLength is the max number of characters
+// of a single records list table cell.
+const recordsTableMaxCellLength = 40
+
+// NewRecordsCommand creates and returns new command for managing
+// the collection records (list, get, create, update, delete).
+//
+// The commands have full (admin) access to the collection records.
+func NewRecordsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "records",
+		Short: "Manages the collection records",
+	}
+
+	command.AddCommand(recordsListCommand(app))
+	command.AddCommand(recordsGetCommand(app))
+	command.AddCommand(recordsCreateCommand(app))
+	command.AddCommand(recordsUpdateCommand(app))
+	command.AddCommand(recordsDeleteCommand(app))
+
+	return command
+}
+
+func recordsListCommand(app core.App) *cobra.Command {
+	var filter string
+	var sort string
+	var limit int
+	var asJSON bool
+
+	command := &cobra.Command{
+		Use:          "list <collection>",
+		Example:      "records list posts --filter='published=true' --sort=-created --limit=10",
+		Short:        "Lists the records of a single collection",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing collection argument.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", args[0])
+			}
+
+			query := app.Dao().RecordQuery(collection)
+
+			resolver := resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, true)
+
+			if filter != "" {
+				expr, err := search.FilterData(filter).BuildExpr(resolver)
+				if err != nil {
+					return fmt.Errorf("Invalid --filter value: %v", err)
+				}
+				query.AndWhere(expr)
+			}
+
+			if sort != "" {
+				for _, sortField := range search.ParseSortFromString(sort) {
+					expr, err := sortField.BuildExpr(resolver)
+					if err != nil {
+						return fmt.Errorf("Invalid --sort value: %v", err)
+					}
+					query.AndOrderBy(expr)
+				}
+			}
+
+			if err := resolver.UpdateQuery(query); err != nil {
+				return err
+			}
+
+			if limit > 0 {
+				query.Limit(int64(limit))
+			}
+
+			records := []*models.Record{}
+			if err := query.All(&records); err != nil {
+				return err
+			}
+
+			out := command.OutOrStdout()
+
+			if asJSON {
+				return printRecordsJSON(out, records)
+			}
+
+			return printRecordsTable(out, collection, records)
+		},
+	}
+
+	command.PersistentFlags().StringVar(&filter, "filter", "", "records filter expression")
+	command.PersistentFlags().StringVar(&sort, "sort", "", "records sort expression (eg. -created,title)")
+	command.PersistentFlags().IntVar(&limit, "limit", 30, "the max number of records to print (0 for all)")
+	command.PersistentFlags().BoolVar(&asJSON, "json", false, "print the records as JSON")
+
+	return command
+}
+
+func recordsGetCommand(app core.App) *cobra.Command {
+	var asJSON bool
+
+	command := &cobra.Command{
+		Use:          "get <collection> <id>",
+		Example:      "records get posts 1hpgtt2aqljd6iq",
+		Short:        "Prints a single collection record",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			record, err := findCommandRecord(app, args)
+			if err != nil {
+				return err
+			}
+
+			return printRecord(command.OutOrStdout(), record, asJSON)
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&asJSON, "json", false, "print the record as JSON")
+
+	return command
+}
+
+func recordsCreateCommand(app core.App) *cobra.Command {
+	var asJSON bool
+
+	command := &cobra.Command{
+		Use:          "create <collection>",
+		Example:      `echo '{"title":"Lorem ipsum"}' | records create posts`,
+		Short:        "Creates a new collection record from the JSON data read from stdin",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Missing collection argument.")
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Missing collection %q.", args[0])
+			}
+
+			if collection.IsView() {
+				return fmt.Errorf("Records of view collection %q cannot be created.", collection.Name)
+			}
+
+			record := models.NewRecord(collection)
+
+			if err := submitCommandRecord(app, record, command.InOrStdin()); err != nil {
+				return fmt.Errorf("Failed to create the record: %v", err)
+			}
+
+			return printRecord(command.OutOrStdout(), record, asJSON)
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&asJSON, "json", false, "print the created record as JSON")
+
+	return command
+}
+
+func recordsUpdateCommand(app core.App) *cobra.Command {
+	var asJSON bool
+
+	command := &cobra.Command{
+		Use:          "update <collection> <id>",
+		Example:      `echo '{"title":"Lorem ipsum"}' | records update posts 1hpgtt2aqljd6iq`,
+		Short:        "Updates a single collection record with the JSON data read from stdin",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			record, err := findCommandRecord(app, args)
+			if err != nil {
+				return err
+			}
+
+			if record.Collection().IsView() {
+				return fmt.Errorf("Records of view collection %q cannot be updated.", record.Collection().Name)
+			}
+
+			if err := submitCommandRecord(app, record, command.InOrStdin()); err != nil {
+				return fmt.Errorf("Failed to update the record: %v", err)
+			}
+
+			return printRecord(command.OutOrStdout(), record, asJSON)
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&asJSON, "json", false, "print the updated record as JSON")
+
+	return command
+}
+
+func recordsDeleteCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "delete <collection> <id>",
+		Example:      "records delete posts 1hpgtt2aqljd6iq",
+		Short:        "Deletes a single collection record",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			record, err := findCommandRecord(app, args)
+			if err != nil {
+				return err
+			}
+
+			if record.Collection().IsView() {
+				return fmt.Errorf("Records of view collection %q cannot be deleted.", record.Collection().Name)
+			}
+
+			if err := app.Dao().DeleteRecord(record); err != nil {
+				return fmt.Errorf("Failed to delete record %q: %v", record.Id, err)
+			}
+
+			fmt.Fprintf(command.OutOrStdout(), "Successfully deleted record %q from %s.\n", record.Id, record.Collection().Name)
+
+			return nil
+		},
+	}
+
+	return command
+}
+
+// findCommandRecord loads the record from the "<collection> <id>" command args.
+func findCommandRecord(app core.App, args []string) (*models.Record, error) {
+	if len(args) != 2 {
+		return nil, errors.New("Missing collection and record id arguments.")
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("Missing collection %q.", args[0])
+	}
+
+	record, err := app.Dao().FindRecordById(collection.Id, args[1])
+	if err != nil {
+		return nil, fmt.Errorf("Missing record %q in collection %q.", args[1], collection.Name)
+	}
+
+	return record, nil
+}
+
+// submitCommandRecord loads the JSON object from r into the
+// provided record and persists it (with full manage access).
+func submitCommandRecord(app core.App, record *models.Record, r io.Reader) error {
+	data := map[string]any{}
+
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("missing JSON data (expected an object from stdin)")
+		}
+		return fmt.Errorf("invalid JSON data: %v", err)
+	}
+
+	form := forms.NewRecordUpsert(app, record)
+	form.SetFullManageAccess(true)
+
+	if err := form.LoadData(data); err != nil {
+		return err
+	}
+
+	return form.Submit()
+}
+
+func printRecord(out io.Writer, record *models.Record, asJSON bool) error {
+	record.IgnoreEmailVisibility(true)
+
+	if asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(record)
+	}
+
+	export := record.PublicExport()
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tVALUE")
+	for _, name := range recordTableColumns(record.Collection()) {
+		fmt.Fprintf(w, "%s\t%s\n", name, recordCellValue(export[name], 0))
+	}
+
+	return w.Flush()
+}
+
+func printRecordsJSON(out io.Writer, records []*models.Record) error {
+	for _, record := range records {
+		record.IgnoreEmailVisibility(true)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(records)
+}
+
+func printRecordsTable(out io.Writer, collection *models.Collection, records []*models.Record) error {
+	columns := recordTableColumns(collection)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+
+	for _, record := range records {
+		record.IgnoreEmailVisibility(true)
+		export := record.PublicExport()
+
+		cells := make([]string, len(columns))
+		for i, name := range columns {
+			cells[i] = recordCellValue(export[name], recordsTableMaxCellLength)
+		}
+
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Total: %d record(s)\n", len(records))
+
+	return nil
+}
+
+// recordTableColumns returns the collection field names in the
+// order they are printed (id, auth fields, schema fields, created, updated).
+func recordTableColumns(collection *models.Collection) []string {
+	columns := []string{schema.FieldNameId}
+
+	if collection.IsAuth() {
+		columns = append(
+			columns,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+		)
+	}
+
+	for _, field := range collection.Schema.Fields() {
+		columns = append(columns, field.Name)
+	}
+
+	if !collection.IsView() {
+		columns = append(columns, schema.FieldNameCreated, schema.FieldNameUpdated)
+	}
+
+	return columns
+}
+
+// recordCellValue returns the table cell representation of a single
+// record field value, truncated to maxLength characters (0 means no limit).
+func recordCellValue(value any, maxLength int) string {
+	var str string
+
+	switch v := value.(type) {
+	case nil:
+		str = ""
+	case string:
+		str = v
+	case []string, []any, map[string]any:
+		raw, _ := json.Marshal(v)
+		str = string(raw)
+	default:
+		var err error
+		if str, err = cast.ToStringE(v); err != nil {
+			raw, _ := json.Marshal(v)
+			str = string(raw)
+		}
+	}
+
+	// keep the table rows on a single line
+	str = strings.NewReplacer("\r", " ", "\n", " ", "\t", " ").Replace(str)
+
+	if maxLength > 0 {
+		if runes := []rune(str); len(runes) > maxLength {
+			str = string(runes[:maxLength-3]) + "..."
+		}
+	}
+
+	return str
+}
@@ -0,0 +1,216 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsCommand(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		stdin            string
+		expectError      bool
+		expectedOutput   []string
+		unexpectedOutput []string
+		afterFunc        func(t *testing.T, app *tests.TestApp)
+	}{
+		{
+			name:        "list - missing collection arg",
+			args:        []string{"list"},
+			expectError: true,
+		},
+		{
+			name:        "list - missing collection",
+			args:        []string{"list", "missing"},
+			expectError: true,
+		},
+		{
+			name:        "list - invalid filter",
+			args:        []string{"list", "demo2", "--filter", "missing = 1"},
+			expectError: true,
+		},
+		{
+			name: "list - table",
+			args: []string{"list", "demo2", "--sort", "-title"},
+			expectedOutput: []string{
+				"ID               TITLE  ACTIVE  CREATED",
+				"0yxhwia2amd8gec  test3  true",
+				"Total: 3 record(s)",
+			},
+		},
+		{
+			name: "list - filter and limit",
+			args: []string{"list", "demo2", "--filter", "active = true", "--sort", "title", "--limit", "1"},
+			expectedOutput: []string{
+				"achvryl401bhse3  test2",
+				"Total: 1 record(s)",
+			},
+			unexpectedOutput: []string{
+				"test1",
+				"test3",
+			},
+		},
+		{
+			name: "list - json",
+			args: []string{"list", "demo2", "--filter", "title = 'test1'", "--json"},
+			expectedOutput: []string{
+				`"id": "llvuca81nly1qls"`,
+				`"title": "test1"`,
+			},
+		},
+		{
+			name: "list - auth collection (email is always visible)",
+			args: []string{"list", "users", "--filter", "id = '4q1xlclmfloku33'"},
+			expectedOutput: []string{
+				"USERNAME",
+				"EMAIL",
+				"test@example.com",
+			},
+		},
+		{
+			name:        "get - missing record",
+			args:        []string{"get", "demo2", "missing"},
+			expectError: true,
+		},
+		{
+			name: "get - table",
+			args: []string{"get", "demo2", "achvryl401bhse3"},
+			expectedOutput: []string{
+				"FIELD",
+				"title    test2",
+				"active   true",
+			},
+		},
+		{
+			name: "get - json",
+			args: []string{"get", "demo2", "achvryl401bhse3", "--json"},
+			expectedOutput: []string{
+				`"collectionName": "demo2"`,
+				`"title": "test2"`,
+			},
+		},
+		{
+			name:        "create - view collection",
+			args:        []string{"create", "view1"},
+			stdin:       `{}`,
+			expectError: true,
+		},
+		{
+			name:        "create - missing stdin data",
+			args:        []string{"create", "demo2"},
+			expectError: true,
+		},
+		{
+			name:        "create - invalid json",
+			args:        []string{"create", "demo2"},
+			stdin:       `{"title":`,
+			expectError: true,
+		},
+		{
+			name:        "create - validation error",
+			args:        []string{"create", "demo2"},
+			stdin:       `{"title":"a"}`,
+			expectError: true,
+		},
+		{
+			name:  "create - valid data",
+			args:  []string{"create", "demo2", "--json"},
+			stdin: `{"title":"cli_test","active":true}`,
+			expectedOutput: []string{
+				`"title": "cli_test"`,
+				`"active": true`,
+			},
+			afterFunc: func(t *testing.T, app *tests.TestApp) {
+				if _, err := app.Dao().FindFirstRecordByData("demo2", "title", "cli_test"); err != nil {
+					t.Fatalf("Expected the new record to be created: %v", err)
+				}
+			},
+		},
+		{
+			name:  "update - valid data",
+			args:  []string{"update", "demo2", "llvuca81nly1qls"},
+			stdin: `{"title":"cli_test_update"}`,
+			expectedOutput: []string{
+				"title    cli_test_update",
+			},
+			afterFunc: func(t *testing.T, app *tests.TestApp) {
+				record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if title := record.GetString("title"); title != "cli_test_update" {
+					t.Fatalf("Expected title %q, got %q", "cli_test_update", title)
+				}
+			},
+		},
+		{
+			name:        "update - missing record",
+			args:        []string{"update", "demo2", "missing"},
+			stdin:       `{"title":"cli_test_update"}`,
+			expectError: true,
+		},
+		{
+			name:        "delete - missing record",
+			args:        []string{"delete", "demo2", "missing"},
+			expectError: true,
+		},
+		{
+			name: "delete - existing record",
+			args: []string{"delete", "demo2", "0yxhwia2amd8gec"},
+			expectedOutput: []string{
+				`Successfully deleted record "0yxhwia2amd8gec" from demo2.`,
+			},
+			afterFunc: func(t *testing.T, app *tests.TestApp) {
+				if _, err := app.Dao().FindRecordById("demo2", "0yxhwia2amd8gec"); err == nil {
+					t.Fatal("Expected the record to be deleted")
+				}
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewRecordsCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetIn(strings.NewReader(s.stdin))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, str := range s.unexpectedOutput {
+				if strings.Contains(out.String(), str) {
+					t.Fatalf("Didn't expect %q in output:\n%s", str, out.String())
+				}
+			}
+
+			if s.afterFunc != nil {
+				s.afterFunc(t, app)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewRoutesCommand creates and returns new command for inspecting
+// the registered app routes.
+func NewRoutesCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "routes",
+		Short: "Inspects the registered app routes",
+	}
+
+	command.AddCommand(routesListCommand(app))
+
+	return command
+}
+
+func routesListCommand(app core.App) *cobra.Command {
+	var asJSON bool
+	var onlyConflicts bool
+
+	command := &cobra.Command{
+		Use:          "list",
+		Example:      "routes list --conflicts",
+		Short:        "Lists all registered routes with their source (core, plugin or script)",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			e, err := apis.InitApi(app)
+			if err != nil {
+				return err
+			}
+
+			// trigger the serve event to ensure that the plugin and script routes are registered
+			err = app.OnBeforeServe().Trigger(&core.ServeEvent{
+				App:    app,
+				Router: e,
+			})
+			if err != nil {
+				return fmt.Errorf("Failed to register the app routes: %v", err)
+			}
+
+			var definitions []*apis.RouteDefinition
+			if onlyConflicts {
+				definitions = apis.RouteConflicts(e)
+			} else {
+				definitions = apis.RouteDefinitions(e)
+			}
+
+			out := command.OutOrStdout()
+
+			if asJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(definitions)
+			}
+
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "METHOD\tPATH\tSOURCE\tCONFLICTS")
+			for _, def := range definitions {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", def.Method, def.Path, def.SourceLabel(), strings.Join(def.Conflicts, ", "))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			if onlyConflicts && len(definitions) > 0 {
+				return errors.New("Found conflicting routes.")
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().BoolVar(&asJSON, "json", false, "print the routes as JSON")
+	command.PersistentFlags().BoolVar(&onlyConflicts, "conflicts", false, "list only the conflicting routes (exits with error if there are any)")
+
+	return command
+}
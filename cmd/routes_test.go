@@ -0,0 +1,124 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRoutesListCommand(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		args           []string
+		withConflict   bool
+		expectError    bool
+		expectedOutput []string
+		notExpected    []string
+	}{
+		{
+			name: "table",
+			args: []string{"list"},
+			expectedOutput: []string{
+				"METHOD",
+				"/api/routes",
+				"/custom",
+				"plugin",
+			},
+		},
+		{
+			name:           "only conflicts (none)",
+			args:           []string{"list", "--conflicts"},
+			expectedOutput: []string{"METHOD"},
+			notExpected:    []string{"/api/routes", "/custom"},
+		},
+		{
+			name:         "only conflicts (with conflict)",
+			args:         []string{"list", "--conflicts"},
+			withConflict: true,
+			expectError:  true,
+			expectedOutput: []string{
+				"/api/health",
+				"core",
+				"script:test.pb.js",
+			},
+			notExpected: []string{"/api/routes"},
+		},
+		{
+			name: "json",
+			args: []string{"list", "--json"},
+			expectedOutput: []string{
+				`"path": "/custom",`,
+				`"source": "plugin",`,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			handler := func(c echo.Context) error {
+				return c.NoContent(http.StatusNoContent)
+			}
+
+			app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+				e.Router.GET("/custom", handler)
+
+				if s.withConflict {
+					_, err := e.Router.AddRoute(apis.SourcedRoute{
+						Route:  echo.Route{Method: http.MethodGet, Path: "/api/health", Handler: handler},
+						Source: apis.RouteSourceScript,
+						Origin: "test.pb.js",
+					})
+					return err
+				}
+
+				return nil
+			})
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewRoutesCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, str := range s.notExpected {
+				if strings.Contains(out.String(), str) {
+					t.Fatalf("Didn't expect %q in output:\n%s", str, out.String())
+				}
+			}
+
+			if strings.Contains(strings.Join(s.args, " "), "--json") {
+				var definitions []*apis.RouteDefinition
+				if err := json.Unmarshal(out.Bytes(), &definitions); err != nil {
+					t.Fatalf("Failed to parse the json output: %v", err)
+				}
+			}
+		})
+	}
+}
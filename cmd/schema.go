@@ -0,0 +1,435 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/plugins/migratecmd"
+	"github.com/pocketbase/pocketbase/tools/rest"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cobra"
+)
+
+// NewSchemaCommand creates and returns new command for comparing and
+// syncing the app collections with a JSON snapshot or a remote instance.
+func NewSchemaCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "schema",
+		Short: "Compares and syncs the app collections with a JSON snapshot or a remote instance",
+		// prevent cobra to print its default help message for the group command
+		RunE: func(command *cobra.Command, args []string) error {
+			return command.Help()
+		},
+	}
+
+	command.AddCommand(schemaDiffCommand(app))
+	command.AddCommand(schemaSyncCommand(app))
+
+	return command
+}
+
+// schemaSource defines the common target collections source flags.
+type schemaSource struct {
+	remoteUrl     string
+	token         string
+	deleteMissing bool
+}
+
+func (s *schemaSource) bindFlags(command *cobra.Command) {
+	command.PersistentFlags().StringVar(&s.remoteUrl, "url", "", "the base url of a remote instance to compare with (eg. https://staging.example.com)")
+	command.PersistentFlags().StringVar(&s.token, "token", "", "admin auth token to use with --url")
+	command.PersistentFlags().BoolVar(&s.deleteMissing, "delete-missing", false, "delete the local collections and fields that are missing in the source")
+}
+
+// load loads the target collections from the snapshot file
+// argument or from the remote instance (if --url is set).
+func (s *schemaSource) load(ctx context.Context, args []string) ([]*models.Collection, error) {
+	if s.remoteUrl != "" {
+		if len(args) > 0 {
+			return nil, errors.New("The snapshot file argument and --url cannot be used together.")
+		}
+
+		return loadRemoteCollections(ctx, s.remoteUrl, s.token)
+	}
+
+	if len(args) != 1 {
+		return nil, errors.New("Missing snapshot file argument (or --url).")
+	}
+
+	raw, err := os.ReadFile(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the snapshot file: %v", err)
+	}
+
+	collections := []*models.Collection{}
+	if err := json.Unmarshal(raw, &collections); err != nil {
+		return nil, fmt.Errorf("Failed to parse the snapshot file: %v", err)
+	}
+
+	return collections, nil
+}
+
+func schemaDiffCommand(app core.App) *cobra.Command {
+	source := &schemaSource{}
+
+	command := &cobra.Command{
+		Use:          "diff",
+		Example:      "schema diff ./pb_schema.json\nschema diff --url=https://staging.example.com --token=ADMIN_TOKEN",
+		Short:        "Prints the changes that a schema sync would apply to the app collections",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			collections, err := source.load(command.Context(), args)
+			if err != nil {
+				return err
+			}
+
+			changes, err := diffSchema(app, collections, source.deleteMissing)
+			if err != nil {
+				return err
+			}
+
+			printSchemaChanges(command.OutOrStdout(), changes)
+
+			return nil
+		},
+	}
+
+	source.bindFlags(command)
+
+	return command
+}
+
+func schemaSyncCommand(app core.App) *cobra.Command {
+	source := &schemaSource{}
+
+	var migrationsDir string
+	var migrationsLang string
+	var dryRun bool
+
+	command := &cobra.Command{
+		Use:          "sync",
+		Example:      "schema sync ./pb_schema.json --delete-missing\nschema sync --url=https://staging.example.com --token=ADMIN_TOKEN --migrations-dir=./pb_migrations",
+		Short:        "Syncs the app collections with a JSON snapshot or a remote instance",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			out := command.OutOrStdout()
+
+			collections, err := source.load(command.Context(), args)
+			if err != nil {
+				return err
+			}
+
+			changes, err := diffSchema(app, collections, source.deleteMissing)
+			if err != nil {
+				return err
+			}
+
+			printSchemaChanges(out, changes)
+
+			if len(changes) == 0 || dryRun {
+				return nil
+			}
+
+			if migrationsDir != "" {
+				previous := []*models.Collection{}
+				if err := app.Dao().CollectionQuery().OrderBy("created ASC").All(&previous); err != nil {
+					return err
+				}
+
+				lang := migrationsLang
+				if lang == "" {
+					lang = detectMigrationsLang(migrationsDir)
+				}
+
+				file, err := migratecmd.CreateSnapshotMigration(
+					migrationsDir,
+					lang,
+					"schema_sync",
+					collections,
+					source.deleteMissing,
+					previous,
+				)
+				if err != nil {
+					return err
+				}
+
+				fmt.Fprintf(out, "Successfully created migration file %q.\n", file)
+
+				return nil
+			}
+
+			form := forms.NewCollectionsImport(app)
+			form.Collections = collections
+			form.DeleteMissing = source.deleteMissing
+
+			if err := form.Submit(); err != nil {
+				return fmt.Errorf("Failed to sync the collections: %v", err)
+			}
+
+			fmt.Fprintf(out, "Successfully applied %d collection change(s).\n", len(changes))
+
+			return nil
+		},
+	}
+
+	source.bindFlags(command)
+	command.PersistentFlags().StringVar(&migrationsDir, "migrations-dir", "", "generate a migration file in the specified directory instead of applying the changes")
+	command.PersistentFlags().StringVar(&migrationsLang, "migrations-lang", "", "the generated migration template language - js or go (default to go if the migrations directory has Go files, otherwise js)")
+	command.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the changes without applying them")
+
+	return command
+}
+
+// -------------------------------------------------------------------
+
+const (
+	schemaChangeCreate = "create"
+	schemaChangeUpdate = "update"
+	schemaChangeDelete = "delete"
+)
+
+// schemaChange describes a single collection change.
+type schemaChange struct {
+	Action     string
+	Collection string
+	Details    []string
+}
+
+// diffSchema compares the app collections with the target ones
+// following the [daos.Dao.ImportCollections] semantic
+// (the collections are matched by their id).
+func diffSchema(app core.App, target []*models.Collection, deleteMissing bool) ([]*schemaChange, error) {
+	if len(target) == 0 {
+		return nil, errors.New("The source has no collections.")
+	}
+
+	existing := []*models.Collection{}
+	if err := app.Dao().CollectionQuery().OrderBy("created ASC").All(&existing); err != nil {
+		return nil, err
+	}
+
+	mappedExisting := make(map[string]*models.Collection, len(existing))
+	for _, c := range existing {
+		mappedExisting[c.Id] = c
+	}
+
+	mappedTarget := make(map[string]struct{}, len(target))
+
+	changes := []*schemaChange{}
+
+	for _, t := range target {
+		mappedTarget[t.Id] = struct{}{}
+
+		old, ok := mappedExisting[t.Id]
+		if !ok {
+			changes = append(changes, &schemaChange{
+				Action:     schemaChangeCreate,
+				Collection: t.Name,
+				Details:    []string{fmt.Sprintf("type %s with %d field(s)", collectionType(t), len(t.Schema.Fields()))},
+			})
+			continue
+		}
+
+		if details := diffCollection(old, t, deleteMissing); len(details) > 0 {
+			changes = append(changes, &schemaChange{
+				Action:     schemaChangeUpdate,
+				Collection: old.Name,
+				Details:    details,
+			})
+		}
+	}
+
+	if deleteMissing {
+		for _, c := range existing {
+			if _, ok := mappedTarget[c.Id]; !ok {
+				changes = append(changes, &schemaChange{Action: schemaChangeDelete, Collection: c.Name})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func diffCollection(old, new *models.Collection, deleteMissing bool) []string {
+	details := []string{}
+
+	if old.Name != new.Name {
+		details = append(details, fmt.Sprintf("name: %q -> %q", old.Name, new.Name))
+	}
+
+	if collectionType(old) != collectionType(new) {
+		details = append(details, fmt.Sprintf("type: %s -> %s", collectionType(old), collectionType(new)))
+	}
+
+	rules := []struct {
+		name string
+		old  *string
+		new  *string
+	}{
+		{"listRule", old.ListRule, new.ListRule},
+		{"viewRule", old.ViewRule, new.ViewRule},
+		{"createRule", old.CreateRule, new.CreateRule},
+		{"updateRule", old.UpdateRule, new.UpdateRule},
+		{"deleteRule", old.DeleteRule, new.DeleteRule},
+	}
+	for _, r := range rules {
+		if oldRule, newRule := ruleString(r.old), ruleString(r.new); oldRule != newRule {
+			details = append(details, fmt.Sprintf("%s: %s -> %s", r.name, oldRule, newRule))
+		}
+	}
+
+	for _, f := range new.Schema.Fields() {
+		oldField := old.Schema.GetFieldById(f.Id)
+		if oldField == nil {
+			details = append(details, fmt.Sprintf("+ field %q (%s)", f.Name, f.Type))
+		} else if !jsonEqual(oldField, f) {
+			details = append(details, fmt.Sprintf("~ field %q (%s)", f.Name, f.Type))
+		}
+	}
+
+	if deleteMissing {
+		for _, f := range old.Schema.Fields() {
+			if new.Schema.GetFieldById(f.Id) == nil {
+				details = append(details, fmt.Sprintf("- field %q (%s)", f.Name, f.Type))
+			}
+		}
+	}
+
+	if !jsonEqual(old.Indexes, new.Indexes) {
+		details = append(details, "indexes changed")
+	}
+
+	if !jsonEqual(normalizedOptions(old), normalizedOptions(new)) {
+		details = append(details, "options changed")
+	}
+
+	return details
+}
+
+func collectionType(c *models.Collection) string {
+	if c.Type == "" {
+		return models.CollectionTypeBase
+	}
+
+	return c.Type
+}
+
+// normalizedOptions returns the collection options normalized
+// to their type specific defaults (without modifying the original).
+func normalizedOptions(c *models.Collection) types.JsonMap {
+	clone := *c
+	clone.NormalizeOptions()
+
+	return clone.Options
+}
+
+func ruleString(rule *string) string {
+	if rule == nil {
+		return "null"
+	}
+
+	return fmt.Sprintf("%q", *rule)
+}
+
+func jsonEqual(a, b any) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && string(rawA) == string(rawB)
+}
+
+func printSchemaChanges(out io.Writer, changes []*schemaChange) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No schema changes.")
+		return
+	}
+
+	for _, c := range changes {
+		switch c.Action {
+		case schemaChangeCreate:
+			fmt.Fprintln(out, color.GreenString("+ collection %q", c.Collection))
+		case schemaChangeDelete:
+			fmt.Fprintln(out, color.RedString("- collection %q", c.Collection))
+		default:
+			fmt.Fprintln(out, color.YellowString("~ collection %q", c.Collection))
+		}
+
+		for _, d := range c.Details {
+			fmt.Fprintf(out, "    %s\n", d)
+		}
+	}
+}
+
+// -------------------------------------------------------------------
+
+// loadRemoteCollections fetches all collections of a remote
+// instance using the admin collections list api.
+func loadRemoteCollections(ctx context.Context, baseUrl string, token string) ([]*models.Collection, error) {
+	baseUrl = strings.TrimRight(baseUrl, "/")
+
+	result := []*models.Collection{}
+
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("page", fmt.Sprint(page))
+		params.Set("perPage", "500")
+		params.Set("sort", "created")
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseUrl+"/api/collections?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", token)
+
+		res, err := rest.ResolveHttpClient(nil).Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+			res.Body.Close()
+			return nil, fmt.Errorf("Failed to load the remote collections (%d): %s", res.StatusCode, body)
+		}
+
+		data := struct {
+			Items      []*models.Collection `json:"items"`
+			TotalPages int                  `json:"totalPages"`
+		}{}
+		err = json.NewDecoder(res.Body).Decode(&data)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, data.Items...)
+
+		if page >= data.TotalPages || len(data.Items) == 0 {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// detectMigrationsLang returns the template language of the existing
+// migration files in dir (go if there is at least one Go file, otherwise js).
+func detectMigrationsLang(dir string) string {
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*.go")); len(matches) > 0 {
+		return migratecmd.TemplateLangGo
+	}
+
+	return migratecmd.TemplateLangJS
+}
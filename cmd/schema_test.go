@@ -0,0 +1,369 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// createTestSchemaSnapshot exports the current app collections
+// with a few modifications (changed rule, new field and new collection).
+func createTestSchemaSnapshot(t *testing.T, app *tests.TestApp) []*models.Collection {
+	collections := []*models.Collection{}
+	if err := app.Dao().CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+		t.Fatal(err)
+	}
+
+	result := make([]*models.Collection, 0, len(collections)+1)
+
+	for _, c := range collections {
+		switch c.Name {
+		case "demo5":
+			continue // missing
+		case "demo2":
+			c.ListRule = types.Pointer("active = true")
+		case "demo3":
+			c.Schema.AddField(&schema.SchemaField{
+				Id:   "snapshot_field",
+				Name: "snapshot_field",
+				Type: schema.FieldTypeText,
+			})
+		}
+		result = append(result, c)
+	}
+
+	newCollection := &models.Collection{
+		Name: "snapshot_new",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(&schema.SchemaField{
+			Name: "title",
+			Type: schema.FieldTypeText,
+		}),
+	}
+	newCollection.SetId("snapshot_new_id")
+	result = append(result, newCollection)
+
+	return result
+}
+
+func writeTestSchemaSnapshot(t *testing.T, collections []*models.Collection) string {
+	raw, err := json.Marshal(collections)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(file, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func TestSchemaDiffCommand(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name             string
+		args             func(t *testing.T, app *tests.TestApp) []string
+		expectError      bool
+		expectedOutput   []string
+		unexpectedOutput []string
+	}{
+		{
+			name: "missing source",
+			args: func(t *testing.T, app *tests.TestApp) []string {
+				return []string{"diff"}
+			},
+			expectError: true,
+		},
+		{
+			name: "missing snapshot file",
+			args: func(t *testing.T, app *tests.TestApp) []string {
+				return []string{"diff", filepath.Join(t.TempDir(), "missing.json")}
+			},
+			expectError: true,
+		},
+		{
+			name: "unchanged snapshot",
+			args: func(t *testing.T, app *tests.TestApp) []string {
+				collections := []*models.Collection{}
+				if err := app.Dao().CollectionQuery().All(&collections); err != nil {
+					t.Fatal(err)
+				}
+				return []string{"diff", writeTestSchemaSnapshot(t, collections)}
+			},
+			expectedOutput: []string{"No schema changes."},
+		},
+		{
+			name: "changed snapshot",
+			args: func(t *testing.T, app *tests.TestApp) []string {
+				return []string{"diff", writeTestSchemaSnapshot(t, createTestSchemaSnapshot(t, app))}
+			},
+			expectedOutput: []string{
+				`~ collection "demo2"`,
+				`listRule: "" -> "active = true"`,
+				`~ collection "demo3"`,
+				`+ field "snapshot_field" (text)`,
+				`+ collection "snapshot_new"`,
+			},
+			unexpectedOutput: []string{
+				`collection "demo5"`,
+				`collection "demo1"`,
+			},
+		},
+		{
+			name: "changed snapshot with --delete-missing",
+			args: func(t *testing.T, app *tests.TestApp) []string {
+				return []string{"diff", writeTestSchemaSnapshot(t, createTestSchemaSnapshot(t, app)), "--delete-missing"}
+			},
+			expectedOutput: []string{
+				`+ collection "snapshot_new"`,
+				`- collection "demo5"`,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewSchemaCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args(t, app))
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, str := range s.unexpectedOutput {
+				if strings.Contains(out.String(), str) {
+					t.Fatalf("Didn't expect %q in output:\n%s", str, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaSyncCommand(t *testing.T) {
+	t.Parallel()
+
+	expectSynced := func(t *testing.T, app *tests.TestApp, synced bool) {
+		_, err := app.Dao().FindCollectionByNameOrId("snapshot_new")
+		if exists := err == nil; exists != synced {
+			t.Fatalf("Expected snapshot_new collection to exist %v, got %v", synced, exists)
+		}
+
+		demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated := demo2.ListRule != nil && *demo2.ListRule == "active = true"; updated != synced {
+			t.Fatalf("Expected demo2 listRule to be updated %v, got %v", synced, updated)
+		}
+
+		// delete missing is not set
+		if _, err := app.Dao().FindCollectionByNameOrId("demo5"); err != nil {
+			t.Fatalf("Expected demo5 collection to not be deleted")
+		}
+	}
+
+	t.Run("dry-run", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		file := writeTestSchemaSnapshot(t, createTestSchemaSnapshot(t, app))
+
+		out := new(bytes.Buffer)
+
+		command := cmd.NewSchemaCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"sync", file, "--dry-run"})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(out.String(), `+ collection "snapshot_new"`) {
+			t.Fatalf("Expected the changes to be printed, got:\n%s", out.String())
+		}
+
+		expectSynced(t, app, false)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		file := writeTestSchemaSnapshot(t, createTestSchemaSnapshot(t, app))
+
+		out := new(bytes.Buffer)
+
+		command := cmd.NewSchemaCommand(app)
+		command.SetOut(out)
+		command.SetArgs([]string{"sync", file})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(out.String(), "Successfully applied 3 collection change(s).") {
+			t.Fatalf("Expected success message, got:\n%s", out.String())
+		}
+
+		expectSynced(t, app, true)
+	})
+
+	t.Run("migration file", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		file := writeTestSchemaSnapshot(t, createTestSchemaSnapshot(t, app))
+		dir := filepath.Join(t.TempDir(), "pb_migrations")
+
+		command := cmd.NewSchemaCommand(app)
+		command.SetOut(new(bytes.Buffer))
+		command.SetArgs([]string{"sync", file, "--migrations-dir", dir})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectSynced(t, app, false)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*_schema_sync.js"))
+		if err != nil || len(files) != 1 {
+			t.Fatalf("Expected 1 migration file, got %v (%v)", files, err)
+		}
+
+		content, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedParts := []string{
+			`"name": "snapshot_new"`,
+			"Dao(db).importCollections(collections, false, null)",
+			// down migration restoring the previous collections
+			`"name": "demo5"`,
+			"Dao(db).importCollections(collections, true, null)",
+		}
+		for _, part := range expectedParts {
+			if !strings.Contains(string(content), part) {
+				t.Fatalf("Cannot find %q in migration:\n%s", part, content)
+			}
+		}
+	})
+
+	t.Run("go migration file", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		file := writeTestSchemaSnapshot(t, createTestSchemaSnapshot(t, app))
+		dir := filepath.Join(t.TempDir(), "migrations")
+
+		// existing go migration
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "1_init.go"), []byte("package migrations"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		command := cmd.NewSchemaCommand(app)
+		command.SetOut(new(bytes.Buffer))
+		command.SetArgs([]string{"sync", file, "--migrations-dir", dir})
+
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectSynced(t, app, false)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*_schema_sync.go"))
+		if err != nil || len(files) != 1 {
+			t.Fatalf("Expected 1 migration file, got %v (%v)", files, err)
+		}
+
+		content, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedParts := []string{
+			"package migrations",
+			`"name": "snapshot_new"`,
+			"daos.New(db).ImportCollections(collections, false, nil)",
+			`"name": "demo5"`,
+			"daos.New(db).ImportCollections(collections, true, nil)",
+		}
+		for _, part := range expectedParts {
+			if !strings.Contains(string(content), part) {
+				t.Fatalf("Cannot find %q in migration:\n%s", part, content)
+			}
+		}
+	})
+
+	t.Run("remote instance", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		collections := createTestSchemaSnapshot(t, app)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/collections" || r.Header.Get("Authorization") != "test_token" {
+				w.WriteHeader(401)
+				return
+			}
+
+			json.NewEncoder(w).Encode(map[string]any{
+				"page":       1,
+				"totalPages": 1,
+				"items":      collections,
+			})
+		}))
+		defer server.Close()
+
+		// invalid token
+		command := cmd.NewSchemaCommand(app)
+		command.SetOut(new(bytes.Buffer))
+		command.SetErr(new(bytes.Buffer))
+		command.SetArgs([]string{"sync", "--url", server.URL, "--token", "invalid"})
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error for invalid remote token, got nil")
+		}
+
+		command = cmd.NewSchemaCommand(app)
+		command.SetOut(new(bytes.Buffer))
+		command.SetArgs([]string{"sync", "--url", server.URL, "--token", "test_token"})
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		expectSynced(t, app, true)
+	})
+}
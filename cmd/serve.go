@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/pocketbase/pocketbase/apis"
 	"github.com/pocketbase/pocketbase/core"
@@ -15,6 +17,13 @@ func NewServeCommand(app core.App, showStartBanner bool) *cobra.Command {
 	var allowedOrigins []string
 	var httpAddr string
 	var httpsAddr string
+	var shutdownTimeout time.Duration
+	var preflightMode string
+	var maxBodySize int64
+	var readTimeout time.Duration
+	var readHeaderTimeout time.Duration
+	var writeTimeout time.Duration
+	var h2c bool
 
 	command := &cobra.Command{
 		Use:          "serve [domain(s)]",
@@ -22,6 +31,12 @@ func NewServeCommand(app core.App, showStartBanner bool) *cobra.Command {
 		Short:        "Starts the web server (default to 127.0.0.1:8090 if no domain is specified)",
 		SilenceUsage: true,
 		RunE: func(command *cobra.Command, args []string) error {
+			switch preflightMode {
+			case apis.PreflightStrict, apis.PreflightWarn, apis.PreflightOff:
+			default:
+				return fmt.Errorf("Invalid --preflight value %q (must be strict, warn or off).", preflightMode)
+			}
+
 			// set default listener addresses if at least one domain is specified
 			if len(args) > 0 {
 				if httpAddr == "" {
@@ -42,6 +57,13 @@ func NewServeCommand(app core.App, showStartBanner bool) *cobra.Command {
 				ShowStartBanner:    showStartBanner,
 				AllowedOrigins:     allowedOrigins,
 				CertificateDomains: args,
+				ShutdownTimeout:    shutdownTimeout,
+				Preflight:          preflightMode,
+				MaxBodySize:        maxBodySize,
+				ReadTimeout:        readTimeout,
+				ReadHeaderTimeout:  readHeaderTimeout,
+				WriteTimeout:       writeTimeout,
+				H2C:                h2c,
 			})
 
 			if errors.Is(err, http.ErrServerClosed) {
@@ -73,5 +95,54 @@ func NewServeCommand(app core.App, showStartBanner bool) *cobra.Command {
 		"TCP address to listen for the HTTPS server\n(if domain args are specified - default to 0.0.0.0:443, otherwise - default to empty string, aka. no TLS)\nThe incoming HTTP traffic also will be auto redirected to the HTTPS version",
 	)
 
+	command.PersistentFlags().DurationVar(
+		&shutdownTimeout,
+		"shutdownTimeout",
+		apis.DefaultShutdownTimeout,
+		"max duration to wait for the in-flight requests to complete on graceful shutdown",
+	)
+
+	command.PersistentFlags().StringVar(
+		&preflightMode,
+		"preflight",
+		apis.PreflightStrict,
+		"how to handle the startup preflight issues (eg. broken hook scripts)\n\"strict\" refuses to start the server, \"warn\" only prints them and \"off\" skips the checks",
+	)
+
+	command.PersistentFlags().Int64Var(
+		&maxBodySize,
+		"maxBodySize",
+		0,
+		"max request body size in bytes of all routes (0 means no server limit)\nThe routes settings limits could only lower it",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&readTimeout,
+		"readTimeout",
+		apis.DefaultReadTimeout,
+		"max duration for reading the entire request, including the body (negative means no timeout)",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&readHeaderTimeout,
+		"readHeaderTimeout",
+		apis.DefaultReadHeaderTimeout,
+		"max duration for reading the request headers (negative means no timeout)",
+	)
+
+	command.PersistentFlags().DurationVar(
+		&writeTimeout,
+		"writeTimeout",
+		0,
+		"max duration for writing the response (0 means no timeout)\nNote that it also limits the realtime connections",
+	)
+
+	command.PersistentFlags().BoolVar(
+		&h2c,
+		"h2c",
+		false,
+		"enable unencrypted HTTP/2 for the plain HTTP server (eg. behind a trusted reverse proxy)",
+	)
+
 	return command
 }
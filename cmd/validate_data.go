@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fatih/color"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms/validators"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// dataFixNone only reports the invalid records.
+	dataFixNone = "none"
+
+	// dataFixClear clears the invalid record fields
+	// (the missing relation ids are removed from the relation fields).
+	dataFixClear = "clear"
+
+	// dataFixDelete deletes the invalid records.
+	dataFixDelete = "delete"
+)
+
+// validateDataBatchSize is the number of records loaded at once.
+const validateDataBatchSize = 500
+
+// NewValidateDataCommand creates and returns new command for rechecking
+// the existing collection records against their collection schema
+// (eg. after manual SQL edits or tightening the field options).
+func NewValidateDataCommand(app core.App) *cobra.Command {
+	var fix string
+
+	command := &cobra.Command{
+		Use:          "validate-data [collection]",
+		Example:      "validate-data\nvalidate-data posts --fix=clear",
+		Short:        "Validates the existing records against their collection schema",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if fix != dataFixNone && fix != dataFixClear && fix != dataFixDelete {
+				return errors.New("Invalid --fix value - must be none, clear or delete.")
+			}
+
+			var collections []*models.Collection
+
+			if len(args) > 0 {
+				collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+				if err != nil {
+					return fmt.Errorf("Missing collection %q.", args[0])
+				}
+				if collection.IsView() {
+					return fmt.Errorf("View collection %q cannot be validated.", collection.Name)
+				}
+				collections = append(collections, collection)
+			} else {
+				err := app.Dao().CollectionQuery().
+					AndWhere(dbx.NewExp("[[type]] != {:type}", dbx.Params{"type": models.CollectionTypeView})).
+					OrderBy("created ASC").
+					All(&collections)
+				if err != nil {
+					return err
+				}
+			}
+
+			report, err := validateData(app, collections, fix)
+			if err != nil {
+				return err
+			}
+
+			printDataReport(command.OutOrStdout(), report, fix)
+
+			if fix == dataFixNone && len(report.Issues) > 0 {
+				return fmt.Errorf("Found %d invalid record(s).", len(report.Issues))
+			}
+
+			return nil
+		},
+	}
+
+	command.PersistentFlags().StringVar(&fix, "fix", dataFixNone, "the invalid records fix strategy - none, clear (reset the invalid fields and remove the missing relation ids) or delete")
+
+	return command
+}
+
+// -------------------------------------------------------------------
+
+// dataIssue describes a single invalid record.
+type dataIssue struct {
+	Collection string
+	RecordId   string
+	Errors     map[string]string
+
+	// Fixed indicates whether the fix strategy was applied successfully.
+	Fixed bool
+
+	// FixError is the reason why the fix strategy has failed (if any).
+	FixError string
+}
+
+// dataReport is the result of a data validation run.
+type dataReport struct {
+	TotalCollections int
+	TotalRecords     int
+	Issues           []*dataIssue
+}
+
+// validateData validates the records of the provided collections
+// and applies the fix strategy to the invalid ones.
+func validateData(app core.App, collections []*models.Collection, fix string) (*dataReport, error) {
+	report := &dataReport{TotalCollections: len(collections)}
+
+	for _, collection := range collections {
+		// the fix could remove or change records so the invalid ones are
+		// collected first to avoid skipping records between the batches
+		invalid := []*models.Record{}
+		invalidErrs := map[string]validation.Errors{}
+
+		query := app.Dao().RecordQuery(collection).OrderBy("rowid ASC")
+
+		for offset := 0; ; offset += validateDataBatchSize {
+			batch := []*models.Record{}
+
+			if err := query.Limit(validateDataBatchSize).Offset(int64(offset)).All(&batch); err != nil {
+				return nil, err
+			}
+
+			for _, record := range batch {
+				report.TotalRecords++
+
+				errs, err := validateRecordData(app, record)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(errs) > 0 {
+					invalid = append(invalid, record)
+					invalidErrs[record.Id] = errs
+				}
+			}
+
+			if len(batch) < validateDataBatchSize {
+				break // no more records
+			}
+		}
+
+		for _, record := range invalid {
+			issue := &dataIssue{
+				Collection: collection.Name,
+				RecordId:   record.Id,
+				Errors:     map[string]string{},
+			}
+
+			for field, fieldErr := range invalidErrs[record.Id] {
+				if ve, ok := fieldErr.(validation.Error); ok {
+					issue.Errors[field] = ve.Code() + ": " + ve.Error()
+				} else {
+					issue.Errors[field] = fieldErr.Error()
+				}
+			}
+
+			if fix != dataFixNone {
+				if err := fixRecordData(app, record, invalidErrs[record.Id], fix); err != nil {
+					issue.FixError = err.Error()
+				} else {
+					issue.Fixed = true
+				}
+			}
+
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	return report, nil
+}
+
+// validateRecordData rechecks the record schema data.
+//
+// It returns a non-nil error only in case of an internal (eg. db) failure.
+func validateRecordData(app core.App, record *models.Record) (validation.Errors, error) {
+	validator := validators.NewRecordDataValidator(app.Dao(), record, nil)
+
+	err := validator.Validate(record.SchemaData())
+	if err == nil {
+		return nil, nil
+	}
+
+	if errs, ok := err.(validation.Errors); ok {
+		return errs, nil
+	}
+
+	if ve, ok := err.(validation.Error); ok {
+		return validation.Errors{"": ve}, nil
+	}
+
+	return nil, err
+}
+
+// fixRecordData applies the fix strategy to the invalid record.
+func fixRecordData(app core.App, record *models.Record, errs validation.Errors, fix string) error {
+	if fix == dataFixDelete {
+		return app.Dao().DeleteRecord(record)
+	}
+
+	for name, fieldErr := range errs {
+		field := record.Collection().Schema.GetFieldByName(name)
+		if field == nil {
+			continue
+		}
+
+		ve, _ := fieldErr.(validation.Error)
+		if field.Type == schema.FieldTypeRelation && ve != nil && ve.Code() == "validation_missing_rel_records" {
+			ids, err := existingRelationIds(app, field, record.GetStringSlice(name))
+			if err != nil {
+				return err
+			}
+			record.Set(name, field.PrepareValue(ids))
+			continue
+		}
+
+		record.Set(name, field.PrepareValue(nil))
+	}
+
+	// ensure that the cleared fields are valid
+	if errs, err := validateRecordData(app, record); err != nil {
+		return err
+	} else if len(errs) > 0 {
+		return fmt.Errorf("the record is still invalid after the fix: %v", errs)
+	}
+
+	return app.Dao().SaveRecord(record)
+}
+
+// existingRelationIds returns only the existing relation record ids
+// (the original ids order is preserved).
+func existingRelationIds(app core.App, field *schema.SchemaField, ids []string) ([]string, error) {
+	options, _ := field.Options.(*schema.RelationOptions)
+	if options == nil {
+		return nil, nil
+	}
+
+	relCollection, err := app.Dao().FindCollectionByNameOrId(options.CollectionId)
+	if err != nil {
+		return nil, nil // the related collection is missing
+	}
+
+	existing := []string{}
+	err = app.Dao().RecordQuery(relCollection).
+		Select("id").
+		AndWhere(dbx.In("id", list.ToInterfaceSlice(ids)...)).
+		Column(&existing)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(existing))
+	for _, id := range ids {
+		if list.ExistInSlice(id, existing) {
+			result = append(result, id)
+		}
+	}
+
+	return result, nil
+}
+
+func printDataReport(out io.Writer, report *dataReport, fix string) {
+	for _, issue := range report.Issues {
+		switch {
+		case issue.Fixed && fix == dataFixDelete:
+			fmt.Fprintln(out, color.RedString("- %s %s (deleted)", issue.Collection, issue.RecordId))
+		case issue.Fixed:
+			fmt.Fprintln(out, color.GreenString("~ %s %s (fixed)", issue.Collection, issue.RecordId))
+		default:
+			fmt.Fprintln(out, color.YellowString("! %s %s", issue.Collection, issue.RecordId))
+		}
+
+		fields := make([]string, 0, len(issue.Errors))
+		for field := range issue.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			fmt.Fprintf(out, "    %s: %s\n", field, issue.Errors[field])
+		}
+
+		if issue.FixError != "" {
+			fmt.Fprintf(out, "    fix failed: %s\n", issue.FixError)
+		}
+	}
+
+	fmt.Fprintf(
+		out,
+		"Checked %d record(s) in %d collection(s), found %d invalid.\n",
+		report.TotalRecords,
+		report.TotalCollections,
+		len(report.Issues),
+	)
+}
@@ -0,0 +1,147 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// invalidateTestData bypasses the record validations by
+// changing the demo2 and users records with raw SQL.
+func invalidateTestData(t *testing.T, app *tests.TestApp) {
+	queries := []string{
+		"UPDATE demo2 SET title = '' WHERE id = 'llvuca81nly1qls'",
+		"UPDATE users SET rel = 'missing' WHERE id = 'bgs820n361vj1qd'",
+	}
+
+	for _, q := range queries {
+		if _, err := app.Dao().DB().NewQuery(q).Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestValidateDataCommand(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name             string
+		args             []string
+		expectError      bool
+		expectedOutput   []string
+		unexpectedOutput []string
+		after            func(t *testing.T, app *tests.TestApp)
+	}{
+		{
+			name:        "missing collection",
+			args:        []string{"missing"},
+			expectError: true,
+		},
+		{
+			name:        "view collection",
+			args:        []string{"view1"},
+			expectError: true,
+		},
+		{
+			name:        "invalid fix strategy",
+			args:        []string{"demo2", "--fix=invalid"},
+			expectError: true,
+		},
+		{
+			name:        "report only",
+			args:        []string{"demo2"},
+			expectError: true,
+			expectedOutput: []string{
+				"! demo2 llvuca81nly1qls",
+				"title: validation_required",
+				"Checked 3 record(s) in 1 collection(s), found 1 invalid.",
+			},
+			unexpectedOutput: []string{
+				"achvryl401bhse3",
+			},
+		},
+		{
+			name: "clear fix",
+			args: []string{"users", "--fix=clear"},
+			expectedOutput: []string{
+				"~ users bgs820n361vj1qd (fixed)",
+				"rel: validation_missing_rel_records",
+			},
+			after: func(t *testing.T, app *tests.TestApp) {
+				record, err := app.Dao().FindRecordById("users", "bgs820n361vj1qd")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rel := record.GetString("rel"); rel != "" {
+					t.Fatalf("Expected the missing rel to be removed, got %q", rel)
+				}
+			},
+		},
+		{
+			name: "clear fix of a required field",
+			args: []string{"demo2", "--fix=clear"},
+			expectedOutput: []string{
+				"! demo2 llvuca81nly1qls",
+				"fix failed:",
+			},
+		},
+		{
+			name: "delete fix",
+			args: []string{"demo2", "--fix=delete"},
+			expectedOutput: []string{
+				"- demo2 llvuca81nly1qls (deleted)",
+			},
+			after: func(t *testing.T, app *tests.TestApp) {
+				if _, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls"); err == nil {
+					t.Fatal("Expected the invalid record to be deleted")
+				}
+
+				if _, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3"); err != nil {
+					t.Fatalf("Expected the valid record to remain, got %v", err)
+				}
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			invalidateTestData(t, app)
+
+			out := new(bytes.Buffer)
+
+			command := cmd.NewValidateDataCommand(app)
+			command.SetOut(out)
+			command.SetErr(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			for _, str := range s.expectedOutput {
+				if !strings.Contains(out.String(), str) {
+					t.Fatalf("Cannot find %q in output:\n%s", str, out.String())
+				}
+			}
+
+			for _, str := range s.unexpectedOutput {
+				if strings.Contains(out.String(), str) {
+					t.Fatalf("Didn't expect %q in output:\n%s", str, out.String())
+				}
+			}
+
+			if s.after != nil {
+				s.after(t, app)
+			}
+		})
+	}
+}
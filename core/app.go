@@ -6,15 +6,22 @@ package core
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/bus"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/secrets"
+	"github.com/pocketbase/pocketbase/tools/sms"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/tracing"
 )
 
 // App defines the main PocketBase app interface.
@@ -74,9 +81,56 @@ type App interface {
 	// SubscriptionsBroker returns the app realtime subscriptions broker instance.
 	SubscriptionsBroker() *subscriptions.Broker
 
+	// Bus returns the app in-process event bus that could be used to
+	// exchange typed messages between Go plugins and the app scripts.
+	Bus() *bus.Bus
+
+	// Secrets returns the app external secret references resolver
+	// used for the sensitive settings values (eg. "env:SMTP_PASS").
+	Secrets() *secrets.Manager
+
+	// Tracer returns the app OpenTelemetry tracer
+	// (it is disabled unless configured in the settings).
+	Tracer() *tracing.Tracer
+
+	// RecordsCache returns the app records API responses cache
+	// (it is disabled unless configured in the settings).
+	RecordsCache() *RecordsCache
+
+	// NodeId returns the unique random id of the current app instance
+	// (used as owner of the app distributed locks).
+	NodeId() string
+
+	// TryLock tries to acquire the cluster-wide lock with the specified
+	// key for the provided ttl and reports whether it was acquired
+	// (eg. to run a task only on a single app instance sharing the same db).
+	//
+	// The lock could be extended by calling TryLock again before its expiration.
+	TryLock(key string, ttl time.Duration) bool
+
+	// Unlock releases the cluster-wide lock with the specified key
+	// (if it is held by the current app instance).
+	Unlock(key string) error
+
 	// NewMailClient creates and returns a configured app mail client.
 	NewMailClient() mailer.Mailer
 
+	// NewSmsClient creates and returns a configured app SMS client.
+	//
+	// Returns an error if the SMS provider is not enabled.
+	NewSmsClient() (sms.Client, error)
+
+	// NewPushClient creates and returns a configured app push
+	// notifications client for the specified provider (fcm, apns or webpush).
+	//
+	// Returns an error if the provider is not enabled.
+	NewPushClient(provider string) (push.Client, error)
+
+	// NewCaptchaVerifier creates and returns a configured app captcha verifier.
+	//
+	// Returns an error if the captcha provider is not enabled.
+	NewCaptchaVerifier() (captcha.Verifier, error)
+
 	// NewFilesystem creates and returns a configured filesystem.System instance
 	// for managing regular app files (eg. collection uploads).
 	//
@@ -149,6 +203,14 @@ type App interface {
 	// allowing you to adjust its options and attach new routes or middlewares.
 	OnBeforeServe() *hook.Hook[*ServeEvent]
 
+	// OnPreflight hook is triggered before serving the app, allowing
+	// the plugins to validate their resources (eg. compile the hook scripts)
+	// and to register the found issues in the event report.
+	//
+	// Depending on the serve config, the app refuses to start
+	// if the report has issues.
+	OnPreflight() *hook.Hook[*PreflightEvent]
+
 	// OnBeforeApiError hook is triggered right before sending an error API
 	// response to the client, allowing you to further modify the error data
 	// or to return a completely different API response.
@@ -163,6 +225,18 @@ type App interface {
 	// of being terminated (eg. on SIGTERM signal).
 	OnTerminate() *hook.Hook[*TerminateEvent]
 
+	// OnBackupCreate hook is triggered before creating a new app backup
+	// (manually or by the backups cron), allowing you to modify the
+	// backup name and excluded pb_data entries.
+	//
+	// Returning an error aborts the backup creation.
+	OnBackupCreate() *hook.Hook[*BackupEvent]
+
+	// OnBackupRestore hook is triggered before restoring an app backup.
+	//
+	// Returning an error aborts the backup restore.
+	OnBackupRestore() *hook.Hook[*BackupEvent]
+
 	// ---------------------------------------------------------------
 	// Dao event hooks
 	// ---------------------------------------------------------------
@@ -362,6 +436,18 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnFileAfterTokenRequest(tags ...string) *hook.TaggedHook[*FileTokenEvent]
 
+	// OnRecordFileScan hook is triggered for each new record file
+	// before uploading it to the storage (eg. on Record create/update form submit).
+	//
+	// Could be used to scan the file content (eg. with an antivirus) and
+	// to set e.Quarantine in order to hold the file in the quarantine
+	// storage area until an admin review (returning an error rejects the upload).
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordFileScan(tags ...string) *hook.TaggedHook[*RecordFileScanEvent]
+
 	// ---------------------------------------------------------------
 	// Admin API event hooks
 	// ---------------------------------------------------------------
@@ -460,6 +546,46 @@ type App interface {
 	// successful confirm password reset API request.
 	OnAdminAfterConfirmPasswordResetRequest() *hook.Hook[*AdminConfirmPasswordResetEvent]
 
+	// OnAdminUIConfigRequest hook is triggered on each admin UI config
+	// API request (when the admin UI is loaded by an authenticated admin).
+	//
+	// Could be used to register custom admin UI panels or to hide
+	// admin UI sections based on the current admin.
+	OnAdminUIConfigRequest() *hook.Hook[*AdminUIConfigEvent]
+
+	// ---------------------------------------------------------------
+	// Auth sessions event hooks
+	// ---------------------------------------------------------------
+
+	// OnSessionCreate hook is triggered before persisting a new
+	// admin or auth record session (aka. a new signed-in device).
+	//
+	// Could be used to additionally validate or modify the session data
+	// (returning an error aborts the related auth request).
+	OnSessionCreate() *hook.Hook[*SessionEvent]
+
+	// OnSessionRevoke hook is triggered before revoking (deleting)
+	// an admin or auth record session.
+	//
+	// Could be used for example to notify the session owner.
+	OnSessionRevoke() *hook.Hook[*SessionEvent]
+
+	// ---------------------------------------------------------------
+	// Signature requests event hooks
+	// ---------------------------------------------------------------
+
+	// OnSignatureComplete hook is triggered after a record document
+	// was successfully signed via the public signing API
+	// (aka. after the signed document was stored).
+	//
+	// Could be used for example to notify the record owner or
+	// to send a copy of the signed document to the signer.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnSignatureComplete(tags ...string) *hook.TaggedHook[*SignatureCompleteEvent]
+
 	// ---------------------------------------------------------------
 	// Record Auth API event hooks
 	// ---------------------------------------------------------------
@@ -516,6 +642,23 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnRecordAfterAuthWithOAuth2Request(tags ...string) *hook.TaggedHook[*RecordAuthWithOAuth2Event]
 
+	// OnRecordAuthWithCustomMethod hook is triggered on each Record
+	// auth with custom method API request (after request data load).
+	//
+	// It allows implementing app specific auth methods (eg. SMS OTP via
+	// a local provider) by checking [RecordAuthWithCustomMethodEvent.Method]
+	// and [RecordAuthWithCustomMethodEvent.Payload] and assigning the
+	// authenticated [RecordAuthWithCustomMethodEvent.Record].
+	//
+	// If no handler assigns a Record, the request fails with 400 error.
+	// Otherwise the standard auth token response is returned
+	// (see also [App.OnRecordAuthRequest]).
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordAuthWithCustomMethod(tags ...string) *hook.TaggedHook[*RecordAuthWithCustomMethodEvent]
+
 	// OnRecordBeforeAuthRefreshRequest hook is triggered before each Record
 	// auth refresh API request (right before generating a new auth token).
 	//
@@ -677,6 +820,43 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnRecordAfterConfirmEmailChangeRequest(tags ...string) *hook.TaggedHook[*RecordConfirmEmailChangeEvent]
 
+	// OnRecordBeforeRequestOTPRequest hook is triggered before each Record
+	// request OTP API request (after the OTP model creation and before sending the SMS).
+	//
+	// Could be used to additionally validate the request data or implement
+	// completely different OTP delivery behavior.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordBeforeRequestOTPRequest(tags ...string) *hook.TaggedHook[*RecordRequestOTPEvent]
+
+	// OnRecordAfterRequestOTPRequest hook is triggered after each
+	// successful request OTP API request.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordAfterRequestOTPRequest(tags ...string) *hook.TaggedHook[*RecordRequestOTPEvent]
+
+	// OnRecordBeforeConfirmOTPRequest hook is triggered before each Record
+	// confirm OTP API request (after the OTP validation and before the auth response).
+	//
+	// Could be used to additionally validate or modify the authenticated record.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordBeforeConfirmOTPRequest(tags ...string) *hook.TaggedHook[*RecordConfirmOTPEvent]
+
+	// OnRecordAfterConfirmOTPRequest hook is triggered after each
+	// successful confirm OTP API request.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordAfterConfirmOTPRequest(tags ...string) *hook.TaggedHook[*RecordConfirmOTPEvent]
+
 	// ---------------------------------------------------------------
 	// Record CRUD API event hooks
 	// ---------------------------------------------------------------
@@ -756,6 +936,20 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnRecordAfterDeleteRequest(tags ...string) *hook.TaggedHook[*RecordDeleteEvent]
 
+	// OnSyncConflict hook is triggered when an API Record update request
+	// is based on a stale record revision (see the "revisionField"
+	// collection option), aka. the record was modified by someone else
+	// after the client has loaded it (eg. an offline client write).
+	//
+	// Could be used to merge the submitted data with the current stored
+	// record by modifying e.Record and setting e.Resolved to true.
+	// If the conflict is not resolved, the write is rejected with 409.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnSyncConflict(tags ...string) *hook.TaggedHook[*SyncConflictEvent]
+
 	// ---------------------------------------------------------------
 	// Collection API event hooks
 	// ---------------------------------------------------------------
@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -17,14 +21,21 @@ import (
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/bus"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/push"
 	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/secrets"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/sms"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/tracing"
+	"github.com/pocketbase/pocketbase/tools/transport"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
@@ -37,9 +48,19 @@ const (
 
 	LocalStorageDirName string = "storage"
 	LocalBackupsDirName string = "backups"
+	LocalLogsDirName    string = "logs"
 	LocalTempDirName    string = ".pb_temp_to_delete" // temp pb_data sub directory that will be deleted on each app.Bootstrap()
+
+	ServePidFileName string = ".serve.pid" // pb_data file with the pid of the serving app process
 )
 
+// LogsBusTopic is the app bus topic on which every accepted log
+// entry is published synchronously (with *logger.Log message data).
+//
+// The topic subscribers must not block and must not log
+// with the app logger to avoid recursive publishing.
+const LogsBusTopic = "pb.logs"
+
 var _ App = (*BaseApp)(nil)
 
 // BaseApp implements core.App and defines the base PocketBase app structure.
@@ -47,13 +68,16 @@ type BaseApp struct {
 	// @todo consider introducing a mutex to allow safe concurrent config changes during runtime
 
 	// configurable parameters
-	isDev            bool
-	dataDir          string
-	encryptionEnv    string
-	dataMaxOpenConns int
-	dataMaxIdleConns int
-	logsMaxOpenConns int
-	logsMaxIdleConns int
+	isDev             bool
+	dataDir           string
+	encryptionEnv     string
+	settingsFile      string
+	settingsEnvPrefix string
+	logsStdout        string
+	dataMaxOpenConns  int
+	dataMaxIdleConns  int
+	logsMaxOpenConns  int
+	logsMaxIdleConns  int
 
 	// internals
 	store               *store.Store[any]
@@ -61,15 +85,33 @@ type BaseApp struct {
 	dao                 *daos.Dao
 	logsDao             *daos.Dao
 	subscriptionsBroker *subscriptions.Broker
+	bus                 *bus.Bus
+	secrets             *secrets.Manager
+	tracer              *tracing.Tracer
 	logger              *slog.Logger
 
+	// the currently applied realtime cluster transport settings
+	realtimeClusterMux    sync.Mutex
+	realtimeClusterConfig settings.RealtimeClusterConfig
+
+	// the current app instance id (used as distributed locks owner)
+	nodeId string
+
+	// the records responses cache and its currently applied store settings
+	recordsCache       *RecordsCache
+	recordsCacheMux    sync.Mutex
+	recordsCacheConfig settings.RecordsCacheConfig
+
 	// app event hooks
 	onBeforeBootstrap *hook.Hook[*BootstrapEvent]
 	onAfterBootstrap  *hook.Hook[*BootstrapEvent]
 	onBeforeServe     *hook.Hook[*ServeEvent]
+	onPreflight       *hook.Hook[*PreflightEvent]
 	onBeforeApiError  *hook.Hook[*ApiErrorEvent]
 	onAfterApiError   *hook.Hook[*ApiErrorEvent]
 	onTerminate       *hook.Hook[*TerminateEvent]
+	onBackupCreate    *hook.Hook[*BackupEvent]
+	onBackupRestore   *hook.Hook[*BackupEvent]
 
 	// dao event hooks
 	onModelBeforeCreate *hook.Hook[*ModelEvent]
@@ -106,6 +148,7 @@ type BaseApp struct {
 	onFileDownloadRequest    *hook.Hook[*FileDownloadEvent]
 	onFileBeforeTokenRequest *hook.Hook[*FileTokenEvent]
 	onFileAfterTokenRequest  *hook.Hook[*FileTokenEvent]
+	onRecordFileScan         *hook.Hook[*RecordFileScanEvent]
 
 	// admin api event hooks
 	onAdminsListRequest                      *hook.Hook[*AdminsListEvent]
@@ -125,6 +168,14 @@ type BaseApp struct {
 	onAdminAfterRequestPasswordResetRequest  *hook.Hook[*AdminRequestPasswordResetEvent]
 	onAdminBeforeConfirmPasswordResetRequest *hook.Hook[*AdminConfirmPasswordResetEvent]
 	onAdminAfterConfirmPasswordResetRequest  *hook.Hook[*AdminConfirmPasswordResetEvent]
+	onAdminUIConfigRequest                   *hook.Hook[*AdminUIConfigEvent]
+
+	// auth sessions event hooks
+	onSessionCreate *hook.Hook[*SessionEvent]
+	onSessionRevoke *hook.Hook[*SessionEvent]
+
+	// signature requests event hooks
+	onSignatureComplete *hook.Hook[*SignatureCompleteEvent]
 
 	// record auth API event hooks
 	onRecordAuthRequest                       *hook.Hook[*RecordAuthEvent]
@@ -132,6 +183,7 @@ type BaseApp struct {
 	onRecordAfterAuthWithPasswordRequest      *hook.Hook[*RecordAuthWithPasswordEvent]
 	onRecordBeforeAuthWithOAuth2Request       *hook.Hook[*RecordAuthWithOAuth2Event]
 	onRecordAfterAuthWithOAuth2Request        *hook.Hook[*RecordAuthWithOAuth2Event]
+	onRecordAuthWithCustomMethod              *hook.Hook[*RecordAuthWithCustomMethodEvent]
 	onRecordBeforeAuthRefreshRequest          *hook.Hook[*RecordAuthRefreshEvent]
 	onRecordAfterAuthRefreshRequest           *hook.Hook[*RecordAuthRefreshEvent]
 	onRecordBeforeRequestPasswordResetRequest *hook.Hook[*RecordRequestPasswordResetEvent]
@@ -146,6 +198,10 @@ type BaseApp struct {
 	onRecordAfterRequestEmailChangeRequest    *hook.Hook[*RecordRequestEmailChangeEvent]
 	onRecordBeforeConfirmEmailChangeRequest   *hook.Hook[*RecordConfirmEmailChangeEvent]
 	onRecordAfterConfirmEmailChangeRequest    *hook.Hook[*RecordConfirmEmailChangeEvent]
+	onRecordBeforeRequestOTPRequest           *hook.Hook[*RecordRequestOTPEvent]
+	onRecordAfterRequestOTPRequest            *hook.Hook[*RecordRequestOTPEvent]
+	onRecordBeforeConfirmOTPRequest           *hook.Hook[*RecordConfirmOTPEvent]
+	onRecordAfterConfirmOTPRequest            *hook.Hook[*RecordConfirmOTPEvent]
 	onRecordListExternalAuthsRequest          *hook.Hook[*RecordListExternalAuthsEvent]
 	onRecordBeforeUnlinkExternalAuthRequest   *hook.Hook[*RecordUnlinkExternalAuthEvent]
 	onRecordAfterUnlinkExternalAuthRequest    *hook.Hook[*RecordUnlinkExternalAuthEvent]
@@ -159,6 +215,7 @@ type BaseApp struct {
 	onRecordAfterUpdateRequest  *hook.Hook[*RecordUpdateEvent]
 	onRecordBeforeDeleteRequest *hook.Hook[*RecordDeleteEvent]
 	onRecordAfterDeleteRequest  *hook.Hook[*RecordDeleteEvent]
+	onSyncConflict              *hook.Hook[*SyncConflictEvent]
 
 	// collection API event hooks
 	onCollectionsListRequest         *hook.Hook[*CollectionsListEvent]
@@ -179,6 +236,19 @@ type BaseAppConfig struct {
 	DataDir          string
 	EncryptionEnv    string
 	DataMaxOpenConns int // default to 500
+
+	// SettingsFile is an optional JSON config file with settings
+	// overrides that are live reloaded while serving.
+	SettingsFile string
+
+	// SettingsEnvPrefix is an optional env variables prefix for
+	// the settings overrides (eg. "PB_" for PB_SMTP_HOST).
+	SettingsEnvPrefix string
+
+	// LogsStdout optionally prints the stored logs to the stdout
+	// as "json" or "text" lines (overrides the logs.stdout setting).
+	LogsStdout string
+
 	DataMaxIdleConns int // default 20
 	LogsMaxOpenConns int // default to 100
 	LogsMaxIdleConns int // default to 5
@@ -193,6 +263,9 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		isDev:               config.IsDev,
 		dataDir:             config.DataDir,
 		encryptionEnv:       config.EncryptionEnv,
+		settingsFile:        config.SettingsFile,
+		settingsEnvPrefix:   config.SettingsEnvPrefix,
+		logsStdout:          config.LogsStdout,
 		dataMaxOpenConns:    config.DataMaxOpenConns,
 		dataMaxIdleConns:    config.DataMaxIdleConns,
 		logsMaxOpenConns:    config.LogsMaxOpenConns,
@@ -200,14 +273,22 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		store:               store.New[any](nil),
 		settings:            settings.New(),
 		subscriptionsBroker: subscriptions.NewBroker(),
+		bus:                 bus.New(),
+		secrets:             secrets.New(secrets.DefaultCacheTTL),
+		tracer:              tracing.New(),
+		recordsCache:        NewRecordsCache(),
+		nodeId:              security.RandomString(15),
 
 		// app event hooks
 		onBeforeBootstrap: &hook.Hook[*BootstrapEvent]{},
 		onAfterBootstrap:  &hook.Hook[*BootstrapEvent]{},
 		onBeforeServe:     &hook.Hook[*ServeEvent]{},
+		onPreflight:       &hook.Hook[*PreflightEvent]{},
 		onBeforeApiError:  &hook.Hook[*ApiErrorEvent]{},
 		onAfterApiError:   &hook.Hook[*ApiErrorEvent]{},
 		onTerminate:       &hook.Hook[*TerminateEvent]{},
+		onBackupCreate:    &hook.Hook[*BackupEvent]{},
+		onBackupRestore:   &hook.Hook[*BackupEvent]{},
 
 		// dao event hooks
 		onModelBeforeCreate: &hook.Hook[*ModelEvent]{},
@@ -244,6 +325,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onFileDownloadRequest:    &hook.Hook[*FileDownloadEvent]{},
 		onFileBeforeTokenRequest: &hook.Hook[*FileTokenEvent]{},
 		onFileAfterTokenRequest:  &hook.Hook[*FileTokenEvent]{},
+		onRecordFileScan:         &hook.Hook[*RecordFileScanEvent]{},
 
 		// admin API event hooks
 		onAdminsListRequest:                      &hook.Hook[*AdminsListEvent]{},
@@ -263,6 +345,14 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onAdminAfterRequestPasswordResetRequest:  &hook.Hook[*AdminRequestPasswordResetEvent]{},
 		onAdminBeforeConfirmPasswordResetRequest: &hook.Hook[*AdminConfirmPasswordResetEvent]{},
 		onAdminAfterConfirmPasswordResetRequest:  &hook.Hook[*AdminConfirmPasswordResetEvent]{},
+		onAdminUIConfigRequest:                   &hook.Hook[*AdminUIConfigEvent]{},
+
+		// auth sessions event hooks
+		onSessionCreate: &hook.Hook[*SessionEvent]{},
+		onSessionRevoke: &hook.Hook[*SessionEvent]{},
+
+		// signature requests event hooks
+		onSignatureComplete: &hook.Hook[*SignatureCompleteEvent]{},
 
 		// record auth API event hooks
 		onRecordAuthRequest:                       &hook.Hook[*RecordAuthEvent]{},
@@ -270,6 +360,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onRecordAfterAuthWithPasswordRequest:      &hook.Hook[*RecordAuthWithPasswordEvent]{},
 		onRecordBeforeAuthWithOAuth2Request:       &hook.Hook[*RecordAuthWithOAuth2Event]{},
 		onRecordAfterAuthWithOAuth2Request:        &hook.Hook[*RecordAuthWithOAuth2Event]{},
+		onRecordAuthWithCustomMethod:              &hook.Hook[*RecordAuthWithCustomMethodEvent]{},
 		onRecordBeforeAuthRefreshRequest:          &hook.Hook[*RecordAuthRefreshEvent]{},
 		onRecordAfterAuthRefreshRequest:           &hook.Hook[*RecordAuthRefreshEvent]{},
 		onRecordBeforeRequestPasswordResetRequest: &hook.Hook[*RecordRequestPasswordResetEvent]{},
@@ -284,6 +375,10 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onRecordAfterRequestEmailChangeRequest:    &hook.Hook[*RecordRequestEmailChangeEvent]{},
 		onRecordBeforeConfirmEmailChangeRequest:   &hook.Hook[*RecordConfirmEmailChangeEvent]{},
 		onRecordAfterConfirmEmailChangeRequest:    &hook.Hook[*RecordConfirmEmailChangeEvent]{},
+		onRecordBeforeRequestOTPRequest:           &hook.Hook[*RecordRequestOTPEvent]{},
+		onRecordAfterRequestOTPRequest:            &hook.Hook[*RecordRequestOTPEvent]{},
+		onRecordBeforeConfirmOTPRequest:           &hook.Hook[*RecordConfirmOTPEvent]{},
+		onRecordAfterConfirmOTPRequest:            &hook.Hook[*RecordConfirmOTPEvent]{},
 		onRecordListExternalAuthsRequest:          &hook.Hook[*RecordListExternalAuthsEvent]{},
 		onRecordBeforeUnlinkExternalAuthRequest:   &hook.Hook[*RecordUnlinkExternalAuthEvent]{},
 		onRecordAfterUnlinkExternalAuthRequest:    &hook.Hook[*RecordUnlinkExternalAuthEvent]{},
@@ -297,6 +392,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onRecordAfterUpdateRequest:  &hook.Hook[*RecordUpdateEvent]{},
 		onRecordBeforeDeleteRequest: &hook.Hook[*RecordDeleteEvent]{},
 		onRecordAfterDeleteRequest:  &hook.Hook[*RecordDeleteEvent]{},
+		onSyncConflict:              &hook.Hook[*SyncConflictEvent]{},
 
 		// collection API event hooks
 		onCollectionsListRequest:         &hook.Hook[*CollectionsListEvent]{},
@@ -489,9 +585,89 @@ func (app *BaseApp) SubscriptionsBroker() *subscriptions.Broker {
 	return app.subscriptionsBroker
 }
 
-// NewMailClient creates and returns a new SMTP or Sendmail client
+// Bus returns the app in-process event bus instance.
+func (app *BaseApp) Bus() *bus.Bus {
+	return app.bus
+}
+
+// Secrets returns the app external secret references resolver.
+func (app *BaseApp) Secrets() *secrets.Manager {
+	return app.secrets
+}
+
+// Tracer returns the app OpenTelemetry tracer.
+func (app *BaseApp) Tracer() *tracing.Tracer {
+	return app.tracer
+}
+
+// RecordsCache returns the app records API responses cache.
+func (app *BaseApp) RecordsCache() *RecordsCache {
+	return app.recordsCache
+}
+
+// NodeId returns the unique random id of the current app instance.
+func (app *BaseApp) NodeId() string {
+	return app.nodeId
+}
+
+// TryLock tries to acquire the cluster-wide lock with the specified
+// key for the provided ttl and reports whether it was acquired.
+//
+// It implements the [cron.Locker] interface.
+func (app *BaseApp) TryLock(key string, ttl time.Duration) bool {
+	acquired, err := app.Dao().AcquireLock(key, app.nodeId, ttl)
+	if err != nil {
+		app.Logger().Debug(
+			"Failed to acquire lock",
+			slog.String("key", key),
+			slog.String("error", err.Error()),
+		)
+		return false
+	}
+
+	return acquired
+}
+
+// Unlock releases the cluster-wide lock with the specified key
+// (if it is held by the current app instance).
+func (app *BaseApp) Unlock(key string) error {
+	return app.Dao().ReleaseLock(key, app.nodeId)
+}
+
+// NewMailClient creates and returns a new HTTP API, SMTP or Sendmail client
 // based on the current app settings.
+//
+// If tracing is enabled, the sent emails are recorded as spans.
 func (app *BaseApp) NewMailClient() mailer.Mailer {
+	client := app.newMailClient()
+
+	if app.tracer.Enabled() {
+		return &tracedMailer{Mailer: client, tracer: app.tracer}
+	}
+
+	return client
+}
+
+func (app *BaseApp) newMailClient() mailer.Mailer {
+	if emailApi := app.Settings().EmailApi; emailApi.Enabled {
+		switch emailApi.Provider {
+		case mailer.ProviderSendGrid:
+			return &mailer.SendGridClient{ApiKey: emailApi.ApiKey}
+		case mailer.ProviderMailgun:
+			return &mailer.MailgunClient{
+				ApiKey: emailApi.ApiKey,
+				Domain: emailApi.Domain,
+				Region: emailApi.Region,
+			}
+		case mailer.ProviderSes:
+			return &mailer.SesClient{
+				AccessKey: emailApi.ApiKey,
+				SecretKey: emailApi.SecretKey,
+				Region:    emailApi.Region,
+			}
+		}
+	}
+
 	if app.Settings().Smtp.Enabled {
 		return &mailer.SmtpClient{
 			Host:       app.Settings().Smtp.Host,
@@ -507,15 +683,121 @@ func (app *BaseApp) NewMailClient() mailer.Mailer {
 	return &mailer.Sendmail{}
 }
 
+// NewSmsClient creates and returns a new Twilio, Vonage or generic
+// HTTP API SMS client based on the current app settings.
+//
+// The sent messages delivery status is logged with the app logger
+// (see [App.Logger]).
+func (app *BaseApp) NewSmsClient() (sms.Client, error) {
+	client, err := app.newSmsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &loggedSmsClient{
+		Client:   client,
+		logger:   app.Logger(),
+		provider: app.Settings().Sms.Provider,
+	}, nil
+}
+
+func (app *BaseApp) newSmsClient() (sms.Client, error) {
+	config := app.Settings().Sms
+
+	if !config.Enabled {
+		return nil, errors.New("the SMS provider is not enabled")
+	}
+
+	switch config.Provider {
+	case sms.ProviderTwilio:
+		return &sms.TwilioClient{
+			AccountSid: config.ApiKey,
+			AuthToken:  config.ApiSecret,
+		}, nil
+	case sms.ProviderVonage:
+		return &sms.VonageClient{
+			ApiKey:    config.ApiKey,
+			ApiSecret: config.ApiSecret,
+		}, nil
+	case sms.ProviderHttp:
+		return &sms.HttpApiClient{
+			Url:   config.Url,
+			Token: config.ApiKey,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported SMS provider %q", config.Provider)
+}
+
+// NewPushClient creates and returns a new push notifications
+// client for the specified provider based on the current app settings.
+//
+// Returns an error if the provider is not enabled.
+func (app *BaseApp) NewPushClient(provider string) (push.Client, error) {
+	config := app.Settings().Push
+
+	if !config.IsEnabled(provider) {
+		return nil, fmt.Errorf("the %q push provider is not enabled", provider)
+	}
+
+	switch provider {
+	case push.ProviderFCM:
+		return &push.FCMClient{
+			ProjectId:   config.FCM.ProjectId,
+			ClientEmail: config.FCM.ClientEmail,
+			PrivateKey:  config.FCM.PrivateKey,
+		}, nil
+	case push.ProviderAPNs:
+		return &push.APNsClient{
+			KeyId:      config.APNs.KeyId,
+			TeamId:     config.APNs.TeamId,
+			PrivateKey: config.APNs.PrivateKey,
+			Topic:      config.APNs.Topic,
+			Production: config.APNs.Production,
+		}, nil
+	default: // webpush
+		return &push.WebPushClient{
+			VapidPublicKey:  config.WebPush.VapidPublicKey,
+			VapidPrivateKey: config.WebPush.VapidPrivateKey,
+			Subject:         config.WebPush.Subject,
+		}, nil
+	}
+}
+
+// NewCaptchaVerifier creates and returns a new captcha verifier
+// for the configured captcha provider.
+func (app *BaseApp) NewCaptchaVerifier() (captcha.Verifier, error) {
+	config := app.Settings().Captcha
+
+	if !config.Enabled {
+		return nil, errors.New("the captcha provider is not enabled")
+	}
+
+	return &captcha.SiteVerifyClient{
+		Provider: config.Provider,
+		Secret:   config.Secret,
+	}, nil
+}
+
 // NewFilesystem creates a new local or S3 filesystem instance
 // for managing regular app files (eg. collection uploads)
 // based on the current app settings.
 //
+// If the app has an encryption key, the returned filesystem has
+// a files encryption keyring (see [filesystem.System.SetKeyring]).
+//
 // NB! Make sure to call Close() on the returned result
 // after you are done working with it.
 func (app *BaseApp) NewFilesystem() (*filesystem.System, error) {
+	keyring, err := app.filesKeyring()
+	if err != nil {
+		return nil, err
+	}
+
+	var fsys *filesystem.System
+
 	if app.settings != nil && app.settings.S3.Enabled {
-		return filesystem.NewS3(
+		fsys, err = filesystem.NewS3(
 			app.settings.S3.Bucket,
 			app.settings.S3.Region,
 			app.settings.S3.Endpoint,
@@ -523,10 +805,41 @@ func (app *BaseApp) NewFilesystem() (*filesystem.System, error) {
 			app.settings.S3.Secret,
 			app.settings.S3.ForcePathStyle,
 		)
+	} else {
+		// fallback to local filesystem
+		fsys, err = filesystem.NewLocal(filepath.Join(app.DataDir(), LocalStorageDirName))
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// fallback to local filesystem
-	return filesystem.NewLocal(filepath.Join(app.DataDir(), LocalStorageDirName))
+	fsys.SetKeyring(keyring)
+
+	return fsys, nil
+}
+
+// filesKeyring returns the files encryption keyring based on the app encryption key
+// and the optional comma separated previous keys from the "{EncryptionEnv}_PREVIOUS" env variable.
+//
+// Returns nil if the app doesn't have an encryption key.
+func (app *BaseApp) filesKeyring() (*filesystem.Keyring, error) {
+	if app.EncryptionEnv() == "" {
+		return nil, nil
+	}
+
+	key := os.Getenv(app.EncryptionEnv())
+	if key == "" {
+		return nil, nil
+	}
+
+	var previous []string
+	for _, k := range strings.Split(os.Getenv(app.EncryptionEnv()+"_PREVIOUS"), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			previous = append(previous, k)
+		}
+	}
+
+	return filesystem.NewKeyring(key, previous...)
 }
 
 // NewFilesystem creates a new local or S3 filesystem instance
@@ -589,16 +902,28 @@ func (app *BaseApp) RefreshSettings() error {
 		return err
 	}
 
-	// no settings were previously stored
 	if storedSettings == nil {
-		return app.Dao().SaveSettings(app.settings, encryptionKey)
+		// no settings were previously stored
+		if err := app.Dao().SaveSettings(app.settings, encryptionKey); err != nil {
+			return err
+		}
+	} else {
+		// load the settings from the stored param into the app ones
+		if err := app.settings.Merge(storedSettings); err != nil {
+			return err
+		}
 	}
 
-	// load the settings from the stored param into the app ones
-	if err := app.settings.Merge(storedSettings); err != nil {
+	// apply the config file and env variables overrides (if any)
+	if err := app.applySettingsOverrides(); err != nil {
 		return err
 	}
 
+	// resolve the external secret references (if any)
+	if err := app.settings.ResolveSecrets(context.Background(), app.secrets); err != nil && app.Logger() != nil {
+		app.Logger().Error("Failed to resolve the settings secret references", slog.String("error", err.Error()))
+	}
+
 	// reload handler level (if initialized)
 	if app.Logger() != nil {
 		if h, ok := app.Logger().Handler().(*logger.BatchHandler); ok {
@@ -606,6 +931,56 @@ func (app *BaseApp) RefreshSettings() error {
 		}
 	}
 
+	if err := app.refreshOutboundTransport(); err != nil {
+		return err
+	}
+
+	if err := app.refreshTracer(); err != nil {
+		return err
+	}
+
+	if err := app.refreshRealtimeCluster(); err != nil {
+		return err
+	}
+
+	return app.refreshRecordsCache()
+}
+
+// refreshOutboundTransport reloads the shared outbound http transports
+// with the current app settings.
+func (app *BaseApp) refreshOutboundTransport() error {
+	config := app.settings.Outbound
+	transportConfig := config.TransportConfig()
+
+	if transportConfig == (transport.Config{}) {
+		// fallback to the default http transport
+		transport.Default.Set(&tracing.Transport{Tracer: app.tracer})
+	} else {
+		t, err := transport.New(transportConfig)
+		if err != nil {
+			return err
+		}
+
+		if config.TLSInsecureSkipVerify && app.Logger() != nil {
+			app.Logger().Warn("The outbound requests TLS certificates verification is disabled (settings.outbound.tlsInsecureSkipVerify)!")
+		}
+
+		transport.Default.Set(&tracing.Transport{Base: t, Tracer: app.tracer})
+	}
+
+	policy := config.Scripts.Policy()
+	if policy.IsZero() {
+		transport.Scripts.Set(transport.Default)
+		return nil
+	}
+
+	t, err := transport.NewWithPolicy(transportConfig, policy)
+	if err != nil {
+		return err
+	}
+
+	transport.Scripts.Set(&tracing.Transport{Base: t, Tracer: app.tracer})
+
 	return nil
 }
 
@@ -625,6 +1000,10 @@ func (app *BaseApp) OnBeforeServe() *hook.Hook[*ServeEvent] {
 	return app.onBeforeServe
 }
 
+func (app *BaseApp) OnPreflight() *hook.Hook[*PreflightEvent] {
+	return app.onPreflight
+}
+
 func (app *BaseApp) OnBeforeApiError() *hook.Hook[*ApiErrorEvent] {
 	return app.onBeforeApiError
 }
@@ -637,6 +1016,14 @@ func (app *BaseApp) OnTerminate() *hook.Hook[*TerminateEvent] {
 	return app.onTerminate
 }
 
+func (app *BaseApp) OnBackupCreate() *hook.Hook[*BackupEvent] {
+	return app.onBackupCreate
+}
+
+func (app *BaseApp) OnBackupRestore() *hook.Hook[*BackupEvent] {
+	return app.onBackupRestore
+}
+
 // -------------------------------------------------------------------
 // Dao event hooks
 // -------------------------------------------------------------------
@@ -761,6 +1148,10 @@ func (app *BaseApp) OnFileAfterTokenRequest(tags ...string) *hook.TaggedHook[*Fi
 	return hook.NewTaggedHook(app.onFileAfterTokenRequest, tags...)
 }
 
+func (app *BaseApp) OnRecordFileScan(tags ...string) *hook.TaggedHook[*RecordFileScanEvent] {
+	return hook.NewTaggedHook(app.onRecordFileScan, tags...)
+}
+
 // -------------------------------------------------------------------
 // Admin API event hooks
 // -------------------------------------------------------------------
@@ -833,6 +1224,30 @@ func (app *BaseApp) OnAdminAfterConfirmPasswordResetRequest() *hook.Hook[*AdminC
 	return app.onAdminAfterConfirmPasswordResetRequest
 }
 
+func (app *BaseApp) OnAdminUIConfigRequest() *hook.Hook[*AdminUIConfigEvent] {
+	return app.onAdminUIConfigRequest
+}
+
+// -------------------------------------------------------------------
+// Auth sessions event hooks
+// -------------------------------------------------------------------
+
+func (app *BaseApp) OnSessionCreate() *hook.Hook[*SessionEvent] {
+	return app.onSessionCreate
+}
+
+func (app *BaseApp) OnSessionRevoke() *hook.Hook[*SessionEvent] {
+	return app.onSessionRevoke
+}
+
+// -------------------------------------------------------------------
+// Signature requests event hooks
+// -------------------------------------------------------------------
+
+func (app *BaseApp) OnSignatureComplete(tags ...string) *hook.TaggedHook[*SignatureCompleteEvent] {
+	return hook.NewTaggedHook(app.onSignatureComplete, tags...)
+}
+
 // -------------------------------------------------------------------
 // Record auth API event hooks
 // -------------------------------------------------------------------
@@ -857,6 +1272,10 @@ func (app *BaseApp) OnRecordAfterAuthWithOAuth2Request(tags ...string) *hook.Tag
 	return hook.NewTaggedHook(app.onRecordAfterAuthWithOAuth2Request, tags...)
 }
 
+func (app *BaseApp) OnRecordAuthWithCustomMethod(tags ...string) *hook.TaggedHook[*RecordAuthWithCustomMethodEvent] {
+	return hook.NewTaggedHook(app.onRecordAuthWithCustomMethod, tags...)
+}
+
 func (app *BaseApp) OnRecordBeforeAuthRefreshRequest(tags ...string) *hook.TaggedHook[*RecordAuthRefreshEvent] {
 	return hook.NewTaggedHook(app.onRecordBeforeAuthRefreshRequest, tags...)
 }
@@ -913,6 +1332,22 @@ func (app *BaseApp) OnRecordAfterConfirmEmailChangeRequest(tags ...string) *hook
 	return hook.NewTaggedHook(app.onRecordAfterConfirmEmailChangeRequest, tags...)
 }
 
+func (app *BaseApp) OnRecordBeforeRequestOTPRequest(tags ...string) *hook.TaggedHook[*RecordRequestOTPEvent] {
+	return hook.NewTaggedHook(app.onRecordBeforeRequestOTPRequest, tags...)
+}
+
+func (app *BaseApp) OnRecordAfterRequestOTPRequest(tags ...string) *hook.TaggedHook[*RecordRequestOTPEvent] {
+	return hook.NewTaggedHook(app.onRecordAfterRequestOTPRequest, tags...)
+}
+
+func (app *BaseApp) OnRecordBeforeConfirmOTPRequest(tags ...string) *hook.TaggedHook[*RecordConfirmOTPEvent] {
+	return hook.NewTaggedHook(app.onRecordBeforeConfirmOTPRequest, tags...)
+}
+
+func (app *BaseApp) OnRecordAfterConfirmOTPRequest(tags ...string) *hook.TaggedHook[*RecordConfirmOTPEvent] {
+	return hook.NewTaggedHook(app.onRecordAfterConfirmOTPRequest, tags...)
+}
+
 func (app *BaseApp) OnRecordListExternalAuthsRequest(tags ...string) *hook.TaggedHook[*RecordListExternalAuthsEvent] {
 	return hook.NewTaggedHook(app.onRecordListExternalAuthsRequest, tags...)
 }
@@ -961,6 +1396,10 @@ func (app *BaseApp) OnRecordAfterDeleteRequest(tags ...string) *hook.TaggedHook[
 	return hook.NewTaggedHook(app.onRecordAfterDeleteRequest, tags...)
 }
 
+func (app *BaseApp) OnSyncConflict(tags ...string) *hook.TaggedHook[*SyncConflictEvent] {
+	return hook.NewTaggedHook(app.onSyncConflict, tags...)
+}
+
 // -------------------------------------------------------------------
 // Collection API event hooks
 // -------------------------------------------------------------------
@@ -1066,16 +1505,23 @@ func (app *BaseApp) initDataDB() error {
 	nonconcurrentDB.DB().SetMaxIdleConns(1)
 	nonconcurrentDB.DB().SetConnMaxIdleTime(3 * time.Minute)
 
-	if app.IsDev() {
-		nonconcurrentDB.QueryLogFunc = func(ctx context.Context, t time.Duration, sql string, rows *sql.Rows, err error) {
+	isDev := app.IsDev()
+	nonconcurrentDB.QueryLogFunc = func(ctx context.Context, t time.Duration, sql string, rows *sql.Rows, err error) {
+		if isDev {
 			color.HiBlack("[%.2fms] %v\n", float64(t.Milliseconds()), sql)
 		}
-		nonconcurrentDB.ExecLogFunc = func(ctx context.Context, t time.Duration, sql string, result sql.Result, err error) {
+		app.traceQuery(ctx, t, sql, err)
+		app.captureSlowQuery(t, sql)
+	}
+	nonconcurrentDB.ExecLogFunc = func(ctx context.Context, t time.Duration, sql string, result sql.Result, err error) {
+		if isDev {
 			color.HiBlack("[%.2fms] %v\n", float64(t.Milliseconds()), sql)
 		}
-		concurrentDB.QueryLogFunc = nonconcurrentDB.QueryLogFunc
-		concurrentDB.ExecLogFunc = nonconcurrentDB.ExecLogFunc
+		app.traceQuery(ctx, t, sql, err)
+		app.captureSlowQuery(t, sql)
 	}
+	concurrentDB.QueryLogFunc = nonconcurrentDB.QueryLogFunc
+	concurrentDB.ExecLogFunc = nonconcurrentDB.ExecLogFunc
 
 	app.dao = app.createDaoWithHooks(concurrentDB, nonconcurrentDB)
 
@@ -1139,6 +1585,8 @@ func (app *BaseApp) createDaoWithHooks(concurrentDB, nonconcurrentDB dbx.Builder
 		return app.OnModelAfterDelete().Trigger(e)
 	}
 
+	dao.AfterCommitFunc = app.invalidateRecordsCache
+
 	return dao
 }
 
@@ -1183,6 +1631,12 @@ func (app *BaseApp) registerDefaultHooks() {
 		app.Logger().Error("Failed to init auto backup hooks", slog.String("error", err.Error()))
 	}
 
+	app.initSecretsRotationHooks()
+	app.initSettingsFileWatcher()
+	app.initTracingHooks()
+	app.initRealtimeClusterHooks()
+	app.initRecordsCacheHooks()
+
 	registerCachedCollectionsAppHooks(app)
 }
 
@@ -1212,21 +1666,43 @@ func (app *BaseApp) initLogger() error {
 	ticker := time.NewTicker(duration)
 	done := make(chan bool)
 
+	logsDir := filepath.Join(app.DataDir(), LocalLogsDirName)
+	requestsFile := logger.NewRotatingFile(filepath.Join(logsDir, "requests.log"), logger.RotatingFileOptions{})
+	appFile := logger.NewRotatingFile(filepath.Join(logsDir, "app.log"), logger.RotatingFileOptions{})
+	sinks := &logSinks{app: app}
+
 	handler := logger.NewBatchHandler(logger.BatchOptions{
 		Level:     app.getLoggerMinLevel(),
 		BatchSize: 200,
+		ModuleLevel: func(module string) (slog.Level, bool) {
+			// in dev mode all logs are printed and the levels are checked in BeforeAddFunc
+			if app.IsDev() || app.Settings() == nil {
+				return 0, false
+			}
+
+			level, ok := app.Settings().Logs.ModuleLevel(module)
+
+			return slog.Level(level), ok
+		},
 		BeforeAddFunc: func(ctx context.Context, log *logger.Log) bool {
 			if app.IsDev() {
 				printLog(log)
 
 				// manually check the log level and skip if necessary
-				if log.Level < slog.Level(app.Settings().Logs.MinLevel) {
+				minLevel := app.Settings().Logs.MinLevel
+				if moduleLevel, ok := app.Settings().Logs.ModuleLevel(cast.ToString(log.Data[logger.ModuleAttrKey])); ok {
+					minLevel = moduleLevel
+				}
+				if log.Level < slog.Level(minLevel) {
 					return false
 				}
 			}
 
 			ticker.Reset(duration)
 
+			// notify the live logs subscribers (if any)
+			app.Bus().Publish(LogsBusTopic, log)
+
 			return app.Settings().Logs.MaxDays > 0
 		},
 		WriteFunc: func(ctx context.Context, logs []*logger.Log) error {
@@ -1234,27 +1710,70 @@ func (app *BaseApp) initLogger() error {
 				return nil
 			}
 
-			// write the accumulated logs
-			// (note: based on several local tests there is no significant performance difference between small number of separate write queries vs 1 big INSERT)
-			app.LogsDao().RunInTransaction(func(txDao *daos.Dao) error {
-				model := &models.Log{}
-				for _, l := range logs {
-					model.MarkAsNew()
-					// note: using pseudorandom for a slightly better performance
-					model.Id = security.PseudorandomStringWithAlphabet(models.DefaultIdLength, models.DefaultIdAlphabet)
-					model.Level = int(l.Level)
-					model.Message = l.Message
-					model.Data = l.Data
-					model.Created, _ = types.ParseDateTime(l.Time)
-					model.Updated = model.Created
-
-					if err := txDao.SaveLog(model); err != nil {
-						log.Println("Failed to write log", model, err)
+			logsSettings := app.Settings().Logs
+
+			fileOptions := logger.RotatingFileOptions{
+				MaxSize:    int64(logsSettings.FileMaxSize) * 1024 * 1024,
+				MaxBackups: logsSettings.FileMaxBackups,
+				MaxAge:     time.Duration(logsSettings.MaxDays) * 24 * time.Hour,
+			}
+			requestsFile.SetOptions(fileOptions)
+			appFile.SetOptions(fileOptions)
+
+			dbLogs := make([]*logger.Log, 0, len(logs))
+
+			for _, l := range logs {
+				isRequest := l.Data["type"] == "request"
+
+				if logsSettings.HasFileTarget(isRequest) {
+					file := appFile
+					if isRequest {
+						file = requestsFile
+					}
+
+					if err := writeLogLine(file, l); err != nil {
+						log.Println("Failed to write log file", file.Path(), err)
 					}
 				}
 
-				return nil
-			})
+				if logsSettings.HasDBTarget(isRequest) {
+					dbLogs = append(dbLogs, l)
+				}
+			}
+
+			// in dev mode the logs are already printed
+			stdoutFormat := app.logsStdout
+			if stdoutFormat == "" {
+				stdoutFormat = logsSettings.Stdout
+			}
+			if app.IsDev() {
+				stdoutFormat = ""
+			}
+			sinks.write(ctx, logsSettings, stdoutFormat, logs)
+
+			if len(dbLogs) > 0 {
+				// write the accumulated logs
+				// (note: based on several local tests there is no significant performance difference between small number of separate write queries vs 1 big INSERT)
+				app.LogsDao().RunInTransaction(func(txDao *daos.Dao) error {
+					model := &models.Log{}
+					for _, l := range dbLogs {
+						model.MarkAsNew()
+						// note: using pseudorandom for a slightly better performance
+						model.Id = security.PseudorandomStringWithAlphabet(models.DefaultIdLength, models.DefaultIdAlphabet)
+						model.Level = int(l.Level)
+						model.Message = l.Message
+						model.Data = l.Data
+						model.Created, _ = types.ParseDateTime(l.Time)
+						model.Updated = model.Created
+
+						if err := txDao.SaveLog(model); err != nil {
+							log.Println("Failed to write log", model, err)
+						}
+					}
+
+					return nil
+				})
+			}
 
 			// delete old logs
 			// ---
@@ -1269,6 +1788,10 @@ func (app *BaseApp) initLogger() error {
 				} else {
 					log.Println("Logs delete failed", deleteErr)
 				}
+
+				if err := app.LogsDao().DeleteOldSlowQueries(now.AddDate(0, 0, -1*logsMaxDays)); err != nil {
+					log.Println("Slow queries delete failed", err)
+				}
 			}
 
 			return nil
@@ -1298,8 +1821,24 @@ func (app *BaseApp) initLogger() error {
 
 		done <- true
 
+		requestsFile.Close()
+		appFile.Close()
+		sinks.close()
+
 		return nil
 	})
 
 	return nil
 }
+
+// writeLogLine writes the provided log as single JSON line into w.
+func writeLogLine(w io.Writer, l *logger.Log) error {
+	raw, err := l.MarshalJSONLine()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(raw, '\n'))
+
+	return err
+}
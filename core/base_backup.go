@@ -20,6 +20,8 @@ import (
 	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/osutils"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"gocloud.dev/blob"
 )
 
 // Deprecated: Replaced with StoreKeyActiveBackup.
@@ -27,6 +29,11 @@ const CacheKeyActiveBackup string = "@activeBackup"
 
 const StoreKeyActiveBackup string = "@activeBackup"
 
+const (
+	autoBackupPrefix            = "@auto_pb_backup_"
+	autoIncrementalBackupPrefix = autoBackupPrefix + "inc_"
+)
+
 // CreateBackup creates a new backup of the current app pb_data directory.
 //
 // If name is empty, it will be autogenerated.
@@ -46,6 +53,13 @@ const StoreKeyActiveBackup string = "@activeBackup"
 //
 // Backups can be stored on S3 if it is configured in app.Settings().Backups.
 func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
+	return app.createBackup(ctx, name, "")
+}
+
+// createBackup creates a new full app backup or, if baseName is set,
+// an incremental backup containing only the pb_data files changed
+// since the creation of the baseName backup (see [settings.BackupsConfig.CronIncremental]).
+func (app *BaseApp) createBackup(ctx context.Context, name string, baseName string) error {
 	if app.Store().Has(StoreKeyActiveBackup) {
 		return errors.New("try again later - another backup/restore operation has already been started")
 	}
@@ -57,52 +71,66 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 	app.Store().Set(StoreKeyActiveBackup, name)
 	defer app.Store().Remove(StoreKeyActiveBackup)
 
-	// root dir entries to exclude from the backup generation
-	exclude := []string{LocalBackupsDirName, LocalTempDirName}
-
-	// make sure that the special temp directory exists
-	// note: it needs to be inside the current pb_data to avoid "cross-device link" errors
-	localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
-	if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
-		return fmt.Errorf("failed to create a temp dir: %w", err)
+	event := &BackupEvent{
+		App:     app,
+		Context: ctx,
+		Name:    name,
+		// root dir entries to exclude from the backup generation
+		Exclude: []string{LocalBackupsDirName, LocalTempDirName, LocalLogsDirName, ServePidFileName},
 	}
 
-	// Archive pb_data in a temp directory, exluding the "backups" and the temp dirs.
-	//
-	// Run in transaction to temporary block other writes (transactions uses the NonconcurrentDB connection).
-	// ---
-	tempPath := filepath.Join(localTempDir, "pb_backup_"+security.PseudorandomString(4))
-	createErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
-		// @todo consider experimenting with temp switching the readonly pragma after the db interface change
-		return archive.Create(app.DataDir(), tempPath, exclude...)
-	})
-	if createErr != nil {
-		return createErr
-	}
-	defer os.Remove(tempPath)
+	return app.OnBackupCreate().Trigger(event, func(e *BackupEvent) error {
+		// in case the name was changed by a hook handler
+		app.Store().Set(StoreKeyActiveBackup, e.Name)
 
-	// Persist the backup in the backups filesystem.
-	// ---
-	fsys, err := app.NewBackupsFilesystem()
-	if err != nil {
-		return err
-	}
-	defer fsys.Close()
+		// make sure that the special temp directory exists
+		// note: it needs to be inside the current pb_data to avoid "cross-device link" errors
+		localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
+		if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create a temp dir: %w", err)
+		}
 
-	fsys.SetContext(ctx)
+		// Archive pb_data in a temp directory, exluding the "backups" and the temp dirs.
+		//
+		// Run in transaction to temporary block other writes (transactions uses the NonconcurrentDB connection).
+		// ---
+		tempPath := filepath.Join(localTempDir, "pb_backup_"+security.PseudorandomString(4))
+		createErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+			// @todo consider experimenting with temp switching the readonly pragma after the db interface change
+			if baseName != "" {
+				return createIncrementalBackupArchive(app.DataDir(), tempPath, baseName, e.Exclude)
+			}
 
-	file, err := filesystem.NewFileFromPath(tempPath)
-	if err != nil {
-		return err
-	}
-	file.OriginalName = name
-	file.Name = file.OriginalName
+			return archive.Create(app.DataDir(), tempPath, e.Exclude...)
+		})
+		if createErr != nil {
+			return createErr
+		}
+		defer os.Remove(tempPath)
 
-	if err := fsys.UploadFile(file, file.Name); err != nil {
-		return err
-	}
+		// Persist the backup in the backups filesystem.
+		// ---
+		fsys, err := app.NewBackupsFilesystem()
+		if err != nil {
+			return err
+		}
+		defer fsys.Close()
 
-	return nil
+		fsys.SetContext(ctx)
+
+		file, err := filesystem.NewFileFromPath(tempPath)
+		if err != nil {
+			return err
+		}
+		file.OriginalName = e.Name
+		file.Name = file.OriginalName
+
+		if err := fsys.UploadFile(file, file.Name); err != nil {
+			return err
+		}
+
+		return nil
+	})
 }
 
 // RestoreBackup restores the backup with the specified name and restarts
@@ -145,108 +173,130 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 	app.Store().Set(StoreKeyActiveBackup, name)
 	defer app.Store().Remove(StoreKeyActiveBackup)
 
-	fsys, err := app.NewBackupsFilesystem()
-	if err != nil {
-		return err
+	// the app is not ready during the restore
+	// (on success the app is restarted)
+	if app.Store().Has(StoreKeyReady) {
+		app.Store().Remove(StoreKeyReady)
+		defer app.Store().Set(StoreKeyReady, true)
 	}
-	defer fsys.Close()
 
-	fsys.SetContext(ctx)
-
-	// fetch the backup file in a temp location
-	br, err := fsys.GetFile(name)
-	if err != nil {
-		return err
+	event := &BackupEvent{
+		App:     app,
+		Context: ctx,
+		Name:    name,
+		// root dir entries to exclude from the backup restore
+		Exclude: []string{LocalBackupsDirName, LocalTempDirName, LocalLogsDirName, ServePidFileName},
 	}
-	defer br.Close()
 
-	// make sure that the special temp directory exists
-	// note: it needs to be inside the current pb_data to avoid "cross-device link" errors
-	localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
-	if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
-		return fmt.Errorf("failed to create a temp dir: %w", err)
-	}
+	return app.OnBackupRestore().Trigger(event, func(e *BackupEvent) error {
+		// in case the name was changed by a hook handler
+		app.Store().Set(StoreKeyActiveBackup, e.Name)
 
-	// create a temp zip file from the blob.Reader and try to extract it
-	tempZip, err := os.CreateTemp(localTempDir, "pb_restore_zip")
-	if err != nil {
-		return err
-	}
-	defer os.Remove(tempZip.Name())
+		fsys, err := app.NewBackupsFilesystem()
+		if err != nil {
+			return err
+		}
+		defer fsys.Close()
 
-	if _, err := io.Copy(tempZip, br); err != nil {
-		return err
-	}
+		fsys.SetContext(ctx)
 
-	extractedDataDir := filepath.Join(localTempDir, "pb_restore_"+security.PseudorandomString(4))
-	defer os.RemoveAll(extractedDataDir)
-	if err := archive.Extract(tempZip.Name(), extractedDataDir); err != nil {
-		return err
-	}
+		// make sure that the special temp directory exists
+		// note: it needs to be inside the current pb_data to avoid "cross-device link" errors
+		localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
+		if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create a temp dir: %w", err)
+		}
 
-	// ensure that a database file exists
-	extractedDB := filepath.Join(extractedDataDir, "data.db")
-	if _, err := os.Stat(extractedDB); err != nil {
-		return fmt.Errorf("data.db file is missing or invalid: %w", err)
-	}
+		extractedDataDir := filepath.Join(localTempDir, "pb_restore_"+security.PseudorandomString(4))
+		defer os.RemoveAll(extractedDataDir)
+		if err := extractBackup(fsys, localTempDir, e.Name, extractedDataDir); err != nil {
+			return err
+		}
 
-	// remove the extracted zip file since we no longer need it
-	// (this is in case the app restarts and the defer calls are not called)
-	if err := os.Remove(tempZip.Name()); err != nil {
-		app.Logger().Debug(
-			"[RestoreBackup] Failed to remove the temp zip backup file",
-			slog.String("file", tempZip.Name()),
-			slog.String("error", err.Error()),
-		)
-	}
+		// merge with the base backup (if it is an incremental one)
+		if err := applyIncrementalBackup(fsys, localTempDir, extractedDataDir); err != nil {
+			return fmt.Errorf("failed to apply the incremental backup: %w", err)
+		}
+
+		// ensure that a database file exists
+		extractedDB := filepath.Join(extractedDataDir, "data.db")
+		if _, err := os.Stat(extractedDB); err != nil {
+			return fmt.Errorf("data.db file is missing or invalid: %w", err)
+		}
 
-	// root dir entries to exclude from the backup restore
-	exclude := []string{LocalBackupsDirName, LocalTempDirName}
+		// move the current pb_data content to a special temp location
+		// that will hold the old data between dirs replace
+		// (the temp dir will be automatically removed on the next app start)
+		oldTempDataDir := filepath.Join(localTempDir, "old_pb_data_"+security.PseudorandomString(4))
+		if err := osutils.MoveDirContent(app.DataDir(), oldTempDataDir, e.Exclude...); err != nil {
+			return fmt.Errorf("failed to move the current pb_data content to a temp location: %w", err)
+		}
 
-	// move the current pb_data content to a special temp location
-	// that will hold the old data between dirs replace
-	// (the temp dir will be automatically removed on the next app start)
-	oldTempDataDir := filepath.Join(localTempDir, "old_pb_data_"+security.PseudorandomString(4))
-	if err := osutils.MoveDirContent(app.DataDir(), oldTempDataDir, exclude...); err != nil {
-		return fmt.Errorf("failed to move the current pb_data content to a temp location: %w", err)
-	}
+		// move the extracted archive content to the app's pb_data
+		if err := osutils.MoveDirContent(extractedDataDir, app.DataDir(), e.Exclude...); err != nil {
+			return fmt.Errorf("failed to move the extracted archive content to pb_data: %w", err)
+		}
 
-	// move the extracted archive content to the app's pb_data
-	if err := osutils.MoveDirContent(extractedDataDir, app.DataDir(), exclude...); err != nil {
-		return fmt.Errorf("failed to move the extracted archive content to pb_data: %w", err)
-	}
+		revertDataDirChanges := func() error {
+			if err := osutils.MoveDirContent(app.DataDir(), extractedDataDir, e.Exclude...); err != nil {
+				return fmt.Errorf("failed to revert the extracted dir change: %w", err)
+			}
 
-	revertDataDirChanges := func() error {
-		if err := osutils.MoveDirContent(app.DataDir(), extractedDataDir, exclude...); err != nil {
-			return fmt.Errorf("failed to revert the extracted dir change: %w", err)
+			if err := osutils.MoveDirContent(oldTempDataDir, app.DataDir(), e.Exclude...); err != nil {
+				return fmt.Errorf("failed to revert old pb_data dir change: %w", err)
+			}
+
+			return nil
 		}
 
-		if err := osutils.MoveDirContent(oldTempDataDir, app.DataDir(), exclude...); err != nil {
-			return fmt.Errorf("failed to revert old pb_data dir change: %w", err)
+		// restart the app
+		if err := app.Restart(); err != nil {
+			if revertErr := revertDataDirChanges(); revertErr != nil {
+				panic(revertErr)
+			}
+
+			return fmt.Errorf("failed to restart the app process: %w", err)
 		}
 
 		return nil
+	})
+}
+
+// extractBackup downloads the named backup in a temp location
+// and extracts its content in the dest directory.
+func extractBackup(fsys *filesystem.System, localTempDir string, name string, dest string) error {
+	// fetch the backup file in a temp location
+	br, err := fsys.GetFile(name)
+	if err != nil {
+		return err
 	}
+	defer br.Close()
 
-	// restart the app
-	if err := app.Restart(); err != nil {
-		if revertErr := revertDataDirChanges(); revertErr != nil {
-			panic(revertErr)
-		}
+	// create a temp zip file from the blob.Reader and try to extract it
+	tempZip, err := os.CreateTemp(localTempDir, "pb_restore_zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempZip.Name())
+	defer tempZip.Close()
 
-		return fmt.Errorf("failed to restart the app process: %w", err)
+	if _, err := io.Copy(tempZip, br); err != nil {
+		return err
 	}
 
-	return nil
+	return archive.Extract(tempZip.Name(), dest)
 }
 
 // initAutobackupHooks registers the autobackup app serve hooks.
 func (app *BaseApp) initAutobackupHooks() error {
 	c := cron.New()
+	c.SetLocker(app)
+	app.Store().Set(StoreKeyCronPrefix+"autobackup", c)
 	isServe := false
 
 	loadJob := func() {
 		c.Stop()
+		c.RemoveAll()
 
 		// make sure that app.Settings() is always up to date
 		//
@@ -263,66 +313,7 @@ func (app *BaseApp) initAutobackupHooks() error {
 			return
 		}
 
-		c.Add("@autobackup", rawSchedule, func() {
-			const autoPrefix = "@auto_pb_backup_"
-
-			name := app.generateBackupName(autoPrefix)
-
-			if err := app.CreateBackup(context.Background(), name); err != nil {
-				app.Logger().Debug(
-					"[Backup cron] Failed to create backup",
-					slog.String("name", name),
-					slog.String("error", err.Error()),
-				)
-			}
-
-			maxKeep := app.Settings().Backups.CronMaxKeep
-
-			if maxKeep == 0 {
-				return // no explicit limit
-			}
-
-			fsys, err := app.NewBackupsFilesystem()
-			if err != nil {
-				app.Logger().Debug(
-					"[Backup cron] Failed to initialize the backup filesystem",
-					slog.String("error", err.Error()),
-				)
-				return
-			}
-			defer fsys.Close()
-
-			files, err := fsys.List(autoPrefix)
-			if err != nil {
-				app.Logger().Debug(
-					"[Backup cron] Failed to list autogenerated backups",
-					slog.String("error", err.Error()),
-				)
-				return
-			}
-
-			if maxKeep >= len(files) {
-				return // nothing to remove
-			}
-
-			// sort desc
-			sort.Slice(files, func(i, j int) bool {
-				return files[i].ModTime.After(files[j].ModTime)
-			})
-
-			// keep only the most recent n auto backup files
-			toRemove := files[maxKeep:]
-
-			for _, f := range toRemove {
-				if err := fsys.Delete(f.Key); err != nil {
-					app.Logger().Debug(
-						"[Backup cron] Failed to remove old autogenerated backup",
-						slog.String("key", f.Key),
-						slog.String("error", err.Error()),
-					)
-				}
-			}
-		})
+		c.Add("@autobackup", rawSchedule, app.runAutoBackup)
 
 		// restart the ticker
 		c.Start()
@@ -335,9 +326,10 @@ func (app *BaseApp) initAutobackupHooks() error {
 		return nil
 	})
 
-	// stop the ticker on app termination
+	// stop the ticker and wait for the running backup (if any) on app termination
 	app.OnTerminate().Add(func(e *TerminateEvent) error {
 		c.Stop()
+		c.Wait()
 		return nil
 	})
 
@@ -356,6 +348,147 @@ func (app *BaseApp) initAutobackupHooks() error {
 	return nil
 }
 
+// runAutoBackup creates a new cron backup and removes the old
+// auto backups that are not covered by the retention rules.
+func (app *BaseApp) runAutoBackup() {
+	name := app.generateBackupName(autoBackupPrefix)
+
+	var baseName string
+	if app.Settings().Backups.CronIncremental {
+		baseName = app.findIncrementalBackupBase()
+		if baseName != "" {
+			name = app.generateBackupName(autoIncrementalBackupPrefix)
+		}
+	}
+
+	if err := app.createBackup(context.Background(), name, baseName); err != nil {
+		app.Logger().Debug(
+			"[Backup cron] Failed to create backup",
+			slog.String("name", name),
+			slog.String("error", err.Error()),
+		)
+
+		notifyErr := app.Dao().RaiseAdminNotification(&models.AdminNotification{
+			Severity: models.AdminNotificationSeverityError,
+			Source:   "backups",
+			Title:    "Failed to create auto backup",
+			Body:     err.Error(),
+			Data:     types.JsonMap{"name": name},
+		})
+		if notifyErr != nil {
+			app.Logger().Debug(
+				"[Backup cron] Failed to raise admin notification",
+				slog.String("error", notifyErr.Error()),
+			)
+		}
+	}
+
+	maxKeep := app.Settings().Backups.CronMaxKeep
+
+	if maxKeep == 0 {
+		return // no explicit limit
+	}
+
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		app.Logger().Debug(
+			"[Backup cron] Failed to initialize the backup filesystem",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	defer fsys.Close()
+
+	files, err := fsys.List(autoBackupPrefix)
+	if err != nil {
+		app.Logger().Debug(
+			"[Backup cron] Failed to list autogenerated backups",
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	toRemove := autoBackupsToRemove(
+		files,
+		maxKeep,
+		app.Settings().Backups.CronKeepDaily,
+		app.Settings().Backups.CronKeepWeekly,
+	)
+
+	for _, f := range toRemove {
+		if err := fsys.Delete(f.Key); err != nil {
+			app.Logger().Debug(
+				"[Backup cron] Failed to remove old autogenerated backup",
+				slog.String("key", f.Key),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// autoBackupsToRemove returns the auto backup files that are not
+// covered by any of the specified retention rules:
+//   - the maxKeep most recent backups
+//   - the most recent backup of each of the last keepDaily days
+//   - the most recent backup of each of the last keepWeekly ISO weeks
+//
+// The base full backups of the kept incremental backups are also preserved.
+func autoBackupsToRemove(files []*blob.ListObject, maxKeep int, keepDaily int, keepWeekly int) []*blob.ListObject {
+	sorted := make([]*blob.ListObject, len(files))
+	copy(sorted, files)
+
+	// sort desc
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ModTime.After(sorted[j].ModTime)
+	})
+
+	keep := make(map[string]struct{}, len(sorted))
+	days := map[string]struct{}{}
+	weeks := map[string]struct{}{}
+
+	for i, f := range sorted {
+		if i < maxKeep {
+			keep[f.Key] = struct{}{}
+		}
+
+		day := f.ModTime.UTC().Format("2006-01-02")
+		if _, ok := days[day]; !ok && len(days) < keepDaily {
+			days[day] = struct{}{}
+			keep[f.Key] = struct{}{}
+		}
+
+		year, w := f.ModTime.UTC().ISOWeek()
+		week := fmt.Sprintf("%d-%d", year, w)
+		if _, ok := weeks[week]; !ok && len(weeks) < keepWeekly {
+			weeks[week] = struct{}{}
+			keep[f.Key] = struct{}{}
+		}
+	}
+
+	// preserve the base (aka. the nearest older full backup) of each kept incremental backup
+	for i, f := range sorted {
+		if _, ok := keep[f.Key]; !ok || !isIncrementalAutoBackup(f.Key) {
+			continue
+		}
+
+		for _, older := range sorted[i+1:] {
+			if !isIncrementalAutoBackup(older.Key) {
+				keep[older.Key] = struct{}{}
+				break
+			}
+		}
+	}
+
+	result := make([]*blob.ListObject, 0, len(sorted))
+	for _, f := range sorted {
+		if _, ok := keep[f.Key]; !ok {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
 func (app *BaseApp) generateBackupName(prefix string) string {
 	appName := inflector.Snakecase(app.Settings().Meta.AppName)
 	if len(appName) > 50 {
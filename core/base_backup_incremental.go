@@ -0,0 +1,241 @@
+package core
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/archive"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"gocloud.dev/blob"
+)
+
+// incrementalBackupManifestName is the name of the incremental backup
+// archive entry that holds the [incrementalBackupManifest] data.
+const incrementalBackupManifestName = ".pb_backup_manifest.json"
+
+// incrementalBackupBaseMaxAge is the max age of a full auto backup
+// that could be used as base for new incremental backups.
+const incrementalBackupBaseMaxAge = 7 * 24 * time.Hour
+
+// incrementalBackupManifest describes the content of an incremental backup.
+type incrementalBackupManifest struct {
+	// Base is the name of the full backup the incremental backup was created from.
+	Base string `json:"base"`
+
+	// Files is the list with all pb_data files at the time of the
+	// incremental backup creation (including the unchanged ones).
+	Files []string `json:"files"`
+}
+
+// isIncrementalAutoBackup reports whether the provided
+// backup key is of an incremental auto backup.
+func isIncrementalAutoBackup(key string) bool {
+	return strings.HasPrefix(filepath.Base(key), autoIncrementalBackupPrefix)
+}
+
+// parseBackupNameTime extracts the creation time from
+// a generated backup name (see [BaseApp.generateBackupName]).
+func parseBackupNameTime(name string) (time.Time, error) {
+	name = strings.TrimSuffix(filepath.Base(name), ".zip")
+
+	const layout = "20060102150405"
+
+	if len(name) < len(layout) {
+		return time.Time{}, errors.New("missing backup name timestamp")
+	}
+
+	return time.Parse(layout, name[len(name)-len(layout):])
+}
+
+// findIncrementalBackupBase returns the name of the latest full auto backup
+// that could be used as base for a new incremental backup.
+//
+// Returns an empty string if there is no such backup.
+func (app *BaseApp) findIncrementalBackupBase() string {
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		app.Logger().Debug(
+			"[Backup cron] Failed to initialize the backup filesystem",
+			slog.String("error", err.Error()),
+		)
+		return ""
+	}
+	defer fsys.Close()
+
+	files, err := fsys.List(autoBackupPrefix)
+	if err != nil {
+		app.Logger().Debug(
+			"[Backup cron] Failed to list autogenerated backups",
+			slog.String("error", err.Error()),
+		)
+		return ""
+	}
+
+	return latestIncrementalBackupBase(files, time.Now())
+}
+
+// latestIncrementalBackupBase returns the key of the most recent full
+// auto backup that is not older than [incrementalBackupBaseMaxAge].
+func latestIncrementalBackupBase(files []*blob.ListObject, now time.Time) string {
+	var base string
+	var baseCreated time.Time
+
+	for _, f := range files {
+		if isIncrementalAutoBackup(f.Key) {
+			continue
+		}
+
+		created, err := parseBackupNameTime(f.Key)
+		if err != nil || now.Sub(created) > incrementalBackupBaseMaxAge {
+			continue
+		}
+
+		if created.After(baseCreated) {
+			base = f.Key
+			baseCreated = created
+		}
+	}
+
+	return base
+}
+
+// createIncrementalBackupArchive creates a new zip archive in dest with the
+// src files modified after the baseName backup creation and a manifest file
+// listing all src files (so that deleted files could be detected on restore).
+func createIncrementalBackupArchive(src string, dest string, baseName string, exclude []string) error {
+	since, err := parseBackupNameTime(baseName)
+	if err != nil {
+		return fmt.Errorf("invalid incremental backup base %q: %w", baseName, err)
+	}
+
+	manifest := incrementalBackupManifest{Base: baseName}
+
+	return archive.CreateWithOptions(src, dest, archive.CreateOptions{
+		SkipPaths: exclude,
+		Filter: func(name string, info fs.FileInfo) bool {
+			manifest.Files = append(manifest.Files, name)
+
+			return !info.ModTime().Before(since)
+		},
+		After: func(w *zip.Writer) error {
+			raw, err := json.Marshal(manifest)
+			if err != nil {
+				return err
+			}
+
+			f, err := w.Create(incrementalBackupManifestName)
+			if err != nil {
+				return err
+			}
+
+			_, err = f.Write(raw)
+
+			return err
+		},
+	})
+}
+
+// applyIncrementalBackup merges the extracted incremental backup in
+// extractedDir with its base backup (the extractedDir content is replaced).
+//
+// It is a no-op if extractedDir doesn't contain an incremental backup manifest.
+func applyIncrementalBackup(fsys *filesystem.System, localTempDir string, extractedDir string) error {
+	manifestPath := filepath.Join(extractedDir, incrementalBackupManifestName)
+
+	raw, err := os.ReadFile(manifestPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil // regular full backup
+	}
+	if err != nil {
+		return err
+	}
+
+	manifest := incrementalBackupManifest{}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return fmt.Errorf("invalid incremental backup manifest: %w", err)
+	}
+
+	if manifest.Base == "" {
+		return errors.New("missing incremental backup base")
+	}
+
+	if err := os.Remove(manifestPath); err != nil {
+		return err
+	}
+
+	baseDir := filepath.Join(localTempDir, "pb_restore_base_"+security.PseudorandomString(4))
+	defer os.RemoveAll(baseDir)
+
+	if err := extractBackup(fsys, localTempDir, manifest.Base, baseDir); err != nil {
+		return fmt.Errorf("failed to extract the base backup %q: %w", manifest.Base, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(baseDir, incrementalBackupManifestName)); err == nil {
+		return fmt.Errorf("the base backup %q must be a full backup", manifest.Base)
+	}
+
+	// overwrite the base files with the changed ones
+	err = filepath.WalkDir(extractedDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(extractedDir, path)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(baseDir, rel)
+
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+
+		return os.Rename(path, target)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply the changed files: %w", err)
+	}
+
+	// remove the files that were deleted after the base backup creation
+	existing := make(map[string]struct{}, len(manifest.Files))
+	for _, name := range manifest.Files {
+		existing[filepath.FromSlash(name)] = struct{}{}
+	}
+
+	err = filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := existing[rel]; ok {
+			return nil
+		}
+
+		return os.Remove(path)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove the deleted files: %w", err)
+	}
+
+	// replace the extracted dir content with the merged one
+	if err := os.RemoveAll(extractedDir); err != nil {
+		return err
+	}
+
+	return os.Rename(baseDir, extractedDir)
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/archive"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"gocloud.dev/blob"
+)
+
+func TestAutoBackupsToRemove(t *testing.T) {
+	t.Parallel()
+
+	date := func(value string) time.Time {
+		d, err := time.Parse(time.DateTime, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	files := []*blob.ListObject{
+		{Key: "@auto_pb_backup_1.zip", ModTime: date("2024-01-01 10:00:00")}, // week 1
+		{Key: "@auto_pb_backup_2.zip", ModTime: date("2024-01-08 10:00:00")}, // week 2
+		{Key: "@auto_pb_backup_inc_3.zip", ModTime: date("2024-01-08 12:00:00")},
+		{Key: "@auto_pb_backup_4.zip", ModTime: date("2024-01-09 10:00:00")},
+		{Key: "@auto_pb_backup_inc_5.zip", ModTime: date("2024-01-09 11:00:00")},
+		{Key: "@auto_pb_backup_inc_6.zip", ModTime: date("2024-01-09 12:00:00")},
+	}
+
+	scenarios := []struct {
+		name       string
+		maxKeep    int
+		keepDaily  int
+		keepWeekly int
+		expected   []string
+	}{
+		{
+			"max keep only (with the incremental base)",
+			1, 0, 0,
+			[]string{
+				"@auto_pb_backup_inc_5.zip",
+				"@auto_pb_backup_inc_3.zip",
+				"@auto_pb_backup_2.zip",
+				"@auto_pb_backup_1.zip",
+			},
+		},
+		{
+			"max keep larger than the files",
+			10, 0, 0,
+			[]string{},
+		},
+		{
+			"max keep + daily",
+			1, 2, 0,
+			[]string{
+				"@auto_pb_backup_inc_5.zip",
+				"@auto_pb_backup_1.zip",
+			},
+		},
+		{
+			"max keep + weekly",
+			1, 0, 2,
+			[]string{
+				"@auto_pb_backup_inc_5.zip",
+				"@auto_pb_backup_inc_3.zip",
+				"@auto_pb_backup_2.zip",
+			},
+		},
+		{
+			"max keep + daily + weekly",
+			1, 1, 2,
+			[]string{
+				"@auto_pb_backup_inc_5.zip",
+				"@auto_pb_backup_inc_3.zip",
+				"@auto_pb_backup_2.zip",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := autoBackupsToRemove(files, s.maxKeep, s.keepDaily, s.keepWeekly)
+
+			keys := make([]string, len(result))
+			for i, f := range result {
+				keys[i] = f.Key
+			}
+
+			if strings.Join(keys, ",") != strings.Join(s.expected, ",") {
+				t.Fatalf("Expected to remove %v, got %v", s.expected, keys)
+			}
+		})
+	}
+}
+
+func TestLatestIncrementalBackupBase(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		name     string
+		keys     []string
+		expected string
+	}{
+		{
+			"no files",
+			nil,
+			"",
+		},
+		{
+			"only incremental and invalid files",
+			[]string{"@auto_pb_backup_inc_test_20240109000000.zip", "@auto_pb_backup_invalid.zip"},
+			"",
+		},
+		{
+			"too old full backup",
+			[]string{"@auto_pb_backup_test_20240101000000.zip"},
+			"",
+		},
+		{
+			"multiple full backups",
+			[]string{
+				"@auto_pb_backup_test_20240104000000.zip",
+				"@auto_pb_backup_test_20240106000000.zip",
+				"@auto_pb_backup_inc_test_20240108000000.zip",
+				"@auto_pb_backup_test_20240105000000.zip",
+			},
+			"@auto_pb_backup_test_20240106000000.zip",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			files := make([]*blob.ListObject, len(s.keys))
+			for i, key := range s.keys {
+				files[i] = &blob.ListObject{Key: key}
+			}
+
+			result := latestIncrementalBackupBase(files, now)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestIncrementalBackupCreateAndApply(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	srcDir := filepath.Join(dir, "src")
+	backupsDir := filepath.Join(dir, "backups")
+	tempDir := filepath.Join(dir, "temp")
+
+	writeFile := func(name string, content string) {
+		path := filepath.Join(srcDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("a", "a_old")
+	writeFile("b/c", "c_old")
+	writeFile("d", "d_old")
+
+	// mark the initial files as created before the base backup
+	oldTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, name := range []string{"a", "b/c", "d"} {
+		if err := os.Chtimes(filepath.Join(srcDir, name), oldTime, oldTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	baseName := "@auto_pb_backup_test_20200101000000.zip"
+	if err := archive.Create(srcDir, filepath.Join(backupsDir, baseName)); err != nil {
+		t.Fatal(err)
+	}
+
+	// changes after the base backup
+	writeFile("a", "a_new")
+	writeFile("e", "e_new")
+	if err := os.Remove(filepath.Join(srcDir, "d")); err != nil {
+		t.Fatal(err)
+	}
+
+	incName := "@auto_pb_backup_inc_test_20200102000000.zip"
+	if err := createIncrementalBackupArchive(srcDir, filepath.Join(backupsDir, incName), baseName, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// check the incremental archive entries
+	zr, err := zip.OpenReader(filepath.Join(backupsDir, incName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	zr.Close()
+
+	expectedNames := "a,e," + incrementalBackupManifestName
+	if strings.Join(names, ",") != expectedNames {
+		t.Fatalf("Expected archive entries %q, got %q", expectedNames, strings.Join(names, ","))
+	}
+
+	// extract and apply the incremental backup
+	fsys, err := filesystem.NewLocal(backupsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	extractedDir := filepath.Join(tempDir, "extracted")
+	if err := extractBackup(fsys, tempDir, incName, extractedDir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyIncrementalBackup(fsys, tempDir, extractedDir); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFiles := map[string]string{
+		"a":   "a_new",
+		"b/c": "c_old",
+		"e":   "e_new",
+	}
+
+	for name, content := range expectedFiles {
+		raw, err := os.ReadFile(filepath.Join(extractedDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %q: %v", name, err)
+		}
+		if string(raw) != content {
+			t.Fatalf("Expected %q content %q, got %q", name, content, raw)
+		}
+	}
+
+	for _, name := range []string{"d", incrementalBackupManifestName} {
+		if _, err := os.Stat(filepath.Join(extractedDir, name)); err == nil {
+			t.Fatalf("Expected %q to be removed", name)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package core_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -82,6 +83,42 @@ func TestCreateBackup(t *testing.T) {
 	}
 }
 
+func TestCreateBackupHook(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.OnBackupCreate().Add(func(e *core.BackupEvent) error {
+		if e.Name == "test" {
+			e.Name = "test_changed"
+		}
+
+		return nil
+	})
+
+	if err := app.CreateBackup(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	backupsDir := filepath.Join(app.DataDir(), core.LocalBackupsDirName)
+
+	if _, err := os.Stat(filepath.Join(backupsDir, "test_changed")); err != nil {
+		t.Fatalf("Expected the backup to be created with the hook name: %v", err)
+	}
+
+	// abort the backup creation
+	app.OnBackupCreate().Add(func(e *core.BackupEvent) error {
+		return errors.New("abort")
+	})
+
+	if err := app.CreateBackup(context.Background(), "test2"); err == nil {
+		t.Fatal("Expected the backup creation to be aborted")
+	}
+
+	if _, err := os.Stat(filepath.Join(backupsDir, "test2")); err == nil {
+		t.Fatal("Expected the aborted backup to not be created")
+	}
+}
+
 func TestRestoreBackup(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
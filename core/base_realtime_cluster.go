@@ -0,0 +1,100 @@
+package core
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+)
+
+// refreshRealtimeCluster (re)connects the subscriptions broker
+// cluster transport if the realtime cluster settings have changed.
+//
+// Failing to connect to the message bus server is not fatal and only
+// logs a warning (the transport keeps reconnecting in the background).
+func (app *BaseApp) refreshRealtimeCluster() error {
+	config := app.settings.RealtimeCluster
+	if !config.Enabled {
+		config = settings.RealtimeClusterConfig{}
+	}
+
+	app.realtimeClusterMux.Lock()
+	defer app.realtimeClusterMux.Unlock()
+
+	if config == app.realtimeClusterConfig {
+		return nil // no changes
+	}
+
+	if !config.Enabled {
+		app.realtimeClusterConfig = config
+		return app.subscriptionsBroker.SetClusterTransport(nil)
+	}
+
+	var transport subscriptions.ClusterTransport
+
+	switch config.Provider {
+	case settings.RealtimeClusterProviderNATS:
+		t, err := subscriptions.NewNATSTransport(config.Url, config.Channel, config.Username, config.Password)
+		if err != nil {
+			return err
+		}
+		transport = t
+	case settings.RealtimeClusterProviderRedis:
+		redisUrl, err := realtimeClusterRedisUrl(config)
+		if err != nil {
+			return err
+		}
+
+		t, err := subscriptions.NewRedisTransport(redisUrl, config.Channel)
+		if err != nil {
+			return err
+		}
+		transport = t
+	default:
+		return fmt.Errorf("unsupported realtime cluster provider %q", config.Provider)
+	}
+
+	app.realtimeClusterConfig = config
+
+	if err := app.subscriptionsBroker.SetClusterTransport(transport); err != nil && app.Logger() != nil {
+		app.Logger().Warn(
+			"Failed to connect to the realtime cluster transport (will retry in the background)",
+			slog.String("provider", config.Provider),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return nil
+}
+
+// realtimeClusterRedisUrl returns the cluster Redis url
+// with the configured credentials (if any).
+func realtimeClusterRedisUrl(config settings.RealtimeClusterConfig) (string, error) {
+	if config.Username == "" && config.Password == "" {
+		return config.Url, nil
+	}
+
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return "", err
+	}
+
+	u.User = url.UserPassword(config.Username, config.Password)
+
+	return u.String(), nil
+}
+
+// initRealtimeClusterHooks registers the app hooks that
+// disconnect the realtime cluster transport on terminate.
+func (app *BaseApp) initRealtimeClusterHooks() {
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		app.realtimeClusterMux.Lock()
+		defer app.realtimeClusterMux.Unlock()
+
+		app.realtimeClusterConfig = settings.RealtimeClusterConfig{}
+
+		return app.subscriptionsBroker.SetClusterTransport(nil)
+	})
+}
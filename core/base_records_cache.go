@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/cache"
+)
+
+// refreshRecordsCache reconfigures the records cache store
+// if the records cache store settings have changed.
+//
+// Failing to connect to the Redis server is not fatal and only logs
+// a warning (the connection is retried with the next cache operations).
+func (app *BaseApp) refreshRecordsCache() error {
+	config := app.settings.RecordsCache
+
+	// only the store related settings require reconfiguration
+	storeConfig := settings.RecordsCacheConfig{}
+	if config.Enabled {
+		storeConfig.Enabled = true
+		storeConfig.Provider = config.Provider
+		storeConfig.RedisUrl = config.RedisUrl
+		storeConfig.MaxEntries = config.MaxEntries
+	}
+
+	app.recordsCacheMux.Lock()
+	defer app.recordsCacheMux.Unlock()
+
+	if storeConfig.Enabled == app.recordsCacheConfig.Enabled &&
+		storeConfig.Provider == app.recordsCacheConfig.Provider &&
+		storeConfig.RedisUrl == app.recordsCacheConfig.RedisUrl &&
+		storeConfig.MaxEntries == app.recordsCacheConfig.MaxEntries {
+		return nil // no changes
+	}
+
+	var store cache.Cache
+
+	if storeConfig.Enabled {
+		switch storeConfig.Provider {
+		case settings.RecordsCacheProviderMemory:
+			store = cache.NewMemory(storeConfig.MaxEntries)
+		case settings.RecordsCacheProviderRedis:
+			redisStore, err := cache.NewRedis(storeConfig.RedisUrl, "pb:records:")
+			if err != nil {
+				return err
+			}
+			store = redisStore
+		default:
+			return fmt.Errorf("unsupported records cache provider %q", storeConfig.Provider)
+		}
+	}
+
+	app.recordsCacheConfig = storeConfig
+
+	if err := app.recordsCache.Configure(store); err != nil && app.Logger() != nil {
+		app.Logger().Debug("Failed to close the previous records cache store", slog.String("error", err.Error()))
+	}
+
+	// the shared store could have stale entries from a previous
+	// app run with a different schema or with direct db changes
+	if storeConfig.Provider == settings.RecordsCacheProviderRedis {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := app.recordsCache.Invalidate(ctx); err != nil && app.Logger() != nil {
+			app.Logger().Warn("Failed to connect to the records cache Redis server", slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// invalidateRecordsCache invalidates the cached records responses
+// of the changed record or collection model.
+//
+// It is registered as the app dao AfterCommitFunc so that the cache is
+// invalidated only after the transaction commit (otherwise a concurrent
+// request could cache the old data under the new version) and also for
+// the writes without hooks.
+func (app *BaseApp) invalidateRecordsCache(m models.Model) {
+	if !app.recordsCache.Enabled() {
+		return
+	}
+
+	var collectionId string
+
+	switch v := m.(type) {
+	case *models.Record:
+		collectionId = v.Collection().Id
+	case *models.Collection:
+		collectionId = v.Id
+	default:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := app.recordsCache.Invalidate(ctx, collectionId); err != nil && app.Logger() != nil {
+		app.Logger().Warn(
+			"Failed to invalidate the records cache",
+			slog.String("collectionId", collectionId),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// initRecordsCacheHooks registers the app hooks that
+// release the records cache store on app termination.
+func (app *BaseApp) initRecordsCacheHooks() {
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		app.recordsCacheMux.Lock()
+		defer app.recordsCacheMux.Unlock()
+
+		app.recordsCacheConfig = settings.RecordsCacheConfig{}
+
+		return app.recordsCache.Configure(nil)
+	})
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// initSecretsRotationHooks registers the app serve hooks that periodically
+// re-resolve the settings secret references (aka. pick up the rotated secrets).
+func (app *BaseApp) initSecretsRotationHooks() {
+	interval := app.secrets.TTL()
+	if interval <= 0 {
+		return // no cache -> the references are resolved on every settings refresh
+	}
+
+	done := make(chan struct{})
+
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					app.rotateSecrets()
+				}
+			}
+		}()
+
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		select {
+		case <-done:
+		default:
+			close(done)
+		}
+
+		return nil
+	})
+}
+
+// rotateSecrets clears the secrets cache and re-resolves
+// the settings secret references (if any).
+func (app *BaseApp) rotateSecrets() {
+	if app.settings == nil || len(app.settings.SecretRefs()) == 0 {
+		return
+	}
+
+	app.secrets.Clear()
+
+	if err := app.settings.ResolveSecrets(context.Background(), app.secrets); err != nil {
+		app.Logger().Error("Failed to rotate the settings secret references", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// SettingsFile returns the path to the settings overrides config file (if any).
+func (app *BaseApp) SettingsFile() string {
+	return app.settingsFile
+}
+
+// SettingsEnvPrefix returns the settings overrides env variables prefix (if any).
+func (app *BaseApp) SettingsEnvPrefix() string {
+	return app.settingsEnvPrefix
+}
+
+// applySettingsOverrides applies the config file and the env variables
+// settings overrides to the current app settings (the env variables have priority).
+func (app *BaseApp) applySettingsOverrides() error {
+	overrides := []settings.Override{}
+
+	if app.settingsFile != "" {
+		fileOverrides, err := settings.FileOverrides(app.settingsFile)
+		if err != nil {
+			return err
+		}
+		overrides = append(overrides, fileOverrides...)
+	}
+
+	envOverrides, err := settings.EnvOverrides(app.settingsEnvPrefix, os.Environ())
+	if err != nil {
+		return err
+	}
+	overrides = append(overrides, envOverrides...)
+
+	return app.settings.ApplyOverrides(overrides)
+}
+
+// initSettingsFileWatcher registers the app serve hooks that watch
+// the settings overrides config file and reload the app settings on change.
+func (app *BaseApp) initSettingsFileWatcher() {
+	if app.settingsFile == "" {
+		return
+	}
+
+	var watcher *fsnotify.Watcher
+
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		var err error
+
+		watcher, err = fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+
+		// watch the parent directory to handle also the file recreation
+		// (eg. editors atomic saves or Kubernetes ConfigMap symlink swaps)
+		if err := watcher.Add(filepath.Dir(app.settingsFile)); err != nil {
+			watcher.Close()
+			return err
+		}
+
+		go app.watchSettingsFile(watcher)
+
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		if watcher != nil {
+			watcher.Close()
+		}
+
+		return nil
+	})
+}
+
+func (app *BaseApp) watchSettingsFile(watcher *fsnotify.Watcher) {
+	var debounceTimer *time.Timer
+
+	defer func() {
+		if debounceTimer != nil {
+			debounceTimer.Stop()
+		}
+	}()
+
+	name := filepath.Clean(app.settingsFile)
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if filepath.Clean(event.Name) != name && !event.Has(fsnotify.Create) {
+				continue
+			}
+
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+
+			debounceTimer = time.AfterFunc(100*time.Millisecond, func() {
+				if err := app.RefreshSettings(); err != nil {
+					app.Logger().Error(
+						"Failed to reload the settings file",
+						slog.String("file", app.settingsFile),
+						slog.String("error", err.Error()),
+					)
+					return
+				}
+
+				app.Logger().Info("Reloaded the settings file", slog.String("file", app.settingsFile))
+			})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			app.Logger().Error("Settings file watch error", slog.String("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/routine"
+)
+
+// captureSlowQuery stores the provided main db query together with its
+// query plan in the slow queries log if it exceeds the configured threshold.
+//
+// The query plan is resolved and the entry is saved in the background
+// so that the query execution itself is not slowed down further.
+func (app *BaseApp) captureSlowQuery(duration time.Duration, sql string) {
+	settings := app.Settings()
+	if settings == nil || settings.Logs.SlowQueryThreshold <= 0 {
+		return
+	}
+
+	if duration < time.Duration(settings.Logs.SlowQueryThreshold)*time.Millisecond {
+		return
+	}
+
+	// the plan queries could be slow too
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(sql)), "EXPLAIN") {
+		return
+	}
+
+	logsDao := app.LogsDao()
+	if logsDao == nil {
+		return
+	}
+
+	routine.FireAndForget(func() {
+		// not all statements can be explained (eg. PRAGMA)
+		plan, _ := app.Dao().ExplainQueryPlan(sql)
+
+		if err := logsDao.RecordSlowQuery(sql, duration, plan); err != nil && app.Logger() != nil {
+			app.Logger().Debug("Failed to store the slow query", slog.String("error", err.Error()))
+		}
+	})
+}
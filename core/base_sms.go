@@ -0,0 +1,54 @@
+package core
+
+import (
+	"log/slog"
+
+	"github.com/pocketbase/pocketbase/tools/sms"
+)
+
+var _ sms.Client = (*loggedSmsClient)(nil)
+
+// loggedSmsClient logs the delivery status of each sent SMS message.
+//
+// The message body is never logged since it could contain
+// sensitive data (eg. OTP codes).
+type loggedSmsClient struct {
+	sms.Client
+	logger   *slog.Logger
+	provider string
+}
+
+// Send implements the [sms.Client] interface.
+func (c *loggedSmsClient) Send(message *sms.Message) error {
+	err := c.Client.Send(message)
+
+	attrs := []any{
+		slog.String("type", "sms"),
+		slog.String("provider", c.provider),
+		slog.String("to", maskPhone(message.To)),
+		slog.Int("length", len([]rune(message.Body))),
+	}
+
+	if err != nil {
+		c.logger.Warn("Failed to send SMS", append(attrs, slog.String("error", err.Error()))...)
+	} else {
+		c.logger.Info("SMS sent", attrs...)
+	}
+
+	return err
+}
+
+// maskPhone replaces all phone number characters, except
+// the leading "+" and the last 4, with "*" (eg. "+*******6789").
+func maskPhone(phone string) string {
+	chars := []rune(phone)
+
+	for i := 0; i < len(chars)-4; i++ {
+		if i == 0 && chars[i] == '+' {
+			continue
+		}
+		chars[i] = '*'
+	}
+
+	return string(chars)
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/sms"
+)
+
+type testSmsClient struct {
+	err error
+}
+
+func (c *testSmsClient) Send(m *sms.Message) error {
+	return c.err
+}
+
+func TestLoggedSmsClient(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			"successful send",
+			nil,
+			[]string{`"level":"INFO"`, `"msg":"SMS sent"`, `"type":"sms"`, `"provider":"twilio"`, `"to":"+*****6789"`, `"length":4`},
+		},
+		{
+			"failed send",
+			errors.New("test_error"),
+			[]string{`"level":"WARN"`, `"msg":"Failed to send SMS"`, `"type":"sms"`, `"error":"test_error"`},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+
+			client := &loggedSmsClient{
+				Client:   &testSmsClient{err: s.err},
+				logger:   slog.New(slog.NewJSONHandler(buf, nil)),
+				provider: "twilio",
+			}
+
+			err := client.Send(&sms.Message{To: "+123456789", Body: "test"})
+			if err != s.err {
+				t.Fatalf("Expected error %v, got %v", s.err, err)
+			}
+
+			log := buf.String()
+
+			for _, str := range s.expected {
+				if !strings.Contains(log, str) {
+					t.Fatalf("Expected %q in\n%s", str, log)
+				}
+			}
+
+			if strings.Contains(log, "test\"") {
+				t.Fatalf("Expected the message body to not be logged, got\n%s", log)
+			}
+		})
+	}
+}
+
+func TestMaskPhone(t *testing.T) {
+	scenarios := []struct {
+		phone    string
+		expected string
+	}{
+		{"", ""},
+		{"123", "123"},
+		{"1234", "1234"},
+		{"12345", "*2345"},
+		{"+12345", "+*2345"},
+		{"+359888123456", "+********3456"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.phone, func(t *testing.T) {
+			if v := maskPhone(s.phone); v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,10 +15,13 @@ import (
 	"github.com/pocketbase/pocketbase/migrations"
 	"github.com/pocketbase/pocketbase/migrations/logs"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/mailer"
 	"github.com/pocketbase/pocketbase/tools/migrate"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/sms"
 )
 
 func TestNewBaseApp(t *testing.T) {
@@ -191,6 +198,10 @@ func TestBaseAppGetters(t *testing.T) {
 		t.Fatalf("Expected app.Logger %v, got %v", app.Logger(), app.logger)
 	}
 
+	if app.secrets != app.Secrets() {
+		t.Fatalf("Expected app.Secrets %v, got %v", app.Secrets(), app.secrets)
+	}
+
 	if app.subscriptionsBroker != app.SubscriptionsBroker() {
 		t.Fatalf("Expected app.SubscriptionsBroker %v, got %v", app.SubscriptionsBroker(), app.subscriptionsBroker)
 	}
@@ -218,6 +229,108 @@ func TestBaseAppNewMailClient(t *testing.T) {
 	if val, ok := client2.(*mailer.SmtpClient); !ok {
 		t.Fatalf("Expected mailer.SmtpClient instance, got %v", val)
 	}
+
+	app.Settings().EmailApi.Enabled = true
+
+	app.Settings().EmailApi.Provider = mailer.ProviderSendGrid
+	if val, ok := app.NewMailClient().(*mailer.SendGridClient); !ok {
+		t.Fatalf("Expected mailer.SendGridClient instance, got %v", val)
+	}
+
+	app.Settings().EmailApi.Provider = mailer.ProviderMailgun
+	if val, ok := app.NewMailClient().(*mailer.MailgunClient); !ok {
+		t.Fatalf("Expected mailer.MailgunClient instance, got %v", val)
+	}
+
+	app.Settings().EmailApi.Provider = mailer.ProviderSes
+	if val, ok := app.NewMailClient().(*mailer.SesClient); !ok {
+		t.Fatalf("Expected mailer.SesClient instance, got %v", val)
+	}
+}
+
+func TestBaseAppNewSmsClient(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	if _, err := app.NewSmsClient(); err == nil {
+		t.Fatal("Expected error for disabled SMS provider, got nil")
+	}
+
+	app.Settings().Sms.Enabled = true
+
+	app.Settings().Sms.Provider = "invalid"
+	if _, err := app.NewSmsClient(); err == nil {
+		t.Fatal("Expected error for unsupported SMS provider, got nil")
+	}
+
+	app.Settings().Sms.Provider = sms.ProviderTwilio
+	if val, _ := app.NewSmsClient(); val == nil {
+		t.Fatal("Expected sms.TwilioClient instance, got nil")
+	} else if logged, ok := val.(*loggedSmsClient); !ok {
+		t.Fatalf("Expected loggedSmsClient instance, got %v", val)
+	} else if _, ok := logged.Client.(*sms.TwilioClient); !ok {
+		t.Fatalf("Expected sms.TwilioClient instance, got %v", logged.Client)
+	}
+
+	app.Settings().Sms.Provider = sms.ProviderVonage
+	if val, _ := app.NewSmsClient(); val == nil {
+		t.Fatal("Expected sms.VonageClient instance, got nil")
+	} else if logged, ok := val.(*loggedSmsClient); !ok {
+		t.Fatalf("Expected loggedSmsClient instance, got %v", val)
+	} else if _, ok := logged.Client.(*sms.VonageClient); !ok {
+		t.Fatalf("Expected sms.VonageClient instance, got %v", logged.Client)
+	}
+
+	app.Settings().Sms.Provider = sms.ProviderHttp
+	if val, _ := app.NewSmsClient(); val == nil {
+		t.Fatal("Expected sms.HttpApiClient instance, got nil")
+	} else if logged, ok := val.(*loggedSmsClient); !ok {
+		t.Fatalf("Expected loggedSmsClient instance, got %v", val)
+	} else if _, ok := logged.Client.(*sms.HttpApiClient); !ok {
+		t.Fatalf("Expected sms.HttpApiClient instance, got %v", logged.Client)
+	}
+}
+
+func TestBaseAppNewPushClient(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	for _, provider := range push.Providers() {
+		if _, err := app.NewPushClient(provider); err == nil {
+			t.Fatalf("Expected error for disabled %q push provider, got nil", provider)
+		}
+	}
+
+	if _, err := app.NewPushClient("invalid"); err == nil {
+		t.Fatal("Expected error for unsupported push provider, got nil")
+	}
+
+	app.Settings().Push.FCM.Enabled = true
+	if val, _ := app.NewPushClient(push.ProviderFCM); val == nil {
+		t.Fatal("Expected push.FCMClient instance, got nil")
+	} else if _, ok := val.(*push.FCMClient); !ok {
+		t.Fatalf("Expected push.FCMClient instance, got %v", val)
+	}
+
+	app.Settings().Push.APNs.Enabled = true
+	if val, _ := app.NewPushClient(push.ProviderAPNs); val == nil {
+		t.Fatal("Expected push.APNsClient instance, got nil")
+	} else if _, ok := val.(*push.APNsClient); !ok {
+		t.Fatalf("Expected push.APNsClient instance, got %v", val)
+	}
+
+	app.Settings().Push.WebPush.Enabled = true
+	if val, _ := app.NewPushClient(push.ProviderWebPush); val == nil {
+		t.Fatal("Expected push.WebPushClient instance, got nil")
+	} else if _, ok := val.(*push.WebPushClient); !ok {
+		t.Fatalf("Expected push.WebPushClient instance, got %v", val)
+	}
 }
 
 func TestBaseAppNewFilesystem(t *testing.T) {
@@ -337,6 +450,98 @@ func TestBaseAppLoggerWrites(t *testing.T) {
 	}
 }
 
+func TestBaseAppLoggerTargets(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	app.Settings().Logs.MaxDays = 1
+	app.Settings().Logs.AppTarget = settings.LogsTargetFile
+	app.Settings().Logs.RequestsTarget = settings.LogsTargetBoth
+
+	app.Logger().Info("app_log")
+	app.Logger().Info("request_log", "type", "request")
+
+	handler, ok := app.Logger().Handler().(*logger.BatchHandler)
+	if !ok {
+		t.Fatalf("Expected BatchHandler, got %T", app.Logger().Handler())
+	}
+	if err := handler.WriteAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var dbMessages []string
+	if err := app.LogsDao().LogQuery().Select("message").Column(&dbMessages); err != nil {
+		t.Fatal(err)
+	}
+	if len(dbMessages) != 1 || dbMessages[0] != "request_log" {
+		t.Fatalf("Expected only the request log to be stored in the db, got %v", dbMessages)
+	}
+
+	logsDir := filepath.Join(app.DataDir(), LocalLogsDirName)
+
+	appLogs, err := os.ReadFile(filepath.Join(logsDir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(appLogs), `"message":"app_log"`) || strings.Contains(string(appLogs), "request_log") {
+		t.Fatalf("Unexpected app.log content:\n%s", appLogs)
+	}
+
+	requestLogs, err := os.ReadFile(filepath.Join(logsDir, "requests.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(requestLogs), `"message":"request_log"`) || strings.Contains(string(requestLogs), "app_log") {
+		t.Fatalf("Unexpected requests.log content:\n%s", requestLogs)
+	}
+}
+
+func TestBaseAppLoggerSinks(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	app.Settings().Logs.MaxDays = 1
+	app.Settings().Logs.Syslog = settings.LogsSyslogConfig{
+		Enabled: true,
+		Address: conn.LocalAddr().String(),
+		Tag:     "sinktest",
+	}
+
+	app.Logger().Info("sink_log")
+
+	handler, ok := app.Logger().Handler().(*logger.BatchHandler)
+	if !ok {
+		t.Fatalf("Expected BatchHandler, got %T", app.Logger().Handler())
+	}
+	if err := handler.WriteAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	buf := make([]byte, 2048)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg := string(buf[:n]); !strings.Contains(msg, " sinktest ") || !strings.Contains(msg, `"message":"sink_log"`) {
+		t.Fatalf("Unexpected syslog message %q", msg)
+	}
+}
+
 func TestBaseAppRefreshSettingsLoggerMinLevelEnabled(t *testing.T) {
 	app, cleanup, err := initTestBaseApp()
 	if err != nil {
@@ -401,6 +606,111 @@ func TestBaseAppRefreshSettingsLoggerMinLevelEnabled(t *testing.T) {
 	}
 }
 
+func TestBaseAppRefreshSettingsSecrets(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	t.Setenv("PB_TEST_SMTP_PASSWORD", "smtp_secret")
+
+	app.Settings().Smtp.Password = "env:PB_TEST_SMTP_PASSWORD"
+
+	if err := app.Dao().SaveSettings(app.Settings()); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+
+	if err := app.RefreshSettings(); err != nil {
+		t.Fatalf("Failed to refresh app settings: %v", err)
+	}
+
+	if app.Settings().Smtp.Password != "smtp_secret" {
+		t.Fatalf("Expected the resolved smtp password, got %q", app.Settings().Smtp.Password)
+	}
+
+	// resave and check that the reference (and not its value) is persisted
+	if err := app.Dao().SaveSettings(app.Settings()); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+
+	stored, err := app.Dao().FindSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stored.Smtp.Password != "env:PB_TEST_SMTP_PASSWORD" {
+		t.Fatalf("Expected the stored smtp password to be the reference, got %q", stored.Smtp.Password)
+	}
+
+	// rotation
+	t.Setenv("PB_TEST_SMTP_PASSWORD", "smtp_rotated")
+	app.rotateSecrets()
+
+	if app.Settings().Smtp.Password != "smtp_rotated" {
+		t.Fatalf("Expected the rotated smtp password, got %q", app.Settings().Smtp.Password)
+	}
+}
+
+func TestBaseAppRefreshSettingsOverrides(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	app.Settings().Smtp.Host = "stored.example.com"
+	app.Settings().Meta.AppName = "stored"
+	if err := app.Dao().SaveSettings(app.Settings()); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+
+	settingsFile := filepath.Join(app.DataDir(), "settings.json")
+	if err := os.WriteFile(settingsFile, []byte(`{"meta":{"appName":"file"},"smtp":{"host":"file.example.com"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PB_TEST_SMTP_HOST", "env.example.com")
+
+	app.settingsFile = settingsFile
+	app.settingsEnvPrefix = "PB_TEST_"
+
+	if err := app.RefreshSettings(); err != nil {
+		t.Fatalf("Failed to refresh app settings: %v", err)
+	}
+
+	if app.Settings().Smtp.Host != "env.example.com" {
+		t.Fatalf("Expected the env smtp host, got %q", app.Settings().Smtp.Host)
+	}
+
+	if app.Settings().Meta.AppName != "file" {
+		t.Fatalf("Expected the file app name, got %q", app.Settings().Meta.AppName)
+	}
+
+	// resave and check that the overridden values are not persisted
+	if err := app.Dao().SaveSettings(app.Settings()); err != nil {
+		t.Fatalf("Failed to save settings: %v", err)
+	}
+
+	stored, err := app.Dao().FindSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stored.Smtp.Host != "stored.example.com" || stored.Meta.AppName != "stored" {
+		t.Fatalf("Expected the stored values to be persisted, got %q and %q", stored.Smtp.Host, stored.Meta.AppName)
+	}
+
+	// invalid settings file
+	if err := os.WriteFile(settingsFile, []byte(`{"meta":{"unknown":1}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.RefreshSettings(); err == nil {
+		t.Fatal("Expected error for invalid settings file, got nil")
+	}
+}
+
 func TestBaseAppLoggerLevelDevPrint(t *testing.T) {
 	app, cleanup, err := initTestBaseApp()
 	if err != nil {
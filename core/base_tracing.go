@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/tracing"
+	"github.com/pocketbase/pocketbase/tools/transport"
+)
+
+// refreshTracer reconfigures the app tracer with the current tracing settings.
+func (app *BaseApp) refreshTracer() error {
+	config := app.settings.Tracing
+
+	if !config.Enabled {
+		app.tracer.Configure(nil, "", 0)
+		return nil
+	}
+
+	exporter, err := tracing.NewOTLPExporter(
+		config.Endpoint,
+		config.Headers,
+		&http.Client{Transport: transport.Default, Timeout: 30 * time.Second},
+	)
+	if err != nil {
+		return err
+	}
+
+	app.tracer.Configure(exporter, config.ServiceName, config.SampleRatio)
+
+	return nil
+}
+
+// initTracingHooks registers the app hooks that log
+// the spans export errors and flush the queued spans on terminate.
+func (app *BaseApp) initTracingHooks() {
+	app.tracer.OnExportError = func(err error) {
+		if app.Logger() != nil {
+			app.Logger().Debug("Failed to export the tracing spans", slog.String("error", err.Error()))
+		}
+	}
+
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := app.tracer.Shutdown(ctx); err != nil && app.Logger() != nil {
+			app.Logger().Debug("Failed to export the remaining tracing spans", slog.String("error", err.Error()))
+		}
+
+		return nil
+	})
+}
+
+// traceQuery records an executed db query as a child span
+// of the query context span (if any).
+func (app *BaseApp) traceQuery(ctx context.Context, duration time.Duration, sql string, err error) {
+	if !app.tracer.Enabled() {
+		return
+	}
+
+	end := time.Now()
+
+	app.tracer.Record(
+		ctx,
+		"db.query",
+		tracing.KindClient,
+		end.Add(-duration),
+		end,
+		map[string]any{
+			"db.system":    "sqlite",
+			"db.statement": sql,
+		},
+		err,
+	)
+}
+
+// -------------------------------------------------------------------
+
+var _ mailer.Mailer = (*tracedMailer)(nil)
+
+// tracedMailer records a span for each sent email.
+type tracedMailer struct {
+	mailer.Mailer
+	tracer *tracing.Tracer
+}
+
+// Send implements the [mailer.Mailer] interface.
+func (m *tracedMailer) Send(message *mailer.Message) error {
+	_, span := m.tracer.Start(context.Background(), "mailer.send", tracing.KindClient)
+	defer span.End()
+
+	span.SetAttribute("mailer.client", fmt.Sprintf("%T", m.Mailer))
+	span.SetAttribute("mailer.recipients", len(message.To)+len(message.Cc)+len(message.Bcc))
+
+	err := m.Mailer.Send(message)
+	span.SetError(err)
+
+	return err
+}
@@ -0,0 +1,11 @@
+package core
+
+// StoreKeyCronPrefix is the app store key prefix of the
+// registered cron schedulers (their status is reported
+// by the deep health checks).
+//
+// Example:
+//
+//	scheduler := cron.New()
+//	app.Store().Set(core.StoreKeyCronPrefix+"myPlugin", scheduler)
+const StoreKeyCronPrefix = "@cron."
@@ -31,8 +31,11 @@ func init() {
 					PRAGMA temp_store         = MEMORY;
 					PRAGMA cache_size         = -16000;
 				`, nil)
+				if err != nil {
+					return err
+				}
 
-				return err
+				return conn.RegisterFunc("geo_distance", sqlGeoDistance, true)
 			},
 		},
 	)
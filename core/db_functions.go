@@ -0,0 +1,33 @@
+package core
+
+import (
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// sqlGeoDistance is the implementation of the custom
+// "geo_distance(lat1, lng1, lat2, lng2)" SQLite function.
+//
+// It returns the distance in meters between the 2 points or
+// NULL if any of the coordinates is NULL or not a number.
+func sqlGeoDistance(lat1, lng1, lat2, lng2 any) (any, error) {
+	coords := [4]float64{}
+
+	for i, v := range []any{lat1, lng1, lat2, lng2} {
+		if v == nil {
+			return nil, nil
+		}
+
+		f, err := cast.ToFloat64E(v)
+		if err != nil {
+			return nil, nil
+		}
+
+		coords[i] = f
+	}
+
+	p1 := types.GeoPoint{Lat: coords[0], Lng: coords[1]}
+	p2 := types.GeoPoint{Lat: coords[2], Lng: coords[3]}
+
+	return p1.DistanceTo(p2), nil
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"database/sql"
+	"math"
+	"os"
+	"testing"
+)
+
+func TestSqlGeoDistance(t *testing.T) {
+	const testDataDir = "./pb_db_functions_test_data_dir/"
+	defer os.RemoveAll(testDataDir)
+
+	app := NewBaseApp(BaseAppConfig{
+		DataDir:       testDataDir,
+		EncryptionEnv: "pb_test_env",
+	})
+	defer app.ResetBootstrapState()
+
+	if err := app.Bootstrap(); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name     string
+		query    string
+		expected float64 // -1 for NULL
+	}{
+		{"same point", "SELECT geo_distance(42.69, 23.32, 42.69, 23.32)", 0},
+		{"different points", "SELECT geo_distance(42.6977, 23.3219, 48.8566, 2.3522)", 1_760_000},
+		{"numeric strings", "SELECT geo_distance('0', '0', '0', '1')", 111_195},
+		{"null argument", "SELECT geo_distance(NULL, 0, 0, 1)", -1},
+		{"non-numeric argument", "SELECT geo_distance('abc', 0, 0, 1)", -1},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var result sql.NullFloat64
+
+			if err := app.DB().NewQuery(s.query).Row(&result); err != nil {
+				t.Fatal(err)
+			}
+
+			if s.expected < 0 {
+				if result.Valid {
+					t.Fatalf("Expected NULL, got %v", result.Float64)
+				}
+				return
+			}
+
+			// allow 1% error margin
+			if !result.Valid || math.Abs(result.Float64-s.expected) > s.expected/100 {
+				t.Fatalf("Expected ~%v, got %v", s.expected, result)
+			}
+		})
+	}
+
+	// ensure that the function is available also for the logs db
+	var logsResult float64
+	if err := app.LogsDB().NewQuery("SELECT geo_distance(0, 0, 0, 0)").Row(&logsResult); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/preflight"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
 	"golang.org/x/crypto/acme/autocert"
@@ -74,6 +76,18 @@ type TerminateEvent struct {
 	IsRestart bool
 }
 
+type BackupEvent struct {
+	App     App
+	Context context.Context
+
+	// Name is the name of the backup file to create or restore.
+	Name string
+
+	// Exclude is a list of the pb_data root dir entries
+	// that are excluded from the backup create or restore.
+	Exclude []string
+}
+
 type ServeEvent struct {
 	App         App
 	Router      *echo.Echo
@@ -81,6 +95,11 @@ type ServeEvent struct {
 	CertManager *autocert.Manager
 }
 
+type PreflightEvent struct {
+	App    App
+	Report *preflight.Report
+}
+
 type ApiErrorEvent struct {
 	HttpContext echo.Context
 	Error       error
@@ -200,6 +219,31 @@ type RecordDeleteEvent struct {
 	Record      *models.Record
 }
 
+type SyncConflictEvent struct {
+	BaseCollectionEvent
+
+	HttpContext echo.Context
+
+	// Record is the record to save, aka. the current stored record
+	// with the client submitted data applied on top of it.
+	//
+	// Handlers could modify it in place to store the merged result.
+	Record *models.Record
+
+	// ServerRecord is the current stored version of the record.
+	ServerRecord *models.Record
+
+	// Data is the client submitted data.
+	Data map[string]any
+
+	// ClientRevision is the stale revision the client write was based on.
+	ClientRevision int
+
+	// Resolved indicates whether the conflict was resolved and Record
+	// should be persisted (the write is rejected with 409 otherwise).
+	Resolved bool
+}
+
 // -------------------------------------------------------------------
 // Auth Record API events data
 // -------------------------------------------------------------------
@@ -207,10 +251,11 @@ type RecordDeleteEvent struct {
 type RecordAuthEvent struct {
 	BaseCollectionEvent
 
-	HttpContext echo.Context
-	Record      *models.Record
-	Token       string
-	Meta        any
+	HttpContext  echo.Context
+	Record       *models.Record
+	Token        string
+	RefreshToken string
+	Meta         any
 }
 
 type RecordAuthWithPasswordEvent struct {
@@ -233,6 +278,16 @@ type RecordAuthWithOAuth2Event struct {
 	IsNewRecord    bool
 }
 
+type RecordAuthWithCustomMethodEvent struct {
+	BaseCollectionEvent
+
+	HttpContext echo.Context
+	Method      string
+	Payload     map[string]any
+	Record      *models.Record
+	Meta        any
+}
+
 type RecordAuthRefreshEvent struct {
 	BaseCollectionEvent
 
@@ -254,6 +309,25 @@ type RecordConfirmPasswordResetEvent struct {
 	Record      *models.Record
 }
 
+type RecordRequestOTPEvent struct {
+	BaseCollectionEvent
+
+	HttpContext echo.Context
+	Record      *models.Record
+	OTP         *models.OTP
+
+	// Password is the plain one-time password that will be sent.
+	Password string
+}
+
+type RecordConfirmOTPEvent struct {
+	BaseCollectionEvent
+
+	HttpContext echo.Context
+	Record      *models.Record
+	OTP         *models.OTP
+}
+
 type RecordRequestVerificationEvent struct {
 	BaseCollectionEvent
 
@@ -329,9 +403,10 @@ type AdminDeleteEvent struct {
 }
 
 type AdminAuthEvent struct {
-	HttpContext echo.Context
-	Admin       *models.Admin
-	Token       string
+	HttpContext  echo.Context
+	Admin        *models.Admin
+	Token        string
+	RefreshToken string
 }
 
 type AdminAuthWithPasswordEvent struct {
@@ -356,6 +431,58 @@ type AdminConfirmPasswordResetEvent struct {
 	Admin       *models.Admin
 }
 
+// AdminUIPanel describes a custom admin UI panel (usually registered by a plugin).
+type AdminUIPanel struct {
+	// Id is the unique panel identifier.
+	Id string `json:"id"`
+
+	// Title is the panel menu item title.
+	Title string `json:"title"`
+
+	// Icon is an optional Remix icon class of the panel menu item (eg. "ri-file-list-line").
+	Icon string `json:"icon"`
+
+	// ScriptUrl is the url of the panel script that renders the panel content
+	// with `window.pbAdminUI.registerPanel(id, (container, ctx) => {})`.
+	ScriptUrl string `json:"scriptUrl"`
+}
+
+type AdminUIConfigEvent struct {
+	HttpContext echo.Context
+	Admin       *models.Admin
+
+	// HiddenSections is the list of the admin UI sections
+	// (eg. "logs", "settings/backups") hidden for the current admin.
+	HiddenSections []string
+
+	// Panels is the list of the custom admin UI panels
+	// available for the current admin.
+	Panels []*AdminUIPanel
+}
+
+// -------------------------------------------------------------------
+// Auth sessions events data
+// -------------------------------------------------------------------
+
+type SessionEvent struct {
+	// HttpContext is the related request context
+	// (could be nil, eg. when the session is revoked because of a refresh token reuse).
+	HttpContext echo.Context
+	Session     *models.Session
+}
+
+// -------------------------------------------------------------------
+// Signature requests events data
+// -------------------------------------------------------------------
+
+type SignatureCompleteEvent struct {
+	BaseCollectionEvent
+
+	HttpContext      echo.Context
+	Record           *models.Record
+	SignatureRequest *models.SignatureRequest
+}
+
 // -------------------------------------------------------------------
 // Collection API events data
 // -------------------------------------------------------------------
@@ -415,3 +542,18 @@ type FileDownloadEvent struct {
 	ServedPath  string
 	ServedName  string
 }
+
+type RecordFileScanEvent struct {
+	BaseCollectionEvent
+
+	Record    *models.Record
+	FileField *schema.SchemaField
+	File      *filesystem.File
+
+	// Quarantine indicates whether the file should be held
+	// in the quarantine storage area until an admin review.
+	Quarantine bool
+
+	// Reason is an optional quarantine reason (eg. the detected signature).
+	Reason string
+}
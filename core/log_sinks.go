@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/logger"
+	"github.com/pocketbase/pocketbase/tools/transport"
+)
+
+// logSinks manages the external logs sinks (stdout, syslog, Loki)
+// and recreates them when their settings change.
+type logSinks struct {
+	app   App
+	mux   sync.Mutex
+	key   string
+	sinks []logger.Sink
+}
+
+// write sends the logs to all configured sinks.
+//
+// stdoutFormat is the effective stdout sink format (empty to disable it).
+func (s *logSinks) write(ctx context.Context, config settings.LogsConfig, stdoutFormat string, logs []*logger.Log) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.sync(config, stdoutFormat)
+
+	for _, sink := range s.sinks {
+		if err := sink.WriteLogs(ctx, logs); err != nil {
+			s.app.Logger().Error(
+				"Failed to write logs to sink",
+				slog.String("sink", fmt.Sprintf("%T", sink)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// close closes all sinks.
+func (s *logSinks) close() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.closeAll()
+	s.key = ""
+}
+
+func (s *logSinks) closeAll() {
+	for _, sink := range s.sinks {
+		sink.Close()
+	}
+	s.sinks = nil
+}
+
+// sync recreates the sinks if their configuration has changed.
+func (s *logSinks) sync(config settings.LogsConfig, stdoutFormat string) {
+	rawKey, _ := json.Marshal([]any{stdoutFormat, config.Syslog, config.Loki})
+	key := string(rawKey)
+
+	if key == s.key {
+		return // no changes
+	}
+
+	s.closeAll()
+	s.key = key
+
+	if stdoutFormat != "" {
+		s.sinks = append(s.sinks, logger.NewWriterSink(os.Stdout, stdoutFormat))
+	}
+
+	if config.Syslog.Enabled {
+		sink, err := logger.NewSyslogSink(config.Syslog.Network, config.Syslog.Address, config.Syslog.Tag)
+		if err != nil {
+			s.app.Logger().Error("Failed to initialize the syslog logs sink", slog.String("error", err.Error()))
+		} else {
+			s.sinks = append(s.sinks, sink)
+		}
+	}
+
+	if config.Loki.Enabled {
+		sink, err := logger.NewLokiSink(
+			config.Loki.Url,
+			config.Loki.Labels,
+			config.Loki.Username,
+			config.Loki.Password,
+			&http.Client{Transport: transport.Default, Timeout: 30 * time.Second},
+		)
+		if err != nil {
+			s.app.Logger().Error("Failed to initialize the Loki logs sink", slog.String("error", err.Error()))
+		} else {
+			s.sinks = append(s.sinks, sink)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package core
+
+// StoreKeyReady is the app store key that marks the app as ready
+// to receive traffic (see the "/api/health/ready" endpoint).
+//
+// It is set by apis.Serve after the app bootstrap, the migrations and the
+// OnBeforeServe hooks (aka. after the app hooks are loaded) and it is removed
+// during a backup restore and on app termination.
+const StoreKeyReady = "@ready"
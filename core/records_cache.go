@@ -0,0 +1,140 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/cache"
+)
+
+// recordsCacheGlobalVersion is the collection id placeholder of the
+// version counter shared by all cached records responses.
+const recordsCacheGlobalVersion = "*"
+
+// RecordsCache is the records API responses cache with
+// versions based invalidation.
+//
+// Each cached response is stored under a key that includes the current
+// versions of the collections it depends on, so incrementing a collection
+// version (see [RecordsCache.Invalidate]) makes all related entries unreachable
+// (they are evicted later by the underlying store ttl or LRU policy).
+//
+// The zero value is a disabled cache (see [RecordsCache.Configure]).
+type RecordsCache struct {
+	mux   sync.RWMutex
+	store cache.Cache
+	stats *cache.Stats
+}
+
+// NewRecordsCache creates a new disabled RecordsCache.
+func NewRecordsCache() *RecordsCache {
+	return &RecordsCache{stats: cache.NewStats()}
+}
+
+// Configure replaces the underlying cache store (closing the previous one).
+//
+// Set a nil store to disable the cache.
+func (rc *RecordsCache) Configure(store cache.Cache) error {
+	rc.mux.Lock()
+	old := rc.store
+	rc.store = store
+	rc.mux.Unlock()
+
+	if old != nil {
+		return old.Close()
+	}
+
+	return nil
+}
+
+// Enabled reports whether the cache has a configured store.
+func (rc *RecordsCache) Enabled() bool {
+	return rc.currentStore() != nil
+}
+
+// Stats returns the cache hits and misses counters grouped by collection name.
+func (rc *RecordsCache) Stats() *cache.Stats {
+	return rc.stats
+}
+
+// Get returns the cached value of the specified key
+// (or [cache.ErrMiss] if missing or the cache is disabled).
+func (rc *RecordsCache) Get(ctx context.Context, key string) ([]byte, error) {
+	store := rc.currentStore()
+	if store == nil {
+		return nil, cache.ErrMiss
+	}
+
+	return store.Get(ctx, "r:"+key)
+}
+
+// Set stores the value of the specified key for the provided ttl
+// (it does nothing if the cache is disabled).
+func (rc *RecordsCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	store := rc.currentStore()
+	if store == nil {
+		return nil
+	}
+
+	return store.Set(ctx, "r:"+key, value, ttl)
+}
+
+// Version returns a combined version string of the specified collections
+// (and the global version) that could be used as part of a cache key.
+func (rc *RecordsCache) Version(ctx context.Context, collectionIds ...string) (string, error) {
+	store := rc.currentStore()
+	if store == nil {
+		return "", nil
+	}
+
+	ids := append([]string{recordsCacheGlobalVersion}, collectionIds...)
+	versions := make([]string, len(ids))
+
+	for i, id := range ids {
+		raw, err := store.Get(ctx, "v:"+id)
+		if err != nil && !errors.Is(err, cache.ErrMiss) {
+			return "", err
+		}
+
+		versions[i] = id + "=" + string(raw)
+	}
+
+	return strings.Join(versions, ","), nil
+}
+
+// Invalidate increments the version of the specified collections
+// making all their dependent cached responses unreachable.
+//
+// If no collection is specified, all cached responses are invalidated.
+func (rc *RecordsCache) Invalidate(ctx context.Context, collectionIds ...string) error {
+	store := rc.currentStore()
+	if store == nil {
+		return nil
+	}
+
+	if len(collectionIds) == 0 {
+		collectionIds = []string{recordsCacheGlobalVersion}
+	}
+
+	for _, id := range collectionIds {
+		if _, err := store.Incr(ctx, "v:"+id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rc *RecordsCache) currentStore() cache.Cache {
+	if rc == nil {
+		return nil
+	}
+
+	rc.mux.RLock()
+	defer rc.mux.RUnlock()
+
+	return rc.store
+}
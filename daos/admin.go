@@ -119,7 +119,21 @@ func (dao *Dao) DeleteAdmin(admin *models.Admin) error {
 		return errors.New("you cannot delete the only existing admin")
 	}
 
-	return dao.Delete(admin)
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.Delete(admin); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteSessionsByOwner("", admin.Id); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteAdminPermissions(admin.Id); err != nil {
+			return err
+		}
+
+		return txDao.deleteRefreshTokensByOwner("", admin.Id)
+	})
 }
 
 // SaveAdmin upserts the provided Admin model.
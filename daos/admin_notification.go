@@ -0,0 +1,125 @@
+package daos
+
+import (
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// AdminNotificationQuery returns a new AdminNotification select query.
+func (dao *Dao) AdminNotificationQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.AdminNotification{})
+}
+
+// FindAdminNotificationById finds a single AdminNotification model by its id.
+func (dao *Dao) FindAdminNotificationById(id string) (*models.AdminNotification, error) {
+	model := &models.AdminNotification{}
+
+	err := dao.AdminNotificationQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindUnreadAdminNotifications returns all unread AdminNotification models
+// updated after the specified date (if not zero) ordered by their last update.
+func (dao *Dao) FindUnreadAdminNotifications(since types.DateTime) ([]*models.AdminNotification, error) {
+	result := []*models.AdminNotification{}
+
+	query := dao.AdminNotificationQuery().
+		AndWhere(dbx.HashExp{"read": false}).
+		OrderBy("updated ASC", "rowid ASC")
+
+	if !since.IsZero() {
+		query.AndWhere(dbx.NewExp("[[updated]] > {:since}", dbx.Params{"since": since.String()}))
+	}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// TotalUnreadAdminNotifications returns the total number of unread AdminNotification models.
+func (dao *Dao) TotalUnreadAdminNotifications() (int, error) {
+	var total int
+
+	err := dao.AdminNotificationQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"read": false}).
+		Row(&total)
+
+	return total, err
+}
+
+// RaiseAdminNotification stores the provided AdminNotification model.
+//
+// If an unread notification with the same source and title already exists,
+// it is updated with the new body and data and its Count is incremented
+// instead of creating a new entry (eg. on repeated errors).
+func (dao *Dao) RaiseAdminNotification(model *models.AdminNotification) error {
+	if model.Title == "" {
+		return errors.New("the admin notification title is required")
+	}
+
+	if model.Severity == "" {
+		model.Severity = models.AdminNotificationSeverityInfo
+	}
+
+	if !list.ExistInSlice(model.Severity, []string{
+		models.AdminNotificationSeverityInfo,
+		models.AdminNotificationSeverityWarning,
+		models.AdminNotificationSeverityError,
+	}) {
+		return errors.New("invalid admin notification severity " + model.Severity)
+	}
+
+	if model.Data == nil {
+		model.Data = types.JsonMap{}
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		existing := &models.AdminNotification{}
+
+		err := txDao.AdminNotificationQuery().
+			AndWhere(dbx.HashExp{
+				"read":   false,
+				"source": model.Source,
+				"title":  model.Title,
+			}).
+			OrderBy("created DESC").
+			Limit(1).
+			One(existing)
+
+		if err == nil {
+			model.Id = existing.Id
+			model.Created = existing.Created
+			model.Count = existing.Count + 1
+			model.MarkAsNotNew()
+		} else {
+			model.Count = 1
+		}
+
+		return txDao.SaveAdminNotification(model)
+	})
+}
+
+// SaveAdminNotification upserts the provided AdminNotification model.
+func (dao *Dao) SaveAdminNotification(model *models.AdminNotification) error {
+	return dao.Save(model)
+}
+
+// DeleteAdminNotification deletes the provided AdminNotification model.
+func (dao *Dao) DeleteAdminNotification(model *models.AdminNotification) error {
+	return dao.Delete(model)
+}
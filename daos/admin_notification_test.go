@@ -0,0 +1,161 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestAdminNotificationQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_adminNotifications}}.* FROM `_adminNotifications`"
+
+	sql := app.Dao().AdminNotificationQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestRaiseAdminNotification(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	invalid := []*models.AdminNotification{
+		{Severity: models.AdminNotificationSeverityInfo},
+		{Severity: "invalid", Title: "test"},
+	}
+	for i, m := range invalid {
+		if err := app.Dao().RaiseAdminNotification(m); err == nil {
+			t.Fatalf("[%d] Expected error, got nil", i)
+		}
+	}
+
+	n1 := &models.AdminNotification{Source: "test", Title: "test1", Body: "a"}
+	if err := app.Dao().RaiseAdminNotification(n1); err != nil {
+		t.Fatal(err)
+	}
+	if n1.Severity != models.AdminNotificationSeverityInfo || n1.Count != 1 {
+		t.Fatalf("Expected new info notification with count 1, got %v", n1)
+	}
+
+	// repeated
+	n2 := &models.AdminNotification{Source: "test", Title: "test1", Body: "b"}
+	if err := app.Dao().RaiseAdminNotification(n2); err != nil {
+		t.Fatal(err)
+	}
+	if n2.Id != n1.Id || n2.Count != 2 {
+		t.Fatalf("Expected the notification %s to be grouped with count 2, got %v", n1.Id, n2)
+	}
+
+	found, err := app.Dao().FindAdminNotificationById(n1.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Body != "b" || found.Count != 2 {
+		t.Fatalf("Expected the stored notification to be updated, got %v", found)
+	}
+
+	// different source
+	n3 := &models.AdminNotification{Source: "other", Title: "test1"}
+	if err := app.Dao().RaiseAdminNotification(n3); err != nil {
+		t.Fatal(err)
+	}
+	if n3.Id == n1.Id || n3.Count != 1 {
+		t.Fatalf("Expected a new notification, got %v", n3)
+	}
+
+	// read notifications are not grouped
+	found.Read = true
+	if err := app.Dao().SaveAdminNotification(found); err != nil {
+		t.Fatal(err)
+	}
+	n4 := &models.AdminNotification{Source: "test", Title: "test1"}
+	if err := app.Dao().RaiseAdminNotification(n4); err != nil {
+		t.Fatal(err)
+	}
+	if n4.Id == n1.Id || n4.Count != 1 {
+		t.Fatalf("Expected a new notification, got %v", n4)
+	}
+
+	total, err := app.Dao().TotalUnreadAdminNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("Expected 2 unread notifications, got %d", total)
+	}
+}
+
+func TestFindUnreadAdminNotifications(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	create := func(title string, read bool, updated time.Time) *models.AdminNotification {
+		m := &models.AdminNotification{
+			Severity: models.AdminNotificationSeverityWarning,
+			Title:    title,
+			Read:     read,
+			Count:    1,
+		}
+		if err := app.Dao().WithoutHooks().SaveAdminNotification(m); err != nil {
+			t.Fatal(err)
+		}
+
+		// the dao save always refreshes the updated date
+		_, err := app.Dao().DB().Update(
+			m.TableName(),
+			dbx.Params{"updated": updated.Format(types.DefaultDateLayout)},
+			dbx.HashExp{"id": m.Id},
+		).Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return m
+	}
+
+	now := time.Now().UTC()
+	create("test1", false, now.Add(-48*time.Hour))
+	create("test2", true, now.Add(-1*time.Hour))
+	create("test3", false, now.Add(-1*time.Hour))
+
+	scenarios := []struct {
+		since    time.Time
+		expected []string
+	}{
+		{time.Time{}, []string{"test1", "test3"}},
+		{now.Add(-24 * time.Hour), []string{"test3"}},
+		{now, nil},
+	}
+
+	for i, s := range scenarios {
+		since, _ := types.ParseDateTime(s.since)
+
+		result, err := app.Dao().FindUnreadAdminNotifications(since)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if len(result) != len(s.expected) {
+			t.Fatalf("[%d] Expected %d notifications, got %d", i, len(s.expected), len(result))
+		}
+
+		for j, title := range s.expected {
+			if result[j].Title != title {
+				t.Fatalf("[%d] Expected notification %q at position %d, got %q", i, title, j, result[j].Title)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package daos
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var adminPermissionRegex = regexp.MustCompile(`^[\w\-\.\:\*]+$`)
+
+// AdminPermissionQuery returns a new AdminPermission select query.
+func (dao *Dao) AdminPermissionQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.AdminPermission{})
+}
+
+// FindAdminPermissions returns all AdminPermission models
+// assigned to the specified admin ordered by their name.
+func (dao *Dao) FindAdminPermissions(adminId string) ([]*models.AdminPermission, error) {
+	result := []*models.AdminPermission{}
+
+	err := dao.AdminPermissionQuery().
+		AndWhere(dbx.HashExp{"adminId": adminId}).
+		OrderBy("permission ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindAdminPermission finds a single AdminPermission model
+// by its admin id and permission name.
+func (dao *Dao) FindAdminPermission(adminId string, permission string) (*models.AdminPermission, error) {
+	model := &models.AdminPermission{}
+
+	err := dao.AdminPermissionQuery().
+		AndWhere(dbx.HashExp{
+			"adminId":    adminId,
+			"permission": permission,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// HasAdminPermission checks whether the specified permission
+// is assigned to the admin.
+func (dao *Dao) HasAdminPermission(adminId string, permission string) bool {
+	_, err := dao.FindAdminPermission(adminId, permission)
+
+	return err == nil
+}
+
+// SaveAdminPermission upserts the provided AdminPermission model.
+func (dao *Dao) SaveAdminPermission(model *models.AdminPermission) error {
+	if model.AdminId == "" {
+		return errors.New("the admin permission adminId is required")
+	}
+
+	if !adminPermissionRegex.MatchString(model.Permission) {
+		return errors.New("invalid admin permission name " + model.Permission)
+	}
+
+	return dao.Save(model)
+}
+
+// DeleteAdminPermission deletes the provided AdminPermission model.
+func (dao *Dao) DeleteAdminPermission(model *models.AdminPermission) error {
+	return dao.Delete(model)
+}
+
+// deleteAdminPermissions deletes all AdminPermission models of the specified admin.
+func (dao *Dao) deleteAdminPermissions(adminId string) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.AdminPermission{}).TableName(), dbx.HashExp{
+		"adminId": adminId,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,97 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAdminPermissionQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_adminPermissions}}.* FROM `_adminPermissions`"
+
+	sql := app.Dao().AdminPermissionQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestAdminPermissionsSaveFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin1, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin2, err := app.Dao().FindAdminByEmail("test2@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalidScenarios := []*models.AdminPermission{
+		{Permission: "logs:read"},
+		{AdminId: admin1.Id},
+		{AdminId: admin1.Id, Permission: "invalid permission"},
+	}
+	for i, m := range invalidScenarios {
+		if err := app.Dao().SaveAdminPermission(m); err == nil {
+			t.Fatalf("[%d] Expected save error, got nil", i)
+		}
+	}
+
+	for _, m := range []*models.AdminPermission{
+		{AdminId: admin1.Id, Permission: "logs:read"},
+		{AdminId: admin1.Id, Permission: "backups:*"},
+		{AdminId: admin2.Id, Permission: "logs:read"},
+	} {
+		if err := app.Dao().SaveAdminPermission(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// duplicated assignment
+	if err := app.Dao().SaveAdminPermission(&models.AdminPermission{AdminId: admin1.Id, Permission: "logs:read"}); err == nil {
+		t.Fatal("Expected duplicated permission error, got nil")
+	}
+
+	permissions, err := app.Dao().FindAdminPermissions(admin1.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(permissions) != 2 || permissions[0].Permission != "backups:*" || permissions[1].Permission != "logs:read" {
+		t.Fatalf("Expected [backups:*, logs:read] permissions, got %v", permissions)
+	}
+
+	if !app.Dao().HasAdminPermission(admin1.Id, "logs:read") {
+		t.Fatal("Expected admin1 to have the logs:read permission")
+	}
+	if app.Dao().HasAdminPermission(admin1.Id, "logs:write") {
+		t.Fatal("Expected admin1 to not have the logs:write permission")
+	}
+
+	if err := app.Dao().DeleteAdminPermission(permissions[1]); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasAdminPermission(admin1.Id, "logs:read") {
+		t.Fatal("Expected the admin1 logs:read permission to be deleted")
+	}
+
+	// the admin permissions are deleted together with the admin
+	if err := app.Dao().DeleteAdmin(admin1); err != nil {
+		t.Fatal(err)
+	}
+	if permissions, _ := app.Dao().FindAdminPermissions(admin1.Id); len(permissions) != 0 {
+		t.Fatalf("Expected the admin1 permissions to be deleted, got %v", permissions)
+	}
+	if !app.Dao().HasAdminPermission(admin2.Id, "logs:read") {
+		t.Fatal("Expected the admin2 permissions to remain unchanged")
+	}
+}
@@ -52,6 +52,14 @@ type Dao struct {
 	AfterUpdateFunc  func(eventDao *Dao, m models.Model) error
 	BeforeDeleteFunc func(eventDao *Dao, m models.Model, action func() error) error
 	AfterDeleteFunc  func(eventDao *Dao, m models.Model) error
+
+	// AfterCommitFunc is an optional callback that is invoked for each
+	// created, updated or deleted model once the change is committed
+	// (immediately for non-transactional writes).
+	//
+	// Unlike the write hooks, it is preserved by [Dao.WithoutHooks]
+	// and it is intended for syncing internal state (eg. caches).
+	AfterCommitFunc func(m models.Model)
 }
 
 // DB returns the default dao db builder (*dbx.DB or *dbx.TX).
@@ -85,7 +93,8 @@ func (dao *Dao) Clone() *Dao {
 }
 
 // WithoutHooks returns a new Dao with the same configuration options
-// as the current one, but without create/update/delete hooks.
+// as the current one, but without create/update/delete hooks
+// (the AfterCommitFunc listener is preserved).
 func (dao *Dao) WithoutHooks() *Dao {
 	clone := dao.Clone()
 
@@ -143,10 +152,12 @@ func (dao *Dao) RunInTransaction(fn func(txDao *Dao) error) error {
 		txDao.AfterCreateFunc = dao.AfterCreateFunc
 		txDao.AfterUpdateFunc = dao.AfterUpdateFunc
 		txDao.AfterDeleteFunc = dao.AfterDeleteFunc
+		txDao.AfterCommitFunc = dao.AfterCommitFunc
 
 		return fn(txDao)
 	case *dbx.DB:
 		afterCalls := []afterCallGroup{}
+		committed := []models.Model{}
 
 		txError := txOrDB.Transactional(func(tx *dbx.Tx) error {
 			txDao := New(tx)
@@ -186,12 +197,22 @@ func (dao *Dao) RunInTransaction(fn func(txDao *Dao) error) error {
 				}
 			}
 
+			if dao.AfterCommitFunc != nil {
+				txDao.AfterCommitFunc = func(m models.Model) {
+					committed = append(committed, m)
+				}
+			}
+
 			return fn(txDao)
 		})
 		if txError != nil {
 			return txError
 		}
 
+		for _, m := range committed {
+			dao.AfterCommitFunc(m)
+		}
+
 		// execute after event calls on successful transaction
 		// (note: using the non-transaction dao to allow following queries in the after hooks)
 		var errs []error
@@ -232,6 +253,8 @@ func (dao *Dao) Delete(m models.Model) error {
 				return err
 			}
 
+			retryDao.afterCommit(m)
+
 			if retryDao.AfterDeleteFunc != nil {
 				retryDao.AfterDeleteFunc(retryDao, m)
 			}
@@ -291,6 +314,8 @@ func (dao *Dao) update(m models.Model) error {
 			return err
 		}
 
+		dao.afterCommit(m)
+
 		if dao.AfterUpdateFunc != nil {
 			return dao.AfterUpdateFunc(dao, m)
 		}
@@ -340,6 +365,8 @@ func (dao *Dao) create(m models.Model) error {
 		// clears the "new" model flag
 		m.MarkAsNotNew()
 
+		dao.afterCommit(m)
+
 		if dao.AfterCreateFunc != nil {
 			return dao.AfterCreateFunc(dao, m)
 		}
@@ -354,6 +381,13 @@ func (dao *Dao) create(m models.Model) error {
 	return action()
 }
 
+// afterCommit notifies the AfterCommitFunc listener (if any) for the changed model.
+func (dao *Dao) afterCommit(m models.Model) {
+	if dao.AfterCommitFunc != nil {
+		dao.AfterCommitFunc(m)
+	}
+}
+
 func (dao *Dao) lockRetry(op func(retryDao *Dao) error) error {
 	retryDao := dao
 
@@ -365,6 +399,7 @@ func (dao *Dao) lockRetry(op func(retryDao *Dao) error) error {
 			retryDao.AfterCreateFunc = dao.AfterCreateFunc
 			retryDao.AfterUpdateFunc = dao.AfterUpdateFunc
 			retryDao.AfterDeleteFunc = dao.AfterDeleteFunc
+			retryDao.AfterCommitFunc = dao.AfterCommitFunc
 		}
 
 		return op(retryDao)
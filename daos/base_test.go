@@ -195,6 +195,67 @@ func TestDaoWithoutHooks(t *testing.T) {
 	}
 }
 
+func TestDaoAfterCommitFunc(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	var calls []string
+
+	dao := daos.NewMultiDB(testApp.Dao().ConcurrentDB(), testApp.Dao().NonconcurrentDB())
+	dao.AfterCommitFunc = func(m models.Model) {
+		calls = append(calls, m.GetId())
+	}
+
+	existingModel, err := dao.FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// non-transactional write without hooks
+	if err := dao.WithoutHooks().Save(existingModel); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 call after the non-transactional write, got %v", calls)
+	}
+
+	// rolled back transaction
+	dao.RunInTransaction(func(txDao *daos.Dao) error {
+		if err := txDao.Save(existingModel); err != nil {
+			t.Fatal(err)
+		}
+		return errors.New("rollback")
+	})
+	if len(calls) != 1 {
+		t.Fatalf("Expected no new calls after the transaction rollback, got %v", calls)
+	}
+
+	// committed (nested) transaction
+	dao.RunInTransaction(func(txDao1 *daos.Dao) error {
+		return txDao1.RunInTransaction(func(txDao2 *daos.Dao) error {
+			newModel := &models.Admin{}
+			newModel.Email = "test_new1@example.com"
+			newModel.SetPassword("123456")
+			if err := txDao2.Save(newModel); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := txDao2.WithoutHooks().Delete(newModel); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(calls) != 1 {
+				t.Fatalf("Expected the calls to be deferred until the transaction commit, got %v", calls)
+			}
+
+			return nil
+		})
+	})
+	if len(calls) != 3 {
+		t.Fatalf("Expected 3 calls after the transaction commit, got %v", calls)
+	}
+}
+
 func TestDaoModelQuery(t *testing.T) {
 	testApp, _ := tests.NewTestApp()
 	defer testApp.Cleanup()
@@ -2,6 +2,8 @@ package daos
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -53,6 +55,46 @@ func (dao *Dao) FindCollectionByNameOrId(nameOrId string) (*models.Collection, e
 	return model, nil
 }
 
+// CollectionsSchemaVersion returns a hash of the schema, indexes,
+// API rules and options of all collections.
+//
+// The returned version changes on each collection create, delete and
+// on each update that modifies any of the hashed collection fields.
+func (dao *Dao) CollectionsSchemaVersion() (string, error) {
+	collections := []*models.Collection{}
+
+	if err := dao.CollectionQuery().OrderBy("id ASC").All(&collections); err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+
+	for _, c := range collections {
+		raw, err := json.Marshal([]any{
+			c.Id,
+			c.Name,
+			c.Type,
+			c.System,
+			c.Schema,
+			c.Indexes,
+			c.ListRule,
+			c.ViewRule,
+			c.CreateRule,
+			c.UpdateRule,
+			c.DeleteRule,
+			c.Options,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		h.Write(raw)
+		h.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // IsCollectionNameUnique checks that there is no existing collection
 // with the provided name (case insensitive!).
 //
@@ -118,12 +160,26 @@ func (dao *Dao) FindCollectionReferences(collection *models.Collection, excludeI
 //
 // NB! The collection cannot be deleted, if:
 // - is system collection (aka. collection.System is true)
+// - is immutable (aka. its records are append-only)
+// - has records under legal hold
 // - is referenced as part of a relation field in another collection
 func (dao *Dao) DeleteCollection(collection *models.Collection) error {
 	if collection.System {
 		return fmt.Errorf("system collection %q cannot be deleted", collection.Name)
 	}
 
+	if collection.Immutable() != nil {
+		return fmt.Errorf("immutable collection %q cannot be deleted", collection.Name)
+	}
+
+	held, err := dao.HasCollectionLegalHolds(collection.Id)
+	if err != nil {
+		return err
+	}
+	if held {
+		return fmt.Errorf("the collection %q has records under legal hold", collection.Name)
+	}
+
 	// ensure that there aren't any existing references.
 	// note: the select is outside of the transaction to prevent SQLITE_LOCKED error when mixing read&write in a single transaction
 	result, err := dao.FindCollectionReferences(collection, collection.Id)
@@ -155,6 +211,10 @@ func (dao *Dao) DeleteCollection(collection *models.Collection) error {
 			return fmt.Errorf("the collection has a view dependency - %w", err)
 		}
 
+		if err := txDao.DeleteRecordChangesByCollection(collection.Id); err != nil {
+			return err
+		}
+
 		return txDao.Delete(collection)
 	})
 }
@@ -164,6 +224,9 @@ func (dao *Dao) DeleteCollection(collection *models.Collection) error {
 //
 // If collection.IsNew() is true, the method will perform a create, otherwise an update.
 // To explicitly mark a collection for update you can use collection.MarkAsNotNew().
+//
+// NB! An existing immutable collection cannot be made mutable again
+// and cannot remove, rename or change the type of its schema fields.
 func (dao *Dao) SaveCollection(collection *models.Collection) error {
 	var oldCollection *models.Collection
 
@@ -175,6 +238,10 @@ func (dao *Dao) SaveCollection(collection *models.Collection) error {
 		if findErr != nil {
 			return findErr
 		}
+
+		if err := validateImmutableCollectionChange(oldCollection, collection); err != nil {
+			return err
+		}
 	}
 
 	txErr := dao.RunInTransaction(func(txDao *Dao) error {
@@ -269,6 +336,10 @@ func (dao *Dao) ImportCollections(
 					}
 					imported.Schema = *schemaClone
 				}
+
+				if err := validateImmutableCollectionChange(existing, imported); err != nil {
+					return err
+				}
 			} else {
 				imported.MarkAsNew()
 			}
@@ -288,6 +359,10 @@ func (dao *Dao) ImportCollections(
 					return fmt.Errorf("system collection %q cannot be deleted", existing.Name)
 				}
 
+				if existing.Immutable() != nil {
+					return fmt.Errorf("immutable collection %q cannot be deleted", existing.Name)
+				}
+
 				// delete the related records table or view
 				if existing.IsView() {
 					if err := txDao.DeleteView(existing.Name); err != nil {
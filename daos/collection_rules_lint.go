@@ -0,0 +1,249 @@
+package daos
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// CollectionRuleLint is the lint and explain result of a single collection API rule.
+type CollectionRuleLint struct {
+	// Name is the rule name (eg. "listRule").
+	Name string `json:"name"`
+
+	// Rule is the rule expression (nil for admins only).
+	Rule *string `json:"rule"`
+
+	// Error is the rule parse or fields resolve error (if any).
+	Error string `json:"error"`
+
+	// Warnings is a list with the rule issues that don't prevent
+	// the rule from being saved but are likely a mistake
+	// (eg. unknown @request.data or @request.auth fields).
+	Warnings []string `json:"warnings"`
+
+	// SQL is the generated records query of the rule with
+	// placeholders for the bound Params ("explain").
+	SQL    string     `json:"sql"`
+	Params dbx.Params `json:"params"`
+}
+
+// HasIssues reports whether the lint result has an error or warnings.
+func (l *CollectionRuleLint) HasIssues() bool {
+	return l.Error != "" || len(l.Warnings) > 0
+}
+
+type collectionRule struct {
+	name string
+	rule *string
+}
+
+// LintCollectionRules parses and resolves the API rules of the provided
+// collection and returns their lint and explain results.
+func (dao *Dao) LintCollectionRules(collection *models.Collection) []*CollectionRuleLint {
+	rules := []collectionRule{
+		{"listRule", collection.ListRule},
+		{"viewRule", collection.ViewRule},
+	}
+
+	if !collection.IsView() {
+		rules = append(
+			rules,
+			collectionRule{"createRule", collection.CreateRule},
+			collectionRule{"updateRule", collection.UpdateRule},
+			collectionRule{"deleteRule", collection.DeleteRule},
+		)
+	}
+
+	if collection.IsAuth() {
+		rules = append(rules, collectionRule{"manageRule", collection.AuthOptions().ManageRule})
+	}
+
+	result := make([]*CollectionRuleLint, 0, len(rules))
+
+	for _, r := range rules {
+		result = append(result, dao.LintCollectionRule(collection, r.name, r.rule))
+	}
+
+	return result
+}
+
+// LintCollectionRule parses and resolves a single collection API rule
+// and returns its lint and explain result.
+func (dao *Dao) LintCollectionRule(collection *models.Collection, name string, rule *string) *CollectionRuleLint {
+	lint := &CollectionRuleLint{
+		Name:     name,
+		Rule:     rule,
+		Warnings: []string{},
+		Params:   dbx.Params{},
+	}
+
+	if rule == nil {
+		return lint // admins only
+	}
+
+	if *rule == "" {
+		if name == "updateRule" || name == "deleteRule" || name == "manageRule" {
+			lint.Warnings = append(lint.Warnings, "The rule is empty and allows the action to everyone (including guests).")
+		}
+		return lint
+	}
+
+	resolver := &lintFieldResolver{
+		RecordFieldResolver: resolvers.NewRecordFieldResolver(dao, collection, nil, true),
+	}
+
+	expr, err := search.FilterData(*rule).BuildExpr(resolver)
+	if err != nil {
+		lint.Error = err.Error()
+		return lint
+	}
+
+	lint.Warnings = dao.lintRuleFields(collection, name, resolver.fields)
+
+	query := dao.RecordQuery(collection)
+	resolver.UpdateQuery(query) // attaches any adhoc joins and aliases
+	query.AndWhere(expr)
+
+	built := query.Build()
+	lint.SQL = dao.quoteExplainSQL(built.SQL())
+	lint.Params = built.Params()
+
+	return lint
+}
+
+// lintRuleFields checks the resolved rule fields and returns
+// the related warnings (if any).
+func (dao *Dao) lintRuleFields(collection *models.Collection, ruleName string, fields []string) []string {
+	warnings := []string{}
+
+	var authFields []string
+
+	for _, field := range fields {
+		if name, ok := lintRequestFieldName(field, "@request.data."); ok {
+			if ruleName == "listRule" || ruleName == "viewRule" || ruleName == "deleteRule" {
+				warnings = append(warnings, fmt.Sprintf("%q is always empty in the %s (the request has no body).", field, ruleName))
+				continue
+			}
+
+			if !list.ExistInSlice(name, lintDataFieldNames(collection)) {
+				warnings = append(warnings, fmt.Sprintf("%q refers to an unknown %s field.", field, collection.Name))
+			}
+
+			continue
+		}
+
+		if name, ok := lintRequestFieldName(field, "@request.auth."); ok {
+			if authFields == nil {
+				authFields = dao.lintAuthFieldNames()
+			}
+
+			if !list.ExistInSlice(name, authFields) {
+				warnings = append(warnings, fmt.Sprintf("%q refers to a field that doesn't exist in any auth collection.", field))
+			}
+		}
+	}
+
+	return list.NonzeroUniques(warnings)
+}
+
+// lintDataFieldNames returns the field names that could be
+// submitted as request data for the provided collection.
+func lintDataFieldNames(collection *models.Collection) []string {
+	names := []string{schema.FieldNameId}
+
+	for _, f := range collection.Schema.Fields() {
+		names = append(names, f.Name)
+	}
+
+	if collection.IsAuth() {
+		names = append(
+			names,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+			"password",
+			"passwordConfirm",
+			"oldPassword",
+		)
+	}
+
+	return names
+}
+
+// lintAuthFieldNames returns the field names available
+// for the @request.auth.* rule identifiers.
+func (dao *Dao) lintAuthFieldNames() []string {
+	names := append(schema.BaseModelFieldNames(), schema.SystemFieldNames()...)
+	names = append(
+		names,
+		schema.FieldNameUsername,
+		schema.FieldNameEmail,
+		schema.FieldNameEmailVisibility,
+		schema.FieldNameVerified,
+	)
+
+	authCollections, _ := dao.FindCollectionsByType(models.CollectionTypeAuth)
+	for _, c := range authCollections {
+		for _, f := range c.Schema.Fields() {
+			names = append(names, f.Name)
+		}
+	}
+
+	return names
+}
+
+// lintRequestFieldName extracts the first field name segment
+// of a static request identifier with the specified prefix
+// (eg. "title" for "@request.data.title:isset").
+func lintRequestFieldName(field string, prefix string) (string, bool) {
+	if !strings.HasPrefix(field, prefix) {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(field, prefix)
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, ":")
+
+	return name, true
+}
+
+var explainQuoteRegex = regexp.MustCompile(`(\{\{[\w\-\. ]+\}\}|\[\[[\w\-\. ]+\]\])`)
+
+// quoteExplainSQL replaces the dbx {{table}} and [[column]] quote
+// tokens of the provided raw query with the db specific quotes.
+func (dao *Dao) quoteExplainSQL(sql string) string {
+	return explainQuoteRegex.ReplaceAllStringFunc(sql, func(m string) string {
+		parts := strings.Split(m[2:len(m)-2], ".")
+		for i, part := range parts {
+			if part != "*" {
+				parts[i] = dao.DB().QuoteSimpleColumnName(part)
+			}
+		}
+
+		return strings.Join(parts, ".")
+	})
+}
+
+// lintFieldResolver is a [resolvers.RecordFieldResolver] that
+// keeps track of all resolved rule fields.
+type lintFieldResolver struct {
+	*resolvers.RecordFieldResolver
+
+	fields []string
+}
+
+// Resolve implements `search.FieldResolver` interface.
+func (r *lintFieldResolver) Resolve(field string) (*search.ResolverResult, error) {
+	r.fields = append(r.fields, field)
+
+	return r.RecordFieldResolver.Resolve(field)
+}
@@ -0,0 +1,191 @@
+package daos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestLintCollectionRules(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collection    string
+		expectedRules []string
+	}{
+		{"demo1", []string{"listRule", "viewRule", "createRule", "updateRule", "deleteRule"}},
+		{"users", []string{"listRule", "viewRule", "createRule", "updateRule", "deleteRule", "manageRule"}},
+		{"view1", []string{"listRule", "viewRule"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.collection, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := app.Dao().LintCollectionRules(collection)
+
+			if len(result) != len(s.expectedRules) {
+				t.Fatalf("Expected %d rules, got %d", len(s.expectedRules), len(result))
+			}
+
+			for i, name := range s.expectedRules {
+				if result[i].Name != name {
+					t.Fatalf("Expected rule %d to be %q, got %q", i, name, result[i].Name)
+				}
+
+				if result[i].Error != "" {
+					t.Fatalf("Expected no error for %q, got %q", name, result[i].Error)
+				}
+			}
+		})
+	}
+}
+
+func TestLintCollectionRule(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name             string
+		ruleName         string
+		rule             *string
+		expectError      bool
+		expectedWarnings []string
+		expectedSQL      []string
+	}{
+		{
+			"admins only",
+			"listRule",
+			nil,
+			false,
+			nil,
+			nil,
+		},
+		{
+			"empty list rule",
+			"listRule",
+			types.Pointer(""),
+			false,
+			nil,
+			nil,
+		},
+		{
+			"empty delete rule",
+			"deleteRule",
+			types.Pointer(""),
+			false,
+			[]string{"allows the action to everyone"},
+			nil,
+		},
+		{
+			"unknown field",
+			"listRule",
+			types.Pointer("missing = 1"),
+			true,
+			nil,
+			nil,
+		},
+		{
+			"valid rule",
+			"viewRule",
+			types.Pointer("title = @request.auth.id"),
+			false,
+			nil,
+			[]string{"SELECT `demo2`.* FROM `demo2` WHERE `demo2`.`title` = {:"},
+		},
+		{
+			"request data in list rule",
+			"listRule",
+			types.Pointer("title = @request.data.title"),
+			false,
+			[]string{`"@request.data.title" is always empty in the listRule`},
+			[]string{"`demo2`.`title`"},
+		},
+		{
+			"unknown request data field",
+			"updateRule",
+			types.Pointer("@request.data.title:isset = false && @request.data.missing:isset = false"),
+			false,
+			[]string{`"@request.data.missing:isset" refers to an unknown demo2 field`},
+			nil,
+		},
+		{
+			"unknown request auth field",
+			"createRule",
+			types.Pointer("@request.auth.name != '' && @request.auth.missing != ''"),
+			false,
+			[]string{`"@request.auth.missing" refers to a field that doesn't exist in any auth collection`},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			lint := app.Dao().LintCollectionRule(demo2, s.ruleName, s.rule)
+
+			if lint.Name != s.ruleName {
+				t.Fatalf("Expected name %q, got %q", s.ruleName, lint.Name)
+			}
+
+			hasErr := lint.Error != ""
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, lint.Error)
+			}
+
+			assertLintStrings(t, "warnings", lint.Warnings, s.expectedWarnings)
+
+			for _, str := range s.expectedSQL {
+				if !strings.Contains(lint.SQL, str) {
+					t.Fatalf("Cannot find %q in the sql %q", str, lint.SQL)
+				}
+			}
+		})
+	}
+}
+
+func assertLintStrings(t *testing.T, name string, values []string, expected []string) {
+	if len(values) != len(expected) {
+		t.Fatalf("Expected %d %s, got %d (%v)", len(expected), name, len(values), values)
+	}
+
+	for i, str := range expected {
+		if !strings.Contains(values[i], str) {
+			t.Fatalf("Expected %s %d to contain %q, got %q", name, i, str, values[i])
+		}
+	}
+}
+
+func TestCollectionRuleLintHasIssues(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		lint     *daos.CollectionRuleLint
+		expected bool
+	}{
+		{&daos.CollectionRuleLint{}, false},
+		{&daos.CollectionRuleLint{Error: "test"}, true},
+		{&daos.CollectionRuleLint{Warnings: []string{"test"}}, true},
+	}
+
+	for i, s := range scenarios {
+		if v := s.lint.HasIssues(); v != s.expected {
+			t.Fatalf("[%d] Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
@@ -123,6 +123,51 @@ func TestIsCollectionNameUnique(t *testing.T) {
 	}
 }
 
+func TestCollectionsSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	v1, err := app.Dao().CollectionsSchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v1) != 64 {
+		t.Fatalf("Expected 64 chars hex version, got %q", v1)
+	}
+
+	// unchanged
+	v2, _ := app.Dao().CollectionsSchemaVersion()
+	if v1 != v2 {
+		t.Fatalf("Expected the same version, got %q and %q", v1, v2)
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// resave without changes
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	v3, _ := app.Dao().CollectionsSchemaVersion()
+	if v1 != v3 {
+		t.Fatalf("Expected the version to remain the same after resave, got %q and %q", v1, v3)
+	}
+
+	// rule change
+	collection.ListRule = types.Pointer("id != ''")
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	v4, _ := app.Dao().CollectionsSchemaVersion()
+	if v4 == v3 {
+		t.Fatal("Expected the version to change after rule change")
+	}
+}
+
 func TestFindCollectionReferences(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,42 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// EmailEventQuery returns a new EmailEvent select query.
+func (dao *Dao) EmailEventQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.EmailEvent{})
+}
+
+// FindEmailEventsByEmail returns all EmailEvent models of the
+// specified email address ordered by their created date (most recent first).
+//
+// Optionally you can specify a list of event types to filter.
+func (dao *Dao) FindEmailEventsByEmail(email string, eventTypes ...string) ([]*models.EmailEvent, error) {
+	result := []*models.EmailEvent{}
+
+	query := dao.EmailEventQuery().
+		AndWhere(dbx.HashExp{"email": email}).
+		OrderBy("created DESC", "rowid DESC")
+
+	if len(eventTypes) > 0 {
+		types := make([]any, len(eventTypes))
+		for i, t := range eventTypes {
+			types[i] = t
+		}
+		query.AndWhere(dbx.In("type", types...))
+	}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveEmailEvent upserts the provided EmailEvent model.
+func (dao *Dao) SaveEmailEvent(model *models.EmailEvent) error {
+	return dao.Save(model)
+}
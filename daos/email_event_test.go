@@ -0,0 +1,63 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestEmailEventQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_email_events}}.* FROM `_email_events`"
+
+	sql := app.Dao().EmailEventQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindEmailEventsByEmail(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for _, item := range [][2]string{
+		{"a@example.com", "bounce"},
+		{"a@example.com", "delivered"},
+		{"a@example.com", "complaint"},
+		{"b@example.com", "bounce"},
+	} {
+		m := &models.EmailEvent{Provider: "ses", Email: item[0], Type: item[1]}
+		if err := app.Dao().SaveEmailEvent(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		email    string
+		types    []string
+		expected int
+	}{
+		{"missing@example.com", nil, 0},
+		{"a@example.com", nil, 3},
+		{"a@example.com", []string{"bounce", "complaint"}, 2},
+		{"b@example.com", []string{"complaint"}, 0},
+	}
+
+	for i, s := range scenarios {
+		events, err := app.Dao().FindEmailEventsByEmail(s.email, s.types...)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if len(events) != s.expected {
+			t.Fatalf("[%d] Expected %d events, got %d", i, s.expected, len(events))
+		}
+	}
+}
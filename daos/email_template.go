@@ -0,0 +1,102 @@
+package daos
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// EmailTemplateQuery returns a new EmailTemplate select query.
+func (dao *Dao) EmailTemplateQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.EmailTemplate{})
+}
+
+// FindEmailTemplateById finds a single EmailTemplate model by its id.
+func (dao *Dao) FindEmailTemplateById(id string) (*models.EmailTemplate, error) {
+	model := &models.EmailTemplate{}
+
+	err := dao.EmailTemplateQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllEmailTemplates returns all stored EmailTemplate models
+// ordered by their action and language.
+func (dao *Dao) FindAllEmailTemplates() ([]*models.EmailTemplate, error) {
+	result := []*models.EmailTemplate{}
+
+	err := dao.EmailTemplateQuery().
+		OrderBy("action ASC", "language ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindEmailTemplate finds the best matching EmailTemplate model
+// for the specified action and language.
+//
+// The lookup falls back in the following order:
+//   - exact language match (eg. "pt-BR")
+//   - base language match (eg. "pt")
+//   - template with empty language
+func (dao *Dao) FindEmailTemplate(action string, language string) (*models.EmailTemplate, error) {
+	// ordered from the most to the least specific
+	candidates := []string{}
+	if language != "" {
+		candidates = append(candidates, language)
+
+		if base, _, ok := strings.Cut(language, "-"); ok && base != "" {
+			candidates = append(candidates, base)
+		}
+	}
+	candidates = append(candidates, "")
+
+	values := make([]any, len(candidates))
+	for i, c := range candidates {
+		values[i] = c
+	}
+
+	templates := []*models.EmailTemplate{}
+
+	err := dao.EmailTemplateQuery().
+		AndWhere(dbx.HashExp{"action": action}).
+		AndWhere(dbx.In("language", values...)).
+		All(&templates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range candidates {
+		for _, t := range templates {
+			if t.Language == c {
+				return t, nil
+			}
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+// SaveEmailTemplate upserts the provided EmailTemplate model.
+func (dao *Dao) SaveEmailTemplate(model *models.EmailTemplate) error {
+	return dao.Save(model)
+}
+
+// DeleteEmailTemplate deletes the provided EmailTemplate model.
+func (dao *Dao) DeleteEmailTemplate(model *models.EmailTemplate) error {
+	return dao.Delete(model)
+}
@@ -0,0 +1,90 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestEmailTemplateQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_emailTemplates}}.* FROM `_emailTemplates`"
+
+	sql := app.Dao().EmailTemplateQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindEmailTemplate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for _, lang := range []string{"", "pt", "pt-BR", "de"} {
+		m := &models.EmailTemplate{
+			Action:   "verification",
+			Language: lang,
+			Subject:  "subject_" + lang,
+			Html:     "html_" + lang,
+		}
+		if err := app.Dao().SaveEmailTemplate(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		action           string
+		language         string
+		expectedLanguage string
+		expectError      bool
+	}{
+		{"verification", "", "", false},
+		{"verification", "pt-BR", "pt-BR", false},
+		{"verification", "pt-PT", "pt", false},
+		{"verification", "pt", "pt", false},
+		{"verification", "de-AT", "de", false},
+		{"verification", "fr", "", false},
+		{"missing", "pt-BR", "", true},
+	}
+
+	for i, s := range scenarios {
+		template, err := app.Dao().FindEmailTemplate(s.action, s.language)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if hasErr {
+			continue
+		}
+
+		if template.Language != s.expectedLanguage {
+			t.Errorf("[%d] Expected template language %q, got %q", i, s.expectedLanguage, template.Language)
+		}
+	}
+
+	all, err := app.Dao().FindAllEmailTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("Expected 4 templates, got %d", len(all))
+	}
+
+	if err := app.Dao().DeleteEmailTemplate(all[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindEmailTemplateById(all[0].Id); err == nil {
+		t.Fatal("Expected the template to be deleted")
+	}
+}
@@ -0,0 +1,183 @@
+package daos
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// kvNotExpiredExp returns an expression matching the
+// non-expiring and the not yet expired kv entries.
+func kvNotExpiredExp(now types.DateTime) dbx.Expression {
+	return dbx.NewExp(
+		"([[expires]] = '' OR [[expires]] > {:now})",
+		dbx.Params{"now": now.String()},
+	)
+}
+
+// kvExpires returns the expiration date string of a kv entry
+// with the provided ttl (empty string for zero ttl, aka. no expiration).
+func kvExpires(now time.Time, ttl time.Duration) (string, error) {
+	if ttl == 0 {
+		return "", nil
+	}
+
+	expires, err := types.ParseDateTime(now.Add(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	return expires.String(), nil
+}
+
+// FindKVValue returns the json encoded value of the key-value store
+// entry with the specified key.
+//
+// Returns nil (and no error) if the entry doesn't exist or it has expired.
+func (dao *Dao) FindKVValue(key string) (types.JsonRaw, error) {
+	now, err := types.ParseDateTime(time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	var value types.JsonRaw
+
+	err = dao.DB().Select("value").
+		From("_kv").
+		Where(dbx.HashExp{"key": key}).
+		AndWhere(kvNotExpiredExp(now)).
+		Row(&value)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+// SetKVValue creates or replaces the key-value store entry with
+// the specified key and json serializable value.
+//
+// The entry expires after the provided ttl (zero ttl means no expiration).
+//
+// The expired entries are removed on each call.
+func (dao *Dao) SetKVValue(key string, value any, ttl time.Duration) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	expires, err := kvExpires(now, ttl)
+	if err != nil {
+		return err
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.DeleteExpiredKVValues(now); err != nil {
+			return err
+		}
+
+		_, err := txDao.DB().NewQuery(`
+			INSERT INTO {{_kv}} ([[key]], [[value]], [[expires]])
+			VALUES ({:key}, {:value}, {:expires})
+			ON CONFLICT ([[key]]) DO UPDATE SET
+				[[value]]   = excluded.[[value]],
+				[[expires]] = excluded.[[expires]]
+		`).Bind(dbx.Params{
+			"key":     key,
+			"value":   string(raw),
+			"expires": expires,
+		}).Execute()
+
+		return err
+	})
+}
+
+// IncrementKVValue atomically increments the integer value of the key-value
+// store entry with the specified key by delta and returns its new value.
+//
+// Missing or expired entries are treated as 0.
+//
+// If ttl is non-zero the entry expiration is reset, otherwise
+// the existing entry expiration (if any) is preserved.
+//
+// Returns an error if the existing entry value is not an integer.
+func (dao *Dao) IncrementKVValue(key string, delta int64, ttl time.Duration) (int64, error) {
+	var result int64
+
+	err := dao.RunInTransaction(func(txDao *Dao) error {
+		now, err := types.ParseDateTime(time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		row := struct {
+			Value   types.JsonRaw `db:"value"`
+			Expires string        `db:"expires"`
+		}{}
+
+		err = txDao.DB().Select("value", "expires").
+			From("_kv").
+			Where(dbx.HashExp{"key": key}).
+			AndWhere(kvNotExpiredExp(now)).
+			One(&row)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		var current int64
+		if len(row.Value) > 0 && string(row.Value) != "null" {
+			if err := json.Unmarshal(row.Value, &current); err != nil {
+				return errors.New("the stored value is not an integer")
+			}
+		}
+
+		result = current + delta
+
+		expires := row.Expires
+		if ttl != 0 {
+			if expires, err = kvExpires(now.Time(), ttl); err != nil {
+				return err
+			}
+		}
+
+		_, err = txDao.DB().NewQuery(`
+			INSERT INTO {{_kv}} ([[key]], [[value]], [[expires]])
+			VALUES ({:key}, {:value}, {:expires})
+			ON CONFLICT ([[key]]) DO UPDATE SET
+				[[value]]   = excluded.[[value]],
+				[[expires]] = excluded.[[expires]]
+		`).Bind(dbx.Params{
+			"key":     key,
+			"value":   result,
+			"expires": expires,
+		}).Execute()
+
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteKVValue deletes the key-value store entry with the specified key (if exists).
+func (dao *Dao) DeleteKVValue(key string) error {
+	_, err := dao.DB().Delete("_kv", dbx.HashExp{"key": key}).Execute()
+
+	return err
+}
+
+// DeleteExpiredKVValues deletes all key-value store entries that have expired before expiredBefore.
+func (dao *Dao) DeleteExpiredKVValues(expiredBefore time.Time) error {
+	formattedDate := expiredBefore.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp("[[expires]] != '' AND [[expires]] <= {:date}", dbx.Params{"date": formattedDate})
+
+	_, err := dao.DB().Delete("_kv", expr).Execute()
+
+	return err
+}
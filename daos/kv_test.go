@@ -0,0 +1,125 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestKVValue(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if v, err := app.Dao().FindKVValue("missing"); err != nil || v != nil {
+		t.Fatalf("Expected nil missing value, got %q (%v)", v, err)
+	}
+
+	scenarios := []struct {
+		name     string
+		key      string
+		value    any
+		ttl      time.Duration
+		expected string
+	}{
+		{"string", "a", "test", 0, `"test"`},
+		{"number", "b", 123.5, time.Minute, `123.5`},
+		{"object", "c", map[string]any{"x": []int{1, 2}}, 0, `{"x":[1,2]}`},
+		{"null", "d", nil, 0, `null`},
+		{"replace", "a", true, 0, `true`},
+		{"expired", "e", "test", -time.Second, ``},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if err := app.Dao().SetKVValue(s.key, s.value, s.ttl); err != nil {
+				t.Fatal(err)
+			}
+
+			v, err := app.Dao().FindKVValue(s.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str := string(v); str != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, str)
+			}
+		})
+	}
+
+	if err := app.Dao().DeleteKVValue("a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := app.Dao().FindKVValue("a"); v != nil {
+		t.Fatalf("Expected the deleted value to be missing, got %q", v)
+	}
+
+	// the expired entries are deleted on set
+	if err := app.Dao().SetKVValue("f", 1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := app.Dao().DB().Select("count(*)").From("_kv").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 4 {
+		t.Fatalf("Expected 4 stored entries (b, c, d, f), got %d", total)
+	}
+}
+
+func TestIncrementKVValue(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		key         string
+		delta       int64
+		ttl         time.Duration
+		expectError bool
+		expected    int64
+	}{
+		{"new entry", "a", 1, 0, false, 1},
+		{"existing entry", "a", 5, 0, false, 6},
+		{"negative delta", "a", -10, time.Minute, false, -4},
+		{"existing entry with preserved ttl", "a", 1, 0, false, -3},
+		{"expired entry", "b", 2, -time.Second, false, 2},
+		{"recreate expired entry", "b", 2, 0, false, 2},
+		{"non integer entry", "c", 1, 0, true, 0},
+	}
+
+	if err := app.Dao().SetKVValue("c", "test", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			v, err := app.Dao().IncrementKVValue(s.key, s.delta, s.ttl)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && v != s.expected {
+				t.Fatalf("Expected %d, got %d", s.expected, v)
+			}
+		})
+	}
+
+	// the "a" entry ttl should have been preserved
+	var expires string
+	if err := app.Dao().DB().Select("expires").From("_kv").Where(dbx.HashExp{"key": "a"}).Row(&expires); err != nil {
+		t.Fatal(err)
+	}
+	if expires == "" {
+		t.Fatal("Expected the entry expiration to be preserved")
+	}
+}
@@ -0,0 +1,122 @@
+package daos
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// ErrRecordUnderLegalHold is returned when trying to delete
+// a record (or its files) that is under legal hold.
+var ErrRecordUnderLegalHold = errors.New("the record is under legal hold and cannot be deleted")
+
+// LegalHoldQuery returns a new LegalHold select query.
+func (dao *Dao) LegalHoldQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.LegalHold{})
+}
+
+// FindLegalHoldById finds a single LegalHold model by its id.
+func (dao *Dao) FindLegalHoldById(id string) (*models.LegalHold, error) {
+	model := &models.LegalHold{}
+
+	err := dao.LegalHoldQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindLegalHoldsByIds finds all LegalHold models by the provided ids.
+//
+// If no ids are provided, returns all LegalHold models.
+func (dao *Dao) FindLegalHoldsByIds(ids []string) ([]*models.LegalHold, error) {
+	result := []*models.LegalHold{}
+
+	query := dao.LegalHoldQuery().OrderBy("created ASC")
+
+	if len(ids) > 0 {
+		query.AndWhere(dbx.In("id", list.ToInterfaceSlice(ids)...))
+	}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindLegalHoldsByRecord returns all LegalHold models of the specified record.
+func (dao *Dao) FindLegalHoldsByRecord(collectionId string, recordId string) ([]*models.LegalHold, error) {
+	result := []*models.LegalHold{}
+
+	err := dao.LegalHoldQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IsRecordUnderLegalHold checks whether the specified
+// record has at least one legal hold.
+func (dao *Dao) IsRecordUnderLegalHold(collectionId string, recordId string) (bool, error) {
+	var exists bool
+
+	err := dao.LegalHoldQuery().
+		Select("(1)").
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		Limit(1).
+		Row(&exists)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// HasCollectionLegalHolds checks whether any of
+// the specified collection records is under legal hold.
+func (dao *Dao) HasCollectionLegalHolds(collectionId string) (bool, error) {
+	var exists bool
+
+	err := dao.LegalHoldQuery().
+		Select("(1)").
+		AndWhere(dbx.HashExp{"collectionId": collectionId}).
+		Limit(1).
+		Row(&exists)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// SaveLegalHold upserts the provided LegalHold model.
+func (dao *Dao) SaveLegalHold(model *models.LegalHold) error {
+	return dao.Save(model)
+}
+
+// DeleteLegalHold deletes (aka. releases) the provided LegalHold model.
+func (dao *Dao) DeleteLegalHold(model *models.LegalHold) error {
+	return dao.Delete(model)
+}
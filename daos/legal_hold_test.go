@@ -0,0 +1,127 @@
+package daos_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestLegalHoldFinders(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	holds := []*models.LegalHold{
+		{CollectionId: "wsmn24bux7wo113", RecordId: "84nmscqy84lsi1t", Reason: "case_1"},
+		{CollectionId: "wsmn24bux7wo113", RecordId: "84nmscqy84lsi1t", Reason: "case_2"},
+		{CollectionId: "wsmn24bux7wo113", RecordId: "al1h9ijdeojtsjy", Reason: "case_3"},
+	}
+	for _, hold := range holds {
+		if err := app.Dao().SaveLegalHold(hold); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	byRecord, err := app.Dao().FindLegalHoldsByRecord("wsmn24bux7wo113", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byRecord) != 2 {
+		t.Fatalf("Expected 2 record holds, got %d", len(byRecord))
+	}
+
+	all, err := app.Dao().FindLegalHoldsByIds(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("Expected 3 holds, got %d", len(all))
+	}
+
+	byIds, err := app.Dao().FindLegalHoldsByIds([]string{holds[0].Id, holds[2].Id, "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byIds) != 2 {
+		t.Fatalf("Expected 2 holds, got %d", len(byIds))
+	}
+
+	scenarios := []struct {
+		collectionId string
+		recordId     string
+		expected     bool
+	}{
+		{"wsmn24bux7wo113", "84nmscqy84lsi1t", true},
+		{"wsmn24bux7wo113", "al1h9ijdeojtsjy", true},
+		{"wsmn24bux7wo113", "missing", false},
+		{"missing", "84nmscqy84lsi1t", false},
+	}
+
+	for i, s := range scenarios {
+		held, err := app.Dao().IsRecordUnderLegalHold(s.collectionId, s.recordId)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+		if held != s.expected {
+			t.Fatalf("[%d] Expected %v, got %v", i, s.expected, held)
+		}
+	}
+
+	if held, _ := app.Dao().HasCollectionLegalHolds("wsmn24bux7wo113"); !held {
+		t.Fatal("Expected the collection to have legal holds")
+	}
+	if held, _ := app.Dao().HasCollectionLegalHolds("sz5l5z67tg7gku0"); held {
+		t.Fatal("Expected the collection to not have legal holds")
+	}
+}
+
+func TestLegalHoldDeleteRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	hold := &models.LegalHold{CollectionId: "wsmn24bux7wo113", RecordId: "84nmscqy84lsi1t"}
+	if err := app.Dao().SaveLegalHold(hold); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); !errors.Is(err, daos.ErrRecordUnderLegalHold) {
+		t.Fatalf("Expected ErrRecordUnderLegalHold, got %v", err)
+	}
+
+	// cascade delete (the users record is referenced by the held demo1 record)
+	user, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteRecord(user); !errors.Is(err, daos.ErrRecordUnderLegalHold) {
+		t.Fatalf("Expected cascade ErrRecordUnderLegalHold, got %v", err)
+	}
+	if _, err := app.Dao().FindRecordById("users", "oap640cot4yru2s"); err != nil {
+		t.Fatalf("Expected the user delete to be rolled back, got %v", err)
+	}
+
+	if err := app.Dao().DeleteCollection(record.Collection()); err == nil || !strings.Contains(err.Error(), "legal hold") {
+		t.Fatalf("Expected legal hold collection delete error, got %v", err)
+	}
+
+	// release
+	if err := app.Dao().DeleteLegalHold(hold); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatalf("Expected the released record to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// AcquireLock tries to acquire (or extend) the lock with the specified
+// key for the provided owner (eg. app instance id) and ttl.
+//
+// It reports whether the lock was acquired, aka. whether the lock
+// didn't exist, it was expired or it was already held by the owner.
+//
+// The expired locks are removed on each call.
+func (dao *Dao) AcquireLock(key string, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+
+	nowDate, err := types.ParseDateTime(now)
+	if err != nil {
+		return false, err
+	}
+
+	expires, err := types.ParseDateTime(now.Add(ttl))
+	if err != nil {
+		return false, err
+	}
+
+	acquired := false
+
+	err = dao.RunInTransaction(func(txDao *Dao) error {
+		_, err := txDao.DB().Delete("_locks", dbx.NewExp(
+			"[[expires]] < {:now}",
+			dbx.Params{"now": nowDate.String()},
+		)).Execute()
+		if err != nil {
+			return err
+		}
+
+		result, err := txDao.DB().NewQuery(`
+			INSERT INTO {{_locks}} ([[key]], [[owner]], [[expires]])
+			VALUES ({:key}, {:owner}, {:expires})
+			ON CONFLICT ([[key]]) DO UPDATE SET [[expires]] = excluded.[[expires]]
+			WHERE {{_locks}}.[[owner]] = excluded.[[owner]]
+		`).Bind(dbx.Params{
+			"key":     key,
+			"owner":   owner,
+			"expires": expires.String(),
+		}).Execute()
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		acquired = affected > 0
+
+		return nil
+	})
+
+	return acquired, err
+}
+
+// ReleaseLock deletes the lock with the specified key
+// (only if it is held by the provided owner).
+func (dao *Dao) ReleaseLock(key string, owner string) error {
+	_, err := dao.DB().Delete("_locks", dbx.HashExp{
+		"key":   key,
+		"owner": owner,
+	}).Execute()
+
+	return err
+}
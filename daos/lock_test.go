@@ -0,0 +1,59 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAcquireAndReleaseLock(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name     string
+		key      string
+		owner    string
+		ttl      time.Duration
+		expected bool
+	}{
+		{"new lock", "test", "node1", time.Minute, true},
+		{"held by another owner", "test", "node2", time.Minute, false},
+		{"extend by the same owner", "test", "node1", time.Minute, true},
+		{"different key", "test2", "node2", time.Minute, true},
+		{"expired lock", "test3", "node1", -time.Second, true},
+		{"acquire expired lock", "test3", "node2", time.Minute, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			acquired, err := app.Dao().AcquireLock(s.key, s.owner, s.ttl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if acquired != s.expected {
+				t.Fatalf("Expected acquired %v, got %v", s.expected, acquired)
+			}
+		})
+	}
+
+	// release by non-owner
+	if err := app.Dao().ReleaseLock("test", "node2"); err != nil {
+		t.Fatal(err)
+	}
+	if acquired, _ := app.Dao().AcquireLock("test", "node2", time.Minute); acquired {
+		t.Fatal("Expected the lock to be still held by node1")
+	}
+
+	// release by owner
+	if err := app.Dao().ReleaseLock("test", "node1"); err != nil {
+		t.Fatal(err)
+	}
+	if acquired, _ := app.Dao().AcquireLock("test", "node2", time.Minute); !acquired {
+		t.Fatal("Expected the released lock to be acquired by node2")
+	}
+}
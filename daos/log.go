@@ -1,11 +1,18 @@
 package daos
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
 )
 
 // LogQuery returns a new Log select query.
@@ -29,6 +36,133 @@ func (dao *Dao) FindLogById(id string) (*models.Log, error) {
 	return model, nil
 }
 
+// LogsFilter defines common logs search criteria.
+//
+// All nonempty criteria are combined with AND.
+type LogsFilter struct {
+	// MinLevel filters the logs with level >= MinLevel (if set).
+	MinLevel *int
+
+	// From and To filter the logs by their created date (inclusive).
+	From types.DateTime
+	To   types.DateTime
+
+	// Message filters the logs by message substring (case-insensitive).
+	Message string
+
+	// Data filters the logs by structured attribute values
+	// (eg. {"jobId": "abc", "error.code": "500"}).
+	//
+	// The values are compared as text.
+	Data map[string]string
+}
+
+// BuildExpr builds and returns a db expression from the filter criteria.
+//
+// Returns nil if there are no criteria to apply.
+func (f LogsFilter) BuildExpr() dbx.Expression {
+	exprs := []dbx.Expression{}
+
+	if f.MinLevel != nil {
+		exprs = append(exprs, dbx.NewExp("[[level]] >= {:minLevel}", dbx.Params{"minLevel": *f.MinLevel}))
+	}
+
+	if !f.From.IsZero() {
+		exprs = append(exprs, dbx.NewExp("[[created]] >= {:from}", dbx.Params{"from": f.From.String()}))
+	}
+
+	if !f.To.IsZero() {
+		exprs = append(exprs, dbx.NewExp("[[created]] <= {:to}", dbx.Params{"to": f.To.String()}))
+	}
+
+	if f.Message != "" {
+		exprs = append(exprs, dbx.Like("message", f.Message))
+	}
+
+	// sort the keys for consistent params naming
+	keys := make([]string, 0, len(f.Data))
+	for k := range f.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		pathParam := fmt.Sprintf("dataPath%d", i)
+		valueParam := fmt.Sprintf("dataValue%d", i)
+
+		exprs = append(exprs, dbx.NewExp(
+			fmt.Sprintf("CAST(JSON_EXTRACT([[data]], {:%s}) AS TEXT) = {:%s}", pathParam, valueParam),
+			dbx.Params{
+				pathParam:  "$." + strings.Trim(key, "."),
+				valueParam: f.Data[key],
+			},
+		))
+	}
+
+	if len(exprs) == 0 {
+		return nil
+	}
+
+	return dbx.And(exprs...)
+}
+
+// Match reports whether the provided log satisfies the filter criteria.
+//
+// It is intended to be used for filtering logs that are not persisted yet
+// (eg. live logs streaming) and mirrors the [LogsFilter.BuildExpr] conditions.
+func (f LogsFilter) Match(log *models.Log) bool {
+	if f.MinLevel != nil && log.Level < *f.MinLevel {
+		return false
+	}
+
+	if !f.From.IsZero() && log.Created.Time().Before(f.From.Time()) {
+		return false
+	}
+
+	if !f.To.IsZero() && log.Created.Time().After(f.To.Time()) {
+		return false
+	}
+
+	if f.Message != "" && !strings.Contains(strings.ToLower(log.Message), strings.ToLower(f.Message)) {
+		return false
+	}
+
+	for key, expected := range f.Data {
+		var value any = map[string]any(log.Data)
+
+		for _, part := range strings.Split(strings.Trim(key, "."), ".") {
+			switch m := value.(type) {
+			case map[string]any:
+				value = m[part]
+			case types.JsonMap:
+				value = m[part]
+			default:
+				value = nil
+			}
+		}
+
+		if value == nil || cast.ToString(value) != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseLogLevel parses a numeric or named (debug, info, warn, error) log level.
+func ParseLogLevel(raw string) (int, error) {
+	if level, err := strconv.Atoi(raw); err == nil {
+		return level, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(raw))); err != nil {
+		return 0, errors.New("invalid level")
+	}
+
+	return int(level), nil
+}
+
 type LogsStatsItem struct {
 	Total int            `db:"total" json:"total"`
 	Date  types.DateTime `db:"date" json:"date"`
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/types"
@@ -80,6 +81,107 @@ func TestLogsStats(t *testing.T) {
 	}
 }
 
+func TestLogsFilterBuildExpr(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tests.MockLogsData(app)
+
+	minLevel := 4
+	from, _ := types.ParseDateTime("2022-05-02 00:00:00.000Z")
+	to, _ := types.ParseDateTime("2022-05-01 23:00:00.000Z")
+
+	scenarios := []struct {
+		name     string
+		filter   daos.LogsFilter
+		expected []string
+	}{
+		{"empty", daos.LogsFilter{}, []string{"873f2133-9f38-44fb-bf82-c8f53b310d91", "f2133873-44fb-9f38-bf82-c918f53b310d"}},
+		{"min level", daos.LogsFilter{MinLevel: &minLevel}, []string{"f2133873-44fb-9f38-bf82-c918f53b310d"}},
+		{"from", daos.LogsFilter{From: from}, []string{"f2133873-44fb-9f38-bf82-c918f53b310d"}},
+		{"to", daos.LogsFilter{To: to}, []string{"873f2133-9f38-44fb-bf82-c8f53b310d91"}},
+		{"message", daos.LogsFilter{Message: "MESSAGE1"}, []string{"873f2133-9f38-44fb-bf82-c8f53b310d91"}},
+		{"data", daos.LogsFilter{Data: map[string]string{"status": "400"}}, []string{"f2133873-44fb-9f38-bf82-c918f53b310d"}},
+		{"combined", daos.LogsFilter{MinLevel: &minLevel, Data: map[string]string{"status": "200"}}, []string{}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			query := app.LogsDao().LogQuery().OrderBy("rowid ASC")
+
+			if expr := s.filter.BuildExpr(); expr != nil {
+				query.AndWhere(expr)
+			}
+
+			logs := []*models.Log{}
+			if err := query.All(&logs); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(logs) != len(s.expected) {
+				t.Fatalf("Expected %d logs, got %d", len(s.expected), len(logs))
+			}
+
+			for i, id := range s.expected {
+				if logs[i].Id != id {
+					t.Fatalf("Expected log %s, got %s", id, logs[i].Id)
+				}
+			}
+		})
+	}
+}
+
+func TestLogsFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	created, _ := types.ParseDateTime("2022-05-01 10:00:00.000Z")
+
+	log := &models.Log{
+		Level:   4,
+		Message: "Test_Message",
+		Data: types.JsonMap{
+			"status": 400,
+			"auth":   map[string]any{"id": "abc"},
+		},
+	}
+	log.Created = created
+
+	minLevel := 4
+	maxLevel := 8
+	before, _ := types.ParseDateTime("2022-05-01 09:00:00.000Z")
+	after, _ := types.ParseDateTime("2022-05-01 11:00:00.000Z")
+
+	scenarios := []struct {
+		name     string
+		filter   daos.LogsFilter
+		expected bool
+	}{
+		{"empty", daos.LogsFilter{}, true},
+		{"min level match", daos.LogsFilter{MinLevel: &minLevel}, true},
+		{"min level mismatch", daos.LogsFilter{MinLevel: &maxLevel}, false},
+		{"from match", daos.LogsFilter{From: before}, true},
+		{"from mismatch", daos.LogsFilter{From: after}, false},
+		{"to match", daos.LogsFilter{To: after}, true},
+		{"to mismatch", daos.LogsFilter{To: before}, false},
+		{"message match", daos.LogsFilter{Message: "message"}, true},
+		{"message mismatch", daos.LogsFilter{Message: "missing"}, false},
+		{"data match", daos.LogsFilter{Data: map[string]string{"status": "400"}}, true},
+		{"nested data match", daos.LogsFilter{Data: map[string]string{"auth.id": "abc"}}, true},
+		{"data mismatch", daos.LogsFilter{Data: map[string]string{"status": "200"}}, false},
+		{"missing data key", daos.LogsFilter{Data: map[string]string{"auth.missing": ""}}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if result := s.filter.Match(log); result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
 func TestDeleteOldLogs(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,68 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// NotificationQuery returns a new Notification select query.
+func (dao *Dao) NotificationQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Notification{})
+}
+
+// FindNotificationById finds a single Notification model by its id.
+func (dao *Dao) FindNotificationById(id string) (*models.Notification, error) {
+	model := &models.Notification{}
+
+	err := dao.NotificationQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllUnreadNotificationsByRecord returns all unread Notification models
+// of the specified auth record.
+func (dao *Dao) FindAllUnreadNotificationsByRecord(collectionId string, recordId string) ([]*models.Notification, error) {
+	result := []*models.Notification{}
+
+	err := dao.NotificationQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+			"read":         false,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveNotification upserts the provided Notification model.
+func (dao *Dao) SaveNotification(model *models.Notification) error {
+	return dao.Save(model)
+}
+
+// DeleteNotification deletes the provided Notification model.
+func (dao *Dao) DeleteNotification(model *models.Notification) error {
+	return dao.Delete(model)
+}
+
+// DeleteNotificationsByRecord deletes all Notification models of the specified auth record.
+func (dao *Dao) DeleteNotificationsByRecord(collectionId string, recordId string) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.Notification{}).TableName(), dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,74 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestNotificationQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_notifications}}.* FROM `_notifications`"
+
+	sql := app.Dao().NotificationQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestNotificationsFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	create := func(recordId string, title string, read bool) *models.Notification {
+		m := &models.Notification{
+			CollectionId: "_pb_users_auth_",
+			RecordId:     recordId,
+			Title:        title,
+			Read:         read,
+		}
+		if err := app.Dao().SaveNotification(m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	n1 := create("4q1xlclmfloku33", "test1", false)
+	create("4q1xlclmfloku33", "test2", true)
+	n3 := create("4q1xlclmfloku33", "test3", false)
+	n4 := create("oap640cot4yru2s", "test4", false)
+
+	found, err := app.Dao().FindNotificationById(n1.Id)
+	if err != nil || found.Title != "test1" {
+		t.Fatalf("Expected to find notification %s, got %v (%v)", n1.Id, found, err)
+	}
+
+	unread, err := app.Dao().FindAllUnreadNotificationsByRecord("_pb_users_auth_", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unread) != 2 || unread[0].Id != n1.Id || unread[1].Id != n3.Id {
+		t.Fatalf("Expected the unread notifications %s and %s, got %v", n1.Id, n3.Id, unread)
+	}
+
+	if err := app.Dao().DeleteNotification(n4); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindNotificationById(n4.Id); err == nil {
+		t.Fatal("Expected the notification to be deleted")
+	}
+
+	if err := app.Dao().DeleteNotificationsByRecord("_pb_users_auth_", "4q1xlclmfloku33"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindNotificationById(n1.Id); err == nil {
+		t.Fatal("Expected all record notifications to be deleted")
+	}
+}
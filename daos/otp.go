@@ -0,0 +1,111 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// OTPQuery returns a new OTP select query.
+func (dao *Dao) OTPQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.OTP{})
+}
+
+// FindOTPById finds a single OTP model by its id.
+func (dao *Dao) FindOTPById(id string) (*models.OTP, error) {
+	model := &models.OTP{}
+
+	err := dao.OTPQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindLastOTPByRecord returns the most recently created OTP
+// model of the specified auth record.
+func (dao *Dao) FindLastOTPByRecord(collectionId string, recordId string) (*models.OTP, error) {
+	model := &models.OTP{}
+
+	err := dao.OTPQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created DESC").
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveOTP upserts the provided OTP model.
+func (dao *Dao) SaveOTP(model *models.OTP) error {
+	return dao.Save(model)
+}
+
+// IncrementOTPAttempts atomically increments the attempts counter of
+// the provided OTP model, but only if it is still below maxAttempts.
+//
+// Returns false if the counter wasn't incremented (aka. the OTP
+// has already reached maxAttempts or was deleted).
+func (dao *Dao) IncrementOTPAttempts(model *models.OTP, maxAttempts int) (bool, error) {
+	result, err := dao.NonconcurrentDB().NewQuery(
+		"UPDATE {{" + model.TableName() + "}} SET [[attempts]] = [[attempts]] + 1 WHERE [[id]] = {:id} AND [[attempts]] < {:max}",
+	).Bind(dbx.Params{
+		"id":  model.Id,
+		"max": maxAttempts,
+	}).Execute()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	model.Attempts++
+
+	return true, nil
+}
+
+// DeleteOTP deletes the provided OTP model.
+func (dao *Dao) DeleteOTP(model *models.OTP) error {
+	return dao.Delete(model)
+}
+
+// DeleteExpiredOTPs deletes all OTPs that have expired before expiredBefore.
+func (dao *Dao) DeleteExpiredOTPs(expiredBefore time.Time) error {
+	formattedDate := expiredBefore.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp("[[expires]] <= {:date}", dbx.Params{"date": formattedDate})
+
+	_, err := dao.NonconcurrentDB().Delete((&models.OTP{}).TableName(), expr).Execute()
+
+	return err
+}
+
+// DeleteOTPsByRecord deletes all OTPs of the specified auth record.
+func (dao *Dao) DeleteOTPsByRecord(collectionId string, recordId string) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.OTP{}).TableName(), dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,150 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestOTPQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_otps}}.* FROM `_otps`"
+
+	sql := app.Dao().OTPQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestOTPsFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	create := func(collectionId, recordId string, expires time.Time) *models.OTP {
+		m := &models.OTP{
+			CollectionId: collectionId,
+			RecordId:     recordId,
+			SentTo:       "+359000000000",
+		}
+		m.SetPassword("123456")
+		m.Expires, _ = types.ParseDateTime(expires)
+		if err := app.Dao().SaveOTP(m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	o1 := create("_pb_users_auth_", "4q1xlclmfloku33", time.Now().Add(-time.Hour))
+	time.Sleep(5 * time.Millisecond) // ensure different created dates
+	o2 := create("_pb_users_auth_", "4q1xlclmfloku33", time.Now().Add(time.Hour))
+	o3 := create("_pb_users_auth_", "oap640cot4yru2s", time.Now().Add(time.Hour))
+
+	found, err := app.Dao().FindOTPById(o1.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.ValidatePassword("123456") {
+		t.Fatal("Expected the stored OTP password hash to be valid")
+	}
+
+	last, err := app.Dao().FindLastOTPByRecord("_pb_users_auth_", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Id != o2.Id {
+		t.Fatalf("Expected last OTP %s, got %s", o2.Id, last.Id)
+	}
+
+	if err := app.Dao().DeleteExpiredOTPs(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindOTPById(o1.Id); err == nil {
+		t.Fatal("Expected the expired OTP to be deleted")
+	}
+
+	if _, err := app.Dao().FindOTPById(o2.Id); err != nil {
+		t.Fatalf("Expected the non-expired OTP to remain, got %v", err)
+	}
+
+	if err := app.Dao().DeleteOTPsByRecord("_pb_users_auth_", "4q1xlclmfloku33"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindOTPById(o2.Id); err == nil {
+		t.Fatal("Expected the record OTPs to be deleted")
+	}
+
+	if _, err := app.Dao().FindOTPById(o3.Id); err != nil {
+		t.Fatalf("Expected the other record OTP to remain, got %v", err)
+	}
+
+	// deleting the record should delete also its OTPs
+	record, err := app.Dao().FindRecordById("_pb_users_auth_", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindOTPById(o3.Id); err == nil {
+		t.Fatal("Expected the deleted record OTPs to be deleted")
+	}
+}
+
+func TestIncrementOTPAttempts(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	otp := &models.OTP{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "4q1xlclmfloku33",
+		SentTo:       "+359000000000",
+		Attempts:     3,
+	}
+	otp.SetPassword("123456")
+	otp.Expires, _ = types.ParseDateTime(time.Now().Add(time.Hour))
+	if err := app.Dao().SaveOTP(otp); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate concurrent requests that have loaded the same OTP state
+	stale1, _ := app.Dao().FindOTPById(otp.Id)
+	stale2, _ := app.Dao().FindOTPById(otp.Id)
+
+	incremented, err := app.Dao().IncrementOTPAttempts(stale1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !incremented || stale1.Attempts != 4 {
+		t.Fatalf("Expected the attempts to be incremented to 4, got %v (%d)", incremented, stale1.Attempts)
+	}
+
+	incremented, err = app.Dao().IncrementOTPAttempts(stale2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if incremented || stale2.Attempts != 3 {
+		t.Fatalf("Expected the attempts to remain unchanged, got %v (%d)", incremented, stale2.Attempts)
+	}
+
+	found, err := app.Dao().FindOTPById(otp.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Attempts != 4 {
+		t.Fatalf("Expected 4 stored attempts, got %d", found.Attempts)
+	}
+}
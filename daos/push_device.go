@@ -0,0 +1,111 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// PushDeviceQuery returns a new PushDevice select query.
+func (dao *Dao) PushDeviceQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.PushDevice{})
+}
+
+// FindPushDeviceById finds a single PushDevice model by its id.
+func (dao *Dao) FindPushDeviceById(id string) (*models.PushDevice, error) {
+	model := &models.PushDevice{}
+
+	err := dao.PushDeviceQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindPushDeviceByToken finds a single PushDevice model by its provider and token.
+func (dao *Dao) FindPushDeviceByToken(provider string, token string) (*models.PushDevice, error) {
+	model := &models.PushDevice{}
+
+	err := dao.PushDeviceQuery().
+		AndWhere(dbx.HashExp{
+			"provider": provider,
+			"token":    token,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllPushDevicesByRecord returns all PushDevice models of the specified auth record.
+func (dao *Dao) FindAllPushDevicesByRecord(collectionId string, recordId string) ([]*models.PushDevice, error) {
+	result := []*models.PushDevice{}
+
+	err := dao.PushDeviceQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindAllPushDevicesByRecordIds returns all PushDevice models
+// of the auth records with the specified ids (from any collection).
+func (dao *Dao) FindAllPushDevicesByRecordIds(recordIds ...string) ([]*models.PushDevice, error) {
+	result := []*models.PushDevice{}
+
+	if len(recordIds) == 0 {
+		return result, nil
+	}
+
+	ids := make([]any, len(recordIds))
+	for i, id := range recordIds {
+		ids[i] = id
+	}
+
+	err := dao.PushDeviceQuery().
+		AndWhere(dbx.In("recordId", ids...)).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SavePushDevice upserts the provided PushDevice model.
+func (dao *Dao) SavePushDevice(model *models.PushDevice) error {
+	return dao.Save(model)
+}
+
+// DeletePushDevice deletes the provided PushDevice model.
+func (dao *Dao) DeletePushDevice(model *models.PushDevice) error {
+	return dao.Delete(model)
+}
+
+// DeletePushDevicesByRecord deletes all PushDevice models of the specified auth record.
+func (dao *Dao) DeletePushDevicesByRecord(collectionId string, recordId string) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.PushDevice{}).TableName(), dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	}).Execute()
+
+	return err
+}
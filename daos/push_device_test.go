@@ -0,0 +1,102 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+func TestPushDeviceQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_pushDevices}}.* FROM `_pushDevices`"
+
+	sql := app.Dao().PushDeviceQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestPushDevicesFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	create := func(collectionId, recordId, provider, token string) *models.PushDevice {
+		m := &models.PushDevice{
+			CollectionId: collectionId,
+			RecordId:     recordId,
+			Provider:     provider,
+			Token:        token,
+		}
+		if err := app.Dao().SavePushDevice(m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	d1 := create("_pb_users_auth_", "4q1xlclmfloku33", push.ProviderFCM, "token1")
+	d2 := create("_pb_users_auth_", "4q1xlclmfloku33", push.ProviderAPNs, "token2")
+	d3 := create("_pb_users_auth_", "oap640cot4yru2s", push.ProviderFCM, "token3")
+
+	// duplicated provider token
+	duplicated := &models.PushDevice{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "oap640cot4yru2s",
+		Provider:     push.ProviderFCM,
+		Token:        "token1",
+	}
+	if err := app.Dao().SavePushDevice(duplicated); err == nil {
+		t.Fatal("Expected unique provider token constraint error")
+	}
+
+	found, err := app.Dao().FindPushDeviceById(d1.Id)
+	if err != nil || found.Token != "token1" {
+		t.Fatalf("Expected to find device %s, got %v (%v)", d1.Id, found, err)
+	}
+
+	found, err = app.Dao().FindPushDeviceByToken(push.ProviderAPNs, "token2")
+	if err != nil || found.Id != d2.Id {
+		t.Fatalf("Expected to find device %s by token, got %v (%v)", d2.Id, found, err)
+	}
+
+	if _, err := app.Dao().FindPushDeviceByToken(push.ProviderFCM, "token2"); err == nil {
+		t.Fatal("Expected the token lookup to be scoped by provider")
+	}
+
+	byRecord, err := app.Dao().FindAllPushDevicesByRecord("_pb_users_auth_", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byRecord) != 2 {
+		t.Fatalf("Expected 2 record devices, got %d", len(byRecord))
+	}
+
+	byIds, err := app.Dao().FindAllPushDevicesByRecordIds("4q1xlclmfloku33", "oap640cot4yru2s", "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byIds) != 3 {
+		t.Fatalf("Expected 3 devices, got %d", len(byIds))
+	}
+
+	if err := app.Dao().DeletePushDevice(d3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindPushDeviceById(d3.Id); err == nil {
+		t.Fatal("Expected the device to be deleted")
+	}
+
+	if err := app.Dao().DeletePushDevicesByRecord("_pb_users_auth_", "4q1xlclmfloku33"); err != nil {
+		t.Fatal(err)
+	}
+	if devices, _ := app.Dao().FindAllPushDevicesByRecordIds("4q1xlclmfloku33"); len(devices) != 0 {
+		t.Fatalf("Expected all record devices to be deleted, got %d", len(devices))
+	}
+}
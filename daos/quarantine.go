@@ -0,0 +1,77 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// QuarantinedFileQuery returns a new QuarantinedFile select query.
+func (dao *Dao) QuarantinedFileQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.QuarantinedFile{})
+}
+
+// FindQuarantinedFileById finds a single QuarantinedFile model by its id.
+func (dao *Dao) FindQuarantinedFileById(id string) (*models.QuarantinedFile, error) {
+	model := &models.QuarantinedFile{}
+
+	err := dao.QuarantinedFileQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindQuarantinedFilesByRecord returns all QuarantinedFile models
+// of the specified record.
+func (dao *Dao) FindQuarantinedFilesByRecord(collectionId string, recordId string) ([]*models.QuarantinedFile, error) {
+	result := []*models.QuarantinedFile{}
+
+	err := dao.QuarantinedFileQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// IsRecordQuarantined checks whether the specified record has
+// at least one file pending quarantine review.
+func (dao *Dao) IsRecordQuarantined(collectionId string, recordId string) bool {
+	var exists bool
+
+	err := dao.QuarantinedFileQuery().
+		Select("(1)").
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		Limit(1).
+		Row(&exists)
+
+	return err == nil && exists
+}
+
+// SaveQuarantinedFile upserts the provided QuarantinedFile model.
+func (dao *Dao) SaveQuarantinedFile(model *models.QuarantinedFile) error {
+	return dao.Save(model)
+}
+
+// DeleteQuarantinedFile deletes the provided QuarantinedFile model.
+//
+// Note that the related quarantine storage file is deleted
+// by the default app model delete hook.
+func (dao *Dao) DeleteQuarantinedFile(model *models.QuarantinedFile) error {
+	return dao.Delete(model)
+}
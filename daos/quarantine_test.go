@@ -0,0 +1,85 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestQuarantinedFileQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_quarantine}}.* FROM `_quarantine`"
+
+	sql := app.Dao().QuarantinedFileQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestQuarantinedFilesFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo3", "1tmknxy2868d869")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Dao().IsRecordQuarantined(record.Collection().Id, record.Id) {
+		t.Fatal("Expected the record to not be quarantined")
+	}
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		m := &models.QuarantinedFile{
+			CollectionId: record.Collection().Id,
+			RecordId:     record.Id,
+			Field:        "files",
+			Name:         name,
+		}
+		if err := app.Dao().SaveQuarantinedFile(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !app.Dao().IsRecordQuarantined(record.Collection().Id, record.Id) {
+		t.Fatal("Expected the record to be quarantined")
+	}
+
+	files, err := app.Dao().FindQuarantinedFilesByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 quarantined files, got %d", len(files))
+	}
+
+	found, err := app.Dao().FindQuarantinedFileById(files[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Name != files[0].Name {
+		t.Fatalf("Expected file %q, got %q", files[0].Name, found.Name)
+	}
+
+	if err := app.Dao().DeleteQuarantinedFile(found); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindQuarantinedFileById(found.Id); err == nil {
+		t.Fatal("Expected the quarantined file to be deleted")
+	}
+
+	// the remaining quarantined files should be deleted together with the record
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().IsRecordQuarantined(record.Collection().Id, record.Id) {
+		t.Fatal("Expected the record quarantined files to be deleted")
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
 	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
@@ -20,6 +23,9 @@ import (
 	"github.com/spf13/cast"
 )
 
+// uniqueIndexColumnRegex matches the plain identifier index columns (aka. non-expressions).
+var uniqueIndexColumnRegex = regexp.MustCompile(`^\w+$`)
+
 // RecordQuery returns a new Record select query from a collection model, id or name.
 //
 // In case a collection id or name is provided and that collection doesn't
@@ -355,6 +361,90 @@ func (dao *Dao) FindFirstRecordByFilter(
 	return result[0], nil
 }
 
+// FindRecordsAfterCursor returns up to limit records matching the
+// optional string filter using keyset (cursor) pagination.
+//
+// The cursor argument is the opaque next cursor returned by the
+// previous call (or empty string for the first page) and it must be
+// used with the same sort, otherwise an error is returned.
+//
+// The returned next cursor is empty if there are no more records.
+//
+// NB! Only plain field sort keys are supported (no relations,
+// modifiers or macros) and "id" is always used as final sort key to
+// guarantee a stable ordering (see [search.Provider.Cursor]).
+//
+// If the limit argument is <= 0, the default search.DefaultPerPage is
+// used and it is capped to search.MaxPerPage.
+//
+// Example:
+//
+//	var cursor string
+//	for {
+//		records, next, err := dao.FindRecordsAfterCursor("posts", "visible = true", "-created", 100, cursor)
+//		if err != nil {
+//			return err
+//		}
+//
+//		// ...
+//
+//		if next == "" {
+//			break
+//		}
+//		cursor = next
+//	}
+func (dao *Dao) FindRecordsAfterCursor(
+	collectionNameOrId string,
+	filter string,
+	sort string,
+	limit int,
+	cursor string,
+	params ...dbx.Params,
+) ([]*models.Record, string, error) {
+	collection, err := dao.FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	q := dao.RecordQuery(collection)
+
+	resolver := resolvers.NewRecordFieldResolver(
+		dao,
+		collection, // the base collection
+		nil,        // no request data
+		true,       // allow searching hidden/protected fields like "email"
+	)
+
+	if filter != "" {
+		expr, err := search.FilterData(filter).BuildExpr(resolver, params...)
+		if err != nil {
+			return nil, "", err
+		}
+		if expr != nil {
+			q.AndWhere(expr)
+		}
+	}
+
+	provider := search.NewProvider(resolver).
+		Query(q).
+		SkipTotal(true).
+		PerPage(limit).
+		Cursor(cursor)
+
+	if sort != "" {
+		provider.Sort(search.ParseSortFromString(sort))
+	}
+
+	records := []*models.Record{}
+
+	result, err := provider.Exec(&records)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return records, result.NextCursor, nil
+}
+
 // IsRecordValueUnique checks if the provided key-value pair is a unique Record value.
 //
 // For correctness, if the collection is "auth" and the key is "username",
@@ -408,6 +498,137 @@ func (dao *Dao) IsRecordValueUnique(
 	return query.Row(&exists) == nil && !exists
 }
 
+// FindRecordUniqueIndexConflicts returns the collection unique indexes
+// (including the composite ones) that are violated by the provided record
+// data, aka. another record with the same indexed column values exists.
+//
+// Expression indexes are not checked and they are left to the database unique constraint.
+func (dao *Dao) FindRecordUniqueIndexConflicts(record *models.Record) ([]dbutils.Index, error) {
+	collection := record.Collection()
+
+	values := record.ColumnValueMap()
+
+	result := []dbutils.Index{}
+
+	for _, raw := range collection.Indexes {
+		idx := dbutils.ParseIndex(raw)
+		if !idx.Unique || !idx.IsValid() {
+			continue
+		}
+
+		exprs := make([]dbx.Expression, 0, len(idx.Columns))
+		for i, col := range idx.Columns {
+			value, ok := findColumnValue(values, col.Name)
+			if !ok || !uniqueIndexColumnRegex.MatchString(col.Name) {
+				exprs = nil // expression or unknown column
+				break
+			}
+
+			param := "unique" + strconv.Itoa(i)
+			sql := "[[" + col.Name + "]] = {:" + param + "}"
+			if col.Collate != "" {
+				sql += " COLLATE " + col.Collate
+			}
+
+			exprs = append(exprs, dbx.NewExp(sql, dbx.Params{param: value}))
+		}
+		if len(exprs) == 0 {
+			continue
+		}
+
+		query := dao.RecordQuery(collection).
+			Select("count(*)").
+			AndWhere(dbx.And(exprs...)).
+			Limit(1)
+
+		// partial index
+		if idx.Where != "" {
+			matches, err := dao.columnValuesMatchExpr(values, idx.Where)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue // the record is not part of the index
+			}
+
+			query.AndWhere(dbx.NewExp(idx.Where))
+		}
+
+		if record.Id != "" {
+			query.AndWhere(dbx.Not(dbx.HashExp{collection.Name + ".id": record.Id}))
+		}
+
+		var exists bool
+		if err := query.Row(&exists); err != nil {
+			return nil, err
+		}
+
+		if exists {
+			result = append(result, idx)
+		}
+	}
+
+	return result, nil
+}
+
+// findColumnValue returns the value of the specified column
+// from the provided column-value map (the lookup is case-insensitive).
+func findColumnValue(values map[string]any, column string) (any, bool) {
+	if v, ok := values[column]; ok {
+		return v, true
+	}
+
+	for k, v := range values {
+		if strings.EqualFold(k, column) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+// columnValuesMatchExpr checks whether the provided column-value map
+// satisfies the raw SQL expression (eg. a partial index WHERE clause).
+func (dao *Dao) columnValuesMatchExpr(values map[string]any, expr string) (bool, error) {
+	columns := make([]string, 0, len(values))
+	params := make(dbx.Params, len(values))
+
+	i := 0
+	for column, value := range values {
+		if !uniqueIndexColumnRegex.MatchString(column) {
+			continue
+		}
+
+		param := "col" + strconv.Itoa(i)
+		columns = append(columns, "{:"+param+"} AS [["+column+"]]")
+		params[param] = value
+		i++
+	}
+
+	var matches bool
+
+	err := dao.DB().NewQuery(
+		"SELECT COUNT(*) FROM (SELECT " + strings.Join(columns, ", ") + ") WHERE " + expr,
+	).Bind(params).Row(&matches)
+
+	return matches, err
+}
+
+// NextRecordSequenceValue returns the next sequence value of the
+// specified collection number field (aka. MAX(field) + 1, starting from 1).
+//
+// Note that the returned value is not reserved, so if you need to guard
+// against concurrent duplicates consider also adding an unique index.
+func (dao *Dao) NextRecordSequenceValue(collectionModelOrIdentifier any, field string) (int, error) {
+	var next int
+
+	err := dao.RecordQuery(collectionModelOrIdentifier).
+		Select("CAST(COALESCE(MAX([[" + inflector.Columnify(field) + "]]), 0) AS INTEGER) + 1 AS next").
+		Row(&next)
+
+	return next, err
+}
+
 // FindAuthRecordByToken finds the auth record associated with the provided JWT.
 //
 // Returns an error if the JWT is invalid, expired or not associated to an auth collection record.
@@ -583,6 +804,8 @@ func (dao *Dao) CanAccessRecord(record *models.Record, requestInfo *models.Reque
 //
 // If record.IsNew() is true, the method will perform a create, otherwise an update.
 // To explicitly mark a record for update you can use record.MarkAsNotNew().
+//
+// The records of an immutable collection can only be created (see [ErrImmutableRecord]).
 func (dao *Dao) SaveRecord(record *models.Record) error {
 	if record.Collection().IsAuth() {
 		if record.Username() == "" {
@@ -606,17 +829,162 @@ func (dao *Dao) SaveRecord(record *models.Record) error {
 		}
 	}
 
+	// log the record change within the same transaction (if enabled)
+	if record.Collection().TrackChanges() {
+		action := models.RecordChangeActionUpdate
+		if record.IsNew() {
+			action = models.RecordChangeActionCreate
+		}
+
+		return dao.RunInTransaction(func(txDao *Dao) error {
+			if err := txDao.saveRecord(record); err != nil {
+				return err
+			}
+
+			return txDao.logRecordChange(record, action)
+		})
+	}
+
+	return dao.saveRecord(record)
+}
+
+// saveRecord persists the provided Record model
+// (without the auth records and changes tracking checks).
+func (dao *Dao) saveRecord(record *models.Record) error {
+	// generate the autonumber fields values within the create transaction
+	// so that the counters are not incremented if the create fails
+	if record.IsNew() && hasAutonumberFields(record.Collection()) {
+		var generated []string
+
+		err := dao.RunInTransaction(func(txDao *Dao) error {
+			var err error
+
+			generated, err = txDao.generateRecordAutonumbers(record)
+			if err != nil {
+				return err
+			}
+
+			return txDao.saveRecordModel(record)
+		})
+
+		if err != nil {
+			// reset the rolled back values
+			for _, name := range generated {
+				record.Set(name, "")
+			}
+		}
+
+		return err
+	}
+
+	return dao.saveRecordModel(record)
+}
+
+// saveRecordModel persists the provided Record model
+// according to the collection immutable and revision options.
+func (dao *Dao) saveRecordModel(record *models.Record) error {
+	// append-only collection (if enabled)
+	if record.Collection().Immutable() != nil {
+		if !record.IsNew() {
+			return ErrImmutableRecord
+		}
+
+		return dao.createImmutableRecord(record)
+	}
+
+	// increment the revision counter (if enabled)
+	if field := record.Collection().RevisionField(); field != "" {
+		return dao.RunInTransaction(func(txDao *Dao) error {
+			revision, err := txDao.findStoredRecordRevision(record, field)
+			if err != nil {
+				return err
+			}
+
+			record.Set(field, revision+1)
+
+			return txDao.Save(record)
+		})
+	}
+
 	return dao.Save(record)
 }
 
+// ErrRecordRevisionConflict is returned by [Dao.CompareAndSaveRecord]
+// when the stored record revision doesn't match the expected one.
+var ErrRecordRevisionConflict = errors.New("the record was modified by another request")
+
+// CompareAndSaveRecord persists the provided Record model only if its
+// stored revision counter matches expectedRevision, otherwise
+// [ErrRecordRevisionConflict] is returned.
+//
+// On success the record revision field is set to expectedRevision+1.
+//
+// Note that the optimistic locking must be enabled for the record
+// collection (see the "revisionField" collection option) and that for
+// new records the expected revision must be 0.
+func (dao *Dao) CompareAndSaveRecord(record *models.Record, expectedRevision int) error {
+	field := record.Collection().RevisionField()
+	if field == "" {
+		return fmt.Errorf("the %q collection doesn't have a revision field", record.Collection().Name)
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		revision, err := txDao.findStoredRecordRevision(record, field)
+		if err != nil {
+			return err
+		}
+
+		if revision != expectedRevision {
+			return ErrRecordRevisionConflict
+		}
+
+		return txDao.SaveRecord(record)
+	})
+}
+
+// findStoredRecordRevision returns the persisted revision counter
+// of the provided record (0 for new records).
+func (dao *Dao) findStoredRecordRevision(record *models.Record, field string) (int, error) {
+	if record.IsNew() {
+		return 0, nil
+	}
+
+	var revision int
+
+	err := dao.DB().Select("[[" + field + "]]").
+		From(record.Collection().Name).
+		AndWhere(dbx.HashExp{schema.FieldNameId: record.Id}).
+		Limit(1).
+		Row(&revision)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	return revision, nil
+}
+
 // DeleteRecord deletes the provided Record model.
 //
 // This method will also cascade the delete operation to all linked
 // relational records (delete or unset, depending on the rel settings).
 //
 // The delete operation may fail if the record is part of a required
-// reference in another record (aka. cannot be deleted or unset).
+// reference in another record (aka. cannot be deleted or unset)
+// or if the record collection is immutable (see [ErrImmutableRecord])
+// or if the record is under legal hold (see [ErrRecordUnderLegalHold]).
 func (dao *Dao) DeleteRecord(record *models.Record) error {
+	if record.Collection().Immutable() != nil {
+		return ErrImmutableRecord
+	}
+
+	held, err := dao.IsRecordUnderLegalHold(record.Collection().Id, record.Id)
+	if err != nil {
+		return err
+	}
+	if held {
+		return ErrRecordUnderLegalHold
+	}
+
 	// fetch rel references (if any)
 	//
 	// note: the select is outside of the transaction to minimize
@@ -649,6 +1017,53 @@ func (dao *Dao) DeleteRecord(record *models.Record) error {
 			return err
 		}
 
+		// delete the pending quarantined files of the record (if any)
+		quarantined, err := txDao.FindQuarantinedFilesByRecord(record.Collection().Id, record.Id)
+		if err != nil {
+			return err
+		}
+		for _, file := range quarantined {
+			if err := txDao.DeleteQuarantinedFile(file); err != nil {
+				return err
+			}
+		}
+
+		if err := txDao.deleteShareLinksByRecord(record.Collection().Id, record.Id); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteSignatureRequestsByRecord(record.Collection().Id, record.Id); err != nil {
+			return err
+		}
+
+		if record.Collection().TrackChanges() {
+			if err := txDao.logRecordChange(record, models.RecordChangeActionDelete); err != nil {
+				return err
+			}
+		}
+
+		if record.Collection().IsAuth() {
+			if err := txDao.deleteSessionsByOwner(record.Collection().Id, record.Id); err != nil {
+				return err
+			}
+
+			if err := txDao.deleteRefreshTokensByOwner(record.Collection().Id, record.Id); err != nil {
+				return err
+			}
+
+			if err := txDao.DeleteOTPsByRecord(record.Collection().Id, record.Id); err != nil {
+				return err
+			}
+
+			if err := txDao.DeletePushDevicesByRecord(record.Collection().Id, record.Id); err != nil {
+				return err
+			}
+
+			if err := txDao.DeleteNotificationsByRecord(record.Collection().Id, record.Id); err != nil {
+				return err
+			}
+		}
+
 		return txDao.cascadeRecordDelete(record, refs)
 	})
 }
@@ -677,25 +1092,7 @@ func (dao *Dao) cascadeRecordDelete(mainRecord *models.Record, refs map[*models.
 		}
 
 		for _, field := range fields {
-			recordTableName := inflector.Columnify(refCollection.Name)
-			prefixedFieldName := recordTableName + "." + inflector.Columnify(field.Name)
-
-			query := dao.RecordQuery(refCollection)
-
-			if opt, ok := field.Options.(schema.MultiValuer); !ok || !opt.IsMultiple() {
-				query.AndWhere(dbx.HashExp{prefixedFieldName: mainRecord.Id})
-			} else {
-				query.AndWhere(dbx.Exists(dbx.NewExp(fmt.Sprintf(
-					`SELECT 1 FROM json_each(CASE WHEN json_valid([[%s]]) THEN [[%s]] ELSE json_array([[%s]]) END) {{__je__}} WHERE [[__je__.value]]={:jevalue}`,
-					prefixedFieldName, prefixedFieldName, prefixedFieldName,
-				), dbx.Params{
-					"jevalue": mainRecord.Id,
-				})))
-			}
-
-			if refCollection.Id == mainRecord.Collection().Id {
-				query.AndWhere(dbx.Not(dbx.HashExp{recordTableName + ".id": mainRecord.Id}))
-			}
+			query := dao.recordReferencesQuery(refCollection, field, mainRecord)
 
 			// trigger cascade for each batchSize rel items until there is none
 			batchSize := 4000
@@ -729,6 +1126,33 @@ func (dao *Dao) cascadeRecordDelete(mainRecord *models.Record, refs map[*models.
 	return nil
 }
 
+// recordReferencesQuery returns a new Record select query for the
+// refCollection records whose relation field references the target record
+// (the target record itself is excluded in case of a self-reference).
+func (dao *Dao) recordReferencesQuery(refCollection *models.Collection, field *schema.SchemaField, target *models.Record) *dbx.SelectQuery {
+	recordTableName := inflector.Columnify(refCollection.Name)
+	prefixedFieldName := recordTableName + "." + inflector.Columnify(field.Name)
+
+	query := dao.RecordQuery(refCollection)
+
+	if opt, ok := field.Options.(schema.MultiValuer); !ok || !opt.IsMultiple() {
+		query.AndWhere(dbx.HashExp{prefixedFieldName: target.Id})
+	} else {
+		query.AndWhere(dbx.Exists(dbx.NewExp(fmt.Sprintf(
+			`SELECT 1 FROM json_each(CASE WHEN json_valid([[%s]]) THEN [[%s]] ELSE json_array([[%s]]) END) {{__je__}} WHERE [[__je__.value]]={:jevalue}`,
+			prefixedFieldName, prefixedFieldName, prefixedFieldName,
+		), dbx.Params{
+			"jevalue": target.Id,
+		})))
+	}
+
+	if refCollection.Id == target.Collection().Id {
+		query.AndWhere(dbx.Not(dbx.HashExp{recordTableName + ".id": target.Id}))
+	}
+
+	return query
+}
+
 // deleteRefRecords checks if related records has to be deleted (if `CascadeDelete` is set)
 // OR
 // just unset the record id from any relation field values (if they are not required).
@@ -0,0 +1,89 @@
+package daos
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// AutonumberSequenceKey returns the counter key of an autonumber field
+// for the specified scope field value and year (0 if the counter is not
+// reset yearly).
+//
+// It could be used together with [Dao.SetSequenceValue] to continue
+// an existing numbering, eg.:
+//
+//	key := daos.AutonumberSequenceKey(collection.Id, field.Id, orgId, 2025)
+//	dao.SetSequenceValue(key, 122) // the next generated value will be 123
+func AutonumberSequenceKey(collectionId string, fieldId string, scope string, year int) string {
+	parts := []string{"autonumber", collectionId, fieldId, scope}
+
+	if year != 0 {
+		parts = append(parts, strconv.Itoa(year))
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// hasAutonumberFields checks whether the collection has at least one autonumber field.
+func hasAutonumberFields(collection *models.Collection) bool {
+	for _, field := range collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeAutonumber {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateRecordAutonumbers assigns the next counter values to the empty
+// autonumber fields of the provided new record and returns their names.
+//
+// It is expected to be called within the record create transaction.
+func (dao *Dao) generateRecordAutonumbers(record *models.Record) ([]string, error) {
+	created := record.Created.Time()
+	if created.IsZero() {
+		created = time.Now().UTC()
+	}
+
+	var generated []string
+
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeAutonumber || record.GetString(field.Name) != "" {
+			continue
+		}
+
+		if err := field.InitOptions(); err != nil {
+			return generated, err
+		}
+
+		options, _ := field.Options.(*schema.AutonumberOptions)
+		if options == nil {
+			options = &schema.AutonumberOptions{}
+		}
+
+		var scope string
+		if options.ScopeField != "" {
+			scope = record.GetString(options.ScopeField)
+		}
+
+		var year int
+		if options.ResetYearly {
+			year = created.Year()
+		}
+
+		counter, err := dao.IncrementSequence(AutonumberSequenceKey(record.Collection().Id, field.Id, scope, year))
+		if err != nil {
+			return generated, err
+		}
+
+		record.Set(field.Name, options.Format(counter, created))
+
+		generated = append(generated, field.Name)
+	}
+
+	return generated, nil
+}
@@ -0,0 +1,137 @@
+package daos_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAutonumberSequenceKey(t *testing.T) {
+	scenarios := []struct {
+		scope    string
+		year     int
+		expected string
+	}{
+		{"", 0, "autonumber:c1:f1:"},
+		{"org1", 0, "autonumber:c1:f1:org1"},
+		{"", 2025, "autonumber:c1:f1::2025"},
+		{"org1", 2025, "autonumber:c1:f1:org1:2025"},
+	}
+
+	for _, s := range scenarios {
+		if result := daos.AutonumberSequenceKey("c1", "f1", s.scope, s.year); result != s.expected {
+			t.Errorf("Expected %q, got %q", s.expected, result)
+		}
+	}
+}
+
+func TestSaveRecordAutonumber(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{}
+	collection.Name = "policies"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "org",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name:    "global",
+			Type:    schema.FieldTypeAutonumber,
+			Options: &schema.AutonumberOptions{},
+		},
+		&schema.SchemaField{
+			Name:    "number",
+			Type:    schema.FieldTypeAutonumber,
+			Options: &schema.AutonumberOptions{Prefix: "POL-{year}-", Padding: 6, ScopeField: "org", ResetYearly: true},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	year := time.Now().UTC().Year()
+
+	scenarios := []struct {
+		org            string
+		created        string
+		expectedGlobal string
+		expectedNumber string
+	}{
+		{"a", "", "1", fmt.Sprintf("POL-%d-000001", year)},
+		{"a", "", "2", fmt.Sprintf("POL-%d-000002", year)},
+		{"b", "", "3", fmt.Sprintf("POL-%d-000001", year)},
+		{"a", "2020-01-01 10:00:00.000Z", "4", "POL-2020-000001"},
+		{"a", "", "5", fmt.Sprintf("POL-%d-000003", year)},
+	}
+
+	for i, s := range scenarios {
+		record := models.NewRecord(collection)
+		record.Set("org", s.org)
+		if s.created != "" {
+			record.Set(schema.FieldNameCreated, s.created)
+		}
+
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatalf("(%d) %v", i, err)
+		}
+
+		if v := record.GetString("global"); v != s.expectedGlobal {
+			t.Fatalf("(%d) Expected global %q, got %q", i, s.expectedGlobal, v)
+		}
+
+		if v := record.GetString("number"); v != s.expectedNumber {
+			t.Fatalf("(%d) Expected number %q, got %q", i, s.expectedNumber, v)
+		}
+	}
+
+	// explicitly set values are not regenerated
+	record := models.NewRecord(collection)
+	record.Set("org", "a")
+	record.Set("number", "LEGACY-1")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("number"); v != "LEGACY-1" {
+		t.Fatalf("Expected the explicit number to be preserved, got %q", v)
+	}
+	if v := record.GetString("global"); v != "6" {
+		t.Fatalf("Expected global 6, got %q", v)
+	}
+
+	// the values are not changed on update
+	record.Set("org", "b")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("global"); v != "6" {
+		t.Fatalf("Expected global 6 after update, got %q", v)
+	}
+
+	// failed create rolls back the counters
+	failDao := daos.New(app.Dao().DB())
+	failDao.BeforeCreateFunc = func(eventDao *daos.Dao, m models.Model, action func() error) error {
+		return errors.New("test")
+	}
+	failed := models.NewRecord(collection)
+	failed.Set("org", "a")
+	if err := failDao.SaveRecord(failed); err == nil {
+		t.Fatal("Expected create error")
+	}
+	if v := failed.GetString("global"); v != "" {
+		t.Fatalf("Expected the failed record global value to be reset, got %q", v)
+	}
+	key := daos.AutonumberSequenceKey(collection.Id, collection.Schema.GetFieldByName("global").Id, "", 0)
+	if v, _ := app.Dao().FindSequenceValue(key); v != 6 {
+		t.Fatalf("Expected the global counter to remain 6, got %d", v)
+	}
+}
@@ -0,0 +1,84 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RecordChangeQuery returns a new RecordChange select query.
+func (dao *Dao) RecordChangeQuery() *dbx.SelectQuery {
+	return dao.DB().Select("{{_recordChanges}}.*").From((&models.RecordChange{}).TableName())
+}
+
+// FindRecordChanges returns the tracked changes of the specified
+// collection records with seq greater than the provided one
+// (ordered by their seq).
+//
+// Set limit <= 0 to return all matching changes.
+func (dao *Dao) FindRecordChanges(collectionId string, since int64, limit int) ([]*models.RecordChange, error) {
+	result := []*models.RecordChange{}
+
+	query := dao.RecordChangeQuery().
+		AndWhere(dbx.HashExp{"collectionId": collectionId}).
+		AndWhere(dbx.NewExp("[[seq]] > {:since}", dbx.Params{"since": since})).
+		OrderBy("seq ASC")
+
+	if limit > 0 {
+		query.Limit(int64(limit))
+	}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LastRecordChangeSeq returns the seq of the last tracked change
+// of the specified collection records (or 0 if there are none).
+func (dao *Dao) LastRecordChangeSeq(collectionId string) (int64, error) {
+	var seq int64
+
+	err := dao.DB().Select("COALESCE(MAX([[seq]]), 0)").
+		From((&models.RecordChange{}).TableName()).
+		AndWhere(dbx.HashExp{"collectionId": collectionId}).
+		Row(&seq)
+
+	return seq, err
+}
+
+// DeleteRecordChangesByCollection deletes all tracked changes of the specified collection records.
+func (dao *Dao) DeleteRecordChangesByCollection(collectionId string) error {
+	_, err := dao.DB().Delete(
+		(&models.RecordChange{}).TableName(),
+		dbx.HashExp{"collectionId": collectionId},
+	).Execute()
+
+	return err
+}
+
+// DeleteOldRecordChanges deletes all tracked changes created before the specified date.
+//
+// Note that the clients that have synced before the date will
+// have to do a full resync to detect the removed records.
+func (dao *Dao) DeleteOldRecordChanges(createdBefore types.DateTime) error {
+	_, err := dao.DB().Delete(
+		(&models.RecordChange{}).TableName(),
+		dbx.NewExp("[[created]] < {:date}", dbx.Params{"date": createdBefore.String()}),
+	).Execute()
+
+	return err
+}
+
+// logRecordChange stores a new tracked change of the provided record.
+func (dao *Dao) logRecordChange(record *models.Record, action string) error {
+	_, err := dao.DB().Insert((&models.RecordChange{}).TableName(), dbx.Params{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+		"action":       action,
+		"created":      types.NowDateTime().String(),
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,172 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func enableCollectionTrackChanges(t *testing.T, app *tests.TestApp, nameOrId string) *models.Collection {
+	collection, err := app.Dao().FindCollectionByNameOrId(nameOrId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := collection.BaseOptions()
+	options.TrackChanges = true
+	collection.SetOptions(options)
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func TestRecordChangesTracking(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableCollectionTrackChanges(t, app, "demo2")
+
+	record1 := models.NewRecord(collection)
+	record1.Set("title", "track_changes_1")
+	if err := app.Dao().SaveRecord(record1); err != nil {
+		t.Fatal(err)
+	}
+
+	record1.Set("title", "track_changes_1_updated")
+	if err := app.Dao().SaveRecord(record1); err != nil {
+		t.Fatal(err)
+	}
+
+	record2 := models.NewRecord(collection)
+	record2.Set("title", "track_changes_2")
+	if err := app.Dao().SaveRecord(record2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record2); err != nil {
+		t.Fatal(err)
+	}
+
+	// untracked collection change
+	demo1Record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SaveRecord(demo1Record); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := app.Dao().FindRecordChanges(collection.Id, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		recordId string
+		action   string
+	}{
+		{record1.Id, models.RecordChangeActionCreate},
+		{record1.Id, models.RecordChangeActionUpdate},
+		{record2.Id, models.RecordChangeActionCreate},
+		{record2.Id, models.RecordChangeActionDelete},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d", len(expected), len(changes))
+	}
+
+	for i, e := range expected {
+		if changes[i].RecordId != e.recordId || changes[i].Action != e.action {
+			t.Errorf("(%d) Expected %s %s, got %s %s", i, e.recordId, e.action, changes[i].RecordId, changes[i].Action)
+		}
+
+		if i > 0 && changes[i].Seq <= changes[i-1].Seq {
+			t.Errorf("(%d) Expected seq greater than %d, got %d", i, changes[i-1].Seq, changes[i].Seq)
+		}
+	}
+
+	demo1Changes, err := app.Dao().FindRecordChanges(demo1Record.Collection().Id, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(demo1Changes) != 0 {
+		t.Fatalf("Expected no changes for the untracked collection, got %d", len(demo1Changes))
+	}
+
+	// since + limit
+	limited, err := app.Dao().FindRecordChanges(collection.Id, changes[1].Seq, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 1 || limited[0].Seq != changes[2].Seq {
+		t.Fatalf("Expected only change %d, got %v", changes[2].Seq, limited)
+	}
+
+	lastSeq, err := app.Dao().LastRecordChangeSeq(collection.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSeq != changes[3].Seq {
+		t.Fatalf("Expected last seq %d, got %d", changes[3].Seq, lastSeq)
+	}
+
+	// delete old
+	hourAgo, _ := types.ParseDateTime(time.Now().Add(-1 * time.Hour))
+	if err := app.Dao().DeleteOldRecordChanges(hourAgo); err != nil {
+		t.Fatal(err)
+	}
+	if changes, _ := app.Dao().FindRecordChanges(collection.Id, 0, 0); len(changes) != 4 {
+		t.Fatalf("Expected the recent changes to remain, got %d", len(changes))
+	}
+
+	hourLater, _ := types.ParseDateTime(time.Now().Add(1 * time.Hour))
+	if err := app.Dao().DeleteOldRecordChanges(hourLater); err != nil {
+		t.Fatal(err)
+	}
+	if changes, _ := app.Dao().FindRecordChanges(collection.Id, 0, 0); len(changes) != 0 {
+		t.Fatalf("Expected all changes to be deleted, got %d", len(changes))
+	}
+
+	lastSeq, err = app.Dao().LastRecordChangeSeq(collection.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastSeq != 0 {
+		t.Fatalf("Expected last seq 0, got %d", lastSeq)
+	}
+}
+
+func TestDeleteRecordChangesByCollection(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableCollectionTrackChanges(t, app, "demo2")
+
+	record := models.NewRecord(collection)
+	record.Set("title", "track_changes")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecordChangesByCollection(collection.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := app.Dao().FindRecordChanges(collection.Id, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Expected no changes, got %d", len(changes))
+	}
+}
@@ -0,0 +1,167 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// RecordsCursor is a forward only iterator over the rows of a records query.
+//
+// Unlike the FindRecords* methods, the records are scanned one by one
+// as they are requested, allowing to process large result sets without
+// loading them in memory.
+//
+// The cursor holds an open db connection until it is exhausted or
+// explicitly closed, so make sure to always call Close() when done.
+//
+// Example:
+//
+//	cursor, err := dao.FindRecordsCursor("posts", "status = 'public'", "-created")
+//	if err != nil {
+//		return err
+//	}
+//	defer cursor.Close()
+//
+//	for cursor.Next() {
+//		record := cursor.Record()
+//		// ...
+//	}
+//
+//	return cursor.Err()
+type RecordsCursor struct {
+	collection *models.Collection
+	rows       *dbx.Rows
+	record     *models.Record
+	err        error
+}
+
+// NewRecordsCursor executes the provided collection records query
+// and returns a new cursor for iterating over its result.
+func NewRecordsCursor(collection *models.Collection, query *dbx.SelectQuery) (*RecordsCursor, error) {
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordsCursor{collection: collection, rows: rows}, nil
+}
+
+// Next scans the next record row and reports whether there was one.
+//
+// The underlying rows are automatically closed when there are no
+// more records or an error has occurred (see [RecordsCursor.Err]).
+func (c *RecordsCursor) Next() bool {
+	c.record = nil
+
+	if c.rows == nil {
+		return false
+	}
+
+	if !c.rows.Next() {
+		c.err = c.rows.Err()
+		c.Close()
+		return false
+	}
+
+	row := dbx.NullStringMap{}
+	if err := c.rows.ScanMap(row); err != nil {
+		c.err = err
+		c.Close()
+		return false
+	}
+
+	c.record = models.NewRecordFromNullStringMap(c.collection, row)
+
+	return true
+}
+
+// Record returns the last scanned record (or nil if Next() wasn't called yet or returned false).
+func (c *RecordsCursor) Record() *models.Record {
+	return c.record
+}
+
+// Err returns the error, if any, that was encountered during the iteration.
+func (c *RecordsCursor) Err() error {
+	return c.err
+}
+
+// Close releases the cursor db resources.
+//
+// It is safe to call Close multiple times.
+func (c *RecordsCursor) Close() error {
+	if c.rows == nil {
+		return nil
+	}
+
+	err := c.rows.Close()
+	c.rows = nil
+
+	return err
+}
+
+// FindRecordsCursor returns a new cursor for iterating over the
+// records matching the provided string filter.
+//
+// NB! Use the last "params" argument to bind untrusted user variables!
+//
+// The filter and sort arguments are optional and can be empty string OR the
+// same format used in the web APIs, eg. "-created,title".
+//
+// Example:
+//
+//	cursor, err := dao.FindRecordsCursor(
+//		"posts",
+//		"title ~ {:title} && visible = {:visible}",
+//		"-created",
+//		dbx.Params{"title": "lorem ipsum", "visible": true}
+//	)
+func (dao *Dao) FindRecordsCursor(
+	collectionNameOrId string,
+	filter string,
+	sort string,
+	params ...dbx.Params,
+) (*RecordsCursor, error) {
+	collection, err := dao.FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil {
+		return nil, err
+	}
+
+	q := dao.RecordQuery(collection)
+
+	resolver := resolvers.NewRecordFieldResolver(
+		dao,
+		collection, // the base collection
+		nil,        // no request data
+		true,       // allow searching hidden/protected fields like "email"
+	)
+
+	if filter != "" {
+		expr, err := search.FilterData(filter).BuildExpr(resolver, params...)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			q.AndWhere(expr)
+		}
+	}
+
+	if sort != "" {
+		for _, sortField := range search.ParseSortFromString(sort) {
+			expr, err := sortField.BuildExpr(resolver)
+			if err != nil {
+				return nil, err
+			}
+			if expr != "" {
+				q.AndOrderBy(expr)
+			}
+		}
+	}
+
+	if err := resolver.UpdateQuery(q); err != nil {
+		return nil, err
+	}
+
+	return NewRecordsCursor(collection, q)
+}
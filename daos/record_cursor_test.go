@@ -0,0 +1,166 @@
+package daos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindRecordsCursor(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name               string
+		collectionIdOrName string
+		filter             string
+		sort               string
+		params             []dbx.Params
+		expectError        bool
+		expectRecordIds    []string
+	}{
+		{
+			"missing collection",
+			"missing",
+			"",
+			"",
+			nil,
+			true,
+			nil,
+		},
+		{
+			"invalid filter",
+			"demo2",
+			"someMissingField > 1",
+			"",
+			nil,
+			true,
+			nil,
+		},
+		{
+			"invalid sort",
+			"demo2",
+			"",
+			"someMissingField",
+			nil,
+			true,
+			nil,
+		},
+		{
+			"no filter and sort",
+			"demo2",
+			"",
+			"",
+			nil,
+			false,
+			[]string{
+				"llvuca81nly1qls",
+				"achvryl401bhse3",
+				"0yxhwia2amd8gec",
+			},
+		},
+		{
+			"filter with sort",
+			"demo2",
+			"active = true",
+			"-created,title",
+			nil,
+			false,
+			[]string{
+				"0yxhwia2amd8gec",
+				"achvryl401bhse3",
+			},
+		},
+		{
+			"filter with params",
+			"demo2",
+			"title = {:title}",
+			"",
+			[]dbx.Params{{"title": "test2"}},
+			false,
+			[]string{"achvryl401bhse3"},
+		},
+		{
+			"no matching records",
+			"demo2",
+			"title = 'missing'",
+			"",
+			nil,
+			false,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			cursor, err := app.Dao().FindRecordsCursor(s.collectionIdOrName, s.filter, s.sort, s.params...)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr to be %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+			defer cursor.Close()
+
+			ids := []string{}
+			for cursor.Next() {
+				ids = append(ids, cursor.Record().Id)
+			}
+
+			if err := cursor.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			if strings.Join(ids, ",") != strings.Join(s.expectRecordIds, ",") {
+				t.Fatalf("Expected records %v, got %v", s.expectRecordIds, ids)
+			}
+
+			// exhausted cursor
+			if cursor.Next() || cursor.Record() != nil {
+				t.Fatal("Expected the cursor to be exhausted")
+			}
+		})
+	}
+}
+
+func TestRecordsCursorClose(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor, err := daos.NewRecordsCursor(collection, app.Dao().RecordQuery(collection))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cursor.Next() {
+		t.Fatal("Expected at least one record")
+	}
+
+	if err := cursor.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// closing multiple times should be safe
+	if err := cursor.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if cursor.Next() {
+		t.Fatal("Expected Next() to return false after Close()")
+	}
+}
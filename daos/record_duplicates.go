@@ -0,0 +1,370 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/similarity"
+	"github.com/spf13/cast"
+)
+
+// DefaultDuplicatesThreshold is the min records duplicate score
+// used if the collection duplicates options threshold is not set.
+const DefaultDuplicatesThreshold = 0.8
+
+// MaxDuplicatesScanRecords is the max number of the most recent
+// collection records that are compared with each other when
+// searching for duplicate pairs (see [Dao.FindRecordDuplicatePairs]).
+const MaxDuplicatesScanRecords = 2000
+
+// duplicatesBatchSize is the number of records loaded
+// at once when searching for a single record duplicates.
+const duplicatesBatchSize = 1000
+
+// RecordDuplicate represents a single probable record duplicate.
+type RecordDuplicate struct {
+	Record *models.Record
+	Score  float64
+}
+
+// RecordDuplicatePair represents a pair of probable duplicate records.
+type RecordDuplicatePair struct {
+	A     *models.Record
+	B     *models.Record
+	Score float64
+}
+
+// FindRecordDuplicates returns the probable duplicates of the provided record
+// (sorted by their score DESC) based on its collection duplicates options.
+//
+// If threshold is <= 0, the collection duplicates options threshold is used.
+// If limit is <= 0, all found duplicates are returned.
+func (dao *Dao) FindRecordDuplicates(record *models.Record, threshold float64, limit int) ([]*RecordDuplicate, error) {
+	scorer, err := newDuplicatesScorer(record.Collection(), threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*RecordDuplicate{}
+
+	query := dao.RecordQuery(record.Collection()).
+		AndWhere(dbx.Not(dbx.HashExp{inflector.Columnify(record.Collection().Name) + ".id": record.Id})).
+		OrderBy("rowid ASC")
+
+	for offset := 0; ; offset += duplicatesBatchSize {
+		batch := []*models.Record{}
+
+		if err := query.Limit(duplicatesBatchSize).Offset(int64(offset)).All(&batch); err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range batch {
+			if score, ok := scorer.score(record, candidate); ok {
+				result = append(result, &RecordDuplicate{Record: candidate, Score: score})
+			}
+		}
+
+		if len(batch) < duplicatesBatchSize {
+			break // no more records
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// FindRecordDuplicatePairs returns the probable duplicate record pairs
+// (sorted by their score DESC) of the specified collection based on its
+// duplicates options.
+//
+// Only the [MaxDuplicatesScanRecords] most recent records are compared.
+//
+// If threshold is <= 0, the collection duplicates options threshold is used.
+// If limit is <= 0, all found pairs are returned.
+func (dao *Dao) FindRecordDuplicatePairs(collection *models.Collection, threshold float64, limit int) ([]*RecordDuplicatePair, error) {
+	scorer, err := newDuplicatesScorer(collection, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*models.Record{}
+
+	err = dao.RecordQuery(collection).
+		OrderBy("rowid DESC").
+		Limit(MaxDuplicatesScanRecords).
+		All(&records)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*RecordDuplicatePair{}
+
+	for i := 0; i < len(records); i++ {
+		for j := i + 1; j < len(records); j++ {
+			if score, ok := scorer.score(records[i], records[j]); ok {
+				result = append(result, &RecordDuplicatePair{A: records[i], B: records[j], Score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// MergeRecords merges the duplicates into the target record by replacing
+// all relation references to the duplicates with the target record id
+// and deleting the duplicates afterwards.
+//
+// The target record fields (including the file ones) are expected to be
+// already combined by the caller because the duplicates files are deleted
+// together with the duplicate records.
+func (dao *Dao) MergeRecords(target *models.Record, duplicates []*models.Record) error {
+	duplicateIds := make(map[string]struct{}, len(duplicates))
+	for _, d := range duplicates {
+		if d.Collection().Id != target.Collection().Id {
+			return fmt.Errorf("the duplicate record %q is from a different collection", d.Id)
+		}
+		if d.Id == target.Id {
+			return errors.New("the target record cannot be merged with itself")
+		}
+		duplicateIds[d.Id] = struct{}{}
+	}
+
+	// note: the select is outside of the transaction to minimize
+	// SQLITE_BUSY errors when mixing read&write in a single transaction
+	refs, err := dao.FindCollectionReferences(target.Collection())
+	if err != nil {
+		return err
+	}
+
+	// sort the refs keys to ensure deterministic hooks firing order
+	sortedRefKeys := make([]*models.Collection, 0, len(refs))
+	for k := range refs {
+		sortedRefKeys = append(sortedRefKeys, k)
+	}
+	sort.Slice(sortedRefKeys, func(i, j int) bool {
+		return sortedRefKeys[i].Name < sortedRefKeys[j].Name
+	})
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		for _, refCollection := range sortedRefKeys {
+			if refCollection.IsView() {
+				continue
+			}
+
+			for _, field := range refs[refCollection] {
+				isSelfRef := refCollection.Id == target.Collection().Id
+
+				// the target self references are replaced in memory
+				// since the target record is saved separately
+				if isSelfRef {
+					ids := replaceRelationIds(target.GetStringSlice(field.Name), duplicateIds, target.Id)
+					ids = list.SubtractSlice(ids, []string{target.Id})
+					target.Set(field.Name, field.PrepareValue(ids))
+				}
+
+				for _, d := range duplicates {
+					refRecords := []*models.Record{}
+					if err := txDao.recordReferencesQuery(refCollection, field, d).All(&refRecords); err != nil {
+						return err
+					}
+
+					for _, refRecord := range refRecords {
+						if isSelfRef {
+							if _, ok := duplicateIds[refRecord.Id]; ok || refRecord.Id == target.Id {
+								continue // will be deleted or is already handled
+							}
+						}
+
+						ids := replaceRelationIds(refRecord.GetStringSlice(field.Name), duplicateIds, target.Id)
+						refRecord.Set(field.Name, field.PrepareValue(ids))
+
+						if err := txDao.SaveRecord(refRecord); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+
+		if err := txDao.SaveRecord(target); err != nil {
+			return err
+		}
+
+		for _, d := range duplicates {
+			if err := txDao.DeleteRecord(d); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// replaceRelationIds replaces the duplicate ids from the
+// provided relation ids with the target id.
+func replaceRelationIds(ids []string, duplicateIds map[string]struct{}, targetId string) []string {
+	result := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if _, ok := duplicateIds[id]; ok {
+			id = targetId
+		}
+		result = append(result, id)
+	}
+
+	return list.ToUniqueStringSlice(result)
+}
+
+// -------------------------------------------------------------------
+
+type duplicatesRule struct {
+	field  string
+	match  string
+	weight float64
+}
+
+// duplicatesScorer compares records using a collection duplicates options.
+type duplicatesScorer struct {
+	rules       []duplicatesRule
+	totalWeight float64
+	threshold   float64
+}
+
+func newDuplicatesScorer(collection *models.Collection, threshold float64) (*duplicatesScorer, error) {
+	var options *models.CollectionDuplicatesOptions
+
+	switch {
+	case collection.IsBase():
+		options = collection.BaseOptions().Duplicates
+	case collection.IsAuth():
+		options = collection.AuthOptions().Duplicates
+	}
+
+	if options == nil || len(options.Rules) == 0 {
+		return nil, fmt.Errorf("missing duplicates options for collection %q", collection.Name)
+	}
+
+	scorer := &duplicatesScorer{threshold: threshold}
+
+	if scorer.threshold <= 0 {
+		scorer.threshold = options.Threshold
+	}
+	if scorer.threshold <= 0 {
+		scorer.threshold = DefaultDuplicatesThreshold
+	}
+
+	for _, r := range options.Rules {
+		weight := r.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+
+		scorer.rules = append(scorer.rules, duplicatesRule{field: r.Field, match: r.Match, weight: weight})
+		scorer.totalWeight += weight
+	}
+
+	// evaluate the cheaper rules first so that the fuzzy ones
+	// could be skipped if the threshold can no longer be reached
+	sort.SliceStable(scorer.rules, func(i, j int) bool {
+		return scorer.rules[i].match != models.DuplicateMatchFuzzy && scorer.rules[j].match == models.DuplicateMatchFuzzy
+	})
+
+	return scorer, nil
+}
+
+// score returns the weighted similarity score of the two records
+// and whether it satisfies the scorer threshold.
+func (s *duplicatesScorer) score(a *models.Record, b *models.Record) (float64, bool) {
+	var total float64
+
+	remaining := s.totalWeight
+
+	for _, rule := range s.rules {
+		// the threshold cannot be reached even if all remaining rules match
+		if (total+remaining)/s.totalWeight < s.threshold {
+			return 0, false
+		}
+
+		remaining -= rule.weight
+
+		valA := duplicateValue(a, rule.field)
+		valB := duplicateValue(b, rule.field)
+		if valA == "" || valB == "" {
+			continue // empty values never match
+		}
+
+		switch rule.match {
+		case models.DuplicateMatchExact:
+			if valA == valB {
+				total += rule.weight
+			}
+		case models.DuplicateMatchNormalized:
+			normA := similarity.Normalize(valA)
+			if normA != "" && normA == similarity.Normalize(valB) {
+				total += rule.weight
+			}
+		case models.DuplicateMatchFuzzy:
+			normA := similarity.Normalize(valA)
+			normB := similarity.Normalize(valB)
+			if normA == "" || normB == "" {
+				continue
+			}
+
+			// skip the expensive comparison if the rule cannot affect the result
+			maxRatio := similarity.MaxRatio(len([]rune(normA)), len([]rune(normB)))
+			if (total+rule.weight*maxRatio+remaining)/s.totalWeight < s.threshold {
+				return 0, false
+			}
+
+			total += rule.weight * similarity.Ratio(normA, normB)
+		}
+	}
+
+	score := total / s.totalWeight
+
+	return score, score >= s.threshold
+}
+
+// duplicateValue returns the string representation of a record field value
+// (the multiple values are sorted to make the comparison order independent).
+func duplicateValue(record *models.Record, field string) string {
+	switch v := record.Get(field).(type) {
+	case []string:
+		values := append([]string{}, v...)
+		sort.Strings(values)
+		return strings.Join(values, ",")
+	case bool:
+		if !v {
+			return ""
+		}
+		return "true"
+	case float64:
+		if v == 0 {
+			return ""
+		}
+		return cast.ToString(v)
+	default:
+		return strings.TrimSpace(cast.ToString(v))
+	}
+}
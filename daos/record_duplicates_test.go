@@ -0,0 +1,269 @@
+package daos_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func createMockDuplicatesData(t *testing.T, dao *daos.Dao) (*models.Collection, *models.Collection) {
+	people := &models.Collection{}
+	people.Id = "people"
+	people.Name = people.Id
+	people.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "name",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name: "email",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name: "friends",
+			Type: schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{
+				MaxSelect:    types.Pointer(10),
+				CollectionId: "people",
+			},
+		},
+	)
+	people.SetOptions(models.CollectionBaseOptions{
+		Duplicates: &models.CollectionDuplicatesOptions{
+			Threshold: 0.7,
+			Rules: []models.CollectionDuplicateRule{
+				{Field: "email", Match: models.DuplicateMatchNormalized, Weight: 2},
+				{Field: "name", Match: models.DuplicateMatchFuzzy},
+			},
+		},
+	})
+	if err := dao.SaveCollection(people); err != nil {
+		t.Fatal(err)
+	}
+
+	notes := &models.Collection{}
+	notes.Id = "notes"
+	notes.Name = notes.Id
+	notes.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "author",
+			Type: schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{
+				MaxSelect:    types.Pointer(1),
+				CollectionId: "people",
+			},
+		},
+	)
+	if err := dao.SaveCollection(notes); err != nil {
+		t.Fatal(err)
+	}
+
+	peopleData := []struct {
+		id      string
+		name    string
+		email   string
+		friends []string
+	}{
+		{"p1", "John Doe", "john@example.com", nil},
+		{"p2", "Jon Doe", "JOHN@example.com", []string{"p1"}},
+		{"p3", "John Doe", "", []string{"p2"}},
+		{"p4", "Jane Smith", "jane@example.com", []string{"p2", "p4"}},
+	}
+	for _, d := range peopleData {
+		r := models.NewRecord(people)
+		r.Id = d.id
+		r.Set("name", d.name)
+		r.Set("email", d.email)
+		r.Set("friends", d.friends)
+		if err := dao.SaveRecord(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for id, author := range map[string]string{"n1": "p1", "n2": "p2", "n3": "p4"} {
+		r := models.NewRecord(notes)
+		r.Id = id
+		r.Set("author", author)
+		if err := dao.SaveRecord(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return people, notes
+}
+
+func TestFindRecordDuplicates(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	people, _ := createMockDuplicatesData(t, app.Dao())
+
+	scenarios := []struct {
+		name      string
+		recordId  string
+		threshold float64
+		limit     int
+		expected  []string
+	}{
+		{"default threshold", "p1", 0, 0, []string{"p2"}},
+		{"lower threshold", "p1", 0.3, 0, []string{"p2", "p3"}},
+		{"lower threshold with limit", "p1", 0.3, 1, []string{"p2"}},
+		{"no duplicates", "p4", 0, 0, []string{}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			record, err := app.Dao().FindRecordById(people.Id, s.recordId)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := app.Dao().FindRecordDuplicates(record, s.threshold, s.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, len(result))
+			for i, d := range result {
+				ids[i] = d.Record.Id
+				if d.Score <= 0 || d.Score > 1 {
+					t.Fatalf("Invalid score %v for %q", d.Score, d.Record.Id)
+				}
+			}
+
+			if strings.Join(ids, ",") != strings.Join(s.expected, ",") {
+				t.Fatalf("Expected duplicates %v, got %v", s.expected, ids)
+			}
+		})
+	}
+}
+
+func TestFindRecordDuplicatesMissingOptions(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo1", "al1h9ijdeojtsjy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordDuplicates(record, 0, 0); err == nil {
+		t.Fatal("Expected error for collection without duplicates options")
+	}
+
+	if _, err := app.Dao().FindRecordDuplicatePairs(record.Collection(), 0, 0); err == nil {
+		t.Fatal("Expected error for collection without duplicates options")
+	}
+}
+
+func TestFindRecordDuplicatePairs(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	people, _ := createMockDuplicatesData(t, app.Dao())
+
+	pairs, err := app.Dao().FindRecordDuplicatePairs(people, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pairs) != 1 {
+		t.Fatalf("Expected 1 pair, got %d", len(pairs))
+	}
+
+	ids := []string{pairs[0].A.Id, pairs[0].B.Id}
+	if !(ids[0] == "p1" && ids[1] == "p2") && !(ids[0] == "p2" && ids[1] == "p1") {
+		t.Fatalf("Expected p1-p2 pair, got %v", ids)
+	}
+}
+
+func TestMergeRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	people, notes := createMockDuplicatesData(t, app.Dao())
+
+	target, err := app.Dao().FindRecordById(people.Id, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	duplicates, err := app.Dao().FindRecordsByIds(people.Id, []string{"p2", "p3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().MergeRecords(target, duplicates); err != nil {
+		t.Fatal(err)
+	}
+
+	// the duplicates should be deleted
+	remaining, err := app.Dao().FindRecordsByIds(people.Id, []string{"p2", "p3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("Expected the duplicates to be deleted, found %d", len(remaining))
+	}
+
+	// the target self reference should be removed
+	target, err = app.Dao().FindRecordById(people.Id, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if friends := target.GetStringSlice("friends"); len(friends) != 0 {
+		t.Fatalf("Expected no target friends, got %v", friends)
+	}
+
+	// the inbound self references should be replaced
+	p4, err := app.Dao().FindRecordById(people.Id, "p4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if friends := strings.Join(p4.GetStringSlice("friends"), ","); friends != "p1,p4" {
+		t.Fatalf("Expected p4 friends %q, got %q", "p1,p4", friends)
+	}
+
+	// the inbound references from other collections should be replaced
+	expectedAuthors := map[string]string{"n1": "p1", "n2": "p1", "n3": "p4"}
+	for id, author := range expectedAuthors {
+		note, err := app.Dao().FindRecordById(notes.Id, id)
+		if err != nil {
+			t.Fatalf("Failed to find note %q: %v", id, err)
+		}
+		if v := note.GetString("author"); v != author {
+			t.Fatalf("Expected note %q author %q, got %q", id, author, v)
+		}
+	}
+}
+
+func TestMergeRecordsWithItself(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	people, _ := createMockDuplicatesData(t, app.Dao())
+
+	target, err := app.Dao().FindRecordById(people.Id, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().MergeRecords(target, []*models.Record{target}); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
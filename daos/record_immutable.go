@@ -0,0 +1,214 @@
+package daos
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// ErrImmutableRecord is returned when trying to update or
+// delete a record of an immutable (append-only) collection.
+var ErrImmutableRecord = errors.New("the records of an immutable collection cannot be updated or deleted")
+
+// hashChainBatchSize is the number of records loaded at once
+// when verifying a collection records hash chain.
+const hashChainBatchSize = 500
+
+// validateImmutableCollectionChange checks whether the new collection
+// state doesn't weaken an existing immutable collection.
+//
+// An immutable collection cannot be made mutable again, cannot change
+// its hash field and cannot remove, rename or change the type of its
+// existing schema fields. New fields could be added only if the
+// collection doesn't have a hash chain (the new field value is part
+// of the hashed data and would break the chain of the existing records).
+func validateImmutableCollectionChange(oldCollection, newCollection *models.Collection) error {
+	if oldCollection == nil {
+		return nil
+	}
+
+	old := oldCollection.Immutable()
+	if old == nil {
+		return nil
+	}
+
+	current := newCollection.Immutable()
+	if current == nil {
+		return fmt.Errorf("immutable collection %q cannot be made mutable again", oldCollection.Name)
+	}
+
+	if current.HashField != old.HashField {
+		return fmt.Errorf("the hash field of immutable collection %q cannot be changed", oldCollection.Name)
+	}
+
+	for _, oldField := range oldCollection.Schema.Fields() {
+		field := newCollection.Schema.GetFieldById(oldField.Id)
+		if field == nil {
+			return fmt.Errorf("the %q field of immutable collection %q cannot be removed", oldField.Name, oldCollection.Name)
+		}
+
+		if field.Name != oldField.Name || field.Type != oldField.Type {
+			return fmt.Errorf("the %q field of immutable collection %q cannot be renamed or change its type", oldField.Name, oldCollection.Name)
+		}
+	}
+
+	if old.HashField != "" {
+		for _, field := range newCollection.Schema.Fields() {
+			if oldCollection.Schema.GetFieldById(field.Id) == nil {
+				return fmt.Errorf("new fields cannot be added to the hash chained immutable collection %q", oldCollection.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// createImmutableRecord inserts the new immutable record and
+// stores its hash chain value (if the collection has a hash field).
+func (dao *Dao) createImmutableRecord(record *models.Record) error {
+	hashField := record.Collection().Immutable().HashField
+	if hashField == "" {
+		return dao.Save(record)
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.Save(record); err != nil {
+			return err
+		}
+
+		// reload the stored record to hash its normalized data
+		// the same way as it will be loaded on verification
+		stored, err := txDao.FindRecordById(record.Collection().Id, record.Id)
+		if err != nil {
+			return err
+		}
+
+		// the insert is already holding the write lock so the
+		// previous record hash cannot change until commit
+		var prevHash string
+		err = txDao.DB().Select("[[" + hashField + "]]").
+			From(record.Collection().Name).
+			AndWhere(dbx.NewExp(
+				"[[rowid]] < (SELECT [[rowid]] FROM {{"+record.Collection().Name+"}} WHERE [[id]] = {:id})",
+				dbx.Params{"id": record.Id},
+			)).
+			OrderBy("rowid DESC").
+			Limit(1).
+			Row(&prevHash)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		hash, err := recordChainHash(stored, prevHash)
+		if err != nil {
+			return err
+		}
+
+		_, err = txDao.DB().Update(
+			record.Collection().Name,
+			dbx.Params{hashField: hash},
+			dbx.HashExp{schema.FieldNameId: record.Id},
+		).Execute()
+		if err != nil {
+			return err
+		}
+
+		record.Set(hashField, hash)
+
+		return nil
+	})
+}
+
+// recordChainHash returns the hex encoded SHA-256 hash of the
+// previous record hash and the record id, created date and
+// schema data (except the hash field itself).
+func recordChainHash(record *models.Record, prevHash string) (string, error) {
+	hashField := record.Collection().Immutable().HashField
+
+	data := map[string]any{
+		schema.FieldNameId:      record.Id,
+		schema.FieldNameCreated: record.Created.String(),
+	}
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Name != hashField {
+			data[field.Name] = record.Get(field.Name)
+		}
+	}
+
+	// note: the map keys are sorted so the encoded data is deterministic
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte{'\n'})
+	h.Write(raw)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyRecordsHashChain recomputes the hash chain of the
+// provided immutable collection records in their insertion order.
+//
+// It returns the id of the first record whose stored hash doesn't
+// match (eg. because of a direct db modification of the record or
+// of its previous one) or empty string if the chain is intact.
+//
+// The records created before enabling the hash chain are skipped.
+//
+// Note that deleting the last records cannot be detected by the
+// chain itself and should be checked against an external copy
+// of the last record hash (eg. a periodically exported checkpoint).
+func (dao *Dao) VerifyRecordsHashChain(collection *models.Collection) (string, error) {
+	options := collection.Immutable()
+	if options == nil || options.HashField == "" {
+		return "", fmt.Errorf("the %q collection doesn't have a hash chain", collection.Name)
+	}
+
+	var prevHash string
+	var started bool
+
+	query := dao.RecordQuery(collection).OrderBy("rowid ASC")
+
+	for offset := 0; ; offset += hashChainBatchSize {
+		batch := []*models.Record{}
+
+		if err := query.Limit(hashChainBatchSize).Offset(int64(offset)).All(&batch); err != nil {
+			return "", err
+		}
+
+		for _, record := range batch {
+			storedHash := record.GetString(options.HashField)
+
+			if storedHash != "" || started {
+				started = true
+
+				hash, err := recordChainHash(record, prevHash)
+				if err != nil {
+					return "", err
+				}
+
+				if hash != storedHash {
+					return record.Id, nil
+				}
+			}
+
+			prevHash = storedHash
+		}
+
+		if len(batch) < hashChainBatchSize {
+			break // no more records
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,278 @@
+package daos_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createMockImmutableCollection(t *testing.T, dao *daos.Dao, hashField string) *models.Collection {
+	collection := &models.Collection{}
+	collection.Id = "audit"
+	collection.Name = collection.Id
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "action",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name: "hash",
+			Type: schema.FieldTypeText,
+		},
+	)
+	collection.SetOptions(models.CollectionBaseOptions{
+		Immutable: &models.CollectionImmutableOptions{
+			Enabled:   true,
+			HashField: hashField,
+		},
+	})
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"a1", "a2", "a3"} {
+		record := models.NewRecord(collection)
+		record.Id = id
+		record.Set("action", "action_"+id)
+		record.Set("hash", "user_provided")
+		if err := dao.SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func TestImmutableRecordUpdateAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createMockImmutableCollection(t, app.Dao(), "")
+
+	record, err := app.Dao().FindRecordById(collection.Id, "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.GetString("hash") != "user_provided" {
+		t.Fatalf("Expected the hash field to be left as it is without hash chain, got %q", record.GetString("hash"))
+	}
+
+	record.Set("action", "changed")
+	if err := app.Dao().SaveRecord(record); !errors.Is(err, daos.ErrImmutableRecord) {
+		t.Fatalf("Expected ErrImmutableRecord on update, got %v", err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); !errors.Is(err, daos.ErrImmutableRecord) {
+		t.Fatalf("Expected ErrImmutableRecord on delete, got %v", err)
+	}
+
+	if err := app.Dao().DeleteCollection(collection); err == nil {
+		t.Fatal("Expected the immutable collection delete to fail")
+	}
+}
+
+func TestVerifyRecordsHashChain(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// without hash chain
+	demo, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().VerifyRecordsHashChain(demo); err == nil {
+		t.Fatal("Expected error for collection without hash chain")
+	}
+
+	collection := createMockImmutableCollection(t, app.Dao(), "hash")
+
+	records, err := app.Dao().FindRecordsByIds(collection.Id, []string{"a1", "a2", "a3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes := map[string]bool{}
+	for _, r := range records {
+		hash := r.GetString("hash")
+		if len(hash) != 64 {
+			t.Fatalf("Expected %q sha256 hex hash, got %q", r.Id, hash)
+		}
+		hashes[hash] = true
+	}
+	if len(hashes) != 3 {
+		t.Fatalf("Expected 3 unique hashes, got %v", hashes)
+	}
+
+	brokenId, err := app.Dao().VerifyRecordsHashChain(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if brokenId != "" {
+		t.Fatalf("Expected valid chain, got broken record %q", brokenId)
+	}
+
+	// direct db modification
+	if _, err := app.Dao().DB().NewQuery("UPDATE audit SET action = 'tampered' WHERE id = 'a2'").Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	brokenId, err = app.Dao().VerifyRecordsHashChain(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if brokenId != "a2" {
+		t.Fatalf("Expected broken record a2, got %q", brokenId)
+	}
+
+	// direct db deletion (the next record chain is broken)
+	if _, err := app.Dao().DB().NewQuery("DELETE FROM audit WHERE id = 'a2'").Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	brokenId, err = app.Dao().VerifyRecordsHashChain(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if brokenId != "a3" {
+		t.Fatalf("Expected broken record a3, got %q", brokenId)
+	}
+}
+
+func TestSaveImmutableCollectionChanges(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name        string
+		hashField   string
+		change      func(c *models.Collection)
+		expectError bool
+	}{
+		{
+			"rules change",
+			"hash",
+			func(c *models.Collection) {
+				rule := ""
+				c.ListRule = &rule
+			},
+			false,
+		},
+		{
+			"made mutable",
+			"",
+			func(c *models.Collection) {
+				c.SetOptions(models.CollectionBaseOptions{})
+			},
+			true,
+		},
+		{
+			"hash field change",
+			"hash",
+			func(c *models.Collection) {
+				c.SetOptions(models.CollectionBaseOptions{
+					Immutable: &models.CollectionImmutableOptions{Enabled: true, HashField: "action"},
+				})
+			},
+			true,
+		},
+		{
+			"field removal",
+			"",
+			func(c *models.Collection) {
+				c.Schema.RemoveField(c.Schema.GetFieldByName("action").Id)
+			},
+			true,
+		},
+		{
+			"field rename",
+			"",
+			func(c *models.Collection) {
+				c.Schema.GetFieldByName("action").Name = "renamed"
+			},
+			true,
+		},
+		{
+			"field type change",
+			"",
+			func(c *models.Collection) {
+				c.Schema.GetFieldByName("action").Type = schema.FieldTypeEditor
+			},
+			true,
+		},
+		{
+			"new field without hash chain",
+			"",
+			func(c *models.Collection) {
+				c.Schema.AddField(&schema.SchemaField{Id: "extra_id", Name: "extra", Type: schema.FieldTypeText})
+			},
+			false,
+		},
+		{
+			"new field with hash chain",
+			"hash",
+			func(c *models.Collection) {
+				c.Schema.AddField(&schema.SchemaField{Id: "extra_id", Name: "extra", Type: schema.FieldTypeText})
+			},
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			createMockImmutableCollection(t, app.Dao(), s.hashField)
+
+			collection, err := app.Dao().FindCollectionByNameOrId("audit")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s.change(collection)
+
+			err = app.Dao().SaveCollection(collection)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+
+	// import changes
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createMockImmutableCollection(t, app.Dao(), "")
+
+	collection, err := app.Dao().FindCollectionByNameOrId("audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.SetOptions(models.CollectionBaseOptions{})
+
+	if err := app.Dao().ImportCollections([]*models.Collection{collection}, false, nil); err == nil {
+		t.Fatal("Expected the import to fail making the immutable collection mutable")
+	}
+
+	// import all collections except the immutable one
+	all := []*models.Collection{}
+	if err := app.Dao().CollectionQuery().AndWhere(dbx.Not(dbx.HashExp{"id": "audit"})).All(&all); err != nil {
+		t.Fatal(err)
+	}
+
+	err = app.Dao().ImportCollections(all, true, nil)
+	if err == nil || !strings.Contains(err.Error(), "immutable") {
+		t.Fatalf("Expected the import to fail deleting the immutable collection, got %v", err)
+	}
+}
@@ -677,6 +677,90 @@ func TestFindFirstRecordByFilter(t *testing.T) {
 	}
 }
 
+func TestFindRecordsAfterCursor(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	t.Run("missing collection", func(t *testing.T) {
+		if _, _, err := app.Dao().FindRecordsAfterCursor("missing", "", "", 0, ""); err == nil {
+			t.Fatal("Expected error")
+		}
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		if _, _, err := app.Dao().FindRecordsAfterCursor("demo2", "missing = 1", "", 0, ""); err == nil {
+			t.Fatal("Expected error")
+		}
+	})
+
+	t.Run("unsupported sort", func(t *testing.T) {
+		if _, _, err := app.Dao().FindRecordsAfterCursor("demo2", "", "@random", 0, ""); err == nil {
+			t.Fatal("Expected error")
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		if _, _, err := app.Dao().FindRecordsAfterCursor("demo2", "", "", 0, "invalid"); err == nil {
+			t.Fatal("Expected error")
+		}
+	})
+
+	t.Run("filter with params and no next page", func(t *testing.T) {
+		records, next, err := app.Dao().FindRecordsAfterCursor("demo2", "active = {:active}", "-title", 2, "", dbx.Params{"active": true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if next != "" {
+			t.Fatalf("Expected empty next cursor, got %q", next)
+		}
+
+		expectedIds := []string{"0yxhwia2amd8gec", "achvryl401bhse3"}
+		if len(records) != len(expectedIds) {
+			t.Fatalf("Expected %d records, got %d", len(expectedIds), len(records))
+		}
+		for i, id := range expectedIds {
+			if records[i].Id != id {
+				t.Fatalf("Expected record %d to be %q, got %q", i, id, records[i].Id)
+			}
+		}
+	})
+
+	t.Run("paginate", func(t *testing.T) {
+		ids := []string{}
+		cursor := ""
+		pages := 0
+
+		for {
+			records, next, err := app.Dao().FindRecordsAfterCursor("demo2", "", "title", 2, cursor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pages++
+
+			for _, r := range records {
+				ids = append(ids, r.Id)
+			}
+
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		if pages != 2 {
+			t.Fatalf("Expected 2 pages, got %d", pages)
+		}
+
+		expected := "llvuca81nly1qls,achvryl401bhse3,0yxhwia2amd8gec"
+		if v := strings.Join(ids, ","); v != expected {
+			t.Fatalf("Expected ids %s, got %s", expected, v)
+		}
+	})
+}
+
 func TestCanAccessRecord(t *testing.T) {
 	t.Parallel()
 
@@ -897,6 +981,151 @@ func TestIsRecordValueUnique(t *testing.T) {
 	}
 }
 
+func TestFindRecordUniqueIndexConflicts(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo2.Indexes = types.JsonArray[string]{
+		"CREATE UNIQUE INDEX `idx_composite` ON `demo2` (`title` COLLATE NOCASE, `active`)",
+		"CREATE UNIQUE INDEX `idx_expr` ON `demo2` (LOWER(`title`), `active`)",
+	}
+	if err := app.Dao().SaveCollection(demo2); err != nil {
+		t.Fatal(err)
+	}
+
+	demo4, err := app.Dao().FindCollectionByNameOrId("demo4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// populate the partial unique index field of an existing record
+	existingDemo4, err := app.Dao().FindRecordById(demo4.Id, "qzaqccwrmva4o1n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingDemo4.Set("rel_one_unique", "mk5fmymtx4wsprk")
+	if err := app.Dao().WithoutHooks().SaveRecord(existingDemo4); err != nil {
+		t.Fatal(err)
+	}
+
+	newRecord := func(collection *models.Collection, data map[string]any) *models.Record {
+		record := models.NewRecord(collection)
+		record.Load(data)
+		return record
+	}
+
+	existingRecord := func(collection *models.Collection, id string, data map[string]any) *models.Record {
+		record, err := app.Dao().FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record.Load(data)
+		return record
+	}
+
+	scenarios := []struct {
+		name     string
+		record   *models.Record
+		expected []string
+	}{
+		{
+			"new record with composite conflict (collate nocase)",
+			newRecord(demo2, map[string]any{"title": "TEST1", "active": false}),
+			[]string{"idx_composite"},
+		},
+		{
+			"new record without composite conflict",
+			newRecord(demo2, map[string]any{"title": "test1", "active": true}),
+			nil,
+		},
+		{
+			"unchanged existing record",
+			existingRecord(demo2, "llvuca81nly1qls", nil),
+			nil,
+		},
+		{
+			"existing record with composite conflict",
+			existingRecord(demo2, "achvryl401bhse3", map[string]any{"title": "test1", "active": false}),
+			[]string{"idx_composite"},
+		},
+		{
+			"new record excluded from the partial index",
+			newRecord(demo4, map[string]any{"rel_one_unique": ""}),
+			nil,
+		},
+		{
+			"new record with partial index conflict",
+			newRecord(demo4, map[string]any{"rel_one_unique": "mk5fmymtx4wsprk"}),
+			[]string{"idx_luoQV2A"},
+		},
+		{
+			"new record without partial index conflict",
+			newRecord(demo4, map[string]any{"rel_one_unique": "7nwo8tuiatetxdm"}),
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			conflicts, err := app.Dao().FindRecordUniqueIndexConflicts(s.record)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(conflicts) != len(s.expected) {
+				t.Fatalf("Expected %d conflicts, got %d (%v)", len(s.expected), len(conflicts), conflicts)
+			}
+
+			for i, idx := range conflicts {
+				if idx.IndexName != s.expected[i] {
+					t.Fatalf("Expected conflict %q, got %q", s.expected[i], idx.IndexName)
+				}
+			}
+		})
+	}
+}
+
+func TestNextRecordSequenceValue(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collectionIdOrName string
+		field              string
+		expected           int
+		expectError        bool
+	}{
+		{"missing", "number", 0, true},
+		{"demo1", "missing", 0, true},
+		{"demo1", "number", 123457, false},
+		{"demo5", "total", 3, false},
+		{"demo3", "title", 1, false}, // non-numeric values
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.collectionIdOrName+"_"+s.field, func(t *testing.T) {
+			result, err := app.Dao().NextRecordSequenceValue(s.collectionIdOrName, s.field)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %d, got %d", s.expected, result)
+			}
+		})
+	}
+}
+
 func TestFindAuthRecordByToken(t *testing.T) {
 	t.Parallel()
 
@@ -1136,6 +1365,116 @@ func TestSaveRecordWithIdFromOtherCollection(t *testing.T) {
 	}
 }
 
+func TestSaveRecordWithRevisionField(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := newRevisionCollection(t, app.Dao())
+
+	record := models.NewRecord(collection)
+	record.Set("title", "test")
+	record.Set("rev", 100) // should be ignored
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if rev := record.GetInt("rev"); rev != 1 {
+		t.Fatalf("Expected revision 1 after create, got %d", rev)
+	}
+
+	// update a stale copy of the record
+	stale, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("title", "test_update")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	stale.Set("title", "test_stale")
+	if err := app.Dao().SaveRecord(stale); err != nil {
+		t.Fatal(err)
+	}
+	if rev := stale.GetInt("rev"); rev != 3 {
+		t.Fatalf("Expected the revision to be incremented from the stored value 3, got %d", rev)
+	}
+}
+
+func TestCompareAndSaveRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// collection without revision field
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().CompareAndSaveRecord(models.NewRecord(demo2), 0); err == nil {
+		t.Fatal("Expected error for collection without revision field")
+	}
+
+	collection := newRevisionCollection(t, app.Dao())
+
+	// new record with non-zero expected revision
+	record := models.NewRecord(collection)
+	record.Set("title", "test")
+	if err := app.Dao().CompareAndSaveRecord(record, 1); !errors.Is(err, daos.ErrRecordRevisionConflict) {
+		t.Fatalf("Expected ErrRecordRevisionConflict, got %v", err)
+	}
+
+	if err := app.Dao().CompareAndSaveRecord(record, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("title", "test_update")
+	if err := app.Dao().CompareAndSaveRecord(record, 1); err != nil {
+		t.Fatal(err)
+	}
+	if rev := record.GetInt("rev"); rev != 2 {
+		t.Fatalf("Expected revision 2, got %d", rev)
+	}
+
+	// stale revision
+	record.Set("title", "test_stale")
+	if err := app.Dao().CompareAndSaveRecord(record, 1); !errors.Is(err, daos.ErrRecordRevisionConflict) {
+		t.Fatalf("Expected ErrRecordRevisionConflict, got %v", err)
+	}
+
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.GetString("title") != "test_update" || stored.GetInt("rev") != 2 {
+		t.Fatalf("Expected the stored record to be unchanged, got %v", stored)
+	}
+}
+
+func newRevisionCollection(t *testing.T, dao *daos.Dao) *models.Collection {
+	collection := &models.Collection{
+		Name: "revision_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "rev", Type: schema.FieldTypeNumber},
+		),
+	}
+
+	if err := collection.SetOptions(&models.CollectionBaseOptions{RevisionField: "rev"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
 func TestDeleteRecord(t *testing.T) {
 	t.Parallel()
 
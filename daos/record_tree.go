@@ -0,0 +1,160 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+)
+
+// FindTreeRelationField returns the self-referencing relation field
+// of the collection with the provided name.
+//
+// If fieldName is empty, returns the only self-referencing relation
+// field of the collection (or error if there is none or more than one).
+func (dao *Dao) FindTreeRelationField(collection *models.Collection, fieldName string) (*schema.SchemaField, error) {
+	var result *schema.SchemaField
+
+	for _, field := range collection.Schema.Fields() {
+		if field.Type != schema.FieldTypeRelation || (fieldName != "" && field.Name != fieldName) {
+			continue
+		}
+
+		field.InitOptions()
+		options, _ := field.Options.(*schema.RelationOptions)
+		if options == nil || options.CollectionId != collection.Id {
+			continue
+		}
+
+		if result != nil {
+			return nil, fmt.Errorf("collection %q has more than one self-referencing relation field", collection.Name)
+		}
+
+		result = field
+	}
+
+	if result == nil {
+		if fieldName != "" {
+			return nil, fmt.Errorf("%q is not a self-referencing relation field of collection %q", fieldName, collection.Name)
+		}
+		return nil, fmt.Errorf("collection %q has no self-referencing relation field", collection.Name)
+	}
+
+	return result, nil
+}
+
+// RecordSubtreeExpr returns an expression that matches all descendants
+// of the record with the provided id (excluding the record itself)
+// by recursively following the self-referencing relation fieldName.
+func (dao *Dao) RecordSubtreeExpr(collection *models.Collection, fieldName string, recordId string) (dbx.Expression, error) {
+	field, err := dao.FindTreeRelationField(collection, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbx.NewExp(
+		fmt.Sprintf("[[%s.id]] IN (%s)", collection.Name, subtreeQuery(collection, field, "subtreeOf")),
+		dbx.Params{"subtreeOf": recordId},
+	), nil
+}
+
+// RecordAncestorsExpr returns an expression that matches all ancestors
+// of the record with the provided id (excluding the record itself)
+// by recursively following the self-referencing relation fieldName.
+func (dao *Dao) RecordAncestorsExpr(collection *models.Collection, fieldName string, recordId string) (dbx.Expression, error) {
+	field, err := dao.FindTreeRelationField(collection, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbx.NewExp(
+		fmt.Sprintf("[[%s.id]] IN (%s)", collection.Name, ancestorsQuery(collection, field, "ancestorsOf")),
+		dbx.Params{"ancestorsOf": recordId},
+	), nil
+}
+
+// FindRecordAncestorIds returns the ids of all ancestors of the record with
+// the provided id by recursively following the self-referencing relation fieldName.
+func (dao *Dao) FindRecordAncestorIds(collection *models.Collection, fieldName string, recordId string) ([]string, error) {
+	field, err := dao.FindTreeRelationField(collection, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+
+	err = dao.DB().NewQuery(ancestorsQuery(collection, field, "ancestorsOf")).
+		Bind(dbx.Params{"ancestorsOf": recordId}).
+		Column(&ids)
+
+	return ids, err
+}
+
+// ErrRecordTreeCycle is returned by [Dao.CheckRecordTreeCycle] when
+// the relation values would make a record an ancestor of itself.
+var ErrRecordTreeCycle = errors.New("the relation creates a cycle")
+
+// CheckRecordTreeCycle checks whether setting the self-referencing relation
+// fieldName of the provided record to relIds would create a cycle
+// (aka. the record becoming its own ancestor).
+func (dao *Dao) CheckRecordTreeCycle(record *models.Record, fieldName string, relIds []string) error {
+	for _, id := range relIds {
+		if id == record.Id {
+			return ErrRecordTreeCycle
+		}
+	}
+
+	if record.IsNew() {
+		return nil // nothing can reference a new record yet
+	}
+
+	for _, id := range relIds {
+		ancestors, err := dao.FindRecordAncestorIds(record.Collection(), fieldName, id)
+		if err != nil {
+			return err
+		}
+
+		for _, ancestor := range ancestors {
+			if ancestor == record.Id {
+				return ErrRecordTreeCycle
+			}
+		}
+	}
+
+	return nil
+}
+
+func ancestorsQuery(collection *models.Collection, field *schema.SchemaField, param string) string {
+	column := "_treeRecords." + field.Name
+
+	return fmt.Sprintf(
+		`WITH RECURSIVE _ancestors(id) AS (
+			SELECT _treeRel.value FROM {{%s}} _treeRecords, %s _treeRel WHERE [[_treeRecords.id]] = {:%s}
+			UNION
+			SELECT _treeRel.value FROM {{%s}} _treeRecords, _ancestors, %s _treeRel WHERE [[_treeRecords.id]] = _ancestors.id
+		)
+		SELECT id FROM _ancestors WHERE id != {:%s} AND id != ''`,
+		collection.Name, dbutils.JsonEach(column), param,
+		collection.Name, dbutils.JsonEach(column),
+		param,
+	)
+}
+
+func subtreeQuery(collection *models.Collection, field *schema.SchemaField, param string) string {
+	column := "_treeRecords." + field.Name
+
+	return fmt.Sprintf(
+		`WITH RECURSIVE _subtree(id) AS (
+			SELECT [[_treeRecords.id]] FROM {{%s}} _treeRecords, %s _treeRel WHERE _treeRel.value = {:%s}
+			UNION
+			SELECT [[_treeRecords.id]] FROM {{%s}} _treeRecords, _subtree, %s _treeRel WHERE _treeRel.value = _subtree.id
+		)
+		SELECT id FROM _subtree WHERE id != {:%s}`,
+		collection.Name, dbutils.JsonEach(column), param,
+		collection.Name, dbutils.JsonEach(column),
+		param,
+	)
+}
@@ -0,0 +1,216 @@
+package daos_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// prepareRecordTreeTestApp links the demo1 rel_one records into the tree:
+// imy661ixudk5izi -> al1h9ijdeojtsjy -> 84nmscqy84lsi1t
+func prepareRecordTreeTestApp(t *testing.T) *tests.TestApp {
+	app, _ := tests.NewTestApp()
+
+	leaf, err := app.Dao().FindRecordById("demo1", "imy661ixudk5izi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf.Set("rel_one", "al1h9ijdeojtsjy")
+	if err := app.Dao().WithoutHooks().SaveRecord(leaf); err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+func TestFindTreeRelationField(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collection    string
+		field         string
+		expectedField string
+	}{
+		{"demo2", "", ""},               // no relation fields
+		{"demo1", "", "rel_one"},        // single self-referencing field
+		{"demo1", "rel_one", "rel_one"}, // explicit field
+		{"demo1", "rel_many", ""},       // not self-referencing
+		{"demo1", "missing", ""},        // missing field
+		{"demo4", "", ""},               // ambiguous
+		{"demo4", "self_rel_many", "self_rel_many"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.collection+"_"+s.field, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			field, err := app.Dao().FindTreeRelationField(collection, s.field)
+
+			hasErr := err != nil
+			if expectErr := s.expectedField == ""; hasErr != expectErr {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", expectErr, hasErr, err)
+			}
+
+			if field != nil && field.Name != s.expectedField {
+				t.Fatalf("Expected field %q, got %q", s.expectedField, field.Name)
+			}
+		})
+	}
+}
+
+func TestRecordSubtreeAndAncestorsExpr(t *testing.T) {
+	t.Parallel()
+
+	app := prepareRecordTreeTestApp(t)
+	defer app.Cleanup()
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo4, err := app.Dao().FindCollectionByNameOrId("demo4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		collection  *models.Collection
+		field       string
+		ancestors   bool
+		id          string
+		expectedIds []string
+	}{
+		{"subtree of root", demo1, "", false, "84nmscqy84lsi1t", []string{"al1h9ijdeojtsjy", "imy661ixudk5izi"}},
+		{"subtree of middle", demo1, "", false, "al1h9ijdeojtsjy", []string{"imy661ixudk5izi"}},
+		{"subtree of leaf", demo1, "", false, "imy661ixudk5izi", []string{}},
+		{"subtree of missing", demo1, "", false, "missing", []string{}},
+		{"ancestors of leaf", demo1, "", true, "imy661ixudk5izi", []string{"84nmscqy84lsi1t", "al1h9ijdeojtsjy"}},
+		{"ancestors of root", demo1, "", true, "84nmscqy84lsi1t", []string{}},
+		// qzaqccwrmva4o1n <-> i9naidtvr6qsgb4
+		{"subtree with cycle", demo4, "self_rel_one", false, "qzaqccwrmva4o1n", []string{"i9naidtvr6qsgb4"}},
+		{"ancestors with cycle", demo4, "self_rel_one", true, "qzaqccwrmva4o1n", []string{"i9naidtvr6qsgb4"}},
+		// qzaqccwrmva4o1n -> [i9naidtvr6qsgb4, qzaqccwrmva4o1n]
+		{"subtree of multiple relation", demo4, "self_rel_many", false, "i9naidtvr6qsgb4", []string{"qzaqccwrmva4o1n"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			exprFunc := app.Dao().RecordSubtreeExpr
+			if s.ancestors {
+				exprFunc = app.Dao().RecordAncestorsExpr
+			}
+
+			expr, err := exprFunc(s.collection, s.field, s.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := []string{}
+			err = app.Dao().RecordQuery(s.collection).
+				Select(s.collection.Name + ".id").
+				AndWhere(expr).
+				OrderBy("id ASC").
+				Column(&ids)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if strings.Join(ids, ",") != strings.Join(s.expectedIds, ",") {
+				t.Fatalf("Expected ids %v, got %v", s.expectedIds, ids)
+			}
+		})
+	}
+
+	// invalid tree field
+	if _, err := app.Dao().RecordSubtreeExpr(demo4, "", "qzaqccwrmva4o1n"); err == nil {
+		t.Fatal("Expected ambiguous tree field error, got nil")
+	}
+	if _, err := app.Dao().RecordAncestorsExpr(demo1, "rel_many", "al1h9ijdeojtsjy"); err == nil {
+		t.Fatal("Expected not self-referencing field error, got nil")
+	}
+}
+
+func TestFindRecordAncestorIds(t *testing.T) {
+	t.Parallel()
+
+	app := prepareRecordTreeTestApp(t)
+	defer app.Cleanup()
+
+	demo1, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := app.Dao().FindRecordAncestorIds(demo1, "rel_one", "imy661ixudk5izi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ordered from the closest ancestor
+	if strings.Join(ids, ",") != "al1h9ijdeojtsjy,84nmscqy84lsi1t" {
+		t.Fatalf("Expected the leaf ancestors, got %v", ids)
+	}
+}
+
+func TestCheckRecordTreeCycle(t *testing.T) {
+	t.Parallel()
+
+	app := prepareRecordTreeTestApp(t)
+	defer app.Cleanup()
+
+	root, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf, err := app.Dao().FindRecordById("demo1", "imy661ixudk5izi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRecord := models.NewRecord(root.Collection())
+	newRecord.SetId("new_record_id01")
+
+	scenarios := []struct {
+		name        string
+		record      *models.Record
+		relIds      []string
+		expectCycle bool
+	}{
+		{"no ids", root, nil, false},
+		{"self reference", root, []string{root.Id}, true},
+		{"descendant", root, []string{"al1h9ijdeojtsjy"}, true},
+		{"nested descendant", root, []string{"imy661ixudk5izi"}, true},
+		{"ancestor", leaf, []string{root.Id}, false},
+		{"new record self reference", newRecord, []string{"new_record_id01"}, true},
+		{"new record", newRecord, []string{leaf.Id}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := app.Dao().CheckRecordTreeCycle(s.record, "rel_one", s.relIds)
+
+			if isCycle := errors.Is(err, daos.ErrRecordTreeCycle); isCycle != s.expectCycle {
+				t.Fatalf("Expected cycle %v, got %v (%v)", s.expectCycle, isCycle, err)
+			}
+		})
+	}
+
+	// non self-referencing field errors shouldn't be reported as cycles
+	err = app.Dao().CheckRecordTreeCycle(root, "rel_many", []string{"al1h9ijdeojtsjy"})
+	if err == nil || errors.Is(err, daos.ErrRecordTreeCycle) {
+		t.Fatalf("Expected non-cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RefreshTokenQuery returns a new RefreshToken select query.
+func (dao *Dao) RefreshTokenQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RefreshToken{})
+}
+
+// FindRefreshTokenByHash finds a single RefreshToken model by its hash.
+func (dao *Dao) FindRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	model := &models.RefreshToken{}
+
+	err := dao.RefreshTokenQuery().
+		AndWhere(dbx.HashExp{"hash": hash}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllRefreshTokensByOwner returns all RefreshToken models
+// issued for the specified owner.
+//
+// collectionId is expected to be empty for admins.
+func (dao *Dao) FindAllRefreshTokensByOwner(collectionId string, recordId string) ([]*models.RefreshToken, error) {
+	result := []*models.RefreshToken{}
+
+	err := dao.RefreshTokenQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveRefreshToken upserts the provided RefreshToken model.
+func (dao *Dao) SaveRefreshToken(model *models.RefreshToken) error {
+	return dao.Save(model)
+}
+
+// RevokeRefreshToken marks the provided RefreshToken model as revoked.
+//
+// It returns false if the token was already revoked
+// (eg. by a concurrent rotation of the same token).
+func (dao *Dao) RevokeRefreshToken(model *models.RefreshToken) (bool, error) {
+	result, err := dao.NonconcurrentDB().Update(
+		model.TableName(),
+		dbx.Params{"revoked": true, "updated": types.NowDateTime().String()},
+		dbx.HashExp{"id": model.Id, "revoked": false},
+	).Execute()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	model.Revoked = true
+
+	return affected > 0, nil
+}
+
+// RevokeRefreshTokenFamily marks all refresh tokens from the specified
+// rotation family as revoked.
+func (dao *Dao) RevokeRefreshTokenFamily(family string) error {
+	return dao.revokeRefreshTokens(dbx.HashExp{"family": family})
+}
+
+// RevokeAllRefreshTokens marks all refresh tokens issued
+// for the specified owner as revoked.
+//
+// collectionId is expected to be empty for admins.
+func (dao *Dao) RevokeAllRefreshTokens(collectionId string, recordId string) error {
+	return dao.revokeRefreshTokens(dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	})
+}
+
+func (dao *Dao) revokeRefreshTokens(expr dbx.Expression) error {
+	_, err := dao.NonconcurrentDB().Update(
+		(&models.RefreshToken{}).TableName(),
+		dbx.Params{"revoked": true, "updated": types.NowDateTime().String()},
+		dbx.And(expr, dbx.HashExp{"revoked": false}),
+	).Execute()
+
+	return err
+}
+
+// DeleteExpiredRefreshTokens deletes all refresh tokens that have expired before expiredBefore.
+func (dao *Dao) DeleteExpiredRefreshTokens(expiredBefore time.Time) error {
+	formattedDate := expiredBefore.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp("[[expires]] <= {:date}", dbx.Params{"date": formattedDate})
+
+	_, err := dao.NonconcurrentDB().Delete((&models.RefreshToken{}).TableName(), expr).Execute()
+
+	return err
+}
+
+// deleteRefreshTokensByOwner deletes all refresh tokens issued for the specified owner.
+func (dao *Dao) deleteRefreshTokensByOwner(collectionId string, recordId string) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.RefreshToken{}).TableName(), dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,114 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRefreshTokenQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_refreshTokens}}.* FROM `_refreshTokens`"
+
+	sql := app.Dao().RefreshTokenQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestRefreshTokensRevoke(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	create := func(collectionId, recordId, family, hash string) *models.RefreshToken {
+		m := &models.RefreshToken{
+			CollectionId: collectionId,
+			RecordId:     recordId,
+			Family:       family,
+			Hash:         hash,
+		}
+		m.Expires, _ = types.ParseDateTime(time.Now().Add(time.Hour))
+		if err := app.Dao().SaveRefreshToken(m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	a1 := create("", "sywbhecnh46rhm0", "f1", "h1")
+	create("", "sywbhecnh46rhm0", "f1", "h2")
+	create("", "sywbhecnh46rhm0", "f2", "h3")
+	create("_pb_users_auth_", "4q1xlclmfloku33", "f3", "h4")
+
+	ok, err := app.Dao().RevokeRefreshToken(a1)
+	if err != nil || !ok {
+		t.Fatalf("Expected the token to be revoked, got %v (%v)", ok, err)
+	}
+
+	ok, err = app.Dao().RevokeRefreshToken(a1)
+	if err != nil || ok {
+		t.Fatalf("Expected already revoked token, got %v (%v)", ok, err)
+	}
+
+	if err := app.Dao().RevokeRefreshTokenFamily("f1"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertRevoked := func(hash string, expected bool) {
+		m, err := app.Dao().FindRefreshTokenByHash(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Revoked != expected {
+			t.Fatalf("Expected %s revoked %v, got %v", hash, expected, m.Revoked)
+		}
+	}
+
+	assertRevoked("h1", true)
+	assertRevoked("h2", true)
+	assertRevoked("h3", false)
+	assertRevoked("h4", false)
+
+	if err := app.Dao().RevokeAllRefreshTokens("", "sywbhecnh46rhm0"); err != nil {
+		t.Fatal(err)
+	}
+
+	assertRevoked("h3", true)
+	assertRevoked("h4", false)
+}
+
+func TestDeleteExpiredRefreshTokens(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for i, date := range []string{"2022-01-01 00:00:00Z", "2030-01-01 00:00:00Z"} {
+		m := &models.RefreshToken{RecordId: "sywbhecnh46rhm0", Family: "f", Hash: "h" + date}
+		m.Expires, _ = types.ParseDateTime(date)
+		if err := app.Dao().SaveRefreshToken(m); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+	}
+
+	if err := app.Dao().DeleteExpiredRefreshTokens(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := app.Dao().FindAllRefreshTokensByOwner("", "sywbhecnh46rhm0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokens) != 1 || tokens[0].Expires.String() != "2030-01-01 00:00:00.000Z" {
+		t.Fatalf("Expected only the non-expired token to remain, got %v", tokens)
+	}
+}
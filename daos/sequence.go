@@ -0,0 +1,63 @@
+package daos
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/pocketbase/dbx"
+)
+
+// IncrementSequence atomically increments the counter with the specified
+// key (creating it if missing) and returns its new value (starting from 1).
+func (dao *Dao) IncrementSequence(key string) (int64, error) {
+	var value int64
+
+	err := dao.RunInTransaction(func(txDao *Dao) error {
+		_, err := txDao.DB().NewQuery(`
+			INSERT INTO {{_sequences}} ([[key]], [[value]])
+			VALUES ({:key}, 1)
+			ON CONFLICT ([[key]]) DO UPDATE SET [[value]] = {{_sequences}}.[[value]] + 1
+		`).Bind(dbx.Params{"key": key}).Execute()
+		if err != nil {
+			return err
+		}
+
+		return txDao.DB().Select("value").
+			From("_sequences").
+			Where(dbx.HashExp{"key": key}).
+			Row(&value)
+	})
+
+	return value, err
+}
+
+// FindSequenceValue returns the current value of the counter
+// with the specified key (0 if the counter doesn't exist).
+func (dao *Dao) FindSequenceValue(key string) (int64, error) {
+	var value int64
+
+	err := dao.DB().Select("value").
+		From("_sequences").
+		Where(dbx.HashExp{"key": key}).
+		Row(&value)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return value, err
+}
+
+// SetSequenceValue sets the value of the counter with the specified key
+// (eg. to continue an existing numbering after a data import).
+//
+// The next [Dao.IncrementSequence] call will return value+1.
+func (dao *Dao) SetSequenceValue(key string, value int64) error {
+	_, err := dao.DB().NewQuery(`
+		INSERT INTO {{_sequences}} ([[key]], [[value]])
+		VALUES ({:key}, {:value})
+		ON CONFLICT ([[key]]) DO UPDATE SET [[value]] = excluded.[[value]]
+	`).Bind(dbx.Params{"key": key, "value": value}).Execute()
+
+	return err
+}
@@ -0,0 +1,54 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSequence(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if v, err := app.Dao().FindSequenceValue("test"); err != nil || v != 0 {
+		t.Fatalf("Expected missing sequence value 0, got %d (%v)", v, err)
+	}
+
+	for i := int64(1); i <= 3; i++ {
+		v, err := app.Dao().IncrementSequence("test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != i {
+			t.Fatalf("Expected %d, got %d", i, v)
+		}
+	}
+
+	// different key
+	if v, err := app.Dao().IncrementSequence("test2"); err != nil || v != 1 {
+		t.Fatalf("Expected test2 value 1, got %d (%v)", v, err)
+	}
+
+	if err := app.Dao().SetSequenceValue("test", 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := app.Dao().FindSequenceValue("test"); err != nil || v != 100 {
+		t.Fatalf("Expected value 100, got %d (%v)", v, err)
+	}
+
+	if v, err := app.Dao().IncrementSequence("test"); err != nil || v != 101 {
+		t.Fatalf("Expected value 101, got %d (%v)", v, err)
+	}
+
+	// create with set
+	if err := app.Dao().SetSequenceValue("test3", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := app.Dao().IncrementSequence("test3"); err != nil || v != 6 {
+		t.Fatalf("Expected test3 value 6, got %d (%v)", v, err)
+	}
+}
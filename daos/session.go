@@ -0,0 +1,76 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// SessionQuery returns a new Session select query.
+func (dao *Dao) SessionQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Session{})
+}
+
+// FindSessionById finds a single Session model by its id.
+func (dao *Dao) FindSessionById(id string) (*models.Session, error) {
+	model := &models.Session{}
+
+	err := dao.SessionQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllSessionsByOwner returns all Session models of the specified owner
+// ordered by their last activity (most recent first).
+//
+// collectionId is expected to be empty for admins.
+func (dao *Dao) FindAllSessionsByOwner(collectionId string, recordId string) ([]*models.Session, error) {
+	result := []*models.Session{}
+
+	err := dao.SessionQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("lastSeen DESC", "created DESC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveSession upserts the provided Session model.
+func (dao *Dao) SaveSession(model *models.Session) error {
+	return dao.Save(model)
+}
+
+// DeleteSession deletes the provided Session model
+// and revokes all of its refresh tokens.
+func (dao *Dao) DeleteSession(model *models.Session) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.RevokeRefreshTokenFamily(model.Id); err != nil {
+			return err
+		}
+
+		return txDao.Delete(model)
+	})
+}
+
+// deleteSessionsByOwner deletes all sessions of the specified owner.
+func (dao *Dao) deleteSessionsByOwner(collectionId string, recordId string) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.Session{}).TableName(), dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,81 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestSessionQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_sessions}}.* FROM `_sessions`"
+
+	sql := app.Dao().SessionQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestSessionsFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	create := func(recordId, userAgent, lastSeen string) *models.Session {
+		m := &models.Session{
+			CollectionId: "_pb_users_auth_",
+			RecordId:     recordId,
+			UserAgent:    userAgent,
+		}
+		m.LastSeen, _ = types.ParseDateTime(lastSeen)
+		if err := app.Dao().SaveSession(m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	s1 := create("4q1xlclmfloku33", "ua1", "2023-01-01 00:00:00Z")
+	s2 := create("4q1xlclmfloku33", "ua2", "2023-01-02 00:00:00Z")
+	create("oap640cot4yru2s", "ua1", "2023-01-03 00:00:00Z")
+
+	sessions, err := app.Dao().FindAllSessionsByOwner("_pb_users_auth_", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0].Id != s2.Id || sessions[1].Id != s1.Id {
+		t.Fatalf("Expected sessions [%s, %s], got %v", s2.Id, s1.Id, sessions)
+	}
+
+	refreshToken := &models.RefreshToken{
+		CollectionId: s1.CollectionId,
+		RecordId:     s1.RecordId,
+		Family:       s1.Id,
+		Hash:         "test",
+	}
+	if err := app.Dao().SaveRefreshToken(refreshToken); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteSession(s1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindSessionById(s1.Id); err == nil {
+		t.Fatal("Expected the session to be deleted")
+	}
+
+	refreshToken, err = app.Dao().FindRefreshTokenByHash("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshToken.Revoked {
+		t.Fatal("Expected the session refresh token to be revoked")
+	}
+}
@@ -59,5 +59,11 @@ func (dao *Dao) FindSettings(optEncryptionKey ...string) (*settings.Settings, er
 //
 // If optEncryptionKey is set, then the stored serialized value will be encrypted with it.
 func (dao *Dao) SaveSettings(newSettings *settings.Settings, optEncryptionKey ...string) error {
-	return dao.SaveParam(models.ParamAppSettings, newSettings, optEncryptionKey...)
+	// persist the external secret references instead of their resolved values
+	unresolved, err := newSettings.UnresolvedClone()
+	if err != nil {
+		return err
+	}
+
+	return dao.SaveParam(models.ParamAppSettings, unresolved, optEncryptionKey...)
 }
@@ -0,0 +1,171 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// ShareLinkQuery returns a new ShareLink select query.
+func (dao *Dao) ShareLinkQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.ShareLink{})
+}
+
+// FindShareLinkById finds a single ShareLink model by its id.
+func (dao *Dao) FindShareLinkById(id string) (*models.ShareLink, error) {
+	model := &models.ShareLink{}
+
+	err := dao.ShareLinkQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindShareLinkByHash finds a single ShareLink model by its token hash.
+func (dao *Dao) FindShareLinkByHash(hash string) (*models.ShareLink, error) {
+	model := &models.ShareLink{}
+
+	err := dao.ShareLinkQuery().
+		AndWhere(dbx.HashExp{"hash": hash}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindShareLinksByRecord returns all ShareLink models of the specified record.
+func (dao *Dao) FindShareLinksByRecord(collectionId string, recordId string) ([]*models.ShareLink, error) {
+	result := []*models.ShareLink{}
+
+	err := dao.ShareLinkQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveShareLink upserts the provided ShareLink model.
+func (dao *Dao) SaveShareLink(model *models.ShareLink) error {
+	return dao.Save(model)
+}
+
+// DeleteShareLink deletes the provided ShareLink model.
+func (dao *Dao) DeleteShareLink(model *models.ShareLink) error {
+	return dao.Delete(model)
+}
+
+// IncrementShareLinkUses atomically increments the usage counter of
+// the provided ShareLink model.
+//
+// It returns false if the share link is revoked or its max uses
+// limit was already reached (eg. by a concurrent request).
+func (dao *Dao) IncrementShareLinkUses(model *models.ShareLink) (bool, error) {
+	result, err := dao.NonconcurrentDB().Update(
+		model.TableName(),
+		dbx.Params{
+			"uses":    dbx.NewExp("[[uses]] + 1"),
+			"updated": types.NowDateTime().String(),
+		},
+		dbx.And(
+			dbx.HashExp{"id": model.Id, "revoked": false},
+			dbx.NewExp("([[maxUses]] = 0 OR [[uses]] < [[maxUses]])"),
+		),
+	).Execute()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected > 0 {
+		model.Uses++
+	}
+
+	return affected > 0, nil
+}
+
+// deleteShareLinksByRecord deletes all share links and
+// share comments of the specified record.
+func (dao *Dao) deleteShareLinksByRecord(collectionId string, recordId string) error {
+	expr := dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	}
+
+	if _, err := dao.NonconcurrentDB().Delete((&models.ShareComment{}).TableName(), expr).Execute(); err != nil {
+		return err
+	}
+
+	_, err := dao.NonconcurrentDB().Delete((&models.ShareLink{}).TableName(), expr).Execute()
+
+	return err
+}
+
+// -------------------------------------------------------------------
+
+// ShareCommentQuery returns a new ShareComment select query.
+func (dao *Dao) ShareCommentQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.ShareComment{})
+}
+
+// FindShareCommentsByRecord returns all ShareComment models of the specified record.
+func (dao *Dao) FindShareCommentsByRecord(collectionId string, recordId string) ([]*models.ShareComment, error) {
+	result := []*models.ShareComment{}
+
+	err := dao.ShareCommentQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindShareCommentsByShareLink returns all ShareComment models
+// created with the specified share link.
+func (dao *Dao) FindShareCommentsByShareLink(shareLinkId string) ([]*models.ShareComment, error) {
+	result := []*models.ShareComment{}
+
+	err := dao.ShareCommentQuery().
+		AndWhere(dbx.HashExp{"shareLinkId": shareLinkId}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveShareComment upserts the provided ShareComment model.
+func (dao *Dao) SaveShareComment(model *models.ShareComment) error {
+	return dao.Save(model)
+}
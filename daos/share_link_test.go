@@ -0,0 +1,173 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestShareLinkQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_shareLinks}}.* FROM `_shareLinks`"
+
+	sql := app.Dao().ShareLinkQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestShareLinksFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, hash := range []string{"hash1", "hash2"} {
+		link := &models.ShareLink{
+			CollectionId: record.Collection().Id,
+			RecordId:     record.Id,
+			Hash:         hash,
+		}
+		if err := app.Dao().SaveShareLink(link); err != nil {
+			t.Fatal(err)
+		}
+
+		comment := &models.ShareComment{
+			ShareLinkId:  link.Id,
+			CollectionId: link.CollectionId,
+			RecordId:     link.RecordId,
+			Author:       "test",
+			Message:      "test",
+		}
+		if err := app.Dao().SaveShareComment(comment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	links, err := app.Dao().FindShareLinksByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("Expected 2 share links, got %d", len(links))
+	}
+
+	found, err := app.Dao().FindShareLinkByHash("hash2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Id != links[1].Id {
+		t.Fatalf("Expected share link %q, got %q", links[1].Id, found.Id)
+	}
+
+	foundById, err := app.Dao().FindShareLinkById(found.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if foundById.Hash != "hash2" {
+		t.Fatalf("Expected share link with hash2, got %q", foundById.Hash)
+	}
+
+	linkComments, err := app.Dao().FindShareCommentsByShareLink(found.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linkComments) != 1 {
+		t.Fatalf("Expected 1 share link comment, got %d", len(linkComments))
+	}
+
+	recordComments, err := app.Dao().FindShareCommentsByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordComments) != 2 {
+		t.Fatalf("Expected 2 record comments, got %d", len(recordComments))
+	}
+
+	if err := app.Dao().DeleteShareLink(found); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindShareLinkById(found.Id); err == nil {
+		t.Fatal("Expected the share link to be deleted")
+	}
+
+	// the remaining share links and comments should be deleted together with the record
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	links, err = app.Dao().FindShareLinksByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 0 {
+		t.Fatalf("Expected no share links, got %d", len(links))
+	}
+
+	recordComments, err = app.Dao().FindShareCommentsByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordComments) != 0 {
+		t.Fatalf("Expected no share comments, got %d", len(recordComments))
+	}
+}
+
+func TestIncrementShareLinkUses(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	link := &models.ShareLink{
+		CollectionId: "test",
+		RecordId:     "test",
+		Hash:         "test",
+		MaxUses:      2,
+	}
+	if err := app.Dao().SaveShareLink(link); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []bool{true, true, false} {
+		ok, err := app.Dao().IncrementShareLinkUses(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != expected {
+			t.Fatalf("(%d) Expected %v, got %v", i, expected, ok)
+		}
+	}
+
+	refreshed, err := app.Dao().FindShareLinkById(link.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.Uses != 2 || link.Uses != 2 {
+		t.Fatalf("Expected 2 uses, got %d (model %d)", refreshed.Uses, link.Uses)
+	}
+
+	// revoked
+	revoked := &models.ShareLink{
+		CollectionId: "test",
+		RecordId:     "test",
+		Hash:         "test2",
+		Revoked:      true,
+	}
+	if err := app.Dao().SaveShareLink(revoked); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := app.Dao().IncrementShareLinkUses(revoked); ok {
+		t.Fatal("Expected the revoked share link uses to not be incremented")
+	}
+}
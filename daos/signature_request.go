@@ -0,0 +1,82 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// SignatureRequestQuery returns a new SignatureRequest select query.
+func (dao *Dao) SignatureRequestQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.SignatureRequest{})
+}
+
+// FindSignatureRequestById finds a single SignatureRequest model by its id.
+func (dao *Dao) FindSignatureRequestById(id string) (*models.SignatureRequest, error) {
+	model := &models.SignatureRequest{}
+
+	err := dao.SignatureRequestQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindSignatureRequestByHash finds a single SignatureRequest model by its token hash.
+func (dao *Dao) FindSignatureRequestByHash(hash string) (*models.SignatureRequest, error) {
+	model := &models.SignatureRequest{}
+
+	err := dao.SignatureRequestQuery().
+		AndWhere(dbx.HashExp{"hash": hash}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindSignatureRequestsByRecord returns all SignatureRequest models of the specified record.
+func (dao *Dao) FindSignatureRequestsByRecord(collectionId string, recordId string) ([]*models.SignatureRequest, error) {
+	result := []*models.SignatureRequest{}
+
+	err := dao.SignatureRequestQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"recordId":     recordId,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveSignatureRequest upserts the provided SignatureRequest model.
+func (dao *Dao) SaveSignatureRequest(model *models.SignatureRequest) error {
+	return dao.Save(model)
+}
+
+// DeleteSignatureRequest deletes the provided SignatureRequest model.
+func (dao *Dao) DeleteSignatureRequest(model *models.SignatureRequest) error {
+	return dao.Delete(model)
+}
+
+// deleteSignatureRequestsByRecord deletes all signature requests of the specified record.
+func (dao *Dao) deleteSignatureRequestsByRecord(collectionId string, recordId string) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.SignatureRequest{}).TableName(), dbx.HashExp{
+		"collectionId": collectionId,
+		"recordId":     recordId,
+	}).Execute()
+
+	return err
+}
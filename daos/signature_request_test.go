@@ -0,0 +1,93 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSignatureRequestQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_signatureRequests}}.* FROM `_signatureRequests`"
+
+	sql := app.Dao().SignatureRequestQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestSignatureRequestsFindAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, hash := range []string{"hash1", "hash2", "hash3"} {
+		request := &models.SignatureRequest{
+			CollectionId: record.Collection().Id,
+			RecordId:     record.Id,
+			Field:        "test",
+			File:         "test.pdf",
+			DocumentHash: "test",
+			Hash:         hash,
+			Status:       models.SignatureRequestStatusPending,
+		}
+		if err := app.Dao().SaveSignatureRequest(request); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	requests, err := app.Dao().FindSignatureRequestsByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 signature requests, got %d", len(requests))
+	}
+
+	found, err := app.Dao().FindSignatureRequestByHash("hash2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Id != requests[1].Id {
+		t.Fatalf("Expected signature request %q, got %q", requests[1].Id, found.Id)
+	}
+
+	foundById, err := app.Dao().FindSignatureRequestById(found.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if foundById.Hash != "hash2" {
+		t.Fatalf("Expected signature request with hash2, got %q", foundById.Hash)
+	}
+
+	if err := app.Dao().DeleteSignatureRequest(found); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindSignatureRequestById(found.Id); err == nil {
+		t.Fatal("Expected the signature request to be deleted")
+	}
+
+	// the remaining signature requests should be deleted together with the record
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	requests, err = app.Dao().FindSignatureRequestsByRecord(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("Expected no signature requests, got %d", len(requests))
+	}
+}
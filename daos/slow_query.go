@@ -0,0 +1,141 @@
+package daos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// SlowQueryQuery returns a new SlowQuery select query.
+func (dao *Dao) SlowQueryQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.SlowQuery{})
+}
+
+// FindSlowQueryById finds a single SlowQuery model by its id.
+func (dao *Dao) FindSlowQueryById(id string) (*models.SlowQuery, error) {
+	model := &models.SlowQuery{}
+
+	err := dao.SlowQueryQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveSlowQuery upserts the provided SlowQuery model.
+func (dao *Dao) SaveSlowQuery(model *models.SlowQuery) error {
+	return dao.Save(model)
+}
+
+// DeleteSlowQuery deletes the provided SlowQuery model.
+func (dao *Dao) DeleteSlowQuery(model *models.SlowQuery) error {
+	return dao.Delete(model)
+}
+
+// RecordSlowQuery stores a new execution of a slow sql statement.
+//
+// The executions of the same statement (ignoring its literal values)
+// are grouped in a single SlowQuery entry by incrementing its counter
+// and updating its duration, plan and last captured sql.
+//
+// A resolved entry is automatically reopened.
+func (dao *Dao) RecordSlowQuery(sql string, duration time.Duration, plan string) error {
+	now := types.NowDateTime().String()
+	ms := float64(duration) / float64(time.Millisecond)
+
+	_, err := dao.NonconcurrentDB().NewQuery(`
+		INSERT INTO {{_slowQueries}} (
+			[[id]], [[fingerprint]], [[sql]], [[plan]], [[count]],
+			[[maxDuration]], [[lastDuration]], [[resolved]], [[created]], [[updated]]
+		)
+		VALUES ({:id}, {:fingerprint}, {:sql}, {:plan}, 1, {:duration}, {:duration}, FALSE, {:now}, {:now})
+		ON CONFLICT ([[fingerprint]]) DO UPDATE SET
+			[[sql]]          = excluded.[[sql]],
+			[[plan]]         = excluded.[[plan]],
+			[[count]]        = [[count]] + 1,
+			[[maxDuration]]  = max([[maxDuration]], excluded.[[maxDuration]]),
+			[[lastDuration]] = excluded.[[lastDuration]],
+			[[resolved]]     = FALSE,
+			[[updated]]      = excluded.[[updated]]
+	`).Bind(dbx.Params{
+		"id":          security.PseudorandomStringWithAlphabet(models.DefaultIdLength, models.DefaultIdAlphabet),
+		"fingerprint": SlowQueryFingerprint(sql),
+		"sql":         sql,
+		"plan":        plan,
+		"duration":    ms,
+		"now":         now,
+	}).Execute()
+
+	return err
+}
+
+// DeleteOldSlowQueries deletes all slow queries that were not captured after the specified date.
+func (dao *Dao) DeleteOldSlowQueries(before time.Time) error {
+	formattedDate := before.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp("[[updated]] <= {:date}", dbx.Params{"date": formattedDate})
+
+	_, err := dao.NonconcurrentDB().Delete((&models.SlowQuery{}).TableName(), expr).Execute()
+
+	return err
+}
+
+var (
+	slowQueryStringLiteralRegex = regexp.MustCompile(`'(?:[^']|'')*'`)
+	slowQueryNumberLiteralRegex = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	slowQueryWhitespaceRegex    = regexp.MustCompile(`\s+`)
+)
+
+// SlowQueryFingerprint returns the hash of the normalized sql statement
+// (with replaced literal values and collapsed whitespaces).
+func SlowQueryFingerprint(sql string) string {
+	normalized := slowQueryStringLiteralRegex.ReplaceAllString(sql, "?")
+	normalized = slowQueryNumberLiteralRegex.ReplaceAllString(normalized, "?")
+	normalized = slowQueryWhitespaceRegex.ReplaceAllString(strings.TrimSpace(normalized), " ")
+
+	h := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(h[:16])
+}
+
+// ExplainQueryPlan returns the formatted "EXPLAIN QUERY PLAN"
+// output of the provided sql statement (the statement is not executed).
+//
+// Each plan step is on a new line and indented based on its depth.
+func (dao *Dao) ExplainQueryPlan(sql string) (string, error) {
+	rows := []struct {
+		Id     int    `db:"id"`
+		Parent int    `db:"parent"`
+		Detail string `db:"detail"`
+	}{}
+
+	if err := dao.DB().NewQuery("EXPLAIN QUERY PLAN " + sql).All(&rows); err != nil {
+		return "", err
+	}
+
+	depths := make(map[int]int, len(rows))
+	lines := make([]string, 0, len(rows))
+
+	for _, row := range rows {
+		depth := 0
+		if parentDepth, ok := depths[row.Parent]; ok {
+			depth = parentDepth + 1
+		}
+		depths[row.Id] = depth
+
+		lines = append(lines, strings.Repeat("  ", depth)+row.Detail)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,164 @@
+package daos_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSlowQueryQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_slowQueries}}.* FROM `_slowQueries`"
+
+	sql := app.LogsDao().SlowQueryQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestSlowQueryFingerprint(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		a          string
+		b          string
+		expectSame bool
+	}{
+		{"SELECT * FROM demo WHERE id = 'a'", "SELECT * FROM demo WHERE id = 'b''c'", true},
+		{"SELECT * FROM demo LIMIT 10", "SELECT  *\nFROM demo LIMIT 200", true},
+		{"SELECT * FROM demo1", "SELECT * FROM demo2", false},
+		{"SELECT * FROM demo WHERE a = 1", "SELECT * FROM demo WHERE b = 1", false},
+	}
+
+	for i, s := range scenarios {
+		same := daos.SlowQueryFingerprint(s.a) == daos.SlowQueryFingerprint(s.b)
+		if same != s.expectSame {
+			t.Errorf("[%d] Expected same %v, got %v", i, s.expectSame, same)
+		}
+	}
+}
+
+func TestRecordSlowQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	executions := []struct {
+		sql      string
+		duration time.Duration
+	}{
+		{"SELECT * FROM demo1 WHERE id = 'a'", 200 * time.Millisecond},
+		{"SELECT * FROM demo1 WHERE id = 'b'", 500 * time.Millisecond},
+		{"SELECT * FROM demo1 WHERE id = 'c'", 300 * time.Millisecond},
+		{"SELECT * FROM demo2", 100 * time.Millisecond},
+	}
+	for _, e := range executions {
+		if err := app.LogsDao().RecordSlowQuery(e.sql, e.duration, "SCAN demo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queries := []*models.SlowQuery{}
+	if err := app.LogsDao().SlowQueryQuery().OrderBy("count DESC").All(&queries); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("Expected 2 grouped slow queries, got %d", len(queries))
+	}
+
+	q := queries[0]
+	if q.Count != 3 || q.MaxDuration != 500 || q.LastDuration != 300 || q.Sql != executions[2].sql || q.Plan != "SCAN demo" {
+		t.Fatalf("Unexpected grouped slow query %#v", q)
+	}
+
+	// resolve and capture again
+	q.Resolved = true
+	if err := app.LogsDao().SaveSlowQuery(q); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.LogsDao().RecordSlowQuery(executions[0].sql, 10*time.Millisecond, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := app.LogsDao().FindSlowQueryById(q.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Resolved || reopened.Count != 4 || reopened.MaxDuration != 500 {
+		t.Fatalf("Expected the slow query to be reopened, got %#v", reopened)
+	}
+
+	// delete
+	if err := app.LogsDao().DeleteSlowQuery(reopened); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.LogsDao().FindSlowQueryById(q.Id); err == nil {
+		t.Fatal("Expected the slow query to be deleted")
+	}
+}
+
+func TestDeleteOldSlowQueries(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if err := app.LogsDao().RecordSlowQuery("SELECT 1", time.Second, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.LogsDao().DeleteOldSlowQueries(time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if total := countSlowQueries(t, app.LogsDao()); total != 1 {
+		t.Fatalf("Expected the recent slow query to remain, got %d", total)
+	}
+
+	if err := app.LogsDao().DeleteOldSlowQueries(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if total := countSlowQueries(t, app.LogsDao()); total != 0 {
+		t.Fatalf("Expected all slow queries to be deleted, got %d", total)
+	}
+}
+
+func TestExplainQueryPlan(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	plan, err := app.Dao().ExplainQueryPlan("SELECT id FROM demo1 UNION SELECT id FROM demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(plan, "demo1") || !strings.Contains(plan, "demo2") || !strings.Contains(plan, "\n  ") {
+		t.Fatalf("Expected nested query plan, got\n%s", plan)
+	}
+
+	if _, err := app.Dao().ExplainQueryPlan("invalid"); err == nil {
+		t.Fatal("Expected explain error")
+	}
+}
+
+func countSlowQueries(t *testing.T, dao *daos.Dao) int {
+	var total int
+
+	if err := dao.SlowQueryQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
@@ -0,0 +1,85 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// UptimeStatQuery returns a new UptimeStat select query.
+func (dao *Dao) UptimeStatQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.UptimeStat{})
+}
+
+// IncrementUptimeStat increments the total and errors counters of the
+// subsystem hourly bucket containing the provided date (creating it if missing).
+func (dao *Dao) IncrementUptimeStat(subsystem string, date time.Time, total int, errors int) error {
+	now := types.NowDateTime().String()
+
+	_, err := dao.NonconcurrentDB().NewQuery(`
+		INSERT INTO {{_uptime}} ([[subsystem]], [[hour]], [[total]], [[errors]], [[created]], [[updated]])
+		VALUES ({:subsystem}, {:hour}, {:total}, {:errors}, {:now}, {:now})
+		ON CONFLICT ([[subsystem]], [[hour]]) DO UPDATE SET
+			[[total]]   = [[total]] + excluded.[[total]],
+			[[errors]]  = [[errors]] + excluded.[[errors]],
+			[[updated]] = excluded.[[updated]]
+	`).Bind(dbx.Params{
+		"subsystem": subsystem,
+		"hour":      date.UTC().Truncate(time.Hour).Format(types.DefaultDateLayout),
+		"total":     total,
+		"errors":    errors,
+		"now":       now,
+	}).Execute()
+
+	return err
+}
+
+// UptimeSummary defines the aggregated requests and errors counters
+// of a subsystem for a specific period.
+type UptimeSummary struct {
+	Total  int `db:"total" json:"total"`
+	Errors int `db:"errors" json:"errors"`
+}
+
+// Availability returns the successful requests percentage
+// (100 if there are no tracked requests).
+func (s *UptimeSummary) Availability() float64 {
+	if s.Total <= 0 {
+		return 100
+	}
+
+	return float64(s.Total-s.Errors) * 100 / float64(s.Total)
+}
+
+// FindUptimeSummary returns the aggregated subsystem counters
+// of all hourly buckets starting from the hour of the since date.
+func (dao *Dao) FindUptimeSummary(subsystem string, since time.Time) (*UptimeSummary, error) {
+	result := &UptimeSummary{}
+
+	err := dao.UptimeStatQuery().
+		Select("COALESCE(SUM([[total]]), 0) as total", "COALESCE(SUM([[errors]]), 0) as errors").
+		AndWhere(dbx.HashExp{"subsystem": subsystem}).
+		AndWhere(dbx.NewExp("[[hour]] >= {:since}", dbx.Params{
+			"since": since.UTC().Truncate(time.Hour).Format(types.DefaultDateLayout),
+		})).
+		One(result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteOldUptimeStats deletes all uptime stats with hour before the provided date.
+func (dao *Dao) DeleteOldUptimeStats(before time.Time) error {
+	expr := dbx.NewExp("[[hour]] < {:date}", dbx.Params{
+		"date": before.UTC().Format(types.DefaultDateLayout),
+	})
+
+	_, err := dao.NonconcurrentDB().Delete((&models.UptimeStat{}).TableName(), expr).Execute()
+
+	return err
+}
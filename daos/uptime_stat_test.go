@@ -0,0 +1,117 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestUptimeStatQuery(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_uptime}}.* FROM `_uptime`"
+
+	sql := app.LogsDao().UptimeStatQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestIncrementUptimeStatAndFindUptimeSummary(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now().UTC()
+
+	increments := []struct {
+		subsystem string
+		date      time.Time
+		total     int
+		errors    int
+	}{
+		{models.UptimeSubsystemApi, now, 10, 1},
+		{models.UptimeSubsystemApi, now, 5, 0}, // same hourly bucket
+		{models.UptimeSubsystemApi, now.Add(-48 * time.Hour), 5, 5},
+		{models.UptimeSubsystemStorage, now, 3, 0},
+	}
+	for _, inc := range increments {
+		if err := app.LogsDao().IncrementUptimeStat(inc.subsystem, inc.date, inc.total, inc.errors); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buckets int
+	if err := app.LogsDao().UptimeStatQuery().Select("count(*)").Row(&buckets); err != nil {
+		t.Fatal(err)
+	}
+	if buckets != 3 {
+		t.Fatalf("Expected 3 hourly buckets, got %d", buckets)
+	}
+
+	scenarios := []struct {
+		subsystem            string
+		since                time.Time
+		expectedTotal        int
+		expectedErrors       int
+		expectedAvailability float64
+	}{
+		{models.UptimeSubsystemApi, now.Add(-24 * time.Hour), 15, 1, 1400.0 / 15},
+		{models.UptimeSubsystemApi, now.Add(-72 * time.Hour), 20, 6, 70},
+		{models.UptimeSubsystemStorage, now.Add(-24 * time.Hour), 3, 0, 100},
+		{models.UptimeSubsystemRealtime, now.Add(-24 * time.Hour), 0, 0, 100},
+	}
+
+	for i, s := range scenarios {
+		summary, err := app.LogsDao().FindUptimeSummary(s.subsystem, s.since)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if summary.Total != s.expectedTotal || summary.Errors != s.expectedErrors {
+			t.Fatalf("[%d] Expected total %d and errors %d, got %d and %d", i, s.expectedTotal, s.expectedErrors, summary.Total, summary.Errors)
+		}
+
+		if summary.Availability() != s.expectedAvailability {
+			t.Fatalf("[%d] Expected availability %v, got %v", i, s.expectedAvailability, summary.Availability())
+		}
+	}
+}
+
+func TestDeleteOldUptimeStats(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now().UTC()
+
+	for _, date := range []time.Time{now, now.Add(-40 * 24 * time.Hour), now.Add(-50 * 24 * time.Hour)} {
+		if err := app.LogsDao().IncrementUptimeStat(models.UptimeSubsystemApi, date, 1, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := app.LogsDao().DeleteOldUptimeStats(now.Add(-31 * 24 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := []*models.UptimeStat{}
+	if err := app.LogsDao().UptimeStatQuery().All(&stats); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats) != 1 {
+		t.Fatalf("Expected 1 remaining stat, got %d", len(stats))
+	}
+
+	if stats[0].Hour.Time().Before(now.Add(-time.Hour)) {
+		t.Fatalf("Expected the current hour stat to remain, got %v", stats[0].Hour)
+	}
+}
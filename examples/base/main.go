@@ -1,9 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -47,6 +49,22 @@ func main() {
 		"the total prewarm goja.Runtime instances for the JS app hooks execution",
 	)
 
+	var templatesDir string
+	app.RootCmd.PersistentFlags().StringVar(
+		&templatesDir,
+		"templatesDir",
+		"",
+		"the directory with the JS app hooks server-side HTML views (default pb_hooks/views)",
+	)
+
+	var templatesReload bool
+	app.RootCmd.PersistentFlags().BoolVar(
+		&templatesReload,
+		"templatesReload",
+		false,
+		"reparse the JS app hooks HTML views on every render (for development)",
+	)
+
 	var migrationsDir string
 	app.RootCmd.PersistentFlags().StringVar(
 		&migrationsDir,
@@ -79,6 +97,46 @@ func main() {
 		"fallback the request to index.html on missing static path (eg. when pretty urls are used with SPA)",
 	)
 
+	var staticMounts []string
+	app.RootCmd.PersistentFlags().StringArrayVar(
+		&staticMounts,
+		"static",
+		nil,
+		"additional static directory mount point in the format prefix=dir[,spa][,browse] (could be repeated)",
+	)
+
+	var staticCacheControl string
+	app.RootCmd.PersistentFlags().StringVar(
+		&staticCacheControl,
+		"staticCacheControl",
+		"",
+		"the Cache-Control header value of the served static files (eg. no-cache)",
+	)
+
+	var staticImmutable string
+	app.RootCmd.PersistentFlags().StringVar(
+		&staticImmutable,
+		"staticImmutable",
+		"",
+		"regex pattern of the static file paths served with immutable cache headers (eg. ^assets/)",
+	)
+
+	var staticPrecompressed bool
+	app.RootCmd.PersistentFlags().BoolVar(
+		&staticPrecompressed,
+		"staticPrecompressed",
+		false,
+		"serve the pre-compressed .br and .gz static file variants (if exist)",
+	)
+
+	var staticBrowse bool
+	app.RootCmd.PersistentFlags().BoolVar(
+		&staticBrowse,
+		"staticBrowse",
+		false,
+		"enable the static directories listing",
+	)
+
 	var queryTimeout int
 	app.RootCmd.PersistentFlags().IntVar(
 		&queryTimeout,
@@ -95,10 +153,12 @@ func main() {
 
 	// load jsvm (hooks and migrations)
 	jsvm.MustRegister(app, jsvm.Config{
-		MigrationsDir: migrationsDir,
-		HooksDir:      hooksDir,
-		HooksWatch:    hooksWatch,
-		HooksPoolSize: hooksPool,
+		MigrationsDir:   migrationsDir,
+		HooksDir:        hooksDir,
+		HooksWatch:      hooksWatch,
+		HooksPoolSize:   hooksPool,
+		TemplatesDir:    templatesDir,
+		TemplatesReload: templatesReload,
 	})
 
 	// load jsvm (hooks and migrations)
@@ -125,8 +185,34 @@ func main() {
 	})
 
 	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		staticConfig := apis.StaticConfig{
+			Precompressed: staticPrecompressed,
+			CacheControl:  staticCacheControl,
+			Browse:        staticBrowse,
+		}
+
+		if staticImmutable != "" {
+			pattern, err := regexp.Compile(staticImmutable)
+			if err != nil {
+				return fmt.Errorf("invalid --staticImmutable pattern: %w", err)
+			}
+			staticConfig.Immutable = pattern
+		}
+
+		mounts := make([]apis.StaticMount, 0, len(staticMounts)+1)
+		for _, v := range staticMounts {
+			mount, err := apis.ParseStaticMount(v)
+			if err != nil {
+				return err
+			}
+			mounts = append(mounts, mount)
+		}
+
 		// serves static files from the provided public dir (if exists)
-		e.Router.GET("/*", apis.StaticDirectoryHandler(os.DirFS(publicDir), indexFallback))
+		mounts = append(mounts, apis.StaticMount{Prefix: "/", Dir: publicDir, IndexFallback: indexFallback})
+
+		apis.BindStaticMounts(e.Router, staticConfig, mounts...)
+
 		return nil
 	})
 
@@ -0,0 +1,98 @@
+package forms
+
+import (
+	"net/http"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var previewRequestPathRegex = regexp.MustCompile(`^/api/collections/[^/?#]+/records(/[^/?#]+)?(\?[^#]*)?$`)
+
+// AdminPreviewRequest is an admin form for previewing (aka. dry-run)
+// a records API request as another identity.
+type AdminPreviewRequest struct {
+	app core.App
+
+	// Method is the previewed request method (GET, POST, PATCH or DELETE).
+	Method string `form:"method" json:"method"`
+
+	// Path is the previewed records API request path with its
+	// optional query parameters (eg. "/api/collections/posts/records?filter=...").
+	Path string `form:"path" json:"path"`
+
+	// Body is the previewed request json body.
+	Body map[string]any `form:"body" json:"body"`
+
+	// AuthCollection and AuthRecord specify the identity the request
+	// is previewed as (if not set the request is previewed as guest).
+	AuthCollection string `form:"authCollection" json:"authCollection"`
+	AuthRecord     string `form:"authRecord" json:"authRecord"`
+}
+
+// NewAdminPreviewRequest creates a new [AdminPreviewRequest] form
+// initialized with the provided [core.App] instance.
+func NewAdminPreviewRequest(app core.App) *AdminPreviewRequest {
+	return &AdminPreviewRequest{app: app}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *AdminPreviewRequest) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Method,
+			validation.Required,
+			validation.In(http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete),
+		),
+		validation.Field(
+			&form.Path,
+			validation.Required,
+			validation.Length(1, 2000),
+			validation.Match(previewRequestPathRegex),
+		),
+		validation.Field(
+			&form.AuthCollection,
+			validation.When(form.AuthRecord != "", validation.Required),
+			validation.By(form.checkAuthCollection),
+		),
+		validation.Field(
+			&form.AuthRecord,
+			validation.When(form.AuthCollection != "", validation.Required),
+		),
+	)
+}
+
+func (form *AdminPreviewRequest) checkAuthCollection(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	collection, err := form.app.Dao().FindCollectionByNameOrId(v)
+	if err != nil || !collection.IsAuth() {
+		return validation.NewError("validation_invalid_auth_collection", "Must be a valid auth collection id or name.")
+	}
+
+	return nil
+}
+
+// FindAuthRecord validates the form and returns the identity auth
+// record of the previewed request (nil when previewed as guest).
+func (form *AdminPreviewRequest) FindAuthRecord() (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if form.AuthRecord == "" {
+		return nil, nil // guest
+	}
+
+	record, err := form.app.Dao().FindRecordById(form.AuthCollection, form.AuthRecord)
+	if err != nil {
+		return nil, validation.Errors{"authRecord": validation.NewError("validation_invalid_auth_record", "Missing or invalid auth record.")}
+	}
+
+	return record, nil
+}
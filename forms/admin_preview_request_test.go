@@ -0,0 +1,98 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAdminPreviewRequestFindAuthRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		data           string
+		expectedErrors []string
+		expectedRecord string
+	}{
+		{
+			"empty data",
+			`{}`,
+			[]string{"method", "path"},
+			"",
+		},
+		{
+			"invalid method and path",
+			`{"method":"PUT","path":"/api/collections/demo2"}`,
+			[]string{"method", "path"},
+			"",
+		},
+		{
+			"auth collection without auth record",
+			`{"method":"GET","path":"/api/collections/demo2/records","authCollection":"users"}`,
+			[]string{"authRecord"},
+			"",
+		},
+		{
+			"non-auth collection",
+			`{"method":"GET","path":"/api/collections/demo2/records","authCollection":"demo2","authRecord":"llvuca81nly1qls"}`,
+			[]string{"authCollection"},
+			"",
+		},
+		{
+			"missing auth record",
+			`{"method":"GET","path":"/api/collections/demo2/records","authCollection":"users","authRecord":"missing"}`,
+			[]string{"authRecord"},
+			"",
+		},
+		{
+			"guest",
+			`{"method":"DELETE","path":"/api/collections/demo2/records/llvuca81nly1qls"}`,
+			[]string{},
+			"",
+		},
+		{
+			"auth record",
+			`{"method":"GET","path":"/api/collections/demo2/records?filter=title='test1'","authCollection":"users","authRecord":"4q1xlclmfloku33"}`,
+			[]string{},
+			"4q1xlclmfloku33",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewAdminPreviewRequest(app)
+
+			if err := json.Unmarshal([]byte(s.data), form); err != nil {
+				t.Fatal(err)
+			}
+
+			record, err := form.FindAuthRecord()
+
+			errs, _ := err.(validation.Errors)
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, err)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			var recordId string
+			if record != nil {
+				recordId = record.Id
+			}
+
+			if recordId != s.expectedRecord {
+				t.Fatalf("Expected auth record %q, got %q", s.expectedRecord, recordId)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package forms
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -139,8 +140,10 @@ func (form *CollectionUpsert) Validate() error {
 			&form.Schema,
 			validation.By(form.checkMinSchemaFields),
 			validation.By(form.ensureNoSystemFieldsChange),
+			validation.By(form.ensureNoImmutableFieldsChange),
 			validation.By(form.ensureNoFieldsTypeChange),
 			validation.By(form.checkRelationFields),
+			validation.By(form.checkAutonumberFields),
 			validation.When(isAuth, validation.By(form.ensureNoAuthFieldName)),
 		),
 		validation.Field(&form.ListRule, validation.By(form.checkRule)),
@@ -306,6 +309,49 @@ func (form *CollectionUpsert) checkRelationFields(value any) error {
 	return nil
 }
 
+// checkAutonumberFields checks whether the autonumber fields scopeField
+// option refers to an existing single value schema field.
+func (form *CollectionUpsert) checkAutonumberFields(value any) error {
+	v, _ := value.(schema.Schema)
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypeAutonumber {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.AutonumberOptions)
+		if options == nil || options.ScopeField == "" {
+			continue
+		}
+
+		scopeField := v.GetFieldByName(options.ScopeField)
+
+		isValid := scopeField != nil &&
+			scopeField.Id != field.Id &&
+			scopeField.Type != schema.FieldTypeFile &&
+			scopeField.Type != schema.FieldTypeJson
+
+		if isValid {
+			if multi, ok := scopeField.Options.(schema.MultiValuer); ok && multi.IsMultiple() {
+				isValid = false
+			}
+		}
+
+		if !isValid {
+			return validation.Errors{fmt.Sprint(i): validation.Errors{
+				"options": validation.Errors{
+					"scopeField": validation.NewError(
+						"validation_invalid_autonumber_scope_field",
+						"The scope field must be an existing single value field.",
+					),
+				}},
+			}
+		}
+	}
+
+	return nil
+}
+
 func (form *CollectionUpsert) ensureNoAuthFieldName(value any) error {
 	v, _ := value.(schema.Schema)
 
@@ -369,6 +415,40 @@ func (form *CollectionUpsert) ensureNoSystemFieldsChange(value any) error {
 	return nil
 }
 
+func (form *CollectionUpsert) ensureNoImmutableFieldsChange(value any) error {
+	v, _ := value.(schema.Schema)
+
+	options := form.collection.Immutable()
+	if form.collection.IsNew() || options == nil {
+		return nil
+	}
+
+	for _, oldField := range form.collection.Schema.Fields() {
+		newField := v.GetFieldById(oldField.Id)
+
+		if newField == nil || newField.Name != oldField.Name || newField.Type != oldField.Type {
+			return validation.NewError(
+				"validation_immutable_field_change",
+				"The fields of an immutable collection cannot be deleted, renamed or change their type.",
+			)
+		}
+	}
+
+	// the new fields are part of the hashed data
+	if options.HashField != "" {
+		for _, field := range v.Fields() {
+			if form.collection.Schema.GetFieldById(field.Id) == nil {
+				return validation.NewError(
+					"validation_immutable_field_add",
+					"New fields cannot be added to an immutable collection with hash chain.",
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (form *CollectionUpsert) checkRule(value any) error {
 	v, _ := value.(*string)
 	if v == nil || *v == "" {
@@ -433,6 +513,40 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 	v, _ := value.(types.JsonMap)
 
 	switch form.Type {
+	case models.CollectionTypeBase:
+		options := models.CollectionBaseOptions{}
+		if err := decodeOptions(v, &options); err != nil {
+			return err
+		}
+
+		// check the generic validations
+		if err := options.Validate(); err != nil {
+			return err
+		}
+
+		if err := form.checkRevisionField(options.RevisionField); err != nil {
+			return err
+		}
+
+		if err := form.checkPublicForm(options.PublicForm); err != nil {
+			return err
+		}
+
+		if err := form.checkImmutable(options.Immutable); err != nil {
+			return err
+		}
+
+		if err := form.checkDuplicates(options.Duplicates); err != nil {
+			return err
+		}
+
+		if err := form.checkTenantField(options.TenantField); err != nil {
+			return err
+		}
+
+		if err := form.checkEncryptFiles(options.EncryptFiles); err != nil {
+			return err
+		}
 	case models.CollectionTypeAuth:
 		options := models.CollectionAuthOptions{}
 		if err := decodeOptions(v, &options); err != nil {
@@ -448,6 +562,32 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 		if err := form.checkRule(options.ManageRule); err != nil {
 			return validation.Errors{"manageRule": err}
 		}
+
+		if options.AllowOTPAuth {
+			field := form.Schema.GetFieldByName(options.OTPPhoneField)
+			if field == nil || (field.Type != schema.FieldTypeText && field.Type != schema.FieldTypePhone) {
+				return validation.Errors{"otpPhoneField": validation.NewError(
+					"validation_invalid_otp_phone_field",
+					"The OTP phone field must be an existing text or phone field.",
+				)}
+			}
+		}
+
+		if err := form.checkRevisionField(options.RevisionField); err != nil {
+			return err
+		}
+
+		if err := form.checkDuplicates(options.Duplicates); err != nil {
+			return err
+		}
+
+		if err := form.checkTenantField(options.TenantField); err != nil {
+			return err
+		}
+
+		if err := form.checkEncryptFiles(options.EncryptFiles); err != nil {
+			return err
+		}
 	case models.CollectionTypeView:
 		options := models.CollectionViewOptions{}
 		if err := decodeOptions(v, &options); err != nil {
@@ -473,6 +613,158 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 	return nil
 }
 
+// checkRevisionField checks whether the optional revisionField
+// option refers to an existing number schema field.
+func (form *CollectionUpsert) checkRevisionField(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	field := form.Schema.GetFieldByName(name)
+	if field == nil || field.Type != schema.FieldTypeNumber {
+		return validation.Errors{"revisionField": validation.NewError(
+			"validation_invalid_revision_field",
+			"The revision field must be an existing number field.",
+		)}
+	}
+
+	return nil
+}
+
+// checkEncryptFiles checks whether the app has a valid encryption
+// key when the collection files encryption is enabled.
+func (form *CollectionUpsert) checkEncryptFiles(enabled bool) error {
+	if !enabled || len(os.Getenv(form.app.EncryptionEnv())) == 32 {
+		return nil
+	}
+
+	return validation.Errors{"encryptFiles": validation.NewError(
+		"validation_missing_encryption_key",
+		"The files encryption requires the app to be started with a 32 characters encryption key (see --encryptionEnv).",
+	)}
+}
+
+// checkTenantField checks whether the optional tenantField
+// is an existing single relation schema field.
+func (form *CollectionUpsert) checkTenantField(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	field := form.Schema.GetFieldByName(name)
+	if field != nil && field.Type == schema.FieldTypeRelation {
+		if options, _ := field.Options.(*schema.RelationOptions); options != nil && !options.IsMultiple() {
+			return nil
+		}
+	}
+
+	return validation.Errors{"tenantField": validation.NewError(
+		"validation_invalid_tenant_field",
+		"The tenant field must be an existing single relation field.",
+	)}
+}
+
+// checkImmutable checks whether the optional immutable hash field
+// is an existing text field and that an existing immutable collection
+// doesn't become mutable again or change its hash field.
+func (form *CollectionUpsert) checkImmutable(options *models.CollectionImmutableOptions) error {
+	enabled := options != nil && options.Enabled
+
+	if !enabled {
+		if !form.collection.IsNew() && form.collection.Immutable() != nil {
+			return validation.Errors{"immutable": validation.NewError(
+				"validation_immutable_collection",
+				"The immutable collection records cannot be made mutable again.",
+			)}
+		}
+		return nil
+	}
+
+	if !form.collection.IsNew() {
+		if old := form.collection.Immutable(); old != nil && old.HashField != options.HashField {
+			return validation.Errors{"immutable": validation.Errors{"hashField": validation.NewError(
+				"validation_hash_field_change",
+				"The hash field of an immutable collection cannot be changed.",
+			)}}
+		}
+	}
+
+	if options.HashField == "" {
+		return nil
+	}
+
+	field := form.Schema.GetFieldByName(options.HashField)
+	if field == nil || field.Type != schema.FieldTypeText {
+		return validation.Errors{"immutable": validation.Errors{"hashField": validation.NewError(
+			"validation_invalid_hash_field",
+			"The hash field must be an existing text field.",
+		)}}
+	}
+
+	return nil
+}
+
+// checkPublicForm checks whether the optional public form fields
+// allowlist refers to existing schema fields and that the honeypot
+// input doesn't collide with any of them.
+func (form *CollectionUpsert) checkPublicForm(options *models.CollectionPublicFormOptions) error {
+	if options == nil {
+		return nil
+	}
+
+	for i, name := range options.Fields {
+		if form.Schema.GetFieldByName(name) == nil {
+			return validation.Errors{"publicForm": validation.Errors{
+				"fields": validation.Errors{strconv.Itoa(i): validation.NewError(
+					"validation_missing_public_form_field",
+					fmt.Sprintf("Missing schema field %q.", name),
+				)},
+			}}
+		}
+	}
+
+	if options.Honeypot != "" && form.Schema.GetFieldByName(options.Honeypot) != nil {
+		return validation.Errors{"publicForm": validation.Errors{
+			"honeypot": validation.NewError(
+				"validation_invalid_public_form_honeypot",
+				"The honeypot input name must not match a schema field.",
+			),
+		}}
+	}
+
+	return nil
+}
+
+// checkDuplicates checks whether the optional duplicate detection
+// rules refer to existing comparable (aka. non file or json) fields.
+func (form *CollectionUpsert) checkDuplicates(options *models.CollectionDuplicatesOptions) error {
+	if options == nil {
+		return nil
+	}
+
+	for i, rule := range options.Rules {
+		// the auth collections system fields
+		if form.Type == models.CollectionTypeAuth &&
+			(rule.Field == schema.FieldNameEmail || rule.Field == schema.FieldNameUsername) {
+			continue
+		}
+
+		field := form.Schema.GetFieldByName(rule.Field)
+		if field == nil || field.Type == schema.FieldTypeFile || field.Type == schema.FieldTypeJson {
+			return validation.Errors{"duplicates": validation.Errors{
+				"rules": validation.Errors{strconv.Itoa(i): validation.Errors{
+					"field": validation.NewError(
+						"validation_invalid_duplicates_field",
+						fmt.Sprintf("%q must be an existing non file or json field.", rule.Field),
+					),
+				}},
+			}}
+		}
+	}
+
+	return nil
+}
+
 func decodeOptions(options types.JsonMap, result any) error {
 	raw, err := options.MarshalJSON()
 	if err != nil {
@@ -535,6 +827,27 @@ func (form *CollectionUpsert) Submit(interceptors ...InterceptorFunc[*models.Col
 	form.collection.SetOptions(form.Options)
 
 	return runInterceptors(form.collection, func(collection *models.Collection) error {
-		return form.dao.SaveCollection(collection)
+		if err := form.dao.SaveCollection(collection); err != nil {
+			return err
+		}
+
+		form.logRulesWarnings(collection)
+
+		return nil
 	}, interceptors...)
 }
+
+// logRulesWarnings logs the lint warnings of the saved collection API rules
+// (eg. references to unknown @request.data fields) to reduce the silent rule mistakes.
+func (form *CollectionUpsert) logRulesWarnings(collection *models.Collection) {
+	for _, lint := range form.dao.LintCollectionRules(collection) {
+		for _, warning := range lint.Warnings {
+			form.app.Logger().Warn(
+				"Collection rule warning",
+				"collection", collection.Name,
+				"rule", lint.Name,
+				"warning", warning,
+			)
+		}
+	}
+}
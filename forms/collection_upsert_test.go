@@ -199,6 +199,295 @@ func TestCollectionUpsertValidateAndSubmit(t *testing.T) {
 			}`,
 			[]string{"options"},
 		},
+		{
+			"create failure - non text OTP phone field",
+			"",
+			`{
+				"name": "test_new",
+				"type": "auth",
+				"schema": [
+					{"name":"test","type":"text"},
+					{"name":"phone","type":"number"}
+				],
+				"options": { "allowOTPAuth": true, "otpPhoneField": "phone" }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create failure - missing OTP phone field",
+			"",
+			`{
+				"name": "test_new",
+				"type": "auth",
+				"schema": [
+					{"name":"test","type":"text"}
+				],
+				"options": { "allowOTPAuth": true, "otpPhoneField": "phone" }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create success - OTP phone field",
+			"",
+			`{
+				"name": "test_otp",
+				"type": "auth",
+				"schema": [
+					{"name":"phone","type":"text"}
+				],
+				"options": { "allowOTPAuth": true, "otpPhoneField": "phone" }
+			}`,
+			[]string{},
+		},
+		{
+			"create success - OTP phone field with phone type",
+			"",
+			`{
+				"name": "test_otp_phone",
+				"type": "auth",
+				"schema": [
+					{"name":"phone","type":"phone","options":{"defaultCountry":"US"}}
+				],
+				"options": { "allowOTPAuth": true, "otpPhoneField": "phone" }
+			}`,
+			[]string{},
+		},
+		{
+			"create failure - non number revision field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"rev","type":"text"}
+				],
+				"options": { "revisionField": "rev" }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create failure - missing auth revision field",
+			"",
+			`{
+				"name": "test_new",
+				"type": "auth",
+				"schema": [
+					{"name":"test","type":"text"}
+				],
+				"options": { "revisionField": "rev" }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create success - revision field",
+			"",
+			`{
+				"name": "test_revision",
+				"schema": [
+					{"name":"rev","type":"number"}
+				],
+				"options": { "revisionField": "rev" }
+			}`,
+			[]string{},
+		},
+		{
+			"create failure - missing autonumber scope field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"number","type":"autonumber","options":{"scopeField":"org"}}
+				]
+			}`,
+			[]string{"schema"},
+		},
+		{
+			"create failure - multiple autonumber scope field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"org","type":"select","options":{"maxSelect":2,"values":["a","b"]}},
+					{"name":"number","type":"autonumber","options":{"scopeField":"org"}}
+				]
+			}`,
+			[]string{"schema"},
+		},
+		{
+			"create success - autonumber field",
+			"",
+			`{
+				"name": "test_autonumber",
+				"schema": [
+					{"name":"org","type":"select","options":{"maxSelect":1,"values":["a","b"]}},
+					{"name":"number","type":"autonumber","options":{"prefix":"POL-{year}-","padding":6,"scopeField":"org","resetYearly":true}}
+				]
+			}`,
+			[]string{},
+		},
+		{
+			"create failure - missing public form field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"email","type":"email"}
+				],
+				"options": { "publicForm": {"enabled":true,"fields":["email","missing"]} }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create failure - public form honeypot matching a schema field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"email","type":"email"}
+				],
+				"options": { "publicForm": {"enabled":true,"fields":["email"],"honeypot":"email"} }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create success - public form",
+			"",
+			`{
+				"name": "test_public_form",
+				"schema": [
+					{"name":"email","type":"email"}
+				],
+				"options": { "publicForm": {"enabled":true,"fields":["email"],"honeypot":"website"} }
+			}`,
+			[]string{},
+		},
+		{
+			"create failure - duplicates rule with missing field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"title","type":"text"}
+				],
+				"options": { "duplicates": {"rules":[{"field":"missing","match":"exact"}]} }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create failure - duplicates rule with file field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"avatar","type":"file","options":{"maxSelect":1,"maxSize":100}}
+				],
+				"options": { "duplicates": {"rules":[{"field":"avatar","match":"exact"}]} }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create success - duplicates",
+			"",
+			`{
+				"name": "test_duplicates",
+				"schema": [
+					{"name":"title","type":"text"},
+					{"name":"phone","type":"text"}
+				],
+				"options": { "duplicates": {"rules":[{"field":"title","match":"fuzzy"},{"field":"phone","match":"normalized","weight":2}],"threshold":0.7} }
+			}`,
+			[]string{},
+		},
+		{
+			"create failure - multiple relation tenant field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"org","type":"relation","options":{"collectionId":"sz5l5z67tg7gku0","maxSelect":2}}
+				],
+				"options": { "tenantField": "org" }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create failure - non relation auth tenant field",
+			"",
+			`{
+				"name": "test_new",
+				"type": "auth",
+				"schema": [
+					{"name":"org","type":"text"}
+				],
+				"options": { "tenantField": "org" }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create success - tenant field",
+			"",
+			`{
+				"name": "test_tenant",
+				"schema": [
+					{"name":"org","type":"relation","options":{"collectionId":"sz5l5z67tg7gku0","maxSelect":1}}
+				],
+				"options": { "tenantField": "org" }
+			}`,
+			[]string{},
+		},
+		{
+			"create failure - immutable with non text hash field",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"hash","type":"number"}
+				],
+				"options": { "immutable": {"enabled":true,"hashField":"hash"} }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"create success - immutable with hash field",
+			"",
+			`{
+				"name": "test_immutable",
+				"schema": [
+					{"name":"hash","type":"text"}
+				],
+				"options": { "immutable": {"enabled":true,"hashField":"hash"} }
+			}`,
+			[]string{},
+		},
+		{
+			"update failure - immutable collection made mutable",
+			"test_immutable",
+			`{
+				"options": { "immutable": {"enabled":false} }
+			}`,
+			[]string{"options"},
+		},
+		{
+			"update failure - immutable collection field replaced",
+			"test_immutable",
+			`{
+				"schema": [
+					{"name":"hash","type":"text"}
+				]
+			}`,
+			[]string{"schema"},
+		},
+		{
+			"create failure - encrypt files without app encryption key",
+			"",
+			`{
+				"name": "test_new",
+				"schema": [
+					{"name":"document","type":"file","options":{"maxSelect":1,"maxSize":100}}
+				],
+				"options": { "encryptFiles": true }
+			}`,
+			[]string{"options"},
+		},
 		{
 			"create failure - check view options validators",
 			"",
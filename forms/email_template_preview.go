@@ -0,0 +1,94 @@
+package forms
+
+import (
+	"net/mail"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+)
+
+// EmailTemplatePreview is an email template preview and test send form.
+//
+// If Html is set, the form Subject, Html and Text are rendered as
+// unsaved template draft, otherwise the stored (or settings) template
+// for the specified Action and Language is used.
+type EmailTemplatePreview struct {
+	app core.App
+
+	Action   string         `form:"action" json:"action"`
+	Language string         `form:"language" json:"language"`
+	Subject  string         `form:"subject" json:"subject"`
+	Html     string         `form:"html" json:"html"`
+	Text     string         `form:"text" json:"text"`
+	Data     map[string]any `form:"data" json:"data"`
+
+	// Email is the test email recipient (used only by Submit).
+	Email string `form:"email" json:"email"`
+}
+
+// NewEmailTemplatePreview creates and initializes new EmailTemplatePreview form.
+func NewEmailTemplatePreview(app core.App) *EmailTemplatePreview {
+	return &EmailTemplatePreview{app: app}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *EmailTemplatePreview) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Action,
+			validation.When(form.Html == "", validation.Required),
+			validation.Match(emailTemplateActionRegex),
+		),
+		validation.Field(
+			&form.Language,
+			validation.Match(emailTemplateLanguageRegex),
+		),
+		validation.Field(&form.Subject, validation.By(checkTextTemplate)),
+		validation.Field(&form.Html, validation.By(checkHtmlTemplate)),
+		validation.Field(&form.Text, validation.By(checkTextTemplate)),
+		validation.Field(
+			&form.Email,
+			validation.Length(1, 255),
+			is.EmailFormat,
+		),
+	)
+}
+
+// Render validates the form and returns the rendered template message.
+func (form *EmailTemplatePreview) Render() (*mailer.Message, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if form.Html != "" {
+		return mails.RenderEmailTemplateModel(form.app, &models.EmailTemplate{
+			Action:   form.Action,
+			Language: form.Language,
+			Subject:  form.Subject,
+			Html:     form.Html,
+			Text:     form.Text,
+		}, form.Data)
+	}
+
+	return mails.RenderEmailTemplate(form.app, form.Action, form.Language, form.Data)
+}
+
+// Submit renders the form template and sends it to the form.Email address.
+func (form *EmailTemplatePreview) Submit() error {
+	if err := validation.Validate(form.Email, validation.Required); err != nil {
+		return validation.Errors{"email": err}
+	}
+
+	message, err := form.Render()
+	if err != nil {
+		return err
+	}
+
+	message.To = []mail.Address{{Address: form.Email}}
+
+	return form.app.NewMailClient().Send(message)
+}
@@ -0,0 +1,153 @@
+package forms
+
+import (
+	htmlTemplate "html/template"
+	"regexp"
+	"text/template"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var (
+	emailTemplateActionRegex   = regexp.MustCompile(`^[\w\.\-]+$`)
+	emailTemplateLanguageRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+)
+
+// EmailTemplateUpsert is a [models.EmailTemplate] upsert (create/update) form.
+type EmailTemplateUpsert struct {
+	app      core.App
+	dao      *daos.Dao
+	template *models.EmailTemplate
+
+	Action   string `form:"action" json:"action"`
+	Language string `form:"language" json:"language"`
+	Subject  string `form:"subject" json:"subject"`
+	Html     string `form:"html" json:"html"`
+	Text     string `form:"text" json:"text"`
+}
+
+// NewEmailTemplateUpsert creates a new [EmailTemplateUpsert] form with initializer
+// config created from the provided [core.App] and [models.EmailTemplate] instances
+// (for create you could pass a pointer to an empty EmailTemplate - `&models.EmailTemplate{}`).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewEmailTemplateUpsert(app core.App, template *models.EmailTemplate) *EmailTemplateUpsert {
+	form := &EmailTemplateUpsert{
+		app:      app,
+		dao:      app.Dao(),
+		template: template,
+	}
+
+	// load defaults
+	form.Action = template.Action
+	form.Language = template.Language
+	form.Subject = template.Subject
+	form.Html = template.Html
+	form.Text = template.Text
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *EmailTemplateUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *EmailTemplateUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Action,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(emailTemplateActionRegex),
+		),
+		validation.Field(
+			&form.Language,
+			validation.Length(0, 35),
+			validation.Match(emailTemplateLanguageRegex),
+			validation.By(form.checkUniqueLanguage),
+		),
+		validation.Field(
+			&form.Subject,
+			validation.Required,
+			validation.By(checkTextTemplate),
+		),
+		validation.Field(
+			&form.Html,
+			validation.Required,
+			validation.By(checkHtmlTemplate),
+		),
+		validation.Field(
+			&form.Text,
+			validation.By(checkTextTemplate),
+		),
+	)
+}
+
+func (form *EmailTemplateUpsert) checkUniqueLanguage(value any) error {
+	v, _ := value.(string)
+
+	var exists bool
+
+	err := form.dao.EmailTemplateQuery().
+		Select("(1)").
+		AndWhere(dbx.HashExp{"action": form.Action, "language": v}).
+		AndWhere(dbx.Not(dbx.HashExp{"id": form.template.Id})).
+		Limit(1).
+		Row(&exists)
+
+	if err == nil && exists {
+		return validation.NewError(
+			"validation_email_template_exists",
+			"There is already an email template for the specified action and language.",
+		)
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form email template model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *EmailTemplateUpsert) Submit(interceptors ...InterceptorFunc[*models.EmailTemplate]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.template.Action = form.Action
+	form.template.Language = form.Language
+	form.template.Subject = form.Subject
+	form.template.Html = form.Html
+	form.template.Text = form.Text
+
+	return runInterceptors(form.template, func(template *models.EmailTemplate) error {
+		return form.dao.SaveEmailTemplate(template)
+	}, interceptors...)
+}
+
+func checkTextTemplate(value any) error {
+	v, _ := value.(string)
+
+	if _, err := template.New("").Parse(v); err != nil {
+		return validation.NewError("validation_invalid_template", "Invalid template - "+err.Error())
+	}
+
+	return nil
+}
+
+func checkHtmlTemplate(value any) error {
+	v, _ := value.(string)
+
+	if _, err := htmlTemplate.New("").Parse(v); err != nil {
+		return validation.NewError("validation_invalid_template", "Invalid template - "+err.Error())
+	}
+
+	return nil
+}
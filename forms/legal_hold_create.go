@@ -0,0 +1,98 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// LegalHoldCreate is a record legal hold create form.
+type LegalHoldCreate struct {
+	app   core.App
+	dao   *daos.Dao
+	admin *models.Admin
+
+	record *models.Record
+
+	Collection string `form:"collection" json:"collection"`
+	RecordId   string `form:"recordId" json:"recordId"`
+	Reason     string `form:"reason" json:"reason"`
+}
+
+// NewLegalHoldCreate creates a new [LegalHoldCreate] form
+// initialized with from the provided [core.App] instance
+// and the optional admin who places the hold.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewLegalHoldCreate(app core.App, admin *models.Admin) *LegalHoldCreate {
+	return &LegalHoldCreate{
+		app:   app,
+		dao:   app.Dao(),
+		admin: admin,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *LegalHoldCreate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *LegalHoldCreate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Collection, validation.Required),
+		validation.Field(&form.RecordId, validation.Required, validation.By(form.checkRecord)),
+		validation.Field(&form.Reason, validation.Length(0, 1000)),
+	)
+}
+
+func (form *LegalHoldCreate) checkRecord(value any) error {
+	v, _ := value.(string)
+
+	collection, err := form.dao.FindCollectionByNameOrId(form.Collection)
+	if err != nil || collection.IsView() {
+		return validation.NewError("validation_invalid_collection", "Missing or invalid collection.")
+	}
+
+	record, err := form.dao.FindRecordById(collection.Id, v)
+	if err != nil {
+		return validation.NewError("validation_missing_record", "Missing record.")
+	}
+
+	form.record = record
+
+	return nil
+}
+
+// Submit validates and submits the form.
+// On success, places a new legal hold on the form record.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *LegalHoldCreate) Submit(interceptors ...InterceptorFunc[*models.LegalHold]) (*models.LegalHold, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	hold := &models.LegalHold{
+		CollectionId: form.record.Collection().Id,
+		RecordId:     form.record.Id,
+		Reason:       form.Reason,
+	}
+	if form.admin != nil {
+		hold.AdminId = form.admin.Id
+	}
+
+	interceptorsErr := runInterceptors(hold, func(m *models.LegalHold) error {
+		hold = m
+		return form.dao.SaveLegalHold(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return hold, nil
+}
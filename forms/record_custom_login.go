@@ -0,0 +1,79 @@
+package forms
+
+import (
+	"errors"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var customLoginMethodRegex = regexp.MustCompile(`^[\w\-\.]+$`)
+
+// RecordCustomLogin is a record login form for the app defined
+// custom auth methods (eg. SMS OTP via a local provider).
+//
+// The form itself doesn't locate the auth record - it is expected
+// to be resolved by the submit interceptors (see [core.App.OnRecordAuthWithCustomMethod]).
+type RecordCustomLogin struct {
+	app        core.App
+	collection *models.Collection
+
+	// Method is the custom auth method name (eg. "sms").
+	Method string `form:"method" json:"method"`
+
+	// Payload is the custom auth method specific data
+	// (eg. {"phone": "...", "code": "..."}).
+	Payload map[string]any `form:"payload" json:"payload"`
+}
+
+// NewRecordCustomLogin creates a new [RecordCustomLogin] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+func NewRecordCustomLogin(app core.App, collection *models.Collection) *RecordCustomLogin {
+	return &RecordCustomLogin{
+		app:        app,
+		collection: collection,
+	}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordCustomLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Method,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(customLoginMethodRegex),
+		),
+	)
+}
+
+// Submit validates and submits the form.
+// On success returns the authorized record model.
+//
+// The authorized record is expected to be provided by one of
+// the form interceptors (by calling next with the resolved record).
+func (form *RecordCustomLogin) Submit(interceptors ...InterceptorFunc[*models.Record]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	var authRecord *models.Record
+
+	interceptorsErr := runInterceptors(nil, func(m *models.Record) error {
+		authRecord = m
+
+		if authRecord == nil || authRecord.Collection().Id != form.collection.Id {
+			return errors.New("Invalid login credentials.")
+		}
+
+		return nil
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return authRecord, nil
+}
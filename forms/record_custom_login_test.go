@@ -0,0 +1,83 @@
+package forms_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordCustomLoginValidateAndSubmit(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	users, err := testApp.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := testApp.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := testApp.Dao().FindAuthRecordByUsername("clients", "clients57772")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		method         string
+		resolvedRecord *models.Record
+		expectError    bool
+	}{
+		{"empty method", "", user, true},
+		{"invalid method", "in valid", user, true},
+		{"unresolved record", "sms", nil, true},
+		{"record from another collection", "sms", client, true},
+		{"resolved record", "sms", user, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordCustomLogin(testApp, users)
+			form.Method = s.method
+			form.Payload = map[string]any{"code": "123"}
+
+			interceptorCalls := 0
+
+			record, err := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+				return func(r *models.Record) error {
+					interceptorCalls++
+
+					if r != nil {
+						t.Fatalf("Expected nil initial record, got %v", r)
+					}
+
+					return next(s.resolvedRecord)
+				}
+			})
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			expectedCalls := 1
+			if s.method != "sms" {
+				expectedCalls = 0 // validation error
+			}
+			if interceptorCalls != expectedCalls {
+				t.Fatalf("Expected %d interceptor calls, got %d", expectedCalls, interceptorCalls)
+			}
+
+			if !hasErr && record.Id != s.resolvedRecord.Id {
+				t.Fatalf("Expected record %q, got %q", s.resolvedRecord.Id, record.Id)
+			}
+		})
+	}
+}
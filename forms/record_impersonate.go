@@ -0,0 +1,55 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+)
+
+// DefaultImpersonationDuration is the default impersonation
+// token duration (in seconds) if the form duration is not set.
+const DefaultImpersonationDuration = 1800
+
+// RecordImpersonate is a form struct to generate a new short-lived
+// auth token on behalf of an auth record (aka. "login as user").
+type RecordImpersonate struct {
+	app    core.App
+	record *models.Record
+
+	// Duration specifies how long the generated token should be considered valid.
+	// The specified value must be in seconds and max 86400 (1 day).
+	//
+	// If not set, [DefaultImpersonationDuration] is used.
+	Duration int64 `form:"duration" json:"duration"`
+}
+
+// NewRecordImpersonate creates a new [RecordImpersonate] form initialized
+// with the provided [core.App] and auth [models.Record] instances.
+func NewRecordImpersonate(app core.App, record *models.Record) *RecordImpersonate {
+	return &RecordImpersonate{
+		app:    app,
+		record: record,
+	}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordImpersonate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Duration, validation.Min(int64(0)), validation.Max(int64(86400))),
+	)
+}
+
+// Submit validates the form and returns a new record impersonation token.
+func (form *RecordImpersonate) Submit() (string, error) {
+	if err := form.Validate(); err != nil {
+		return "", err
+	}
+
+	duration := form.Duration
+	if duration == 0 {
+		duration = DefaultImpersonationDuration
+	}
+
+	return tokens.NewRecordImpersonationToken(form.app, form.record, duration)
+}
@@ -0,0 +1,87 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestRecordImpersonateValidateAndSubmit(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	authRecord, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseRecord, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		base           bool
+		data           string
+		expectedErrors []string
+		expectError    bool
+	}{
+		{"negative duration", false, `{"duration":-1}`, []string{"duration"}, true},
+		{"too large duration", false, `{"duration":86401}`, []string{"duration"}, true},
+		{"non-auth record", true, `{}`, []string{}, true},
+		{"default duration", false, `{}`, []string{}, false},
+		{"custom duration", false, `{"duration":60}`, []string{}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			record := authRecord
+			if s.base {
+				record = baseRecord
+			}
+
+			form := forms.NewRecordImpersonate(app, record)
+
+			if err := json.Unmarshal([]byte(s.data), form); err != nil {
+				t.Fatal(err)
+			}
+
+			token, err := form.Submit()
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			errs, _ := err.(validation.Errors)
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			if s.expectError {
+				return
+			}
+
+			claims, err := security.ParseJWT(token, record.TokenKey()+app.Settings().RecordAuthToken.Secret)
+			if err != nil {
+				t.Fatalf("Failed to parse the token: %v", err)
+			}
+
+			if claims[tokens.ClaimImpersonated] != true {
+				t.Fatalf("Expected the impersonated claim to be set, got %v", claims)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package forms
+
+import (
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// RecordMerge is a record merge form that combines
+// probable duplicate records into the form record.
+type RecordMerge struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	duplicates []*models.Record
+
+	// Duplicates is the list with the ids of the records
+	// that will be merged into the form record (and then deleted).
+	Duplicates []string `form:"duplicates" json:"duplicates"`
+}
+
+// NewRecordMerge creates a new [RecordMerge] form initialized with
+// the provided [core.App] and target [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordMerge(app core.App, record *models.Record) *RecordMerge {
+	return &RecordMerge{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordMerge) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordMerge) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Duplicates,
+			validation.Required,
+			validation.Length(1, 50),
+			validation.By(form.checkDuplicates),
+		),
+	)
+}
+
+func (form *RecordMerge) checkDuplicates(value any) error {
+	ids := list.ToUniqueStringSlice(value)
+
+	if list.ExistInSlice(form.record.Id, ids) {
+		return validation.NewError("validation_merge_self", "The record cannot be merged with itself.")
+	}
+
+	records, err := form.dao.FindRecordsByIds(form.record.Collection().Id, ids)
+	if err != nil || len(records) != len(ids) {
+		return validation.NewError("validation_missing_duplicates", "Missing or invalid duplicate records.")
+	}
+
+	// keep the submitted order since the empty
+	// target fields are filled from the first duplicate
+	positions := make(map[string]int, len(ids))
+	for i, id := range ids {
+		positions[id] = i
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return positions[records[i].Id] < positions[records[j].Id]
+	})
+
+	form.duplicates = records
+
+	return nil
+}
+
+// Submit validates and submits the form.
+// On success, the form record is updated with:
+//   - its empty (non file) fields filled from the first duplicate with a nonempty value
+//   - its file fields combined with the duplicates files (up to the field max select)
+//
+// and all relation references to the duplicates are replaced
+// with the form record before deleting the duplicates.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordMerge) Submit(interceptors ...InterceptorFunc[*models.Record]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	return runInterceptors(form.record, func(record *models.Record) error {
+		fsys, err := form.app.NewFilesystem()
+		if err != nil {
+			return err
+		}
+		defer fsys.Close()
+
+		copied, err := form.combineFields(fsys, record)
+		if err == nil {
+			err = form.dao.MergeRecords(record, form.duplicates)
+		}
+
+		if err != nil {
+			// cleanup the copied files
+			for _, key := range copied {
+				if deleteErr := fsys.Delete(key); deleteErr != nil {
+					form.app.Logger().Debug("Failed to delete merged record file", "key", key, "error", deleteErr.Error())
+				}
+			}
+
+			return err
+		}
+
+		return nil
+	}, interceptors...)
+}
+
+// combineFields fills the empty record fields with the duplicates values and
+// copies the duplicates files to the record storage directory.
+//
+// Returns the storage keys of the copied files.
+func (form *RecordMerge) combineFields(fsys *filesystem.System, record *models.Record) ([]string, error) {
+	var copied []string
+
+	for _, field := range record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeFile {
+			if validation.Required.Validate(record.Get(field.Name)) == nil {
+				continue // nonempty target value
+			}
+
+			for _, d := range form.duplicates {
+				if v := d.Get(field.Name); validation.Required.Validate(v) == nil {
+					record.Set(field.Name, v)
+					break
+				}
+			}
+
+			continue
+		}
+
+		options, _ := field.Options.(*schema.FileOptions)
+		maxSelect := 1
+		if options != nil {
+			maxSelect = options.MaxSelect
+		}
+
+		names := record.GetStringSlice(field.Name)
+
+		for _, d := range form.duplicates {
+			for _, name := range d.GetStringSlice(field.Name) {
+				if len(names) >= maxSelect {
+					break
+				}
+
+				if list.ExistInSlice(name, names) {
+					continue
+				}
+
+				dstKey := record.BaseFilesPath() + "/" + name
+
+				if err := fsys.Copy(d.BaseFilesPath()+"/"+name, dstKey); err != nil {
+					return copied, err
+				}
+
+				copied = append(copied, dstKey)
+				names = append(names, name)
+			}
+		}
+
+		record.Set(field.Name, field.PrepareValue(names))
+	}
+
+	return copied, nil
+}
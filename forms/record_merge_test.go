@@ -0,0 +1,150 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordMergeValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		jsonData      string
+		expectedError string
+	}{
+		{"empty data", `{}`, "validation_required"},
+		{"merge with itself", `{"duplicates":["llvuca81nly1qls"]}`, "validation_merge_self"},
+		{"missing duplicate", `{"duplicates":["achvryl401bhse3","missing"]}`, "validation_missing_duplicates"},
+		{"duplicate from another collection", `{"duplicates":["al1h9ijdeojtsjy"]}`, "validation_missing_duplicates"},
+		{"valid duplicates", `{"duplicates":["achvryl401bhse3","0yxhwia2amd8gec"]}`, ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordMerge(app, record)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			err := form.Validate()
+
+			if s.expectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			errs, ok := err.(validation.Errors)
+			if !ok {
+				t.Fatalf("Expected validation.Errors, got %v", err)
+			}
+
+			fieldErr, ok := errs["duplicates"].(validation.Error)
+			if !ok {
+				t.Fatalf("Expected duplicates validation error, got %v", errs)
+			}
+
+			if fieldErr.Code() != s.expectedError {
+				t.Fatalf("Expected error code %q, got %q", s.expectedError, fieldErr.Code())
+			}
+		})
+	}
+}
+
+func TestRecordMergeSubmit(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordMerge(app, record)
+	form.Duplicates = []string{"achvryl401bhse3"}
+
+	interceptorCalls := 0
+	interceptor := func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(r *models.Record) error {
+			interceptorCalls++
+			return next(r)
+		}
+	}
+
+	if err := form.Submit(interceptor); err != nil {
+		t.Fatal(err)
+	}
+
+	if interceptorCalls != 1 {
+		t.Fatalf("Expected the interceptor to be called once, got %d", interceptorCalls)
+	}
+
+	if _, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3"); err == nil {
+		t.Fatal("Expected the duplicate to be deleted")
+	}
+
+	merged, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the nonempty target field should remain unchanged
+	if v := merged.GetString("title"); v != "test1" {
+		t.Fatalf("Expected title %q, got %q", "test1", v)
+	}
+
+	// the empty target field should be filled from the duplicate
+	if !merged.GetBool("active") {
+		t.Fatal("Expected active to be filled from the duplicate")
+	}
+}
+
+func TestRecordMergeSubmitInterceptorError(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordMerge(app, record)
+	form.Duplicates = []string{"achvryl401bhse3"}
+
+	testErr := errors.New("test_error")
+
+	interceptor := func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(r *models.Record) error {
+			return testErr
+		}
+	}
+
+	if err := form.Submit(interceptor); err != testErr {
+		t.Fatalf("Expected %v, got %v", testErr, err)
+	}
+
+	if _, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3"); err != nil {
+		t.Fatalf("Expected the duplicate to remain, got %v", err)
+	}
+}
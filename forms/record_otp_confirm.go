@@ -0,0 +1,130 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// maxOTPAttempts is the max number of failed password checks
+// after which the OTP is invalidated.
+const maxOTPAttempts = 5
+
+// RecordOTPConfirmData defines the OTP confirm submit interceptor data.
+type RecordOTPConfirmData struct {
+	Record *models.Record
+	OTP    *models.OTP
+}
+
+// RecordOTPConfirm is an auth record SMS one-time password confirm (aka. login) form.
+type RecordOTPConfirm struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	OtpId    string `form:"otpId" json:"otpId"`
+	Password string `form:"password" json:"password"`
+}
+
+// NewRecordOTPConfirm creates a new [RecordOTPConfirm] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordOTPConfirm(app core.App, collection *models.Collection) *RecordOTPConfirm {
+	return &RecordOTPConfirm{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordOTPConfirm) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordOTPConfirm) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.OtpId, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.Password, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// Submit validates and submits the form.
+// On success returns the authorized record model.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordOTPConfirm) Submit(interceptors ...InterceptorFunc[*RecordOTPConfirmData]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	invalidErr := validation.Errors{"password": validation.NewError(
+		"validation_invalid_otp",
+		"Invalid or expired one-time password.",
+	)}
+
+	options := form.collection.AuthOptions()
+	if !options.AllowOTPAuth {
+		return nil, invalidErr
+	}
+
+	otp, err := form.dao.FindOTPById(form.OtpId)
+	if err != nil || otp.CollectionId != form.collection.Id {
+		return nil, invalidErr
+	}
+
+	if otp.IsExpired() {
+		form.dao.DeleteOTP(otp)
+		return nil, invalidErr
+	}
+
+	// reserve an attempt before comparing the password
+	// so that concurrent requests can't exceed the max attempts limit
+	incremented, err := form.dao.IncrementOTPAttempts(otp, maxOTPAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if !incremented {
+		form.dao.DeleteOTP(otp)
+		return nil, invalidErr
+	}
+
+	if !otp.ValidatePassword(form.Password) {
+		if otp.Attempts >= maxOTPAttempts {
+			form.dao.DeleteOTP(otp)
+		}
+		return nil, invalidErr
+	}
+
+	authRecord, err := form.dao.FindRecordById(form.collection.Id, otp.RecordId)
+	if err != nil {
+		return nil, invalidErr
+	}
+
+	// the phone number was changed after the OTP was sent
+	if authRecord.GetString(options.OTPPhoneField) != otp.SentTo {
+		form.dao.DeleteOTP(otp)
+		return nil, invalidErr
+	}
+
+	interceptorData := &RecordOTPConfirmData{
+		Record: authRecord,
+		OTP:    otp,
+	}
+
+	interceptorsErr := runInterceptors(interceptorData, func(data *RecordOTPConfirmData) error {
+		// OTPs are single use
+		return form.dao.DeleteOTP(data.OTP)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return interceptorData.Record, nil
+}
@@ -0,0 +1,181 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createTestOTP(t *testing.T, app *tests.TestApp, collection *models.Collection, password string, expires time.Time) *models.OTP {
+	record, err := app.Dao().FindAuthRecordByEmail(collection.Id, "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tests.CreateOTP(t, app, collection.Id, record.Id, record.GetString("phone"), password, expires)
+}
+
+func TestRecordOTPConfirmSubmit(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	valid := createTestOTP(t, testApp, authCollection, "123456", time.Now().Add(time.Hour))
+	expired := createTestOTP(t, testApp, authCollection, "123456", time.Now().Add(-time.Hour))
+
+	scenarios := []struct {
+		name        string
+		jsonData    string
+		expectError bool
+	}{
+		{"empty data", `{}`, true},
+		{"missing otp", `{"otpId":"missing","password":"123456"}`, true},
+		{"expired otp", fmt.Sprintf(`{"otpId":%q,"password":"123456"}`, expired.Id), true},
+		{"invalid password", fmt.Sprintf(`{"otpId":%q,"password":"654321"}`, valid.Id), true},
+		{"valid password", fmt.Sprintf(`{"otpId":%q,"password":"123456"}`, valid.Id), false},
+		{"already used otp", fmt.Sprintf(`{"otpId":%q,"password":"123456"}`, valid.Id), true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordOTPConfirm(testApp, authCollection)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*forms.RecordOTPConfirmData]) forms.InterceptorNextFunc[*forms.RecordOTPConfirmData] {
+				return func(data *forms.RecordOTPConfirmData) error {
+					interceptorCalls++
+					return next(data)
+				}
+			}
+
+			record, err := form.Submit(interceptor)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr to be %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			expectInterceptorCalls := 1
+			if s.expectError {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Fatalf("Expected interceptor to be called %d, got %d", expectInterceptorCalls, interceptorCalls)
+			}
+
+			if !s.expectError && record.Email() != "test@example.com" {
+				t.Fatalf("Expected the test@example.com record, got %v", record.Email())
+			}
+		})
+	}
+}
+
+func TestRecordOTPConfirmMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	otp := createTestOTP(t, testApp, authCollection, "123456", time.Now().Add(time.Hour))
+
+	for i := 0; i < 5; i++ {
+		form := forms.NewRecordOTPConfirm(testApp, authCollection)
+		form.OtpId = otp.Id
+		form.Password = "000000"
+
+		if _, err := form.Submit(); err == nil {
+			t.Fatalf("[%d] Expected error, got nil", i)
+		}
+	}
+
+	// the OTP must be invalidated even with the correct password
+	form := forms.NewRecordOTPConfirm(testApp, authCollection)
+	form.OtpId = otp.Id
+	form.Password = "123456"
+
+	if _, err := form.Submit(); err == nil {
+		t.Fatal("Expected error after reaching the max attempts, got nil")
+	}
+
+	if _, err := testApp.Dao().FindOTPById(otp.Id); err == nil {
+		t.Fatal("Expected the OTP to be deleted")
+	}
+}
+
+func TestRecordOTPConfirmChangedPhone(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	otp := createTestOTP(t, testApp, authCollection, "123456", time.Now().Add(time.Hour))
+
+	record, _ := testApp.Dao().FindRecordById(authCollection.Id, otp.RecordId)
+	record.Set("phone", "+359111111111")
+	if err := testApp.Dao().WithoutHooks().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordOTPConfirm(testApp, authCollection)
+	form.OtpId = otp.Id
+	form.Password = "123456"
+
+	if _, err := form.Submit(); err == nil {
+		t.Fatal("Expected error for changed phone number, got nil")
+	}
+}
+
+func TestRecordOTPConfirmInterceptors(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	otp := createTestOTP(t, testApp, authCollection, "123456", time.Now().Add(time.Hour))
+
+	form := forms.NewRecordOTPConfirm(testApp, authCollection)
+	form.OtpId = otp.Id
+	form.Password = "123456"
+
+	testErr := errors.New("test_error")
+
+	interceptorRecordId := ""
+	interceptor := func(next forms.InterceptorNextFunc[*forms.RecordOTPConfirmData]) forms.InterceptorNextFunc[*forms.RecordOTPConfirmData] {
+		return func(data *forms.RecordOTPConfirmData) error {
+			interceptorRecordId = data.Record.Id
+			return testErr
+		}
+	}
+
+	if _, err := form.Submit(interceptor); err != testErr {
+		t.Fatalf("Expected submitError %v, got %v", testErr, err)
+	}
+
+	if interceptorRecordId != otp.RecordId {
+		t.Fatalf("Expected interceptor record %s, got %s", otp.RecordId, interceptorRecordId)
+	}
+
+	// the OTP should remain unused
+	if _, err := testApp.Dao().FindOTPById(otp.Id); err != nil {
+		t.Fatalf("Expected the OTP to remain, got %v", err)
+	}
+}
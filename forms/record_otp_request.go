@@ -0,0 +1,165 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/sms"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// RecordOTPRequestData defines the OTP request submit interceptor data.
+type RecordOTPRequestData struct {
+	Record *models.Record
+	OTP    *models.OTP
+
+	// Password is the plain one-time password that will be sent.
+	Password string
+}
+
+// RecordOTPRequest is an auth record SMS one-time password request form.
+type RecordOTPRequest struct {
+	app             core.App
+	dao             *daos.Dao
+	collection      *models.Collection
+	resendThreshold float64 // in seconds
+
+	Phone string `form:"phone" json:"phone"`
+}
+
+// NewRecordOTPRequest creates a new [RecordOTPRequest]
+// form initialized with from the provided [core.App] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordOTPRequest(app core.App, collection *models.Collection) *RecordOTPRequest {
+	return &RecordOTPRequest{
+		app:             app,
+		dao:             app.Dao(),
+		collection:      collection,
+		resendThreshold: 60, // 1 min
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordOTPRequest) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+//
+// This method doesn't check whether auth record with `form.Phone` exists (this is done on Submit).
+func (form *RecordOTPRequest) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Phone, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+// Submit validates and submits the form.
+// On success, stores a new OTP for the `form.Phone` auth record and sends it via SMS.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordOTPRequest) Submit(interceptors ...InterceptorFunc[*RecordOTPRequestData]) (*models.OTP, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	options := form.collection.AuthOptions()
+	if !options.AllowOTPAuth {
+		return nil, errors.New("OTP authentication is not allowed for the auth collection.")
+	}
+
+	phone := form.Phone
+
+	// normalize the number in the same way as the stored phone field values (aka. E.164)
+	if field := form.collection.Schema.GetFieldByName(options.OTPPhoneField); field != nil && field.Type == schema.FieldTypePhone {
+		phone = cast.ToString(field.PrepareValue(phone))
+	}
+
+	authRecord, err := form.dao.FindFirstRecordByData(form.collection.Id, options.OTPPhoneField, phone)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch %s record with phone %s: %w", form.collection.Id, phone, err)
+	}
+
+	now := time.Now().UTC()
+
+	lastOTP, _ := form.dao.FindLastOTPByRecord(form.collection.Id, authRecord.Id)
+	if lastOTP != nil && now.Sub(lastOTP.Created.Time()).Seconds() < form.resendThreshold {
+		return nil, errors.New("You've already requested an OTP.")
+	}
+
+	settings := form.app.Settings().Sms
+
+	otp := &models.OTP{
+		CollectionId: form.collection.Id,
+		RecordId:     authRecord.Id,
+		SentTo:       phone,
+	}
+	otp.Expires, _ = types.ParseDateTime(now.Add(time.Duration(settings.OtpDuration) * time.Second))
+
+	password := security.RandomStringWithAlphabet(settings.OtpLength, "0123456789")
+	otp.SetPassword(password)
+
+	interceptorData := &RecordOTPRequestData{
+		Record:   authRecord,
+		OTP:      otp,
+		Password: password,
+	}
+
+	interceptorsErr := runInterceptors(interceptorData, func(data *RecordOTPRequestData) error {
+		saveErr := form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			// only the last requested OTP is valid
+			if err := txDao.DeleteOTPsByRecord(data.OTP.CollectionId, data.OTP.RecordId); err != nil {
+				return err
+			}
+
+			if err := txDao.DeleteExpiredOTPs(now); err != nil {
+				return err
+			}
+
+			return txDao.SaveOTP(data.OTP)
+		})
+		if saveErr != nil {
+			return saveErr
+		}
+
+		if err := form.sendOTP(data.OTP.SentTo, data.Password); err != nil {
+			// the OTP can't be used anyway
+			form.dao.DeleteOTP(data.OTP)
+
+			return err
+		}
+
+		return nil
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return interceptorData.OTP, nil
+}
+
+func (form *RecordOTPRequest) sendOTP(phone string, password string) error {
+	client, err := form.app.NewSmsClient()
+	if err != nil {
+		return err
+	}
+
+	settings := form.app.Settings()
+
+	return client.Send(&sms.Message{
+		From: settings.Sms.From,
+		To:   phone,
+		Body: settings.Sms.ResolveOtpMessage(settings.Meta.AppName, password),
+	})
+}
@@ -0,0 +1,271 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// enableTestOTPAuth adds a "phone" field to the "users" collection,
+// enables the OTP auth for it and assigns a phone number to
+// the test@example.com user.
+func enableTestOTPAuth(t *testing.T, app *tests.TestApp) *models.Collection {
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "phone",
+		Type: schema.FieldTypeText,
+	})
+
+	options := collection.AuthOptions()
+	options.AllowOTPAuth = true
+	options.OTPPhoneField = "phone"
+	collection.SetOptions(options)
+
+	if err := app.Dao().WithoutHooks().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindAuthRecordByEmail(collection.Id, "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("phone", "+359000000000")
+	if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	app.Settings().Sms.Enabled = true
+
+	return collection
+}
+
+func TestRecordOTPRequestSubmit(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	scenarios := []struct {
+		name        string
+		jsonData    string
+		expectError bool
+	}{
+		{"empty phone", `{"phone":""}`, true},
+		{"missing record", `{"phone":"+359111111111"}`, true},
+		{"existing record", `{"phone":"+359000000000"}`, false},
+		{"existing record - reached send threshold", `{"phone":"+359000000000"}`, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp.TestSmsClient.Reset()
+
+			form := forms.NewRecordOTPRequest(testApp, authCollection)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*forms.RecordOTPRequestData]) forms.InterceptorNextFunc[*forms.RecordOTPRequestData] {
+				return func(data *forms.RecordOTPRequestData) error {
+					interceptorCalls++
+					return next(data)
+				}
+			}
+
+			otp, err := form.Submit(interceptor)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr to be %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			expectInterceptorCalls := 1
+			if s.expectError {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Fatalf("Expected interceptor to be called %d, got %d", expectInterceptorCalls, interceptorCalls)
+			}
+
+			if s.expectError {
+				if total := testApp.TestSmsClient.TotalSend(); total != 0 {
+					t.Fatalf("Expected no sent messages, got %d", total)
+				}
+				return
+			}
+
+			if total := testApp.TestSmsClient.TotalSend(); total != 1 {
+				t.Fatalf("Expected 1 sent message, got %d", total)
+			}
+
+			msg := testApp.TestSmsClient.LastMessage()
+			if msg.To != "+359000000000" {
+				t.Fatalf("Expected the message to be sent to +359000000000, got %q", msg.To)
+			}
+
+			stored, err := testApp.Dao().FindOTPById(otp.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// extract the sent password from the default message template
+			password := strings.TrimSuffix(msg.Body[strings.LastIndex(msg.Body, " ")+1:], ".")
+			if len(password) != 6 || !stored.ValidatePassword(password) {
+				t.Fatalf("Expected the sent password %q to match the stored OTP hash", password)
+			}
+
+			if stored.IsExpired() {
+				t.Fatal("Expected the stored OTP to be non-expired")
+			}
+		})
+	}
+}
+
+func TestRecordOTPRequestSubmitPhoneField(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	// change the OTP field to a phone field
+	field := authCollection.Schema.GetFieldByName("phone")
+	field.Type = schema.FieldTypePhone
+	field.Options = &schema.PhoneOptions{DefaultCountry: "US"}
+	if err := testApp.Dao().WithoutHooks().SaveCollection(authCollection); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := testApp.Dao().FindAuthRecordByEmail(authCollection.Id, "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("phone", "+1 202 555 0143")
+	if err := testApp.Dao().WithoutHooks().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordOTPRequest(testApp, authCollection)
+	form.Phone = "(202) 555-0143" // national format
+
+	otp, err := form.Submit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if otp.RecordId != record.Id {
+		t.Fatalf("Expected the OTP to be for record %q, got %q", record.Id, otp.RecordId)
+	}
+
+	if otp.SentTo != "+12025550143" {
+		t.Fatalf("Expected the OTP to be sent to the normalized number, got %q", otp.SentTo)
+	}
+
+	if msg := testApp.TestSmsClient.LastMessage(); msg.To != "+12025550143" {
+		t.Fatalf("Expected the message to be sent to +12025550143, got %q", msg.To)
+	}
+}
+
+func TestRecordOTPRequestSubmitDisabled(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	// disabled SMS provider
+	testApp.Settings().Sms.Enabled = false
+
+	form := forms.NewRecordOTPRequest(testApp, authCollection)
+	form.Phone = "+359000000000"
+
+	if _, err := form.Submit(); err == nil {
+		t.Fatal("Expected error for disabled SMS provider, got nil")
+	}
+
+	// the OTP should be deleted on failed delivery
+	record, _ := testApp.Dao().FindAuthRecordByEmail(authCollection.Id, "test@example.com")
+	if otp, _ := testApp.Dao().FindLastOTPByRecord(authCollection.Id, record.Id); otp != nil {
+		t.Fatalf("Expected the OTP to be deleted, got %v", otp.Id)
+	}
+
+	// disabled OTP auth
+	testApp.Settings().Sms.Enabled = true
+
+	options := authCollection.AuthOptions()
+	options.AllowOTPAuth = false
+	authCollection.SetOptions(options)
+
+	if _, err := form.Submit(); err == nil {
+		t.Fatal("Expected error for disabled OTP auth, got nil")
+	}
+}
+
+func TestRecordOTPRequestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	authCollection := enableTestOTPAuth(t, testApp)
+
+	form := forms.NewRecordOTPRequest(testApp, authCollection)
+	form.Phone = "+359000000000"
+
+	var interceptorPassword string
+	testErr := errors.New("test_error")
+
+	interceptor1Called := false
+	interceptor1 := func(next forms.InterceptorNextFunc[*forms.RecordOTPRequestData]) forms.InterceptorNextFunc[*forms.RecordOTPRequestData] {
+		return func(data *forms.RecordOTPRequestData) error {
+			interceptor1Called = true
+			interceptorPassword = data.Password
+			return next(data)
+		}
+	}
+
+	interceptor2Called := false
+	interceptor2 := func(next forms.InterceptorNextFunc[*forms.RecordOTPRequestData]) forms.InterceptorNextFunc[*forms.RecordOTPRequestData] {
+		return func(data *forms.RecordOTPRequestData) error {
+			interceptor2Called = true
+			return testErr
+		}
+	}
+
+	_, submitErr := form.Submit(interceptor1, interceptor2)
+	if submitErr != testErr {
+		t.Fatalf("Expected submitError %v, got %v", testErr, submitErr)
+	}
+
+	if !interceptor1Called {
+		t.Fatalf("Expected interceptor1 to be called")
+	}
+
+	if !interceptor2Called {
+		t.Fatalf("Expected interceptor2 to be called")
+	}
+
+	if len(interceptorPassword) != 6 {
+		t.Fatalf("Expected 6 digits password, got %q", interceptorPassword)
+	}
+
+	if total := testApp.TestSmsClient.TotalSend(); total != 0 {
+		t.Fatalf("Expected no sent messages, got %d", total)
+	}
+}
@@ -0,0 +1,101 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+// RecordPushDeviceRegister is an auth record push notifications
+// device token registration form.
+type RecordPushDeviceRegister struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	Provider string `form:"provider" json:"provider"`
+	Token    string `form:"token" json:"token"`
+	Name     string `form:"name" json:"name"`
+}
+
+// NewRecordPushDeviceRegister creates a new [RecordPushDeviceRegister]
+// form initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordPushDeviceRegister(app core.App, record *models.Record) *RecordPushDeviceRegister {
+	return &RecordPushDeviceRegister{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordPushDeviceRegister) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordPushDeviceRegister) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Provider,
+			validation.Required,
+			validation.In(list.ToInterfaceSlice(push.Providers())...),
+			validation.By(form.checkProviderIsEnabled),
+		),
+		validation.Field(&form.Token, validation.Required, validation.Length(1, 4000)),
+		validation.Field(&form.Name, validation.Length(0, 255)),
+	)
+}
+
+func (form *RecordPushDeviceRegister) checkProviderIsEnabled(value any) error {
+	v, _ := value.(string)
+
+	if !form.app.Settings().Push.IsEnabled(v) {
+		return validation.NewError("validation_push_provider_disabled", "The push provider is not enabled.")
+	}
+
+	return nil
+}
+
+// Submit validates and submits the form.
+// On success, registers (or updates) the device token for the form auth record.
+//
+// If the same provider token is already registered for another
+// auth record (eg. on user switch), it is reassigned to the form one.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordPushDeviceRegister) Submit(interceptors ...InterceptorFunc[*models.PushDevice]) (*models.PushDevice, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	device, err := form.dao.FindPushDeviceByToken(form.Provider, form.Token)
+	if err != nil {
+		device = &models.PushDevice{
+			Provider: form.Provider,
+			Token:    form.Token,
+		}
+	}
+
+	device.CollectionId = form.record.Collection().Id
+	device.RecordId = form.record.Id
+	device.Name = form.Name
+
+	interceptorsErr := runInterceptors(device, func(m *models.PushDevice) error {
+		device = m
+		return form.dao.SavePushDevice(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return device, nil
+}
@@ -0,0 +1,118 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+func TestRecordPushDeviceRegisterValidateAndSubmit(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	testApp.Settings().Push.FCM.Enabled = true
+
+	user1, err := testApp.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := testApp.Dao().FindAuthRecordByEmail("users", "test2@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		record         *models.Record
+		jsonData       string
+		expectedErrors []string
+	}{
+		{"empty data", user1, `{}`, []string{"provider", "token"}},
+		{"invalid provider", user1, `{"provider":"invalid","token":"abc"}`, []string{"provider"}},
+		{"disabled provider", user1, `{"provider":"apns","token":"abc"}`, []string{"provider"}},
+		{"valid data", user1, `{"provider":"fcm","token":"abc","name":"Pixel"}`, nil},
+		{"same token for another record", user2, `{"provider":"fcm","token":"abc","name":"Pixel 2"}`, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordPushDeviceRegister(testApp, s.record)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.PushDevice]) forms.InterceptorNextFunc[*models.PushDevice] {
+				return func(m *models.PushDevice) error {
+					interceptorCalls++
+					return next(m)
+				}
+			}
+
+			device, err := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Fatalf("Failed to parse errors %v", err)
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Fatalf("Expected interceptor to be called %d, got %d", expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			stored, err := testApp.Dao().FindPushDeviceByToken(push.ProviderFCM, "abc")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stored.Id != device.Id {
+				t.Fatalf("Expected device %s, got %s", device.Id, stored.Id)
+			}
+
+			if stored.RecordId != s.record.Id || stored.CollectionId != s.record.Collection().Id {
+				t.Fatalf("Expected the device to be registered for record %s, got %s", s.record.Id, stored.RecordId)
+			}
+
+			if stored.Name != form.Name {
+				t.Fatalf("Expected device name %q, got %q", form.Name, stored.Name)
+			}
+		})
+	}
+
+	// the token should be reassigned and not duplicated
+	devices, err := testApp.Dao().FindAllPushDevicesByRecordIds(user1.Id, user2.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 1 {
+		t.Fatalf("Expected 1 registered device, got %d", len(devices))
+	}
+}
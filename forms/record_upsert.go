@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
@@ -33,9 +35,17 @@ type RecordUpsert struct {
 	dao          *daos.Dao
 	manageAccess bool
 	record       *models.Record
+	authRecord   *models.Record
 
 	filesToUpload map[string][]*filesystem.File
 	filesToDelete []string // names list
+	quarantined   []*models.QuarantinedFile
+
+	expectedRevision *int
+
+	// submittedKeys are the names of the explicitly loaded data fields
+	// (used to determine which schema field defaults to apply on create)
+	submittedKeys map[string]struct{}
 
 	// base model fields
 	Id string `json:"id"`
@@ -67,6 +77,7 @@ func NewRecordUpsert(app core.App, record *models.Record) *RecordUpsert {
 		record:        record,
 		filesToDelete: []string{},
 		filesToUpload: map[string][]*filesystem.File{},
+		submittedKeys: map[string]struct{}{},
 	}
 
 	form.loadFormDefaults()
@@ -91,6 +102,19 @@ func (form *RecordUpsert) SetDao(dao *daos.Dao) {
 	form.dao = dao
 }
 
+// SetExpectedRevision enables the optimistic locking check on submit,
+// aka. the record will be persisted only if its stored revision counter
+// matches the provided one (see [daos.Dao.CompareAndSaveRecord]).
+func (form *RecordUpsert) SetExpectedRevision(revision int) {
+	form.expectedRevision = &revision
+}
+
+// SetAuthRecord sets the auth record of the current request
+// that is used by the [schema.FieldGeneratorAuthId] field generator.
+func (form *RecordUpsert) SetAuthRecord(authRecord *models.Record) {
+	form.authRecord = authRecord
+}
+
 func (form *RecordUpsert) loadFormDefaults() {
 	form.Id = form.record.Id
 
@@ -397,6 +421,10 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 	// replace modifiers (if any)
 	requestData = form.record.ReplaceModifers(requestData)
 
+	for key := range requestData {
+		form.submittedKeys[key] = struct{}{}
+	}
+
 	// create a shallow copy of form.data
 	var extendedData = make(map[string]any, len(form.data))
 	for k, v := range form.data {
@@ -413,6 +441,11 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 	}
 
 	for _, field := range form.record.Collection().Schema.Fields() {
+		// the autonumber values are generated on create and can't be changed
+		if field.Type == schema.FieldTypeAutonumber {
+			continue
+		}
+
 		key := field.Name
 		value := field.PrepareValue(extendedData[key])
 
@@ -636,11 +669,75 @@ func (form *RecordUpsert) checkOldPassword(value any) error {
 	return nil
 }
 
+// applyFieldDefaults assigns the schema fields default or generated values
+// to the new record data fields that were not submitted (or preset).
+func (form *RecordUpsert) applyFieldDefaults() error {
+	if !form.record.IsNew() {
+		return nil
+	}
+
+	for _, field := range form.record.Collection().Schema.Fields() {
+		if field.Default == nil && field.Generator == "" {
+			continue
+		}
+
+		if _, ok := form.submittedKeys[field.Name]; ok || !isEmptyFieldValue(form.data[field.Name]) {
+			continue
+		}
+
+		var value any
+
+		switch field.Generator {
+		case schema.FieldGeneratorNow:
+			value = types.NowDateTime()
+		case schema.FieldGeneratorUUID:
+			value = security.RandomUUID()
+		case schema.FieldGeneratorAuthId:
+			if form.authRecord == nil {
+				continue // guest or admin request
+			}
+			value = form.authRecord.Id
+		case schema.FieldGeneratorSequence:
+			next, err := form.dao.NextRecordSequenceValue(form.record.Collection(), field.Name)
+			if err != nil {
+				return err
+			}
+			value = next
+		default:
+			value = field.Default
+		}
+
+		form.data[field.Name] = field.PrepareValue(value)
+	}
+
+	return nil
+}
+
+// isEmptyFieldValue reports whether the provided record field value is empty
+// (zero struct values like [types.DateTime] and [types.GeoPoint] included).
+func isEmptyFieldValue(value any) bool {
+	if validation.IsEmpty(value) {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+
+	return rv.Kind() == reflect.Struct && rv.IsZero()
+}
+
 func (form *RecordUpsert) ValidateAndFill() error {
+	if err := form.applyFieldDefaults(); err != nil {
+		return err
+	}
+
 	if err := form.Validate(); err != nil {
 		return err
 	}
 
+	if err := form.checkLegalHold(); err != nil {
+		return err
+	}
+
 	isNew := form.record.IsNew()
 
 	// custom insertion id can be set only on create
@@ -689,9 +786,106 @@ func (form *RecordUpsert) ValidateAndFill() error {
 	// bulk load the remaining form data
 	form.record.Load(form.data)
 
+	return form.checkUniqueIndexes()
+}
+
+// checkLegalHold checks whether the form tries to delete
+// any of the existing files of a record under legal hold.
+func (form *RecordUpsert) checkLegalHold() error {
+	if form.record.IsNew() || len(form.filesToDelete) == 0 {
+		return nil
+	}
+
+	held, err := form.dao.IsRecordUnderLegalHold(form.record.Collection().Id, form.record.Id)
+	if err != nil || !held {
+		return err
+	}
+
+	errs := validation.Errors{}
+
+	original := form.record.OriginalCopy()
+	for _, field := range form.record.Collection().Schema.Fields() {
+		if field.Type != schema.FieldTypeFile {
+			continue
+		}
+
+		for _, name := range list.ToUniqueStringSlice(original.Get(field.Name)) {
+			if list.ExistInSlice(name, form.filesToDelete) {
+				errs[field.Name] = validation.NewError(
+					"validation_legal_hold",
+					"The record is under legal hold and its files cannot be deleted or replaced.",
+				)
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
 
+// checkUniqueIndexes checks the filled record against the collection
+// unique indexes (including the composite ones) in order to return a
+// validation error naming the conflicting fields instead of the raw db error.
+func (form *RecordUpsert) checkUniqueIndexes() error {
+	conflicts, err := form.dao.FindRecordUniqueIndexConflicts(form.record)
+	if err != nil {
+		return err
+	}
+
+	validationErrs := validation.Errors{}
+
+	for _, idx := range conflicts {
+		fields := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			fields = append(fields, col.Name)
+		}
+
+		for k, v := range form.notUniqueErrors(fields) {
+			validationErrs[k] = v
+		}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
+
+// notUniqueErrors returns a "validation_not_unique" error for each
+// of the provided unique index fields (the error message of the
+// composite indexes lists all index fields).
+func (form *RecordUpsert) notUniqueErrors(fields []string) validation.Errors {
+	// normalize the field names
+	for i, name := range fields {
+		for _, f := range form.record.Collection().Schema.Fields() {
+			if strings.EqualFold(f.Name, name) {
+				fields[i] = f.Name
+				break
+			}
+		}
+	}
+
+	validationErrs := make(validation.Errors, len(fields))
+
+	for _, name := range fields {
+		if len(fields) == 1 {
+			validationErrs[name] = validation.NewError("validation_not_unique", "Value must be unique")
+		} else {
+			validationErrs[name] = validation.NewError(
+				"validation_not_unique",
+				fmt.Sprintf("The combination of %s must be unique.", strings.Join(fields, ", ")),
+			)
+		}
+	}
+
+	return validationErrs
+}
+
 // DrySubmit performs a form submit within a transaction and reverts it.
 // For actual record persistence, check the `form.Submit()` method.
 //
@@ -720,8 +914,14 @@ func (form *RecordUpsert) DrySubmit(callback func(txDao *daos.Dao) error) error
 		}
 		defer tx.Rollback()
 
-		if err := txDao.SaveRecord(form.record); err != nil {
-			return form.prepareError(err)
+		var saveErr error
+		if form.expectedRevision != nil {
+			saveErr = txDao.CompareAndSaveRecord(form.record, *form.expectedRevision)
+		} else {
+			saveErr = txDao.SaveRecord(form.record)
+		}
+		if saveErr != nil {
+			return form.prepareError(saveErr)
 		}
 
 		// restore record isNew state
@@ -800,9 +1000,23 @@ func (form *RecordUpsert) Submit(interceptors ...InterceptorFunc[*models.Record]
 		// ---
 
 		// persist the record model
-		if err := dao.SaveRecord(form.record); err != nil {
-			return form.prepareError(err)
+		var saveErr error
+		if form.expectedRevision != nil {
+			saveErr = dao.CompareAndSaveRecord(form.record, *form.expectedRevision)
+		} else {
+			saveErr = dao.SaveRecord(form.record)
+		}
+		if saveErr != nil {
+			return form.prepareError(saveErr)
+		}
+
+		// flag the record for review (if any of its new files was quarantined)
+		for _, file := range form.quarantined {
+			if err := dao.SaveQuarantinedFile(file); err != nil {
+				return err
+			}
 		}
+		form.quarantined = nil
 
 		// delete old files (if any)
 		//
@@ -834,15 +1048,32 @@ func (form *RecordUpsert) processFilesToUpload() error {
 	}
 	defer fs.Close()
 
-	var uploadErrors []error // list of upload errors
-	var uploaded []string    // list of uploaded file paths
+	fs.SetUploadsEncryption(form.record.Collection().EncryptFiles())
+
+	var uploadErrors []error                  // list of upload errors
+	var uploaded []string                     // list of uploaded file paths
+	var quarantined []*models.QuarantinedFile // list of uploaded quarantined files
 
 	for fieldKey := range form.filesToUpload {
 		for i, file := range form.filesToUpload[fieldKey] {
+			quarantinedFile, err := form.scanFile(fieldKey, file)
+			if err != nil {
+				uploadErrors = append(uploadErrors, fmt.Errorf("file %d: %v", i, err))
+				continue
+			}
+
 			path := form.record.BaseFilesPath() + "/" + file.Name
+			if quarantinedFile != nil {
+				path = quarantinedFile.Path()
+			}
+
 			if err := fs.UploadFile(file, path); err == nil {
 				// keep track of the already uploaded file
-				uploaded = append(uploaded, path)
+				if quarantinedFile != nil {
+					quarantined = append(quarantined, quarantinedFile)
+				} else {
+					uploaded = append(uploaded, path)
+				}
 			} else {
 				// store the upload error
 				uploadErrors = append(uploadErrors, fmt.Errorf("file %d: %v", i, err))
@@ -853,13 +1084,49 @@ func (form *RecordUpsert) processFilesToUpload() error {
 	if len(uploadErrors) > 0 {
 		// cleanup - try to delete the successfully uploaded files (if any)
 		form.deleteFilesByNamesList(uploaded)
+		for _, q := range quarantined {
+			fs.DeletePrefix(q.BaseFilesPath())
+		}
 
 		return fmt.Errorf("failed to upload all files: %v", uploadErrors)
 	}
 
+	form.quarantined = quarantined
+
 	return nil
 }
 
+// scanFile triggers the app OnRecordFileScan hook for the provided new file
+// and returns a new (unsaved) quarantine entry if the file was quarantined.
+func (form *RecordUpsert) scanFile(fieldKey string, file *filesystem.File) (*models.QuarantinedFile, error) {
+	event := new(core.RecordFileScanEvent)
+	event.Collection = form.record.Collection()
+	event.Record = form.record
+	event.FileField = form.record.Collection().Schema.GetFieldByName(fieldKey)
+	event.File = file
+
+	if err := form.app.OnRecordFileScan().Trigger(event); err != nil {
+		return nil, err
+	}
+
+	if !event.Quarantine {
+		return nil, nil
+	}
+
+	quarantined := &models.QuarantinedFile{
+		CollectionId: form.record.Collection().Id,
+		RecordId:     form.record.Id,
+		Field:        fieldKey,
+		Name:         file.Name,
+		OriginalName: file.OriginalName,
+		Size:         file.Size,
+		Reason:       event.Reason,
+	}
+	quarantined.RefreshId()
+
+	return quarantined, nil
+}
+
 func (form *RecordUpsert) processFilesToDelete() (err error) {
 	form.filesToDelete, err = form.deleteFilesByNamesList(form.filesToDelete)
 	return
@@ -919,12 +1186,18 @@ func (form *RecordUpsert) prepareError(err error) error {
 		msg = strings.ReplaceAll(strings.TrimSpace(msg), ",", " ")
 
 		c := form.record.Collection()
+
+		fields := []string{}
 		for _, f := range c.Schema.Fields() {
 			// blank space to unify multi-columns lookup
-			if strings.Contains(msg+" ", strings.ToLower(c.Name+"."+f.Name)) {
-				validationErrs[f.Name] = validation.NewError("validation_not_unique", "Value must be unique")
+			if strings.Contains(msg+" ", strings.ToLower(c.Name+"."+f.Name)+" ") {
+				fields = append(fields, f.Name)
 			}
 		}
+
+		if len(fields) > 0 {
+			validationErrs = form.notUniqueErrors(fields)
+		}
 	}
 
 	if len(validationErrs) > 0 {
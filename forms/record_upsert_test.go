@@ -729,6 +729,173 @@ func TestRecordUpsertWithCustomId(t *testing.T) {
 	}
 }
 
+func TestRecordUpsertFieldDefaults(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "defaults_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText, Required: true, Default: "untitled"},
+			&schema.SchemaField{Name: "code", Type: schema.FieldTypeText, Generator: schema.FieldGeneratorUUID},
+			&schema.SchemaField{Name: "published", Type: schema.FieldTypeDate, Generator: schema.FieldGeneratorNow},
+			&schema.SchemaField{Name: "owner", Type: schema.FieldTypeText, Generator: schema.FieldGeneratorAuthId},
+			&schema.SchemaField{Name: "position", Type: schema.FieldTypeNumber, Generator: schema.FieldGeneratorSequence},
+		),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	authRecord, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// create
+	// ---
+	created := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, created)
+	form.SetAuthRecord(authRecord)
+	if err := form.LoadData(map[string]any{"code": "custom"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Submit(); err != nil {
+		t.Fatalf("Failed to submit the create form: %v", err)
+	}
+
+	if v := created.GetString("title"); v != "untitled" {
+		t.Fatalf("Expected the default title, got %q", v)
+	}
+
+	if v := created.GetString("code"); v != "custom" {
+		t.Fatalf("Expected the submitted code to not be replaced, got %q", v)
+	}
+
+	if created.GetDateTime("published").IsZero() {
+		t.Fatal("Expected the published date to be generated")
+	}
+
+	if v := created.GetString("owner"); v != authRecord.Id {
+		t.Fatalf("Expected owner %q, got %q", authRecord.Id, v)
+	}
+
+	if v := created.GetInt("position"); v != 1 {
+		t.Fatalf("Expected position 1, got %d", v)
+	}
+
+	// create without auth record
+	// ---
+	guestRecord := models.NewRecord(collection)
+	guestForm := forms.NewRecordUpsert(app, guestRecord)
+	if err := guestForm.Submit(); err != nil {
+		t.Fatalf("Failed to submit the guest create form: %v", err)
+	}
+
+	if v := guestRecord.GetString("owner"); v != "" {
+		t.Fatalf("Expected empty owner, got %q", v)
+	}
+
+	if v := guestRecord.GetInt("position"); v != 2 {
+		t.Fatalf("Expected position 2, got %d", v)
+	}
+
+	if v := guestRecord.GetString("code"); len(v) != 36 {
+		t.Fatalf("Expected generated uuid code, got %q", v)
+	}
+
+	// explicitly submitted empty value
+	// ---
+	emptyForm := forms.NewRecordUpsert(app, models.NewRecord(collection))
+	if err := emptyForm.LoadData(map[string]any{"title": ""}); err != nil {
+		t.Fatal(err)
+	}
+	if err := emptyForm.Submit(); err == nil {
+		t.Fatal("Expected the required title validation error")
+	}
+
+	// update
+	// ---
+	updateForm := forms.NewRecordUpsert(app, created)
+	updateForm.SetAuthRecord(authRecord)
+	if err := updateForm.LoadData(map[string]any{"title": "new", "owner": "", "position": 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateForm.Submit(); err != nil {
+		t.Fatalf("Failed to submit the update form: %v", err)
+	}
+
+	if v := created.GetString("owner"); v != "" {
+		t.Fatalf("Expected the generators to not be applied on update, got owner %q", v)
+	}
+
+	if v := created.GetInt("position"); v != 0 {
+		t.Fatalf("Expected the generators to not be applied on update, got position %d", v)
+	}
+}
+
+func TestRecordUpsertAutonumber(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "autonumber_test",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{
+				Name:    "number",
+				Type:    schema.FieldTypeAutonumber,
+				Options: &schema.AutonumberOptions{Prefix: "INV-", Padding: 3},
+			},
+		),
+	}
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create (the submitted value is ignored)
+	// ---
+	record := models.NewRecord(collection)
+	form := forms.NewRecordUpsert(app, record)
+	if err := form.LoadData(map[string]any{"title": "a", "number": "custom"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Submit(); err != nil {
+		t.Fatalf("Failed to submit the create form: %v", err)
+	}
+
+	if v := record.GetString("number"); v != "INV-001" {
+		t.Fatalf("Expected number INV-001, got %q", v)
+	}
+
+	// update (the value can't be changed)
+	// ---
+	updateForm := forms.NewRecordUpsert(app, record)
+	if err := updateForm.LoadData(map[string]any{"title": "b", "number": "INV-999"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateForm.Submit(); err != nil {
+		t.Fatalf("Failed to submit the update form: %v", err)
+	}
+
+	if v := record.GetString("number"); v != "INV-001" {
+		t.Fatalf("Expected number INV-001 after update, got %q", v)
+	}
+
+	// second create
+	// ---
+	record2 := models.NewRecord(collection)
+	if err := forms.NewRecordUpsert(app, record2).Submit(); err != nil {
+		t.Fatalf("Failed to submit the second create form: %v", err)
+	}
+
+	if v := record2.GetString("number"); v != "INV-002" {
+		t.Fatalf("Expected number INV-002, got %q", v)
+	}
+}
+
 func TestRecordUpsertAuthRecord(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -1004,11 +1171,13 @@ func TestRecordUpsertUniqueValidator(t *testing.T) {
 
 	scenarios := []struct {
 		name           string
+		record         *models.Record
 		data           map[string]any
 		expectedErrors []string
 	}{
 		{
 			"duplicated unique value",
+			nil,
 			map[string]any{
 				"fieldA": "a",
 			},
@@ -1016,6 +1185,7 @@ func TestRecordUpsertUniqueValidator(t *testing.T) {
 		},
 		{
 			"duplicated combined unique value",
+			nil,
 			map[string]any{
 				"fieldB": "b",
 				"fieldC": "c",
@@ -1024,6 +1194,7 @@ func TestRecordUpsertUniqueValidator(t *testing.T) {
 		},
 		{
 			"non-duplicated unique value",
+			nil,
 			map[string]any{
 				"fieldA": "a2",
 			},
@@ -1031,16 +1202,30 @@ func TestRecordUpsertUniqueValidator(t *testing.T) {
 		},
 		{
 			"non-duplicated combined unique value",
+			nil,
 			map[string]any{
 				"fieldB": "b",
 				"fieldC": "d",
 			},
 			nil,
 		},
+		{
+			"unchanged unique values of the same record",
+			dummyRecord,
+			map[string]any{
+				"fieldA": "a",
+				"fieldB": "b",
+				"fieldC": "c",
+			},
+			nil,
+		},
 	}
 
 	for _, s := range scenarios {
-		record := models.NewRecord(collection)
+		record := s.record
+		if record == nil {
+			record = models.NewRecord(collection)
+		}
 
 		form := forms.NewRecordUpsert(app, record)
 		if err := form.LoadData(s.data); err != nil {
@@ -1225,3 +1410,104 @@ func TestRecordUpsertUploadFailure(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordUpsertFileScan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("scan error", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		app.OnRecordFileScan("demo3").Add(func(e *core.RecordFileScanEvent) error {
+			return errors.New("infected")
+		})
+
+		record, err := app.Dao().FindRecordById("demo3", "1tmknxy2868d869")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		file, _ := filesystem.NewFileFromBytes([]byte("test"), "test.txt")
+
+		form := forms.NewRecordUpsert(app, record)
+		form.AddFiles("files", file)
+
+		if err := form.Submit(); err == nil {
+			t.Fatal("Expected the scan error to reject the upload")
+		}
+
+		if app.Dao().IsRecordQuarantined(record.Collection().Id, record.Id) {
+			t.Fatal("Expected the record to not be quarantined")
+		}
+	})
+
+	t.Run("quarantine", func(t *testing.T) {
+		app, _ := tests.NewTestApp()
+		defer app.Cleanup()
+
+		var scanned []string
+		app.OnRecordFileScan("demo3").Add(func(e *core.RecordFileScanEvent) error {
+			scanned = append(scanned, e.File.OriginalName)
+			if e.FileField == nil || e.FileField.Name != "files" {
+				t.Fatalf("Expected files field, got %v", e.FileField)
+			}
+			if e.File.OriginalName == "infected.txt" {
+				e.Quarantine = true
+				e.Reason = "test_signature"
+			}
+			return nil
+		})
+
+		record, err := app.Dao().FindRecordById("demo3", "1tmknxy2868d869")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clean, _ := filesystem.NewFileFromBytes([]byte("clean"), "clean.txt")
+		infected, _ := filesystem.NewFileFromBytes([]byte("infected"), "infected.txt")
+
+		form := forms.NewRecordUpsert(app, record)
+		form.AddFiles("files", clean, infected)
+
+		if err := form.Submit(); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(scanned) != 2 {
+			t.Fatalf("Expected 2 scanned files, got %v", scanned)
+		}
+
+		if !list.ExistInSlice(clean.Name, record.GetStringSlice("files")) ||
+			!list.ExistInSlice(infected.Name, record.GetStringSlice("files")) {
+			t.Fatalf("Expected both files to be referenced by the record, got %v", record.GetStringSlice("files"))
+		}
+
+		quarantined, err := app.Dao().FindQuarantinedFilesByRecord(record.Collection().Id, record.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(quarantined) != 1 {
+			t.Fatalf("Expected 1 quarantined file, got %d", len(quarantined))
+		}
+		if quarantined[0].Name != infected.Name || quarantined[0].Field != "files" || quarantined[0].Reason != "test_signature" {
+			t.Fatalf("Unexpected quarantined file %v", quarantined[0])
+		}
+
+		fs, err := app.NewFilesystem()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer fs.Close()
+
+		existChecks := map[string]bool{
+			record.BaseFilesPath() + "/" + clean.Name:    true,
+			record.BaseFilesPath() + "/" + infected.Name: false,
+			quarantined[0].Path():                        true,
+		}
+		for path, expected := range existChecks {
+			if exists, _ := fs.Exists(path); exists != expected {
+				t.Fatalf("Expected %q exists to be %v", path, expected)
+			}
+		}
+	})
+}
@@ -0,0 +1,325 @@
+package forms
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// Supported records import and export formats.
+const (
+	RecordsFormatCSV    = "csv"
+	RecordsFormatNDJSON = "ndjson"
+)
+
+// maxNDJSONLineSize is the max allowed size of a single NDJSON import line.
+const maxNDJSONLineSize = 10 << 20
+
+// errRecordsImportDryRun is used to rollback the dry-run import transaction.
+var errRecordsImportDryRun = errors.New("records import dry-run")
+
+// RecordsImportRowError defines a single failed records import row.
+type RecordsImportRowError struct {
+	// Row is the 1-based position of the failed row in the imported file
+	// (excluding the CSV header and the empty NDJSON lines).
+	Row int `json:"row"`
+
+	Error string `json:"error"`
+}
+
+// RecordsImportResult defines the records import report.
+type RecordsImportResult struct {
+	Total    int                      `json:"total"`
+	Imported int                      `json:"imported"`
+	Failed   int                      `json:"failed"`
+	DryRun   bool                     `json:"dryRun"`
+	Errors   []*RecordsImportRowError `json:"errors"`
+}
+
+// RecordsImport is a form model to bulk create collection
+// records from a CSV or NDJSON file.
+type RecordsImport struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	// Format is the import file format (csv or ndjson).
+	//
+	// If not set, it is resolved from the file extension.
+	Format string `form:"format" json:"format"`
+
+	File *filesystem.File `form:"file" json:"file"`
+
+	// Mapping is an optional "column => field" map.
+	//
+	// If set, only the mapped columns are imported,
+	// otherwise the columns are matched by the field names.
+	Mapping map[string]string `form:"mapping" json:"mapping"`
+
+	// DryRun validates and reports the import rows
+	// without persisting them.
+	DryRun bool `form:"dryRun" json:"dryRun"`
+}
+
+// NewRecordsImport creates a new [RecordsImport] form
+// initialized with the provided [core.App] and [models.Collection] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordsImport(app core.App, collection *models.Collection) *RecordsImport {
+	return &RecordsImport{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordsImport) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordsImport) Validate() error {
+	if form.Format == "" && form.File != nil {
+		switch strings.ToLower(filepath.Ext(form.File.OriginalName)) {
+		case ".csv":
+			form.Format = RecordsFormatCSV
+		case ".ndjson", ".jsonl":
+			form.Format = RecordsFormatNDJSON
+		}
+	}
+
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Format,
+			validation.Required,
+			validation.In(RecordsFormatCSV, RecordsFormatNDJSON),
+		),
+		validation.Field(&form.File, validation.Required),
+		validation.Field(&form.Mapping, validation.By(form.checkMapping)),
+	)
+}
+
+func (form *RecordsImport) checkMapping(value any) error {
+	v, _ := value.(map[string]string)
+
+	if form.collection.IsView() {
+		return validation.NewError("validation_view_collection", "View collection records cannot be imported.")
+	}
+
+	allowed := form.importableFields()
+
+	for column, field := range v {
+		if !list.ExistInSlice(field, allowed) {
+			return validation.NewError(
+				"validation_invalid_mapping_field",
+				fmt.Sprintf("Invalid or non-importable field %q for column %q.", field, column),
+			)
+		}
+	}
+
+	return nil
+}
+
+// importableFields returns the names of the collection fields
+// that could be populated with the import.
+func (form *RecordsImport) importableFields() []string {
+	result := []string{schema.FieldNameId}
+
+	if form.collection.IsAuth() {
+		result = append(
+			result,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+			"password",
+			"passwordConfirm",
+		)
+	}
+
+	for _, field := range form.collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeFile {
+			continue // files are not supported
+		}
+		result = append(result, field.Name)
+	}
+
+	return result
+}
+
+// Submit validates the form and creates a new record for each import row.
+//
+// All records are created in a single transaction but failing rows
+// don't interrupt the import and are reported in the result errors.
+// In dry-run mode the transaction is always rollbacked.
+func (form *RecordsImport) Submit() (*RecordsImportResult, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	rows, err := form.readRows()
+	if err != nil {
+		return nil, validation.Errors{"file": validation.NewError(
+			"validation_invalid_import_file",
+			fmt.Sprintf("Failed to read the import file: %v", err),
+		)}
+	}
+
+	result := &RecordsImportResult{
+		Total:  len(rows),
+		DryRun: form.DryRun,
+		Errors: []*RecordsImportRowError{},
+	}
+
+	allowed := form.importableFields()
+
+	txErr := form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+		for i, row := range rows {
+			data := make(map[string]any, len(row))
+			for column, value := range row {
+				field := column
+				if len(form.Mapping) > 0 {
+					field = form.Mapping[column]
+				}
+				if field != "" && list.ExistInSlice(field, allowed) {
+					data[field] = value
+				}
+			}
+
+			// for convenience allow specifying only the auth record password
+			if _, ok := data["passwordConfirm"]; !ok && form.collection.IsAuth() {
+				if password, ok := data["password"]; ok {
+					data["passwordConfirm"] = password
+				}
+			}
+
+			upsert := NewRecordUpsert(form.app, models.NewRecord(form.collection))
+			upsert.SetFullManageAccess(true)
+			upsert.SetDao(txDao)
+
+			err := upsert.LoadData(data)
+			if err == nil {
+				err = upsert.Submit()
+			}
+			if err != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, &RecordsImportRowError{
+					Row:   i + 1,
+					Error: err.Error(),
+				})
+				continue
+			}
+
+			result.Imported++
+		}
+
+		if form.DryRun {
+			return errRecordsImportDryRun
+		}
+
+		return nil
+	})
+	if txErr != nil && !errors.Is(txErr, errRecordsImportDryRun) {
+		return nil, txErr
+	}
+
+	return result, nil
+}
+
+// readRows reads and parses all form file rows.
+func (form *RecordsImport) readRows() ([]map[string]any, error) {
+	f, err := form.File.Reader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if form.Format == RecordsFormatCSV {
+		return readCSVRows(f)
+	}
+
+	return readNDJSONRows(f)
+}
+
+func readCSVRows(r io.Reader) ([]map[string]any, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	// strip the UTF-8 BOM (if any)
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\uFEFF")
+	}
+
+	result := []map[string]any{}
+
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]any, len(header))
+		for i, column := range header {
+			if i < len(values) {
+				row[column] = values[i]
+			}
+		}
+
+		result = append(result, row)
+	}
+
+	return result, nil
+}
+
+func readNDJSONRows(r io.Reader) ([]map[string]any, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	result := []map[string]any{}
+
+	var line int
+	for scanner.Scan() {
+		line++
+
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+
+		row := map[string]any{}
+		if err := json.Unmarshal([]byte(raw), &row); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		result = append(result, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
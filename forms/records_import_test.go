@@ -0,0 +1,272 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+func TestRecordsImportValidate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	view1, err := app.Dao().FindCollectionByNameOrId("view1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	csvFile, _ := filesystem.NewFileFromBytes([]byte("title\ntest"), "data.csv")
+	txtFile, _ := filesystem.NewFileFromBytes([]byte("title\ntest"), "data.txt")
+
+	scenarios := []struct {
+		name           string
+		form           func() *forms.RecordsImport
+		expectedErrors []string
+	}{
+		{
+			"empty form",
+			func() *forms.RecordsImport {
+				return forms.NewRecordsImport(app, demo2)
+			},
+			[]string{"format", "file"},
+		},
+		{
+			"unknown format (no explicit format)",
+			func() *forms.RecordsImport {
+				form := forms.NewRecordsImport(app, demo2)
+				form.File = txtFile
+				return form
+			},
+			[]string{"format"},
+		},
+		{
+			"invalid explicit format",
+			func() *forms.RecordsImport {
+				form := forms.NewRecordsImport(app, demo2)
+				form.File = csvFile
+				form.Format = "xml"
+				return form
+			},
+			[]string{"format"},
+		},
+		{
+			"invalid mapping field",
+			func() *forms.RecordsImport {
+				form := forms.NewRecordsImport(app, demo2)
+				form.File = csvFile
+				form.Mapping = map[string]string{"a": "missing"}
+				return form
+			},
+			[]string{"mapping"},
+		},
+		{
+			"view collection",
+			func() *forms.RecordsImport {
+				form := forms.NewRecordsImport(app, view1)
+				form.File = csvFile
+				return form
+			},
+			[]string{"mapping"},
+		},
+		{
+			"valid data (format from the file extension)",
+			func() *forms.RecordsImport {
+				form := forms.NewRecordsImport(app, demo2)
+				form.File = csvFile
+				form.Mapping = map[string]string{"a": "title", "b": "id"}
+				return form
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.form().Validate()
+
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Fatalf("Failed to parse errors %v", result)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordsImportSubmit(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name             string
+		collection       string
+		content          string
+		filename         string
+		mapping          map[string]string
+		dryRun           bool
+		expectError      bool
+		expectedImported int
+		expectedFailed   []int
+		expectedTitles   []string
+	}{
+		{
+			name:        "invalid csv",
+			collection:  "demo2",
+			content:     `title` + "\n" + `"test`,
+			filename:    "data.csv",
+			expectError: true,
+		},
+		{
+			name:        "invalid ndjson",
+			collection:  "demo2",
+			content:     `{"title":"test_a"}` + "\n" + `{invalid`,
+			filename:    "data.ndjson",
+			expectError: true,
+		},
+		{
+			name:             "csv with row errors",
+			collection:       "demo2",
+			content:          "\uFEFFtitle,active,unknown\ntest_a,true,1\na,false,2\ntest1,true,3\ntest_b,false,4",
+			filename:         "data.csv",
+			expectedImported: 2,
+			expectedFailed:   []int{2, 3}, // too short and duplicated title
+			expectedTitles:   []string{"test_a", "test_b"},
+		},
+		{
+			name:             "csv with mapping",
+			collection:       "demo2",
+			content:          "name,title\ntest_a,ignored",
+			filename:         "data.csv",
+			mapping:          map[string]string{"name": "title"},
+			expectedImported: 1,
+			expectedTitles:   []string{"test_a"},
+		},
+		{
+			name:             "ndjson",
+			collection:       "demo2",
+			content:          `{"title":"test_a","active":true}` + "\n\n" + `{"title":"test_b"}` + "\n",
+			filename:         "data.ndjson",
+			expectedImported: 2,
+			expectedTitles:   []string{"test_a", "test_b"},
+		},
+		{
+			name:             "dry-run",
+			collection:       "demo2",
+			content:          "title\ntest_a\na",
+			filename:         "data.csv",
+			dryRun:           true,
+			expectedImported: 1,
+			expectedFailed:   []int{2},
+		},
+		{
+			name:             "auth collection (password confirm fallback)",
+			collection:       "users",
+			content:          "email,password,verified\nimport@example.com,1234567890,true\ninvalid,1234567890,true",
+			filename:         "data.csv",
+			expectedImported: 1,
+			expectedFailed:   []int{2},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			totalBefore := countCollectionRecords(t, app, collection.Name)
+
+			file, _ := filesystem.NewFileFromBytes([]byte(s.content), s.filename)
+
+			form := forms.NewRecordsImport(app, collection)
+			form.File = file
+			form.Mapping = s.mapping
+			form.DryRun = s.dryRun
+
+			result, err := form.Submit()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if result.Imported != s.expectedImported {
+				t.Fatalf("Expected %d imported rows, got %d (%v)", s.expectedImported, result.Imported, result.Errors)
+			}
+
+			if result.Failed != len(s.expectedFailed) || len(result.Errors) != len(s.expectedFailed) {
+				t.Fatalf("Expected failed rows %v, got %d (%v)", s.expectedFailed, result.Failed, result.Errors)
+			}
+
+			for i, row := range s.expectedFailed {
+				if result.Errors[i].Row != row || result.Errors[i].Error == "" {
+					t.Fatalf("Expected row %d error, got %v", row, result.Errors[i])
+				}
+			}
+
+			if result.DryRun != s.dryRun {
+				t.Fatalf("Expected dryRun %v, got %v", s.dryRun, result.DryRun)
+			}
+
+			expectedTotal := totalBefore + s.expectedImported
+			if s.dryRun {
+				expectedTotal = totalBefore
+			}
+			if total := countCollectionRecords(t, app, collection.Name); total != expectedTotal {
+				t.Fatalf("Expected %d total records, got %d", expectedTotal, total)
+			}
+
+			for _, title := range s.expectedTitles {
+				if _, err := app.Dao().FindFirstRecordByData(collection.Name, "title", title); err != nil {
+					t.Fatalf("Expected record with title %q to be imported", title)
+				}
+			}
+
+			if collection.IsAuth() && !s.dryRun {
+				record, err := app.Dao().FindAuthRecordByEmail(collection.Name, "import@example.com")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !record.Verified() || !record.ValidatePassword("1234567890") {
+					t.Fatalf("Expected verified record with the imported password")
+				}
+			}
+		})
+	}
+}
+
+func countCollectionRecords(t *testing.T, app *tests.TestApp, collection string) int {
+	var total int
+
+	err := app.Dao().DB().Select("count(*)").From(collection).Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
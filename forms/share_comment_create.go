@@ -0,0 +1,80 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// ShareCommentCreate is a shared record comment create form.
+type ShareCommentCreate struct {
+	app       core.App
+	dao       *daos.Dao
+	shareLink *models.ShareLink
+
+	Author  string `form:"author" json:"author"`
+	Message string `form:"message" json:"message"`
+}
+
+// NewShareCommentCreate creates a new [ShareCommentCreate] form
+// initialized with from the provided [core.App] and [models.ShareLink] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewShareCommentCreate(app core.App, shareLink *models.ShareLink) *ShareCommentCreate {
+	return &ShareCommentCreate{
+		app:       app,
+		dao:       app.Dao(),
+		shareLink: shareLink,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *ShareCommentCreate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *ShareCommentCreate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Author, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.Message, validation.Required, validation.Length(1, 5000)),
+	)
+}
+
+// Submit validates and submits the form.
+// On success, creates a new comment for the form share link record.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *ShareCommentCreate) Submit(interceptors ...InterceptorFunc[*models.ShareComment]) (*models.ShareComment, error) {
+	if !form.shareLink.AllowComments {
+		return nil, errors.New("The share link doesn't allow comments.")
+	}
+
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	comment := &models.ShareComment{
+		ShareLinkId:  form.shareLink.Id,
+		CollectionId: form.shareLink.CollectionId,
+		RecordId:     form.shareLink.RecordId,
+		Author:       form.Author,
+		Message:      form.Message,
+	}
+
+	interceptorsErr := runInterceptors(comment, func(m *models.ShareComment) error {
+		comment = m
+		return form.dao.SaveShareComment(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return comment, nil
+}
@@ -0,0 +1,85 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestShareCommentCreateValidateAndSubmit(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	link := &models.ShareLink{
+		CollectionId:  "test_collection",
+		RecordId:      "test_record",
+		Hash:          "test",
+		AllowComments: true,
+	}
+	if err := testApp.Dao().SaveShareLink(link); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{"empty data", `{}`, []string{"author", "message"}},
+		{"valid data", `{"author":"John","message":"Looks good"}`, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewShareCommentCreate(testApp, link)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			comment, err := form.Submit()
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Fatalf("Failed to parse errors %v", err)
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			if comment.ShareLinkId != link.Id || comment.CollectionId != link.CollectionId || comment.RecordId != link.RecordId {
+				t.Fatalf("Expected the comment to be linked to the share link record, got %v", comment)
+			}
+		})
+	}
+
+	t.Run("share link without comments", func(t *testing.T) {
+		link.AllowComments = false
+
+		form := forms.NewShareCommentCreate(testApp, link)
+		form.Author = "John"
+		form.Message = "test"
+
+		if _, err := form.Submit(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package forms
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// shareTokenLength is the length of the plain share link token.
+const shareTokenLength = 50
+
+// ShareLinkCreate is a record share link create form.
+type ShareLinkCreate struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	Label         string         `form:"label" json:"label"`
+	AllowComments bool           `form:"allowComments" json:"allowComments"`
+	Expires       types.DateTime `form:"expires" json:"expires"`
+	MaxUses       int            `form:"maxUses" json:"maxUses"`
+}
+
+// NewShareLinkCreate creates a new [ShareLinkCreate] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewShareLinkCreate(app core.App, record *models.Record) *ShareLinkCreate {
+	return &ShareLinkCreate{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *ShareLinkCreate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *ShareLinkCreate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Label, validation.Length(0, 255)),
+		validation.Field(&form.Expires, validation.By(form.checkExpires)),
+		validation.Field(&form.MaxUses, validation.Min(0)),
+	)
+}
+
+func (form *ShareLinkCreate) checkExpires(value any) error {
+	v, _ := value.(types.DateTime)
+
+	if !v.IsZero() && v.Time().Before(time.Now()) {
+		return validation.NewError("validation_share_link_expired", "The expiration date must be in the future.")
+	}
+
+	return nil
+}
+
+// Submit validates and submits the form.
+// On success, creates a new share link for the form record and
+// returns it together with its plain share token.
+//
+// Only the share token hash is persisted, so the returned
+// plain token cannot be retrieved later.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *ShareLinkCreate) Submit(interceptors ...InterceptorFunc[*models.ShareLink]) (*models.ShareLink, string, error) {
+	if err := form.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	token := security.RandomString(shareTokenLength)
+
+	link := &models.ShareLink{
+		CollectionId:  form.record.Collection().Id,
+		RecordId:      form.record.Id,
+		Hash:          security.SHA256(token),
+		Label:         form.Label,
+		AllowComments: form.AllowComments,
+		Expires:       form.Expires,
+		MaxUses:       form.MaxUses,
+	}
+
+	interceptorsErr := runInterceptors(link, func(m *models.ShareLink) error {
+		link = m
+		return form.dao.SaveShareLink(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, "", interceptorsErr
+	}
+
+	return link, token, nil
+}
@@ -0,0 +1,106 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestShareLinkCreateValidateAndSubmit(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	record, err := testApp.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{"empty data", `{}`, nil},
+		{"invalid data", `{"label":"` + security.RandomString(256) + `","expires":"2000-01-01 00:00:00.000Z","maxUses":-1}`, []string{"label", "expires", "maxUses"}},
+		{"valid data", `{"label":"test","allowComments":true,"expires":"2100-01-01 00:00:00.000Z","maxUses":3}`, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewShareLinkCreate(testApp, record)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.ShareLink]) forms.InterceptorNextFunc[*models.ShareLink] {
+				return func(m *models.ShareLink) error {
+					interceptorCalls++
+					return next(m)
+				}
+			}
+
+			link, token, err := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Fatalf("Failed to parse errors %v", err)
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Fatalf("Expected interceptor to be called %d, got %d", expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			if token == "" {
+				t.Fatal("Expected non-empty share token")
+			}
+
+			stored, err := testApp.Dao().FindShareLinkByHash(security.SHA256(token))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stored.Id != link.Id {
+				t.Fatalf("Expected share link %s, got %s", link.Id, stored.Id)
+			}
+
+			if stored.RecordId != record.Id || stored.CollectionId != record.Collection().Id {
+				t.Fatalf("Expected the share link to be created for record %s, got %s", record.Id, stored.RecordId)
+			}
+
+			if stored.Label != form.Label ||
+				stored.AllowComments != form.AllowComments ||
+				stored.MaxUses != form.MaxUses ||
+				stored.Expires.String() != form.Expires.String() {
+				t.Fatalf("The stored share link doesn't match with the form data: %v", stored)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package forms
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// signatureTokenLength is the length of the plain signing token.
+const signatureTokenLength = 50
+
+// SignatureRequestCreate is a record file signature request create form.
+type SignatureRequestCreate struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	// Field is the name of the record file field with the document to sign.
+	Field string `form:"field" json:"field"`
+
+	// File is the name of the PDF document to sign
+	// (default to the first field file).
+	File string `form:"file" json:"file"`
+
+	SignerName  string         `form:"signerName" json:"signerName"`
+	SignerEmail string         `form:"signerEmail" json:"signerEmail"`
+	Expires     types.DateTime `form:"expires" json:"expires"`
+}
+
+// NewSignatureRequestCreate creates a new [SignatureRequestCreate] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewSignatureRequestCreate(app core.App, record *models.Record) *SignatureRequestCreate {
+	return &SignatureRequestCreate{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *SignatureRequestCreate) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *SignatureRequestCreate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Field, validation.Required, validation.By(form.checkField)),
+		validation.Field(&form.File, validation.By(form.checkFile)),
+		validation.Field(&form.SignerName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.SignerEmail, validation.Length(0, 255), is.EmailFormat),
+		validation.Field(&form.Expires, validation.By(form.checkExpires)),
+	)
+}
+
+func (form *SignatureRequestCreate) checkField(value any) error {
+	v, _ := value.(string)
+
+	field := form.record.Collection().Schema.GetFieldByName(v)
+	if field == nil || field.Type != schema.FieldTypeFile {
+		return validation.NewError("validation_invalid_file_field", "Must be a file field of the record collection.")
+	}
+
+	return nil
+}
+
+func (form *SignatureRequestCreate) checkFile(value any) error {
+	if form.Field == "" {
+		return nil // nothing to check
+	}
+
+	file := form.documentFile()
+
+	if file == "" || !list.ExistInSlice(file, form.record.GetStringSlice(form.Field)) {
+		return validation.NewError("validation_missing_file", "Missing record file.")
+	}
+
+	if !strings.EqualFold(filepath.Ext(file), ".pdf") {
+		return validation.NewError("validation_invalid_file_type", "Only PDF documents could be signed.")
+	}
+
+	return nil
+}
+
+func (form *SignatureRequestCreate) checkExpires(value any) error {
+	v, _ := value.(types.DateTime)
+
+	if !v.IsZero() && v.Time().Before(time.Now()) {
+		return validation.NewError("validation_signature_request_expired", "The expiration date must be in the future.")
+	}
+
+	return nil
+}
+
+// documentFile returns the name of the document to sign.
+func (form *SignatureRequestCreate) documentFile() string {
+	if form.File != "" {
+		return form.File
+	}
+
+	files := form.record.GetStringSlice(form.Field)
+	if len(files) > 0 {
+		return files[0]
+	}
+
+	return ""
+}
+
+// Submit validates and submits the form.
+// On success, creates a new signature request for the form record
+// document and returns it together with its plain signing token.
+//
+// Only the signing token hash is persisted, so the returned
+// plain token cannot be retrieved later.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *SignatureRequestCreate) Submit(interceptors ...InterceptorFunc[*models.SignatureRequest]) (*models.SignatureRequest, string, error) {
+	if err := form.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	file := form.documentFile()
+
+	doc, err := readRecordFile(form.app, form.record, file)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := security.RandomString(signatureTokenLength)
+
+	request := &models.SignatureRequest{
+		CollectionId: form.record.Collection().Id,
+		RecordId:     form.record.Id,
+		Field:        form.Field,
+		File:         file,
+		DocumentHash: security.SHA256(string(doc)),
+		Hash:         security.SHA256(token),
+		SignerName:   form.SignerName,
+		SignerEmail:  form.SignerEmail,
+		Expires:      form.Expires,
+		Status:       models.SignatureRequestStatusPending,
+	}
+
+	interceptorsErr := runInterceptors(request, func(m *models.SignatureRequest) error {
+		request = m
+		return form.dao.SaveSignatureRequest(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, "", interceptorsErr
+	}
+
+	return request, token, nil
+}
+
+// readRecordFile reads the entire content of the specified record file.
+func readRecordFile(app core.App, record *models.Record, filename string) ([]byte, error) {
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		return nil, err
+	}
+	defer fsys.Close()
+
+	r, err := fsys.OpenFile(record.BaseFilesPath() + "/" + filename)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
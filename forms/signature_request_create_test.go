@@ -0,0 +1,149 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// testSignDocument is a minimal single page PDF document.
+const testSignDocument = "%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n3 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>\nendobj\nxref\n0 4\n0000000000 65535 f \n0000000009 00000 n \n0000000058 00000 n \n0000000115 00000 n \ntrailer\n<< /Size 4 /Root 1 0 R >>\nstartxref\n186\n%%EOF\n"
+
+// mockSignDocument stores testSignDocument as "doc_test.pdf" file
+// of the "84nmscqy84lsi1t" demo1 record.
+func mockSignDocument(t *testing.T, app *tests.TestApp) *models.Record {
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.Close()
+
+	if err := fsys.Upload([]byte(testSignDocument), record.BaseFilesPath()+"/doc_test.pdf"); err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("file_many", append(record.GetStringSlice("file_many"), "doc_test.pdf"))
+	if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	return record
+}
+
+func TestSignatureRequestCreateValidateAndSubmit(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	record := mockSignDocument(t, testApp)
+
+	scenarios := []struct {
+		name           string
+		jsonData       string
+		expectedFile   string
+		expectedErrors []string
+	}{
+		{"empty data", `{}`, "", []string{"field", "signerName"}},
+		{
+			"invalid data",
+			`{"field":"missing","signerName":"` + security.RandomString(256) + `","signerEmail":"invalid","expires":"2000-01-01 00:00:00.000Z"}`,
+			"",
+			[]string{"field", "signerName", "signerEmail", "expires"},
+		},
+		{"missing file", `{"field":"file_many","file":"missing.pdf","signerName":"test"}`, "", []string{"file"}},
+		{"non-pdf default file", `{"field":"file_many","signerName":"test"}`, "", []string{"file"}},
+		{"non-pdf file", `{"field":"file_one","file":"test_d61b33QdDU.txt","signerName":"test"}`, "", []string{"file"}},
+		{
+			"valid data",
+			`{"field":"file_many","file":"doc_test.pdf","signerName":"test","signerEmail":"test@example.com","expires":"2100-01-01 00:00:00.000Z"}`,
+			"doc_test.pdf",
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewSignatureRequestCreate(testApp, record)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.SignatureRequest]) forms.InterceptorNextFunc[*models.SignatureRequest] {
+				return func(m *models.SignatureRequest) error {
+					interceptorCalls++
+					return next(m)
+				}
+			}
+
+			request, token, err := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Fatalf("Failed to parse errors %v", err)
+			}
+
+			// check errors
+			if len(errs) > len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			expectInterceptorCalls := 1
+			if len(s.expectedErrors) > 0 {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Fatalf("Expected interceptor to be called %d, got %d", expectInterceptorCalls, interceptorCalls)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				return
+			}
+
+			if token == "" {
+				t.Fatal("Expected non-empty signing token")
+			}
+
+			stored, err := testApp.Dao().FindSignatureRequestByHash(security.SHA256(token))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stored.Id != request.Id {
+				t.Fatalf("Expected signature request %s, got %s", request.Id, stored.Id)
+			}
+
+			if stored.RecordId != record.Id || stored.CollectionId != record.Collection().Id {
+				t.Fatalf("Expected the signature request to be created for record %s, got %s", record.Id, stored.RecordId)
+			}
+
+			if stored.File != s.expectedFile ||
+				stored.Field != form.Field ||
+				stored.Status != models.SignatureRequestStatusPending ||
+				stored.DocumentHash != security.SHA256(testSignDocument) ||
+				stored.SignerName != form.SignerName ||
+				stored.SignerEmail != form.SignerEmail ||
+				stored.Expires.String() != form.Expires.String() {
+				t.Fatalf("The stored signature request doesn't match with the form data: %v", stored)
+			}
+		})
+	}
+}
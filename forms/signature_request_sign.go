@@ -0,0 +1,235 @@
+package forms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/pdfsign"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// signatureImageMaxSize is the max allowed length of the base64 encoded drawn signature.
+const signatureImageMaxSize = 1 << 20
+
+// SignatureRequestSign is a signature request sign form.
+type SignatureRequestSign struct {
+	app       core.App
+	dao       *daos.Dao
+	request   *models.SignatureRequest
+	ip        string
+	userAgent string
+
+	// Name is the signer typed full name.
+	Name string `form:"name" json:"name"`
+
+	// Signature is an optional drawn signature PNG or JPEG image
+	// (base64 encoded, with or without the "data:image/...;base64," prefix).
+	Signature string `form:"signature" json:"signature"`
+
+	// Agree is the signer consent to sign the document electronically.
+	Agree bool `form:"agree" json:"agree"`
+}
+
+// NewSignatureRequestSign creates a new [SignatureRequestSign] form
+// initialized with from the provided [core.App] and [models.SignatureRequest] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewSignatureRequestSign(app core.App, request *models.SignatureRequest) *SignatureRequestSign {
+	return &SignatureRequestSign{
+		app:     app,
+		dao:     app.Dao(),
+		request: request,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *SignatureRequestSign) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// SetClient sets the signer client ip and user agent stored in the signature audit trail.
+func (form *SignatureRequestSign) SetClient(ip string, userAgent string) {
+	form.ip = ip
+	form.userAgent = userAgent
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *SignatureRequestSign) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.Signature, validation.Length(0, signatureImageMaxSize), validation.By(form.checkSignature)),
+		validation.Field(&form.Agree, validation.Required),
+	)
+}
+
+func (form *SignatureRequestSign) checkSignature(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil
+	}
+
+	if _, err := decodeSignatureImage(v); err != nil {
+		return validation.NewError("validation_invalid_signature_image", "Must be a base64 encoded PNG or JPEG image.")
+	}
+
+	return nil
+}
+
+// decodeSignatureImage decodes a base64 encoded image (optionally as data url).
+func decodeSignatureImage(str string) (image.Image, error) {
+	if strings.HasPrefix(str, "data:") {
+		_, data, ok := strings.Cut(str, ",")
+		if !ok {
+			return nil, errors.New("invalid data url")
+		}
+		str = data
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+
+	return img, err
+}
+
+// Submit validates and submits the form.
+//
+// On success, appends the signature and its audit trail (signer, ip,
+// date and document hashes) as new page to the request document,
+// replaces the record file with the signed document and marks
+// the signature request as signed.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *SignatureRequestSign) Submit(interceptors ...InterceptorFunc[*models.SignatureRequest]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !form.request.IsPending() {
+		return nil, errors.New("The signature request is no longer pending.")
+	}
+
+	record, err := form.dao.FindRecordById(form.request.CollectionId, form.request.RecordId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !list.ExistInSlice(form.request.File, record.GetStringSlice(form.request.Field)) {
+		return nil, errors.New("The document to sign is no longer available.")
+	}
+
+	doc, err := readRecordFile(form.app, record, form.request.File)
+	if err != nil {
+		return nil, err
+	}
+
+	if security.SHA256(string(doc)) != form.request.DocumentHash {
+		return nil, errors.New("The document was modified after the signature request creation.")
+	}
+
+	signatureType := models.SignatureTypeTyped
+
+	var signatureImage image.Image
+	if form.Signature != "" {
+		signatureType = models.SignatureTypeDrawn
+
+		signatureImage, err = decodeSignatureImage(form.Signature)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := types.NowDateTime()
+
+	signer := form.request.SignerName
+	if form.request.SignerEmail != "" {
+		signer += " <" + form.request.SignerEmail + ">"
+	}
+
+	signed, err := pdfsign.AppendPage(doc, pdfsign.Page{
+		Title:         "Electronic signature certificate",
+		Signature:     signatureImage,
+		SignatureText: form.Name,
+		Lines: []string{
+			"Signature request: " + form.request.Id,
+			"Document: " + form.request.File,
+			"Document SHA-256: " + form.request.DocumentHash,
+			"Signer: " + signer,
+			"Signed by: " + form.Name + " (" + signatureType + " signature)",
+			"Signed at: " + now.String(),
+			"IP address: " + form.ip,
+			"User agent: " + form.userAgent,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := filesystem.NewFileFromBytes(signed, form.request.File)
+	if err != nil {
+		return nil, err
+	}
+
+	// update a copy to preserve the original request state on failure
+	signedRequest := *form.request
+	signedRequest.Status = models.SignatureRequestStatusSigned
+	signedRequest.SignatureType = signatureType
+	signedRequest.SignedName = form.Name
+	signedRequest.SignedFile = file.Name
+	signedRequest.SignedHash = security.SHA256(string(signed))
+	signedRequest.SignedIp = form.ip
+	signedRequest.SignedUserAgent = form.userAgent
+	signedRequest.Signed = now
+
+	interceptorsErr := runInterceptors(&signedRequest, func(m *models.SignatureRequest) error {
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			if err := txDao.SaveSignatureRequest(m); err != nil {
+				return err
+			}
+
+			// replace the original document with the signed one
+			upsert := NewRecordUpsert(form.app, record)
+			upsert.SetDao(txDao)
+			upsert.SetFullManageAccess(true)
+
+			if err := upsert.RemoveFiles(m.Field, m.File); err != nil {
+				return err
+			}
+
+			if err := upsert.AddFiles(m.Field, file); err != nil {
+				return err
+			}
+
+			if err := upsert.Submit(); err != nil {
+				return err
+			}
+
+			*form.request = *m
+
+			return nil
+		})
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return record, nil
+}
@@ -0,0 +1,190 @@
+package forms_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestSignatureRequestSignValidate(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	var pngBuf bytes.Buffer
+	png.Encode(&pngBuf, image.NewGray(image.Rect(0, 0, 2, 2)))
+	pngBase64 := base64.StdEncoding.EncodeToString(pngBuf.Bytes())
+
+	scenarios := []struct {
+		name           string
+		jsonData       string
+		expectedErrors []string
+	}{
+		{"empty data", `{}`, []string{"name", "agree"}},
+		{"invalid signature", `{"name":"test","agree":true,"signature":"invalid"}`, []string{"signature"}},
+		{"non-image signature", `{"name":"test","agree":true,"signature":"dGVzdA=="}`, []string{"signature"}},
+		{"valid typed signature", `{"name":"test","agree":true}`, nil},
+		{"valid drawn signature", `{"name":"test","agree":true,"signature":"` + pngBase64 + `"}`, nil},
+		{"valid drawn signature data url", `{"name":"test","agree":true,"signature":"data:image/png;base64,` + pngBase64 + `"}`, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewSignatureRequestSign(testApp, &models.SignatureRequest{})
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			errs, ok := form.Validate().(validation.Errors)
+			if !ok && len(s.expectedErrors) > 0 {
+				t.Fatalf("Expected errors %v, got nil", s.expectedErrors)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestSignatureRequestSignSubmit(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name        string
+		status      string
+		document    string
+		expectError bool
+	}{
+		{"non-pending request", models.SignatureRequestStatusRevoked, testSignDocument, true},
+		{"modified document", models.SignatureRequestStatusPending, "%PDF-1.4 modified", true},
+		{"pending request", models.SignatureRequestStatusPending, testSignDocument, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			record := mockSignDocument(t, testApp)
+
+			request := &models.SignatureRequest{
+				CollectionId: record.Collection().Id,
+				RecordId:     record.Id,
+				Field:        "file_many",
+				File:         "doc_test.pdf",
+				DocumentHash: security.SHA256(s.document),
+				Hash:         "test",
+				SignerName:   "John Doe",
+				Status:       s.status,
+			}
+			if err := testApp.Dao().SaveSignatureRequest(request); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewSignatureRequestSign(testApp, request)
+			form.SetClient("1.2.3.4", "test_agent")
+			form.Name = "John Doe"
+			form.Agree = true
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.SignatureRequest]) forms.InterceptorNextFunc[*models.SignatureRequest] {
+				return func(m *models.SignatureRequest) error {
+					interceptorCalls++
+					return next(m)
+				}
+			}
+
+			signedRecord, err := form.Submit(interceptor)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if s.expectError {
+				if interceptorCalls != 0 {
+					t.Fatalf("Expected interceptor to not be called, got %d", interceptorCalls)
+				}
+
+				if request.Status != s.status {
+					t.Fatalf("Expected the request status to remain %q, got %q", s.status, request.Status)
+				}
+
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Fatalf("Expected interceptor to be called once, got %d", interceptorCalls)
+			}
+
+			stored, err := testApp.Dao().FindSignatureRequestById(request.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if stored.Status != models.SignatureRequestStatusSigned ||
+				stored.SignatureType != models.SignatureTypeTyped ||
+				stored.SignedName != "John Doe" ||
+				stored.SignedIp != "1.2.3.4" ||
+				stored.SignedUserAgent != "test_agent" ||
+				stored.Signed.IsZero() ||
+				stored.SignedFile == "" ||
+				stored.SignedFile != request.SignedFile {
+				t.Fatalf("Invalid signed request state: %v", stored)
+			}
+
+			files := signedRecord.GetStringSlice("file_many")
+			if list.ExistInSlice("doc_test.pdf", files) || !list.ExistInSlice(stored.SignedFile, files) {
+				t.Fatalf("Expected the original document to be replaced with %q, got %v", stored.SignedFile, files)
+			}
+
+			fsys, err := testApp.NewFilesystem()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fsys.Close()
+
+			r, err := fsys.OpenFile(signedRecord.BaseFilesPath() + "/" + stored.SignedFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			signed, _ := io.ReadAll(r)
+
+			if security.SHA256(string(signed)) != stored.SignedHash {
+				t.Fatal("The signed document hash doesn't match")
+			}
+
+			if !strings.HasPrefix(string(signed), testSignDocument) {
+				t.Fatal("Expected the original document content to be preserved")
+			}
+
+			for _, part := range []string{"(John Doe) Tj", "(IP address: 1.2.3.4) Tj", "/Count 2"} {
+				if !strings.Contains(string(signed), part) {
+					t.Fatalf("Missing %q in the signed document", part)
+				}
+			}
+		})
+	}
+}
@@ -1001,6 +1001,214 @@ func TestRecordDataValidatorValidateJson(t *testing.T) {
 	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
 }
 
+func TestRecordDataValidatorValidateGeoPoint(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "field1",
+			Type: schema.FieldTypeGeoPoint,
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeGeoPoint,
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(geoPoint) check required constraint - nil",
+			map[string]any{
+				"field1": nil,
+				"field2": nil,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(geoPoint) check required constraint - zero point",
+			map[string]any{
+				"field1": `{"lat":0,"lng":0}`,
+				"field2": `{"lat":0,"lng":0}`,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(geoPoint) check latitude and longitude ranges",
+			map[string]any{
+				"field1": `{"lat":90.1,"lng":0}`,
+				"field2": `{"lat":0,"lng":-180.1}`,
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(geoPoint) valid data",
+			map[string]any{
+				"field1": `{"lat":-90,"lng":180}`,
+				"field2": map[string]any{"lat": 42.69, "lng": 23.32},
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateMoney(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	min := int64(100)
+	max := int64(1000)
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypeMoney,
+			Options: &schema.MoneyOptions{Currency: "USD"},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeMoney,
+			Options:  &schema.MoneyOptions{Currency: "USD", Min: &min, Max: &max},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(money) check required constraint - nil",
+			map[string]any{
+				"field1": nil,
+				"field2": nil,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(money) check required constraint - zero amount",
+			map[string]any{
+				"field1": 0,
+				"field2": "0.00",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(money) check min constraint",
+			map[string]any{
+				"field1": -1,
+				"field2": "0.99",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(money) check max constraint",
+			map[string]any{
+				"field1": 1_000_000,
+				"field2": 1001,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(money) valid data",
+			map[string]any{
+				"field1": `{"amount":1,"currency":"USD"}`,
+				"field2": "10.00",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidatePhone(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypePhone,
+			Options: &schema.PhoneOptions{},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypePhone,
+			Options:  &schema.PhoneOptions{DefaultCountry: "FR", OnlyCountries: []string{"FR", "BE"}},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(phone) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": "",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(phone) check invalid numbers",
+			map[string]any{
+				"field1": "06 12 34 56 78", // no default country
+				"field2": "06 12 34",
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(phone) check only countries constraint",
+			map[string]any{
+				"field1": "+1 202 555 0143",
+				"field2": "+1 202 555 0143",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(phone) valid data",
+			map[string]any{
+				"field1": "+33 6 12 34 56 78",
+				"field2": "06 12 34 56 78",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
 func TestRecordDataValidatorValidateFile(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -1291,6 +1499,71 @@ func TestRecordDataValidatorValidateRelation(t *testing.T) {
 	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
 }
 
+func TestRecordDataValidatorValidateSelfRelationCycle(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// demo1 rel_one tree: imy661ixudk5izi -> al1h9ijdeojtsjy -> 84nmscqy84lsi1t
+	leaf, err := app.Dao().FindRecordById("demo1", "imy661ixudk5izi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf.Set("rel_one", "al1h9ijdeojtsjy")
+	if err := app.Dao().WithoutHooks().SaveRecord(leaf); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkValidatorErrors(t, app.Dao(), root, []testDataFieldScenario{
+		{
+			"self reference",
+			map[string]any{"rel_one": root.Id},
+			nil,
+			[]string{"rel_one"},
+		},
+		{
+			"direct descendant",
+			map[string]any{"rel_one": "al1h9ijdeojtsjy"},
+			nil,
+			[]string{"rel_one"},
+		},
+		{
+			"nested descendant",
+			map[string]any{"rel_one": "imy661ixudk5izi"},
+			nil,
+			[]string{"rel_one"},
+		},
+	})
+
+	checkValidatorErrors(t, app.Dao(), leaf, []testDataFieldScenario{
+		{
+			"unchanged parent",
+			map[string]any{"rel_one": "al1h9ijdeojtsjy"},
+			nil,
+			[]string{},
+		},
+		{
+			"ancestor as parent",
+			map[string]any{"rel_one": root.Id},
+			nil,
+			[]string{},
+		},
+	})
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(leaf.Collection()), []testDataFieldScenario{
+		{
+			"new record with existing parent",
+			map[string]any{"rel_one": "imy661ixudk5izi"},
+			nil,
+			[]string{},
+		},
+	})
+}
+
 func checkValidatorErrors(t *testing.T, dao *daos.Dao, record *models.Record, scenarios []testDataFieldScenario) {
 	for i, s := range scenarios {
 		prefix := s.name
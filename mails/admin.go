@@ -20,8 +20,9 @@ func SendAdminPasswordReset(app core.App, admin *models.Admin) error {
 	}
 
 	actionUrl, urlErr := rest.NormalizeUrl(fmt.Sprintf(
-		"%s/_/#/confirm-password-reset/%s",
+		"%s%s#/confirm-password-reset/%s",
 		app.Settings().Meta.AppUrl,
+		app.Settings().AdminUI.TrailedPath(),
 		token,
 	))
 	if urlErr != nil {
@@ -74,3 +75,47 @@ func SendAdminPasswordReset(app core.App, admin *models.Admin) error {
 		return app.OnMailerAfterAdminResetPasswordSend().Trigger(e)
 	})
 }
+
+// SendAdminNotificationsDigest sends an email digest with the
+// provided unread admin notifications to the specified admin.
+func SendAdminNotificationsDigest(app core.App, admin *models.Admin, notifications []*models.AdminNotification) error {
+	if len(notifications) == 0 {
+		return nil // nothing to send
+	}
+
+	actionUrl, urlErr := rest.NormalizeUrl(app.Settings().Meta.AppUrl + app.Settings().AdminUI.TrailedPath())
+	if urlErr != nil {
+		return urlErr
+	}
+
+	params := struct {
+		AppName       string
+		AppUrl        string
+		Admin         *models.Admin
+		Notifications []*models.AdminNotification
+		ActionUrl     string
+	}{
+		AppName:       app.Settings().Meta.AppName,
+		AppUrl:        app.Settings().Meta.AppUrl,
+		Admin:         admin,
+		Notifications: notifications,
+		ActionUrl:     actionUrl,
+	}
+
+	body, renderErr := resolveTemplateContent(params, templates.Layout, templates.AdminNotificationsDigestBody)
+	if renderErr != nil {
+		return renderErr
+	}
+
+	message := &mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      []mail.Address{{Address: admin.Email}},
+		Subject: fmt.Sprintf("%s - %d new notification(s)", app.Settings().Meta.AppName, len(notifications)),
+		HTML:    body,
+	}
+
+	return app.NewMailClient().Send(message)
+}
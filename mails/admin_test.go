@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
 )
 
@@ -37,3 +38,51 @@ func TestSendAdminPasswordReset(t *testing.T) {
 		}
 	}
 }
+
+func TestSendAdminNotificationsDigest(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	testApp.Settings().Meta.AppUrl = "http://localhost:8090////"
+
+	admin, _ := testApp.Dao().FindAdminByEmail("test@example.com")
+
+	// no notifications
+	if err := mails.SendAdminNotificationsDigest(testApp, admin, nil); err != nil {
+		t.Fatal(err)
+	}
+	if testApp.TestMailer.TotalSend != 0 {
+		t.Fatalf("Expected no emails to be sent, got %d", testApp.TestMailer.TotalSend)
+	}
+
+	notifications := []*models.AdminNotification{
+		{Severity: models.AdminNotificationSeverityError, Title: "Backup failed", Body: "disk full", Count: 3},
+		{Severity: models.AdminNotificationSeverityInfo, Title: "New version", Count: 1},
+	}
+
+	if err := mails.SendAdminNotificationsDigest(testApp, admin, notifications); err != nil {
+		t.Fatal(err)
+	}
+
+	if testApp.TestMailer.TotalSend != 1 {
+		t.Fatalf("Expected one email to be sent, got %d", testApp.TestMailer.TotalSend)
+	}
+
+	expectedParts := []string{
+		"[error]</strong> Backup failed <i>(x3)</i>",
+		"disk full",
+		"[info]</strong> New version",
+		"http://localhost:8090/_/",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(testApp.TestMailer.LastMessage.HTML, part) {
+			t.Fatalf("Couldn't find %s \nin\n %s", part, testApp.TestMailer.LastMessage.HTML)
+		}
+	}
+
+	if strings.Contains(testApp.TestMailer.LastMessage.HTML, "New version <i>") {
+		t.Fatalf("Expected no repeat count for single notifications")
+	}
+}
@@ -1,15 +1,11 @@
 package mails
 
 import (
-	"html/template"
 	"net/mail"
 
 	"github.com/pocketbase/pocketbase/core"
-	"github.com/pocketbase/pocketbase/mails/templates"
 	"github.com/pocketbase/pocketbase/models"
-	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tokens"
-	"github.com/pocketbase/pocketbase/tools/mailer"
 )
 
 // SendRecordPasswordReset sends a password reset request email to the specified user.
@@ -21,20 +17,11 @@ func SendRecordPasswordReset(app core.App, authRecord *models.Record) error {
 
 	mailClient := app.NewMailClient()
 
-	subject, body, err := resolveEmailTemplate(app, token, app.Settings().Meta.ResetPasswordTemplate)
+	message, err := resolveRecordEmailTemplate(app, models.EmailTemplateActionPasswordReset, authRecord, token, nil)
 	if err != nil {
 		return err
 	}
-
-	message := &mailer.Message{
-		From: mail.Address{
-			Name:    app.Settings().Meta.SenderName,
-			Address: app.Settings().Meta.SenderAddress,
-		},
-		To:      []mail.Address{{Address: authRecord.Email()}},
-		Subject: subject,
-		HTML:    body,
-	}
+	message.To = []mail.Address{{Address: authRecord.Email()}}
 
 	event := new(core.MailerRecordEvent)
 	event.MailClient = mailClient
@@ -61,20 +48,11 @@ func SendRecordVerification(app core.App, authRecord *models.Record) error {
 
 	mailClient := app.NewMailClient()
 
-	subject, body, err := resolveEmailTemplate(app, token, app.Settings().Meta.VerificationTemplate)
+	message, err := resolveRecordEmailTemplate(app, models.EmailTemplateActionVerification, authRecord, token, nil)
 	if err != nil {
 		return err
 	}
-
-	message := &mailer.Message{
-		From: mail.Address{
-			Name:    app.Settings().Meta.SenderName,
-			Address: app.Settings().Meta.SenderAddress,
-		},
-		To:      []mail.Address{{Address: authRecord.Email()}},
-		Subject: subject,
-		HTML:    body,
-	}
+	message.To = []mail.Address{{Address: authRecord.Email()}}
 
 	event := new(core.MailerRecordEvent)
 	event.MailClient = mailClient
@@ -101,20 +79,11 @@ func SendRecordChangeEmail(app core.App, record *models.Record, newEmail string)
 
 	mailClient := app.NewMailClient()
 
-	subject, body, err := resolveEmailTemplate(app, token, app.Settings().Meta.ConfirmEmailChangeTemplate)
+	message, err := resolveRecordEmailTemplate(app, models.EmailTemplateActionEmailChange, record, token, map[string]any{"newEmail": newEmail})
 	if err != nil {
 		return err
 	}
-
-	message := &mailer.Message{
-		From: mail.Address{
-			Name:    app.Settings().Meta.SenderName,
-			Address: app.Settings().Meta.SenderAddress,
-		},
-		To:      []mail.Address{{Address: newEmail}},
-		Subject: subject,
-		HTML:    body,
-	}
+	message.To = []mail.Address{{Address: newEmail}}
 
 	event := new(core.MailerRecordEvent)
 	event.MailClient = mailClient
@@ -134,28 +103,3 @@ func SendRecordChangeEmail(app core.App, record *models.Record, newEmail string)
 		return app.OnMailerAfterRecordChangeEmailSend().Trigger(e)
 	})
 }
-
-func resolveEmailTemplate(
-	app core.App,
-	token string,
-	emailTemplate settings.EmailTemplate,
-) (subject string, body string, err error) {
-	subject, rawBody, _ := emailTemplate.Resolve(
-		app.Settings().Meta.AppName,
-		app.Settings().Meta.AppUrl,
-		token,
-	)
-
-	params := struct {
-		HtmlContent template.HTML
-	}{
-		HtmlContent: template.HTML(rawBody),
-	}
-
-	body, err = resolveTemplateContent(params, templates.Layout, templates.HtmlBody)
-	if err != nil {
-		return "", "", err
-	}
-
-	return subject, body, nil
-}
@@ -0,0 +1,267 @@
+package mails
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	htmlTemplate "html/template"
+	"net/mail"
+	"strings"
+	"text/template"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/mails/templates"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+)
+
+// RecordLanguageFields lists the auth record field names that are
+// checked (in order) when resolving the record preferred language.
+var RecordLanguageFields = []string{"language", "lang"}
+
+// RecordLanguage resolves the preferred language code of the provided record.
+//
+// The language is read from the first nonempty [RecordLanguageFields] field.
+// If the field is a relation (eg. to a "languages" collection), the
+// "code" field of the related record is used as language code.
+//
+// Returns empty string if the record doesn't have a language.
+func RecordLanguage(app core.App, record *models.Record) string {
+	if record == nil {
+		return ""
+	}
+
+	for _, name := range RecordLanguageFields {
+		value := record.GetString(name)
+		if value == "" {
+			continue
+		}
+
+		field := record.Collection().Schema.GetFieldByName(name)
+		if field != nil && field.Type == schema.FieldTypeRelation {
+			field.InitOptions()
+			options, _ := field.Options.(*schema.RelationOptions)
+			if options == nil {
+				continue
+			}
+
+			rel, err := app.Dao().FindRecordById(options.CollectionId, value)
+			if err != nil {
+				continue
+			}
+
+			value = rel.GetString("code")
+			if value == "" {
+				continue
+			}
+		}
+
+		return value
+	}
+
+	return ""
+}
+
+// FindEmailTemplate returns the best matching stored email template
+// for the specified action and language (see [daos.Dao.FindEmailTemplate]).
+//
+// For the builtin actions the related settings template is returned
+// in case there is no stored template.
+func FindEmailTemplate(app core.App, action string, language string) (*models.EmailTemplate, error) {
+	tpl, err := app.Dao().FindEmailTemplate(action, language)
+	if err == nil {
+		return tpl, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	fallback, ok := settingsEmailTemplate(app, action)
+	if !ok {
+		return nil, errors.New("missing " + action + " email template")
+	}
+
+	return &models.EmailTemplate{
+		Action:  action,
+		Subject: fallback.Subject,
+		Html:    fallback.Body,
+	}, nil
+}
+
+// RenderEmailTemplate finds and renders the email template for the
+// specified action and language.
+//
+// See [RenderEmailTemplateModel] for the available template data.
+func RenderEmailTemplate(app core.App, action string, language string, data map[string]any) (*mailer.Message, error) {
+	tpl, err := FindEmailTemplate(app, action, language)
+	if err != nil {
+		return nil, err
+	}
+
+	return RenderEmailTemplateModel(app, tpl, data)
+}
+
+// RenderEmailTemplateModel renders the provided email template and
+// returns a new mail message with the resolved subject, html and text
+// content (the message recipients are left for the caller).
+//
+// The template subject, html and text are executed as Go templates
+// with the provided data (html is executed with "html/template" and
+// is wrapped in the default mail layout). The "appName" and "appUrl"
+// data keys are always populated from the app settings.
+//
+// The legacy settings placeholders ({APP_NAME}, {APP_URL}, {TOKEN}, {ACTION_URL})
+// are also replaced with the "appName", "appUrl", "token" and "actionUrl" data values.
+func RenderEmailTemplateModel(app core.App, tpl *models.EmailTemplate, data map[string]any) (*mailer.Message, error) {
+	params := make(map[string]any, len(data)+2)
+	for k, v := range data {
+		params[k] = v
+	}
+	params["appName"] = app.Settings().Meta.AppName
+	params["appUrl"] = app.Settings().Meta.AppUrl
+
+	placeholders := []string{}
+	for placeholder, key := range map[string]string{
+		settings.EmailPlaceholderAppName:   "appName",
+		settings.EmailPlaceholderAppUrl:    "appUrl",
+		settings.EmailPlaceholderToken:     "token",
+		settings.EmailPlaceholderActionUrl: "actionUrl",
+	} {
+		value, _ := params[key].(string)
+		placeholders = append(placeholders, placeholder, value)
+	}
+	replacer := strings.NewReplacer(placeholders...)
+
+	subject, err := executeTextTemplate(replacer.Replace(tpl.Subject), params)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := executeTextTemplate(replacer.Replace(tpl.Text), params)
+	if err != nil {
+		return nil, err
+	}
+
+	rawHtml, err := executeHtmlTemplate(replacer.Replace(tpl.Html), params)
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := resolveTemplateContent(
+		struct{ HtmlContent htmlTemplate.HTML }{HtmlContent: htmlTemplate.HTML(rawHtml)},
+		templates.Layout,
+		templates.HtmlBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		Subject: strings.TrimSpace(subject),
+		HTML:    html,
+		Text:    text,
+	}, nil
+}
+
+// SendEmailTemplate renders the email template for the specified
+// action and language and sends it to the provided email address.
+func SendEmailTemplate(app core.App, action string, language string, to string, data map[string]any) error {
+	message, err := RenderEmailTemplate(app, action, language, data)
+	if err != nil {
+		return err
+	}
+
+	message.To = []mail.Address{{Address: to}}
+
+	return app.NewMailClient().Send(message)
+}
+
+// resolveRecordEmailTemplate renders the builtin action email template
+// of the provided auth record in its preferred language.
+func resolveRecordEmailTemplate(
+	app core.App,
+	action string,
+	record *models.Record,
+	token string,
+	meta map[string]any,
+) (*mailer.Message, error) {
+	tpl, err := FindEmailTemplate(app, action, RecordLanguage(app, record))
+	if err != nil {
+		return nil, err
+	}
+
+	// the action url is always resolved from the settings template
+	fallback, _ := settingsEmailTemplate(app, action)
+	_, _, actionUrl := fallback.Resolve(
+		app.Settings().Meta.AppName,
+		app.Settings().Meta.AppUrl,
+		token,
+	)
+
+	data := map[string]any{
+		"record":    record,
+		"token":     token,
+		"actionUrl": actionUrl,
+	}
+	for k, v := range meta {
+		data[k] = v
+	}
+
+	return RenderEmailTemplateModel(app, tpl, data)
+}
+
+func settingsEmailTemplate(app core.App, action string) (settings.EmailTemplate, bool) {
+	switch action {
+	case models.EmailTemplateActionVerification:
+		return app.Settings().Meta.VerificationTemplate, true
+	case models.EmailTemplateActionPasswordReset:
+		return app.Settings().Meta.ResetPasswordTemplate, true
+	case models.EmailTemplateActionEmailChange:
+		return app.Settings().Meta.ConfirmEmailChangeTemplate, true
+	}
+
+	return settings.EmailTemplate{}, false
+}
+
+func executeTextTemplate(content string, data any) (string, error) {
+	if content == "" {
+		return "", nil
+	}
+
+	t, err := template.New("email_template").Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	var wr bytes.Buffer
+	if err := t.Execute(&wr, data); err != nil {
+		return "", err
+	}
+
+	return wr.String(), nil
+}
+
+func executeHtmlTemplate(content string, data any) (string, error) {
+	if content == "" {
+		return "", nil
+	}
+
+	t, err := htmlTemplate.New("email_template").Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	var wr bytes.Buffer
+	if err := t.Execute(&wr, data); err != nil {
+		return "", err
+	}
+
+	return wr.String(), nil
+}
@@ -0,0 +1,136 @@
+package mails_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordLanguage(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	user, _ := testApp.Dao().FindFirstRecordByData("users", "email", "test@example.com")
+
+	if lang := mails.RecordLanguage(testApp, user); lang != "" {
+		t.Fatalf("Expected empty language, got %q", lang)
+	}
+
+	user.Set("lang", "de")
+	if lang := mails.RecordLanguage(testApp, user); lang != "de" {
+		t.Fatalf("Expected language de, got %q", lang)
+	}
+
+	user.Set("language", "pt-BR")
+	if lang := mails.RecordLanguage(testApp, user); lang != "pt-BR" {
+		t.Fatalf("Expected language pt-BR, got %q", lang)
+	}
+
+	if lang := mails.RecordLanguage(testApp, nil); lang != "" {
+		t.Fatalf("Expected empty language for nil record, got %q", lang)
+	}
+}
+
+func TestRenderEmailTemplate(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	template := &models.EmailTemplate{
+		Action:   "welcome",
+		Language: "de",
+		Subject:  "Hallo {{.name}} - {APP_NAME}",
+		Html:     "<p>{{.name}}</p>",
+		Text:     "Hallo {{.name}}",
+	}
+	if err := testApp.Dao().SaveEmailTemplate(template); err != nil {
+		t.Fatal(err)
+	}
+
+	// missing template
+	if _, err := mails.RenderEmailTemplate(testApp, "welcome", "en", nil); err == nil {
+		t.Fatal("Expected missing template error")
+	}
+
+	// builtin action fallback to the settings template
+	message, err := mails.RenderEmailTemplate(testApp, models.EmailTemplateActionVerification, "de", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.Subject != "Verify your "+testApp.Settings().Meta.AppName+" email" {
+		t.Fatalf("Unexpected fallback subject %q", message.Subject)
+	}
+
+	message, err = mails.RenderEmailTemplate(testApp, "welcome", "de-AT", map[string]any{"name": "<b>test</b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSubject := "Hallo <b>test</b> - " + testApp.Settings().Meta.AppName
+	if message.Subject != expectedSubject {
+		t.Fatalf("Expected subject %q, got %q", expectedSubject, message.Subject)
+	}
+
+	if message.Text != "Hallo <b>test</b>" {
+		t.Fatalf("Expected unescaped text, got %q", message.Text)
+	}
+
+	if !strings.Contains(message.HTML, "<p>&lt;b&gt;test&lt;/b&gt;</p>") {
+		t.Fatalf("Expected escaped html content, got \n%s", message.HTML)
+	}
+
+	if !strings.Contains(message.HTML, "<html") {
+		t.Fatalf("Expected the html to be wrapped in the mail layout, got \n%s", message.HTML)
+	}
+}
+
+func TestSendRecordVerificationWithLanguageTemplate(t *testing.T) {
+	t.Parallel()
+
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	template := &models.EmailTemplate{
+		Action:   models.EmailTemplateActionVerification,
+		Language: "de",
+		Subject:  "Bestätigen",
+		Html:     `<a href="{ACTION_URL}">{{.record.Email}}</a>`,
+		Text:     "{{.actionUrl}}",
+	}
+	if err := testApp.Dao().SaveEmailTemplate(template); err != nil {
+		t.Fatal(err)
+	}
+
+	user, _ := testApp.Dao().FindFirstRecordByData("users", "email", "test@example.com")
+	user.Set("language", "de-DE")
+
+	if err := mails.SendRecordVerification(testApp, user); err != nil {
+		t.Fatal(err)
+	}
+
+	message := testApp.TestMailer.LastMessage
+
+	if message.Subject != "Bestätigen" {
+		t.Fatalf("Expected the language template subject, got %q", message.Subject)
+	}
+
+	expectedParts := []string{
+		`<a href="http://localhost:8090/_/#/auth/confirm-verification/eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.`,
+		">test@example.com</a>",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(message.HTML, part) {
+			t.Fatalf("Couldn't find %s \nin\n %s", part, message.HTML)
+		}
+	}
+
+	if !strings.HasPrefix(message.Text, "http://localhost:8090/_/#/auth/confirm-verification/") {
+		t.Fatalf("Expected the text to contain the action url, got %q", message.Text)
+	}
+}
@@ -0,0 +1,28 @@
+package templates
+
+// Available variables:
+//
+// ```
+// Admin         *models.Admin
+// AppName       string
+// AppUrl        string
+// Notifications []*models.AdminNotification
+// ActionUrl     string
+// ```
+const AdminNotificationsDigestBody = `
+{{define "content"}}
+	<p>Hello,</p>
+	<p>There are {{len .Notifications}} new unread notification(s) for {{.AppName}}:</p>
+	<ul>
+		{{range .Notifications}}
+			<li>
+				<strong>[{{.Severity}}]</strong> {{.Title}}{{if gt .Count 1}} <i>(x{{.Count}})</i>{{end}}
+				{{if .Body}}<br/>{{.Body}}{{end}}
+			</li>
+		{{end}}
+	</ul>
+	<p>
+		<a class="btn" href="{{.ActionUrl}}" target="_blank" rel="noopener">Open dashboard</a>
+	</p>
+{{end}}
+`
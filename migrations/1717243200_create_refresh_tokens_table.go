@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the refresh tokens revocation table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_refreshTokens}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT DEFAULT "" NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[family]]       TEXT NOT NULL,
+				[[hash]]         TEXT NOT NULL,
+				[[expires]]      TEXT DEFAULT "" NOT NULL,
+				[[revoked]]      BOOLEAN DEFAULT FALSE NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _refreshTokens_hash_idx on {{_refreshTokens}} ([[hash]]);
+			CREATE INDEX _refreshTokens_owner_idx on {{_refreshTokens}} ([[collectionId]], [[recordId]]);
+			CREATE INDEX _refreshTokens_family_idx on {{_refreshTokens}} ([[family]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_refreshTokens").Execute()
+
+		return err
+	})
+}
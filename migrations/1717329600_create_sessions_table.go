@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the auth sessions table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_sessions}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT DEFAULT "" NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[userAgent]]    TEXT DEFAULT "" NOT NULL,
+				[[ip]]           TEXT DEFAULT "" NOT NULL,
+				[[lastSeen]]     TEXT DEFAULT "" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _sessions_owner_idx on {{_sessions}} ([[collectionId]], [[recordId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_sessions").Execute()
+
+		return err
+	})
+}
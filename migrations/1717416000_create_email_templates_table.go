@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the stored email templates table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_emailTemplates}} (
+				[[id]]       TEXT PRIMARY KEY NOT NULL,
+				[[action]]   TEXT NOT NULL,
+				[[language]] TEXT DEFAULT "" NOT NULL,
+				[[subject]]  TEXT DEFAULT "" NOT NULL,
+				[[html]]     TEXT DEFAULT "" NOT NULL,
+				[[text]]     TEXT DEFAULT "" NOT NULL,
+				[[created]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _emailTemplates_action_language_idx on {{_emailTemplates}} ([[action]], [[language]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_emailTemplates").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the mail provider webhook events table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_email_events}} (
+				[[id]]        TEXT PRIMARY KEY NOT NULL,
+				[[provider]]  TEXT NOT NULL,
+				[[type]]      TEXT NOT NULL,
+				[[email]]     TEXT DEFAULT "" NOT NULL,
+				[[messageId]] TEXT DEFAULT "" NOT NULL,
+				[[reason]]    TEXT DEFAULT "" NOT NULL,
+				[[data]]      JSON DEFAULT "{}" NOT NULL,
+				[[created]]   TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]   TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _email_events_email_idx on {{_email_events}} ([[email]]);
+			CREATE INDEX _email_events_type_idx on {{_email_events}} ([[type]]);
+			CREATE INDEX _email_events_created_idx on {{_email_events}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_email_events").Execute()
+
+		return err
+	})
+}
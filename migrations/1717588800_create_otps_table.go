@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the auth records one-time passwords table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_otps}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[sentTo]]       TEXT DEFAULT "" NOT NULL,
+				[[hash]]         TEXT NOT NULL,
+				[[attempts]]     INTEGER DEFAULT 0 NOT NULL,
+				[[expires]]      TEXT DEFAULT "" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _otps_record_idx on {{_otps}} ([[collectionId]], [[recordId]]);
+			CREATE INDEX _otps_expires_idx on {{_otps}} ([[expires]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_otps").Execute()
+
+		return err
+	})
+}
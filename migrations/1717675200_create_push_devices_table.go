@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the auth records push notifications devices table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_pushDevices}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[provider]]     TEXT NOT NULL,
+				[[token]]        TEXT NOT NULL,
+				[[name]]         TEXT DEFAULT "" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _pushDevices_provider_token_idx on {{_pushDevices}} ([[provider]], [[token]]);
+			CREATE INDEX _pushDevices_record_idx on {{_pushDevices}} ([[collectionId]], [[recordId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_pushDevices").Execute()
+
+		return err
+	})
+}
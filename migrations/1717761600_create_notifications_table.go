@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the auth records in-app notifications table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_notifications}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[title]]        TEXT NOT NULL,
+				[[body]]         TEXT DEFAULT "" NOT NULL,
+				[[read]]         BOOLEAN DEFAULT FALSE NOT NULL,
+				[[data]]         JSON DEFAULT "{}" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _notifications_record_idx on {{_notifications}} ([[collectionId]], [[recordId]], [[read]]);
+			CREATE INDEX _notifications_created_idx on {{_notifications}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_notifications").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the quarantined record files table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_quarantine}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[field]]        TEXT NOT NULL,
+				[[name]]         TEXT NOT NULL,
+				[[originalName]] TEXT DEFAULT "" NOT NULL,
+				[[size]]         INTEGER DEFAULT 0 NOT NULL,
+				[[reason]]       TEXT DEFAULT "" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _quarantine_record_idx on {{_quarantine}} ([[collectionId]], [[recordId]]);
+			CREATE INDEX _quarantine_created_idx on {{_quarantine}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_quarantine").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the record share links and share comments tables.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_shareLinks}} (
+				[[id]]            TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]  TEXT NOT NULL,
+				[[recordId]]      TEXT NOT NULL,
+				[[hash]]          TEXT NOT NULL,
+				[[label]]         TEXT DEFAULT "" NOT NULL,
+				[[allowComments]] BOOLEAN DEFAULT FALSE NOT NULL,
+				[[expires]]       TEXT DEFAULT "" NOT NULL,
+				[[maxUses]]       INTEGER DEFAULT 0 NOT NULL,
+				[[uses]]          INTEGER DEFAULT 0 NOT NULL,
+				[[revoked]]       BOOLEAN DEFAULT FALSE NOT NULL,
+				[[created]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _shareLinks_hash_idx on {{_shareLinks}} ([[hash]]);
+			CREATE INDEX _shareLinks_record_idx on {{_shareLinks}} ([[collectionId]], [[recordId]]);
+
+			CREATE TABLE {{_shareComments}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[shareLinkId]]  TEXT NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[author]]       TEXT DEFAULT "" NOT NULL,
+				[[message]]      TEXT DEFAULT "" NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _shareComments_record_idx on {{_shareComments}} ([[collectionId]], [[recordId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		tables := []string{"_shareComments", "_shareLinks"}
+
+		for _, name := range tables {
+			if _, err := db.DropTable(name).Execute(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
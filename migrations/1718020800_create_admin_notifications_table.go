@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the admin notification center table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_adminNotifications}} (
+				[[id]]       TEXT PRIMARY KEY NOT NULL,
+				[[severity]] TEXT NOT NULL,
+				[[source]]   TEXT DEFAULT "" NOT NULL,
+				[[title]]    TEXT NOT NULL,
+				[[body]]     TEXT DEFAULT "" NOT NULL,
+				[[read]]     BOOLEAN DEFAULT FALSE NOT NULL,
+				[[count]]    INTEGER DEFAULT 1 NOT NULL,
+				[[data]]     JSON DEFAULT "{}" NOT NULL,
+				[[created]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _adminNotifications_read_idx on {{_adminNotifications}} ([[read]], [[source]], [[title]]);
+			CREATE INDEX _adminNotifications_created_idx on {{_adminNotifications}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_adminNotifications").Execute()
+
+		return err
+	})
+}
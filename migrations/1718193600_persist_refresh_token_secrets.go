@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"encoding/json"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// Persists randomly generated adminRefreshToken and recordRefreshToken
+// secrets for the already existing settings, otherwise new secrets
+// will be generated on each app start invalidating the issued refresh tokens.
+//
+// Encrypted settings are left untouched because the encryption key
+// is not available during the migration (the secrets will be persisted
+// with the next settings save).
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		dao := daos.New(db)
+
+		param, err := dao.FindParamByKey(models.ParamAppSettings)
+		if err != nil {
+			return nil // no stored settings
+		}
+
+		data := map[string]any{}
+		if err := json.Unmarshal(param.Value, &data); err != nil {
+			return nil // encrypted
+		}
+
+		for _, key := range []string{"adminRefreshToken", "recordRefreshToken"} {
+			config, _ := data[key].(map[string]any)
+			if config == nil {
+				config = map[string]any{"duration": 2592000} // 30 days
+			}
+
+			if secret, _ := config["secret"].(string); secret != "" {
+				continue
+			}
+
+			config["secret"] = security.RandomString(50)
+
+			data[key] = config
+		}
+
+		return dao.SaveParam(models.ParamAppSettings, data)
+	}, nil)
+}
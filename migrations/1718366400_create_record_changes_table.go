@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the tracked records changes table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_recordChanges}} (
+				[[seq]]          INTEGER PRIMARY KEY AUTOINCREMENT,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[action]]       TEXT NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _recordChanges_collection_seq_idx on {{_recordChanges}} ([[collectionId]], [[seq]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_recordChanges").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the distributed (cluster-wide) locks table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_locks}} (
+				[[key]]     TEXT PRIMARY KEY NOT NULL,
+				[[owner]]   TEXT NOT NULL,
+				[[expires]] TEXT NOT NULL
+			);
+
+			CREATE INDEX _locks_expires_idx on {{_locks}} ([[expires]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_locks").Execute()
+
+		return err
+	})
+}
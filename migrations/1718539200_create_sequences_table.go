@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the autonumber fields counters table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_sequences}} (
+				[[key]]   TEXT PRIMARY KEY NOT NULL,
+				[[value]] INTEGER DEFAULT 0 NOT NULL
+			);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_sequences").Execute()
+
+		return err
+	})
+}
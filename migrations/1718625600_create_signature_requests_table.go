@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the record files signature requests table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_signatureRequests}} (
+				[[id]]              TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]    TEXT NOT NULL,
+				[[recordId]]        TEXT NOT NULL,
+				[[field]]           TEXT NOT NULL,
+				[[file]]            TEXT NOT NULL,
+				[[documentHash]]    TEXT NOT NULL,
+				[[hash]]            TEXT NOT NULL,
+				[[signerName]]      TEXT DEFAULT "" NOT NULL,
+				[[signerEmail]]     TEXT DEFAULT "" NOT NULL,
+				[[expires]]         TEXT DEFAULT "" NOT NULL,
+				[[status]]          TEXT DEFAULT "pending" NOT NULL,
+				[[signatureType]]   TEXT DEFAULT "" NOT NULL,
+				[[signedName]]      TEXT DEFAULT "" NOT NULL,
+				[[signedFile]]      TEXT DEFAULT "" NOT NULL,
+				[[signedHash]]      TEXT DEFAULT "" NOT NULL,
+				[[signedIp]]        TEXT DEFAULT "" NOT NULL,
+				[[signedUserAgent]] TEXT DEFAULT "" NOT NULL,
+				[[signed]]          TEXT DEFAULT "" NOT NULL,
+				[[created]]         TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]         TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _signatureRequests_hash_idx on {{_signatureRequests}} ([[hash]]);
+			CREATE INDEX _signatureRequests_record_idx on {{_signatureRequests}} ([[collectionId]], [[recordId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_signatureRequests").Execute()
+
+		return err
+	})
+}
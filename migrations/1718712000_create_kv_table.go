@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the persistent key-value store table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_kv}} (
+				[[key]]     TEXT PRIMARY KEY NOT NULL,
+				[[value]]   JSON DEFAULT NULL,
+				[[expires]] TEXT DEFAULT '' NOT NULL
+			);
+
+			CREATE INDEX _kv_expires_idx on {{_kv}} ([[expires]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_kv").Execute()
+
+		return err
+	})
+}
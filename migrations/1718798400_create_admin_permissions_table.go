@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the admin permissions (droits) table.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_adminPermissions}} (
+				[[id]]         TEXT PRIMARY KEY NOT NULL,
+				[[adminId]]    TEXT NOT NULL,
+				[[permission]] TEXT NOT NULL,
+				[[created]]    TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]    TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _adminPermissions_admin_permission_idx on {{_adminPermissions}} ([[adminId]], [[permission]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_adminPermissions").Execute()
+
+		return err
+	})
+}
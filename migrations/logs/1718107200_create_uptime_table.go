@@ -0,0 +1,31 @@
+package logs
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the hourly uptime stats table.
+func init() {
+	LogsMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_uptime}} (
+				[[id]]        TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[subsystem]] TEXT NOT NULL,
+				[[hour]]      TEXT NOT NULL,
+				[[total]]     INTEGER DEFAULT 0 NOT NULL,
+				[[errors]]    INTEGER DEFAULT 0 NOT NULL,
+				[[created]]   TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]   TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _uptime_subsystem_hour_idx on {{_uptime}} ([[subsystem]], [[hour]]);
+			CREATE INDEX _uptime_hour_idx on {{_uptime}} ([[hour]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_uptime").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,34 @@
+package logs
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Creates the slow queries table.
+func init() {
+	LogsMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_slowQueries}} (
+				[[id]]           TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[fingerprint]]  TEXT NOT NULL,
+				[[sql]]          TEXT DEFAULT "" NOT NULL,
+				[[plan]]         TEXT DEFAULT "" NOT NULL,
+				[[count]]        INTEGER DEFAULT 0 NOT NULL,
+				[[maxDuration]]  REAL DEFAULT 0 NOT NULL,
+				[[lastDuration]] REAL DEFAULT 0 NOT NULL,
+				[[resolved]]     BOOLEAN DEFAULT FALSE NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _slowQueries_fingerprint_idx on {{_slowQueries}} ([[fingerprint]]);
+			CREATE INDEX _slowQueries_updated_idx on {{_slowQueries}} ([[updated]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_slowQueries").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,40 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*AdminNotification)(nil)
+
+// Admin notification severity levels.
+const (
+	AdminNotificationSeverityInfo    = "info"
+	AdminNotificationSeverityWarning = "warning"
+	AdminNotificationSeverityError   = "error"
+)
+
+// AdminNotification defines a single operator notification
+// shown in the admin dashboard (eg. a failed backup).
+type AdminNotification struct {
+	BaseModel
+
+	// Severity is the notification severity level (info, warning or error).
+	Severity string `db:"severity" json:"severity"`
+
+	// Source is the name of the component that raised the
+	// notification (eg. "backups", "ghupdate", "jsvm").
+	Source string `db:"source" json:"source"`
+
+	Title string `db:"title" json:"title"`
+	Body  string `db:"body" json:"body"`
+	Read  bool   `db:"read" json:"read"`
+
+	// Count is the number of times the same unread
+	// notification was raised (see [daos.Dao.RaiseAdminNotification]).
+	Count int `db:"count" json:"count"`
+
+	// Data is an optional custom payload (eg. the related backup name).
+	Data types.JsonMap `db:"data" json:"data"`
+}
+
+func (m *AdminNotification) TableName() string {
+	return "_adminNotifications"
+}
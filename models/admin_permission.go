@@ -0,0 +1,19 @@
+package models
+
+var _ Model = (*AdminPermission)(nil)
+
+// AdminPermission defines a single permission ("droit") assigned to an admin
+// (eg. "collections:posts:write" or "backups:*").
+//
+// The permission names are free-form and their meaning is up to the app
+// (eg. checked in a custom route middleware with [daos.Dao.HasAdminPermission]).
+type AdminPermission struct {
+	BaseModel
+
+	AdminId    string `db:"adminId" json:"adminId"`
+	Permission string `db:"permission" json:"permission"`
+}
+
+func (m *AdminPermission) TableName() string {
+	return "_adminPermissions"
+}
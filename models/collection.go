@@ -97,6 +97,102 @@ func (m *Collection) ViewOptions() CollectionViewOptions {
 	return result
 }
 
+// RevisionField returns the name of the collection revision counter
+// field or empty string if the optimistic locking is not enabled
+// (it is always empty for view collections).
+func (m *Collection) RevisionField() string {
+	switch m.Type {
+	case CollectionTypeAuth:
+		return m.AuthOptions().RevisionField
+	case CollectionTypeView:
+		return ""
+	default:
+		return m.BaseOptions().RevisionField
+	}
+}
+
+// TenantField returns the name of the collection tenant relation
+// field or empty string if the collection is not tenant scoped
+// (it is always empty for view collections).
+func (m *Collection) TenantField() string {
+	switch m.Type {
+	case CollectionTypeAuth:
+		return m.AuthOptions().TenantField
+	case CollectionTypeView:
+		return ""
+	default:
+		return m.BaseOptions().TenantField
+	}
+}
+
+// TrackChanges checks whether the collection records changes
+// should be logged (it is always false for view collections).
+func (m *Collection) TrackChanges() bool {
+	switch m.Type {
+	case CollectionTypeAuth:
+		return m.AuthOptions().TrackChanges
+	case CollectionTypeView:
+		return false
+	default:
+		return m.BaseOptions().TrackChanges
+	}
+}
+
+// EncryptFiles checks whether the uploaded collection files should be encrypted.
+func (m *Collection) EncryptFiles() bool {
+	switch m.Type {
+	case CollectionTypeAuth:
+		return m.AuthOptions().EncryptFiles
+	case CollectionTypeView:
+		return false
+	default:
+		return m.BaseOptions().EncryptFiles
+	}
+}
+
+// FileCacheControl returns the custom Cache-Control header value
+// for the served collection files (empty string means the default one).
+func (m *Collection) FileCacheControl() string {
+	switch m.Type {
+	case CollectionTypeAuth:
+		return m.AuthOptions().FileCacheControl
+	case CollectionTypeView:
+		return m.ViewOptions().FileCacheControl
+	default:
+		return m.BaseOptions().FileCacheControl
+	}
+}
+
+// PublicForm returns the collection public form options
+// or nil if the public form submissions are not enabled.
+func (m *Collection) PublicForm() *CollectionPublicFormOptions {
+	if m.Type != CollectionTypeBase {
+		return nil
+	}
+
+	options := m.BaseOptions().PublicForm
+	if options == nil || !options.Enabled {
+		return nil
+	}
+
+	return options
+}
+
+// Immutable returns the collection append-only records options
+// or nil if the collection records are not immutable.
+func (m *Collection) Immutable() *CollectionImmutableOptions {
+	if m.Type != CollectionTypeBase {
+		return nil
+	}
+
+	options := m.BaseOptions().Immutable
+	if options == nil || !options.Enabled {
+		return nil
+	}
+
+	return options
+}
+
 // NormalizeOptions updates the current collection options with a
 // new normalized state based on the collection type.
 func (m *Collection) NormalizeOptions() error {
@@ -162,11 +258,175 @@ func (m *Collection) SetOptions(typedOptions any) error {
 
 // CollectionBaseOptions defines the "base" Collection.Options fields.
 type CollectionBaseOptions struct {
+	// RevisionField is the optional name of the collection number field
+	// that holds the record revision counter used for optimistic locking.
+	RevisionField string `form:"revisionField" json:"revisionField,omitempty"`
+
+	// FileCacheControl is the optional Cache-Control header value
+	// of the served collection files (eg. "public, max-age=31536000, immutable").
+	FileCacheControl string `form:"fileCacheControl" json:"fileCacheControl,omitempty"`
+
+	// PublicForm optionally enables the unauthenticated
+	// public form submissions for the collection.
+	PublicForm *CollectionPublicFormOptions `form:"publicForm" json:"publicForm,omitempty"`
+
+	// Duplicates optionally defines the rules for detecting
+	// probable duplicate collection records.
+	Duplicates *CollectionDuplicatesOptions `form:"duplicates" json:"duplicates,omitempty"`
+
+	// TenantField is the optional name of the collection single relation
+	// field that holds the record owner tenant (eg. organisation).
+	TenantField string `form:"tenantField" json:"tenantField,omitempty"`
+
+	// EncryptFiles enables the encryption-at-rest of the uploaded
+	// collection files (requires the app encryption key).
+	EncryptFiles bool `form:"encryptFiles" json:"encryptFiles,omitempty"`
+
+	// Immutable optionally makes the collection records append-only
+	// (aka. WORM - write once, read many).
+	Immutable *CollectionImmutableOptions `form:"immutable" json:"immutable,omitempty"`
+
+	// TrackChanges enables logging the collection records changes
+	// (including the deleted records ids) for delta syncing.
+	TrackChanges bool `form:"trackChanges" json:"trackChanges,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
 func (o CollectionBaseOptions) Validate() error {
-	return nil
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.RevisionField, validation.Length(0, 255)),
+		validation.Field(&o.FileCacheControl, validation.Length(0, 255)),
+		validation.Field(&o.PublicForm),
+		validation.Field(&o.Duplicates),
+		validation.Field(&o.TenantField, validation.Length(0, 255)),
+		validation.Field(&o.Immutable),
+	)
+}
+
+// CollectionImmutableOptions defines the "base" collection
+// append-only records options.
+//
+// The records of an immutable collection cannot be updated or
+// deleted, even by admins (it is enforced at the dao layer).
+type CollectionImmutableOptions struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// HashField is the optional name of the collection text field
+	// that holds the record hash chain value for tamper evidence.
+	//
+	// Each record hash is computed from its data and the hash of the
+	// previously created record (see [daos.Dao.VerifyRecordsHashChain]).
+	HashField string `form:"hashField" json:"hashField"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (o CollectionImmutableOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.HashField, validation.Length(0, 255)),
+	)
+}
+
+// CollectionPublicFormOptions defines the "base" collection
+// public form submissions options.
+//
+// The public form submissions don't check the collection API rules
+// and accept only the allowlisted fields.
+type CollectionPublicFormOptions struct {
+	Enabled bool `form:"enabled" json:"enabled"`
+
+	// Fields is the allowlist of the schema field names that could be
+	// submitted (any other submitted field is ignored).
+	Fields []string `form:"fields" json:"fields"`
+
+	// Honeypot is the optional name of a hidden form input that must
+	// be left empty (submissions with filled honeypot are silently discarded).
+	Honeypot string `form:"honeypot" json:"honeypot"`
+
+	// RequireCaptcha requires a captcha response token
+	// verified with the app captcha provider settings.
+	RequireCaptcha bool `form:"requireCaptcha" json:"requireCaptcha"`
+
+	// MaxRequests is the max number of allowed submissions per client IP
+	// in the specified Duration interval (0 means no limit).
+	MaxRequests int `form:"maxRequests" json:"maxRequests"`
+
+	// Duration is the rate limit interval in seconds.
+	Duration int64 `form:"duration" json:"duration"`
+
+	// RedirectUrl is the optional url where the client is redirected
+	// after a submission (eg. for plain HTML forms).
+	RedirectUrl string `form:"redirectUrl" json:"redirectUrl"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (o CollectionPublicFormOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Fields, validation.When(o.Enabled, validation.Required)),
+		validation.Field(&o.Honeypot, validation.Length(0, 255)),
+		validation.Field(&o.MaxRequests, validation.Min(0)),
+		validation.Field(&o.Duration, validation.When(o.MaxRequests > 0, validation.Required), validation.Min(int64(0))),
+		validation.Field(&o.RedirectUrl, is.URL),
+	)
+}
+
+// Duplicate detection rule match types.
+const (
+	// DuplicateMatchExact matches only equal field values.
+	DuplicateMatchExact = "exact"
+
+	// DuplicateMatchNormalized matches field values that are equal after
+	// lowercasing and removing all non letter and digit characters
+	// (eg. "+1 (555) 123" and "1555123").
+	DuplicateMatchNormalized = "normalized"
+
+	// DuplicateMatchFuzzy scores the normalized field values
+	// with their Levenshtein similarity ratio.
+	DuplicateMatchFuzzy = "fuzzy"
+)
+
+// CollectionDuplicatesOptions defines the collection
+// records duplicate detection options.
+type CollectionDuplicatesOptions struct {
+	// Rules is the list of the compared fields and their match type.
+	Rules []CollectionDuplicateRule `form:"rules" json:"rules"`
+
+	// Threshold is the min weighted rules score (0-1) for two records
+	// to be considered probable duplicates (default to 0.8 if not set).
+	Threshold float64 `form:"threshold" json:"threshold"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (o CollectionDuplicatesOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Rules, validation.Required, validation.Length(1, 20)),
+		validation.Field(&o.Threshold, validation.Min(0.0), validation.Max(1.0)),
+	)
+}
+
+// CollectionDuplicateRule defines a single duplicate detection field rule.
+type CollectionDuplicateRule struct {
+	// Field is the name of the compared record field.
+	Field string `form:"field" json:"field"`
+
+	// Match is the field values match type
+	// (DuplicateMatchExact, DuplicateMatchNormalized or DuplicateMatchFuzzy).
+	Match string `form:"match" json:"match"`
+
+	// Weight is the relative weight of the rule score (default to 1 if not set).
+	Weight float64 `form:"weight" json:"weight"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (r CollectionDuplicateRule) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Field, validation.Required, validation.Length(1, 255)),
+		validation.Field(
+			&r.Match,
+			validation.Required,
+			validation.In(DuplicateMatchExact, DuplicateMatchNormalized, DuplicateMatchFuzzy),
+		),
+		validation.Field(&r.Weight, validation.Min(0.0)),
+	)
 }
 
 // -------------------------------------------------------------------
@@ -182,6 +442,38 @@ type CollectionAuthOptions struct {
 	OnlyVerified       bool     `form:"onlyVerified" json:"onlyVerified"`
 	OnlyEmailDomains   []string `form:"onlyEmailDomains" json:"onlyEmailDomains"`
 	MinPasswordLength  int      `form:"minPasswordLength" json:"minPasswordLength"`
+
+	// AllowOTPAuth enables the SMS one-time password auth flow
+	// (request-otp and confirm-otp) for the collection records.
+	AllowOTPAuth bool `form:"allowOTPAuth" json:"allowOTPAuth"`
+
+	// OTPPhoneField is the name of the collection text or phone field
+	// that holds the auth record phone number.
+	OTPPhoneField string `form:"otpPhoneField" json:"otpPhoneField"`
+
+	// RevisionField is the optional name of the collection number field
+	// that holds the record revision counter used for optimistic locking.
+	RevisionField string `form:"revisionField" json:"revisionField,omitempty"`
+
+	// FileCacheControl is the optional Cache-Control header value
+	// of the served collection files (eg. "public, max-age=31536000, immutable").
+	FileCacheControl string `form:"fileCacheControl" json:"fileCacheControl,omitempty"`
+
+	// Duplicates optionally defines the rules for detecting
+	// probable duplicate collection records.
+	Duplicates *CollectionDuplicatesOptions `form:"duplicates" json:"duplicates,omitempty"`
+
+	// TenantField is the optional name of the collection single relation
+	// field that holds the record owner tenant (eg. organisation).
+	TenantField string `form:"tenantField" json:"tenantField,omitempty"`
+
+	// EncryptFiles enables the encryption-at-rest of the uploaded
+	// collection files (requires the app encryption key).
+	EncryptFiles bool `form:"encryptFiles" json:"encryptFiles,omitempty"`
+
+	// TrackChanges enables logging the collection records changes
+	// (including the deleted records ids) for delta syncing.
+	TrackChanges bool `form:"trackChanges" json:"trackChanges,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
@@ -202,6 +494,15 @@ func (o CollectionAuthOptions) Validate() error {
 			validation.Min(5),
 			validation.Max(72),
 		),
+		validation.Field(
+			&o.OTPPhoneField,
+			validation.When(o.AllowOTPAuth, validation.Required),
+			validation.Length(0, 255),
+		),
+		validation.Field(&o.RevisionField, validation.Length(0, 255)),
+		validation.Field(&o.FileCacheControl, validation.Length(0, 255)),
+		validation.Field(&o.Duplicates),
+		validation.Field(&o.TenantField, validation.Length(0, 255)),
 	)
 }
 
@@ -210,11 +511,16 @@ func (o CollectionAuthOptions) Validate() error {
 // CollectionViewOptions defines the "view" Collection.Options fields.
 type CollectionViewOptions struct {
 	Query string `form:"query" json:"query"`
+
+	// FileCacheControl is the optional Cache-Control header value
+	// of the served collection files (eg. "public, max-age=31536000, immutable").
+	FileCacheControl string `form:"fileCacheControl" json:"fileCacheControl,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
 func (o CollectionViewOptions) Validate() error {
 	return validation.ValidateStruct(&o,
 		validation.Field(&o.Query, validation.Required),
+		validation.Field(&o.FileCacheControl, validation.Length(0, 255)),
 	)
 }
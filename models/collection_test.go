@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -100,7 +101,7 @@ func TestCollectionMarshalJSON(t *testing.T) {
 		{
 			"auth type + non empty options",
 			models.Collection{BaseModel: models.BaseModel{Id: "test"}, Type: models.CollectionTypeAuth, Options: types.JsonMap{"test": 123, "allowOAuth2Auth": true, "minPasswordLength": 4, "onlyVerified": true}},
-			`{"id":"test","created":"","updated":"","name":"","type":"auth","system":false,"schema":[],"indexes":[],"listRule":null,"viewRule":null,"createRule":null,"updateRule":null,"deleteRule":null,"options":{"allowEmailAuth":false,"allowOAuth2Auth":true,"allowUsernameAuth":false,"exceptEmailDomains":null,"manageRule":null,"minPasswordLength":4,"onlyEmailDomains":null,"onlyVerified":true,"requireEmail":false}}`,
+			`{"id":"test","created":"","updated":"","name":"","type":"auth","system":false,"schema":[],"indexes":[],"listRule":null,"viewRule":null,"createRule":null,"updateRule":null,"deleteRule":null,"options":{"allowEmailAuth":false,"allowOAuth2Auth":true,"allowOTPAuth":false,"allowUsernameAuth":false,"exceptEmailDomains":null,"manageRule":null,"minPasswordLength":4,"onlyEmailDomains":null,"onlyVerified":true,"otpPhoneField":"","requireEmail":false}}`,
 		},
 	}
 
@@ -168,7 +169,7 @@ func TestCollectionAuthOptions(t *testing.T) {
 	t.Parallel()
 
 	options := types.JsonMap{"test": 123, "minPasswordLength": 4}
-	expectedSerialization := `{"manageRule":null,"allowOAuth2Auth":false,"allowUsernameAuth":false,"allowEmailAuth":false,"requireEmail":false,"exceptEmailDomains":null,"onlyVerified":false,"onlyEmailDomains":null,"minPasswordLength":4}`
+	expectedSerialization := `{"manageRule":null,"allowOAuth2Auth":false,"allowUsernameAuth":false,"allowEmailAuth":false,"requireEmail":false,"exceptEmailDomains":null,"onlyVerified":false,"onlyEmailDomains":null,"minPasswordLength":4,"allowOTPAuth":false,"otpPhoneField":""}`
 
 	scenarios := []struct {
 		name       string
@@ -283,7 +284,7 @@ func TestNormalizeOptions(t *testing.T) {
 		{
 			"auth type",
 			models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"test": 123, "minPasswordLength": 4}},
-			`{"allowEmailAuth":false,"allowOAuth2Auth":false,"allowUsernameAuth":false,"exceptEmailDomains":null,"manageRule":null,"minPasswordLength":4,"onlyEmailDomains":null,"onlyVerified":false,"requireEmail":false}`,
+			`{"allowEmailAuth":false,"allowOAuth2Auth":false,"allowOTPAuth":false,"allowUsernameAuth":false,"exceptEmailDomains":null,"manageRule":null,"minPasswordLength":4,"onlyEmailDomains":null,"onlyVerified":false,"otpPhoneField":"","requireEmail":false}`,
 		},
 	}
 
@@ -356,7 +357,7 @@ func TestSetOptions(t *testing.T) {
 			"auth type",
 			models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"test": 123}},
 			map[string]any{"test": 456, "minPasswordLength": 4},
-			`{"allowEmailAuth":false,"allowOAuth2Auth":false,"allowUsernameAuth":false,"exceptEmailDomains":null,"manageRule":null,"minPasswordLength":4,"onlyEmailDomains":null,"onlyVerified":false,"requireEmail":false}`,
+			`{"allowEmailAuth":false,"allowOAuth2Auth":false,"allowOTPAuth":false,"allowUsernameAuth":false,"exceptEmailDomains":null,"manageRule":null,"minPasswordLength":4,"onlyEmailDomains":null,"onlyVerified":false,"otpPhoneField":"","requireEmail":false}`,
 		},
 	}
 
@@ -378,6 +379,162 @@ func TestSetOptions(t *testing.T) {
 	}
 }
 
+func TestCollectionRevisionField(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name       string
+		collection models.Collection
+		expected   string
+	}{
+		{
+			"base type without revision field",
+			models.Collection{Type: models.CollectionTypeBase},
+			"",
+		},
+		{
+			"base type with revision field",
+			models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"revisionField": "rev"}},
+			"rev",
+		},
+		{
+			"auth type with revision field",
+			models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"revisionField": "rev"}},
+			"rev",
+		},
+		{
+			"view type with revision field",
+			models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"revisionField": "rev"}},
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if result := s.collection.RevisionField(); result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestCollectionTrackChanges(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name       string
+		collection models.Collection
+		expected   bool
+	}{
+		{
+			"base type without track changes",
+			models.Collection{Type: models.CollectionTypeBase},
+			false,
+		},
+		{
+			"base type with track changes",
+			models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"trackChanges": true}},
+			true,
+		},
+		{
+			"auth type with track changes",
+			models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"trackChanges": true}},
+			true,
+		},
+		{
+			"view type with track changes",
+			models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"trackChanges": true}},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if result := s.collection.TrackChanges(); result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestCollectionTenantField(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name       string
+		collection models.Collection
+		expected   string
+	}{
+		{
+			"base type without tenant field",
+			models.Collection{Type: models.CollectionTypeBase},
+			"",
+		},
+		{
+			"base type with tenant field",
+			models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"tenantField": "org"}},
+			"org",
+		},
+		{
+			"auth type with tenant field",
+			models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"tenantField": "org"}},
+			"org",
+		},
+		{
+			"view type with tenant field",
+			models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"tenantField": "org"}},
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if result := s.collection.TenantField(); result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestCollectionFileCacheControl(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name       string
+		collection models.Collection
+		expected   string
+	}{
+		{
+			"base type without file cache control",
+			models.Collection{Type: models.CollectionTypeBase},
+			"",
+		},
+		{
+			"base type with file cache control",
+			models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"fileCacheControl": "no-store"}},
+			"no-store",
+		},
+		{
+			"auth type with file cache control",
+			models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"fileCacheControl": "no-store"}},
+			"no-store",
+		},
+		{
+			"view type with file cache control",
+			models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"fileCacheControl": "no-store"}},
+			"no-store",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if result := s.collection.FileCacheControl(); result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
 func TestCollectionBaseOptionsValidate(t *testing.T) {
 	t.Parallel()
 
@@ -385,6 +542,212 @@ func TestCollectionBaseOptionsValidate(t *testing.T) {
 	if err := opt.Validate(); err != nil {
 		t.Fatal(err)
 	}
+
+	opt.RevisionField = strings.Repeat("a", 256)
+	if err := opt.Validate(); err == nil {
+		t.Fatal("Expected revisionField length validation error")
+	}
+
+	opt.RevisionField = ""
+	opt.FileCacheControl = strings.Repeat("a", 256)
+	if err := opt.Validate(); err == nil {
+		t.Fatal("Expected fileCacheControl length validation error")
+	}
+
+	opt.FileCacheControl = ""
+	opt.PublicForm = &models.CollectionPublicFormOptions{Enabled: true}
+	if err := opt.Validate(); err == nil {
+		t.Fatal("Expected publicForm validation error")
+	}
+
+	opt.PublicForm.Fields = []string{"title"}
+	if err := opt.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCollectionPublicFormOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		options        models.CollectionPublicFormOptions
+		expectedErrors []string
+	}{
+		{
+			"empty",
+			models.CollectionPublicFormOptions{},
+			nil,
+		},
+		{
+			"enabled without fields",
+			models.CollectionPublicFormOptions{Enabled: true},
+			[]string{"fields"},
+		},
+		{
+			"rate limit without duration",
+			models.CollectionPublicFormOptions{MaxRequests: 1},
+			[]string{"duration"},
+		},
+		{
+			"negative rate limit",
+			models.CollectionPublicFormOptions{MaxRequests: -1, Duration: -1},
+			[]string{"maxRequests", "duration"},
+		},
+		{
+			"invalid redirect url",
+			models.CollectionPublicFormOptions{RedirectUrl: "invalid"},
+			[]string{"redirectUrl"},
+		},
+		{
+			"valid data",
+			models.CollectionPublicFormOptions{
+				Enabled:        true,
+				Fields:         []string{"title"},
+				Honeypot:       "website",
+				RequireCaptcha: true,
+				MaxRequests:    5,
+				Duration:       60,
+				RedirectUrl:    "https://example.com/thanks",
+			},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.options.Validate()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Fatalf("Failed to parse errors %v", result)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got errors \n%v", s.expectedErrors, result)
+			}
+
+			for key := range errs {
+				if !list.ExistInSlice(key, s.expectedErrors) {
+					t.Fatalf("Unexpected error key %q in \n%v", key, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectionDuplicatesOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name           string
+		options        models.CollectionDuplicatesOptions
+		expectedErrors []string
+	}{
+		{
+			"empty",
+			models.CollectionDuplicatesOptions{},
+			[]string{"rules"},
+		},
+		{
+			"invalid threshold",
+			models.CollectionDuplicatesOptions{
+				Rules:     []models.CollectionDuplicateRule{{Field: "title", Match: models.DuplicateMatchExact}},
+				Threshold: 1.1,
+			},
+			[]string{"threshold"},
+		},
+		{
+			"invalid rule",
+			models.CollectionDuplicatesOptions{
+				Rules: []models.CollectionDuplicateRule{
+					{Field: "title", Match: models.DuplicateMatchFuzzy},
+					{Field: "", Match: "invalid", Weight: -1},
+				},
+			},
+			[]string{"rules"},
+		},
+		{
+			"valid data",
+			models.CollectionDuplicatesOptions{
+				Rules: []models.CollectionDuplicateRule{
+					{Field: "title", Match: models.DuplicateMatchFuzzy, Weight: 2},
+					{Field: "phone", Match: models.DuplicateMatchNormalized},
+					{Field: "email", Match: models.DuplicateMatchExact, Weight: 0.5},
+				},
+				Threshold: 0.7,
+			},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.options.Validate()
+
+			// parse errors
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Fatalf("Failed to parse errors %v", result)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got errors \n%v", s.expectedErrors, result)
+			}
+
+			for key := range errs {
+				if !list.ExistInSlice(key, s.expectedErrors) {
+					t.Fatalf("Unexpected error key %q in \n%v", key, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectionPublicForm(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name       string
+		collection models.Collection
+		expected   bool
+	}{
+		{
+			"base type without public form",
+			models.Collection{Type: models.CollectionTypeBase},
+			false,
+		},
+		{
+			"base type with disabled public form",
+			models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"publicForm": map[string]any{"enabled": false}}},
+			false,
+		},
+		{
+			"base type with enabled public form",
+			models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"publicForm": map[string]any{"enabled": true, "fields": []string{"title"}}}},
+			true,
+		},
+		{
+			"auth type with enabled public form",
+			models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"publicForm": map[string]any{"enabled": true, "fields": []string{"title"}}}},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.collection.PublicForm()
+
+			if (result != nil) != s.expected {
+				t.Fatalf("Expected enabled public form %v, got %v", s.expected, result)
+			}
+
+			if result != nil && (len(result.Fields) != 1 || result.Fields[0] != "title") {
+				t.Fatalf("Expected fields [title], got %v", result.Fields)
+			}
+		})
+	}
 }
 
 func TestCollectionAuthOptionsValidate(t *testing.T) {
@@ -415,6 +778,16 @@ func TestCollectionAuthOptionsValidate(t *testing.T) {
 			models.CollectionAuthOptions{MinPasswordLength: 73},
 			[]string{"minPasswordLength"},
 		},
+		{
+			"AllowOTPAuth without OTPPhoneField",
+			models.CollectionAuthOptions{AllowOTPAuth: true},
+			[]string{"otpPhoneField"},
+		},
+		{
+			"AllowOTPAuth with OTPPhoneField",
+			models.CollectionAuthOptions{AllowOTPAuth: true, OTPPhoneField: "phone"},
+			[]string{},
+		},
 		{
 			"both OnlyDomains and ExceptDomains set",
 			models.CollectionAuthOptions{
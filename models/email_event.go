@@ -0,0 +1,26 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*EmailEvent)(nil)
+
+// EmailEvent defines a single mail provider delivery event
+// (eg. bounce or complaint) received through the provider webhook.
+type EmailEvent struct {
+	BaseModel
+
+	// Provider is the mail provider that reported the event (eg. "sendgrid").
+	Provider string `db:"provider" json:"provider"`
+
+	// Type is the normalized event type (eg. "bounce", "complaint", "delivered").
+	Type string `db:"type" json:"type"`
+
+	Email     string        `db:"email" json:"email"`
+	MessageId string        `db:"messageId" json:"messageId"`
+	Reason    string        `db:"reason" json:"reason"`
+	Data      types.JsonMap `db:"data" json:"data"`
+}
+
+func (m *EmailEvent) TableName() string {
+	return "_email_events"
+}
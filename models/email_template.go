@@ -0,0 +1,33 @@
+package models
+
+var _ Model = (*EmailTemplate)(nil)
+
+// Builtin email template actions.
+const (
+	EmailTemplateActionVerification  = "verification"
+	EmailTemplateActionPasswordReset = "password-reset"
+	EmailTemplateActionEmailChange   = "email-change"
+)
+
+// EmailTemplate defines a single stored email template.
+//
+// Templates are identified by their action and language pair.
+// A template with empty language is used as fallback when there is
+// no template for the requested language.
+type EmailTemplate struct {
+	BaseModel
+
+	// Action is the mail action key (eg. "verification" or any custom key).
+	Action string `db:"action" json:"action"`
+
+	// Language is the template language code (eg. "en", "pt-BR").
+	Language string `db:"language" json:"language"`
+
+	Subject string `db:"subject" json:"subject"`
+	Html    string `db:"html" json:"html"`
+	Text    string `db:"text" json:"text"`
+}
+
+func (m *EmailTemplate) TableName() string {
+	return "_emailTemplates"
+}
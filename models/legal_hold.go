@@ -0,0 +1,25 @@
+package models
+
+var _ Model = (*LegalHold)(nil)
+
+// LegalHold defines a single legal hold placed on a record.
+//
+// While a record has at least one legal hold, the record and its
+// files cannot be deleted (including by cascade deletes).
+type LegalHold struct {
+	BaseModel
+
+	// CollectionId and RecordId identify the held record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Reason is an optional description of the hold (eg. the case reference).
+	Reason string `db:"reason" json:"reason"`
+
+	// AdminId is the id of the admin who placed the hold (if any).
+	AdminId string `db:"adminId" json:"adminId"`
+}
+
+func (m *LegalHold) TableName() string {
+	return "_legalHolds"
+}
@@ -0,0 +1,25 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*Notification)(nil)
+
+// Notification defines a single in-app notification of an auth record.
+type Notification struct {
+	BaseModel
+
+	// CollectionId and RecordId identify the recipient auth record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	Title string `db:"title" json:"title"`
+	Body  string `db:"body" json:"body"`
+	Read  bool   `db:"read" json:"read"`
+
+	// Data is an optional custom payload (eg. a link to the related resource).
+	Data types.JsonMap `db:"data" json:"data"`
+}
+
+func (m *Notification) TableName() string {
+	return "_notifications"
+}
@@ -0,0 +1,14 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestNotificationTableName(t *testing.T) {
+	m := models.Notification{}
+	if m.TableName() != "_notifications" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
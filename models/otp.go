@@ -0,0 +1,58 @@
+package models
+
+import (
+	"crypto/subtle"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*OTP)(nil)
+
+// OTP defines a single one-time password sent to an auth record.
+//
+// Only the hash of the password is stored.
+type OTP struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// SentTo is the phone number the password was sent to.
+	SentTo string `db:"sentTo" json:"sentTo"`
+
+	Hash     string         `db:"hash" json:"-"`
+	Attempts int            `db:"attempts" json:"attempts"`
+	Expires  types.DateTime `db:"expires" json:"expires"`
+}
+
+func (m *OTP) TableName() string {
+	return "_otps"
+}
+
+// IsExpired checks whether the OTP has expired.
+func (m *OTP) IsExpired() bool {
+	return m.Expires.IsZero() || m.Expires.Time().Before(types.NowDateTime().Time())
+}
+
+// SetPassword sets the OTP password hash.
+//
+// The hash is keyed with the model id, so an id is generated if missing.
+func (m *OTP) SetPassword(password string) {
+	if !m.HasId() {
+		m.RefreshId()
+	}
+
+	m.Hash = security.HS256(password, m.Id)
+}
+
+// ValidatePassword validates a plain password against the OTP hash.
+func (m *OTP) ValidatePassword(password string) bool {
+	if m.Hash == "" || password == "" {
+		return false
+	}
+
+	hash := security.HS256(password, m.Id)
+
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(m.Hash)) == 1
+}
@@ -0,0 +1,71 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestOTPTableName(t *testing.T) {
+	m := models.OTP{}
+	if m.TableName() != "_otps" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestOTPIsExpired(t *testing.T) {
+	scenarios := []struct {
+		expires  time.Time
+		expected bool
+	}{
+		{time.Time{}, true},
+		{time.Now().Add(-1 * time.Minute), true},
+		{time.Now().Add(1 * time.Minute), false},
+	}
+
+	for i, s := range scenarios {
+		m := models.OTP{}
+		if !s.expires.IsZero() {
+			m.Expires, _ = types.ParseDateTime(s.expires)
+		}
+
+		if v := m.IsExpired(); v != s.expected {
+			t.Fatalf("[%d] Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestOTPSetAndValidatePassword(t *testing.T) {
+	m := models.OTP{}
+
+	if m.ValidatePassword("") {
+		t.Fatal("Expected empty password to be invalid")
+	}
+
+	m.SetPassword("123456")
+
+	if !m.HasId() {
+		t.Fatal("Expected the model id to be generated")
+	}
+
+	if m.Hash == "" || m.Hash == "123456" {
+		t.Fatalf("Expected the password to be hashed, got %q", m.Hash)
+	}
+
+	if m.ValidatePassword("654321") {
+		t.Fatal("Expected 654321 to be invalid")
+	}
+
+	if !m.ValidatePassword("123456") {
+		t.Fatal("Expected 123456 to be valid")
+	}
+
+	// the hash is keyed with the model id
+	other := models.OTP{}
+	other.SetPassword("123456")
+	if other.Hash == m.Hash {
+		t.Fatal("Expected different hashes for different model ids")
+	}
+}
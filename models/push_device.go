@@ -0,0 +1,26 @@
+package models
+
+var _ Model = (*PushDevice)(nil)
+
+// PushDevice defines a single push notifications device token
+// registered for an auth record.
+type PushDevice struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Provider is the push notifications provider of the token (fcm, apns or webpush).
+	Provider string `db:"provider" json:"provider"`
+
+	// Token is the provider device token
+	// (or the JSON serialized subscription for webpush).
+	Token string `db:"token" json:"token"`
+
+	// Name is an optional device name (eg. "iPhone 15").
+	Name string `db:"name" json:"name"`
+}
+
+func (m *PushDevice) TableName() string {
+	return "_pushDevices"
+}
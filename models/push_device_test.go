@@ -0,0 +1,14 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestPushDeviceTableName(t *testing.T) {
+	m := models.PushDevice{}
+	if m.TableName() != "_pushDevices" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+var (
+	_ Model        = (*QuarantinedFile)(nil)
+	_ FilesManager = (*QuarantinedFile)(nil)
+)
+
+// QuarantineFilesPath is the storage dir of the quarantined record files.
+const QuarantineFilesPath = "_pb_quarantine_"
+
+// QuarantinedFile defines a single suspicious record file that is
+// held in the quarantine storage area until an admin review.
+type QuarantinedFile struct {
+	BaseModel
+
+	// CollectionId and RecordId identify the owning record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Field is the name of the owning record file field.
+	Field string `db:"field" json:"field"`
+
+	// Name is the stored (normalized) file name as referenced by the record field.
+	Name         string `db:"name" json:"name"`
+	OriginalName string `db:"originalName" json:"originalName"`
+	Size         int64  `db:"size" json:"size"`
+
+	// Reason is an optional description of why the file was quarantined
+	// (eg. the detected malware signature).
+	Reason string `db:"reason" json:"reason"`
+}
+
+func (m *QuarantinedFile) TableName() string {
+	return "_quarantine"
+}
+
+// BaseFilesPath returns the quarantine storage dir of the file.
+func (m *QuarantinedFile) BaseFilesPath() string {
+	return QuarantineFilesPath + "/" + m.CollectionId + "/" + m.RecordId + "/" + m.Id
+}
+
+// Path returns the quarantine storage path of the file.
+func (m *QuarantinedFile) Path() string {
+	return m.BaseFilesPath() + "/" + m.Name
+}
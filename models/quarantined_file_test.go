@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestQuarantinedFileTableName(t *testing.T) {
+	m := models.QuarantinedFile{}
+	if m.TableName() != "_quarantine" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestQuarantinedFilePaths(t *testing.T) {
+	m := models.QuarantinedFile{
+		CollectionId: "c1",
+		RecordId:     "r1",
+		Name:         "test.txt",
+	}
+	m.Id = "q1"
+
+	if v := m.BaseFilesPath(); v != "_pb_quarantine_/c1/r1/q1" {
+		t.Fatalf("Unexpected BaseFilesPath, got %q", v)
+	}
+
+	if v := m.Path(); v != "_pb_quarantine_/c1/r1/q1/test.txt" {
+		t.Fatalf("Unexpected Path, got %q", v)
+	}
+}
@@ -389,6 +389,24 @@ func (m *Record) GetDateTime(key string) types.DateTime {
 	return d
 }
 
+// GetGeoPoint returns the data value for "key" as a GeoPoint instance.
+func (m *Record) GetGeoPoint(key string) types.GeoPoint {
+	p, _ := types.ParseGeoPoint(m.Get(key))
+	return p
+}
+
+// GetMoney returns the data value for "key" as a Money instance.
+func (m *Record) GetMoney(key string) types.Money {
+	v, _ := types.ParseMoney(m.Get(key), "")
+	return v
+}
+
+// GetPhoneNumber returns the data value for "key" as a PhoneNumber instance.
+func (m *Record) GetPhoneNumber(key string) types.PhoneNumber {
+	v, _ := types.ParsePhoneNumber(m.GetString(key), "")
+	return v
+}
+
 // GetStringSlice returns the data value for "key" as a slice of unique strings.
 func (m *Record) GetStringSlice(key string) []string {
 	return list.ToUniqueStringSlice(m.Get(key))
@@ -527,6 +545,16 @@ func (m *Record) PublicExport() map[string]any {
 
 	// export schema field values
 	for _, field := range m.collection.Schema.Fields() {
+		if options, ok := field.Options.(*schema.MoneyOptions); ok {
+			result[field.Name] = options.Export(m.GetMoney(field.Name))
+			continue
+		}
+
+		if options, ok := field.Options.(*schema.PhoneOptions); ok {
+			result[field.Name] = options.Export(m.GetString(field.Name))
+			continue
+		}
+
 		result[field.Name] = m.Get(field.Name)
 	}
 
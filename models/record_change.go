@@ -0,0 +1,27 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+// Record change actions.
+const (
+	RecordChangeActionCreate = "create"
+	RecordChangeActionUpdate = "update"
+	RecordChangeActionDelete = "delete"
+)
+
+// RecordChange defines a single tracked record change
+// (see [CollectionBaseOptions.TrackChanges]).
+type RecordChange struct {
+	// Seq is the monotonically increasing change sequence number
+	// (used as delta sync cursor).
+	Seq int64 `db:"seq" json:"seq"`
+
+	CollectionId string         `db:"collectionId" json:"collectionId"`
+	RecordId     string         `db:"recordId" json:"recordId"`
+	Action       string         `db:"action" json:"action"`
+	Created      types.DateTime `db:"created" json:"created"`
+}
+
+func (m *RecordChange) TableName() string {
+	return "_recordChanges"
+}
@@ -1031,6 +1031,80 @@ func TestRecordGetDateTime(t *testing.T) {
 	}
 }
 
+func TestRecordGetMoney(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "price",
+				Type:    schema.FieldTypeMoney,
+				Options: &schema.MoneyOptions{Currency: "EUR"},
+			},
+		),
+	}
+
+	scenarios := []struct {
+		key      string
+		value    any
+		expected string
+	}{
+		{"price", nil, "0.00 EUR"},
+		{"price", "1234", "12.34 EUR"},
+		{"price", "12.34", "12.34 EUR"},
+		{"price", types.NewMoney(1, "USD"), "0.00 EUR"},
+		{"unknown", nil, "0.00"},
+		{"unknown", types.NewMoney(1, "USD"), "0.01 USD"},
+	}
+
+	for i, s := range scenarios {
+		m := models.NewRecord(collection)
+		m.Set(s.key, s.value)
+
+		result := m.GetMoney(s.key)
+		if result.String() != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result.String())
+		}
+	}
+}
+
+func TestRecordGetPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "phone",
+				Type:    schema.FieldTypePhone,
+				Options: &schema.PhoneOptions{DefaultCountry: "FR"},
+			},
+		),
+	}
+
+	scenarios := []struct {
+		key      string
+		value    any
+		expected string
+	}{
+		{"phone", nil, ""},
+		{"phone", "06 12 34 56 78", "+33612345678"},
+		{"phone", "+1 (202) 555-0143", "+12025550143"},
+		{"phone", "invalid", ""},
+		{"unknown", "+33612345678", "+33612345678"},
+		{"unknown", "06 12 34 56 78", ""},
+	}
+
+	for i, s := range scenarios {
+		m := models.NewRecord(collection)
+		m.Set(s.key, s.value)
+
+		result := m.GetPhoneNumber(s.key)
+		if result.E164() != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result.E164())
+		}
+	}
+}
+
 func TestRecordGetStringSlice(t *testing.T) {
 	t.Parallel()
 
@@ -1372,6 +1446,103 @@ func TestRecordColumnValueMap(t *testing.T) {
 	}
 }
 
+func TestRecordPublicExportMoney(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Name: "c_name",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "object",
+				Type:    schema.FieldTypeMoney,
+				Options: &schema.MoneyOptions{Currency: "USD"},
+			},
+			&schema.SchemaField{
+				Name:    "minor",
+				Type:    schema.FieldTypeMoney,
+				Options: &schema.MoneyOptions{Currency: "USD", Format: schema.MoneyFormatMinor},
+			},
+			&schema.SchemaField{
+				Name:    "decimal",
+				Type:    schema.FieldTypeMoney,
+				Options: &schema.MoneyOptions{Currency: "JPY", Format: schema.MoneyFormatDecimal},
+			},
+		),
+	}
+	collection.Id = "c_id"
+
+	m := models.NewRecord(collection)
+	m.Id = "test_id"
+	m.Set("object", 1234)
+	m.Set("minor", "12.34")
+	m.Set("decimal", 1234)
+
+	raw, err := json.Marshal(m.PublicExport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"collectionId":"c_id","collectionName":"c_name","created":"","decimal":"1234","id":"test_id","minor":1234,"object":{"amount":1234,"currency":"USD"},"updated":""}`
+
+	if string(raw) != expected {
+		t.Fatalf("Expected \n%v \ngot \n%v", expected, string(raw))
+	}
+}
+
+func TestRecordPublicExportPhone(t *testing.T) {
+	t.Parallel()
+
+	collection := &models.Collection{
+		Name: "c_name",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "e164",
+				Type:    schema.FieldTypePhone,
+				Options: &schema.PhoneOptions{DefaultCountry: "US"},
+			},
+			&schema.SchemaField{
+				Name:    "international",
+				Type:    schema.FieldTypePhone,
+				Options: &schema.PhoneOptions{Format: schema.PhoneFormatInternational},
+			},
+			&schema.SchemaField{
+				Name:    "national",
+				Type:    schema.FieldTypePhone,
+				Options: &schema.PhoneOptions{Format: schema.PhoneFormatNational},
+			},
+			&schema.SchemaField{
+				Name:    "object",
+				Type:    schema.FieldTypePhone,
+				Options: &schema.PhoneOptions{Format: schema.PhoneFormatObject},
+			},
+			&schema.SchemaField{
+				Name:    "empty",
+				Type:    schema.FieldTypePhone,
+				Options: &schema.PhoneOptions{Format: schema.PhoneFormatObject},
+			},
+		),
+	}
+	collection.Id = "c_id"
+
+	m := models.NewRecord(collection)
+	m.Id = "test_id"
+	m.Set("e164", "(202) 555-0143")
+	m.Set("international", "+12025550143")
+	m.Set("national", "+33612345678")
+	m.Set("object", "+33612345678")
+
+	raw, err := json.Marshal(m.PublicExport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"collectionId":"c_id","collectionName":"c_name","created":"","e164":"+12025550143","empty":null,"id":"test_id","international":"+1 202 555 0143","national":"06 12 34 56 78","object":{"country":"FR","e164":"+33612345678","international":"+33 6 12 34 56 78","national":"06 12 34 56 78"},"updated":""}`
+
+	if string(raw) != expected {
+		t.Fatalf("Expected \n%v \ngot \n%v", expected, string(raw))
+	}
+}
+
 func TestRecordPublicExportAndMarshalJSON(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,41 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*RefreshToken)(nil)
+
+// RefreshToken defines a single server-side stored refresh token.
+//
+// Only the hash of the refresh token is stored.
+//
+// All tokens issued as part of the same rotation chain share the same Family,
+// which allows revoking the entire chain in case of a token reuse.
+type RefreshToken struct {
+	BaseModel
+
+	// CollectionId is the auth collection id of the token owner
+	// (empty for admins).
+	CollectionId string `db:"collectionId" json:"collectionId"`
+
+	// RecordId is the id of the token owner (auth record or admin).
+	RecordId string `db:"recordId" json:"recordId"`
+
+	Family  string         `db:"family" json:"family"`
+	Hash    string         `db:"hash" json:"-"`
+	Expires types.DateTime `db:"expires" json:"expires"`
+	Revoked bool           `db:"revoked" json:"revoked"`
+}
+
+func (m *RefreshToken) TableName() string {
+	return "_refreshTokens"
+}
+
+// IsAdmin checks whether the refresh token belongs to an admin.
+func (m *RefreshToken) IsAdmin() bool {
+	return m.CollectionId == ""
+}
+
+// IsExpired checks whether the refresh token has expired.
+func (m *RefreshToken) IsExpired() bool {
+	return m.Expires.IsZero() || m.Expires.Time().Before(types.NowDateTime().Time())
+}
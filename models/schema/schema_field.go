@@ -3,9 +3,11 @@ package schema
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -31,6 +33,24 @@ func FieldValueModifiers() []string {
 	}
 }
 
+// field value generators
+const (
+	FieldGeneratorNow      string = "now()"
+	FieldGeneratorUUID     string = "uuid()"
+	FieldGeneratorAuthId   string = "auth.id"
+	FieldGeneratorSequence string = "sequence"
+)
+
+// FieldGenerators returns a list with all available field value generators.
+func FieldGenerators() []string {
+	return []string{
+		FieldGeneratorNow,
+		FieldGeneratorUUID,
+		FieldGeneratorAuthId,
+		FieldGeneratorSequence,
+	}
+}
+
 // commonly used field names
 const (
 	FieldNameId                     string = "id"
@@ -83,17 +103,21 @@ func AuthFieldNames() []string {
 
 // All valid field types
 const (
-	FieldTypeText     string = "text"
-	FieldTypeNumber   string = "number"
-	FieldTypeBool     string = "bool"
-	FieldTypeEmail    string = "email"
-	FieldTypeUrl      string = "url"
-	FieldTypeEditor   string = "editor"
-	FieldTypeDate     string = "date"
-	FieldTypeSelect   string = "select"
-	FieldTypeJson     string = "json"
-	FieldTypeFile     string = "file"
-	FieldTypeRelation string = "relation"
+	FieldTypeText       string = "text"
+	FieldTypeNumber     string = "number"
+	FieldTypeBool       string = "bool"
+	FieldTypeEmail      string = "email"
+	FieldTypeUrl        string = "url"
+	FieldTypeEditor     string = "editor"
+	FieldTypeDate       string = "date"
+	FieldTypeSelect     string = "select"
+	FieldTypeJson       string = "json"
+	FieldTypeFile       string = "file"
+	FieldTypeRelation   string = "relation"
+	FieldTypeGeoPoint   string = "geoPoint"
+	FieldTypeMoney      string = "money"
+	FieldTypeAutonumber string = "autonumber"
+	FieldTypePhone      string = "phone"
 
 	// Deprecated: Will be removed in v0.9+
 	FieldTypeUser string = "user"
@@ -113,6 +137,10 @@ func FieldTypes() []string {
 		FieldTypeJson,
 		FieldTypeFile,
 		FieldTypeRelation,
+		FieldTypeGeoPoint,
+		FieldTypeMoney,
+		FieldTypeAutonumber,
+		FieldTypePhone,
 	}
 }
 
@@ -137,6 +165,16 @@ type SchemaField struct {
 	// visualization purposes (eg. in the Admin UI relation views).
 	Presentable bool `form:"presentable" json:"presentable"`
 
+	// Default is an optional static value that is assigned to the
+	// field on record create when the client omits it.
+	Default any `form:"default" json:"default,omitempty"`
+
+	// Generator is an optional server-side value generator (see [FieldGenerators])
+	// that is applied on record create when the client omits the field.
+	//
+	// It cannot be combined with Default.
+	Generator string `form:"generator" json:"generator,omitempty"`
+
 	// Deprecated: This field is no-op and will be removed in future versions.
 	// Please use the collection.Indexes field to define a unique constraint.
 	Unique bool `form:"unique" json:"unique"`
@@ -153,6 +191,10 @@ func (f *SchemaField) ColDefinition() string {
 		return "BOOLEAN DEFAULT FALSE NOT NULL"
 	case FieldTypeJson:
 		return "JSON DEFAULT NULL"
+	case FieldTypeGeoPoint:
+		return `JSON DEFAULT '{"lat":0,"lng":0}' NOT NULL`
+	case FieldTypeMoney:
+		return "INTEGER DEFAULT 0 NOT NULL"
 	default:
 		if opt, ok := f.Options.(MultiValuer); ok && opt.IsMultiple() {
 			return "JSON DEFAULT '[]' NOT NULL"
@@ -218,9 +260,131 @@ func (f SchemaField) Validate() error {
 		// currently file fields cannot be unique because a proper
 		// hash/content check could cause performance issues
 		validation.Field(&f.Unique, validation.When(f.Type == FieldTypeFile, validation.Empty)),
+		validation.Field(
+			&f.Default,
+			validation.When(f.Type == FieldTypeFile || f.Type == FieldTypeAutonumber || f.Generator != "", validation.Empty),
+			validation.By(f.checkDefault),
+		),
+		validation.Field(
+			&f.Generator,
+			validation.In(list.ToInterfaceSlice(FieldGenerators())...),
+			validation.By(f.checkGenerator),
+		),
 	)
 }
 
+// checkGenerator checks whether the field generator is compatible with the field type.
+func (f *SchemaField) checkGenerator(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil
+	}
+
+	var allowedTypes []string
+
+	switch v {
+	case FieldGeneratorNow:
+		allowedTypes = []string{FieldTypeDate, FieldTypeText}
+	case FieldGeneratorUUID:
+		allowedTypes = []string{FieldTypeText}
+	case FieldGeneratorAuthId:
+		allowedTypes = []string{FieldTypeText, FieldTypeRelation}
+	case FieldGeneratorSequence:
+		allowedTypes = []string{FieldTypeNumber}
+	}
+
+	if !list.ExistInSlice(f.Type, allowedTypes) {
+		return validation.NewError(
+			"validation_invalid_generator",
+			"The generator is not supported by the field type.",
+		)
+	}
+
+	return nil
+}
+
+// checkDefault checks whether the field default value is compatible
+// with the field type and satisfies the field options constraints.
+func (f *SchemaField) checkDefault(value any) error {
+	if value == nil || f.Type == FieldTypeFile || f.Type == FieldTypeAutonumber || f.Generator != "" {
+		return nil // nothing to check or already handled by the Empty rule
+	}
+
+	if !f.isDefaultTypeCompatible(value) {
+		return validation.NewError(
+			"validation_invalid_default",
+			"The default value is not compatible with the field type.",
+		)
+	}
+
+	// the options are validated separately
+	if _, ok := f.Options.(FieldOptions); !ok {
+		return nil
+	}
+
+	return f.ValidateValue(f.PrepareValue(value))
+}
+
+// isDefaultTypeCompatible reports whether the raw default value
+// could be converted to the field type without losing information.
+func (f *SchemaField) isDefaultTypeCompatible(value any) bool {
+	switch f.Type {
+	case FieldTypeText, FieldTypeEmail, FieldTypeUrl, FieldTypeEditor, FieldTypePhone:
+		_, ok := value.(string)
+		return ok
+	case FieldTypeNumber:
+		switch v := value.(type) {
+		case bool:
+			return false
+		case string:
+			_, err := strconv.ParseFloat(v, 64)
+			return err == nil
+		default:
+			_, err := cast.ToFloat64E(v)
+			return err == nil
+		}
+	case FieldTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return true
+		case string:
+			_, err := strconv.ParseBool(v)
+			return err == nil
+		default:
+			return false
+		}
+	case FieldTypeDate:
+		dt, err := types.ParseDateTime(value)
+		return err == nil && !dt.IsZero()
+	case FieldTypeGeoPoint:
+		_, err := types.ParseGeoPoint(value)
+		return err == nil
+	case FieldTypeMoney:
+		options, _ := f.Options.(*MoneyOptions)
+		if options == nil {
+			return false
+		}
+		_, err := types.ParseMoney(value, options.Currency)
+		return err == nil
+	case FieldTypeSelect, FieldTypeRelation:
+		switch v := value.(type) {
+		case string, []string:
+			return true
+		case []any:
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return false
+				}
+			}
+			return true
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
 func (f *SchemaField) checkOptions(value any) error {
 	v, ok := value.(FieldOptions)
 	if !ok {
@@ -281,6 +445,14 @@ func (f *SchemaField) InitOptions() error {
 		options = &FileOptions{}
 	case FieldTypeRelation:
 		options = &RelationOptions{}
+	case FieldTypeGeoPoint:
+		options = &GeoPointOptions{}
+	case FieldTypeMoney:
+		options = &MoneyOptions{}
+	case FieldTypeAutonumber:
+		options = &AutonumberOptions{}
+	case FieldTypePhone:
+		options = &PhoneOptions{}
 
 	// Deprecated: Will be removed in v0.9+
 	case FieldTypeUser:
@@ -305,7 +477,7 @@ func (f *SchemaField) PrepareValue(value any) any {
 	f.InitOptions()
 
 	switch f.Type {
-	case FieldTypeText, FieldTypeEmail, FieldTypeUrl, FieldTypeEditor:
+	case FieldTypeText, FieldTypeEmail, FieldTypeUrl, FieldTypeEditor, FieldTypeAutonumber:
 		return cast.ToString(value)
 	case FieldTypeJson:
 		val := value
@@ -346,6 +518,30 @@ func (f *SchemaField) PrepareValue(value any) any {
 	case FieldTypeDate:
 		val, _ := types.ParseDateTime(value)
 		return val
+	case FieldTypeGeoPoint:
+		val, _ := types.ParseGeoPoint(value)
+		return val
+	case FieldTypeMoney:
+		options, _ := f.Options.(*MoneyOptions)
+		if options == nil {
+			options = &MoneyOptions{}
+		}
+		val, err := types.ParseMoney(value, options.Currency)
+		if err != nil {
+			return types.NewMoney(0, options.Currency)
+		}
+		return val
+	case FieldTypePhone:
+		options, _ := f.Options.(*PhoneOptions)
+		if options == nil {
+			options = &PhoneOptions{}
+		}
+		raw := strings.TrimSpace(cast.ToString(value))
+		val, err := types.ParsePhoneNumber(raw, options.DefaultCountry)
+		if err != nil {
+			return raw // leave it as it is for the value validator
+		}
+		return val.E164()
 	case FieldTypeSelect:
 		val := list.ToUniqueStringSlice(value)
 
@@ -400,6 +596,23 @@ func (f *SchemaField) PrepareValueWithModifier(baseValue any, modifier string, m
 		case FieldValueModifierSubtract:
 			resolvedValue = cast.ToFloat64(baseValue) - cast.ToFloat64(modifierValue)
 		}
+	case FieldTypeMoney:
+		base, _ := f.PrepareValue(baseValue).(types.Money)
+		delta, err := types.ParseMoney(modifierValue, base.Currency)
+		if err != nil {
+			break
+		}
+
+		switch modifier {
+		case FieldValueModifierAdd:
+			if v, err := base.Add(delta); err == nil {
+				resolvedValue = v
+			}
+		case FieldValueModifierSubtract:
+			if v, err := base.Sub(delta); err == nil {
+				resolvedValue = v
+			}
+		}
 	case FieldTypeSelect, FieldTypeRelation:
 		switch modifier {
 		case FieldValueModifierAdd:
@@ -639,6 +852,184 @@ func (o JsonOptions) Validate() error {
 
 // -------------------------------------------------------------------
 
+type GeoPointOptions struct {
+}
+
+func (o GeoPointOptions) Validate() error {
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// Money field json formats (see [MoneyOptions.Format]).
+const (
+	MoneyFormatObject  string = "object"
+	MoneyFormatMinor   string = "minor"
+	MoneyFormatDecimal string = "decimal"
+)
+
+var moneyCurrencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type MoneyOptions struct {
+	// Currency is the ISO 4217 code of the field values (eg. "USD").
+	Currency string `form:"currency" json:"currency"`
+
+	// Min and Max are optional amount constraints in minor units.
+	Min *int64 `form:"min" json:"min"`
+	Max *int64 `form:"max" json:"max"`
+
+	// Format specifies how the field value is serialized in the record json:
+	//   - "object" (default) - {"amount":1234,"currency":"USD"}
+	//   - "minor" - the integer amount in minor units (eg. 1234)
+	//   - "decimal" - the amount in major units as string (eg. "12.34")
+	Format string `form:"format" json:"format"`
+}
+
+func (o MoneyOptions) Validate() error {
+	var maxRules []validation.Rule
+	if o.Min != nil && o.Max != nil {
+		maxRules = append(maxRules, validation.Min(*o.Min))
+	}
+
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Currency, validation.Required, validation.Match(moneyCurrencyRegex)),
+		validation.Field(&o.Max, maxRules...),
+		validation.Field(&o.Format, validation.In(MoneyFormatObject, MoneyFormatMinor, MoneyFormatDecimal)),
+	)
+}
+
+// Export returns the provided money value serialized
+// according to the configured json [MoneyOptions.Format].
+func (o MoneyOptions) Export(value types.Money) any {
+	switch o.Format {
+	case MoneyFormatMinor:
+		return value.Amount
+	case MoneyFormatDecimal:
+		return value.Decimal()
+	default:
+		return value
+	}
+}
+
+// -------------------------------------------------------------------
+
+// AutonumberYearPlaceholder is the [AutonumberOptions.Prefix]
+// placeholder that is replaced with the record creation year.
+const AutonumberYearPlaceholder string = "{year}"
+
+type AutonumberOptions struct {
+	// Prefix is an optional static prefix of the generated values (eg. "POL-{year}-").
+	//
+	// The "{year}" placeholder is replaced with the record creation year.
+	Prefix string `form:"prefix" json:"prefix"`
+
+	// Padding is the min number of the counter digits
+	// (eg. 6 for "000123", 0 means no padding).
+	Padding int `form:"padding" json:"padding"`
+
+	// ScopeField is an optional name of a single value field from the same
+	// collection (eg. an "organisation" relation) that scopes the counter,
+	// aka. the records with different ScopeField value have separate counters.
+	ScopeField string `form:"scopeField" json:"scopeField"`
+
+	// ResetYearly indicates whether to start a new counter each year.
+	ResetYearly bool `form:"resetYearly" json:"resetYearly"`
+}
+
+func (o AutonumberOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Prefix, validation.Length(0, 100)),
+		validation.Field(&o.Padding, validation.Min(0), validation.Max(18)),
+		validation.Field(&o.ScopeField, validation.Length(1, 255), validation.Match(schemaFieldNameRegex)),
+	)
+}
+
+// Format returns the field value for the provided counter value
+// and record creation date (eg. "POL-2025-000123").
+func (o AutonumberOptions) Format(counter int64, created time.Time) string {
+	prefix := strings.ReplaceAll(o.Prefix, AutonumberYearPlaceholder, strconv.Itoa(created.Year()))
+
+	return prefix + fmt.Sprintf("%0*d", o.Padding, counter)
+}
+
+// -------------------------------------------------------------------
+
+// Phone field json formats (see [PhoneOptions.Format]).
+const (
+	PhoneFormatE164          string = "e164"
+	PhoneFormatInternational string = "international"
+	PhoneFormatNational      string = "national"
+	PhoneFormatObject        string = "object"
+)
+
+type PhoneOptions struct {
+	// DefaultCountry is the ISO 3166-1 alpha-2 region code (eg. "FR")
+	// used to normalize the numbers submitted without country calling code.
+	//
+	// If not set, only numbers in international format are accepted.
+	DefaultCountry string `form:"defaultCountry" json:"defaultCountry"`
+
+	// OnlyCountries optionally restricts the allowed numbers regions.
+	OnlyCountries []string `form:"onlyCountries" json:"onlyCountries"`
+
+	// Format specifies how the field value is serialized in the record json:
+	//   - "e164" (default) - "+12025550143"
+	//   - "international" - "+1 202 555 0143"
+	//   - "national" - "(202) 555-0143"
+	//   - "object" - {"e164":"...","international":"...","national":"...","country":"US"}
+	Format string `form:"format" json:"format"`
+}
+
+func (o PhoneOptions) Validate() error {
+	regions := list.ToInterfaceSlice(types.PhoneRegions())
+
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.DefaultCountry, validation.In(regions...)),
+		validation.Field(&o.OnlyCountries, validation.Each(validation.In(regions...))),
+		validation.Field(
+			&o.Format,
+			validation.In(PhoneFormatE164, PhoneFormatInternational, PhoneFormatNational, PhoneFormatObject),
+		),
+	)
+}
+
+// Export returns the provided E.164 phone number serialized
+// according to the configured json [PhoneOptions.Format].
+//
+// Values that are not valid phone numbers are returned as they are
+// (empty values are exported as null with the "object" format).
+func (o PhoneOptions) Export(value string) any {
+	phone, err := types.ParsePhoneNumber(value, o.DefaultCountry)
+	if err != nil {
+		return value
+	}
+
+	if phone.IsZero() {
+		if o.Format == PhoneFormatObject {
+			return nil
+		}
+		return ""
+	}
+
+	switch o.Format {
+	case PhoneFormatInternational:
+		return phone.International()
+	case PhoneFormatNational:
+		return phone.National()
+	case PhoneFormatObject:
+		return map[string]any{
+			"e164":          phone.E164(),
+			"international": phone.International(),
+			"national":      phone.National(),
+			"country":       phone.Region,
+		}
+	default:
+		return phone.E164()
+	}
+}
+
+// -------------------------------------------------------------------
+
 var _ MultiValuer = (*FileOptions)(nil)
 
 type FileOptions struct {
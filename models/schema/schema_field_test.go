@@ -3,6 +3,7 @@ package schema_test
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,7 +41,7 @@ func TestAuthFieldNames(t *testing.T) {
 
 func TestFieldTypes(t *testing.T) {
 	result := schema.FieldTypes()
-	expected := 11
+	expected := 15
 
 	if len(result) != expected {
 		t.Fatalf("Expected %d types, got %d (%v)", expected, len(result), result)
@@ -56,6 +57,15 @@ func TestArraybleFieldTypes(t *testing.T) {
 	}
 }
 
+func TestFieldGenerators(t *testing.T) {
+	result := schema.FieldGenerators()
+	expected := 4
+
+	if len(result) != expected {
+		t.Fatalf("Expected %d generators, got %d (%v)", expected, len(result), result)
+	}
+}
+
 func TestSchemaFieldColDefinition(t *testing.T) {
 	scenarios := []struct {
 		field    schema.SchemaField
@@ -117,6 +127,22 @@ func TestSchemaFieldColDefinition(t *testing.T) {
 			schema.SchemaField{Type: schema.FieldTypeRelation, Name: "test_multiple", Options: &schema.RelationOptions{MaxSelect: nil}},
 			"JSON DEFAULT '[]' NOT NULL",
 		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeGeoPoint, Name: "test"},
+			`JSON DEFAULT '{"lat":0,"lng":0}' NOT NULL`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeMoney, Name: "test"},
+			"INTEGER DEFAULT 0 NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeAutonumber, Name: "test"},
+			"TEXT DEFAULT '' NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypePhone, Name: "test"},
+			"TEXT DEFAULT '' NOT NULL",
+		},
 	}
 
 	for i, s := range scenarios {
@@ -184,6 +210,16 @@ func TestSchemaFieldMarshalJSON(t *testing.T) {
 			},
 			`{"system":true,"id":"","name":"test","type":"text","required":true,"presentable":false,"unique":false,"options":{"min":null,"max":null,"pattern":"test"}}`,
 		},
+		// with default value and generator
+		{
+			schema.SchemaField{
+				Name:      "test",
+				Type:      schema.FieldTypeText,
+				Default:   "abc",
+				Generator: schema.FieldGeneratorUUID,
+			},
+			`{"system":false,"id":"","name":"test","type":"text","required":false,"presentable":false,"default":"abc","generator":"uuid()","unique":false,"options":{"min":null,"max":null,"pattern":""}}`,
+		},
 	}
 
 	for i, s := range scenarios {
@@ -234,6 +270,11 @@ func TestSchemaFieldUnmarshalJSON(t *testing.T) {
 			false,
 			`{"system":false,"id":"","name":"","type":"text","required":false,"presentable":false,"unique":false,"options":{"min":null,"max":null,"pattern":"test"}}`,
 		},
+		{
+			[]byte(`{"type":"number","default":5,"generator":"sequence"}`),
+			false,
+			`{"system":false,"id":"","name":"","type":"number","required":false,"presentable":false,"default":5,"generator":"sequence","unique":false,"options":{"min":null,"max":null,"noDecimal":false}}`,
+		},
 	}
 
 	for i, s := range scenarios {
@@ -417,6 +458,131 @@ func TestSchemaFieldValidate(t *testing.T) {
 			},
 			[]string{"unique"},
 		},
+		{
+			"default value for type file",
+			schema.SchemaField{
+				Type:    schema.FieldTypeFile,
+				Id:      "1234567890",
+				Name:    "test",
+				Default: "abc.txt",
+				Options: &schema.FileOptions{MaxSelect: 1, MaxSize: 1},
+			},
+			[]string{"default"},
+		},
+		{
+			"default value with generator",
+			schema.SchemaField{
+				Type:      schema.FieldTypeText,
+				Id:        "1234567890",
+				Name:      "test",
+				Default:   "abc",
+				Generator: schema.FieldGeneratorUUID,
+			},
+			[]string{"default"},
+		},
+		{
+			"default value for autonumber field",
+			schema.SchemaField{
+				Type:    schema.FieldTypeAutonumber,
+				Id:      "1234567890",
+				Name:    "test",
+				Default: "abc",
+			},
+			[]string{"default"},
+		},
+		{
+			"autonumber field generator",
+			schema.SchemaField{
+				Type:      schema.FieldTypeAutonumber,
+				Id:        "1234567890",
+				Name:      "test",
+				Generator: schema.FieldGeneratorSequence,
+			},
+			[]string{"generator"},
+		},
+		{
+			"valid default value",
+			schema.SchemaField{
+				Type:    schema.FieldTypeText,
+				Id:      "1234567890",
+				Name:    "test",
+				Default: "abc",
+			},
+			[]string{},
+		},
+		{
+			"default value incompatible with the field type",
+			schema.SchemaField{
+				Type:    schema.FieldTypeNumber,
+				Id:      "1234567890",
+				Name:    "test",
+				Default: "abc",
+			},
+			[]string{"default"},
+		},
+		{
+			"default value violating the field options",
+			schema.SchemaField{
+				Type:    schema.FieldTypeSelect,
+				Id:      "1234567890",
+				Name:    "test",
+				Default: "c",
+				Options: &schema.SelectOptions{MaxSelect: 1, Values: []string{"a", "b"}},
+			},
+			[]string{"default"},
+		},
+		{
+			"valid default value for type select",
+			schema.SchemaField{
+				Type:    schema.FieldTypeSelect,
+				Id:      "1234567890",
+				Name:    "test",
+				Default: "b",
+				Options: &schema.SelectOptions{MaxSelect: 1, Values: []string{"a", "b"}},
+			},
+			[]string{},
+		},
+		{
+			"valid numeric default value",
+			schema.SchemaField{
+				Type:    schema.FieldTypeNumber,
+				Id:      "1234567890",
+				Name:    "test",
+				Default: 10,
+				Options: &schema.NumberOptions{Max: types.Pointer(20.0)},
+			},
+			[]string{},
+		},
+		{
+			"unknown generator",
+			schema.SchemaField{
+				Type:      schema.FieldTypeText,
+				Id:        "1234567890",
+				Name:      "test",
+				Generator: "random()",
+			},
+			[]string{"generator"},
+		},
+		{
+			"generator not supported by the field type",
+			schema.SchemaField{
+				Type:      schema.FieldTypeText,
+				Id:        "1234567890",
+				Name:      "test",
+				Generator: schema.FieldGeneratorSequence,
+			},
+			[]string{"generator"},
+		},
+		{
+			"valid generator",
+			schema.SchemaField{
+				Type:      schema.FieldTypeNumber,
+				Id:        "1234567890",
+				Name:      "test",
+				Generator: schema.FieldGeneratorSequence,
+			},
+			[]string{},
+		},
 		{
 			"trigger options validator (auto init)",
 			schema.SchemaField{
@@ -546,6 +712,26 @@ func TestSchemaFieldInitOptions(t *testing.T) {
 			false,
 			`{"system":false,"id":"","name":"","type":"user","required":false,"presentable":false,"unique":false,"options":{"maxSelect":0,"cascadeDelete":false}}`,
 		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeGeoPoint},
+			false,
+			`{"system":false,"id":"","name":"","type":"geoPoint","required":false,"presentable":false,"unique":false,"options":{}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeMoney},
+			false,
+			`{"system":false,"id":"","name":"","type":"money","required":false,"presentable":false,"unique":false,"options":{"currency":"","min":null,"max":null,"format":""}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeAutonumber},
+			false,
+			`{"system":false,"id":"","name":"","type":"autonumber","required":false,"presentable":false,"unique":false,"options":{"prefix":"","padding":0,"scopeField":"","resetYearly":false}}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypePhone},
+			false,
+			`{"system":false,"id":"","name":"","type":"phone","required":false,"presentable":false,"unique":false,"options":{"defaultCountry":"","onlyCountries":null,"format":""}}`,
+		},
 		{
 			schema.SchemaField{
 				Type:    schema.FieldTypeText,
@@ -641,6 +827,37 @@ func TestSchemaFieldPrepareValue(t *testing.T) {
 		{schema.SchemaField{Type: schema.FieldTypeJson}, []int{1, 2, 1}, `[1,2,1]`},
 		{schema.SchemaField{Type: schema.FieldTypeJson}, `[1,2,1]`, `[1,2,1]`},
 
+		// geoPoint
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, nil, `{"lat":0,"lng":0}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, "", `{"lat":0,"lng":0}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, "invalid", `{"lat":0,"lng":0}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, `{"lat":42.5,"lng":-23.1}`, `{"lat":42.5,"lng":-23.1}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, map[string]any{"lat": 1, "lng": 2.5}, `{"lat":1,"lng":2.5}`},
+		{schema.SchemaField{Type: schema.FieldTypeGeoPoint}, types.GeoPoint{Lat: 10, Lng: 20}, `{"lat":10,"lng":20}`},
+
+		// money
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, nil, `{"amount":0,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, "", `{"amount":0,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, "invalid", `{"amount":0,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, 1234, `{"amount":1234,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, "1234", `{"amount":1234,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, "12.5", `{"amount":1250,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, "12.345", `{"amount":0,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, map[string]any{"amount": 5, "currency": "usd"}, `{"amount":5,"currency":"USD"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}}, types.NewMoney(5, "EUR"), `{"amount":0,"currency":"USD"}`},
+
+		// autonumber
+		{schema.SchemaField{Type: schema.FieldTypeAutonumber}, nil, `""`},
+		{schema.SchemaField{Type: schema.FieldTypeAutonumber}, "POL-000123", `"POL-000123"`},
+		{schema.SchemaField{Type: schema.FieldTypeAutonumber}, 123, `"123"`},
+
+		// phone
+		{schema.SchemaField{Type: schema.FieldTypePhone}, nil, `""`},
+		{schema.SchemaField{Type: schema.FieldTypePhone}, " +1 (202) 555-0143 ", `"+12025550143"`},
+		{schema.SchemaField{Type: schema.FieldTypePhone}, "06 12 34 56 78", `"06 12 34 56 78"`},
+		{schema.SchemaField{Type: schema.FieldTypePhone, Options: &schema.PhoneOptions{DefaultCountry: "FR"}}, "06 12 34 56 78", `"+33612345678"`},
+		{schema.SchemaField{Type: schema.FieldTypePhone, Options: &schema.PhoneOptions{DefaultCountry: "FR"}}, " invalid ", `"invalid"`},
+
 		// number
 		{schema.SchemaField{Type: schema.FieldTypeNumber}, nil, "0"},
 		{schema.SchemaField{Type: schema.FieldTypeNumber}, "", "0"},
@@ -1012,6 +1229,40 @@ func TestSchemaFieldPrepareValueWithModifier(t *testing.T) {
 			`4`,
 		},
 
+		// money
+		{
+			"money with '+' modifier",
+			schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}},
+			1000,
+			"+",
+			"2.5",
+			`{"amount":1250,"currency":"USD"}`,
+		},
+		{
+			"money with '-' modifier",
+			schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}},
+			"1000",
+			"-",
+			1250,
+			`{"amount":-250,"currency":"USD"}`,
+		},
+		{
+			"money with unknown modifier",
+			schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}},
+			1000,
+			"?",
+			250,
+			`{"amount":1000,"currency":"USD"}`,
+		},
+		{
+			"money with invalid modifier value",
+			schema.SchemaField{Type: schema.FieldTypeMoney, Options: &schema.MoneyOptions{Currency: "USD"}},
+			1000,
+			"+",
+			types.NewMoney(250, "EUR"),
+			`{"amount":1000,"currency":"USD"}`,
+		},
+
 		// bool
 		{
 			"bool with '+' modifier",
@@ -2086,6 +2337,170 @@ func TestJsonOptionsValidate(t *testing.T) {
 	checkFieldOptionsScenarios(t, scenarios)
 }
 
+func TestMoneyOptionsValidate(t *testing.T) {
+	amount1 := int64(10)
+	amount2 := int64(20)
+
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.MoneyOptions{},
+			[]string{"currency"},
+		},
+		{
+			"invalid currency and format",
+			schema.MoneyOptions{Currency: "usd", Format: "invalid"},
+			[]string{"currency", "format"},
+		},
+		{
+			"max - failure with min",
+			schema.MoneyOptions{Currency: "USD", Min: &amount2, Max: &amount1},
+			[]string{"max"},
+		},
+		{
+			"valid",
+			schema.MoneyOptions{Currency: "USD", Min: &amount1, Max: &amount2, Format: schema.MoneyFormatDecimal},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestMoneyOptionsExport(t *testing.T) {
+	value := types.NewMoney(1234, "USD")
+
+	scenarios := []struct {
+		format   string
+		expected string
+	}{
+		{"", `{"amount":1234,"currency":"USD"}`},
+		{schema.MoneyFormatObject, `{"amount":1234,"currency":"USD"}`},
+		{schema.MoneyFormatMinor, `1234`},
+		{schema.MoneyFormatDecimal, `"12.34"`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.format, func(t *testing.T) {
+			options := schema.MoneyOptions{Currency: "USD", Format: s.format}
+
+			raw, err := json.Marshal(options.Export(value))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, raw)
+			}
+		})
+	}
+}
+
+func TestPhoneOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.PhoneOptions{},
+			[]string{},
+		},
+		{
+			"invalid countries and format",
+			schema.PhoneOptions{DefaultCountry: "fr", OnlyCountries: []string{"FR", "XX"}, Format: "invalid"},
+			[]string{"defaultCountry", "onlyCountries", "format"},
+		},
+		{
+			"valid",
+			schema.PhoneOptions{DefaultCountry: "FR", OnlyCountries: []string{"FR", "BE"}, Format: schema.PhoneFormatObject},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestPhoneOptionsExport(t *testing.T) {
+	scenarios := []struct {
+		format   string
+		value    string
+		expected string
+	}{
+		{"", "+12025550143", `"+12025550143"`},
+		{"", "", `""`},
+		{"", "invalid", `"invalid"`},
+		{schema.PhoneFormatE164, "+12025550143", `"+12025550143"`},
+		{schema.PhoneFormatInternational, "+12025550143", `"+1 202 555 0143"`},
+		{schema.PhoneFormatNational, "+12025550143", `"(202) 555-0143"`},
+		{schema.PhoneFormatObject, "+12025550143", `{"country":"US","e164":"+12025550143","international":"+1 202 555 0143","national":"(202) 555-0143"}`},
+		{schema.PhoneFormatObject, "", `null`},
+		{schema.PhoneFormatObject, "invalid", `"invalid"`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.format+"_"+s.value, func(t *testing.T) {
+			options := schema.PhoneOptions{Format: s.format}
+
+			raw, err := json.Marshal(options.Export(s.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, raw)
+			}
+		})
+	}
+}
+
+func TestAutonumberOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.AutonumberOptions{},
+			[]string{},
+		},
+		{
+			"invalid padding and scope field",
+			schema.AutonumberOptions{Padding: 19, ScopeField: "a-b"},
+			[]string{"padding", "scopeField"},
+		},
+		{
+			"negative padding and too long prefix",
+			schema.AutonumberOptions{Prefix: strings.Repeat("a", 101), Padding: -1},
+			[]string{"prefix", "padding"},
+		},
+		{
+			"valid",
+			schema.AutonumberOptions{Prefix: "POL-{year}-", Padding: 6, ScopeField: "organisation", ResetYearly: true},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestAutonumberOptionsFormat(t *testing.T) {
+	created := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		options  schema.AutonumberOptions
+		counter  int64
+		expected string
+	}{
+		{schema.AutonumberOptions{}, 1, "1"},
+		{schema.AutonumberOptions{Padding: 3}, 7, "007"},
+		{schema.AutonumberOptions{Padding: 3}, 12345, "12345"},
+		{schema.AutonumberOptions{Prefix: "INV-"}, 42, "INV-42"},
+		{schema.AutonumberOptions{Prefix: "POL-{year}-", Padding: 6}, 123, "POL-2025-000123"},
+		{schema.AutonumberOptions{Prefix: "{year}/{year}/", Padding: 2}, 5, "2025/2025/05"},
+	}
+
+	for i, s := range scenarios {
+		if result := s.options.Format(s.counter, created); result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
 func TestFileOptionsValidate(t *testing.T) {
 	scenarios := []fieldOptionsScenario{
 		{
@@ -0,0 +1,352 @@
+package schema
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var requiredValueErr = validation.NewError("validation_required", "Missing required value")
+
+var emptyJsonValues = []string{
+	"null", `""`, "[]", "{}",
+}
+
+// ValidateValue checks a single normalized field value (see [SchemaField.PrepareValue])
+// against the field required state and type specific options.
+//
+// Checks that require external state (eg. the existence of the related
+// records or the uploaded files size) are not part of this method.
+func (f *SchemaField) ValidateValue(value any) error {
+	// init field options (if not already)
+	f.InitOptions()
+
+	if f.Required && validation.Required.Validate(value) != nil {
+		return requiredValueErr
+	}
+
+	switch f.Type {
+	case FieldTypeText:
+		return f.validateTextValue(value)
+	case FieldTypeNumber:
+		return f.validateNumberValue(value)
+	case FieldTypeEmail:
+		return f.validateEmailValue(value)
+	case FieldTypeUrl:
+		return f.validateUrlValue(value)
+	case FieldTypeDate:
+		return f.validateDateValue(value)
+	case FieldTypeSelect:
+		return f.validateSelectValue(value)
+	case FieldTypeJson:
+		return f.validateJsonValue(value)
+	case FieldTypeFile:
+		return f.validateFileValue(value)
+	case FieldTypeRelation:
+		return f.validateRelationValue(value)
+	case FieldTypeGeoPoint:
+		return f.validateGeoPointValue(value)
+	case FieldTypeMoney:
+		return f.validateMoneyValue(value)
+	case FieldTypePhone:
+		return f.validatePhoneValue(value)
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateTextValue(value any) error {
+	val, _ := value.(string)
+	if val == "" {
+		return nil // nothing to check (skip zero-defaults)
+	}
+
+	options, _ := f.Options.(*TextOptions)
+
+	// note: casted to []rune to count multi-byte chars as one
+	length := len([]rune(val))
+
+	if options.Min != nil && length < *options.Min {
+		return validation.NewError("validation_min_text_constraint", fmt.Sprintf("Must be at least %d character(s)", *options.Min))
+	}
+
+	if options.Max != nil && length > *options.Max {
+		return validation.NewError("validation_max_text_constraint", fmt.Sprintf("Must be less than %d character(s)", *options.Max))
+	}
+
+	if options.Pattern != "" {
+		match, _ := regexp.MatchString(options.Pattern, val)
+		if !match {
+			return validation.NewError("validation_invalid_format", "Invalid value format")
+		}
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateNumberValue(value any) error {
+	val, _ := value.(float64)
+	if val == 0 {
+		return nil // nothing to check (skip zero-defaults)
+	}
+
+	options, _ := f.Options.(*NumberOptions)
+
+	if options.NoDecimal && val != float64(int64(val)) {
+		return validation.NewError("validation_no_decimal_constraint", "Decimal numbers are not allowed")
+	}
+
+	if options.Min != nil && val < *options.Min {
+		return validation.NewError("validation_min_number_constraint", fmt.Sprintf("Must be larger than %f", *options.Min))
+	}
+
+	if options.Max != nil && val > *options.Max {
+		return validation.NewError("validation_max_number_constraint", fmt.Sprintf("Must be less than %f", *options.Max))
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateEmailValue(value any) error {
+	val, _ := value.(string)
+	if val == "" {
+		return nil // nothing to check
+	}
+
+	if is.EmailFormat.Validate(val) != nil {
+		return validation.NewError("validation_invalid_email", "Must be a valid email")
+	}
+
+	options, _ := f.Options.(*EmailOptions)
+	domain := val[strings.LastIndex(val, "@")+1:]
+
+	// only domains check
+	if len(options.OnlyDomains) > 0 && !list.ExistInSlice(domain, options.OnlyDomains) {
+		return validation.NewError("validation_email_domain_not_allowed", "Email domain is not allowed")
+	}
+
+	// except domains check
+	if len(options.ExceptDomains) > 0 && list.ExistInSlice(domain, options.ExceptDomains) {
+		return validation.NewError("validation_email_domain_not_allowed", "Email domain is not allowed")
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateUrlValue(value any) error {
+	val, _ := value.(string)
+	if val == "" {
+		return nil // nothing to check
+	}
+
+	if is.URL.Validate(val) != nil {
+		return validation.NewError("validation_invalid_url", "Must be a valid url")
+	}
+
+	options, _ := f.Options.(*UrlOptions)
+
+	// extract host/domain
+	u, _ := url.Parse(val)
+	host := u.Host
+
+	// only domains check
+	if len(options.OnlyDomains) > 0 && !list.ExistInSlice(host, options.OnlyDomains) {
+		return validation.NewError("validation_url_domain_not_allowed", "Url domain is not allowed")
+	}
+
+	// except domains check
+	if len(options.ExceptDomains) > 0 && list.ExistInSlice(host, options.ExceptDomains) {
+		return validation.NewError("validation_url_domain_not_allowed", "Url domain is not allowed")
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateDateValue(value any) error {
+	val, _ := value.(types.DateTime)
+	if val.IsZero() {
+		if f.Required {
+			return requiredValueErr
+		}
+		return nil // nothing to check
+	}
+
+	options, _ := f.Options.(*DateOptions)
+
+	if !options.Min.IsZero() {
+		if err := validation.Min(options.Min.Time()).Validate(val.Time()); err != nil {
+			return err
+		}
+	}
+
+	if !options.Max.IsZero() {
+		if err := validation.Max(options.Max.Time()).Validate(val.Time()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateSelectValue(value any) error {
+	normalizedVal := list.ToUniqueStringSlice(value)
+	if len(normalizedVal) == 0 {
+		if f.Required {
+			return requiredValueErr
+		}
+		return nil // nothing to check
+	}
+
+	options, _ := f.Options.(*SelectOptions)
+
+	// check max selected items
+	if len(normalizedVal) > options.MaxSelect {
+		return validation.NewError("validation_too_many_values", fmt.Sprintf("Select no more than %d", options.MaxSelect))
+	}
+
+	// check against the allowed values
+	for _, val := range normalizedVal {
+		if !list.ExistInSlice(val, options.Values) {
+			return validation.NewError("validation_invalid_value", "Invalid value "+val)
+		}
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateJsonValue(value any) error {
+	if is.JSON.Validate(value) != nil {
+		return validation.NewError("validation_invalid_json", "Must be a valid json value")
+	}
+
+	raw, _ := types.ParseJsonRaw(value)
+
+	options, _ := f.Options.(*JsonOptions)
+
+	if len(raw) > options.MaxSize {
+		return validation.NewError("validation_json_size_limit", fmt.Sprintf("The maximum allowed JSON size is %v bytes", options.MaxSize))
+	}
+
+	rawStr := strings.TrimSpace(raw.String())
+	if f.Required && list.ExistInSlice(rawStr, emptyJsonValues) {
+		return requiredValueErr
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateGeoPointValue(value any) error {
+	val, _ := value.(types.GeoPoint)
+
+	if val.IsZero() {
+		if f.Required {
+			return requiredValueErr
+		}
+		return nil // nothing to check
+	}
+
+	if val.Lat < -90 || val.Lat > 90 {
+		return validation.NewError("validation_invalid_latitude", "The latitude must be between -90 and 90 degrees")
+	}
+
+	if val.Lng < -180 || val.Lng > 180 {
+		return validation.NewError("validation_invalid_longitude", "The longitude must be between -180 and 180 degrees")
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateMoneyValue(value any) error {
+	val, _ := value.(types.Money)
+
+	if val.IsZero() {
+		if f.Required {
+			return requiredValueErr
+		}
+		return nil // nothing to check (skip zero-defaults)
+	}
+
+	options, _ := f.Options.(*MoneyOptions)
+
+	if val.Currency != options.Currency {
+		return validation.NewError("validation_invalid_currency", fmt.Sprintf("The currency must be %s", options.Currency))
+	}
+
+	if options.Min != nil && val.Amount < *options.Min {
+		limit := types.NewMoney(*options.Min, options.Currency)
+		return validation.NewError("validation_min_money_constraint", fmt.Sprintf("Must be at least %s", limit.String()))
+	}
+
+	if options.Max != nil && val.Amount > *options.Max {
+		limit := types.NewMoney(*options.Max, options.Currency)
+		return validation.NewError("validation_max_money_constraint", fmt.Sprintf("Must be at most %s", limit.String()))
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validatePhoneValue(value any) error {
+	val, _ := value.(string)
+	if val == "" {
+		if f.Required {
+			return requiredValueErr
+		}
+		return nil
+	}
+
+	options, _ := f.Options.(*PhoneOptions)
+
+	phone, err := types.ParsePhoneNumber(val, options.DefaultCountry)
+	if err != nil {
+		return validation.NewError("validation_invalid_phone", "Must be a valid phone number")
+	}
+
+	if len(options.OnlyCountries) > 0 && !list.ExistInSlice(phone.Region, options.OnlyCountries) {
+		return validation.NewError("validation_phone_country_not_allowed", "Phone numbers from this country are not allowed")
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateFileValue(value any) error {
+	names := list.ToUniqueStringSlice(value)
+	if len(names) == 0 && f.Required {
+		return requiredValueErr
+	}
+
+	options, _ := f.Options.(*FileOptions)
+
+	if len(names) > options.MaxSelect {
+		return validation.NewError("validation_too_many_values", fmt.Sprintf("Select no more than %d", options.MaxSelect))
+	}
+
+	return nil
+}
+
+func (f *SchemaField) validateRelationValue(value any) error {
+	ids := list.ToUniqueStringSlice(value)
+	if len(ids) == 0 {
+		if f.Required {
+			return requiredValueErr
+		}
+		return nil // nothing to check
+	}
+
+	options, _ := f.Options.(*RelationOptions)
+
+	if options.MinSelect != nil && len(ids) < *options.MinSelect {
+		return validation.NewError("validation_not_enough_values", fmt.Sprintf("Select at least %d", *options.MinSelect))
+	}
+
+	if options.MaxSelect != nil && len(ids) > *options.MaxSelect {
+		return validation.NewError("validation_too_many_values", fmt.Sprintf("Select no more than %d", *options.MaxSelect))
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*Session)(nil)
+
+// Session defines a single auth session (aka. device) of an admin or auth record.
+//
+// The session id is used as family of the refresh tokens issued for the session.
+type Session struct {
+	BaseModel
+
+	// CollectionId is the auth collection id of the session owner
+	// (empty for admins).
+	CollectionId string `db:"collectionId" json:"collectionId"`
+
+	// RecordId is the id of the session owner (auth record or admin).
+	RecordId string `db:"recordId" json:"recordId"`
+
+	UserAgent string         `db:"userAgent" json:"userAgent"`
+	Ip        string         `db:"ip" json:"ip"`
+	LastSeen  types.DateTime `db:"lastSeen" json:"lastSeen"`
+}
+
+func (m *Session) TableName() string {
+	return "_sessions"
+}
+
+// IsAdmin checks whether the session belongs to an admin.
+func (m *Session) IsAdmin() bool {
+	return m.CollectionId == ""
+}
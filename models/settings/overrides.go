@@ -0,0 +1,343 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// Override defines a single settings field override
+// (eg. from an environment variable or a config file).
+type Override struct {
+	// Path is the settings field JSON path (eg. "smtp.host").
+	Path string
+
+	// Value is the JSON compatible field value.
+	Value any
+
+	// Source describes the override origin (eg. "env:PB_SMTP_HOST").
+	Source string
+}
+
+type appliedOverride struct {
+	source string
+	stored any
+	value  any
+}
+
+// EnvName returns the environment variable name of the settings
+// field path (eg. "PB_SMTP_HOST" for "smtp.host" with "PB_" prefix).
+func EnvName(prefix string, path string) string {
+	parts := strings.Split(path, ".")
+
+	for i, part := range parts {
+		parts[i] = strings.ToUpper(inflector.Snakecase(part))
+	}
+
+	return prefix + strings.Join(parts, "_")
+}
+
+// EnvOverrides returns the settings overrides from the provided
+// environment variables (in "KEY=value" format, eg. [os.Environ]).
+//
+// Only the variables matching a settings field [EnvName] are considered.
+// The non-string field values are parsed based on the field type
+// (bool, number or JSON for the arrays and objects).
+func EnvOverrides(prefix string, environ []string) ([]Override, error) {
+	if prefix == "" {
+		return nil, nil
+	}
+
+	fields := leafFields()
+
+	names := make(map[string]string, len(fields))
+	for path := range fields {
+		names[EnvName(prefix, path)] = path
+	}
+
+	result := []Override{}
+
+	for _, kv := range environ {
+		name, raw, _ := strings.Cut(kv, "=")
+
+		path, ok := names[name]
+		if !ok {
+			continue
+		}
+
+		value, err := parseEnvValue(raw, fields[path])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", name, err)
+		}
+
+		result = append(result, Override{Path: path, Value: value, Source: "env:" + name})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result, nil
+}
+
+// FileOverrides returns the settings overrides from a JSON config file
+// with the same (partial) structure as the settings, eg.:
+//
+//	{"meta": {"appName": "Acme"}, "smtp": {"enabled": true, "host": "smtp.example.com"}}
+func FileOverrides(file string) ([]Override, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse the settings file %q: %w", file, err)
+	}
+
+	fields := leafFields()
+	result := []Override{}
+
+	var walk func(prefix string, data map[string]any) error
+	walk = func(prefix string, data map[string]any) error {
+		for key, value := range data {
+			path := prefix + key
+
+			if _, ok := fields[path]; ok {
+				result = append(result, Override{Path: path, Value: value, Source: "file:" + file})
+				continue
+			}
+
+			nested, ok := value.(map[string]any)
+			if !ok || !hasFieldsPrefix(fields, path+".") {
+				return fmt.Errorf("unknown settings field %q in %q", path, file)
+			}
+
+			if err := walk(path+".", nested); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk("", data); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+
+	return result, nil
+}
+
+// ApplyOverrides applies the provided field overrides
+// (the latest override of the same field wins).
+//
+// The overridden stored values are remembered so that they could
+// be restored before persisting the settings (see [Settings.UnresolvedClone]).
+func (s *Settings) ApplyOverrides(overrides []Override) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.overrides = map[string]appliedOverride{}
+
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	data, err := toMap(s)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range overrides {
+		stored := getPath(data, o.Path)
+		if old, ok := s.overrides[o.Path]; ok {
+			stored = old.stored
+		}
+
+		if err := setPath(data, o.Path, o.Value); err != nil {
+			return err
+		}
+
+		s.overrides[o.Path] = appliedOverride{source: o.Source, stored: stored}
+	}
+
+	if err := fromMap(data, s); err != nil {
+		return err
+	}
+
+	// store the normalized effective values
+	data, err = toMap(s)
+	if err != nil {
+		return err
+	}
+	for path, o := range s.overrides {
+		o.value = getPath(data, path)
+		s.overrides[path] = o
+	}
+
+	return nil
+}
+
+// Overrides returns the sources of the overridden fields
+// keyed by their JSON path (eg. {"smtp.host": "env:PB_SMTP_HOST"}).
+func (s *Settings) Overrides() map[string]string {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	result := make(map[string]string, len(s.overrides))
+	for path, o := range s.overrides {
+		result[path] = o.source
+	}
+
+	return result
+}
+
+// restoreOverrides replaces the unchanged overridden field values
+// with their stored ones.
+func (s *Settings) restoreOverrides() error {
+	if len(s.overrides) == 0 {
+		return nil
+	}
+
+	data, err := toMap(s)
+	if err != nil {
+		return err
+	}
+
+	for path, o := range s.overrides {
+		if reflect.DeepEqual(getPath(data, path), o.value) {
+			if err := setPath(data, path, o.stored); err != nil {
+				return err
+			}
+		}
+	}
+
+	return fromMap(data, s)
+}
+
+// -------------------------------------------------------------------
+
+// leafFields returns the default value of all settings
+// leaf fields (aka. non-object fields) keyed by their JSON path.
+func leafFields() map[string]any {
+	result := map[string]any{}
+
+	data, _ := toMap(New())
+
+	var walk func(prefix string, data map[string]any)
+	walk = func(prefix string, data map[string]any) {
+		for key, value := range data {
+			if nested, ok := value.(map[string]any); ok {
+				walk(prefix+key+".", nested)
+			} else {
+				result[prefix+key] = value
+			}
+		}
+	}
+	walk("", data)
+
+	return result
+}
+
+func hasFieldsPrefix(fields map[string]any, prefix string) bool {
+	for path := range fields {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseEnvValue parses the raw env value based on the field default value type.
+func parseEnvValue(raw string, defaultValue any) (any, error) {
+	switch defaultValue.(type) {
+	case string:
+		return raw, nil
+	case bool:
+		return strconv.ParseBool(raw)
+	case float64:
+		return strconv.ParseFloat(raw, 64)
+	default:
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			if defaultValue == nil {
+				return raw, nil // fallback to plain string for the unknown types
+			}
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// toMap marshalizes v (without locking) into a generic JSON map.
+func toMap(v *Settings) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// fromMap loads the generic JSON map into v (without locking).
+func fromMap(data map[string]any, v *Settings) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+func getPath(data map[string]any, path string) any {
+	parts := strings.Split(path, ".")
+
+	for i, part := range parts {
+		if i == len(parts)-1 {
+			return data[part]
+		}
+
+		nested, ok := data[part].(map[string]any)
+		if !ok {
+			return nil
+		}
+		data = nested
+	}
+
+	return nil
+}
+
+func setPath(data map[string]any, path string, value any) error {
+	parts := strings.Split(path, ".")
+
+	for i, part := range parts {
+		if i == len(parts)-1 {
+			data[part] = value
+			return nil
+		}
+
+		nested, ok := data[part].(map[string]any)
+		if !ok {
+			return fmt.Errorf("invalid settings field path %q", path)
+		}
+		data = nested
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+func TestEnvName(t *testing.T) {
+	scenarios := []struct {
+		prefix   string
+		path     string
+		expected string
+	}{
+		{"PB_", "smtp.host", "PB_SMTP_HOST"},
+		{"PB_", "meta.appName", "PB_META_APP_NAME"},
+		{"APP_", "outbound.scripts.blockPrivateIPs", "APP_OUTBOUND_SCRIPTS_BLOCK_PRIVATE_IPS"},
+		{"", "oidc2Auth.clientId", "OIDC2_AUTH_CLIENT_ID"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			result := settings.EnvName(s.prefix, s.path)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	environ := []string{
+		"PB_SMTP_HOST=smtp.example.com",
+		"PB_SMTP_PORT=2525",
+		"PB_SMTP_ENABLED=true",
+		"PB_META_APP_NAME=a=b",
+		"PB_UNKNOWN=123",
+		"OTHER_SMTP_HOST=other",
+	}
+
+	overrides, err := settings.EnvOverrides("PB_", environ)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []settings.Override{
+		{Path: "meta.appName", Value: "a=b", Source: "env:PB_META_APP_NAME"},
+		{Path: "smtp.enabled", Value: true, Source: "env:PB_SMTP_ENABLED"},
+		{Path: "smtp.host", Value: "smtp.example.com", Source: "env:PB_SMTP_HOST"},
+		{Path: "smtp.port", Value: float64(2525), Source: "env:PB_SMTP_PORT"},
+	}
+
+	if len(overrides) != len(expected) {
+		t.Fatalf("Expected %d overrides, got %d: %v", len(expected), len(overrides), overrides)
+	}
+
+	for i, o := range overrides {
+		if o != expected[i] {
+			t.Fatalf("Expected override %d %v, got %v", i, expected[i], o)
+		}
+	}
+
+	// invalid value type
+	if _, err := settings.EnvOverrides("PB_", []string{"PB_SMTP_PORT=abc"}); err == nil {
+		t.Fatal("Expected error for invalid number value, got nil")
+	}
+
+	// disabled
+	overrides, err = settings.EnvOverrides("", environ)
+	if err != nil || len(overrides) != 0 {
+		t.Fatalf("Expected no overrides for empty prefix, got %v (%v)", overrides, err)
+	}
+}
+
+func TestFileOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	validFile := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(validFile, []byte(`{"meta":{"appName":"test"},"smtp":{"port":25}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	unknownFile := filepath.Join(dir, "unknown.json")
+	if err := os.WriteFile(unknownFile, []byte(`{"meta":{"unknown":"test"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	invalidFile := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidFile, []byte(`{`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	overrides, err := settings.FileOverrides(validFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []settings.Override{
+		{Path: "meta.appName", Value: "test", Source: "file:" + validFile},
+		{Path: "smtp.port", Value: float64(25), Source: "file:" + validFile},
+	}
+
+	if len(overrides) != len(expected) {
+		t.Fatalf("Expected %d overrides, got %d: %v", len(expected), len(overrides), overrides)
+	}
+
+	for i, o := range overrides {
+		if o != expected[i] {
+			t.Fatalf("Expected override %d %v, got %v", i, expected[i], o)
+		}
+	}
+
+	for _, file := range []string{unknownFile, invalidFile, filepath.Join(dir, "missing.json")} {
+		if _, err := settings.FileOverrides(file); err == nil {
+			t.Fatalf("Expected error for %q, got nil", file)
+		}
+	}
+}
+
+func TestSettingsApplyOverrides(t *testing.T) {
+	s := settings.New()
+	s.Meta.AppName = "stored"
+	s.Smtp.Host = "stored.example.com"
+
+	err := s.ApplyOverrides([]settings.Override{
+		{Path: "smtp.host", Value: "file.example.com", Source: "file:test.json"},
+		{Path: "smtp.host", Value: "env.example.com", Source: "env:PB_SMTP_HOST"},
+		{Path: "smtp.port", Value: float64(2525), Source: "env:PB_SMTP_PORT"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Smtp.Host != "env.example.com" {
+		t.Fatalf("Expected the env smtp host, got %q", s.Smtp.Host)
+	}
+
+	if s.Smtp.Port != 2525 {
+		t.Fatalf("Expected smtp port 2525, got %d", s.Smtp.Port)
+	}
+
+	sources := s.Overrides()
+	if len(sources) != 2 || sources["smtp.host"] != "env:PB_SMTP_HOST" || sources["smtp.port"] != "env:PB_SMTP_PORT" {
+		t.Fatalf("Unexpected sources %v", sources)
+	}
+
+	// change a non-overridden and an overridden field
+	s.Meta.AppName = "changed"
+	s.Smtp.Port = 587
+
+	unresolved, err := s.UnresolvedClone()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if unresolved.Smtp.Host != "stored.example.com" {
+		t.Fatalf("Expected the stored smtp host to be restored, got %q", unresolved.Smtp.Host)
+	}
+
+	if unresolved.Smtp.Port != 587 {
+		t.Fatalf("Expected the changed smtp port to be kept, got %d", unresolved.Smtp.Port)
+	}
+
+	if unresolved.Meta.AppName != "changed" {
+		t.Fatalf("Expected the changed app name, got %q", unresolved.Meta.AppName)
+	}
+
+	if len(unresolved.Overrides()) != 0 {
+		t.Fatalf("Expected no unresolved clone overrides, got %v", unresolved.Overrides())
+	}
+
+	// invalid path
+	if err := s.ApplyOverrides([]settings.Override{{Path: "meta.appName.invalid", Value: 1}}); err == nil {
+		t.Fatal("Expected error for invalid path, got nil")
+	}
+}
@@ -6,8 +6,11 @@ const (
 	EmailPlaceholderAppUrl    string = "{APP_URL}"
 	EmailPlaceholderToken     string = "{TOKEN}"
 	EmailPlaceholderActionUrl string = "{ACTION_URL}"
+	SmsPlaceholderOtp         string = "{OTP}"
 )
 
+var defaultOtpMessage = "Your " + EmailPlaceholderAppName + " verification code is " + SmsPlaceholderOtp + "."
+
 var defaultVerificationTemplate = EmailTemplate{
 	Subject: "Verify your " + EmailPlaceholderAppName + " email",
 	Body: `<p>Hello,</p>
@@ -2,15 +2,21 @@ package settings_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/auth"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/sms"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -22,16 +28,36 @@ func TestSettingsValidate(t *testing.T) {
 	s.Logs.MaxDays = -10
 	s.Smtp.Enabled = true
 	s.Smtp.Host = ""
+	s.EmailApi.Enabled = true
+	s.Sms.Enabled = true
+	s.Push.FCM.Enabled = true
+	s.Captcha.Enabled = true
 	s.S3.Enabled = true
 	s.S3.Endpoint = "invalid"
+	s.Routes.Rules = []settings.RouteRuleConfig{{Path: ""}}
+	s.AdminNotifications.DigestHour = 24
+	s.StatusPage.CacheMaxAge = -1
+	s.Outbound.ProxyUrl = "invalid url"
+	s.Tracing.Enabled = true
+	s.RealtimeCluster.Enabled = true
+	s.RealtimeCluster.Url = ""
+	s.RecordsCache.TTL = -1
+	s.RealtimeLimits.MaxPerIp = -1
+	s.AdminUI.Path = "invalid path"
+	s.LoadShedding.MaxScripts = -1
+	s.Health.Timeout = 0
+	s.DataSources = []settings.DataSourceConfig{{Name: ""}}
+	s.Pingers = []settings.PingerConfig{{Name: ""}}
 	s.AdminAuthToken.Duration = -10
 	s.AdminPasswordResetToken.Duration = -10
 	s.AdminFileToken.Duration = -10
+	s.AdminRefreshToken.Duration = -10
 	s.RecordAuthToken.Duration = -10
 	s.RecordPasswordResetToken.Duration = -10
 	s.RecordEmailChangeToken.Duration = -10
 	s.RecordVerificationToken.Duration = -10
 	s.RecordFileToken.Duration = -10
+	s.RecordRefreshToken.Duration = -10
 	s.GoogleAuth.Enabled = true
 	s.GoogleAuth.ClientId = ""
 	s.FacebookAuth.Enabled = true
@@ -93,15 +119,34 @@ func TestSettingsValidate(t *testing.T) {
 		`"meta":{`,
 		`"logs":{`,
 		`"smtp":{`,
+		`"emailApi":{`,
+		`"sms":{`,
+		`"push":{`,
+		`"captcha":{`,
 		`"s3":{`,
+		`"routes":{`,
+		`"adminNotifications":{`,
+		`"statusPage":{`,
+		`"outbound":{`,
+		`"tracing":{`,
+		`"realtimeCluster":{`,
+		`"recordsCache":{`,
+		`"realtimeLimits":{`,
+		`"adminUI":{`,
+		`"loadShedding":{`,
+		`"health":{`,
+		`"dataSources":{`,
+		`"pingers":{`,
 		`"adminAuthToken":{`,
 		`"adminPasswordResetToken":{`,
 		`"adminFileToken":{`,
+		`"adminRefreshToken":{`,
 		`"recordAuthToken":{`,
 		`"recordPasswordResetToken":{`,
 		`"recordEmailChangeToken":{`,
 		`"recordVerificationToken":{`,
 		`"recordFileToken":{`,
+		`"recordRefreshToken":{`,
 		`"googleAuth":{`,
 		`"facebookAuth":{`,
 		`"githubAuth":{`,
@@ -267,16 +312,31 @@ func TestSettingsRedactClone(t *testing.T) {
 
 	// secrets
 	s1.Smtp.Password = testSecret
+	s1.EmailApi.ApiKey = testSecret
+	s1.EmailApi.SecretKey = testSecret
+	s1.EmailApi.WebhookToken = testSecret
+	s1.Sms.ApiKey = testSecret
+	s1.Sms.ApiSecret = testSecret
+	s1.Push.FCM.PrivateKey = testSecret
+	s1.Push.APNs.PrivateKey = testSecret
+	s1.Push.WebPush.VapidPrivateKey = testSecret
+	s1.Captcha.Secret = testSecret
 	s1.S3.Secret = testSecret
 	s1.Backups.S3.Secret = testSecret
+	s1.Tracing.Headers = testSecret
+	s1.Logs.Loki.Password = testSecret
+	s1.RealtimeCluster.Password = testSecret
+	s1.RecordsCache.RedisUrl = testSecret
 	s1.AdminAuthToken.Secret = testSecret
 	s1.AdminPasswordResetToken.Secret = testSecret
 	s1.AdminFileToken.Secret = testSecret
+	s1.AdminRefreshToken.Secret = testSecret
 	s1.RecordAuthToken.Secret = testSecret
 	s1.RecordPasswordResetToken.Secret = testSecret
 	s1.RecordEmailChangeToken.Secret = testSecret
 	s1.RecordVerificationToken.Secret = testSecret
 	s1.RecordFileToken.Secret = testSecret
+	s1.RecordRefreshToken.Secret = testSecret
 	s1.GoogleAuth.ClientSecret = testSecret
 	s1.FacebookAuth.ClientSecret = testSecret
 	s1.GithubAuth.ClientSecret = testSecret
@@ -302,6 +362,7 @@ func TestSettingsRedactClone(t *testing.T) {
 	s1.MailcowAuth.ClientSecret = testSecret
 	s1.BitbucketAuth.ClientSecret = testSecret
 	s1.PlanningcenterAuth.ClientSecret = testSecret
+	s1.DataSources = []settings.DataSourceConfig{{Name: "test", AuthSecret: testSecret}}
 
 	s1Bytes, err := json.Marshal(s1)
 	if err != nil {
@@ -335,6 +396,122 @@ func TestSettingsRedactClone(t *testing.T) {
 	}
 }
 
+type mockSecretResolver struct {
+	values map[string]string
+}
+
+func (r *mockSecretResolver) IsReference(value string) bool {
+	return strings.HasPrefix(value, "test:")
+}
+
+func (r *mockSecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	v, ok := r.values[ref]
+	if !ok {
+		return "", errors.New("missing secret")
+	}
+	return v, nil
+}
+
+func TestSettingsResolveSecrets(t *testing.T) {
+	resolver := &mockSecretResolver{values: map[string]string{
+		"test:smtp": "smtp_secret",
+		"test:s3":   "s3_secret",
+	}}
+
+	s := settings.New()
+	s.Smtp.Password = "test:smtp"
+	s.S3.Secret = "test:s3"
+	s.Captcha.Secret = "test:missing"
+	s.Sms.ApiKey = "plain_secret"
+	s.Meta.AppName = "test:smtp" // not a sensitive field
+
+	err := s.ResolveSecrets(context.Background(), resolver)
+	if err == nil || !strings.Contains(err.Error(), "captcha.secret") {
+		t.Fatalf("Expected captcha.secret resolve error, got %v", err)
+	}
+
+	if s.Smtp.Password != "smtp_secret" {
+		t.Fatalf("Expected the smtp password to be resolved, got %q", s.Smtp.Password)
+	}
+	if s.S3.Secret != "s3_secret" {
+		t.Fatalf("Expected the s3 secret to be resolved, got %q", s.S3.Secret)
+	}
+	if s.Captcha.Secret != "test:missing" {
+		t.Fatalf("Expected the unresolved captcha secret to remain unchanged, got %q", s.Captcha.Secret)
+	}
+	if s.Sms.ApiKey != "plain_secret" {
+		t.Fatalf("Expected the plain sms api key to remain unchanged, got %q", s.Sms.ApiKey)
+	}
+	if s.Meta.AppName != "test:smtp" {
+		t.Fatalf("Expected the non-sensitive field to remain unchanged, got %q", s.Meta.AppName)
+	}
+
+	refs := s.SecretRefs()
+	expectedRefs := map[string]string{
+		"smtp.password":  "test:smtp",
+		"s3.secret":      "test:s3",
+		"captcha.secret": "test:missing",
+	}
+	if len(refs) != len(expectedRefs) {
+		t.Fatalf("Expected refs %v, got %v", expectedRefs, refs)
+	}
+	for k, v := range expectedRefs {
+		if refs[k] != v {
+			t.Fatalf("Expected ref %q for %q, got %q", v, k, refs[k])
+		}
+	}
+
+	// rotation
+	resolver.values["test:smtp"] = "smtp_rotated"
+	resolver.values["test:missing"] = "captcha_secret"
+	if err := s.ResolveSecrets(context.Background(), resolver); err != nil {
+		t.Fatal(err)
+	}
+	if s.Smtp.Password != "smtp_rotated" {
+		t.Fatalf("Expected the rotated smtp password, got %q", s.Smtp.Password)
+	}
+	if s.Captcha.Secret != "captcha_secret" {
+		t.Fatalf("Expected the captcha secret to be resolved, got %q", s.Captcha.Secret)
+	}
+
+	// changed resolved field
+	s.S3.Secret = "new_s3_secret"
+
+	unresolved, err := s.UnresolvedClone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unresolved.Smtp.Password != "test:smtp" {
+		t.Fatalf("Expected the smtp password reference to be restored, got %q", unresolved.Smtp.Password)
+	}
+	if unresolved.S3.Secret != "new_s3_secret" {
+		t.Fatalf("Expected the changed s3 secret to be kept, got %q", unresolved.S3.Secret)
+	}
+	if len(unresolved.SecretRefs()) != 0 {
+		t.Fatalf("Expected no refs in the unresolved clone, got %v", unresolved.SecretRefs())
+	}
+
+	redacted, err := s.RedactClone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redacted.Smtp.Password != "test:smtp" {
+		t.Fatalf("Expected the redacted smtp password to show the reference, got %q", redacted.Smtp.Password)
+	}
+	if redacted.S3.Secret != settings.SecretMask {
+		t.Fatalf("Expected the redacted s3 secret to be masked, got %q", redacted.S3.Secret)
+	}
+
+	// the clone should preserve the references
+	clone, err := s.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.Smtp.Password != "smtp_rotated" || clone.SecretRefs()["smtp.password"] != "test:smtp" {
+		t.Fatalf("Expected the clone to preserve the resolved value and its reference, got %q (%v)", clone.Smtp.Password, clone.SecretRefs())
+	}
+}
+
 func TestNamedAuthProviderConfigs(t *testing.T) {
 	s := settings.New()
 
@@ -545,222 +722,1802 @@ func TestSmtpConfigValidate(t *testing.T) {
 	}
 }
 
-func TestS3ConfigValidate(t *testing.T) {
+func TestEmailApiConfigValidate(t *testing.T) {
 	scenarios := []struct {
-		config      settings.S3Config
+		name        string
+		config      settings.EmailApiConfig
 		expectError bool
 	}{
-		// zero values (disabled)
+		{"zero values (disabled)", settings.EmailApiConfig{}, false},
+		{"zero values (enabled)", settings.EmailApiConfig{Enabled: true}, true},
 		{
-			settings.S3Config{},
+			"invalid provider",
+			settings.EmailApiConfig{Enabled: true, Provider: "invalid", ApiKey: "test"},
+			true,
+		},
+		{
+			"sendgrid",
+			settings.EmailApiConfig{Enabled: true, Provider: mailer.ProviderSendGrid, ApiKey: "test"},
 			false,
 		},
-		// zero values (enabled)
 		{
-			settings.S3Config{Enabled: true},
+			"mailgun without domain",
+			settings.EmailApiConfig{Enabled: true, Provider: mailer.ProviderMailgun, ApiKey: "test"},
 			true,
 		},
-		// invalid data
 		{
-			settings.S3Config{
-				Enabled:  true,
-				Endpoint: "test:test:test",
-			},
+			"mailgun with invalid region",
+			settings.EmailApiConfig{Enabled: true, Provider: mailer.ProviderMailgun, ApiKey: "test", Domain: "example.com", Region: "invalid"},
 			true,
 		},
-		// valid data (url endpoint)
 		{
-			settings.S3Config{
-				Enabled:   true,
-				Endpoint:  "https://localhost:8090",
-				Bucket:    "test",
-				Region:    "test",
-				AccessKey: "test",
-				Secret:    "test",
-			},
+			"mailgun",
+			settings.EmailApiConfig{Enabled: true, Provider: mailer.ProviderMailgun, ApiKey: "test", Domain: "example.com", Region: "eu"},
 			false,
 		},
-		// valid data (hostname endpoint)
 		{
-			settings.S3Config{
-				Enabled:   true,
-				Endpoint:  "example.com",
-				Bucket:    "test",
-				Region:    "test",
-				AccessKey: "test",
-				Secret:    "test",
-			},
+			"ses without secret and region",
+			settings.EmailApiConfig{Enabled: true, Provider: mailer.ProviderSes, ApiKey: "test"},
+			true,
+		},
+		{
+			"ses",
+			settings.EmailApiConfig{Enabled: true, Provider: mailer.ProviderSes, ApiKey: "test", SecretKey: "test", Region: "us-east-1"},
 			false,
 		},
 	}
 
-	for i, scenario := range scenarios {
-		result := scenario.config.Validate()
-
-		if result != nil && !scenario.expectError {
-			t.Errorf("(%d) Didn't expect error, got %v", i, result)
-		}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
 
-		if result == nil && scenario.expectError {
-			t.Errorf("(%d) Expected error, got nil", i)
-		}
+			hasErr := result != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, result)
+			}
+		})
 	}
 }
 
-func TestMetaConfigValidate(t *testing.T) {
-	invalidTemplate := settings.EmailTemplate{
-		Subject:   "test",
-		ActionUrl: "test",
-		Body:      "test",
-	}
-
-	noPlaceholdersTemplate := settings.EmailTemplate{
-		Subject:   "test",
-		ActionUrl: "http://example.com",
-		Body:      "test",
-	}
-
-	withPlaceholdersTemplate := settings.EmailTemplate{
-		Subject:   "test",
-		ActionUrl: "http://example.com" + settings.EmailPlaceholderToken,
-		Body:      "test" + settings.EmailPlaceholderActionUrl,
+func TestSmsConfigValidate(t *testing.T) {
+	otpDefaults := func(c settings.SmsConfig) settings.SmsConfig {
+		c.OtpLength = 6
+		c.OtpDuration = 300
+		c.OtpMessage = "code: {OTP}"
+		return c
 	}
 
 	scenarios := []struct {
-		config      settings.MetaConfig
+		name        string
+		config      settings.SmsConfig
 		expectError bool
 	}{
-		// zero values
+		{"zero values (disabled)", settings.SmsConfig{}, true},
+		{"otp defaults (disabled)", otpDefaults(settings.SmsConfig{}), false},
+		{"otp defaults (enabled)", otpDefaults(settings.SmsConfig{Enabled: true}), true},
 		{
-			settings.MetaConfig{},
+			"invalid otp length",
+			settings.SmsConfig{OtpLength: 2, OtpDuration: 300, OtpMessage: "code: {OTP}"},
 			true,
 		},
-		// invalid data
 		{
-			settings.MetaConfig{
-				AppName:                    strings.Repeat("a", 300),
-				AppUrl:                     "test",
-				SenderName:                 strings.Repeat("a", 300),
-				SenderAddress:              "invalid_email",
-				VerificationTemplate:       invalidTemplate,
-				ResetPasswordTemplate:      invalidTemplate,
-				ConfirmEmailChangeTemplate: invalidTemplate,
-			},
+			"invalid otp duration",
+			settings.SmsConfig{OtpLength: 6, OtpDuration: 10, OtpMessage: "code: {OTP}"},
 			true,
 		},
-		// invalid data (missing required placeholders)
 		{
-			settings.MetaConfig{
-				AppName:                    "test",
-				AppUrl:                     "https://example.com",
-				SenderName:                 "test",
-				SenderAddress:              "test@example.com",
-				VerificationTemplate:       noPlaceholdersTemplate,
-				ResetPasswordTemplate:      noPlaceholdersTemplate,
-				ConfirmEmailChangeTemplate: noPlaceholdersTemplate,
-			},
+			"missing otp message placeholder",
+			settings.SmsConfig{OtpLength: 6, OtpDuration: 300, OtpMessage: "code"},
 			true,
 		},
-		// valid data
 		{
-			settings.MetaConfig{
-				AppName:                    "test",
-				AppUrl:                     "https://example.com",
-				SenderName:                 "test",
-				SenderAddress:              "test@example.com",
-				VerificationTemplate:       withPlaceholdersTemplate,
-				ResetPasswordTemplate:      withPlaceholdersTemplate,
-				ConfirmEmailChangeTemplate: withPlaceholdersTemplate,
-			},
+			"invalid provider",
+			otpDefaults(settings.SmsConfig{Enabled: true, Provider: "invalid", From: "test", ApiKey: "test", ApiSecret: "test"}),
+			true,
+		},
+		{
+			"twilio without secret",
+			otpDefaults(settings.SmsConfig{Enabled: true, Provider: sms.ProviderTwilio, From: "test", ApiKey: "test"}),
+			true,
+		},
+		{
+			"twilio",
+			otpDefaults(settings.SmsConfig{Enabled: true, Provider: sms.ProviderTwilio, From: "test", ApiKey: "test", ApiSecret: "test"}),
+			false,
+		},
+		{
+			"vonage",
+			otpDefaults(settings.SmsConfig{Enabled: true, Provider: sms.ProviderVonage, From: "test", ApiKey: "test", ApiSecret: "test"}),
+			false,
+		},
+		{
+			"http without url",
+			otpDefaults(settings.SmsConfig{Enabled: true, Provider: sms.ProviderHttp}),
+			true,
+		},
+		{
+			"http with invalid url",
+			otpDefaults(settings.SmsConfig{Enabled: true, Provider: sms.ProviderHttp, Url: "invalid"}),
+			true,
+		},
+		{
+			"http",
+			otpDefaults(settings.SmsConfig{Enabled: true, Provider: sms.ProviderHttp, Url: "https://example.com/sms"}),
 			false,
 		},
 	}
 
-	for i, scenario := range scenarios {
-		result := scenario.config.Validate()
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
 
-		if result != nil && !scenario.expectError {
-			t.Errorf("(%d) Didn't expect error, got %v", i, result)
-		}
+			hasErr := result != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, result)
+			}
+		})
+	}
+}
 
-		if result == nil && scenario.expectError {
-			t.Errorf("(%d) Expected error, got nil", i)
-		}
+func TestSmsConfigResolveOtpMessage(t *testing.T) {
+	config := settings.SmsConfig{OtpMessage: "{APP_NAME}: your code is {OTP} ({OTP})"}
+
+	result := config.ResolveOtpMessage("Acme", "123456")
+
+	expected := "Acme: your code is 123456 (123456)"
+	if result != expected {
+		t.Fatalf("Expected %q, got %q", expected, result)
 	}
 }
 
-func TestBackupsConfigValidate(t *testing.T) {
+func TestPushConfigValidate(t *testing.T) {
 	scenarios := []struct {
-		name           string
-		config         settings.BackupsConfig
-		expectedErrors []string
+		name        string
+		config      settings.PushConfig
+		expectError bool
 	}{
+		{"zero values (disabled)", settings.PushConfig{}, false},
 		{
-			"zero value",
-			settings.BackupsConfig{},
-			[]string{},
+			"enabled fcm with missing fields",
+			settings.PushConfig{FCM: settings.FCMConfig{Enabled: true}},
+			true,
 		},
 		{
-			"invalid cron",
-			settings.BackupsConfig{
-				Cron:        "invalid",
-				CronMaxKeep: 0,
-			},
-			[]string{"cron", "cronMaxKeep"},
+			"enabled fcm with invalid client email",
+			settings.PushConfig{FCM: settings.FCMConfig{Enabled: true, ProjectId: "test", ClientEmail: "invalid", PrivateKey: "test"}},
+			true,
 		},
 		{
-			"invalid enabled S3",
-			settings.BackupsConfig{
-				S3: settings.S3Config{
-					Enabled: true,
-				},
-			},
-			[]string{"s3"},
+			"enabled fcm",
+			settings.PushConfig{FCM: settings.FCMConfig{Enabled: true, ProjectId: "test", ClientEmail: "test@example.com", PrivateKey: "test"}},
+			false,
 		},
 		{
-			"valid data",
-			settings.BackupsConfig{
-				S3: settings.S3Config{
-					Enabled:   true,
-					Endpoint:  "example.com",
-					Bucket:    "test",
-					Region:    "test",
-					AccessKey: "test",
-					Secret:    "test",
-				},
-				Cron:        "*/10 * * * *",
-				CronMaxKeep: 1,
-			},
-			[]string{},
+			"enabled apns with missing fields",
+			settings.PushConfig{APNs: settings.APNsConfig{Enabled: true, KeyId: "test"}},
+			true,
+		},
+		{
+			"enabled apns",
+			settings.PushConfig{APNs: settings.APNsConfig{Enabled: true, KeyId: "test", TeamId: "test", PrivateKey: "test", Topic: "com.example.app"}},
+			false,
+		},
+		{
+			"enabled webpush with invalid subject",
+			settings.PushConfig{WebPush: settings.WebPushConfig{Enabled: true, VapidPublicKey: "test", VapidPrivateKey: "test", Subject: "test@example.com"}},
+			true,
+		},
+		{
+			"enabled webpush",
+			settings.PushConfig{WebPush: settings.WebPushConfig{Enabled: true, VapidPublicKey: "test", VapidPrivateKey: "test", Subject: "mailto:test@example.com"}},
+			false,
 		},
 	}
 
 	for _, s := range scenarios {
-		result := s.config.Validate()
+		t.Run(s.name, func(t *testing.T) {
+			result := s.config.Validate()
 
-		// parse errors
-		errs, ok := result.(validation.Errors)
-		if !ok && result != nil {
-			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
-			continue
-		}
+			hasErr := result != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, result)
+			}
+		})
+	}
+}
 
-		// check errors
-		if len(errs) > len(s.expectedErrors) {
-			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+func TestPushConfigIsEnabled(t *testing.T) {
+	config := settings.PushConfig{
+		FCM:     settings.FCMConfig{Enabled: true},
+		WebPush: settings.WebPushConfig{Enabled: true},
+	}
+
+	scenarios := map[string]bool{
+		push.ProviderFCM:     true,
+		push.ProviderAPNs:    false,
+		push.ProviderWebPush: true,
+		"missing":            false,
+	}
+
+	for provider, expected := range scenarios {
+		if result := config.IsEnabled(provider); result != expected {
+			t.Fatalf("[%s] Expected %v, got %v", provider, expected, result)
 		}
-		for _, k := range s.expectedErrors {
-			if _, ok := errs[k]; !ok {
-				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+	}
+}
+
+func TestCaptchaConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.CaptchaConfig
+		expectError bool
+	}{
+		{"zero values (disabled)", settings.CaptchaConfig{}, false},
+		{"zero values (enabled)", settings.CaptchaConfig{Enabled: true}, true},
+		{"invalid provider", settings.CaptchaConfig{Enabled: true, Provider: "invalid", Secret: "test"}, true},
+		{"missing secret", settings.CaptchaConfig{Enabled: true, Provider: captcha.ProviderTurnstile}, true},
+		{"turnstile", settings.CaptchaConfig{Enabled: true, Provider: captcha.ProviderTurnstile, Secret: "test"}, false},
+		{"hcaptcha", settings.CaptchaConfig{Enabled: true, Provider: captcha.ProviderHCaptcha, Secret: "test"}, false},
+		{"recaptcha", settings.CaptchaConfig{Enabled: true, Provider: captcha.ProviderRecaptcha, Secret: "test"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
 			}
-		}
+		})
 	}
 }
 
-func TestEmailTemplateValidate(t *testing.T) {
+func TestAdminNotificationsConfigValidate(t *testing.T) {
 	scenarios := []struct {
-		emailTemplate  settings.EmailTemplate
-		expectedErrors []string
+		name        string
+		config      settings.AdminNotificationsConfig
+		expectError bool
+	}{
+		{"zero values", settings.AdminNotificationsConfig{}, false},
+		{"negative hour", settings.AdminNotificationsConfig{DigestEnabled: true, DigestHour: -1}, true},
+		{"too large hour", settings.AdminNotificationsConfig{DigestEnabled: true, DigestHour: 24}, true},
+		{"valid hour", settings.AdminNotificationsConfig{DigestEnabled: true, DigestHour: 23}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestAdminNotificationsConfigDigestCron(t *testing.T) {
+	config := settings.AdminNotificationsConfig{DigestHour: 8}
+
+	if cron := config.DigestCron(); cron != "0 8 * * *" {
+		t.Fatalf("Expected cron %q, got %q", "0 8 * * *", cron)
+	}
+}
+
+func TestStatusPageConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.StatusPageConfig
+		expectError bool
+	}{
+		{"zero values", settings.StatusPageConfig{}, false},
+		{"negative cache max age", settings.StatusPageConfig{Enabled: true, CacheMaxAge: -1}, true},
+		{"valid cache max age", settings.StatusPageConfig{Enabled: true, CacheMaxAge: 60}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestAdminUIConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.AdminUIConfig
+		expectError bool
+	}{
+		{"zero values", settings.AdminUIConfig{}, false},
+		{"invalid path", settings.AdminUIConfig{Path: "/a/b/"}, true},
+		{"valid path", settings.AdminUIConfig{Path: "/backoffice/"}, false},
+		{"invalid logo url", settings.AdminUIConfig{LogoUrl: "//example.com/logo.png"}, true},
+		{"same origin logo url", settings.AdminUIConfig{LogoUrl: "/logo.png"}, false},
+		{"data logo url", settings.AdminUIConfig{LogoUrl: "data:image/png;base64,abc"}, false},
+		{"absolute logo url", settings.AdminUIConfig{LogoUrl: "https://example.com/logo.png"}, false},
+		{"invalid primary color", settings.AdminUIConfig{PrimaryColor: "red"}, true},
+		{"valid primary color", settings.AdminUIConfig{PrimaryColor: "#1e40af"}, false},
+		{"invalid hidden section", settings.AdminUIConfig{HiddenSections: []string{"logs", "settings/"}}, true},
+		{"valid hidden sections", settings.AdminUIConfig{HiddenSections: []string{"logs", "settings/backups"}}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestAdminUIConfigTrailedPath(t *testing.T) {
+	scenarios := []struct {
+		path     string
+		expected string
+	}{
+		{"", "/_/"},
+		{"/_/", "/_/"},
+		{"/backoffice", "/backoffice/"},
+		{"backoffice/", "/backoffice/"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			result := settings.AdminUIConfig{Path: s.path}.TrailedPath()
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestOutboundConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.OutboundConfig
+		expectError bool
+	}{
+		{"zero values", settings.OutboundConfig{}, false},
+		{"invalid proxy url", settings.OutboundConfig{ProxyUrl: "invalid url"}, true},
+		{"invalid CA certs", settings.OutboundConfig{CACerts: "invalid"}, true},
+		{"invalid scripts policy", settings.OutboundConfig{Scripts: settings.EgressPolicyConfig{Resolver: "invalid"}}, true},
+		{
+			"valid data",
+			settings.OutboundConfig{
+				ProxyUrl:              "http://proxy.example.com:3128",
+				NoProxy:               "localhost,10.0.0.0/8",
+				TLSInsecureSkipVerify: true,
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestTracingConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.TracingConfig
+		expectError bool
+	}{
+		{"zero values", settings.TracingConfig{}, false},
+		{"enabled without endpoint", settings.TracingConfig{Enabled: true}, true},
+		{"invalid endpoint", settings.TracingConfig{Endpoint: "invalid url"}, true},
+		{"invalid headers", settings.TracingConfig{Headers: "invalid"}, true},
+		{"negative sample ratio", settings.TracingConfig{SampleRatio: -0.1}, true},
+		{"too large sample ratio", settings.TracingConfig{SampleRatio: 1.1}, true},
+		{
+			"valid data",
+			settings.TracingConfig{
+				Enabled:     true,
+				Endpoint:    "http://localhost:4318/v1/traces",
+				Headers:     "Authorization=Bearer 123",
+				ServiceName: "test",
+				SampleRatio: 0.5,
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestLogsConfigRouteLevel(t *testing.T) {
+	c := settings.LogsConfig{
+		RouteLevels: []settings.LogsRouteLevelConfig{
+			{Path: "/api/health", Level: -4},
+			{Path: "/api/files/*", Methods: []string{"GET"}, Level: -8},
+			{Path: "/api/*", Level: 4},
+		},
+	}
+
+	scenarios := []struct {
+		method        string
+		path          string
+		expectedLevel int
+		expectedOk    bool
+	}{
+		{"GET", "/", 0, false},
+		{"GET", "/api/health", -4, true},
+		{"GET", "/api/files/abc/test.png", -8, true},
+		{"POST", "/api/files/abc/test.png", 4, true},
+		{"GET", "/api/collections", 4, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.method+" "+s.path, func(t *testing.T) {
+			level, ok := c.RouteLevel(s.method, s.path)
+
+			if ok != s.expectedOk || level != s.expectedLevel {
+				t.Fatalf("Expected (%d, %v), got (%d, %v)", s.expectedLevel, s.expectedOk, level, ok)
+			}
+		})
+	}
+}
+
+func TestLogsConfigValidateSinks(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.LogsConfig
+		expectError bool
+	}{
+		{"zero values", settings.LogsConfig{}, false},
+		{"invalid sample ratio", settings.LogsConfig{RequestsSampleRatio: 1.5}, true},
+		{"invalid route level path", settings.LogsConfig{RouteLevels: []settings.LogsRouteLevelConfig{{Path: "api"}}}, true},
+		{"invalid route level method", settings.LogsConfig{RouteLevels: []settings.LogsRouteLevelConfig{{Path: "/api", Methods: []string{"INVALID"}}}}, true},
+		{"invalid stdout format", settings.LogsConfig{Stdout: "xml"}, true},
+		{"enabled syslog without address", settings.LogsConfig{Syslog: settings.LogsSyslogConfig{Enabled: true}}, true},
+		{"invalid syslog network", settings.LogsConfig{Syslog: settings.LogsSyslogConfig{Network: "http"}}, true},
+		{"enabled loki without url", settings.LogsConfig{Loki: settings.LogsLokiConfig{Enabled: true}}, true},
+		{"reserved loki label", settings.LogsConfig{Loki: settings.LogsLokiConfig{Labels: map[string]string{"level": "test"}}}, true},
+		{"invalid loki label", settings.LogsConfig{Loki: settings.LogsLokiConfig{Labels: map[string]string{"1a": "test"}}}, true},
+		{"negative slow query threshold", settings.LogsConfig{SlowQueryThreshold: -1}, true},
+		{
+			"valid data",
+			settings.LogsConfig{
+				RequestsSampleRatio: 0.5,
+				RouteLevels:         []settings.LogsRouteLevelConfig{{Path: "/api/*", Methods: []string{"GET"}, Level: -4}},
+				Stdout:              "json",
+				Syslog:              settings.LogsSyslogConfig{Enabled: true, Network: "tcp", Address: "localhost:514", Tag: "test"},
+				Loki:                settings.LogsLokiConfig{Enabled: true, Url: "http://localhost:3100", Labels: map[string]string{"app": "test"}},
+				SlowQueryThreshold:  100,
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestRealtimeClusterConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.RealtimeClusterConfig
+		expectError bool
+	}{
+		{"zero values", settings.RealtimeClusterConfig{}, false},
+		{"enabled without url", settings.RealtimeClusterConfig{Enabled: true, Provider: "nats", Channel: "test"}, true},
+		{"enabled without channel", settings.RealtimeClusterConfig{Enabled: true, Provider: "nats", Url: "nats://localhost:4222"}, true},
+		{"enabled without provider", settings.RealtimeClusterConfig{Enabled: true, Url: "nats://localhost:4222", Channel: "test"}, true},
+		{"unknown provider", settings.RealtimeClusterConfig{Provider: "unknown"}, true},
+		{"invalid url", settings.RealtimeClusterConfig{Url: "invalid url"}, true},
+		{"invalid channel", settings.RealtimeClusterConfig{Channel: "invalid channel"}, true},
+		{
+			"valid data",
+			settings.RealtimeClusterConfig{
+				Enabled:  true,
+				Provider: "nats",
+				Url:      "nats://localhost:4222",
+				Channel:  "pocketbase.realtime",
+				Username: "test",
+				Password: "123",
+			},
+			false,
+		},
+		{
+			"valid redis data",
+			settings.RealtimeClusterConfig{
+				Enabled:  true,
+				Provider: "redis",
+				Url:      "redis://localhost:6379/0",
+				Channel:  "pocketbase:realtime",
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestRecordsCacheConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.RecordsCacheConfig
+		expectError bool
+	}{
+		{"zero values", settings.RecordsCacheConfig{}, false},
+		{"enabled without provider", settings.RecordsCacheConfig{Enabled: true}, true},
+		{"unknown provider", settings.RecordsCacheConfig{Provider: "unknown"}, true},
+		{"enabled redis without url", settings.RecordsCacheConfig{Enabled: true, Provider: "redis"}, true},
+		{"invalid redis url", settings.RecordsCacheConfig{RedisUrl: "invalid url"}, true},
+		{"negative ttl", settings.RecordsCacheConfig{TTL: -1}, true},
+		{"negative max entries", settings.RecordsCacheConfig{MaxEntries: -1}, true},
+		{"enabled memory", settings.RecordsCacheConfig{Enabled: true, Provider: "memory"}, false},
+		{
+			"valid redis data",
+			settings.RecordsCacheConfig{
+				Enabled:     true,
+				Provider:    "redis",
+				RedisUrl:    "redis://localhost:6379/0",
+				TTL:         30,
+				Collections: map[string]int{"posts": 300, "orders": -1},
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestRealtimeLimitsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.RealtimeLimitsConfig
+		expectError bool
+	}{
+		{"zero values", settings.RealtimeLimitsConfig{}, false},
+		{"negative max per auth record", settings.RealtimeLimitsConfig{MaxPerAuthRecord: -1}, true},
+		{"negative max per ip", settings.RealtimeLimitsConfig{MaxPerIp: -1}, true},
+		{"limit without policy", settings.RealtimeLimitsConfig{MaxPerIp: 1}, true},
+		{"unknown policy", settings.RealtimeLimitsConfig{Policy: "unknown"}, true},
+		{"reject policy", settings.RealtimeLimitsConfig{MaxPerIp: 10, Policy: "reject"}, false},
+		{"evictOldest policy", settings.RealtimeLimitsConfig{MaxPerAuthRecord: 3, Policy: "evictOldest"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadSheddingConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.LoadSheddingConfig
+		expectError bool
+	}{
+		{"zero values", settings.LoadSheddingConfig{}, false},
+		{"negative max record writes", settings.LoadSheddingConfig{MaxRecordWrites: -1}, true},
+		{"negative max scripts", settings.LoadSheddingConfig{MaxScripts: -1}, true},
+		{"negative max file transforms", settings.LoadSheddingConfig{MaxFileTransforms: -1}, true},
+		{"negative retry after", settings.LoadSheddingConfig{RetryAfter: -1}, true},
+		{"valid limits", settings.LoadSheddingConfig{MaxRecordWrites: 10, MaxScripts: 5, MaxFileTransforms: 2, RetryAfter: 3}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestHealthConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.HealthConfig
+		expectError bool
+	}{
+		{"zero values", settings.HealthConfig{}, true},
+		{"negative failure threshold", settings.HealthConfig{Timeout: 1, FailureThreshold: -1}, true},
+		{"negative min free disk space", settings.HealthConfig{Timeout: 1, MinFreeDiskSpace: -1}, true},
+		{"valid data", settings.HealthConfig{Timeout: 5, FailureThreshold: 2, MinFreeDiskSpace: 100}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestSettingsValidateDuplicatedDataSources(t *testing.T) {
+	s := settings.New()
+	s.DataSources = []settings.DataSourceConfig{
+		{Name: "test", Url: "http://a", Fields: []settings.DataSourceFieldConfig{{Name: "title", Type: "text"}}},
+		{Name: "TEST", Url: "http://b", Fields: []settings.DataSourceFieldConfig{{Name: "title", Type: "text"}}},
+	}
+
+	errs, _ := s.Validate().(validation.Errors)
+	if _, ok := errs["dataSources"]; !ok {
+		t.Fatalf("Expected dataSources error, got %v", errs)
+	}
+
+	s.DataSources[1].Name = "test2"
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+}
+
+func TestSettingsFindDataSource(t *testing.T) {
+	s := settings.New()
+	s.DataSources = []settings.DataSourceConfig{{Name: "a"}, {Name: "b"}}
+
+	scenarios := []struct {
+		name     string
+		expected string
+	}{
+		{"", ""},
+		{"missing", ""},
+		{"a", "a"},
+		{"B", "b"},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			result := s.FindDataSource(scenario.name)
+
+			var name string
+			if result != nil {
+				name = result.Name
+			}
+
+			if name != scenario.expected {
+				t.Fatalf("Expected data source %q, got %q", scenario.expected, name)
+			}
+		})
+	}
+}
+
+func TestDataSourceConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.DataSourceConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.DataSourceConfig{},
+			[]string{"name", "url", "fields"},
+		},
+		{
+			"invalid data",
+			settings.DataSourceConfig{
+				Name:           "in valid",
+				Url:            "ftp://example.com",
+				ViewUrl:        "https://example.com/items",
+				Headers:        map[string]string{"X Invalid": "1"},
+				AuthType:       "invalid",
+				Fields:         []settings.DataSourceFieldConfig{{Name: "id", Type: "file"}},
+				CacheTTL:       -1,
+				Timeout:        -1,
+				SyncCollection: "in valid",
+				SyncCron:       "invalid",
+			},
+			[]string{"name", "url", "viewUrl", "headers", "authType", "authSecret", "fields", "cacheTTL", "timeout", "syncCollection", "syncCron"},
+		},
+		{
+			"missing auth credentials",
+			settings.DataSourceConfig{
+				Name:     "test",
+				Url:      "https://example.com",
+				AuthType: settings.DataSourceAuthBasic,
+				Fields:   []settings.DataSourceFieldConfig{{Name: "title", Type: "text"}},
+			},
+			[]string{"authUsername", "authSecret"},
+		},
+		{
+			"duplicated field names",
+			settings.DataSourceConfig{
+				Name: "test",
+				Url:  "https://example.com",
+				Fields: []settings.DataSourceFieldConfig{
+					{Name: "title", Type: "text"},
+					{Name: "Title", Type: "number"},
+				},
+			},
+			[]string{"fields"},
+		},
+		{
+			"sync cron without sync collection",
+			settings.DataSourceConfig{
+				Name:     "test",
+				Url:      "https://example.com",
+				Fields:   []settings.DataSourceFieldConfig{{Name: "title", Type: "text"}},
+				SyncCron: "* * * * *",
+			},
+			[]string{"syncCollection"},
+		},
+		{
+			"valid data",
+			settings.DataSourceConfig{
+				Name:           "test",
+				Url:            "https://example.com/api/items?limit=100",
+				ViewUrl:        "https://example.com/api/items/{id}",
+				ItemsPath:      "data.items",
+				IdPath:         "uuid",
+				Headers:        map[string]string{"Accept-Language": "en"},
+				AuthType:       settings.DataSourceAuthBearer,
+				AuthSecret:     "test",
+				Fields:         []settings.DataSourceFieldConfig{{Name: "title", Type: "text", Path: "meta.title"}},
+				ListRule:       types.Pointer(""),
+				CacheTTL:       60,
+				Timeout:        10,
+				SyncCron:       "*/5 * * * *",
+				SyncCollection: "items",
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestSettingsValidateDuplicatedPingers(t *testing.T) {
+	s := settings.New()
+	s.Pingers = []settings.PingerConfig{
+		{Name: "test", Cron: "@daily", Url: "http://a"},
+		{Name: "TEST", Cron: "@daily", Url: "http://b"},
+	}
+
+	errs, _ := s.Validate().(validation.Errors)
+	if _, ok := errs["pingers"]; !ok {
+		t.Fatalf("Expected pingers error, got %v", errs)
+	}
+
+	s.Pingers[1].Name = "test2"
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+}
+
+func TestSettingsFindPinger(t *testing.T) {
+	s := settings.New()
+	s.Pingers = []settings.PingerConfig{{Name: "a"}, {Name: "b"}}
+
+	scenarios := []struct {
+		name     string
+		expected string
+	}{
+		{"", ""},
+		{"missing", ""},
+		{"a", "a"},
+		{"B", "b"},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			result := s.FindPinger(scenario.name)
+
+			var name string
+			if result != nil {
+				name = result.Name
+			}
+
+			if name != scenario.expected {
+				t.Fatalf("Expected pinger %q, got %q", scenario.expected, name)
+			}
+		})
+	}
+}
+
+func TestPingerConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.PingerConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.PingerConfig{},
+			[]string{"name", "cron", "url"},
+		},
+		{
+			"invalid data",
+			settings.PingerConfig{
+				Name:           "in valid",
+				Cron:           "invalid",
+				Method:         "get",
+				Url:            "ftp://example.com",
+				Headers:        map[string]string{"X Invalid": "1"},
+				ExpectedStatus: 600,
+				Timeout:        -1,
+			},
+			[]string{"name", "cron", "method", "url", "headers", "expectedStatus", "timeout"},
+		},
+		{
+			"body with GET method",
+			settings.PingerConfig{
+				Name: "test",
+				Cron: "@daily",
+				Url:  "https://example.com",
+				Body: "test",
+			},
+			[]string{"body"},
+		},
+		{
+			"valid data",
+			settings.PingerConfig{
+				Name:           "test",
+				Cron:           "0 3 * * *",
+				Method:         "POST",
+				Url:            "https://example.com/api/cleanup?force=1",
+				Headers:        map[string]string{"Authorization": "Bearer test"},
+				Body:           `{"days":30}`,
+				ExpectedStatus: 204,
+				Timeout:        10,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRecordsCacheConfigCollectionTTL(t *testing.T) {
+	config := settings.RecordsCacheConfig{
+		TTL:         60,
+		Collections: map[string]int{"posts": 300, "orders": -1, "zero": 0},
+	}
+
+	scenarios := []struct {
+		collection string
+		expected   time.Duration
+	}{
+		{"missing", 60 * time.Second},
+		{"zero", 60 * time.Second},
+		{"posts", 300 * time.Second},
+		{"orders", 0},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.collection, func(t *testing.T) {
+			if ttl := config.CollectionTTL(s.collection); ttl != s.expected {
+				t.Fatalf("Expected ttl %v, got %v", s.expected, ttl)
+			}
+		})
+	}
+
+	config.TTL = 0
+	if ttl := config.CollectionTTL("missing"); ttl != 0 {
+		t.Fatalf("Expected disabled cache, got %v", ttl)
+	}
+}
+
+func TestEgressPolicyConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      settings.EgressPolicyConfig
+		expectError bool
+	}{
+		{"zero values", settings.EgressPolicyConfig{}, false},
+		{"empty allowed domain", settings.EgressPolicyConfig{AllowedDomains: []string{""}}, true},
+		{"empty denied domain", settings.EgressPolicyConfig{DeniedDomains: []string{""}}, true},
+		{"invalid resolver", settings.EgressPolicyConfig{Resolver: "1.1.1.1"}, true},
+		{"invalid rate limit", settings.EgressPolicyConfig{RateLimits: []settings.EgressRateLimitConfig{{Domain: "*"}}}, true},
+		{
+			"valid data",
+			settings.EgressPolicyConfig{
+				AllowedDomains:  []string{"*.example.com"},
+				DeniedDomains:   []string{"internal.example.com"},
+				BlockPrivateIPs: true,
+				Resolver:        "1.1.1.1:53",
+				RateLimits:      []settings.EgressRateLimitConfig{{Domain: "*", MaxRequests: 10, Duration: 60}},
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.config.Validate()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestEgressPolicyConfigPolicy(t *testing.T) {
+	config := settings.EgressPolicyConfig{
+		AllowedDomains:  []string{"example.com"},
+		BlockPrivateIPs: true,
+		RateLimits:      []settings.EgressRateLimitConfig{{Domain: "*", MaxRequests: 10, Duration: 60}},
+	}
+
+	policy := config.Policy()
+
+	if len(policy.AllowedDomains) != 1 || !policy.BlockPrivateIPs {
+		t.Fatalf("Unexpected policy %v", policy)
+	}
+
+	if len(policy.RateLimits) != 1 || policy.RateLimits[0].Interval != time.Minute {
+		t.Fatalf("Expected 1 minute rate limit, got %v", policy.RateLimits)
+	}
+}
+
+func TestS3ConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		config      settings.S3Config
+		expectError bool
+	}{
+		// zero values (disabled)
+		{
+			settings.S3Config{},
+			false,
+		},
+		// zero values (enabled)
+		{
+			settings.S3Config{Enabled: true},
+			true,
+		},
+		// invalid data
+		{
+			settings.S3Config{
+				Enabled:  true,
+				Endpoint: "test:test:test",
+			},
+			true,
+		},
+		// valid data (url endpoint)
+		{
+			settings.S3Config{
+				Enabled:   true,
+				Endpoint:  "https://localhost:8090",
+				Bucket:    "test",
+				Region:    "test",
+				AccessKey: "test",
+				Secret:    "test",
+			},
+			false,
+		},
+		// valid data (hostname endpoint)
+		{
+			settings.S3Config{
+				Enabled:   true,
+				Endpoint:  "example.com",
+				Bucket:    "test",
+				Region:    "test",
+				AccessKey: "test",
+				Secret:    "test",
+			},
+			false,
+		},
+	}
+
+	for i, scenario := range scenarios {
+		result := scenario.config.Validate()
+
+		if result != nil && !scenario.expectError {
+			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+		}
+
+		if result == nil && scenario.expectError {
+			t.Errorf("(%d) Expected error, got nil", i)
+		}
+	}
+}
+
+func TestMetaConfigValidate(t *testing.T) {
+	invalidTemplate := settings.EmailTemplate{
+		Subject:   "test",
+		ActionUrl: "test",
+		Body:      "test",
+	}
+
+	noPlaceholdersTemplate := settings.EmailTemplate{
+		Subject:   "test",
+		ActionUrl: "http://example.com",
+		Body:      "test",
+	}
+
+	withPlaceholdersTemplate := settings.EmailTemplate{
+		Subject:   "test",
+		ActionUrl: "http://example.com" + settings.EmailPlaceholderToken,
+		Body:      "test" + settings.EmailPlaceholderActionUrl,
+	}
+
+	scenarios := []struct {
+		config      settings.MetaConfig
+		expectError bool
+	}{
+		// zero values
+		{
+			settings.MetaConfig{},
+			true,
+		},
+		// invalid data
+		{
+			settings.MetaConfig{
+				AppName:                    strings.Repeat("a", 300),
+				AppUrl:                     "test",
+				SenderName:                 strings.Repeat("a", 300),
+				SenderAddress:              "invalid_email",
+				VerificationTemplate:       invalidTemplate,
+				ResetPasswordTemplate:      invalidTemplate,
+				ConfirmEmailChangeTemplate: invalidTemplate,
+			},
+			true,
+		},
+		// invalid data (missing required placeholders)
+		{
+			settings.MetaConfig{
+				AppName:                    "test",
+				AppUrl:                     "https://example.com",
+				SenderName:                 "test",
+				SenderAddress:              "test@example.com",
+				VerificationTemplate:       noPlaceholdersTemplate,
+				ResetPasswordTemplate:      noPlaceholdersTemplate,
+				ConfirmEmailChangeTemplate: noPlaceholdersTemplate,
+			},
+			true,
+		},
+		// valid data
+		{
+			settings.MetaConfig{
+				AppName:                    "test",
+				AppUrl:                     "https://example.com",
+				SenderName:                 "test",
+				SenderAddress:              "test@example.com",
+				VerificationTemplate:       withPlaceholdersTemplate,
+				ResetPasswordTemplate:      withPlaceholdersTemplate,
+				ConfirmEmailChangeTemplate: withPlaceholdersTemplate,
+			},
+			false,
+		},
+	}
+
+	for i, scenario := range scenarios {
+		result := scenario.config.Validate()
+
+		if result != nil && !scenario.expectError {
+			t.Errorf("(%d) Didn't expect error, got %v", i, result)
+		}
+
+		if result == nil && scenario.expectError {
+			t.Errorf("(%d) Expected error, got nil", i)
+		}
+	}
+}
+
+func TestBackupsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.BackupsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.BackupsConfig{},
+			[]string{},
+		},
+		{
+			"invalid cron",
+			settings.BackupsConfig{
+				Cron:        "invalid",
+				CronMaxKeep: 0,
+			},
+			[]string{"cron", "cronMaxKeep"},
+		},
+		{
+			"invalid enabled S3",
+			settings.BackupsConfig{
+				S3: settings.S3Config{
+					Enabled: true,
+				},
+			},
+			[]string{"s3"},
+		},
+		{
+			"negative retention rules",
+			settings.BackupsConfig{
+				Cron:           "*/10 * * * *",
+				CronMaxKeep:    1,
+				CronKeepDaily:  -1,
+				CronKeepWeekly: -1,
+			},
+			[]string{"cronKeepDaily", "cronKeepWeekly"},
+		},
+		{
+			"valid data",
+			settings.BackupsConfig{
+				S3: settings.S3Config{
+					Enabled:   true,
+					Endpoint:  "example.com",
+					Bucket:    "test",
+					Region:    "test",
+					AccessKey: "test",
+					Secret:    "test",
+				},
+				Cron:            "*/10 * * * *",
+				CronMaxKeep:     1,
+				CronKeepDaily:   7,
+				CronKeepWeekly:  4,
+				CronIncremental: true,
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRoutesConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RoutesConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.RoutesConfig{},
+			[]string{},
+		},
+		{
+			"invalid rate limits and rules",
+			settings.RoutesConfig{
+				RateLimits: []settings.RateLimitConfig{
+					{Name: "", MaxRequests: 0, Duration: 0},
+				},
+				Rules: []settings.RouteRuleConfig{
+					{Path: "missing_slash", Methods: []string{"INVALID"}, Auth: "invalid"},
+				},
+			},
+			[]string{"rateLimits", "rules"},
+		},
+		{
+			"duplicated rate limit names",
+			settings.RoutesConfig{
+				RateLimits: []settings.RateLimitConfig{
+					{Name: "test", MaxRequests: 1, Duration: 1},
+					{Name: "test", MaxRequests: 2, Duration: 2},
+				},
+			},
+			[]string{"rateLimits"},
+		},
+		{
+			"missing rule rate limit profile",
+			settings.RoutesConfig{
+				RateLimits: []settings.RateLimitConfig{
+					{Name: "test", MaxRequests: 1, Duration: 1},
+				},
+				Rules: []settings.RouteRuleConfig{
+					{Path: "/a", RateLimit: "test"},
+					{Path: "/b", RateLimit: "missing"},
+				},
+			},
+			[]string{"rules"},
+		},
+		{
+			"invalid cors overrides",
+			settings.RoutesConfig{
+				CORSOverrides: []settings.CORSOverrideConfig{
+					{Group: "/api/public"},
+					{Group: ""},
+				},
+			},
+			[]string{"corsOverrides"},
+		},
+		{
+			"invalid limits",
+			settings.RoutesConfig{
+				Limits: settings.RequestLimitsConfig{MaxBodySize: -1},
+				GroupLimits: []settings.RouteGroupLimitsConfig{
+					{Group: "/api/uploads"},
+					{Group: "api/invalid"},
+				},
+			},
+			[]string{"limits", "groupLimits"},
+		},
+		{
+			"valid data",
+			settings.RoutesConfig{
+				RateLimits: []settings.RateLimitConfig{
+					{Name: "test", MaxRequests: 1, Duration: 1},
+				},
+				Rules: []settings.RouteRuleConfig{
+					{Path: "/api/*", Methods: []string{"GET"}, RateLimit: "test"},
+					{Path: "/api/collections/posts/*", Auth: settings.RouteAuthRecord, AuthCollections: []string{"users"}},
+					{Path: "/api/public/*", CORS: settings.CORSConfig{Enabled: true, AllowOrigins: []string{"*"}}},
+				},
+				CORSOverrides: []settings.CORSOverrideConfig{
+					{Group: "/api/public", CORS: settings.CORSConfig{Enabled: true, AllowOrigins: []string{"*"}}},
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRoutesConfigFindLimits(t *testing.T) {
+	config := settings.RoutesConfig{
+		Limits: settings.RequestLimitsConfig{MaxBodySize: 100, ReadTimeout: 10},
+		GroupLimits: []settings.RouteGroupLimitsConfig{
+			{Group: "/api/collections", WriteTimeout: 20},
+			{Group: "/api/collections/uploads/", MaxBodySize: 1000},
+			{Group: "/api/files", ReadTimeout: 30},
+		},
+	}
+
+	scenarios := []struct {
+		path     string
+		expected settings.RequestLimitsConfig
+	}{
+		{"/", settings.RequestLimitsConfig{MaxBodySize: 100, ReadTimeout: 10}},
+		{"/api/collectionsX", settings.RequestLimitsConfig{MaxBodySize: 100, ReadTimeout: 10}},
+		{"/api/collections", settings.RequestLimitsConfig{MaxBodySize: 100, ReadTimeout: 10, WriteTimeout: 20}},
+		{"/api/collections/posts/records", settings.RequestLimitsConfig{MaxBodySize: 100, ReadTimeout: 10, WriteTimeout: 20}},
+		{"/api/collections/uploads/records", settings.RequestLimitsConfig{MaxBodySize: 1000, ReadTimeout: 10}},
+		{"/api/files/posts/abc/test.png", settings.RequestLimitsConfig{MaxBodySize: 100, ReadTimeout: 30}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			result := config.FindLimits(s.path)
+			if result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestRequestLimitsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RequestLimitsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.RequestLimitsConfig{},
+			[]string{},
+		},
+		{
+			"negative values",
+			settings.RequestLimitsConfig{MaxBodySize: -1, ReadTimeout: -1, WriteTimeout: -1},
+			[]string{"maxBodySize", "readTimeout", "writeTimeout"},
+		},
+		{
+			"valid data",
+			settings.RequestLimitsConfig{MaxBodySize: 1 << 20, ReadTimeout: 10, WriteTimeout: 20},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRouteGroupLimitsConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RouteGroupLimitsConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.RouteGroupLimitsConfig{},
+			[]string{"group"},
+		},
+		{
+			"invalid data",
+			settings.RouteGroupLimitsConfig{Group: "api/uploads", MaxBodySize: -1, ReadTimeout: -1, WriteTimeout: -1},
+			[]string{"group", "maxBodySize", "readTimeout", "writeTimeout"},
+		},
+		{
+			"valid data",
+			settings.RouteGroupLimitsConfig{Group: "/api/uploads", MaxBodySize: 1 << 20, ReadTimeout: 10},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRoutesConfigFindProxy(t *testing.T) {
+	config := settings.RoutesConfig{
+		Proxies: []settings.RouteProxyConfig{
+			{Prefix: "/svc", Target: "http://a"},
+			{Prefix: "/svc/admin/", Target: "http://b"},
+			{Prefix: "/other", Target: "http://c"},
+		},
+	}
+
+	scenarios := []struct {
+		path     string
+		expected string
+	}{
+		{"/", ""},
+		{"/svcX", ""},
+		{"/svc", "http://a"},
+		{"/svc/", "http://a"},
+		{"/svc/hello", "http://a"},
+		{"/svc/admin", "http://b"},
+		{"/svc/admin/users", "http://b"},
+		{"/svc/administrators", "http://a"},
+		{"/other/test", "http://c"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			var result string
+			if proxy := config.FindProxy(s.path); proxy != nil {
+				result = proxy.Target
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestRoutesConfigValidateDuplicatedProxies(t *testing.T) {
+	config := settings.New().Routes
+	config.Proxies = []settings.RouteProxyConfig{
+		{Prefix: "/svc", Target: "http://a"},
+		{Prefix: "/svc/", Target: "http://b"},
+	}
+
+	errs, _ := config.Validate().(validation.Errors)
+	if _, ok := errs["proxies"]; !ok {
+		t.Fatalf("Expected proxies error, got %v", errs)
+	}
+
+	config.Proxies[1].Prefix = "/svc2"
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+}
+
+func TestRouteProxyConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RouteProxyConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.RouteProxyConfig{},
+			[]string{"prefix", "target"},
+		},
+		{
+			"invalid data",
+			settings.RouteProxyConfig{
+				Prefix:          "svc",
+				Target:          "ftp://example.com",
+				RequestHeaders:  map[string]string{"X Invalid": "1"},
+				ResponseHeaders: map[string]string{"X-Test": "a\r\nb"},
+				Auth:            "invalid",
+				AuthCollections: []string{"users"},
+			},
+			[]string{"prefix", "target", "requestHeaders", "responseHeaders", "auth", "authCollections"},
+		},
+		{
+			"reserved prefix",
+			settings.RouteProxyConfig{Prefix: "/api/", Target: "http://example.com"},
+			[]string{"prefix"},
+		},
+		{
+			"admin UI prefix",
+			settings.RouteProxyConfig{Prefix: "/_/test", Target: "http://example.com"},
+			[]string{"prefix"},
+		},
+		{
+			"target with query",
+			settings.RouteProxyConfig{Prefix: "/svc", Target: "http://example.com?a=1"},
+			[]string{"target"},
+		},
+		{
+			"valid data",
+			settings.RouteProxyConfig{
+				Prefix:          "/api/svc",
+				Target:          "https://127.0.0.1:3000/v1",
+				RequestHeaders:  map[string]string{"X-Api-Key": "test", "Cookie": ""},
+				ResponseHeaders: map[string]string{"Cache-Control": "no-store"},
+				Auth:            settings.RouteAuthRecord,
+				AuthCollections: []string{"users"},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRouteRuleConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.RouteRuleConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.RouteRuleConfig{},
+			[]string{"path"},
+		},
+		{
+			"invalid data",
+			settings.RouteRuleConfig{
+				Path:            "test",
+				Methods:         []string{"GET", "get"},
+				Auth:            "invalid",
+				AuthCollections: []string{"users"},
+				CORS:            settings.CORSConfig{Enabled: true},
+			},
+			[]string{"path", "methods", "auth", "authCollections", "cors"},
+		},
+		{
+			"auth collections with admin auth",
+			settings.RouteRuleConfig{
+				Path:            "/test",
+				Auth:            settings.RouteAuthAdmin,
+				AuthCollections: []string{"users"},
+			},
+			[]string{"authCollections"},
+		},
+		{
+			"cors credentials with wildcard origin",
+			settings.RouteRuleConfig{
+				Path: "/test",
+				CORS: settings.CORSConfig{Enabled: true, AllowOrigins: []string{"*"}, AllowCredentials: true},
+			},
+			[]string{"cors"},
+		},
+		{
+			"valid data",
+			settings.RouteRuleConfig{
+				Path:            "/test/*",
+				Methods:         []string{"GET", "POST"},
+				Auth:            settings.RouteAuthAdminOrRecord,
+				AuthCollections: []string{"users"},
+				CORS: settings.CORSConfig{
+					Enabled:          true,
+					AllowOrigins:     []string{"https://example.com"},
+					AllowHeaders:     []string{"X-Test"},
+					AllowCredentials: true,
+					MaxAge:           60,
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestCORSOverrideConfigValidate(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		config         settings.CORSOverrideConfig
+		expectedErrors []string
+	}{
+		{
+			"zero value",
+			settings.CORSOverrideConfig{},
+			[]string{"group"},
+		},
+		{
+			"invalid group",
+			settings.CORSOverrideConfig{
+				Group: "api/public",
+			},
+			[]string{"group"},
+		},
+		{
+			"both group and collections",
+			settings.CORSOverrideConfig{
+				Group:       "/api/public",
+				Collections: []string{"posts"},
+			},
+			[]string{"group"},
+		},
+		{
+			"empty collection identifier",
+			settings.CORSOverrideConfig{
+				Collections: []string{"posts", ""},
+			},
+			[]string{"collections"},
+		},
+		{
+			"invalid cors",
+			settings.CORSOverrideConfig{
+				Group: "/api/public",
+				CORS:  settings.CORSConfig{Enabled: true},
+			},
+			[]string{"cors"},
+		},
+		{
+			"valid group",
+			settings.CORSOverrideConfig{
+				Group: "/api/public",
+				CORS:  settings.CORSConfig{Enabled: true, AllowOrigins: []string{"*"}},
+			},
+			[]string{},
+		},
+		{
+			"valid collections",
+			settings.CORSOverrideConfig{
+				Collections: []string{"posts", "comments"},
+				CORS: settings.CORSConfig{
+					Enabled:          true,
+					AllowOrigins:     []string{"https://admin.example.com"},
+					AllowCredentials: true,
+				},
+			},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		result := s.config.Validate()
+
+		// parse errors
+		errs, ok := result.(validation.Errors)
+		if !ok && result != nil {
+			t.Errorf("[%s] Failed to parse errors %v", s.name, result)
+			continue
+		}
+
+		// check errors
+		if len(errs) > len(s.expectedErrors) {
+			t.Errorf("[%s] Expected error keys %v, got %v", s.name, s.expectedErrors, errs)
+		}
+		for _, k := range s.expectedErrors {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("[%s] Missing expected error key %q in %v", s.name, k, errs)
+			}
+		}
+	}
+}
+
+func TestRoutesConfigFindRateLimit(t *testing.T) {
+	config := settings.RoutesConfig{
+		RateLimits: []settings.RateLimitConfig{
+			{Name: "a", MaxRequests: 1, Duration: 1},
+			{Name: "b", MaxRequests: 2, Duration: 2},
+		},
+	}
+
+	if profile := config.FindRateLimit("missing"); profile != nil {
+		t.Fatalf("Expected nil profile, got %v", profile)
+	}
+
+	if profile := config.FindRateLimit("b"); profile == nil || profile.MaxRequests != 2 {
+		t.Fatalf("Expected profile b, got %v", profile)
+	}
+}
+
+func TestEmailTemplateValidate(t *testing.T) {
+	scenarios := []struct {
+		emailTemplate  settings.EmailTemplate
+		expectedErrors []string
 	}{
 		// require values
 		{
@@ -896,11 +2653,41 @@ func TestLogsConfigValidate(t *testing.T) {
 			settings.LogsConfig{MaxDays: -10},
 			true,
 		},
+		{
+			settings.LogsConfig{ModuleLevels: map[string]int{" ": 0}},
+			true,
+		},
+		{
+			settings.LogsConfig{RequestsTarget: "invalid"},
+			true,
+		},
+		{
+			settings.LogsConfig{AppTarget: "invalid"},
+			true,
+		},
+		{
+			settings.LogsConfig{FileMaxSize: -1, FileMaxBackups: -1},
+			true,
+		},
 		// valid data
 		{
 			settings.LogsConfig{MaxDays: 1},
 			false,
 		},
+		{
+			settings.LogsConfig{MaxDays: 1, ModuleLevels: map[string]int{"billing": -4}},
+			false,
+		},
+		{
+			settings.LogsConfig{
+				MaxDays:        1,
+				RequestsTarget: settings.LogsTargetFile,
+				AppTarget:      settings.LogsTargetBoth,
+				FileMaxSize:    1,
+				FileMaxBackups: 1,
+			},
+			false,
+		},
 	}
 
 	for i, scenario := range scenarios {
@@ -916,6 +2703,69 @@ func TestLogsConfigValidate(t *testing.T) {
 	}
 }
 
+func TestLogsConfigTargets(t *testing.T) {
+	scenarios := []struct {
+		target       string
+		expectedDB   bool
+		expectedFile bool
+	}{
+		{"", true, false},
+		{settings.LogsTargetDB, true, false},
+		{settings.LogsTargetFile, false, true},
+		{settings.LogsTargetBoth, true, true},
+	}
+
+	for _, s := range scenarios {
+		requestsConfig := settings.LogsConfig{RequestsTarget: s.target, AppTarget: "invalid"}
+		if v := requestsConfig.HasDBTarget(true); v != s.expectedDB {
+			t.Errorf("[requests %q] Expected HasDBTarget %v, got %v", s.target, s.expectedDB, v)
+		}
+		if v := requestsConfig.HasFileTarget(true); v != s.expectedFile {
+			t.Errorf("[requests %q] Expected HasFileTarget %v, got %v", s.target, s.expectedFile, v)
+		}
+
+		appConfig := settings.LogsConfig{RequestsTarget: "invalid", AppTarget: s.target}
+		if v := appConfig.HasDBTarget(false); v != s.expectedDB {
+			t.Errorf("[app %q] Expected HasDBTarget %v, got %v", s.target, s.expectedDB, v)
+		}
+		if v := appConfig.HasFileTarget(false); v != s.expectedFile {
+			t.Errorf("[app %q] Expected HasFileTarget %v, got %v", s.target, s.expectedFile, v)
+		}
+	}
+}
+
+func TestLogsConfigModuleLevel(t *testing.T) {
+	config := settings.LogsConfig{
+		ModuleLevels: map[string]int{
+			"billing":          -4,
+			"billing.invoices": 8,
+		},
+	}
+
+	scenarios := []struct {
+		module        string
+		expectedLevel int
+		expectedOk    bool
+	}{
+		{"", 0, false},
+		{"missing", 0, false},
+		{"billing", -4, true},
+		{"billing.invoices", 8, true},
+		{"billing.invoices.pdf", 8, true},
+		{"billing.payments", -4, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.module, func(t *testing.T) {
+			level, ok := config.ModuleLevel(s.module)
+
+			if level != s.expectedLevel || ok != s.expectedOk {
+				t.Fatalf("Expected (%d, %v), got (%d, %v)", s.expectedLevel, s.expectedOk, level, ok)
+			}
+		})
+	}
+}
+
 func TestAuthProviderConfigValidate(t *testing.T) {
 	scenarios := []struct {
 		config      settings.AuthProviderConfig
@@ -0,0 +1,24 @@
+package models
+
+var _ Model = (*ShareComment)(nil)
+
+// ShareComment defines a single comment left on a shared record
+// by a share link holder.
+type ShareComment struct {
+	BaseModel
+
+	// ShareLinkId is the id of the share link used for the comment.
+	ShareLinkId string `db:"shareLinkId" json:"shareLinkId"`
+
+	// CollectionId and RecordId identify the commented record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Author is the self-reported name of the commenter.
+	Author  string `db:"author" json:"author"`
+	Message string `db:"message" json:"message"`
+}
+
+func (m *ShareComment) TableName() string {
+	return "_shareComments"
+}
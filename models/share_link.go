@@ -0,0 +1,60 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*ShareLink)(nil)
+
+// ShareLink defines a single revocable public share token that grants
+// read access to a single record (and its files) regardless of the
+// collection API rules.
+//
+// Only the hash of the share token is stored.
+type ShareLink struct {
+	BaseModel
+
+	// CollectionId and RecordId identify the shared record.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	Hash string `db:"hash" json:"-"`
+
+	// Label is an optional description of the share (eg. the recipient name).
+	Label string `db:"label" json:"label"`
+
+	// AllowComments indicates whether the share token holders
+	// could also leave comments on the shared record.
+	AllowComments bool `db:"allowComments" json:"allowComments"`
+
+	// Expires is the optional expiration date of the share (empty means no expiration).
+	Expires types.DateTime `db:"expires" json:"expires"`
+
+	// MaxUses is the max allowed record views and file downloads
+	// with the share token (0 means no limit).
+	//
+	// Once reached, the share link comments are also no longer accessible.
+	MaxUses int `db:"maxUses" json:"maxUses"`
+
+	// Uses is the number of record views and file downloads with the share token so far.
+	Uses int `db:"uses" json:"uses"`
+
+	Revoked bool `db:"revoked" json:"revoked"`
+}
+
+func (m *ShareLink) TableName() string {
+	return "_shareLinks"
+}
+
+// IsExpired checks whether the share link has expired.
+func (m *ShareLink) IsExpired() bool {
+	return !m.Expires.IsZero() && m.Expires.Time().Before(types.NowDateTime().Time())
+}
+
+// IsExhausted checks whether the share link max uses limit was reached.
+func (m *ShareLink) IsExhausted() bool {
+	return m.MaxUses > 0 && m.Uses >= m.MaxUses
+}
+
+// IsActive checks whether the share link could be still used.
+func (m *ShareLink) IsActive() bool {
+	return !m.Revoked && !m.IsExpired() && !m.IsExhausted()
+}
@@ -0,0 +1,59 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestShareLinkTableName(t *testing.T) {
+	m := models.ShareLink{}
+	if m.TableName() != "_shareLinks" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestShareLinkIsActive(t *testing.T) {
+	past, _ := types.ParseDateTime(time.Now().Add(-1 * time.Hour))
+	future, _ := types.ParseDateTime(time.Now().Add(1 * time.Hour))
+
+	scenarios := []struct {
+		name            string
+		link            models.ShareLink
+		expectExpired   bool
+		expectExhausted bool
+		expectActive    bool
+	}{
+		{"zero value", models.ShareLink{}, false, false, true},
+		{"future expires", models.ShareLink{Expires: future}, false, false, true},
+		{"past expires", models.ShareLink{Expires: past}, true, false, false},
+		{"uses below max", models.ShareLink{MaxUses: 2, Uses: 1}, false, false, true},
+		{"uses reached max", models.ShareLink{MaxUses: 2, Uses: 2}, false, true, false},
+		{"revoked", models.ShareLink{Revoked: true}, false, false, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if v := s.link.IsExpired(); v != s.expectExpired {
+				t.Fatalf("Expected IsExpired %v, got %v", s.expectExpired, v)
+			}
+
+			if v := s.link.IsExhausted(); v != s.expectExhausted {
+				t.Fatalf("Expected IsExhausted %v, got %v", s.expectExhausted, v)
+			}
+
+			if v := s.link.IsActive(); v != s.expectActive {
+				t.Fatalf("Expected IsActive %v, got %v", s.expectActive, v)
+			}
+		})
+	}
+}
+
+func TestShareCommentTableName(t *testing.T) {
+	m := models.ShareComment{}
+	if m.TableName() != "_shareComments" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*SignatureRequest)(nil)
+
+// Signature request statuses.
+const (
+	SignatureRequestStatusPending = "pending"
+	SignatureRequestStatusSigned  = "signed"
+	SignatureRequestStatusRevoked = "revoked"
+)
+
+// Signature types.
+const (
+	SignatureTypeTyped = "typed"
+	SignatureTypeDrawn = "drawn"
+)
+
+// SignatureRequest defines a single request for signing a record PDF file
+// through a tokenized public endpoint.
+//
+// Only the hash of the signing token is stored.
+type SignatureRequest struct {
+	BaseModel
+
+	// CollectionId and RecordId identify the record with the document to sign.
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Field is the name of the record file field with the document.
+	Field string `db:"field" json:"field"`
+
+	// File is the name of the document file to sign.
+	File string `db:"file" json:"file"`
+
+	// DocumentHash is the SHA-256 hex hash of the document at the
+	// time of the request creation.
+	DocumentHash string `db:"documentHash" json:"documentHash"`
+
+	Hash string `db:"hash" json:"-"`
+
+	SignerName  string `db:"signerName" json:"signerName"`
+	SignerEmail string `db:"signerEmail" json:"signerEmail"`
+
+	// Expires is the optional expiration date of the request (empty means no expiration).
+	Expires types.DateTime `db:"expires" json:"expires"`
+
+	Status string `db:"status" json:"status"`
+
+	// Signature audit trail fields (set on sign).
+	SignatureType   string         `db:"signatureType" json:"signatureType"`
+	SignedName      string         `db:"signedName" json:"signedName"`
+	SignedFile      string         `db:"signedFile" json:"signedFile"`
+	SignedHash      string         `db:"signedHash" json:"signedHash"`
+	SignedIp        string         `db:"signedIp" json:"signedIp"`
+	SignedUserAgent string         `db:"signedUserAgent" json:"signedUserAgent"`
+	Signed          types.DateTime `db:"signed" json:"signed"`
+}
+
+func (m *SignatureRequest) TableName() string {
+	return "_signatureRequests"
+}
+
+// IsExpired checks whether the signature request has expired.
+func (m *SignatureRequest) IsExpired() bool {
+	return !m.Expires.IsZero() && m.Expires.Time().Before(types.NowDateTime().Time())
+}
+
+// IsPending checks whether the signature request could be still signed.
+func (m *SignatureRequest) IsPending() bool {
+	return m.Status == SignatureRequestStatusPending && !m.IsExpired()
+}
@@ -0,0 +1,47 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestSignatureRequestTableName(t *testing.T) {
+	m := models.SignatureRequest{}
+	if m.TableName() != "_signatureRequests" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestSignatureRequestIsPending(t *testing.T) {
+	past, _ := types.ParseDateTime(time.Now().Add(-1 * time.Hour))
+	future, _ := types.ParseDateTime(time.Now().Add(1 * time.Hour))
+
+	scenarios := []struct {
+		name          string
+		request       models.SignatureRequest
+		expectExpired bool
+		expectPending bool
+	}{
+		{"zero value", models.SignatureRequest{}, false, false},
+		{"pending", models.SignatureRequest{Status: models.SignatureRequestStatusPending}, false, true},
+		{"pending with future expires", models.SignatureRequest{Status: models.SignatureRequestStatusPending, Expires: future}, false, true},
+		{"pending with past expires", models.SignatureRequest{Status: models.SignatureRequestStatusPending, Expires: past}, true, false},
+		{"signed", models.SignatureRequest{Status: models.SignatureRequestStatusSigned}, false, false},
+		{"revoked", models.SignatureRequest{Status: models.SignatureRequestStatusRevoked}, false, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if v := s.request.IsExpired(); v != s.expectExpired {
+				t.Fatalf("Expected IsExpired %v, got %v", s.expectExpired, v)
+			}
+
+			if v := s.request.IsPending(); v != s.expectPending {
+				t.Fatalf("Expected IsPending %v, got %v", s.expectPending, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+var _ Model = (*SlowQuery)(nil)
+
+// SlowQuery defines a captured db query that exceeded the
+// configured slow query threshold.
+//
+// The queries are grouped by their fingerprint (the sql statement
+// with the literal values replaced by placeholders).
+type SlowQuery struct {
+	BaseModel
+
+	// Fingerprint is the hash of the normalized sql statement.
+	Fingerprint string `db:"fingerprint" json:"fingerprint"`
+
+	// Sql is the last captured sql statement of the group.
+	Sql string `db:"sql" json:"sql"`
+
+	// Plan is the "EXPLAIN QUERY PLAN" output of the last captured statement.
+	Plan string `db:"plan" json:"plan"`
+
+	// Count is the total number of the captured executions.
+	Count int `db:"count" json:"count"`
+
+	// MaxDuration and LastDuration are the execution times in ms.
+	MaxDuration  float64 `db:"maxDuration" json:"maxDuration"`
+	LastDuration float64 `db:"lastDuration" json:"lastDuration"`
+
+	// Resolved indicates that the query was marked as reviewed/fixed
+	// (it is reset if the query is captured again).
+	Resolved bool `db:"resolved" json:"resolved"`
+}
+
+func (m *SlowQuery) TableName() string {
+	return "_slowQueries"
+}
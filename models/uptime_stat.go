@@ -0,0 +1,31 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*UptimeStat)(nil)
+
+// Uptime tracked subsystems.
+const (
+	UptimeSubsystemApi      = "api"
+	UptimeSubsystemRealtime = "realtime"
+	UptimeSubsystemStorage  = "storage"
+)
+
+// UptimeStat defines the hourly requests and errors counters
+// of a single app subsystem (used by the public status page).
+type UptimeStat struct {
+	BaseModel
+
+	// Subsystem is the name of the tracked subsystem (api, realtime or storage).
+	Subsystem string `db:"subsystem" json:"subsystem"`
+
+	// Hour is the start of the hourly bucket (UTC).
+	Hour types.DateTime `db:"hour" json:"hour"`
+
+	Total  int `db:"total" json:"total"`
+	Errors int `db:"errors" json:"errors"`
+}
+
+func (m *UptimeStat) TableName() string {
+	return "_uptime"
+}
@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// NotifyAdmins raises a new admin notification center entry.
+//
+// Repeated calls with the same source and title update the existing
+// unread notification and increment its Count instead of creating a new one.
+func NotifyAdmins(app core.App, severity string, source string, title string, body string, data map[string]any) (*models.AdminNotification, error) {
+	notification := &models.AdminNotification{
+		Severity: severity,
+		Source:   source,
+		Title:    title,
+		Body:     body,
+		Data:     types.JsonMap{},
+	}
+	for k, v := range data {
+		notification.Data[k] = v
+	}
+
+	if err := app.Dao().RaiseAdminNotification(notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// MarkAdminNotificationAsRead marks the provided admin notification as read.
+func MarkAdminNotificationAsRead(app core.App, notification *models.AdminNotification) error {
+	if notification.Read {
+		return nil // already read
+	}
+
+	notification.Read = true
+
+	return app.Dao().SaveAdminNotification(notification)
+}
+
+// MarkAllAdminNotificationsAsRead marks all unread admin notifications as read.
+func MarkAllAdminNotificationsAsRead(app core.App) error {
+	return app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		unread, err := txDao.FindUnreadAdminNotifications(types.DateTime{})
+		if err != nil {
+			return err
+		}
+
+		for _, notification := range unread {
+			notification.Read = true
+
+			if err := txDao.SaveAdminNotification(notification); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// SendAdminDigest sends an email digest with the unread admin notifications
+// raised (or repeated) in the last 24 hours to all admins.
+//
+// No emails are sent if there are no new unread notifications.
+func SendAdminDigest(app core.App) error {
+	since, err := types.ParseDateTime(time.Now().UTC().Add(-24 * time.Hour))
+	if err != nil {
+		return err
+	}
+
+	unread, err := app.Dao().FindUnreadAdminNotifications(since)
+	if err != nil {
+		return err
+	}
+
+	if len(unread) == 0 {
+		return nil
+	}
+
+	admins := []*models.Admin{}
+	if err := app.Dao().AdminQuery().OrderBy("created ASC").All(&admins); err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, admin := range admins {
+		if err := mails.SendAdminNotificationsDigest(app, admin, unread); err != nil {
+			app.Logger().Debug(
+				"Failed to send admin notifications digest",
+				slog.String("email", admin.Email),
+				slog.String("error", err.Error()),
+			)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,92 @@
+package notifications_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/notifications"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestNotifyAdmins(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := notifications.NotifyAdmins(app, models.AdminNotificationSeverityError, "test", "", "", nil); err == nil {
+		t.Fatal("Expected error for missing title, got nil")
+	}
+
+	n1, err := notifications.NotifyAdmins(app, models.AdminNotificationSeverityError, "test", "Failed", "a", map[string]any{"a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n1.Count != 1 || n1.Data["a"] != 1 {
+		t.Fatalf("Unexpected notification %v", n1)
+	}
+
+	n2, err := notifications.NotifyAdmins(app, models.AdminNotificationSeverityError, "test", "Failed", "b", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n2.Id != n1.Id || n2.Count != 2 {
+		t.Fatalf("Expected the repeated notification to be grouped, got %v", n2)
+	}
+}
+
+func TestMarkAdminNotificationsAsRead(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	n1, _ := notifications.NotifyAdmins(app, models.AdminNotificationSeverityInfo, "test", "test1", "", nil)
+	notifications.NotifyAdmins(app, models.AdminNotificationSeverityInfo, "test", "test2", "", nil)
+	notifications.NotifyAdmins(app, models.AdminNotificationSeverityInfo, "test", "test3", "", nil)
+
+	if err := notifications.MarkAdminNotificationAsRead(app, n1); err != nil {
+		t.Fatal(err)
+	}
+
+	if total, _ := app.Dao().TotalUnreadAdminNotifications(); total != 2 {
+		t.Fatalf("Expected 2 unread notifications, got %d", total)
+	}
+
+	if err := notifications.MarkAllAdminNotificationsAsRead(app); err != nil {
+		t.Fatal(err)
+	}
+
+	if total, _ := app.Dao().TotalUnreadAdminNotifications(); total != 0 {
+		t.Fatalf("Expected 0 unread notifications, got %d", total)
+	}
+}
+
+func TestSendAdminDigest(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	totalAdmins, err := app.Dao().TotalAdmins()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no unread notifications
+	if err := notifications.SendAdminDigest(app); err != nil {
+		t.Fatal(err)
+	}
+	if app.TestMailer.TotalSend != 0 {
+		t.Fatalf("Expected no digest emails, got %d", app.TestMailer.TotalSend)
+	}
+
+	notifications.NotifyAdmins(app, models.AdminNotificationSeverityError, "test", "test1", "", nil)
+
+	if err := notifications.SendAdminDigest(app); err != nil {
+		t.Fatal(err)
+	}
+	if app.TestMailer.TotalSend != totalAdmins {
+		t.Fatalf("Expected %d digest emails, got %d", totalAdmins, app.TestMailer.TotalSend)
+	}
+}
@@ -0,0 +1,134 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// Create creates a new in-app notification for the specified auth record.
+//
+// The created notification is automatically delivered to the
+// recipient realtime clients subscribed to the "@notifications" topic.
+func Create(app core.App, recipient *models.Record, title string, body string, data map[string]any) (*models.Notification, error) {
+	result, err := CreateBatch(app, []*models.Record{recipient}, title, body, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return result[0], nil
+}
+
+// CreateBatch creates a new in-app notification with the same content
+// for each of the specified auth records.
+//
+// The notifications are created in a single transaction.
+func CreateBatch(app core.App, recipients []*models.Record, title string, body string, data map[string]any) ([]*models.Notification, error) {
+	if title == "" {
+		return nil, errors.New("the notification title is required")
+	}
+
+	for _, recipient := range recipients {
+		if recipient == nil || recipient.Collection() == nil || !recipient.Collection().IsAuth() {
+			return nil, errors.New("the notification recipient must be an auth record")
+		}
+	}
+
+	result := make([]*models.Notification, len(recipients))
+
+	err := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for i, recipient := range recipients {
+			notification := &models.Notification{
+				CollectionId: recipient.Collection().Id,
+				RecordId:     recipient.Id,
+				Title:        title,
+				Body:         body,
+				Data:         types.JsonMap{},
+			}
+			for k, v := range data {
+				notification.Data[k] = v
+			}
+
+			if err := txDao.SaveNotification(notification); err != nil {
+				return err
+			}
+
+			result[i] = notification
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateForRelation creates a new in-app notification for each auth record
+// referenced by the relationField of the provided record
+// (eg. all "members" of an "organizations" record).
+func CreateForRelation(app core.App, record *models.Record, relationField string, title string, body string, data map[string]any) ([]*models.Notification, error) {
+	field := record.Collection().Schema.GetFieldByName(relationField)
+	if field == nil || field.Type != schema.FieldTypeRelation {
+		return nil, fmt.Errorf("%q is not a relation field", relationField)
+	}
+
+	field.InitOptions()
+	options, _ := field.Options.(*schema.RelationOptions)
+	if options == nil {
+		return nil, fmt.Errorf("failed to load the %q relation field options", relationField)
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId(options.CollectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !collection.IsAuth() {
+		return nil, fmt.Errorf("%q is not an auth collection relation field", relationField)
+	}
+
+	recipients, err := app.Dao().FindRecordsByIds(collection.Id, record.GetStringSlice(relationField))
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateBatch(app, recipients, title, body, data)
+}
+
+// MarkAsRead marks the provided notification as read.
+func MarkAsRead(app core.App, notification *models.Notification) error {
+	if notification.Read {
+		return nil // already read
+	}
+
+	notification.Read = true
+
+	return app.Dao().SaveNotification(notification)
+}
+
+// MarkAllAsRead marks all unread notifications of the specified auth record as read.
+func MarkAllAsRead(app core.App, recipient *models.Record) error {
+	return app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		unread, err := txDao.FindAllUnreadNotificationsByRecord(recipient.Collection().Id, recipient.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, notification := range unread {
+			notification.Read = true
+
+			if err := txDao.SaveNotification(notification); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,158 @@
+package notifications_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/notifications"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo, err := app.Dao().FindRecordById("demo1", "al1h9ijdeojtsjy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := notifications.Create(app, user, "", "test", nil); err == nil {
+		t.Fatal("Expected error for missing title, got nil")
+	}
+
+	if _, err := notifications.Create(app, demo, "test", "", nil); err == nil {
+		t.Fatal("Expected error for non-auth recipient, got nil")
+	}
+
+	notification, err := notifications.Create(app, user, "test_title", "test_body", map[string]any{"a": 123})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.Dao().FindNotificationById(notification.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.CollectionId != user.Collection().Id || found.RecordId != user.Id {
+		t.Fatalf("Expected recipient %s/%s, got %s/%s", user.Collection().Id, user.Id, found.CollectionId, found.RecordId)
+	}
+
+	if found.Title != "test_title" || found.Body != "test_body" || found.Read {
+		t.Fatalf("Unexpected notification %v", found)
+	}
+
+	if v := found.Data.Get("a"); v != float64(123) {
+		t.Fatalf("Expected data.a to be 123, got %v", v)
+	}
+}
+
+func TestCreateForRelation(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo, err := app.Dao().FindRecordById("demo1", "al1h9ijdeojtsjy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		field       string
+		expectError bool
+	}{
+		{"missing", true},
+		{"text", true},
+		{"rel_one", true}, // non-auth collection relation
+		{"rel_many", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.field, func(t *testing.T) {
+			result, err := notifications.CreateForRelation(app, demo, s.field, "test", "", nil)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			expectedIds := demo.GetStringSlice(s.field)
+			if len(result) != len(expectedIds) {
+				t.Fatalf("Expected %d notifications, got %d", len(expectedIds), len(result))
+			}
+
+			for _, id := range expectedIds {
+				var exists bool
+				for _, n := range result {
+					if n.RecordId == id {
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					t.Fatalf("Missing notification for recipient %q", id)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkAsRead(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n1, _ := notifications.Create(app, user1, "test1", "", nil)
+	n2, _ := notifications.Create(app, user1, "test2", "", nil)
+	n3, _ := notifications.Create(app, user1, "test3", "", nil)
+	n4, _ := notifications.Create(app, user2, "test4", "", nil)
+
+	if err := notifications.MarkAsRead(app, n1); err != nil {
+		t.Fatal(err)
+	}
+
+	assertRead := func(n *models.Notification, expected bool) {
+		found, err := app.Dao().FindNotificationById(n.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Read != expected {
+			t.Fatalf("Expected notification %q read %v, got %v", found.Title, expected, found.Read)
+		}
+	}
+
+	assertRead(n1, true)
+	assertRead(n2, false)
+
+	if err := notifications.MarkAllAsRead(app, user1); err != nil {
+		t.Fatal(err)
+	}
+
+	assertRead(n2, true)
+	assertRead(n3, true)
+	assertRead(n4, false)
+}
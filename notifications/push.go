@@ -0,0 +1,165 @@
+// Package notifications implements helpers for creating in-app notifications
+// and delivering push notifications to the registered auth records devices.
+package notifications
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+// maxConcurrentSends is the max number of concurrent provider requests per batch.
+const maxConcurrentSends = 10
+
+// Delivery defines the push notification delivery result of a single device.
+type Delivery struct {
+	DeviceId string `json:"deviceId"`
+	RecordId string `json:"recordId"`
+	Provider string `json:"provider"`
+	Error    string `json:"error"`
+
+	// Removed indicates that the device token was no longer valid
+	// and the device was unregistered.
+	Removed bool `json:"removed"`
+}
+
+// SendResult defines the push notifications batch delivery result.
+type SendResult struct {
+	Total      int         `json:"total"`
+	Sent       int         `json:"sent"`
+	Failed     int         `json:"failed"`
+	Deliveries []*Delivery `json:"deliveries"`
+}
+
+// Send sends a push notification to all registered devices of the specified auth record.
+//
+// See [SendBatch] for more details.
+func Send(app core.App, recordId string, message *push.Message) (*SendResult, error) {
+	return SendBatch(app, []string{recordId}, message)
+}
+
+// SendBatch sends a push notification to all registered devices
+// of the specified auth records.
+//
+// The deliveries are executed concurrently (with up to 10 parallel
+// provider requests) and the devices with invalid or expired tokens
+// are automatically removed.
+//
+// Individual delivery failures are not returned as error but are
+// reported in the result deliveries and logged in the app logs.
+func SendBatch(app core.App, recordIds []string, message *push.Message) (*SendResult, error) {
+	if message == nil || (message.Title == "" && message.Body == "") {
+		return nil, errors.New("the push notification title or body is required")
+	}
+
+	devices, err := app.Dao().FindAllPushDevicesByRecordIds(recordIds...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SendResult{
+		Total:      len(devices),
+		Deliveries: make([]*Delivery, len(devices)),
+	}
+
+	if len(devices) == 0 {
+		return result, nil
+	}
+
+	// init a single client per provider
+	clients := map[string]push.Client{}
+	clientErrs := map[string]error{}
+	for _, device := range devices {
+		if _, ok := clients[device.Provider]; ok {
+			continue
+		}
+		if _, ok := clientErrs[device.Provider]; ok {
+			continue
+		}
+
+		client, err := app.NewPushClient(device.Provider)
+		if err != nil {
+			clientErrs[device.Provider] = err
+		} else {
+			clients[device.Provider] = client
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSends)
+
+	for i, device := range devices {
+		delivery := &Delivery{
+			DeviceId: device.Id,
+			RecordId: device.RecordId,
+			Provider: device.Provider,
+		}
+		result.Deliveries[i] = delivery
+
+		if err := clientErrs[device.Provider]; err != nil {
+			delivery.Error = err.Error()
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(client push.Client, device *models.PushDevice) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := client.Send(device.Token, message); err != nil {
+				delivery.Error = err.Error()
+				delivery.Removed = errors.Is(err, push.ErrInvalidToken)
+			}
+		}(clients[device.Provider], device)
+	}
+
+	wg.Wait()
+
+	for i, delivery := range result.Deliveries {
+		if delivery.Error == "" {
+			result.Sent++
+			continue
+		}
+
+		result.Failed++
+
+		if delivery.Removed {
+			if err := app.Dao().DeletePushDevice(devices[i]); err != nil {
+				delivery.Removed = false
+				app.Logger().Debug(
+					"Failed to delete invalid push device",
+					"deviceId", delivery.DeviceId,
+					"error", err.Error(),
+				)
+			}
+		}
+
+		app.Logger().Warn(
+			"Push notification delivery failed",
+			"type", "push",
+			"deviceId", delivery.DeviceId,
+			"recordId", delivery.RecordId,
+			"provider", delivery.Provider,
+			"removed", delivery.Removed,
+			"error", delivery.Error,
+		)
+	}
+
+	app.Logger().Info(
+		"Push notification sent",
+		"type", "push",
+		"title", message.Title,
+		"total", result.Total,
+		"sent", result.Sent,
+		"failed", result.Failed,
+	)
+
+	return result, nil
+}
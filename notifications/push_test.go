@@ -0,0 +1,112 @@
+package notifications_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/notifications"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+func TestSendValidation(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if _, err := notifications.Send(app, "4q1xlclmfloku33", nil); err == nil {
+		t.Fatal("Expected error for nil message, got nil")
+	}
+
+	if _, err := notifications.Send(app, "4q1xlclmfloku33", &push.Message{}); err == nil {
+		t.Fatal("Expected error for empty message, got nil")
+	}
+
+	result, err := notifications.Send(app, "4q1xlclmfloku33", &push.Message{Title: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 0 {
+		t.Fatalf("Expected 0 deliveries for record without devices, got %d", result.Total)
+	}
+}
+
+func TestSendBatch(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Push.FCM.Enabled = true
+	app.Settings().Push.WebPush.Enabled = true
+
+	user1, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindAuthRecordByEmail("users", "test2@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests.CreatePushDevice(t, app, "", user1.Collection().Id, user1.Id, push.ProviderFCM, "fcm1")
+	tests.CreatePushDevice(t, app, "", user1.Collection().Id, user1.Id, push.ProviderAPNs, "apns1") // disabled provider
+	tests.CreatePushDevice(t, app, "", user2.Collection().Id, user2.Id, push.ProviderWebPush, "webpush2")
+	invalid := tests.CreatePushDevice(t, app, "", user2.Collection().Id, user2.Id, push.ProviderFCM, "fcm2")
+
+	app.TestPushClient.InvalidTokens = []string{"fcm2"}
+
+	message := &push.Message{
+		Title: "test title",
+		Body:  "test body",
+		Data:  map[string]string{"orderId": "abc"},
+	}
+
+	result, err := notifications.SendBatch(app, []string{user1.Id, user2.Id, "missing"}, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Total != 4 || result.Sent != 2 || result.Failed != 2 {
+		t.Fatalf("Expected total 4, sent 2 and failed 2, got %d, %d, %d", result.Total, result.Sent, result.Failed)
+	}
+
+	if total := app.TestPushClient.TotalSend(); total != 2 {
+		t.Fatalf("Expected 2 sent push notifications, got %d", total)
+	}
+
+	for _, sent := range app.TestPushClient.SentMessages {
+		if sent.Token != "fcm1" && sent.Token != "webpush2" {
+			t.Fatalf("Unexpected sent token %q", sent.Token)
+		}
+		if sent.Message.Title != "test title" || sent.Message.Data["orderId"] != "abc" {
+			t.Fatalf("Unexpected sent message %v", sent.Message)
+		}
+	}
+
+	for _, delivery := range result.Deliveries {
+		switch delivery.Provider {
+		case push.ProviderAPNs:
+			if delivery.Error == "" || delivery.Removed {
+				t.Fatalf("Expected non-removed apns delivery error, got %v", delivery)
+			}
+		case push.ProviderFCM:
+			if delivery.DeviceId == invalid.Id && (delivery.Error == "" || !delivery.Removed) {
+				t.Fatalf("Expected the invalid device delivery to fail and be removed, got %v", delivery)
+			}
+		}
+	}
+
+	if _, err := app.Dao().FindPushDeviceById(invalid.Id); err == nil {
+		t.Fatal("Expected the invalid token device to be deleted")
+	}
+
+	devices, err := app.Dao().FindAllPushDevicesByRecordIds(user1.Id, user2.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 3 {
+		t.Fatalf("Expected 3 remaining devices, got %d", len(devices))
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"net/http"
 	"os"
@@ -19,11 +20,18 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/notifications"
 	"github.com/pocketbase/pocketbase/tools/archive"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/transport"
 	"github.com/spf13/cobra"
 )
 
@@ -50,10 +58,60 @@ type Config struct {
 	Context context.Context
 
 	// The HTTP client to use when fetching and downloading the latest release.
-	// Defaults to `http.DefaultClient`.
+	// Defaults to a client with the app outbound transport (proxy, CA certs, etc.).
 	HttpClient HttpClient
+
+	// NotifyNewVersion enables a daily check (starting on app serve)
+	// for a newer release that raises an admin notification when available.
+	NotifyNewVersion bool
+
+	// Token is an optional GitHub access token used for fetching the
+	// releases of a private repository (default to the GITHUB_TOKEN env variable).
+	Token string
+
+	// Channel specifies the default release channel - ChannelStable
+	// (the latest non-prerelease) or ChannelBeta (the newest release
+	// including the prereleases). Default to ChannelStable.
+	//
+	// It could be changed with the "update --channel" flag.
+	Channel string
+
+	// ChecksumsAsset specifies the name of the release asset with the
+	// sha256sum formatted checksums of the other assets (default to "checksums.txt").
+	//
+	// The downloaded archive is verified against it if the release has such asset.
+	ChecksumsAsset string
+
+	// MinisignPublicKey is an optional minisign public key used to verify the
+	// release checksums asset signature (the "{ChecksumsAsset}.minisig" asset).
+	MinisignPublicKey string
+
+	// CosignPublicKey is an optional PEM encoded cosign public key used to verify
+	// the release checksums asset signature (the "{ChecksumsAsset}.sig" asset).
+	CosignPublicKey string
+
+	// HooksAsset specifies the name of an optional release asset with
+	// the app hooks bundle (eg. "hooks.tar.gz").
+	//
+	// If set and the release has such asset, the hooks directory
+	// is replaced with the bundle content together with the executable
+	// (the current hooks directory is kept as "{HooksDir}.old").
+	HooksAsset string
+
+	// HooksDir specifies the app hooks directory that is replaced with
+	// the HooksAsset bundle (default to "pb_data/../pb_hooks").
+	HooksDir string
 }
 
+// Release channels.
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+// previousExecSuffix is the suffix of the executable replaced by the last update.
+const previousExecSuffix = ".old"
+
 // MustRegister registers the ghupdate plugin to the provided app instance
 // and panic if it fails.
 func MustRegister(app core.App, rootCmd *cobra.Command, config Config) {
@@ -83,18 +141,41 @@ func Register(app core.App, rootCmd *cobra.Command, config Config) error {
 	}
 
 	if p.config.HttpClient == nil {
-		p.config.HttpClient = http.DefaultClient
+		p.config.HttpClient = &http.Client{Transport: transport.Default}
 	}
 
 	if p.config.Context == nil {
 		p.config.Context = context.Background()
 	}
 
+	if p.config.Token == "" {
+		p.config.Token = os.Getenv("GITHUB_TOKEN")
+	}
+
+	if p.config.Channel == "" {
+		p.config.Channel = ChannelStable
+	}
+
+	if p.config.ChecksumsAsset == "" {
+		p.config.ChecksumsAsset = "checksums.txt"
+	}
+
+	if p.config.HooksDir == "" {
+		p.config.HooksDir = filepath.Join(app.DataDir(), "../pb_hooks")
+	}
+
 	rootCmd.AddCommand(p.updateCmd())
 
+	if p.config.NotifyNewVersion {
+		p.bindVersionCheck()
+	}
+
 	return nil
 }
 
+// notificationSource is the source name of the ghupdate admin notifications.
+const notificationSource = "ghupdate"
+
 type plugin struct {
 	app            core.App
 	config         Config
@@ -103,6 +184,8 @@ type plugin struct {
 
 func (p *plugin) updateCmd() *cobra.Command {
 	var withBackup bool
+	var restart bool
+	var channel string
 
 	command := &cobra.Command{
 		Use:          "update",
@@ -132,10 +215,12 @@ func (p *plugin) updateCmd() *cobra.Command {
 				}
 			}
 
-			return p.update(withBackup)
+			return p.update(withBackup, restart, channel)
 		},
 	}
 
+	command.AddCommand(p.rollbackCmd(&restart))
+
 	command.PersistentFlags().BoolVar(
 		&withBackup,
 		"backup",
@@ -143,10 +228,83 @@ func (p *plugin) updateCmd() *cobra.Command {
 		"Creates a pb_data backup at the end of the update process",
 	)
 
+	command.PersistentFlags().BoolVar(
+		&restart,
+		"restart",
+		false,
+		"Restarts the running app server with the new executable without dropping the in-flight requests",
+	)
+
+	command.Flags().StringVar(
+		&channel,
+		"channel",
+		p.config.Channel,
+		"The release channel to update from (stable or beta)",
+	)
+
 	return command
 }
 
-func (p *plugin) update(withBackup bool) error {
+func (p *plugin) rollbackCmd(restart *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:          "rollback",
+		Short:        "Restores the app executable replaced by the last update",
+		SilenceUsage: true,
+		RunE: func(command *cobra.Command, args []string) error {
+			return p.rollback(*restart)
+		},
+	}
+}
+
+// rollback swaps the current executable with the one replaced by the last update
+// (calling it again restores the updated executable).
+func (p *plugin) rollback(restart bool) error {
+	currentExec, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	prevExec := currentExec + previousExecSuffix
+	if _, err := os.Stat(prevExec); err != nil {
+		return errors.New("there is no previous executable to rollback to")
+	}
+
+	tempExec := currentExec + ".rollback"
+
+	if err := os.Rename(currentExec, tempExec); err != nil {
+		return fmt.Errorf("Failed to rename the current executable: %w", err)
+	}
+
+	if err := os.Rename(prevExec, currentExec); err != nil {
+		if revertErr := os.Rename(tempExec, currentExec); revertErr != nil {
+			p.app.Logger().Debug(
+				"Failed to revert executable",
+				slog.String("old", tempExec),
+				slog.String("new", currentExec),
+				slog.String("error", revertErr.Error()),
+			)
+		}
+		return fmt.Errorf("Failed to restore the previous executable: %w", err)
+	}
+
+	if err := os.Rename(tempExec, prevExec); err != nil {
+		return fmt.Errorf("Failed to keep the replaced executable: %w", err)
+	}
+
+	if err := p.rollbackHooksDir(); err != nil {
+		return err
+	}
+
+	color.Green("Rollback completed successfully! You can start the executable as usual.")
+
+	if restart {
+		return p.restartServer()
+	}
+
+	return nil
+}
+
+func (p *plugin) update(withBackup bool, restart bool, channel string) error {
 	color.Yellow("Fetching release information...")
 
 	latest, err := fetchLatestRelease(
@@ -154,6 +312,8 @@ func (p *plugin) update(withBackup bool) error {
 		p.config.HttpClient,
 		p.config.Owner,
 		p.config.Repo,
+		p.config.Token,
+		channel,
 	)
 	if err != nil {
 		return err
@@ -181,7 +341,11 @@ func (p *plugin) update(withBackup bool) error {
 
 	// download the release asset
 	assetZip := filepath.Join(releaseDir, asset.Name)
-	if err := downloadFile(p.config.Context, p.config.HttpClient, asset.DownloadUrl, assetZip); err != nil {
+	if err := downloadFile(p.config.Context, p.config.HttpClient, asset, p.config.Token, assetZip); err != nil {
+		return err
+	}
+
+	if err := p.verifyAsset(latest, asset, assetZip); err != nil {
 		return err
 	}
 
@@ -194,14 +358,25 @@ func (p *plugin) update(withBackup bool) error {
 		return err
 	}
 
+	newHooksDir, err := p.prepareHooksBundle(latest, releaseDir)
+	if err != nil {
+		return err
+	}
+	if newHooksDir != "" {
+		defer os.RemoveAll(newHooksDir)
+	}
+
 	color.Yellow("Replacing the executable...")
 
 	oldExec, err := os.Executable()
 	if err != nil {
 		return err
 	}
-	renamedOldExec := oldExec + ".old"
-	defer os.Remove(renamedOldExec)
+
+	// keep the current executable for rollback
+	// (replacing the one from the previous update, if any)
+	renamedOldExec := oldExec + previousExecSuffix
+	os.Remove(renamedOldExec)
 
 	newExec := filepath.Join(extractDir, p.config.ArchiveExecutable)
 	if _, err := os.Stat(newExec); err != nil {
@@ -234,11 +409,24 @@ func (p *plugin) update(withBackup bool) error {
 		return fmt.Errorf("Failed replacing the executable: %w", err)
 	}
 
+	// replace the hooks directory with the extracted bundle
+	revertHooks := func() {}
+	if newHooksDir != "" {
+		color.Yellow("Replacing the hooks directory...")
+
+		revertHooks, err = p.replaceHooksDir(newHooksDir)
+		if err != nil {
+			tryToRevertExecChanges()
+			return err
+		}
+	}
+
 	if withBackup {
 		color.Yellow("Creating pb_data backup...")
 
 		backupName := fmt.Sprintf("@update_%s.zip", latest.Tag)
 		if err := p.app.CreateBackup(p.config.Context, backupName); err != nil {
+			revertHooks()
 			tryToRevertExecChanges()
 			return err
 		}
@@ -246,6 +434,10 @@ func (p *plugin) update(withBackup bool) error {
 
 	color.HiBlack("---")
 	color.Green("Update completed successfully! You can start the executable as usual.")
+	color.HiBlack("The previous executable was kept as %s (run \"update rollback\" to restore it).", filepath.Base(renamedOldExec))
+	if newHooksDir != "" {
+		color.HiBlack("The previous hooks directory was kept as %s.", filepath.Base(p.config.HooksDir+previousHooksSuffix))
+	}
 
 	// print the release notes
 	if latest.Body != "" {
@@ -257,57 +449,230 @@ func (p *plugin) update(withBackup bool) error {
 		fmt.Print("\n")
 	}
 
+	if restart {
+		return p.restartServer()
+	}
+
 	return nil
 }
 
+// restartServer triggers a zero-downtime handover restart of the running
+// app server (if any) by sending SIGHUP to the pid from the serve pid file.
+func (p *plugin) restartServer() error {
+	if runtime.GOOS == "windows" {
+		return errors.New("the app server restart is not supported on windows")
+	}
+
+	raw, err := os.ReadFile(filepath.Join(p.app.DataDir(), core.ServePidFileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			color.Yellow("No running app server was found.")
+			return nil
+		}
+		return err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return fmt.Errorf("invalid serve pid file: %w", err)
+	}
+
+	process, err := os.FindProcess(pid)
+	if err == nil {
+		err = process.Signal(syscall.SIGHUP)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restart the app server (pid %d): %w", pid, err)
+	}
+
+	color.Green("The running app server (pid %d) is restarting with the new executable.", pid)
+
+	return nil
+}
+
+// bindVersionCheck registers the new version check app serve hooks.
+func (p *plugin) bindVersionCheck() {
+	c := cron.New()
+	c.SetLocker(p.app)
+
+	check := func() {
+		if err := p.checkNewVersion(); err != nil {
+			p.app.Logger().Debug(
+				"[ghupdate] Failed to check for a new version",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	c.MustAdd("@ghupdateCheck", "@daily", check)
+
+	p.app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		routine.FireAndForget(check)
+		c.Start()
+		return nil
+	})
+
+	p.app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+}
+
+// checkNewVersion raises an admin notification if there is a newer release
+// than the current one (each release is notified only once).
+func (p *plugin) checkNewVersion() error {
+	latest, err := fetchLatestRelease(
+		p.config.Context,
+		p.config.HttpClient,
+		p.config.Owner,
+		p.config.Repo,
+		p.config.Token,
+		p.config.Channel,
+	)
+	if err != nil {
+		return err
+	}
+
+	if compareVersions(strings.TrimPrefix(p.currentVersion, "v"), strings.TrimPrefix(latest.Tag, "v")) <= 0 {
+		return nil // already the latest version
+	}
+
+	title := fmt.Sprintf("New version %s is available", latest.Tag)
+
+	var total int
+	err = p.app.Dao().AdminNotificationQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"source": notificationSource, "title": title}).
+		Row(&total)
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return nil // already notified
+	}
+
+	_, err = notifications.NotifyAdmins(
+		p.app,
+		models.AdminNotificationSeverityInfo,
+		notificationSource,
+		title,
+		fmt.Sprintf("You are currently running %s. Run the update command to upgrade to the latest version.", p.currentVersion),
+		map[string]any{"tag": latest.Tag, "url": latest.Url},
+	)
+
+	return err
+}
+
+// fetchLatestRelease fetches the latest release of the specified channel.
+//
+// For ChannelBeta it returns the newest (by tag version) non-draft
+// release, including the prereleases.
 func fetchLatestRelease(
 	ctx context.Context,
 	client HttpClient,
 	owner string,
 	repo string,
+	token string,
+	channel string,
 ) (*release, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
+	if channel != ChannelBeta {
+		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
 
+		result := &release{}
+		if err := fetchJson(ctx, client, url, token, result); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases?per_page=30", owner, repo)
+
+	list := []*release{}
+	if err := fetchJson(ctx, client, url, token, &list); err != nil {
+		return nil, err
+	}
+
+	var result *release
+	for _, r := range list {
+		if r.Draft {
+			continue
+		}
+
+		if result == nil || compareVersions(strings.TrimPrefix(result.Tag, "v"), strings.TrimPrefix(r.Tag, "v")) > 0 {
+			result = r
+		}
+	}
+
+	if result == nil {
+		return nil, errors.New("no releases found")
+	}
+
+	return result, nil
+}
+
+func fetchJson(ctx context.Context, client HttpClient, url string, token string, result any) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	res, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer res.Body.Close()
 
 	rawBody, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// http.Client doesn't treat non 2xx responses as error
 	if res.StatusCode >= 400 {
-		return nil, fmt.Errorf(
+		return fmt.Errorf(
 			"(%d) failed to fetch latest releases:\n%s",
 			res.StatusCode,
 			string(rawBody),
 		)
 	}
 
-	result := &release{}
-	if err := json.Unmarshal(rawBody, result); err != nil {
+	return json.Unmarshal(rawBody, result)
+}
+
+// newDownloadRequest creates a new release asset download request.
+//
+// If token is set, the asset is downloaded through the GitHub API
+// (the browser download url is not accessible for private repositories).
+func newDownloadRequest(ctx context.Context, asset *releaseAsset, token string) (*http.Request, error) {
+	if token == "" || asset.ApiUrl == "" {
+		return http.NewRequestWithContext(ctx, "GET", asset.DownloadUrl, nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", asset.ApiUrl, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	req.Header.Set("Accept", "application/octet-stream")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return req, nil
 }
 
 func downloadFile(
 	ctx context.Context,
 	client HttpClient,
-	url string,
+	asset *releaseAsset,
+	token string,
 	destPath string,
 ) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := newDownloadRequest(ctx, asset, token)
 	if err != nil {
 		return err
 	}
@@ -341,6 +706,40 @@ func downloadFile(
 	return nil
 }
 
+// maxSmallAssetSize is the max allowed size of the downloaded
+// checksums and signature assets.
+const maxSmallAssetSize = 1 << 20
+
+// downloadBytes downloads a small release asset (eg. checksums or signature) in memory.
+func downloadBytes(ctx context.Context, client HttpClient, asset *releaseAsset, token string) ([]byte, error) {
+	req, err := newDownloadRequest(ctx, asset, token)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// http.Client doesn't treat non 2xx responses as error
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("(%d) failed to download %s", res.StatusCode, asset.Name)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxSmallAssetSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(raw) > maxSmallAssetSize {
+		return nil, fmt.Errorf("%s is too large", asset.Name)
+	}
+
+	return raw, nil
+}
+
 func archiveSuffix(goos, goarch string) string {
 	switch goos {
 	case "linux":
@@ -371,11 +770,37 @@ func archiveSuffix(goos, goarch string) string {
 	return ""
 }
 
+// compareVersions compares the a and b semver-like versions.
+//
+// It returns 1 if b is newer, -1 if a is newer and 0 if they are equal.
+//
+// A prerelease (eg. "1.2.0-beta.1") is older than its release ("1.2.0").
 func compareVersions(a, b string) int {
-	aSplit := strings.Split(a, ".")
-	aTotal := len(aSplit)
+	aCore, aPre, _ := strings.Cut(a, "-")
+	bCore, bPre, _ := strings.Cut(b, "-")
 
-	bSplit := strings.Split(b, ".")
+	if result := compareVersionParts(strings.Split(aCore, "."), strings.Split(bCore, "."), false); result != 0 {
+		return result
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return -1 // a is the release
+	case bPre == "":
+		return 1 // b is the release
+	}
+
+	return compareVersionParts(strings.Split(aPre, "."), strings.Split(bPre, "."), true)
+}
+
+// compareVersionParts compares the dot separated version identifiers.
+//
+// If prerelease is set, the non-numeric identifiers are compared
+// lexically and a shorter set of identifiers is considered older.
+func compareVersionParts(aSplit, bSplit []string, prerelease bool) int {
+	aTotal := len(aSplit)
 	bTotal := len(bSplit)
 
 	limit := aTotal
@@ -384,14 +809,40 @@ func compareVersions(a, b string) int {
 	}
 
 	for i := 0; i < limit; i++ {
-		var x, y int
+		if prerelease {
+			if i >= aTotal {
+				return 1 // b is newer
+			}
+			if i >= bTotal {
+				return -1 // a is newer
+			}
+		}
+
+		var aPart, bPart string
 
 		if i < aTotal {
-			x, _ = strconv.Atoi(aSplit[i])
+			aPart = aSplit[i]
 		}
 
 		if i < bTotal {
-			y, _ = strconv.Atoi(bSplit[i])
+			bPart = bSplit[i]
+		}
+
+		x, xErr := strconv.Atoi(aPart)
+		y, yErr := strconv.Atoi(bPart)
+
+		if prerelease && (xErr != nil || yErr != nil) {
+			switch {
+			case xErr == nil:
+				return 1 // numeric identifiers are older
+			case yErr == nil:
+				return -1
+			case aPart < bPart:
+				return 1
+			case aPart > bPart:
+				return -1
+			}
+			continue
 		}
 
 		if x < y {
@@ -1,6 +1,15 @@
 package ghupdate
 
-import "testing"
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
 
 func TestCompareVersions(t *testing.T) {
 	scenarios := []struct {
@@ -24,6 +33,15 @@ func TestCompareVersions(t *testing.T) {
 		{"1.2.9", "1.2.10", 1},
 		{"3.2", "4.0", 1},
 		{"3.2.4", "3.2.3", -1},
+		{"1.2.0-beta.1", "1.2.0", 1},
+		{"1.2.0", "1.2.0-beta.1", -1},
+		{"1.2.0-beta.1", "1.2.0-beta.1", 0},
+		{"1.2.0-beta.1", "1.2.0-beta.2", 1},
+		{"1.2.0-beta.10", "1.2.0-beta.9", -1},
+		{"1.2.0-alpha", "1.2.0-beta", 1},
+		{"1.2.0-beta", "1.2.0-beta.1", 1},
+		{"1.2.0-rc.1", "1.1.9", -1},
+		{"1.2.0-rc.1", "1.2.1-alpha", 1},
 	}
 
 	for i, s := range scenarios {
@@ -34,3 +52,62 @@ func TestCompareVersions(t *testing.T) {
 		}
 	}
 }
+
+type testHttpClient struct {
+	body string
+}
+
+func (c *testHttpClient) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+	}, nil
+}
+
+func TestCheckNewVersion(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name           string
+		currentVersion string
+		expectedTotal  int
+	}{
+		{"same version", "v0.22.0", 0},
+		{"newer current version", "v0.23.0", 0},
+		{"older current version", "v0.21.0", 1},
+		{"older current version (repeated check)", "v0.21.0", 1},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			p := &plugin{
+				app:            app,
+				currentVersion: s.currentVersion,
+				config: Config{
+					Context:    context.Background(),
+					HttpClient: &testHttpClient{body: `{"tag_name":"v0.22.0","html_url":"https://example.com/v0.22.0"}`},
+				},
+			}
+
+			if err := p.checkNewVersion(); err != nil {
+				t.Fatal(err)
+			}
+
+			result := []*models.AdminNotification{}
+			if err := app.Dao().AdminNotificationQuery().All(&result); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(result) != s.expectedTotal {
+				t.Fatalf("Expected %d admin notifications, got %d", s.expectedTotal, len(result))
+			}
+
+			for _, n := range result {
+				if n.Source != "ghupdate" || n.Title != "New version v0.22.0 is available" || n.Count != 1 {
+					t.Fatalf("Unexpected admin notification %v", n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package ghupdate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/tools/archive"
+)
+
+// previousHooksSuffix is the suffix of the hooks directory replaced by the last update.
+const previousHooksSuffix = ".old"
+
+// prepareHooksBundle downloads, verifies and extracts the release hooks
+// bundle asset next to the hooks directory and returns the extracted
+// directory path.
+//
+// It returns empty string if there is no configured or available hooks bundle
+// (in which case the hooks directory of the previous update, if any, is discarded
+// so that a rollback doesn't restore an unrelated hooks directory).
+func (p *plugin) prepareHooksBundle(r *release, releaseDir string) (string, error) {
+	if p.config.HooksAsset == "" {
+		return "", nil
+	}
+
+	asset, err := r.findAssetByName(p.config.HooksAsset)
+	if err != nil {
+		color.HiBlack("The release doesn't have a %s asset (the hooks directory will not be changed).", p.config.HooksAsset)
+		os.RemoveAll(p.config.HooksDir + previousHooksSuffix)
+		return "", nil
+	}
+
+	color.Yellow("Downloading %s...", asset.Name)
+
+	assetPath := filepath.Join(releaseDir, asset.Name)
+	if err := downloadFile(p.config.Context, p.config.HttpClient, asset, p.config.Token, assetPath); err != nil {
+		return "", err
+	}
+
+	if err := p.verifyAsset(r, asset, assetPath); err != nil {
+		return "", err
+	}
+
+	color.Yellow("Extracting %s...", asset.Name)
+
+	// extract next to the hooks directory so that it could be renamed
+	// (aka. the paths are on the same filesystem)
+	extractDir := p.config.HooksDir + ".new"
+	if err := os.RemoveAll(extractDir); err != nil {
+		return "", err
+	}
+
+	if err := archive.ExtractTarGz(assetPath, extractDir); err != nil {
+		os.RemoveAll(extractDir)
+		return "", fmt.Errorf("Failed to extract the hooks bundle: %w", err)
+	}
+
+	return extractDir, nil
+}
+
+// replaceHooksDir replaces the hooks directory with newDir, keeping
+// the current one as "{HooksDir}.old".
+//
+// It returns a function that reverts the replacement.
+func (p *plugin) replaceHooksDir(newDir string) (func(), error) {
+	hooksDir := p.config.HooksDir
+	prevDir := hooksDir + previousHooksSuffix
+
+	if err := os.RemoveAll(prevDir); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(hooksDir); err == nil {
+		if err := os.Rename(hooksDir, prevDir); err != nil {
+			return nil, fmt.Errorf("Failed to rename the current hooks directory: %w", err)
+		}
+	} else if err := os.MkdirAll(prevDir, os.ModePerm); err != nil {
+		// there is no current hooks directory and the rollback
+		// should restore an empty one
+		return nil, err
+	}
+
+	revert := func() {
+		os.RemoveAll(hooksDir)
+		os.Rename(prevDir, hooksDir)
+	}
+
+	if err := os.Rename(newDir, hooksDir); err != nil {
+		revert()
+		return nil, fmt.Errorf("Failed replacing the hooks directory: %w", err)
+	}
+
+	return revert, nil
+}
+
+// rollbackHooksDir swaps the hooks directory with the one
+// replaced by the last update (if any).
+func (p *plugin) rollbackHooksDir() error {
+	if p.config.HooksAsset == "" {
+		return nil
+	}
+
+	hooksDir := p.config.HooksDir
+	prevDir := hooksDir + previousHooksSuffix
+
+	if _, err := os.Stat(prevDir); err != nil {
+		return nil // nothing to rollback
+	}
+
+	tempDir := hooksDir + ".rollback"
+	if err := os.RemoveAll(tempDir); err != nil {
+		return err
+	}
+
+	if err := os.Rename(hooksDir, tempDir); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Failed to rename the current hooks directory: %w", err)
+	}
+
+	if err := os.Rename(prevDir, hooksDir); err != nil {
+		os.Rename(tempDir, hooksDir)
+		return fmt.Errorf("Failed to restore the previous hooks directory: %w", err)
+	}
+
+	if err := os.Rename(tempDir, prevDir); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Failed to keep the replaced hooks directory: %w", err)
+	}
+
+	return nil
+}
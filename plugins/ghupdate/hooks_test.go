@@ -0,0 +1,108 @@
+package ghupdate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplaceAndRollbackHooksDir(t *testing.T) {
+	dir := t.TempDir()
+
+	p := &plugin{
+		config: Config{
+			HooksAsset: "hooks.tar.gz",
+			HooksDir:   filepath.Join(dir, "pb_hooks"),
+		},
+	}
+
+	writeHook := func(dir, content string) {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "main.pb.js"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkHook := func(dir, expected string) {
+		raw, err := os.ReadFile(filepath.Join(dir, "main.pb.js"))
+		if err != nil {
+			t.Fatalf("Failed to read the %s hook: %v", dir, err)
+		}
+		if string(raw) != expected {
+			t.Fatalf("Expected %s hook %q, got %q", dir, expected, raw)
+		}
+	}
+
+	writeHook(p.config.HooksDir, "old")
+
+	newDir := filepath.Join(dir, "pb_hooks.new")
+	writeHook(newDir, "new")
+
+	revert, err := p.replaceHooksDir(newDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkHook(p.config.HooksDir, "new")
+	checkHook(p.config.HooksDir+previousHooksSuffix, "old")
+
+	if _, err := os.Stat(newDir); err == nil {
+		t.Fatal("Expected the new hooks dir to be moved")
+	}
+
+	// rollback
+	if err := p.rollbackHooksDir(); err != nil {
+		t.Fatal(err)
+	}
+	checkHook(p.config.HooksDir, "old")
+	checkHook(p.config.HooksDir+previousHooksSuffix, "new")
+
+	// rollback again (aka. restore the updated hooks)
+	if err := p.rollbackHooksDir(); err != nil {
+		t.Fatal(err)
+	}
+	checkHook(p.config.HooksDir, "new")
+	checkHook(p.config.HooksDir+previousHooksSuffix, "old")
+
+	// revert
+	revert()
+	checkHook(p.config.HooksDir, "old")
+	if _, err := os.Stat(p.config.HooksDir + previousHooksSuffix); err == nil {
+		t.Fatal("Expected the previous hooks dir to be restored")
+	}
+}
+
+func TestReplaceHooksDirWithoutExisting(t *testing.T) {
+	dir := t.TempDir()
+
+	p := &plugin{
+		config: Config{
+			HooksAsset: "hooks.tar.gz",
+			HooksDir:   filepath.Join(dir, "pb_hooks"),
+		},
+	}
+
+	newDir := filepath.Join(dir, "pb_hooks.new")
+	if err := os.MkdirAll(newDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.replaceHooksDir(newDir); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(p.config.HooksDir); err != nil {
+		t.Fatalf("Expected the hooks dir to exist, got %v", err)
+	}
+
+	// the rollback should restore an empty hooks dir
+	entries, err := os.ReadDir(p.config.HooksDir + previousHooksSuffix)
+	if err != nil {
+		t.Fatalf("Expected an empty previous hooks dir, got %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Expected empty previous hooks dir, got %d entries", len(entries))
+	}
+}
@@ -8,18 +8,21 @@ import (
 type releaseAsset struct {
 	Name        string `json:"name"`
 	DownloadUrl string `json:"browser_download_url"`
+	ApiUrl      string `json:"url"`
 	Id          int    `json:"id"`
 	Size        int    `json:"size"`
 }
 
 type release struct {
-	Name      string          `json:"name"`
-	Tag       string          `json:"tag_name"`
-	Published string          `json:"published_at"`
-	Url       string          `json:"html_url"`
-	Body      string          `json:"body"`
-	Assets    []*releaseAsset `json:"assets"`
-	Id        int             `json:"id"`
+	Name       string          `json:"name"`
+	Tag        string          `json:"tag_name"`
+	Published  string          `json:"published_at"`
+	Url        string          `json:"html_url"`
+	Body       string          `json:"body"`
+	Assets     []*releaseAsset `json:"assets"`
+	Id         int             `json:"id"`
+	Draft      bool            `json:"draft"`
+	Prerelease bool            `json:"prerelease"`
 }
 
 // findAssetBySuffix returns the first available asset containing the specified suffix.
@@ -34,3 +37,14 @@ func (r *release) findAssetBySuffix(suffix string) (*releaseAsset, error) {
 
 	return nil, errors.New("missing asset containing " + suffix)
 }
+
+// findAssetByName returns the asset with the specified name.
+func (r *release) findAssetByName(name string) (*releaseAsset, error) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, nil
+		}
+	}
+
+	return nil, errors.New("missing asset " + name)
+}
@@ -21,3 +21,25 @@ func TestReleaseFindAssetBySuffix(t *testing.T) {
 		t.Fatalf("Expected asset with id %d, got %v", 2, asset)
 	}
 }
+
+func TestReleaseFindAssetByName(t *testing.T) {
+	r := release{
+		Assets: []*releaseAsset{
+			{Name: "checksums.txt", Id: 1},
+			{Name: "checksums.txt.sig", Id: 2},
+		},
+	}
+
+	asset, err := r.findAssetByName("checksums.txt")
+	if err != nil {
+		t.Fatalf("Expected nil, got err: %v", err)
+	}
+
+	if asset.Id != 1 {
+		t.Fatalf("Expected asset with id %d, got %v", 1, asset)
+	}
+
+	if _, err := r.findAssetByName("checksums"); err == nil {
+		t.Fatal("Expected error for missing asset, got nil")
+	}
+}
@@ -0,0 +1,248 @@
+package ghupdate
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"golang.org/x/crypto/blake2b"
+)
+
+// parseChecksums parses a sha256sum formatted checksums file
+// (aka. "<hex hash>  <file name>" per line) into a file name -> hash map.
+func parseChecksums(content []byte) map[string]string {
+	result := map[string]string{}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		// "*" is the binary mode marker
+		name := strings.TrimPrefix(fields[len(fields)-1], "*")
+
+		result[name] = strings.ToLower(fields[0])
+	}
+
+	return result
+}
+
+// verifyFileChecksum checks whether the SHA-256 hash of the
+// specified file matches the expected hex encoded one.
+func verifyFileChecksum(path string, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(hex.EncodeToString(h.Sum(nil)), expected) {
+		return fmt.Errorf("checksum mismatch for %s", filepath.Base(path))
+	}
+
+	return nil
+}
+
+// verifyMinisign verifies a minisign signature of message.
+//
+// publicKey could be either the base64 encoded key or the full minisign.pub file content.
+// Both the legacy ("Ed") and the prehashed ("ED") signature algorithms are supported.
+func verifyMinisign(publicKey string, message []byte, signature []byte) error {
+	rawKey, err := base64.StdEncoding.DecodeString(lastMinisignLine(publicKey))
+	if err != nil || len(rawKey) != 42 || string(rawKey[:2]) != "Ed" {
+		return errors.New("invalid minisign public key")
+	}
+	keyId := rawKey[2:10]
+	key := ed25519.PublicKey(rawKey[10:])
+
+	lines := []string{}
+	for _, line := range strings.Split(string(signature), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("invalid minisign signature format")
+	}
+
+	rawSig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(rawSig) != 74 {
+		return errors.New("invalid minisign signature")
+	}
+
+	alg := string(rawSig[:2])
+	if alg != "Ed" && alg != "ED" {
+		return fmt.Errorf("unsupported minisign signature algorithm %q", alg)
+	}
+
+	if !bytes.Equal(rawSig[2:10], keyId) {
+		return errors.New("the minisign signature was created with a different key")
+	}
+
+	sig := rawSig[10:]
+
+	if alg == "ED" {
+		hash := blake2b.Sum512(message)
+		message = hash[:]
+	}
+
+	if !ed25519.Verify(key, message, sig) {
+		return errors.New("minisign signature verification failed")
+	}
+
+	// verify the trusted comment
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return errors.New("invalid minisign global signature")
+	}
+
+	trustedComment := strings.TrimPrefix(lines[2], "trusted comment: ")
+
+	signed := make([]byte, 0, len(sig)+len(trustedComment))
+	signed = append(signed, sig...)
+	signed = append(signed, trustedComment...)
+
+	if !ed25519.Verify(key, signed, globalSig) {
+		return errors.New("minisign trusted comment verification failed")
+	}
+
+	return nil
+}
+
+func lastMinisignLine(content string) string {
+	var result string
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "untrusted comment:") {
+			result = line
+		}
+	}
+
+	return result
+}
+
+// verifyCosign verifies a key based cosign signature
+// (aka. "cosign sign-blob --key ...") of message.
+//
+// publicKey must be a PEM encoded ECDSA or Ed25519 public key and
+// signature is the base64 encoded signature generated by cosign.
+func verifyCosign(publicKey string, message []byte, signature []byte) error {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return errors.New("invalid cosign public key")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid cosign public key: %w", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.New("invalid cosign signature")
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(k, hash[:], sig) {
+			return errors.New("cosign signature verification failed")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, message, sig) {
+			return errors.New("cosign signature verification failed")
+		}
+	default:
+		return fmt.Errorf("unsupported cosign public key type %T", key)
+	}
+
+	return nil
+}
+
+// verifyAsset verifies the downloaded release asset against the
+// release checksums asset (and its signature if a public key is configured).
+//
+// The verification is skipped with a warning if the release doesn't
+// have a checksums asset and there is no configured public key.
+func (p *plugin) verifyAsset(r *release, asset *releaseAsset, assetPath string) error {
+	hasKey := p.config.MinisignPublicKey != "" || p.config.CosignPublicKey != ""
+
+	checksumsAsset, err := r.findAssetByName(p.config.ChecksumsAsset)
+	if err != nil {
+		if hasKey {
+			return fmt.Errorf("unable to verify the release signature: %w", err)
+		}
+
+		color.Yellow("Warning: the release doesn't have a %s asset and will not be verified.", p.config.ChecksumsAsset)
+		return nil
+	}
+
+	checksums, err := downloadBytes(p.config.Context, p.config.HttpClient, checksumsAsset, p.config.Token)
+	if err != nil {
+		return err
+	}
+
+	if p.config.MinisignPublicKey != "" {
+		if err := p.verifyChecksumsSignature(r, ".minisig", checksums, p.config.MinisignPublicKey, verifyMinisign); err != nil {
+			return err
+		}
+	}
+
+	if p.config.CosignPublicKey != "" {
+		if err := p.verifyChecksumsSignature(r, ".sig", checksums, p.config.CosignPublicKey, verifyCosign); err != nil {
+			return err
+		}
+	}
+
+	expected, ok := parseChecksums(checksums)[asset.Name]
+	if !ok {
+		return fmt.Errorf("missing %s checksum in %s", asset.Name, checksumsAsset.Name)
+	}
+
+	if err := verifyFileChecksum(assetPath, expected); err != nil {
+		return err
+	}
+
+	color.Green("Verified %s.", asset.Name)
+
+	return nil
+}
+
+func (p *plugin) verifyChecksumsSignature(
+	r *release,
+	ext string,
+	checksums []byte,
+	publicKey string,
+	verify func(publicKey string, message []byte, signature []byte) error,
+) error {
+	sigAsset, err := r.findAssetByName(p.config.ChecksumsAsset + ext)
+	if err != nil {
+		return fmt.Errorf("unable to verify the release signature: %w", err)
+	}
+
+	sig, err := downloadBytes(p.config.Context, p.config.HttpClient, sigAsset, p.config.Token)
+	if err != nil {
+		return err
+	}
+
+	return verify(publicKey, checksums, sig)
+}
@@ -0,0 +1,187 @@
+package ghupdate
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestParseChecksums(t *testing.T) {
+	content := "ABC123  test1.zip\n\ndef456 *test2.zip\ninvalid\n"
+
+	result := parseChecksums([]byte(content))
+
+	expected := map[string]string{
+		"test1.zip": "abc123",
+		"test2.zip": "def456",
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, result)
+	}
+
+	for k, v := range expected {
+		if result[k] != v {
+			t.Fatalf("Expected %s hash %q, got %q", k, v, result[k])
+		}
+	}
+}
+
+func TestVerifyFileChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.zip")
+	if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := sha256.Sum256([]byte("test"))
+	valid := hex.EncodeToString(hash[:])
+
+	scenarios := []struct {
+		expected    string
+		expectError bool
+	}{
+		{"", true},
+		{"abc", true},
+		{valid, false},
+		{"  " + valid, true},
+	}
+
+	for i, s := range scenarios {
+		err := verifyFileChecksum(path, s.expected)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyId := []byte("12345678")
+
+	publicKey := "untrusted comment: minisign public key\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyId...), pub...))
+
+	message := []byte("test message")
+
+	sign := func(alg string, msg []byte, signKeyId []byte) []byte {
+		toSign := msg
+		if alg == "ED" {
+			hash := blake2b.Sum512(msg)
+			toSign = hash[:]
+		}
+
+		sig := ed25519.Sign(priv, toSign)
+		trustedComment := "timestamp:1700000000"
+		globalSig := ed25519.Sign(priv, append(append([]byte{}, sig...), trustedComment...))
+
+		rawSig := append(append([]byte(alg), signKeyId...), sig...)
+
+		return []byte("untrusted comment: signature\n" +
+			base64.StdEncoding.EncodeToString(rawSig) + "\n" +
+			"trusted comment: " + trustedComment + "\n" +
+			base64.StdEncoding.EncodeToString(globalSig) + "\n")
+	}
+
+	scenarios := []struct {
+		name        string
+		publicKey   string
+		message     []byte
+		signature   []byte
+		expectError bool
+	}{
+		{"invalid public key", "invalid", message, sign("ED", message, keyId), true},
+		{"invalid signature format", publicKey, message, []byte("invalid"), true},
+		{"legacy signature", publicKey, message, sign("Ed", message, keyId), false},
+		{"prehashed signature", publicKey, message, sign("ED", message, keyId), false},
+		{"different message", publicKey, []byte("test"), sign("ED", message, keyId), true},
+		{"different key id", publicKey, message, sign("ED", message, []byte("87654321")), true},
+		{"unsupported algorithm", publicKey, message, sign("XX", message, keyId), true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := verifyMinisign(s.publicKey, s.message, s.signature)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyCosign(t *testing.T) {
+	message := []byte("test message")
+	hash := sha256.Sum256(message)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaSig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edSig := ed25519.Sign(edPriv, message)
+
+	encodeKey := func(key any) string {
+		raw, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: raw}))
+	}
+
+	encodeSig := func(sig []byte) []byte {
+		return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+	}
+
+	scenarios := []struct {
+		name        string
+		publicKey   string
+		message     []byte
+		signature   []byte
+		expectError bool
+	}{
+		{"invalid public key", "invalid", message, encodeSig(ecdsaSig), true},
+		{"invalid signature", encodeKey(&ecdsaKey.PublicKey), message, []byte("!invalid"), true},
+		{"ecdsa signature", encodeKey(&ecdsaKey.PublicKey), message, encodeSig(ecdsaSig), false},
+		{"ecdsa different message", encodeKey(&ecdsaKey.PublicKey), []byte("test"), encodeSig(ecdsaSig), true},
+		{"ed25519 signature", encodeKey(edPub), message, encodeSig(edSig), false},
+		{"ed25519 different message", encodeKey(edPub), []byte("test"), encodeSig(edSig), true},
+		{"mismatched key type", encodeKey(edPub), message, encodeSig(ecdsaSig), true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := verifyCosign(s.publicKey, s.message, s.signature)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
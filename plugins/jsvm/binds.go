@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -28,16 +29,25 @@ import (
 	"github.com/pocketbase/pocketbase/mails"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/notifications"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/barcode"
+	"github.com/pocketbase/pocketbase/tools/bus"
 	"github.com/pocketbase/pocketbase/tools/cron"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/push"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/sms"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/timeutils"
+	"github.com/pocketbase/pocketbase/tools/tracing"
+	"github.com/pocketbase/pocketbase/tools/transport"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
@@ -80,6 +90,9 @@ func hooksBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 					handlerArgs[i] = arg.Interface()
 				}
 
+				_, span := app.Tracer().Start(hookEventContext(args), "jsvm."+jsName, tracing.KindInternal)
+				defer span.End()
+
 				err := executors.run(func(executor *goja.Runtime) error {
 					executor.Set("__args", handlerArgs)
 					res, err := executor.RunProgram(pr)
@@ -100,6 +113,10 @@ func hooksBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 					return err
 				})
 
+				if !errors.Is(err, hook.StopPropagation) {
+					span.SetError(err)
+				}
+
 				return []reflect.Value{reflect.ValueOf(&err).Elem()}
 			})
 
@@ -111,6 +128,8 @@ func hooksBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 
 func cronBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 	scheduler := cron.New()
+	scheduler.SetLocker(app)
+	app.Store().Set(core.StoreKeyCronPrefix+"jsvm", scheduler)
 
 	var wasServeTriggered bool
 
@@ -129,6 +148,23 @@ func cronBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 					slog.String("jobId", jobId),
 					slog.String("error", err.Error()),
 				)
+
+				// repeated failures are grouped in a single notification
+				_, notifyErr := notifications.NotifyAdmins(
+					app,
+					models.AdminNotificationSeverityWarning,
+					"jsvm",
+					fmt.Sprintf("Cron job %q failed", jobId),
+					err.Error(),
+					map[string]any{"jobId": jobId},
+				)
+				if notifyErr != nil {
+					app.Logger().Debug(
+						"[cronAdd] failed to raise admin notification",
+						slog.String("jobId", jobId),
+						slog.String("error", notifyErr.Error()),
+					)
+				}
 			}
 		})
 		if err != nil {
@@ -160,29 +196,105 @@ func cronBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 
 		return nil
 	})
+
+	// stop the ticker and wait for the running jobs on app termination
+	app.OnTerminate().Add(func(e *core.TerminateEvent) error {
+		scheduler.Stop()
+		scheduler.Wait()
+		return nil
+	})
+}
+
+func busBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
+	loader.Set("busSubscribe", func(topic string, handler string) string {
+		pr := goja.MustCompile("", "{("+handler+").apply(undefined, __args)}", true)
+
+		return app.Bus().Subscribe(topic, func(m *bus.Message) error {
+			return executors.run(func(executor *goja.Runtime) error {
+				executor.Set("__args", []any{m})
+				res, err := executor.RunProgram(pr)
+				executor.Set("__args", goja.Undefined())
+
+				// check for returned error or false
+				if res != nil {
+					switch v := res.Export().(type) {
+					case error:
+						return v
+					case bool:
+						if !v {
+							return hook.StopPropagation
+						}
+					}
+				}
+
+				return err
+			})
+		})
+	})
+
+	loader.Set("busUnsubscribe", func(topic string, id string) {
+		app.Bus().Unsubscribe(topic, id)
+	})
+}
+
+// hookEventContext returns the request context of the hook event
+// (if the event has a HttpContext field) so that the hook handler
+// span could be linked to the request trace.
+func hookEventContext(args []reflect.Value) context.Context {
+	if len(args) > 0 {
+		v := reflect.Indirect(args[0])
+		if v.Kind() == reflect.Struct {
+			if field := v.FieldByName("HttpContext"); field.IsValid() {
+				if c, ok := field.Interface().(echo.Context); ok && c != nil {
+					return c.Request().Context()
+				}
+			}
+		}
+	}
+
+	return context.Background()
 }
 
 func routerBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 	loader.Set("routerAdd", func(method string, path string, handler goja.Value, middlewares ...goja.Value) {
-		wrappedMiddlewares, err := wrapMiddlewares(executors, middlewares...)
+		wrappedMiddlewares, err := wrapMiddlewares(app, executors, middlewares...)
 		if err != nil {
 			panic("[routerAdd] failed to wrap middlewares: " + err.Error())
 		}
 
-		wrappedHandler, err := wrapHandler(executors, handler)
+		wrappedHandler, err := wrapHandler(app, executors, handler)
 		if err != nil {
 			panic("[routerAdd] failed to wrap handler: " + err.Error())
 		}
 
+		route := scriptRoute(scriptOrigin(loader), method, path, wrappedHandler, wrappedMiddlewares)
+
 		app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
-			e.Router.Add(strings.ToUpper(method), path, wrappedHandler, wrappedMiddlewares...)
+			if _, err := e.Router.AddRoute(route); err != nil {
+				return fmt.Errorf("[routerAdd] %w", err)
+			}
 
 			return nil
 		})
 	})
 
+	loader.Set("routerGroup", func(prefix string, middlewares ...goja.Value) *routerGroup {
+		wrappedMiddlewares, err := wrapMiddlewares(app, executors, middlewares...)
+		if err != nil {
+			panic("[routerGroup] failed to wrap middlewares: " + err.Error())
+		}
+
+		return &routerGroup{
+			app:         app,
+			executors:   executors,
+			origin:      scriptOrigin(loader),
+			prefix:      prefix,
+			middlewares: wrappedMiddlewares,
+		}
+	})
+
 	loader.Set("routerUse", func(middlewares ...goja.Value) {
-		wrappedMiddlewares, err := wrapMiddlewares(executors, middlewares...)
+		wrappedMiddlewares, err := wrapMiddlewares(app, executors, middlewares...)
 		if err != nil {
 			panic("[routerUse] failed to wrap middlewares: " + err.Error())
 		}
@@ -194,7 +306,7 @@ func routerBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 	})
 
 	loader.Set("routerPre", func(middlewares ...goja.Value) {
-		wrappedMiddlewares, err := wrapMiddlewares(executors, middlewares...)
+		wrappedMiddlewares, err := wrapMiddlewares(app, executors, middlewares...)
 		if err != nil {
 			panic("[routerPre] failed to wrap middlewares: " + err.Error())
 		}
@@ -206,7 +318,213 @@ func routerBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
 	})
 }
 
-func wrapHandler(executors *vmsPool, handler goja.Value) (echo.HandlerFunc, error) {
+func virtualCollectionBinds(app core.App, loader *goja.Runtime, executors *vmsPool) {
+	loader.Set("virtualCollectionAdd", func(config goja.Value) {
+		if config == nil || goja.IsUndefined(config) || goja.IsNull(config) {
+			panic("[virtualCollectionAdd] missing virtual collection config")
+		}
+
+		obj := config.ToObject(loader)
+
+		get := func(key string) goja.Value {
+			v := obj.Get(key)
+			if v == nil || goja.IsUndefined(v) || goja.IsNull(v) {
+				return nil
+			}
+			return v
+		}
+
+		vc := &apis.VirtualCollection{}
+
+		if v := get("name"); v != nil {
+			vc.Name = v.String()
+		}
+
+		if v := get("schema"); v != nil {
+			raw, err := json.Marshal(v.Export())
+			if err == nil {
+				err = vc.Schema.UnmarshalJSON(raw)
+			}
+			if err != nil {
+				panic("[virtualCollectionAdd] invalid schema: " + err.Error())
+			}
+		}
+
+		if v := get("listRule"); v != nil {
+			vc.ListRule = types.Pointer(v.String())
+		}
+
+		if v := get("viewRule"); v != nil {
+			vc.ViewRule = types.Pointer(v.String())
+		}
+
+		if v := get("list"); v != nil {
+			pr := goja.MustCompile("", "{("+v.String()+").apply(undefined, __args)}", true)
+
+			vc.List = func(c echo.Context) ([]map[string]any, error) {
+				var result []map[string]any
+				err := runVirtualCollectionResolver(app, executors, c, pr, &result, newRequestContext(c, executors))
+				return result, err
+			}
+		}
+
+		if v := get("view"); v != nil {
+			pr := goja.MustCompile("", "{("+v.String()+").apply(undefined, __args)}", true)
+
+			vc.View = func(c echo.Context, id string) (map[string]any, error) {
+				var result map[string]any
+				err := runVirtualCollectionResolver(app, executors, c, pr, &result, newRequestContext(c, executors), id)
+				return result, err
+			}
+		}
+
+		if err := vc.Validate(); err != nil {
+			panic("[virtualCollectionAdd] invalid virtual collection: " + err.Error())
+		}
+
+		app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+			if err := apis.BindVirtualCollection(e.App, e.Router, vc); err != nil {
+				return fmt.Errorf("[virtualCollectionAdd] %w", err)
+			}
+
+			return nil
+		})
+	})
+}
+
+// runVirtualCollectionResolver executes a virtual collection resolver
+// program and loads its returned records data into result.
+func runVirtualCollectionResolver(
+	app core.App,
+	executors *vmsPool,
+	c echo.Context,
+	pr *goja.Program,
+	result any,
+	args ...any,
+) error {
+	release, err := apis.EnterLoadSheddingGate(c, app, apis.LoadSheddingScripts)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return executors.run(func(executor *goja.Runtime) error {
+		executor.Set("__args", args)
+		res, err := executor.RunProgram(pr)
+		executor.Set("__args", goja.Undefined())
+
+		if err != nil {
+			return err
+		}
+
+		if res == nil || goja.IsUndefined(res) || goja.IsNull(res) {
+			return nil
+		}
+
+		exported := res.Export()
+		if v, ok := exported.(error); ok {
+			return v
+		}
+
+		// normalize the returned value (eg. Record models)
+		raw, err := json.Marshal(exported)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(raw, result)
+	})
+}
+
+// scriptOrigin returns the name of the script file that is currently
+// executed by the loader vm (or empty string if unknown).
+func scriptOrigin(loader *goja.Runtime) string {
+	for _, frame := range loader.CaptureCallStack(0, nil) {
+		if name := frame.SrcName(); name != "" && name != "<native>" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// scriptRoute creates a new script route annotated with its origin file name.
+func scriptRoute(origin string, method string, path string, handler echo.HandlerFunc, middlewares []echo.MiddlewareFunc) apis.SourcedRoute {
+	return apis.SourcedRoute{
+		Route: echo.Route{
+			Method:      strings.ToUpper(method),
+			Path:        path,
+			Handler:     handler,
+			Middlewares: middlewares,
+		},
+		Source: apis.RouteSourceScript,
+		Origin: origin,
+	}
+}
+
+// routerGroup defines a JS route group with common path prefix and middlewares.
+type routerGroup struct {
+	app         core.App
+	executors   *vmsPool
+	origin      string
+	prefix      string
+	middlewares []echo.MiddlewareFunc
+}
+
+// Add registers a new group route definition.
+//
+// The group middlewares are executed before the route specific ones.
+func (g *routerGroup) Add(method string, path string, handler goja.Value, middlewares ...goja.Value) {
+	wrappedMiddlewares, err := wrapMiddlewares(g.app, g.executors, middlewares...)
+	if err != nil {
+		panic("[routerGroup.add] failed to wrap middlewares: " + err.Error())
+	}
+
+	wrappedHandler, err := wrapHandler(g.app, g.executors, handler)
+	if err != nil {
+		panic("[routerGroup.add] failed to wrap handler: " + err.Error())
+	}
+
+	allMiddlewares := make([]echo.MiddlewareFunc, 0, len(g.middlewares)+len(wrappedMiddlewares))
+	allMiddlewares = append(allMiddlewares, g.middlewares...)
+	allMiddlewares = append(allMiddlewares, wrappedMiddlewares...)
+
+	route := scriptRoute(g.origin, method, g.prefix+path, wrappedHandler, allMiddlewares)
+
+	g.app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+		if _, err := e.Router.AddRoute(route); err != nil {
+			return fmt.Errorf("[routerGroup.add] %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Group creates a new nested route group that inherits
+// the current group prefix and middlewares.
+func (g *routerGroup) Group(prefix string, middlewares ...goja.Value) *routerGroup {
+	wrappedMiddlewares, err := wrapMiddlewares(g.app, g.executors, middlewares...)
+	if err != nil {
+		panic("[routerGroup.group] failed to wrap middlewares: " + err.Error())
+	}
+
+	allMiddlewares := make([]echo.MiddlewareFunc, 0, len(g.middlewares)+len(wrappedMiddlewares))
+	allMiddlewares = append(allMiddlewares, g.middlewares...)
+	allMiddlewares = append(allMiddlewares, wrappedMiddlewares...)
+
+	return &routerGroup{
+		app:         g.app,
+		executors:   g.executors,
+		origin:      g.origin,
+		prefix:      g.prefix + prefix,
+		middlewares: allMiddlewares,
+	}
+}
+
+// wrapHandler wraps the provided JS route handler into an echo handler.
+//
+// The handler executions are limited by the app scripts load shedding gate.
+func wrapHandler(app core.App, executors *vmsPool, handler goja.Value) (echo.HandlerFunc, error) {
 	if handler == nil {
 		return nil, errors.New("handler must be non-nil")
 	}
@@ -219,8 +537,14 @@ func wrapHandler(executors *vmsPool, handler goja.Value) (echo.HandlerFunc, erro
 		pr := goja.MustCompile("", "{("+handler.String()+").apply(undefined, __args)}", true)
 
 		wrappedHandler := func(c echo.Context) error {
+			release, err := apis.EnterLoadSheddingGate(c, app, apis.LoadSheddingScripts)
+			if err != nil {
+				return err
+			}
+			defer release()
+
 			return executors.run(func(executor *goja.Runtime) error {
-				executor.Set("__args", []any{c})
+				executor.Set("__args", []any{newRequestContext(c, executors)})
 				res, err := executor.RunProgram(pr)
 				executor.Set("__args", goja.Undefined())
 
@@ -241,7 +565,10 @@ func wrapHandler(executors *vmsPool, handler goja.Value) (echo.HandlerFunc, erro
 	}
 }
 
-func wrapMiddlewares(executors *vmsPool, rawMiddlewares ...goja.Value) ([]echo.MiddlewareFunc, error) {
+// wrapMiddlewares wraps the provided JS middlewares into echo middlewares.
+//
+// The middlewares executions are limited by the app scripts load shedding gate.
+func wrapMiddlewares(app core.App, executors *vmsPool, rawMiddlewares ...goja.Value) ([]echo.MiddlewareFunc, error) {
 	wrappedMiddlewares := make([]echo.MiddlewareFunc, len(rawMiddlewares))
 
 	for i, m := range rawMiddlewares {
@@ -258,9 +585,15 @@ func wrapMiddlewares(executors *vmsPool, rawMiddlewares ...goja.Value) ([]echo.M
 
 			wrappedMiddlewares[i] = func(next echo.HandlerFunc) echo.HandlerFunc {
 				return func(c echo.Context) error {
+					release, err := apis.EnterLoadSheddingGate(c, app, apis.LoadSheddingScripts)
+					if err != nil {
+						return err
+					}
+					defer release()
+
 					return executors.run(func(executor *goja.Runtime) error {
 						executor.Set("__args", []any{next})
-						executor.Set("__args2", []any{c})
+						executor.Set("__args2", []any{newRequestContext(c, executors)})
 						res, err := executor.RunProgram(pr)
 						executor.Set("__args", goja.Undefined())
 						executor.Set("__args2", goja.Undefined())
@@ -303,6 +636,8 @@ func baseBinds(vm *goja.Runtime) {
 		return string(bodyBytes), nil
 	})
 
+	vm.Set("onResponse", onResponse)
+
 	vm.Set("sleep", func(milliseconds int64) {
 		time.Sleep(time.Duration(milliseconds) * time.Millisecond)
 	})
@@ -368,6 +703,31 @@ func baseBinds(vm *goja.Runtime) {
 		return structConstructorUnmarshal(vm, call, instance)
 	})
 
+	vm.Set("GeoPointOptions", func(call goja.ConstructorCall) *goja.Object {
+		instance := &schema.GeoPointOptions{}
+		return structConstructorUnmarshal(vm, call, instance)
+	})
+
+	vm.Set("GeoPoint", func(call goja.ConstructorCall) *goja.Object {
+		instance := &types.GeoPoint{}
+		return structConstructorUnmarshal(vm, call, instance)
+	})
+
+	vm.Set("MoneyOptions", func(call goja.ConstructorCall) *goja.Object {
+		instance := &schema.MoneyOptions{}
+		return structConstructorUnmarshal(vm, call, instance)
+	})
+
+	vm.Set("AutonumberOptions", func(call goja.ConstructorCall) *goja.Object {
+		instance := &schema.AutonumberOptions{}
+		return structConstructorUnmarshal(vm, call, instance)
+	})
+
+	vm.Set("PhoneOptions", func(call goja.ConstructorCall) *goja.Object {
+		instance := &schema.PhoneOptions{}
+		return structConstructorUnmarshal(vm, call, instance)
+	})
+
 	vm.Set("MailerMessage", func(call goja.ConstructorCall) *goja.Object {
 		instance := &mailer.Message{}
 		return structConstructor(vm, call, instance)
@@ -397,6 +757,32 @@ func baseBinds(vm *goja.Runtime) {
 		return structConstructor(vm, call, instance)
 	})
 
+	vm.Set("JsonMap", func(call goja.ConstructorCall) *goja.Object {
+		instance := types.JsonMap{}
+
+		data, _ := call.Argument(0).Export().(map[string]any)
+		for k, v := range data {
+			instance[k] = v
+		}
+
+		instanceValue := vm.ToValue(instance).(*goja.Object)
+		instanceValue.SetPrototype(call.This.Prototype())
+
+		return instanceValue
+	})
+
+	vm.Set("JsonArray", func(call goja.ConstructorCall) *goja.Object {
+		instance := types.JsonArray[any]{}
+
+		data, _ := call.Argument(0).Export().([]any)
+		instance = append(instance, data...)
+
+		instanceValue := vm.ToValue(instance).(*goja.Object)
+		instanceValue.SetPrototype(call.This.Prototype())
+
+		return instanceValue
+	})
+
 	vm.Set("ValidationError", func(call goja.ConstructorCall) *goja.Object {
 		code, _ := call.Argument(0).Export().(string)
 		message, _ := call.Argument(1).Export().(string)
@@ -473,6 +859,323 @@ func mailsBinds(vm *goja.Runtime) {
 	obj.Set("sendRecordPasswordReset", mails.SendRecordPasswordReset)
 	obj.Set("sendRecordVerification", mails.SendRecordVerification)
 	obj.Set("sendRecordChangeEmail", mails.SendRecordChangeEmail)
+
+	// templates
+	obj.Set("recordLanguage", mails.RecordLanguage)
+	obj.Set("renderTemplate", mails.RenderEmailTemplate)
+	obj.Set("sendTemplate", mails.SendEmailTemplate)
+}
+
+func smsBinds(app core.App, vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("$sms", obj)
+
+	// send sends a single SMS message using the configured app SMS provider.
+	//
+	// It could be called either with recipient and body text arguments
+	// (eg. $sms.send("+359000000000", "Hello!")) or with app and
+	// message object arguments (eg. $sms.send($app, {to: "...", body: "..."})).
+	//
+	// If message.from is not set, it fallbacks to the Sms.From settings value.
+	obj.Set("send", func(target goja.Value, message goja.Value) error {
+		sendApp := app
+		msg := &sms.Message{}
+
+		if customApp, ok := target.Export().(core.App); ok {
+			sendApp = customApp
+			if err := vm.ExportTo(message, msg); err != nil {
+				return err
+			}
+		} else {
+			msg.To = target.String()
+			msg.Body = message.String()
+		}
+
+		client, err := sendApp.NewSmsClient()
+		if err != nil {
+			return err
+		}
+
+		if msg.From == "" {
+			msg.From = sendApp.Settings().Sms.From
+		}
+
+		return client.Send(msg)
+	})
+}
+
+func captchaBinds(app core.App, vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("$captcha", obj)
+
+	// verify checks the captcha response token (and the optional
+	// client IP) with the configured app captcha provider.
+	obj.Set("verify", func(token string, ip string) error {
+		verifier, err := app.NewCaptchaVerifier()
+		if err != nil {
+			return err
+		}
+
+		return verifier.Verify(token, ip)
+	})
+}
+
+func backupsBinds(app core.App, vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("$backups", obj)
+
+	// create creates a new app backup with the specified name
+	// (or autogenerated one if empty).
+	obj.Set("create", func(name string) error {
+		form := forms.NewBackupCreate(app)
+		form.Name = name
+
+		return form.Submit()
+	})
+
+	// upload uploads the provided zip file as a new app backup.
+	obj.Set("upload", func(file *filesystem.File) error {
+		form := forms.NewBackupUpload(app)
+		form.File = file
+
+		return form.Submit()
+	})
+
+	// list returns the info of all available app backups.
+	obj.Set("list", func() ([]models.BackupFileInfo, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		fsys, err := app.NewBackupsFilesystem()
+		if err != nil {
+			return nil, err
+		}
+		defer fsys.Close()
+
+		fsys.SetContext(ctx)
+
+		backups, err := fsys.List("")
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]models.BackupFileInfo, len(backups))
+
+		for i, obj := range backups {
+			modified, _ := types.ParseDateTime(obj.ModTime)
+
+			result[i] = models.BackupFileInfo{
+				Key:      obj.Key,
+				Size:     obj.Size,
+				Modified: modified,
+			}
+		}
+
+		return result, nil
+	})
+
+	// delete deletes a single app backup by its name.
+	obj.Set("delete", func(name string) error {
+		if name != "" && cast.ToString(app.Store().Get(core.StoreKeyActiveBackup)) == name {
+			return errors.New("the backup is currently being used and cannot be deleted")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		fsys, err := app.NewBackupsFilesystem()
+		if err != nil {
+			return err
+		}
+		defer fsys.Close()
+
+		fsys.SetContext(ctx)
+
+		return fsys.Delete(name)
+	})
+}
+
+func notificationsBinds(app core.App, vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("$notifications", obj)
+
+	// send sends a push notification to all registered devices
+	// of the specified auth record.
+	obj.Set("send", func(recordId string, message *push.Message) (*notifications.SendResult, error) {
+		return notifications.Send(app, recordId, message)
+	})
+
+	// sendBatch sends a push notification to all registered devices
+	// of the specified auth records.
+	obj.Set("sendBatch", func(recordIds []string, message *push.Message) (*notifications.SendResult, error) {
+		return notifications.SendBatch(app, recordIds, message)
+	})
+
+	// create creates a new in-app notification for the specified auth record.
+	obj.Set("create", func(recipient *models.Record, title string, body string, data map[string]any) (*models.Notification, error) {
+		return notifications.Create(app, recipient, title, body, data)
+	})
+
+	// createForRelation creates a new in-app notification for each
+	// auth record referenced by the record relation field.
+	obj.Set("createForRelation", func(record *models.Record, relationField string, title string, body string, data map[string]any) ([]*models.Notification, error) {
+		return notifications.CreateForRelation(app, record, relationField, title, body, data)
+	})
+
+	// notifyAdmins raises a new admin notification center entry
+	// (repeated unread notifications with the same source and title are grouped).
+	obj.Set("notifyAdmins", func(severity string, source string, title string, body string, data map[string]any) (*models.AdminNotification, error) {
+		return notifications.NotifyAdmins(app, severity, source, title, body, data)
+	})
+}
+
+func realtimeBinds(app core.App, vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("$realtime", obj)
+
+	// clients returns the metadata of all realtime clients
+	// connected to the current app instance.
+	obj.Set("clients", func() []*apis.RealtimeClientInfo {
+		return apis.RealtimeClients(app)
+	})
+
+	// client returns the metadata of a single connected realtime client.
+	obj.Set("client", func(clientId string) (*apis.RealtimeClientInfo, error) {
+		client, err := app.SubscriptionsBroker().ClientById(clientId)
+		if err != nil {
+			return nil, err
+		}
+
+		return apis.NewRealtimeClientInfo(client), nil
+	})
+
+	// disconnect closes the connection of a single realtime client.
+	obj.Set("disconnect", func(clientId string) error {
+		return apis.DisconnectRealtimeClient(app, clientId)
+	})
+}
+
+func settingsBinds(app core.App, vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("$settings", obj)
+
+	// get returns a redacted copy of the current app settings
+	// (the secret values are replaced with settings.SecretMask).
+	obj.Set("get", func() (*settings.Settings, error) {
+		return app.Settings().RedactClone()
+	})
+
+	// patch validates and merges the provided data into the app settings
+	// using the same form as the settings update API endpoint.
+	//
+	// Secret values equal to settings.SecretMask are ignored so that
+	// the result of get() could be safely modified and submitted back.
+	obj.Set("patch", func(data any) (*settings.Settings, error) {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		values := map[string]any{}
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return nil, err
+		}
+		removeSettingsSecretMasks(values)
+
+		raw, err = json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+
+		form := forms.NewSettingsUpsert(app)
+		if err := json.Unmarshal(raw, form); err != nil {
+			return nil, err
+		}
+
+		if err := form.Submit(); err != nil {
+			return nil, err
+		}
+
+		return app.Settings().RedactClone()
+	})
+}
+
+func storeBinds(app core.App, vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("$store", obj)
+
+	// note: the values are persisted in the app db so that they are shared
+	// across all pooled vms and survive the app restarts
+
+	// get returns the json decoded value of the specified key
+	// (null if the key doesn't exist or it has expired).
+	obj.Set("get", func(key string) (any, error) {
+		raw, err := app.Dao().FindKVValue(key)
+		if err != nil || raw == nil {
+			return nil, err
+		}
+
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	})
+
+	// set stores the json serializable value under the specified key
+	// with optional ttl in seconds (0 or missing means no expiration).
+	obj.Set("set", func(key string, value any, ttl ...int64) error {
+		return app.Dao().SetKVValue(key, value, storeTTL(ttl))
+	})
+
+	// del deletes the specified key (if exists).
+	obj.Set("del", func(key string) error {
+		return app.Dao().DeleteKVValue(key)
+	})
+
+	// incr atomically increments the integer value of the specified key
+	// and returns its new value.
+	//
+	// The optional args are the delta (default to 1) and the ttl in seconds
+	// (0 or missing preserves the existing key expiration).
+	obj.Set("incr", func(key string, args ...int64) (int64, error) {
+		delta := int64(1)
+		if len(args) > 0 {
+			delta = args[0]
+		}
+
+		var ttl []int64
+		if len(args) > 1 {
+			ttl = args[1:2]
+		}
+
+		return app.Dao().IncrementKVValue(key, delta, storeTTL(ttl))
+	})
+}
+
+// storeTTL converts the optional $store ttl seconds argument to [time.Duration].
+func storeTTL(ttl []int64) time.Duration {
+	if len(ttl) == 0 {
+		return 0
+	}
+
+	return time.Duration(ttl[0]) * time.Second
+}
+
+// removeSettingsSecretMasks recursively deletes all
+// settings.SecretMask string values from the provided map.
+func removeSettingsSecretMasks(values map[string]any) {
+	for k, v := range values {
+		switch vv := v.(type) {
+		case string:
+			if vv == settings.SecretMask {
+				delete(values, k)
+			}
+		case map[string]any:
+			removeSettingsSecretMasks(vv)
+		}
+	}
 }
 
 func tokensBinds(vm *goja.Runtime) {
@@ -491,6 +1194,7 @@ func tokensBinds(vm *goja.Runtime) {
 	obj.Set("recordResetPasswordToken", tokens.NewRecordResetPasswordToken)
 	obj.Set("recordChangeEmailToken", tokens.NewRecordChangeEmailToken)
 	obj.Set("recordFileToken", tokens.NewRecordFileToken)
+	obj.Set("impersonationToken", tokens.NewRecordImpersonationToken)
 }
 
 func securityBinds(vm *goja.Runtime) {
@@ -534,6 +1238,278 @@ func securityBinds(vm *goja.Runtime) {
 	})
 }
 
+func inflectorBinds(vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("Inflector", obj)
+	vm.Set("$inflector", obj)
+
+	obj.Set("ucFirst", inflector.UcFirst)
+	obj.Set("columnify", inflector.Columnify)
+	obj.Set("sentenize", inflector.Sentenize)
+	obj.Set("sanitize", inflector.Sanitize)
+	obj.Set("snakecase", inflector.Snakecase)
+}
+
+func listBinds(vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("List", obj)
+	vm.Set("$list", obj)
+
+	// note: the generic helpers are bound with their string variants
+	// because the JS values are not guaranteed to be comparable
+	obj.Set("subtractSlice", list.SubtractSlice[string])
+	obj.Set("existInSlice", list.ExistInSlice[string])
+	obj.Set("existInSliceWithRegex", list.ExistInSliceWithRegex)
+	obj.Set("nonzeroUniques", list.NonzeroUniques[string])
+	obj.Set("toUniqueStringSlice", list.ToUniqueStringSlice)
+}
+
+func typesBinds(vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("Types", obj)
+	vm.Set("$types", obj)
+
+	obj.Set("nowDateTime", types.NowDateTime)
+	obj.Set("parseDateTime", types.ParseDateTime)
+	obj.Set("parseJsonRaw", types.ParseJsonRaw)
+	obj.Set("parseGeoPoint", types.ParseGeoPoint)
+}
+
+func moneyBinds(vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("MoneyHelpers", obj)
+	vm.Set("$money", obj)
+
+	obj.Set("create", types.NewMoney)
+	obj.Set("parse", types.ParseMoney)
+
+	// arithmetic
+	obj.Set("add", func(a, b any) (types.Money, error) {
+		ma, mb, err := bindMoneyPair(a, b)
+		if err != nil {
+			return ma, err
+		}
+
+		return ma.Add(mb)
+	})
+	obj.Set("sub", func(a, b any) (types.Money, error) {
+		ma, mb, err := bindMoneyPair(a, b)
+		if err != nil {
+			return ma, err
+		}
+
+		return ma.Sub(mb)
+	})
+	obj.Set("mul", func(m any, factor float64) (types.Money, error) {
+		mm, err := types.ParseMoney(m, "")
+		if err != nil {
+			return mm, err
+		}
+
+		return mm.Mul(factor)
+	})
+	obj.Set("allocate", func(m any, ratios []int) ([]types.Money, error) {
+		mm, err := types.ParseMoney(m, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return mm.Allocate(ratios...)
+	})
+	obj.Set("compare", func(a, b any) (int, error) {
+		ma, mb, err := bindMoneyPair(a, b)
+		if err != nil {
+			return 0, err
+		}
+
+		return ma.Compare(mb)
+	})
+
+	// formatting
+	obj.Set("format", func(m any, thousandsSeparator string, decimalSeparator string) (string, error) {
+		mm, err := types.ParseMoney(m, "")
+		if err != nil {
+			return "", err
+		}
+
+		if decimalSeparator == "" {
+			decimalSeparator = "."
+		}
+
+		return mm.Format(thousandsSeparator, decimalSeparator), nil
+	})
+}
+
+// bindMoneyPair parses the provided arguments as money values
+// with the second one in the currency of the first one.
+func bindMoneyPair(a, b any) (types.Money, types.Money, error) {
+	ma, err := types.ParseMoney(a, "")
+	if err != nil {
+		return ma, types.Money{}, err
+	}
+
+	mb, err := types.ParseMoney(b, ma.Currency)
+	if err != nil {
+		return ma, mb, err
+	}
+
+	return ma, mb, nil
+}
+
+func timeBinds(vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("Time", obj)
+	vm.Set("$time", obj)
+
+	obj.Set("now", types.NowDateTime)
+
+	// durations
+	obj.Set("add", func(date any, duration string) (types.DateTime, error) {
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return types.DateTime{}, err
+		}
+
+		t, err := bindTime(date, "")
+		if err != nil {
+			return types.DateTime{}, err
+		}
+
+		return types.ParseDateTime(t.Add(d))
+	})
+	obj.Set("addDate", func(date any, years, months, days int, timezone string) (types.DateTime, error) {
+		t, err := bindTime(date, timezone)
+		if err != nil {
+			return types.DateTime{}, err
+		}
+
+		return types.ParseDateTime(t.AddDate(years, months, days))
+	})
+	obj.Set("diff", func(a, b any, unit string) (float64, error) {
+		ta, err := bindTime(a, "")
+		if err != nil {
+			return 0, err
+		}
+
+		tb, err := bindTime(b, "")
+		if err != nil {
+			return 0, err
+		}
+
+		d := ta.Sub(tb)
+
+		switch unit {
+		case "", "ms":
+			return float64(d.Milliseconds()), nil
+		case "s":
+			return d.Seconds(), nil
+		case "m":
+			return d.Minutes(), nil
+		case "h":
+			return d.Hours(), nil
+		case "d":
+			return d.Hours() / 24, nil
+		default:
+			return 0, fmt.Errorf("unsupported diff unit %q (ms, s, m, h, d)", unit)
+		}
+	})
+
+	// timezones
+	obj.Set("inTimezone", bindTime)
+	obj.Set("format", func(date any, layout string, timezone string) (string, error) {
+		t, err := bindTime(date, timezone)
+		if err != nil {
+			return "", err
+		}
+
+		return t.Format(layout), nil
+	})
+	obj.Set("parse", func(layout string, value string, timezone string) (types.DateTime, error) {
+		loc, err := timeutils.LoadLocation(timezone)
+		if err != nil {
+			return types.DateTime{}, err
+		}
+
+		t, err := time.ParseInLocation(layout, value, loc)
+		if err != nil {
+			return types.DateTime{}, err
+		}
+
+		return types.ParseDateTime(t)
+	})
+
+	// iso weeks
+	obj.Set("isoWeek", func(date any, timezone string) (map[string]int, error) {
+		t, err := bindTime(date, timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		year, week := t.ISOWeek()
+
+		return map[string]int{"year": year, "week": week}, nil
+	})
+	obj.Set("startOfIsoWeek", func(year, week int, timezone string) (types.DateTime, error) {
+		loc, err := timeutils.LoadLocation(timezone)
+		if err != nil {
+			return types.DateTime{}, err
+		}
+
+		return types.ParseDateTime(timeutils.StartOfISOWeek(year, week, loc))
+	})
+
+	// business days
+	obj.Set("isBusinessDay", func(date any, holidays []string, timezone string) (bool, error) {
+		t, err := bindTime(date, timezone)
+		if err != nil {
+			return false, err
+		}
+
+		return timeutils.IsBusinessDay(t, holidays), nil
+	})
+	obj.Set("addBusinessDays", func(date any, days int, holidays []string, timezone string) (types.DateTime, error) {
+		t, err := bindTime(date, timezone)
+		if err != nil {
+			return types.DateTime{}, err
+		}
+
+		return types.ParseDateTime(timeutils.AddBusinessDays(t, days, holidays))
+	})
+	obj.Set("businessDaysBetween", func(start, end any, holidays []string, timezone string) (int, error) {
+		ts, err := bindTime(start, timezone)
+		if err != nil {
+			return 0, err
+		}
+
+		te, err := bindTime(end, timezone)
+		if err != nil {
+			return 0, err
+		}
+
+		return timeutils.BusinessDaysBetween(ts, te, holidays), nil
+	})
+}
+
+// bindTime converts a JS date value (DateTime, Date, date string or unix timestamp)
+// into a time.Time in the specified IANA timezone (default to UTC).
+func bindTime(date any, timezone string) (time.Time, error) {
+	loc, err := timeutils.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	dt, err := types.ParseDateTime(date)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if dt.IsZero() {
+		return time.Time{}, fmt.Errorf("invalid or empty date %v", date)
+	}
+
+	return dt.Time().In(loc), nil
+}
+
 func filesystemBinds(vm *goja.Runtime) {
 	obj := vm.NewObject()
 	vm.Set("Filesystem", obj)
@@ -550,10 +1526,60 @@ func filesystemBinds(vm *goja.Runtime) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(secTimeout)*time.Second)
 		defer cancel()
 
-		return filesystem.NewFileFromUrl(ctx, url)
+		return filesystem.NewFileFromUrlWithClient(ctx, &http.Client{Transport: transport.Scripts}, url)
+	})
+}
+
+func barcodeBinds(vm *goja.Runtime) {
+	obj := vm.NewObject()
+	vm.Set("Barcode", obj)
+	vm.Set("$barcode", obj)
+
+	obj.Set("qr", func(data string, size int, format string) (*filesystem.File, error) {
+		if size <= 0 {
+			size = 256
+		}
+
+		b, err := barcode.NewQR(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return barcodeFile(b, "qrcode", format, size, size)
+	})
+
+	obj.Set("code128", func(data string, height int, format string) (*filesystem.File, error) {
+		if height <= 0 {
+			height = 80
+		}
+
+		b, err := barcode.NewCode128(data)
+		if err != nil {
+			return nil, err
+		}
+
+		// 2px per module
+		cols, _ := b.Size()
+
+		return barcodeFile(b, "code128", format, cols*2, height)
 	})
 }
 
+// barcodeFile encodes the barcode image in the specified format
+// (default to png) as a new file ready to be assigned to a file field.
+func barcodeFile(b *barcode.Barcode, name string, format string, width int, height int) (*filesystem.File, error) {
+	if format == "" {
+		format = barcode.FormatPNG
+	}
+
+	data, err := b.Encode(format, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return filesystem.NewFileFromBytes(data, name+"."+format)
+}
+
 func filepathBinds(vm *goja.Runtime) {
 	obj := vm.NewObject()
 	vm.Set("Filepath", obj)
@@ -583,7 +1609,6 @@ func osBinds(vm *goja.Runtime) {
 	vm.Set("$os", obj)
 
 	obj.Set("args", os.Args)
-	obj.Set("exec", exec.Command) // @deprecated
 	obj.Set("cmd", exec.Command)
 	obj.Set("exit", os.Exit)
 	obj.Set("getenv", os.Getenv)
@@ -632,6 +1657,7 @@ func apisBinds(vm *goja.Runtime) {
 	obj.Set("staticDirectoryHandler", func(dir string, indexFallback bool) echo.HandlerFunc {
 		return apis.StaticDirectoryHandler(os.DirFS(dir), indexFallback)
 	})
+	obj.Set("proxy", apis.Proxy)
 
 	// middlewares
 	obj.Set("requireGuestOnly", apis.RequireGuestOnly)
@@ -640,15 +1666,18 @@ func apisBinds(vm *goja.Runtime) {
 	obj.Set("requireAdminAuthOnlyIfAny", apis.RequireAdminAuthOnlyIfAny)
 	obj.Set("requireAdminOrRecordAuth", apis.RequireAdminOrRecordAuth)
 	obj.Set("requireAdminOrOwnerAuth", apis.RequireAdminOrOwnerAuth)
+	obj.Set("requireCaptcha", apis.RequireCaptcha)
 	obj.Set("activityLogger", apis.ActivityLogger)
 	obj.Set("gzip", middleware.Gzip)
 	obj.Set("bodyLimit", middleware.BodyLimit)
+	obj.Set("requestLimits", apis.RequestLimits)
 
 	// record helpers
 	obj.Set("requestInfo", apis.RequestInfo)
 	obj.Set("recordAuthResponse", apis.RecordAuthResponse)
 	obj.Set("enrichRecord", apis.EnrichRecord)
 	obj.Set("enrichRecords", apis.EnrichRecords)
+	obj.Set("verifyCaptcha", apis.VerifyCaptcha)
 
 	// api errors
 	registerFactoryAsConstructor(vm, "ApiError", apis.NewApiError)
@@ -774,7 +1803,7 @@ func httpClientBinds(vm *goja.Runtime) {
 			req.Header.Set("content-type", "application/json")
 		}
 
-		res, err := http.DefaultClient.Do(req)
+		res, err := (&http.Client{Transport: transport.Scripts}).Do(req)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,156 @@
+package jsvm
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/dop251/goja"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// Supported script API versions (see [Config.ScriptApiVersion]).
+const (
+	// ScriptApiV1 is the initial script API version.
+	ScriptApiV1 = 1
+
+	// ScriptApiV2 renamed:
+	//  - $os.exec -> $os.cmd
+	//  - $apis.requestData -> $apis.requestInfo
+	ScriptApiV2 = 2
+
+	// LatestScriptApiVersion is the current script API version.
+	LatestScriptApiVersion = ScriptApiV2
+
+	// MinScriptApiVersion is the oldest script API version whose
+	// deprecated names are still available (aka. one major version back).
+	MinScriptApiVersion = LatestScriptApiVersion - 1
+)
+
+// scriptApiShim defines a single renamed JS bind API.
+type scriptApiShim struct {
+	// version is the script API version in which oldName was renamed.
+	version int
+
+	// object is the global object holding the API (empty for the global scope).
+	object string
+
+	oldName string
+	newName string
+}
+
+// scriptApiShims lists the renamed JS bind APIs.
+//
+// The shims are removed one major version after their deprecation.
+var scriptApiShims = []scriptApiShim{
+	{ScriptApiV2, "$os", "exec", "cmd"},
+	{ScriptApiV2, "$apis", "requestData", "requestInfo"},
+}
+
+// scriptCompat registers the deprecated JS bind API names
+// for the configured script API version.
+type scriptCompat struct {
+	app     core.App
+	version int
+
+	mu     sync.Mutex
+	warned map[string]struct{}
+}
+
+func newScriptCompat(app core.App, version int) (*scriptCompat, error) {
+	if version == 0 {
+		version = MinScriptApiVersion
+	}
+
+	if version < MinScriptApiVersion || version > LatestScriptApiVersion {
+		return nil, fmt.Errorf(
+			"unsupported script API version %d (must be between %d and %d)",
+			version,
+			MinScriptApiVersion,
+			LatestScriptApiVersion,
+		)
+	}
+
+	return &scriptCompat{
+		app:     app,
+		version: version,
+		warned:  map[string]struct{}{},
+	}, nil
+}
+
+// apply defines the deprecated names of the APIs renamed after
+// the configured script API version as aliases of their replacements.
+//
+// Accessing a deprecated name logs a warning (once per name and script location).
+func (sc *scriptCompat) apply(vm *goja.Runtime) {
+	for _, shim := range scriptApiShims {
+		if shim.version <= sc.version {
+			continue // the scripts are expected to use the new name
+		}
+
+		obj := vm.GlobalObject()
+		if shim.object != "" {
+			obj, _ = vm.Get(shim.object).(*goja.Object)
+			if obj == nil {
+				continue // the object is not bound in this vm
+			}
+		}
+
+		if obj.Get(shim.newName) == nil {
+			continue // missing replacement
+		}
+
+		shim := shim
+		target := obj
+
+		getter := vm.ToValue(func(call goja.FunctionCall) goja.Value {
+			sc.warn(vm, shim)
+			return target.Get(shim.newName)
+		})
+
+		obj.DefineAccessorProperty(shim.oldName, getter, nil, goja.FLAG_TRUE, goja.FLAG_FALSE)
+	}
+}
+
+// warn logs a deprecation warning for the provided shim.
+func (sc *scriptCompat) warn(vm *goja.Runtime, shim scriptApiShim) {
+	oldName := shim.oldName
+	newName := shim.newName
+	if shim.object != "" {
+		oldName = shim.object + "." + oldName
+		newName = shim.object + "." + newName
+	}
+
+	var location string
+	for _, frame := range vm.CaptureCallStack(0, nil) {
+		if name := frame.SrcName(); name != "" && name != "<native>" {
+			location = frame.Position().String()
+			break
+		}
+	}
+
+	key := oldName + "@" + location
+
+	sc.mu.Lock()
+	_, warned := sc.warned[key]
+	sc.warned[key] = struct{}{}
+	sc.mu.Unlock()
+
+	if warned {
+		return
+	}
+
+	attrs := []any{
+		slog.String("deprecated", oldName),
+		slog.String("replacement", newName),
+		slog.Int("removedInVersion", shim.version+1),
+	}
+	if location != "" {
+		attrs = append(attrs, slog.String("location", location))
+	}
+
+	sc.app.Logger().Warn(
+		fmt.Sprintf("%s is deprecated and will be removed in the next script API version, use %s instead", oldName, newName),
+		attrs...,
+	)
+}
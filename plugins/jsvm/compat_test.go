@@ -0,0 +1,119 @@
+package jsvm
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestNewScriptCompat(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		version         int
+		expectError     bool
+		expectedVersion int
+	}{
+		{0, false, MinScriptApiVersion},
+		{MinScriptApiVersion - 1, true, 0},
+		{MinScriptApiVersion, false, MinScriptApiVersion},
+		{LatestScriptApiVersion, false, LatestScriptApiVersion},
+		{LatestScriptApiVersion + 1, true, 0},
+	}
+
+	for _, s := range scenarios {
+		compat, err := newScriptCompat(app, s.version)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", s.version, s.expectError, hasErr, err)
+		}
+
+		if !hasErr && compat.version != s.expectedVersion {
+			t.Fatalf("[%d] Expected version %d, got %d", s.version, s.expectedVersion, compat.version)
+		}
+	}
+}
+
+func TestScriptCompatApply(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		version     int
+		expectShims bool
+	}{
+		{ScriptApiV1, true},
+		{ScriptApiV2, false},
+	}
+
+	for _, s := range scenarios {
+		compat, err := newScriptCompat(app, s.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		vm := goja.New()
+		osBinds(vm)
+		apisBinds(vm)
+		compat.apply(vm)
+
+		result, err := vm.RunString(`[
+			$os.exec === $os.cmd,
+			Os.exec === $os.cmd,
+			$apis.requestData === $apis.requestInfo,
+			Object.keys($os).includes("exec"),
+		]`)
+		if err != nil {
+			t.Fatalf("[%d] Failed to run the script: %v", s.version, err)
+		}
+
+		checks, _ := result.Export().([]any)
+		if len(checks) != 4 {
+			t.Fatalf("[%d] Expected 4 checks, got %v", s.version, checks)
+		}
+
+		for i, check := range checks[:3] {
+			if check != s.expectShims {
+				t.Fatalf("[%d] Expected check %d to be %v, got %v", s.version, i, s.expectShims, check)
+			}
+		}
+
+		if checks[3] != false {
+			t.Fatalf("[%d] Expected the deprecated names to be non-enumerable", s.version)
+		}
+	}
+}
+
+func TestScriptCompatWarnOnce(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	compat, err := newScriptCompat(app, ScriptApiV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm := goja.New()
+	osBinds(vm)
+	apisBinds(vm)
+	compat.apply(vm)
+
+	_, err = vm.RunScript("test.pb.js", `
+		for (let i = 0; i < 3; i++) {
+			$os.exec;
+		}
+		$os.exec;
+		$apis.requestData;
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 distinct $os.exec locations + 1 $apis.requestData
+	if total := len(compat.warned); total != 3 {
+		t.Fatalf("Expected %d logged warnings, got %d (%v)", 3, total, compat.warned)
+	}
+}
@@ -77,7 +77,9 @@ func NewEventLoopWithVM(vm *goja.Runtime, opts ...Option) *EventLoop {
 		opt(loop)
 	}
 	vm.SetParserOptions(parser.WithDisableSourceMaps)
-	loop.registry = new(require.Registry)
+	if loop.registry == nil {
+		loop.registry = new(require.Registry)
+	}
 	loop.registry.Enable(vm)
 	console.Enable(vm)
 	buffer.Enable(vm)
@@ -92,7 +94,7 @@ func NewEventLoopWithVM(vm *goja.Runtime, opts ...Option) *EventLoop {
 
 	vm.Set("runLoop", func(call goja.FunctionCall) goja.Value {
 		if fn, ok := goja.AssertFunction(call.Argument(0)); ok {
-			_loop := NewEventLoopWithVM(loop.vm)
+			_loop := NewEventLoopWithVM(loop.vm, WithRegistry(loop.registry))
 			var args []goja.Value = []goja.Value{
 				loop.vm.ToValue(func() {
 					go _loop.StopNoWait()
@@ -128,6 +130,8 @@ func EnableConsole(enableConsole bool) Option {
 	}
 }
 
+// WithRegistry sets the require() modules registry of the loop runtime
+// (by default a new registry with the default source loader is created).
 func WithRegistry(registry *require.Registry) Option {
 	return func(loop *EventLoop) {
 		loop.registry = registry
@@ -62,6 +62,48 @@ declare function cronAdd(
  */
 declare function cronRemove(jobId: string): void;
 
+// -------------------------------------------------------------------
+// busBinds
+// -------------------------------------------------------------------
+
+/**
+ * BusSubscribe registers a new app event bus topic handler
+ * and returns its subscription id.
+ *
+ * The handler is called synchronously for every message published
+ * to the topic (either from Go via app.Bus() or from another script).
+ * Returning false stops the delivery to the remaining topic handlers.
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * busSubscribe("orders.paid", (e) => {
+ *     console.log(e.topic, e.data.orderId)
+ * })
+ *
+ * // publish from a route, hook, etc.
+ * $app.bus().publish("orders.paid", { orderId: "abc" })
+ * ` + "```" + `
+ *
+ * _Note that this method is available only in pb_hooks context._
+ *
+ * @group PocketBase
+ */
+declare function busSubscribe(
+  topic:   string,
+  handler: (e: bus.Message) => void|boolean,
+): string;
+
+/**
+ * BusUnsubscribe removes a single app event bus topic handler
+ * by its subscription id.
+ *
+ * _Note that this method is available only in pb_hooks context._
+ *
+ * @group PocketBase
+ */
+declare function busUnsubscribe(topic: string, id: string): void;
+
 // -------------------------------------------------------------------
 // routerBinds
 // -------------------------------------------------------------------
@@ -136,6 +178,133 @@ declare function routerUse(...middlewares: Array<string|echo.MiddlewareFunc>): v
  */
 declare function routerPre(...middlewares: Array<string|echo.MiddlewareFunc>): void;
 
+/**
+ * RouterGroup defines a route group with common path prefix and middlewares.
+ *
+ * @group PocketBase
+ */
+interface RouterGroup {
+  /**
+   * Registers a new group route definition
+   * (the group middlewares are executed before the route ones).
+   */
+  add(
+    method: string,
+    path: string,
+    handler: echo.HandlerFunc,
+    ...middlewares: Array<string|echo.MiddlewareFunc>,
+  ): void
+
+  /**
+   * Creates a new nested route group that inherits
+   * the current group prefix and middlewares.
+   */
+  group(prefix: string, ...middlewares: Array<string|echo.MiddlewareFunc>): RouterGroup
+}
+
+/**
+ * RouterGroup creates a new route group with common path prefix and middlewares.
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * const v2 = routerGroup("/api/v2", $apis.requireAdminOrRecordAuth())
+ *
+ * v2.add("GET", "/hello", (c) => {
+ *     return c.json(200, {"message": "Hello!"})
+ * })
+ *
+ * const admin = v2.group("/admin", $apis.requireAdminAuth())
+ *
+ * admin.add("POST", "/reindex", (c) => {
+ *     return c.noContent(204)
+ * })
+ * ` + "```" + `
+ *
+ * _Note that this method is available only in pb_hooks context._
+ *
+ * @group PocketBase
+ */
+declare function routerGroup(prefix: string, ...middlewares: Array<string|echo.MiddlewareFunc>): RouterGroup;
+
+// -------------------------------------------------------------------
+// virtualCollectionBinds
+// -------------------------------------------------------------------
+
+interface VirtualCollectionConfig {
+  /**
+   * Unique collection name used in the records api paths
+   * (it must not match an existing collection name).
+   */
+  name: string
+
+  /**
+   * Records fields definitions (besides the system "id", "created" and "updated" fields).
+   */
+  schema?: Array<schema.SchemaField|{[key:string]:any}>
+
+  /**
+   * Collection list and view API rules
+   * (null/undefined means admins only, empty string means public).
+   */
+  listRule?: string|null
+  viewRule?: string|null
+
+  /**
+   * Returns the data of all collection records for the current request.
+   */
+  list: (c: echo.Context) => Array<{[key:string]:any}>
+
+  /**
+   * Optionally returns the data of a single collection record (null if missing).
+   *
+   * If not set, the record is searched in the list records.
+   */
+  view?: (c: echo.Context, id: string) => {[key:string]:any}|null
+}
+
+/**
+ * VirtualCollectionAdd registers a read-only "virtual collection" whose
+ * records are provided by the specified JS resolvers and are exposed
+ * through the standard records list and view api endpoints
+ * (with filter, sort, pagination, API rules and expand support).
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * virtualCollectionAdd({
+ *     name: "weather",
+ *     schema: [
+ *         new SchemaField({name: "city", type: "text"}),
+ *         new SchemaField({name: "temp", type: "number"}),
+ *     ],
+ *     listRule: "",
+ *     viewRule: "",
+ *     list: (c) => {
+ *         const res = $http.send({url: "https://example.com/weather"})
+ *         return res.json.map((item) => ({id: item.code, city: item.city, temp: item.temp}))
+ *     },
+ * })
+ * ` + "```" + `
+ *
+ * _Note that this method is available only in pb_hooks context._
+ *
+ * @group PocketBase
+ */
+declare function virtualCollectionAdd(config: VirtualCollectionConfig): void;
+
+declare namespace echo {
+  interface Context {
+    /**
+     * Request scoped key-value store shared between all JS middlewares
+     * and the route handler of the current request.
+     *
+     * _Note that it is available only in the route handlers and middlewares._
+     */
+    store: store.Store<any>
+  }
+}
+
 // -------------------------------------------------------------------
 // baseBinds
 // -------------------------------------------------------------------
@@ -143,6 +312,11 @@ declare function routerPre(...middlewares: Array<string|echo.MiddlewareFunc>): v
 /**
  * Global helper variable that contains the absolute path to the app pb_hooks directory.
  *
+ * Note that the relative ` + "`require()`" + ` paths are also resolved from the
+ * pb_hooks directory (incl. its "node_modules"), so
+ * ` + "`require(\"./utils.js\")`" + ` and ` + "`require(__hooks + \"/utils.js\")`" + `
+ * load the same module.
+ *
  * @group PocketBase
  */
 declare var __hooks: string
@@ -173,12 +347,41 @@ type PocketBase = excludeHooks<ORIGINAL_POCKETBASE>
 declare var $app: PocketBase
 declare var App: PocketBase
 
+/**
+interface TemplateViews extends template.Registry {
+  /**
+   * Returns the views directory (default to "pb_hooks/views").
+   */
+  dir(): string
+
+  /**
+   * Renders the named view (eg. "posts/list" for "views/posts/list.html").
+   */
+  render(name: string, data?: any): string
+
+  /**
+   * Renders the named view wrapped in the named layout
+   * (eg. "base" for "views/layouts/base.html").
+   */
+  renderLayout(layout: string, name: string, data?: any): string
+
+  /**
+   * Returns the key translation from the "views/locales/{lang}.json" file.
+   */
+  translate(lang: string, key: string, ...args: Array<any>): string
+}
+
 /**
  * ` + "`$template`" + ` is a global helper to load and cache HTML templates on the fly.
  *
  * The templates uses the standard Go [html/template](https://pkg.go.dev/html/template)
  * and [text/template](https://pkg.go.dev/text/template) package syntax.
  *
+ * The render methods load the views from the "pb_hooks/views" directory
+ * with the "layouts/*.html", "partials/*.html" and "locales/*.json" support.
+ * Besides the default template functions, the views could also use
+ * ` + "`{{t .lang \"key\" args...}}`" + `, ` + "`{{field .record \"name\"}}`" + ` and ` + "`{{json .value}}`" + `.
+ *
  * Example:
  *
  * ` + "```" + `js
@@ -186,6 +389,15 @@ declare var App: PocketBase
  *     "views/layout.html",
  *     "views/content.html",
  * ).render({"name": "John"})
+ *
+ * routerAdd("GET", "/posts", (c) => {
+ *     const posts = $app.dao().findRecordsByFilter("posts", "published = true")
+ *
+ *     return c.html(200, $template.renderLayout("base", "posts/list", {
+ *         "lang":  "en",
+ *         "posts": posts,
+ *     }))
+ * })
  * ` + "```" + `
  *
  * _Note that this method is available only in pb_hooks context._
@@ -193,8 +405,8 @@ declare var App: PocketBase
  * @namespace
  * @group PocketBase
  */
-declare var Template: template.Registry
-declare var $template: template.Registry
+declare var Template: TemplateViews
+declare var $template: TemplateViews
 
 /**
  * readerToString reads the content of the specified io.Reader until
@@ -214,6 +426,37 @@ declare var $template: template.Registry
  */
 declare function readerToString(reader: any, maxBytes?: number): string;
 
+/**
+ * onResponse registers a handler that is called right before the response
+ * of the current route request is written, allowing it to inspect and change
+ * the response status and headers.
+ *
+ * Similar to the route handlers, the handler is executed in an isolated
+ * context and cannot access variables from the outer scope
+ * (use the request scoped ` + "`" + `c.store` + "`" + ` to pass data to it).
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * routerUse((next) => {
+ *     return (c) => {
+ *         c.store.set("locale", c.request().header.get("Accept-Language") || "en")
+ *
+ *         onResponse(c, (c) => {
+ *             c.response().header().set("Content-Language", c.store.get("locale"))
+ *         })
+ *
+ *         return next(c)
+ *     }
+ * })
+ * ` + "```" + `
+ *
+ * _Note that this method is available only in the route handlers and middlewares._
+ *
+ * @group PocketBase
+ */
+declare function onResponse(c: echo.Context, handler: (c: echo.Context) => void): void;
+
 /**
  * sleep pauses the current goroutine for at least the specified user duration (in ms).
  * A zero or negative duration returns immediately.
@@ -355,6 +598,77 @@ declare class SchemaField implements schema.SchemaField {
   constructor(data?: Partial<schema.SchemaField>)
 }
 
+interface GeoPointOptions extends schema.GeoPointOptions{} // merge
+/**
+ * GeoPointOptions defines the "geoPoint" schema field options.
+ *
+ * @group PocketBase
+ */
+declare class GeoPointOptions implements schema.GeoPointOptions {
+  constructor(data?: Partial<schema.GeoPointOptions>)
+}
+
+interface GeoPoint extends types.GeoPoint{} // merge
+/**
+ * GeoPoint defines a single geographic point with lat/lng coordinates
+ * (usually used as "geoPoint" schema field value).
+ *
+ * ` + "```" + `js
+ * record.set("location", new GeoPoint({lat: 42.6977, lng: 23.3219}))
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare class GeoPoint implements types.GeoPoint {
+  constructor(data?: Partial<types.GeoPoint>)
+}
+
+interface MoneyOptions extends schema.MoneyOptions{} // merge
+/**
+ * MoneyOptions defines the "money" schema field options.
+ *
+ * @group PocketBase
+ */
+declare class MoneyOptions implements schema.MoneyOptions {
+  constructor(data?: Partial<schema.MoneyOptions>)
+}
+
+interface AutonumberOptions extends schema.AutonumberOptions{} // merge
+/**
+ * AutonumberOptions defines the "autonumber" schema field options.
+ *
+ * ` + "```" + `js
+ * collection.schema.addField(new SchemaField({
+ *   name:    "number",
+ *   type:    "autonumber",
+ *   options: new AutonumberOptions({prefix: "POL-{year}-", padding: 6, resetYearly: true}),
+ * }))
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare class AutonumberOptions implements schema.AutonumberOptions {
+  constructor(data?: Partial<schema.AutonumberOptions>)
+}
+
+interface PhoneOptions extends schema.PhoneOptions{} // merge
+/**
+ * PhoneOptions defines the "phone" schema field options.
+ *
+ * ` + "```" + `js
+ * collection.schema.addField(new SchemaField({
+ *   name:    "phone",
+ *   type:    "phone",
+ *   options: new PhoneOptions({defaultCountry: "FR", format: "international"}),
+ * }))
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare class PhoneOptions implements schema.PhoneOptions {
+  constructor(data?: Partial<schema.PhoneOptions>)
+}
+
 interface MailerMessage extends mailer.Message{} // merge
 /**
  * MailerMessage defines a single email message.
@@ -445,6 +759,41 @@ declare class DateTime implements types.DateTime {
   constructor(date?: string)
 }
 
+interface JsonMap extends types.JsonMap{} // merge
+/**
+ * JsonMap defines a single JSON object (aka. map[string]any).
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * const data = new JsonMap({"title": "Lorem ipsum"})
+ *
+ * data.set("active", true)
+ * data.get("title") // "Lorem ipsum"
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare class JsonMap implements types.JsonMap {
+  constructor(data?: { [key:string]: any })
+}
+
+interface JsonArray extends types.JsonArray<any>{} // merge
+/**
+ * JsonArray defines a single JSON array (aka. []any).
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * const tags = new JsonArray(["a", "b"])
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare class JsonArray implements types.JsonArray<any> {
+  constructor(data?: Array<any>)
+}
+
 interface ValidationError extends ozzo_validation.Error{} // merge
 /**
  * ValidationError defines a single formatted data validation error,
@@ -577,6 +926,7 @@ declare namespace $tokens {
   let recordResetPasswordToken: tokens.newRecordResetPasswordToken
   let recordChangeEmailToken:   tokens.newRecordChangeEmailToken
   let recordFileToken:          tokens.newRecordFileToken
+  let impersonationToken:       tokens.newRecordImpersonationToken
 }
 // Alias
 import Tokens = $tokens
@@ -599,6 +949,284 @@ declare namespace $mails {
 // Alias
 import Mail = $mails
 // -------------------------------------------------------------------
+// smsBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$sms` + "`" + ` defines helpers to send SMS messages
+ * using the configured app SMS provider.
+ *
+ * ` + "```" + `js
+ * $sms.send("+359000000000", "Hello!")
+ *
+ * // or with custom sender
+ * $sms.send($app, {
+ *     from: "MyApp",
+ *     to:   "+359000000000",
+ *     body: "Hello!",
+ * })
+ * ` + "```" + `
+ *
+ * The sent messages delivery status is logged in the app logs (with "type" sms).
+ *
+ * @group PocketBase
+ */
+declare namespace $sms {
+  /**
+   * Sends a single SMS message.
+   *
+   * If ` + "`" + `message.from` + "`" + ` is not set, the one from the SMS settings is used.
+   */
+  export function send(to: string, body: string): void
+  export function send(app: CoreApp, message: Partial<sms.Message>): void
+}
+// -------------------------------------------------------------------
+// captchaBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$captcha` + "`" + ` defines helpers for verifying captcha response tokens
+ * with the configured app captcha provider (Turnstile, hCaptcha or reCAPTCHA).
+ *
+ * ` + "```" + `js
+ * onRecordBeforeAuthWithPasswordRequest((e) => {
+ *     const info = $apis.requestInfo(e.httpContext)
+ *
+ *     // throws an error if the token is missing or invalid
+ *     $captcha.verify(info.data.captchaToken, e.httpContext.realIP())
+ * }, "users")
+ * ` + "```" + `
+ *
+ * For custom routes you could also use the ` + "`" + `$apis.requireCaptcha($app)` + "`" + ` middleware.
+ *
+ * @group PocketBase
+ */
+declare namespace $captcha {
+  /**
+   * Verifies the captcha response token with the app captcha provider.
+   *
+   * The client IP is optional and could be an empty string.
+   */
+  export function verify(token: string, ip?: string): void
+}
+// -------------------------------------------------------------------
+// backupsBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$backups` + "`" + ` defines helpers for managing the app backups.
+ *
+ * ` + "```" + `js
+ * // backup before a risky bulk update
+ * $backups.create("before_import.zip")
+ *
+ * for (let backup of $backups.list()) {
+ *     console.log(backup.key, backup.size)
+ * }
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $backups {
+  /**
+   * Creates a new app backup with the specified name
+   * (an autogenerated one is used if empty).
+   */
+  export function create(name?: string): void
+
+  /**
+   * Uploads the provided zip file as a new app backup.
+   */
+  export function upload(file: filesystem.File): void
+
+  /**
+   * Returns the info of all available app backups.
+   */
+  export function list(): Array<models.BackupFileInfo>
+
+  /**
+   * Deletes a single app backup by its name.
+   */
+  export function delete(name: string): void
+}
+// -------------------------------------------------------------------
+// notificationsBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$notifications` + "`" + ` defines helpers for creating in-app notifications
+ * and sending push notifications to the registered devices of the auth records.
+ *
+ * ` + "```" + `js
+ * const result = $notifications.send("RECORD_ID", {
+ *     title: "New message",
+ *     body:  "You have a new message.",
+ *     data:  { "chatId": "abc" },
+ * })
+ *
+ * console.log(result.sent, result.failed)
+ *
+ * $notifications.create(user, "New message", "You have a new message.", { "chatId": "abc" })
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $notifications {
+  /**
+   * Sends a push notification to all registered devices of a single auth record.
+   */
+  export function send(recordId: string, message: Partial<push.Message>): notifications.SendResult
+
+  /**
+   * Sends a push notification to all registered devices of the specified auth records.
+   */
+  export function sendBatch(recordIds: Array<string>, message: Partial<push.Message>): notifications.SendResult
+
+  /**
+   * Creates a new in-app notification for the specified auth record.
+   */
+  export function create(recipient: models.Record, title: string, body?: string, data?: { [key:string]: any }): models.Notification
+
+  /**
+   * Creates a new in-app notification for each auth record referenced
+   * by the relation field of the provided record (eg. organization members).
+   */
+  export function createForRelation(record: models.Record, relationField: string, title: string, body?: string, data?: { [key:string]: any }): Array<models.Notification>
+
+  /**
+   * Raises a new admin notification center entry with "info", "warning" or "error" severity.
+   *
+   * Repeated unread notifications with the same source and title are grouped
+   * in a single entry with incremented ` + "`" + `count` + "`" + `.
+   */
+  export function notifyAdmins(severity: string, source: string, title: string, body?: string, data?: { [key:string]: any }): models.AdminNotification
+}
+// -------------------------------------------------------------------
+// realtimeBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$realtime` + "`" + ` defines helpers for inspecting and managing
+ * the realtime clients connected to the current app instance.
+ *
+ * ` + "```" + `js
+ * for (let client of $realtime.clients()) {
+ *     console.log(client.id, client.authId, client.subscriptions)
+ * }
+ *
+ * $realtime.disconnect("CLIENT_ID")
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $realtime {
+  /**
+   * Returns the metadata of all connected realtime clients
+   * (subscriptions, auth identity, connect date, etc.).
+   */
+  export function clients(): Array<apis.RealtimeClientInfo>
+
+  /**
+   * Returns the metadata of a single connected realtime client.
+   */
+  export function client(clientId: string): apis.RealtimeClientInfo
+
+  /**
+   * Closes the connection of a single realtime client.
+   */
+  export function disconnect(clientId: string): void
+}
+// -------------------------------------------------------------------
+// settingsBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$settings` + "`" + ` defines helpers for reading and updating the app settings
+ * with the same validation (and encryption) as the settings API endpoint.
+ *
+ * ` + "```" + `js
+ * const current = $settings.get()
+ *
+ * $settings.patch({
+ *     smtp: {
+ *         enabled: true,
+ *         host:    "smtp.example.com",
+ *         port:    587,
+ *     },
+ * })
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $settings {
+  /**
+   * Returns a copy of the current app settings
+   * with redacted secret values (` + "`" + `******` + "`" + `).
+   */
+  export function get(): settings.Settings
+
+  /**
+   * Validates and merges the provided data into the app settings.
+   *
+   * Redacted secret values (` + "`" + `******` + "`" + `) are ignored, so the result
+   * of ` + "`" + `$settings.get()` + "`" + ` could be modified and submitted back.
+   *
+   * Returns the updated redacted app settings.
+   */
+  export function patch(data: { [key:string]: any }): settings.Settings
+}
+// -------------------------------------------------------------------
+// storeBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$store` + "`" + ` defines a persistent key-value store for sharing state
+ * between the app hooks (the pooled JS runtimes have isolated globals).
+ *
+ * The values are stored as JSON in the ` + "`" + `_kv` + "`" + ` system table,
+ * so they are shared across all runtimes and survive the app restarts.
+ *
+ * ` + "```" + `js
+ * $store.set("config", {enabled: true})
+ * $store.set("session:abc", {userId: "123"}, 3600) // expires after 1h
+ *
+ * const config = $store.get("config") // null if missing or expired
+ *
+ * const hits = $store.incr("hits:" + ip, 1, 60)
+ *
+ * $store.del("config")
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $store {
+  /**
+   * Returns the JSON decoded value of the specified key
+   * or null if the key doesn't exist or it has expired.
+   */
+  export function get(key: string): any
+
+  /**
+   * Stores the JSON serializable value under the specified key.
+   *
+   * The optional ttl is in seconds (0 or missing means no expiration).
+   */
+  export function set(key: string, value: any, ttl?: number): void
+
+  /**
+   * Deletes the specified key (if exists).
+   */
+  export function del(key: string): void
+
+  /**
+   * Atomically increments the integer value of the specified key
+   * (missing or expired keys are treated as 0) and returns its new value.
+   *
+   * The optional ttl is in seconds (0 or missing preserves the existing key expiration).
+   */
+  export function incr(key: string, delta?: number, ttl?: number): number
+}
+// -------------------------------------------------------------------
 // securityBinds
 // -------------------------------------------------------------------
 
@@ -637,6 +1265,229 @@ declare namespace $security {
 // Alias
 import Security = $security
 
+// -------------------------------------------------------------------
+// inflectorBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$inflector` + "`" + ` defines common string casing and normalization
+ * helpers (eg. uppercase first letter, snakecase, etc.).
+ *
+ * @group PocketBase
+ */
+declare namespace $inflector {
+  let ucFirst:   inflector.ucFirst
+  let columnify: inflector.columnify
+  let sentenize: inflector.sentenize
+  let sanitize:  inflector.sanitize
+  let snakecase: inflector.snakecase
+}
+
+// Alias
+import Inflector = $inflector
+
+// -------------------------------------------------------------------
+// listBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$list` + "`" + ` defines common helpers for working with string slices.
+ *
+ * @group PocketBase
+ */
+declare namespace $list {
+  /**
+   * Returns a new slice with only the "base" elements
+   * that don't exist in "subtract".
+   */
+  export function subtractSlice(base: Array<string>, subtract: Array<string>): Array<string>
+
+  /**
+   * Checks whether a string exists in a slice.
+   */
+  export function existInSlice(item: string, list: Array<string>): boolean
+
+  /**
+   * Checks whether a string matches any of the provided regex patterns.
+   */
+  export function existInSliceWithRegex(str: string, patterns: Array<string>): boolean
+
+  /**
+   * Returns only the nonzero unique values from a slice.
+   */
+  export function nonzeroUniques(list: Array<string>): Array<string>
+
+  /**
+   * Casts the provided value into a slice of unique and nonzero strings.
+   */
+  export function toUniqueStringSlice(value: any): Array<string>
+}
+
+// Alias
+import List = $list
+
+// -------------------------------------------------------------------
+// typesBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$types` + "`" + ` defines helpers for parsing values into the
+ * PocketBase field types (DateTime, JsonRaw, GeoPoint).
+ *
+ * @group PocketBase
+ */
+declare namespace $types {
+  let nowDateTime:   types.nowDateTime
+  let parseDateTime: types.parseDateTime
+  let parseJsonRaw:  types.parseJsonRaw
+  let parseGeoPoint: types.parseGeoPoint
+}
+
+// Alias
+import Types = $types
+
+// -------------------------------------------------------------------
+// timeBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$time` + "`" + ` defines helpers for dates arithmetic, timezones
+ * conversion (IANA names), ISO weeks and business days calculations.
+ *
+ * The date arguments could be DateTime, Date, date string or unix timestamp.
+ * The timezone arguments are optional and default to UTC.
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * const due = $time.addBusinessDays(record.get("created"), 10, ["2024-12-25"], "Europe/Sofia")
+ *
+ * $time.format(due, "02.01.2006 15:04", "Europe/Sofia")
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $time {
+  export function now(): types.DateTime
+
+  /**
+   * Adds a Go duration string (eg. "1h30m", "-15m") to the date.
+   */
+  export function add(date: any, duration: string): types.DateTime
+
+  /**
+   * Adds the specified calendar years, months and days
+   * to the date (in the specified timezone).
+   */
+  export function addDate(date: any, years: number, months: number, days: number, timezone?: string): types.DateTime
+
+  /**
+   * Returns a - b in the specified unit ("ms" (default), "s", "m", "h", "d").
+   */
+  export function diff(a: any, b: any, unit?: string): number
+
+  /**
+   * Converts the date into a time.Time in the specified timezone.
+   */
+  export function inTimezone(date: any, timezone: string): time.Time
+
+  /**
+   * Formats the date in the specified timezone using a Go layout (eg. "2006-01-02 15:04").
+   */
+  export function format(date: any, layout: string, timezone?: string): string
+
+  /**
+   * Parses a date string with the Go layout in the specified timezone.
+   */
+  export function parse(layout: string, value: string, timezone?: string): types.DateTime
+
+  /**
+   * Returns the ISO 8601 year and week of the date.
+   */
+  export function isoWeek(date: any, timezone?: string): { year: number, week: number }
+
+  /**
+   * Returns the start (Monday 00:00) of the ISO 8601 week.
+   */
+  export function startOfIsoWeek(year: number, week: number, timezone?: string): types.DateTime
+
+  /**
+   * Reports whether the date is neither weekend nor one of the holidays ("YYYY-MM-DD").
+   */
+  export function isBusinessDay(date: any, holidays?: Array<string>, timezone?: string): boolean
+
+  /**
+   * Shifts the date with the specified number of business days (negative to go backwards).
+   */
+  export function addBusinessDays(date: any, days: number, holidays?: Array<string>, timezone?: string): types.DateTime
+
+  /**
+   * Returns the number of business days after start up to and including end.
+   */
+  export function businessDaysBetween(start: any, end: any, holidays?: Array<string>, timezone?: string): number
+}
+
+// -------------------------------------------------------------------
+// moneyBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$money` + "`" + ` defines helpers for the "money" field values arithmetic and formatting.
+ *
+ * The amounts are integer minor units (eg. cents). The money arguments could be
+ * types.Money, {amount, currency} objects, minor units integers or
+ * major units decimal strings (eg. "12.34").
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * const total = $money.add(record.get("price"), record.get("shipping"))
+ * const vat   = $money.mul(total, 0.2)
+ *
+ * $money.format(vat, ",", ".") // "1,234.56"
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $money {
+  let create: types.newMoney
+  let parse:  types.parseMoney
+
+  /**
+   * Returns a + b (b is parsed in the currency of a).
+   */
+  export function add(a: any, b: any): types.Money
+
+  /**
+   * Returns a - b (b is parsed in the currency of a).
+   */
+  export function sub(a: any, b: any): types.Money
+
+  /**
+   * Multiplies the amount by factor rounding half away from zero to the nearest minor unit.
+   */
+  export function mul(money: any, factor: number): types.Money
+
+  /**
+   * Splits the amount between the ratios without losing minor units
+   * (eg. 1.00 with [1, 1, 1] results in [0.34, 0.33, 0.33]).
+   */
+  export function allocate(money: any, ratios: Array<number>): Array<types.Money>
+
+  /**
+   * Returns -1, 0 or 1 if a is respectively less than, equal to or greater than b.
+   */
+  export function compare(a: any, b: any): number
+
+  /**
+   * Formats the amount in major units (the decimal separator defaults to ".").
+   */
+  export function format(money: any, thousandsSeparator?: string, decimalSeparator?: string): string
+}
+
+// Alias
+import MoneyHelpers = $money
+
 // -------------------------------------------------------------------
 // filesystemBinds
 // -------------------------------------------------------------------
@@ -671,6 +1522,44 @@ declare namespace $filesystem {
 
 // Alias
 import Filesystem = $filesystem
+
+// -------------------------------------------------------------------
+// barcodeBinds
+// -------------------------------------------------------------------
+
+/**
+ * ` + "`" + `$barcode` + "`" + ` defines helpers for generating scannable barcodes
+ * as PNG or SVG files that could be assigned to a record file field.
+ *
+ * Example:
+ *
+ * ` + "```" + `js
+ * onRecordAfterCreateRequest((e) => {
+ *     const form = new RecordUpsertForm($app, e.record)
+ *     form.addFiles("qr", $barcode.qr("https://example.com/cards/" + e.record.id))
+ *     form.submit()
+ * }, "cards")
+ * ` + "```" + `
+ *
+ * @group PocketBase
+ */
+declare namespace $barcode {
+  /**
+   * qr generates a QR code image file with the specified
+   * size in pixels (default to 256) and format ("png" (default) or "svg").
+   */
+  export function qr(data: string, size?: number, format?: string): filesystem.File
+
+  /**
+   * code128 generates a Code 128 linear barcode image file for the ASCII data
+   * with the specified height in pixels (default to 80) and
+   * format ("png" (default) or "svg").
+   */
+  export function code128(data: string, height?: number, format?: string): filesystem.File
+}
+
+// Alias
+import Barcode = $barcode
 // -------------------------------------------------------------------
 // filepathBinds
 // -------------------------------------------------------------------
@@ -714,6 +1603,8 @@ import Filepath = $filepath
 declare namespace $os {
   /**
    * Legacy alias for $os.cmd().
+   *
+   * @deprecated Use $os.cmd() (unavailable with ScriptApiVersion >= 2).
    */
   export let exec: exec.command
 
@@ -1003,19 +1894,35 @@ declare namespace $apis {
    */
   export function staticDirectoryHandler(dir: string, indexFallback: boolean): echo.HandlerFunc
 
+  /**
+   * Route handler that forwards the request to the target upstream url
+   * (the path prefix is replaced with the target url path).
+   *
+   * Example:
+   *
+   * ` + "```" + `js
+   * // "/svc/hello?a=1" -> "http://127.0.0.1:3000/v1/hello?a=1"
+   * routerAdd("GET", "/svc/*", $apis.proxy("/svc", "http://127.0.0.1:3000/v1"))
+   * ` + "```" + `
+   */
+  export function proxy(prefix: string, target: string): echo.HandlerFunc
+
   let requireGuestOnly:          apis.requireGuestOnly
   let requireRecordAuth:         apis.requireRecordAuth
   let requireAdminAuth:          apis.requireAdminAuth
   let requireAdminAuthOnlyIfAny: apis.requireAdminAuthOnlyIfAny
   let requireAdminOrRecordAuth:  apis.requireAdminOrRecordAuth
   let requireAdminOrOwnerAuth:   apis.requireAdminOrOwnerAuth
+  let requireCaptcha:            apis.requireCaptcha
   let activityLogger:            apis.activityLogger
   let requestInfo:               apis.requestInfo
   let recordAuthResponse:        apis.recordAuthResponse
   let gzip:                      middleware.gzip
   let bodyLimit:                 middleware.bodyLimit
+  let requestLimits:             apis.requestLimits
   let enrichRecord:              apis.enrichRecord
   let enrichRecords:             apis.enrichRecords
+  let verifyCaptcha:             apis.verifyCaptcha
 }
 
 // Alias
@@ -1106,12 +2013,17 @@ func main() {
 			"github.com/go-ozzo/ozzo-validation/v4":             {"Error"},
 			"github.com/pocketbase/dbx":                         {"*"},
 			"github.com/pocketbase/pocketbase/tools/security":   {"*"},
+			"github.com/pocketbase/pocketbase/tools/inflector":  {"*"},
+			"github.com/pocketbase/pocketbase/tools/types":      {"*"},
 			"github.com/pocketbase/pocketbase/tools/filesystem": {"*"},
 			"github.com/pocketbase/pocketbase/tools/template":   {"*"},
+			"github.com/pocketbase/pocketbase/tools/sms":        {"*"},
+			"github.com/pocketbase/pocketbase/tools/push":       {"*"},
 			"github.com/pocketbase/pocketbase/tokens":           {"*"},
 			"github.com/pocketbase/pocketbase/mails":            {"*"},
 			"github.com/pocketbase/pocketbase/apis":             {"*"},
 			"github.com/pocketbase/pocketbase/forms":            {"*"},
+			"github.com/pocketbase/pocketbase/notifications":    {"*"},
 			"github.com/pocketbase/pocketbase":                  {"*"},
 			"path/filepath":                                     {"*"},
 			"os":                                                {"*"},
@@ -22,8 +22,10 @@ import (
 	"time"
 
 	"github.com/dop251/goja"
+	"github.com/dop251/goja/parser"
 	"github.com/dop251/goja_nodejs/console"
 	"github.com/dop251/goja_nodejs/process"
+	"github.com/dop251/goja_nodejs/require"
 	"github.com/fatih/color"
 	"github.com/fsnotify/fsnotify"
 	"github.com/labstack/echo/v5"
@@ -31,6 +33,7 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 	m "github.com/pocketbase/pocketbase/migrations"
 	"github.com/pocketbase/pocketbase/plugins/jsvm/internal/types/generated"
+	"github.com/pocketbase/pocketbase/tools/preflight"
 	"github.com/pocketbase/pocketbase/tools/template"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -58,11 +61,33 @@ type Config struct {
 	// If not set it fallbacks to a relative "pb_data/../pb_hooks" directory.
 	HooksDir string
 
+	// TemplatesDir specifies the server-side HTML views directory
+	// used by the $template.render* helpers.
+	//
+	// If not set it fallbacks to a relative "pb_hooks/views" directory.
+	TemplatesDir string
+
+	// TemplatesReload enables reparsing the views and translations
+	// on every $template.render* call (useful during development).
+	TemplatesReload bool
+
+	// RequireSourceLoader is an optional custom source loader of the
+	// modules imported with require() by the JS app hooks and migrations.
+	//
+	// The relative module paths are resolved from the requiring script
+	// location (or from the HooksDir/MigrationsDir if called inside
+	// a hook handler) and the loader receives the full module file path.
+	// The loader must return [require.ModuleFileDoesNotExistError]
+	// for the missing files to allow the "node_modules" lookup to continue.
+	//
+	// If not set it fallbacks to [require.DefaultSourceLoader].
+	RequireSourceLoader require.SourceLoader
+
 	// HooksFilesPattern specifies a regular expression pattern that
 	// identify which file to load by the hook vm(s).
 	//
 	// If not set it fallbacks to `^.*(\.pb\.js|\.pb\.ts)$`, aka. any
-	// HookdsDir file ending in ".pb.js" or ".pb.ts" (the last one is to enforce IDE linters).
+	// HookdsDir file ending in ".pb.js" or ".pb.ts" (the ".ts" files are transpiled to JS before their execution).
 	HooksFilesPattern string
 
 	// HooksPoolSize specifies how many goja.Runtime instances to prewarm
@@ -78,7 +103,7 @@ type Config struct {
 	MigrationsDir string
 
 	// If not set it fallbacks to `^.*(\.js|\.ts)$`, aka. any MigrationDir file
-	// ending in ".js" or ".ts" (the last one is to enforce IDE linters).
+	// ending in ".js" or ".ts" (the ".ts" files are transpiled to JS before their execution).
 	MigrationsFilesPattern string
 
 	// TypesDir specifies the directory where to store the embedded
@@ -89,6 +114,18 @@ type Config struct {
 	// Note: Avoid using the same directory as the HooksDir when HooksWatch is enabled
 	// to prevent unnecessary app restarts when the types file is initially created.
 	TypesDir string
+
+	// ScriptApiVersion specifies the JS bind APIs version that the
+	// hooks and migrations scripts are written for.
+	//
+	// The APIs renamed after this version remain available under their
+	// deprecated names (logging a warning on use) for one major version,
+	// allowing large hooks codebases to be upgraded incrementally.
+	//
+	// If not set it fallbacks to MinScriptApiVersion (aka. all still
+	// supported deprecated names are available).
+	// Set it to LatestScriptApiVersion to disable the deprecated names.
+	ScriptApiVersion int
 }
 
 // MustRegister registers the jsvm plugin in the provided app instance
@@ -110,12 +147,22 @@ func MustRegister(app core.App, config Config) {
 
 // Register registers the jsvm plugin in the provided app instance.
 func Register(app core.App, config Config) error {
-	p := &plugin{app: app, config: config}
+	p := &plugin{app: app, config: config, hooksErrors: map[string]error{}}
+
+	compat, err := newScriptCompat(app, p.config.ScriptApiVersion)
+	if err != nil {
+		return err
+	}
+	p.compat = compat
 
 	if p.config.HooksDir == "" {
 		p.config.HooksDir = filepath.Join(app.DataDir(), "../pb_hooks")
 	}
 
+	if p.config.TemplatesDir == "" {
+		p.config.TemplatesDir = filepath.Join(p.config.HooksDir, "views")
+	}
+
 	if p.config.MigrationsDir == "" {
 		p.config.MigrationsDir = filepath.Join(app.DataDir(), "../pb_migrations")
 	}
@@ -140,7 +187,11 @@ func Register(app core.App, config Config) error {
 
 		return nil
 	})
-	err := p.registerMigrations()
+	p.app.OnPreflight().Add(func(e *core.PreflightEvent) error {
+		p.preflight(e.Report)
+		return nil
+	})
+	err = p.registerMigrations()
 	if err != nil {
 		return (fmt.Errorf("registerMigrations: %w", err))
 	}
@@ -154,6 +205,13 @@ func Register(app core.App, config Config) error {
 type plugin struct {
 	app    core.App
 	config Config
+
+	// hooksErrors stores the compile and execution errors of the
+	// failed hooks files (reported with the app preflight checks)
+	hooksErrors map[string]error
+
+	// compat registers the deprecated script API names
+	compat *scriptCompat
 }
 
 // registerMigrations registers the JS migrations loader.
@@ -163,19 +221,32 @@ func (p *plugin) registerMigrations() error {
 	if err != nil {
 		return err
 	}
+	absMigrationsDir, err := filepath.Abs(p.config.MigrationsDir)
+	if err != nil {
+		return err
+	}
+
+	requireRegistry := newRequireRegistry(absMigrationsDir, p.config.RequireSourceLoader)
+
 	var _err = make(chan error)
 	go func() {
 		// vm := goja.New()
 		for file, content := range files {
-			loop := NewEventLoop()
+			loop := NewEventLoop(WithRegistry(requireRegistry))
 			loop.Stop()
 			baseBinds(loop.vm)
 			dbxBinds(loop.vm)
 			tokensBinds(loop.vm)
 			securityBinds(loop.vm)
+			inflectorBinds(loop.vm)
+			listBinds(loop.vm)
+			typesBinds(loop.vm)
+			timeBinds(loop.vm)
+			moneyBinds(loop.vm)
 			osBinds(loop.vm)
 			filepathBinds(loop.vm)
 			httpClientBinds(loop.vm)
+			p.compat.apply(loop.vm)
 			if p.config.OnInit != nil {
 				p.config.OnInit(loop.vm)
 			}
@@ -183,7 +254,10 @@ func (p *plugin) registerMigrations() error {
 				m.AppMigrations.Register(up, down, file)
 			})
 			loop.RunOnLoop(func(vm *goja.Runtime) {
-				_, err := vm.RunString(string(content))
+				program, err := compileScript(file, content)
+				if err == nil {
+					_, err = vm.RunProgram(program)
+				}
 				if err != nil {
 					_err <- fmt.Errorf("failed to run migration %s: %w", file, err)
 					return
@@ -245,25 +319,37 @@ func (p *plugin) registerHooks() error {
 	})
 
 	// safe to be shared across multiple vms
-	// requireRegistry := new(require.Registry)
-	templateRegistry := template.NewRegistry()
+	requireRegistry := newRequireRegistry(absHooksDir, p.config.RequireSourceLoader)
+	templateRegistry := template.NewViews(p.config.TemplatesDir).SetReload(p.config.TemplatesReload)
 
 	sharedBinds := func(vm *goja.Runtime) {
-		// requireRegistry.Enable(vm)
 		console.Enable(vm)
 		process.Enable(vm)
 
 		baseBinds(vm)
 		dbxBinds(vm)
 		filesystemBinds(vm)
+		barcodeBinds(vm)
 		tokensBinds(vm)
 		securityBinds(vm)
+		inflectorBinds(vm)
+		listBinds(vm)
+		typesBinds(vm)
+		timeBinds(vm)
+		moneyBinds(vm)
 		osBinds(vm)
 		filepathBinds(vm)
 		httpClientBinds(vm)
 		formsBinds(vm)
 		apisBinds(vm)
 		mailsBinds(vm)
+		smsBinds(p.app, vm)
+		captchaBinds(p.app, vm)
+		backupsBinds(p.app, vm)
+		notificationsBinds(p.app, vm)
+		realtimeBinds(p.app, vm)
+		settingsBinds(p.app, vm)
+		storeBinds(p.app, vm)
 
 		// Remove all characters that are not alphanumeric or spaces or underscores
 		s := regexp.MustCompile("[^a-zA-Z0-9_ ]+").ReplaceAllString(p.app.Settings().Meta.AppName, "")
@@ -287,6 +373,8 @@ func (p *plugin) registerHooks() error {
 		vm.Set("Template", templateRegistry)
 		vm.Set("__hooks", absHooksDir)
 
+		p.compat.apply(vm)
+
 		if p.config.OnInit != nil {
 			p.config.OnInit(vm)
 		}
@@ -304,7 +392,7 @@ func (p *plugin) registerHooks() error {
 	executors := newPool(p.config.HooksPoolSize, func() *goja.Runtime {
 		var vm = make(chan *goja.Runtime)
 		go func() {
-			loop := NewEventLoop()
+			loop := NewEventLoop(WithRegistry(requireRegistry))
 			loop.Stop()
 			loop.RunOnLoop(func(r *goja.Runtime) {
 				sharedBinds(r)
@@ -318,48 +406,90 @@ func (p *plugin) registerHooks() error {
 		return ret
 	})
 
-	// initialize the loader vm
-	// loader := goja.New()
-	_err := make(chan error)
+	// compile and load the hooks files
+	//
+	// note: the files with errors are skipped and reported
+	// later with the app preflight checks
+	type loadResult struct {
+		file string
+		err  error
+	}
+
+	results := make(chan loadResult, len(files))
+
 	for file, content := range files {
-		go func(file string, content []byte) {
-			defer func() {
-				if err := recover(); err != nil {
-					fmtErr := fmt.Errorf("Failed to execute %s:\n - %v", file, err)
+		program, err := compileScript(file, content)
+		if err != nil {
+			results <- loadResult{file, err}
+			continue
+		}
 
-					if p.config.HooksWatch {
-						color.Red("%v", fmtErr)
-					} else {
-						panic(fmtErr)
-					}
+		go func(file string, program *goja.Program) {
+			var err error
+
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%v", r)
 				}
+				results <- loadResult{file, err}
 			}()
-			loop := NewEventLoop()
+
+			loop := NewEventLoop(WithRegistry(requireRegistry))
 			loop.Stop()
 			loop.RunOnLoop(func(vm *goja.Runtime) {
 				sharedBinds(vm)
 				hooksBinds(p.app, vm, executors)
 				cronBinds(p.app, vm, executors)
+				busBinds(p.app, vm, executors)
 				routerBinds(p.app, vm, executors)
-				_, err := vm.RunString(string(content))
-				if err != nil {
-					_err <- err
-				}
-				_err <- nil
+				virtualCollectionBinds(p.app, vm, executors)
+				_, err = vm.RunProgram(program)
 			})
-		}(file, content)
+		}(file, program)
 	}
-	for i := len(files); i > 0; {
-		if err = <-_err; err != nil {
-			return err
-		} else {
-			i--
-		}
 
+	for i := 0; i < len(files); i++ {
+		result := <-results
+		if result.err != nil {
+			p.hooksErrors[result.file] = result.err
+			color.Red("Failed to execute %s:\n - %v", result.file, result.err)
+		}
 	}
+
 	return nil
 }
 
+// compileScript parses and compiles the provided JS script.
+//
+// TypeScript files are transpiled first and their inline source maps are loaded
+// (for the plain JS files the source maps are disabled for consistency with the loop vms).
+func compileScript(name string, content []byte) (*goja.Program, error) {
+	opts := []parser.Option{parser.WithDisableSourceMaps}
+
+	if isTypeScript(name) {
+		var err error
+		content, err = transpileScript(name, content)
+		if err != nil {
+			return nil, err
+		}
+		opts = nil
+	}
+
+	ast, err := goja.Parse(name, string(content), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return goja.CompileAST(ast, false)
+}
+
+// preflight registers the hooks files errors (if any) in the provided report.
+func (p *plugin) preflight(report *preflight.Report) {
+	for file, err := range p.hooksErrors {
+		report.Add("jsvm", filepath.Join(p.config.HooksDir, file), err)
+	}
+}
+
 // normalizeExceptions wraps the provided error handler and returns a new one
 // with extracted goja exception error value for consistency when throwing or returning errors.
 func (p *plugin) normalizeServeExceptions(oldErrorHandler echo.HTTPErrorHandler) echo.HTTPErrorHandler {
@@ -0,0 +1,74 @@
+package jsvm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/preflight"
+)
+
+func TestRegisterHooksPreflight(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	hooksDir := t.TempDir()
+
+	files := map[string]string{
+		"valid.pb.js":   `onModelBeforeCreate((e) => {})`,
+		"syntax.pb.js":  `onModelBeforeCreate((e) => {`,
+		"runtime.pb.js": `throw new Error("boom")`,
+		"ignored.js":    `invalid(`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := Register(app, Config{
+		HooksDir:      hooksDir,
+		MigrationsDir: t.TempDir(),
+		TypesDir:      t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("Expected the broken hooks to be reported with the preflight checks, got %v", err)
+	}
+
+	event := &core.PreflightEvent{App: app, Report: &preflight.Report{}}
+	if err := app.OnPreflight().Trigger(event); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := event.Report.Issues()
+
+	expected := []struct {
+		file    string
+		message string
+	}{
+		{"runtime.pb.js", "boom"},
+		{"syntax.pb.js", "SyntaxError"},
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %v", len(expected), issues)
+	}
+
+	for i, e := range expected {
+		if issues[i].Source != "jsvm" {
+			t.Fatalf("[%d] Expected jsvm source, got %q", i, issues[i].Source)
+		}
+
+		if issues[i].File != filepath.Join(hooksDir, e.file) {
+			t.Fatalf("[%d] Expected file %q, got %q", i, e.file, issues[i].File)
+		}
+
+		if !strings.Contains(issues[i].Message, e.message) {
+			t.Fatalf("[%d] Expected message to contain %q, got %q", i, e.message, issues[i].Message)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package jsvm
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/dop251/goja"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tools/store"
+)
+
+// requestStoreKey is the echo.Context key of the request scoped JS store.
+const requestStoreKey = "__jsvmRequestStore"
+
+// requestContext wraps the echo.Context passed to the JS route
+// handlers and middlewares with additional request scoped helpers.
+type requestContext struct {
+	echo.Context
+
+	// Store is a key-value store shared between all JS middlewares
+	// and the route handler of the current request.
+	Store *store.Store[any]
+
+	executors *vmsPool
+}
+
+// newRequestContext wraps the provided echo.Context into a requestContext
+// (the existing request store, if any, is reused).
+func newRequestContext(c echo.Context, executors *vmsPool) *requestContext {
+	if rc, ok := c.(*requestContext); ok {
+		return rc
+	}
+
+	s, _ := c.Get(requestStoreKey).(*store.Store[any])
+	if s == nil {
+		s = store.New[any](nil)
+		c.Set(requestStoreKey, s)
+	}
+
+	return &requestContext{
+		Context:   c,
+		Store:     s,
+		executors: executors,
+	}
+}
+
+// onResponsePrograms caches the compiled onResponse handlers by their source.
+var onResponsePrograms sync.Map
+
+// onResponse registers fn to be called right before the response
+// of the current route request is written, allowing it to inspect and
+// change the response status and headers (eg. c.response().header().set(...)).
+//
+// Similar to the route handlers, fn is executed in an isolated vm and
+// cannot access variables from the outer scope (use c.store instead).
+func onResponse(c echo.Context, fn goja.Value) error {
+	rc, ok := c.(*requestContext)
+	if !ok {
+		return errors.New("onResponse is available only in route handlers and middlewares")
+	}
+
+	if fn == nil || goja.IsUndefined(fn) || goja.IsNull(fn) {
+		return errors.New("onResponse handler must be non-nil")
+	}
+
+	source := fn.String()
+
+	var pr *goja.Program
+	if cached, ok := onResponsePrograms.Load(source); ok {
+		pr = cached.(*goja.Program)
+	} else {
+		var err error
+		pr, err = goja.Compile("", "{("+source+").apply(undefined, __args)}", true)
+		if err != nil {
+			return err
+		}
+		onResponsePrograms.Store(source, pr)
+	}
+
+	rc.Response().Before(func() {
+		err := rc.executors.run(func(executor *goja.Runtime) error {
+			executor.Set("__args", []any{rc})
+			_, err := executor.RunProgram(pr)
+			executor.Set("__args", goja.Undefined())
+
+			return err
+		})
+		if err != nil {
+			rc.Echo().Logger.Error(err)
+		}
+	})
+
+	return nil
+}
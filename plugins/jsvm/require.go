@@ -0,0 +1,41 @@
+package jsvm
+
+import (
+	"path/filepath"
+
+	"github.com/dop251/goja_nodejs/require"
+)
+
+// newRequireRegistry creates a new require() modules registry that
+// resolves the relative module paths against the specified base dir.
+//
+// The hooks and migrations scripts are compiled with names relative to
+// their directory, so the relative require() paths (incl. the "node_modules"
+// lookups) are resolved from the script location no matter of the app cwd.
+//
+// The ".ts" modules are transpiled to plain JS before their compilation
+// (note that they must be required with their full file name, eg. require("./utils.ts")).
+//
+// The registry caches the compiled modules and it is safe to be shared across multiple vms.
+func newRequireRegistry(baseDir string, loader require.SourceLoader) *require.Registry {
+	if loader == nil {
+		loader = require.DefaultSourceLoader
+	}
+
+	return require.NewRegistry(require.WithLoader(func(path string) ([]byte, error) {
+		path = filepath.FromSlash(path)
+
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+
+		path = filepath.ToSlash(path)
+
+		content, err := loader(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return transpileScript(path, content)
+	}))
+}
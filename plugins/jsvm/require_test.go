@@ -0,0 +1,109 @@
+package jsvm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/require"
+)
+
+func TestNewRequireRegistry(t *testing.T) {
+	baseDir := t.TempDir()
+
+	files := map[string]string{
+		"utils.js":                      `module.exports = { name: "utils", lib: require("./lib/a.js") }`,
+		"lib/a.js":                      `exports.value = "a+" + require("./b.js").value + "+" + require("pkg").value`,
+		"lib/b.js":                      `exports.value = "b"`,
+		"lib/c.ts":                      `interface C { value: string }; const c: C = { value: "c" }; export const value = c.value`,
+		"node_modules/pkg/index.js":     `exports.value = "pkg"`,
+		"node_modules/pkg/package.json": `{"name": "pkg"}`,
+	}
+
+	for name, content := range files {
+		p := filepath.Join(baseDir, name)
+
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loadedPaths := []string{}
+	loader := func(path string) ([]byte, error) {
+		loadedPaths = append(loadedPaths, path)
+		return require.DefaultSourceLoader(path)
+	}
+
+	scenarios := []struct {
+		name       string
+		scriptName string
+		script     string
+		expected   string
+	}{
+		{
+			"relative require from a hooks file",
+			"main.pb.js",
+			`const utils = require("./utils.js"); utils.name + ":" + utils.lib.value`,
+			"utils:a+b+pkg",
+		},
+		{
+			"relative require from a hook handler (aka. unnamed script)",
+			"",
+			`require("./lib/a.js").value`,
+			"a+b+pkg",
+		},
+		{
+			"node_modules require",
+			"main.pb.js",
+			`require("pkg").value`,
+			"pkg",
+		},
+		{
+			"typescript require",
+			"main.pb.ts",
+			`const lib: { value: string } = require("./lib/c.ts"); lib.value`,
+			"c",
+		},
+		{
+			"absolute require",
+			"main.pb.js",
+			`require("` + filepath.ToSlash(filepath.Join(baseDir, "lib/b.js")) + `").value`,
+			"b",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			loadedPaths = loadedPaths[:0]
+
+			vm := goja.New()
+			newRequireRegistry(baseDir, loader).Enable(vm)
+
+			program, err := compileScript(s.scriptName, []byte(s.script))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := vm.RunProgram(program)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v := result.String(); v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+
+			for _, p := range loadedPaths {
+				if !strings.HasPrefix(p, filepath.ToSlash(baseDir)) {
+					t.Fatalf("Expected all loaded paths to be resolved from %q, got %q", baseDir, p)
+				}
+			}
+		})
+	}
+}
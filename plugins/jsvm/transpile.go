@@ -0,0 +1,55 @@
+package jsvm
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+)
+
+// isTypeScript checks whether the provided script file name has a TypeScript extension.
+func isTypeScript(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".ts")
+}
+
+// transpileScript transforms the provided TypeScript file content to
+// a plain CommonJS script that could be executed by the goja runtime.
+//
+// The generated script has an inline source map so that the errors
+// stack traces point to the original TypeScript file lines.
+//
+// The newer syntax that goja doesn't support (eg. class fields or the
+// logical assignment operators) is lowered to ES2017.
+//
+// Non TypeScript files are returned as it is.
+func transpileScript(name string, content []byte) ([]byte, error) {
+	if !isTypeScript(name) {
+		return content, nil
+	}
+
+	result := api.Transform(string(content), api.TransformOptions{
+		Loader:     api.LoaderTS,
+		Format:     api.FormatCommonJS,
+		Target:     api.ES2017,
+		Sourcemap:  api.SourceMapInline,
+		Sourcefile: name,
+	})
+
+	if len(result.Errors) > 0 {
+		errs := make([]error, 0, len(result.Errors))
+
+		for _, msg := range result.Errors {
+			if msg.Location != nil {
+				errs = append(errs, fmt.Errorf("%s:%d:%d: %s", name, msg.Location.Line, msg.Location.Column+1, msg.Text))
+			} else {
+				errs = append(errs, fmt.Errorf("%s: %s", name, msg.Text))
+			}
+		}
+
+		return nil, errors.Join(errs...)
+	}
+
+	return result.Code, nil
+}
@@ -0,0 +1,134 @@
+package jsvm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dop251/goja"
+)
+
+func TestTranspileScript(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		file        string
+		content     string
+		expectError bool
+		expected    string
+	}{
+		{
+			"js file",
+			"test.pb.js",
+			`const a: number = 1`, // invalid js but returned as it is
+			false,
+			`const a: number = 1`,
+		},
+		{
+			"ts file",
+			"test.pb.ts",
+			`const a: number = 1`,
+			false,
+			"const a = 1;",
+		},
+		{
+			"uppercased ts extension",
+			"test.pb.TS",
+			`const a: number = 1`,
+			false,
+			"const a = 1;",
+		},
+		{
+			"ts file with syntax error",
+			"test.pb.ts",
+			`const a: = 1`,
+			true,
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := transpileScript(s.file, []byte(s.content))
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				if !strings.Contains(err.Error(), s.file+":1:") {
+					t.Fatalf("Expected the error to contain the file location, got %v", err)
+				}
+				return
+			}
+
+			if !strings.HasPrefix(string(result), s.expected) {
+				t.Fatalf("Expected %q to start with %q", result, s.expected)
+			}
+
+			if isTypeScript(s.file) && !strings.Contains(string(result), "//# sourceMappingURL=data:application/json;base64,") {
+				t.Fatalf("Expected inline source map, got %q", result)
+			}
+		})
+	}
+}
+
+func TestCompileScriptTypeScript(t *testing.T) {
+	script := strings.Join([]string{
+		`interface Item {`,
+		`    name: string`,
+		`}`,
+		`const items: Array<Item> = [{ name: "a" }, { name: "b" }]`,
+		`const names = items.map((item: Item): string => item.name).join(",")`,
+		`if (names != "a,b") {`,
+		`    throw new Error("unexpected names " + names)`,
+		`}`,
+		`throw new Error("test")`,
+	}, "\n")
+
+	program, err := compileScript("test.pb.ts", []byte(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = goja.New().RunProgram(program)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	// the stack trace should point to the original ts file line
+	if !strings.Contains(err.Error(), "Error: test") {
+		t.Fatalf("Expected the thrown test error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "test.pb.ts:9:") {
+		t.Fatalf("Expected the error to point to test.pb.ts:9, got %v", err)
+	}
+}
+
+func TestCompileScriptTypeScriptModernSyntax(t *testing.T) {
+	script := strings.Join([]string{
+		`class Counter {`,
+		`    count: number = 1`,
+		`    static total = 0`,
+		`    #step = 2`,
+		`    inc(): number {`,
+		`        Counter.total++`,
+		`        return this.count += this.#step`,
+		`    }`,
+		`}`,
+		`let counter: Counter | null = null`,
+		`counter ??= new Counter()`,
+		`const result = counter?.inc() ?? 0`,
+		`if (result !== 3 || Counter.total !== 1) {`,
+		`    throw new Error("unexpected result " + result)`,
+		`}`,
+	}, "\n")
+
+	program, err := compileScript("test.pb.ts", []byte(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := goja.New().RunProgram(program); err != nil {
+		t.Fatal(err)
+	}
+}
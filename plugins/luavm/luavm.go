@@ -28,7 +28,11 @@ import (
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/plugins/luavm/internal/types/generated"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/preflight"
 	"github.com/pocketbase/pocketbase/tools/rest"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 const (
@@ -140,6 +144,10 @@ func Register(app core.App, config Config) error {
 
 		return nil
 	})
+	p.app.OnPreflight().Add(func(e *core.PreflightEvent) error {
+		p.preflight(e.Report)
+		return nil
+	})
 	err := p.registerMigrations()
 	if err != nil {
 		return (fmt.Errorf("registerMigrations: %w", err))
@@ -201,6 +209,9 @@ func (p *plugin) registerMigrations() error {
 		for file, content := range files {
 			vm := p.newVM()
 			baseBinds(vm)
+			inflectorBinds(vm)
+			listBinds(vm)
+			typesBinds(vm)
 			// dbxBinds(vm)
 			// tokensBinds(vm)
 			// securityBinds(vm)
@@ -226,6 +237,29 @@ func (p *plugin) registerMigrations() error {
 	return <-_err
 }
 
+// preflight parses the Lua hooks files and registers
+// their syntax errors (if any) in the provided report.
+//
+// Note that the files are only compiled and loaded, but not executed.
+func (p *plugin) preflight(report *preflight.Report) {
+	files, err := filesContent(p.config.HooksDir, p.config.HooksFilesPattern)
+	if err != nil {
+		report.Add("luavm", p.config.HooksDir, err)
+		return
+	}
+
+	if len(files) == 0 {
+		return
+	}
+
+	vm := p.newVM()
+
+	for file, content := range files {
+		_, err := vm.CompileAndLoadLuaChunk(file, content, rt.TableValue(vm.GlobalEnv()))
+		report.Add("luavm", filepath.Join(p.config.HooksDir, file), err)
+	}
+}
+
 func (p *plugin) newVM() *rt.Runtime {
 	// First we obtain a new Lua runtime which outputs to stdout
 	r := rt.New(os.Stdout)
@@ -403,6 +437,46 @@ func baseBinds(vm *rt.Runtime) {
 	// })
 }
 
+func inflectorBinds(vm *rt.Runtime) {
+	vmSet(vm, "inflector", map[string]any{
+		"ucFirst":   inflector.UcFirst,
+		"columnify": inflector.Columnify,
+		"sentenize": inflector.Sentenize,
+		"sanitize":  inflector.Sanitize,
+		"snakecase": inflector.Snakecase,
+	})
+}
+
+func listBinds(vm *rt.Runtime) {
+	// note: the generic helpers are bound with their string variants (same as in jsvm)
+	vmSet(vm, "list", map[string]any{
+		"subtractSlice":         list.SubtractSlice[string],
+		"existInSlice":          list.ExistInSlice[string],
+		"existInSliceWithRegex": list.ExistInSliceWithRegex,
+		"nonzeroUniques":        list.NonzeroUniques[string],
+		"toUniqueStringSlice":   list.ToUniqueStringSlice,
+	})
+}
+
+func typesBinds(vm *rt.Runtime) {
+	vmSet(vm, "types", map[string]any{
+		"nowDateTime":   types.NowDateTime,
+		"parseDateTime": types.ParseDateTime,
+		"parseJsonRaw":  types.ParseJsonRaw,
+		"parseGeoPoint": types.ParseGeoPoint,
+		"JsonMap": func(data map[string]any) types.JsonMap {
+			instance := types.JsonMap{}
+			for k, v := range data {
+				instance[k] = v
+			}
+			return instance
+		},
+		"JsonArray": func(data []any) types.JsonArray[any] {
+			return append(types.JsonArray[any]{}, data...)
+		},
+	})
+}
+
 // filesContent returns a map with all direct files within the specified dir and their content.
 //
 // If directory with dirPath is missing or no files matching the pattern were found,
@@ -222,9 +222,9 @@ func (p *plugin) migrateCollectionsHandler(args []string, interactive bool) (str
 	var template string
 	var templateErr error
 	if p.config.TemplateLang == TemplateLangJS {
-		template, templateErr = p.jsSnapshotTemplate(collections)
+		template, templateErr = p.jsSnapshotTemplate(collections, true, nil)
 	} else {
-		template, templateErr = p.goSnapshotTemplate(collections)
+		template, templateErr = p.goSnapshotTemplate(collections, true, nil)
 	}
 	if templateErr != nil {
 		return "", fmt.Errorf("Failed to resolve template: %v", templateErr)
@@ -232,3 +232,43 @@ func (p *plugin) migrateCollectionsHandler(args []string, interactive bool) (str
 
 	return p.migrateCreateHandler(template, createArgs, interactive)
 }
+
+// CreateSnapshotMigration creates a new migration file in dir (using the
+// specified template language) that imports the provided collections
+// the same way as [daos.Dao.ImportCollections].
+//
+// If previous is not nil, the migration down step restores the previous
+// collections state (eg. the local collections before the import).
+//
+// Returns the path of the created migration file.
+func CreateSnapshotMigration(
+	dir string,
+	templateLang string,
+	name string,
+	collections []*models.Collection,
+	deleteMissing bool,
+	previous []*models.Collection,
+) (string, error) {
+	p := &plugin{config: Config{Dir: dir, TemplateLang: templateLang}}
+
+	var template string
+	var templateErr error
+	switch p.config.TemplateLang {
+	case TemplateLangJS:
+		template, templateErr = p.jsSnapshotTemplate(collections, deleteMissing, previous)
+	case TemplateLangGo:
+		template, templateErr = p.goSnapshotTemplate(collections, deleteMissing, previous)
+	default:
+		templateErr = fmt.Errorf("unsupported template language %q", templateLang)
+	}
+	if templateErr != nil {
+		return "", fmt.Errorf("Failed to resolve template: %v", templateErr)
+	}
+
+	filename, err := p.migrateCreateHandler(template, []string{name}, false)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, filename), nil
+}
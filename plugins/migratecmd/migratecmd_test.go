@@ -44,12 +44,14 @@ migrate((db) => {
     "options": {
       "allowEmailAuth": false,
       "allowOAuth2Auth": false,
+      "allowOTPAuth": false,
       "allowUsernameAuth": false,
       "exceptEmailDomains": null,
       "manageRule": "created > 0",
       "minPasswordLength": 20,
       "onlyEmailDomains": null,
       "onlyVerified": false,
+      "otpPhoneField": "",
       "requireEmail": false
     }
   });
@@ -98,12 +100,14 @@ func init() {
 			"options": {
 				"allowEmailAuth": false,
 				"allowOAuth2Auth": false,
+				"allowOTPAuth": false,
 				"allowUsernameAuth": false,
 				"exceptEmailDomains": null,
 				"manageRule": "created > 0",
 				"minPasswordLength": 20,
 				"onlyEmailDomains": null,
 				"onlyVerified": false,
+				"otpPhoneField": "",
 				"requireEmail": false
 			}
 		}` + "`" + `
@@ -226,12 +230,14 @@ migrate((db) => {
     "options": {
       "allowEmailAuth": false,
       "allowOAuth2Auth": false,
+      "allowOTPAuth": false,
       "allowUsernameAuth": false,
       "exceptEmailDomains": null,
       "manageRule": "created > 0",
       "minPasswordLength": 20,
       "onlyEmailDomains": null,
       "onlyVerified": false,
+      "otpPhoneField": "",
       "requireEmail": false
     }
   });
@@ -284,12 +290,14 @@ func init() {
 			"options": {
 				"allowEmailAuth": false,
 				"allowOAuth2Auth": false,
+				"allowOTPAuth": false,
 				"allowUsernameAuth": false,
 				"exceptEmailDomains": null,
 				"manageRule": "created > 0",
 				"minPasswordLength": 20,
 				"onlyEmailDomains": null,
 				"onlyVerified": false,
+				"otpPhoneField": "",
 				"requireEmail": false
 			}
 		}` + "`" + `
@@ -448,12 +456,14 @@ migrate((db) => {
   collection.options = {
     "allowEmailAuth": false,
     "allowOAuth2Auth": false,
+    "allowOTPAuth": false,
     "allowUsernameAuth": false,
     "exceptEmailDomains": null,
     "manageRule": "created > 0",
     "minPasswordLength": 20,
     "onlyEmailDomains": null,
     "onlyVerified": false,
+    "otpPhoneField": "",
     "requireEmail": false
   }
   collection.indexes = [
@@ -611,12 +621,14 @@ func init() {
 		if err := json.Unmarshal([]byte(` + "`" + `{
 			"allowEmailAuth": false,
 			"allowOAuth2Auth": false,
+			"allowOTPAuth": false,
 			"allowUsernameAuth": false,
 			"exceptEmailDomains": null,
 			"manageRule": "created > 0",
 			"minPasswordLength": 20,
 			"onlyEmailDomains": null,
 			"onlyVerified": false,
+			"otpPhoneField": "",
 			"requireEmail": false
 		}` + "`" + `), &options); err != nil {
 			return err
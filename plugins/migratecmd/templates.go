@@ -39,24 +39,40 @@ func (p *plugin) jsBlankTemplate() (string, error) {
 	return template, nil
 }
 
-func (p *plugin) jsSnapshotTemplate(collections []*models.Collection) (string, error) {
+// jsSnapshotTemplate generates a migration that imports the provided
+// collections (and restores the previous ones on revert, if not nil).
+func (p *plugin) jsSnapshotTemplate(collections []*models.Collection, deleteMissing bool, previous []*models.Collection) (string, error) {
 	jsonData, err := marhshalWithoutEscape(collections, "  ", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to serialize collections list: %w", err)
 	}
 
+	down := "return null;"
+	if previous != nil {
+		previousJsonData, err := marhshalWithoutEscape(previous, "  ", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize the previous collections list: %w", err)
+		}
+
+		down = fmt.Sprintf(`const snapshot = %s;
+
+  const collections = snapshot.map((item) => new Collection(item));
+
+  return Dao(db).importCollections(collections, true, null);`, string(previousJsonData))
+	}
+
 	const template = jsTypesDirective + `migrate((db) => {
   const snapshot = %s;
 
   const collections = snapshot.map((item) => new Collection(item));
 
-  return Dao(db).importCollections(collections, true, null);
+  return Dao(db).importCollections(collections, %t, null);
 }, (db) => {
-  return null;
+  %s
 })
 `
 
-	return fmt.Sprintf(template, string(jsonData)), nil
+	return fmt.Sprintf(template, string(jsonData), deleteMissing, down), nil
 }
 
 func (p *plugin) jsCreateTemplate(collection *models.Collection) (string, error) {
@@ -359,12 +375,31 @@ func init() {
 	return fmt.Sprintf(template, filepath.Base(p.config.Dir)), nil
 }
 
-func (p *plugin) goSnapshotTemplate(collections []*models.Collection) (string, error) {
+// goSnapshotTemplate generates a migration that imports the provided
+// collections (and restores the previous ones on revert, if not nil).
+func (p *plugin) goSnapshotTemplate(collections []*models.Collection, deleteMissing bool, previous []*models.Collection) (string, error) {
 	jsonData, err := marhshalWithoutEscape(collections, "\t\t", "\t")
 	if err != nil {
 		return "", fmt.Errorf("failed to serialize collections list: %w", err)
 	}
 
+	down := "return nil"
+	if previous != nil {
+		previousJsonData, err := marhshalWithoutEscape(previous, "\t\t", "\t")
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize the previous collections list: %w", err)
+		}
+
+		down = fmt.Sprintf(`jsonData := `+"`%s`"+`
+
+		collections := []*models.Collection{}
+		if err := json.Unmarshal([]byte(jsonData), &collections); err != nil {
+			return err
+		}
+
+		return daos.New(db).ImportCollections(collections, true, nil)`, escapeBacktick(string(previousJsonData)))
+	}
+
 	const template = `package %s
 
 import (
@@ -385,9 +420,9 @@ func init() {
 			return err
 		}
 
-		return daos.New(db).ImportCollections(collections, true, nil)
+		return daos.New(db).ImportCollections(collections, %t, nil)
 	}, func(db dbx.Builder) error {
-		return nil
+		%s
 	})
 }
 `
@@ -395,6 +430,8 @@ func init() {
 		template,
 		filepath.Base(p.config.Dir),
 		escapeBacktick(string(jsonData)),
+		deleteMissing,
+		down,
 	), nil
 }
 
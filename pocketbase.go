@@ -1,12 +1,15 @@
 package pocketbase
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/pocketbase/pocketbase/cmd"
@@ -32,10 +35,14 @@ type appWrapper struct {
 type PocketBase struct {
 	*appWrapper
 
-	devFlag           bool
-	dataDirFlag       string
-	encryptionEnvFlag string
-	hideStartBanner   bool
+	devFlag               bool
+	dataDirFlag           string
+	encryptionEnvFlag     string
+	settingsFileFlag      string
+	settingsEnvPrefixFlag string
+	logsStdoutFlag        string
+	hideStartBanner       bool
+	terminateTimeout      time.Duration
 
 	// RootCmd is the main console command
 	RootCmd *cobra.Command
@@ -48,6 +55,13 @@ type Config struct {
 	DefaultDataDir       string // if not set, it will fallback to "./pb_data"
 	DefaultEncryptionEnv string
 
+	// optional default values for the settings overrides console flags
+	DefaultSettingsFile      string
+	DefaultSettingsEnvPrefix string // if not set, it will fallback to "PB_"
+
+	// optional default value of the logs stdout format console flag ("json" or "text")
+	DefaultLogsStdout string
+
 	// hide the default console server info on app startup
 	HideStartBanner bool
 
@@ -56,8 +70,15 @@ type Config struct {
 	DataMaxIdleConns int // default to core.DefaultDataMaxIdleConns
 	LogsMaxOpenConns int // default to core.DefaultLogsMaxOpenConns
 	LogsMaxIdleConns int // default to core.DefaultLogsMaxIdleConns
+
+	// optional max duration to wait for the app.OnTerminate() hooks
+	// to complete on graceful shutdown (default to DefaultTerminateTimeout)
+	TerminateTimeout time.Duration
 }
 
+// DefaultTerminateTimeout is the default [Config.TerminateTimeout].
+const DefaultTerminateTimeout = 1 * time.Minute
+
 // New creates a new PocketBase instance with the default configuration.
 // Use [NewWithConfig()] if you want to provide a custom configuration.
 //
@@ -88,6 +109,14 @@ func NewWithConfig(config Config) *PocketBase {
 		config.DefaultDataDir = filepath.Join(baseDir, "pb_data")
 	}
 
+	if config.DefaultSettingsEnvPrefix == "" {
+		config.DefaultSettingsEnvPrefix = "PB_"
+	}
+
+	if config.TerminateTimeout <= 0 {
+		config.TerminateTimeout = DefaultTerminateTimeout
+	}
+
 	pb := &PocketBase{
 		RootCmd: &cobra.Command{
 			Use:     filepath.Base(os.Args[0]),
@@ -101,10 +130,14 @@ func NewWithConfig(config Config) *PocketBase {
 				DisableDefaultCmd: true,
 			},
 		},
-		devFlag:           config.DefaultDev,
-		dataDirFlag:       config.DefaultDataDir,
-		encryptionEnvFlag: config.DefaultEncryptionEnv,
-		hideStartBanner:   config.HideStartBanner,
+		devFlag:               config.DefaultDev,
+		dataDirFlag:           config.DefaultDataDir,
+		encryptionEnvFlag:     config.DefaultEncryptionEnv,
+		settingsFileFlag:      config.DefaultSettingsFile,
+		settingsEnvPrefixFlag: config.DefaultSettingsEnvPrefix,
+		logsStdoutFlag:        config.DefaultLogsStdout,
+		hideStartBanner:       config.HideStartBanner,
+		terminateTimeout:      config.TerminateTimeout,
 	}
 
 	// replace with a colored stderr writer
@@ -116,13 +149,16 @@ func NewWithConfig(config Config) *PocketBase {
 
 	// initialize the app instance
 	pb.appWrapper = &appWrapper{core.NewBaseApp(core.BaseAppConfig{
-		IsDev:            pb.devFlag,
-		DataDir:          pb.dataDirFlag,
-		EncryptionEnv:    pb.encryptionEnvFlag,
-		DataMaxOpenConns: config.DataMaxOpenConns,
-		DataMaxIdleConns: config.DataMaxIdleConns,
-		LogsMaxOpenConns: config.LogsMaxOpenConns,
-		LogsMaxIdleConns: config.LogsMaxIdleConns,
+		IsDev:             pb.devFlag,
+		DataDir:           pb.dataDirFlag,
+		EncryptionEnv:     pb.encryptionEnvFlag,
+		SettingsFile:      pb.settingsFileFlag,
+		SettingsEnvPrefix: pb.settingsEnvPrefixFlag,
+		LogsStdout:        pb.logsStdoutFlag,
+		DataMaxOpenConns:  config.DataMaxOpenConns,
+		DataMaxIdleConns:  config.DataMaxIdleConns,
+		LogsMaxOpenConns:  config.LogsMaxOpenConns,
+		LogsMaxIdleConns:  config.LogsMaxIdleConns,
 	})}
 
 	// hide the default help command (allow only `--help` flag)
@@ -136,6 +172,18 @@ func NewWithConfig(config Config) *PocketBase {
 func (pb *PocketBase) Start() error {
 	// register system commands
 	pb.RootCmd.AddCommand(cmd.NewAdminCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewDroitsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewLogsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewImportCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewExportCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewRoutesCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewSchemaCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewValidateDataCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewLintRulesCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewBenchCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewRecordsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewCollectionsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewFilesCommand(pb))
 	pb.RootCmd.AddCommand(cmd.NewServeCommand(pb, !pb.hideStartBanner))
 
 	return pb.Execute()
@@ -156,13 +204,9 @@ func (pb *PocketBase) Execute() error {
 	done := make(chan bool, 1)
 
 	// listen for interrupt signal to gracefully shutdown the application
-	go func() {
-		sigch := make(chan os.Signal, 1)
-		signal.Notify(sigch, os.Interrupt, syscall.SIGTERM)
-		<-sigch
-
-		done <- true
-	}()
+	sigch := make(chan os.Signal, 1)
+	signal.Notify(sigch, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigch)
 
 	// execute the root command
 	go func() {
@@ -172,14 +216,30 @@ func (pb *PocketBase) Execute() error {
 		done <- true
 	}()
 
-	<-done
+	select {
+	case <-sigch:
+	case <-done:
+	}
 
 	// trigger cleanups
-	return pb.OnTerminate().Trigger(&core.TerminateEvent{
-		App: pb,
-	}, func(e *core.TerminateEvent) error {
-		return e.App.ResetBootstrapState()
-	})
+	terminated := make(chan error, 1)
+	go func() {
+		terminated <- pb.OnTerminate().Trigger(&core.TerminateEvent{
+			App: pb,
+		}, func(e *core.TerminateEvent) error {
+			return e.App.ResetBootstrapState()
+		})
+	}()
+
+	select {
+	case err := <-terminated:
+		return err
+	case <-sigch:
+		// a second interrupt signal forces the exit
+		return errors.New("forced shutdown before the cleanups have completed")
+	case <-time.After(pb.terminateTimeout):
+		return fmt.Errorf("the cleanups haven't completed in %s", pb.terminateTimeout)
+	}
 }
 
 // eagerParseFlags parses the global app flags before calling pb.RootCmd.Execute().
@@ -199,6 +259,20 @@ func (pb *PocketBase) eagerParseFlags(config *Config) error {
 		"the env variable whose value of 32 characters will be used \nas encryption key for the app settings (default none)",
 	)
 
+	pb.RootCmd.PersistentFlags().StringVar(
+		&pb.settingsFileFlag,
+		"settingsFile",
+		config.DefaultSettingsFile,
+		"optional JSON file with app settings overrides \n(live reloaded on change while serving)",
+	)
+
+	pb.RootCmd.PersistentFlags().StringVar(
+		&pb.settingsEnvPrefixFlag,
+		"settingsEnvPrefix",
+		config.DefaultSettingsEnvPrefix,
+		"the env variables prefix of the app settings overrides \n(eg. PB_SMTP_HOST; set to empty string to disable)",
+	)
+
 	pb.RootCmd.PersistentFlags().BoolVar(
 		&pb.devFlag,
 		"dev",
@@ -206,6 +280,13 @@ func (pb *PocketBase) eagerParseFlags(config *Config) error {
 		"enable dev mode, aka. printing logs and sql statements to the console",
 	)
 
+	pb.RootCmd.PersistentFlags().StringVar(
+		&pb.logsStdoutFlag,
+		"logsStdout",
+		config.DefaultLogsStdout,
+		"print the stored logs to the stdout as \"json\" or \"text\" lines \n(overrides the logs.stdout setting)",
+	)
+
 	return pb.RootCmd.ParseFlags(os.Args[1:])
 }
 
@@ -0,0 +1,89 @@
+// Package quarantine implements the admin review actions of the
+// suspicious record files held in the quarantine storage area
+// (see [core.App.OnRecordFileScan]).
+package quarantine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// Release moves the provided quarantined file to its owning record
+// storage dir (aka. making it publicly available) and completes its review.
+//
+// Returns an error if the owning record is missing or no longer
+// references the file (use [Purge] instead).
+func Release(app core.App, file *models.QuarantinedFile) error {
+	record, err := app.Dao().FindRecordById(file.CollectionId, file.RecordId)
+	if err != nil {
+		return fmt.Errorf("failed to find the owning record: %w", err)
+	}
+
+	if !list.ExistInSlice(file.Name, record.GetStringSlice(file.Field)) {
+		return errors.New("the file is no longer referenced by the owning record")
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	if err := fs.Copy(file.Path(), record.BaseFilesPath()+"/"+file.Name); err != nil {
+		return fmt.Errorf("failed to copy the quarantined file: %w", err)
+	}
+
+	if err := app.Dao().DeleteQuarantinedFile(file); err != nil {
+		return err
+	}
+
+	// the quarantine dir is also deleted by the default model delete
+	// hook but try to remove it immediately to avoid serving stale data
+	fs.DeletePrefix(file.BaseFilesPath())
+
+	return nil
+}
+
+// Purge deletes the provided quarantined file and removes its
+// reference from the owning record file field (if the record still exists).
+func Purge(app core.App, file *models.QuarantinedFile) error {
+	err := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		if err := txDao.DeleteQuarantinedFile(file); err != nil {
+			return err
+		}
+
+		record, err := txDao.FindRecordById(file.CollectionId, file.RecordId)
+		if err != nil {
+			return nil // the record was already deleted
+		}
+
+		names := record.GetStringSlice(file.Field)
+		if !list.ExistInSlice(file.Name, names) {
+			return nil // already removed
+		}
+
+		record.Set(file.Field, list.SubtractSlice(names, []string{file.Name}))
+
+		return txDao.SaveRecord(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	if errs := fs.DeletePrefix(file.BaseFilesPath()); len(errs) > 0 {
+		return fmt.Errorf("failed to delete the quarantined file: %v", errs)
+	}
+
+	return nil
+}
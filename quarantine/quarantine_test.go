@@ -0,0 +1,156 @@
+package quarantine_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/quarantine"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// uploadQuarantinedFile uploads a new quarantined file to the specified demo3 record.
+func uploadQuarantinedFile(t *testing.T, app *tests.TestApp, recordId string) (*models.Record, *models.QuarantinedFile) {
+	app.OnRecordFileScan().Add(func(e *core.RecordFileScanEvent) error {
+		e.Quarantine = true
+		return nil
+	})
+
+	record, err := app.Dao().FindRecordById("demo3", recordId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, _ := filesystem.NewFileFromBytes([]byte("test"), "test.txt")
+
+	form := forms.NewRecordUpsert(app, record)
+	form.AddFiles("files", file)
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	quarantined, err := app.Dao().FindQuarantinedFilesByRecord(record.Collection().Id, record.Id)
+	if err != nil || len(quarantined) != 1 {
+		t.Fatalf("Expected 1 quarantined file, got %v (%v)", quarantined, err)
+	}
+
+	return record, quarantined[0]
+}
+
+func TestRelease(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, file := uploadQuarantinedFile(t, app, "1tmknxy2868d869")
+
+	if err := quarantine.Release(app, file); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Dao().IsRecordQuarantined(record.Collection().Id, record.Id) {
+		t.Fatal("Expected the record to not be quarantined")
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if exists, _ := fs.Exists(record.BaseFilesPath() + "/" + file.Name); !exists {
+		t.Fatal("Expected the file to be released in the record storage dir")
+	}
+
+	if exists, _ := fs.Exists(file.Path()); exists {
+		t.Fatal("Expected the quarantined file to be deleted")
+	}
+}
+
+func TestReleaseUnreferencedFile(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, file := uploadQuarantinedFile(t, app, "1tmknxy2868d869")
+
+	record.Set("files", []string{})
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := quarantine.Release(app, file); err == nil {
+		t.Fatal("Expected error for unreferenced file")
+	}
+
+	if !app.Dao().IsRecordQuarantined(record.Collection().Id, record.Id) {
+		t.Fatal("Expected the record to remain quarantined")
+	}
+}
+
+func TestPurge(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, file := uploadQuarantinedFile(t, app, "7nwo8tuiatetxdm")
+
+	if err := quarantine.Purge(app, file); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.Dao().IsRecordQuarantined(record.Collection().Id, record.Id) {
+		t.Fatal("Expected the record to not be quarantined")
+	}
+
+	updated, err := app.Dao().FindRecordById("demo3", record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := updated.GetStringSlice("files")
+	if list.ExistInSlice(file.Name, files) {
+		t.Fatalf("Expected %q to be removed from the record files %v", file.Name, files)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected the other record files to remain, got %v", files)
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if exists, _ := fs.Exists(file.Path()); exists {
+		t.Fatal("Expected the quarantined file to be deleted")
+	}
+}
+
+func TestPurgeWithDeletedRecord(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, file := uploadQuarantinedFile(t, app, "1tmknxy2868d869")
+
+	if _, err := app.Dao().DB().Delete(record.TableName(), dbx.HashExp{"id": record.Id}).Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := quarantine.Purge(app, file); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindQuarantinedFileById(file.Id); err == nil {
+		t.Fatal("Expected the quarantined file to be deleted")
+	}
+}
@@ -478,8 +478,15 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 
 		field := collection.Schema.GetFieldByName(prop)
 
-		// json field -> treat the rest of the props as json path
-		if field != nil && field.Type == schema.FieldTypeJson {
+		// geo point field -> allow only its coordinates as json path
+		if field != nil && field.Type == schema.FieldTypeGeoPoint {
+			if len(r.activeProps) != i+2 || (r.activeProps[i+1] != "lat" && r.activeProps[i+1] != "lng") {
+				return nil, fmt.Errorf("invalid geo point field path %q", r.fieldName)
+			}
+		}
+
+		// json or geo point field -> treat the rest of the props as json path
+		if field != nil && (field.Type == schema.FieldTypeJson || field.Type == schema.FieldTypeGeoPoint) {
 			var jsonPath strings.Builder
 			for j, p := range r.activeProps[i+1:] {
 				if _, err := strconv.Atoi(p); err == nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
@@ -184,6 +185,20 @@ func (r *RecordFieldResolver) resolveStaticRequestField(path ...string) (*search
 	// note: we are ignoring the error because requestInfo is dynamic
 	// and some of the lookup keys may not be defined for the request
 
+	// normalize the money field values to their stored minor units amount
+	// (eg. "12.34" or {"amount":1234} -> 1234)
+	if resultVal != nil {
+		field := r.baseCollection.Schema.GetFieldByName(path[len(path)-1])
+		if field != nil && field.Type == schema.FieldTypeMoney {
+			options, _ := field.Options.(*schema.MoneyOptions)
+			if options != nil {
+				if m, err := types.ParseMoney(resultVal, options.Currency); err == nil {
+					resultVal = m.Amount
+				}
+			}
+		}
+	}
+
 	switch v := resultVal.(type) {
 	case nil:
 		return &search.ResolverResult{Identifier: "NULL"}, nil
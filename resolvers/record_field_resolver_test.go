@@ -534,6 +534,13 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// in-memory only money field
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "price",
+		Type:    schema.FieldTypeMoney,
+		Options: &schema.MoneyOptions{Currency: "USD"},
+	})
+
 	authRecord, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
 	if err != nil {
 		t.Fatal(err)
@@ -548,6 +555,7 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		Data: map[string]any{
 			"number":         "10",
 			"number_unknown": "20",
+			"price":          "12.34",
 			"b":              456,
 			"c":              map[string]int{"sub": 1},
 		},
@@ -581,6 +589,7 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		{"@request.data.b", false, `456`},
 		{"@request.data.number", false, `10`},           // number field normalization
 		{"@request.data.number_unknown", false, `"20"`}, // no numeric normalizations for unknown fields
+		{"@request.data.price", false, `1234`},          // money field normalization
 		{"@request.data.b.missing", false, ``},
 		{"@request.data.c", false, `"{\"sub\":1}"`},
 		{"@request.auth", true, ""},
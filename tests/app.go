@@ -2,6 +2,8 @@
 package tests
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path"
@@ -13,8 +15,11 @@ import (
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/migrations"
 	"github.com/pocketbase/pocketbase/migrations/logs"
+	"github.com/pocketbase/pocketbase/tools/captcha"
 	"github.com/pocketbase/pocketbase/tools/mailer"
 	"github.com/pocketbase/pocketbase/tools/migrate"
+	"github.com/pocketbase/pocketbase/tools/push"
+	"github.com/pocketbase/pocketbase/tools/sms"
 )
 
 // TestApp is a wrapper app instance used for testing.
@@ -33,6 +38,12 @@ type TestApp struct {
 	EventCalls map[string]int
 
 	TestMailer *TestMailer
+
+	TestSmsClient *TestSmsClient
+
+	TestPushClient *TestPushClient
+
+	TestCaptchaVerifier *TestCaptchaVerifier
 }
 
 // Cleanup resets the test application state and removes the test
@@ -42,6 +53,9 @@ type TestApp struct {
 func (t *TestApp) Cleanup() {
 	t.OnTerminate().Trigger(&core.TerminateEvent{App: t}, func(e *core.TerminateEvent) error {
 		t.TestMailer.Reset()
+		t.TestSmsClient.Reset()
+		t.TestPushClient.Reset()
+		t.TestCaptchaVerifier.Reset()
 		t.ResetEventCalls()
 		t.ResetBootstrapState()
 
@@ -65,6 +79,64 @@ func (t *TestApp) NewMailClient() mailer.Mailer {
 	return t.TestMailer
 }
 
+// NewSmsClient initializes (if not already) a test app SMS client.
+//
+// Similar to the default app implementation, it returns an error
+// if the SMS provider is not enabled in the app settings.
+func (t *TestApp) NewSmsClient() (sms.Client, error) {
+	if !t.Settings().Sms.Enabled {
+		return nil, errors.New("the SMS provider is not enabled")
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.TestSmsClient == nil {
+		t.TestSmsClient = &TestSmsClient{}
+	}
+
+	return t.TestSmsClient, nil
+}
+
+// NewPushClient initializes (if not already) a test app push client
+// (the same client is shared between all providers).
+//
+// Similar to the default app implementation, it returns an error
+// if the provider is not enabled in the app settings.
+func (t *TestApp) NewPushClient(provider string) (push.Client, error) {
+	if !t.Settings().Push.IsEnabled(provider) {
+		return nil, fmt.Errorf("the %q push provider is not enabled", provider)
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.TestPushClient == nil {
+		t.TestPushClient = &TestPushClient{}
+	}
+
+	return t.TestPushClient, nil
+}
+
+// NewCaptchaVerifier initializes (if not already) a test app captcha verifier.
+//
+// Similar to the default app implementation, it returns an error
+// if the captcha provider is not enabled in the app settings.
+func (t *TestApp) NewCaptchaVerifier() (captcha.Verifier, error) {
+	if !t.Settings().Captcha.Enabled {
+		return nil, errors.New("the captcha provider is not enabled")
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.TestCaptchaVerifier == nil {
+		t.TestCaptchaVerifier = &TestCaptchaVerifier{}
+	}
+
+	return t.TestCaptchaVerifier, nil
+}
+
 // ResetEventCalls resets the EventCalls counter.
 func (t *TestApp) ResetEventCalls() {
 	t.mux.Lock()
@@ -133,9 +205,12 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 	app.Settings().Logs.MaxDays = 0
 
 	t := &TestApp{
-		BaseApp:    app,
-		EventCalls: make(map[string]int),
-		TestMailer: &TestMailer{},
+		BaseApp:             app,
+		EventCalls:          make(map[string]int),
+		TestMailer:          &TestMailer{},
+		TestSmsClient:       &TestSmsClient{},
+		TestPushClient:      &TestPushClient{},
+		TestCaptchaVerifier: &TestCaptchaVerifier{},
 	}
 
 	t.OnBeforeApiError().Add(func(e *core.ApiErrorEvent) error {
@@ -146,6 +221,18 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnAfterApiError")
 	})
 
+	t.OnPreflight().Add(func(e *core.PreflightEvent) error {
+		return t.registerEventCall("OnPreflight")
+	})
+
+	t.OnBackupCreate().Add(func(e *core.BackupEvent) error {
+		return t.registerEventCall("OnBackupCreate")
+	})
+
+	t.OnBackupRestore().Add(func(e *core.BackupEvent) error {
+		return t.registerEventCall("OnBackupRestore")
+	})
+
 	t.OnModelBeforeCreate().Add(func(e *core.ModelEvent) error {
 		return t.registerEventCall("OnModelBeforeCreate")
 	})
@@ -202,6 +289,10 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnRecordAfterDeleteRequest")
 	})
 
+	t.OnSyncConflict().Add(func(e *core.SyncConflictEvent) error {
+		return t.registerEventCall("OnSyncConflict")
+	})
+
 	t.OnRecordAuthRequest().Add(func(e *core.RecordAuthEvent) error {
 		return t.registerEventCall("OnRecordAuthRequest")
 	})
@@ -222,6 +313,10 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnRecordAfterAuthWithOAuth2Request")
 	})
 
+	t.OnRecordAuthWithCustomMethod().Add(func(e *core.RecordAuthWithCustomMethodEvent) error {
+		return t.registerEventCall("OnRecordAuthWithCustomMethod")
+	})
+
 	t.OnRecordBeforeAuthRefreshRequest().Add(func(e *core.RecordAuthRefreshEvent) error {
 		return t.registerEventCall("OnRecordBeforeAuthRefreshRequest")
 	})
@@ -238,6 +333,22 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnRecordAfterRequestPasswordResetRequest")
 	})
 
+	t.OnRecordBeforeRequestOTPRequest().Add(func(e *core.RecordRequestOTPEvent) error {
+		return t.registerEventCall("OnRecordBeforeRequestOTPRequest")
+	})
+
+	t.OnRecordAfterRequestOTPRequest().Add(func(e *core.RecordRequestOTPEvent) error {
+		return t.registerEventCall("OnRecordAfterRequestOTPRequest")
+	})
+
+	t.OnRecordBeforeConfirmOTPRequest().Add(func(e *core.RecordConfirmOTPEvent) error {
+		return t.registerEventCall("OnRecordBeforeConfirmOTPRequest")
+	})
+
+	t.OnRecordAfterConfirmOTPRequest().Add(func(e *core.RecordConfirmOTPEvent) error {
+		return t.registerEventCall("OnRecordAfterConfirmOTPRequest")
+	})
+
 	t.OnRecordBeforeConfirmPasswordResetRequest().Add(func(e *core.RecordConfirmPasswordResetEvent) error {
 		return t.registerEventCall("OnRecordBeforeConfirmPasswordResetRequest")
 	})
@@ -466,6 +577,22 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnAdminAfterConfirmPasswordResetRequest")
 	})
 
+	t.OnAdminUIConfigRequest().Add(func(e *core.AdminUIConfigEvent) error {
+		return t.registerEventCall("OnAdminUIConfigRequest")
+	})
+
+	t.OnSessionCreate().Add(func(e *core.SessionEvent) error {
+		return t.registerEventCall("OnSessionCreate")
+	})
+
+	t.OnSessionRevoke().Add(func(e *core.SessionEvent) error {
+		return t.registerEventCall("OnSessionRevoke")
+	})
+
+	t.OnSignatureComplete().Add(func(e *core.SignatureCompleteEvent) error {
+		return t.registerEventCall("OnSignatureComplete")
+	})
+
 	t.OnFileDownloadRequest().Add(func(e *core.FileDownloadEvent) error {
 		return t.registerEventCall("OnFileDownloadRequest")
 	})
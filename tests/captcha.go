@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/captcha"
+)
+
+// TestCaptchaValidToken is the captcha response token
+// accepted by the [TestCaptchaVerifier].
+const TestCaptchaValidToken = "test_captcha_token"
+
+var _ captcha.Verifier = (*TestCaptchaVerifier)(nil)
+
+// TestCaptchaVerifier is a mock `captcha.Verifier` implementation
+// that accepts only the [TestCaptchaValidToken] response token.
+type TestCaptchaVerifier struct {
+	mux sync.Mutex
+
+	// VerifiedTokens is the list of all tokens passed to the Verify method.
+	VerifiedTokens []string
+}
+
+// Reset clears any previously test collected data.
+func (tv *TestCaptchaVerifier) Reset() {
+	tv.mux.Lock()
+	defer tv.mux.Unlock()
+
+	tv.VerifiedTokens = nil
+}
+
+// TotalVerified returns the total number of verified tokens (valid and invalid).
+func (tv *TestCaptchaVerifier) TotalVerified() int {
+	tv.mux.Lock()
+	defer tv.mux.Unlock()
+
+	return len(tv.VerifiedTokens)
+}
+
+// Verify implements `captcha.Verifier` interface.
+func (tv *TestCaptchaVerifier) Verify(token string, remoteIp string) error {
+	tv.mux.Lock()
+	defer tv.mux.Unlock()
+
+	tv.VerifiedTokens = append(tv.VerifiedTokens, token)
+
+	if token != TestCaptchaValidToken {
+		return captcha.ErrInvalidResponse
+	}
+
+	return nil
+}
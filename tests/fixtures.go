@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// TestRefreshTokenSecret is the refresh token secret set by [CreateRefreshToken].
+const TestRefreshTokenSecret = "test_secret"
+
+// SaveModel persists the provided model in the test app db
+// (without triggering the model hooks) and fails the test on error.
+//
+// If id is not empty, the model is saved as new with the provided id.
+func SaveModel[T models.Model](t testing.TB, app *TestApp, id string, model T) T {
+	if id != "" {
+		model.MarkAsNew()
+		model.SetId(id)
+	}
+
+	if err := app.Dao().WithoutHooks().Save(model); err != nil {
+		t.Fatal(err)
+	}
+
+	return model
+}
+
+// CreateSession persists a new auth session with the specified user agent
+// (if id is empty a random one is generated).
+//
+// An empty collectionId means an admin session.
+func CreateSession(t testing.TB, app *TestApp, id string, collectionId string, recordId string, userAgent string) *models.Session {
+	return SaveModel(t, app, id, &models.Session{
+		CollectionId: collectionId,
+		RecordId:     recordId,
+		UserAgent:    userAgent,
+		LastSeen:     types.NowDateTime(),
+	})
+}
+
+// CreateRefreshToken persists a new refresh token with a known plain token value
+// (the related refresh token secret setting is replaced with [TestRefreshTokenSecret]).
+//
+// An empty collectionId means an admin refresh token.
+func CreateRefreshToken(t testing.TB, app *TestApp, collectionId string, recordId string, token string, revoked bool) *models.RefreshToken {
+	if collectionId == "" {
+		app.Settings().AdminRefreshToken.Secret = TestRefreshTokenSecret
+	} else {
+		app.Settings().RecordRefreshToken.Secret = TestRefreshTokenSecret
+	}
+
+	model := &models.RefreshToken{
+		CollectionId: collectionId,
+		RecordId:     recordId,
+		Family:       security.RandomString(15),
+		Hash:         security.HS256(token, TestRefreshTokenSecret),
+		Revoked:      revoked,
+	}
+	model.Expires, _ = types.ParseDateTime(time.Now().Add(1 * time.Hour))
+
+	return SaveModel(t, app, "", model)
+}
+
+// CreatePushDevice persists a new push device of the specified auth record
+// (if id is empty a random one is generated).
+func CreatePushDevice(t testing.TB, app *TestApp, id string, collectionId string, recordId string, provider string, token string) *models.PushDevice {
+	return SaveModel(t, app, id, &models.PushDevice{
+		CollectionId: collectionId,
+		RecordId:     recordId,
+		Provider:     provider,
+		Token:        token,
+	})
+}
+
+// CreateOTP persists a new one-time password of the specified auth record.
+func CreateOTP(t testing.TB, app *TestApp, collectionId string, recordId string, sentTo string, password string, expires time.Time) *models.OTP {
+	model := &models.OTP{
+		CollectionId: collectionId,
+		RecordId:     recordId,
+		SentTo:       sentTo,
+	}
+	model.SetPassword(password)
+	model.Expires, _ = types.ParseDateTime(expires)
+
+	return SaveModel(t, app, "", model)
+}
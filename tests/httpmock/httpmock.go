@@ -0,0 +1,77 @@
+// Package httpmock implements a minimal mock http server for testing
+// the external API clients (mailer, sms, push, captcha, etc.).
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// CapturedRequest holds the details of the last request received by a mock server.
+type CapturedRequest struct {
+	// Hits is the total number of the received requests.
+	Hits int
+
+	Method string
+	Host   string
+	Path   string
+	Query  string
+	Header http.Header
+
+	// Body is the raw request body (empty for form requests).
+	Body []byte
+
+	// Form is the parsed urlencoded or multipart/form-data request body.
+	//
+	// The multipart files are stored as "file:{name}" keys
+	// with the uploaded file name as value.
+	Form url.Values
+}
+
+// NewServer starts a new mock http server that responds to every
+// request with the provided status and response body.
+//
+// The server is closed automatically at the end of the test.
+func NewServer(t testing.TB, status int, response string) (*httptest.Server, *CapturedRequest) {
+	captured := &CapturedRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured.Hits++
+		captured.Method = r.Method
+		captured.Host = r.Host
+		captured.Path = r.URL.Path
+		captured.Query = r.URL.RawQuery
+		captured.Header = r.Header.Clone()
+
+		contentType := r.Header.Get("Content-Type")
+
+		switch {
+		case strings.HasPrefix(contentType, "multipart/form-data"):
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Error(err)
+			}
+			captured.Form = r.MultipartForm.Value
+			for name, files := range r.MultipartForm.File {
+				captured.Form["file:"+name] = []string{files[0].Filename}
+			}
+		case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+			if err := r.ParseForm(); err != nil {
+				t.Error(err)
+			}
+			captured.Form = r.PostForm
+		default:
+			captured.Body, _ = io.ReadAll(r.Body)
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(response))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, captured
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/push"
+)
+
+var _ push.Client = (*TestPushClient)(nil)
+
+// TestPushMessage defines a single push notification sent with [TestPushClient].
+type TestPushMessage struct {
+	Token   string
+	Message push.Message
+}
+
+// TestPushClient is a mock `push.Client` implementation.
+type TestPushClient struct {
+	mux sync.Mutex
+
+	SentMessages []TestPushMessage
+
+	// InvalidTokens is an optional list with device tokens for which
+	// the Send method will return [push.ErrInvalidToken].
+	InvalidTokens []string
+}
+
+// Reset clears any previously test collected data.
+func (tc *TestPushClient) Reset() {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	tc.SentMessages = nil
+	tc.InvalidTokens = nil
+}
+
+// TotalSend returns the total number of sent messages.
+func (tc *TestPushClient) TotalSend() int {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	return len(tc.SentMessages)
+}
+
+// LastMessage returns the last sent message (if any).
+func (tc *TestPushClient) LastMessage() TestPushMessage {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	if len(tc.SentMessages) == 0 {
+		return TestPushMessage{}
+	}
+
+	return tc.SentMessages[len(tc.SentMessages)-1]
+}
+
+// Send implements `push.Client` interface.
+func (tc *TestPushClient) Send(token string, m *push.Message) error {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	for _, invalid := range tc.InvalidTokens {
+		if invalid == token {
+			return push.ErrInvalidToken
+		}
+	}
+
+	tc.SentMessages = append(tc.SentMessages, TestPushMessage{Token: token, Message: *m})
+
+	return nil
+}
@@ -0,0 +1,208 @@
+// Package redismock implements a minimal in-memory Redis (RESP) server
+// for testing the Redis based clients (cache, realtime cluster, etc.).
+package redismock
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server is a minimal in-memory Redis server.
+//
+// It supports only the AUTH, SELECT, PING, GET, SET, INCR,
+// SUBSCRIBE and PUBLISH commands.
+type Server struct {
+	listener net.Listener
+	password string
+
+	mux      sync.Mutex
+	data     map[string]string
+	conns    map[net.Conn]string // conn -> subscribed channel
+	commands []string
+}
+
+// NewServer starts a new mock Redis server that requires
+// the provided password (if not empty).
+//
+// The server is closed automatically at the end of the test.
+func NewServer(t testing.TB, password string) *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		listener: l,
+		password: password,
+		data:     map[string]string{},
+		conns:    map[net.Conn]string{},
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.handle(conn)
+		}
+	}()
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Addr returns the server "host:port" address.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// URL returns the server connection url (including the password, if any).
+func (s *Server) URL() string {
+	if s.password != "" {
+		return "redis://:" + s.password + "@" + s.Addr()
+	}
+
+	return "redis://" + s.Addr()
+}
+
+// Commands returns a copy of all received commands
+// (each command is represented as its space separated arguments).
+func (s *Server) Commands() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return append([]string{}, s.commands...)
+}
+
+// DropConnections closes all active client connections
+// (the server continues to accept new ones).
+func (s *Server) DropConnections() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// Close stops the server and closes all active client connections.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.DropConnections()
+}
+
+func (s *Server) handle(conn net.Conn) {
+	s.mux.Lock()
+	s.conns[conn] = ""
+	s.mux.Unlock()
+
+	defer func() {
+		s.mux.Lock()
+		delete(s.conns, conn)
+		s.mux.Unlock()
+		conn.Close()
+	}()
+
+	authorized := s.password == ""
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil || len(args) == 0 {
+			return
+		}
+
+		s.mux.Lock()
+
+		s.commands = append(s.commands, strings.Join(args, " "))
+
+		var reply string
+		switch name := strings.ToUpper(args[0]); {
+		case name == "AUTH":
+			if args[len(args)-1] == s.password {
+				authorized = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authorized:
+			reply = "-NOAUTH Authentication required\r\n"
+		case name == "SELECT":
+			reply = "+OK\r\n"
+		case name == "PING":
+			reply = "+PONG\r\n"
+		case name == "SET" && len(args) >= 3:
+			s.data[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case name == "GET" && len(args) == 2:
+			if v, ok := s.data[args[1]]; ok {
+				reply = bulk(v)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case name == "INCR" && len(args) == 2:
+			n, _ := strconv.Atoi(s.data[args[1]])
+			n++
+			s.data[args[1]] = strconv.Itoa(n)
+			reply = ":" + strconv.Itoa(n) + "\r\n"
+		case name == "SUBSCRIBE" && len(args) == 2:
+			s.conns[conn] = args[1]
+			reply = "*3\r\n" + bulk("subscribe") + bulk(args[1]) + ":1\r\n"
+		case name == "PUBLISH" && len(args) == 3:
+			var total int
+			for other, channel := range s.conns {
+				if channel != args[1] {
+					continue
+				}
+				total++
+				other.Write([]byte("*3\r\n" + bulk("message") + bulk(args[1]) + bulk(args[2])))
+			}
+			reply = ":" + strconv.Itoa(total) + "\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+
+		conn.Write([]byte(reply))
+
+		s.mux.Unlock()
+	}
+}
+
+func bulk(v string) string {
+	return "$" + strconv.Itoa(len(v)) + "\r\n" + v + "\r\n"
+}
+
+// readCommand reads a single RESP array command from the reader.
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	total, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+
+	args := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+
+		args = append(args, string(arg[:size]))
+	}
+
+	return args, nil
+}
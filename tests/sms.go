@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/sms"
+)
+
+var _ sms.Client = (*TestSmsClient)(nil)
+
+// TestSmsClient is a mock `sms.Client` implementation.
+type TestSmsClient struct {
+	mux sync.Mutex
+
+	SentMessages []sms.Message
+}
+
+// Reset clears any previously test collected data.
+func (tc *TestSmsClient) Reset() {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	tc.SentMessages = nil
+}
+
+// TotalSend returns the total number of sent messages.
+func (tc *TestSmsClient) TotalSend() int {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	return len(tc.SentMessages)
+}
+
+// LastMessage returns the last sent message (if any).
+func (tc *TestSmsClient) LastMessage() sms.Message {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	if len(tc.SentMessages) == 0 {
+		return sms.Message{}
+	}
+
+	return tc.SentMessages[len(tc.SentMessages)-1]
+}
+
+// Send implements `sms.Client` interface.
+func (tc *TestSmsClient) Send(m *sms.Message) error {
+	tc.mux.Lock()
+	defer tc.mux.Unlock()
+
+	tc.SentMessages = append(tc.SentMessages, *m)
+
+	return nil
+}
@@ -16,6 +16,18 @@ func NewAdminAuthToken(app core.App, admin *models.Admin) (string, error) {
 	)
 }
 
+// NewAdminSessionAuthToken generates and returns a new admin authentication
+// token bound to the provided auth session.
+//
+// The token is no longer accepted once the session is revoked.
+func NewAdminSessionAuthToken(app core.App, admin *models.Admin, session *models.Session) (string, error) {
+	return security.NewJWT(
+		jwt.MapClaims{"id": admin.Id, "type": TypeAdmin, ClaimSessionId: session.Id},
+		(admin.TokenKey + app.Settings().AdminAuthToken.Secret),
+		app.Settings().AdminAuthToken.Duration,
+	)
+}
+
 // NewAdminResetPasswordToken generates and returns a new admin password reset request token.
 func NewAdminResetPasswordToken(app core.App, admin *models.Admin) (string, error) {
 	return security.NewJWT(
@@ -3,8 +3,10 @@ package tokens_test
 import (
 	"testing"
 
+	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 func TestNewAdminAuthToken(t *testing.T) {
@@ -32,6 +34,39 @@ func TestNewAdminAuthToken(t *testing.T) {
 	}
 }
 
+func TestNewAdminSessionAuthToken(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session := &models.Session{RecordId: admin.Id}
+	session.Id = "test_session"
+
+	token, err := tokens.NewAdminSessionAuthToken(app, admin, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenAdmin, _ := app.Dao().FindAdminByToken(
+		token,
+		app.Settings().AdminAuthToken.Secret,
+	)
+	if tokenAdmin == nil || tokenAdmin.Id != admin.Id {
+		t.Fatalf("Expected admin %v, got %v", admin, tokenAdmin)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if claims[tokens.ClaimSessionId] != session.Id {
+		t.Fatalf("Expected session claim %q, got %v", session.Id, claims[tokens.ClaimSessionId])
+	}
+}
+
 func TestNewAdminResetPasswordToken(t *testing.T) {
 	t.Parallel()
 
@@ -26,6 +26,53 @@ func NewRecordAuthToken(app core.App, record *models.Record) (string, error) {
 	)
 }
 
+// NewRecordSessionAuthToken generates and returns a new auth record
+// authentication token bound to the provided auth session.
+//
+// The token is no longer accepted once the session is revoked.
+func NewRecordSessionAuthToken(app core.App, record *models.Record, session *models.Session) (string, error) {
+	if !record.Collection().IsAuth() {
+		return "", errors.New("The record is not from an auth collection.")
+	}
+
+	return security.NewJWT(
+		jwt.MapClaims{
+			"id":           record.Id,
+			"type":         TypeAuthRecord,
+			"collectionId": record.Collection().Id,
+			ClaimSessionId: session.Id,
+		},
+		(record.TokenKey() + app.Settings().RecordAuthToken.Secret),
+		app.Settings().RecordAuthToken.Duration,
+	)
+}
+
+// NewRecordImpersonationToken generates and returns a new auth record
+// authentication token with the [ClaimImpersonated] claim that is valid
+// for the specified duration (in seconds).
+//
+// The impersonation tokens cannot be refreshed.
+func NewRecordImpersonationToken(app core.App, record *models.Record, duration int64) (string, error) {
+	if !record.Collection().IsAuth() {
+		return "", errors.New("The record is not from an auth collection.")
+	}
+
+	if duration <= 0 {
+		return "", errors.New("The impersonation token duration must be positive.")
+	}
+
+	return security.NewJWT(
+		jwt.MapClaims{
+			"id":              record.Id,
+			"type":            TypeAuthRecord,
+			"collectionId":    record.Collection().Id,
+			ClaimImpersonated: true,
+		},
+		(record.TokenKey() + app.Settings().RecordAuthToken.Secret),
+		duration,
+	)
+}
+
 // NewRecordVerifyToken generates and returns a new record verification token.
 func NewRecordVerifyToken(app core.App, record *models.Record) (string, error) {
 	if !record.Collection().IsAuth() {
@@ -2,9 +2,12 @@ package tokens_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 func TestNewRecordAuthToken(t *testing.T) {
@@ -32,6 +35,39 @@ func TestNewRecordAuthToken(t *testing.T) {
 	}
 }
 
+func TestNewRecordSessionAuthToken(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session := &models.Session{CollectionId: user.Collection().Id, RecordId: user.Id}
+	session.Id = "test_session"
+
+	token, err := tokens.NewRecordSessionAuthToken(app, user, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRecord, _ := app.Dao().FindAuthRecordByToken(
+		token,
+		app.Settings().RecordAuthToken.Secret,
+	)
+	if tokenRecord == nil || tokenRecord.Id != user.Id {
+		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if claims[tokens.ClaimSessionId] != session.Id {
+		t.Fatalf("Expected session claim %q, got %v", session.Id, claims[tokens.ClaimSessionId])
+	}
+}
+
 func TestNewRecordVerifyToken(t *testing.T) {
 	t.Parallel()
 
@@ -131,3 +167,42 @@ func TestNewRecordFileToken(t *testing.T) {
 		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
 	}
 }
+
+func TestNewRecordImpersonationToken(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tokens.NewRecordImpersonationToken(app, user, 0); err == nil {
+		t.Fatal("Expected error for zero duration")
+	}
+
+	token, err := tokens.NewRecordImpersonationToken(app, user, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRecord, _ := app.Dao().FindAuthRecordByToken(
+		token,
+		app.Settings().RecordAuthToken.Secret,
+	)
+	if tokenRecord == nil || tokenRecord.Id != user.Id {
+		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if claims[tokens.ClaimImpersonated] != true {
+		t.Fatalf("Expected the %q claim to be true, got %v", tokens.ClaimImpersonated, claims[tokens.ClaimImpersonated])
+	}
+
+	exp := int64(claims["exp"].(float64))
+	if remaining := exp - time.Now().Unix(); remaining <= 0 || remaining > 60 {
+		t.Fatalf("Expected the token to expire in up to 60 seconds, got %d", remaining)
+	}
+}
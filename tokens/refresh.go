@@ -0,0 +1,174 @@
+package tokens
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
+)
+
+// refreshTokenLength is the length of the plain opaque refresh token.
+const refreshTokenLength = 50
+
+var (
+	// ErrInvalidRefreshToken is returned when the refresh token is
+	// missing, expired or issued for a different owner.
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
+	// ErrRefreshTokenReused is returned when an already rotated (revoked)
+	// refresh token is used again.
+	//
+	// In this case the entire token family is revoked as a precaution.
+	ErrRefreshTokenReused = errors.New("the refresh token has already been used")
+)
+
+// NewAdminRefreshToken generates, persists and returns a new admin refresh token.
+func NewAdminRefreshToken(app core.App, admin *models.Admin) (string, error) {
+	return newRefreshToken(app, "", admin.Id, "")
+}
+
+// NewRecordRefreshToken generates, persists and returns a new auth record refresh token.
+func NewRecordRefreshToken(app core.App, record *models.Record) (string, error) {
+	if !record.Collection().IsAuth() {
+		return "", errors.New("The record is not from an auth collection.")
+	}
+
+	return newRefreshToken(app, record.Collection().Id, record.Id, "")
+}
+
+// NewSessionRefreshToken generates, persists and returns a new refresh token
+// for the provided auth session (the session id is used as token family).
+func NewSessionRefreshToken(app core.App, session *models.Session) (string, error) {
+	return newRefreshToken(app, session.CollectionId, session.RecordId, session.Id)
+}
+
+// FindAdminRefreshToken returns the active stored model of the provided plain admin refresh token.
+func FindAdminRefreshToken(app core.App, token string) (*models.RefreshToken, error) {
+	return findRefreshToken(app, "", token)
+}
+
+// FindRecordRefreshToken returns the active stored model of the provided
+// plain refresh token issued for an auth record from the specified collection.
+func FindRecordRefreshToken(app core.App, collection *models.Collection, token string) (*models.RefreshToken, error) {
+	return findRefreshToken(app, collection.Id, token)
+}
+
+// RotateRefreshToken revokes the provided refresh token model and
+// returns a new plain refresh token from the same family.
+//
+// Returns ErrRefreshTokenReused if the provided token was already rotated.
+func RotateRefreshToken(app core.App, model *models.RefreshToken) (string, error) {
+	ok, err := app.Dao().RevokeRefreshToken(model)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		if err := revokeRefreshTokenFamily(app, model.Family); err != nil {
+			return "", err
+		}
+		return "", ErrRefreshTokenReused
+	}
+
+	return newRefreshToken(app, model.CollectionId, model.RecordId, model.Family)
+}
+
+func refreshTokenConfig(app core.App, collectionId string) (secret string, duration int64) {
+	if collectionId == "" {
+		return app.Settings().AdminRefreshToken.Secret, app.Settings().AdminRefreshToken.Duration
+	}
+
+	return app.Settings().RecordRefreshToken.Secret, app.Settings().RecordRefreshToken.Duration
+}
+
+func newRefreshToken(app core.App, collectionId string, recordId string, family string) (string, error) {
+	secret, duration := refreshTokenConfig(app, collectionId)
+
+	token := security.RandomString(refreshTokenLength)
+
+	model := &models.RefreshToken{
+		CollectionId: collectionId,
+		RecordId:     recordId,
+		Family:       family,
+		Hash:         security.HS256(token, secret),
+	}
+	model.RefreshId()
+
+	if model.Family == "" {
+		model.Family = model.Id
+	}
+
+	expires := time.Now().Add(time.Duration(duration) * time.Second)
+	if err := model.Expires.Scan(expires); err != nil {
+		return "", err
+	}
+
+	// the refresh tokens are internal bookkeeping records
+	// and are not expected to trigger the OnModel* hooks
+	if err := app.Dao().WithoutHooks().SaveRefreshToken(model); err != nil {
+		return "", err
+	}
+
+	deleteExpiredRefreshTokens(app)
+
+	return token, nil
+}
+
+func findRefreshToken(app core.App, collectionId string, token string) (*models.RefreshToken, error) {
+	if token == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	secret, _ := refreshTokenConfig(app, collectionId)
+
+	model, err := app.Dao().FindRefreshTokenByHash(security.HS256(token, secret))
+	if err != nil || model.CollectionId != collectionId || model.IsExpired() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if model.Revoked {
+		if err := revokeRefreshTokenFamily(app, model.Family); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	return model, nil
+}
+
+// revokeRefreshTokenFamily revokes all refresh tokens from the specified
+// family together with their auth session (if any).
+func revokeRefreshTokenFamily(app core.App, family string) error {
+	session, _ := app.Dao().FindSessionById(family)
+	if session == nil {
+		return app.Dao().RevokeRefreshTokenFamily(family)
+	}
+
+	event := new(core.SessionEvent)
+	event.Session = session
+
+	return app.OnSessionRevoke().Trigger(event, func(e *core.SessionEvent) error {
+		return app.Dao().WithoutHooks().DeleteSession(e.Session)
+	})
+}
+
+// deleteExpiredRefreshTokens removes the expired refresh tokens
+// at most once per day.
+func deleteExpiredRefreshTokens(app core.App) {
+	now := time.Now()
+
+	lastDeletedAt := cast.ToTime(app.Store().Get("lastRefreshTokensDeletedAt"))
+	if now.Sub(lastDeletedAt) < 24*time.Hour {
+		return
+	}
+
+	if err := app.Dao().DeleteExpiredRefreshTokens(now); err != nil {
+		app.Logger().Warn("Failed to delete expired refresh tokens", "error", err)
+		return
+	}
+
+	app.Store().Set("lastRefreshTokensDeletedAt", now)
+}
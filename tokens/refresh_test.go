@@ -0,0 +1,103 @@
+package tokens_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tokens"
+)
+
+func TestAdminRefreshTokenRotation(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.NewAdminRefreshToken(app, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model, err := tokens.FindAdminRefreshToken(app, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !model.IsAdmin() || model.RecordId != admin.Id || model.Family != model.Id {
+		t.Fatalf("Unexpected refresh token model %v", model)
+	}
+
+	newToken, err := tokens.RotateRefreshToken(app, model)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newModel, err := tokens.FindAdminRefreshToken(app, newToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newModel.Family != model.Family {
+		t.Fatalf("Expected family %q, got %q", model.Family, newModel.Family)
+	}
+
+	// reuse of the rotated token
+	if _, err := tokens.FindAdminRefreshToken(app, token); !errors.Is(err, tokens.ErrRefreshTokenReused) {
+		t.Fatalf("Expected ErrRefreshTokenReused, got %v", err)
+	}
+
+	// the entire family should be revoked
+	if _, err := tokens.FindAdminRefreshToken(app, newToken); !errors.Is(err, tokens.ErrRefreshTokenReused) {
+		t.Fatalf("Expected the new token to be revoked, got %v", err)
+	}
+}
+
+func TestRecordRefreshTokenRotation(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.NewRecordRefreshToken(app, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tokens.FindAdminRefreshToken(app, token); !errors.Is(err, tokens.ErrInvalidRefreshToken) {
+		t.Fatalf("Expected ErrInvalidRefreshToken for admin lookup, got %v", err)
+	}
+
+	clients, err := app.Dao().FindCollectionByNameOrId("clients")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.FindRecordRefreshToken(app, clients, token); !errors.Is(err, tokens.ErrInvalidRefreshToken) {
+		t.Fatalf("Expected ErrInvalidRefreshToken for different collection lookup, got %v", err)
+	}
+
+	model, err := tokens.FindRecordRefreshToken(app, user.Collection(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.RecordId != user.Id || model.CollectionId != user.Collection().Id {
+		t.Fatalf("Unexpected refresh token model %v", model)
+	}
+
+	if _, err := tokens.RotateRefreshToken(app, model); err != nil {
+		t.Fatal(err)
+	}
+
+	// concurrent rotation of the same model
+	if _, err := tokens.RotateRefreshToken(app, model); !errors.Is(err, tokens.ErrRefreshTokenReused) {
+		t.Fatalf("Expected ErrRefreshTokenReused, got %v", err)
+	}
+}
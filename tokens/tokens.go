@@ -5,3 +5,11 @@ const (
 	TypeAdmin      = "admin"
 	TypeAuthRecord = "authRecord"
 )
+
+// ClaimSessionId is the auth token claim with the id of the auth session
+// (aka. device) the token was issued for.
+const ClaimSessionId = "sessionId"
+
+// ClaimImpersonated is the auth token claim that marks the
+// tokens issued by an admin (or script) on behalf of the auth record.
+const ClaimImpersonated = "impersonated"
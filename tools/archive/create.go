@@ -15,6 +15,28 @@ import (
 // You can specify skipPaths to skip/ignore certain directories and files (relative to src)
 // preventing adding them in the final archive.
 func Create(src string, dest string, skipPaths ...string) error {
+	return CreateWithOptions(src, dest, CreateOptions{SkipPaths: skipPaths})
+}
+
+// CreateOptions defines the optional settings of [CreateWithOptions].
+type CreateOptions struct {
+	// SkipPaths is a list of directories and files (relative to src)
+	// to skip/ignore from adding in the final archive.
+	SkipPaths []string
+
+	// Filter is an optional function that is called for each
+	// non-skipped src file and reports whether the file
+	// should be added in the final archive.
+	Filter func(name string, info fs.FileInfo) bool
+
+	// After is an optional function that is called after all src files
+	// are added, allowing to write additional archive entries (eg. a manifest file).
+	After func(w *zip.Writer) error
+}
+
+// CreateWithOptions creates a new zip archive from src dir content
+// and saves it in dest path, similar to [Create] but with extra options.
+func CreateWithOptions(src string, dest string, options CreateOptions) error {
 	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
 		return err
 	}
@@ -33,7 +55,12 @@ func Create(src string, dest string, skipPaths ...string) error {
 		return flate.NewWriter(out, flate.BestSpeed)
 	})
 
-	if err := zipAddFS(zw, os.DirFS(src), skipPaths...); err != nil {
+	err = zipAddFS(zw, os.DirFS(src), options.Filter, options.SkipPaths...)
+	if err == nil && options.After != nil {
+		err = options.After(zw)
+	}
+
+	if err != nil {
 		// try to cleanup at least the created zip file
 		os.Remove(dest)
 
@@ -44,7 +71,7 @@ func Create(src string, dest string, skipPaths ...string) error {
 }
 
 // note remove after similar method is added in the std lib (https://github.com/golang/go/issues/54898)
-func zipAddFS(w *zip.Writer, fsys fs.FS, skipPaths ...string) error {
+func zipAddFS(w *zip.Writer, fsys fs.FS, filter func(name string, info fs.FileInfo) bool, skipPaths ...string) error {
 	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -67,6 +94,10 @@ func zipAddFS(w *zip.Writer, fsys fs.FS, skipPaths ...string) error {
 			return err
 		}
 
+		if filter != nil && !filter(name, info) {
+			return nil
+		}
+
 		h, err := zip.FileInfoHeader(info)
 		if err != nil {
 			return err
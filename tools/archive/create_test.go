@@ -1,6 +1,8 @@
 package archive_test
 
 import (
+	"archive/zip"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -54,6 +56,66 @@ func TestCreateSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateWithOptions(t *testing.T) {
+	testDir := createTestDir(t)
+	defer os.RemoveAll(testDir)
+
+	zipPath := filepath.Join(os.TempDir(), "pb_test_options.zip")
+	defer os.RemoveAll(zipPath)
+
+	filtered := []string{}
+
+	err := archive.CreateWithOptions(testDir, zipPath, archive.CreateOptions{
+		SkipPaths: []string{"a/b/c"},
+		Filter: func(name string, info fs.FileInfo) bool {
+			filtered = append(filtered, name)
+			return name != "test2"
+		},
+		After: func(w *zip.Writer) error {
+			f, err := w.Create("manifest.json")
+			if err != nil {
+				return err
+			}
+			_, err = f.Write([]byte("{}"))
+			return err
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	expectedFiltered := []string{"a/b/sub1", "a/test", "test", "test2", "test_symlink"}
+	if len(filtered) != len(expectedFiltered) {
+		t.Fatalf("Expected filtered files %v, got %v", expectedFiltered, filtered)
+	}
+	for i, name := range expectedFiltered {
+		if filtered[i] != name {
+			t.Fatalf("Expected filtered files %v, got %v", expectedFiltered, filtered)
+		}
+	}
+
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+
+	expectedNames := []string{"a/b/sub1", "a/test", "test", "test_symlink", "manifest.json"}
+	if len(names) != len(expectedNames) {
+		t.Fatalf("Expected archive files %v, got %v", expectedNames, names)
+	}
+	for i, name := range expectedNames {
+		if names[i] != name {
+			t.Fatalf("Expected archive files %v, got %v", expectedNames, names)
+		}
+	}
+}
+
 // -------------------------------------------------------------------
 
 // note: make sure to call os.RemoveAll(dir) after you are done
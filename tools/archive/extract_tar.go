@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExtractTarGz extracts the gzip compressed tar archive at "src" to "dest".
+//
+// Similar to [Extract], only dirs and regular files will be extracted.
+func ExtractTarGz(src, dest string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+
+	// normalize dest path to check later for Zip Slip
+	dest = filepath.Clean(dest) + string(os.PathSeparator)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := extractTarFile(tr, header, dest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractTarFile extracts the current tar reader entry into
+// "basePath/headerName" path, creating all the necessary path directories.
+func extractTarFile(r io.Reader, header *tar.Header, basePath string) error {
+	path := filepath.Join(basePath, header.Name)
+
+	// check for Zip Slip
+	if !strings.HasPrefix(path, basePath) && path+string(os.PathSeparator) != basePath {
+		return fmt.Errorf("invalid file path: %s", path)
+	}
+
+	// allow only dirs or regular files
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(path, os.ModePerm)
+	case tar.TypeReg:
+		// ensure that the file path directories are created
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, header.FileInfo().Mode().Perm())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(f, r)
+
+		return err
+	}
+
+	return nil
+}
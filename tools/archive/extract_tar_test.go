@@ -0,0 +1,118 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/archive"
+)
+
+type testTarEntry struct {
+	name     string
+	typeflag byte
+	content  string
+}
+
+func createTestTarGz(t *testing.T, path string, entries []testTarEntry) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	defer gw.Close()
+
+	tw := tar.NewWriter(gw)
+	defer tw.Close()
+
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Mode:     0644,
+			Size:     int64(len(entry.content)),
+		}
+		if entry.typeflag == tar.TypeSymlink {
+			header.Linkname = "/etc/passwd"
+			header.Size = 0
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Size > 0 {
+			if _, err := tw.Write([]byte(entry.content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestExtractTarGzFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	extractedPath := filepath.Join(dir, "extracted")
+
+	if err := archive.ExtractTarGz(filepath.Join(dir, "missing.tar.gz"), extractedPath); err == nil {
+		t.Fatal("Expected ExtractTarGz to fail due to missing src")
+	}
+
+	// zip slip
+	tarPath := filepath.Join(dir, "test.tar.gz")
+	createTestTarGz(t, tarPath, []testTarEntry{
+		{"../outside", tar.TypeReg, "test"},
+	})
+
+	if err := archive.ExtractTarGz(tarPath, extractedPath); err == nil {
+		t.Fatal("Expected ExtractTarGz to fail due to invalid file path")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "outside")); err == nil {
+		t.Fatal("Expected the file outside of the dest dir to not be created")
+	}
+}
+
+func TestExtractTarGzSuccess(t *testing.T) {
+	dir := t.TempDir()
+
+	tarPath := filepath.Join(dir, "test.tar.gz")
+	createTestTarGz(t, tarPath, []testTarEntry{
+		{"./", tar.TypeDir, ""},
+		{"a/", tar.TypeDir, ""},
+		{"test.pb.js", tar.TypeReg, "test1"},
+		{"a/b/test.pb.js", tar.TypeReg, "test2"},
+		{"link", tar.TypeSymlink, ""},
+	})
+
+	extractedPath := filepath.Join(dir, "extracted")
+
+	if err := archive.ExtractTarGz(tarPath, extractedPath); err != nil {
+		t.Fatalf("Failed to extract %q in %q: %v", tarPath, extractedPath, err)
+	}
+
+	expectedFiles := map[string]string{
+		"test.pb.js":     "test1",
+		"a/b/test.pb.js": "test2",
+	}
+
+	for name, content := range expectedFiles {
+		raw, err := os.ReadFile(filepath.Join(extractedPath, name))
+		if err != nil {
+			t.Fatalf("Missing file %q: %v", name, err)
+		}
+
+		if string(raw) != content {
+			t.Fatalf("Expected %q content %q, got %q", name, content, raw)
+		}
+	}
+
+	// (note: symbolic links should be missing)
+	if _, err := os.Lstat(filepath.Join(extractedPath, "link")); err == nil {
+		t.Fatal("Expected the symbolic link to be skipped")
+	}
+}
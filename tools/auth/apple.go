@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/tools/transport"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 	"golang.org/x/oauth2"
@@ -190,7 +191,7 @@ func (p *Apple) fetchJWK(kid string) (*jwk, error) {
 		return nil, err
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := (&http.Client{Transport: transport.Default}).Do(req)
 	if err != nil {
 		return nil, err
 	}
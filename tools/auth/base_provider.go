@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/pocketbase/pocketbase/tools/transport"
 	"golang.org/x/oauth2"
 )
 
@@ -130,12 +131,27 @@ func (p *baseProvider) BuildAuthUrl(state string, opts ...oauth2.AuthCodeOption)
 
 // FetchToken implements Provider.FetchToken() interface method.
 func (p *baseProvider) FetchToken(code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
-	return p.oauth2Config().Exchange(p.ctx, code, opts...)
+	return p.oauth2Config().Exchange(p.clientContext(), code, opts...)
 }
 
 // Client implements Provider.Client() interface method.
 func (p *baseProvider) Client(token *oauth2.Token) *http.Client {
-	return p.oauth2Config().Client(p.ctx, token)
+	return p.oauth2Config().Client(p.clientContext(), token)
+}
+
+// clientContext returns the provider context with the outbound
+// http client (unless a custom [oauth2.HTTPClient] is already set).
+func (p *baseProvider) clientContext() context.Context {
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport.Default})
 }
 
 // FetchRawUserData implements Provider.FetchRawUserData() interface method.
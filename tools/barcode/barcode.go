@@ -0,0 +1,139 @@
+// Package barcode implements helpers for generating QR and Code 128
+// barcodes and rendering them as PNG or SVG images.
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// Supported barcode image formats.
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+// Barcode defines a barcode modules grid
+// (the linear barcodes have a single row).
+type Barcode struct {
+	// Modules contains the barcode rows with the dark modules set to true
+	// (the quiet zone around the barcode is included).
+	Modules [][]bool
+}
+
+// NewQR encodes data as QR code with medium (~15%) error recovery level.
+func NewQR(data string) (*Barcode, error) {
+	if data == "" {
+		return nil, errors.New("missing QR code data")
+	}
+
+	q, err := qrcode.New(data, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Barcode{Modules: q.Bitmap()}, nil
+}
+
+// Size returns the number of the barcode columns and rows in modules.
+func (b *Barcode) Size() (int, int) {
+	if len(b.Modules) == 0 {
+		return 0, 0
+	}
+
+	return len(b.Modules[0]), len(b.Modules)
+}
+
+// Image returns the barcode as black and white image with the specified size.
+//
+// The size is automatically increased to fit at least 1 pixel per module.
+func (b *Barcode) Image(width int, height int) image.Image {
+	cols, rows := b.Size()
+
+	width = max(width, cols)
+	height = max(height, rows)
+
+	img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.White, color.Black})
+
+	if cols == 0 || rows == 0 {
+		return img
+	}
+
+	for y := 0; y < height; y++ {
+		row := b.Modules[y*rows/height]
+
+		for x := 0; x < width; x++ {
+			if row[x*cols/width] {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	return img
+}
+
+// PNG returns the barcode as PNG image with the specified size (see [Barcode.Image]).
+func (b *Barcode) PNG(width int, height int) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, b.Image(width, height)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SVG returns the barcode as SVG image with the specified size.
+//
+// The modules are stretched to fill the entire image
+// (eg. a single row linear barcode to the image height).
+func (b *Barcode) SVG(width int, height int) []byte {
+	cols, rows := b.Size()
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(
+		&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" preserveAspectRatio="none" shape-rendering="crispEdges">`,
+		width, height, cols, rows,
+	)
+	buf.WriteString(`<rect width="100%" height="100%" fill="#fff"/><path fill="#000" d="`)
+
+	for y, row := range b.Modules {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
+
+// Encode returns the barcode as image in the specified format (png or svg).
+func (b *Barcode) Encode(format string, width int, height int) ([]byte, error) {
+	switch format {
+	case FormatPNG:
+		return b.PNG(width, height)
+	case FormatSVG:
+		return b.SVG(width, height), nil
+	default:
+		return nil, fmt.Errorf("unsupported barcode format %q", format)
+	}
+}
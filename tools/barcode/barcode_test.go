@@ -0,0 +1,118 @@
+package barcode_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/barcode"
+)
+
+func TestNewQR(t *testing.T) {
+	if _, err := barcode.NewQR(""); err == nil {
+		t.Fatal("Expected error for empty data")
+	}
+
+	b, err := barcode.NewQR("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cols, rows := b.Size()
+
+	// version 1 (21x21) + 4 modules quiet zone on each side
+	if cols != 29 || rows != 29 {
+		t.Fatalf("Expected 29x29 modules, got %dx%d", cols, rows)
+	}
+
+	// top-left finder pattern corners
+	if b.Modules[3][3] || !b.Modules[4][4] || !b.Modules[4][10] || !b.Modules[10][4] || b.Modules[5][5] || !b.Modules[6][6] {
+		t.Fatal("Expected top-left finder pattern")
+	}
+}
+
+func TestBarcodeImage(t *testing.T) {
+	b := &barcode.Barcode{Modules: [][]bool{
+		{true, false},
+		{false, true},
+	}}
+
+	scenarios := []struct {
+		width          int
+		height         int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{0, 0, 2, 2},
+		{4, 4, 4, 4},
+		{5, 3, 5, 3},
+	}
+
+	for _, s := range scenarios {
+		img := b.Image(s.width, s.height)
+
+		bounds := img.Bounds()
+		if bounds.Dx() != s.expectedWidth || bounds.Dy() != s.expectedHeight {
+			t.Fatalf("[%dx%d] Expected %dx%d image, got %dx%d", s.width, s.height, s.expectedWidth, s.expectedHeight, bounds.Dx(), bounds.Dy())
+		}
+
+		if r, _, _, _ := img.At(0, 0).RGBA(); r != 0 {
+			t.Fatalf("[%dx%d] Expected black top-left pixel", s.width, s.height)
+		}
+
+		if r, _, _, _ := img.At(bounds.Dx()-1, 0).RGBA(); r == 0 {
+			t.Fatalf("[%dx%d] Expected white top-right pixel", s.width, s.height)
+		}
+
+		if r, _, _, _ := img.At(bounds.Dx()-1, bounds.Dy()-1).RGBA(); r != 0 {
+			t.Fatalf("[%dx%d] Expected black bottom-right pixel", s.width, s.height)
+		}
+	}
+}
+
+func TestBarcodeEncode(t *testing.T) {
+	b, err := barcode.NewCode128("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// png
+	raw, err := b.Encode(barcode.FormatPNG, 200, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bounds := img.Bounds(); bounds.Dx() != 200 || bounds.Dy() != 50 {
+		t.Fatalf("Expected 200x50 png, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	// svg
+	raw, err = b.Encode(barcode.FormatSVG, 200, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svg := string(raw)
+
+	expectedParts := []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="200" height="50" viewBox="0 0 99 1"`,
+		`<path fill="#000" d="M10 0h2v1h-2z`,
+		`"/></svg>`,
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(svg, part) {
+			t.Fatalf("Missing %q in\n%s", part, svg)
+		}
+	}
+
+	// unknown
+	if _, err := b.Encode("gif", 200, 50); err == nil {
+		t.Fatal("Expected unsupported format error")
+	}
+}
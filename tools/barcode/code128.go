@@ -0,0 +1,157 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code128QuietZone is the number of the blank modules
+// before and after the Code 128 barcode symbols.
+const Code128QuietZone = 10
+
+// code128 special symbol values
+const (
+	code128CodeC  = 99
+	code128CodeB  = 100
+	code128CodeA  = 101
+	code128StartA = 103
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+)
+
+// code128Patterns contains the alternating bar and space widths
+// (starting with a bar) of each Code 128 symbol value.
+var code128Patterns = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// NewCode128 encodes the ASCII data as Code 128 linear barcode.
+//
+// The code sets are switched automatically (code set C is used
+// for the runs of 4 or more digits to produce shorter barcodes).
+func NewCode128(data string) (*Barcode, error) {
+	values, err := code128Values(data)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make([]bool, Code128QuietZone, Code128QuietZone*2+len(values)*11+2)
+
+	for _, v := range values {
+		dark := true
+		for _, w := range code128Patterns[v] {
+			for i := 0; i < int(w-'0'); i++ {
+				row = append(row, dark)
+			}
+			dark = !dark
+		}
+	}
+
+	row = append(row, make([]bool, Code128QuietZone)...)
+
+	return &Barcode{Modules: [][]bool{row}}, nil
+}
+
+// code128Values returns the Code 128 symbol values of data
+// including the start, checksum and stop symbols.
+func code128Values(data string) ([]int, error) {
+	if data == "" {
+		return nil, errors.New("missing Code 128 data")
+	}
+
+	for i := 0; i < len(data); i++ {
+		if data[i] > 127 {
+			return nil, fmt.Errorf("unsupported Code 128 character %q", data[i])
+		}
+	}
+
+	values := make([]int, 0, len(data)+3)
+
+	var set int
+
+	switch {
+	case code128DigitsRun(data, 0) >= 4 || (len(data) == 2 && code128DigitsRun(data, 0) == 2):
+		set = code128CodeC
+		values = append(values, code128StartC)
+	case data[0] < 32:
+		set = code128CodeA
+		values = append(values, code128StartA)
+	default:
+		set = code128CodeB
+		values = append(values, code128StartB)
+	}
+
+	for i := 0; i < len(data); {
+		c := data[i]
+
+		if set == code128CodeC {
+			if code128DigitsRun(data, i) >= 2 {
+				values = append(values, int(c-'0')*10+int(data[i+1]-'0'))
+				i += 2
+				continue
+			}
+
+			if c < 32 {
+				set = code128CodeA
+			} else {
+				set = code128CodeB
+			}
+			values = append(values, set)
+			continue
+		}
+
+		// switch to code set C for 4+ digits (keeping the first digit
+		// in the current set if the run has odd length)
+		if run := code128DigitsRun(data, i); run >= 4 && run%2 == 0 {
+			set = code128CodeC
+			values = append(values, set)
+			continue
+		}
+
+		if set == code128CodeB && c < 32 {
+			set = code128CodeA
+			values = append(values, set)
+		} else if set == code128CodeA && c >= 96 {
+			set = code128CodeB
+			values = append(values, set)
+		}
+
+		if set == code128CodeA && c < 32 {
+			values = append(values, int(c)+64)
+		} else {
+			values = append(values, int(c)-32)
+		}
+		i++
+	}
+
+	checksum := values[0]
+	for i := 1; i < len(values); i++ {
+		checksum += values[i] * i
+	}
+
+	values = append(values, checksum%103, code128Stop)
+
+	return values, nil
+}
+
+// code128DigitsRun returns the number of the consecutive digits in data starting from i.
+func code128DigitsRun(data string, i int) int {
+	n := 0
+
+	for ; i < len(data) && data[i] >= '0' && data[i] <= '9'; i++ {
+		n++
+	}
+
+	return n
+}
@@ -0,0 +1,87 @@
+package barcode
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	for i, p := range code128Patterns {
+		expectedWidth := 11
+		if i == code128Stop {
+			expectedWidth = 13
+		}
+
+		width := 0
+		for _, w := range p {
+			width += int(w - '0')
+		}
+
+		if width != expectedWidth {
+			t.Fatalf("[%d] Expected pattern %q width %d, got %d", i, p, expectedWidth, width)
+		}
+	}
+}
+
+func TestCode128Values(t *testing.T) {
+	scenarios := []struct {
+		data        string
+		expectError bool
+		expected    []int
+	}{
+		{"", true, nil},
+		{"ü", true, nil},
+		{"PJJ123C", false, []int{104, 48, 42, 42, 17, 18, 19, 35, 55, 106}},
+		{"123456", false, []int{105, 12, 34, 56, 44, 106}},
+		{"AB12345", false, []int{104, 33, 34, 17, 99, 23, 45, 7, 106}},
+		{"1234a", false, []int{105, 12, 34, 100, 65, 24, 106}},
+		{"12a", false, []int{104, 17, 18, 65, 43, 106}},
+		{"\tA", false, []int{103, 73, 33, 36, 106}},
+		{"a\n", false, []int{104, 65, 101, 74, 78, 106}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.data, func(t *testing.T) {
+			result, err := code128Values(s.data)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !reflect.DeepEqual(result, s.expected) {
+				t.Fatalf("Expected values %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestNewCode128(t *testing.T) {
+	b, err := NewCode128("123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cols, rows := b.Size()
+
+	// 2x quiet zone + start, 3 data symbols, checksum and stop
+	expectedCols := 2*Code128QuietZone + 5*11 + 13
+	if cols != expectedCols || rows != 1 {
+		t.Fatalf("Expected %dx1 modules, got %dx%d", expectedCols, cols, rows)
+	}
+
+	row := b.Modules[0]
+
+	// the quiet zones
+	for i := 0; i < Code128QuietZone; i++ {
+		if row[i] || row[cols-1-i] {
+			t.Fatalf("Expected blank quiet zone module at %d", i)
+		}
+	}
+
+	// start C pattern (211232)
+	expectedStart := []bool{true, true, false, true, false, false, true, true, true, false, false}
+	if start := row[Code128QuietZone : Code128QuietZone+11]; !reflect.DeepEqual(start, expectedStart) {
+		t.Fatalf("Expected start C modules %v, got %v", expectedStart, start)
+	}
+}
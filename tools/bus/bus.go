@@ -0,0 +1,223 @@
+// Package bus implements a simple in-process publish/subscribe event bus
+// with support for typed topics.
+//
+// It is intended to be used as integration point between compiled Go
+// plugins and the app scripts (eg. jsvm hooks) without the need of
+// persisting "fake" records as queue.
+//
+// Delivery guarantees:
+//   - messages are delivered only to the subscribers registered in the
+//     current process (there is no persistence or cross-node propagation);
+//   - [Bus.Publish] is synchronous - the subscribers are invoked one by one
+//     in their registration order and the publish call returns after
+//     the last subscriber completes;
+//   - the execution stops on the first subscriber error (which is returned
+//     to the publisher) or when [hook.StopPropagation] is returned;
+//   - [Bus.PublishAsync] delivers the message in a separate goroutine
+//     (aka. "fire and forget") and its errors are reported only to the
+//     optional error callback;
+//   - publishing to a topic without subscribers is a no-op (the message is dropped).
+//
+// Example:
+//
+//	type OrderPaid struct {
+//		OrderId string `json:"orderId"`
+//		Amount  int    `json:"amount"`
+//	}
+//
+//	topic := bus.NewTopic[OrderPaid](app.Bus(), "orders.paid")
+//
+//	topic.Subscribe(func(data OrderPaid) error {
+//		log.Println(data.OrderId, data.Amount)
+//		return nil
+//	})
+//
+//	topic.Publish(OrderPaid{OrderId: "abc", Amount: 100})
+package bus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/routine"
+)
+
+// Message defines a single bus message.
+type Message struct {
+	Topic string `json:"topic"`
+	Data  any    `json:"data"`
+}
+
+// Bus defines a concurrent safe in-process topic based event bus.
+type Bus struct {
+	mux    sync.RWMutex
+	topics map[string]*hook.Hook[*Message]
+}
+
+// New creates and returns a new empty Bus instance.
+func New() *Bus {
+	return &Bus{
+		topics: map[string]*hook.Hook[*Message]{},
+	}
+}
+
+// Subscribe registers a new topic message handler.
+//
+// Returns an autogenerated subscription id that could be used
+// later to remove the handler with Bus.Unsubscribe(topic, id).
+func (b *Bus) Subscribe(topic string, fn hook.Handler[*Message]) string {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	h, ok := b.topics[topic]
+	if !ok {
+		h = &hook.Hook[*Message]{}
+		b.topics[topic] = h
+	}
+
+	return h.Add(fn)
+}
+
+// Unsubscribe removes a single topic handler by its subscription id.
+func (b *Bus) Unsubscribe(topic string, id string) {
+	b.mux.RLock()
+	h, ok := b.topics[topic]
+	b.mux.RUnlock()
+
+	if ok {
+		h.Remove(id)
+	}
+}
+
+// UnsubscribeAll removes all handlers of the specified topic.
+func (b *Bus) UnsubscribeAll(topic string) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	delete(b.topics, topic)
+}
+
+// Topics returns a sorted list with the names of all topics
+// that have at least one registered handler.
+func (b *Bus) Topics() []string {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	result := make([]string, 0, len(b.topics))
+	for name := range b.topics {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Publish synchronously delivers data to all subscribers of the specified topic.
+//
+// It returns the first subscriber error (if any).
+func (b *Bus) Publish(topic string, data any) error {
+	b.mux.RLock()
+	h, ok := b.topics[topic]
+	b.mux.RUnlock()
+
+	if !ok {
+		return nil // no subscribers
+	}
+
+	return h.Trigger(&Message{Topic: topic, Data: data})
+}
+
+// PublishAsync delivers data to all subscribers of the specified
+// topic in a separate goroutine.
+//
+// The optional onError callback is called in case of a subscriber error.
+func (b *Bus) PublishAsync(topic string, data any, onError ...func(err error)) {
+	routine.FireAndForget(func() {
+		if err := b.Publish(topic, data); err != nil {
+			for _, fn := range onError {
+				fn(err)
+			}
+		}
+	})
+}
+
+// -------------------------------------------------------------------
+
+// Topic defines a typed proxy for publishing and subscribing to a single bus topic.
+type Topic[T any] struct {
+	bus  *Bus
+	name string
+}
+
+// NewTopic creates a new typed topic proxy bound to the provided bus.
+func NewTopic[T any](b *Bus, name string) *Topic[T] {
+	return &Topic[T]{bus: b, name: name}
+}
+
+// Name returns the topic name.
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Publish synchronously delivers the typed data to all topic subscribers.
+func (t *Topic[T]) Publish(data T) error {
+	return t.bus.Publish(t.name, data)
+}
+
+// PublishAsync delivers the typed data to all topic subscribers in a separate goroutine.
+func (t *Topic[T]) PublishAsync(data T, onError ...func(err error)) {
+	t.bus.PublishAsync(t.name, data, onError...)
+}
+
+// Subscribe registers a new typed topic handler.
+//
+// Messages with untyped data (eg. plain objects published from scripts)
+// are converted to T through their JSON representation.
+// An error is returned to the publisher if the conversion fails.
+func (t *Topic[T]) Subscribe(fn func(data T) error) string {
+	return t.bus.Subscribe(t.name, func(m *Message) error {
+		data, err := Decode[T](m.Data)
+		if err != nil {
+			return fmt.Errorf("bus: failed to decode %q message data: %w", t.name, err)
+		}
+
+		return fn(data)
+	})
+}
+
+// Unsubscribe removes a single topic handler by its subscription id.
+func (t *Topic[T]) Unsubscribe(id string) {
+	t.bus.Unsubscribe(t.name, id)
+}
+
+// Decode converts the provided raw message data to T.
+//
+// If data is already of type T (or *T) it is returned as it is,
+// otherwise the conversion is performed through its JSON representation.
+func Decode[T any](data any) (T, error) {
+	var result T
+
+	switch v := data.(type) {
+	case T:
+		return v, nil
+	case *T:
+		if v == nil {
+			return result, errors.New("nil data")
+		}
+		return *v, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(raw, &result)
+
+	return result, err
+}
@@ -0,0 +1,191 @@
+package bus_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/bus"
+	"github.com/pocketbase/pocketbase/tools/hook"
+)
+
+func TestBusSubscribeAndPublish(t *testing.T) {
+	b := bus.New()
+
+	sequence := ""
+
+	b.Subscribe("a", func(m *bus.Message) error {
+		sequence += "a1:" + m.Data.(string) + ";"
+		return nil
+	})
+	b.Subscribe("a", func(m *bus.Message) error {
+		sequence += "a2:" + m.Data.(string) + ";"
+		return nil
+	})
+	b.Subscribe("b", func(m *bus.Message) error {
+		sequence += "b1:" + m.Data.(string) + ";"
+		return nil
+	})
+
+	if err := b.Publish("a", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Publish("missing", "test"); err != nil {
+		t.Fatalf("Expected nil error for topic without subscribers, got %v", err)
+	}
+
+	expected := "a1:test;a2:test;"
+	if sequence != expected {
+		t.Fatalf("Expected sequence %q, got %q", expected, sequence)
+	}
+}
+
+func TestBusPublishErrorAndStopPropagation(t *testing.T) {
+	b := bus.New()
+
+	calls := 0
+
+	b.Subscribe("stop", func(m *bus.Message) error {
+		calls++
+		return hook.StopPropagation
+	})
+	b.Subscribe("stop", func(m *bus.Message) error {
+		calls++
+		return nil
+	})
+
+	if err := b.Publish("stop", nil); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("Expected 1 call, got %d", calls)
+	}
+
+	b.Subscribe("err", func(m *bus.Message) error {
+		return errors.New("test")
+	})
+
+	if err := b.Publish("err", nil); err == nil || err.Error() != "test" {
+		t.Fatalf("Expected the subscriber error to be returned, got %v", err)
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	b := bus.New()
+
+	calls := 0
+
+	id := b.Subscribe("a", func(m *bus.Message) error {
+		calls++
+		return nil
+	})
+	b.Subscribe("b", func(m *bus.Message) error {
+		calls++
+		return nil
+	})
+
+	if topics := strings.Join(b.Topics(), ","); topics != "a,b" {
+		t.Fatalf("Expected topics a,b, got %s", topics)
+	}
+
+	b.Unsubscribe("a", "missing") // should do nothing
+	b.Unsubscribe("a", id)
+	b.UnsubscribeAll("b")
+
+	b.Publish("a", nil)
+	b.Publish("b", nil)
+
+	if calls != 0 {
+		t.Fatalf("Expected 0 calls, got %d", calls)
+	}
+
+	if topics := strings.Join(b.Topics(), ","); topics != "a" {
+		t.Fatalf("Expected topics a, got %s", topics)
+	}
+}
+
+func TestBusPublishAsync(t *testing.T) {
+	b := bus.New()
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	b.Subscribe("a", func(m *bus.Message) error {
+		return errors.New("test")
+	})
+
+	var publishErr error
+	b.PublishAsync("a", nil, func(err error) {
+		publishErr = err
+		wg.Done()
+	})
+
+	wg.Wait()
+
+	if publishErr == nil || publishErr.Error() != "test" {
+		t.Fatalf("Expected async error test, got %v", publishErr)
+	}
+}
+
+func TestTopic(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name"`
+		Total int    `json:"total"`
+	}
+
+	b := bus.New()
+
+	topic := bus.NewTopic[payload](b, "demo")
+
+	if topic.Name() != "demo" {
+		t.Fatalf("Expected topic name demo, got %q", topic.Name())
+	}
+
+	var received []payload
+
+	id := topic.Subscribe(func(data payload) error {
+		received = append(received, data)
+		return nil
+	})
+
+	// typed
+	if err := topic.Publish(payload{"a", 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// pointer
+	if err := b.Publish("demo", &payload{"b", 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// untyped (eg. from a script)
+	if err := b.Publish("demo", map[string]any{"name": "c", "total": 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid
+	if err := b.Publish("demo", map[string]any{"total": "invalid"}); err == nil {
+		t.Fatal("Expected decode error, got nil")
+	}
+
+	topic.Unsubscribe(id)
+
+	if err := topic.Publish(payload{"d", 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []payload{{"a", 1}, {"b", 2}, {"c", 3}}
+
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %d messages, got %d (%v)", len(expected), len(received), received)
+	}
+
+	for i, item := range expected {
+		if received[i] != item {
+			t.Fatalf("[%d] Expected %v, got %v", i, item, received[i])
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Package cache implements a simple byte values cache abstraction
+// with an in-memory LRU and a Redis backed implementations.
+package cache
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrMiss is returned by [Cache.Get] when the key doesn't exist or it is expired.
+var ErrMiss = errors.New("cache: key not found")
+
+// Cache defines a common interface for the cache stores.
+type Cache interface {
+	// Get returns the value of the specified key (or [ErrMiss]).
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores the key value for the specified ttl (zero ttl means no expiration).
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Incr atomically increments the integer value of the specified
+	// key (starting from 0) and returns the new value.
+	//
+	// The counter value could be read as decimal string with Get.
+	Incr(ctx context.Context, key string) (int64, error)
+
+	// Close releases the cache resources.
+	Close() error
+}
+
+// -------------------------------------------------------------------
+
+// StatsItem holds the usage counters of a single cache group.
+type StatsItem struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// HitRatio returns the ratio of the cache hits to all lookups.
+func (s StatsItem) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+// Stats is a concurrent safe cache hits and misses counter
+// grouped by an arbitrary name (eg. collection name).
+type Stats struct {
+	mux    sync.Mutex
+	groups map[string]*StatsItem
+}
+
+// NewStats creates a new empty Stats instance.
+func NewStats() *Stats {
+	return &Stats{groups: map[string]*StatsItem{}}
+}
+
+// Hit increments the hits counter of the specified group.
+func (s *Stats) Hit(group string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.item(group).Hits++
+}
+
+// Miss increments the misses counter of the specified group.
+func (s *Stats) Miss(group string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.item(group).Misses++
+}
+
+// Snapshot returns a copy of the current counters of all groups.
+func (s *Stats) Snapshot() map[string]StatsItem {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := make(map[string]StatsItem, len(s.groups))
+	for name, item := range s.groups {
+		result[name] = *item
+	}
+
+	return result
+}
+
+// Groups returns the sorted names of the tracked groups.
+func (s *Stats) Groups() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	names := make([]string, 0, len(s.groups))
+	for name := range s.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Reset clears all counters.
+func (s *Stats) Reset() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.groups = map[string]*StatsItem{}
+}
+
+func (s *Stats) item(group string) *StatsItem {
+	item, ok := s.groups[group]
+	if !ok {
+		item = &StatsItem{}
+		s.groups[group] = item
+	}
+
+	return item
+}
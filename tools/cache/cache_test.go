@@ -0,0 +1,43 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/cache"
+)
+
+func TestStats(t *testing.T) {
+	s := cache.NewStats()
+
+	s.Hit("a")
+	s.Hit("a")
+	s.Miss("a")
+	s.Miss("b")
+
+	snapshot := s.Snapshot()
+
+	if len(snapshot) != 2 {
+		t.Fatalf("Expected 2 groups, got %v", snapshot)
+	}
+
+	if a := snapshot["a"]; a.Hits != 2 || a.Misses != 1 {
+		t.Fatalf("Unexpected group a counters %v", a)
+	}
+
+	if ratio := snapshot["a"].HitRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Fatalf("Expected ~0.66 hit ratio, got %v", ratio)
+	}
+
+	if ratio := snapshot["b"].HitRatio(); ratio != 0 {
+		t.Fatalf("Expected 0 hit ratio, got %v", ratio)
+	}
+
+	if groups := s.Groups(); len(groups) != 2 || groups[0] != "a" || groups[1] != "b" {
+		t.Fatalf("Unexpected groups %v", groups)
+	}
+
+	s.Reset()
+	if len(s.Snapshot()) != 0 {
+		t.Fatalf("Expected no groups after reset, got %v", s.Snapshot())
+	}
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var _ Cache = (*Memory)(nil)
+
+// DefaultMemoryMaxEntries is the default max number of the [Memory] cache entries.
+const DefaultMemoryMaxEntries = 10000
+
+// Memory is an in-memory LRU cache.
+type Memory struct {
+	mux        sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemory creates a new in-memory LRU cache that holds up to
+// maxEntries (the least recently used entries are evicted first).
+//
+// Non-positive maxEntries fallbacks to [DefaultMemoryMaxEntries].
+func NewMemory(maxEntries int) *Memory {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryMaxEntries
+	}
+
+	return &Memory{
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// Len returns the number of the stored (including the expired but not evicted yet) entries.
+func (m *Memory) Len() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.lru.Len()
+}
+
+// Get implements the [Cache.Get] interface method.
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		m.remove(elem)
+		return nil, ErrMiss
+	}
+
+	m.lru.MoveToFront(elem)
+
+	return entry.value, nil
+}
+
+// Set implements the [Cache.Set] interface method.
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	m.set(key, value, expires)
+
+	return nil
+}
+
+// Incr implements the [Cache.Incr] interface method.
+func (m *Memory) Incr(ctx context.Context, key string) (int64, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var current int64
+	var expires time.Time
+
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		if entry.expires.IsZero() || time.Now().Before(entry.expires) {
+			current, _ = strconv.ParseInt(string(entry.value), 10, 64)
+			expires = entry.expires
+		}
+	}
+
+	current++
+
+	m.set(key, []byte(strconv.FormatInt(current, 10)), expires)
+
+	return current, nil
+}
+
+// Close implements the [Cache.Close] interface method.
+func (m *Memory) Close() error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.entries = map[string]*list.Element{}
+	m.lru.Init()
+
+	return nil
+}
+
+func (m *Memory) set(key string, value []byte, expires time.Time) {
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expires = expires
+		m.lru.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.lru.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+
+	for m.lru.Len() > m.maxEntries {
+		m.remove(m.lru.Back())
+	}
+}
+
+func (m *Memory) remove(elem *list.Element) {
+	m.lru.Remove(elem)
+	delete(m.entries, elem.Value.(*memoryEntry).key)
+}
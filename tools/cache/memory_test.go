@@ -0,0 +1,70 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/cache"
+)
+
+func TestMemoryGetSet(t *testing.T) {
+	ctx := context.Background()
+
+	m := cache.NewMemory(2)
+
+	if _, err := m.Get(ctx, "a"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("Expected ErrMiss, got %v", err)
+	}
+
+	m.Set(ctx, "a", []byte("1"), 0)
+	m.Set(ctx, "b", []byte("2"), 0)
+	m.Get(ctx, "a") // mark as recently used
+	m.Set(ctx, "c", []byte("3"), 0)
+
+	if m.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", m.Len())
+	}
+
+	if _, err := m.Get(ctx, "b"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("Expected the least recently used entry to be evicted, got %v", err)
+	}
+
+	for key, expected := range map[string]string{"a": "1", "c": "3"} {
+		value, err := m.Get(ctx, key)
+		if err != nil || string(value) != expected {
+			t.Fatalf("Expected %q value %q, got %q (%v)", key, expected, value, err)
+		}
+	}
+
+	// expired
+	m.Set(ctx, "d", []byte("4"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, err := m.Get(ctx, "d"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("Expected the expired entry to be missing, got %v", err)
+	}
+
+	m.Close()
+	if m.Len() != 0 {
+		t.Fatalf("Expected no entries after close, got %d", m.Len())
+	}
+}
+
+func TestMemoryIncr(t *testing.T) {
+	ctx := context.Background()
+
+	m := cache.NewMemory(0)
+
+	for i := int64(1); i <= 3; i++ {
+		v, err := m.Incr(ctx, "counter")
+		if err != nil || v != i {
+			t.Fatalf("Expected %d, got %d (%v)", i, v, err)
+		}
+	}
+
+	raw, err := m.Get(ctx, "counter")
+	if err != nil || string(raw) != "3" {
+		t.Fatalf("Expected counter value 3, got %q (%v)", raw, err)
+	}
+}
@@ -0,0 +1,49 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tests/redismock"
+	"github.com/pocketbase/pocketbase/tools/cache"
+)
+
+func TestRedis(t *testing.T) {
+	server := redismock.NewServer(t, "")
+
+	ctx := context.Background()
+
+	r, err := cache.NewRedis(server.URL(), "test:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if _, err := r.Get(ctx, "a"); !errors.Is(err, cache.ErrMiss) {
+		t.Fatalf("Expected ErrMiss, got %v", err)
+	}
+
+	if err := r.Set(ctx, "a", []byte("value"), 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Set(ctx, "b", []byte("value"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := r.Get(ctx, "a"); err != nil || string(v) != "value" {
+		t.Fatalf("Expected value, got %q (%v)", v, err)
+	}
+
+	if v, err := r.Incr(ctx, "counter"); err != nil || v != 1 {
+		t.Fatalf("Expected 1, got %d (%v)", v, err)
+	}
+
+	expected := "GET test:a\nSET test:a value PX 2000\nSET test:b value\nGET test:a\nINCR test:counter"
+	if got := strings.Join(server.Commands(), "\n"); got != expected {
+		t.Fatalf("Expected commands\n%s\ngot\n%s", expected, got)
+	}
+}
@@ -0,0 +1,124 @@
+// Package captcha implements a server-side captcha response verifier
+// for the Cloudflare Turnstile, hCaptcha and Google reCAPTCHA providers.
+package captcha
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+// Supported captcha providers.
+const (
+	ProviderTurnstile = "turnstile"
+	ProviderHCaptcha  = "hcaptcha"
+	ProviderRecaptcha = "recaptcha"
+)
+
+// ErrInvalidResponse is returned when the provider rejects the captcha response token.
+var ErrInvalidResponse = errors.New("invalid or expired captcha response")
+
+// Verifier defines a base captcha verifier interface.
+type Verifier interface {
+	// Verify checks the captcha response token submitted by the client
+	// with the specified remote IP (could be empty).
+	Verify(token string, remoteIp string) error
+}
+
+// defaultVerifyUrls holds the siteverify endpoint of each provider.
+var defaultVerifyUrls = map[string]string{
+	ProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	ProviderHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	ProviderRecaptcha: "https://www.google.com/recaptcha/api/siteverify",
+}
+
+// responseFields holds the default captcha widget form input name of each provider.
+var responseFields = map[string]string{
+	ProviderTurnstile: "cf-turnstile-response",
+	ProviderHCaptcha:  "h-captcha-response",
+	ProviderRecaptcha: "g-recaptcha-response",
+}
+
+// ResponseField returns the name of the form input that the provider
+// widget populates with the captcha response token (eg. "cf-turnstile-response").
+func ResponseField(provider string) string {
+	return responseFields[provider]
+}
+
+var _ Verifier = (*SiteVerifyClient)(nil)
+
+// SiteVerifyClient defines a captcha verifier using the provider
+// "siteverify" API (all supported providers share the same protocol).
+type SiteVerifyClient struct {
+	Provider string
+	Secret   string
+
+	// VerifyUrl is the optional siteverify endpoint url
+	// (if not explicitly set, defaults to the provider one).
+	VerifyUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+// Verify implements [Verifier] interface.
+func (c *SiteVerifyClient) Verify(token string, remoteIp string) error {
+	if token == "" {
+		return ErrInvalidResponse
+	}
+
+	verifyUrl := c.VerifyUrl
+	if verifyUrl == "" {
+		verifyUrl = defaultVerifyUrls[c.Provider]
+	}
+	if verifyUrl == "" {
+		return fmt.Errorf("unsupported captcha provider %q", c.Provider)
+	}
+
+	form := url.Values{}
+	form.Set("secret", c.Secret)
+	form.Set("response", token)
+	if remoteIp != "" {
+		form.Set("remoteip", remoteIp)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, verifyUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s: failed to verify the captcha response (%d): %s", c.Provider, res.StatusCode, body)
+	}
+
+	result := struct {
+		Success bool `json:"success"`
+	}{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return err
+	}
+
+	if !result.Success {
+		return ErrInvalidResponse
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package captcha
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func TestResponseField(t *testing.T) {
+	scenarios := map[string]string{
+		"":                "",
+		"missing":         "",
+		ProviderTurnstile: "cf-turnstile-response",
+		ProviderHCaptcha:  "h-captcha-response",
+		ProviderRecaptcha: "g-recaptcha-response",
+	}
+
+	for provider, expected := range scenarios {
+		if v := ResponseField(provider); v != expected {
+			t.Fatalf("[%s] Expected %q, got %q", provider, expected, v)
+		}
+	}
+}
+
+func TestSiteVerifyClientVerify(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 200, `{"success":true}`)
+
+	client := &SiteVerifyClient{
+		Provider:  ProviderTurnstile,
+		Secret:    "test_secret",
+		VerifyUrl: server.URL,
+	}
+
+	if err := client.Verify("test_token", "127.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedForm := map[string]string{
+		"secret":   "test_secret",
+		"response": "test_token",
+		"remoteip": "127.0.0.1",
+	}
+	for k, v := range expectedForm {
+		if captured.Form.Get(k) != v {
+			t.Fatalf("Expected form field %q to be %q, got %q", k, v, captured.Form.Get(k))
+		}
+	}
+}
+
+func TestSiteVerifyClientVerifyFailure(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		status        int
+		response      string
+		token         string
+		expectInvalid bool
+	}{
+		{"empty token", 200, `{"success":true}`, "", true},
+		{"unsuccessful verification", 200, `{"success":false,"error-codes":["invalid-input-response"]}`, "test", true},
+		{"non 2xx response", 500, `{"success":true}`, "test", false},
+		{"invalid json response", 200, `invalid`, "test", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			server, _ := httpmock.NewServer(t, s.status, s.response)
+
+			client := &SiteVerifyClient{Provider: ProviderHCaptcha, VerifyUrl: server.URL}
+
+			err := client.Verify(s.token, "")
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if isInvalid := errors.Is(err, ErrInvalidResponse); isInvalid != s.expectInvalid {
+				t.Fatalf("Expected ErrInvalidResponse %v, got %v (%v)", s.expectInvalid, isInvalid, err)
+			}
+		})
+	}
+}
+
+func TestSiteVerifyClientUnsupportedProvider(t *testing.T) {
+	client := &SiteVerifyClient{Provider: "missing"}
+
+	if err := client.Verify("test", ""); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
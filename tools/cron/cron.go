@@ -18,6 +18,19 @@ import (
 	"time"
 )
 
+// Locker defines a distributed lock used to run each scheduled
+// cron job occurrence only once across multiple app instances
+// (eg. load-balanced nodes sharing the same database).
+type Locker interface {
+	// TryLock tries to acquire the lock with the specified key for
+	// the provided ttl and reports whether it was acquired.
+	TryLock(key string, ttl time.Duration) bool
+}
+
+// lockTTL is the duration of a single job occurrence lock
+// (it should be larger than the max expected clock skew between the nodes).
+const lockTTL = 1 * time.Hour
+
 type job struct {
 	schedule *Schedule
 	run      func()
@@ -31,6 +44,8 @@ type Cron struct {
 	jobs       map[string]*job
 	interval   time.Duration
 	tickerDone chan bool
+	locker     Locker
+	running    sync.WaitGroup
 
 	sync.RWMutex
 }
@@ -72,6 +87,17 @@ func (c *Cron) SetTimezone(l *time.Location) {
 	c.timezone = l
 }
 
+// SetLocker sets an optional distributed locker that ensures that
+// each due job occurrence runs only on the instance that acquired its lock.
+//
+// Set a nil locker to run the due jobs unconditionally (default).
+func (c *Cron) SetLocker(locker Locker) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.locker = locker
+}
+
 // MustAdd is similar to Add() but panic on failure.
 func (c *Cron) MustAdd(jobId string, cronExpr string, run func()) {
 	if err := c.Add(jobId, cronExpr, run); err != nil {
@@ -202,9 +228,36 @@ func (c *Cron) runDue(t time.Time) {
 
 	moment := NewMoment(t.In(c.timezone))
 
-	for _, j := range c.jobs {
-		if j.schedule.IsDue(moment) {
-			go j.run()
+	for id, j := range c.jobs {
+		if !j.schedule.IsDue(moment) {
+			continue
 		}
+
+		c.running.Add(1)
+
+		if c.locker == nil {
+			go func(run func()) {
+				defer c.running.Done()
+				run()
+			}(j.run)
+			continue
+		}
+
+		// the schedule has second precision so the same job occurrence
+		// of all instances share the same lock key
+		key := "cron:" + id + ":" + t.UTC().Truncate(time.Second).Format(time.RFC3339)
+
+		go func(locker Locker, run func()) {
+			defer c.running.Done()
+			if locker.TryLock(key, lockTTL) {
+				run()
+			}
+		}(c.locker, j.run)
 	}
 }
+
+// Wait blocks until all currently running jobs complete
+// (usually called after Stop() on graceful shutdown).
+func (c *Cron) Wait() {
+	c.running.Wait()
+}
@@ -2,6 +2,8 @@ package cron
 
 import (
 	"encoding/json"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -261,3 +263,134 @@ func TestCronStartStop(t *testing.T) {
 		t.Fatalf("Expected %d test2, got %d", expectedCalls, test2)
 	}
 }
+
+type testLocker struct {
+	mux   sync.Mutex
+	locks map[string]struct{}
+}
+
+func (l *testLocker) TryLock(key string, ttl time.Duration) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if _, ok := l.locks[key]; ok {
+		return false
+	}
+
+	l.locks[key] = struct{}{}
+
+	return true
+}
+
+func TestCronLocker(t *testing.T) {
+	t.Parallel()
+
+	locker := &testLocker{locks: map[string]struct{}{}}
+
+	var mux sync.Mutex
+	calls := map[string]int{}
+
+	wg := sync.WaitGroup{}
+
+	nodes := []*Cron{New(), New(), New()}
+	for _, c := range nodes {
+		c.SetLocker(locker)
+		c.MustAdd("test", "* * * * *", func() {
+			defer wg.Done()
+			mux.Lock()
+			calls["test"]++
+			mux.Unlock()
+		})
+	}
+
+	// unlocked cron
+	unlocked := New()
+	unlocked.MustAdd("test", "* * * * *", func() {
+		defer wg.Done()
+		mux.Lock()
+		calls["unlocked"]++
+		mux.Unlock()
+	})
+
+	moments := []time.Time{
+		time.Date(2024, 1, 1, 10, 0, 0, 1000, time.UTC),
+		time.Date(2024, 1, 1, 10, 0, 0, 2000, time.UTC), // same occurrence
+		time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC),
+	}
+
+	// 2 locked runs (one per distinct occurrence) + 3 unlocked ones
+	wg.Add(5)
+
+	for _, moment := range moments {
+		for _, c := range nodes {
+			c.runDue(moment)
+		}
+		unlocked.runDue(moment)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for the jobs to run")
+	}
+
+	// wait a little to ensure that there are no extra runs
+	time.Sleep(50 * time.Millisecond)
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	if calls["test"] != 2 {
+		t.Fatalf("Expected 2 locked job runs, got %d", calls["test"])
+	}
+
+	if calls["unlocked"] != 3 {
+		t.Fatalf("Expected 3 unlocked job runs, got %d", calls["unlocked"])
+	}
+}
+
+func TestCronWait(t *testing.T) {
+	t.Parallel()
+
+	c := New()
+
+	release := make(chan struct{})
+	var finished atomic.Bool
+
+	c.MustAdd("test", "* * * * *", func() {
+		<-release
+		finished.Store(true)
+	})
+
+	c.runDue(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+
+	waited := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		t.Fatal("Expected Wait to block while the job is running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for the running job")
+	}
+
+	if !finished.Load() {
+		t.Fatal("Expected the job to be finished")
+	}
+}
@@ -0,0 +1,400 @@
+package filesystem
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+	"gocloud.dev/blob"
+)
+
+// The metadata keys of the encrypted files.
+const (
+	// MetadataEncryptionKeyId is the id of the keyring key
+	// used to wrap (aka. encrypt) the file data key.
+	MetadataEncryptionKeyId = "encryption-key-id"
+
+	// MetadataEncryptionDataKey is the wrapped file data key.
+	MetadataEncryptionDataKey = "encryption-data-key"
+)
+
+// encryptionChunkSize is the size of a single plaintext chunk.
+//
+// The file content is encrypted in separate AES-256-GCM chunks
+// so that arbitrary byte ranges could be decrypted without
+// reading the entire file.
+const encryptionChunkSize = 64 * 1024
+
+// encryptionTagSize is the AES-GCM authentication tag size appended to each chunk.
+const encryptionTagSize = 16
+
+// ErrMissingKeyring is returned when trying to encrypt or
+// decrypt a file without a filesystem keyring.
+var ErrMissingKeyring = errors.New("missing filesystem encryption keyring")
+
+// Keyring holds the files encryption keys (aka. key encryption keys).
+//
+// The current key is used to wrap the data keys of the newly encrypted files
+// and the previous ones are kept only to unwrap the data keys of the files
+// encrypted before a key rotation.
+type Keyring struct {
+	currentId string
+	keys      map[string]string
+}
+
+// NewKeyring creates a new files encryption keyring from
+// the current and optional previous keys (each must be 32 chars).
+func NewKeyring(current string, previous ...string) (*Keyring, error) {
+	k := &Keyring{keys: map[string]string{}}
+
+	for i, key := range append([]string{current}, previous...) {
+		if len(key) != 32 {
+			return nil, errors.New("the files encryption keys must be 32 characters long")
+		}
+
+		id := KeyId(key)
+		if i == 0 {
+			k.currentId = id
+		}
+		k.keys[id] = key
+	}
+
+	return k, nil
+}
+
+// KeyId returns the public identifier of the provided encryption key.
+func KeyId(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// CurrentId returns the id of the current keyring key.
+func (k *Keyring) CurrentId() string {
+	return k.currentId
+}
+
+// newDataKey generates a new random file data key and returns it
+// together with its wrapped (aka. encrypted with the current key) version.
+func (k *Keyring) newDataKey() ([]byte, string, error) {
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(crand.Reader, dataKey); err != nil {
+		return nil, "", err
+	}
+
+	wrapped, err := k.wrapDataKey(dataKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return dataKey, wrapped, nil
+}
+
+func (k *Keyring) wrapDataKey(dataKey []byte) (string, error) {
+	return security.Encrypt(dataKey, k.keys[k.currentId])
+}
+
+func (k *Keyring) unwrapDataKey(keyId string, wrapped string) ([]byte, error) {
+	key, ok := k.keys[keyId]
+	if !ok {
+		return nil, fmt.Errorf("missing files encryption key with id %q", keyId)
+	}
+
+	return security.Decrypt(wrapped, key)
+}
+
+// IsEncrypted checks whether the file metadata belongs to an encrypted file.
+func IsEncrypted(metadata map[string]string) bool {
+	return metadata[MetadataEncryptionKeyId] != "" && metadata[MetadataEncryptionDataKey] != ""
+}
+
+// -------------------------------------------------------------------
+
+func newChunksCipher(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of the chunk with the specified index.
+//
+// A counter nonce is safe because every file has its own random data key.
+func chunkNonce(index int64) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[4:], uint64(index))
+	return nonce
+}
+
+// chunkAdditionalData marks the last chunk to detect truncated files.
+func chunkAdditionalData(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+// plainSize returns the plaintext size of an encrypted content with the specified size.
+func plainSize(cipherSize int64) int64 {
+	chunks := (cipherSize + encryptionChunkSize + encryptionTagSize - 1) / (encryptionChunkSize + encryptionTagSize)
+
+	return max(cipherSize-chunks*encryptionTagSize, 0)
+}
+
+// encryptWriter is an [io.WriteCloser] that encrypts the written
+// content in chunks and writes them to the underlying writer.
+//
+// Close must be called to flush the last chunk (it doesn't close the underlying writer).
+type encryptWriter struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	buf   []byte
+	index int64
+}
+
+func newEncryptWriter(w io.Writer, dataKey []byte) (*encryptWriter, error) {
+	aead, err := newChunksCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptWriter{
+		w:    w,
+		aead: aead,
+		buf:  make([]byte, 0, encryptionChunkSize),
+	}, nil
+}
+
+// Write implements [io.Writer] interface.
+func (ew *encryptWriter) Write(p []byte) (int, error) {
+	total := len(p)
+
+	for len(p) > 0 {
+		// the full chunks are flushed only on the next write
+		// so that the last chunk could be always marked on Close
+		if len(ew.buf) == encryptionChunkSize {
+			if err := ew.flush(false); err != nil {
+				return 0, err
+			}
+		}
+
+		n := copy(ew.buf[len(ew.buf):encryptionChunkSize], p)
+		ew.buf = ew.buf[:len(ew.buf)+n]
+		p = p[n:]
+	}
+
+	return total, nil
+}
+
+// Close implements [io.Closer] interface.
+func (ew *encryptWriter) Close() error {
+	return ew.flush(true)
+}
+
+func (ew *encryptWriter) flush(last bool) error {
+	sealed := ew.aead.Seal(nil, chunkNonce(ew.index), ew.buf, chunkAdditionalData(last))
+
+	ew.buf = ew.buf[:0]
+	ew.index++
+
+	_, err := ew.w.Write(sealed)
+
+	return err
+}
+
+// encryptedBlobWriter encrypts the written content
+// into the underlying bucket writer.
+type encryptedBlobWriter struct {
+	*encryptWriter
+	bw *blob.Writer
+}
+
+// Close flushes the last encrypted chunk and closes the bucket writer.
+func (w *encryptedBlobWriter) Close() error {
+	if err := w.encryptWriter.Close(); err != nil {
+		w.bw.Close()
+		return err
+	}
+
+	return w.bw.Close()
+}
+
+// decryptReader is an [io.ReadSeeker] for serving a single encrypted bucket file.
+//
+// Only the chunks of the requested byte ranges are read from the storage and decrypted.
+type decryptReader struct {
+	ctx    context.Context
+	bucket *blob.Bucket
+	key    string
+	aead   cipher.AEAD
+
+	size        int64
+	cipherSize  int64
+	modTime     time.Time
+	contentType string
+
+	// offset is the current logical (plaintext) read position
+	offset int64
+
+	// chunk is the currently decrypted chunk (if any)
+	chunk      []byte
+	chunkIndex int64
+
+	// reader is the currently opened bucket reader (if any)
+	// and readerChunk is the index of its next chunk
+	reader      *blob.Reader
+	readerChunk int64
+}
+
+func newDecryptReader(ctx context.Context, bucket *blob.Bucket, key string, attrs *blob.Attributes, keyring *Keyring) (*decryptReader, error) {
+	if keyring == nil {
+		return nil, ErrMissingKeyring
+	}
+
+	dataKey, err := keyring.unwrapDataKey(
+		attrs.Metadata[MetadataEncryptionKeyId],
+		attrs.Metadata[MetadataEncryptionDataKey],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newChunksCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		ctx:         ctx,
+		bucket:      bucket,
+		key:         key,
+		aead:        aead,
+		size:        plainSize(attrs.Size),
+		cipherSize:  attrs.Size,
+		modTime:     attrs.ModTime,
+		contentType: attrs.ContentType,
+		chunkIndex:  -1,
+	}, nil
+}
+
+// ContentType returns the MIME type of the file.
+func (r *decryptReader) ContentType() string {
+	return r.contentType
+}
+
+// ModTime returns the time the file was last modified.
+func (r *decryptReader) ModTime() time.Time {
+	return r.modTime
+}
+
+// ETag returns a strong ETag value of the file
+// based on its modification time and size.
+func (r *decryptReader) ETag() string {
+	return fmt.Sprintf(`"%x-%x"`, r.modTime.UnixNano(), r.size)
+}
+
+// Read implements [io.Reader] interface.
+func (r *decryptReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	index := r.offset / encryptionChunkSize
+	if index != r.chunkIndex {
+		if err := r.loadChunk(index); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.chunk[r.offset-index*encryptionChunkSize:])
+	r.offset += int64(n)
+
+	return n, nil
+}
+
+func (r *decryptReader) loadChunk(index int64) error {
+	const sealedChunkSize = encryptionChunkSize + encryptionTagSize
+
+	if r.reader == nil || r.readerChunk != index {
+		r.closeReader()
+
+		reader, err := r.bucket.NewRangeReader(r.ctx, r.key, index*sealedChunkSize, -1, nil)
+		if err != nil {
+			return err
+		}
+		r.reader = reader
+		r.readerChunk = index
+	}
+
+	sealed := make([]byte, min(sealedChunkSize, r.cipherSize-index*sealedChunkSize))
+	if _, err := io.ReadFull(r.reader, sealed); err != nil {
+		r.closeReader()
+		return err
+	}
+	r.readerChunk++
+
+	last := (index+1)*sealedChunkSize >= r.cipherSize
+
+	chunk, err := r.aead.Open(sealed[:0], chunkNonce(index), sealed, chunkAdditionalData(last))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt file chunk %d: %w", index, err)
+	}
+
+	r.chunk = chunk
+	r.chunkIndex = index
+
+	return nil
+}
+
+// Seek implements [io.Seeker] interface.
+//
+// The bucket reader is reopened lazily on the next Read (if needed).
+func (r *decryptReader) Seek(offset int64, whence int) (int64, error) {
+	var newOffset int64
+
+	switch whence {
+	case io.SeekStart:
+		newOffset = offset
+	case io.SeekCurrent:
+		newOffset = r.offset + offset
+	case io.SeekEnd:
+		newOffset = r.size + offset
+	default:
+		return 0, errors.New("invalid seek whence")
+	}
+
+	if newOffset < 0 {
+		return 0, errors.New("negative seek offset")
+	}
+
+	r.offset = newOffset
+
+	return r.offset, nil
+}
+
+// Close implements [io.Closer] interface.
+func (r *decryptReader) Close() error {
+	return r.closeReader()
+}
+
+func (r *decryptReader) closeReader() error {
+	if r.reader == nil {
+		return nil
+	}
+
+	err := r.reader.Close()
+	r.reader = nil
+
+	return err
+}
@@ -0,0 +1,230 @@
+package filesystem_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+const (
+	testEncryptionKey1 = "12345678901234567890123456789012"
+	testEncryptionKey2 = "abcdefghijklmnopqrstuvwxyz123456"
+)
+
+func TestNewKeyring(t *testing.T) {
+	if _, err := filesystem.NewKeyring("short"); err == nil {
+		t.Fatal("Expected error for invalid current key, got nil")
+	}
+
+	if _, err := filesystem.NewKeyring(testEncryptionKey1, "short"); err == nil {
+		t.Fatal("Expected error for invalid previous key, got nil")
+	}
+
+	keyring, err := filesystem.NewKeyring(testEncryptionKey1, testEncryptionKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keyring.CurrentId() != filesystem.KeyId(testEncryptionKey1) {
+		t.Fatalf("Expected current key id %q, got %q", filesystem.KeyId(testEncryptionKey1), keyring.CurrentId())
+	}
+
+	if filesystem.KeyId(testEncryptionKey1) == filesystem.KeyId(testEncryptionKey2) {
+		t.Fatal("Expected different key ids")
+	}
+}
+
+func TestFileSystemUploadEncrypted(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	fs.SetUploadsEncryption(true)
+
+	// missing keyring
+	if err := fs.Upload([]byte("test"), "missing_keyring.txt"); !errors.Is(err, filesystem.ErrMissingKeyring) {
+		t.Fatalf("Expected ErrMissingKeyring, got %v", err)
+	}
+
+	keyring, err := filesystem.NewKeyring(testEncryptionKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.SetKeyring(keyring)
+
+	// multiple chunks
+	content := bytes.Repeat([]byte("0123456789"), 20000)
+
+	if err := fs.Upload(content, "encrypted.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	attrs, err := fs.Attributes("encrypted.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !filesystem.IsEncrypted(attrs.Metadata) {
+		t.Fatalf("Expected encrypted file metadata, got %v", attrs.Metadata)
+	}
+
+	if attrs.Metadata[filesystem.MetadataEncryptionKeyId] != keyring.CurrentId() {
+		t.Fatalf("Expected key id %q, got %q", keyring.CurrentId(), attrs.Metadata[filesystem.MetadataEncryptionKeyId])
+	}
+
+	// the stored content is encrypted
+	raw, err := os.ReadFile(dir + "/encrypted.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("0123456789")) {
+		t.Fatal("Expected the stored file content to be encrypted")
+	}
+
+	// full serve
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	if err := fs.Serve(res, req, "encrypted.txt", "encrypted.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Body.Bytes(), content) {
+		t.Fatalf("Expected the decrypted content (%d bytes), got %d bytes", len(content), res.Body.Len())
+	}
+
+	// range serve from the second chunk
+	res = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Add("Range", "bytes=70000-70004")
+	if err := fs.Serve(res, req, "encrypted.txt", "encrypted.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if body := res.Body.String(); body != "01234" {
+		t.Fatalf("Expected range body %q, got %q", "01234", body)
+	}
+}
+
+func TestFileSystemEncryptFile(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	if _, err := fs.EncryptFile("image.png"); !errors.Is(err, filesystem.ErrMissingKeyring) {
+		t.Fatalf("Expected ErrMissingKeyring, got %v", err)
+	}
+
+	oldKeyring, err := filesystem.NewKeyring(testEncryptionKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.SetKeyring(oldKeyring)
+
+	original, err := os.ReadFile(dir + "/image.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// encrypt the not encrypted file
+	changed, err := fs.EncryptFile("image.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("Expected the file to be encrypted")
+	}
+
+	// already encrypted with the current key
+	changed, err = fs.EncryptFile("image.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Fatal("Expected the file to be unchanged")
+	}
+
+	// rotate the key
+	newKeyring, err := filesystem.NewKeyring(testEncryptionKey2, testEncryptionKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.SetKeyring(newKeyring)
+
+	changed, err = fs.EncryptFile("image.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("Expected the file data key to be rewrapped")
+	}
+
+	attrs, err := fs.Attributes("image.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attrs.Metadata[filesystem.MetadataEncryptionKeyId] != newKeyring.CurrentId() {
+		t.Fatalf("Expected key id %q, got %q", newKeyring.CurrentId(), attrs.Metadata[filesystem.MetadataEncryptionKeyId])
+	}
+	if attrs.ContentType != "image/png" {
+		t.Fatalf("Expected the content type to be preserved, got %q", attrs.ContentType)
+	}
+
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	if err := fs.Serve(res, req, "image.png", "image.png"); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Body.Bytes(), original) {
+		t.Fatal("Expected the served file to match the original one")
+	}
+
+	// the encrypted file thumb is also encrypted
+	if err := fs.CreateThumb("image.png", "thumb.png", "10x10"); err != nil {
+		t.Fatal(err)
+	}
+
+	thumbAttrs, err := fs.Attributes("thumb.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filesystem.IsEncrypted(thumbAttrs.Metadata) {
+		t.Fatal("Expected the thumb to be encrypted")
+	}
+
+	res = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/", nil)
+	if err := fs.Serve(res, req, "thumb.png", "thumb.png"); err != nil {
+		t.Fatal(err)
+	}
+
+	thumb, err := png.Decode(res.Body)
+	if err != nil {
+		t.Fatalf("Failed to decode the decrypted thumb: %v", err)
+	}
+	if thumb.Bounds() != image.Rect(0, 0, 10, 10) {
+		t.Fatalf("Expected 10x10 thumb, got %v", thumb.Bounds())
+	}
+
+	// missing previous key
+	fs.SetKeyring(oldKeyring)
+
+	res = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/", nil)
+	if err := fs.Serve(res, req, "image.png", "image.png"); err == nil {
+		t.Fatal("Expected serve error for missing key, got nil")
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/transport"
 )
 
 // FileReader defines an interface for a file resource reader.
@@ -89,12 +90,18 @@ func NewFileFromMultipart(mh *multipart.FileHeader) (*File, error) {
 //
 //	file, err := filesystem.NewFileFromUrl(ctx, "https://example.com/image.png")
 func NewFileFromUrl(ctx context.Context, url string) (*File, error) {
+	return NewFileFromUrlWithClient(ctx, &http.Client{Transport: transport.Default}, url)
+}
+
+// NewFileFromUrlWithClient is similar to [NewFileFromUrl] but downloads
+// the resource with the provided http client.
+func NewFileFromUrlWithClient(ctx context.Context, client *http.Client, url string) (*File, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +178,41 @@ func TestNewFileFromUrlTimeout(t *testing.T) {
 	}
 }
 
+func TestNewFileFromUrlWithClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "test")
+	}))
+	defer srv.Close()
+
+	var called bool
+
+	client := &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			called = true
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	}
+
+	f, err := filesystem.NewFileFromUrlWithClient(context.Background(), client, srv.URL+"/test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Fatal("Expected the custom client to be used")
+	}
+
+	if f.Size != 4 {
+		t.Fatalf("Expected Size %v, got %v", 4, f.Size)
+	}
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
 func TestFileNameNormalizations(t *testing.T) {
 	scenarios := []struct {
 		name    string
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -22,6 +23,7 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/transport"
 	"gocloud.dev/blob"
 	"gocloud.dev/blob/fileblob"
 )
@@ -31,6 +33,12 @@ var gcpIgnoreHeaders = []string{"Accept-Encoding"}
 type System struct {
 	ctx    context.Context
 	bucket *blob.Bucket
+
+	// keyring holds the files encryption keys (if any)
+	keyring *Keyring
+
+	// encryptUploads indicates whether the uploaded files should be encrypted
+	encryptUploads bool
 }
 
 // NewS3 initializes an S3 filesystem instance.
@@ -51,6 +59,7 @@ func NewS3(
 	cfg, err := config.LoadDefaultConfig(ctx,
 		config.WithCredentialsProvider(cred),
 		config.WithRegion(region),
+		config.WithHTTPClient(&http.Client{Transport: transport.Default}),
 		config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 			// ensure that the endpoint has url scheme for
 			// backward compatibility with v1 of the aws sdk
@@ -111,6 +120,25 @@ func (s *System) SetContext(ctx context.Context) {
 	s.ctx = ctx
 }
 
+// SetKeyring assigns the files encryption keyring to the current filesystem.
+//
+// The keyring is required to encrypt the uploaded files
+// (see [System.SetUploadsEncryption]) and to transparently
+// decrypt the encrypted ones on [System.Serve] and [System.CreateThumb].
+func (s *System) SetKeyring(keyring *Keyring) {
+	s.keyring = keyring
+}
+
+// SetUploadsEncryption enables or disables the envelope encryption
+// of the files uploaded with the current filesystem instance.
+//
+// Each encrypted file has its own random data key that is wrapped with
+// the current keyring key and stored in the file metadata together with
+// the key id (see [MetadataEncryptionKeyId] and [MetadataEncryptionDataKey]).
+func (s *System) SetUploadsEncryption(enabled bool) {
+	s.encryptUploads = enabled
+}
+
 // Close releases any resources used for the related filesystem.
 func (s *System) Close() error {
 	return s.bucket.Close()
@@ -128,6 +156,8 @@ func (s *System) Attributes(fileKey string) (*blob.Attributes, error) {
 
 // GetFile returns a file content reader for the given fileKey.
 //
+// Note that the content of the encrypted files is returned as it is stored (aka. encrypted).
+//
 // NB! Make sure to call `Close()` after you are done working with it.
 func (s *System) GetFile(fileKey string) (*blob.Reader, error) {
 	br, err := s.bucket.NewReader(s.ctx, fileKey, nil)
@@ -138,6 +168,16 @@ func (s *System) GetFile(fileKey string) (*blob.Reader, error) {
 	return br, nil
 }
 
+// OpenFile returns a file content reader for the given fileKey.
+//
+// Unlike [System.GetFile], if the filesystem has a keyring,
+// the content of the encrypted files is transparently decrypted.
+//
+// NB! Make sure to call `Close()` after you are done working with it.
+func (s *System) OpenFile(fileKey string) (io.ReadCloser, error) {
+	return s.newServeReader(fileKey, "")
+}
+
 // Copy copies the file stored at srcKey to dstKey.
 //
 // If dstKey file already exists, it is overwritten.
@@ -173,7 +213,7 @@ func (s *System) Upload(content []byte, fileKey string) error {
 		ContentType: mimetype.Detect(content).String(),
 	}
 
-	w, writerErr := s.bucket.NewWriter(s.ctx, fileKey, opts)
+	w, writerErr := s.newWriter(fileKey, opts, s.encryptUploads)
 	if writerErr != nil {
 		return writerErr
 	}
@@ -215,12 +255,12 @@ func (s *System) UploadFile(file *File, fileKey string) error {
 		},
 	}
 
-	w, err := s.bucket.NewWriter(s.ctx, fileKey, opts)
+	w, err := s.newWriter(fileKey, opts, s.encryptUploads)
 	if err != nil {
 		return err
 	}
 
-	if _, err := w.ReadFrom(f); err != nil {
+	if _, err := io.Copy(w, f); err != nil {
 		w.Close()
 		return err
 	}
@@ -257,12 +297,12 @@ func (s *System) UploadMultipart(fh *multipart.FileHeader, fileKey string) error
 		},
 	}
 
-	w, err := s.bucket.NewWriter(s.ctx, fileKey, opts)
+	w, err := s.newWriter(fileKey, opts, s.encryptUploads)
 	if err != nil {
 		return err
 	}
 
-	if _, err := w.ReadFrom(f); err != nil {
+	if _, err := io.Copy(w, f); err != nil {
 		w.Close()
 		return err
 	}
@@ -270,6 +310,146 @@ func (s *System) UploadMultipart(fh *multipart.FileHeader, fileKey string) error
 	return w.Close()
 }
 
+// EncryptFile ensures that the file at fileKey location is encrypted
+// with the current keyring key:
+//   - the not encrypted file is encrypted
+//   - the data key of a file encrypted with a previous keyring key
+//     is rewrapped with the current one (the content is not reencrypted)
+//
+// Returns true if the file was changed.
+func (s *System) EncryptFile(fileKey string) (bool, error) {
+	if s.keyring == nil {
+		return false, ErrMissingKeyring
+	}
+
+	attrs, err := s.bucket.Attributes(s.ctx, fileKey)
+	if err != nil {
+		return false, err
+	}
+
+	encrypted := IsEncrypted(attrs.Metadata)
+	if encrypted && attrs.Metadata[MetadataEncryptionKeyId] == s.keyring.CurrentId() {
+		return false, nil // already encrypted with the current key
+	}
+
+	metadata := make(map[string]string, len(attrs.Metadata)+2)
+	for k, v := range attrs.Metadata {
+		metadata[k] = v
+	}
+
+	opts := &blob.WriterOptions{
+		ContentType: attrs.ContentType,
+		Metadata:    metadata,
+	}
+
+	// download the original in a temp file to avoid
+	// reading and writing the same storage object at once
+	tmp, err := os.CreateTemp("", "pb_encrypt_*")
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	r, err := s.bucket.NewReader(s.ctx, fileKey, nil)
+	if err != nil {
+		return false, err
+	}
+	_, err = io.Copy(tmp, r)
+	r.Close()
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	var w io.WriteCloser
+	if encrypted {
+		dataKey, err := s.keyring.unwrapDataKey(metadata[MetadataEncryptionKeyId], metadata[MetadataEncryptionDataKey])
+		if err != nil {
+			return false, err
+		}
+
+		wrapped, err := s.keyring.wrapDataKey(dataKey)
+		if err != nil {
+			return false, err
+		}
+
+		metadata[MetadataEncryptionKeyId] = s.keyring.CurrentId()
+		metadata[MetadataEncryptionDataKey] = wrapped
+
+		w, err = s.newWriter(fileKey, opts, false)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		w, err = s.newWriter(fileKey, opts, true)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := io.Copy(w, tmp); err != nil {
+		w.Close()
+		return false, err
+	}
+
+	if err := w.Close(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// newWriter opens a new bucket writer for the fileKey location.
+//
+// If encrypt is true, the written content is encrypted with a new
+// data key and the wrapped data key is stored in the file metadata.
+func (s *System) newWriter(fileKey string, opts *blob.WriterOptions, encrypt bool) (io.WriteCloser, error) {
+	if !encrypt {
+		return s.bucket.NewWriter(s.ctx, fileKey, opts)
+	}
+
+	if s.keyring == nil {
+		return nil, ErrMissingKeyring
+	}
+
+	dataKey, wrapped, err := s.keyring.newDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// copy the options to avoid modifying the original metadata map
+	encryptedOpts := &blob.WriterOptions{}
+	if opts != nil {
+		*encryptedOpts = *opts
+	}
+	metadata := make(map[string]string, len(encryptedOpts.Metadata)+2)
+	for k, v := range encryptedOpts.Metadata {
+		metadata[k] = v
+	}
+	encryptedOpts.Metadata = metadata
+	encryptedOpts.Metadata[MetadataEncryptionKeyId] = s.keyring.CurrentId()
+	encryptedOpts.Metadata[MetadataEncryptionDataKey] = wrapped
+
+	bw, err := s.bucket.NewWriter(s.ctx, fileKey, encryptedOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	ew, err := newEncryptWriter(bw, dataKey)
+	if err != nil {
+		bw.Close()
+		return nil, err
+	}
+
+	return &encryptedBlobWriter{encryptWriter: ew, bw: bw}, nil
+}
+
 // Delete deletes stored file at fileKey location.
 func (s *System) Delete(fileKey string) error {
 	return s.bucket.Delete(s.ctx, fileKey)
@@ -360,8 +540,13 @@ const forceAttachmentParam = "download"
 //
 // If the `download` query parameter is used the file will be always served for
 // download no matter of its type (aka. with "Content-Disposition: attachment").
+//
+// The response has a strong ETag and the requested byte ranges are read
+// directly from the storage (eg. S3 range requests) without buffering the entire file.
+//
+// If the filesystem has a keyring, the encrypted files are transparently decrypted.
 func (s *System) Serve(res http.ResponseWriter, req *http.Request, fileKey string, name string) error {
-	br, readErr := s.bucket.NewReader(s.ctx, fileKey, nil)
+	br, readErr := s.newServeReader(fileKey, req.Header.Get("Range"))
 	if readErr != nil {
 		return readErr
 	}
@@ -394,11 +579,44 @@ func (s *System) Serve(res http.ResponseWriter, req *http.Request, fileKey strin
 	// that are made in the last day while revalidating the res in the background)
 	setHeaderIfMissing(res, "Cache-Control", "max-age=2592000, stale-while-revalidate=86400")
 
+	// the etag is used also by http.ServeContent for the
+	// If-None-Match, If-Match and If-Range conditional requests
+	setHeaderIfMissing(res, "Etag", br.ETag())
+
 	http.ServeContent(res, req, name, br.ModTime(), br)
 
 	return nil
 }
 
+// serveReader is the common interface of the served file readers.
+type serveReader interface {
+	io.ReadSeekCloser
+	ContentType() string
+	ModTime() time.Time
+	ETag() string
+}
+
+// newServeReader opens a new reader for serving the file at fileKey location.
+func (s *System) newServeReader(fileKey string, rangeHeader string) (serveReader, error) {
+	// the attributes are checked only when there is a keyring
+	// to avoid the extra storage request for the not encrypted files
+	if s.keyring != nil {
+		attrs, err := s.bucket.Attributes(s.ctx, fileKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if IsEncrypted(attrs.Metadata) {
+			return newDecryptReader(s.ctx, s.bucket, fileKey, attrs, s.keyring)
+		}
+	}
+
+	// open the file starting from the first requested byte range (if any)
+	// and reopen it only for the other ranges instead of reading the
+	// entire file from the storage
+	return newRangeReader(s.ctx, s.bucket, fileKey, rangeHeader)
+}
+
 // note: expects key to be in a canonical form (eg. "accept-encoding" should be "Accept-Encoding").
 func setHeaderIfMissing(res http.ResponseWriter, key string, value string) {
 	if _, ok := res.Header()[key]; !ok {
@@ -432,13 +650,16 @@ func (s *System) CreateThumb(originalKey string, thumbKey, thumbSize string) err
 		return errors.New("thumb width and height cannot be zero at the same time")
 	}
 
-	// fetch the original
-	r, readErr := s.bucket.NewReader(s.ctx, originalKey, nil)
+	// fetch the original (decrypting it if necessary)
+	r, readErr := s.newServeReader(originalKey, "")
 	if readErr != nil {
 		return readErr
 	}
 	defer r.Close()
 
+	// the thumbs of the encrypted files are also encrypted
+	_, encrypted := r.(*decryptReader)
+
 	// create imaging object from the original reader
 	// (note: only the first frame for animated image formats)
 	img, decodeErr := imaging.Decode(r, imaging.AutoOrientation(true))
@@ -473,7 +694,7 @@ func (s *System) CreateThumb(originalKey string, thumbKey, thumbSize string) err
 	}
 
 	// open a thumb storage writer (aka. prepare for upload)
-	w, writerErr := s.bucket.NewWriter(s.ctx, thumbKey, opts)
+	w, writerErr := s.newWriter(thumbKey, opts, encrypted)
 	if writerErr != nil {
 		return writerErr
 	}
@@ -585,6 +585,131 @@ func TestFileSystemServeMultiRange(t *testing.T) {
 	}
 }
 
+func TestFileSystemServeETagAndRanges(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	content := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	if err := fs.Upload(content, "range.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	// resolve the file etag
+	etag := func() string {
+		res := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+
+		if err := fs.Serve(res, req, "range.txt", "range.txt"); err != nil {
+			t.Fatal(err)
+		}
+
+		return res.Result().Header.Get("ETag")
+	}()
+	if !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) || len(etag) < 3 {
+		t.Fatalf("Expected strong ETag, got %q", etag)
+	}
+
+	scenarios := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			"full content",
+			nil,
+			http.StatusOK,
+			string(content),
+		},
+		{
+			"matching If-None-Match",
+			map[string]string{"If-None-Match": etag},
+			http.StatusNotModified,
+			"",
+		},
+		{
+			"non-matching If-None-Match",
+			map[string]string{"If-None-Match": `"abc"`},
+			http.StatusOK,
+			string(content),
+		},
+		{
+			"single range",
+			map[string]string{"Range": "bytes=10-15"},
+			http.StatusPartialContent,
+			"abcdef",
+		},
+		{
+			"open-ended range",
+			map[string]string{"Range": "bytes=30-"},
+			http.StatusPartialContent,
+			"uvwxyz",
+		},
+		{
+			"suffix range",
+			map[string]string{"Range": "bytes=-3"},
+			http.StatusPartialContent,
+			"xyz",
+		},
+		{
+			"matching If-Range",
+			map[string]string{"Range": "bytes=0-1", "If-Range": etag},
+			http.StatusPartialContent,
+			"01",
+		},
+		{
+			"non-matching If-Range",
+			map[string]string{"Range": "bytes=0-1", "If-Range": `"abc"`},
+			http.StatusOK,
+			string(content),
+		},
+		{
+			"unsatisfiable range",
+			map[string]string{"Range": "bytes=100-200"},
+			http.StatusRequestedRangeNotSatisfiable,
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			for k, v := range s.headers {
+				req.Header.Set(k, v)
+			}
+
+			if err := fs.Serve(res, req, "range.txt", "range.txt"); err != nil {
+				t.Fatal(err)
+			}
+
+			result := res.Result()
+
+			if result.StatusCode != s.expectedStatus {
+				t.Fatalf("Expected StatusCode %d, got %d", s.expectedStatus, result.StatusCode)
+			}
+
+			if v := result.Header.Get("ETag"); v != etag {
+				t.Fatalf("Expected ETag %q, got %q", etag, v)
+			}
+
+			if s.expectedBody == "" {
+				return
+			}
+
+			if body := res.Body.String(); body != s.expectedBody {
+				t.Fatalf("Expected body %q, got %q", s.expectedBody, body)
+			}
+		})
+	}
+}
+
 func TestFileSystemCreateThumb(t *testing.T) {
 	dir := createTestDir(t)
 	defer os.RemoveAll(dir)
@@ -0,0 +1,263 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gocloud.dev/blob"
+)
+
+// rangeReader is an [io.ReadSeeker] for serving a single bucket file.
+//
+// Unlike [blob.Reader], it passes through the requested byte ranges to
+// the storage driver (eg. S3 "Range" GetObject requests) instead of
+// reading the file from its start or until its end.
+type rangeReader struct {
+	ctx    context.Context
+	bucket *blob.Bucket
+	key    string
+
+	size        int64
+	modTime     time.Time
+	contentType string
+
+	// ranges are the requested byte ranges of the file (if any)
+	// used as length hint when opening a new bucket reader.
+	ranges []byteRange
+
+	// offset is the current logical read position
+	offset int64
+
+	// reader is the currently opened bucket reader (if any)
+	// and readerOffset is its read position
+	reader       *blob.Reader
+	readerOffset int64
+}
+
+// byteRange represents a single [start, end) byte range.
+type byteRange struct {
+	start int64
+	end   int64
+}
+
+// newRangeReader opens a new bucket file reader starting from the
+// first byte range of the provided Range header (if any).
+//
+// The file size, modification time and content type are resolved from the
+// opened reader so that no extra attributes request to the storage is needed.
+func newRangeReader(ctx context.Context, bucket *blob.Bucket, key string, rangeHeader string) (*rangeReader, error) {
+	start, length := firstByteRangeHint(rangeHeader)
+
+	reader, err := bucket.NewRangeReader(ctx, key, start, length, nil)
+	if err != nil && start > 0 {
+		// eg. unsatisfiable range (the actual range validation is left to http.ServeContent)
+		start = 0
+		reader, err = bucket.NewReader(ctx, key, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	size := reader.Size()
+
+	return &rangeReader{
+		ctx:          ctx,
+		bucket:       bucket,
+		key:          key,
+		size:         size,
+		modTime:      reader.ModTime(),
+		contentType:  reader.ContentType(),
+		ranges:       parseByteRanges(rangeHeader, size),
+		reader:       reader,
+		readerOffset: start,
+	}, nil
+}
+
+// ContentType returns the MIME type of the file.
+func (r *rangeReader) ContentType() string {
+	return r.contentType
+}
+
+// ModTime returns the time the file was last modified.
+func (r *rangeReader) ModTime() time.Time {
+	return r.modTime
+}
+
+// ETag returns a strong ETag value of the file
+// based on its modification time and size.
+func (r *rangeReader) ETag() string {
+	return fmt.Sprintf(`"%x-%x"`, r.modTime.UnixNano(), r.size)
+}
+
+// Read implements [io.Reader] interface.
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.reader == nil || r.readerOffset != r.offset {
+		r.closeReader()
+
+		length := int64(-1)
+		for _, br := range r.ranges {
+			if br.start == r.offset {
+				length = br.end - br.start
+				break
+			}
+		}
+
+		reader, err := r.bucket.NewRangeReader(r.ctx, r.key, r.offset, length, nil)
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+		r.readerOffset = r.offset
+	}
+
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	r.readerOffset += int64(n)
+
+	// the current range was fully read but there is still more
+	// data to read from the file (it will be reopened on the next Read)
+	if errors.Is(err, io.EOF) && r.offset < r.size {
+		r.closeReader()
+		err = nil
+	}
+
+	return n, err
+}
+
+// Seek implements [io.Seeker] interface.
+//
+// The bucket reader is reopened lazily on the next Read (if needed).
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	var newOffset int64
+
+	switch whence {
+	case io.SeekStart:
+		newOffset = offset
+	case io.SeekCurrent:
+		newOffset = r.offset + offset
+	case io.SeekEnd:
+		newOffset = r.size + offset
+	default:
+		return 0, errors.New("invalid seek whence")
+	}
+
+	if newOffset < 0 {
+		return 0, errors.New("negative seek offset")
+	}
+
+	r.offset = newOffset
+
+	return r.offset, nil
+}
+
+// Close implements [io.Closer] interface.
+func (r *rangeReader) Close() error {
+	return r.closeReader()
+}
+
+func (r *rangeReader) closeReader() error {
+	if r.reader == nil {
+		return nil
+	}
+
+	err := r.reader.Close()
+	r.reader = nil
+
+	return err
+}
+
+// firstByteRangeHint returns the start offset and length of the first
+// byte range of the provided "Range" header value that could be resolved
+// without knowing the file size (eg. "bytes=10-" or "bytes=10-20").
+//
+// Returns (0, -1), aka. the entire file, for missing or other ranges.
+func firstByteRangeHint(header string) (int64, int64) {
+	header, ok := strings.CutPrefix(header, "bytes=")
+	if !ok {
+		return 0, -1
+	}
+
+	first, _, _ := strings.Cut(header, ",")
+
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(first), "-")
+	if !ok || startStr == "" {
+		return 0, -1
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return 0, -1
+	}
+
+	if endStr == "" {
+		return start, -1
+	}
+
+	end, err := strconv.ParseInt(endStr, 10, 64)
+	if err != nil || end < start {
+		return 0, -1
+	}
+
+	return start, end - start + 1
+}
+
+// parseByteRanges parses the provided "Range" header value
+// (eg. "bytes=0-99,200-" or "bytes=-500").
+//
+// Invalid or unsatisfiable ranges are ignored since they are
+// only used as a hint and the actual validation is left to [http.ServeContent].
+func parseByteRanges(header string, size int64) []byteRange {
+	header, ok := strings.CutPrefix(header, "bytes=")
+	if !ok {
+		return nil
+	}
+
+	var result []byteRange
+
+	for _, part := range strings.Split(header, ",") {
+		startStr, endStr, ok := strings.Cut(strings.TrimSpace(part), "-")
+		if !ok {
+			continue
+		}
+
+		var br byteRange
+
+		if startStr == "" {
+			// suffix range (aka. the last N bytes)
+			n, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil || n <= 0 {
+				continue
+			}
+			br.start = max(size-n, 0)
+			br.end = size
+		} else {
+			start, err := strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 || start >= size {
+				continue
+			}
+			br.start = start
+			br.end = size
+
+			if endStr != "" {
+				end, err := strconv.ParseInt(endStr, 10, 64)
+				if err != nil || end < start {
+					continue
+				}
+				br.end = min(end+1, size)
+			}
+		}
+
+		result = append(result, br)
+	}
+
+	return result
+}
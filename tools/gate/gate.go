@@ -0,0 +1,53 @@
+// Package gate implements a non-blocking concurrency limiter.
+package gate
+
+import "sync"
+
+// Gate limits the number of the simultaneously running operations
+// without queueing the ones over the limit
+// (they are expected to be rejected by the caller).
+//
+// The limit is specified on each [Gate.TryEnter] call so that
+// it could be changed at runtime (eg. from the app settings).
+type Gate struct {
+	mu       sync.Mutex
+	inFlight int
+}
+
+// TryEnter reports whether a new operation is allowed with the
+// specified max in-flight operations limit and if so, increments
+// the in-flight operations counter.
+//
+// Zero or negative limit means no limit.
+//
+// Each successful TryEnter call must be followed by a [Gate.Leave] call.
+func (g *Gate) TryEnter(limit int) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if limit > 0 && g.inFlight >= limit {
+		return false
+	}
+
+	g.inFlight++
+
+	return true
+}
+
+// Leave decrements the in-flight operations counter.
+func (g *Gate) Leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.inFlight > 0 {
+		g.inFlight--
+	}
+}
+
+// InFlight returns the number of the currently running operations.
+func (g *Gate) InFlight() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.inFlight
+}
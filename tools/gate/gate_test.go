@@ -0,0 +1,89 @@
+package gate_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/gate"
+)
+
+func TestGateTryEnterAndLeave(t *testing.T) {
+	g := &gate.Gate{}
+
+	if !g.TryEnter(2) || !g.TryEnter(2) {
+		t.Fatal("Expected the first 2 operations to enter")
+	}
+
+	if g.TryEnter(2) {
+		t.Fatal("Expected the 3rd operation to be rejected")
+	}
+
+	if g.InFlight() != 2 {
+		t.Fatalf("Expected 2 in-flight operations, got %d", g.InFlight())
+	}
+
+	// increased limit
+	if !g.TryEnter(3) {
+		t.Fatal("Expected the operation to enter after the limit increase")
+	}
+
+	g.Leave()
+
+	if !g.TryEnter(3) {
+		t.Fatal("Expected the operation to enter after leave")
+	}
+
+	if g.InFlight() != 3 {
+		t.Fatalf("Expected 3 in-flight operations, got %d", g.InFlight())
+	}
+}
+
+func TestGateNoLimit(t *testing.T) {
+	g := &gate.Gate{}
+
+	for i := 0; i < 100; i++ {
+		if !g.TryEnter(0) {
+			t.Fatalf("[%d] Expected the operation to enter", i)
+		}
+	}
+
+	if g.InFlight() != 100 {
+		t.Fatalf("Expected 100 in-flight operations, got %d", g.InFlight())
+	}
+}
+
+func TestGateLeaveWithoutEnter(t *testing.T) {
+	g := &gate.Gate{}
+
+	g.Leave()
+
+	if g.InFlight() != 0 {
+		t.Fatalf("Expected 0 in-flight operations, got %d", g.InFlight())
+	}
+}
+
+func TestGateConcurrent(t *testing.T) {
+	g := &gate.Gate{}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	entered := 0
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if g.TryEnter(10) {
+				mu.Lock()
+				entered++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if entered != 10 {
+		t.Fatalf("Expected 10 entered operations, got %d", entered)
+	}
+}
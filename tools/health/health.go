@@ -0,0 +1,190 @@
+// Package health implements helpers for running concurrent
+// component health checks (db, storage, smtp, etc.).
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Component health statuses.
+const (
+	StatusOk       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+	StatusDisabled = "disabled"
+)
+
+// ErrDisabled could be returned by a [Check] to mark its component as disabled.
+var ErrDisabled = errors.New("the component is disabled")
+
+// Degraded wraps err to mark the checked component as degraded
+// (aka. working but with issues) instead of down.
+func Degraded(err error) error {
+	return &degradedError{err: err}
+}
+
+type degradedError struct {
+	err error
+}
+
+func (e *degradedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *degradedError) Unwrap() error {
+	return e.err
+}
+
+// Check defines a single component health check function.
+//
+// The returned details (if any) are included in the component result.
+type Check func(ctx context.Context) (details map[string]any, err error)
+
+// Component defines the result of a single component health check.
+type Component struct {
+	Name    string         `json:"name"`
+	Status  string         `json:"status"`
+	Latency float64        `json:"latency"` // in milliseconds
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Failed reports whether the component is down or degraded.
+func (c *Component) Failed() bool {
+	return c.Status == StatusDown || c.Status == StatusDegraded
+}
+
+// Run executes concurrently the provided checks, each limited
+// to the specified timeout (zero or negative means no timeout),
+// and returns their results sorted by name.
+//
+// Panicking checks are reported as down.
+func Run(ctx context.Context, timeout time.Duration, checks map[string]Check) []*Component {
+	result := make([]*Component, 0, len(checks))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, check := range checks {
+		wg.Add(1)
+
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			component := runCheck(ctx, timeout, name, check)
+
+			mu.Lock()
+			result = append(result, component)
+			mu.Unlock()
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func runCheck(ctx context.Context, timeout time.Duration, name string, check Check) *Component {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type checkResult struct {
+		details map[string]any
+		err     error
+	}
+
+	// buffered so that a timed out check doesn't leak a blocked goroutine
+	done := make(chan checkResult, 1)
+
+	start := time.Now()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- checkResult{err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+
+		details, err := check(ctx)
+		done <- checkResult{details, err}
+	}()
+
+	var res checkResult
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		res.err = ctx.Err()
+	}
+
+	component := &Component{
+		Name:    name,
+		Status:  StatusOk,
+		Latency: float64(time.Since(start).Microseconds()) / 1000,
+		Details: res.details,
+	}
+
+	var degraded *degradedError
+
+	switch {
+	case res.err == nil:
+	case errors.Is(res.err, ErrDisabled):
+		component.Status = StatusDisabled
+	case errors.As(res.err, &degraded):
+		component.Status = StatusDegraded
+		component.Error = res.err.Error()
+	default:
+		component.Status = StatusDown
+		component.Error = res.err.Error()
+	}
+
+	return component
+}
+
+// Overall returns the overall status of the provided components.
+//
+// The overall status is:
+//   - [StatusDown] if any of the critical components is down or if the number
+//     of the failed components reaches failureThreshold (zero or negative disables the threshold);
+//   - [StatusDegraded] if any of the components is down or degraded;
+//   - [StatusOk] otherwise.
+func Overall(components []*Component, failureThreshold int, critical ...string) string {
+	var failed int
+
+	for _, c := range components {
+		if !c.Failed() {
+			continue
+		}
+
+		if c.Status == StatusDown {
+			for _, name := range critical {
+				if c.Name == name {
+					return StatusDown
+				}
+			}
+		}
+
+		failed++
+	}
+
+	if failureThreshold > 0 && failed >= failureThreshold {
+		return StatusDown
+	}
+
+	if failed > 0 {
+		return StatusDegraded
+	}
+
+	return StatusOk
+}
@@ -0,0 +1,160 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/health"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	checks := map[string]health.Check{
+		"ok": func(ctx context.Context) (map[string]any, error) {
+			return map[string]any{"test": 123}, nil
+		},
+		"disabled": func(ctx context.Context) (map[string]any, error) {
+			return nil, health.ErrDisabled
+		},
+		"degraded": func(ctx context.Context) (map[string]any, error) {
+			return nil, health.Degraded(errors.New("low space"))
+		},
+		"down": func(ctx context.Context) (map[string]any, error) {
+			return nil, errors.New("unreachable")
+		},
+		"panic": func(ctx context.Context) (map[string]any, error) {
+			panic("test")
+		},
+		"timeout": func(ctx context.Context) (map[string]any, error) {
+			time.Sleep(1 * time.Second)
+			return nil, nil
+		},
+	}
+
+	start := time.Now()
+
+	result := health.Run(context.Background(), 50*time.Millisecond, checks)
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("Expected the slow check to timeout, took %v", elapsed)
+	}
+
+	expected := []struct {
+		name   string
+		status string
+		error  string
+	}{
+		{"degraded", health.StatusDegraded, "low space"},
+		{"disabled", health.StatusDisabled, ""},
+		{"down", health.StatusDown, "unreachable"},
+		{"ok", health.StatusOk, ""},
+		{"panic", health.StatusDown, "panic: test"},
+		{"timeout", health.StatusDown, context.DeadlineExceeded.Error()},
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d components, got %d", len(expected), len(result))
+	}
+
+	for i, e := range expected {
+		c := result[i]
+
+		if c.Name != e.name {
+			t.Fatalf("[%d] Expected component %q, got %q", i, e.name, c.Name)
+		}
+
+		if c.Status != e.status {
+			t.Fatalf("[%s] Expected status %q, got %q", e.name, e.status, c.Status)
+		}
+
+		if c.Error != e.error {
+			t.Fatalf("[%s] Expected error %q, got %q", e.name, e.error, c.Error)
+		}
+	}
+
+	if v := result[3].Details["test"]; v != 123 {
+		t.Fatalf("Expected the ok component details to be set, got %v", result[3].Details)
+	}
+}
+
+func TestOverall(t *testing.T) {
+	t.Parallel()
+
+	newComponents := func(statuses ...string) []*health.Component {
+		result := make([]*health.Component, len(statuses))
+		for i, s := range statuses {
+			result[i] = &health.Component{Name: string(rune('a' + i)), Status: s}
+		}
+		return result
+	}
+
+	scenarios := []struct {
+		name       string
+		components []*health.Component
+		threshold  int
+		critical   []string
+		expected   string
+	}{
+		{
+			"no components",
+			nil,
+			0,
+			nil,
+			health.StatusOk,
+		},
+		{
+			"ok and disabled components",
+			newComponents(health.StatusOk, health.StatusDisabled),
+			1,
+			nil,
+			health.StatusOk,
+		},
+		{
+			"failed components without threshold",
+			newComponents(health.StatusOk, health.StatusDown, health.StatusDegraded),
+			0,
+			nil,
+			health.StatusDegraded,
+		},
+		{
+			"failed components below the threshold",
+			newComponents(health.StatusOk, health.StatusDown, health.StatusDegraded),
+			3,
+			nil,
+			health.StatusDegraded,
+		},
+		{
+			"failed components reaching the threshold",
+			newComponents(health.StatusOk, health.StatusDown, health.StatusDegraded),
+			2,
+			nil,
+			health.StatusDown,
+		},
+		{
+			"down critical component",
+			newComponents(health.StatusDown, health.StatusOk),
+			0,
+			[]string{"a"},
+			health.StatusDown,
+		},
+		{
+			"degraded critical component",
+			newComponents(health.StatusDegraded, health.StatusOk),
+			0,
+			[]string{"a"},
+			health.StatusDegraded,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := health.Overall(s.components, s.threshold, s.critical...)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
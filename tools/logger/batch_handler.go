@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 	"log/slog"
+	"sort"
 	"sync"
 
 	"github.com/pocketbase/pocketbase/tools/types"
@@ -10,6 +11,13 @@ import (
 
 var _ slog.Handler = (*BatchHandler)(nil)
 
+// ModuleAttrKey is the name of the logger attribute used to identify
+// scoped module loggers (eg. logger.With("module", "billing")).
+const ModuleAttrKey = "module"
+
+// badKey is the key used by slog for attribute values without a key.
+const badKey = "!BADKEY"
+
 // BatchOptions are options for the BatchHandler.
 type BatchOptions struct {
 	// WriteFunc processes the batched logs.
@@ -29,6 +37,12 @@ type BatchOptions struct {
 	// BatchSize specifies how many logs to accumulate before calling WriteFunc.
 	// If not set or 0, fallback to 100 by default.
 	BatchSize int
+
+	// ModuleLevel is an optional function that returns the minimum level
+	// of the specified scoped logger module (see [ModuleAttrKey]).
+	//
+	// Return false as second argument to fallback to the default Level.
+	ModuleLevel func(module string) (slog.Level, bool)
 }
 
 // NewBatchHandler creates a slog compatible handler that writes JSON
@@ -80,15 +94,33 @@ type BatchHandler struct {
 	parent  *BatchHandler
 	options *BatchOptions
 	group   string
+	module  string
 	attrs   []slog.Attr
 	logs    []*Log
 }
 
 // Enabled reports whether the handler handles records at the given level.
 //
-// The handler ignores records whose level is lower.
+// The handler ignores records whose level is lower than the configured
+// one for the handler module (if any) or the default options Level.
 func (h *BatchHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	return level >= h.options.Level.Level()
+	return level >= h.MinLevel()
+}
+
+// Module returns the name of the handler scoped module (if any).
+func (h *BatchHandler) Module() string {
+	return h.module
+}
+
+// MinLevel returns the minimum level of the current handler.
+func (h *BatchHandler) MinLevel() slog.Level {
+	if h.module != "" && h.options.ModuleLevel != nil {
+		if level, ok := h.options.ModuleLevel(h.module); ok {
+			return level
+		}
+	}
+
+	return h.options.Level.Level()
 }
 
 // WithGroup returns a new BatchHandler that starts a group.
@@ -103,20 +135,39 @@ func (h *BatchHandler) WithGroup(name string) slog.Handler {
 		parent:  h,
 		mux:     h.mux,
 		options: h.options,
+		module:  h.module,
 		group:   name,
 	}
 }
 
 // WithAttrs returns a new BatchHandler loaded with the specified attributes.
+//
+// Map values without key (eg. logger.With(map[string]any{...}) as it is
+// common when called from the app scripts) are expanded to regular attributes.
+//
+// If the attributes contain [ModuleAttrKey], the returned handler
+// will be scoped to the specified module.
 func (h *BatchHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	attrs = expandAttrs(attrs)
+
 	if len(attrs) == 0 {
 		return h
 	}
 
+	module := h.module
+	if h.group == "" {
+		for _, a := range attrs {
+			if a.Key == ModuleAttrKey {
+				module = a.Value.Resolve().String()
+			}
+		}
+	}
+
 	return &BatchHandler{
 		parent:  h,
 		mux:     h.mux,
 		options: h.options,
+		module:  module,
 		attrs:   attrs,
 	}
 }
@@ -233,6 +284,16 @@ func (h *BatchHandler) resolveAttr(data map[string]any, attr slog.Attr) error {
 		return nil // ignore empty attrs
 	}
 
+	// expand the unnamed map values (eg. logger.Info("msg", map[string]any{...}))
+	if attr.Key == badKey {
+		if m, ok := attr.Value.Any().(map[string]any); ok {
+			for k, v := range m {
+				h.resolveAttr(data, slog.Any(k, v))
+			}
+			return nil
+		}
+	}
+
 	switch attr.Value.Kind() {
 	case slog.KindGroup:
 		attrs := attr.Value.Group()
@@ -262,3 +323,30 @@ func (h *BatchHandler) resolveAttr(data map[string]any, attr slog.Attr) error {
 
 	return nil
 }
+
+// expandAttrs expands the unnamed map attributes (aka. with badKey)
+// into separate key-value attributes.
+func expandAttrs(attrs []slog.Attr) []slog.Attr {
+	result := make([]slog.Attr, 0, len(attrs))
+
+	for _, a := range attrs {
+		if a.Key == badKey {
+			if m, ok := a.Value.Resolve().Any().(map[string]any); ok {
+				keys := make([]string, 0, len(m))
+				for k := range m {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+
+				for _, k := range keys {
+					result = append(result, slog.Any(k, m[k]))
+				}
+				continue
+			}
+		}
+
+		result = append(result, a)
+	}
+
+	return result
+}
@@ -105,6 +105,57 @@ func TestBatchHandlerSetLevel(t *testing.T) {
 	}
 }
 
+func TestBatchHandlerModuleLevel(t *testing.T) {
+	h := NewBatchHandler(BatchOptions{
+		Level: slog.LevelWarn,
+		ModuleLevel: func(module string) (slog.Level, bool) {
+			if module == "billing" {
+				return slog.LevelDebug, true
+			}
+			return 0, false
+		},
+		WriteFunc: func(ctx context.Context, logs []*Log) error {
+			return nil
+		},
+	})
+
+	billing := slog.New(h).With(ModuleAttrKey, "billing")
+	billingGroup := billing.WithGroup("sub")
+	other := slog.New(h).With(ModuleAttrKey, "other")
+	// (note: passed as slice to simulate a script call and to silence the vet slog check)
+	fromMapArgs := []any{map[string]any{ModuleAttrKey: "billing"}}
+	fromMap := slog.New(h).With(fromMapArgs...)
+
+	scenarios := []struct {
+		name     string
+		logger   *slog.Logger
+		level    slog.Level
+		expected bool
+	}{
+		{"root debug", slog.New(h), slog.LevelDebug, false},
+		{"root warn", slog.New(h), slog.LevelWarn, true},
+		{"billing debug", billing, slog.LevelDebug, true},
+		{"billing group debug", billingGroup, slog.LevelDebug, true},
+		{"billing from map debug", fromMap, slog.LevelDebug, true},
+		{"other debug", other, slog.LevelDebug, false},
+		{"other warn", other, slog.LevelWarn, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.logger.Enabled(context.Background(), s.level)
+
+			if result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+
+	if m := billing.Handler().(*BatchHandler).Module(); m != "billing" {
+		t.Fatalf("Expected module billing, got %q", m)
+	}
+}
+
 func TestBatchHandlerWithAttrsAndWithGroup(t *testing.T) {
 	h0 := NewBatchHandler(BatchOptions{
 		WriteFunc: func(ctx context.Context, logs []*Log) error {
@@ -304,6 +355,37 @@ func TestBatchHandlerAttrsFormat(t *testing.T) {
 	}
 }
 
+func TestBatchHandlerUnnamedMapAttrs(t *testing.T) {
+	beforeLogs := []*Log{}
+
+	h := NewBatchHandler(BatchOptions{
+		BeforeAddFunc: func(_ context.Context, log *Log) bool {
+			beforeLogs = append(beforeLogs, log)
+			return true
+		},
+		WriteFunc: func(_ context.Context, logs []*Log) error {
+			return nil
+		},
+	})
+
+	// simulates a script call like $app.logger().with({...}).info("hello", {...})
+	withArgs := []any{map[string]any{"module": "billing", "a": 1}}
+	infoArgs := []any{map[string]any{"b": 2}, "c", 3}
+	l := slog.New(h).With(withArgs...)
+	l.Info("hello", infoArgs...)
+
+	if len(beforeLogs) != 1 {
+		t.Fatalf("Expected 1 log, got %d", len(beforeLogs))
+	}
+
+	expected := `{"a":1,"b":2,"c":3,"module":"billing"}`
+
+	raw, _ := beforeLogs[0].Data.MarshalJSON()
+	if string(raw) != expected {
+		t.Fatalf("Expected \n%s \ngot \n%s", expected, raw)
+	}
+}
+
 func checkLogMessages(expected []string, logs []*Log, t *testing.T) {
 	if len(logs) != len(expected) {
 		t.Fatalf("Expected %d batched logs, got %d (expected: %v)", len(expected), len(logs), expected)
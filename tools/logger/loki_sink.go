@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var _ Sink = (*LokiSink)(nil)
+
+// LokiSink pushes the logs to a Grafana Loki server using its HTTP push API.
+//
+// The logs are grouped in streams by their level and type ("request" or "app")
+// in addition to the configured static labels.
+type LokiSink struct {
+	endpoint string
+	labels   map[string]string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewLokiSink creates a new LokiSink for the specified Loki server url
+// (eg. "http://localhost:3100"; "/loki/api/v1/push" is appended if the url doesn't have a path).
+//
+// Username and password are optional basic auth credentials
+// (if only password is set, it is sent as bearer token).
+func NewLokiSink(rawURL string, labels map[string]string, username string, password string, client *http.Client) (*LokiSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.New("the Loki url must be a valid http or https url")
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/loki/api/v1/push"
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &LokiSink{
+		endpoint: u.String(),
+		labels:   labels,
+		username: username,
+		password: password,
+		client:   client,
+	}, nil
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// WriteLogs implements the [Sink] interface.
+func (s *LokiSink) WriteLogs(ctx context.Context, logs []*Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	streams := []*lokiStream{}
+	streamsIndex := map[string]*lokiStream{}
+
+	for _, l := range logs {
+		logType := "app"
+		if l.Data["type"] == "request" {
+			logType = "request"
+		}
+
+		level := strings.ToLower(l.Level.String())
+
+		key := level + "|" + logType
+
+		stream, ok := streamsIndex[key]
+		if !ok {
+			labels := make(map[string]string, len(s.labels)+2)
+			for k, v := range s.labels {
+				labels[k] = v
+			}
+			labels["level"] = level
+			labels["type"] = logType
+
+			stream = &lokiStream{Stream: labels}
+			streamsIndex[key] = stream
+			streams = append(streams, stream)
+		}
+
+		line, err := l.MarshalJSONLine()
+		if err != nil {
+			return err
+		}
+
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(l.Time.UnixNano(), 10),
+			string(line),
+		})
+	}
+
+	body, err := json.Marshal(map[string]any{"streams": streams})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	} else if s.password != "" {
+		req.Header.Set("Authorization", "Bearer "+s.password)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("failed to push %d logs to Loki (%d): %s", len(logs), res.StatusCode, raw)
+	}
+
+	return nil
+}
+
+// Close implements the [Sink] interface.
+func (s *LokiSink) Close() error {
+	return nil
+}
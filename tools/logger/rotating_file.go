@@ -0,0 +1,207 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatedTimeLayout is the time layout used as suffix for the rotated file names.
+const rotatedTimeLayout = "20060102T150405.000"
+
+// RotatingFileOptions defines the RotatingFile rotation options.
+type RotatingFileOptions struct {
+	// MaxSize is the max file size in bytes before the file gets rotated
+	// (0 means no size limit).
+	MaxSize int64
+
+	// MaxBackups is the max number of rotated files to keep
+	// (0 means no limit).
+	MaxBackups int
+
+	// MaxAge is the max age of the rotated files to keep
+	// (0 means no limit).
+	MaxAge time.Duration
+}
+
+// RotatingFile is an [io.WriteCloser] that writes to a file which is
+// rotated once it reaches the configured max size.
+//
+// The rotated files are stored next to the original one
+// with a timestamp suffix, eg. "app.log" -> "app-20240101T150405.000.log".
+type RotatingFile struct {
+	mux     sync.Mutex
+	path    string
+	options RotatingFileOptions
+	file    *os.File
+	size    int64
+}
+
+// NewRotatingFile creates a new RotatingFile writer for the specified path.
+//
+// The file (and its parent directories) is created lazily on the first write.
+func NewRotatingFile(path string, options RotatingFileOptions) *RotatingFile {
+	return &RotatingFile{
+		path:    path,
+		options: options,
+	}
+}
+
+// Path returns the path of the active log file.
+func (f *RotatingFile) Path() string {
+	return f.path
+}
+
+// SetOptions updates the file rotation options.
+//
+// The new options are applied on the next write.
+func (f *RotatingFile) SetOptions(options RotatingFileOptions) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	f.options = options
+}
+
+// Write implements the [io.Writer] interface.
+//
+// It rotates the file if the write will exceed the configured max size.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.options.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.options.MaxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Rotate closes the active file, renames it with a timestamp suffix
+// and removes the obsolete rotated files.
+func (f *RotatingFile) Rotate() error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.rotate()
+}
+
+// Close implements the [io.Closer] interface.
+func (f *RotatingFile) Close() error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.close()
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+
+	return nil
+}
+
+func (f *RotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	f.size = 0
+
+	return err
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.close(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(f.path); err == nil {
+		if err := os.Rename(f.path, f.rotatedPath(time.Now())); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	return f.cleanup()
+}
+
+func (f *RotatingFile) rotatedPath(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+
+	return base + "-" + t.UTC().Format(rotatedTimeLayout) + ext
+}
+
+// cleanup removes the rotated files that exceed the MaxBackups and MaxAge options.
+func (f *RotatingFile) cleanup() error {
+	if f.options.MaxBackups <= 0 && f.options.MaxAge <= 0 {
+		return nil
+	}
+
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+
+	matches, err := filepath.Glob(base + "-*" + ext)
+	if err != nil {
+		return err
+	}
+
+	// the timestamp suffix is sortable (newest first)
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+
+	var errs []error
+
+	for i, match := range matches {
+		remove := f.options.MaxBackups > 0 && i >= f.options.MaxBackups
+
+		if !remove && f.options.MaxAge > 0 {
+			info, err := os.Stat(match)
+			remove = err == nil && time.Since(info.ModTime()) > f.options.MaxAge
+		}
+
+		if remove {
+			if err := os.Remove(match); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileWrite(t *testing.T) {
+	dir := t.TempDir()
+
+	f := NewRotatingFile(filepath.Join(dir, "nested", "app.log"), RotatingFileOptions{MaxSize: 10})
+	defer f.Close()
+
+	for _, line := range []string{"12345\n", "67890\n", "abc\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	active, err := os.ReadFile(f.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if str := string(active); str != "67890\nabc\n" {
+		t.Fatalf("Expected the active file to contain only the last 2 lines, got %q", str)
+	}
+
+	rotated, _ := filepath.Glob(filepath.Join(dir, "nested", "app-*.log"))
+	if len(rotated) != 1 {
+		t.Fatalf("Expected 1 rotated file, got %v", rotated)
+	}
+
+	rotatedContent, err := os.ReadFile(rotated[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if str := string(rotatedContent); str != "12345\n" {
+		t.Fatalf("Expected the rotated file to contain the first line, got %q", str)
+	}
+}
+
+func TestRotatingFileMaxBackups(t *testing.T) {
+	dir := t.TempDir()
+
+	f := NewRotatingFile(filepath.Join(dir, "app.log"), RotatingFileOptions{})
+	defer f.Close()
+
+	// create some old rotated files
+	for _, name := range []string{"app-20220101T000000.000.log", "app-20220102T000000.000.log", "app-20220103T000000.000.log"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f.SetOptions(RotatingFileOptions{MaxBackups: 2})
+
+	if _, err := f.Write([]byte("test")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, _ := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if len(rotated) != 2 {
+		t.Fatalf("Expected 2 rotated files, got %v", rotated)
+	}
+
+	for _, name := range rotated {
+		if strings.HasSuffix(name, "20220101T000000.000.log") || strings.HasSuffix(name, "20220102T000000.000.log") {
+			t.Fatalf("Expected the oldest rotated files to be removed, got %v", rotated)
+		}
+	}
+}
+
+func TestRotatingFileMaxAge(t *testing.T) {
+	dir := t.TempDir()
+
+	old := filepath.Join(dir, "app-20220101T000000.000.log")
+	if err := os.WriteFile(old, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	oldTime := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(old, oldTime, oldTime); err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewRotatingFile(filepath.Join(dir, "app.log"), RotatingFileOptions{MaxAge: 24 * time.Hour})
+	defer f.Close()
+
+	if err := f.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Fatalf("Expected the old rotated file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// Supported log line formats.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Sink defines a common interface for the external logs destinations
+// (eg. stdout, syslog, Loki, etc.).
+type Sink interface {
+	// WriteLogs writes the provided batch of logs.
+	WriteLogs(ctx context.Context, logs []*Log) error
+
+	// Close releases the sink resources (eg. open connections).
+	Close() error
+}
+
+// MarshalJSONLine returns the log encoded as single JSON line (without the trailing new line).
+func (l *Log) MarshalJSONLine() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"time":    l.Time.UTC().Format(types.DefaultDateLayout),
+		"level":   l.Level.String(),
+		"message": l.Message,
+		"data":    l.Data,
+	})
+}
+
+// TextLine returns the log formatted as single
+// "time level message key=value..." text line.
+func (l *Log) TextLine() string {
+	var str strings.Builder
+
+	str.WriteString(l.Time.UTC().Format(types.DefaultDateLayout))
+	str.WriteString(" ")
+	str.WriteString(l.Level.String())
+	str.WriteString(" ")
+	str.WriteString(l.Message)
+
+	keys := make([]string, 0, len(l.Data))
+	for k := range l.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		var value string
+
+		switch v := l.Data[k].(type) {
+		case string:
+			value = v
+		case nil:
+			continue
+		default:
+			if raw, err := json.Marshal(v); err == nil {
+				value = string(raw)
+			} else {
+				value = fmt.Sprint(v)
+			}
+		}
+
+		if strings.ContainsAny(value, " \t\r\n\"=") {
+			value = fmt.Sprintf("%q", value)
+		}
+
+		str.WriteString(" ")
+		str.WriteString(k)
+		str.WriteString("=")
+		str.WriteString(value)
+	}
+
+	return str.String()
+}
+
+// -------------------------------------------------------------------
+
+var _ Sink = (*WriterSink)(nil)
+
+// WriterSink writes the logs as JSON or text lines into an [io.Writer] (eg. os.Stdout).
+type WriterSink struct {
+	mux    sync.Mutex
+	writer io.Writer
+	format string
+}
+
+// NewWriterSink creates a new WriterSink with the specified
+// format ("json" or "text", fallbacks to "json").
+func NewWriterSink(writer io.Writer, format string) *WriterSink {
+	if format != FormatText {
+		format = FormatJSON
+	}
+
+	return &WriterSink{writer: writer, format: format}
+}
+
+// WriteLogs implements the [Sink] interface.
+func (s *WriterSink) WriteLogs(ctx context.Context, logs []*Log) error {
+	var buf strings.Builder
+
+	for _, l := range logs {
+		if s.format == FormatText {
+			buf.WriteString(l.TextLine())
+		} else {
+			raw, err := l.MarshalJSONLine()
+			if err != nil {
+				return err
+			}
+			buf.Write(raw)
+		}
+		buf.WriteString("\n")
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	_, err := io.WriteString(s.writer, buf.String())
+
+	return err
+}
+
+// Close implements the [Sink] interface.
+//
+// Note that the underlying writer is not closed.
+func (s *WriterSink) Close() error {
+	return nil
+}
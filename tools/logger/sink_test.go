@@ -0,0 +1,201 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func testSinkLogs() []*Log {
+	logTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	return []*Log{
+		{
+			Time:    logTime,
+			Message: "GET /api/test",
+			Level:   slog.LevelInfo,
+			Data:    types.JsonMap{"type": "request", "status": 200, "url": "/api/test"},
+		},
+		{
+			Time:    logTime,
+			Message: "app error",
+			Level:   slog.LevelError,
+			Data:    types.JsonMap{"error": "something went wrong"},
+		},
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	scenarios := []struct {
+		format   string
+		expected string
+	}{
+		{
+			"",
+			`{"data":{"status":200,"type":"request","url":"/api/test"},"level":"INFO","message":"GET /api/test","time":"2024-01-02 03:04:05.000Z"}` + "\n" +
+				`{"data":{"error":"something went wrong"},"level":"ERROR","message":"app error","time":"2024-01-02 03:04:05.000Z"}` + "\n",
+		},
+		{
+			"text",
+			`2024-01-02 03:04:05.000Z INFO GET /api/test status=200 type=request url=/api/test` + "\n" +
+				`2024-01-02 03:04:05.000Z ERROR app error error="something went wrong"` + "\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.format, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			sink := NewWriterSink(&buf, s.format)
+
+			if err := sink.WriteLogs(context.Background(), testSinkLogs()); err != nil {
+				t.Fatal(err)
+			}
+
+			if str := buf.String(); str != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, str)
+			}
+		})
+	}
+}
+
+func TestSyslogSink(t *testing.T) {
+	if _, err := NewSyslogSink("invalid", "localhost:514", ""); err == nil {
+		t.Fatal("Expected unsupported network error")
+	}
+
+	if _, err := NewSyslogSink("udp", "", ""); err == nil {
+		t.Fatal("Expected missing address error")
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := NewSyslogSink("", conn.LocalAddr().String(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.WriteLogs(context.Background(), testSinkLogs()); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPrefixes := []string{
+		"<14>1 2024-01-02T03:04:05Z ",
+		"<11>1 2024-01-02T03:04:05Z ",
+	}
+
+	for i, prefix := range expectedPrefixes {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		buf := make([]byte, 2048)
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg := string(buf[:n])
+
+		if !strings.HasPrefix(msg, prefix) {
+			t.Fatalf("[%d] Expected message to start with %q, got %q", i, prefix, msg)
+		}
+
+		if !strings.Contains(msg, " test ") {
+			t.Fatalf("[%d] Expected the tag to be set, got %q", i, msg)
+		}
+
+		if i == 0 && !strings.Contains(msg, " request - {") {
+			t.Fatalf("[%d] Expected request msgid, got %q", i, msg)
+		}
+	}
+}
+
+func TestLokiSink(t *testing.T) {
+	if _, err := NewLokiSink("invalid", nil, "", "", nil); err == nil {
+		t.Fatal("Expected invalid url error")
+	}
+
+	var body []byte
+	var path string
+	var auth string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		auth = r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := NewLokiSink(server.URL, map[string]string{"app": "test"}, "", "123", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sink.WriteLogs(context.Background(), testSinkLogs()); err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/loki/api/v1/push" {
+		t.Fatalf("Expected the default push path, got %q", path)
+	}
+
+	if auth != "Bearer 123" {
+		t.Fatalf("Expected bearer token auth, got %q", auth)
+	}
+
+	payload := struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payload.Streams) != 2 {
+		t.Fatalf("Expected 2 streams, got %s", body)
+	}
+
+	expectedLabels := []string{"app=test level=info type=request", "app=test level=error type=app"}
+	for i, stream := range payload.Streams {
+		labels := "app=" + stream.Stream["app"] + " level=" + stream.Stream["level"] + " type=" + stream.Stream["type"]
+		if labels != expectedLabels[i] {
+			t.Fatalf("[%d] Expected labels %q, got %q", i, expectedLabels[i], labels)
+		}
+
+		if len(stream.Values) != 1 || stream.Values[0][0] != "1704164645000000000" {
+			t.Fatalf("[%d] Unexpected stream values %v", i, stream.Values)
+		}
+	}
+
+	// error response
+	errServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer errServer.Close()
+
+	errSink, err := NewLokiSink(errServer.URL+"/custom/push", nil, "user", "pass", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := errSink.WriteLogs(context.Background(), testSinkLogs()); err == nil {
+		t.Fatal("Expected push error")
+	}
+}
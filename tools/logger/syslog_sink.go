@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var _ Sink = (*SyslogSink)(nil)
+
+// syslogFacilityUser is the "user-level messages" syslog facility.
+const syslogFacilityUser = 1
+
+// SyslogSink sends the logs to a syslog server as RFC 5424 messages
+// (with JSON encoded message body).
+//
+// It supports "udp", "tcp" (with octet counting framing) and "unixgram" networks.
+type SyslogSink struct {
+	mux      sync.Mutex
+	network  string
+	address  string
+	tag      string
+	hostname string
+	conn     net.Conn
+}
+
+// NewSyslogSink creates a new SyslogSink for the specified server
+// network and address (eg. "udp", "localhost:514").
+//
+// The tag is used as syslog APP-NAME (default to "pocketbase").
+func NewSyslogSink(network string, address string, tag string) (*SyslogSink, error) {
+	switch network {
+	case "udp", "tcp", "unixgram":
+	case "":
+		network = "udp"
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q", network)
+	}
+
+	if address == "" {
+		return nil, errors.New("missing syslog server address")
+	}
+
+	if tag == "" {
+		tag = "pocketbase"
+	}
+
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+
+	return &SyslogSink{
+		network:  network,
+		address:  address,
+		tag:      tag,
+		hostname: hostname,
+	}, nil
+}
+
+// WriteLogs implements the [Sink] interface.
+//
+// The connection is lazily (re)established on write.
+func (s *SyslogSink) WriteLogs(ctx context.Context, logs []*Log) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, l := range logs {
+		msg, err := s.format(l)
+		if err != nil {
+			return err
+		}
+
+		if err := s.write(msg); err != nil {
+			// retry once with a new connection
+			s.closeConn()
+			if err := s.write(msg); err != nil {
+				s.closeConn()
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close implements the [Sink] interface.
+func (s *SyslogSink) Close() error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.closeConn()
+}
+
+func (s *SyslogSink) write(msg []byte) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, 10*time.Second)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+
+	if s.network == "tcp" {
+		// octet counting framing (RFC 6587)
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+
+	_, err := s.conn.Write(msg)
+
+	return err
+}
+
+func (s *SyslogSink) closeConn() error {
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+// format returns the RFC 5424 formatted log message:
+// "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID - MSG".
+func (s *SyslogSink) format(l *Log) ([]byte, error) {
+	body, err := l.MarshalJSONLine()
+	if err != nil {
+		return nil, err
+	}
+
+	msgId := "-"
+	if t, ok := l.Data["type"].(string); ok && t != "" {
+		msgId = t
+	}
+
+	header := fmt.Sprintf(
+		"<%d>1 %s %s %s %d %s - ",
+		syslogFacilityUser*8+syslogSeverity(l.Level),
+		l.Time.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		s.tag,
+		os.Getpid(),
+		msgId,
+	)
+
+	return append([]byte(header), body...), nil
+}
+
+// syslogSeverity maps the slog level to the closest syslog severity.
+func syslogSeverity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return 3 // error
+	case level >= slog.LevelWarn:
+		return 4 // warning
+	case level >= slog.LevelInfo:
+		return 6 // informational
+	default:
+		return 7 // debug
+	}
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the default max number of
+// messages that are sent in parallel by [SendBatch].
+const DefaultBatchConcurrency = 5
+
+// SendBatch sends the provided messages using the specified mail client.
+//
+// Up to concurrency messages are sent in parallel (if concurrency <= 0,
+// [DefaultBatchConcurrency] is used). A failed message doesn't stop
+// the remaining ones and all send errors are returned joined together.
+func SendBatch(client Mailer, messages []*Message, concurrency int) error {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	var mux sync.Mutex
+	var errs []error
+
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, m := range messages {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, m *Message) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := client.Send(m); err != nil {
+				mux.Lock()
+				errs = append(errs, fmt.Errorf("message %d: %w", i, err))
+				mux.Unlock()
+			}
+		}(i, m)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
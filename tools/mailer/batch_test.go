@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testBatchMailer struct {
+	mux  sync.Mutex
+	sent []string
+}
+
+func (m *testBatchMailer) Send(message *Message) error {
+	if message.Subject == "fail" {
+		return errors.New("test error")
+	}
+
+	m.mux.Lock()
+	m.sent = append(m.sent, message.Subject)
+	m.mux.Unlock()
+
+	return nil
+}
+
+func TestSendBatch(t *testing.T) {
+	client := &testBatchMailer{}
+
+	messages := []*Message{
+		{Subject: "a"},
+		{Subject: "fail"},
+		{Subject: "b"},
+		{Subject: "c"},
+	}
+
+	err := SendBatch(client, messages, 2)
+	if err == nil || !strings.Contains(err.Error(), "message 1: test error") {
+		t.Fatalf("Expected message 1 error, got %v", err)
+	}
+
+	if len(client.sent) != 3 {
+		t.Fatalf("Expected 3 sent messages, got %v", client.sent)
+	}
+
+	if err := SendBatch(client, nil, 0); err != nil {
+		t.Fatalf("Expected nil error for empty batch, got %v", err)
+	}
+}
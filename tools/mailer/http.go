@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+// Supported HTTP API mail providers.
+const (
+	ProviderSendGrid = "sendgrid"
+	ProviderMailgun  = "mailgun"
+	ProviderSes      = "ses"
+)
+
+// plainTextContent returns the message plain text content
+// (generated from the HTML body if not explicitly set).
+func plainTextContent(m *Message) string {
+	if m.Text != "" {
+		return m.Text
+	}
+
+	plain, _ := html2Text(m.HTML)
+
+	return plain
+}
+
+// checkResponse returns an error if res has a non 2xx status code.
+func checkResponse(provider string, res *http.Response) error {
+	if err := rest.CheckResponse(res); err != nil {
+		return fmt.Errorf("%s: failed to send the email %w", provider, err)
+	}
+
+	return nil
+}
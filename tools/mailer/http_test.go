@@ -0,0 +1,19 @@
+package mailer
+
+import (
+	"io"
+	"net/mail"
+	"strings"
+)
+
+func testMessage() *Message {
+	return &Message{
+		From:        mail.Address{Name: "Support", Address: "support@example.com"},
+		To:          []mail.Address{{Name: "Test", Address: "test@example.com"}},
+		Bcc:         []mail.Address{{Address: "bcc@example.com"}},
+		Subject:     "Test subject",
+		HTML:        "<p>Test html</p>",
+		Headers:     map[string]string{"X-Custom": "123"},
+		Attachments: map[string]io.Reader{"a.txt": strings.NewReader("attachment")},
+	}
+}
@@ -0,0 +1,116 @@
+package mailer
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+var _ Mailer = (*MailgunClient)(nil)
+
+// MailgunClient defines a Mailgun API mail client
+// that implements `mailer.Mailer` interface.
+type MailgunClient struct {
+	ApiKey string
+
+	// Domain is the Mailgun sending domain.
+	Domain string
+
+	// Region is the optional Mailgun account region ("us" or "eu")
+	// (if not explicitly set, defaults to "us").
+	Region string
+
+	// BaseUrl is the optional API base url
+	// (if set, it takes precedence over the region base url).
+	BaseUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+// Send implements `mailer.Mailer` interface.
+func (c *MailgunClient) Send(m *Message) error {
+	body := &bytes.Buffer{}
+	mp := multipart.NewWriter(body)
+
+	fields := [][2]string{
+		{"from", m.From.String()},
+		{"subject", m.Subject},
+		{"html", m.HTML},
+	}
+	if m.From.Name == "" {
+		fields[0][1] = m.From.Address
+	}
+	if text := plainTextContent(m); text != "" {
+		fields = append(fields, [2]string{"text", text})
+	}
+	for _, addr := range addressesToStrings(m.To, true) {
+		fields = append(fields, [2]string{"to", addr})
+	}
+	for _, addr := range addressesToStrings(m.Cc, true) {
+		fields = append(fields, [2]string{"cc", addr})
+	}
+	for _, addr := range addressesToStrings(m.Bcc, true) {
+		fields = append(fields, [2]string{"bcc", addr})
+	}
+	for k, v := range m.Headers {
+		fields = append(fields, [2]string{"h:" + k, v})
+	}
+
+	for _, f := range fields {
+		if err := mp.WriteField(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+
+	for name, r := range m.Attachments {
+		w, err := mp.CreateFormFile("attachment", name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(w, r); err != nil {
+			return err
+		}
+	}
+
+	if err := mp.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		c.baseUrl()+"/v3/"+url.PathEscape(c.Domain)+"/messages",
+		body,
+	)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("api", c.ApiKey)
+	req.Header.Set("Content-Type", mp.FormDataContentType())
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderMailgun, res)
+}
+
+func (c *MailgunClient) baseUrl() string {
+	if c.BaseUrl != "" {
+		return strings.TrimRight(c.BaseUrl, "/")
+	}
+
+	if strings.EqualFold(c.Region, "eu") {
+		return "https://api.eu.mailgun.net"
+	}
+
+	return "https://api.mailgun.net"
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func TestMailgunClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 200, `{"message":"test"}`)
+
+	client := &MailgunClient{ApiKey: "test_key", Domain: "example.com", BaseUrl: server.URL}
+
+	if err := client.Send(testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/v3/example.com/messages" {
+		t.Fatalf("Unexpected path %q", captured.Path)
+	}
+
+	if auth := captured.Header.Get("Authorization"); auth != "Basic "+base64.StdEncoding.EncodeToString([]byte("api:test_key")) {
+		t.Fatalf("Unexpected Authorization header %q", auth)
+	}
+
+	expectedFields := map[string]string{
+		"from":            `"Support" <support@example.com>`,
+		"to":              `"Test" <test@example.com>`,
+		"bcc":             "bcc@example.com",
+		"subject":         "Test subject",
+		"html":            "<p>Test html</p>",
+		"text":            "Test html",
+		"h:X-Custom":      "123",
+		"file:attachment": "a.txt",
+	}
+	for k, v := range expectedFields {
+		if len(captured.Form[k]) != 1 || captured.Form[k][0] != v {
+			t.Fatalf("Expected field %q to be %q, got %v", k, v, captured.Form[k])
+		}
+	}
+
+	// region base url
+	if url := (&MailgunClient{Region: "EU"}).baseUrl(); url != "https://api.eu.mailgun.net" {
+		t.Fatalf("Unexpected eu base url %q", url)
+	}
+	if url := (&MailgunClient{}).baseUrl(); url != "https://api.mailgun.net" {
+		t.Fatalf("Unexpected default base url %q", url)
+	}
+}
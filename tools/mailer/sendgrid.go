@@ -0,0 +1,127 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/mail"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+var _ Mailer = (*SendGridClient)(nil)
+
+// SendGridClient defines a SendGrid Web API v3 mail client
+// that implements `mailer.Mailer` interface.
+type SendGridClient struct {
+	ApiKey string
+
+	// BaseUrl is the optional API base url
+	// (if not explicitly set, defaults to "https://api.sendgrid.com").
+	BaseUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To  []sendGridAddress `json:"to"`
+	Cc  []sendGridAddress `json:"cc,omitempty"`
+	Bcc []sendGridAddress `json:"bcc,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content  string `json:"content"`
+	Filename string `json:"filename"`
+}
+
+type sendGridPayload struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+}
+
+// Send implements `mailer.Mailer` interface.
+func (c *SendGridClient) Send(m *Message) error {
+	payload := sendGridPayload{
+		Personalizations: []sendGridPersonalization{{
+			To:  toSendGridAddresses(m.To),
+			Cc:  toSendGridAddresses(m.Cc),
+			Bcc: toSendGridAddresses(m.Bcc),
+		}},
+		From:    sendGridAddress{Email: m.From.Address, Name: m.From.Name},
+		Subject: m.Subject,
+		Headers: m.Headers,
+	}
+
+	// the plain text content must be first
+	if text := plainTextContent(m); text != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/plain", Value: text})
+	}
+	payload.Content = append(payload.Content, sendGridContent{Type: "text/html", Value: m.HTML})
+
+	for name, r := range m.Attachments {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		payload.Attachments = append(payload.Attachments, sendGridAttachment{
+			Filename: name,
+			Content:  base64.StdEncoding.EncodeToString(data),
+		})
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		baseUrl = "https://api.sendgrid.com"
+	}
+
+	req, err := http.NewRequest(http.MethodPost, baseUrl+"/v3/mail/send", bytes.NewReader(rawPayload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.ApiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderSendGrid, res)
+}
+
+func toSendGridAddresses(addresses []mail.Address) []sendGridAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	result := make([]sendGridAddress, len(addresses))
+	for i, addr := range addresses {
+		result[i] = sendGridAddress{Email: addr.Address, Name: addr.Name}
+	}
+
+	return result
+}
@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func TestSendGridClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 202, `{"message":"test"}`)
+
+	client := &SendGridClient{ApiKey: "test_key", BaseUrl: server.URL}
+
+	if err := client.Send(testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/v3/mail/send" {
+		t.Fatalf("Unexpected path %q", captured.Path)
+	}
+
+	if auth := captured.Header.Get("Authorization"); auth != "Bearer test_key" {
+		t.Fatalf("Unexpected Authorization header %q", auth)
+	}
+
+	payload := sendGridPayload{}
+	if err := json.Unmarshal(captured.Body, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Subject != "Test subject" || payload.From.Email != "support@example.com" {
+		t.Fatalf("Unexpected payload %s", captured.Body)
+	}
+
+	if len(payload.Personalizations) != 1 ||
+		payload.Personalizations[0].To[0].Email != "test@example.com" ||
+		payload.Personalizations[0].Bcc[0].Email != "bcc@example.com" ||
+		len(payload.Personalizations[0].Cc) != 0 {
+		t.Fatalf("Unexpected personalizations %v", payload.Personalizations)
+	}
+
+	if len(payload.Content) != 2 || payload.Content[0].Type != "text/plain" || payload.Content[0].Value != "Test html" {
+		t.Fatalf("Unexpected content %v", payload.Content)
+	}
+
+	if len(payload.Attachments) != 1 ||
+		payload.Attachments[0].Filename != "a.txt" ||
+		payload.Attachments[0].Content != base64.StdEncoding.EncodeToString([]byte("attachment")) {
+		t.Fatalf("Unexpected attachments %v", payload.Attachments)
+	}
+
+	if payload.Headers["X-Custom"] != "123" {
+		t.Fatalf("Unexpected headers %v", payload.Headers)
+	}
+
+	// error response
+	errServer, _ := httpmock.NewServer(t, 400, `{"message":"test"}`)
+	client.BaseUrl = errServer.URL
+	if err := client.Send(testMessage()); err == nil || !strings.Contains(err.Error(), "(400)") {
+		t.Fatalf("Expected 400 error, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/domodwyer/mailyak/v3"
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+var _ Mailer = (*SesClient)(nil)
+
+// SesClient defines an Amazon SES (API v2) mail client
+// that implements `mailer.Mailer` interface.
+type SesClient struct {
+	AccessKey string
+	SecretKey string
+	Region    string
+
+	// BaseUrl is the optional API base url
+	// (if not explicitly set, defaults to "https://email.{Region}.amazonaws.com").
+	BaseUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+type sesPayload struct {
+	Destination struct {
+		ToAddresses  []string `json:"ToAddresses,omitempty"`
+		CcAddresses  []string `json:"CcAddresses,omitempty"`
+		BccAddresses []string `json:"BccAddresses,omitempty"`
+	} `json:"Destination"`
+	Content struct {
+		Raw struct {
+			Data []byte `json:"Data"`
+		} `json:"Raw"`
+	} `json:"Content"`
+}
+
+// Send implements `mailer.Mailer` interface.
+func (c *SesClient) Send(m *Message) error {
+	yak := mailyak.New("", nil)
+	populateMailYak(yak, m)
+
+	mime, err := yak.MimeBuf()
+	if err != nil {
+		return err
+	}
+
+	payload := sesPayload{}
+	payload.Destination.ToAddresses = addressesToStrings(m.To, true)
+	payload.Destination.CcAddresses = addressesToStrings(m.Cc, true)
+	payload.Destination.BccAddresses = addressesToStrings(m.Bcc, true)
+	payload.Content.Raw.Data = mime.Bytes() // base64 encoded by the json marshaler
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		baseUrl = "https://email." + c.Region + ".amazonaws.com"
+	}
+
+	req, err := http.NewRequest(http.MethodPost, baseUrl+"/v2/email/outbound-emails", bytes.NewReader(rawPayload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	payloadHash := sha256.Sum256(rawPayload)
+
+	err = v4.NewSigner().SignHTTP(
+		context.Background(),
+		aws.Credentials{AccessKeyID: c.AccessKey, SecretAccessKey: c.SecretKey},
+		req,
+		hex.EncodeToString(payloadHash[:]),
+		"ses",
+		c.Region,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderSes, res)
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func TestSesClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 200, `{"message":"test"}`)
+
+	client := &SesClient{
+		AccessKey: "test_access",
+		SecretKey: "test_secret",
+		Region:    "eu-west-1",
+		BaseUrl:   server.URL,
+	}
+
+	if err := client.Send(testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/v2/email/outbound-emails" {
+		t.Fatalf("Unexpected path %q", captured.Path)
+	}
+
+	auth := captured.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=test_access/") || !strings.Contains(auth, "/eu-west-1/ses/aws4_request") {
+		t.Fatalf("Unexpected Authorization header %q", auth)
+	}
+
+	payload := sesPayload{}
+	if err := json.Unmarshal(captured.Body, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(payload.Destination.BccAddresses) != 1 || payload.Destination.BccAddresses[0] != "bcc@example.com" {
+		t.Fatalf("Unexpected destination %v", payload.Destination)
+	}
+
+	raw := string(payload.Content.Raw.Data)
+	expectedParts := []string{"Subject: Test subject", "X-Custom: 123", "a.txt", "Test html"}
+	for _, part := range expectedParts {
+		if !strings.Contains(raw, part) {
+			t.Fatalf("Couldn't find %q in the raw message:\n%s", part, raw)
+		}
+	}
+}
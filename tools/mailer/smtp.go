@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/domodwyer/mailyak/v3"
-	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 var _ Mailer = (*SmtpClient)(nil)
@@ -84,57 +83,7 @@ func (c *SmtpClient) Send(m *Message) error {
 		yak.LocalName(c.LocalName)
 	}
 
-	if m.From.Name != "" {
-		yak.FromName(m.From.Name)
-	}
-	yak.From(m.From.Address)
-	yak.Subject(m.Subject)
-	yak.HTML().Set(m.HTML)
-
-	if m.Text == "" {
-		// try to generate a plain text version of the HTML
-		if plain, err := html2Text(m.HTML); err == nil {
-			yak.Plain().Set(plain)
-		}
-	} else {
-		yak.Plain().Set(m.Text)
-	}
-
-	if len(m.To) > 0 {
-		yak.To(addressesToStrings(m.To, true)...)
-	}
-
-	if len(m.Bcc) > 0 {
-		yak.Bcc(addressesToStrings(m.Bcc, true)...)
-	}
-
-	if len(m.Cc) > 0 {
-		yak.Cc(addressesToStrings(m.Cc, true)...)
-	}
-
-	// add attachements (if any)
-	for name, data := range m.Attachments {
-		yak.Attach(name, data)
-	}
-
-	// add custom headers (if any)
-	var hasMessageId bool
-	for k, v := range m.Headers {
-		if strings.EqualFold(k, "Message-ID") {
-			hasMessageId = true
-		}
-		yak.AddHeader(k, v)
-	}
-	if !hasMessageId {
-		// add a default message id if missing
-		fromParts := strings.Split(m.From.Address, "@")
-		if len(fromParts) == 2 {
-			yak.AddHeader("Message-ID", fmt.Sprintf("<%s@%s>",
-				security.PseudorandomString(15),
-				fromParts[1],
-			))
-		}
-	}
+	populateMailYak(yak, m)
 
 	return yak.Send()
 }
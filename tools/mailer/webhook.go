@@ -0,0 +1,238 @@
+package mailer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Normalized webhook event types.
+const (
+	WebhookEventDelivered = "delivered"
+	WebhookEventBounce    = "bounce"
+	WebhookEventComplaint = "complaint"
+	WebhookEventDropped   = "dropped"
+	WebhookEventDeferred  = "deferred"
+)
+
+// WebhookEvent defines a single normalized mail provider webhook event
+// (eg. bounce or complaint notification).
+type WebhookEvent struct {
+	// Type is the normalized event type (see the WebhookEvent* constants).
+	// Events that don't have a normalized type keep the provider event name.
+	Type      string         `json:"type"`
+	Email     string         `json:"email"`
+	MessageId string         `json:"messageId"`
+	Reason    string         `json:"reason"`
+	Date      time.Time      `json:"date"`
+	Raw       map[string]any `json:"raw"`
+}
+
+// ParseSendGridWebhook parses a SendGrid Event Webhook request body.
+func ParseSendGridWebhook(body []byte) ([]*WebhookEvent, error) {
+	items := []map[string]any{}
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, err
+	}
+
+	result := make([]*WebhookEvent, 0, len(items))
+
+	for _, item := range items {
+		event := &WebhookEvent{
+			Email:     stringValue(item["email"]),
+			MessageId: stringValue(item["sg_message_id"]),
+			Reason:    stringValue(item["reason"]),
+			Raw:       item,
+		}
+
+		if ts, ok := item["timestamp"].(float64); ok {
+			event.Date = time.Unix(int64(ts), 0).UTC()
+		}
+
+		switch name := stringValue(item["event"]); name {
+		case "delivered":
+			event.Type = WebhookEventDelivered
+		case "bounce":
+			event.Type = WebhookEventBounce
+		case "spamreport":
+			event.Type = WebhookEventComplaint
+		case "dropped":
+			event.Type = WebhookEventDropped
+		case "deferred":
+			event.Type = WebhookEventDeferred
+		default:
+			event.Type = name
+		}
+
+		result = append(result, event)
+	}
+
+	return result, nil
+}
+
+// ParseMailgunWebhook parses a Mailgun webhook request body.
+func ParseMailgunWebhook(body []byte) ([]*WebhookEvent, error) {
+	payload := struct {
+		EventData map[string]any `json:"event-data"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	if payload.EventData == nil {
+		return nil, errors.New("mailgun: missing event-data")
+	}
+
+	data := payload.EventData
+
+	event := &WebhookEvent{
+		Email:  stringValue(data["recipient"]),
+		Reason: stringValue(data["reason"]),
+		Raw:    data,
+	}
+
+	if ts, ok := data["timestamp"].(float64); ok {
+		event.Date = time.UnixMilli(int64(ts * 1000)).UTC()
+	}
+
+	if message, ok := data["message"].(map[string]any); ok {
+		if headers, ok := message["headers"].(map[string]any); ok {
+			event.MessageId = stringValue(headers["message-id"])
+		}
+	}
+
+	if status, ok := data["delivery-status"].(map[string]any); ok && event.Reason == "" {
+		event.Reason = stringValue(status["description"])
+		if event.Reason == "" {
+			event.Reason = stringValue(status["message"])
+		}
+	}
+
+	switch name := stringValue(data["event"]); name {
+	case "delivered":
+		event.Type = WebhookEventDelivered
+	case "failed":
+		if stringValue(data["severity"]) == "temporary" {
+			event.Type = WebhookEventDeferred
+		} else {
+			event.Type = WebhookEventBounce
+		}
+	case "complained":
+		event.Type = WebhookEventComplaint
+	default:
+		event.Type = name
+	}
+
+	return []*WebhookEvent{event}, nil
+}
+
+// ParseSesWebhook parses an Amazon SES notification delivered through
+// an Amazon SNS HTTP(S) subscription.
+//
+// For SNS subscription confirmation requests no events are returned and
+// subscribeUrl is populated with the url that must be visited to confirm the subscription.
+func ParseSesWebhook(body []byte) (events []*WebhookEvent, subscribeUrl string, err error) {
+	envelope := struct {
+		Type         string `json:"Type"`
+		Message      string `json:"Message"`
+		SubscribeURL string `json:"SubscribeURL"`
+	}{}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, "", err
+	}
+
+	switch envelope.Type {
+	case "SubscriptionConfirmation":
+		u, err := url.Parse(envelope.SubscribeURL)
+		if err != nil || u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+			return nil, "", errors.New("ses: invalid subscribe url")
+		}
+		return nil, envelope.SubscribeURL, nil
+	case "Notification":
+	default:
+		return nil, "", errors.New("ses: unsupported message type " + envelope.Type)
+	}
+
+	notification := struct {
+		NotificationType string `json:"notificationType"`
+		EventType        string `json:"eventType"`
+		Mail             struct {
+			MessageId   string   `json:"messageId"`
+			Destination []string `json:"destination"`
+		} `json:"mail"`
+		Bounce struct {
+			BounceType        string `json:"bounceType"`
+			Timestamp         string `json:"timestamp"`
+			BouncedRecipients []struct {
+				EmailAddress   string `json:"emailAddress"`
+				DiagnosticCode string `json:"diagnosticCode"`
+			} `json:"bouncedRecipients"`
+		} `json:"bounce"`
+		Complaint struct {
+			Timestamp             string `json:"timestamp"`
+			ComplaintFeedbackType string `json:"complaintFeedbackType"`
+			ComplainedRecipients  []struct {
+				EmailAddress string `json:"emailAddress"`
+			} `json:"complainedRecipients"`
+		} `json:"complaint"`
+		Delivery struct {
+			Timestamp  string   `json:"timestamp"`
+			Recipients []string `json:"recipients"`
+		} `json:"delivery"`
+	}{}
+	if err := json.Unmarshal([]byte(envelope.Message), &notification); err != nil {
+		return nil, "", err
+	}
+
+	raw := map[string]any{}
+	_ = json.Unmarshal([]byte(envelope.Message), &raw)
+
+	newEvent := func(eventType, email, reason, timestamp string) *WebhookEvent {
+		date, _ := time.Parse(time.RFC3339, timestamp)
+		return &WebhookEvent{
+			Type:      eventType,
+			Email:     email,
+			MessageId: notification.Mail.MessageId,
+			Reason:    reason,
+			Date:      date.UTC(),
+			Raw:       raw,
+		}
+	}
+
+	name := notification.NotificationType
+	if name == "" {
+		name = notification.EventType
+	}
+
+	switch name {
+	case "Bounce":
+		eventType := WebhookEventBounce
+		if notification.Bounce.BounceType == "Transient" {
+			eventType = WebhookEventDeferred
+		}
+		for _, r := range notification.Bounce.BouncedRecipients {
+			events = append(events, newEvent(eventType, r.EmailAddress, r.DiagnosticCode, notification.Bounce.Timestamp))
+		}
+	case "Complaint":
+		for _, r := range notification.Complaint.ComplainedRecipients {
+			events = append(events, newEvent(WebhookEventComplaint, r.EmailAddress, notification.Complaint.ComplaintFeedbackType, notification.Complaint.Timestamp))
+		}
+	case "Delivery":
+		for _, email := range notification.Delivery.Recipients {
+			events = append(events, newEvent(WebhookEventDelivered, email, "", notification.Delivery.Timestamp))
+		}
+	default:
+		for _, email := range notification.Mail.Destination {
+			events = append(events, newEvent(strings.ToLower(name), email, "", ""))
+		}
+	}
+
+	return events, "", nil
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
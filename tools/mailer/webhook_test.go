@@ -0,0 +1,146 @@
+package mailer
+
+import (
+	"testing"
+)
+
+func TestParseSendGridWebhook(t *testing.T) {
+	body := `[
+		{"email":"a@example.com","timestamp":1700000000,"event":"bounce","sg_message_id":"id1","reason":"invalid mailbox"},
+		{"email":"b@example.com","timestamp":1700000000,"event":"spamreport","sg_message_id":"id2"},
+		{"email":"c@example.com","timestamp":1700000000,"event":"open","sg_message_id":"id3"}
+	]`
+
+	events, err := ParseSendGridWebhook([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct{ eventType, email, messageId, reason string }{
+		{WebhookEventBounce, "a@example.com", "id1", "invalid mailbox"},
+		{WebhookEventComplaint, "b@example.com", "id2", ""},
+		{"open", "c@example.com", "id3", ""},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+
+	for i, e := range expected {
+		if events[i].Type != e.eventType || events[i].Email != e.email || events[i].MessageId != e.messageId || events[i].Reason != e.reason {
+			t.Fatalf("[%d] Expected %v, got %v", i, e, events[i])
+		}
+
+		if events[i].Date.Unix() != 1700000000 {
+			t.Fatalf("[%d] Unexpected date %v", i, events[i].Date)
+		}
+	}
+
+	if _, err := ParseSendGridWebhook([]byte(`{}`)); err == nil {
+		t.Fatal("Expected error for invalid payload")
+	}
+}
+
+func TestParseMailgunWebhook(t *testing.T) {
+	scenarios := []struct {
+		body          string
+		expectError   bool
+		expectedType  string
+		expectedEmail string
+		expectedId    string
+	}{
+		{`{}`, true, "", "", ""},
+		{
+			`{"event-data":{"event":"failed","severity":"permanent","recipient":"a@example.com","timestamp":1700000000.5,"message":{"headers":{"message-id":"id1"}},"delivery-status":{"description":"No such user"}}}`,
+			false,
+			WebhookEventBounce,
+			"a@example.com",
+			"id1",
+		},
+		{
+			`{"event-data":{"event":"failed","severity":"temporary","recipient":"a@example.com"}}`,
+			false,
+			WebhookEventDeferred,
+			"a@example.com",
+			"",
+		},
+		{
+			`{"event-data":{"event":"complained","recipient":"b@example.com"}}`,
+			false,
+			WebhookEventComplaint,
+			"b@example.com",
+			"",
+		},
+	}
+
+	for i, s := range scenarios {
+		events, err := ParseMailgunWebhook([]byte(s.body))
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+
+		if hasErr {
+			continue
+		}
+
+		if len(events) != 1 || events[0].Type != s.expectedType || events[0].Email != s.expectedEmail || events[0].MessageId != s.expectedId {
+			t.Fatalf("[%d] Unexpected events %v", i, events[0])
+		}
+	}
+}
+
+func TestParseSesWebhook(t *testing.T) {
+	// subscription confirmation
+	_, subscribeUrl, err := ParseSesWebhook([]byte(`{"Type":"SubscriptionConfirmation","SubscribeURL":"https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscribeUrl != "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription" {
+		t.Fatalf("Unexpected subscribe url %q", subscribeUrl)
+	}
+
+	// subscription confirmation with untrusted url
+	if _, _, err := ParseSesWebhook([]byte(`{"Type":"SubscriptionConfirmation","SubscribeURL":"https://example.com/"}`)); err == nil {
+		t.Fatal("Expected untrusted subscribe url error")
+	}
+
+	// bounce
+	body := `{
+		"Type":"Notification",
+		"Message":"{\"notificationType\":\"Bounce\",\"mail\":{\"messageId\":\"id1\"},\"bounce\":{\"bounceType\":\"Permanent\",\"timestamp\":\"2024-01-01T10:00:00.000Z\",\"bouncedRecipients\":[{\"emailAddress\":\"a@example.com\",\"diagnosticCode\":\"550\"},{\"emailAddress\":\"b@example.com\"}]}}"
+	}`
+
+	events, _, err := ParseSesWebhook([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if events[0].Type != WebhookEventBounce || events[0].Email != "a@example.com" || events[0].Reason != "550" || events[0].MessageId != "id1" {
+		t.Fatalf("Unexpected first event %v", events[0])
+	}
+
+	if events[1].Email != "b@example.com" || events[0].Date.Year() != 2024 {
+		t.Fatalf("Unexpected second event %v", events[1])
+	}
+
+	// complaint
+	body = `{
+		"Type":"Notification",
+		"Message":"{\"notificationType\":\"Complaint\",\"mail\":{\"messageId\":\"id2\"},\"complaint\":{\"complainedRecipients\":[{\"emailAddress\":\"c@example.com\"}]}}"
+	}`
+
+	events, _, err = ParseSesWebhook([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || events[0].Type != WebhookEventComplaint || events[0].Email != "c@example.com" {
+		t.Fatalf("Unexpected complaint events %v", events)
+	}
+}
@@ -0,0 +1,10 @@
+package osutils
+
+// DiskUsage describes the space of the filesystem containing a specific path.
+type DiskUsage struct {
+	// Total is the filesystem size in bytes.
+	Total uint64 `json:"total"`
+
+	// Free is the number of bytes available to the current (unprivileged) user.
+	Free uint64 `json:"free"`
+}
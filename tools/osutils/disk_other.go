@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !freebsd && !dragonfly && !windows
+
+package osutils
+
+import "errors"
+
+// GetDiskUsage returns the space usage of the filesystem containing path.
+//
+// It is not supported on the current platform and always returns [errors.ErrUnsupported].
+func GetDiskUsage(path string) (DiskUsage, error) {
+	return DiskUsage{}, errors.ErrUnsupported
+}
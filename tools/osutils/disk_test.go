@@ -0,0 +1,28 @@
+package osutils_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/osutils"
+)
+
+func TestGetDiskUsage(t *testing.T) {
+	usage, err := osutils.GetDiskUsage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.Total == 0 {
+		t.Fatal("Expected non-zero total disk space")
+	}
+
+	if usage.Free > usage.Total {
+		t.Fatalf("Expected free space (%d) to be <= total (%d)", usage.Free, usage.Total)
+	}
+}
+
+func TestGetDiskUsageMissingPath(t *testing.T) {
+	if _, err := osutils.GetDiskUsage("/missing/path/123"); err == nil {
+		t.Fatal("Expected error for missing path")
+	}
+}
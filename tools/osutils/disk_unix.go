@@ -0,0 +1,19 @@
+//go:build linux || darwin || freebsd || dragonfly
+
+package osutils
+
+import "golang.org/x/sys/unix"
+
+// GetDiskUsage returns the space usage of the filesystem containing path.
+func GetDiskUsage(path string) (DiskUsage, error) {
+	var stat unix.Statfs_t
+
+	if err := unix.Statfs(path, &stat); err != nil {
+		return DiskUsage{}, err
+	}
+
+	return DiskUsage{
+		Total: uint64(stat.Blocks) * uint64(stat.Bsize),
+		Free:  uint64(stat.Bavail) * uint64(stat.Bsize),
+	}, nil
+}
@@ -0,0 +1,21 @@
+//go:build windows
+
+package osutils
+
+import "golang.org/x/sys/windows"
+
+// GetDiskUsage returns the space usage of the filesystem containing path.
+func GetDiskUsage(path string) (DiskUsage, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+
+	var free, total uint64
+
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &free, &total, nil); err != nil {
+		return DiskUsage{}, err
+	}
+
+	return DiskUsage{Total: total, Free: free}, nil
+}
@@ -0,0 +1,517 @@
+// Package pdfsign implements helpers for appending a signature and
+// audit trail page to an existing PDF document.
+//
+// The page is appended as PDF incremental update, aka. the original
+// document bytes are preserved as they are and could be verified
+// against a previously stored hash.
+package pdfsign
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Page sizes and margins (in PDF points) of the appended page.
+const (
+	pageWidth  = 612 // US Letter
+	pageHeight = 792
+	pageMargin = 50
+
+	// max drawn signature image size on the page
+	signatureMaxWidth  = 250
+	signatureMaxHeight = 100
+
+	// max audit trail line length (in characters) before wrapping
+	lineMaxLength = 95
+)
+
+var (
+	// ErrInvalidDocument is returned when the document is not a valid or supported PDF.
+	ErrInvalidDocument = errors.New("invalid or unsupported PDF document")
+
+	// ErrEncryptedDocument is returned when the document is encrypted.
+	ErrEncryptedDocument = errors.New("encrypted PDF documents are not supported")
+)
+
+var (
+	startxrefRegex = regexp.MustCompile(`startxref\s+(\d+)`)
+	rootRegex      = regexp.MustCompile(`/Root\s+(\d+)\s+(\d+)\s+R`)
+	sizeRegex      = regexp.MustCompile(`/Size\s+(\d+)`)
+	infoRegex      = regexp.MustCompile(`/Info\s+\d+\s+\d+\s+R`)
+	idRegex        = regexp.MustCompile(`/ID\s*\[[^\]]*\]`)
+	pagesRegex     = regexp.MustCompile(`/Pages\s+(\d+)\s+(\d+)\s+R`)
+	kidsRegex      = regexp.MustCompile(`/Kids\s*\[([^\]]*)\]`)
+	countRegex     = regexp.MustCompile(`/Count\s+(\d+)`)
+	objStmRegex    = regexp.MustCompile(`/Type\s*/ObjStm`)
+	firstRegex     = regexp.MustCompile(`/First\s+(\d+)`)
+	nRegex         = regexp.MustCompile(`/N\s+(\d+)`)
+)
+
+// Page defines the content of the signature page.
+type Page struct {
+	// Title is the page heading (eg. "Signature certificate").
+	Title string
+
+	// Signature is an optional drawn signature image.
+	//
+	// The image is scaled down to fit in 250x100 points
+	// and its transparent pixels are rendered as white.
+	Signature image.Image
+
+	// SignatureText is an optional typed signature
+	// (rendered with a larger font below the drawn signature).
+	SignatureText string
+
+	// Lines are the audit trail text lines (eg. signer, ip, date, hash).
+	//
+	// The long lines are wrapped and the lines that doesn't
+	// fit in the page are truncated.
+	Lines []string
+}
+
+// ref defines an indirect object reference.
+type ref struct {
+	num int
+	gen int
+}
+
+func (r ref) String() string {
+	return fmt.Sprintf("%d %d R", r.num, r.gen)
+}
+
+// AppendPage appends a new signature page at the end of the PDF document
+// and returns the updated document.
+//
+// Only the cross-reference tables and streams without predictors are
+// supported when looking for the document page tree.
+func AppendPage(doc []byte, page Page) ([]byte, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(doc, "\x00\t\r\n "), []byte("%PDF-")) {
+		return nil, ErrInvalidDocument
+	}
+
+	// last cross-reference section
+	xrefMatches := startxrefRegex.FindAllSubmatch(doc, -1)
+	if len(xrefMatches) == 0 {
+		return nil, ErrInvalidDocument
+	}
+	prev, _ := strconv.Atoi(string(xrefMatches[len(xrefMatches)-1][1]))
+	if prev <= 0 || prev >= len(doc) {
+		return nil, ErrInvalidDocument
+	}
+	trailer := doc[prev:]
+
+	if bytes.Contains(trailer, []byte("/Encrypt")) {
+		return nil, ErrEncryptedDocument
+	}
+
+	root, ok := findRef(rootRegex, trailer)
+	if !ok {
+		return nil, ErrInvalidDocument
+	}
+
+	sizeMatch := sizeRegex.FindSubmatch(trailer)
+	if sizeMatch == nil {
+		return nil, ErrInvalidDocument
+	}
+	size, _ := strconv.Atoi(string(sizeMatch[1]))
+
+	catalog, err := findObject(doc, root)
+	if err != nil {
+		return nil, err
+	}
+
+	pagesRef, ok := findRef(pagesRegex, catalog)
+	if !ok {
+		return nil, ErrInvalidDocument
+	}
+
+	pages, err := findObject(doc, pagesRef)
+	if err != nil {
+		return nil, err
+	}
+
+	kidsMatch := kidsRegex.FindSubmatchIndex(pages)
+	countMatch := countRegex.FindSubmatchIndex(pages)
+	if kidsMatch == nil || countMatch == nil {
+		return nil, ErrInvalidDocument
+	}
+	count, _ := strconv.Atoi(string(pages[countMatch[2]:countMatch[3]]))
+
+	// new objects
+	fontRef := ref{num: size}
+	contentRef := ref{num: size + 1}
+	pageRef := ref{num: size + 2}
+	imageRef := ref{num: size + 3}
+
+	var img *image.RGBA
+	if page.Signature != nil && !page.Signature.Bounds().Empty() {
+		img = flattenImage(page.Signature)
+	}
+
+	// updated page tree root
+	newPages := splice(pages, kidsMatch[3], kidsMatch[3], " "+pageRef.String()+" ")
+	countMatch = countRegex.FindSubmatchIndex(newPages)
+	newPages = splice(newPages, countMatch[2], countMatch[3], strconv.Itoa(count+1))
+
+	resources := "/Font << /F1 " + fontRef.String() + " >>"
+	if img != nil {
+		resources += " /XObject << /Im1 " + imageRef.String() + " >>"
+	}
+
+	var out bytes.Buffer
+	out.Write(doc)
+	if !bytes.HasSuffix(doc, []byte("\n")) {
+		out.WriteByte('\n')
+	}
+
+	offsets := map[int]int{}
+
+	writeObject := func(r ref, body []byte) {
+		offsets[r.num] = out.Len()
+		fmt.Fprintf(&out, "%d %d obj\n", r.num, r.gen)
+		out.Write(body)
+		out.WriteString("\nendobj\n")
+	}
+
+	writeObject(pagesRef, newPages)
+
+	writeObject(fontRef, []byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"))
+
+	content := pageContent(page, img)
+	writeObject(contentRef, streamObject(fmt.Sprintf("/Length %d", len(content)), content))
+
+	writeObject(pageRef, []byte(fmt.Sprintf(
+		"<< /Type /Page /Parent %s /MediaBox [0 0 %d %d] /Resources << %s >> /Contents %s >>",
+		pagesRef, pageWidth, pageHeight, resources, contentRef,
+	)))
+
+	newSize := pageRef.num + 1
+
+	if img != nil {
+		data, err := compress(imagePixels(img))
+		if err != nil {
+			return nil, err
+		}
+
+		writeObject(imageRef, streamObject(fmt.Sprintf(
+			"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d",
+			img.Bounds().Dx(), img.Bounds().Dy(), len(data),
+		), data))
+
+		newSize = imageRef.num + 1
+	}
+
+	// cross-reference section
+	xrefOffset := out.Len()
+	out.WriteString("xref\n")
+	fmt.Fprintf(&out, "%d 1\n%010d %05d n \n", pagesRef.num, offsets[pagesRef.num], pagesRef.gen)
+	fmt.Fprintf(&out, "%d %d\n", size, newSize-size)
+	for num := size; num < newSize; num++ {
+		fmt.Fprintf(&out, "%010d %05d n \n", offsets[num], 0)
+	}
+
+	out.WriteString("trailer\n")
+	fmt.Fprintf(&out, "<< /Size %d /Root %s /Prev %d", newSize, root, prev)
+	if info := infoRegex.Find(trailer); info != nil {
+		out.WriteByte(' ')
+		out.Write(info)
+	}
+	if id := idRegex.Find(trailer); id != nil {
+		out.WriteByte(' ')
+		out.Write(id)
+	}
+	out.WriteString(" >>\n")
+	fmt.Fprintf(&out, "startxref\n%d\n%%%%EOF\n", xrefOffset)
+
+	return out.Bytes(), nil
+}
+
+// splice returns a copy of data with the data[start:end] bytes replaced with str.
+func splice(data []byte, start int, end int, str string) []byte {
+	result := make([]byte, 0, len(data)+len(str))
+	result = append(result, data[:start]...)
+	result = append(result, str...)
+	result = append(result, data[end:]...)
+
+	return result
+}
+
+// findRef returns the first indirect object reference matched by re.
+func findRef(re *regexp.Regexp, data []byte) (ref, bool) {
+	match := re.FindSubmatch(data)
+	if match == nil {
+		return ref{}, false
+	}
+
+	num, _ := strconv.Atoi(string(match[1]))
+	gen, _ := strconv.Atoi(string(match[2]))
+
+	return ref{num: num, gen: gen}, true
+}
+
+// findObject returns the trimmed body of the latest r object definition
+// (either as regular indirect object or from a compressed object stream).
+func findObject(doc []byte, r ref) ([]byte, error) {
+	objRegex := regexp.MustCompile(fmt.Sprintf(`(?:^|\s)%d\s+%d\s+obj\b`, r.num, r.gen))
+
+	if matches := objRegex.FindAllIndex(doc, -1); len(matches) > 0 {
+		start := matches[len(matches)-1][1]
+
+		end := bytes.Index(doc[start:], []byte("endobj"))
+		if end < 0 {
+			return nil, ErrInvalidDocument
+		}
+
+		return bytes.TrimSpace(doc[start : start+end]), nil
+	}
+
+	// objects in object streams always have generation 0
+	if r.gen == 0 {
+		var result []byte
+
+		for _, loc := range objStmRegex.FindAllIndex(doc, -1) {
+			if body := findStreamObject(doc, loc[0], r.num); body != nil {
+				result = body
+			}
+		}
+
+		if result != nil {
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: missing object %d %d", ErrInvalidDocument, r.num, r.gen)
+}
+
+// findStreamObject returns the body of the num object from the
+// object stream whose dictionary contains the doc[pos] byte.
+//
+// Returns nil if the object stream doesn't contain the object
+// or it couldn't be decoded.
+func findStreamObject(doc []byte, pos int, num int) []byte {
+	dictStart := bytes.LastIndex(doc[:pos], []byte("obj"))
+	streamStart := bytes.Index(doc[pos:], []byte("stream"))
+	if dictStart < 0 || streamStart < 0 {
+		return nil
+	}
+	streamStart += pos
+
+	dict := doc[dictStart:streamStart]
+	if !bytes.Contains(dict, []byte("/FlateDecode")) || bytes.Contains(dict, []byte("/Predictor")) {
+		return nil
+	}
+
+	dataStart := streamStart + len("stream")
+	if bytes.HasPrefix(doc[dataStart:], []byte("\r\n")) {
+		dataStart += 2
+	} else if bytes.HasPrefix(doc[dataStart:], []byte("\n")) {
+		dataStart++
+	}
+
+	dataEnd := bytes.Index(doc[dataStart:], []byte("endstream"))
+	if dataEnd < 0 {
+		return nil
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(doc[dataStart : dataStart+dataEnd]))
+	if err != nil {
+		return nil
+	}
+	defer zr.Close()
+
+	// the stream could have trailing end-of-line bytes before
+	// "endstream" so the unexpected EOF errors are ignored
+	data, _ := io.ReadAll(zr)
+
+	firstMatch := firstRegex.FindSubmatch(dict)
+	nMatch := nRegex.FindSubmatch(dict)
+	if firstMatch == nil || nMatch == nil {
+		return nil
+	}
+	first, _ := strconv.Atoi(string(firstMatch[1]))
+	n, _ := strconv.Atoi(string(nMatch[1]))
+	if first > len(data) {
+		return nil
+	}
+
+	header := strings.Fields(string(data[:first]))
+	if len(header) < n*2 {
+		return nil
+	}
+
+	for i := 0; i < n; i++ {
+		objNum, _ := strconv.Atoi(header[i*2])
+		if objNum != num {
+			continue
+		}
+
+		start, _ := strconv.Atoi(header[i*2+1])
+		end := len(data) - first
+		if i+1 < n {
+			end, _ = strconv.Atoi(header[i*2+3])
+		}
+
+		if start < 0 || start > end || first+end > len(data) {
+			return nil
+		}
+
+		return bytes.TrimSpace(data[first+start : first+end])
+	}
+
+	return nil
+}
+
+// pageContent returns the signature page content stream.
+func pageContent(page Page, img *image.RGBA) []byte {
+	var buf bytes.Buffer
+
+	y := pageHeight - pageMargin - 18
+
+	fmt.Fprintf(&buf, "BT /F1 18 Tf %d %d Td (%s) Tj ET\n", pageMargin, y, pdfString(page.Title))
+	y -= 30
+
+	if img != nil {
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+		scale := min(1, float64(signatureMaxWidth)/float64(w), float64(signatureMaxHeight)/float64(h))
+		sw := max(1, int(float64(w)*scale))
+		sh := max(1, int(float64(h)*scale))
+
+		y -= sh
+		fmt.Fprintf(&buf, "q %d 0 0 %d %d %d cm /Im1 Do Q\n", sw, sh, pageMargin, y)
+		y -= 10
+	}
+
+	if page.SignatureText != "" {
+		y -= 24
+		fmt.Fprintf(&buf, "BT /F1 24 Tf %d %d Td (%s) Tj ET\n", pageMargin, y, pdfString(page.SignatureText))
+		y -= 10
+	}
+
+	// signature line
+	fmt.Fprintf(&buf, "0.5 w %d %d m %d %d l S\n", pageMargin, y, pageMargin+signatureMaxWidth, y)
+	y -= 30
+
+	buf.WriteString("BT /F1 10 Tf 14 TL\n")
+	fmt.Fprintf(&buf, "%d %d Td\n", pageMargin, y)
+	for _, line := range wrapLines(page.Lines, lineMaxLength) {
+		if y < pageMargin {
+			break
+		}
+		fmt.Fprintf(&buf, "(%s) Tj T*\n", pdfString(line))
+		y -= 14
+	}
+	buf.WriteString("ET")
+
+	return buf.Bytes()
+}
+
+// wrapLines splits the lines longer than maxLength characters.
+func wrapLines(lines []string, maxLength int) []string {
+	result := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		runes := []rune(line)
+
+		for len(runes) > maxLength {
+			cut := maxLength
+
+			// prefer to wrap on space
+			for i := maxLength; i > maxLength/2; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+
+			result = append(result, string(runes[:cut]))
+			runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+		}
+
+		result = append(result, string(runes))
+	}
+
+	return result
+}
+
+// pdfString escapes s as PDF literal string content.
+//
+// The characters outside of the Latin-1 range are replaced with "?"
+// because the standard fonts support only single byte encodings.
+func pdfString(s string) string {
+	var sb strings.Builder
+
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r >= 32 && r < 127:
+			sb.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&sb, "\\%03o", r)
+		default:
+			sb.WriteByte('?')
+		}
+	}
+
+	return sb.String()
+}
+
+// streamObject returns a stream object body with the specified dictionary entries.
+func streamObject(dict string, data []byte) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("<< " + dict + " >>\nstream\n")
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+
+	return buf.Bytes()
+}
+
+// flattenImage draws img over white background.
+func flattenImage(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+
+	return dst
+}
+
+// imagePixels returns the raw RGB pixels of img.
+func imagePixels(img *image.RGBA) []byte {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	pixels := make([]byte, 0, w*h*3)
+	for i := 0; i < len(img.Pix); i += 4 {
+		pixels = append(pixels, img.Pix[i], img.Pix[i+1], img.Pix[i+2])
+	}
+
+	return pixels
+}
+
+// compress returns the zlib (aka. PDF FlateDecode) compressed data.
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
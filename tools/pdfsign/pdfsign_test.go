@@ -0,0 +1,257 @@
+package pdfsign
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// buildPDF returns a minimal PDF document with the specified objects
+// (the object numbers start from 1) and a classic cross-reference table.
+func buildPDF(objects []string, trailerExtra string) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R%s >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailerExtra, xref)
+
+	return buf.Bytes()
+}
+
+func testDocument() []byte {
+	return buildPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Count 1 /Kids [3 0 R] >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] >>",
+	}, " /Info 1 0 R /ID [<abc> <def>]")
+}
+
+// checkPageTree checks whether the document page tree has the expected pages count
+// and that each cross-reference offset of the last section points to its object.
+func checkPageTree(t *testing.T, doc []byte, expectedCount int) {
+	t.Helper()
+
+	pages, err := findObject(doc, ref{num: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := countRegex.FindSubmatch(pages)
+	if count == nil || string(count[1]) != strconv.Itoa(expectedCount) {
+		t.Fatalf("Expected pages count %d, got %s", expectedCount, pages)
+	}
+
+	kids := kidsRegex.FindSubmatch(pages)
+	if kids == nil || len(strings.Fields(string(kids[1]))) != expectedCount*3 {
+		t.Fatalf("Expected %d kids, got %s", expectedCount, pages)
+	}
+
+	xrefMatches := startxrefRegex.FindAllSubmatch(doc, -1)
+	xrefOffset, _ := strconv.Atoi(string(xrefMatches[len(xrefMatches)-1][1]))
+	if !bytes.HasPrefix(doc[xrefOffset:], []byte("xref\n")) {
+		t.Fatalf("Expected startxref to point to the xref section, got %q", doc[xrefOffset:xrefOffset+10])
+	}
+
+	section := regexp.MustCompile(`(?m)^(\d+) (\d+)\n`)
+	lines := strings.Split(string(doc[xrefOffset:]), "\n")
+	for i := 1; i < len(lines) && lines[i] != "trailer"; i++ {
+		m := section.FindStringSubmatch(lines[i] + "\n")
+		if m == nil {
+			t.Fatalf("Invalid xref subsection line %q", lines[i])
+		}
+
+		first, _ := strconv.Atoi(m[1])
+		total, _ := strconv.Atoi(m[2])
+		for j := 0; j < total; j++ {
+			i++
+			if len(lines[i]) != 19 {
+				t.Fatalf("Expected 20 bytes xref entry, got %q", lines[i])
+			}
+			offset, _ := strconv.Atoi(lines[i][:10])
+			expected := fmt.Sprintf("%d 0 obj", first+j)
+			if !bytes.HasPrefix(doc[offset:], []byte(expected)) {
+				t.Fatalf("Expected offset %d to point to %q, got %q", offset, expected, doc[offset:offset+10])
+			}
+		}
+	}
+}
+
+func TestAppendPageInvalidDocument(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		doc      []byte
+		expected error
+	}{
+		{"empty", nil, ErrInvalidDocument},
+		{"not pdf", []byte("hello world"), ErrInvalidDocument},
+		{"missing startxref", []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n"), ErrInvalidDocument},
+		{"invalid startxref", []byte("%PDF-1.4\nstartxref\n99999\n%%EOF"), ErrInvalidDocument},
+		{
+			"missing pages",
+			buildPDF([]string{"<< /Type /Catalog >>"}, ""),
+			ErrInvalidDocument,
+		},
+		{
+			"missing pages object",
+			buildPDF([]string{"<< /Type /Catalog /Pages 5 0 R >>"}, ""),
+			ErrInvalidDocument,
+		},
+		{
+			"encrypted",
+			buildPDF([]string{
+				"<< /Type /Catalog /Pages 2 0 R >>",
+				"<< /Type /Pages /Count 0 /Kids [] >>",
+			}, " /Encrypt 3 0 R"),
+			ErrEncryptedDocument,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			_, err := AppendPage(s.doc, Page{Title: "test"})
+			if !errors.Is(err, s.expected) {
+				t.Fatalf("Expected error %v, got %v", s.expected, err)
+			}
+		})
+	}
+}
+
+func TestAppendPage(t *testing.T) {
+	original := testDocument()
+
+	signature := image.NewNRGBA(image.Rect(0, 0, 500, 100))
+	signature.Set(10, 10, color.Black)
+
+	doc, err := AppendPage(original, Page{
+		Title:         "Signature certificate",
+		Signature:     signature,
+		SignatureText: "John (Doe)",
+		Lines:         []string{"Signer: John Doe", "IP: 127.0.0.1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(doc, original) {
+		t.Fatal("Expected the original document to be preserved")
+	}
+
+	checkPageTree(t, doc, 2)
+
+	update := string(doc[len(original):])
+
+	expectedParts := []string{
+		"/Kids [3 0 R 6 0 R ]",
+		"/Type /Page /Parent 2 0 R",
+		"/XObject << /Im1 7 0 R >>",
+		"/Subtype /Image /Width 500 /Height 100",
+		"(Signature certificate) Tj",
+		"(John \\(Doe\\)) Tj",
+		"(Signer: John Doe) Tj",
+		"(IP: 127.0.0.1) Tj",
+		"q 250 0 0 50 50 ",
+		"/Size 8 /Root 1 0 R /Prev ",
+		"/Info 1 0 R /ID [<abc> <def>]",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(update, part) {
+			t.Errorf("Missing %q in\n%s", part, update)
+		}
+	}
+
+	// append another page without signature image
+	doc2, err := AppendPage(doc, Page{Title: "Second", SignatureText: "Jane"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkPageTree(t, doc2, 3)
+
+	update2 := string(doc2[len(doc):])
+	if strings.Contains(update2, "/XObject") {
+		t.Fatalf("Didn't expect image XObject in\n%s", update2)
+	}
+	if !strings.Contains(update2, "/Size 11 /Root 1 0 R") {
+		t.Fatalf("Expected /Size 11 in\n%s", update2)
+	}
+}
+
+func TestAppendPageObjectStream(t *testing.T) {
+	// catalog and page tree root in a compressed object stream
+	objs := "1 0 2 40 "
+	streamData := objs +
+		fmt.Sprintf("%-40s", "<< /Type /Catalog /Pages 2 0 R >>") +
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>"
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte(streamData))
+	zw.Close()
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+	buf.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R >>\nendobj\n")
+	fmt.Fprintf(&buf, "4 0 obj\n<< /Type /ObjStm /N 2 /First %d /Filter /FlateDecode /Length %d >>\nstream\n", len(objs), compressed.Len())
+	buf.Write(compressed.Bytes())
+	buf.WriteString("\nendstream\nendobj\n")
+	xref := buf.Len()
+	buf.WriteString("5 0 obj\n<< /Type /XRef /Size 6 /Root 1 0 R /W [1 2 1] /Length 0 >>\nstream\n\nendstream\nendobj\n")
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", xref)
+
+	doc, err := AppendPage(buf.Bytes(), Page{Title: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkPageTree(t, doc, 2)
+
+	if !strings.Contains(string(doc), "/Size 9 /Root 1 0 R /Prev "+strconv.Itoa(xref)) {
+		t.Fatalf("Invalid trailer in\n%s", doc[buf.Len():])
+	}
+}
+
+func TestPdfString(t *testing.T) {
+	scenarios := map[string]string{
+		"":           "",
+		"abc 123":    "abc 123",
+		`a\b(c)`:     `a\\b\(c\)`,
+		"café":       `caf\351`,
+		"a\nb":       "a?b",
+		"日本":         "??",
+		"\u00a0-ÿ-€": `\240-\377-?`,
+	}
+
+	for input, expected := range scenarios {
+		if result := pdfString(input); result != expected {
+			t.Errorf("[%q] Expected %q, got %q", input, expected, result)
+		}
+	}
+}
+
+func TestWrapLines(t *testing.T) {
+	result := wrapLines([]string{"", "short", "aaaa bbbb cccc", "abcdefghij"}, 9)
+
+	expected := []string{"", "short", "aaaa bbbb", "cccc", "abcdefghi", "j"}
+
+	if strings.Join(result, "|") != strings.Join(expected, "|") {
+		t.Fatalf("Expected %q, got %q", expected, result)
+	}
+}
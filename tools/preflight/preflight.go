@@ -0,0 +1,102 @@
+// Package preflight implements a simple report for collecting
+// the startup validation issues (eg. broken hook scripts).
+package preflight
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Issue defines a single preflight validation issue.
+type Issue struct {
+	// Source is the name of the component that reported the issue (eg. "jsvm").
+	Source string `json:"source"`
+
+	// File is the optional path of the file with the issue.
+	File string `json:"file"`
+
+	// Message is the issue description.
+	Message string `json:"message"`
+}
+
+// String implements the [fmt.Stringer] interface.
+func (i *Issue) String() string {
+	if i.File == "" {
+		return fmt.Sprintf("[%s] %s", i.Source, i.Message)
+	}
+
+	return fmt.Sprintf("[%s] %s: %s", i.Source, i.File, i.Message)
+}
+
+// Report is a concurrent safe preflight issues collection.
+type Report struct {
+	mu     sync.Mutex
+	issues []*Issue
+}
+
+// Add registers a new issue for the specified source and file.
+//
+// Nil errors are ignored.
+func (r *Report) Add(source string, file string, err error) {
+	if err == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.issues = append(r.issues, &Issue{
+		Source:  source,
+		File:    file,
+		Message: strings.TrimSpace(err.Error()),
+	})
+}
+
+// Issues returns a copy of the reported issues sorted by their source and file.
+func (r *Report) Issues() []*Issue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*Issue, len(r.issues))
+	copy(result, r.issues)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Source != result[j].Source {
+			return result[i].Source < result[j].Source
+		}
+		return result[i].File < result[j].File
+	})
+
+	return result
+}
+
+// HasIssues reports whether at least one issue was registered.
+func (r *Report) HasIssues() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.issues) > 0
+}
+
+// String returns a consolidated human readable report of all issues.
+func (r *Report) String() string {
+	issues := r.Issues()
+
+	if len(issues) == 0 {
+		return "Preflight checks passed."
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Preflight checks found %d issue(s):\n", len(issues)))
+
+	for _, issue := range issues {
+		sb.WriteString(" - ")
+		sb.WriteString(strings.ReplaceAll(issue.String(), "\n", "\n   "))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package preflight_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/preflight"
+)
+
+func TestReportAdd(t *testing.T) {
+	r := &preflight.Report{}
+
+	if r.HasIssues() {
+		t.Fatal("Expected no issues")
+	}
+
+	r.Add("jsvm", "a.pb.js", nil)
+
+	if r.HasIssues() {
+		t.Fatal("Expected nil errors to be ignored")
+	}
+
+	r.Add("luavm", "b.pb.lua", errors.New("test1"))
+	r.Add("jsvm", "c.pb.js", errors.New("test2\n"))
+	r.Add("jsvm", "", errors.New("test3"))
+
+	if !r.HasIssues() {
+		t.Fatal("Expected issues")
+	}
+
+	issues := r.Issues()
+
+	expected := []string{
+		"[jsvm] test3",
+		"[jsvm] c.pb.js: test2",
+		"[luavm] b.pb.lua: test1",
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d", len(expected), len(issues))
+	}
+
+	for i, str := range expected {
+		if v := issues[i].String(); v != str {
+			t.Fatalf("Expected issue %d to be %q, got %q", i, str, v)
+		}
+	}
+}
+
+func TestReportConcurrentAdd(t *testing.T) {
+	r := &preflight.Report{}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Add("test", "", errors.New("test"))
+		}()
+	}
+
+	wg.Wait()
+
+	if total := len(r.Issues()); total != 20 {
+		t.Fatalf("Expected 20 issues, got %d", total)
+	}
+}
+
+func TestReportString(t *testing.T) {
+	r := &preflight.Report{}
+
+	if str := r.String(); str != "Preflight checks passed." {
+		t.Fatalf("Unexpected empty report string %q", str)
+	}
+
+	r.Add("jsvm", "b.pb.js", errors.New("line1\nline2"))
+	r.Add("jsvm", "a.pb.js", errors.New("test"))
+
+	expected := "Preflight checks found 2 issue(s):\n" +
+		" - [jsvm] a.pb.js: test\n" +
+		" - [jsvm] b.pb.js: line1\n   line2\n"
+
+	if str := r.String(); str != expected {
+		t.Fatalf("Expected\n%q\ngot\n%q", expected, str)
+	}
+}
@@ -0,0 +1,147 @@
+package push
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+var _ Client = (*APNsClient)(nil)
+
+// apnsTokenDuration specifies how long a generated APNs provider token is reused
+// (APNs rejects tokens older than 1 hour).
+const apnsTokenDuration = 50 * time.Minute
+
+// APNsClient defines an Apple Push Notification service HTTP/2 API client
+// that implements `push.Client` interface.
+//
+// The API requests are authorized with a token based
+// provider authentication (.p8 signing key).
+type APNsClient struct {
+	// KeyId is the id of the APNs signing key.
+	KeyId string
+
+	// TeamId is the Apple developer account team id.
+	TeamId string
+
+	// PrivateKey is the PEM encoded content of the .p8 signing key.
+	PrivateKey string
+
+	// Topic is the app bundle id.
+	Topic string
+
+	// Production specifies whether to use the production
+	// or the development (sandbox) APNs environment.
+	Production bool
+
+	// BaseUrl is the optional API base url
+	// (if not explicitly set, it is resolved based on the Production flag).
+	BaseUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+
+	mux          sync.Mutex
+	token        string
+	tokenExpires time.Time
+}
+
+// Send implements `push.Client` interface.
+func (c *APNsClient) Send(token string, m *Message) error {
+	authToken, err := c.providerToken()
+	if err != nil {
+		return err
+	}
+
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		if c.Production {
+			baseUrl = "https://api.push.apple.com"
+		} else {
+			baseUrl = "https://api.sandbox.push.apple.com"
+		}
+	}
+
+	payload := map[string]any{}
+	for k, v := range m.Data {
+		payload[k] = v
+	}
+	payload["aps"] = map[string]any{
+		"alert": map[string]any{
+			"title": m.Title,
+			"body":  m.Body,
+		},
+		"sound": "default",
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		strings.TrimRight(baseUrl, "/")+"/3/device/"+url.PathEscape(token),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+authToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apns-topic", c.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderAPNs, res, func(status int, body []byte) bool {
+		return status == http.StatusGone ||
+			bytes.Contains(body, []byte("BadDeviceToken")) ||
+			bytes.Contains(body, []byte("DeviceTokenNotForTopic"))
+	})
+}
+
+// providerToken returns a valid (cached) ES256 signed APNs provider token.
+func (c *APNsClient) providerToken() (string, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := time.Now()
+
+	if c.token != "" && now.Before(c.tokenExpires) {
+		return c.token, nil
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(c.PrivateKey))
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": c.TeamId,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = c.KeyId
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	c.token = signed
+	c.tokenExpires = now.Add(apnsTokenDuration)
+
+	return c.token, nil
+}
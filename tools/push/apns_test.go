@@ -0,0 +1,116 @@
+package push
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func testECPrivateKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw}))
+}
+
+func TestAPNsClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 200, ``)
+
+	key, pemKey := testECPrivateKey(t)
+
+	client := &APNsClient{
+		KeyId:      "test_kid",
+		TeamId:     "test_team",
+		PrivateKey: pemKey,
+		Topic:      "com.example.app",
+		BaseUrl:    server.URL,
+	}
+
+	if err := client.Send("device_token", testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/3/device/device_token" {
+		t.Fatalf("Unexpected request path %q", captured.Path)
+	}
+
+	if topic := captured.Header.Get("apns-topic"); topic != "com.example.app" {
+		t.Fatalf("Expected apns-topic header com.example.app, got %q", topic)
+	}
+
+	rawToken, ok := strings.CutPrefix(captured.Header.Get("Authorization"), "bearer ")
+	if !ok {
+		t.Fatalf("Expected bearer Authorization header, got %q", captured.Header.Get("Authorization"))
+	}
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (any, error) {
+		return &key.PublicKey, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Header["kid"] != "test_kid" {
+		t.Fatalf("Expected kid header test_kid, got %v", token.Header["kid"])
+	}
+	if claims["iss"] != "test_team" {
+		t.Fatalf("Expected iss claim test_team, got %v", claims["iss"])
+	}
+
+	expectedBody := `{"aps":{"alert":{"body":"test body","title":"test title"},"sound":"default"},"orderId":"abc"}`
+	if string(captured.Body) != expectedBody {
+		t.Fatalf("Expected body\n%s\ngot\n%s", expectedBody, captured.Body)
+	}
+
+	// the provider token should be reused
+	if err := client.Send("device_token", testMessage()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(captured.Header.Get("Authorization"), rawToken) {
+		t.Fatal("Expected the provider token to be reused")
+	}
+}
+
+func TestAPNsClientSendInvalidToken(t *testing.T) {
+	scenarios := []struct {
+		status        int
+		response      string
+		expectInvalid bool
+	}{
+		{410, `{"reason":"Unregistered"}`, true},
+		{400, `{"reason":"BadDeviceToken"}`, true},
+		{400, `{"reason":"PayloadEmpty"}`, false},
+	}
+
+	_, pemKey := testECPrivateKey(t)
+
+	for _, s := range scenarios {
+		server, _ := httpmock.NewServer(t, s.status, s.response)
+
+		client := &APNsClient{PrivateKey: pemKey, BaseUrl: server.URL}
+
+		err := client.Send("device_token", testMessage())
+		if err == nil {
+			t.Fatalf("[%s] Expected error, got nil", s.response)
+		}
+
+		if isInvalid := errors.Is(err, ErrInvalidToken); isInvalid != s.expectInvalid {
+			t.Fatalf("[%s] Expected isInvalid %v, got %v", s.response, s.expectInvalid, isInvalid)
+		}
+	}
+}
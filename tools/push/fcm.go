@@ -0,0 +1,124 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+var _ Client = (*FCMClient)(nil)
+
+// FCMClient defines a Firebase Cloud Messaging HTTP v1 API client
+// that implements `push.Client` interface.
+//
+// The API requests are authorized with a Google service account
+// (ClientEmail and PEM encoded PrivateKey from the service account JSON file).
+type FCMClient struct {
+	ProjectId   string
+	ClientEmail string
+	PrivateKey  string
+
+	// BaseUrl is the optional API base url
+	// (if not explicitly set, defaults to "https://fcm.googleapis.com").
+	BaseUrl string
+
+	// TokenUrl is the optional OAuth2 token url
+	// (if not explicitly set, defaults to "https://oauth2.googleapis.com/token").
+	TokenUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+
+	mux         sync.Mutex
+	tokenSource oauth2.TokenSource
+}
+
+// Send implements `push.Client` interface.
+func (c *FCMClient) Send(token string, m *Message) error {
+	accessToken, err := c.accessToken()
+	if err != nil {
+		return err
+	}
+
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		baseUrl = "https://fcm.googleapis.com"
+	}
+
+	payload := map[string]any{
+		"token": token,
+		"notification": map[string]any{
+			"title": m.Title,
+			"body":  m.Body,
+		},
+	}
+	if len(m.Data) > 0 {
+		payload["data"] = m.Data
+	}
+
+	body, err := json.Marshal(map[string]any{"message": payload})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		strings.TrimRight(baseUrl, "/")+"/v1/projects/"+url.PathEscape(c.ProjectId)+"/messages:send",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderFCM, res, func(status int, body []byte) bool {
+		return status == http.StatusNotFound || bytes.Contains(body, []byte("UNREGISTERED"))
+	})
+}
+
+// accessToken returns a valid (cached) OAuth2 access token
+// for the configured service account.
+func (c *FCMClient) accessToken() (string, error) {
+	c.mux.Lock()
+	if c.tokenSource == nil {
+		tokenUrl := c.TokenUrl
+		if tokenUrl == "" {
+			tokenUrl = "https://oauth2.googleapis.com/token"
+		}
+
+		config := &jwt.Config{
+			Email:      c.ClientEmail,
+			PrivateKey: []byte(c.PrivateKey),
+			Scopes:     []string{"https://www.googleapis.com/auth/firebase.messaging"},
+			TokenURL:   tokenUrl,
+		}
+
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, rest.ResolveHttpClient(c.HttpClient))
+
+		c.tokenSource = config.TokenSource(ctx)
+	}
+	ts := c.tokenSource
+	c.mux.Unlock()
+
+	token, err := ts.Token()
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
@@ -0,0 +1,87 @@
+package push
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func testRSAPrivateKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: raw}))
+}
+
+func TestFCMClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 200, `{"name":"projects/test/messages/1"}`)
+
+	// token endpoint
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"test_access_token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	client := &FCMClient{
+		ProjectId:   "test-project",
+		ClientEmail: "test@test-project.iam.gserviceaccount.com",
+		PrivateKey:  testRSAPrivateKey(t),
+		BaseUrl:     server.URL,
+		TokenUrl:    tokenServer.URL,
+	}
+
+	if err := client.Send("device_token", testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/v1/projects/test-project/messages:send" {
+		t.Fatalf("Unexpected request path %q", captured.Path)
+	}
+
+	if auth := captured.Header.Get("Authorization"); auth != "Bearer test_access_token" {
+		t.Fatalf("Expected Bearer access token Authorization header, got %q", auth)
+	}
+
+	expectedBody := `{"message":{"data":{"orderId":"abc"},"notification":{"body":"test body","title":"test title"},"token":"device_token"}}`
+	if string(captured.Body) != expectedBody {
+		t.Fatalf("Expected body\n%s\ngot\n%s", expectedBody, captured.Body)
+	}
+}
+
+func TestFCMClientSendInvalidToken(t *testing.T) {
+	server, _ := httpmock.NewServer(t, 404, `{"error":{"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`)
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"test_access_token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	client := &FCMClient{
+		ProjectId:   "test-project",
+		ClientEmail: "test@test-project.iam.gserviceaccount.com",
+		PrivateKey:  testRSAPrivateKey(t),
+		BaseUrl:     server.URL,
+		TokenUrl:    tokenServer.URL,
+	}
+
+	err := client.Send("device_token", testMessage())
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Expected ErrInvalidToken, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Package push implements a simple push notifications client interface
+// with Firebase Cloud Messaging (FCM), Apple Push Notification service (APNs)
+// and Web Push providers.
+package push
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+// Supported push notification providers.
+const (
+	ProviderFCM     = "fcm"
+	ProviderAPNs    = "apns"
+	ProviderWebPush = "webpush"
+)
+
+// Providers returns the list with all supported push notification providers.
+func Providers() []string {
+	return []string{ProviderFCM, ProviderAPNs, ProviderWebPush}
+}
+
+// ErrInvalidToken is returned by the clients when the device token
+// is no longer valid (eg. the app was uninstalled or the subscription expired)
+// and it should be removed.
+var ErrInvalidToken = errors.New("push: invalid or expired device token")
+
+// Message defines a generic push notification message.
+type Message struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+
+	// Data is an optional custom key-value payload delivered to the app.
+	Data map[string]string `json:"data"`
+}
+
+// Client defines a base push notifications client interface.
+type Client interface {
+	// Send delivers the provided message to a single device token.
+	//
+	// It returns [ErrInvalidToken] (could be wrapped) if the token
+	// is no longer valid.
+	Send(token string, message *Message) error
+}
+
+// checkResponse returns an error if res has a non 2xx status code.
+//
+// If isInvalidToken reports true for the response status and body,
+// the returned error wraps [ErrInvalidToken].
+func checkResponse(provider string, res *http.Response, isInvalidToken func(status int, body []byte) bool) error {
+	err := rest.CheckResponse(res)
+
+	var resErr *rest.ResponseError
+	if !errors.As(err, &resErr) {
+		return err
+	}
+
+	if isInvalidToken != nil && isInvalidToken(resErr.Status, resErr.Body) {
+		return fmt.Errorf("%s: %w %v", provider, ErrInvalidToken, resErr)
+	}
+
+	return fmt.Errorf("%s: failed to send the message %w", provider, resErr)
+}
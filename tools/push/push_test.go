@@ -0,0 +1,55 @@
+package push
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func testMessage() *Message {
+	return &Message{
+		Title: "test title",
+		Body:  "test body",
+		Data:  map[string]string{"orderId": "abc"},
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	scenarios := []struct {
+		status        int
+		body          string
+		expectError   bool
+		expectInvalid bool
+	}{
+		{200, "", false, false},
+		{204, "", false, false},
+		{400, "error", true, false},
+		{410, "gone", true, true},
+	}
+
+	for _, s := range scenarios {
+		server, _ := httpmock.NewServer(t, s.status, s.body)
+
+		res, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = checkResponse("test", res, func(status int, body []byte) bool {
+			return status == http.StatusGone
+		})
+		res.Body.Close()
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", s.status, s.expectError, hasErr, err)
+		}
+
+		isInvalid := errors.Is(err, ErrInvalidToken)
+		if isInvalid != s.expectInvalid {
+			t.Fatalf("[%d] Expected isInvalid %v, got %v (%v)", s.status, s.expectInvalid, isInvalid, err)
+		}
+	}
+}
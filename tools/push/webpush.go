@@ -0,0 +1,249 @@
+package push
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/tools/rest"
+	"golang.org/x/crypto/hkdf"
+)
+
+var _ Client = (*WebPushClient)(nil)
+
+// webPushRecordSize is the aes128gcm encrypted content record size.
+const webPushRecordSize uint32 = 4096
+
+// WebPushSubscription defines the browser PushSubscription JSON
+// (the result of `pushSubscription.toJSON()`).
+type WebPushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// WebPushClient defines a Web Push protocol (RFC 8030) client
+// that implements `push.Client` interface.
+//
+// The message payload is encrypted as described in RFC 8291 and
+// the requests are authorized with VAPID (RFC 8292).
+//
+// The device token is expected to be the JSON serialized
+// browser push subscription (see [WebPushSubscription]).
+type WebPushClient struct {
+	// VapidPublicKey is the base64 url encoded uncompressed P-256 public key.
+	VapidPublicKey string
+
+	// VapidPrivateKey is the base64 url encoded raw P-256 private key.
+	VapidPrivateKey string
+
+	// Subject is the VAPID contact uri (eg. "mailto:support@example.com").
+	Subject string
+
+	// TTL is the optional message time-to-live in seconds
+	// (if not explicitly set, defaults to 1 day).
+	TTL int
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+// GenerateVAPIDKeys generates a new base64 url encoded VAPID key pair.
+func GenerateVAPIDKeys() (publicKey string, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey = base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	privateKey = base64.RawURLEncoding.EncodeToString(key.Bytes())
+
+	return publicKey, privateKey, nil
+}
+
+// Send implements `push.Client` interface.
+func (c *WebPushClient) Send(token string, m *Message) error {
+	sub := WebPushSubscription{}
+	if err := json.Unmarshal([]byte(token), &sub); err != nil || sub.Endpoint == "" {
+		return errors.Join(ErrInvalidToken, err)
+	}
+
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil {
+		return errors.Join(ErrInvalidToken, err)
+	}
+
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	// single aes128gcm record (+1 padding delimiter and 16 auth tag bytes)
+	if len(payload)+17 > int(webPushRecordSize) {
+		return errors.New("webpush: the message payload is too large")
+	}
+
+	body, err := encryptWebPushPayload(&sub, payload)
+	if err != nil {
+		return errors.Join(ErrInvalidToken, err)
+	}
+
+	vapidToken, err := c.vapidToken(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return err
+	}
+
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = 86400
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "vapid t="+vapidToken+", k="+c.VapidPublicKey)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(ttl))
+	req.Header.Set("Urgency", "normal")
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderWebPush, res, func(status int, body []byte) bool {
+		return status == http.StatusNotFound || status == http.StatusGone
+	})
+}
+
+// vapidToken generates a new ES256 signed VAPID JWT for the specified push service origin.
+func (c *WebPushClient) vapidToken(audience string) (string, error) {
+	rawPrivateKey, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(c.VapidPrivateKey, "="))
+	if err != nil {
+		return "", err
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(rawPrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey := key.PublicKey().Bytes() // 0x04 || X || Y
+
+	signingKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(publicKey[1:33]),
+			Y:     new(big.Int).SetBytes(publicKey[33:]),
+		},
+		D: new(big.Int).SetBytes(rawPrivateKey),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": audience,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": c.Subject,
+	})
+
+	return token.SignedString(signingKey)
+}
+
+// encryptWebPushPayload encrypts the payload for the specified
+// subscription using the "aes128gcm" content encoding (RFC 8291).
+func encryptWebPushPayload(sub *WebPushSubscription, payload []byte) ([]byte, error) {
+	rawClientPublicKey, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(sub.Keys.P256dh, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	authSecret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(sub.Keys.Auth, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	clientPublicKey, err := ecdh.P256().NewPublicKey(rawClientPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serverPublicKey := serverKey.PublicKey().Bytes()
+
+	sharedSecret, err := serverKey.ECDH(clientPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := append([]byte("WebPush: info\x00"), rawClientPublicKey...)
+	keyInfo = append(keyInfo, serverPublicKey...)
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, authSecret, keyInfo), ikm); err != nil {
+		return nil, err
+	}
+
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+
+	cek := make([]byte, 16)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: aes128gcm\x00")), cek); err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 12)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: nonce\x00")), nonce); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// single record with the 0x02 last record padding delimiter
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	// header: salt (16) || rs (4) || idlen (1) || keyid (as_public)
+	result := bytes.NewBuffer(make([]byte, 0, 21+len(serverPublicKey)+len(plaintext)+gcm.Overhead()))
+	result.Write(salt)
+	binary.Write(result, binary.BigEndian, webPushRecordSize)
+	result.WriteByte(byte(len(serverPublicKey)))
+	result.Write(serverPublicKey)
+	result.Write(gcm.Seal(nil, nonce, plaintext, nil))
+
+	return result.Bytes(), nil
+}
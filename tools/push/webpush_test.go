@@ -0,0 +1,189 @@
+package push
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+	"golang.org/x/crypto/hkdf"
+)
+
+// decryptWebPushPayload decrypts an aes128gcm encrypted payload
+// as a user agent would do (RFC 8291).
+func decryptWebPushPayload(t *testing.T, key *ecdh.PrivateKey, authSecret []byte, body []byte) []byte {
+	salt := body[:16]
+	rs := binary.BigEndian.Uint32(body[16:20])
+	idlen := int(body[20])
+	serverPublicKey := body[21 : 21+idlen]
+	ciphertext := body[21+idlen:]
+
+	if rs != webPushRecordSize {
+		t.Fatalf("Expected record size %d, got %d", webPushRecordSize, rs)
+	}
+
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sharedSecret, err := key.ECDH(serverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, serverPublicKey...)
+	ikm := make([]byte, 32)
+	io.ReadFull(hkdf.New(sha256.New, sharedSecret, authSecret, keyInfo), ikm)
+
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+
+	cek := make([]byte, 16)
+	io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: aes128gcm\x00")), cek)
+
+	nonce := make([]byte, 12)
+	io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: nonce\x00")), nonce)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.TrimRight(plaintext, "\x02\x00")
+}
+
+func testWebPushSubscription(t *testing.T, endpoint string) (string, *ecdh.PrivateKey, []byte) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authSecret := make([]byte, 16)
+	rand.Read(authSecret)
+
+	sub := WebPushSubscription{Endpoint: endpoint}
+	sub.Keys.P256dh = base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	sub.Keys.Auth = base64.RawURLEncoding.EncodeToString(authSecret)
+
+	raw, _ := json.Marshal(sub)
+
+	return string(raw), key, authSecret
+}
+
+func TestGenerateVAPIDKeys(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawPublicKey, _ := base64.RawURLEncoding.DecodeString(publicKey)
+	if len(rawPublicKey) != 65 {
+		t.Fatalf("Expected 65 bytes uncompressed public key, got %d", len(rawPublicKey))
+	}
+
+	rawPrivateKey, _ := base64.RawURLEncoding.DecodeString(privateKey)
+	if len(rawPrivateKey) != 32 {
+		t.Fatalf("Expected 32 bytes private key, got %d", len(rawPrivateKey))
+	}
+}
+
+func TestWebPushClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 201, ``)
+
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &WebPushClient{
+		VapidPublicKey:  publicKey,
+		VapidPrivateKey: privateKey,
+		Subject:         "mailto:test@example.com",
+	}
+
+	token, key, authSecret := testWebPushSubscription(t, server.URL+"/push/abc")
+
+	if err := client.Send(token, testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/push/abc" {
+		t.Fatalf("Unexpected request path %q", captured.Path)
+	}
+
+	if encoding := captured.Header.Get("Content-Encoding"); encoding != "aes128gcm" {
+		t.Fatalf("Expected aes128gcm Content-Encoding, got %q", encoding)
+	}
+
+	if ttl := captured.Header.Get("TTL"); ttl != "86400" {
+		t.Fatalf("Expected default TTL 86400, got %q", ttl)
+	}
+
+	// vapid authorization
+	auth := captured.Header.Get("Authorization")
+	if !strings.HasSuffix(auth, ", k="+publicKey) {
+		t.Fatalf("Expected the vapid public key in the Authorization header, got %q", auth)
+	}
+	rawToken := strings.TrimSuffix(strings.TrimPrefix(auth, "vapid t="), ", k="+publicKey)
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawToken, claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims["aud"] != server.URL {
+		t.Fatalf("Expected aud claim %q, got %v", server.URL, claims["aud"])
+	}
+	if claims["sub"] != "mailto:test@example.com" {
+		t.Fatalf("Expected sub claim mailto:test@example.com, got %v", claims["sub"])
+	}
+
+	// payload
+	payload := decryptWebPushPayload(t, key, authSecret, captured.Body)
+	expectedPayload := `{"title":"test title","body":"test body","data":{"orderId":"abc"}}`
+	if string(payload) != expectedPayload {
+		t.Fatalf("Expected payload\n%s\ngot\n%s", expectedPayload, payload)
+	}
+}
+
+func TestWebPushClientSendInvalidToken(t *testing.T) {
+	server, _ := httpmock.NewServer(t, 410, `gone`)
+
+	publicKey, privateKey, _ := GenerateVAPIDKeys()
+
+	client := &WebPushClient{
+		VapidPublicKey:  publicKey,
+		VapidPrivateKey: privateKey,
+	}
+
+	validToken, _, _ := testWebPushSubscription(t, server.URL)
+
+	scenarios := []struct {
+		name  string
+		token string
+	}{
+		{"malformed token", "invalid"},
+		{"missing keys", `{"endpoint":"` + server.URL + `"}`},
+		{"expired subscription", validToken},
+	}
+
+	for _, s := range scenarios {
+		err := client.Send(s.token, testMessage())
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("[%s] Expected ErrInvalidToken, got %v", s.name, err)
+		}
+	}
+}
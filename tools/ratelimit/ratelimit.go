@@ -0,0 +1,77 @@
+// Package ratelimit implements a simple in-memory fixed window rate limiter.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// cleanupThreshold is the number of tracked keys after which
+// the expired windows are removed on the next Allow call.
+const cleanupThreshold = 10000
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// Limiter defines a concurrent safe fixed window rate limiter.
+type Limiter struct {
+	windows map[string]*window
+	now     func() time.Time
+	mux     sync.Mutex
+}
+
+// New creates a new Limiter instance.
+func New() *Limiter {
+	return &Limiter{
+		windows: map[string]*window{},
+		now:     time.Now,
+	}
+}
+
+// Allow reports whether a new request for the specified key is allowed,
+// aka. whether there were less than maxRequests in the current interval.
+//
+// The key usually combines the rate limit profile and the client identifier (eg. its IP).
+func (l *Limiter) Allow(key string, maxRequests int, interval time.Duration) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	now := l.now()
+
+	if len(l.windows) >= cleanupThreshold {
+		l.cleanup(now, interval)
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= interval {
+		l.windows[key] = &window{start: now, count: 1}
+		return maxRequests > 0
+	}
+
+	if w.count >= maxRequests {
+		return false
+	}
+
+	w.count++
+
+	return true
+}
+
+// Reset removes all tracked windows.
+func (l *Limiter) Reset() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.windows = map[string]*window{}
+}
+
+// cleanup removes the windows that are older than the specified interval.
+func (l *Limiter) cleanup(now time.Time, interval time.Duration) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= interval {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	l := New()
+	l.now = func() time.Time {
+		return now
+	}
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow("a", 3, time.Minute) {
+			t.Fatalf("Expected request %d to be allowed", i)
+		}
+	}
+
+	if l.Allow("a", 3, time.Minute) {
+		t.Fatal("Expected the 4th request to be rejected")
+	}
+
+	// different key
+	if !l.Allow("b", 3, time.Minute) {
+		t.Fatal("Expected the b key request to be allowed")
+	}
+
+	// new window
+	now = now.Add(time.Minute)
+	if !l.Allow("a", 3, time.Minute) {
+		t.Fatal("Expected the a key request to be allowed in the new window")
+	}
+
+	// zero max requests
+	if l.Allow("c", 0, time.Minute) {
+		t.Fatal("Expected the c key request with zero max requests to be rejected")
+	}
+
+	l.Reset()
+	if len(l.windows) != 0 {
+		t.Fatalf("Expected no windows after reset, got %d", len(l.windows))
+	}
+}
+
+func TestLimiterCleanup(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	l := New()
+	l.now = func() time.Time {
+		return now
+	}
+
+	l.Allow("old", 1, time.Second)
+
+	now = now.Add(time.Second)
+	l.Allow("new", 1, time.Second)
+
+	l.cleanup(now, time.Second)
+
+	if _, ok := l.windows["old"]; ok {
+		t.Fatal("Expected the old window to be removed")
+	}
+	if _, ok := l.windows["new"]; !ok {
+		t.Fatal("Expected the new window to be kept")
+	}
+}
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/transport"
+)
+
+// DefaultHttpClient is the http client used by the external API clients
+// (mailer, sms, push, captcha, etc.) when no explicit client is set.
+var DefaultHttpClient = &http.Client{
+	Timeout:   30 * time.Second,
+	Transport: transport.Default,
+}
+
+// ResolveHttpClient returns the provided client or [DefaultHttpClient] if nil.
+func ResolveHttpClient(client *http.Client) *http.Client {
+	if client == nil {
+		return DefaultHttpClient
+	}
+
+	return client
+}
+
+// maxErrorBodySize is the max number of response body bytes
+// that are read and stored in a [ResponseError].
+const maxErrorBodySize = 1 << 16
+
+// ResponseError defines a non 2xx external API response error.
+type ResponseError struct {
+	Status int
+	Body   []byte
+}
+
+// Error implements the [error] interface.
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("(%d): %s", e.Status, e.Body)
+}
+
+// CheckResponse returns a [*ResponseError] if res has a non 2xx status code.
+//
+// The error holds up to the first 64KB of the response body.
+func CheckResponse(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+
+	return &ResponseError{Status: res.StatusCode, Body: body}
+}
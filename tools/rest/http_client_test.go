@@ -0,0 +1,68 @@
+package rest_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+func TestResolveHttpClient(t *testing.T) {
+	if c := rest.ResolveHttpClient(nil); c != rest.DefaultHttpClient {
+		t.Fatalf("Expected the default client, got %v", c)
+	}
+
+	custom := &http.Client{}
+	if c := rest.ResolveHttpClient(custom); c != custom {
+		t.Fatalf("Expected the custom client, got %v", c)
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	scenarios := []struct {
+		status      int
+		body        string
+		expectError bool
+	}{
+		{200, "ok", false},
+		{204, "", false},
+		{299, "", false},
+		{301, "moved", true},
+		{400, "invalid", true},
+		{500, "", true},
+	}
+
+	for _, s := range scenarios {
+		res := &http.Response{
+			StatusCode: s.status,
+			Body:       io.NopCloser(strings.NewReader(s.body)),
+		}
+
+		err := rest.CheckResponse(res)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", s.status, s.expectError, hasErr, err)
+		}
+
+		if !hasErr {
+			continue
+		}
+
+		var resErr *rest.ResponseError
+		if !errors.As(err, &resErr) {
+			t.Fatalf("[%d] Expected ResponseError, got %T", s.status, err)
+		}
+
+		if resErr.Status != s.status {
+			t.Fatalf("[%d] Expected status %d, got %d", s.status, s.status, resErr.Status)
+		}
+
+		if string(resErr.Body) != s.body {
+			t.Fatalf("[%d] Expected body %q, got %q", s.status, s.body, resErr.Body)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package search
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+)
+
+// cursorData is the decoded representation of an opaque pagination cursor.
+type cursorData struct {
+	// Sort is the sort signature of the query that generated the cursor
+	// and it is used to prevent using the cursor with a different sort.
+	Sort string `json:"s"`
+
+	// Values are the sort keys of the last returned item (in sort order).
+	Values []any `json:"v"`
+}
+
+// cursorSort normalizes the provided sort fields for keyset pagination
+// by appending the unique tie-breaker column (if not already present).
+//
+// Only plain field sort keys (no relations, modifiers or macros) are supported.
+func cursorSort(sort []SortField, tieBreaker string) ([]SortField, error) {
+	result := make([]SortField, 0, len(sort)+1)
+
+	hasTieBreaker := false
+
+	for _, field := range sort {
+		if field.Name == "" || strings.ContainsAny(field.Name, ".:@") {
+			return nil, fmt.Errorf("sort field %q is not supported with cursor pagination", field.Name)
+		}
+
+		if field.Name == tieBreaker {
+			hasTieBreaker = true
+		}
+
+		result = append(result, field)
+	}
+
+	if !hasTieBreaker {
+		result = append(result, SortField{Name: tieBreaker, Direction: SortAsc})
+	}
+
+	return result, nil
+}
+
+// cursorSignature returns the sort signature stored in the cursors.
+func cursorSignature(sort []SortField) string {
+	parts := make([]string, len(sort))
+	for i, field := range sort {
+		parts[i] = field.Name + " " + field.Direction
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// encodeCursor generates a new opaque cursor from the sort keys of the provided item.
+//
+// The sort keys are extracted with item.Get(field) if the item implements it
+// (eg. models.Record) or from the item JSON serialization otherwise.
+func encodeCursor(sort []SortField, item any) (string, error) {
+	var data map[string]any
+
+	getter, hasGetter := item.(interface{ Get(string) any })
+	if !hasGetter {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return "", err
+		}
+
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return "", err
+		}
+	}
+
+	cursor := cursorData{
+		Sort:   cursorSignature(sort),
+		Values: make([]any, len(sort)),
+	}
+
+	for i, field := range sort {
+		if hasGetter {
+			cursor.Values[i] = getter.Get(field.Name)
+		} else {
+			cursor.Values[i] = data[field.Name]
+		}
+	}
+
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor parses the provided opaque cursor and validates it
+// against the current sort fields.
+func decodeCursor(cursor string, sort []SortField) (*cursorData, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor format")
+	}
+
+	data := &cursorData{}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, errors.New("invalid cursor format")
+	}
+
+	if data.Sort != cursorSignature(sort) || len(data.Values) != len(sort) {
+		return nil, errors.New("the cursor doesn't match the current sort")
+	}
+
+	return data, nil
+}
+
+// buildCursorExpr builds the keyset condition that selects
+// only the items after the provided cursor sort keys, eg.:
+//
+//	(a > {:a}) OR (a = {:a} AND b < {:b}) OR (a = {:a} AND b = {:b} AND id > {:id})
+func buildCursorExpr(fieldResolver FieldResolver, sort []SortField, cursor *cursorData) (dbx.Expression, error) {
+	identifiers := make([]string, len(sort))
+	for i, field := range sort {
+		result, err := fieldResolver.Resolve(field.Name)
+		if err != nil || len(result.Params) > 0 || result.Identifier == "" || strings.ToLower(result.Identifier) == "null" {
+			return nil, fmt.Errorf("invalid sort field %q", field.Name)
+		}
+		identifiers[i] = result.Identifier
+	}
+
+	params := dbx.Params{}
+	for i, v := range cursor.Values {
+		params["cursor"+strconv.Itoa(i)] = v
+	}
+
+	ors := make([]string, len(sort))
+	for i, field := range sort {
+		ands := make([]string, 0, i+1)
+
+		for j := 0; j < i; j++ {
+			ands = append(ands, fmt.Sprintf("%s = {:cursor%d}", identifiers[j], j))
+		}
+
+		op := ">"
+		if field.Direction == SortDesc {
+			op = "<"
+		}
+		ands = append(ands, fmt.Sprintf("%s %s {:cursor%d}", identifiers[i], op, i))
+
+		ors[i] = "(" + strings.Join(ands, " AND ") + ")"
+	}
+
+	return dbx.NewExp("("+strings.Join(ors, " OR ")+")", params), nil
+}
+
+// lastSliceItem returns the last element of the provided slice pointer
+// (or nil if the slice is empty or items is not a slice pointer).
+func lastSliceItem(items any) any {
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Slice || rv.Elem().Len() == 0 {
+		return nil
+	}
+
+	return rv.Elem().Index(rv.Elem().Len() - 1).Interface()
+}
+
+// truncateSlice truncates the provided slice pointer to max n elements
+// and reports whether there were more elements.
+func truncateSlice(items any, n int) bool {
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Slice || rv.Elem().Len() <= n {
+		return false
+	}
+
+	rv.Elem().Set(rv.Elem().Slice(0, n))
+
+	return true
+}
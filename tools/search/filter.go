@@ -64,6 +64,21 @@ func (f FilterData) BuildExpr(
 		}
 	}
 
+	// replace the function calls (if any) with resolvable placeholders
+	if strings.Contains(raw, "(") {
+		var calls map[string]*filterFunctionCall
+		var err error
+
+		raw, calls, err = extractFilterFunctions(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(calls) > 0 {
+			fieldResolver = &filterFunctionsResolver{FieldResolver: fieldResolver, calls: calls}
+		}
+	}
+
 	if parsedFilterData.Has(raw) {
 		return buildParsedFilterExpr(parsedFilterData.Get(raw), fieldResolver)
 	}
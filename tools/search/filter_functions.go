@@ -0,0 +1,257 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/dbx"
+)
+
+// filterFunc defines a filter function that resolves
+// the provided call arguments into a single SQL operand.
+type filterFunc func(fieldResolver FieldResolver, args ...fexpr.Token) (*ResolverResult, error)
+
+// filterFunctions holds the supported filter functions.
+var filterFunctions = map[string]filterFunc{
+	"geoDistance": geoDistanceFunc,
+}
+
+// filterFunctionPlaceholderPrefix is the prefix of the identifiers
+// that replace the function calls in the raw filter string.
+const filterFunctionPlaceholderPrefix = "@__fn"
+
+// filterFunctionCall represents a single parsed filter function call.
+type filterFunctionCall struct {
+	name string
+	args []fexpr.Token
+}
+
+// extractFilterFunctions replaces all supported function calls in the
+// raw filter string with placeholder identifiers (since the fexpr grammar
+// doesn't support function calls), eg.:
+//
+//	"geoDistance(location, 42.69, 23.32) < 5000" -> "@__fn0 < 5000"
+//
+// Returns the rewritten filter and the placeholder->call pairs.
+func extractFilterFunctions(raw string) (string, map[string]*filterFunctionCall, error) {
+	var result strings.Builder
+	var calls map[string]*filterFunctionCall
+
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+
+		// skip quoted text
+		if quote != 0 {
+			if ch == '\\' && i+1 < len(raw) {
+				result.WriteByte(ch)
+				i++
+				result.WriteByte(raw[i])
+				continue
+			}
+			if ch == quote {
+				quote = 0
+			}
+			result.WriteByte(ch)
+			continue
+		}
+		if ch == '\'' || ch == '"' {
+			quote = ch
+			result.WriteByte(ch)
+			continue
+		}
+
+		// check for a function name at identifier boundary
+		if isFilterNameStart(ch) && (i == 0 || !isFilterNameChar(raw[i-1])) {
+			end := i
+			for end < len(raw) && isFilterNameChar(raw[end]) {
+				end++
+			}
+
+			name := raw[i:end]
+
+			openIndex := end
+			for openIndex < len(raw) && raw[openIndex] == ' ' {
+				openIndex++
+			}
+
+			if _, ok := filterFunctions[name]; ok && openIndex < len(raw) && raw[openIndex] == '(' {
+				rawArgs, closeIndex, err := splitFilterFunctionArgs(raw, openIndex)
+				if err != nil {
+					return "", nil, fmt.Errorf("invalid %s() call: %w", name, err)
+				}
+
+				args := make([]fexpr.Token, len(rawArgs))
+				for j, rawArg := range rawArgs {
+					args[j], err = scanFilterFunctionArg(rawArg)
+					if err != nil {
+						return "", nil, fmt.Errorf("invalid %s() argument %d: %w", name, j, err)
+					}
+				}
+
+				if calls == nil {
+					calls = map[string]*filterFunctionCall{}
+				}
+				placeholder := filterFunctionPlaceholderPrefix + strconv.Itoa(len(calls))
+				calls[placeholder] = &filterFunctionCall{name: name, args: args}
+
+				result.WriteString(placeholder)
+				i = closeIndex
+				continue
+			}
+
+			result.WriteString(name)
+			i = end - 1
+			continue
+		}
+
+		result.WriteByte(ch)
+	}
+
+	return result.String(), calls, nil
+}
+
+// splitFilterFunctionArgs returns the top-level comma separated
+// arguments of the function call starting at openIndex (aka. "(").
+func splitFilterFunctionArgs(raw string, openIndex int) ([]string, int, error) {
+	var args []string
+	var quote byte
+
+	depth := 0
+	argStart := openIndex + 1
+
+	for i := openIndex; i < len(raw); i++ {
+		ch := raw[i]
+
+		if quote != 0 {
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch ch {
+		case '\'', '"':
+			quote = ch
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				if last := strings.TrimSpace(raw[argStart:i]); last != "" || len(args) > 0 {
+					args = append(args, last)
+				}
+				return args, i, nil
+			}
+		case ',':
+			if depth == 1 {
+				args = append(args, strings.TrimSpace(raw[argStart:i]))
+				argStart = i + 1
+			}
+		}
+	}
+
+	return nil, 0, errors.New("missing closing parenthesis")
+}
+
+// scanFilterFunctionArg scans a single function argument token.
+func scanFilterFunctionArg(rawArg string) (fexpr.Token, error) {
+	scanner := fexpr.NewScanner(strings.NewReader(rawArg))
+
+	token, err := scanner.Scan()
+	if err != nil {
+		return token, err
+	}
+
+	switch token.Type {
+	case fexpr.TokenIdentifier, fexpr.TokenNumber, fexpr.TokenText:
+	default:
+		return token, fmt.Errorf("unsupported argument %q", rawArg)
+	}
+
+	if next, _ := scanner.Scan(); next.Type != fexpr.TokenEOF {
+		return token, fmt.Errorf("unsupported argument %q", rawArg)
+	}
+
+	return token, nil
+}
+
+func isFilterNameStart(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isFilterNameChar(ch byte) bool {
+	return isFilterNameStart(ch) || (ch >= '0' && ch <= '9') || ch == '_' ||
+		ch == '@' || ch == '#' || ch == '.' || ch == ':'
+}
+
+// filterFunctionsResolver is a [FieldResolver] wrapper that resolves
+// the extracted function call placeholders.
+type filterFunctionsResolver struct {
+	FieldResolver
+
+	calls map[string]*filterFunctionCall
+}
+
+// Resolve implements the [FieldResolver] interface.
+func (r *filterFunctionsResolver) Resolve(field string) (*ResolverResult, error) {
+	call, ok := r.calls[field]
+	if !ok {
+		return r.FieldResolver.Resolve(field)
+	}
+
+	return filterFunctions[call.name](r.FieldResolver, call.args...)
+}
+
+// -------------------------------------------------------------------
+
+// geoDistanceFunc resolves the "geoDistance(field, lat, lng)" filter
+// function call to the distance in meters between the geo point field
+// value and the provided coordinates.
+func geoDistanceFunc(fieldResolver FieldResolver, args ...fexpr.Token) (*ResolverResult, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("geoDistance() expects 3 arguments, got %d", len(args))
+	}
+
+	if args[0].Type != fexpr.TokenIdentifier {
+		return nil, errors.New("the first geoDistance() argument must be a field identifier")
+	}
+
+	resolved := make([]*ResolverResult, len(args))
+	for i, arg := range args {
+		r, err := resolveToken(arg, fieldResolver)
+		if err != nil || r.Identifier == "" {
+			return nil, fmt.Errorf("invalid geoDistance() argument %q - %v", arg.Literal, err)
+		}
+
+		if r.MultiMatchSubQuery != nil {
+			return nil, fmt.Errorf("geoDistance() doesn't support multiple values argument %q", arg.Literal)
+		}
+
+		resolved[i] = r
+	}
+
+	params := dbx.Params{}
+	for _, r := range resolved {
+		for k, v := range r.Params {
+			params[k] = v
+		}
+	}
+
+	field := resolved[0].Identifier
+
+	return &ResolverResult{
+		NoCoalesce: true,
+		Identifier: fmt.Sprintf(
+			"geo_distance(json_extract(%s, '$.lat'), json_extract(%s, '$.lng'), %s, %s)",
+			field, field, resolved[1].Identifier, resolved[2].Identifier,
+		),
+		Params: params,
+	}, nil
+}
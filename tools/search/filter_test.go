@@ -138,6 +138,36 @@ func TestFilterDataBuildExpr(t *testing.T) {
 			false,
 			"((COALESCE([[test1]], '') = COALESCE([[test2]], '') OR COALESCE([[test2]], '') IS NOT COALESCE([[test3]], '')) AND ([[test2]] LIKE {:TEST} ESCAPE '\\' OR [[test2]] NOT LIKE {:TEST} ESCAPE '\\') AND {:TEST} LIKE ('%' || [[test1]] || '%') ESCAPE '\\' AND {:TEST} NOT LIKE ('%' || [[test2]] || '%') ESCAPE '\\' AND [[test3]] > {:TEST} AND [[test3]] >= {:TEST} AND [[test3]] <= {:TEST} AND {:TEST} < {:TEST})",
 		},
+		{
+			"geoDistance with invalid number of arguments",
+			"geoDistance(test1, 1) < 100",
+			true,
+			"",
+		},
+		{
+			"geoDistance with unknown field",
+			"geoDistance(unknown, 1, 2) < 100",
+			true,
+			"",
+		},
+		{
+			"geoDistance with non identifier first argument",
+			"geoDistance(1, 2, 3) < 100",
+			true,
+			"",
+		},
+		{
+			"geoDistance with missing closing parenthesis",
+			"geoDistance(test1, 1, 2 < 100",
+			true,
+			"",
+		},
+		{
+			"geoDistance call",
+			"geoDistance(test1, 42.69, test2) < 5000 && test3 != 'geoDistance(test1, 1, 2)'",
+			false,
+			"(geo_distance(json_extract([[test1]], '$.lat'), json_extract([[test1]], '$.lng'), {:TEST}, [[test2]]) < {:TEST} AND [[test3]] IS NOT {:TEST})",
+		},
 	}
 
 	for _, s := range scenarios {
@@ -2,6 +2,7 @@ package search
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pocketbase/pocketbase/tools/types"
@@ -113,3 +114,19 @@ var identifierMacros = map[string]func() (any, error){
 		return d.String(), nil
 	},
 }
+
+// HasIdentifierMacro checks whether the raw filter expression contains
+// any of the time based identifier macros (eg. "@now", "@todayStart").
+func HasIdentifierMacro(raw string) bool {
+	if !strings.Contains(raw, "@") {
+		return false
+	}
+
+	for macro := range identifierMacros {
+		if strings.Contains(raw, macro) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -54,3 +54,24 @@ func TestIdentifierMacros(t *testing.T) {
 	// restore
 	timeNow = originalTimeNow
 }
+
+func TestHasIdentifierMacro(t *testing.T) {
+	scenarios := []struct {
+		raw      string
+		expected bool
+	}{
+		{"", false},
+		{"title = 'test'", false},
+		{"@request.auth.id != ''", false},
+		{"created > @now", true},
+		{"created > @todayStart && a = 1", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.raw, func(t *testing.T) {
+			if result := HasIdentifierMacro(s.raw); result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
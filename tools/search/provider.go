@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math"
 	"net/url"
+	"regexp"
 	"strconv"
 
 	"github.com/pocketbase/dbx"
@@ -23,6 +24,7 @@ const (
 	SortQueryParam      string = "sort"
 	FilterQueryParam    string = "filter"
 	SkipTotalQueryParam string = "skipTotal"
+	CursorQueryParam    string = "cursor"
 )
 
 // Result defines the returned search result structure.
@@ -32,6 +34,10 @@ type Result struct {
 	TotalItems int `json:"totalItems"`
 	TotalPages int `json:"totalPages"`
 	Items      any `json:"items"`
+
+	// NextCursor is the cursor of the next page in cursor pagination
+	// mode (it is empty if there are no more items).
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // Provider represents a single configured search provider instance.
@@ -44,6 +50,7 @@ type Provider struct {
 	perPage       int
 	sort          []SortField
 	filter        []FilterData
+	cursor        *string
 }
 
 // NewProvider creates and returns a new search provider.
@@ -105,6 +112,22 @@ func (s *Provider) PerPage(perPage int) *Provider {
 	return s
 }
 
+// Cursor enables the keyset (cursor) pagination mode and sets the
+// opaque cursor returned as `Result.NextCursor` from the previous page
+// (use empty string to fetch the first page).
+//
+// In cursor mode the `page` field is ignored and the items are
+// always sorted additionally by the count column (aka. "id") as
+// tie-breaker to guarantee a stable ordering.
+//
+// Note that only plain field sort keys are supported (no relations,
+// modifiers or macros like @random) and the sorted columns are not
+// expected to have NULL values.
+func (s *Provider) Cursor(cursor string) *Provider {
+	s.cursor = &cursor
+	return s
+}
+
 // Sort sets the `sort` field of the current search provider.
 func (s *Provider) Sort(sort []SortField) *Provider {
 	s.sort = sort
@@ -167,6 +190,10 @@ func (s *Provider) Parse(urlQuery string) error {
 		s.PerPage(v)
 	}
 
+	if params.Has(CursorQueryParam) {
+		s.Cursor(params.Get(CursorQueryParam))
+	}
+
 	if raw := params.Get(SortQueryParam); raw != "" {
 		for _, sortField := range ParseSortFromString(raw) {
 			s.AddSort(sortField)
@@ -201,8 +228,28 @@ func (s *Provider) Exec(items any) (*Result, error) {
 		}
 	}
 
+	sort := s.sort
+	if s.cursor != nil {
+		var err error
+		if sort, err = cursorSort(sort, s.countCol); err != nil {
+			return nil, err
+		}
+	}
+
+	var cursorExpr dbx.Expression
+	if s.cursor != nil && *s.cursor != "" {
+		cursor, err := decodeCursor(*s.cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursorExpr, err = buildCursorExpr(s.fieldResolver, sort, cursor); err != nil {
+			return nil, err
+		}
+	}
+
 	// apply sorting
-	for _, sortField := range s.sort {
+	for _, sortField := range sort {
 		expr, err := sortField.BuildExpr(s.fieldResolver)
 		if err != nil {
 			return nil, err
@@ -239,7 +286,7 @@ func (s *Provider) Exec(items any) (*Result, error) {
 		queryInfo := countQuery.Info()
 		countCol := s.countCol
 		if len(queryInfo.From) > 0 {
-			countCol = queryInfo.From[0] + "." + countCol
+			countCol = fromAlias(queryInfo.From[0]) + "." + countCol
 		}
 
 		// note: countQuery is shallow cloned and slice/map in-place modifications should be avoided
@@ -256,12 +303,37 @@ func (s *Provider) Exec(items any) (*Result, error) {
 		return nil
 	}
 
+	var nextCursor string
+
 	// apply pagination to the original query and fetch the models
 	modelsExec := func() error {
-		modelsQuery.Limit(int64(s.perPage))
-		modelsQuery.Offset(int64(s.perPage * (s.page - 1)))
+		if s.cursor == nil {
+			modelsQuery.Limit(int64(s.perPage))
+			modelsQuery.Offset(int64(s.perPage * (s.page - 1)))
+
+			return modelsQuery.All(items)
+		}
+
+		if cursorExpr != nil {
+			modelsQuery.AndWhere(cursorExpr)
+		}
+
+		// fetch 1 extra item to check whether there is a next page
+		modelsQuery.Limit(int64(s.perPage + 1))
+
+		if err := modelsQuery.All(items); err != nil {
+			return err
+		}
+
+		if truncateSlice(items, s.perPage) {
+			var err error
+			nextCursor, err = encodeCursor(sort, lastSliceItem(items))
+			if err != nil {
+				return err
+			}
+		}
 
-		return modelsQuery.All(items)
+		return nil
 	}
 
 	if !s.skipTotal {
@@ -285,6 +357,7 @@ func (s *Provider) Exec(items any) (*Result, error) {
 		TotalItems: totalCount,
 		TotalPages: totalPages,
 		Items:      items,
+		NextCursor: nextCursor,
 	}
 
 	return result, nil
@@ -299,3 +372,16 @@ func (s *Provider) ParseAndExec(urlQuery string, modelsSlice any) (*Result, erro
 
 	return s.Exec(modelsSlice)
 }
+
+// fromAliasRegex matches the alias of a "table alias" or "(subquery) AS alias" FROM clause.
+var fromAliasRegex = regexp.MustCompile(`(?i:\s+as\s+|\s+)([\w\-\.]+)$`)
+
+// fromAlias returns the alias of the provided FROM clause table
+// (or the table itself if it doesn't have an alias).
+func fromAlias(from string) string {
+	if m := fromAliasRegex.FindStringSubmatch(from); len(m) == 2 {
+		return m[1]
+	}
+
+	return from
+}
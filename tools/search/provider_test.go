@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -179,6 +180,22 @@ func TestProviderParse(t *testing.T) {
 		},
 	}
 
+	t.Run("cursor", func(t *testing.T) {
+		p := NewProvider(&testFieldResolver{})
+
+		if err := p.Parse("page=2"); err != nil || p.cursor != nil {
+			t.Fatalf("Expected nil cursor, got %v (%v)", p.cursor, err)
+		}
+
+		if err := p.Parse("cursor="); err != nil || p.cursor == nil || *p.cursor != "" {
+			t.Fatalf("Expected empty cursor, got %v (%v)", p.cursor, err)
+		}
+
+		if err := p.Parse("cursor=abc"); err != nil || p.cursor == nil || *p.cursor != "abc" {
+			t.Fatalf("Expected abc cursor, got %v (%v)", p.cursor, err)
+		}
+	})
+
 	for i, s := range scenarios {
 		r := &testFieldResolver{}
 		p := NewProvider(r).
@@ -427,6 +444,48 @@ func TestProviderExecNonEmptyQuery(t *testing.T) {
 	}
 }
 
+func TestProviderExecAliasedFrom(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	query := testDB.Select("sub.*").
+		From("(SELECT * FROM test WHERE test1 IS NOT NULL) AS sub")
+
+	result, err := NewProvider(&testFieldResolver{}).
+		Query(query).
+		Exec(&[]testTableStruct{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.TotalItems != 2 {
+		t.Fatalf("Expected 2 total items, got %d", result.TotalItems)
+	}
+
+	expectedCount := "SELECT COUNT(DISTINCT `sub`.`id`)"
+	if len(testDB.CalledQueries) == 0 || !strings.Contains(strings.Join(testDB.CalledQueries, "\n"), expectedCount) {
+		t.Fatalf("Expected %q query, got %v", expectedCount, testDB.CalledQueries)
+	}
+}
+
+func TestFromAlias(t *testing.T) {
+	scenarios := map[string]string{
+		"test":                          "test",
+		"test t":                        "t",
+		"test AS t":                     "t",
+		"(SELECT * FROM test) as sub_1": "sub_1",
+	}
+
+	for from, expected := range scenarios {
+		if result := fromAlias(from); result != expected {
+			t.Errorf("[%s] Expected %q, got %q", from, expected, result)
+		}
+	}
+}
+
 func TestProviderParseAndExec(t *testing.T) {
 	testDB, err := createTestDB()
 	if err != nil {
@@ -550,6 +609,84 @@ func TestProviderParseAndExec(t *testing.T) {
 	}
 }
 
+func TestProviderCursor(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	db := testDB.DB
+	db.Insert("test", dbx.Params{"id": 3, "test1": 3, "test2": "test2.2"}).Execute()
+	defer db.Delete("test", dbx.HashExp{"id": 3}).Execute()
+
+	fetch := func(sort string, cursor string) (*Result, []testTableStruct, error) {
+		items := []testTableStruct{}
+
+		p := NewProvider(&testFieldResolver{}).
+			Query(db.Select("*").From("test")).
+			CountCol("test1").
+			SkipTotal(true).
+			PerPage(2).
+			Cursor(cursor)
+
+		if sort != "" {
+			p.Sort(ParseSortFromString(sort))
+		}
+
+		result, err := p.Exec(&items)
+
+		return result, items, err
+	}
+
+	t.Run("unsupported sort fields", func(t *testing.T) {
+		for _, sort := range []string{"@random", "a.b", "test2:lower"} {
+			if _, _, err := fetch(sort, ""); err == nil {
+				t.Fatalf("Expected error for sort %q", sort)
+			}
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		if _, _, err := fetch("", "invalid"); err == nil {
+			t.Fatal("Expected error")
+		}
+	})
+
+	t.Run("paginate", func(t *testing.T) {
+		result, items, err := fetch("-test2", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(items) != 2 || items[0].Test1 != 2 || items[1].Test1 != 3 {
+			t.Fatalf("Expected items 2,3, got %v", items)
+		}
+
+		if result.NextCursor == "" {
+			t.Fatal("Expected non-empty next cursor")
+		}
+
+		// cursor with different sort
+		if _, _, err := fetch("test2", result.NextCursor); err == nil {
+			t.Fatal("Expected sort mismatch error")
+		}
+
+		result, items, err = fetch("-test2", result.NextCursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(items) != 1 || items[0].Test1 != 1 {
+			t.Fatalf("Expected item 1, got %v", items)
+		}
+
+		if result.NextCursor != "" {
+			t.Fatalf("Expected empty next cursor, got %q", result.NextCursor)
+		}
+	})
+}
+
 // -------------------------------------------------------------------
 // Helpers
 // -------------------------------------------------------------------
@@ -0,0 +1,228 @@
+package secrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+// maxResponseSize is the max allowed secret provider response body size.
+const maxResponseSize = 1 << 20
+
+func fetchEnv(ctx context.Context, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "env:")
+
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("missing environment variable %q", name)
+	}
+
+	return v, nil
+}
+
+func fetchFile(ctx context.Context, ref string) (string, error) {
+	raw, err := os.ReadFile(strings.TrimPrefix(ref, "file:"))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(raw), "\r\n"), nil
+}
+
+// -------------------------------------------------------------------
+
+// VaultProvider is a HashiCorp Vault secrets [Provider]
+// that supports both KV v1 and v2 secret engines.
+//
+// Example reference: "vault:kv/data/pb#smtp_password".
+type VaultProvider struct {
+	Addr      string
+	Token     string
+	Namespace string
+	Client    *http.Client
+}
+
+// NewVaultProviderFromEnv creates a new [VaultProvider] configured with
+// the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE env variables.
+func NewVaultProviderFromEnv() *VaultProvider {
+	return &VaultProvider{
+		Addr:      os.Getenv("VAULT_ADDR"),
+		Token:     os.Getenv("VAULT_TOKEN"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+	}
+}
+
+// Fetch implements the [Provider] interface.
+//
+// It returns the secret data as JSON object string.
+func (p *VaultProvider) Fetch(ctx context.Context, ref string) (string, error) {
+	if p.Addr == "" {
+		return "", errors.New("missing Vault address (VAULT_ADDR)")
+	}
+
+	path := strings.Trim(strings.TrimPrefix(ref, "vault:"), "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(p.Addr, "/")+"/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("X-Vault-Token", p.Token)
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+
+	body, err := doRequest(rest.ResolveHttpClient(p.Client), req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch Vault secret %q: %w", path, err)
+	}
+
+	result := struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+
+	// KV v2 (the secret data is nested under data.data)
+	if nested, ok := result.Data["data"]; ok && len(nested) > 0 && nested[0] == '{' {
+		if _, hasMetadata := result.Data["metadata"]; hasMetadata {
+			return string(nested), nil
+		}
+	}
+
+	// KV v1
+	encoded, err := json.Marshal(result.Data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// -------------------------------------------------------------------
+
+// AWSProvider is an AWS Secrets Manager [Provider].
+//
+// The references are the secrets ARNs (eg. "arn:aws:secretsmanager:us-east-1:123456789012:secret:pb-AbCdEf")
+// and the credentials are loaded from the default AWS credentials chain
+// (env variables, shared config, instance role, etc.).
+type AWSProvider struct {
+	Client *http.Client
+
+	// Endpoint optionally overwrites the default regional service endpoint
+	// (eg. for testing or VPC endpoints).
+	Endpoint string
+
+	mux         sync.Mutex
+	credentials aws.CredentialsProvider
+}
+
+// NewAWSProvider creates a new [AWSProvider] with the provided
+// static credentials (nil to use the default AWS credentials chain).
+func NewAWSProvider(credentials aws.CredentialsProvider) *AWSProvider {
+	return &AWSProvider{credentials: credentials}
+}
+
+// Fetch implements the [Provider] interface.
+//
+// It returns the secret SecretString value.
+func (p *AWSProvider) Fetch(ctx context.Context, ref string) (string, error) {
+	// arn:partition:service:region:account:resource
+	parts := strings.SplitN(ref, ":", 6)
+	if len(parts) != 6 || parts[2] != "secretsmanager" || parts[3] == "" {
+		return "", fmt.Errorf("invalid AWS Secrets Manager ARN %q", ref)
+	}
+	region := parts[3]
+
+	creds, err := p.retrieveCredentials(ctx, region)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(map[string]string{"SecretId": ref})
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = "https://secretsmanager." + region + ".amazonaws.com"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(string(payload)))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	hash := sha256.Sum256(payload)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "secretsmanager", region, time.Now()); err != nil {
+		return "", err
+	}
+
+	body, err := doRequest(rest.ResolveHttpClient(p.Client), req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch AWS secret %q: %w", ref, err)
+	}
+
+	result := struct {
+		SecretString *string `json:"SecretString"`
+	}{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+
+	if result.SecretString == nil {
+		return "", errors.New("binary AWS secrets are not supported")
+	}
+
+	return *result.SecretString, nil
+}
+
+func (p *AWSProvider) retrieveCredentials(ctx context.Context, region string) (aws.Credentials, error) {
+	p.mux.Lock()
+	if p.credentials == nil {
+		cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+		if err != nil {
+			p.mux.Unlock()
+			return aws.Credentials{}, err
+		}
+		p.credentials = aws.NewCredentialsCache(cfg.Credentials)
+	}
+	credentials := p.credentials
+	p.mux.Unlock()
+
+	return credentials.Retrieve(ctx)
+}
+
+// doRequest sends the request and returns the response body
+// (or an error for non 2xx responses).
+func doRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if err := rest.CheckResponse(res); err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+}
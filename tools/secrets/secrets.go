@@ -0,0 +1,196 @@
+// Package secrets implements resolving of external secret references
+// like "env:SMTP_PASS", "file:/run/secrets/smtp_pass",
+// "vault:kv/data/pb#smtp_password" or AWS Secrets Manager ARNs.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is the default duration for which
+// the resolved secret values are cached.
+const DefaultCacheTTL = 5 * time.Minute
+
+// Provider defines a single external secrets source.
+type Provider interface {
+	// Fetch returns the raw secret value of the provided reference
+	// (the reference is without the "#key" fragment).
+	Fetch(ctx context.Context, ref string) (string, error)
+}
+
+// ProviderFunc is an adapter to allow the use of an ordinary function as [Provider].
+type ProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// Fetch implements the [Provider] interface.
+func (f ProviderFunc) Fetch(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+type cacheItem struct {
+	value   string
+	expires time.Time
+}
+
+// Manager resolves and caches the external secret references.
+//
+// A reference has the format "scheme:path#key", where the scheme
+// selects the registered provider and the optional key selects
+// a single field from a JSON object secret.
+type Manager struct {
+	providers map[string]Provider
+	cache     map[string]*cacheItem
+	ttl       time.Duration
+	mux       sync.RWMutex
+}
+
+// New creates a new secrets Manager with the default providers:
+//   - "env" - environment variables (eg. "env:SMTP_PASS")
+//   - "file" - file contents (eg. "file:/run/secrets/smtp_pass")
+//   - "vault" - HashiCorp Vault secrets (eg. "vault:kv/data/pb#smtp_password"),
+//     configured with the VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE env variables
+//   - "arn" - AWS Secrets Manager ARNs (eg. "arn:aws:secretsmanager:us-east-1:123:secret:pb#smtp"),
+//     using the default AWS credentials chain
+//
+// ttl is the cache duration of the resolved values (<= 0 disables the cache).
+func New(ttl time.Duration) *Manager {
+	m := &Manager{
+		providers: map[string]Provider{},
+		cache:     map[string]*cacheItem{},
+		ttl:       ttl,
+	}
+
+	m.Register("env", ProviderFunc(fetchEnv))
+	m.Register("file", ProviderFunc(fetchFile))
+	m.Register("vault", NewVaultProviderFromEnv())
+	m.Register("arn", NewAWSProvider(nil))
+
+	return m
+}
+
+// Register registers (or replaces) the provider of the specified reference scheme.
+func (m *Manager) Register(scheme string, provider Provider) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.providers[strings.ToLower(scheme)] = provider
+}
+
+// TTL returns the cache duration of the resolved values.
+func (m *Manager) TTL() time.Duration {
+	return m.ttl
+}
+
+// IsReference reports whether value is a reference with a registered scheme.
+func (m *Manager) IsReference(value string) bool {
+	scheme := Scheme(value)
+	if scheme == "" {
+		return false
+	}
+
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	_, ok := m.providers[scheme]
+
+	return ok
+}
+
+// Resolve returns the secret value of the provided reference
+// (from the cache if not expired).
+func (m *Manager) Resolve(ctx context.Context, ref string) (string, error) {
+	now := time.Now()
+
+	m.mux.RLock()
+	item, ok := m.cache[ref]
+	provider := m.providers[Scheme(ref)]
+	m.mux.RUnlock()
+
+	if ok && now.Before(item.expires) {
+		return item.value, nil
+	}
+
+	if provider == nil {
+		return "", fmt.Errorf("missing secrets provider for %q", Scheme(ref))
+	}
+
+	path, key, _ := strings.Cut(ref, "#")
+
+	raw, err := provider.Fetch(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := extractKey(raw, key)
+	if err != nil {
+		return "", err
+	}
+
+	if m.ttl > 0 {
+		m.mux.Lock()
+		m.cache[ref] = &cacheItem{value: value, expires: now.Add(m.ttl)}
+		m.mux.Unlock()
+	}
+
+	return value, nil
+}
+
+// Clear removes all cached values so that the next [Manager.Resolve]
+// call fetches the latest secret value (eg. after rotation).
+func (m *Manager) Clear() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.cache = map[string]*cacheItem{}
+}
+
+// Scheme returns the lowercased scheme of the provided reference
+// or empty string if the value is not in "scheme:path" format.
+func Scheme(ref string) string {
+	scheme, path, ok := strings.Cut(ref, ":")
+	if !ok || scheme == "" || path == "" {
+		return ""
+	}
+
+	for _, c := range scheme {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return ""
+		}
+	}
+
+	return strings.ToLower(scheme)
+}
+
+// extractKey returns the raw value or, if key is set,
+// the key field value of the raw JSON object.
+func extractKey(raw string, key string) (string, error) {
+	if key == "" {
+		return raw, nil
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return "", errors.New("the secret is not a JSON object")
+	}
+
+	v, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("missing secret key %q", key)
+	}
+
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,319 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+	"github.com/pocketbase/pocketbase/tools/secrets"
+)
+
+func TestScheme(t *testing.T) {
+	scenarios := []struct {
+		ref      string
+		expected string
+	}{
+		{"", ""},
+		{"plain", ""},
+		{"env:", ""},
+		{":abc", ""},
+		{"has space:abc", ""},
+		{"env:SMTP_PASS", "env"},
+		{"VAULT:kv/data/pb#key", "vault"},
+		{"arn:aws:secretsmanager:us-east-1:123:secret:pb", "arn"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.ref, func(t *testing.T) {
+			if v := secrets.Scheme(s.ref); v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestManagerIsReference(t *testing.T) {
+	m := secrets.New(0)
+
+	scenarios := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"plain password", false},
+		{"missing:abc", false},
+		{"env:SMTP_PASS", true},
+		{"file:/run/secrets/test", true},
+		{"vault:kv/data/pb#key", true},
+		{"arn:aws:secretsmanager:us-east-1:123:secret:pb", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			if v := m.IsReference(s.value); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestManagerResolve(t *testing.T) {
+	t.Setenv("PB_TEST_SECRET", "env_value")
+	t.Setenv("PB_TEST_JSON_SECRET", `{"a":"json_value","b":123}`)
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file_value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := secrets.New(0)
+
+	scenarios := []struct {
+		ref           string
+		expected      string
+		expectedError bool
+	}{
+		{"missing:abc", "", true},
+		{"env:PB_TEST_MISSING", "", true},
+		{"env:PB_TEST_SECRET", "env_value", false},
+		{"env:PB_TEST_SECRET#a", "", true},
+		{"env:PB_TEST_JSON_SECRET#a", "json_value", false},
+		{"env:PB_TEST_JSON_SECRET#b", "123", false},
+		{"env:PB_TEST_JSON_SECRET#c", "", true},
+		{"file:" + secretFile, "file_value", false},
+		{"file:" + secretFile + "_missing", "", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.ref, func(t *testing.T) {
+			v, err := m.Resolve(context.Background(), s.ref)
+
+			if hasErr := err != nil; hasErr != s.expectedError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectedError, hasErr, err)
+			}
+
+			if v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestManagerCache(t *testing.T) {
+	calls := 0
+
+	m := secrets.New(time.Minute)
+	m.Register("test", secrets.ProviderFunc(func(ctx context.Context, ref string) (string, error) {
+		calls++
+		return ref, nil
+	}))
+
+	for i := 0; i < 3; i++ {
+		v, err := m.Resolve(context.Background(), "test:abc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != "test:abc" {
+			t.Fatalf("Expected %q, got %q", "test:abc", v)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("Expected 1 provider call, got %d", calls)
+	}
+
+	m.Clear()
+
+	if _, err := m.Resolve(context.Background(), "test:abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("Expected 2 provider calls after clear, got %d", calls)
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		ref           string
+		status        int
+		response      string
+		namespace     string
+		expectedPath  string
+		expected      string
+		expectedError bool
+	}{
+		{
+			"kv v2",
+			"vault:kv/data/pb#smtp_password",
+			200,
+			`{"data":{"data":{"smtp_password":"v2_value"},"metadata":{"version":1}}}`,
+			"",
+			"/v1/kv/data/pb",
+			"v2_value",
+			false,
+		},
+		{
+			"kv v1 with namespace",
+			"vault:secret/pb#smtp_password",
+			200,
+			`{"data":{"smtp_password":"v1_value"}}`,
+			"test_ns",
+			"/v1/secret/pb",
+			"v1_value",
+			false,
+		},
+		{
+			"missing secret",
+			"vault:kv/data/missing#smtp_password",
+			404,
+			`{"errors":[]}`,
+			"",
+			"/v1/kv/data/missing",
+			"",
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			server, captured := httpmock.NewServer(t, s.status, s.response)
+
+			m := secrets.New(0)
+			m.Register("vault", &secrets.VaultProvider{
+				Addr:      server.URL,
+				Token:     "test_token",
+				Namespace: s.namespace,
+			})
+
+			v, err := m.Resolve(context.Background(), s.ref)
+
+			if hasErr := err != nil; hasErr != s.expectedError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectedError, hasErr, err)
+			}
+
+			if v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+
+			if captured.Method != http.MethodGet || captured.Path != s.expectedPath {
+				t.Fatalf("Expected GET %s request, got %s %s", s.expectedPath, captured.Method, captured.Path)
+			}
+
+			if token := captured.Header.Get("X-Vault-Token"); token != "test_token" {
+				t.Fatalf("Expected X-Vault-Token header %q, got %q", "test_token", token)
+			}
+
+			if ns := captured.Header.Get("X-Vault-Namespace"); ns != s.namespace {
+				t.Fatalf("Expected X-Vault-Namespace header %q, got %q", s.namespace, ns)
+			}
+		})
+	}
+}
+
+func TestAWSProvider(t *testing.T) {
+	arn := "arn:aws:secretsmanager:us-east-1:123456789012:secret:pb-AbCdEf"
+
+	scenarios := []struct {
+		name            string
+		ref             string
+		status          int
+		response        string
+		expectedRequest bool
+		expected        string
+		expectedError   bool
+	}{
+		{
+			"invalid arn",
+			"arn:aws:s3:::bucket",
+			200,
+			`{"SecretString":"{\"smtp\":\"aws_value\"}"}`,
+			false,
+			"",
+			true,
+		},
+		{
+			"existing secret",
+			arn + "#smtp",
+			200,
+			`{"SecretString":"{\"smtp\":\"aws_value\"}"}`,
+			true,
+			"aws_value",
+			false,
+		},
+		{
+			"binary secret",
+			arn + "#smtp",
+			200,
+			`{"SecretBinary":"dGVzdA=="}`,
+			true,
+			"",
+			true,
+		},
+		{
+			"missing secret",
+			arn + "#smtp",
+			400,
+			`{"__type":"ResourceNotFoundException"}`,
+			true,
+			"",
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			server, captured := httpmock.NewServer(t, s.status, s.response)
+
+			provider := secrets.NewAWSProvider(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: "test_key", SecretAccessKey: "test_secret"}, nil
+			}))
+			provider.Endpoint = server.URL
+
+			m := secrets.New(0)
+			m.Register("arn", provider)
+
+			v, err := m.Resolve(context.Background(), s.ref)
+
+			if hasErr := err != nil; hasErr != s.expectedError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectedError, hasErr, err)
+			}
+
+			if v != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, v)
+			}
+
+			if hasRequest := captured.Method != ""; hasRequest != s.expectedRequest {
+				t.Fatalf("Expected hasRequest %v, got %v", s.expectedRequest, hasRequest)
+			}
+
+			if !s.expectedRequest {
+				return
+			}
+
+			if target := captured.Header.Get("X-Amz-Target"); target != "secretsmanager.GetSecretValue" {
+				t.Fatalf("Unexpected X-Amz-Target header %q", target)
+			}
+
+			if auth := captured.Header.Get("Authorization"); !strings.Contains(auth, "Credential=test_key/") {
+				t.Fatalf("Expected a signed Authorization header, got %q", auth)
+			}
+
+			payload := map[string]string{}
+			if err := json.Unmarshal(captured.Body, &payload); err != nil {
+				t.Fatal(err)
+			}
+			if payload["SecretId"] != arn {
+				t.Fatalf("Expected SecretId %q, got %q", arn, payload["SecretId"])
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package security
 
 import (
 	cryptoRand "crypto/rand"
+	"encoding/hex"
 	"math/big"
 	mathRand "math/rand"
 	"time"
@@ -39,6 +40,33 @@ func RandomStringWithAlphabet(length int, alphabet string) string {
 	return string(b)
 }
 
+// RandomUUID generates a cryptographically random RFC 4122 version 4 UUID
+// string (eg. "6ba7b810-9dad-41d1-80b4-00c04fd430c8").
+//
+// It panics if for some reason rand.Read returns a non-nil error.
+func RandomUUID() string {
+	b := make([]byte, 16)
+	if _, err := cryptoRand.Read(b); err != nil {
+		panic(err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	dst := make([]byte, 36)
+	hex.Encode(dst[0:8], b[0:4])
+	dst[8] = '-'
+	hex.Encode(dst[9:13], b[4:6])
+	dst[13] = '-'
+	hex.Encode(dst[14:18], b[6:8])
+	dst[18] = '-'
+	hex.Encode(dst[19:23], b[8:10])
+	dst[23] = '-'
+	hex.Encode(dst[24:], b[10:])
+
+	return string(dst)
+}
+
 // PseudorandomString generates a pseudorandom string with the specified length.
 //
 // The generated string matches [A-Za-z0-9]+ and it's transparent to URL-encoding.
@@ -15,6 +15,26 @@ func TestRandomStringWithAlphabet(t *testing.T) {
 	testRandomStringWithAlphabet(t, security.RandomStringWithAlphabet)
 }
 
+func TestRandomUUID(t *testing.T) {
+	reg := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	generated := map[string]struct{}{}
+
+	for i := 0; i < 1000; i++ {
+		result := security.RandomUUID()
+
+		if !reg.MatchString(result) {
+			t.Fatalf("(%d) Invalid UUID v4 %q", i, result)
+		}
+
+		if _, ok := generated[result]; ok {
+			t.Fatalf("(%d) Repeating UUID %q", i, result)
+		}
+
+		generated[result] = struct{}{}
+	}
+}
+
 func TestPseudorandomString(t *testing.T) {
 	testRandomString(t, security.PseudorandomString)
 }
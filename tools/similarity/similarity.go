@@ -0,0 +1,86 @@
+// Package similarity implements helpers for comparing text values
+// (eg. when searching for probable duplicate records).
+package similarity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalize returns a lowercased version of s containing only
+// its letters and digits (eg. "+1 (555) 123-45" -> "155512345").
+func Normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return b.String()
+}
+
+// Levenshtein returns the edit distance (insertions, deletions or
+// substitutions) between the runes of a and b.
+func Levenshtein(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+
+	// single row of the distance matrix
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			current := row[j]
+
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			row[j] = min(row[j]+1, row[j-1]+1, prev+cost)
+
+			prev = current
+		}
+	}
+
+	return row[len(rb)]
+}
+
+// Ratio returns the Levenshtein similarity of a and b
+// in the range [0, 1], where 1 means equal strings.
+func Ratio(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// MaxRatio returns the upper bound of [Ratio] for strings with
+// the specified lengths (useful for skipping expensive comparisons).
+func MaxRatio(lenA int, lenB int) float64 {
+	longest := max(lenA, lenB)
+	if longest == 0 {
+		return 1
+	}
+
+	return float64(min(lenA, lenB)) / float64(longest)
+}
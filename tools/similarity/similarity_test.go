@@ -0,0 +1,103 @@
+package similarity_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/similarity"
+)
+
+func TestNormalize(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"  ", ""},
+		{"Test", "test"},
+		{"+1 (555) 123-45", "155512345"},
+		{"John  O'Connor", "johnoconnor"},
+		{"Ünïcödé Çase", "ünïcödéçase"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			result := similarity.Normalize(s.value)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	scenarios := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"kitten", "sitting", 3},
+		{"sitting", "kitten", 3},
+		{"flaw", "lawn", 2},
+		{"ä", "a", 1},
+	}
+
+	for _, s := range scenarios {
+		t.Run(fmt.Sprintf("%s_%s", s.a, s.b), func(t *testing.T) {
+			result := similarity.Levenshtein(s.a, s.b)
+			if result != s.expected {
+				t.Fatalf("Expected %d, got %d", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestRatio(t *testing.T) {
+	scenarios := []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{"", "", "1.00"},
+		{"abc", "", "0.00"},
+		{"abc", "abc", "1.00"},
+		{"johnsmith", "jonsmith", "0.89"},
+		{"abcd", "wxyz", "0.00"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(fmt.Sprintf("%s_%s", s.a, s.b), func(t *testing.T) {
+			result := fmt.Sprintf("%.2f", similarity.Ratio(s.a, s.b))
+			if result != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestMaxRatio(t *testing.T) {
+	scenarios := []struct {
+		lenA     int
+		lenB     int
+		expected string
+	}{
+		{0, 0, "1.00"},
+		{0, 5, "0.00"},
+		{5, 5, "1.00"},
+		{4, 8, "0.50"},
+		{8, 4, "0.50"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(fmt.Sprintf("%d_%d", s.lenA, s.lenB), func(t *testing.T) {
+			result := fmt.Sprintf("%.2f", similarity.MaxRatio(s.lenA, s.lenB))
+			if result != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package sms
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+var _ Client = (*HttpApiClient)(nil)
+
+// HttpApiClient defines a generic HTTP API SMS client
+// that implements `sms.Client` interface.
+//
+// The message is sent as JSON POST request to the specified Url
+// with the following body:
+//
+//	{"from": "...", "to": "...", "body": "..."}
+//
+// Any 2xx response is considered successful.
+type HttpApiClient struct {
+	Url string
+
+	// Token is the optional "Authorization: Bearer" request token.
+	Token string
+
+	// Headers are optional extra request headers.
+	Headers map[string]string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+// Send implements `sms.Client` interface.
+func (c *HttpApiClient) Send(m *Message) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderHttp, res)
+}
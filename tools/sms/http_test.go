@@ -0,0 +1,51 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func TestHttpApiClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 204, "")
+
+	client := &HttpApiClient{
+		Url:     server.URL + "/send",
+		Token:   "test_token",
+		Headers: map[string]string{"X-Test": "123"},
+	}
+
+	if err := client.Send(testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Method != "POST" || captured.Path != "/send" {
+		t.Fatalf("Unexpected request %s %s", captured.Method, captured.Path)
+	}
+
+	if v := captured.Header.Get("Authorization"); v != "Bearer test_token" {
+		t.Fatalf("Unexpected Authorization header %q", v)
+	}
+
+	if v := captured.Header.Get("X-Test"); v != "123" {
+		t.Fatalf("Unexpected X-Test header %q", v)
+	}
+
+	expectedBody := `{"from":"Acme","to":"+359000000000","body":"test body"}`
+	if string(captured.Body) != expectedBody {
+		t.Fatalf("Expected body %s, got %s", expectedBody, captured.Body)
+	}
+}
+
+func TestHttpApiClientSendFailure(t *testing.T) {
+	server, _ := httpmock.NewServer(t, 401, "unauthorized")
+
+	client := &HttpApiClient{Url: server.URL}
+
+	err := client.Send(testMessage())
+
+	expected := "http: failed to send the message (401): unauthorized"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package sms implements a simple text messages client interface
+// with Twilio, Vonage and generic HTTP API providers.
+package sms
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+// Supported SMS providers.
+const (
+	ProviderTwilio = "twilio"
+	ProviderVonage = "vonage"
+	ProviderHttp   = "http"
+)
+
+// Message defines a generic text message struct.
+type Message struct {
+	// From is the sender phone number or alphanumeric sender id.
+	From string `json:"from"`
+
+	// To is the recipient phone number (preferably in E.164 format).
+	To string `json:"to"`
+
+	Body string `json:"body"`
+}
+
+// Client defines a base SMS client interface.
+type Client interface {
+	// Send sends a text message with the provided Message.
+	Send(message *Message) error
+}
+
+// checkResponse returns an error if res has a non 2xx status code.
+func checkResponse(provider string, res *http.Response) error {
+	if err := rest.CheckResponse(res); err != nil {
+		return fmt.Errorf("%s: failed to send the message %w", provider, err)
+	}
+
+	return nil
+}
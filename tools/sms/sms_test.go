@@ -0,0 +1,9 @@
+package sms
+
+func testMessage() *Message {
+	return &Message{
+		From: "Acme",
+		To:   "+359000000000",
+		Body: "test body",
+	}
+}
@@ -0,0 +1,57 @@
+package sms
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+var _ Client = (*TwilioClient)(nil)
+
+// TwilioClient defines a Twilio Programmable Messaging API client
+// that implements `sms.Client` interface.
+type TwilioClient struct {
+	AccountSid string
+	AuthToken  string
+
+	// BaseUrl is the optional API base url
+	// (if not explicitly set, defaults to "https://api.twilio.com").
+	BaseUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+// Send implements `sms.Client` interface.
+func (c *TwilioClient) Send(m *Message) error {
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		baseUrl = "https://api.twilio.com"
+	}
+
+	form := url.Values{}
+	form.Set("From", m.From)
+	form.Set("To", m.To)
+	form.Set("Body", m.Body)
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		strings.TrimRight(baseUrl, "/")+"/2010-04-01/Accounts/"+url.PathEscape(c.AccountSid)+"/Messages.json",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.AccountSid, c.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return checkResponse(ProviderTwilio, res)
+}
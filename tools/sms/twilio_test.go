@@ -0,0 +1,58 @@
+package sms
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func TestTwilioClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 201, `{"sid":"test"}`)
+
+	client := &TwilioClient{
+		AccountSid: "AC123",
+		AuthToken:  "test_token",
+		BaseUrl:    server.URL,
+	}
+
+	if err := client.Send(testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+		t.Fatalf("Unexpected request path %q", captured.Path)
+	}
+
+	expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("AC123:test_token"))
+	if auth := captured.Header.Get("Authorization"); auth != expectedAuth {
+		t.Fatalf("Expected Authorization header %q, got %q", expectedAuth, auth)
+	}
+
+	expectedForm := map[string]string{
+		"From": "Acme",
+		"To":   "+359000000000",
+		"Body": "test body",
+	}
+	for k, v := range expectedForm {
+		if captured.Form.Get(k) != v {
+			t.Fatalf("Expected form field %q to be %q, got %q", k, v, captured.Form.Get(k))
+		}
+	}
+}
+
+func TestTwilioClientSendFailure(t *testing.T) {
+	server, _ := httpmock.NewServer(t, 400, `{"message":"invalid number"}`)
+
+	client := &TwilioClient{BaseUrl: server.URL}
+
+	err := client.Send(testMessage())
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	expected := `twilio: failed to send the message (400): {"message":"invalid number"}`
+	if err.Error() != expected {
+		t.Fatalf("Expected error %q, got %q", expected, err.Error())
+	}
+}
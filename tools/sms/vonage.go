@@ -0,0 +1,86 @@
+package sms
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/rest"
+)
+
+var _ Client = (*VonageClient)(nil)
+
+// VonageClient defines a Vonage (Nexmo) SMS API client
+// that implements `sms.Client` interface.
+type VonageClient struct {
+	ApiKey    string
+	ApiSecret string
+
+	// BaseUrl is the optional API base url
+	// (if not explicitly set, defaults to "https://rest.nexmo.com").
+	BaseUrl string
+
+	// HttpClient is the optional http client used to send the API requests.
+	HttpClient *http.Client
+}
+
+type vonageResponse struct {
+	Messages []struct {
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+// Send implements `sms.Client` interface.
+func (c *VonageClient) Send(m *Message) error {
+	baseUrl := c.BaseUrl
+	if baseUrl == "" {
+		baseUrl = "https://rest.nexmo.com"
+	}
+
+	form := url.Values{}
+	form.Set("api_key", c.ApiKey)
+	form.Set("api_secret", c.ApiSecret)
+	form.Set("from", m.From)
+	// Vonage expects the number without the leading "+"
+	form.Set("to", strings.TrimPrefix(m.To, "+"))
+	form.Set("text", m.Body)
+	form.Set("type", "unicode")
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		strings.TrimRight(baseUrl, "/")+"/sms/json",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := rest.ResolveHttpClient(c.HttpClient).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := checkResponse(ProviderVonage, res); err != nil {
+		return err
+	}
+
+	// the API responds with 200 even on failure so we need to check
+	// the status of each message part ("0" means success)
+	result := vonageResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+
+	for _, msg := range result.Messages {
+		if msg.Status != "0" {
+			return fmt.Errorf("%s: failed to send the message (status %s): %s", ProviderVonage, msg.Status, msg.ErrorText)
+		}
+	}
+
+	return nil
+}
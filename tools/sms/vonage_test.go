@@ -0,0 +1,73 @@
+package sms
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests/httpmock"
+)
+
+func TestVonageClientSend(t *testing.T) {
+	server, captured := httpmock.NewServer(t, 200, `{"message-count":"1","messages":[{"status":"0"}]}`)
+
+	client := &VonageClient{
+		ApiKey:    "test_key",
+		ApiSecret: "test_secret",
+		BaseUrl:   server.URL,
+	}
+
+	if err := client.Send(testMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	if captured.Path != "/sms/json" {
+		t.Fatalf("Unexpected request path %q", captured.Path)
+	}
+
+	expectedForm := map[string]string{
+		"api_key":    "test_key",
+		"api_secret": "test_secret",
+		"from":       "Acme",
+		"to":         "359000000000",
+		"text":       "test body",
+	}
+	for k, v := range expectedForm {
+		if captured.Form.Get(k) != v {
+			t.Fatalf("Expected form field %q to be %q, got %q", k, v, captured.Form.Get(k))
+		}
+	}
+}
+
+func TestVonageClientSendFailure(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		status   int
+		response string
+		expected string
+	}{
+		{
+			"non 2xx response",
+			500,
+			`error`,
+			`vonage: failed to send the message (500): error`,
+		},
+		{
+			"failed message status",
+			200,
+			`{"message-count":"1","messages":[{"status":"4","error-text":"Bad Credentials"}]}`,
+			`vonage: failed to send the message (status 4): Bad Credentials`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			server, _ := httpmock.NewServer(t, s.status, s.response)
+
+			client := &VonageClient{BaseUrl: server.URL}
+
+			err := client.Send(testMessage())
+			if err == nil || err.Error() != s.expected {
+				t.Fatalf("Expected error %q, got %v", s.expected, err)
+			}
+		})
+	}
+}
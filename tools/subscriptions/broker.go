@@ -9,6 +9,10 @@ import (
 type Broker struct {
 	clients map[string]Client
 	mux     sync.RWMutex
+
+	cluster         ClusterTransport
+	clusterHandlers []func(payload []byte)
+	clusterMux      sync.RWMutex
 }
 
 // NewBroker initializes and returns a new Broker instance.
@@ -68,3 +72,63 @@ func (b *Broker) Unregister(clientId string) {
 		delete(b.clients, clientId)
 	}
 }
+
+// SetClusterTransport replaces the broker cluster transport
+// (closing the previous one) and starts receiving its messages.
+//
+// Set a nil transport to disable the cluster messages exchange.
+func (b *Broker) SetClusterTransport(transport ClusterTransport) error {
+	b.clusterMux.Lock()
+	old := b.cluster
+	b.cluster = transport
+	b.clusterMux.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+
+	if transport == nil {
+		return nil
+	}
+
+	return transport.Subscribe(b.dispatchClusterMessage)
+}
+
+// ClusterTransport returns the current broker cluster transport (if any).
+func (b *Broker) ClusterTransport() ClusterTransport {
+	b.clusterMux.RLock()
+	defer b.clusterMux.RUnlock()
+
+	return b.cluster
+}
+
+// OnClusterMessage registers a handler for the messages
+// published by the other cluster nodes.
+func (b *Broker) OnClusterMessage(handler func(payload []byte)) {
+	b.clusterMux.Lock()
+	defer b.clusterMux.Unlock()
+
+	b.clusterHandlers = append(b.clusterHandlers, handler)
+}
+
+// PublishToCluster sends the payload to the other cluster nodes.
+//
+// This method does nothing if the broker doesn't have a cluster transport.
+func (b *Broker) PublishToCluster(payload []byte) error {
+	transport := b.ClusterTransport()
+	if transport == nil {
+		return nil
+	}
+
+	return transport.Publish(payload)
+}
+
+func (b *Broker) dispatchClusterMessage(payload []byte) {
+	b.clusterMux.RLock()
+	handlers := b.clusterHandlers
+	b.clusterMux.RUnlock()
+
+	for _, h := range handlers {
+		h(payload)
+	}
+}
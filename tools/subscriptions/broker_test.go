@@ -91,3 +91,82 @@ func TestUnregister(t *testing.T) {
 		t.Fatalf("Expected client with id %s, got error %v", clientB.Id(), err)
 	}
 }
+
+type testClusterTransport struct {
+	published [][]byte
+	handler   func(payload []byte)
+	closed    bool
+}
+
+func (t *testClusterTransport) Publish(payload []byte) error {
+	t.published = append(t.published, payload)
+	return nil
+}
+
+func (t *testClusterTransport) Subscribe(handler func(payload []byte)) error {
+	t.handler = handler
+	return nil
+}
+
+func (t *testClusterTransport) Close() error {
+	t.closed = true
+	return nil
+}
+
+func TestBrokerClusterTransport(t *testing.T) {
+	b := subscriptions.NewBroker()
+
+	// no transport
+	if err := b.PublishToCluster([]byte("test")); err != nil {
+		t.Fatalf("Expected nil error without transport, got %v", err)
+	}
+
+	var received []string
+	b.OnClusterMessage(func(payload []byte) {
+		received = append(received, "a:"+string(payload))
+	})
+	b.OnClusterMessage(func(payload []byte) {
+		received = append(received, "b:"+string(payload))
+	})
+
+	transportA := &testClusterTransport{}
+	if err := b.SetClusterTransport(transportA); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.ClusterTransport() != transportA {
+		t.Fatal("Expected transportA to be the current cluster transport")
+	}
+
+	if err := b.PublishToCluster([]byte("test")); err != nil {
+		t.Fatal(err)
+	}
+	if len(transportA.published) != 1 || string(transportA.published[0]) != "test" {
+		t.Fatalf("Expected the payload to be published, got %v", transportA.published)
+	}
+
+	transportA.handler([]byte("remote"))
+	if len(received) != 2 || received[0] != "a:remote" || received[1] != "b:remote" {
+		t.Fatalf("Expected the remote payload to be dispatched to both handlers, got %v", received)
+	}
+
+	// replace
+	transportB := &testClusterTransport{}
+	if err := b.SetClusterTransport(transportB); err != nil {
+		t.Fatal(err)
+	}
+	if !transportA.closed {
+		t.Fatal("Expected the previous transport to be closed")
+	}
+
+	// unset
+	if err := b.SetClusterTransport(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !transportB.closed {
+		t.Fatal("Expected transportB to be closed")
+	}
+	if b.ClusterTransport() != nil {
+		t.Fatal("Expected nil cluster transport")
+	}
+}
@@ -44,12 +44,15 @@ type Client interface {
 	// Subscribe subscribes the client to the provided subscriptions list.
 	//
 	// Each subscription can also have "options" (json serialized SubscriptionOptions) as query parameter.
+	// The other subscription query parameters are loaded as options query
+	// (the "options" query values take precedence).
 	//
 	// Example:
 	//
 	// 	Subscribe(
 	// 	    "subscriptionA",
 	// 	    `subscriptionB?options={"query":{"a":1},"headers":{"x_token":"abc"}}`,
+	// 	    "subscriptionC?a=1",
 	// 	)
 	Subscribe(subs ...string)
 
@@ -167,31 +170,61 @@ func (c *DefaultClient) Subscribe(subs ...string) {
 			continue // skip empty
 		}
 
-		// extract subscription options (if any)
-		options := SubscriptionOptions{}
-		u, err := url.Parse(s)
-		if err == nil {
-			rawOptions := u.Query().Get(optionsParam)
-			if rawOptions != "" {
-				json.Unmarshal([]byte(rawOptions), &options)
-			}
+		_, options := ParseSubscription(s)
+
+		c.subscriptions[s] = options
+	}
+}
+
+// ParseSubscription extracts the topic and the normalized options
+// of a single subscription (see [Client.Subscribe]).
+//
+// Example:
+//
+//	// topic: "posts/*"
+//	// options: {"query":{"filter":"org='X'","expand":"author"},"headers":null}
+//	ParseSubscription(`posts/*?filter=org='X'&options={"query":{"expand":"author"}}`)
+func ParseSubscription(sub string) (string, SubscriptionOptions) {
+	topic, rawQuery, _ := strings.Cut(sub, "?")
+
+	options := SubscriptionOptions{}
+
+	query, _ := url.ParseQuery(rawQuery)
+
+	rawOptions := query.Get(optionsParam)
+	if rawOptions != "" {
+		json.Unmarshal([]byte(rawOptions), &options)
+	}
+
+	// load the inline query parameters
+	for k, v := range query {
+		if k == optionsParam || len(v) == 0 {
+			continue
 		}
 
-		// normalize query
-		// (currently only single string values are supported for consistency with the default routes handling)
-		for k, v := range options.Query {
-			options.Query[k] = cast.ToString(v)
+		if options.Query == nil {
+			options.Query = map[string]any{}
 		}
 
-		// normalize headers name and values, eg. "X-Token" is converted to "x_token"
-		// (currently only single string values are supported for consistency with the default routes handling)
-		for k, v := range options.Headers {
-			delete(options.Headers, k)
-			options.Headers[inflector.Snakecase(k)] = cast.ToString(v)
+		if _, ok := options.Query[k]; !ok {
+			options.Query[k] = v[0]
 		}
+	}
 
-		c.subscriptions[s] = options
+	// normalize query
+	// (currently only single string values are supported for consistency with the default routes handling)
+	for k, v := range options.Query {
+		options.Query[k] = cast.ToString(v)
+	}
+
+	// normalize headers name and values, eg. "X-Token" is converted to "x_token"
+	// (currently only single string values are supported for consistency with the default routes handling)
+	for k, v := range options.Headers {
+		delete(options.Headers, k)
+		options.Headers[inflector.Snakecase(k)] = cast.ToString(v)
 	}
+
+	return topic, options
 }
 
 // Unsubscribe implements the [Client.Unsubscribe] interface method.
@@ -117,8 +117,9 @@ func TestSubscribeOptions(t *testing.T) {
 
 	sub1 := "test1"
 	sub2 := `test2?options={"query":{"name":123},"headers":{"X-Token":456}}`
+	sub3 := `test3?filter=a%3D'1'&name=inline&options={"query":{"name":123}}`
 
-	c.Subscribe(sub1, sub2)
+	c.Subscribe(sub1, sub2, sub3)
 
 	subs := c.Subscriptions()
 
@@ -128,6 +129,7 @@ func TestSubscribeOptions(t *testing.T) {
 	}{
 		{sub1, `{"query":null,"headers":null}`},
 		{sub2, `{"query":{"name":"123"},"headers":{"x_token":"456"}}`},
+		{sub3, `{"query":{"filter":"a='1'","name":"123"},"headers":null}`},
 	}
 
 	for _, s := range scenarios {
@@ -242,3 +244,40 @@ func TestSend(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSubscription(t *testing.T) {
+	scenarios := []struct {
+		sub             string
+		expectedTopic   string
+		expectedOptions string
+	}{
+		{"", "", `{"query":null,"headers":null}`},
+		{"posts", "posts", `{"query":null,"headers":null}`},
+		{"posts/*?", "posts/*", `{"query":null,"headers":null}`},
+		{"*?filter=org%3D'X'&expand=author", "*", `{"query":{"expand":"author","filter":"org='X'"},"headers":null}`},
+		{
+			`posts/abc?filter=a&options={"query":{"filter":"b"},"headers":{"X-Token":1}}`,
+			"posts/abc",
+			`{"query":{"filter":"b"},"headers":{"x_token":"1"}}`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.sub, func(t *testing.T) {
+			topic, options := subscriptions.ParseSubscription(s.sub)
+
+			if topic != s.expectedTopic {
+				t.Fatalf("Expected topic %q, got %q", s.expectedTopic, topic)
+			}
+
+			raw, err := json.Marshal(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(raw) != s.expectedOptions {
+				t.Fatalf("Expected options \n%s \ngot \n%s", s.expectedOptions, raw)
+			}
+		})
+	}
+}
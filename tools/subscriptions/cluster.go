@@ -0,0 +1,28 @@
+package subscriptions
+
+import "errors"
+
+// ErrClusterDisconnected is returned when trying to publish
+// a cluster message while the transport is not connected.
+var ErrClusterDisconnected = errors.New("the cluster transport is not connected")
+
+// ClusterTransport defines a shared message bus (eg. NATS or Redis pub/sub)
+// used to exchange the realtime events between multiple app instances
+// (nodes) so that an event published on one node could reach the
+// subscription clients connected to the other nodes.
+//
+// The transport is not expected to deliver the messages published
+// by the current node back to itself.
+type ClusterTransport interface {
+	// Publish sends the payload to all other cluster nodes.
+	Publish(payload []byte) error
+
+	// Subscribe starts receiving the payloads published by the
+	// other cluster nodes and calls handler for each of them.
+	//
+	// The handler calls are sequential and in the order of receiving.
+	Subscribe(handler func(payload []byte)) error
+
+	// Close disconnects the transport and stops receiving messages.
+	Close() error
+}
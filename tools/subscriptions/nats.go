@@ -0,0 +1,362 @@
+package subscriptions
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ ClusterTransport = (*NATSTransport)(nil)
+
+const (
+	natsDefaultPort     = "4222"
+	natsDialTimeout     = 10 * time.Second
+	natsMaxReconnectGap = 10 * time.Second
+	natsQueueSize       = 1024
+)
+
+// NATSTransport is a [ClusterTransport] implementation that exchanges
+// the cluster messages through a NATS server subject
+// (using the NATS client text protocol).
+//
+// The connection is automatically restored on failure, but note that
+// the messages published while disconnected are not delivered.
+type NATSTransport struct {
+	address  string
+	useTLS   bool
+	subject  string
+	username string
+	password string
+
+	mux     sync.Mutex
+	conn    net.Conn
+	writer  *bufio.Writer
+	started bool
+
+	queue   chan []byte
+	closeCh chan struct{}
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewNATSTransport creates a new NATS cluster transport for the server
+// with the provided url (eg. "nats://localhost:4222" or "tls://example.com:4222")
+// and subject (the channel name shared by all cluster nodes).
+//
+// Username and password are optional. If only the password is set,
+// it is sent as auth token. The url credentials are used as fallback.
+func NewNATSTransport(rawURL string, subject string, username string, password string) (*NATSTransport, error) {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid NATS subject %q", subject)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &NATSTransport{
+		subject:  subject,
+		username: username,
+		password: password,
+		queue:    make(chan []byte, natsQueueSize),
+		closeCh:  make(chan struct{}),
+	}
+
+	switch u.Scheme {
+	case "nats", "":
+	case "tls":
+		t.useTLS = true
+	default:
+		return nil, fmt.Errorf("unsupported NATS url scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, errors.New("missing NATS server host")
+	}
+
+	t.address = u.Host
+	if u.Port() == "" {
+		t.address = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+
+	if t.username == "" && t.password == "" && u.User != nil {
+		t.username = u.User.Username()
+		t.password, _ = u.User.Password()
+
+		// "nats://token@host" form
+		if t.password == "" {
+			t.username, t.password = "", t.username
+		}
+	}
+
+	return t, nil
+}
+
+// Publish implements the [ClusterTransport] interface.
+func (t *NATSTransport) Publish(payload []byte) error {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.writer == nil {
+		return ErrClusterDisconnected
+	}
+
+	t.conn.SetWriteDeadline(time.Now().Add(natsDialTimeout))
+
+	t.writer.WriteString("PUB " + t.subject + " " + strconv.Itoa(len(payload)) + "\r\n")
+	t.writer.Write(payload)
+	t.writer.WriteString("\r\n")
+
+	return t.writer.Flush()
+}
+
+// Subscribe implements the [ClusterTransport] interface.
+//
+// It returns an error if the initial connection fails, but the
+// transport will keep trying to reconnect in the background until closed.
+func (t *NATSTransport) Subscribe(handler func(payload []byte)) error {
+	t.mux.Lock()
+	if t.started || t.closed {
+		t.mux.Unlock()
+		return errors.New("the NATS transport is already subscribed or closed")
+	}
+	t.started = true
+	t.mux.Unlock()
+
+	t.wg.Add(2)
+
+	go func() {
+		defer t.wg.Done()
+
+		for {
+			select {
+			case <-t.closeCh:
+				return
+			case payload := <-t.queue:
+				handler(payload)
+			}
+		}
+	}()
+
+	conn, reader, err := t.connect()
+
+	go func() {
+		defer t.wg.Done()
+		t.loop(conn, reader)
+	}()
+
+	return err
+}
+
+// Close implements the [ClusterTransport] interface.
+func (t *NATSTransport) Close() error {
+	t.mux.Lock()
+	if t.closed {
+		t.mux.Unlock()
+		return nil
+	}
+	t.closed = true
+	close(t.closeCh)
+	conn := t.conn
+	t.conn = nil
+	t.writer = nil
+	t.mux.Unlock()
+
+	var err error
+	if conn != nil {
+		err = conn.Close()
+	}
+
+	t.wg.Wait()
+
+	return err
+}
+
+// loop reads the server messages and reconnects (with backoff) on failure.
+func (t *NATSTransport) loop(conn net.Conn, reader *bufio.Reader) {
+	gap := 500 * time.Millisecond
+
+	for {
+		if conn != nil {
+			t.read(conn, reader)
+			gap = 500 * time.Millisecond
+		}
+
+		t.mux.Lock()
+		if t.conn == conn {
+			t.conn = nil
+			t.writer = nil
+		}
+		t.mux.Unlock()
+
+		if conn != nil {
+			conn.Close()
+		}
+
+		select {
+		case <-t.closeCh:
+			return
+		case <-time.After(gap):
+		}
+
+		gap = min(2*gap, natsMaxReconnectGap)
+
+		conn, reader, _ = t.connect()
+	}
+}
+
+// connect dials the server, sends the CONNECT and SUB commands and
+// waits for the server PONG to confirm that they were accepted.
+func (t *NATSTransport) connect() (net.Conn, *bufio.Reader, error) {
+	dialer := &net.Dialer{Timeout: natsDialTimeout}
+
+	conn, err := dialer.Dial("tcp", t.address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(natsDialTimeout))
+
+	reader := bufio.NewReader(conn)
+
+	// the server greets with INFO
+	line, err := readNATSLine(reader)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		conn.Close()
+		return nil, nil, fmt.Errorf("unexpected NATS server greeting %q", line)
+	}
+
+	if t.useTLS {
+		host, _, _ := net.SplitHostPort(t.address)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	options := map[string]any{
+		"verbose":  false,
+		"pedantic": false,
+		"echo":     false, // don't deliver our own messages back
+		"name":     "pocketbase",
+		"lang":     "go",
+		"protocol": 1,
+	}
+	if t.username != "" {
+		options["user"] = t.username
+		options["pass"] = t.password
+	} else if t.password != "" {
+		options["auth_token"] = t.password
+	}
+
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	writer := bufio.NewWriter(conn)
+	writer.WriteString("CONNECT " + string(rawOptions) + "\r\n")
+	writer.WriteString("SUB " + t.subject + " 1\r\n")
+	writer.WriteString("PING\r\n")
+	if err := writer.Flush(); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+
+		if line == "PONG" {
+			break
+		}
+
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return nil, nil, fmt.Errorf("NATS server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.closed {
+		conn.Close()
+		return nil, nil, errors.New("the NATS transport is closed")
+	}
+
+	t.conn = conn
+	t.writer = writer
+
+	return conn, reader, nil
+}
+
+// read processes the server messages until a read error.
+func (t *NATSTransport) read(conn net.Conn, reader *bufio.Reader) {
+	for {
+		line, err := readNATSLine(reader)
+		if err != nil {
+			return
+		}
+
+		switch {
+		case line == "PING":
+			t.mux.Lock()
+			if t.writer != nil && t.conn == conn {
+				t.writer.WriteString("PONG\r\n")
+				t.writer.Flush()
+			}
+			t.mux.Unlock()
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			parts := strings.Fields(line)
+			size, err := strconv.Atoi(parts[len(parts)-1])
+			if err != nil || size < 0 {
+				return
+			}
+
+			payload := make([]byte, size+2) // + \r\n
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+
+			select {
+			case <-t.closeCh:
+				return
+			case t.queue <- payload[:size]:
+			}
+		}
+	}
+}
+
+func readNATSLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,253 @@
+package subscriptions
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/redis"
+)
+
+var _ ClusterTransport = (*RedisTransport)(nil)
+
+const (
+	redisCommandTimeout  = 10 * time.Second
+	redisMaxReconnectGap = 10 * time.Second
+)
+
+// RedisTransport is a [ClusterTransport] implementation that exchanges
+// the cluster messages through a Redis pub/sub channel.
+//
+// Redis delivers the published messages also to the publisher own
+// subscription, so each payload is prefixed with a random node id
+// that is used to skip the messages of the current node.
+//
+// The subscription connection is automatically restored on failure,
+// but note that the messages published while disconnected are not delivered.
+type RedisTransport struct {
+	client  *redis.Client
+	channel string
+	nodeId  string
+
+	mux     sync.Mutex
+	conn    *redis.Conn
+	started bool
+
+	closeCh chan struct{}
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewRedisTransport creates a new Redis cluster transport for the server
+// with the provided url (eg. "redis://:pass@localhost:6379/0" or "rediss://...")
+// and channel name (shared by all cluster nodes).
+func NewRedisTransport(rawURL string, channel string) (*RedisTransport, error) {
+	if channel == "" || strings.ContainsAny(channel, " \t\r\n") {
+		return nil, fmt.Errorf("invalid Redis channel %q", channel)
+	}
+
+	client, err := redis.New(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeId := make([]byte, 8)
+	if _, err := rand.Read(nodeId); err != nil {
+		return nil, err
+	}
+
+	return &RedisTransport{
+		client:  client,
+		channel: channel,
+		nodeId:  hex.EncodeToString(nodeId),
+		closeCh: make(chan struct{}),
+	}, nil
+}
+
+// Publish implements the [ClusterTransport] interface.
+func (t *RedisTransport) Publish(payload []byte) error {
+	t.mux.Lock()
+	connected := t.conn != nil
+	t.mux.Unlock()
+
+	if !connected {
+		return ErrClusterDisconnected
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisCommandTimeout)
+	defer cancel()
+
+	message := make([]byte, 0, len(t.nodeId)+1+len(payload))
+	message = append(message, t.nodeId...)
+	message = append(message, '\n')
+	message = append(message, payload...)
+
+	_, err := t.client.Do(ctx, "PUBLISH", t.channel, message)
+
+	return err
+}
+
+// Subscribe implements the [ClusterTransport] interface.
+//
+// It returns an error if the initial connection fails, but the
+// transport will keep trying to reconnect in the background until closed.
+func (t *RedisTransport) Subscribe(handler func(payload []byte)) error {
+	t.mux.Lock()
+	if t.started || t.closed {
+		t.mux.Unlock()
+		return errors.New("the Redis transport is already subscribed or closed")
+	}
+	t.started = true
+	t.mux.Unlock()
+
+	conn, err := t.connect()
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.loop(conn, handler)
+	}()
+
+	return err
+}
+
+// Close implements the [ClusterTransport] interface.
+func (t *RedisTransport) Close() error {
+	t.mux.Lock()
+	if t.closed {
+		t.mux.Unlock()
+		return nil
+	}
+	t.closed = true
+	close(t.closeCh)
+	conn := t.conn
+	t.conn = nil
+	t.mux.Unlock()
+
+	var err error
+	if conn != nil {
+		err = conn.Close()
+	}
+
+	t.wg.Wait()
+
+	return errors.Join(err, t.client.Close())
+}
+
+// loop reads the subscription messages and reconnects (with backoff) on failure.
+func (t *RedisTransport) loop(conn *redis.Conn, handler func(payload []byte)) {
+	gap := 500 * time.Millisecond
+
+	for {
+		if conn != nil {
+			t.read(conn, handler)
+			gap = 500 * time.Millisecond
+		}
+
+		t.mux.Lock()
+		if t.conn == conn {
+			t.conn = nil
+		}
+		t.mux.Unlock()
+
+		if conn != nil {
+			conn.Close()
+		}
+
+		select {
+		case <-t.closeCh:
+			return
+		case <-time.After(gap):
+		}
+
+		gap = min(2*gap, redisMaxReconnectGap)
+
+		conn, _ = t.connect()
+	}
+}
+
+// connect opens a dedicated connection and subscribes to the transport channel.
+func (t *RedisTransport) connect() (*redis.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisCommandTimeout)
+	defer cancel()
+
+	conn, err := t.client.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.Do(ctx, "SUBSCRIBE", t.channel)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if kind, _ := redisMessageKind(reply); kind != "subscribe" {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected Redis SUBSCRIBE reply %v", reply)
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.closed {
+		conn.Close()
+		return nil, errors.New("the Redis transport is closed")
+	}
+
+	t.conn = conn
+
+	return conn, nil
+}
+
+// read processes the subscription messages until a read error.
+func (t *RedisTransport) read(conn *redis.Conn, handler func(payload []byte)) {
+	for {
+		reply, err := conn.Receive(context.Background())
+		if err != nil {
+			return
+		}
+
+		kind, items := redisMessageKind(reply)
+		if kind != "message" || len(items) != 3 {
+			continue
+		}
+
+		message, _ := items[2].([]byte)
+
+		nodeId, payload, ok := bytes.Cut(message, []byte{'\n'})
+		if !ok || string(nodeId) == t.nodeId {
+			continue // invalid or own message
+		}
+
+		select {
+		case <-t.closeCh:
+			return
+		default:
+			handler(payload)
+		}
+	}
+}
+
+// redisMessageKind returns the kind (eg. "message") and the items of a pub/sub reply.
+func redisMessageKind(reply any) (string, []any) {
+	items, _ := reply.([]any)
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	switch v := items[0].(type) {
+	case []byte:
+		return string(v), items
+	case string:
+		return v, items
+	}
+
+	return "", items
+}
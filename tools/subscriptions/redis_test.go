@@ -0,0 +1,153 @@
+package subscriptions_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tests/redismock"
+	"github.com/pocketbase/pocketbase/tools/subscriptions"
+)
+
+func TestNewRedisTransport(t *testing.T) {
+	scenarios := []struct {
+		url         string
+		channel     string
+		expectError bool
+	}{
+		{"redis://localhost:6379", "", true},
+		{"redis://localhost:6379", "invalid channel", true},
+		{"http://localhost:6379", "test", true},
+		{"redis://localhost", "test", false},
+		{"rediss://:pass@localhost:6379/1", "test", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.url+"_"+s.channel, func(t *testing.T) {
+			transport, err := subscriptions.NewRedisTransport(s.url, s.channel)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if transport != nil {
+				transport.Close()
+			}
+		})
+	}
+}
+
+func TestRedisTransportPublishAndSubscribe(t *testing.T) {
+	server := redismock.NewServer(t, "secret")
+
+	nodeA, err := subscriptions.NewRedisTransport(server.URL(), "test.realtime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nodeA.Close()
+
+	nodeB, err := subscriptions.NewRedisTransport(server.URL(), "test.realtime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nodeB.Close()
+
+	if err := nodeA.Publish([]byte("before")); err != subscriptions.ErrClusterDisconnected {
+		t.Fatalf("Expected ErrClusterDisconnected, got %v", err)
+	}
+
+	receivedA := make(chan string, 10)
+	if err := nodeA.Subscribe(func(payload []byte) { receivedA <- string(payload) }); err != nil {
+		t.Fatal(err)
+	}
+
+	receivedB := make(chan string, 10)
+	if err := nodeB.Subscribe(func(payload []byte) { receivedB <- string(payload) }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := nodeA.Publish([]byte("hello\r\nworld")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-receivedB:
+		if msg != "hello\r\nworld" {
+			t.Fatalf("Expected %q, got %q", "hello\r\nworld", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for the published message")
+	}
+
+	select {
+	case msg := <-receivedA:
+		t.Fatalf("Expected the publisher to not receive its own message, got %q", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	commands := strings.Join(server.Commands(), "\n")
+	if !strings.Contains(commands, "AUTH secret") {
+		t.Fatalf("Expected the password to be sent, got %s", commands)
+	}
+
+	if err := nodeA.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := nodeA.Publish([]byte("after")); err != subscriptions.ErrClusterDisconnected {
+		t.Fatalf("Expected ErrClusterDisconnected after close, got %v", err)
+	}
+
+	if err := nodeA.Subscribe(func(payload []byte) {}); err == nil {
+		t.Fatal("Expected error when subscribing a closed transport")
+	}
+}
+
+func TestRedisTransportReconnect(t *testing.T) {
+	server := redismock.NewServer(t, "secret")
+
+	nodeA, err := subscriptions.NewRedisTransport(server.URL(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nodeA.Close()
+
+	received := make(chan string, 10)
+	if err := nodeA.Subscribe(func(payload []byte) { received <- string(payload) }); err != nil {
+		t.Fatal(err)
+	}
+
+	nodeB, err := subscriptions.NewRedisTransport(server.URL(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nodeB.Close()
+
+	if err := nodeB.Subscribe(func(payload []byte) {}); err != nil {
+		t.Fatal(err)
+	}
+
+	// drop the server side connections
+	server.DropConnections()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if err := nodeB.Publish([]byte("reconnected")); err == nil {
+			select {
+			case msg := <-received:
+				if msg != "reconnected" {
+					t.Fatalf("Expected %q, got %q", "reconnected", msg)
+				}
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("Timeout waiting for the transports to reconnect")
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+}
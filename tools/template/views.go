@@ -0,0 +1,243 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/store"
+)
+
+// Views directory structure.
+const (
+	ViewsExt         = ".html"
+	ViewsLayoutsDir  = "layouts"
+	ViewsPartialsDir = "partials"
+	ViewsLocalesDir  = "locales"
+)
+
+// NewViews creates and initializes a new templates registry bound
+// to the specified views directory.
+//
+// The views directory could have the following structure:
+//
+//	layouts/*.html  - the page layouts (eg. "layouts/base.html")
+//	partials/*.html - the partials available in every view (eg. {{template "header.html" .}})
+//	locales/*.json  - the translations (eg. "locales/en.json" with {"hello": "Hello %s!"})
+//	**/*.html       - the page views (eg. "posts/list.html")
+//
+// Besides the default [Registry] functions, the views could also use
+// the "t" (see [Views.Translate]), "field" (returns a record or map field value)
+// and "json" (serializes a value as JSON string) template functions.
+//
+// Example:
+//
+//	views := template.NewViews("./pb_views")
+//
+//	html, err := views.RenderLayout("base", "posts/list", map[string]any{
+//		"lang":  "en",
+//		"posts": posts,
+//	})
+func NewViews(dir string) *Views {
+	v := &Views{
+		Registry: NewRegistry(),
+		dir:      dir,
+		locales:  store.New[map[string]string](nil),
+	}
+
+	v.AddFuncs(map[string]any{
+		"t":     v.Translate,
+		"field": viewField,
+		"json":  viewJson,
+	})
+
+	return v
+}
+
+// Views defines a templates registry bound to a views directory
+// with layouts, partials and translations support.
+//
+// Views embeds [Registry] so the Registry.Load* methods are also available.
+type Views struct {
+	*Registry
+
+	dir     string
+	reload  bool
+	locales *store.Store[map[string]string]
+}
+
+// Dir returns the views directory.
+func (v *Views) Dir() string {
+	return v.dir
+}
+
+// SetReload enables or disables the views and translations reload
+// on every render (useful during development to see the changes
+// without restarting the app).
+func (v *Views) SetReload(reload bool) *Views {
+	v.reload = reload
+
+	return v
+}
+
+// Render renders the named view (eg. "posts/list") with the specified data.
+func (v *Views) Render(name string, data any) (string, error) {
+	return v.RenderLayout("", name, data)
+}
+
+// RenderLayout renders the named view (eg. "posts/list") wrapped
+// in the named layout (eg. "base" for "layouts/base.html").
+//
+// The layout executes the view blocks (eg. {{block "content" .}}{{end}})
+// that are defined in the view (eg. {{define "content"}}...{{end}}).
+func (v *Views) RenderLayout(layout string, name string, data any) (string, error) {
+	viewFile := v.path(name)
+
+	files := make([]string, 0, 5)
+
+	if layout != "" {
+		files = append(files, v.path(path.Join(ViewsLayoutsDir, layout)))
+	}
+
+	files = append(files, viewFile)
+
+	partials, err := filepath.Glob(filepath.Join(v.dir, ViewsPartialsDir, "*"+ViewsExt))
+	if err != nil {
+		return "", err
+	}
+
+	// exclude the partials with the same name as the layout or view
+	// since they would otherwise replace them in the parsed templates set
+	for _, partial := range partials {
+		base := filepath.Base(partial)
+		if base != filepath.Base(files[0]) && base != filepath.Base(viewFile) {
+			files = append(files, partial)
+		}
+	}
+
+	return v.load(files...).Render(data)
+}
+
+// Translate returns the translation of the specified key from
+// the "locales/{lang}.json" file of the views directory.
+//
+// If the key is missing for the language (eg. "en-US"), it fallbacks to
+// the base language (eg. "en") and then to the key itself.
+//
+// The optional args are used to format the translation with [fmt.Sprintf].
+func (v *Views) Translate(lang string, key string, args ...any) string {
+	result := key
+
+	for _, l := range []string{lang, strings.Split(lang, "-")[0]} {
+		if translation, ok := v.locale(l)[key]; ok {
+			result = translation
+			break
+		}
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(result, args...)
+	}
+
+	return result
+}
+
+// locale loads (if not already) and returns the flattened translations
+// of the specified language (nil if missing or invalid).
+func (v *Views) locale(lang string) map[string]string {
+	if lang == "" || strings.ContainsAny(lang, `/\.`) {
+		return nil
+	}
+
+	if !v.reload && v.locales.Has(lang) {
+		return v.locales.Get(lang)
+	}
+
+	var result map[string]string
+
+	raw, err := os.ReadFile(filepath.Join(v.dir, ViewsLocalesDir, lang+".json"))
+	if err == nil {
+		data := map[string]any{}
+		if err := json.Unmarshal(raw, &data); err == nil {
+			result = map[string]string{}
+			flattenTranslations("", data, result)
+		}
+	}
+
+	v.locales.Set(lang, result)
+
+	return result
+}
+
+// load returns the renderer of the specified files set (parsing them on
+// every call if reload is enabled).
+func (v *Views) load(filenames ...string) *Renderer {
+	if !v.reload {
+		return v.LoadFiles(filenames...)
+	}
+
+	tpl, err := template.New(filepath.Base(filenames[0])).Funcs(v.funcs).ParseFiles(filenames...)
+
+	return &Renderer{template: tpl, parseError: err}
+}
+
+// path returns the views dir file path of the named view
+// (the name is normalized to prevent escaping the views dir).
+func (v *Views) path(name string) string {
+	name = path.Clean("/" + filepath.ToSlash(name))
+
+	if path.Ext(name) != ViewsExt {
+		name += ViewsExt
+	}
+
+	return filepath.Join(v.dir, filepath.FromSlash(name))
+}
+
+// flattenTranslations flattens the nested translations objects
+// into dot-notation keys (eg. {"a":{"b":"c"}} -> {"a.b":"c"}).
+func flattenTranslations(prefix string, data map[string]any, result map[string]string) {
+	for key, value := range data {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			flattenTranslations(key, v, result)
+		case string:
+			result[key] = v
+		default:
+			result[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// viewField returns the named field value of the provided map
+// or value with Get(string) method (eg. models.Record).
+func viewField(value any, name string) (any, error) {
+	switch v := value.(type) {
+	case interface{ Get(string) any }:
+		return v.Get(name), nil
+	case map[string]any:
+		return v[name], nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, errors.New("field: unsupported value type")
+	}
+}
+
+// viewJson returns the JSON serialization of the provided value.
+func viewJson(value any) (string, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
@@ -0,0 +1,202 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testViewsRecord map[string]any
+
+func (r testViewsRecord) Get(key string) any {
+	return r[key]
+}
+
+func createTestViewsDir(t *testing.T) string {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"layouts/base.html":    `<main>{{block "content" .}}default{{end}}</main>{{template "footer.html" .}}`,
+		"partials/footer.html": `<footer>{{t .lang "footer.copy" 2024}}</footer>`,
+		"posts/list.html":      `{{define "content"}}{{range .posts}}<p>{{field . "title"}}</p>{{end}}{{end}}`,
+		"hello.html":           `<h1>{{t .lang "hello" .name}}</h1><script>var data = {{json .data}}</script>`,
+		"locales/en.json":      `{"hello": "Hello %s!", "footer": {"copy": "Copyright %d"}}`,
+		"locales/bg.json":      `{"hello": "Здравей %s!"}`,
+		"locales/invalid.json": `{"hello"`,
+		"secret.txt":           "secret",
+	}
+
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestNewViews(t *testing.T) {
+	v := NewViews("test")
+
+	if v.Dir() != "test" {
+		t.Fatalf("Expected dir %q, got %q", "test", v.Dir())
+	}
+
+	checkRegistryFuncs(t, v.Registry, "raw", "t", "field", "json")
+}
+
+func TestViewsRender(t *testing.T) {
+	dir := createTestViewsDir(t)
+
+	scenarios := []struct {
+		name        string
+		layout      string
+		view        string
+		data        any
+		expectError bool
+		expected    string
+	}{
+		{
+			"missing view",
+			"",
+			"missing",
+			nil,
+			true,
+			"",
+		},
+		{
+			"missing layout",
+			"missing",
+			"hello",
+			nil,
+			true,
+			"",
+		},
+		{
+			"view without layout",
+			"",
+			"hello",
+			map[string]any{"lang": "en-US", "name": "<b>test</b>", "data": []int{1, 2}},
+			false,
+			`<h1>Hello &lt;b&gt;test&lt;/b&gt;!</h1><script>var data = "[1,2]"</script>`,
+		},
+		{
+			"view with extension",
+			"",
+			"hello.html",
+			map[string]any{"lang": "bg", "name": "test"},
+			false,
+			`<h1>Здравей test!</h1><script>var data = "null"</script>`,
+		},
+		{
+			"view with layout and partial",
+			"base",
+			"posts/list",
+			map[string]any{
+				"lang":  "en",
+				"posts": []any{testViewsRecord{"title": "a"}, map[string]any{"title": "b"}},
+			},
+			false,
+			`<main><p>a</p><p>b</p></main><footer>Copyright 2024</footer>`,
+		},
+		{
+			"view outside of the views dir",
+			"",
+			"../../" + filepath.Base(dir) + "/hello",
+			map[string]any{"lang": "en", "name": "test"},
+			true,
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			v := NewViews(dir)
+
+			result, err := v.RenderLayout(s.layout, s.view, s.data)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected result\n%s\ngot\n%s", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestViewsReload(t *testing.T) {
+	dir := createTestViewsDir(t)
+
+	for _, reload := range []bool{false, true} {
+		v := NewViews(dir).SetReload(reload)
+
+		data := map[string]any{"lang": "en", "name": "test"}
+
+		if _, err := v.Render("hello", data); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "hello.html"), []byte(`{{t .lang "hello" .name}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "locales/en.json"), []byte(`{"hello": "Hi %s!"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := v.Render("hello", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `<h1>Hello test!</h1><script>var data = "null"</script>`
+		if reload {
+			expected = "Hi test!"
+		}
+
+		if result != expected {
+			t.Fatalf("[reload %v] Expected %q, got %q", reload, expected, result)
+		}
+	}
+}
+
+func TestViewsTranslate(t *testing.T) {
+	v := NewViews(createTestViewsDir(t))
+
+	scenarios := []struct {
+		lang     string
+		key      string
+		args     []any
+		expected string
+	}{
+		{"", "hello", nil, "hello"},
+		{"missing", "hello", nil, "hello"},
+		{"invalid", "hello", nil, "hello"},
+		{"../locales/en", "hello", nil, "hello"},
+		{"en", "missing", nil, "missing"},
+		{"en", "hello", nil, "Hello %s!"},
+		{"en", "hello", []any{"test"}, "Hello test!"},
+		{"en-US", "hello", []any{"test"}, "Hello test!"},
+		{"en", "footer.copy", []any{2024}, "Copyright 2024"},
+		{"bg", "hello", []any{"test"}, "Здравей test!"},
+		{"bg", "footer.copy", nil, "footer.copy"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.lang+"_"+s.key, func(t *testing.T) {
+			result := v.Translate(s.lang, s.key, s.args...)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
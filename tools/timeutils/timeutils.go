@@ -0,0 +1,127 @@
+// Package timeutils implements common date and time helpers
+// (timezones, ISO weeks, business days, etc.).
+package timeutils
+
+import (
+	"time"
+
+	// embed the IANA timezone database as a fallback for
+	// the systems without one (eg. Windows, scratch containers)
+	_ "time/tzdata"
+)
+
+// DateLayout is the layout of the holiday dates (see [IsBusinessDay]).
+const DateLayout = "2006-01-02"
+
+// LoadLocation returns the location with the specified IANA name
+// (eg. "Europe/Sofia").
+//
+// Empty name returns UTC.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(name)
+}
+
+// StartOfISOWeek returns the start (Monday 00:00) of the
+// specified ISO 8601 week in the provided location.
+func StartOfISOWeek(year int, week int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// January 4th is always in the first ISO week
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+
+	// ISO weekdays are from Monday (1) to Sunday (7)
+	weekday := int(jan4.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+
+	return jan4.AddDate(0, 0, 1-weekday+(week-1)*7)
+}
+
+// IsWeekend reports whether t is Saturday or Sunday.
+func IsWeekend(t time.Time) bool {
+	weekday := t.Weekday()
+
+	return weekday == time.Saturday || weekday == time.Sunday
+}
+
+// IsBusinessDay reports whether t is neither a weekend day nor a holiday.
+//
+// The holidays are dates in the [DateLayout] format and are
+// compared with the t date in its own location.
+func IsBusinessDay(t time.Time, holidays []string) bool {
+	if IsWeekend(t) {
+		return false
+	}
+
+	date := t.Format(DateLayout)
+	for _, h := range holidays {
+		if h == date {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddBusinessDays returns t shifted with the specified number of
+// business days (negative days shift backwards) preserving its clock time.
+//
+// Zero days returns t unchanged.
+func AddBusinessDays(t time.Time, days int, holidays []string) time.Time {
+	step := 1
+	if days < 0 {
+		step = -1
+		days = -days
+	}
+
+	for days > 0 {
+		t = t.AddDate(0, 0, step)
+
+		if IsBusinessDay(t, holidays) {
+			days--
+		}
+	}
+
+	return t
+}
+
+// BusinessDaysBetween returns the number of business days between
+// start and end so that AddBusinessDays(start, result) returns the end date
+// (if end is a business day).
+//
+// That is the business days after start up to and including end, or if end
+// is before start - the negative number of the business days from end up to start.
+//
+// The clock time of the dates is ignored and end is converted in the start location.
+func BusinessDaysBetween(start time.Time, end time.Time, holidays []string) int {
+	loc := start.Location()
+	end = end.In(loc)
+
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+
+	var total int
+
+	if to.Before(from) {
+		for d := to; d.Before(from); d = d.AddDate(0, 0, 1) {
+			if IsBusinessDay(d, holidays) {
+				total--
+			}
+		}
+	} else {
+		for d := from.AddDate(0, 0, 1); !d.After(to); d = d.AddDate(0, 0, 1) {
+			if IsBusinessDay(d, holidays) {
+				total++
+			}
+		}
+	}
+
+	return total
+}
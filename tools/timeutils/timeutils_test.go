@@ -0,0 +1,158 @@
+package timeutils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/timeutils"
+)
+
+func TestLoadLocation(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		expected    string
+		expectError bool
+	}{
+		{"", "UTC", false},
+		{"UTC", "UTC", false},
+		{"Europe/Sofia", "Europe/Sofia", false},
+		{"America/New_York", "America/New_York", false},
+		{"missing/zone", "", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			loc, err := timeutils.LoadLocation(s.name)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && loc.String() != s.expected {
+				t.Fatalf("Expected location %q, got %q", s.expected, loc.String())
+			}
+		})
+	}
+}
+
+func TestStartOfISOWeek(t *testing.T) {
+	sofia, err := time.LoadLocation("Europe/Sofia")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		year     int
+		week     int
+		loc      *time.Location
+		expected string
+	}{
+		{2024, 1, nil, "2024-01-01 00:00:00 +0000 UTC"},
+		{2021, 1, time.UTC, "2021-01-04 00:00:00 +0000 UTC"},
+		{2020, 53, time.UTC, "2020-12-28 00:00:00 +0000 UTC"},
+		{2026, 1, time.UTC, "2025-12-29 00:00:00 +0000 UTC"},
+		{2024, 27, sofia, "2024-07-01 00:00:00 +0300 EEST"},
+	}
+
+	for _, s := range scenarios {
+		result := timeutils.StartOfISOWeek(s.year, s.week, s.loc)
+
+		if result.String() != s.expected {
+			t.Fatalf("[%d-W%d] Expected %q, got %q", s.year, s.week, s.expected, result.String())
+		}
+
+		year, week := result.ISOWeek()
+		if year != s.year || week != s.week {
+			t.Fatalf("[%d-W%d] Expected the result to be in the same ISO week, got %d-W%d", s.year, s.week, year, week)
+		}
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	holidays := []string{"2024-12-25"}
+
+	scenarios := []struct {
+		date     string
+		expected bool
+	}{
+		{"2024-12-20 10:00:00Z", true},  // Friday
+		{"2024-12-21 10:00:00Z", false}, // Saturday
+		{"2024-12-22 10:00:00Z", false}, // Sunday
+		{"2024-12-23 10:00:00Z", true},  // Monday
+		{"2024-12-25 10:00:00Z", false}, // holiday
+	}
+
+	for _, s := range scenarios {
+		date, err := time.Parse("2006-01-02 15:04:05Z07:00", s.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result := timeutils.IsBusinessDay(date, holidays); result != s.expected {
+			t.Fatalf("[%s] Expected %v, got %v", s.date, s.expected, result)
+		}
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	holidays := []string{"2024-12-25", "2024-12-26"}
+
+	start := time.Date(2024, 12, 20, 10, 30, 0, 0, time.UTC) // Friday
+
+	scenarios := []struct {
+		days     int
+		expected string
+	}{
+		{0, "2024-12-20 10:30"},
+		{1, "2024-12-23 10:30"},
+		{2, "2024-12-24 10:30"},
+		{3, "2024-12-27 10:30"},
+		{-1, "2024-12-19 10:30"},
+		{-5, "2024-12-13 10:30"},
+	}
+
+	for _, s := range scenarios {
+		result := timeutils.AddBusinessDays(start, s.days, holidays).Format("2006-01-02 15:04")
+
+		if result != s.expected {
+			t.Fatalf("[%d] Expected %q, got %q", s.days, s.expected, result)
+		}
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	holidays := []string{"2024-12-25", "2024-12-26"}
+
+	start := time.Date(2024, 12, 20, 10, 30, 0, 0, time.UTC) // Friday
+
+	scenarios := []struct {
+		end      time.Time
+		expected int
+	}{
+		{start, 0},
+		{time.Date(2024, 12, 20, 23, 0, 0, 0, time.UTC), 0},
+		{time.Date(2024, 12, 22, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2024, 12, 23, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC), 3},
+		{time.Date(2024, 12, 19, 0, 0, 0, 0, time.UTC), -1},
+		{time.Date(2024, 12, 13, 0, 0, 0, 0, time.UTC), -5},
+	}
+
+	for _, s := range scenarios {
+		result := timeutils.BusinessDaysBetween(start, s.end, holidays)
+
+		if result != s.expected {
+			t.Fatalf("[%s] Expected %d, got %d", s.end, s.expected, result)
+		}
+	}
+
+	// AddBusinessDays inverse
+	for _, days := range []int{-7, -3, -1, 0, 1, 4, 10} {
+		end := timeutils.AddBusinessDays(start, days, holidays)
+
+		if result := timeutils.BusinessDaysBetween(start, end, holidays); result != days {
+			t.Fatalf("Expected %d business days until %s, got %d", days, end, result)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Exporter = (*OTLPExporter)(nil)
+
+// OTLPExporter exports the spans to an OpenTelemetry collector
+// (or any other compatible backend) using the OTLP/HTTP JSON encoding.
+type OTLPExporter struct {
+	// Endpoint is the OTLP/HTTP traces endpoint url
+	// (eg. "http://localhost:4318/v1/traces").
+	//
+	// If the url doesn't have a path, "/v1/traces" is appended.
+	Endpoint string
+
+	// Headers are optional extra request headers (eg. authorization).
+	Headers map[string]string
+
+	// Client is an optional HTTP client used for the export requests
+	// (fallbacks to http.DefaultClient).
+	Client *http.Client
+}
+
+// errMissingEndpoint is returned by [NewOTLPExporter] for empty endpoint.
+var errMissingEndpoint = errors.New("missing OTLP endpoint")
+
+// NewOTLPExporter creates a new [OTLPExporter] with the provided
+// endpoint url and optional "key=value" comma separated headers.
+func NewOTLPExporter(endpoint string, headers string, client *http.Client) (*OTLPExporter, error) {
+	if endpoint == "" {
+		return nil, errMissingEndpoint
+	}
+
+	parsedHeaders, err := ParseHeaders(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OTLPExporter{
+		Endpoint: endpoint,
+		Headers:  parsedHeaders,
+		Client:   client,
+	}, nil
+}
+
+// ParseHeaders parses a comma separated list of "key=value" pairs
+// (the OTEL_EXPORTER_OTLP_HEADERS format, eg. "Authorization=Bearer 123,X-Tenant=abc").
+func ParseHeaders(raw string) (map[string]string, error) {
+	result := map[string]string{}
+
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header pair %q", pair)
+		}
+
+		if unescaped, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = unescaped
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// Export implements the [Exporter] interface.
+func (e *OTLPExporter) Export(ctx context.Context, serviceName string, spans []*Span) error {
+	endpoint := e.Endpoint
+	if u, err := url.Parse(endpoint); err == nil && (u.Path == "" || u.Path == "/") {
+		endpoint = strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	}
+
+	body, err := json.Marshal(otlpPayload(serviceName, spans))
+	if err != nil {
+		return err
+	}
+
+	// the export requests must not be traced themselves
+	ctx = WithoutTracing(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		raw, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("failed to export %d spans (%d): %s", len(spans), res.StatusCode, raw)
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+// OTLP JSON encoding
+// (https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding)
+// -------------------------------------------------------------------
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+type otlpSpan struct {
+	TraceId           string         `json:"traceId"`
+	SpanId            string         `json:"spanId"`
+	ParentSpanId      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            map[string]any `json:"status,omitempty"`
+}
+
+func otlpPayload(serviceName string, spans []*Span) map[string]any {
+	encoded := make([]otlpSpan, 0, len(spans))
+
+	for _, s := range spans {
+		s.mux.Lock()
+
+		item := otlpSpan{
+			TraceId:           hex.EncodeToString(s.traceId[:]),
+			SpanId:            hex.EncodeToString(s.spanId[:]),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: unixNano(s.start),
+			EndTimeUnixNano:   unixNano(s.end),
+			Attributes:        otlpAttributes(s.attributes),
+		}
+
+		if s.parentId != ([8]byte{}) {
+			item.ParentSpanId = hex.EncodeToString(s.parentId[:])
+		}
+
+		if s.err != "" {
+			item.Status = map[string]any{"code": 2, "message": s.err}
+		}
+
+		s.mux.Unlock()
+
+		encoded = append(encoded, item)
+	}
+
+	return map[string]any{
+		"resourceSpans": []any{
+			map[string]any{
+				"resource": map[string]any{
+					"attributes": otlpAttributes(map[string]any{"service.name": serviceName}),
+				},
+				"scopeSpans": []any{
+					map[string]any{
+						"scope": map[string]any{"name": "github.com/pocketbase/pocketbase"},
+						"spans": encoded,
+					},
+				},
+			},
+		},
+	}
+}
+
+func otlpAttributes(attributes map[string]any) []otlpKeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, otlpKeyValue{Key: k, Value: otlpValue(attributes[k])})
+	}
+
+	return result
+}
+
+func otlpValue(v any) map[string]any {
+	switch val := v.(type) {
+	case string:
+		return map[string]any{"stringValue": val}
+	case bool:
+		return map[string]any{"boolValue": val}
+	case int:
+		return map[string]any{"intValue": strconv.Itoa(val)}
+	case int64:
+		return map[string]any{"intValue": strconv.FormatInt(val, 10)}
+	case float64:
+		return map[string]any{"doubleValue": val}
+	case []string:
+		values := make([]any, len(val))
+		for i, item := range val {
+			values[i] = otlpValue(item)
+		}
+		return map[string]any{"arrayValue": map[string]any{"values": values}}
+	case error:
+		return map[string]any{"stringValue": val.Error()}
+	default:
+		return map[string]any{"stringValue": fmt.Sprint(val)}
+	}
+}
+
+func unixNano(t time.Time) string {
+	if t.IsZero() {
+		return "0"
+	}
+
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,128 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/tracing"
+)
+
+func TestParseHeaders(t *testing.T) {
+	scenarios := []struct {
+		raw       string
+		expectErr bool
+		expected  map[string]string
+	}{
+		{"", false, map[string]string{}},
+		{"invalid", true, nil},
+		{"=value", true, nil},
+		{"a=1, b = 2 ,c=x%20y", false, map[string]string{"a": "1", "b": "2", "c": "x y"}},
+		{"Authorization=Bearer 123", false, map[string]string{"Authorization": "Bearer 123"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.raw, func(t *testing.T) {
+			result, err := tracing.ParseHeaders(s.raw)
+
+			if (err != nil) != s.expectErr {
+				t.Fatalf("Expected hasErr %v, got %v", s.expectErr, err)
+			}
+
+			if len(result) != len(s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+			for k, v := range s.expected {
+				if result[k] != v {
+					t.Fatalf("Expected %q header %q, got %q", k, v, result[k])
+				}
+			}
+		})
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var path string
+	var header string
+	var payload map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		header = r.Header.Get("X-Test")
+
+		raw, _ := io.ReadAll(r.Body)
+		json.Unmarshal(raw, &payload)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if _, err := tracing.NewOTLPExporter("", "", nil); err == nil {
+		t.Fatal("Expected missing endpoint error")
+	}
+
+	exporter, err := tracing.NewOTLPExporter(server.URL, "X-Test=abc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracer := tracing.New()
+	tracer.Configure(exporter, "test_service", 1)
+	defer tracer.Shutdown(context.Background())
+
+	ctx, root := tracer.Start(context.Background(), "root", tracing.KindServer)
+	root.SetAttribute("http.route", "/api/test")
+	root.SetAttribute("http.response.status_code", 200)
+
+	_, child := tracer.Start(ctx, "child", tracing.KindInternal)
+	child.SetError(errors.New("test_error"))
+	child.End()
+	root.End()
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/v1/traces" {
+		t.Fatalf("Expected /v1/traces path, got %q", path)
+	}
+
+	if header != "abc" {
+		t.Fatalf("Expected X-Test header abc, got %q", header)
+	}
+
+	raw, _ := json.Marshal(payload)
+	encoded := string(raw)
+
+	expectedParts := []string{
+		`"service.name","value":{"stringValue":"test_service"}`,
+		`"name":"root"`,
+		`"name":"child"`,
+		`"parentSpanId":"` + root.SpanId() + `"`,
+		`"traceId":"` + root.TraceId() + `"`,
+		`"key":"http.route","value":{"stringValue":"/api/test"}`,
+		`"key":"http.response.status_code","value":{"intValue":"200"}`,
+		`"status":{"code":2,"message":"test_error"}`,
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(encoded, part) {
+			t.Errorf("Missing %s in\n%s", part, encoded)
+		}
+	}
+
+	// error response
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failServer.Close()
+
+	failExporter := &tracing.OTLPExporter{Endpoint: failServer.URL + "/custom"}
+	if err := failExporter.Export(context.Background(), "test", nil); err == nil {
+		t.Fatal("Expected export error")
+	}
+}
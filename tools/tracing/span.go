@@ -0,0 +1,220 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpanKind describes the relationship between the span, its parent and its children
+// (the values match the OpenTelemetry span kinds).
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+type spanContextKey struct{}
+
+// Span represents a single timed operation of a trace.
+//
+// All Span methods are safe to be called on a nil span
+// (eg. when the tracer is disabled).
+type Span struct {
+	tracer   *Tracer
+	traceId  [16]byte
+	spanId   [8]byte
+	parentId [8]byte
+	sampled  bool
+
+	mux        sync.Mutex
+	name       string
+	kind       SpanKind
+	start      time.Time
+	end        time.Time
+	attributes map[string]any
+	err        string
+	ended      bool
+}
+
+// SpanFromContext returns the current span stored in ctx (if any).
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+
+	s, _ := ctx.Value(spanContextKey{}).(*Span)
+
+	return s
+}
+
+// ContextWithSpan returns a copy of ctx that holds the provided span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// ContextWithTraceparent returns a copy of ctx with a remote parent span
+// loaded from a W3C "traceparent" header value (eg. of an incoming request).
+//
+// The original ctx is returned if the header value is empty or invalid.
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	remote, err := parseTraceparent(traceparent)
+	if err != nil {
+		return ctx
+	}
+
+	return ContextWithSpan(ctx, remote)
+}
+
+// parseTraceparent parses a W3C trace context "traceparent" header value
+// (eg. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").
+func parseTraceparent(value string) (*Span, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return nil, errors.New("invalid traceparent format")
+	}
+
+	s := &Span{}
+
+	if len(parts[1]) != 32 {
+		return nil, errors.New("invalid traceparent trace id")
+	}
+	if _, err := hex.Decode(s.traceId[:], []byte(parts[1])); err != nil || s.traceId == ([16]byte{}) {
+		return nil, errors.New("invalid traceparent trace id")
+	}
+
+	if len(parts[2]) != 16 {
+		return nil, errors.New("invalid traceparent parent id")
+	}
+	if _, err := hex.Decode(s.spanId[:], []byte(parts[2])); err != nil || s.spanId == ([8]byte{}) {
+		return nil, errors.New("invalid traceparent parent id")
+	}
+
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return nil, errors.New("invalid traceparent flags")
+	}
+	s.sampled = flags[0]&1 == 1
+
+	// the remote span is used only as parent and it is never exported
+	s.ended = true
+
+	return s, nil
+}
+
+// TraceId returns the hex encoded trace id of the span.
+func (s *Span) TraceId() string {
+	if s == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(s.traceId[:])
+}
+
+// SpanId returns the hex encoded id of the span.
+func (s *Span) SpanId() string {
+	if s == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(s.spanId[:])
+}
+
+// IsSampled reports whether the span (and its trace) will be exported.
+func (s *Span) IsSampled() bool {
+	return s != nil && s.sampled
+}
+
+// Traceparent returns the W3C trace context "traceparent" header
+// value of the span (eg. for propagating the trace to outgoing requests).
+func (s *Span) Traceparent() string {
+	if s == nil {
+		return ""
+	}
+
+	flags := "00"
+	if s.sampled {
+		flags = "01"
+	}
+
+	return "00-" + s.TraceId() + "-" + s.SpanId() + "-" + flags
+}
+
+// SetName replaces the span name (eg. with the matched route path).
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.name = name
+}
+
+// SetAttribute sets a single span attribute.
+//
+// The value should be a string, bool, number or a slice of strings.
+func (s *Span) SetAttribute(key string, value any) {
+	if s == nil || !s.sampled {
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.attributes == nil {
+		s.attributes = map[string]any{}
+	}
+
+	s.attributes[key] = value
+}
+
+// SetError marks the span as failed with the provided error.
+//
+// Nil err is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.err = err.Error()
+}
+
+// End completes the span and queues it for export (if sampled).
+//
+// Calling End multiple times has no effect.
+func (s *Span) End() {
+	s.endAt(time.Now())
+}
+
+func (s *Span) endAt(end time.Time) {
+	if s == nil {
+		return
+	}
+
+	s.mux.Lock()
+	if s.ended {
+		s.mux.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = end
+	s.mux.Unlock()
+
+	if s.sampled && s.tracer != nil {
+		s.tracer.enqueue(s)
+	}
+}
@@ -0,0 +1,275 @@
+// Package tracing implements a minimal OpenTelemetry compatible tracer
+// with W3C trace context propagation and an OTLP/HTTP (JSON) spans exporter.
+package tracing
+
+import (
+	"context"
+	crand "crypto/rand"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultServiceName is the default "service.name" resource attribute of the exported spans.
+	DefaultServiceName = "pocketbase"
+
+	// maxBatchSize is the max number of spans sent with a single export request.
+	maxBatchSize = 512
+
+	// maxQueueSize is the max number of queued spans waiting for export
+	// (the new spans are dropped if the exporter can't keep up).
+	maxQueueSize = 4096
+
+	// flushInterval is the interval at which the queued spans are exported.
+	flushInterval = 5 * time.Second
+)
+
+// Exporter defines a common interface for the finished spans exporters.
+type Exporter interface {
+	// Export sends the provided finished spans.
+	Export(ctx context.Context, serviceName string, spans []*Span) error
+}
+
+// Tracer creates the spans and queues the finished ones for a batched export.
+//
+// The zero value is a disabled tracer (see [Tracer.Configure]).
+type Tracer struct {
+	mux         sync.RWMutex
+	exporter    Exporter
+	serviceName string
+	sampleRatio float64
+
+	queueMux sync.Mutex
+	queue    []*Span
+
+	// OnExportError is an optional callback that is called when the spans export fails.
+	OnExportError func(err error)
+
+	loopOnce sync.Once
+	flushCh  chan struct{}
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// New creates a new disabled Tracer.
+func New() *Tracer {
+	return &Tracer{}
+}
+
+// Configure enables the tracer with the provided exporter and the
+// ratio (0-1] of the sampled (aka. exported) root spans.
+//
+// Zero sampleRatio samples all root spans.
+//
+// Set a nil exporter to disable the tracer.
+func (t *Tracer) Configure(exporter Exporter, serviceName string, sampleRatio float64) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+
+	if sampleRatio <= 0 || sampleRatio > 1 {
+		sampleRatio = 1
+	}
+
+	t.exporter = exporter
+	t.serviceName = serviceName
+	t.sampleRatio = sampleRatio
+
+	if exporter != nil {
+		t.loopOnce.Do(t.startLoop)
+	}
+}
+
+// Enabled reports whether the tracer has a configured exporter.
+func (t *Tracer) Enabled() bool {
+	if t == nil {
+		return false
+	}
+
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	return t.exporter != nil
+}
+
+// Start creates a new span as a child of the span stored in ctx (if any)
+// and returns it together with a copy of ctx that holds the new span.
+//
+// If the tracer is disabled, returns ctx and a nil span
+// (all Span methods are nil safe).
+//
+// If ctx doesn't have a parent span, the new span starts a new
+// trace and it is sampled based on the tracer sample ratio.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if !t.Enabled() {
+		return ctx, nil
+	}
+
+	span := t.newSpan(SpanFromContext(ctx), name, kind, time.Now())
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// Record records an already completed operation as a child span
+// of the span stored in ctx.
+//
+// The span is not recorded if ctx doesn't have a sampled parent span
+// (eg. for the frequent operations like the db queries that are not
+// worth tracing on their own).
+func (t *Tracer) Record(
+	ctx context.Context,
+	name string,
+	kind SpanKind,
+	start time.Time,
+	end time.Time,
+	attributes map[string]any,
+	err error,
+) {
+	if !t.Enabled() {
+		return
+	}
+
+	parent := SpanFromContext(ctx)
+	if !parent.IsSampled() {
+		return
+	}
+
+	span := t.newSpan(parent, name, kind, start)
+	span.attributes = attributes
+	span.SetError(err)
+	span.endAt(end)
+}
+
+func (t *Tracer) newSpan(parent *Span, name string, kind SpanKind, start time.Time) *Span {
+	span := &Span{
+		tracer: t,
+		name:   name,
+		kind:   kind,
+		start:  start,
+	}
+
+	crand.Read(span.spanId[:])
+
+	if parent != nil {
+		span.traceId = parent.traceId
+		span.parentId = parent.spanId
+		span.sampled = parent.sampled
+	} else {
+		crand.Read(span.traceId[:])
+
+		t.mux.RLock()
+		ratio := t.sampleRatio
+		t.mux.RUnlock()
+
+		span.sampled = ratio >= 1 || rand.Float64() < ratio
+	}
+
+	return span
+}
+
+// QueueSize returns the number of the finished spans waiting for export.
+func (t *Tracer) QueueSize() int {
+	if t == nil {
+		return 0
+	}
+
+	t.queueMux.Lock()
+	defer t.queueMux.Unlock()
+
+	return len(t.queue)
+}
+
+func (t *Tracer) enqueue(span *Span) {
+	t.queueMux.Lock()
+	if len(t.queue) >= maxQueueSize {
+		t.queueMux.Unlock()
+		return // drop
+	}
+	t.queue = append(t.queue, span)
+	total := len(t.queue)
+	t.queueMux.Unlock()
+
+	if total >= maxBatchSize && t.flushCh != nil {
+		select {
+		case t.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush exports all queued spans.
+func (t *Tracer) Flush(ctx context.Context) error {
+	t.mux.RLock()
+	exporter := t.exporter
+	serviceName := t.serviceName
+	t.mux.RUnlock()
+
+	for {
+		t.queueMux.Lock()
+		total := min(len(t.queue), maxBatchSize)
+		batch := make([]*Span, total)
+		copy(batch, t.queue)
+		t.queue = t.queue[total:]
+		t.queueMux.Unlock()
+
+		if total == 0 {
+			return nil
+		}
+
+		if exporter == nil {
+			continue // discard
+		}
+
+		if err := exporter.Export(ctx, serviceName, batch); err != nil {
+			return err
+		}
+	}
+}
+
+// Shutdown stops the background export loop and exports the remaining queued spans.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t.stopCh != nil {
+		select {
+		case <-t.stopCh:
+		default:
+			close(t.stopCh)
+			<-t.doneCh
+		}
+	}
+
+	return t.Flush(ctx)
+}
+
+func (t *Tracer) startLoop() {
+	t.flushCh = make(chan struct{}, 1)
+	t.stopCh = make(chan struct{})
+	t.doneCh = make(chan struct{})
+
+	go func() {
+		defer close(t.doneCh)
+
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-t.stopCh:
+				return
+			case <-ticker.C:
+			case <-t.flushCh:
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			err := t.Flush(ctx)
+			cancel()
+
+			if err != nil && t.OnExportError != nil {
+				t.OnExportError(err)
+			}
+		}
+	}()
+}
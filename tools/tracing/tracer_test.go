@@ -0,0 +1,184 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/tracing"
+)
+
+type testExporter struct {
+	mux   sync.Mutex
+	spans []*tracing.Span
+}
+
+func (e *testExporter) Export(ctx context.Context, serviceName string, spans []*tracing.Span) error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	e.spans = append(e.spans, spans...)
+
+	return nil
+}
+
+func TestTracerDisabled(t *testing.T) {
+	tracer := tracing.New()
+
+	if tracer.Enabled() {
+		t.Fatal("Expected the tracer to be disabled")
+	}
+
+	ctx, span := tracer.Start(context.Background(), "test", tracing.KindInternal)
+	if span != nil {
+		t.Fatalf("Expected nil span, got %v", span)
+	}
+	if tracing.SpanFromContext(ctx) != nil {
+		t.Fatal("Expected ctx without span")
+	}
+
+	// nil span methods
+	span.SetName("test")
+	span.SetAttribute("a", 1)
+	span.SetError(errors.New("test"))
+	span.End()
+	if span.TraceId() != "" || span.Traceparent() != "" || span.IsSampled() {
+		t.Fatal("Expected empty nil span values")
+	}
+}
+
+func TestTracerStartAndFlush(t *testing.T) {
+	exporter := &testExporter{}
+
+	tracer := tracing.New()
+	tracer.Configure(exporter, "", 0)
+	defer tracer.Shutdown(context.Background())
+
+	ctx, root := tracer.Start(context.Background(), "root", tracing.KindServer)
+	if tracing.SpanFromContext(ctx) != root {
+		t.Fatal("Expected ctx with the root span")
+	}
+	if !root.IsSampled() {
+		t.Fatal("Expected the root span to be sampled")
+	}
+
+	_, child := tracer.Start(ctx, "child", tracing.KindInternal)
+	if child.TraceId() != root.TraceId() {
+		t.Fatalf("Expected child trace id %q, got %q", root.TraceId(), child.TraceId())
+	}
+	if child.SpanId() == root.SpanId() {
+		t.Fatal("Expected different span ids")
+	}
+
+	now := time.Now()
+	tracer.Record(ctx, "db", tracing.KindClient, now.Add(-time.Millisecond), now, map[string]any{"db.statement": "SELECT 1"}, nil)
+
+	// without parent span
+	tracer.Record(context.Background(), "db_orphan", tracing.KindClient, now, now, nil, nil)
+
+	child.End()
+	root.End()
+	root.End() // duplicated end
+
+	if size := tracer.QueueSize(); size != 3 {
+		t.Fatalf("Expected 3 queued spans, got %d", size)
+	}
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exporter.spans) != 3 {
+		t.Fatalf("Expected 3 exported spans, got %d", len(exporter.spans))
+	}
+
+	if size := tracer.QueueSize(); size != 0 {
+		t.Fatalf("Expected empty queue after flush, got %d", size)
+	}
+}
+
+func TestTracerSampling(t *testing.T) {
+	exporter := &testExporter{}
+
+	tracer := tracing.New()
+	tracer.Configure(exporter, "", 0.000001)
+	defer tracer.Shutdown(context.Background())
+
+	// not sampled root (with very high probability)
+	var notSampled *tracing.Span
+	for i := 0; i < 100; i++ {
+		_, span := tracer.Start(context.Background(), "root", tracing.KindServer)
+		if !span.IsSampled() {
+			notSampled = span
+			break
+		}
+	}
+	if notSampled == nil {
+		t.Fatal("Expected at least one not sampled root span")
+	}
+
+	ctx := tracing.ContextWithSpan(context.Background(), notSampled)
+
+	_, child := tracer.Start(ctx, "child", tracing.KindInternal)
+	if child.IsSampled() {
+		t.Fatal("Expected the child of a not sampled span to not be sampled")
+	}
+	child.End()
+	notSampled.End()
+
+	// remote sampled parent
+	ctx = tracing.ContextWithTraceparent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, remoteChild := tracer.Start(ctx, "remote_child", tracing.KindServer)
+	if !remoteChild.IsSampled() {
+		t.Fatal("Expected the child of a sampled remote parent to be sampled")
+	}
+	if remoteChild.TraceId() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("Expected the remote trace id, got %q", remoteChild.TraceId())
+	}
+	remoteChild.End()
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exporter.spans) != 1 {
+		t.Fatalf("Expected only 1 exported span, got %d", len(exporter.spans))
+	}
+}
+
+func TestContextWithTraceparent(t *testing.T) {
+	scenarios := []struct {
+		value   string
+		valid   bool
+		sampled bool
+	}{
+		{"", false, false},
+		{"invalid", false, false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", false, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			span := tracing.SpanFromContext(tracing.ContextWithTraceparent(context.Background(), s.value))
+
+			if (span != nil) != s.valid {
+				t.Fatalf("Expected valid %v, got %v", s.valid, span != nil)
+			}
+
+			if span.IsSampled() != s.sampled {
+				t.Fatalf("Expected sampled %v, got %v", s.sampled, span.IsSampled())
+			}
+
+			if s.valid && span.Traceparent() != s.value {
+				t.Fatalf("Expected traceparent %q, got %q", s.value, span.Traceparent())
+			}
+		})
+	}
+}
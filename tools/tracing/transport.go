@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+type skipTracingKey struct{}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// Transport is a [http.RoundTripper] that records a client span for each
+// outgoing request and propagates the trace with the "traceparent" header.
+type Transport struct {
+	// Base is the underlying round tripper (fallbacks to http.DefaultTransport).
+	Base http.RoundTripper
+
+	// Tracer is the tracer used to create the client spans.
+	Tracer *Tracer
+}
+
+// RoundTrip implements the [http.RoundTripper] interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if !t.Tracer.Enabled() || req.Context().Value(skipTracingKey{}) != nil {
+		return base.RoundTrip(req)
+	}
+
+	ctx, span := t.Tracer.Start(req.Context(), "HTTP "+req.Method, KindClient)
+	defer span.End()
+
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("server.address", req.URL.Hostname())
+	span.SetAttribute("url.full", redactedUrl(req))
+
+	// the round tripper must not modify the original request
+	req = req.Clone(ctx)
+	req.Header.Set("traceparent", span.Traceparent())
+
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		span.SetError(err)
+		return nil, err
+	}
+
+	span.SetAttribute("http.response.status_code", res.StatusCode)
+	if res.StatusCode >= 500 {
+		span.SetError(httpStatusError(res.StatusCode))
+	}
+
+	return res, nil
+}
+
+// WithoutTracing returns a copy of ctx that disables the
+// tracing of the outgoing requests created with it.
+func WithoutTracing(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipTracingKey{}, true)
+}
+
+func redactedUrl(req *http.Request) string {
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String()
+}
+
+type httpStatusError int
+
+func (e httpStatusError) Error() string {
+	return "HTTP " + strconv.Itoa(int(e))
+}
@@ -0,0 +1,77 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/tracing"
+)
+
+func TestTransport(t *testing.T) {
+	var traceparent string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	exporter := &testExporter{}
+
+	tracer := tracing.New()
+
+	client := &http.Client{Transport: &tracing.Transport{Tracer: tracer}}
+
+	// disabled tracer
+	if _, err := client.Get(server.URL); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent != "" {
+		t.Fatalf("Expected no traceparent header, got %q", traceparent)
+	}
+
+	tracer.Configure(exporter, "", 1)
+	defer tracer.Shutdown(context.Background())
+
+	ctx, root := tracer.Start(context.Background(), "root", tracing.KindServer)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?secret=123", nil)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if req.Header.Get("traceparent") != "" {
+		t.Fatal("Expected the original request to not be modified")
+	}
+
+	propagated := tracing.SpanFromContext(tracing.ContextWithTraceparent(context.Background(), traceparent))
+	if propagated.TraceId() != root.TraceId() {
+		t.Fatalf("Expected propagated trace id %q, got %q", root.TraceId(), propagated.TraceId())
+	}
+
+	// skipped tracing
+	traceparent = ""
+	req, _ = http.NewRequestWithContext(tracing.WithoutTracing(ctx), http.MethodGet, server.URL, nil)
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if traceparent != "" {
+		t.Fatalf("Expected no traceparent header, got %q", traceparent)
+	}
+
+	root.End()
+
+	if err := tracer.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("Expected 2 exported spans (root + client), got %d", len(exporter.spans))
+	}
+}
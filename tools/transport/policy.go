@@ -0,0 +1,261 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/ratelimit"
+)
+
+// Scripts is the shared round tripper of the script initiated
+// outbound requests ($http.send, $filesystem.fileFromUrl, etc.).
+//
+// By default it delegates to [Default] and the app replaces it with
+// a [Policy] restricted transport if a scripts egress policy is configured.
+var Scripts = &Switchable{transport: Default}
+
+// ErrPolicyDenied is returned when the request destination
+// is not allowed by the outbound policy.
+var ErrPolicyDenied = errors.New("the request destination is not allowed by the outbound policy")
+
+// ErrPolicyRateLimited is returned when the request destination
+// rate limit of the outbound policy was reached.
+var ErrPolicyRateLimited = errors.New("the request destination rate limit was reached")
+
+// Policy defines an outbound requests restriction policy.
+type Policy struct {
+	// AllowedDomains is an optional list of the only allowed destination
+	// domains (eg. "example.com" or "*.example.com" for its subdomains).
+	//
+	// Leave empty to allow all domains (except the DeniedDomains).
+	AllowedDomains []string
+
+	// DeniedDomains is a list of the forbidden destination domains
+	// (with the same format as AllowedDomains).
+	DeniedDomains []string
+
+	// BlockPrivateIPs forbids the requests to loopback, private,
+	// link-local and unspecified IP addresses (SSRF protection).
+	//
+	// The check is performed on the actually dialed IP address so it
+	// also covers DNS rebinding (for proxied requests the destination
+	// host is resolved and checked before sending the request).
+	BlockPrivateIPs bool
+
+	// Resolver is an optional custom DNS server address
+	// used for resolving the destination hosts (eg. "1.1.1.1:53").
+	Resolver string
+
+	// RateLimits is an optional list of per destination host rate limits.
+	RateLimits []RateLimit
+}
+
+// RateLimit defines a single per destination host rate limit.
+type RateLimit struct {
+	// Domain is the destination domain pattern the rate limit applies to
+	// (eg. "api.example.com", "*.example.com" or "*" for all hosts).
+	Domain string
+
+	// MaxRequests is the max allowed requests per destination host in Interval.
+	MaxRequests int
+
+	// Interval is the rate limit window duration.
+	Interval time.Duration
+}
+
+// IsZero reports whether the policy doesn't have any restrictions.
+func (p Policy) IsZero() bool {
+	return len(p.AllowedDomains) == 0 &&
+		len(p.DeniedDomains) == 0 &&
+		len(p.RateLimits) == 0 &&
+		!p.BlockPrivateIPs &&
+		p.Resolver == ""
+}
+
+// NewWithPolicy creates a new round tripper based on the provided
+// transport config that enforces the specified outbound policy.
+func NewWithPolicy(config Config, policy Policy) (http.RoundTripper, error) {
+	base, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := net.DefaultResolver
+	if policy.Resolver != "" {
+		if _, _, err := net.SplitHostPort(policy.Resolver); err != nil {
+			return nil, fmt.Errorf("invalid resolver address: %w", err)
+		}
+
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				d := net.Dialer{Timeout: 10 * time.Second}
+				return d.DialContext(ctx, network, policy.Resolver)
+			},
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Resolver:  resolver,
+	}
+
+	if policy.BlockPrivateIPs {
+		dialer.ControlContext = func(ctx context.Context, network, address string, _ syscall.RawConn) error {
+			if proxied, _ := ctx.Value(proxiedDialKey{}).(bool); proxied {
+				return nil // the proxy is trusted and the destination is checked before the dial
+			}
+
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if IsPrivateIP(net.ParseIP(host)) {
+				return ErrPolicyDenied
+			}
+
+			return nil
+		}
+	}
+
+	base.DialContext = dialer.DialContext
+
+	return &policyTransport{
+		base:     base,
+		policy:   policy,
+		resolver: resolver,
+		limiter:  ratelimit.New(),
+	}, nil
+}
+
+// IsPrivateIP reports whether ip is a loopback, private,
+// link-local or unspecified IP address.
+//
+// Nil (aka. invalid) ip is also considered private.
+func IsPrivateIP(ip net.IP) bool {
+	return ip == nil ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast()
+}
+
+// MatchDomain reports whether host matches the domain pattern.
+//
+// The pattern could be an exact domain ("example.com"),
+// a subdomains wildcard ("*.example.com") or "*" for any host.
+func MatchDomain(host string, pattern string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	pattern = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(pattern)), ".")
+
+	if pattern == "*" {
+		return true
+	}
+
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+
+	return host == pattern
+}
+
+// -------------------------------------------------------------------
+
+type proxiedDialKey struct{}
+
+type policyTransport struct {
+	base     *http.Transport
+	policy   Policy
+	resolver *net.Resolver
+	limiter  *ratelimit.Limiter
+}
+
+// RoundTrip implements the [http.RoundTripper] interface.
+func (t *policyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	if !t.isDomainAllowed(host) {
+		return nil, ErrPolicyDenied
+	}
+
+	for _, limit := range t.policy.RateLimits {
+		if !MatchDomain(host, limit.Domain) {
+			continue
+		}
+
+		if !t.limiter.Allow(limit.Domain+"@"+strings.ToLower(host), limit.MaxRequests, limit.Interval) {
+			return nil, ErrPolicyRateLimited
+		}
+	}
+
+	if t.policy.BlockPrivateIPs && t.base.Proxy != nil {
+		proxyUrl, err := t.base.Proxy(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if proxyUrl != nil {
+			if err := t.checkHost(req.Context(), host); err != nil {
+				return nil, err
+			}
+
+			req = req.WithContext(context.WithValue(req.Context(), proxiedDialKey{}, true))
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// isDomainAllowed checks the host against the policy domain lists.
+func (t *policyTransport) isDomainAllowed(host string) bool {
+	for _, pattern := range t.policy.DeniedDomains {
+		if MatchDomain(host, pattern) {
+			return false
+		}
+	}
+
+	if len(t.policy.AllowedDomains) == 0 {
+		return true
+	}
+
+	for _, pattern := range t.policy.AllowedDomains {
+		if MatchDomain(host, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkHost resolves the host and checks whether any of its IPs is private.
+func (t *policyTransport) checkHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if IsPrivateIP(ip) {
+			return ErrPolicyDenied
+		}
+		return nil
+	}
+
+	addrs, err := t.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if IsPrivateIP(addr.IP) {
+			return ErrPolicyDenied
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package transport_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/transport"
+)
+
+func TestMatchDomain(t *testing.T) {
+	scenarios := []struct {
+		host     string
+		pattern  string
+		expected bool
+	}{
+		{"example.com", "*", true},
+		{"example.com", "example.com", true},
+		{"EXAMPLE.com.", "example.COM", true},
+		{"api.example.com", "example.com", false},
+		{"api.example.com", "*.example.com", true},
+		{"a.b.example.com", "*.example.com", true},
+		{"example.com", "*.example.com", false},
+		{"badexample.com", "*.example.com", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.host+"_"+s.pattern, func(t *testing.T) {
+			if v := transport.MatchDomain(s.host, s.pattern); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	scenarios := []struct {
+		ip       string
+		expected bool
+	}{
+		{"", true},
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"0.0.0.0", true},
+		{"fd00::1", true},
+		{"8.8.8.8", false},
+		{"2001:4860:4860::8888", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.ip, func(t *testing.T) {
+			if v := transport.IsPrivateIP(net.ParseIP(s.ip)); v != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, v)
+			}
+		})
+	}
+}
+
+func TestPolicyIsZero(t *testing.T) {
+	if !(transport.Policy{}).IsZero() {
+		t.Fatal("Expected zero policy")
+	}
+
+	if (transport.Policy{BlockPrivateIPs: true}).IsZero() {
+		t.Fatal("Expected non-zero policy")
+	}
+}
+
+func TestNewWithPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	if _, err := transport.NewWithPolicy(transport.Config{}, transport.Policy{Resolver: "invalid"}); err == nil {
+		t.Fatal("Expected invalid resolver error")
+	}
+
+	scenarios := []struct {
+		name          string
+		policy        transport.Policy
+		url           string
+		requests      int
+		expectedError error
+	}{
+		{
+			"no restrictions",
+			transport.Policy{},
+			"http://localhost:" + port,
+			1,
+			nil,
+		},
+		{
+			"allowed domain",
+			transport.Policy{AllowedDomains: []string{"localhost"}},
+			"http://localhost:" + port,
+			1,
+			nil,
+		},
+		{
+			"not allowed domain",
+			transport.Policy{AllowedDomains: []string{"example.com"}},
+			"http://localhost:" + port,
+			1,
+			transport.ErrPolicyDenied,
+		},
+		{
+			"denied domain",
+			transport.Policy{DeniedDomains: []string{"localhost"}},
+			"http://localhost:" + port,
+			1,
+			transport.ErrPolicyDenied,
+		},
+		{
+			"blocked private ip",
+			transport.Policy{BlockPrivateIPs: true},
+			server.URL,
+			1,
+			transport.ErrPolicyDenied,
+		},
+		{
+			"blocked private ip (resolved host)",
+			transport.Policy{BlockPrivateIPs: true},
+			"http://localhost:" + port,
+			1,
+			transport.ErrPolicyDenied,
+		},
+		{
+			"within the rate limit",
+			transport.Policy{RateLimits: []transport.RateLimit{{Domain: "*", MaxRequests: 2, Interval: time.Minute}}},
+			server.URL,
+			2,
+			nil,
+		},
+		{
+			"exceeded rate limit",
+			transport.Policy{RateLimits: []transport.RateLimit{{Domain: "*", MaxRequests: 2, Interval: time.Minute}}},
+			server.URL,
+			3,
+			transport.ErrPolicyRateLimited,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			rt, err := transport.NewWithPolicy(transport.Config{}, s.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := &http.Client{Transport: rt}
+
+			var lastErr error
+			for i := 0; i < s.requests; i++ {
+				res, err := client.Get(s.url)
+				if err != nil {
+					lastErr = err
+					break
+				}
+				res.Body.Close()
+			}
+
+			if !errors.Is(lastErr, s.expectedError) {
+				t.Fatalf("Expected error %v, got %v", s.expectedError, lastErr)
+			}
+		})
+	}
+}
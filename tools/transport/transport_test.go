@@ -0,0 +1,111 @@
+package transport_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/transport"
+)
+
+func TestNewProxy(t *testing.T) {
+	tr, err := transport.New(transport.Config{
+		ProxyUrl: "http://proxy.example.com:3128",
+		NoProxy:  "internal.example.com,10.0.0.0/8",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		url           string
+		expectedProxy string
+	}{
+		{"https://api.example.com/test", "http://proxy.example.com:3128"},
+		{"http://api.example.com/test", "http://proxy.example.com:3128"},
+		{"https://internal.example.com/test", ""},
+		{"http://10.1.2.3/test", ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.url, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, s.url, nil)
+
+			proxy, err := tr.Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result string
+			if proxy != nil {
+				result = proxy.String()
+			}
+
+			if result != s.expectedProxy {
+				t.Fatalf("Expected proxy %q, got %q", s.expectedProxy, result)
+			}
+		})
+	}
+}
+
+func TestNewTLS(t *testing.T) {
+	if _, err := transport.New(transport.Config{CACerts: "invalid"}); err == nil {
+		t.Fatal("Expected invalid CA certificates error")
+	}
+
+	tr, err := transport.New(transport.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.TLSClientConfig != nil && tr.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("Expected the TLS verification to be enabled by default")
+	}
+
+	tr, err = transport.New(transport.Config{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.TLSClientConfig.InsecureSkipVerify {
+		t.Fatal("Expected the TLS verification to be disabled")
+	}
+}
+
+func TestSwitchable(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	tr := &transport.Switchable{}
+	client := &http.Client{Transport: tr}
+
+	if tr.Get() != http.DefaultTransport {
+		t.Fatal("Expected the default transport fallback")
+	}
+
+	// self-signed test server certificate
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("Expected certificate verification error")
+	}
+
+	insecure, err := transport.New(transport.Config{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.Set(insecure)
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected status %d, got %d", http.StatusNoContent, res.StatusCode)
+	}
+
+	tr.Set(nil)
+	if tr.Get() != http.DefaultTransport {
+		t.Fatal("Expected the default transport fallback after reset")
+	}
+}
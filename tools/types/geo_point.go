@@ -0,0 +1,98 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// earthRadius is the mean Earth radius in meters.
+const earthRadius = 6371008.8
+
+// GeoPoint defines a geographic point value type that is safe for db read/write.
+type GeoPoint struct {
+	Lat float64 `form:"lat" json:"lat"`
+	Lng float64 `form:"lng" json:"lng"`
+}
+
+// ParseGeoPoint creates a new GeoPoint instance from the provided value
+// (could be GeoPoint, *GeoPoint, map, json encoded string or []byte, etc.).
+func ParseGeoPoint(value any) (GeoPoint, error) {
+	result := GeoPoint{}
+	err := result.Scan(value)
+	return result, err
+}
+
+// IsZero checks whether the current GeoPoint instance has zero coordinates.
+func (p GeoPoint) IsZero() bool {
+	return p.Lat == 0 && p.Lng == 0
+}
+
+// String returns the current GeoPoint instance as a json encoded string.
+func (p GeoPoint) String() string {
+	raw, _ := json.Marshal(p)
+	return string(raw)
+}
+
+// DistanceTo returns the great-circle distance in meters between
+// the current and the provided point (using the haversine formula).
+func (p GeoPoint) DistanceTo(other GeoPoint) float64 {
+	lat1 := p.Lat * math.Pi / 180
+	lat2 := other.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (other.Lng - p.Lng) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Value implements the [driver.Valuer] interface.
+func (p GeoPoint) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current GeoPoint instance.
+func (p *GeoPoint) Scan(value any) error {
+	var data []byte
+
+	switch v := value.(type) {
+	case nil:
+		// no cast is needed
+	case GeoPoint:
+		*p = v
+		return nil
+	case *GeoPoint:
+		if v != nil {
+			*p = *v
+		}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	case JsonRaw:
+		data = v
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		data = raw
+	}
+
+	*p = GeoPoint{}
+
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, p); err != nil {
+		return fmt.Errorf("failed to unmarshal GeoPoint value: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestParseGeoPoint(t *testing.T) {
+	scenarios := []struct {
+		value       any
+		expectError bool
+		expectJson  string
+	}{
+		{nil, false, `{"lat":0,"lng":0}`},
+		{"", false, `{"lat":0,"lng":0}`},
+		{"null", false, `{"lat":0,"lng":0}`},
+		{"invalid", true, `{"lat":0,"lng":0}`},
+		{`{"lat":"invalid"}`, true, `{"lat":0,"lng":0}`},
+		{`{"lat":42.5,"lng":-23.1}`, false, `{"lat":42.5,"lng":-23.1}`},
+		{[]byte(`{"lat":1}`), false, `{"lat":1,"lng":0}`},
+		{types.JsonRaw(`{"lng":2}`), false, `{"lat":0,"lng":2}`},
+		{map[string]any{"lat": 1.5, "lng": 2.5}, false, `{"lat":1.5,"lng":2.5}`},
+		{types.GeoPoint{Lat: 3, Lng: 4}, false, `{"lat":3,"lng":4}`},
+		{&types.GeoPoint{Lat: 5, Lng: 6}, false, `{"lat":5,"lng":6}`},
+	}
+
+	for i, s := range scenarios {
+		p, err := types.ParseGeoPoint(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if p.String() != s.expectJson {
+			t.Errorf("(%d) Expected %s, got %s", i, s.expectJson, p.String())
+		}
+	}
+}
+
+func TestGeoPointIsZero(t *testing.T) {
+	if !(types.GeoPoint{}).IsZero() {
+		t.Fatal("Expected zero point")
+	}
+
+	if (types.GeoPoint{Lat: 1}).IsZero() {
+		t.Fatal("Expected non-zero point")
+	}
+}
+
+func TestGeoPointValue(t *testing.T) {
+	v, err := types.GeoPoint{Lat: 1, Lng: 2}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != `{"lat":1,"lng":2}` {
+		t.Fatalf("Expected %q, got %v", `{"lat":1,"lng":2}`, v)
+	}
+}
+
+func TestGeoPointDistanceTo(t *testing.T) {
+	sofia := types.GeoPoint{Lat: 42.6977, Lng: 23.3219}
+	plovdiv := types.GeoPoint{Lat: 42.1354, Lng: 24.7453}
+
+	if d := sofia.DistanceTo(sofia); d != 0 {
+		t.Fatalf("Expected 0 distance to the same point, got %v", d)
+	}
+
+	// ~132km
+	if d := sofia.DistanceTo(plovdiv); math.Abs(d-132000) > 2000 {
+		t.Fatalf("Expected ~132km distance, got %vm", d)
+	}
+
+	if d1, d2 := sofia.DistanceTo(plovdiv), plovdiv.DistanceTo(sofia); math.Abs(d1-d2) > 0.001 {
+		t.Fatalf("Expected symmetric distance, got %v and %v", d1, d2)
+	}
+}
@@ -0,0 +1,423 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrencyScale is the number of the minor unit digits
+// of the currencies that are not listed in [currencyScales].
+const DefaultCurrencyScale = 2
+
+// currencyScales lists the ISO 4217 currencies with minor unit digits
+// different from [DefaultCurrencyScale].
+var currencyScales = map[string]int{
+	"BHD": 3, "BIF": 0, "CLF": 4, "CLP": 0, "DJF": 0, "GNF": 0,
+	"IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0,
+	"KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "RWF": 0, "TND": 3,
+	"UGX": 0, "UYI": 0, "UYW": 4, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+}
+
+// CurrencyScale returns the number of the minor unit digits
+// of the specified ISO 4217 currency code (eg. 2 for "USD", 0 for "JPY").
+func CurrencyScale(currency string) int {
+	if scale, ok := currencyScales[strings.ToUpper(currency)]; ok {
+		return scale
+	}
+
+	return DefaultCurrencyScale
+}
+
+// Money defines a monetary amount value type that is safe for db read/write.
+//
+// The amount is stored as integer minor units (eg. cents) to avoid
+// the floating point rounding errors.
+type Money struct {
+	Amount   int64  `form:"amount" json:"amount"`
+	Currency string `form:"currency" json:"currency"`
+}
+
+// NewMoney creates a new Money instance from the provided
+// minor units amount and currency code.
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney creates a new Money instance from the provided value
+// in the specified currency (empty currency means the value currency).
+//
+// Integer numbers and numeric strings without a decimal point are
+// treated as minor units, strings with a decimal point (eg. "12.34") -
+// as major units. Money, *Money and map values with "amount" and
+// "currency" keys (or their json encoded representation) are also supported.
+//
+// Returns an error if the value has a different currency or
+// fractional minor units (eg. "0.001" USD).
+func ParseMoney(value any, currency string) (Money, error) {
+	result := Money{Currency: strings.ToUpper(currency)}
+
+	var other Money
+
+	switch v := value.(type) {
+	case nil:
+		return result, nil
+	case Money:
+		other = v
+	case *Money:
+		if v == nil {
+			return result, nil
+		}
+		other = *v
+	case map[string]any:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return result, err
+		}
+		return ParseMoney(raw, currency)
+	case []byte:
+		return parseMoneyJson(v, result.Currency)
+	case JsonRaw:
+		return parseMoneyJson(v, result.Currency)
+	case string:
+		str := strings.TrimSpace(v)
+		if strings.HasPrefix(str, "{") {
+			return parseMoneyJson([]byte(str), result.Currency)
+		}
+		amount, err := parseMoneyAmount(str, result.Currency)
+		if err != nil {
+			return result, err
+		}
+		result.Amount = amount
+		return result, nil
+	case json.Number:
+		return ParseMoney(v.String(), currency)
+	case float64:
+		amount, err := floatToMinorUnits(v)
+		if err != nil {
+			return result, err
+		}
+		result.Amount = amount
+		return result, nil
+	case float32:
+		return ParseMoney(float64(v), currency)
+	case int:
+		result.Amount = int64(v)
+		return result, nil
+	case int8:
+		result.Amount = int64(v)
+		return result, nil
+	case int16:
+		result.Amount = int64(v)
+		return result, nil
+	case int32:
+		result.Amount = int64(v)
+		return result, nil
+	case int64:
+		result.Amount = v
+		return result, nil
+	case uint8:
+		result.Amount = int64(v)
+		return result, nil
+	case uint16:
+		result.Amount = int64(v)
+		return result, nil
+	case uint32:
+		result.Amount = int64(v)
+		return result, nil
+	case uint:
+		return ParseMoney(uint64(v), currency)
+	case uint64:
+		if v > math.MaxInt64 {
+			return result, errors.New("the money amount is out of range")
+		}
+		result.Amount = int64(v)
+		return result, nil
+	default:
+		return result, fmt.Errorf("unsupported money value type %T", value)
+	}
+
+	if result.Currency != "" && other.Currency != "" && !strings.EqualFold(result.Currency, other.Currency) {
+		return result, fmt.Errorf("expected %s money value, got %s", result.Currency, other.Currency)
+	}
+
+	if result.Currency == "" {
+		result.Currency = strings.ToUpper(other.Currency)
+	}
+	result.Amount = other.Amount
+
+	return result, nil
+}
+
+func parseMoneyJson(data []byte, currency string) (Money, error) {
+	raw := struct {
+		Amount   json.Number `json:"amount"`
+		Currency string      `json:"currency"`
+	}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Money{Currency: currency}, fmt.Errorf("failed to unmarshal Money value: %w", err)
+	}
+
+	if raw.Currency == "" {
+		raw.Currency = currency
+	}
+
+	amount, err := ParseMoney(raw.Amount, raw.Currency)
+	if err != nil {
+		return Money{Currency: currency}, err
+	}
+
+	return ParseMoney(amount, currency)
+}
+
+// parseMoneyAmount parses a minor units integer string or
+// a major units decimal string into minor units.
+func parseMoneyAmount(str string, currency string) (int64, error) {
+	if str == "" {
+		return 0, nil
+	}
+
+	intPart, fracPart, isDecimal := strings.Cut(str, ".")
+	if !isDecimal {
+		return strconv.ParseInt(str, 10, 64)
+	}
+
+	scale := CurrencyScale(currency)
+
+	if len(fracPart) > scale {
+		// allow trailing zeros (eg. "1.500" for USD)
+		trimmed := strings.TrimRight(fracPart[scale:], "0")
+		if trimmed != "" {
+			return 0, fmt.Errorf("the amount %q has more than %d decimal places", str, scale)
+		}
+		fracPart = fracPart[:scale]
+	}
+
+	if strings.ContainsAny(fracPart, "+-") {
+		return 0, fmt.Errorf("invalid money amount %q", str)
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", scale-len(fracPart))
+	if digits == "" || digits == "-" || digits == "+" {
+		return 0, fmt.Errorf("invalid money amount %q", str)
+	}
+
+	return strconv.ParseInt(digits, 10, 64)
+}
+
+func floatToMinorUnits(v float64) (int64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v != math.Trunc(v) {
+		return 0, fmt.Errorf("the money amount %v is not a whole number of minor units", v)
+	}
+
+	if v >= math.MaxInt64 || v < math.MinInt64 {
+		return 0, errors.New("the money amount is out of range")
+	}
+
+	return int64(v), nil
+}
+
+// IsZero checks whether the current Money instance has zero amount.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Scale returns the number of the minor unit digits of the Money currency.
+func (m Money) Scale() int {
+	return CurrencyScale(m.Currency)
+}
+
+// Decimal returns the amount in major units as decimal string (eg. "12.34").
+func (m Money) Decimal() string {
+	return m.Format("", ".")
+}
+
+// Format returns the amount in major units as decimal string
+// using the provided thousands and decimal separators (eg. "1,234.56").
+func (m Money) Format(thousandsSeparator string, decimalSeparator string) string {
+	scale := m.Scale()
+
+	digits := strconv.FormatUint(absInt64(m.Amount), 10)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	intPart := digits[:len(digits)-scale]
+	fracPart := digits[len(digits)-scale:]
+
+	var sb strings.Builder
+
+	if m.Amount < 0 {
+		sb.WriteString("-")
+	}
+
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(thousandsSeparator)
+		}
+		sb.WriteRune(c)
+	}
+
+	if fracPart != "" {
+		sb.WriteString(decimalSeparator)
+		sb.WriteString(fracPart)
+	}
+
+	return sb.String()
+}
+
+// String returns the current Money instance as
+// decimal string with its currency code (eg. "12.34 USD").
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal()
+	}
+
+	return m.Decimal() + " " + m.Currency
+}
+
+// Add returns the sum of the current and the other Money instance.
+//
+// Returns an error on currency mismatch or overflow.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return m, err
+	}
+
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return m, errors.New("the money amount is out of range")
+	}
+
+	return Money{Amount: sum, Currency: m.mergeCurrency(other)}, nil
+}
+
+// Sub returns the difference of the current and the other Money instance.
+//
+// Returns an error on currency mismatch or overflow.
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return m, errors.New("the money amount is out of range")
+	}
+
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Mul returns the current Money amount multiplied by factor
+// (eg. a tax rate) rounded half away from zero to the nearest minor unit.
+func (m Money) Mul(factor float64) (Money, error) {
+	amount, err := floatToMinorUnits(math.Round(float64(m.Amount) * factor))
+	if err != nil {
+		return m, err
+	}
+
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Compare returns -1, 0 or 1 if the current Money amount is
+// respectively less than, equal to or greater than the other amount.
+//
+// Returns an error on currency mismatch.
+func (m Money) Compare(other Money) (int, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Allocate splits the current Money amount between the provided
+// ratios without losing minor units (the remainder is distributed
+// one minor unit at a time starting from the first ratio).
+//
+// For example allocating 100 with ratios [1, 1, 1] results in [34, 33, 33].
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, errors.New("the allocation ratios must be non-negative")
+		}
+		total += int64(r)
+	}
+
+	if total == 0 {
+		return nil, errors.New("the allocation ratios sum must be positive")
+	}
+
+	result := make([]Money, len(ratios))
+
+	remainder := m.Amount
+	for i, r := range ratios {
+		share := int64(math.Floor(float64(m.Amount) * float64(r) / float64(total)))
+		result[i] = Money{Amount: share, Currency: m.Currency}
+		remainder -= share
+	}
+
+	for i := 0; remainder > 0; i = (i + 1) % len(result) {
+		if ratios[i] == 0 {
+			continue
+		}
+		result[i].Amount++
+		remainder--
+	}
+
+	return result, nil
+}
+
+func (m Money) checkCurrency(other Money) error {
+	if m.Currency != "" && other.Currency != "" && !strings.EqualFold(m.Currency, other.Currency) {
+		return fmt.Errorf("currency mismatch (%s and %s)", m.Currency, other.Currency)
+	}
+
+	return nil
+}
+
+func (m Money) mergeCurrency(other Money) string {
+	if m.Currency != "" {
+		return m.Currency
+	}
+
+	return other.Currency
+}
+
+// Value implements the [driver.Valuer] interface.
+//
+// Only the minor units amount is stored (the currency is
+// expected to be defined by the field options).
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount, nil
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current Money instance (preserving its currency if set).
+func (m *Money) Scan(value any) error {
+	result, err := ParseMoney(value, m.Currency)
+	if err != nil {
+		return err
+	}
+
+	*m = result
+
+	return nil
+}
+
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+
+	return uint64(v)
+}
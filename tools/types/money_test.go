@@ -0,0 +1,228 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestCurrencyScale(t *testing.T) {
+	scenarios := map[string]int{
+		"":    2,
+		"USD": 2,
+		"eur": 2,
+		"JPY": 0,
+		"KWD": 3,
+		"XYZ": 2,
+	}
+
+	for currency, expected := range scenarios {
+		if result := types.CurrencyScale(currency); result != expected {
+			t.Errorf("[%s] Expected %d, got %d", currency, expected, result)
+		}
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	scenarios := []struct {
+		value       any
+		currency    string
+		expectError bool
+		expected    string
+	}{
+		{nil, "USD", false, "0.00 USD"},
+		{"", "USD", false, "0.00 USD"},
+		{1234, "usd", false, "12.34 USD"},
+		{int64(-5), "USD", false, "-0.05 USD"},
+		{float64(1234), "USD", false, "12.34 USD"},
+		{12.5, "USD", true, "0.00 USD"},
+		{"1234", "USD", false, "12.34 USD"},
+		{"12.34", "USD", false, "12.34 USD"},
+		{"12.3", "USD", false, "12.30 USD"},
+		{"12.300", "USD", false, "12.30 USD"},
+		{"-.5", "USD", false, "-0.50 USD"},
+		{"12.345", "USD", true, "0.00 USD"},
+		{"1.5", "JPY", true, "0 JPY"},
+		{"1.5", "KWD", false, "1.500 KWD"},
+		{"12.-3", "USD", true, "0.00 USD"},
+		{"abc", "USD", true, "0.00 USD"},
+		{types.NewMoney(100, "eur"), "", false, "1.00 EUR"},
+		{&types.Money{Amount: 100, Currency: "EUR"}, "EUR", false, "1.00 EUR"},
+		{types.NewMoney(100, "EUR"), "USD", true, "0.00 USD"},
+		{map[string]any{"amount": 250, "currency": "USD"}, "USD", false, "2.50 USD"},
+		{map[string]any{"amount": "2.5"}, "USD", false, "2.50 USD"},
+		{`{"amount":100,"currency":"BGN"}`, "", false, "1.00 BGN"},
+		{[]byte(`{"amount":100,"currency":"BGN"}`), "USD", true, "0.00 USD"},
+		{types.JsonRaw(`{"amount":100}`), "USD", false, "1.00 USD"},
+		{true, "USD", true, "0.00 USD"},
+	}
+
+	for i, s := range scenarios {
+		m, err := types.ParseMoney(s.value, s.currency)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if m.String() != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, m.String())
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	scenarios := []struct {
+		money    types.Money
+		expected string
+	}{
+		{types.NewMoney(0, "USD"), "0,00"},
+		{types.NewMoney(5, "USD"), "0,05"},
+		{types.NewMoney(123456789, "USD"), "1 234 567,89"},
+		{types.NewMoney(-123456, "USD"), "-1 234,56"},
+		{types.NewMoney(1234567, "JPY"), "1 234 567"},
+		{types.NewMoney(1, "KWD"), "0,001"},
+	}
+
+	for i, s := range scenarios {
+		if result := s.money.Format(" ", ","); result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestMoneyAddSub(t *testing.T) {
+	a := types.NewMoney(1000, "USD")
+
+	sum, err := a.Add(types.NewMoney(250, "USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.String() != "12.50 USD" {
+		t.Fatalf("Expected sum 12.50 USD, got %s", sum)
+	}
+
+	diff, err := a.Sub(types.NewMoney(1250, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.String() != "-2.50 USD" {
+		t.Fatalf("Expected diff -2.50 USD, got %s", diff)
+	}
+
+	if _, err := a.Add(types.NewMoney(1, "EUR")); err == nil {
+		t.Fatal("Expected currency mismatch error")
+	}
+
+	if _, err := types.NewMoney(1<<62, "USD").Add(types.NewMoney(1<<62, "USD")); err == nil {
+		t.Fatal("Expected overflow error")
+	}
+}
+
+func TestMoneyCompare(t *testing.T) {
+	scenarios := []struct {
+		a           types.Money
+		b           types.Money
+		expectError bool
+		expected    int
+	}{
+		{types.NewMoney(1, "USD"), types.NewMoney(2, "USD"), false, -1},
+		{types.NewMoney(2, "USD"), types.NewMoney(2, ""), false, 0},
+		{types.NewMoney(3, "USD"), types.NewMoney(2, "USD"), false, 1},
+		{types.NewMoney(1, "USD"), types.NewMoney(1, "EUR"), true, 0},
+	}
+
+	for i, s := range scenarios {
+		result, err := s.a.Compare(s.b)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+
+		if result != s.expected {
+			t.Fatalf("(%d) Expected %d, got %d", i, s.expected, result)
+		}
+	}
+}
+
+func TestMoneyMul(t *testing.T) {
+	scenarios := []struct {
+		amount   int64
+		factor   float64
+		expected int64
+	}{
+		{1999, 0.15, 300},
+		{1000, 1.5, 1500},
+		{-5, 0.5, -3},
+		{100, 0, 0},
+	}
+
+	for i, s := range scenarios {
+		result, err := types.NewMoney(s.amount, "USD").Mul(s.factor)
+		if err != nil {
+			t.Fatalf("(%d) %v", i, err)
+		}
+
+		if result.Amount != s.expected || result.Currency != "USD" {
+			t.Fatalf("(%d) Expected %d USD, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	scenarios := []struct {
+		amount      int64
+		ratios      []int
+		expectError bool
+		expected    []int64
+	}{
+		{100, nil, true, nil},
+		{100, []int{0, 0}, true, nil},
+		{100, []int{1, -1}, true, nil},
+		{100, []int{1, 1, 1}, false, []int64{34, 33, 33}},
+		{5, []int{70, 30}, false, []int64{4, 1}},
+		{5, []int{0, 1, 1}, false, []int64{0, 3, 2}},
+		{-100, []int{1, 1, 1}, false, []int64{-33, -33, -34}},
+	}
+
+	for i, s := range scenarios {
+		result, err := types.NewMoney(s.amount, "USD").Allocate(s.ratios...)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+
+		if len(result) != len(s.expected) {
+			t.Fatalf("(%d) Expected %d shares, got %d", i, len(s.expected), len(result))
+		}
+
+		for j, share := range result {
+			if share.Amount != s.expected[j] {
+				t.Fatalf("(%d) Expected shares %v, got %v", i, s.expected, result)
+			}
+		}
+	}
+}
+
+func TestMoneyValueAndScan(t *testing.T) {
+	v, err := types.NewMoney(1234, "USD").Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != int64(1234) {
+		t.Fatalf("Expected 1234, got %v", v)
+	}
+
+	m := types.Money{Currency: "EUR"}
+	if err := m.Scan(int64(99)); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.String() != "0.99 EUR" {
+		t.Fatalf("Expected 0.99 EUR, got %s", m)
+	}
+}
@@ -0,0 +1,404 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// phoneRegion describes the numbering plan of a single region
+// (a compact subset of the libphonenumber metadata).
+type phoneRegion struct {
+	// code is the ISO 3166-1 alpha-2 region code (eg. "US").
+	code string
+
+	// callingCode is the international country calling code (eg. "1").
+	callingCode string
+
+	// trunkPrefix is the national dialing prefix (eg. "0" for "FR")
+	// that is stripped from the nationally formatted numbers.
+	trunkPrefix string
+
+	// minLength and maxLength are the allowed national
+	// significant number lengths (aka. without the trunk prefix).
+	minLength int
+	maxLength int
+
+	// pattern is an optional national significant number
+	// display pattern with "X" digit placeholders.
+	pattern string
+}
+
+// phoneRegions lists the supported numbering plans.
+//
+// The first region of a shared calling code (eg. "US" for "+1")
+// is used as main region when parsing international numbers.
+var phoneRegions = []phoneRegion{
+	{"US", "1", "", 10, 10, "(XXX) XXX-XXXX"},
+	{"CA", "1", "", 10, 10, "(XXX) XXX-XXXX"},
+	{"RU", "7", "8", 10, 10, "XXX XXX-XX-XX"},
+	{"KZ", "7", "8", 10, 10, "XXX XXX XXXX"},
+	{"EG", "20", "0", 8, 10, ""},
+	{"ZA", "27", "0", 9, 9, "XX XXX XXXX"},
+	{"GR", "30", "", 10, 10, "XXX XXX XXXX"},
+	{"NL", "31", "0", 9, 9, "X XXXXXXXX"},
+	{"BE", "32", "0", 8, 9, ""},
+	{"FR", "33", "0", 9, 9, "X XX XX XX XX"},
+	{"ES", "34", "", 9, 9, "XXX XX XX XX"},
+	{"HU", "36", "06", 8, 9, ""},
+	{"IT", "39", "", 6, 12, ""},
+	{"RO", "40", "0", 9, 9, "XXX XXX XXX"},
+	{"CH", "41", "0", 9, 9, "XX XXX XX XX"},
+	{"AT", "43", "0", 4, 13, ""},
+	{"GB", "44", "0", 9, 10, ""},
+	{"DK", "45", "", 8, 8, "XX XX XX XX"},
+	{"SE", "46", "0", 6, 12, ""},
+	{"NO", "47", "", 8, 8, "XX XX XX XX"},
+	{"PL", "48", "", 9, 9, "XXX XXX XXX"},
+	{"DE", "49", "0", 5, 15, ""},
+	{"PE", "51", "0", 8, 9, ""},
+	{"MX", "52", "", 10, 10, "XXX XXX XXXX"},
+	{"AR", "54", "0", 10, 11, ""},
+	{"BR", "55", "0", 10, 11, ""},
+	{"CL", "56", "", 9, 9, "X XXXX XXXX"},
+	{"CO", "57", "", 10, 10, "XXX XXXXXXX"},
+	{"MY", "60", "0", 8, 10, ""},
+	{"AU", "61", "0", 9, 9, "XXX XXX XXX"},
+	{"ID", "62", "0", 8, 12, ""},
+	{"PH", "63", "0", 8, 10, ""},
+	{"NZ", "64", "0", 8, 10, ""},
+	{"SG", "65", "", 8, 8, "XXXX XXXX"},
+	{"TH", "66", "0", 8, 9, ""},
+	{"JP", "81", "0", 9, 10, ""},
+	{"KR", "82", "0", 8, 11, ""},
+	{"VN", "84", "0", 9, 10, ""},
+	{"CN", "86", "0", 7, 11, ""},
+	{"TR", "90", "0", 10, 10, "XXX XXX XX XX"},
+	{"IN", "91", "0", 10, 10, "XXXXX XXXXX"},
+	{"PK", "92", "0", 9, 10, ""},
+	{"MA", "212", "0", 9, 9, "XXX-XXXXXX"},
+	{"DZ", "213", "0", 8, 9, ""},
+	{"TN", "216", "", 8, 8, "XX XXX XXX"},
+	{"SN", "221", "", 9, 9, "XX XXX XX XX"},
+	{"CI", "225", "", 10, 10, "XX XX XX XXXX"},
+	{"CM", "237", "", 9, 9, "X XX XX XX XX"},
+	{"NG", "234", "0", 8, 10, ""},
+	{"KE", "254", "0", 9, 9, "XXX XXXXXX"},
+	{"PT", "351", "", 9, 9, "XXX XXX XXX"},
+	{"IE", "353", "0", 7, 10, ""},
+	{"FI", "358", "0", 5, 12, ""},
+	{"UA", "380", "0", 9, 9, "XX XXX XXXX"},
+	{"CZ", "420", "", 9, 9, "XXX XXX XXX"},
+	{"HK", "852", "", 8, 8, "XXXX XXXX"},
+	{"BD", "880", "0", 8, 10, ""},
+	{"TW", "886", "0", 8, 9, ""},
+	{"SA", "966", "0", 9, 9, ""},
+	{"AE", "971", "0", 8, 9, ""},
+	{"IL", "972", "0", 8, 9, ""},
+}
+
+var (
+	phoneRegionsByCode        = map[string]*phoneRegion{}
+	phoneRegionsByCallingCode = map[string][]*phoneRegion{}
+)
+
+func init() {
+	for i := range phoneRegions {
+		r := &phoneRegions[i]
+		phoneRegionsByCode[r.code] = r
+		phoneRegionsByCallingCode[r.callingCode] = append(phoneRegionsByCallingCode[r.callingCode], r)
+	}
+}
+
+// E.164 numbers limits (excluding the leading "+").
+const (
+	phoneMinLength = 7
+	phoneMaxLength = 15
+)
+
+// PhoneRegions returns the sorted ISO 3166-1 alpha-2 codes
+// of the regions with known numbering plan.
+func PhoneRegions() []string {
+	result := make([]string, 0, len(phoneRegions))
+
+	for _, r := range phoneRegions {
+		result = append(result, r.code)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// PhoneNumber defines a phone number value type that is safe for db read/write.
+//
+// The number is stored in its E.164 format (eg. "+12025550143").
+type PhoneNumber struct {
+	// Region is the ISO 3166-1 alpha-2 code of the number region
+	// (could be empty for numbers with unknown numbering plan).
+	Region string
+
+	// CallingCode is the country calling code (eg. "1").
+	CallingCode string
+
+	// Number is the national significant number (eg. "2025550143").
+	Number string
+}
+
+// ParsePhoneNumber parses and normalizes the provided phone number string.
+//
+// The common visual separators (spaces, dashes, dots, slashes and brackets) are ignored.
+//
+// Numbers starting with "+" or "00" are treated as international.
+// All other numbers are treated as national numbers of the specified
+// defaultRegion (ISO 3166-1 alpha-2 code, eg. "FR") and error is
+// returned if it is empty or unknown.
+//
+// Empty value returns zero PhoneNumber and no error.
+func ParsePhoneNumber(value string, defaultRegion string) (PhoneNumber, error) {
+	result := PhoneNumber{}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return result, nil
+	}
+
+	international := strings.HasPrefix(value, "+")
+	if international {
+		value = value[1:]
+	}
+
+	var digits strings.Builder
+	for _, c := range value {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == ' ' || c == '-' || c == '.' || c == '/' || c == '(' || c == ')' || c == '\u00a0':
+			// visual separator
+		default:
+			return result, errors.New("the phone number contains invalid characters")
+		}
+	}
+
+	number := digits.String()
+
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	defaultRegion = strings.ToUpper(defaultRegion)
+
+	if international {
+		return parseInternationalPhoneNumber(number, defaultRegion)
+	}
+
+	region := phoneRegionsByCode[defaultRegion]
+	if region == nil {
+		return result, errors.New("the phone number must be in international format (eg. +12025550143)")
+	}
+
+	// national number with the country calling code but without "+" (eg. "33612345678")
+	if len(number) > region.maxLength && strings.HasPrefix(number, region.callingCode) {
+		return parsePhoneNumberInRegion(number[len(region.callingCode):], region)
+	}
+
+	return parsePhoneNumberInRegion(number, region)
+}
+
+func parseInternationalPhoneNumber(number string, defaultRegion string) (PhoneNumber, error) {
+	if len(number) < phoneMinLength || len(number) > phoneMaxLength {
+		return PhoneNumber{}, errors.New("invalid phone number length")
+	}
+
+	// the calling codes are prefix free so there is only one possible match
+	for i := 1; i <= 3 && i < len(number); i++ {
+		regions := phoneRegionsByCallingCode[number[:i]]
+		if len(regions) == 0 {
+			continue
+		}
+
+		region := regions[0]
+		for _, r := range regions {
+			if r.code == defaultRegion {
+				region = r
+				break
+			}
+		}
+
+		return parsePhoneNumberInRegion(number[i:], region)
+	}
+
+	// unknown numbering plan
+	return PhoneNumber{Number: number}, nil
+}
+
+func parsePhoneNumberInRegion(number string, region *phoneRegion) (PhoneNumber, error) {
+	// strip the trunk prefix (eg. "+33 (0)6 12..." or "06 12...")
+	if region.trunkPrefix != "" &&
+		len(number) > region.minLength &&
+		strings.HasPrefix(number, region.trunkPrefix) &&
+		len(number)-len(region.trunkPrefix) >= region.minLength {
+		number = number[len(region.trunkPrefix):]
+	}
+
+	if len(number) < region.minLength || len(number) > region.maxLength {
+		return PhoneNumber{}, errors.New("invalid phone number length for region " + region.code)
+	}
+
+	if len(region.callingCode)+len(number) > phoneMaxLength {
+		return PhoneNumber{}, errors.New("invalid phone number length")
+	}
+
+	return PhoneNumber{
+		Region:      region.code,
+		CallingCode: region.callingCode,
+		Number:      number,
+	}, nil
+}
+
+// IsZero checks whether the current PhoneNumber instance is empty.
+func (p PhoneNumber) IsZero() bool {
+	return p.Number == ""
+}
+
+// E164 returns the phone number in its E.164 format (eg. "+12025550143").
+func (p PhoneNumber) E164() string {
+	if p.IsZero() {
+		return ""
+	}
+
+	return "+" + p.CallingCode + p.Number
+}
+
+// International returns the phone number in
+// international display format (eg. "+1 202 555 0143").
+func (p PhoneNumber) International() string {
+	if p.IsZero() {
+		return ""
+	}
+
+	if p.CallingCode == "" {
+		return p.E164() // unknown numbering plan
+	}
+
+	return "+" + p.CallingCode + " " + strings.Join(p.groups(), " ")
+}
+
+// National returns the phone number in national
+// display format (eg. "(202) 555-0143" or "06 12 34 56 78").
+func (p PhoneNumber) National() string {
+	if p.IsZero() {
+		return ""
+	}
+
+	region := phoneRegionsByCode[p.Region]
+	if region == nil {
+		return p.E164() // unknown numbering plan
+	}
+
+	if region.pattern == "" || strings.Count(region.pattern, "X") != len(p.Number) {
+		return region.trunkPrefix + strings.Join(p.groups(), " ")
+	}
+
+	var result strings.Builder
+	result.WriteString(region.trunkPrefix)
+
+	i := 0
+	for _, c := range region.pattern {
+		if c == 'X' {
+			result.WriteByte(p.Number[i])
+			i++
+		} else {
+			result.WriteRune(c)
+		}
+	}
+
+	return result.String()
+}
+
+// groups splits the national significant number into display groups
+// based on the region pattern (if any) or in groups of 2-4 digits.
+func (p PhoneNumber) groups() []string {
+	var sizes []int
+
+	if region := phoneRegionsByCode[p.Region]; region != nil &&
+		region.pattern != "" && strings.Count(region.pattern, "X") == len(p.Number) {
+		for _, part := range strings.FieldsFunc(region.pattern, func(c rune) bool { return c != 'X' }) {
+			sizes = append(sizes, len(part))
+		}
+	} else {
+		for rest := len(p.Number); rest > 0; {
+			size := 3
+			if rest <= 4 {
+				size = rest
+			} else if rest == 5 {
+				size = 2
+			}
+			sizes = append(sizes, size)
+			rest -= size
+		}
+	}
+
+	result := make([]string, 0, len(sizes))
+
+	offset := 0
+	for _, size := range sizes {
+		result = append(result, p.Number[offset:offset+size])
+		offset += size
+	}
+
+	return result
+}
+
+// String returns the E.164 phone number representation.
+func (p PhoneNumber) String() string {
+	return p.E164()
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (p PhoneNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.E164())
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (p *PhoneNumber) UnmarshalJSON(b []byte) error {
+	var raw string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	return p.Scan(raw)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (p PhoneNumber) Value() (driver.Value, error) {
+	return p.E164(), nil
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current PhoneNumber instance (preserving its region as default region).
+func (p *PhoneNumber) Scan(value any) error {
+	var raw string
+
+	switch v := value.(type) {
+	case nil:
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return errors.New("unsupported phone number value type")
+	}
+
+	result, err := ParsePhoneNumber(raw, p.Region)
+	if err != nil {
+		return err
+	}
+
+	*p = result
+
+	return nil
+}
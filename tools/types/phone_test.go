@@ -0,0 +1,168 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestPhoneRegions(t *testing.T) {
+	regions := types.PhoneRegions()
+
+	for _, code := range []string{"US", "CA", "FR", "GB", "DE", "IN"} {
+		found := false
+		for _, r := range regions {
+			if r == code {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected region %q to be supported", code)
+		}
+	}
+
+	for i := 1; i < len(regions); i++ {
+		if regions[i-1] >= regions[i] {
+			t.Fatalf("Expected sorted unique regions, got %v", regions)
+		}
+	}
+}
+
+func TestParsePhoneNumber(t *testing.T) {
+	scenarios := []struct {
+		value          string
+		defaultRegion  string
+		expectError    bool
+		expectedRegion string
+		expectedE164   string
+	}{
+		{"", "", false, "", ""},
+		{"  ", "US", false, "", ""},
+		{"+1 (202) 555-0143", "", false, "US", "+12025550143"},
+		{"+1 202 555 0143", "ca", false, "CA", "+12025550143"},
+		{"001.202.555.0143", "", false, "US", "+12025550143"},
+		{"(202) 555-0143", "US", false, "US", "+12025550143"},
+		{"1 202 555 0143", "US", false, "US", "+12025550143"},
+		{"202 555 014", "US", true, "", ""},
+		{"202 555 0143", "", true, "", ""},
+		{"202 555 0143", "XX", true, "", ""},
+		{"06 12 34 56 78", "FR", false, "FR", "+33612345678"},
+		{"+33 (0)6 12 34 56 78", "", false, "FR", "+33612345678"},
+		{"33612345678", "FR", false, "FR", "+33612345678"},
+		{"+33 6 12 34 56 7", "", true, "", ""},
+		{"+39 06 1234 5678", "", false, "IT", "+390612345678"},
+		{"8 (912) 345-67-89", "RU", false, "RU", "+79123456789"},
+		{"+7 800 123 45 67", "", false, "RU", "+78001234567"},
+		{"+44 7400 123456", "", false, "GB", "+447400123456"},
+		{"+999 1234 5678", "", false, "", "+99912345678"},
+		{"+123", "", true, "", ""},
+		{"+1234567890123456", "", true, "", ""},
+		{"+1 202 555 0143 ext. 1", "", true, "", ""},
+		{"+1 202 555 O143", "", true, "", ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value+"_"+s.defaultRegion, func(t *testing.T) {
+			result, err := types.ParsePhoneNumber(s.value, s.defaultRegion)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if result.Region != s.expectedRegion {
+				t.Fatalf("Expected region %q, got %q", s.expectedRegion, result.Region)
+			}
+
+			if result.E164() != s.expectedE164 {
+				t.Fatalf("Expected E.164 %q, got %q", s.expectedE164, result.E164())
+			}
+		})
+	}
+}
+
+func TestPhoneNumberFormats(t *testing.T) {
+	scenarios := []struct {
+		value                 string
+		expectedInternational string
+		expectedNational      string
+	}{
+		{"", "", ""},
+		{"+12025550143", "+1 202 555 0143", "(202) 555-0143"},
+		{"+33612345678", "+33 6 12 34 56 78", "06 12 34 56 78"},
+		{"+447400123456", "+44 740 012 3456", "0740 012 3456"},
+		{"+4930123456", "+49 301 23 456", "0301 23 456"},
+		{"+99912345678", "+99912345678", "+99912345678"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			p, err := types.ParsePhoneNumber(s.value, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v := p.International(); v != s.expectedInternational {
+				t.Fatalf("Expected international %q, got %q", s.expectedInternational, v)
+			}
+
+			if v := p.National(); v != s.expectedNational {
+				t.Fatalf("Expected national %q, got %q", s.expectedNational, v)
+			}
+
+			if v := p.String(); v != s.value {
+				t.Fatalf("Expected string %q, got %q", s.value, v)
+			}
+		})
+	}
+}
+
+func TestPhoneNumberJsonAndDb(t *testing.T) {
+	p, err := types.ParsePhoneNumber("+1 202 555 0143", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != `"+12025550143"` {
+		t.Fatalf("Expected json %q, got %q", `"+12025550143"`, raw)
+	}
+
+	unmarshaled := types.PhoneNumber{}
+	if err := json.Unmarshal(raw, &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if unmarshaled != p {
+		t.Fatalf("Expected %#v, got %#v", p, unmarshaled)
+	}
+
+	value, err := p.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "+12025550143" {
+		t.Fatalf("Expected db value %q, got %v", "+12025550143", value)
+	}
+
+	// scan preserving the region as default region
+	scanned := types.PhoneNumber{Region: "FR"}
+	if err := scanned.Scan([]byte("06 12 34 56 78")); err != nil {
+		t.Fatal(err)
+	}
+	if scanned.E164() != "+33612345678" {
+		t.Fatalf("Expected scanned %q, got %q", "+33612345678", scanned.E164())
+	}
+
+	if err := scanned.Scan(123); err == nil {
+		t.Fatal("Expected scan error for unsupported value type")
+	}
+
+	if err := scanned.Scan(nil); err != nil || !scanned.IsZero() {
+		t.Fatalf("Expected zero phone number, got %#v (%v)", scanned, err)
+	}
+}
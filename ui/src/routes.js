@@ -8,6 +8,7 @@ import PageAdmins            from "@/components/admins/PageAdmins.svelte";
 import PageAdminLogin        from "@/components/admins/PageAdminLogin.svelte";
 import PageApplication       from "@/components/settings/PageApplication.svelte";
 import PageMail              from "@/components/settings/PageMail.svelte";
+import PageSms               from "@/components/settings/PageSms.svelte";
 import PageStorage           from "@/components/settings/PageStorage.svelte";
 import PageAuthProviders     from "@/components/settings/PageAuthProviders.svelte";
 import PageTokenOptions      from "@/components/settings/PageTokenOptions.svelte";
@@ -76,6 +77,12 @@ const routes = {
         userData: { showAppSidebar: true },
     }),
 
+    "/settings/sms": wrap({
+        component:  PageSms,
+        conditions: baseConditions.concat([(_) => ApiClient.authStore.isValid]),
+        userData: { showAppSidebar: true },
+    }),
+
     "/settings/storage": wrap({
         component:  PageStorage,
         conditions: baseConditions.concat([(_) => ApiClient.authStore.isValid]),